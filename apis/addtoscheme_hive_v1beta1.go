@@ -0,0 +1,10 @@
+package apis
+
+import (
+	hivev1beta1 "github.com/openshift/hive/apis/hive/v1beta1"
+)
+
+func init() {
+	// Register the types with the Scheme so the components can map objects to GroupVersionKinds and back
+	AddToSchemes = append(AddToSchemes, hivev1beta1.SchemeBuilder.AddToScheme)
+}
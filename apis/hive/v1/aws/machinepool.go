@@ -6,6 +6,13 @@ type MachinePoolPlatform struct {
 	// Zones is list of availability zones that can be used.
 	Zones []string `json:"zones,omitempty"`
 
+	// ZoneSelection controls which availability zones of the region are used when no zones are specified. With All,
+	// the default, every zone of the region is used. With InstanceTypeAvailable, only the zones in which the instance
+	// type is offered are used, and the other zones are reported in the skippedZones of the machine pool status.
+	// +kubebuilder:validation:Enum=All;InstanceTypeAvailable
+	// +optional
+	ZoneSelection ZoneSelection `json:"zoneSelection,omitempty"`
+
 	// Subnets is the list of subnets to which to attach the machines.
 	// There must be exactly one private subnet for each availability zone used.
 	// If public subnets are specified, there must be exactly one private and one public subnet specified for each availability zone.
@@ -15,14 +22,42 @@ type MachinePoolPlatform struct {
 	// eg. m4-large
 	InstanceType string `json:"type"`
 
+	// AMIID is the ID of the AMI to create the machines from. When not set, the RHCOS AMI for the region published by
+	// the release of the cluster is used, falling back to the AMI of the master machines of clusters whose release
+	// does not publish its boot images.
+	// +optional
+	AMIID string `json:"amiID,omitempty"`
+
 	// EC2RootVolume defines the storage for ec2 instance.
 	EC2RootVolume `json:"rootVolume"`
 
 	// SpotMarketOptions allows users to configure instances to be run using AWS Spot instances.
 	// +optional
 	SpotMarketOptions *SpotMarketOptions `json:"spotMarketOptions,omitempty"`
+
+	// AdditionalSecurityGroupIDs is the list of IDs of security groups to attach to the machines, in addition to
+	// the worker security group of the cluster.
+	// +optional
+	AdditionalSecurityGroupIDs []string `json:"additionalSecurityGroupIDs,omitempty"`
+
+	// AssociatePublicIP specifies whether the machines are assigned a public IP address. Machines are only
+	// reachable through their public IP address when they are in public subnets.
+	// +optional
+	AssociatePublicIP *bool `json:"associatePublicIP,omitempty"`
 }
 
+// ZoneSelection is how the availability zones of a machine pool are selected when none are specified.
+type ZoneSelection string
+
+const (
+	// AllZoneSelection selects every availability zone of the region.
+	AllZoneSelection ZoneSelection = "All"
+
+	// InstanceTypeAvailableZoneSelection selects the availability zones of the region in which the instance type is
+	// offered.
+	InstanceTypeAvailableZoneSelection ZoneSelection = "InstanceTypeAvailable"
+)
+
 // SpotMarketOptions defines the options available to a user when configuring
 // Machines to run on Spot instances.
 // Most users should provide an empty struct.
@@ -0,0 +1,11 @@
+package aws
+
+// Metadata contains AWS metadata (e.g. for uninstalling the cluster).
+type Metadata struct {
+	// Region is the AWS region in which the cluster was installed.
+	Region string `json:"region"`
+
+	// ClusterDomain is the domain of the cluster.
+	// +optional
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+}
@@ -29,11 +29,80 @@ type Platform struct {
 	// Endpoint accross AWS accounts and allows clients to connect to services using AWS's
 	// internal networking instead of the Internet.
 	PrivateLink *PrivateLinkAccess `json:"privateLink,omitempty"`
+
+	// Egress configures existing NAT gateways through which the cluster reaches the internet.
+	// +optional
+	Egress *Egress `json:"egress,omitempty"`
+}
+
+// Egress configures existing NAT gateways for the egress traffic of a cluster.
+type Egress struct {
+	// NATGatewayIDs are the IDs of existing NAT gateways through which the private subnets of the cluster route egress
+	// traffic. The install config must install the cluster into existing subnets, and each private subnet must have a
+	// default route through one of the NAT gateways.
+	// +kubebuilder:validation:MinItems=1
+	NATGatewayIDs []string `json:"natGatewayIDs"`
 }
 
 // PlatformStatus contains the observed state on AWS platform.
 type PlatformStatus struct {
 	PrivateLink *PrivateLinkAccessStatus `json:"privateLink,omitempty"`
+
+	// VPCID is the ID of the VPC in which the cluster was installed.
+	// +optional
+	VPCID string `json:"vpcID,omitempty"`
+
+	// SubnetIDs are the IDs of the subnets used by the cluster.
+	// +optional
+	SubnetIDs []string `json:"subnetIDs,omitempty"`
+
+	// PrivateHostedZoneID is the ID of the private Route53 hosted zone for the cluster domain.
+	// +optional
+	PrivateHostedZoneID string `json:"privateHostedZoneID,omitempty"`
+
+	// UserProvidedNetwork describes the existing VPC the cluster was installed into, as discovered when it was
+	// validated before the install. It is not set for clusters whose VPC was created by the installer.
+	// +optional
+	UserProvidedNetwork *UserProvidedNetworkStatus `json:"userProvidedNetwork,omitempty"`
+
+	// NATGateways are the NAT gateways through which the private subnets of the cluster route egress traffic, whether
+	// created by the installer or brought by the user.
+	// +optional
+	NATGateways []NATGatewayStatus `json:"natGateways,omitempty"`
+}
+
+// NATGatewayStatus describes a NAT gateway used by a cluster.
+type NATGatewayStatus struct {
+	// ID is the ID of the NAT gateway.
+	ID string `json:"id"`
+
+	// PublicIPs are the elastic IPs of the NAT gateway, from which the egress traffic of the cluster originates.
+	// +optional
+	PublicIPs []string `json:"publicIPs,omitempty"`
+}
+
+// UserProvidedNetworkStatus describes an existing VPC into which a cluster is installed.
+type UserProvidedNetworkStatus struct {
+	// VPCID is the ID of the VPC of the subnets.
+	VPCID string `json:"vpcID"`
+
+	// PrivateSubnets are the subnets routing egress traffic without an internet gateway. Machine pools which do not
+	// specify subnets use the private subnet of each of their availability zones.
+	// +optional
+	PrivateSubnets []SubnetStatus `json:"privateSubnets,omitempty"`
+
+	// PublicSubnets are the subnets routing traffic through an internet gateway.
+	// +optional
+	PublicSubnets []SubnetStatus `json:"publicSubnets,omitempty"`
+}
+
+// SubnetStatus describes a subnet of a user-provided VPC.
+type SubnetStatus struct {
+	// ID is the ID of the subnet.
+	ID string `json:"id"`
+
+	// AvailabilityZone is the availability zone of the subnet.
+	AvailabilityZone string `json:"availabilityZone"`
 }
 
 // PrivateLinkAccess configures access to the cluster API using AWS PrivateLink
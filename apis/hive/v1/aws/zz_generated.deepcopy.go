@@ -36,6 +36,27 @@ func (in *EC2RootVolume) DeepCopy() *EC2RootVolume {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Egress) DeepCopyInto(out *Egress) {
+	*out = *in
+	if in.NATGatewayIDs != nil {
+		in, out := &in.NATGatewayIDs, &out.NATGatewayIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Egress.
+func (in *Egress) DeepCopy() *Egress {
+	if in == nil {
+		return nil
+	}
+	out := new(Egress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolPlatform) DeepCopyInto(out *MachinePoolPlatform) {
 	*out = *in
@@ -55,6 +76,16 @@ func (in *MachinePoolPlatform) DeepCopyInto(out *MachinePoolPlatform) {
 		*out = new(SpotMarketOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalSecurityGroupIDs != nil {
+		in, out := &in.AdditionalSecurityGroupIDs, &out.AdditionalSecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AssociatePublicIP != nil {
+		in, out := &in.AssociatePublicIP, &out.AssociatePublicIP
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -68,6 +99,43 @@ func (in *MachinePoolPlatform) DeepCopy() *MachinePoolPlatform {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Metadata) DeepCopyInto(out *Metadata) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metadata.
+func (in *Metadata) DeepCopy() *Metadata {
+	if in == nil {
+		return nil
+	}
+	out := new(Metadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGatewayStatus) DeepCopyInto(out *NATGatewayStatus) {
+	*out = *in
+	if in.PublicIPs != nil {
+		in, out := &in.PublicIPs, &out.PublicIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGatewayStatus.
+func (in *NATGatewayStatus) DeepCopy() *NATGatewayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGatewayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Platform) DeepCopyInto(out *Platform) {
 	*out = *in
@@ -89,6 +157,11 @@ func (in *Platform) DeepCopyInto(out *Platform) {
 		*out = new(PrivateLinkAccess)
 		**out = **in
 	}
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = new(Egress)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -110,6 +183,23 @@ func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
 		*out = new(PrivateLinkAccessStatus)
 		**out = **in
 	}
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserProvidedNetwork != nil {
+		in, out := &in.UserProvidedNetwork, &out.UserProvidedNetwork
+		*out = new(UserProvidedNetworkStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NATGateways != nil {
+		in, out := &in.NATGateways, &out.NATGateways
+		*out = make([]NATGatewayStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -177,6 +267,48 @@ func (in *SpotMarketOptions) DeepCopy() *SpotMarketOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetStatus.
+func (in *SubnetStatus) DeepCopy() *SubnetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserProvidedNetworkStatus) DeepCopyInto(out *UserProvidedNetworkStatus) {
+	*out = *in
+	if in.PrivateSubnets != nil {
+		in, out := &in.PrivateSubnets, &out.PrivateSubnets
+		*out = make([]SubnetStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.PublicSubnets != nil {
+		in, out := &in.PublicSubnets, &out.PublicSubnets
+		*out = make([]SubnetStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserProvidedNetworkStatus.
+func (in *UserProvidedNetworkStatus) DeepCopy() *UserProvidedNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UserProvidedNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VPCEndpointService) DeepCopyInto(out *VPCEndpointService) {
 	*out = *in
@@ -13,6 +13,72 @@ type MachinePool struct {
 
 	// OSDisk defines the storage for instance.
 	OSDisk `json:"osDisk"`
+
+	// NetworkResourceGroupName is the resource group of the virtual network of the machines.
+	// Required when VirtualNetwork is set.
+	// +optional
+	NetworkResourceGroupName string `json:"networkResourceGroupName,omitempty"`
+
+	// VirtualNetwork is the name of an existing virtual network to create the machines in, such as a
+	// virtual network peered with the virtual network of the cluster. Defaults to the virtual network
+	// of the cluster.
+	// +optional
+	VirtualNetwork string `json:"virtualNetwork,omitempty"`
+
+	// ComputeSubnet is the subnet of the virtual network to create the machines in.
+	// Required when VirtualNetwork is set, unless ZoneSubnets has a subnet for every zone.
+	// +optional
+	ComputeSubnet string `json:"computeSubnet,omitempty"`
+
+	// ZoneSubnets maps zones to the subnet of the virtual network to create their machines in,
+	// overriding ComputeSubnet.
+	// eg. {"1": "subnet-a", "2": "subnet-b"}
+	// +optional
+	ZoneSubnets map[string]string `json:"zoneSubnets,omitempty"`
+
+	// OSImage is the image to create the machines from, either an image of a Shared Image Gallery or a marketplace
+	// image. Defaults to the image of the cluster.
+	// +optional
+	OSImage *OSImage `json:"osImage,omitempty"`
+
+	// SpotVMOptions makes the machines of the pool Azure Spot VMs, which cost less but may be evicted by Azure at any
+	// time. Evicted VMs are deleted, and replaced by the machine API. Requires a cluster version of 4.6 or later.
+	// Most users should provide an empty struct.
+	// +optional
+	SpotVMOptions *SpotVMOptions `json:"spotVMOptions,omitempty"`
+}
+
+// SpotVMOptions defines the options of machines running on Azure Spot VMs.
+type SpotVMOptions struct {
+	// MaxPrice is the maximum price, in US dollars per hour, to pay for a VM. VMs are evicted when their price rises
+	// above it. "-1" pays up to the pay-as-you-go price, so that VMs are only evicted for capacity.
+	// Default: -1
+	// +optional
+	MaxPrice *string `json:"maxPrice,omitempty"`
+}
+
+// OSImage is the image of Azure machines. Either ResourceID, or all of Publisher, Offer, SKU and Version, must be set.
+type OSImage struct {
+	// ResourceID is the resource ID of the image, such as the ID of an image version of a Shared Image Gallery.
+	// eg. /subscriptions/<subscription>/resourceGroups/<group>/providers/Microsoft.Compute/galleries/<gallery>/images/<image>/versions/<version>
+	// +optional
+	ResourceID string `json:"resourceID,omitempty"`
+
+	// Publisher is the publisher of the marketplace image.
+	// +optional
+	Publisher string `json:"publisher,omitempty"`
+
+	// Offer is the offer of the marketplace image.
+	// +optional
+	Offer string `json:"offer,omitempty"`
+
+	// SKU is the SKU of the marketplace image.
+	// +optional
+	SKU string `json:"sku,omitempty"`
+
+	// Version is the version of the marketplace image.
+	// +optional
+	Version string `json:"version,omitempty"`
 }
 
 // OSDisk defines the disk for machines on Azure.
@@ -38,4 +104,28 @@ func (a *MachinePool) Set(required *MachinePool) {
 	if required.OSDisk.DiskSizeGB != 0 {
 		a.OSDisk.DiskSizeGB = required.OSDisk.DiskSizeGB
 	}
+
+	if required.NetworkResourceGroupName != "" {
+		a.NetworkResourceGroupName = required.NetworkResourceGroupName
+	}
+
+	if required.VirtualNetwork != "" {
+		a.VirtualNetwork = required.VirtualNetwork
+	}
+
+	if required.ComputeSubnet != "" {
+		a.ComputeSubnet = required.ComputeSubnet
+	}
+
+	if len(required.ZoneSubnets) > 0 {
+		a.ZoneSubnets = required.ZoneSubnets
+	}
+
+	if required.OSImage != nil {
+		a.OSImage = required.OSImage
+	}
+
+	if required.SpotVMOptions != nil {
+		a.SpotVMOptions = required.SpotVMOptions
+	}
 }
@@ -3,4 +3,12 @@ package azure
 // Metadata contains Azure metadata (e.g. for uninstalling the cluster).
 type Metadata struct {
 	Region string `json:"region"`
+
+	// ResourceGroupName is the name of the resource group holding the resources of the cluster.
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// BaseDomainResourceGroupName is the name of the resource group holding the DNS zone for the base domain.
+	// +optional
+	BaseDomainResourceGroupName string `json:"baseDomainResourceGroupName,omitempty"`
 }
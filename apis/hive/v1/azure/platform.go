@@ -24,6 +24,34 @@ type Platform struct {
 	// If empty, the value is equal to "AzurePublicCloud".
 	// +optional
 	CloudName CloudEnvironment `json:"cloudName,omitempty"`
+
+	// Egress configures an existing NAT gateway through which the cluster reaches the internet.
+	// +optional
+	Egress *Egress `json:"egress,omitempty"`
+}
+
+// Egress configures an existing NAT gateway for the egress traffic of a cluster.
+type Egress struct {
+	// NATGatewayID is the resource ID of an existing NAT gateway associated with the subnets of the existing virtual
+	// network of the install config. The cluster is installed with the UserDefinedRouting outbound type, so that its
+	// egress traffic goes through the NAT gateway rather than the public load balancer.
+	NATGatewayID string `json:"natGatewayID"`
+}
+
+// PlatformStatus contains the observed state on Azure platform.
+type PlatformStatus struct {
+	// ResourceGroupName is the name of the resource group holding the resources of the cluster.
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// BaseDomainResourceGroupName is the name of the resource group holding the DNS zone for the base domain.
+	// +optional
+	BaseDomainResourceGroupName string `json:"baseDomainResourceGroupName,omitempty"`
+
+	// NATGatewayID is the resource ID of the NAT gateway through which the cluster routes egress traffic, when the
+	// cluster was installed with an existing NAT gateway.
+	// +optional
+	NATGatewayID string `json:"natGatewayID,omitempty"`
 }
 
 // CloudEnvironment is the name of the Azure cloud environment
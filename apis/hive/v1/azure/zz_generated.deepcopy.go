@@ -4,6 +4,22 @@
 
 package azure
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Egress) DeepCopyInto(out *Egress) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Egress.
+func (in *Egress) DeepCopy() *Egress {
+	if in == nil {
+		return nil
+	}
+	out := new(Egress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePool) DeepCopyInto(out *MachinePool) {
 	*out = *in
@@ -13,6 +29,23 @@ func (in *MachinePool) DeepCopyInto(out *MachinePool) {
 		copy(*out, *in)
 	}
 	out.OSDisk = in.OSDisk
+	if in.ZoneSubnets != nil {
+		in, out := &in.ZoneSubnets, &out.ZoneSubnets
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.OSImage != nil {
+		in, out := &in.OSImage, &out.OSImage
+		*out = new(OSImage)
+		**out = **in
+	}
+	if in.SpotVMOptions != nil {
+		in, out := &in.SpotVMOptions, &out.SpotVMOptions
+		*out = new(SpotVMOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -58,10 +91,31 @@ func (in *OSDisk) DeepCopy() *OSDisk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSImage) DeepCopyInto(out *OSImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSImage.
+func (in *OSImage) DeepCopy() *OSImage {
+	if in == nil {
+		return nil
+	}
+	out := new(OSImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Platform) DeepCopyInto(out *Platform) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = new(Egress)
+		**out = **in
+	}
 	return
 }
 
@@ -74,3 +128,40 @@ func (in *Platform) DeepCopy() *Platform {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
+func (in *PlatformStatus) DeepCopy() *PlatformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpotVMOptions) DeepCopyInto(out *SpotVMOptions) {
+	*out = *in
+	if in.MaxPrice != nil {
+		in, out := &in.MaxPrice, &out.MaxPrice
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotVMOptions.
+func (in *SpotVMOptions) DeepCopy() *SpotVMOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SpotVMOptions)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,94 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterAccessTokenSpec defines the access to a cluster requested by a ClusterAccessToken.
+type ClusterAccessTokenSpec struct {
+	// ClusterDeploymentRef references the ClusterDeployment of the cluster to access. The ClusterDeployment must be in
+	// the namespace of the ClusterAccessToken.
+	ClusterDeploymentRef corev1.LocalObjectReference `json:"clusterDeploymentRef"`
+
+	// ClusterRoleName is the name of the ClusterRole in the cluster which is bound to the token.
+	// +kubebuilder:validation:MinLength=1
+	ClusterRoleName string `json:"clusterRoleName"`
+
+	// Namespace is the namespace in the cluster to which the access is restricted. The ClusterRole is bound in the
+	// namespace with a RoleBinding. If not set, the ClusterRole is bound cluster-wide with a ClusterRoleBinding.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Lifetime is how long the token can be used after it is issued. Once the lifetime has elapsed, the access is
+	// revoked in the cluster and the ClusterAccessToken is deleted. Defaults to 1h.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+}
+
+// ClusterAccessTokenStatus defines the observed state of a ClusterAccessToken.
+type ClusterAccessTokenStatus struct {
+	// KubeconfigSecretRef references the secret, in the namespace of the ClusterAccessToken, containing a kubeconfig
+	// for the cluster which uses the token. It is set once the token has been issued.
+	// +optional
+	KubeconfigSecretRef *corev1.LocalObjectReference `json:"kubeconfigSecretRef,omitempty"`
+
+	// ExpirationTimestamp is the time the lifetime of the token elapses.
+	// +optional
+	ExpirationTimestamp *metav1.Time `json:"expirationTimestamp,omitempty"`
+
+	// RoleBindingRef references the binding created for the token in the cluster. Exactly this binding is deleted
+	// when the access is revoked, even if the spec has changed since it was created.
+	// +optional
+	RoleBindingRef *ClusterAccessTokenRoleBindingReference `json:"roleBindingRef,omitempty"`
+}
+
+// ClusterAccessTokenRoleBindingReference references a RoleBinding or ClusterRoleBinding in the cluster.
+type ClusterAccessTokenRoleBindingReference struct {
+	// Kind is the kind of the binding, RoleBinding or ClusterRoleBinding.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of a RoleBinding.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the binding.
+	Name string `json:"name"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterAccessToken requests a time-limited kubeconfig for a cluster, which is bound to a ClusterRole in the cluster.
+// Hive creates a service account and role binding in the cluster, and stores a kubeconfig with a token for the service
+// account in a secret.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ClusterDeployment",type="string",JSONPath=".spec.clusterDeploymentRef.name"
+// +kubebuilder:printcolumn:name="ClusterRole",type="string",JSONPath=".spec.clusterRoleName"
+// +kubebuilder:printcolumn:name="Expiration",type="date",JSONPath=".status.expirationTimestamp"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced
+type ClusterAccessToken struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterAccessTokenSpec   `json:"spec,omitempty"`
+	Status ClusterAccessTokenStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterAccessTokenList contains a list of ClusterAccessTokens.
+type ClusterAccessTokenList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterAccessToken `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterAccessToken{}, &ClusterAccessTokenList{})
+}
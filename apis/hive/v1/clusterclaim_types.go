@@ -40,6 +40,10 @@ type ClusterClaimStatus struct {
 	// when the lifetime has elapsed, the claim will be deleted by Hive.
 	// +optional
 	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// ObservedGeneration is the generation of the cluster claim which was last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // ClusterClaimCondition contains details for the current condition of a cluster claim.
@@ -60,6 +64,22 @@ type ClusterClaimCondition struct {
 	// Message is a human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// ObservedGeneration is the generation of the cluster claim when the condition was set.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ToCondition returns the cluster claim condition as a metav1.Condition, with the generation of the claim when it
+// was last reconciled.
+func (c *ClusterClaimCondition) ToCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(c.Type),
+		Status:             metav1.ConditionStatus(c.Status),
+		ObservedGeneration: c.ObservedGeneration,
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             c.Reason,
+		Message:            c.Message,
+	}
 }
 
 // ClusterClaimConditionType is a valid value for ClusterClaimCondition.Type.
@@ -70,6 +90,9 @@ const (
 	ClusterClaimPendingCondition ClusterClaimConditionType = "Pending"
 	// ClusterRunningCondition is true when a claimed cluster is running and ready for use.
 	ClusterRunningCondition ClusterClaimConditionType = "ClusterRunning"
+	// ClusterClaimReadyCondition is true when a cluster has been assigned to the claim and is running. Its reason is
+	// Pending until a cluster is assigned.
+	ClusterClaimReadyCondition ClusterClaimConditionType = "Ready"
 )
 
 // +genclient
@@ -3,6 +3,9 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	configv1 "github.com/openshift/api/config/v1"
 
 	"github.com/openshift/hive/apis/hive/v1/agent"
 	"github.com/openshift/hive/apis/hive/v1/aws"
@@ -93,6 +96,11 @@ type ClusterDeploymentSpec struct {
 	// +optional
 	PreserveOnDelete bool `json:"preserveOnDelete,omitempty"`
 
+	// DeletionProtection guards the ClusterDeployment against accidental deletion by requiring the deletion to be
+	// confirmed, and optionally delaying the start of deprovisioning.
+	// +optional
+	DeletionProtection *DeletionProtection `json:"deletionProtection,omitempty"`
+
 	// ControlPlaneConfig contains additional configuration for the target cluster's control plane
 	// +optional
 	ControlPlaneConfig ControlPlaneConfigSpec `json:"controlPlaneConfig,omitempty"`
@@ -156,8 +164,224 @@ type ClusterDeploymentSpec struct {
 	// provision AWS clusters to use Amazon's Security Token Service.
 	// +optional
 	BoundServiceAccountSignkingKeySecretRef *corev1.LocalObjectReference `json:"boundServiceAccountSigningKeySecretRef,omitempty"`
+
+	// RemediationPolicy configures the actions Hive takes when the cluster remains unreachable, or fails to resume
+	// from hibernation, for too long.
+	// +optional
+	RemediationPolicy *RemediationPolicy `json:"remediationPolicy,omitempty"`
+
+	// MaintenanceWindows are the times during which Hive may start disruptive actions on the cluster: replacing the
+	// machines of MachinePools with a rollout strategy, and hibernating the cluster after HibernateAfter. Outside of
+	// the windows, these actions wait for the next window to open. Hibernation requested by setting PowerState is not
+	// affected. When empty, the actions may start at any time.
+	// The windows can be overridden in emergencies by setting the "hive.openshift.io/override-maintenance-windows-until"
+	// annotation to an RFC 3339 timestamp.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// Proxy is the cluster-wide proxy configuration of the cluster. It is copied to the proxy section and the
+	// additional trust bundle of the install-config when the cluster is provisioned, and Hive sends the traffic of its
+	// clients of the cluster through the proxy. Changes after the cluster is installed only affect how Hive reaches
+	// the cluster; the proxy configuration of the cluster itself is managed in the cluster.
+	// +optional
+	Proxy *ClusterProxy `json:"proxy,omitempty"`
+
+	// PropagatedNodeLabels lists the keys of the labels of the ClusterDeployment, such as environment or tenant labels,
+	// which Hive applies to all the nodes of the cluster, so that the nodes carry the same metadata as the
+	// ClusterDeployment. The labels are added to the machine template of the MachineSets of every MachinePool, where
+	// the labels of the MachinePool take precedence, and to the existing Machines of the MachinePools and the master
+	// Machines of the cluster. The machine API copies the labels of the Machines to their nodes. Labels which stop
+	// being propagated are removed from the Machines, but are left on the nodes.
+	// +optional
+	PropagatedNodeLabels []string `json:"propagatedNodeLabels,omitempty"`
+
+	// TeardownHooks are run against the cluster when the ClusterDeployment is deleted, one at a time and before the
+	// cluster is deprovisioned, for example to deregister the cluster from external systems or to drain its storage.
+	// Their progress is reported in the status of the ClusterDeprovision.
+	// +optional
+	TeardownHooks []TeardownHook `json:"teardownHooks,omitempty"`
+
+	// ControlPlaneMachines is the desired configuration of the control plane machines of the installed cluster, which
+	// lets the control plane be resized on day 2. It is applied to the ControlPlaneMachineSet of the cluster on AWS,
+	// GCP and Azure, which replaces the control plane machines to match it according to its update strategy. The
+	// progress is reported in the ControlPlaneResizing condition, and clusters which cannot be resized in the
+	// ControlPlaneResizeUnsupported condition. The control plane machines are reconciled along with the MachinePools
+	// of the cluster, so the cluster needs at least one MachinePool.
+	// +optional
+	ControlPlaneMachines *ControlPlaneMachineSpec `json:"controlPlaneMachines,omitempty"`
+
+	// ClusterAutoscaler configures the default ClusterAutoscaler which Hive creates in the cluster when a MachinePool
+	// is auto-scaling. When set, the scale down, node group balancing and resource limits of the ClusterAutoscaler are
+	// managed by Hive, and settings which are left out are cleared from it. When not set, Hive only makes sure that
+	// scale down is enabled, and the ClusterAutoscaler can be tuned in the cluster.
+	// +optional
+	ClusterAutoscaler *ClusterAutoscalerSettings `json:"clusterAutoscaler,omitempty"`
+}
+
+// ClusterAutoscalerSettings are the settings of the default ClusterAutoscaler of a cluster.
+type ClusterAutoscalerSettings struct {
+	// ScaleDown configures when nodes are removed from the cluster. Scale down is always enabled.
+	// +optional
+	ScaleDown *ClusterAutoscalerScaleDown `json:"scaleDown,omitempty"`
+
+	// BalanceSimilarNodeGroups keeps the sizes of node groups with the same instance type and the same labels
+	// balanced.
+	// +optional
+	BalanceSimilarNodeGroups *bool `json:"balanceSimilarNodeGroups,omitempty"`
+
+	// ResourceLimits are the limits of the total resources of the cluster, beyond which the cluster is not scaled up.
+	// +optional
+	ResourceLimits *ClusterAutoscalerResourceLimits `json:"resourceLimits,omitempty"`
+}
+
+// ClusterAutoscalerScaleDown configures the scale down of a cluster. The durations default to those of the cluster
+// autoscaler.
+type ClusterAutoscalerScaleDown struct {
+	// DelayAfterAdd is how long after a scale up scale down evaluation resumes.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +optional
+	DelayAfterAdd *metav1.Duration `json:"delayAfterAdd,omitempty"`
+
+	// DelayAfterDelete is how long after the deletion of a node scale down evaluation resumes.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +optional
+	DelayAfterDelete *metav1.Duration `json:"delayAfterDelete,omitempty"`
+
+	// DelayAfterFailure is how long after a failed scale down scale down evaluation resumes.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +optional
+	DelayAfterFailure *metav1.Duration `json:"delayAfterFailure,omitempty"`
+
+	// UnneededTime is how long a node must be unneeded before it is removed.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	// +optional
+	UnneededTime *metav1.Duration `json:"unneededTime,omitempty"`
+
+	// UtilizationThreshold is the utilization of a node, as a decimal between 0 and 1 such as "0.5", below which the
+	// node is considered for removal. The utilization is the sum of the requests of the pods of the node divided by
+	// its capacity. Defaults to "0.5".
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	UtilizationThreshold *string `json:"utilizationThreshold,omitempty"`
+}
+
+// ClusterAutoscalerResourceLimits are the limits of the total resources of a cluster.
+type ClusterAutoscalerResourceLimits struct {
+	// MaxNodesTotal is the maximum number of nodes of the cluster, including the control plane nodes.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxNodesTotal *int32 `json:"maxNodesTotal,omitempty"`
+
+	// Cores is the range of the number of cores of the cluster.
+	// +optional
+	Cores *ClusterAutoscalerResourceRange `json:"cores,omitempty"`
+
+	// Memory is the range of the gigabytes of memory of the cluster.
+	// +optional
+	Memory *ClusterAutoscalerResourceRange `json:"memory,omitempty"`
+
+	// GPUs are the ranges of the number of GPUs of the cluster, by type of GPU.
+	// +optional
+	GPUs []ClusterAutoscalerGPULimit `json:"gpus,omitempty"`
+}
+
+// ClusterAutoscalerResourceRange is a range of an amount of a resource.
+type ClusterAutoscalerResourceRange struct {
+	// Min is the minimum amount of the resource.
+	// +kubebuilder:validation:Minimum=0
+	Min int32 `json:"min"`
+
+	// Max is the maximum amount of the resource. It must not be less than the minimum.
+	// +kubebuilder:validation:Minimum=0
+	Max int32 `json:"max"`
 }
 
+// ClusterAutoscalerGPULimit is the range of the number of GPUs of a type.
+type ClusterAutoscalerGPULimit struct {
+	// Type is the type of the GPUs, as in the resource name of the GPUs of the nodes, such as nvidia.com/gpu.
+	// +kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+
+	// Min is the minimum number of GPUs of the type.
+	// +kubebuilder:validation:Minimum=0
+	Min int32 `json:"min"`
+
+	// Max is the maximum number of GPUs of the type. It must not be less than the minimum.
+	// +kubebuilder:validation:Minimum=1
+	Max int32 `json:"max"`
+}
+
+// ControlPlaneMachineSpec is the desired configuration of the control plane machines of a cluster.
+type ControlPlaneMachineSpec struct {
+	// Type is the instance type of the control plane machines: the instance type on AWS, the machine type on GCP and
+	// the VM size on Azure, as in the type of the platforms of a MachinePool.
+	// +kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+}
+
+// TeardownHook is a set of resources applied to a cluster being deleted, and awaited before the cluster is
+// deprovisioned.
+type TeardownHook struct {
+	// Name identifies the hook in the status of the ClusterDeprovision.
+	Name string `json:"name"`
+
+	// Resources are the objects applied to the cluster when the hook runs, as in a SyncSet. Objects which already
+	// exist in the cluster are left unchanged. The hook succeeds once every Job among them has succeeded, and fails as
+	// soon as one of them fails. Objects of other kinds only need to be created.
+	Resources []runtime.RawExtension `json:"resources"`
+
+	// Timeout is how long the hook is awaited, from when its resources are first applied, before the cluster is
+	// deprovisioned regardless. Defaults to 10m.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ClusterProxy is the cluster-wide proxy configuration of a cluster.
+type ClusterProxy struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests. The URL scheme must be http.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests. The URL scheme must be http or https.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of domains and CIDRs for which the proxy is not used. A domain with a leading
+	// "." matches its subdomains only, and "*" bypasses the proxy for all destinations.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// AdditionalTrustBundle is a PEM-encoded bundle of X.509 certificates trusted in addition to the system
+	// certificates, such as the certificate of a TLS-intercepting proxy.
+	// +optional
+	AdditionalTrustBundle string `json:"additionalTrustBundle,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period of time during which disruptive actions may start.
+type MaintenanceWindow struct {
+	// Days are the days of the week on which the window opens. When empty, the window opens every day.
+	// +optional
+	Days []MaintenanceWindowDay `json:"days,omitempty"`
+
+	// StartTime is the time of day, in UTC, at which the window opens, formatted as HH:MM in 24-hour notation.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// Duration is how long the window stays open. It must be positive and at most a week.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	Duration metav1.Duration `json:"duration"`
+}
+
+// MaintenanceWindowDay is a day of the week on which a maintenance window opens.
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type MaintenanceWindowDay string
+
 // ClusterInstallLocalReference provides reference to an object that implements
 // the hivecontract ClusterInstall. The namespace of the object is same as the
 // ClusterDeployment.
@@ -169,6 +393,66 @@ type ClusterInstallLocalReference struct {
 	Name string `json:"name"`
 }
 
+// DeletionProtection contains settings protecting a ClusterDeployment from accidental deletion.
+type DeletionProtection struct {
+	// Enabled requires deletion of the ClusterDeployment to be confirmed. Before the ClusterDeployment can be deleted,
+	// the "hive.openshift.io/confirm-delete" annotation must be set to the name of the ClusterDeployment.
+	Enabled bool `json:"enabled"`
+
+	// GracePeriod is the length of time after the ClusterDeployment is deleted before deprovisioning starts. During
+	// the grace period the deletion can be cancelled by removing the "hive.openshift.io/confirm-delete" annotation,
+	// in which case deprovisioning is held and the cluster is left intact.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// RemediationPolicy contains the actions to take when a cluster is unhealthy.
+type RemediationPolicy struct {
+	// UnhealthyDuration is how long the cluster must be unreachable, or failing to resume from hibernation, before
+	// remediation starts.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	UnhealthyDuration metav1.Duration `json:"unhealthyDuration"`
+
+	// Actions are the remediation actions to take, in order. The first action is taken once the cluster has been
+	// unhealthy for the UnhealthyDuration, and each following action if the cluster is still unhealthy the
+	// UnhealthyDuration after the previous one. Each action is attempted once each time the cluster becomes unhealthy.
+	// +kubebuilder:validation:MinItems=1
+	Actions []RemediationAction `json:"actions"`
+}
+
+// RemediationActionType is a type of remediation action.
+// +kubebuilder:validation:Enum=RestartMachines;ApproveCSRs;Webhook
+type RemediationActionType string
+
+const (
+	// RestartMachinesRemediationAction stops and then starts the cloud instances of the cluster. It is only
+	// supported on platforms that support hibernation.
+	RestartMachinesRemediationAction RemediationActionType = "RestartMachines"
+	// ApproveCSRsRemediationAction approves the pending certificate signing requests of the cluster's nodes.
+	ApproveCSRsRemediationAction RemediationActionType = "ApproveCSRs"
+	// WebhookRemediationAction sends a notification of the unhealthy cluster to a webhook, such as an external
+	// incident system.
+	WebhookRemediationAction RemediationActionType = "Webhook"
+)
+
+// RemediationAction is an action to take to remediate an unhealthy cluster.
+type RemediationAction struct {
+	// Type is the type of the action.
+	Type RemediationActionType `json:"type"`
+
+	// Webhook configures the webhook to notify. It is required for Webhook actions.
+	// +optional
+	Webhook *RemediationWebhook `json:"webhook,omitempty"`
+}
+
+// RemediationWebhook is a webhook notified of an unhealthy cluster.
+type RemediationWebhook struct {
+	// URL is the URL to which the notification is POSTed as JSON.
+	URL string `json:"url"`
+}
+
 // Provisioning contains settings used only for initial cluster provisioning.
 type Provisioning struct {
 	// InstallConfigSecretRef is the reference to a secret that contains an openshift-install
@@ -212,8 +496,59 @@ type Provisioning struct {
 	// additional features of the installer.
 	// +optional
 	InstallerEnv []corev1.EnvVar `json:"installerEnv,omitempty"`
+
+	// FIPS enables or disables FIPS mode for the cluster. When set, it overrides the fips setting of the
+	// InstallConfig. It cannot be changed once the cluster is installed.
+	// +optional
+	FIPS *bool `json:"fips,omitempty"`
+
+	// EtcdEncryption configures the encryption at rest of the cluster's etcd datastore. It is rendered into the
+	// APIServer configuration manifest at install time, and cannot be changed once the cluster is installed.
+	// +optional
+	EtcdEncryption *configv1.APIServerEncryption `json:"etcdEncryption,omitempty"`
+
+	// Topology is the shape of the cluster. For the Compact and SingleNode topologies, the replicas of the control
+	// plane and compute pools of the InstallConfig are overridden so that the cluster has no workers and its control
+	// plane nodes are schedulable. It cannot be changed once the cluster is installed. Defaults to HighlyAvailable.
+	// +optional
+	Topology ClusterTopology `json:"topology,omitempty"`
+
+	// ImageBasedInstall installs the cluster by configuring a host which was pre-installed from a seed image, instead
+	// of running the installer against the platform. It requires the SingleNode topology and the bare metal platform.
+	// +optional
+	ImageBasedInstall *ImageBasedInstall `json:"imageBasedInstall,omitempty"`
+}
+
+// ImageBasedInstall configures the image-based install of a single-node cluster onto a host which was pre-installed
+// from a seed image. The release of the seed image must match the release image of the cluster.
+type ImageBasedInstall struct {
+	// HostAddress is the address at which the install pod reaches the host over SSH, with the key referenced by
+	// SSHPrivateKeySecretRef.
+	HostAddress string `json:"hostAddress"`
+
+	// SSHUser is the user the install pod logs into the host as. Defaults to core.
+	// +optional
+	SSHUser string `json:"sshUser,omitempty"`
+
+	// Hostname is the hostname of the node of the cluster. Defaults to the name of the cluster.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
 }
 
+// ClusterTopology is the shape of a cluster.
+// +kubebuilder:validation:Enum="";HighlyAvailable;Compact;SingleNode
+type ClusterTopology string
+
+const (
+	// HighlyAvailableClusterTopology is a cluster with three control plane nodes and the workers given by the
+	// InstallConfig.
+	HighlyAvailableClusterTopology ClusterTopology = "HighlyAvailable"
+	// CompactClusterTopology is a cluster with three schedulable control plane nodes and no workers.
+	CompactClusterTopology ClusterTopology = "Compact"
+	// SingleNodeClusterTopology is a cluster with a single schedulable control plane node and no workers.
+	SingleNodeClusterTopology ClusterTopology = "SingleNode"
+)
+
 // ClusterImageSetReference is a reference to a ClusterImageSet
 type ClusterImageSetReference struct {
 	// Name is the name of the ClusterImageSet that this refers to
@@ -249,6 +584,22 @@ type ClusterMetadata struct {
 	// AdminPasswordSecretRef references the secret containing the admin username/password which can be used to login to this cluster.
 	// +optional
 	AdminPasswordSecretRef *corev1.LocalObjectReference `json:"adminPasswordSecretRef,omitempty"`
+
+	// Platform is the platform-specific metadata generated by the installer for this cluster, as found in its metadata.json.
+	// +optional
+	Platform *ClusterPlatformMetadata `json:"platform,omitempty"`
+}
+
+// ClusterPlatformMetadata contains the platform-specific metadata generated by the installer for a cluster.
+type ClusterPlatformMetadata struct {
+	// AWS is the metadata of a cluster installed on AWS.
+	AWS *aws.Metadata `json:"aws,omitempty"`
+
+	// Azure is the metadata of a cluster installed on Azure.
+	Azure *azure.Metadata `json:"azure,omitempty"`
+
+	// GCP is the metadata of a cluster installed on GCP.
+	GCP *gcp.Metadata `json:"gcp,omitempty"`
 }
 
 // ClusterDeploymentStatus defines the observed state of ClusterDeployment
@@ -305,6 +656,97 @@ type ClusterDeploymentStatus struct {
 	// perform the installation.
 	// +optional
 	Platform *PlatformStatus `json:"platformStatus,omitempty"`
+
+	// CostEstimate is the estimated cost of running the cluster, reported when cost estimation is configured
+	// in HiveConfig.
+	// +optional
+	CostEstimate *CostEstimate `json:"costEstimate,omitempty"`
+
+	// Remediation is the status of the remediation of the cluster under its RemediationPolicy.
+	// +optional
+	Remediation *RemediationStatus `json:"remediation,omitempty"`
+
+	// MachinePools summarizes the replicas of the MachinePools of the cluster, so that its compute capacity can be
+	// read from the ClusterDeployment alone.
+	// +optional
+	MachinePools []MachinePoolSummary `json:"machinePools,omitempty"`
+
+	// KubeadminPasswordRotatedTimestamp is the time the kubeadmin password of the cluster was last rotated at the
+	// request of the rotate-kubeadmin-password annotation.
+	// +optional
+	KubeadminPasswordRotatedTimestamp *metav1.Time `json:"kubeadminPasswordRotatedTimestamp,omitempty"`
+
+	// ClaimReleaseHooks is the status of the claim release hooks of the pool while the released cluster is being
+	// sanitized for reuse by the pool.
+	// +optional
+	ClaimReleaseHooks []TeardownHookStatus `json:"claimReleaseHooks,omitempty"`
+}
+
+// MachinePoolSummary is the summary of a MachinePool in the status of its ClusterDeployment.
+type MachinePoolSummary struct {
+	// Name is the name of the MachinePool in the cluster, e.g. worker.
+	Name string `json:"name"`
+
+	// Replicas is the number of machines desired across the MachineSets of the MachinePool.
+	Replicas int32 `json:"replicas"`
+
+	// ReadyReplicas is the number of ready machines across the MachineSets of the MachinePool.
+	ReadyReplicas int32 `json:"readyReplicas"`
+}
+
+// RemediationStatus is the status of the remediation of an unhealthy cluster.
+type RemediationStatus struct {
+	// UnhealthySince is the time the cluster became unhealthy.
+	UnhealthySince metav1.Time `json:"unhealthySince"`
+
+	// Reason is the reason the cluster is considered unhealthy.
+	Reason string `json:"reason"`
+
+	// Attempts are the remediation actions attempted since the cluster became unhealthy.
+	// +optional
+	Attempts []RemediationAttempt `json:"attempts,omitempty"`
+}
+
+// RemediationAttemptResult is the result of a remediation attempt.
+type RemediationAttemptResult string
+
+const (
+	// InProgressRemediationAttemptResult is used while the remediation action is still being carried out.
+	InProgressRemediationAttemptResult RemediationAttemptResult = "InProgress"
+	// SucceededRemediationAttemptResult is used when the remediation action was carried out.
+	SucceededRemediationAttemptResult RemediationAttemptResult = "Succeeded"
+	// FailedRemediationAttemptResult is used when the remediation action could not be carried out.
+	FailedRemediationAttemptResult RemediationAttemptResult = "Failed"
+)
+
+// RemediationAttempt is a record of a remediation action taken for an unhealthy cluster.
+type RemediationAttempt struct {
+	// Action is the type of the action attempted.
+	Action RemediationActionType `json:"action"`
+
+	// StartTime is the time the attempt started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// Result is the result of the attempt.
+	Result RemediationAttemptResult `json:"result"`
+
+	// Message is a human-readable message with details of the result.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CostEstimate is the estimated cost of running a cluster.
+type CostEstimate struct {
+	// HourlyCost is the estimated cost of running the cluster for an hour, as a decimal number.
+	HourlyCost string `json:"hourlyCost"`
+
+	// Currency is the currency of the HourlyCost.
+	Currency string `json:"currency"`
+
+	// UnpricedInstanceTypes lists the instance types used by the cluster which are missing from the pricing
+	// table, and so are not included in the HourlyCost.
+	// +optional
+	UnpricedInstanceTypes []string `json:"unpricedInstanceTypes,omitempty"`
 }
 
 // ClusterDeploymentCondition contains details for the current condition of a cluster deployment
@@ -327,6 +769,18 @@ type ClusterDeploymentCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
+// ToCondition returns the cluster deployment condition as a metav1.Condition. Many ClusterDeployment conditions
+// report a problem when True; ClusterReadyCondition is the one which summarizes the health of the cluster.
+func (c *ClusterDeploymentCondition) ToCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(c.Type),
+		Status:             metav1.ConditionStatus(c.Status),
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             c.Reason,
+		Message:            c.Message,
+	}
+}
+
 // ClusterDeploymentConditionType is a valid value for ClusterDeploymentCondition.Type
 type ClusterDeploymentConditionType string
 
@@ -406,6 +860,19 @@ const (
 	// for the cluster.
 	AWSPrivateLinkFailedClusterDeploymentCondition ClusterDeploymentConditionType = "AWSPrivateLinkFailed"
 
+	// DeletionBlockedClusterDeploymentCondition is true when the ClusterDeployment has been waiting on its finalizers
+	// to be deleted for longer than the deletion blocked timeout of HiveConfig.
+	DeletionBlockedClusterDeploymentCondition ClusterDeploymentConditionType = "DeletionBlocked"
+
+	// ControlPlaneResizingCondition is true while the control plane machines of the cluster are being replaced to
+	// match the ControlPlaneMachines of the ClusterDeployment.
+	ControlPlaneResizingCondition ClusterDeploymentConditionType = "ControlPlaneResizing"
+
+	// ControlPlaneResizeUnsupportedCondition is true when the ControlPlaneMachines of the ClusterDeployment cannot be
+	// applied, because the platform of the cluster is not supported or the cluster has no active
+	// ControlPlaneMachineSet.
+	ControlPlaneResizeUnsupportedCondition ClusterDeploymentConditionType = "ControlPlaneResizeUnsupported"
+
 	// These are conditions that are copied from ClusterInstall on to the ClusterDeployment object.
 	ClusterInstallFailedClusterDeploymentCondition          ClusterDeploymentConditionType = "ClusterInstallFailed"
 	ClusterInstallCompletedClusterDeploymentCondition       ClusterDeploymentConditionType = "ClusterInstallCompleted"
@@ -565,6 +1032,12 @@ type Platform struct {
 type PlatformStatus struct {
 	// AWS is the observed state on AWS.
 	AWS *aws.PlatformStatus `json:"aws,omitempty"`
+
+	// Azure is the observed state on Azure.
+	Azure *azure.PlatformStatus `json:"azure,omitempty"`
+
+	// GCP is the observed state on GCP.
+	GCP *gcp.PlatformStatus `json:"gcp,omitempty"`
 }
 
 // ClusterIngress contains the configurable pieces for any ClusterIngress objects
@@ -592,6 +1065,69 @@ type ClusterIngress struct {
 	// should be used for this Ingress
 	// +optional
 	ServingCertificate string `json:"servingCertificate,omitempty"`
+
+	// Replicas is the desired number of ingress controller replicas. If unset, the ingress operator
+	// chooses a default based on the cluster topology.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// EndpointPublishingStrategy is used to publish the ingress controller endpoints. If unset, the
+	// ingress operator chooses a default based on the platform.
+	// +optional
+	EndpointPublishingStrategy *IngressEndpointPublishingStrategy `json:"endpointPublishingStrategy,omitempty"`
+
+	// NodePlacement controls the scheduling of the ingress controller pods.
+	// +optional
+	NodePlacement *IngressNodePlacement `json:"nodePlacement,omitempty"`
+
+	// TLSSecurityProfile specifies the TLS settings of the ingress controller. If unset, the cluster's
+	// APIServer TLS security profile is used.
+	// +optional
+	TLSSecurityProfile *configv1.TLSSecurityProfile `json:"tlsSecurityProfile,omitempty"`
+}
+
+// IngressEndpointPublishingStrategyType is a way to publish ingress controller endpoints.
+// +kubebuilder:validation:Enum=LoadBalancerService;HostNetwork;Private;NodePortService
+type IngressEndpointPublishingStrategyType string
+
+const (
+	IngressLoadBalancerServiceStrategyType IngressEndpointPublishingStrategyType = "LoadBalancerService"
+	IngressHostNetworkStrategyType         IngressEndpointPublishingStrategyType = "HostNetwork"
+	IngressPrivateStrategyType             IngressEndpointPublishingStrategyType = "Private"
+	IngressNodePortServiceStrategyType     IngressEndpointPublishingStrategyType = "NodePortService"
+)
+
+// IngressLoadBalancerScope is the scope at which a load balancer is exposed.
+// +kubebuilder:validation:Enum=Internal;External
+type IngressLoadBalancerScope string
+
+const (
+	IngressInternalLoadBalancerScope IngressLoadBalancerScope = "Internal"
+	IngressExternalLoadBalancerScope IngressLoadBalancerScope = "External"
+)
+
+// IngressEndpointPublishingStrategy specifies how the ingress controller endpoints are published.
+type IngressEndpointPublishingStrategy struct {
+	// Type is the publishing strategy to use.
+	Type IngressEndpointPublishingStrategyType `json:"type"`
+
+	// LoadBalancerScope is the scope at which the load balancer is exposed. Only used with the
+	// LoadBalancerService type. Defaults to External.
+	// +optional
+	LoadBalancerScope IngressLoadBalancerScope `json:"loadBalancerScope,omitempty"`
+}
+
+// IngressNodePlacement specifies where the ingress controller pods are scheduled.
+type IngressNodePlacement struct {
+	// NodeSelector is the node selector applied to the ingress controller pods. If set, it replaces
+	// the default selector of linux worker nodes.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
+
+	// Tolerations is a list of tolerations applied to the ingress controller pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
 // ControlPlaneConfigSpec contains additional configuration settings for a target
@@ -650,6 +1186,37 @@ type CertificateBundleSpec struct {
 	// reference. Otherwise, it is expected that the secret should exist in the same namespace
 	// as the ClusterDeployment
 	CertificateSecretRef corev1.LocalObjectReference `json:"certificateSecretRef"`
+
+	// CertManager requests that the certificate bundle be issued by cert-manager. Hive creates a cert-manager
+	// Certificate which stores the issued certificate in the CertificateSecretRef secret, and pushes the certificate
+	// to the cluster again whenever cert-manager renews it.
+	// +optional
+	CertManager *CertManagerCertificate `json:"certManager,omitempty"`
+}
+
+// CertManagerCertificate specifies a certificate to be issued by cert-manager.
+type CertManagerCertificate struct {
+	// IssuerRef references the cert-manager issuer which issues the certificate.
+	IssuerRef CertManagerIssuerReference `json:"issuerRef"`
+
+	// DNSNames is the list of DNS names the certificate is issued for.
+	// +kubebuilder:validation:MinItems=1
+	DNSNames []string `json:"dnsNames"`
+}
+
+// CertManagerIssuerReference references a cert-manager issuer.
+type CertManagerIssuerReference struct {
+	// Name is the name of the issuer.
+	Name string `json:"name"`
+
+	// Kind is the kind of the issuer, e.g. Issuer or ClusterIssuer. An Issuer must be in the same namespace as
+	// the ClusterDeployment. Defaults to Issuer.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Group is the API group of the issuer. Defaults to cert-manager.io.
+	// +optional
+	Group string `json:"group,omitempty"`
 }
 
 // CertificateBundleStatus specifies whether a certificate bundle was generated for this
@@ -27,8 +27,60 @@ type ClusterDeprovisionStatus struct {
 	// Conditions includes more detailed status for the cluster deprovision
 	// +optional
 	Conditions []ClusterDeprovisionCondition `json:"conditions,omitempty"`
+
+	// TeardownHooks reports the progress of the teardown hooks of the ClusterDeployment, which are run before the
+	// uninstall job is started.
+	// +optional
+	TeardownHooks []TeardownHookStatus `json:"teardownHooks,omitempty"`
+
+	// ObservedGeneration is the generation of the cluster deprovision which was last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// TeardownHookStatus is the progress of a teardown hook.
+type TeardownHookStatus struct {
+	// Name is the name of the teardown hook.
+	Name string `json:"name"`
+
+	// State is Running while the hook is awaited, and Succeeded, Failed, TimedOut or Skipped once it no longer holds
+	// back the deprovision.
+	State TeardownHookState `json:"state"`
+
+	// StartTime is when the resources of the hook were first applied to the cluster.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the hook stopped holding back the deprovision.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message is a human-readable explanation of the state, such as the Job which failed.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
+// TeardownHookState is the state of a teardown hook.
+type TeardownHookState string
+
+const (
+	// RunningTeardownHookState is the state of a hook whose resources are being applied or awaited.
+	RunningTeardownHookState TeardownHookState = "Running"
+
+	// SucceededTeardownHookState is the state of a hook whose resources were applied and whose Jobs succeeded.
+	SucceededTeardownHookState TeardownHookState = "Succeeded"
+
+	// FailedTeardownHookState is the state of a hook one of whose Jobs failed.
+	FailedTeardownHookState TeardownHookState = "Failed"
+
+	// TimedOutTeardownHookState is the state of a hook which did not complete within its timeout.
+	TimedOutTeardownHookState TeardownHookState = "TimedOut"
+
+	// SkippedTeardownHookState is the state of a hook which was not run, for example because the cluster is
+	// hibernating.
+	SkippedTeardownHookState TeardownHookState = "Skipped"
+)
+
 // ClusterDeprovisionPlatform contains platform-specific configuration for the
 // deprovision
 type ClusterDeprovisionPlatform struct {
@@ -70,6 +122,13 @@ type AzureClusterDeprovision struct {
 	// If empty, the value is equal to "AzurePublicCloud".
 	// +optional
 	CloudName *azure.CloudEnvironment `json:"cloudName,omitempty"`
+	// ResourceGroupName is the name of the resource group holding the resources of the cluster.
+	// If empty, the resource group named after the infra ID is used.
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+	// BaseDomainResourceGroupName is the name of the resource group holding the DNS zone for the base domain.
+	// +optional
+	BaseDomainResourceGroupName string `json:"baseDomainResourceGroupName,omitempty"`
 }
 
 // GCPClusterDeprovision contains GCP-specific configuration for a ClusterDeprovision
@@ -153,6 +212,22 @@ type ClusterDeprovisionCondition struct {
 	// Message is a human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// ObservedGeneration is the generation of the cluster deprovision when the condition was set.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ToCondition returns the cluster deprovision condition as a metav1.Condition, with the generation of the
+// ClusterDeprovision it was set for.
+func (c *ClusterDeprovisionCondition) ToCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(c.Type),
+		Status:             metav1.ConditionStatus(c.Status),
+		ObservedGeneration: c.ObservedGeneration,
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             c.Reason,
+		Message:            c.Message,
+	}
 }
 
 // ClusterDeprovisionConditionType is a valid value for ClusterDeprovisionCondition.Type
@@ -164,6 +239,9 @@ const (
 
 	// DeprovisionFailedClusterDeprovisionCondition is true when deprovision attempt failed
 	DeprovisionFailedClusterDeprovisionCondition ClusterDeprovisionConditionType = "DeprovisionFailed"
+
+	// ReadyClusterDeprovisionCondition is true when the uninstall of the cluster has completed.
+	ReadyClusterDeprovisionCondition ClusterDeprovisionConditionType = "Ready"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -27,6 +27,18 @@ type ClusterInstallCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
+// ToCondition returns the condition of a ClusterInstall implementation, such as an AgentClusterInstall, as a
+// metav1.Condition.
+func (c *ClusterInstallCondition) ToCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(c.Type),
+		Status:             metav1.ConditionStatus(c.Status),
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             c.Reason,
+		Message:            c.Message,
+	}
+}
+
 const (
 	// ClusterInstallRequirementsMet is True when all pre-install requirements have been met.
 	ClusterInstallRequirementsMet = "RequirementsMet"
@@ -63,6 +63,12 @@ type ClusterPoolSpec struct {
 	// +optional
 	InstallConfigSecretTemplateRef *corev1.LocalObjectReference `json:"installConfigSecretTemplateRef,omitempty"`
 
+	// HostedCluster, when set, makes the clusters of the pool HyperShift hosted clusters. Each ClusterDeployment is
+	// installed by a HostedClusterInstall with this configuration instead of by the installer. Hosted clusters are
+	// not hibernated, so RunningCount is ignored, and InstallConfigSecretTemplateRef cannot be set.
+	// +optional
+	HostedCluster *HostedClusterConfig `json:"hostedCluster,omitempty"`
+
 	// HibernateAfter will be applied to new ClusterDeployments created for the pool. HibernateAfter will transition
 	// clusters in the clusterpool to hibernating power state after it has been running for the given duration. The time
 	// that a cluster has been running is the time since the cluster was installed or the time since the cluster last came
@@ -84,8 +90,56 @@ type ClusterPoolSpec struct {
 	// ClaimLifetime defines the lifetimes for claims for the cluster pool.
 	// +optional
 	ClaimLifetime *ClusterPoolClaimLifetime `json:"claimLifetime,omitempty"`
+
+	// NamespaceTemplate is a Go template for the prefix of the names of the namespaces created for the clusters in the
+	// pool. The namespace name is also used as the name of the ClusterDeployment. The template may refer to
+	// {{.PoolName}} and {{.PoolNamespace}}. A random suffix is always appended to the rendered prefix so that names do
+	// not collide. Defaults to {{.PoolName}}.
+	// Clusters are created before they are claimed, so the name cannot refer to the claim. Instead, the namespace of a
+	// claimed cluster is labeled with the name and namespace of the claim and with the labels of the claim.
+	// +optional
+	NamespaceTemplate string `json:"namespaceTemplate,omitempty"`
+
+	// BrokenClusterTimeout is how long an unclaimed cluster in the pool may fail to resume, fail to hibernate, wait
+	// for its ClusterOperators to become ready, or be unreachable while running before it is considered broken.
+	// Broken clusters are never assigned to claims; they are deleted and replaced. Defaults to 1h.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Format=duration
+	BrokenClusterTimeout *metav1.Duration `json:"brokenClusterTimeout,omitempty"`
+
+	// ClaimReleaseHooks are run against a claimed cluster of the pool once its claim is deleted, before the cluster is
+	// destroyed, for example to clean up what the user of the claim left behind. They are added to the TeardownHooks of
+	// the ClusterDeployment when it is deleted, after any hooks of the same name already there, so the cluster is only
+	// deprovisioned once they have succeeded, failed or timed out.
+	// With the Reuse ReleasePolicy, they are instead run against the cluster to sanitize it before it is returned to
+	// the pool.
+	// +optional
+	ClaimReleaseHooks []TeardownHook `json:"claimReleaseHooks,omitempty"`
+
+	// ReleasePolicy is what happens to a claimed cluster of the pool once its claim is deleted. Defaults to Destroy.
+	// Reuse is experimental, and meant for platforms such as vSphere and bare metal where provisioning a replacement
+	// cluster is slow.
+	// +optional
+	ReleasePolicy ClusterPoolReleasePolicy `json:"releasePolicy,omitempty"`
 }
 
+// ClusterPoolReleasePolicy is what happens to a claimed cluster of a pool once its claim is deleted.
+// +kubebuilder:validation:Enum="";Destroy;Reuse
+type ClusterPoolReleasePolicy string
+
+const (
+	// DestroyClusterPoolReleasePolicy deprovisions released clusters, after running the claim release hooks of the
+	// pool. The pool replaces them with newly provisioned clusters.
+	DestroyClusterPoolReleasePolicy ClusterPoolReleasePolicy = "Destroy"
+	// ReuseClusterPoolReleasePolicy sanitizes released clusters and returns them to the pool as unclaimed clusters.
+	// The claim release hooks of the pool, which are expected to wipe what the user of the claim left behind, are run
+	// against the cluster, the kubeadmin password is rotated, and the SyncSets of the cluster are reapplied. Clusters
+	// which are not running, or whose hooks do not all succeed, are destroyed instead.
+	ReuseClusterPoolReleasePolicy ClusterPoolReleasePolicy = "Reuse"
+)
+
 // ClusterPoolClaimLifetime defines the lifetimes for claims for the cluster pool.
 type ClusterPoolClaimLifetime struct {
 	// Default is the default lifetime of the claim when no lifetime is set on the claim itself.
@@ -116,6 +170,10 @@ type ClusterPoolStatus struct {
 	// Conditions includes more detailed status for the cluster pool
 	// +optional
 	Conditions []ClusterPoolCondition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation of the cluster pool which was last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // ClusterPoolCondition contains details for the current condition of a cluster pool
@@ -136,6 +194,22 @@ type ClusterPoolCondition struct {
 	// Message is a human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// ObservedGeneration is the generation of the cluster pool when the condition was set.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ToCondition returns the cluster pool condition as a metav1.Condition, with the generation of the pool it was
+// last evaluated for.
+func (c *ClusterPoolCondition) ToCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(c.Type),
+		Status:             metav1.ConditionStatus(c.Status),
+		ObservedGeneration: c.ObservedGeneration,
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             c.Reason,
+		Message:            c.Message,
+	}
 }
 
 // ClusterPoolConditionType is a valid value for ClusterPoolCondition.Type
@@ -151,6 +225,12 @@ const (
 	// ClusterPoolAllClustersCurrentCondition indicates whether all unassigned (installing or ready)
 	// ClusterDeployments in the pool match the current configuration of the ClusterPool.
 	ClusterPoolAllClustersCurrentCondition ClusterPoolConditionType = "AllClustersCurrent"
+	// ClusterPoolCredentialsCurrentCondition indicates whether the copies of the pool's cloud credentials held by all
+	// of the ClusterDeployments in the pool, claimed or not, match the pool's credentials Secret.
+	ClusterPoolCredentialsCurrentCondition ClusterPoolConditionType = "CredentialsCurrent"
+	// ClusterPoolReadyCondition is true when the pool has as many clusters ready to be claimed as its size, and none
+	// of its dependencies is missing.
+	ClusterPoolReadyCondition ClusterPoolConditionType = "Ready"
 )
 
 // +genclient
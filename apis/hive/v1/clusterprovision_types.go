@@ -39,6 +39,14 @@ type ClusterProvisionSpec struct {
 	// AdminPasswordSecretRef references the secret containing the admin username/password which can be used to login to this cluster.
 	AdminPasswordSecretRef *corev1.LocalObjectReference `json:"adminPasswordSecretRef,omitempty"`
 
+	// PlatformStatus contains the platform resources used by the cluster, captured after a successful installation.
+	// +optional
+	PlatformStatus *PlatformStatus `json:"platformStatus,omitempty"`
+
+	// ImageBasedInstallProgress reports how far the reconfiguration of the host of an image-based install has got.
+	// +optional
+	ImageBasedInstallProgress *ImageBasedInstallProgress `json:"imageBasedInstallProgress,omitempty"`
+
 	// PrevClusterID is the cluster ID of the previous failed provision attempt.
 	PrevClusterID *string `json:"prevClusterID,omitempty"`
 
@@ -57,6 +65,10 @@ type ClusterProvisionStatus struct {
 	// Conditions includes more detailed status for the cluster provision
 	// +optional
 	Conditions []ClusterProvisionCondition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation of the cluster provision which was last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // ClusterProvisionStage is the stage of provisioning.
@@ -73,6 +85,31 @@ const (
 	ClusterProvisionStageFailed ClusterProvisionStage = "failed"
 )
 
+// ImageBasedInstallProgress is the progress of the image-based install of a cluster.
+type ImageBasedInstallProgress struct {
+	// Stage is the stage the image-based install has reached.
+	Stage ImageBasedInstallStage `json:"stage"`
+	// Message is a human-readable message with details about the stage.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// LastTransitionTime is the last time the stage or message changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// ImageBasedInstallStage is a stage of an image-based install.
+type ImageBasedInstallStage string
+
+const (
+	// ImageBasedInstallStageConfigImageCreated indicates that the configuration image of the cluster has been created.
+	ImageBasedInstallStageConfigImageCreated ImageBasedInstallStage = "ConfigImageCreated"
+	// ImageBasedInstallStageReimaging indicates that the configuration image has been attached to the host, which is
+	// reconfiguring itself from its seed image into the cluster.
+	ImageBasedInstallStageReimaging ImageBasedInstallStage = "Reimaging"
+	// ImageBasedInstallStageComplete indicates that the cluster is available.
+	ImageBasedInstallStageComplete ImageBasedInstallStage = "Complete"
+)
+
 // ClusterProvisionCondition contains details for the current condition of a cluster provision
 type ClusterProvisionCondition struct {
 	// Type is the type of the condition.
@@ -91,6 +128,22 @@ type ClusterProvisionCondition struct {
 	// Message is a human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// ObservedGeneration is the generation of the cluster provision when the condition was set.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ToCondition returns the cluster provision condition as a metav1.Condition, with the generation of the
+// ClusterProvision it was set for.
+func (c *ClusterProvisionCondition) ToCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(c.Type),
+		Status:             metav1.ConditionStatus(c.Status),
+		ObservedGeneration: c.ObservedGeneration,
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             c.Reason,
+		Message:            c.Message,
+	}
 }
 
 // ClusterProvisionConditionType is a valid value for ClusterProvisionCondition.Type
@@ -111,6 +164,10 @@ const (
 
 	// InstallPodStuckCondition is set when the install pod is stuck
 	InstallPodStuckCondition ClusterProvisionConditionType = "InstallPodStuck"
+
+	// ClusterProvisionReadyCondition is true when the cluster provision has completed. Its reason is the stage of
+	// the provision until then.
+	ClusterProvisionReadyCondition ClusterProvisionConditionType = "Ready"
 )
 
 // +genclient
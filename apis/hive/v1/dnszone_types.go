@@ -70,6 +70,12 @@ type AWSDNSZoneSpec struct {
 	// For AWS China, use cn-northwest-1.
 	// +optional
 	Region string `json:"region,omitempty"`
+
+	// ZoneID is the ID of an existing route53 hosted zone to use for the DNSZone instead of creating one, e.g. a zone
+	// created by a central DNS team. Hive does not tag, clean up or delete an existing hosted zone, and only manages
+	// the delegation to it from the parent domain. The zone ID cannot be changed.
+	// +optional
+	ZoneID string `json:"zoneID,omitempty"`
 }
 
 // AWSResourceTag represents a tag that is applied to an AWS cloud resource
@@ -118,6 +124,11 @@ type DNSZoneStatus struct {
 	// +optional
 	LastSyncGeneration int64 `json:"lastSyncGeneration,omitempty"`
 
+	// NextSyncTimestamp is the time that the zone will next be sync'd, unless the zone resource changes first.
+	// Zones which are found unchanged are sync'd less and less often, to limit the calls to the DNS provider.
+	// +optional
+	NextSyncTimestamp *metav1.Time `json:"nextSyncTimestamp,omitempty"`
+
 	// NameServers is a list of nameservers for this DNS zone
 	// +optional
 	NameServers []string `json:"nameServers,omitempty"`
@@ -136,6 +147,10 @@ type DNSZoneStatus struct {
 	// Conditions includes more detailed status for the DNSZone
 	// +optional
 	Conditions []DNSZoneCondition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation of the DNSZone which was last reconciled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
 // AWSDNSZoneStatus contains status information specific to AWS DNS zones
@@ -174,6 +189,22 @@ type DNSZoneCondition struct {
 	// Message is a human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// ObservedGeneration is the generation of the DNSZone when the condition was set.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ToCondition returns the DNS zone condition as a metav1.Condition, with the generation of the DNSZone which was
+// last reconciled.
+func (c *DNSZoneCondition) ToCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(c.Type),
+		Status:             metav1.ConditionStatus(c.Status),
+		ObservedGeneration: c.ObservedGeneration,
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             c.Reason,
+		Message:            c.Message,
+	}
 }
 
 // DNSZoneConditionType is a valid value for DNSZoneCondition.Type
@@ -199,6 +230,12 @@ const (
 	// GenericDNSErrorsCondition is true when there's some DNS Zone related error that isn't related to
 	// authentication or credentials, and needs to be bubbled up to ClusterDeployment
 	GenericDNSErrorsCondition DNSZoneConditionType = "DNSError"
+	// DeletionBlockedDNSZoneCondition is true when the DNSZone has been waiting on its finalizers to be deleted for
+	// longer than the deletion blocked timeout of HiveConfig.
+	DeletionBlockedDNSZoneCondition DNSZoneConditionType = "DeletionBlocked"
+	// ReadyDNSZoneCondition is true when the zone is available in the cloud provider and none of the error conditions
+	// of the DNSZone is set.
+	ReadyDNSZoneCondition DNSZoneConditionType = "Ready"
 )
 
 // +genclient
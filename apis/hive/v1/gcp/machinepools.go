@@ -13,6 +13,54 @@ type MachinePool struct {
 	//
 	// +optional
 	OSDisk OSDisk `json:"osDisk"`
+
+	// OSImage is the image to create the machines from, such as a golden image. When not set, the image of the
+	// master machines is used.
+	//
+	// +optional
+	OSImage *OSImage `json:"osImage,omitempty"`
+
+	// Preemptible makes the instances of the pool preemptible VMs, which cost less but may be stopped by GCP at any
+	// time, and are stopped after 24 hours at the latest. Requires a cluster version of 4.6 or later. Cannot be set
+	// together with provisioningModel.
+	//
+	// +optional
+	Preemptible bool `json:"preemptible,omitempty"`
+
+	// ProvisioningModel is the provisioning model of the instances of the pool. Spot VMs cost less but may be stopped
+	// by GCP at any time, without the 24 hour limit of preemptible VMs. Requires a cluster version of 4.14 or later.
+	// Cannot be set together with preemptible. Defaults to standard VMs.
+	//
+	// +optional
+	ProvisioningModel *ProvisioningModel `json:"provisioningModel,omitempty"`
+}
+
+// ProvisioningModel is a provisioning model of GCP instances.
+// +kubebuilder:validation:Enum=Spot
+type ProvisioningModel string
+
+const (
+	// SpotProvisioningModel provisions instances as Spot VMs.
+	SpotProvisioningModel ProvisioningModel = "Spot"
+)
+
+// OSImage is a GCP compute image, identified either by its name or by an image family whose latest image is used.
+type OSImage struct {
+	// Project is the ID of the project containing the image. Defaults to the project of the cluster.
+	//
+	// +optional
+	Project string `json:"project,omitempty"`
+
+	// Name is the name of the image. Exactly one of name and family must be set.
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Family is the image family whose latest image is used when a machine is created. Exactly one of name and
+	// family must be set.
+	//
+	// +optional
+	Family string `json:"family,omitempty"`
 }
 
 // OSDisk defines the disk for machines on GCP.
@@ -13,4 +13,44 @@ type Platform struct {
 
 	// Region specifies the GCP region where the cluster will be created.
 	Region string `json:"region"`
+
+	// Egress configures an existing Cloud NAT through which the cluster reaches the internet.
+	// +optional
+	Egress *Egress `json:"egress,omitempty"`
+}
+
+// Egress configures an existing Cloud NAT for the egress traffic of a cluster.
+type Egress struct {
+	// CloudNATRouter is the name of an existing Cloud Router, in the region of the cluster, whose Cloud NAT gateways
+	// translate the egress traffic of the subnetworks of the existing network of the install config.
+	CloudNATRouter string `json:"cloudNATRouter"`
+}
+
+// PlatformStatus contains the observed state on GCP platform.
+type PlatformStatus struct {
+	// Network is the name of the VPC network used by the cluster.
+	// +optional
+	Network string `json:"network,omitempty"`
+
+	// Subnetworks are the names of the subnetworks used by the cluster.
+	// +optional
+	Subnetworks []string `json:"subnetworks,omitempty"`
+
+	// ServiceAccounts are the emails of the service accounts used by the cluster machines.
+	// +optional
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+
+	// CloudNATRouter is the name of the Cloud Router translating the egress traffic of the cluster, whether created by
+	// the installer or brought by the user.
+	// +optional
+	CloudNATRouter string `json:"cloudNATRouter,omitempty"`
+
+	// CloudNATs are the names of the Cloud NAT gateways of the Cloud Router.
+	// +optional
+	CloudNATs []string `json:"cloudNATs,omitempty"`
+
+	// NATIPs are the names of the static external IP addresses of the Cloud NAT gateways. Gateways which allocate their
+	// IP addresses automatically have none.
+	// +optional
+	NATIPs []string `json:"natIPs,omitempty"`
 }
@@ -4,6 +4,22 @@
 
 package gcp
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Egress) DeepCopyInto(out *Egress) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Egress.
+func (in *Egress) DeepCopy() *Egress {
+	if in == nil {
+		return nil
+	}
+	out := new(Egress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EncryptionKeyReference) DeepCopyInto(out *EncryptionKeyReference) {
 	*out = *in
@@ -50,6 +66,16 @@ func (in *MachinePool) DeepCopyInto(out *MachinePool) {
 		copy(*out, *in)
 	}
 	in.OSDisk.DeepCopyInto(&out.OSDisk)
+	if in.OSImage != nil {
+		in, out := &in.OSImage, &out.OSImage
+		*out = new(OSImage)
+		**out = **in
+	}
+	if in.ProvisioningModel != nil {
+		in, out := &in.ProvisioningModel, &out.ProvisioningModel
+		*out = new(ProvisioningModel)
+		**out = **in
+	}
 	return
 }
 
@@ -100,10 +126,31 @@ func (in *OSDisk) DeepCopy() *OSDisk {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OSImage) DeepCopyInto(out *OSImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OSImage.
+func (in *OSImage) DeepCopy() *OSImage {
+	if in == nil {
+		return nil
+	}
+	out := new(OSImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Platform) DeepCopyInto(out *Platform) {
 	*out = *in
 	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = new(Egress)
+		**out = **in
+	}
 	return
 }
 
@@ -116,3 +163,39 @@ func (in *Platform) DeepCopy() *Platform {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
+	*out = *in
+	if in.Subnetworks != nil {
+		in, out := &in.Subnetworks, &out.Subnetworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CloudNATs != nil {
+		in, out := &in.CloudNATs, &out.CloudNATs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NATIPs != nil {
+		in, out := &in.NATIPs, &out.NATIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
+func (in *PlatformStatus) DeepCopy() *PlatformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -144,6 +144,299 @@ type HiveConfigSpec struct {
 	// extract metrics. The operator also sets up RBAC in the TargetNamespace so that openshift
 	// prometheus in the cluster can list/access objects required to pull metrics.
 	ExportMetrics bool `json:"exportMetrics,omitempty"`
+
+	// CostEstimation enables estimation of the hourly cost of each ClusterDeployment from its MachinePools and
+	// control plane, using the configured pricing table. Estimates are reported in ClusterDeployment status and
+	// as metrics. If not specified, cost estimation is disabled.
+	// +optional
+	CostEstimation *CostEstimationConfig `json:"costEstimation,omitempty"`
+
+	// Notifications configures webhooks which are notified of ClusterDeployment lifecycle events, so that external
+	// systems do not need to poll the API. If not specified, no notifications are sent.
+	// +optional
+	Notifications *NotificationsConfig `json:"notifications,omitempty"`
+
+	// ExternalSecrets configures the external secret provider from which cloud credentials are resolved. A
+	// credentials secret referenced by a ClusterDeployment may hold the path of the credentials in the provider,
+	// in the hive.openshift.io/external-secret-path annotation, instead of the credentials themselves.
+	// +optional
+	ExternalSecrets *ExternalSecretsConfig `json:"externalSecrets,omitempty"`
+
+	// CredentialsBroker configures Hive to mint short-lived credentials for each provision and deprovision job,
+	// and to inject only those into the job, rather than giving the job the long-lived credentials they are
+	// minted from. If not specified, jobs are given the credentials configured for the cluster.
+	// +optional
+	CredentialsBroker *CredentialsBrokerConfig `json:"credentialsBroker,omitempty"`
+
+	// InstallManagerImageOverrides overrides the images used by the install pods of clusters of specific OpenShift
+	// minor versions, so that fixes for a given installer version can be rolled out without upgrading Hive.
+	// +optional
+	InstallManagerImageOverrides []InstallManagerImageOverride `json:"installManagerImageOverrides,omitempty"`
+
+	// ExecutionCluster configures Hive to run install and uninstall jobs on an external execution cluster rather
+	// than on the hub, for hubs which cannot reach the clouds of their clusters or lack the capacity to run the jobs.
+	// If not specified, jobs are run on the hub.
+	// +optional
+	ExecutionCluster *ExecutionClusterConfig `json:"executionCluster,omitempty"`
+
+	// GarbageCollection enables the periodic deletion of orphaned Hive artifacts: install and uninstall pods whose
+	// jobs are gone, secrets of ClusterProvisions which are gone, MachinePoolNameLeases whose MachinePools are gone,
+	// and child DNSZones whose ClusterDeployments are gone. If not specified, orphaned artifacts are not collected.
+	// +optional
+	GarbageCollection *GarbageCollectionConfig `json:"garbageCollection,omitempty"`
+
+	// SpokeStatus enables a "hive" ClusterOperator on each installed cluster which reports the hub managing the
+	// cluster, when its SyncSets were last applied successfully, and when the hub last contacted it, so that the
+	// admins of the cluster can tell which hub manages it. If not specified, no ClusterOperator is created.
+	// +optional
+	SpokeStatus *SpokeStatusConfig `json:"spokeStatus,omitempty"`
+
+	// DeletionBlockedTimeout is how long ClusterDeployments, MachinePools and DNSZones may wait on their finalizers
+	// while being deleted before they are reported with a DeletionBlocked condition and in the
+	// hive_deletion_blocked_objects metric. Defaults to 1h.
+	// +optional
+	DeletionBlockedTimeout *metav1.Duration `json:"deletionBlockedTimeout,omitempty"`
+
+	// LogArchive configures Hive to archive the logs of install and uninstall pods once their jobs finish, so that
+	// they can be retrieved after the pods are gone. If not specified, the logs are not archived.
+	// +optional
+	LogArchive *LogArchiveConfig `json:"logArchive,omitempty"`
+
+	// SensitiveArtifacts configures Hive to delete or encrypt the sensitive intermediate artifacts of an installation
+	// once the cluster is installed: the install-config secret, which may embed credentials, and the admin kubeconfig
+	// and password secrets of earlier, failed provisions. If not specified, the artifacts are kept as they are.
+	// +optional
+	SensitiveArtifacts *SensitiveArtifactsConfig `json:"sensitiveArtifacts,omitempty"`
+
+	// AdminSecretEncryption configures Hive to envelope-encrypt the admin kubeconfig and password secrets of installed
+	// clusters, for hubs which must not store the credentials of their clusters in plain secrets. Hive decrypts the
+	// secrets transparently when it connects to the clusters. If not specified, the secrets are not encrypted.
+	// +optional
+	AdminSecretEncryption *AdminSecretEncryptionConfig `json:"adminSecretEncryption,omitempty"`
+
+	// AWSClient configures how the controllers retry and time out calls to the AWS APIs. If not specified, failed
+	// calls are retried with the standard retry mode, and calls time out after 2m.
+	// +optional
+	AWSClient *AWSClientConfig `json:"awsClient,omitempty"`
+
+	// MachinePoolDefaults are applied to the MachineSets Hive generates for every MachinePool, for policies which
+	// would otherwise have to be repeated in every MachinePool, such as a label exempting nodes from monitoring.
+	// If not specified, the MachineSets only carry the settings of their MachinePools.
+	// +optional
+	MachinePoolDefaults *MachinePoolDefaults `json:"machinePoolDefaults,omitempty"`
+
+	// ClusterAccessTokens configures which access to the clusters ClusterAccessTokens may request. If not specified,
+	// ClusterAccessTokens may only bind the "view" ClusterRole.
+	// +optional
+	ClusterAccessTokens *ClusterAccessTokensConfig `json:"clusterAccessTokens,omitempty"`
+}
+
+// ClusterAccessTokensConfig contains settings for the ClusterAccessTokens of the clusters managed by the hub.
+type ClusterAccessTokensConfig struct {
+	// AllowedClusterRoles are the ClusterRoles of the clusters which ClusterAccessTokens may bind. ClusterAccessTokens
+	// for other ClusterRoles are rejected, so that creating a ClusterAccessToken for a cluster does not grant more
+	// access to the cluster than the hub admins allow.
+	// +kubebuilder:validation:MinItems=1
+	AllowedClusterRoles []string `json:"allowedClusterRoles"`
+}
+
+// MachinePoolDefaults contains settings applied to the MachineSets of every MachinePool. The settings of a
+// MachinePool and of its ClusterDeployment take precedence over the defaults.
+type MachinePoolDefaults struct {
+	// Labels are added to the labels of the machines of every MachinePool. A label of the MachinePool takes
+	// precedence over the default label with the same key.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Taints are appended to the taints of the machines of every MachinePool. A default taint is skipped for a
+	// MachinePool which has a taint with the same key and effect.
+	// +optional
+	Taints []corev1.Taint `json:"taints,omitempty"`
+
+	// UserTags are added to the tags of the machines of every MachinePool of AWS clusters. A userTag of the
+	// ClusterDeployment takes precedence over the default tag with the same key.
+	// +optional
+	UserTags map[string]string `json:"userTags,omitempty"`
+}
+
+// AWSRetryMode is how calls to the AWS APIs are retried.
+// +kubebuilder:validation:Enum=Standard;Adaptive
+type AWSRetryMode string
+
+const (
+	// StandardAWSRetryMode retries failed calls with exponential backoff.
+	StandardAWSRetryMode AWSRetryMode = "Standard"
+	// AdaptiveAWSRetryMode retries failed calls like the standard mode, and additionally limits the rate of calls to
+	// each service in each region while the service throttles them.
+	AdaptiveAWSRetryMode AWSRetryMode = "Adaptive"
+)
+
+// AWSClientConfig contains settings for the calls of the controllers to the AWS APIs.
+type AWSClientConfig struct {
+	// RetryMode is how failed calls are retried. Defaults to Standard.
+	// +optional
+	RetryMode AWSRetryMode `json:"retryMode,omitempty"`
+
+	// MaxAttempts is how many times a call is attempted, including its retries. Defaults to 4.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAttempts *int `json:"maxAttempts,omitempty"`
+
+	// CallTimeout is how long a call, including its retries, may take before it is abandoned, so that reconciles are
+	// not blocked by unresponsive or throttling APIs. Defaults to 2m.
+	// +optional
+	CallTimeout *metav1.Duration `json:"callTimeout,omitempty"`
+}
+
+// AdminSecretEncryptionConfig contains settings for the encryption of the admin secrets of clusters.
+type AdminSecretEncryptionConfig struct {
+	// KMS configures the key management service which encrypts the data keys of the secrets.
+	KMS KMSConfig `json:"kms"`
+}
+
+// SensitiveArtifactsPolicy is what Hive does with the sensitive artifacts of an installation once the cluster is
+// installed.
+// +kubebuilder:validation:Enum=Delete;Encrypt
+type SensitiveArtifactsPolicy string
+
+const (
+	// DeleteSensitiveArtifactsPolicy deletes the sensitive artifacts.
+	DeleteSensitiveArtifactsPolicy SensitiveArtifactsPolicy = "Delete"
+	// EncryptSensitiveArtifactsPolicy replaces the data of the sensitive artifacts with copies envelope-encrypted by
+	// the configured KMS provider.
+	EncryptSensitiveArtifactsPolicy SensitiveArtifactsPolicy = "Encrypt"
+)
+
+// SensitiveArtifactsConfig contains settings for the handling of the sensitive artifacts of installations.
+type SensitiveArtifactsConfig struct {
+	// Policy is what Hive does with the sensitive artifacts once the cluster is installed.
+	Policy SensitiveArtifactsPolicy `json:"policy"`
+
+	// KMS configures the KMS provider which envelope-encrypts the artifacts. It is required by the Encrypt policy.
+	// +optional
+	KMS *KMSConfig `json:"kms,omitempty"`
+}
+
+// KMSConfig contains settings for a key management service. Exactly one of its providers must be configured.
+type KMSConfig struct {
+	// AWS configures AWS KMS as the key management service.
+	// +optional
+	AWS *AWSKMSConfig `json:"aws,omitempty"`
+
+	// GCP configures Google Cloud KMS as the key management service.
+	// +optional
+	GCP *GCPKMSConfig `json:"gcp,omitempty"`
+
+	// Azure configures Azure Key Vault as the key management service.
+	// +optional
+	Azure *AzureKeyVaultConfig `json:"azure,omitempty"`
+
+	// Local configures an AES key held in a secret on the hub, for hubs without a key management service.
+	// +optional
+	Local *LocalKeyConfig `json:"local,omitempty"`
+}
+
+// AWSKMSConfig contains settings for AWS KMS.
+type AWSKMSConfig struct {
+	// KeyID is the ID, ARN or alias of the KMS key which encrypts the data keys.
+	// +kubebuilder:validation:MinLength=1
+	KeyID string `json:"keyID"`
+
+	// Region is the AWS region of the key. Defaults to us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// CredentialsSecretRef references a secret in the namespace of Hive whose aws_access_key_id and
+	// aws_secret_access_key keys hold the AWS credentials used to encrypt and decrypt data keys with the KMS key.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// GCPKMSConfig contains settings for Google Cloud KMS.
+type GCPKMSConfig struct {
+	// KeyName is the resource name of the key, in the form
+	// projects/<project>/locations/<location>/keyRings/<key ring>/cryptoKeys/<key>.
+	// +kubebuilder:validation:MinLength=1
+	KeyName string `json:"keyName"`
+
+	// CredentialsSecretRef references a secret in the namespace of Hive whose osServiceAccount.json key holds the
+	// service account used to encrypt and decrypt data keys with the key.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+}
+
+// AzureKeyVaultConfig contains settings for Azure Key Vault.
+type AzureKeyVaultConfig struct {
+	// VaultURL is the URL of the vault, such as "https://myvault.vault.azure.net".
+	// +kubebuilder:validation:MinLength=1
+	VaultURL string `json:"vaultURL"`
+
+	// KeyName is the name of the RSA key in the vault which wraps the data keys.
+	// +kubebuilder:validation:MinLength=1
+	KeyName string `json:"keyName"`
+
+	// KeyVersion is the version of the key. Defaults to the current version of the key.
+	// +optional
+	KeyVersion string `json:"keyVersion,omitempty"`
+
+	// CredentialsSecretRef references a secret in the namespace of Hive whose osServicePrincipal.json key holds the
+	// service principal used to wrap and unwrap data keys with the key.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// CloudName is the name of the Azure cloud environment of the vault.
+	// If empty, the value is equal to "AzurePublicCloud".
+	// +optional
+	CloudName azure.CloudEnvironment `json:"cloudName,omitempty"`
+}
+
+// LocalKeyConfig contains settings for an AES key held in a secret on the hub.
+type LocalKeyConfig struct {
+	// SecretRef references a secret in the namespace of Hive whose "key" key holds a 32-byte AES key.
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
+// LogArchiveConfig contains settings for archiving the logs of install and uninstall pods.
+type LogArchiveConfig struct {
+	// AWS configures archiving the logs to an AWS S3 bucket.
+	// +optional
+	AWS *LogArchiveAWSConfig `json:"aws,omitempty"`
+
+	// RetentionDays is the number of days after which archived logs expire. Defaults to 30.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RetentionDays *int32 `json:"retentionDays,omitempty"`
+}
+
+// LogArchiveAWSConfig contains settings for archiving logs to AWS S3.
+type LogArchiveAWSConfig struct {
+	// CredentialsSecretRef references a secret in the namespace of Hive whose aws_access_key_id and
+	// aws_secret_access_key keys hold the AWS credentials used to write and sign URLs to the logs. They need
+	// permission to put objects in the bucket and to get and put its lifecycle configuration.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Region is the AWS region of the bucket. Defaults to us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Bucket is the S3 bucket to archive the logs in.
+	// +kubebuilder:validation:MinLength=1
+	Bucket string `json:"bucket"`
+}
+
+// SpokeStatusConfig contains settings for the "hive" ClusterOperator on the clusters managed by the hub.
+type SpokeStatusConfig struct {
+	// HubName identifies the hub to the admins of the clusters, such as the name or the console URL of the hub.
+	// +kubebuilder:validation:MinLength=1
+	HubName string `json:"hubName"`
+}
+
+// GarbageCollectionConfig contains settings for the garbage collection of orphaned Hive artifacts.
+type GarbageCollectionConfig struct {
+	// DryRun reports the orphaned artifacts in the logs and metrics of the garbage collector without deleting them.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Interval is the time between garbage collection runs. Defaults to 1h.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
 }
 
 // ReleaseImageVerificationConfigMapReference is a reference to the ConfigMap that
@@ -335,6 +628,18 @@ type HiveConfigCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
+// ToCondition returns the HiveConfig condition as a metav1.Condition. The generation of the HiveConfig which was
+// applied is HiveConfigStatus.ObservedGeneration.
+func (c *HiveConfigCondition) ToCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(c.Type),
+		Status:             metav1.ConditionStatus(c.Status),
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             c.Reason,
+		Message:            c.Message,
+	}
+}
+
 // HiveConfigConditionType is a valid value for HiveConfigCondition.Type
 type HiveConfigConditionType string
 
@@ -355,6 +660,179 @@ type ArgoCDConfig struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// CostEstimationConfig contains settings for the cost estimation of ClusterDeployments.
+type CostEstimationConfig struct {
+	// Currency is the currency of the prices in the pricing table. It is reported alongside each estimate.
+	// Defaults to "USD".
+	// +optional
+	Currency string `json:"currency,omitempty"`
+
+	// InstanceTypes is the pricing table used to estimate the cost of machines. Machines using instance types
+	// which are not in the table are not included in estimates.
+	InstanceTypes []InstanceTypePrice `json:"instanceTypes,omitempty"`
+
+	// ControlPlane describes the control plane assumed for every ClusterDeployment, as the shape of the control
+	// plane is not tracked by a MachinePool. If not specified, the control plane is not included in estimates.
+	// +optional
+	ControlPlane *ControlPlaneCostEstimation `json:"controlPlane,omitempty"`
+}
+
+// InstanceTypePrice is the price of an instance type.
+type InstanceTypePrice struct {
+	// InstanceType is the name of the instance type, e.g. "m5.xlarge". For OpenStack this is the flavor.
+	InstanceType string `json:"instanceType"`
+
+	// HourlyCost is the cost of running one instance of the instance type for an hour, as a decimal number.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	HourlyCost string `json:"hourlyCost"`
+}
+
+// ControlPlaneCostEstimation describes the control plane used for cost estimation.
+type ControlPlaneCostEstimation struct {
+	// InstanceType is the instance type of the control plane machines. It must be in the pricing table.
+	InstanceType string `json:"instanceType"`
+
+	// Replicas is the number of control plane machines. Defaults to 3.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// NotificationsConfig contains settings for the notification of ClusterDeployment lifecycle events.
+type NotificationsConfig struct {
+	// Sinks are the webhooks to notify.
+	Sinks []NotificationSink `json:"sinks,omitempty"`
+}
+
+// NotificationSinkFormat is the format of the payload POSTed to a notification sink.
+// +kubebuilder:validation:Enum=Generic;Slack
+type NotificationSinkFormat string
+
+const (
+	// GenericNotificationSinkFormat POSTs the event as a JSON object.
+	GenericNotificationSinkFormat NotificationSinkFormat = "Generic"
+	// SlackNotificationSinkFormat POSTs a Slack-compatible message describing the event, for use with Slack
+	// incoming webhooks.
+	SlackNotificationSinkFormat NotificationSinkFormat = "Slack"
+)
+
+// NotificationEventType is a type of ClusterDeployment lifecycle event.
+// +kubebuilder:validation:Enum=ProvisionSucceeded;ProvisionFailed;DeprovisionCompleted;ClaimAssigned;Hibernated;Running
+type NotificationEventType string
+
+const (
+	// ProvisionSucceededNotificationEvent is sent when a cluster has been installed.
+	ProvisionSucceededNotificationEvent NotificationEventType = "ProvisionSucceeded"
+	// ProvisionFailedNotificationEvent is sent when provisioning of a cluster has failed and will not be retried.
+	ProvisionFailedNotificationEvent NotificationEventType = "ProvisionFailed"
+	// DeprovisionCompletedNotificationEvent is sent when a cluster has been deprovisioned.
+	DeprovisionCompletedNotificationEvent NotificationEventType = "DeprovisionCompleted"
+	// ClaimAssignedNotificationEvent is sent when a cluster from a ClusterPool has been assigned to a ClusterClaim.
+	ClaimAssignedNotificationEvent NotificationEventType = "ClaimAssigned"
+	// HibernatedNotificationEvent is sent when the machines of a cluster have stopped for hibernation.
+	HibernatedNotificationEvent NotificationEventType = "Hibernated"
+	// RunningNotificationEvent is sent when a cluster has started and is ready, including after resuming from
+	// hibernation.
+	RunningNotificationEvent NotificationEventType = "Running"
+)
+
+// NotificationSink is a webhook notified of ClusterDeployment lifecycle events.
+type NotificationSink struct {
+	// Name identifies the sink in logs and metrics.
+	Name string `json:"name"`
+
+	// URL is the HTTPS URL to which the notifications are POSTed.
+	// +kubebuilder:validation:Pattern=`^https://`
+	URL string `json:"url"`
+
+	// Format is the format of the notification payload. Defaults to Generic.
+	// +optional
+	Format NotificationSinkFormat `json:"format,omitempty"`
+
+	// Events are the types of event to notify. If not specified, all events are notified.
+	// +optional
+	Events []NotificationEventType `json:"events,omitempty"`
+}
+
+// ExternalSecretsConfig contains settings for resolving credentials from an external secret provider.
+type ExternalSecretsConfig struct {
+	// PathPrefix is the path in the provider under which the credentials of each namespace are held. The
+	// hive.openshift.io/external-secret-path annotation of a credentials secret is a path relative to
+	// "<pathPrefix>/<namespace>", so that a namespace can only read its own credentials. For version 2 of the Vault KV
+	// secrets engine it includes "data", e.g. "secret/data/hive".
+	PathPrefix string `json:"pathPrefix"`
+
+	// Vault configures HashiCorp Vault as the external secret provider.
+	// +optional
+	Vault *VaultSecretProviderConfig `json:"vault,omitempty"`
+}
+
+// VaultSecretProviderConfig contains settings for reading secrets from HashiCorp Vault. Hive controllers and install
+// pods log in to Vault with their service account tokens using the Kubernetes auth method.
+type VaultSecretProviderConfig struct {
+	// Address is the URL of the Vault server, e.g. "https://vault.example.com:8200".
+	Address string `json:"address"`
+
+	// Role is the Vault role to log in as.
+	Role string `json:"role"`
+
+	// AuthMountPath is the path at which the Kubernetes auth method is mounted in Vault. Defaults to "kubernetes".
+	// +optional
+	AuthMountPath string `json:"authMountPath,omitempty"`
+}
+
+// CredentialsBrokerConfig contains settings for minting short-lived credentials for provision and deprovision jobs.
+type CredentialsBrokerConfig struct {
+	// AWS enables the credentials broker for AWS clusters which use credentialsAssumeRole.
+	// +optional
+	AWS *AWSCredentialsBrokerConfig `json:"aws,omitempty"`
+}
+
+// AWSCredentialsBrokerConfig contains settings for minting short-lived AWS credentials. Hive assumes the
+// credentialsAssumeRole of the cluster with its AWS service provider credentials, once per job, and gives the job the
+// resulting session credentials. The service provider credentials are then never copied to the namespace of the
+// cluster.
+type AWSCredentialsBrokerConfig struct {
+	// SessionDuration is the lifetime of the credentials minted for each job. It must not exceed the maximum session
+	// duration of the assumed roles, and should cover the expected duration of an install or uninstall. Defaults to
+	// one hour.
+	// +optional
+	SessionDuration *metav1.Duration `json:"sessionDuration,omitempty"`
+}
+
+// ExecutionClusterConfig contains settings for running install and uninstall jobs on an external execution cluster.
+// The jobs are created in a namespace of the execution cluster with the same name as the namespace of their
+// ClusterDeployment, along with copies of the secrets and configmaps they use.
+type ExecutionClusterConfig struct {
+	// KubeconfigSecretRef references the secret in the namespace of Hive whose "kubeconfig" key holds the kubeconfig
+	// used by Hive to manage jobs on the execution cluster.
+	KubeconfigSecretRef corev1.LocalObjectReference `json:"kubeconfigSecretRef"`
+
+	// HubKubeconfigSecretRef references the secret in the namespace of Hive whose "kubeconfig" key holds the
+	// kubeconfig used by install jobs to report their progress to the hub. It must grant the permissions of the
+	// service account used by install jobs on the hub.
+	HubKubeconfigSecretRef corev1.LocalObjectReference `json:"hubKubeconfigSecretRef"`
+}
+
+// InstallManagerImageOverride overrides the images used by the install pods of clusters of an OpenShift minor version.
+type InstallManagerImageOverride struct {
+	// OpenShiftVersion is the OpenShift minor version, such as "4.9", of the clusters whose install pods use the
+	// images. It is matched against the version of the release image resolved for the installation.
+	OpenShiftVersion string `json:"openShiftVersion"`
+
+	// InstallManagerImage is the image running the install manager, in place of the Hive image.
+	// +optional
+	InstallManagerImage string `json:"installManagerImage,omitempty"`
+
+	// CLIImage is the image providing the oc cli, in place of the cli image of the release image.
+	// +optional
+	CLIImage string `json:"cliImage,omitempty"`
+
+	// ImagePullPolicy is the pull policy of the images overridden above.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+}
+
 // BackupConfig contains settings for the Velero backup integration.
 type BackupConfig struct {
 	// Velero specifies configuration for the Velero backup integration.
@@ -367,6 +845,14 @@ type BackupConfig struct {
 	// backup happening once the interval has been completed.
 	// +optional
 	MinBackupPeriodSeconds *int `json:"minBackupPeriodSeconds,omitempty"`
+
+	// RepairAfterRestore enables the repair of ClusterDeployments restored by Velero into a hub: owner references to
+	// objects which were recreated with new UIDs are updated, finalizers whose controllers are disabled are removed,
+	// and ClusterSync leases are reset so that SyncSets are fully reapplied. Each ClusterDeployment is repaired once
+	// per restore. Individual ClusterDeployments can also be repaired with the "hive.openshift.io/repair-after-restore"
+	// annotation.
+	// +optional
+	RepairAfterRestore bool `json:"repairAfterRestore,omitempty"`
 }
 
 // VeleroBackupConfig contains settings for the Velero backup integration.
@@ -419,6 +905,38 @@ type ManageDNSConfig struct {
 	// As other cloud providers are supported, additional fields will be
 	// added for each of those cloud providers. Only a single cloud provider
 	// may be configured at a time.
+
+	// ChildZones configures the DNS provider hosting the DNSZones of clusters using the domains. By default, the
+	// DNSZone of a cluster is hosted on the platform of the cluster, using the credentials of the cluster.
+	// +optional
+	ChildZones *ManageDNSChildZonesConfig `json:"childZones,omitempty"`
+}
+
+// ManageDNSProvider is a DNS provider hosting managed domains.
+// +kubebuilder:validation:Enum=AWS;GCP;Azure
+type ManageDNSProvider string
+
+const (
+	// ManageDNSProviderAWS is AWS Route53.
+	ManageDNSProviderAWS ManageDNSProvider = "AWS"
+	// ManageDNSProviderGCP is GCP Cloud DNS.
+	ManageDNSProviderGCP ManageDNSProvider = "GCP"
+	// ManageDNSProviderAzure is Azure DNS.
+	ManageDNSProviderAzure ManageDNSProvider = "Azure"
+)
+
+// ManageDNSChildZonesConfig configures the DNS provider hosting the DNSZones of clusters using a managed domain,
+// regardless of the platform of the clusters. This allows e.g. an Azure cluster to use a base domain in Route53.
+type ManageDNSChildZonesConfig struct {
+	// Provider is the DNS provider hosting the DNSZones. The settings for the provider must be present in the
+	// parent ManageDNSConfig object, and are used for the DNSZones too.
+	Provider ManageDNSProvider `json:"provider"`
+
+	// CredentialsSecretRef references a secret in the TargetNamespace with the credentials used to manage the
+	// DNSZones, in the format expected for the provider. Hive copies the secret into the namespace of each cluster.
+	// If unset, the credentials configured for the provider in the parent ManageDNSConfig object are used.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
 }
 
 // FailedProvisionAWSConfig contains AWS-specific info to upload log files.
@@ -513,13 +1031,24 @@ type ControllerConfig struct {
 	// QueueBurst specifies workqueue rate limiter burst for a controller
 	// +optional
 	QueueBurst *int32 `json:"queueBurst,omitempty"`
+	// StartupRampDuration spreads the first reconciles of a controller after it starts over this window, so that the
+	// reconciles of all objects do not run at once when Hive restarts.
+	// +optional
+	StartupRampDuration *metav1.Duration `json:"startupRampDuration,omitempty"`
+	// UnreachableConcurrentReconciles limits how many of the concurrent reconciles of a controller can be spent on
+	// clusters known to be unreachable. Reconciles of unreachable clusters beyond this are deferred, so that healthy
+	// clusters are not starved when many clusters are unreachable at once. It only applies to controllers which connect
+	// to the managed clusters. Unreachable clusters are not limited by default.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	UnreachableConcurrentReconciles *int32 `json:"unreachableConcurrentReconciles,omitempty"`
 	// Replicas specifies the number of replicas the specific controller pod should use.
 	// This is ONLY for controllers that have been split out into their own pods.
 	// This is ignored for all others.
 	Replicas *int32 `json:"replicas,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;machinepool;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync
+// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;machinepool;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync;tenantquota;costestimation;remediation;kubeadminpassword;clusteraccesstoken;hostedclusterinstall;hostinventory;restorerepair;garbagecollection;spokestatus;deletionmonitor;logarchive;sensitiveartifacts;adminsecretencryption
 type ControllerName string
 
 func (controllerName ControllerName) String() string {
@@ -542,28 +1071,42 @@ func (c ControllerNames) Contains(controllerName ControllerName) bool {
 
 // WARNING: All the controller names below should also be added to the kubebuilder validation of the type ControllerName
 const (
-	ClusterClaimControllerName         ControllerName = "clusterclaim"
-	ClusterDeploymentControllerName    ControllerName = "clusterDeployment"
-	ClusterDeprovisionControllerName   ControllerName = "clusterDeprovision"
-	ClusterpoolControllerName          ControllerName = "clusterpool"
-	ClusterpoolNamespaceControllerName ControllerName = "clusterpoolnamespace"
-	ClusterProvisionControllerName     ControllerName = "clusterProvision"
-	ClusterRelocateControllerName      ControllerName = "clusterRelocate"
-	ClusterStateControllerName         ControllerName = "clusterState"
-	ClusterVersionControllerName       ControllerName = "clusterversion"
-	ControlPlaneCertsControllerName    ControllerName = "controlPlaneCerts"
-	DNSEndpointControllerName          ControllerName = "dnsendpoint"
-	DNSZoneControllerName              ControllerName = "dnszone"
-	FakeClusterInstallControllerName   ControllerName = "fakeclusterinstall"
-	HibernationControllerName          ControllerName = "hibernation"
-	RemoteIngressControllerName        ControllerName = "remoteingress"
-	SyncIdentityProviderControllerName ControllerName = "syncidentityprovider"
-	UnreachableControllerName          ControllerName = "unreachable"
-	VeleroBackupControllerName         ControllerName = "velerobackup"
-	MetricsControllerName              ControllerName = "metrics"
-	ClustersyncControllerName          ControllerName = "clustersync"
-	AWSPrivateLinkControllerName       ControllerName = "awsprivatelink"
-	HiveControllerName                 ControllerName = "hive"
+	ClusterClaimControllerName          ControllerName = "clusterclaim"
+	ClusterDeploymentControllerName     ControllerName = "clusterDeployment"
+	ClusterDeprovisionControllerName    ControllerName = "clusterDeprovision"
+	ClusterpoolControllerName           ControllerName = "clusterpool"
+	ClusterpoolNamespaceControllerName  ControllerName = "clusterpoolnamespace"
+	ClusterProvisionControllerName      ControllerName = "clusterProvision"
+	ClusterRelocateControllerName       ControllerName = "clusterRelocate"
+	ClusterStateControllerName          ControllerName = "clusterState"
+	ClusterVersionControllerName        ControllerName = "clusterversion"
+	ControlPlaneCertsControllerName     ControllerName = "controlPlaneCerts"
+	DNSEndpointControllerName           ControllerName = "dnsendpoint"
+	DNSZoneControllerName               ControllerName = "dnszone"
+	FakeClusterInstallControllerName    ControllerName = "fakeclusterinstall"
+	HibernationControllerName           ControllerName = "hibernation"
+	RemoteIngressControllerName         ControllerName = "remoteingress"
+	SyncIdentityProviderControllerName  ControllerName = "syncidentityprovider"
+	UnreachableControllerName           ControllerName = "unreachable"
+	VeleroBackupControllerName          ControllerName = "velerobackup"
+	MetricsControllerName               ControllerName = "metrics"
+	ClustersyncControllerName           ControllerName = "clustersync"
+	AWSPrivateLinkControllerName        ControllerName = "awsprivatelink"
+	HiveControllerName                  ControllerName = "hive"
+	TenantQuotaControllerName           ControllerName = "tenantquota"
+	CostEstimationControllerName        ControllerName = "costestimation"
+	RemediationControllerName           ControllerName = "remediation"
+	KubeadminPasswordControllerName     ControllerName = "kubeadminpassword"
+	ClusterAccessTokenControllerName    ControllerName = "clusteraccesstoken"
+	HostedClusterInstallControllerName  ControllerName = "hostedclusterinstall"
+	HostInventoryControllerName         ControllerName = "hostinventory"
+	RestoreRepairControllerName         ControllerName = "restorerepair"
+	GarbageCollectionControllerName     ControllerName = "garbagecollection"
+	SpokeStatusControllerName           ControllerName = "spokestatus"
+	DeletionMonitorControllerName       ControllerName = "deletionmonitor"
+	LogArchiveControllerName            ControllerName = "logarchive"
+	SensitiveArtifactsControllerName    ControllerName = "sensitiveartifacts"
+	AdminSecretEncryptionControllerName ControllerName = "adminsecretencryption"
 
 	// DeprecatedRemoteMachinesetControllerName was deprecated but can be used to disable the
 	// MachinePool controller which supercedes it for compatability.
@@ -587,6 +1130,7 @@ type ControllersConfig struct {
 	// default for client burst is 10
 	// default for queue qps is 10
 	// default for queue burst is 100
+	// default for startup ramp duration is none
 	// +optional
 	Default *ControllerConfig `json:"default,omitempty"`
 	// Controllers contains a list of configurations for different controllers
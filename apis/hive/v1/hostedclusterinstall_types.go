@@ -0,0 +1,97 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// HostedClusterConfig configures the HyperShift HostedCluster and NodePools of a cluster with a hosted control plane.
+type HostedClusterConfig struct {
+	// HostedClusterSpec holds fields of the spec of the HostedCluster, for example platform, networking and services.
+	// Hive sets the release image, pull secret and DNS base domain of the HostedCluster from the ClusterDeployment.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	HostedClusterSpec runtime.RawExtension `json:"hostedClusterSpec"`
+
+	// NodePools are the NodePools created for the HostedCluster. They are created once the HostedCluster is created,
+	// and are not updated afterwards.
+	// +optional
+	NodePools []HostedClusterNodePool `json:"nodePools,omitempty"`
+}
+
+// HostedClusterNodePool configures a NodePool of a HostedCluster.
+type HostedClusterNodePool struct {
+	// Name identifies the NodePool within the HostedCluster. The NodePool is named after both the cluster and this name.
+	Name string `json:"name"`
+
+	// Replicas is the number of nodes of the NodePool.
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
+
+	// Platform is the platform of the NodePool, for example {"type": "AWS", "aws": {"instanceType": "m5.large"}}.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Platform runtime.RawExtension `json:"platform"`
+}
+
+// HostedClusterInstallSpec defines the desired state of a HostedClusterInstall.
+type HostedClusterInstallSpec struct {
+	// ClusterDeploymentRef is a reference to the ClusterDeployment of the cluster, in the namespace of the
+	// HostedClusterInstall.
+	ClusterDeploymentRef corev1.LocalObjectReference `json:"clusterDeploymentRef"`
+
+	// ImageSetRef is a reference to a ClusterImageSet. The release image specified in the ClusterImageSet is the
+	// release of the HostedCluster.
+	ImageSetRef ClusterImageSetReference `json:"imageSetRef"`
+
+	// ClusterMetadata contains metadata information about the installed cluster. It is set by Hive once the
+	// kubeconfig and kubeadmin password of the HostedCluster are available.
+	// +optional
+	ClusterMetadata *ClusterMetadata `json:"clusterMetadata,omitempty"`
+
+	HostedClusterConfig `json:",inline"`
+}
+
+// HostedClusterInstallStatus defines the observed state of a HostedClusterInstall.
+type HostedClusterInstallStatus struct {
+	// Conditions includes more detailed status for the cluster install.
+	// +optional
+	Conditions []ClusterInstallCondition `json:"conditions,omitempty"`
+
+	// HostedClusterRef references the HostedCluster created for the cluster, in the namespace of the
+	// HostedClusterInstall.
+	// +optional
+	HostedClusterRef *corev1.LocalObjectReference `json:"hostedClusterRef,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HostedClusterInstall installs a cluster with a HyperShift hosted control plane. Instead of running the installer,
+// Hive creates a HostedCluster and NodePools, and completes the install once the hosted control plane is available.
+// A ClusterDeployment uses it through its clusterInstallRef.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ClusterDeployment",type="string",JSONPath=".spec.clusterDeploymentRef.name"
+// +kubebuilder:printcolumn:name="Completed",type="string",JSONPath=".status.conditions[?(@.type=='Completed')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced
+type HostedClusterInstall struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HostedClusterInstallSpec   `json:"spec,omitempty"`
+	Status HostedClusterInstallStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HostedClusterInstallList contains a list of HostedClusterInstalls.
+type HostedClusterInstallList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HostedClusterInstall `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HostedClusterInstall{}, &HostedClusterInstallList{})
+}
@@ -0,0 +1,138 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HostInventorySpec defines the hosts of an agent based install and how their static network configuration is
+// generated.
+type HostInventorySpec struct {
+	// ClusterDeploymentRef is a reference to the ClusterDeployment of the cluster the hosts are installed into, in the
+	// namespace of the HostInventory.
+	ClusterDeploymentRef corev1.LocalObjectReference `json:"clusterDeploymentRef"`
+
+	// NetworkConfigTemplate is a Go template of the NMState network configuration of a host, in YAML. It is rendered
+	// for each host with .Name, .Interfaces (each with .Name and .MACAddress) and .Vars, the variables of the
+	// inventory overridden by the variables of the host.
+	// +kubebuilder:validation:MinLength=1
+	NetworkConfigTemplate string `json:"networkConfigTemplate"`
+
+	// Variables are available to the template of every host as .Vars.
+	// +optional
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// NMStateConfigLabels are set on every NMStateConfig generated for the hosts. They must match the
+	// nmStateConfigLabelSelector of the InfraEnv of the hosts.
+	// +optional
+	NMStateConfigLabels map[string]string `json:"nmStateConfigLabels,omitempty"`
+
+	// Hosts are the hosts of the inventory. An NMStateConfig is generated for each of them.
+	Hosts []InventoryHost `json:"hosts"`
+}
+
+// InventoryHost is a host of a HostInventory.
+type InventoryHost struct {
+	// Name identifies the host within the inventory. The NMStateConfig of the host is named after both the inventory
+	// and this name.
+	Name string `json:"name"`
+
+	// Interfaces are the network interfaces of the host. Every ethernet interface of the rendered network
+	// configuration must be one of them.
+	// +kubebuilder:validation:MinItems=1
+	Interfaces []InventoryHostInterface `json:"interfaces"`
+
+	// Variables are available to the template of this host as .Vars, overriding the variables of the inventory.
+	// +optional
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// InventoryHostInterface maps the name of a network interface of a host to its MAC address.
+type InventoryHostInterface struct {
+	// Name is the name of the interface in the network configuration, for example eth0.
+	Name string `json:"name"`
+
+	// MACAddress is the MAC address of the interface.
+	MACAddress string `json:"macAddress"`
+}
+
+// HostInventoryStatus defines the observed state of a HostInventory.
+type HostInventoryStatus struct {
+	// Conditions includes more detailed status for the inventory.
+	// +optional
+	Conditions []HostInventoryCondition `json:"conditions,omitempty"`
+}
+
+// HostInventoryCondition contains details for the current condition of a HostInventory.
+type HostInventoryCondition struct {
+	// Type is the type of the condition.
+	Type HostInventoryConditionType `json:"type"`
+	// Status is the status of the condition.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastProbeTime is the last time we probed the condition.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty"`
+	// LastTransitionTime is the last time the condition transitioned from one status to another.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a unique, one-word, CamelCase reason for the condition's last transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message indicating details about last transition.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ToCondition returns the host inventory condition as a metav1.Condition.
+func (c *HostInventoryCondition) ToCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(c.Type),
+		Status:             metav1.ConditionStatus(c.Status),
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             c.Reason,
+		Message:            c.Message,
+	}
+}
+
+// HostInventoryConditionType is a valid value for HostInventoryCondition.Type
+type HostInventoryConditionType string
+
+const (
+	// HostInventoryValidCondition is True when the network configuration of every host renders, and is False with the
+	// errors otherwise. NMStateConfigs are only generated for valid inventories.
+	HostInventoryValidCondition HostInventoryConditionType = "Valid"
+	// HostInventoryNMStateConfigsCurrentCondition is True when the NMStateConfigs of all hosts match the inventory.
+	HostInventoryNMStateConfigsCurrentCondition HostInventoryConditionType = "NMStateConfigsCurrent"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HostInventory is a hub-side inventory of the hosts of an agent based install. Hive renders the static network
+// configuration of each host from a template and generates the NMStateConfigs used by the assisted installer.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ClusterDeployment",type="string",JSONPath=".spec.clusterDeploymentRef.name"
+// +kubebuilder:printcolumn:name="Valid",type="string",JSONPath=".status.conditions[?(@.type=='Valid')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced
+type HostInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HostInventorySpec   `json:"spec,omitempty"`
+	Status HostInventoryStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HostInventoryList contains a list of HostInventories.
+type HostInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HostInventory `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HostInventory{}, &HostInventoryList{})
+}
@@ -3,6 +3,8 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/apis/hive/v1/azure"
@@ -18,6 +20,11 @@ const (
 	// is intended for very limited use cases we do not recommend pursuing regularly. As such it is not currently
 	// part of our official API.
 	MachinePoolImageIDOverrideAnnotation = "hive.openshift.io/image-id-override"
+
+	// MachinePoolForceApplyAnnotation can be set to "true" on MachinePools to have Hive take over the fields of the
+	// remote MachineSets of the pool which conflict with other field managers in the cluster, as reported by the
+	// MachineSetConflict condition, rather than leave them alone.
+	MachinePoolForceApplyAnnotation = "hive.openshift.io/force-apply"
 )
 
 // MachinePoolSpec defines the desired state of MachinePool
@@ -33,6 +40,7 @@ type MachinePoolSpec struct {
 	// Replicas is the count of machines for this machine pool.
 	// Replicas and autoscaling cannot be used together.
 	// Default is 1, if autoscaling is not used.
+	// +kubebuilder:validation:Minimum=0
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
 
@@ -44,6 +52,15 @@ type MachinePoolSpec struct {
 	// Platform is configuration for machine pool specific to the platform.
 	Platform MachinePoolPlatform `json:"platform"`
 
+	// ZoneDistribution pins the replicas of the MachineSets of specific availability zones, keyed by zone, instead
+	// of spreading the replicas of the machine pool evenly across its zones. The replicas left over by the pinned
+	// zones are spread evenly across the other zones. Without autoscaling, each entry sets the replicas of its zone;
+	// with autoscaling, each entry sets the minimum and maximum replicas of its zone. Every zone must be a zone of the
+	// machine pool, and the pinned replicas must add up to no more than the replicas of the machine pool, and to
+	// exactly them when every zone is pinned.
+	// +optional
+	ZoneDistribution map[string]MachinePoolZoneReplicas `json:"zoneDistribution,omitempty"`
+
 	// Map of label string keys and values that will be applied to the created MachineSet's
 	// MachineSpec. This list will overwrite any modifications made to Node labels on an
 	// ongoing basis.
@@ -54,6 +71,141 @@ type MachinePoolSpec struct {
 	// This list will overwrite any modifications made to Node taints on an ongoing basis.
 	// +optional
 	Taints []corev1.Taint `json:"taints,omitempty"`
+
+	// KubeletConfig is the kubelet configuration applied to the nodes of the machine pool, for example
+	// {"maxPods": 500}. Hive creates a machineconfiguration.openshift.io KubeletConfig in the cluster with this
+	// configuration, bound to the machine config pool of the machine pool's nodes.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	KubeletConfig *runtime.RawExtension `json:"kubeletConfig,omitempty"`
+
+	// MachineConfigs are machineconfiguration.openshift.io MachineConfigs applied to the nodes of the machine pool.
+	// +optional
+	MachineConfigs []MachinePoolMachineConfig `json:"machineConfigs,omitempty"`
+
+	// TagReconciliation controls which cloud resources of the machine pool are kept tagged with the tags configured
+	// for the cluster, such as the userTags of an AWS ClusterDeployment. With MachineSets, the default, only the
+	// MachineSets are updated, so that changed tags only reach new machines. With Instances, the tags of the running
+	// instances of the machine pool, and of their volumes, are also updated, without replacing the machines.
+	// +kubebuilder:validation:Enum=MachineSets;Instances
+	// +optional
+	TagReconciliation MachinePoolTagReconciliation `json:"tagReconciliation,omitempty"`
+
+	// RolloutStrategy makes the platform of the machine pool mutable. When the platform changes, for example the
+	// instance type, the MachineSets of the machine pool are updated, and Hive replaces the existing machines of the
+	// MachineSets which do not match them, within the bounds of the strategy. Without a rollout strategy, the platform
+	// cannot be changed.
+	// +optional
+	RolloutStrategy *MachinePoolRolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// MachineSetSelector makes the machine pool externally managed, for clusters where another system owns the
+	// MachineSets. Hive observes the MachineSets of the cluster which match the selector, and reports them in the
+	// status of the machine pool, but never creates, updates or deletes them. The platform must be left empty, and
+	// replicas, labels, taints, kubeletConfig, machineConfigs, tagReconciliation and rolloutStrategy cannot be used.
+	// With autoscaling, Hive syncs MachineAutoscalers for the selected MachineSets, spreading the replicas across them
+	// as it does for the MachineSets it manages.
+	// +optional
+	MachineSetSelector *metav1.LabelSelector `json:"machineSetSelector,omitempty"`
+
+	// Paused freezes the machine pool, for example during incident response. Hive stops creating, updating and
+	// deleting the MachineSets, MachineAutoscalers and other resources of a paused machine pool in the cluster, but
+	// keeps reporting their state in the status of the machine pool. Changes made while the machine pool is paused are
+	// applied once it is unpaused. A paused machine pool which is deleted is still cleaned up.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// AdoptExisting makes the machine pool adopt MachineSets which already exist in the cluster, for example hand-made
+	// ones, instead of creating duplicates of them. A MachineSet of the cluster whose name starts with the name of the
+	// machine pool's MachineSets, and which is not already managed by Hive, is adopted in place of the generated
+	// MachineSet of its zone. Adopted MachineSets are labelled and synced like generated ones, and are reported in the
+	// Adopted condition.
+	// +optional
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+
+	// MachineHealthCheck makes Hive create a MachineHealthCheck in the cluster for each MachineSet of the machine pool,
+	// so that the machine API remediates the machines whose nodes are unhealthy. The MachineHealthChecks are named after
+	// their MachineSets, and are deleted along with them or when the machineHealthCheck is removed.
+	// +optional
+	MachineHealthCheck *MachinePoolMachineHealthCheck `json:"machineHealthCheck,omitempty"`
+}
+
+// MachinePoolMachineHealthCheck configures the MachineHealthChecks of the MachineSets of a machine pool.
+type MachinePoolMachineHealthCheck struct {
+	// UnhealthyConditions are the node conditions which make a machine unhealthy. A machine is unhealthy as soon as any
+	// of them has lasted for its timeout.
+	// +kubebuilder:validation:MinItems=1
+	UnhealthyConditions []MachinePoolUnhealthyCondition `json:"unhealthyConditions"`
+
+	// MaxUnhealthy stops the remediation of the machines of a MachineSet while more than this many of them are
+	// unhealthy, either as an absolute number or as a percentage of the machines of the MachineSet. Defaults to 100%.
+	// +optional
+	MaxUnhealthy *intstr.IntOrString `json:"maxUnhealthy,omitempty"`
+
+	// NodeStartupTimeout is how long a machine can go without a node before it is unhealthy. Defaults to 10m.
+	// +optional
+	NodeStartupTimeout *metav1.Duration `json:"nodeStartupTimeout,omitempty"`
+}
+
+// MachinePoolUnhealthyCondition is a node condition which makes a machine unhealthy.
+type MachinePoolUnhealthyCondition struct {
+	// Type is the type of the node condition, for example Ready.
+	Type corev1.NodeConditionType `json:"type"`
+
+	// Status is the status of the node condition which makes the machine unhealthy, for example False or Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+
+	// Timeout is how long the node condition must have had the status for the machine to be unhealthy.
+	Timeout metav1.Duration `json:"timeout"`
+}
+
+// MachinePoolRolloutStrategy is how the machines of a machine pool are replaced when its platform changes.
+type MachinePoolRolloutStrategy struct {
+	// Type is how machines are replaced. With RollingUpdate, the default, the MachineSets of the machine pool are
+	// updated in place, and their outdated machines replaced within the bounds of maxSurge and maxUnavailable. With
+	// BlueGreen, new MachineSets are created alongside the existing ones, named after them with a "-v2", "-v3", ...
+	// suffix. Once all of their machines are ready, the existing MachineSets are scaled down and deleted. With
+	// RollingReplacement, new MachineSets are created as with BlueGreen, but scaled up while the existing ones are
+	// scaled down, within the bounds of maxSurge and maxUnavailable. BlueGreen and RollingReplacement cannot be used with
+	// auto-scaling.
+	// +kubebuilder:validation:Enum=RollingUpdate;BlueGreen;RollingReplacement
+	// +optional
+	Type MachinePoolRolloutStrategyType `json:"type,omitempty"`
+
+	// MaxSurge is the number of machines which can be created above the replicas of a MachineSet while its machines
+	// are replaced, either as an absolute number or as a percentage of the replicas, rounded up. In RollingReplacement
+	// rollouts, the machines of the MachineSets replacing it count towards it. Ignored for auto-scaling machine pools
+	// and BlueGreen rollouts. Defaults to 1.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is the number of machines of a MachineSet which can be unavailable while its machines are
+	// replaced, either as an absolute number or as a percentage of the replicas, rounded down. Defaults to 0, unless
+	// no machines can be surged, in which case it defaults to 1. Ignored for BlueGreen rollouts.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// MachinePoolTagReconciliation is the mode in which the tags of the cloud resources of a machine pool are reconciled.
+type MachinePoolTagReconciliation string
+
+const (
+	// MachineSetsTagReconciliation updates the tags of the MachineSets of the machine pool only.
+	MachineSetsTagReconciliation MachinePoolTagReconciliation = "MachineSets"
+
+	// InstancesTagReconciliation updates the tags of the running instances of the machine pool, in addition to the
+	// tags of its MachineSets.
+	InstancesTagReconciliation MachinePoolTagReconciliation = "Instances"
+)
+
+// MachinePoolMachineConfig is a MachineConfig applied to the nodes of a machine pool.
+type MachinePoolMachineConfig struct {
+	// Name identifies the MachineConfig within the machine pool. The MachineConfig created in the cluster is named
+	// after both the machine pool and this name.
+	Name string `json:"name"`
+
+	// Spec is the spec of the MachineConfig, for example kernelArguments or an ignition config.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec runtime.RawExtension `json:"spec"`
 }
 
 // MachinePoolAutoscaling details how the machine pool is to be auto-scaled.
@@ -65,6 +217,24 @@ type MachinePoolAutoscaling struct {
 	MaxReplicas int32 `json:"maxReplicas"`
 }
 
+// MachinePoolZoneReplicas are the replicas pinned for the MachineSets of an availability zone.
+type MachinePoolZoneReplicas struct {
+	// Replicas is the count of machines of the zone. Required when the machine pool does not use autoscaling.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// MinReplicas is the minimum number of replicas of the zone. Required when the machine pool uses autoscaling.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the maximum number of replicas of the zone. Required when the machine pool uses autoscaling.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+}
+
 // MachinePoolPlatform is the platform-specific configuration for a machine
 // pool. Only one of the platforms should be set.
 type MachinePoolPlatform struct {
@@ -94,6 +264,108 @@ type MachinePoolStatus struct {
 	// Conditions includes more detailed status for the cluster deployment
 	// +optional
 	Conditions []MachinePoolCondition `json:"conditions,omitempty"`
+
+	// Rollout is the progress of replacing the machines of the machine pool which do not match its MachineSets.
+	// Only set for machine pools with a rollout strategy.
+	// +optional
+	Rollout *MachinePoolRolloutStatus `json:"rollout,omitempty"`
+
+	// ObservedGeneration is the generation of the machine pool which was last synced to the remote cluster.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// SkippedZones is the list of zones of the region which are not used by the machine pool because its instance type
+	// is not offered in them. Only set for machine pools which select their zones by instance type availability.
+	// +optional
+	SkippedZones []string `json:"skippedZones,omitempty"`
+
+	// AuthoritativeAPI is the API which manages the machines of the remote cluster, as detected from its feature gates.
+	// Hive continues to sync the machine pool as Machine API MachineSets, which are mirrored to Cluster API when it is
+	// authoritative.
+	// +optional
+	AuthoritativeAPI MachineAuthority `json:"authoritativeAPI,omitempty"`
+
+	// Zones are the numbers of machines of the machine pool in each phase, aggregated by the availability zone of
+	// their machine sets, for visibility into the health of the machines from the hub.
+	// +optional
+	Zones []MachinePoolZoneStatus `json:"zones,omitempty"`
+}
+
+// MachinePoolZoneStatus contains the machines of a machine pool in an availability zone.
+type MachinePoolZoneStatus struct {
+	// Zone is the availability zone. It is empty for platforms without zones.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// Machines are the numbers of machines of the zone in each phase.
+	Machines MachinePhaseCounts `json:"machines"`
+}
+
+// MachinePhaseCounts contains the numbers of machines in each phase of their lifecycle.
+type MachinePhaseCounts struct {
+	// Provisioning is the number of machines whose instance is being created, including machines whose provisioning
+	// has not started yet.
+	// +optional
+	Provisioning int32 `json:"provisioning,omitempty"`
+
+	// Provisioned is the number of machines whose instance exists, but whose node has not joined the cluster yet.
+	// +optional
+	Provisioned int32 `json:"provisioned,omitempty"`
+
+	// Running is the number of machines whose node has joined the cluster.
+	// +optional
+	Running int32 `json:"running,omitempty"`
+
+	// Failed is the number of machines which failed and need to be replaced.
+	// +optional
+	Failed int32 `json:"failed,omitempty"`
+
+	// Deleting is the number of machines being deleted.
+	// +optional
+	Deleting int32 `json:"deleting,omitempty"`
+}
+
+// MachineAuthority is the API which manages the machines of a cluster.
+// +kubebuilder:validation:Enum=MachineAPI;ClusterAPI
+type MachineAuthority string
+
+const (
+	// MachineAPIMachineAuthority means the machines of the cluster are managed by the Machine API.
+	MachineAPIMachineAuthority MachineAuthority = "MachineAPI"
+
+	// ClusterAPIMachineAuthority means the machines of the cluster are managed by the Cluster API, with the Machine API
+	// MachineSets mirrored to Cluster API MachineSets.
+	ClusterAPIMachineAuthority MachineAuthority = "ClusterAPI"
+)
+
+// MachinePoolRolloutStrategyType is how the machines of a machine pool are replaced.
+type MachinePoolRolloutStrategyType string
+
+const (
+	// RollingUpdateMachinePoolRolloutStrategyType replaces the machines of the MachineSets of a machine pool in place.
+	RollingUpdateMachinePoolRolloutStrategyType MachinePoolRolloutStrategyType = "RollingUpdate"
+
+	// BlueGreenMachinePoolRolloutStrategyType replaces the MachineSets of a machine pool with new MachineSets.
+	BlueGreenMachinePoolRolloutStrategyType MachinePoolRolloutStrategyType = "BlueGreen"
+
+	// RollingReplacementMachinePoolRolloutStrategyType replaces the MachineSets of a machine pool with new MachineSets,
+	// moving their replicas over a few at a time.
+	RollingReplacementMachinePoolRolloutStrategyType MachinePoolRolloutStrategyType = "RollingReplacement"
+)
+
+// MachinePoolRolloutStatus is the progress of replacing the machines of a machine pool.
+type MachinePoolRolloutStatus struct {
+	// Generation is the generation of the machine pool which the machines are being rolled out to.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+
+	// UpdatedReplicas is the number of machines which match their MachineSet. For BlueGreen rollouts, the number of
+	// ready machines of the new MachineSets.
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+
+	// OutdatedReplicas is the number of machines which remain to be replaced. For BlueGreen rollouts, the number of
+	// machines of the MachineSets being replaced.
+	OutdatedReplicas int32 `json:"outdatedReplicas"`
 }
 
 // MachineSetStatus is the status of a machineset in the remote cluster.
@@ -116,6 +388,22 @@ type MachineSetStatus struct {
 	// MaxReplicas is the maximum number of replicas for the machine set.
 	MaxReplicas int32 `json:"maxReplicas"`
 
+	// AppliedGeneration is the generation of the machine pool which was last applied to the machine set in the remote
+	// cluster. Machine sets which are being replaced in a BlueGreen rollout keep the generation they were last
+	// applied with.
+	// +optional
+	AppliedGeneration int64 `json:"appliedGeneration,omitempty"`
+
+	// Infrastructure is the cloud infrastructure that the machines of the machine set are created with, as resolved
+	// by the actuator for the platform of the cluster. It is not reported for all platforms.
+	// +optional
+	Infrastructure *MachineSetInfrastructure `json:"infrastructure,omitempty"`
+
+	// Machines are the numbers of machines of the machine set in each phase. Not set when the machines of the remote
+	// cluster could not be listed.
+	// +optional
+	Machines *MachinePhaseCounts `json:"machines,omitempty"`
+
 	// In the event that there is a terminal problem reconciling the
 	// replicas, both ErrorReason and ErrorMessage will be set. ErrorReason
 	// will be populated with a succinct value suitable for machine
@@ -127,6 +415,26 @@ type MachineSetStatus struct {
 	ErrorMessage *string `json:"errorMessage,omitempty"`
 }
 
+// MachineSetInfrastructure contains the cloud details of the machines of a machine set.
+type MachineSetInfrastructure struct {
+	// Zone is the availability zone of the machines.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// Subnet is the subnet of the machines. On AWS, when the subnet is selected by a filter instead of an ID, it is
+	// the value of the filter.
+	// +optional
+	Subnet string `json:"subnet,omitempty"`
+
+	// InstanceType is the instance type, machine type or VM size of the machines.
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// Image is the image of the machines, such as the ID of the AMI on AWS.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
 // MachinePoolCondition contains details for the current condition of a machine pool
 type MachinePoolCondition struct {
 	// Type is the type of the condition.
@@ -145,6 +453,23 @@ type MachinePoolCondition struct {
 	// Message is a human-readable message indicating details about last transition.
 	// +optional
 	Message string `json:"message,omitempty"`
+	// ObservedGeneration is the generation of the machine pool which was last synced to the remote cluster when the
+	// condition was set.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ToCondition converts the machine pool condition to a standard metav1.Condition, for tooling which interprets
+// the conditions of resources generically.
+func (c *MachinePoolCondition) ToCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(c.Type),
+		Status:             metav1.ConditionStatus(c.Status),
+		ObservedGeneration: c.ObservedGeneration,
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             c.Reason,
+		Message:            c.Message,
+	}
 }
 
 // MachinePoolConditionType is a valid value for MachinePoolCondition.Type
@@ -162,9 +487,47 @@ const (
 	// InvalidSubnetsMachinePoolCondition is true when there are missing or invalid entries in the subnet field
 	InvalidSubnetsMachinePoolCondition MachinePoolConditionType = "InvalidSubnets"
 
+	// InvalidZoneDistributionMachinePoolCondition is true when the zone distribution of the machine pool names zones
+	// which the machine pool has no MachineSets in, or its pinned replicas do not add up to the replicas of the
+	// machine pool.
+	InvalidZoneDistributionMachinePoolCondition MachinePoolConditionType = "InvalidZoneDistribution"
+
+	// PausedMachinePoolCondition is true while the machine pool is paused, and its resources in the cluster are not
+	// synced.
+	PausedMachinePoolCondition MachinePoolConditionType = "Paused"
+
+	// AdoptedMachinePoolCondition is true when the machine pool has adopted MachineSets which already existed in the
+	// cluster. The message lists the adopted MachineSets.
+	AdoptedMachinePoolCondition MachinePoolConditionType = "Adopted"
+
 	// UnsupportedConfigurationMachinePoolCondition is true when the configuration of the MachinePool is unsupported
 	// by the cluster.
 	UnsupportedConfigurationMachinePoolCondition MachinePoolConditionType = "UnsupportedConfiguration"
+
+	// RolloutInProgressMachinePoolCondition is true while the MachineSets of a machine pool with the BlueGreen rollout
+	// strategy are being replaced. The reason is the step of the rollout.
+	RolloutInProgressMachinePoolCondition MachinePoolConditionType = "RolloutInProgress"
+
+	// ReadyMachinePoolCondition is true when the MachineSets of the machine pool are synced to the remote cluster, and
+	// all of their machines are ready and up to date. Unlike the other conditions of machine pools, it follows the
+	// positive polarity of standard Kubernetes conditions.
+	ReadyMachinePoolCondition MachinePoolConditionType = "Ready"
+
+	// DeletionBlockedMachinePoolCondition is true when the machine pool has been waiting on its finalizers to be
+	// deleted for longer than the deletion blocked timeout of HiveConfig.
+	DeletionBlockedMachinePoolCondition MachinePoolConditionType = "DeletionBlocked"
+
+	// DeletionProtectedMachinePoolCondition is true when remote MachineSets, MachineAutoscalers or MachineHealthChecks
+	// of the machine pool which Hive would delete, for example when the zones of the machine pool change, are kept
+	// because they are annotated with hive.openshift.io/protected-delete=true in the cluster. A deleted machine pool
+	// keeps its finalizer until they are unprotected or deleted in the cluster.
+	DeletionProtectedMachinePoolCondition MachinePoolConditionType = "DeletionProtected"
+
+	// MachineSetConflictMachinePoolCondition is true when fields of remote MachineSets of the machine pool could not
+	// be synced because they are managed by another field manager in the cluster, such as a user or another
+	// controller, with a different value. The message lists the MachineSets, the fields and their managers. The fields
+	// are taken over when the machine pool is annotated with hive.openshift.io/force-apply=true.
+	MachineSetConflictMachinePoolCondition MachinePoolConditionType = "MachineSetConflict"
 )
 
 // +genclient
@@ -178,6 +541,7 @@ const (
 // +kubebuilder:printcolumn:name="ClusterDeployment",type="string",JSONPath=".spec.clusterDeploymentRef.name"
 // +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas"
 // +kubebuilder:resource:path=machinepools,scope=Namespaced
+// +kubebuilder:storageversion
 type MachinePool struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
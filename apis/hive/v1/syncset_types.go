@@ -58,6 +58,22 @@ const (
 	AlwaysApplyPatchApplyMode SyncSetPatchApplyMode = "AlwaysApply"
 )
 
+// SyncObjectReference identifies an object in the target cluster.
+type SyncObjectReference struct {
+	// APIVersion is the Group and Version of the object.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the Kind of the object.
+	Kind string `json:"kind"`
+
+	// Name is the name of the object.
+	Name string `json:"name"`
+
+	// Namespace is the Namespace of the object. Empty for cluster-scoped objects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 // SyncObjectPatch represents a patch to be applied to a specific object
 type SyncObjectPatch struct {
 	// APIVersion is the Group and Version of the object to be patched.
@@ -77,7 +93,10 @@ type SyncObjectPatch struct {
 	// Patch is the patch to apply.
 	Patch string `json:"patch"`
 
-	// PatchType indicates the PatchType as "strategic" (default), "json", or "merge".
+	// PatchType indicates the PatchType as "strategic" (default), "json", "merge", or "apply".
+	// "json" patches may include "test" operations; if any test fails, none of the patch is applied.
+	// "apply" patches are server-side apply patches containing the fields to set, which, unlike
+	// strategic merge patches, are supported for custom resources.
 	// +optional
 	PatchType string `json:"patchType,omitempty"`
 }
@@ -143,6 +162,17 @@ type SyncCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
+// ToCondition returns the condition of a SyncSet or SelectorSyncSet applied to a cluster as a metav1.Condition.
+func (c *SyncCondition) ToCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(c.Type),
+		Status:             metav1.ConditionStatus(c.Status),
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             c.Reason,
+		Message:            c.Message,
+	}
+}
+
 // SyncSetObjectStatus describes the status of resources created or patches that have
 // been applied from a SyncSet or SelectorSyncSet.
 type SyncSetObjectStatus struct {
@@ -222,6 +252,11 @@ type SyncSetCommonSpec struct {
 	// +optional
 	Secrets []SecretMapping `json:"secretMappings,omitempty"`
 
+	// ResourcesToDelete is the list of objects to delete from the target cluster, such as deprecated objects that are
+	// no longer wanted. Objects which do not exist, or whose type is not served by the cluster, are considered deleted.
+	// +optional
+	ResourcesToDelete []SyncObjectReference `json:"resourcesToDelete,omitempty"`
+
 	// ApplyBehavior indicates how resources in this syncset will be applied to the target
 	// cluster. The default value of "Apply" indicates that resources should be applied
 	// using the 'oc apply' command. If no value is set, "Apply" is assumed.
@@ -0,0 +1,91 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TenantQuotaSpec defines the limits placed on the Hive resources which may be created in a namespace.
+type TenantQuotaSpec struct {
+	// MaxClusterDeployments is the maximum number of ClusterDeployments which may exist in the namespace.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxClusterDeployments *int32 `json:"maxClusterDeployments,omitempty"`
+
+	// MaxMachineReplicas is the maximum total number of machine replicas across all MachinePools in the namespace.
+	// The maximum replicas of autoscaling MachinePools are counted.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxMachineReplicas *int64 `json:"maxMachineReplicas,omitempty"`
+
+	// InstanceFamilies limits the total number of machine replicas using specific instance families.
+	// +optional
+	InstanceFamilies []InstanceFamilyQuota `json:"instanceFamilies,omitempty"`
+}
+
+// InstanceFamilyQuota limits the number of machine replicas using an instance family.
+type InstanceFamilyQuota struct {
+	// Family is matched against the part of the MachinePool instance type before the first "." or "-". For
+	// example, "p3" matches the "p3.2xlarge" AWS instance type but "m5" does not match "m5a.xlarge".
+	Family string `json:"family"`
+
+	// MaxReplicas is the maximum total number of machine replicas using the instance family. Setting this to zero
+	// prevents the instance family from being used.
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicas int64 `json:"maxReplicas"`
+}
+
+// TenantQuotaStatus defines the observed usage of the namespace.
+type TenantQuotaStatus struct {
+	// ClusterDeployments is the number of ClusterDeployments in the namespace.
+	// +optional
+	ClusterDeployments int32 `json:"clusterDeployments,omitempty"`
+
+	// MachineReplicas is the total number of machine replicas across all MachinePools in the namespace.
+	// +optional
+	MachineReplicas int64 `json:"machineReplicas,omitempty"`
+
+	// InstanceFamilies is the number of machine replicas using each of the instance families limited by the quota.
+	// +optional
+	InstanceFamilies []InstanceFamilyUsage `json:"instanceFamilies,omitempty"`
+}
+
+// InstanceFamilyUsage is the number of machine replicas using an instance family.
+type InstanceFamilyUsage struct {
+	// Family is the instance family.
+	Family string `json:"family"`
+
+	// Replicas is the total number of machine replicas using the instance family.
+	Replicas int64 `json:"replicas"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TenantQuota limits the number of ClusterDeployments, machine replicas and instance families which may be created in
+// a namespace. Limits are enforced by hiveadmission when ClusterDeployments and MachinePools are created or updated.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ClusterDeployments",type="integer",JSONPath=".status.clusterDeployments"
+// +kubebuilder:printcolumn:name="MachineReplicas",type="integer",JSONPath=".status.machineReplicas"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced
+type TenantQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantQuotaSpec   `json:"spec,omitempty"`
+	Status TenantQuotaStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TenantQuotaList contains a list of TenantQuotas.
+type TenantQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TenantQuota{}, &TenantQuotaList{})
+}
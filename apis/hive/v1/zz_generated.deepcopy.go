@@ -18,6 +18,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -42,6 +43,32 @@ func (in *AWSAssociatedVPC) DeepCopy() *AWSAssociatedVPC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSClientConfig) DeepCopyInto(out *AWSClientConfig) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int)
+		**out = **in
+	}
+	if in.CallTimeout != nil {
+		in, out := &in.CallTimeout, &out.CallTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSClientConfig.
+func (in *AWSClientConfig) DeepCopy() *AWSClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSClusterDeprovision) DeepCopyInto(out *AWSClusterDeprovision) {
 	*out = *in
@@ -68,6 +95,27 @@ func (in *AWSClusterDeprovision) DeepCopy() *AWSClusterDeprovision {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSCredentialsBrokerConfig) DeepCopyInto(out *AWSCredentialsBrokerConfig) {
+	*out = *in
+	if in.SessionDuration != nil {
+		in, out := &in.SessionDuration, &out.SessionDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSCredentialsBrokerConfig.
+func (in *AWSCredentialsBrokerConfig) DeepCopy() *AWSCredentialsBrokerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSCredentialsBrokerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSDNSZoneSpec) DeepCopyInto(out *AWSDNSZoneSpec) {
 	*out = *in
@@ -116,6 +164,23 @@ func (in *AWSDNSZoneStatus) DeepCopy() *AWSDNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSKMSConfig) DeepCopyInto(out *AWSKMSConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSKMSConfig.
+func (in *AWSKMSConfig) DeepCopy() *AWSKMSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSKMSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSPrivateLinkConfig) DeepCopyInto(out *AWSPrivateLinkConfig) {
 	*out = *in
@@ -234,6 +299,23 @@ func (in *AWSServiceProviderCredentials) DeepCopy() *AWSServiceProviderCredentia
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdminSecretEncryptionConfig) DeepCopyInto(out *AdminSecretEncryptionConfig) {
+	*out = *in
+	in.KMS.DeepCopyInto(&out.KMS)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdminSecretEncryptionConfig.
+func (in *AdminSecretEncryptionConfig) DeepCopy() *AdminSecretEncryptionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdminSecretEncryptionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDConfig) DeepCopyInto(out *ArgoCDConfig) {
 	*out = *in
@@ -309,6 +391,23 @@ func (in *AzureDNSZoneStatus) DeepCopy() *AzureDNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureKeyVaultConfig) DeepCopyInto(out *AzureKeyVaultConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKeyVaultConfig.
+func (in *AzureKeyVaultConfig) DeepCopy() *AzureKeyVaultConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureKeyVaultConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupConfig) DeepCopyInto(out *BackupConfig) {
 	*out = *in
@@ -347,10 +446,53 @@ func (in *BackupReference) DeepCopy() *BackupReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerCertificate) DeepCopyInto(out *CertManagerCertificate) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerCertificate.
+func (in *CertManagerCertificate) DeepCopy() *CertManagerCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateBundleSpec) DeepCopyInto(out *CertificateBundleSpec) {
 	*out = *in
 	out.CertificateSecretRef = in.CertificateSecretRef
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerCertificate)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -476,7 +618,7 @@ func (in *CheckpointStatus) DeepCopy() *CheckpointStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaim) DeepCopyInto(out *ClusterClaim) {
+func (in *ClusterAccessToken) DeepCopyInto(out *ClusterAccessToken) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -485,18 +627,18 @@ func (in *ClusterClaim) DeepCopyInto(out *ClusterClaim) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaim.
-func (in *ClusterClaim) DeepCopy() *ClusterClaim {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessToken.
+func (in *ClusterAccessToken) DeepCopy() *ClusterAccessToken {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaim)
+	out := new(ClusterAccessToken)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterClaim) DeepCopyObject() runtime.Object {
+func (in *ClusterAccessToken) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -504,31 +646,13 @@ func (in *ClusterClaim) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaimCondition) DeepCopyInto(out *ClusterClaimCondition) {
-	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimCondition.
-func (in *ClusterClaimCondition) DeepCopy() *ClusterClaimCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(ClusterClaimCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaimList) DeepCopyInto(out *ClusterClaimList) {
+func (in *ClusterAccessTokenList) DeepCopyInto(out *ClusterAccessTokenList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterClaim, len(*in))
+		*out = make([]ClusterAccessToken, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -536,18 +660,18 @@ func (in *ClusterClaimList) DeepCopyInto(out *ClusterClaimList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimList.
-func (in *ClusterClaimList) DeepCopy() *ClusterClaimList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessTokenList.
+func (in *ClusterAccessTokenList) DeepCopy() *ClusterAccessTokenList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaimList)
+	out := new(ClusterAccessTokenList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterClaimList) DeepCopyObject() runtime.Object {
+func (in *ClusterAccessTokenList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -555,13 +679,25 @@ func (in *ClusterClaimList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaimSpec) DeepCopyInto(out *ClusterClaimSpec) {
+func (in *ClusterAccessTokenRoleBindingReference) DeepCopyInto(out *ClusterAccessTokenRoleBindingReference) {
 	*out = *in
-	if in.Subjects != nil {
-		in, out := &in.Subjects, &out.Subjects
-		*out = make([]rbacv1.Subject, len(*in))
-		copy(*out, *in)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessTokenRoleBindingReference.
+func (in *ClusterAccessTokenRoleBindingReference) DeepCopy() *ClusterAccessTokenRoleBindingReference {
+	if in == nil {
+		return nil
 	}
+	out := new(ClusterAccessTokenRoleBindingReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAccessTokenSpec) DeepCopyInto(out *ClusterAccessTokenSpec) {
+	*out = *in
+	out.ClusterDeploymentRef = in.ClusterDeploymentRef
 	if in.Lifetime != nil {
 		in, out := &in.Lifetime, &out.Lifetime
 		*out = new(metav1.Duration)
@@ -570,256 +706,209 @@ func (in *ClusterClaimSpec) DeepCopyInto(out *ClusterClaimSpec) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimSpec.
-func (in *ClusterClaimSpec) DeepCopy() *ClusterClaimSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessTokenSpec.
+func (in *ClusterAccessTokenSpec) DeepCopy() *ClusterAccessTokenSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaimSpec)
+	out := new(ClusterAccessTokenSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterClaimStatus) DeepCopyInto(out *ClusterClaimStatus) {
+func (in *ClusterAccessTokenStatus) DeepCopyInto(out *ClusterAccessTokenStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterClaimCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.KubeconfigSecretRef != nil {
+		in, out := &in.KubeconfigSecretRef, &out.KubeconfigSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
-	if in.Lifetime != nil {
-		in, out := &in.Lifetime, &out.Lifetime
-		*out = new(metav1.Duration)
+	if in.ExpirationTimestamp != nil {
+		in, out := &in.ExpirationTimestamp, &out.ExpirationTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.RoleBindingRef != nil {
+		in, out := &in.RoleBindingRef, &out.RoleBindingRef
+		*out = new(ClusterAccessTokenRoleBindingReference)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimStatus.
-func (in *ClusterClaimStatus) DeepCopy() *ClusterClaimStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessTokenStatus.
+func (in *ClusterAccessTokenStatus) DeepCopy() *ClusterAccessTokenStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterClaimStatus)
+	out := new(ClusterAccessTokenStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeployment) DeepCopyInto(out *ClusterDeployment) {
+func (in *ClusterAccessTokensConfig) DeepCopyInto(out *ClusterAccessTokensConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.AllowedClusterRoles != nil {
+		in, out := &in.AllowedClusterRoles, &out.AllowedClusterRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeployment.
-func (in *ClusterDeployment) DeepCopy() *ClusterDeployment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAccessTokensConfig.
+func (in *ClusterAccessTokensConfig) DeepCopy() *ClusterAccessTokensConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeployment)
+	out := new(ClusterAccessTokensConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeployment) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentCondition) DeepCopyInto(out *ClusterDeploymentCondition) {
+func (in *ClusterAutoscalerGPULimit) DeepCopyInto(out *ClusterAutoscalerGPULimit) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCondition.
-func (in *ClusterDeploymentCondition) DeepCopy() *ClusterDeploymentCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalerGPULimit.
+func (in *ClusterAutoscalerGPULimit) DeepCopy() *ClusterAutoscalerGPULimit {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentCondition)
+	out := new(ClusterAutoscalerGPULimit)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentList) DeepCopyInto(out *ClusterDeploymentList) {
+func (in *ClusterAutoscalerResourceLimits) DeepCopyInto(out *ClusterAutoscalerResourceLimits) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterDeployment, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.MaxNodesTotal != nil {
+		in, out := &in.MaxNodesTotal, &out.MaxNodesTotal
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Cores != nil {
+		in, out := &in.Cores, &out.Cores
+		*out = new(ClusterAutoscalerResourceRange)
+		**out = **in
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(ClusterAutoscalerResourceRange)
+		**out = **in
+	}
+	if in.GPUs != nil {
+		in, out := &in.GPUs, &out.GPUs
+		*out = make([]ClusterAutoscalerGPULimit, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentList.
-func (in *ClusterDeploymentList) DeepCopy() *ClusterDeploymentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalerResourceLimits.
+func (in *ClusterAutoscalerResourceLimits) DeepCopy() *ClusterAutoscalerResourceLimits {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentList)
+	out := new(ClusterAutoscalerResourceLimits)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeploymentList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscalerResourceRange) DeepCopyInto(out *ClusterAutoscalerResourceRange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalerResourceRange.
+func (in *ClusterAutoscalerResourceRange) DeepCopy() *ClusterAutoscalerResourceRange {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ClusterAutoscalerResourceRange)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
+func (in *ClusterAutoscalerScaleDown) DeepCopyInto(out *ClusterAutoscalerScaleDown) {
 	*out = *in
-	in.Platform.DeepCopyInto(&out.Platform)
-	if in.PullSecretRef != nil {
-		in, out := &in.PullSecretRef, &out.PullSecretRef
-		*out = new(corev1.LocalObjectReference)
+	if in.DelayAfterAdd != nil {
+		in, out := &in.DelayAfterAdd, &out.DelayAfterAdd
+		*out = new(metav1.Duration)
 		**out = **in
 	}
-	in.ControlPlaneConfig.DeepCopyInto(&out.ControlPlaneConfig)
-	if in.Ingress != nil {
-		in, out := &in.Ingress, &out.Ingress
-		*out = make([]ClusterIngress, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.DelayAfterDelete != nil {
+		in, out := &in.DelayAfterDelete, &out.DelayAfterDelete
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	if in.CertificateBundles != nil {
-		in, out := &in.CertificateBundles, &out.CertificateBundles
-		*out = make([]CertificateBundleSpec, len(*in))
-		copy(*out, *in)
+	if in.DelayAfterFailure != nil {
+		in, out := &in.DelayAfterFailure, &out.DelayAfterFailure
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	if in.ClusterMetadata != nil {
-		in, out := &in.ClusterMetadata, &out.ClusterMetadata
-		*out = new(ClusterMetadata)
-		(*in).DeepCopyInto(*out)
+	if in.UnneededTime != nil {
+		in, out := &in.UnneededTime, &out.UnneededTime
+		*out = new(metav1.Duration)
+		**out = **in
 	}
-	if in.Provisioning != nil {
-		in, out := &in.Provisioning, &out.Provisioning
-		*out = new(Provisioning)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ClusterInstallRef != nil {
-		in, out := &in.ClusterInstallRef, &out.ClusterInstallRef
-		*out = new(ClusterInstallLocalReference)
-		**out = **in
-	}
-	if in.ClusterPoolRef != nil {
-		in, out := &in.ClusterPoolRef, &out.ClusterPoolRef
-		*out = new(ClusterPoolReference)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.HibernateAfter != nil {
-		in, out := &in.HibernateAfter, &out.HibernateAfter
-		*out = new(metav1.Duration)
-		**out = **in
-	}
-	if in.InstallAttemptsLimit != nil {
-		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
-		*out = new(int32)
-		**out = **in
-	}
-	if in.BoundServiceAccountSignkingKeySecretRef != nil {
-		in, out := &in.BoundServiceAccountSignkingKeySecretRef, &out.BoundServiceAccountSignkingKeySecretRef
-		*out = new(corev1.LocalObjectReference)
+	if in.UtilizationThreshold != nil {
+		in, out := &in.UtilizationThreshold, &out.UtilizationThreshold
+		*out = new(string)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentSpec.
-func (in *ClusterDeploymentSpec) DeepCopy() *ClusterDeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalerScaleDown.
+func (in *ClusterAutoscalerScaleDown) DeepCopy() *ClusterAutoscalerScaleDown {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentSpec)
+	out := new(ClusterAutoscalerScaleDown)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
+func (in *ClusterAutoscalerSettings) DeepCopyInto(out *ClusterAutoscalerSettings) {
 	*out = *in
-	if in.InstallerImage != nil {
-		in, out := &in.InstallerImage, &out.InstallerImage
-		*out = new(string)
-		**out = **in
-	}
-	if in.InstallVersion != nil {
-		in, out := &in.InstallVersion, &out.InstallVersion
-		*out = new(string)
-		**out = **in
-	}
-	if in.CLIImage != nil {
-		in, out := &in.CLIImage, &out.CLIImage
-		*out = new(string)
-		**out = **in
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterDeploymentCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.CertificateBundles != nil {
-		in, out := &in.CertificateBundles, &out.CertificateBundles
-		*out = make([]CertificateBundleStatus, len(*in))
-		copy(*out, *in)
-	}
-	if in.InstallStartedTimestamp != nil {
-		in, out := &in.InstallStartedTimestamp, &out.InstallStartedTimestamp
-		*out = (*in).DeepCopy()
-	}
-	if in.InstalledTimestamp != nil {
-		in, out := &in.InstalledTimestamp, &out.InstalledTimestamp
-		*out = (*in).DeepCopy()
+	if in.ScaleDown != nil {
+		in, out := &in.ScaleDown, &out.ScaleDown
+		*out = new(ClusterAutoscalerScaleDown)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ProvisionRef != nil {
-		in, out := &in.ProvisionRef, &out.ProvisionRef
-		*out = new(corev1.LocalObjectReference)
+	if in.BalanceSimilarNodeGroups != nil {
+		in, out := &in.BalanceSimilarNodeGroups, &out.BalanceSimilarNodeGroups
+		*out = new(bool)
 		**out = **in
 	}
-	if in.Platform != nil {
-		in, out := &in.Platform, &out.Platform
-		*out = new(PlatformStatus)
+	if in.ResourceLimits != nil {
+		in, out := &in.ResourceLimits, &out.ResourceLimits
+		*out = new(ClusterAutoscalerResourceLimits)
 		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentStatus.
-func (in *ClusterDeploymentStatus) DeepCopy() *ClusterDeploymentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalerSettings.
+func (in *ClusterAutoscalerSettings) DeepCopy() *ClusterAutoscalerSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeploymentStatus)
+	out := new(ClusterAutoscalerSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovision) DeepCopyInto(out *ClusterDeprovision) {
+func (in *ClusterClaim) DeepCopyInto(out *ClusterClaim) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -828,18 +917,18 @@ func (in *ClusterDeprovision) DeepCopyInto(out *ClusterDeprovision) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovision.
-func (in *ClusterDeprovision) DeepCopy() *ClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaim.
+func (in *ClusterClaim) DeepCopy() *ClusterClaim {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovision)
+	out := new(ClusterClaim)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeprovision) DeepCopyObject() runtime.Object {
+func (in *ClusterClaim) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -847,31 +936,31 @@ func (in *ClusterDeprovision) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionCondition) DeepCopyInto(out *ClusterDeprovisionCondition) {
+func (in *ClusterClaimCondition) DeepCopyInto(out *ClusterClaimCondition) {
 	*out = *in
 	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionCondition.
-func (in *ClusterDeprovisionCondition) DeepCopy() *ClusterDeprovisionCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimCondition.
+func (in *ClusterClaimCondition) DeepCopy() *ClusterClaimCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovisionCondition)
+	out := new(ClusterClaimCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionList) DeepCopyInto(out *ClusterDeprovisionList) {
+func (in *ClusterClaimList) DeepCopyInto(out *ClusterClaimList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterDeprovision, len(*in))
+		*out = make([]ClusterClaim, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -879,18 +968,18 @@ func (in *ClusterDeprovisionList) DeepCopyInto(out *ClusterDeprovisionList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionList.
-func (in *ClusterDeprovisionList) DeepCopy() *ClusterDeprovisionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimList.
+func (in *ClusterClaimList) DeepCopy() *ClusterClaimList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovisionList)
+	out := new(ClusterClaimList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterDeprovisionList) DeepCopyObject() runtime.Object {
+func (in *ClusterClaimList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -898,113 +987,81 @@ func (in *ClusterDeprovisionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionPlatform) DeepCopyInto(out *ClusterDeprovisionPlatform) {
+func (in *ClusterClaimSpec) DeepCopyInto(out *ClusterClaimSpec) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSClusterDeprovision)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureClusterDeprovision)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(GCPClusterDeprovision)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.OpenStack != nil {
-		in, out := &in.OpenStack, &out.OpenStack
-		*out = new(OpenStackClusterDeprovision)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.VSphere != nil {
-		in, out := &in.VSphere, &out.VSphere
-		*out = new(VSphereClusterDeprovision)
-		**out = **in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
 	}
-	if in.Ovirt != nil {
-		in, out := &in.Ovirt, &out.Ovirt
-		*out = new(OvirtClusterDeprovision)
+	if in.Lifetime != nil {
+		in, out := &in.Lifetime, &out.Lifetime
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionPlatform.
-func (in *ClusterDeprovisionPlatform) DeepCopy() *ClusterDeprovisionPlatform {
-	if in == nil {
-		return nil
-	}
-	out := new(ClusterDeprovisionPlatform)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionSpec) DeepCopyInto(out *ClusterDeprovisionSpec) {
-	*out = *in
-	in.Platform.DeepCopyInto(&out.Platform)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionSpec.
-func (in *ClusterDeprovisionSpec) DeepCopy() *ClusterDeprovisionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimSpec.
+func (in *ClusterClaimSpec) DeepCopy() *ClusterClaimSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovisionSpec)
+	out := new(ClusterClaimSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterDeprovisionStatus) DeepCopyInto(out *ClusterDeprovisionStatus) {
+func (in *ClusterClaimStatus) DeepCopyInto(out *ClusterClaimStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterDeprovisionCondition, len(*in))
+		*out = make([]ClusterClaimCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Lifetime != nil {
+		in, out := &in.Lifetime, &out.Lifetime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionStatus.
-func (in *ClusterDeprovisionStatus) DeepCopy() *ClusterDeprovisionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClaimStatus.
+func (in *ClusterClaimStatus) DeepCopy() *ClusterClaimStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterDeprovisionStatus)
+	out := new(ClusterClaimStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSet) DeepCopyInto(out *ClusterImageSet) {
+func (in *ClusterDeployment) DeepCopyInto(out *ClusterDeployment) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSet.
-func (in *ClusterImageSet) DeepCopy() *ClusterImageSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeployment.
+func (in *ClusterDeployment) DeepCopy() *ClusterDeployment {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSet)
+	out := new(ClusterDeployment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterImageSet) DeepCopyObject() runtime.Object {
+func (in *ClusterDeployment) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1012,13 +1069,31 @@ func (in *ClusterImageSet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetList) DeepCopyInto(out *ClusterImageSetList) {
+func (in *ClusterDeploymentCondition) DeepCopyInto(out *ClusterDeploymentCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentCondition.
+func (in *ClusterDeploymentCondition) DeepCopy() *ClusterDeploymentCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentList) DeepCopyInto(out *ClusterDeploymentList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterImageSet, len(*in))
+		*out = make([]ClusterDeployment, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1026,18 +1101,18 @@ func (in *ClusterImageSetList) DeepCopyInto(out *ClusterImageSetList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetList.
-func (in *ClusterImageSetList) DeepCopy() *ClusterImageSetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentList.
+func (in *ClusterDeploymentList) DeepCopy() *ClusterDeploymentList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSetList)
+	out := new(ClusterDeploymentList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterImageSetList) DeepCopyObject() runtime.Object {
+func (in *ClusterDeploymentList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1045,141 +1120,1455 @@ func (in *ClusterImageSetList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetReference) DeepCopyInto(out *ClusterImageSetReference) {
+func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetReference.
-func (in *ClusterImageSetReference) DeepCopy() *ClusterImageSetReference {
-	if in == nil {
-		return nil
+	in.Platform.DeepCopyInto(&out.Platform)
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
-	out := new(ClusterImageSetReference)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetSpec) DeepCopyInto(out *ClusterImageSetSpec) {
-	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetSpec.
-func (in *ClusterImageSetSpec) DeepCopy() *ClusterImageSetSpec {
-	if in == nil {
-		return nil
+	if in.DeletionProtection != nil {
+		in, out := &in.DeletionProtection, &out.DeletionProtection
+		*out = new(DeletionProtection)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(ClusterImageSetSpec)
-	in.DeepCopyInto(out)
-	return out
-}
+	in.ControlPlaneConfig.DeepCopyInto(&out.ControlPlaneConfig)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = make([]ClusterIngress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CertificateBundles != nil {
+		in, out := &in.CertificateBundles, &out.CertificateBundles
+		*out = make([]CertificateBundleSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterMetadata != nil {
+		in, out := &in.ClusterMetadata, &out.ClusterMetadata
+		*out = new(ClusterMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(Provisioning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterInstallRef != nil {
+		in, out := &in.ClusterInstallRef, &out.ClusterInstallRef
+		*out = new(ClusterInstallLocalReference)
+		**out = **in
+	}
+	if in.ClusterPoolRef != nil {
+		in, out := &in.ClusterPoolRef, &out.ClusterPoolRef
+		*out = new(ClusterPoolReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HibernateAfter != nil {
+		in, out := &in.HibernateAfter, &out.HibernateAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.InstallAttemptsLimit != nil {
+		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BoundServiceAccountSignkingKeySecretRef != nil {
+		in, out := &in.BoundServiceAccountSignkingKeySecretRef, &out.BoundServiceAccountSignkingKeySecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.RemediationPolicy != nil {
+		in, out := &in.RemediationPolicy, &out.RemediationPolicy
+		*out = new(RemediationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ClusterProxy)
+		**out = **in
+	}
+	if in.PropagatedNodeLabels != nil {
+		in, out := &in.PropagatedNodeLabels, &out.PropagatedNodeLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TeardownHooks != nil {
+		in, out := &in.TeardownHooks, &out.TeardownHooks
+		*out = make([]TeardownHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ControlPlaneMachines != nil {
+		in, out := &in.ControlPlaneMachines, &out.ControlPlaneMachines
+		*out = new(ControlPlaneMachineSpec)
+		**out = **in
+	}
+	if in.ClusterAutoscaler != nil {
+		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
+		*out = new(ClusterAutoscalerSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentSpec.
+func (in *ClusterDeploymentSpec) DeepCopy() *ClusterDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
+	*out = *in
+	if in.InstallerImage != nil {
+		in, out := &in.InstallerImage, &out.InstallerImage
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstallVersion != nil {
+		in, out := &in.InstallVersion, &out.InstallVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.CLIImage != nil {
+		in, out := &in.CLIImage, &out.CLIImage
+		*out = new(string)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterDeploymentCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CertificateBundles != nil {
+		in, out := &in.CertificateBundles, &out.CertificateBundles
+		*out = make([]CertificateBundleStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstallStartedTimestamp != nil {
+		in, out := &in.InstallStartedTimestamp, &out.InstallStartedTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.InstalledTimestamp != nil {
+		in, out := &in.InstalledTimestamp, &out.InstalledTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.ProvisionRef != nil {
+		in, out := &in.ProvisionRef, &out.ProvisionRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Platform != nil {
+		in, out := &in.Platform, &out.Platform
+		*out = new(PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CostEstimate != nil {
+		in, out := &in.CostEstimate, &out.CostEstimate
+		*out = new(CostEstimate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(RemediationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachinePools != nil {
+		in, out := &in.MachinePools, &out.MachinePools
+		*out = make([]MachinePoolSummary, len(*in))
+		copy(*out, *in)
+	}
+	if in.KubeadminPasswordRotatedTimestamp != nil {
+		in, out := &in.KubeadminPasswordRotatedTimestamp, &out.KubeadminPasswordRotatedTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.ClaimReleaseHooks != nil {
+		in, out := &in.ClaimReleaseHooks, &out.ClaimReleaseHooks
+		*out = make([]TeardownHookStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeploymentStatus.
+func (in *ClusterDeploymentStatus) DeepCopy() *ClusterDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovision) DeepCopyInto(out *ClusterDeprovision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovision.
+func (in *ClusterDeprovision) DeepCopy() *ClusterDeprovision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeprovision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionCondition) DeepCopyInto(out *ClusterDeprovisionCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionCondition.
+func (in *ClusterDeprovisionCondition) DeepCopy() *ClusterDeprovisionCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionList) DeepCopyInto(out *ClusterDeprovisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterDeprovision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionList.
+func (in *ClusterDeprovisionList) DeepCopy() *ClusterDeprovisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterDeprovisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionPlatform) DeepCopyInto(out *ClusterDeprovisionPlatform) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenStack != nil {
+		in, out := &in.OpenStack, &out.OpenStack
+		*out = new(OpenStackClusterDeprovision)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VSphere != nil {
+		in, out := &in.VSphere, &out.VSphere
+		*out = new(VSphereClusterDeprovision)
+		**out = **in
+	}
+	if in.Ovirt != nil {
+		in, out := &in.Ovirt, &out.Ovirt
+		*out = new(OvirtClusterDeprovision)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionPlatform.
+func (in *ClusterDeprovisionPlatform) DeepCopy() *ClusterDeprovisionPlatform {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionPlatform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionSpec) DeepCopyInto(out *ClusterDeprovisionSpec) {
+	*out = *in
+	in.Platform.DeepCopyInto(&out.Platform)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionSpec.
+func (in *ClusterDeprovisionSpec) DeepCopy() *ClusterDeprovisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterDeprovisionStatus) DeepCopyInto(out *ClusterDeprovisionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterDeprovisionCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TeardownHooks != nil {
+		in, out := &in.TeardownHooks, &out.TeardownHooks
+		*out = make([]TeardownHookStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterDeprovisionStatus.
+func (in *ClusterDeprovisionStatus) DeepCopy() *ClusterDeprovisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterDeprovisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSet) DeepCopyInto(out *ClusterImageSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSet.
+func (in *ClusterImageSet) DeepCopy() *ClusterImageSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterImageSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetList) DeepCopyInto(out *ClusterImageSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterImageSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetList.
+func (in *ClusterImageSetList) DeepCopy() *ClusterImageSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterImageSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetReference) DeepCopyInto(out *ClusterImageSetReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetReference.
+func (in *ClusterImageSetReference) DeepCopy() *ClusterImageSetReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetSpec) DeepCopyInto(out *ClusterImageSetSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetSpec.
+func (in *ClusterImageSetSpec) DeepCopy() *ClusterImageSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterImageSetStatus) DeepCopyInto(out *ClusterImageSetStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetStatus.
+func (in *ClusterImageSetStatus) DeepCopy() *ClusterImageSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterImageSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterIngress) DeepCopyInto(out *ClusterIngress) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouteSelector != nil {
+		in, out := &in.RouteSelector, &out.RouteSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EndpointPublishingStrategy != nil {
+		in, out := &in.EndpointPublishingStrategy, &out.EndpointPublishingStrategy
+		*out = new(IngressEndpointPublishingStrategy)
+		**out = **in
+	}
+	if in.NodePlacement != nil {
+		in, out := &in.NodePlacement, &out.NodePlacement
+		*out = new(IngressNodePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSSecurityProfile != nil {
+		in, out := &in.TLSSecurityProfile, &out.TLSSecurityProfile
+		*out = new(configv1.TLSSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIngress.
+func (in *ClusterIngress) DeepCopy() *ClusterIngress {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInstallCondition) DeepCopyInto(out *ClusterInstallCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInstallCondition.
+func (in *ClusterInstallCondition) DeepCopy() *ClusterInstallCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInstallCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInstallLocalReference) DeepCopyInto(out *ClusterInstallLocalReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInstallLocalReference.
+func (in *ClusterInstallLocalReference) DeepCopy() *ClusterInstallLocalReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInstallLocalReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMetadata) DeepCopyInto(out *ClusterMetadata) {
+	*out = *in
+	out.AdminKubeconfigSecretRef = in.AdminKubeconfigSecretRef
+	if in.AdminPasswordSecretRef != nil {
+		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Platform != nil {
+		in, out := &in.Platform, &out.Platform
+		*out = new(ClusterPlatformMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetadata.
+func (in *ClusterMetadata) DeepCopy() *ClusterMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperatorState) DeepCopyInto(out *ClusterOperatorState) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]configv1.ClusterOperatorStatusCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperatorState.
+func (in *ClusterOperatorState) DeepCopy() *ClusterOperatorState {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperatorState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPlatformMetadata) DeepCopyInto(out *ClusterPlatformMetadata) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(aws.Metadata)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(azure.Metadata)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(gcp.Metadata)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPlatformMetadata.
+func (in *ClusterPlatformMetadata) DeepCopy() *ClusterPlatformMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPlatformMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPool) DeepCopyInto(out *ClusterPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPool.
+func (in *ClusterPool) DeepCopy() *ClusterPool {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolClaimLifetime) DeepCopyInto(out *ClusterPoolClaimLifetime) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolClaimLifetime.
+func (in *ClusterPoolClaimLifetime) DeepCopy() *ClusterPoolClaimLifetime {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolClaimLifetime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolCondition) DeepCopyInto(out *ClusterPoolCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolCondition.
+func (in *ClusterPoolCondition) DeepCopy() *ClusterPoolCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolList) DeepCopyInto(out *ClusterPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolList.
+func (in *ClusterPoolList) DeepCopy() *ClusterPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolReference) DeepCopyInto(out *ClusterPoolReference) {
+	*out = *in
+	if in.ClaimedTimestamp != nil {
+		in, out := &in.ClaimedTimestamp, &out.ClaimedTimestamp
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolReference.
+func (in *ClusterPoolReference) DeepCopy() *ClusterPoolReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
+	*out = *in
+	in.Platform.DeepCopyInto(&out.Platform)
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+	out.ImageSetRef = in.ImageSetRef
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InstallConfigSecretTemplateRef != nil {
+		in, out := &in.InstallConfigSecretTemplateRef, &out.InstallConfigSecretTemplateRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.HostedCluster != nil {
+		in, out := &in.HostedCluster, &out.HostedCluster
+		*out = new(HostedClusterConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HibernateAfter != nil {
+		in, out := &in.HibernateAfter, &out.HibernateAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.InstallAttemptsLimit != nil {
+		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClaimLifetime != nil {
+		in, out := &in.ClaimLifetime, &out.ClaimLifetime
+		*out = new(ClusterPoolClaimLifetime)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BrokenClusterTimeout != nil {
+		in, out := &in.BrokenClusterTimeout, &out.BrokenClusterTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ClaimReleaseHooks != nil {
+		in, out := &in.ClaimReleaseHooks, &out.ClaimReleaseHooks
+		*out = make([]TeardownHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolSpec.
+func (in *ClusterPoolSpec) DeepCopy() *ClusterPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolStatus) DeepCopyInto(out *ClusterPoolStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterPoolCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolStatus.
+func (in *ClusterPoolStatus) DeepCopy() *ClusterPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvision) DeepCopyInto(out *ClusterProvision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvision.
+func (in *ClusterProvision) DeepCopy() *ClusterProvision {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterProvision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionCondition) DeepCopyInto(out *ClusterProvisionCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionCondition.
+func (in *ClusterProvisionCondition) DeepCopy() *ClusterProvisionCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionList) DeepCopyInto(out *ClusterProvisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterProvision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionList.
+func (in *ClusterProvisionList) DeepCopy() *ClusterProvisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterProvisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionSpec) DeepCopyInto(out *ClusterProvisionSpec) {
+	*out = *in
+	out.ClusterDeploymentRef = in.ClusterDeploymentRef
+	in.PodSpec.DeepCopyInto(&out.PodSpec)
+	if in.ClusterID != nil {
+		in, out := &in.ClusterID, &out.ClusterID
+		*out = new(string)
+		**out = **in
+	}
+	if in.InfraID != nil {
+		in, out := &in.InfraID, &out.InfraID
+		*out = new(string)
+		**out = **in
+	}
+	if in.InstallLog != nil {
+		in, out := &in.InstallLog, &out.InstallLog
+		*out = new(string)
+		**out = **in
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminKubeconfigSecretRef != nil {
+		in, out := &in.AdminKubeconfigSecretRef, &out.AdminKubeconfigSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.AdminPasswordSecretRef != nil {
+		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.PlatformStatus != nil {
+		in, out := &in.PlatformStatus, &out.PlatformStatus
+		*out = new(PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageBasedInstallProgress != nil {
+		in, out := &in.ImageBasedInstallProgress, &out.ImageBasedInstallProgress
+		*out = new(ImageBasedInstallProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrevClusterID != nil {
+		in, out := &in.PrevClusterID, &out.PrevClusterID
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrevInfraID != nil {
+		in, out := &in.PrevInfraID, &out.PrevInfraID
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrevProvisionName != nil {
+		in, out := &in.PrevProvisionName, &out.PrevProvisionName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionSpec.
+func (in *ClusterProvisionSpec) DeepCopy() *ClusterProvisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionStatus) DeepCopyInto(out *ClusterProvisionStatus) {
+	*out = *in
+	if in.JobRef != nil {
+		in, out := &in.JobRef, &out.JobRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterProvisionCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionStatus.
+func (in *ClusterProvisionStatus) DeepCopy() *ClusterProvisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProxy) DeepCopyInto(out *ClusterProxy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProxy.
+func (in *ClusterProxy) DeepCopy() *ClusterProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocate) DeepCopyInto(out *ClusterRelocate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocate.
+func (in *ClusterRelocate) DeepCopy() *ClusterRelocate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterRelocate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateList.
+func (in *ClusterRelocateList) DeepCopy() *ClusterRelocateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterRelocateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocateSpec) DeepCopyInto(out *ClusterRelocateSpec) {
+	*out = *in
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateSpec.
+func (in *ClusterRelocateSpec) DeepCopy() *ClusterRelocateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocateStatus) DeepCopyInto(out *ClusterRelocateStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateStatus.
+func (in *ClusterRelocateStatus) DeepCopy() *ClusterRelocateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterState) DeepCopyInto(out *ClusterState) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterState.
+func (in *ClusterState) DeepCopy() *ClusterState {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterState) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateList) DeepCopyInto(out *ClusterStateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateList.
+func (in *ClusterStateList) DeepCopy() *ClusterStateList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterStateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterImageSetStatus) DeepCopyInto(out *ClusterImageSetStatus) {
+func (in *ClusterStateSpec) DeepCopyInto(out *ClusterStateSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterImageSetStatus.
-func (in *ClusterImageSetStatus) DeepCopy() *ClusterImageSetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSpec.
+func (in *ClusterStateSpec) DeepCopy() *ClusterStateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterImageSetStatus)
+	out := new(ClusterStateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterIngress) DeepCopyInto(out *ClusterIngress) {
+func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
 	*out = *in
-	if in.NamespaceSelector != nil {
-		in, out := &in.NamespaceSelector, &out.NamespaceSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
 	}
-	if in.RouteSelector != nil {
-		in, out := &in.RouteSelector, &out.RouteSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+	if in.ClusterOperators != nil {
+		in, out := &in.ClusterOperators, &out.ClusterOperators
+		*out = make([]ClusterOperatorState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIngress.
-func (in *ClusterIngress) DeepCopy() *ClusterIngress {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateStatus.
+func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterIngress)
+	out := new(ClusterStateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterInstallCondition) DeepCopyInto(out *ClusterInstallCondition) {
+func (in *ControlPlaneAdditionalCertificate) DeepCopyInto(out *ControlPlaneAdditionalCertificate) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInstallCondition.
-func (in *ClusterInstallCondition) DeepCopy() *ClusterInstallCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneAdditionalCertificate.
+func (in *ControlPlaneAdditionalCertificate) DeepCopy() *ControlPlaneAdditionalCertificate {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterInstallCondition)
+	out := new(ControlPlaneAdditionalCertificate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterInstallLocalReference) DeepCopyInto(out *ClusterInstallLocalReference) {
+func (in *ControlPlaneConfigSpec) DeepCopyInto(out *ControlPlaneConfigSpec) {
 	*out = *in
+	in.ServingCertificates.DeepCopyInto(&out.ServingCertificates)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInstallLocalReference.
-func (in *ClusterInstallLocalReference) DeepCopy() *ClusterInstallLocalReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfigSpec.
+func (in *ControlPlaneConfigSpec) DeepCopy() *ControlPlaneConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterInstallLocalReference)
+	out := new(ControlPlaneConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterMetadata) DeepCopyInto(out *ClusterMetadata) {
+func (in *ControlPlaneCostEstimation) DeepCopyInto(out *ControlPlaneCostEstimation) {
 	*out = *in
-	out.AdminKubeconfigSecretRef = in.AdminKubeconfigSecretRef
-	if in.AdminPasswordSecretRef != nil {
-		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
-		*out = new(corev1.LocalObjectReference)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetadata.
-func (in *ClusterMetadata) DeepCopy() *ClusterMetadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneCostEstimation.
+func (in *ControlPlaneCostEstimation) DeepCopy() *ControlPlaneCostEstimation {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterMetadata)
+	out := new(ControlPlaneCostEstimation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterOperatorState) DeepCopyInto(out *ClusterOperatorState) {
+func (in *ControlPlaneMachineSpec) DeepCopyInto(out *ControlPlaneMachineSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]configv1.ClusterOperatorStatusCondition, len(*in))
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneMachineSpec.
+func (in *ControlPlaneMachineSpec) DeepCopy() *ControlPlaneMachineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneMachineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneServingCertificateSpec) DeepCopyInto(out *ControlPlaneServingCertificateSpec) {
+	*out = *in
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make([]ControlPlaneAdditionalCertificate, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneServingCertificateSpec.
+func (in *ControlPlaneServingCertificateSpec) DeepCopy() *ControlPlaneServingCertificateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneServingCertificateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerConfig) DeepCopyInto(out *ControllerConfig) {
+	*out = *in
+	if in.ConcurrentReconciles != nil {
+		in, out := &in.ConcurrentReconciles, &out.ConcurrentReconciles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClientQPS != nil {
+		in, out := &in.ClientQPS, &out.ClientQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ClientBurst != nil {
+		in, out := &in.ClientBurst, &out.ClientBurst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueueQPS != nil {
+		in, out := &in.QueueQPS, &out.QueueQPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueueBurst != nil {
+		in, out := &in.QueueBurst, &out.QueueBurst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StartupRampDuration != nil {
+		in, out := &in.StartupRampDuration, &out.StartupRampDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.UnreachableConcurrentReconciles != nil {
+		in, out := &in.UnreachableConcurrentReconciles, &out.UnreachableConcurrentReconciles
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerConfig.
+func (in *ControllerConfig) DeepCopy() *ControllerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ControllerNames) DeepCopyInto(out *ControllerNames) {
+	{
+		in := &in
+		*out = make(ControllerNames, len(*in))
+		copy(*out, *in)
+		return
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerNames.
+func (in ControllerNames) DeepCopy() ControllerNames {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerNames)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(ControllerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make([]SpecificControllerConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1187,96 +2576,138 @@ func (in *ClusterOperatorState) DeepCopyInto(out *ClusterOperatorState) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperatorState.
-func (in *ClusterOperatorState) DeepCopy() *ClusterOperatorState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllersConfig.
+func (in *ControllersConfig) DeepCopy() *ControllersConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterOperatorState)
+	out := new(ControllersConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPool) DeepCopyInto(out *ClusterPool) {
+func (in *CostEstimate) DeepCopyInto(out *CostEstimate) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.UnpricedInstanceTypes != nil {
+		in, out := &in.UnpricedInstanceTypes, &out.UnpricedInstanceTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPool.
-func (in *ClusterPool) DeepCopy() *ClusterPool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostEstimate.
+func (in *CostEstimate) DeepCopy() *CostEstimate {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPool)
+	out := new(CostEstimate)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterPool) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CostEstimationConfig) DeepCopyInto(out *CostEstimationConfig) {
+	*out = *in
+	if in.InstanceTypes != nil {
+		in, out := &in.InstanceTypes, &out.InstanceTypes
+		*out = make([]InstanceTypePrice, len(*in))
+		copy(*out, *in)
 	}
-	return nil
+	if in.ControlPlane != nil {
+		in, out := &in.ControlPlane, &out.ControlPlane
+		*out = new(ControlPlaneCostEstimation)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostEstimationConfig.
+func (in *CostEstimationConfig) DeepCopy() *CostEstimationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CostEstimationConfig)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolClaimLifetime) DeepCopyInto(out *ClusterPoolClaimLifetime) {
+func (in *CredentialsBrokerConfig) DeepCopyInto(out *CredentialsBrokerConfig) {
 	*out = *in
-	if in.Default != nil {
-		in, out := &in.Default, &out.Default
-		*out = new(metav1.Duration)
-		**out = **in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSCredentialsBrokerConfig)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Maximum != nil {
-		in, out := &in.Maximum, &out.Maximum
-		*out = new(metav1.Duration)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsBrokerConfig.
+func (in *CredentialsBrokerConfig) DeepCopy() *CredentialsBrokerConfig {
+	if in == nil {
+		return nil
 	}
+	out := new(CredentialsBrokerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolClaimLifetime.
-func (in *ClusterPoolClaimLifetime) DeepCopy() *ClusterPoolClaimLifetime {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
+func (in *DNSZone) DeepCopy() *DNSZone {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolClaimLifetime)
+	out := new(DNSZone)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolCondition) DeepCopyInto(out *ClusterPoolCondition) {
+func (in *DNSZoneCondition) DeepCopyInto(out *DNSZoneCondition) {
 	*out = *in
 	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolCondition.
-func (in *ClusterPoolCondition) DeepCopy() *ClusterPoolCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneCondition.
+func (in *DNSZoneCondition) DeepCopy() *DNSZoneCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolCondition)
+	out := new(DNSZoneCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolList) DeepCopyInto(out *ClusterPoolList) {
+func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterPool, len(*in))
+		*out = make([]DNSZone, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1284,18 +2715,18 @@ func (in *ClusterPoolList) DeepCopyInto(out *ClusterPoolList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolList.
-func (in *ClusterPoolList) DeepCopy() *ClusterPoolList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneList.
+func (in *DNSZoneList) DeepCopy() *DNSZoneList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolList)
+	out := new(DNSZoneList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterPoolList) DeepCopyObject() runtime.Object {
+func (in *DNSZoneList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1303,402 +2734,355 @@ func (in *ClusterPoolList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolReference) DeepCopyInto(out *ClusterPoolReference) {
+func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
 	*out = *in
-	if in.ClaimedTimestamp != nil {
-		in, out := &in.ClaimedTimestamp, &out.ClaimedTimestamp
-		*out = (*in).DeepCopy()
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSDNSZoneSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPDNSZoneSpec)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureDNSZoneSpec)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolReference.
-func (in *ClusterPoolReference) DeepCopy() *ClusterPoolReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneSpec.
+func (in *DNSZoneSpec) DeepCopy() *DNSZoneSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolReference)
+	out := new(DNSZoneSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
+func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
 	*out = *in
-	in.Platform.DeepCopyInto(&out.Platform)
-	if in.PullSecretRef != nil {
-		in, out := &in.PullSecretRef, &out.PullSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.MaxSize != nil {
-		in, out := &in.MaxSize, &out.MaxSize
-		*out = new(int32)
-		**out = **in
-	}
-	if in.MaxConcurrent != nil {
-		in, out := &in.MaxConcurrent, &out.MaxConcurrent
-		*out = new(int32)
-		**out = **in
+	if in.LastSyncTimestamp != nil {
+		in, out := &in.LastSyncTimestamp, &out.LastSyncTimestamp
+		*out = (*in).DeepCopy()
 	}
-	out.ImageSetRef = in.ImageSetRef
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.NextSyncTimestamp != nil {
+		in, out := &in.NextSyncTimestamp, &out.NextSyncTimestamp
+		*out = (*in).DeepCopy()
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.NameServers != nil {
+		in, out := &in.NameServers, &out.NameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.InstallConfigSecretTemplateRef != nil {
-		in, out := &in.InstallConfigSecretTemplateRef, &out.InstallConfigSecretTemplateRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSDNSZoneStatus)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.HibernateAfter != nil {
-		in, out := &in.HibernateAfter, &out.HibernateAfter
-		*out = new(metav1.Duration)
-		**out = **in
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPDNSZoneStatus)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.InstallAttemptsLimit != nil {
-		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
-		*out = new(int32)
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureDNSZoneStatus)
 		**out = **in
 	}
-	if in.ClaimLifetime != nil {
-		in, out := &in.ClaimLifetime, &out.ClaimLifetime
-		*out = new(ClusterPoolClaimLifetime)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DNSZoneCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolSpec.
-func (in *ClusterPoolSpec) DeepCopy() *ClusterPoolSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneStatus.
+func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolSpec)
+	out := new(DNSZoneStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterPoolStatus) DeepCopyInto(out *ClusterPoolStatus) {
+func (in *DeletionProtection) DeepCopyInto(out *DeletionProtection) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterPoolCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolStatus.
-func (in *ClusterPoolStatus) DeepCopy() *ClusterPoolStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionProtection.
+func (in *DeletionProtection) DeepCopy() *DeletionProtection {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterPoolStatus)
+	out := new(DeletionProtection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvision) DeepCopyInto(out *ClusterProvision) {
+func (in *ExecutionClusterConfig) DeepCopyInto(out *ExecutionClusterConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+	out.HubKubeconfigSecretRef = in.HubKubeconfigSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvision.
-func (in *ClusterProvision) DeepCopy() *ClusterProvision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionClusterConfig.
+func (in *ExecutionClusterConfig) DeepCopy() *ExecutionClusterConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterProvision)
+	out := new(ExecutionClusterConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterProvision) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvisionCondition) DeepCopyInto(out *ClusterProvisionCondition) {
+func (in *ExternalSecretsConfig) DeepCopyInto(out *ExternalSecretsConfig) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultSecretProviderConfig)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionCondition.
-func (in *ClusterProvisionCondition) DeepCopy() *ClusterProvisionCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretsConfig.
+func (in *ExternalSecretsConfig) DeepCopy() *ExternalSecretsConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterProvisionCondition)
+	out := new(ExternalSecretsConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvisionList) DeepCopyInto(out *ClusterProvisionList) {
+func (in *FailedProvisionAWSConfig) DeepCopyInto(out *FailedProvisionAWSConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterProvision, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionList.
-func (in *ClusterProvisionList) DeepCopy() *ClusterProvisionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAWSConfig.
+func (in *FailedProvisionAWSConfig) DeepCopy() *FailedProvisionAWSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterProvisionList)
+	out := new(FailedProvisionAWSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterProvisionList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvisionSpec) DeepCopyInto(out *ClusterProvisionSpec) {
+func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 	*out = *in
-	out.ClusterDeploymentRef = in.ClusterDeploymentRef
-	in.PodSpec.DeepCopyInto(&out.PodSpec)
-	if in.ClusterID != nil {
-		in, out := &in.ClusterID, &out.ClusterID
-		*out = new(string)
-		**out = **in
-	}
-	if in.InfraID != nil {
-		in, out := &in.InfraID, &out.InfraID
-		*out = new(string)
-		**out = **in
-	}
-	if in.InstallLog != nil {
-		in, out := &in.InstallLog, &out.InstallLog
-		*out = new(string)
-		**out = **in
-	}
-	if in.Metadata != nil {
-		in, out := &in.Metadata, &out.Metadata
-		*out = new(runtime.RawExtension)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AdminKubeconfigSecretRef != nil {
-		in, out := &in.AdminKubeconfigSecretRef, &out.AdminKubeconfigSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.AdminPasswordSecretRef != nil {
-		in, out := &in.AdminPasswordSecretRef, &out.AdminPasswordSecretRef
-		*out = new(corev1.LocalObjectReference)
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(FailedProvisionAWSConfig)
 		**out = **in
 	}
-	if in.PrevClusterID != nil {
-		in, out := &in.PrevClusterID, &out.PrevClusterID
-		*out = new(string)
-		**out = **in
+	if in.RetryReasons != nil {
+		in, out := &in.RetryReasons, &out.RetryReasons
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
 	}
-	if in.PrevInfraID != nil {
-		in, out := &in.PrevInfraID, &out.PrevInfraID
-		*out = new(string)
-		**out = **in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionConfig.
+func (in *FailedProvisionConfig) DeepCopy() *FailedProvisionConfig {
+	if in == nil {
+		return nil
 	}
-	if in.PrevProvisionName != nil {
-		in, out := &in.PrevProvisionName, &out.PrevProvisionName
-		*out = new(string)
-		**out = **in
+	out := new(FailedProvisionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureGateSelection) DeepCopyInto(out *FeatureGateSelection) {
+	*out = *in
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(FeatureGatesEnabled)
+		(*in).DeepCopyInto(*out)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionSpec.
-func (in *ClusterProvisionSpec) DeepCopy() *ClusterProvisionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGateSelection.
+func (in *FeatureGateSelection) DeepCopy() *FeatureGateSelection {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterProvisionSpec)
+	out := new(FeatureGateSelection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterProvisionStatus) DeepCopyInto(out *ClusterProvisionStatus) {
+func (in *FeatureGatesEnabled) DeepCopyInto(out *FeatureGatesEnabled) {
 	*out = *in
-	if in.JobRef != nil {
-		in, out := &in.JobRef, &out.JobRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterProvisionCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionStatus.
-func (in *ClusterProvisionStatus) DeepCopy() *ClusterProvisionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGatesEnabled.
+func (in *FeatureGatesEnabled) DeepCopy() *FeatureGatesEnabled {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterProvisionStatus)
+	out := new(FeatureGatesEnabled)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocate) DeepCopyInto(out *ClusterRelocate) {
+func (in *GCPClusterDeprovision) DeepCopyInto(out *GCPClusterDeprovision) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocate.
-func (in *ClusterRelocate) DeepCopy() *ClusterRelocate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterDeprovision.
+func (in *GCPClusterDeprovision) DeepCopy() *GCPClusterDeprovision {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocate)
+	out := new(GCPClusterDeprovision)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPDNSZoneSpec) DeepCopyInto(out *GCPDNSZoneSpec) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneSpec.
+func (in *GCPDNSZoneSpec) DeepCopy() *GCPDNSZoneSpec {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(GCPDNSZoneSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
+func (in *GCPDNSZoneStatus) DeepCopyInto(out *GCPDNSZoneStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ClusterRelocate, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ZoneName != nil {
+		in, out := &in.ZoneName, &out.ZoneName
+		*out = new(string)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateList.
-func (in *ClusterRelocateList) DeepCopy() *ClusterRelocateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneStatus.
+func (in *GCPDNSZoneStatus) DeepCopy() *GCPDNSZoneStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateList)
+	out := new(GCPDNSZoneStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterRelocateList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateSpec) DeepCopyInto(out *ClusterRelocateSpec) {
+func (in *GCPKMSConfig) DeepCopyInto(out *GCPKMSConfig) {
 	*out = *in
-	out.KubeconfigSecretRef = in.KubeconfigSecretRef
-	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateSpec.
-func (in *ClusterRelocateSpec) DeepCopy() *ClusterRelocateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPKMSConfig.
+func (in *GCPKMSConfig) DeepCopy() *GCPKMSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateSpec)
+	out := new(GCPKMSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterRelocateStatus) DeepCopyInto(out *ClusterRelocateStatus) {
+func (in *GarbageCollectionConfig) DeepCopyInto(out *GarbageCollectionConfig) {
 	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateStatus.
-func (in *ClusterRelocateStatus) DeepCopy() *ClusterRelocateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GarbageCollectionConfig.
+func (in *GarbageCollectionConfig) DeepCopy() *GarbageCollectionConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterRelocateStatus)
+	out := new(GarbageCollectionConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterState) DeepCopyInto(out *ClusterState) {
+func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterState.
-func (in *ClusterState) DeepCopy() *ClusterState {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfig.
+func (in *HiveConfig) DeepCopy() *HiveConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterState)
+	out := new(HiveConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterState) DeepCopyObject() runtime.Object {
+func (in *HiveConfig) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1706,13 +3090,31 @@ func (in *ClusterState) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateList) DeepCopyInto(out *ClusterStateList) {
+func (in *HiveConfigCondition) DeepCopyInto(out *HiveConfigCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigCondition.
+func (in *HiveConfigCondition) DeepCopy() *HiveConfigCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveConfigCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveConfigList) DeepCopyInto(out *HiveConfigList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterState, len(*in))
+		*out = make([]HiveConfig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1720,50 +3122,177 @@ func (in *ClusterStateList) DeepCopyInto(out *ClusterStateList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateList.
-func (in *ClusterStateList) DeepCopy() *ClusterStateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigList.
+func (in *HiveConfigList) DeepCopy() *HiveConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateList)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterStateList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+	out := new(HiveConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HiveConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
+	*out = *in
+	if in.ManagedDomains != nil {
+		in, out := &in.ManagedDomains, &out.ManagedDomains
+		*out = make([]ManageDNSConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalCertificateAuthoritiesSecretRef != nil {
+		in, out := &in.AdditionalCertificateAuthoritiesSecretRef, &out.AdditionalCertificateAuthoritiesSecretRef
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.GlobalPullSecretRef != nil {
+		in, out := &in.GlobalPullSecretRef, &out.GlobalPullSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	in.Backup.DeepCopyInto(&out.Backup)
+	in.FailedProvisionConfig.DeepCopyInto(&out.FailedProvisionConfig)
+	in.ServiceProviderCredentialsConfig.DeepCopyInto(&out.ServiceProviderCredentialsConfig)
+	if in.MaintenanceMode != nil {
+		in, out := &in.MaintenanceMode, &out.MaintenanceMode
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeprovisionsDisabled != nil {
+		in, out := &in.DeprovisionsDisabled, &out.DeprovisionsDisabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisabledControllers != nil {
+		in, out := &in.DisabledControllers, &out.DisabledControllers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControllersConfig != nil {
+		in, out := &in.ControllersConfig, &out.ControllersConfig
+		*out = new(ControllersConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSPrivateLink != nil {
+		in, out := &in.AWSPrivateLink, &out.AWSPrivateLink
+		*out = new(AWSPrivateLinkConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReleaseImageVerificationConfigMapRef != nil {
+		in, out := &in.ReleaseImageVerificationConfigMapRef, &out.ReleaseImageVerificationConfigMapRef
+		*out = new(ReleaseImageVerificationConfigMapReference)
+		**out = **in
+	}
+	out.ArgoCD = in.ArgoCD
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = new(FeatureGateSelection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CostEstimation != nil {
+		in, out := &in.CostEstimation, &out.CostEstimation
+		*out = new(CostEstimationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(NotificationsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalSecrets != nil {
+		in, out := &in.ExternalSecrets, &out.ExternalSecrets
+		*out = new(ExternalSecretsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsBroker != nil {
+		in, out := &in.CredentialsBroker, &out.CredentialsBroker
+		*out = new(CredentialsBrokerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallManagerImageOverrides != nil {
+		in, out := &in.InstallManagerImageOverrides, &out.InstallManagerImageOverrides
+		*out = make([]InstallManagerImageOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExecutionCluster != nil {
+		in, out := &in.ExecutionCluster, &out.ExecutionCluster
+		*out = new(ExecutionClusterConfig)
+		**out = **in
+	}
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(GarbageCollectionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SpokeStatus != nil {
+		in, out := &in.SpokeStatus, &out.SpokeStatus
+		*out = new(SpokeStatusConfig)
+		**out = **in
+	}
+	if in.DeletionBlockedTimeout != nil {
+		in, out := &in.DeletionBlockedTimeout, &out.DeletionBlockedTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.LogArchive != nil {
+		in, out := &in.LogArchive, &out.LogArchive
+		*out = new(LogArchiveConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SensitiveArtifacts != nil {
+		in, out := &in.SensitiveArtifacts, &out.SensitiveArtifacts
+		*out = new(SensitiveArtifactsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminSecretEncryption != nil {
+		in, out := &in.AdminSecretEncryption, &out.AdminSecretEncryption
+		*out = new(AdminSecretEncryptionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWSClient != nil {
+		in, out := &in.AWSClient, &out.AWSClient
+		*out = new(AWSClientConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachinePoolDefaults != nil {
+		in, out := &in.MachinePoolDefaults, &out.MachinePoolDefaults
+		*out = new(MachinePoolDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterAccessTokens != nil {
+		in, out := &in.ClusterAccessTokens, &out.ClusterAccessTokens
+		*out = new(ClusterAccessTokensConfig)
+		(*in).DeepCopyInto(*out)
 	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateSpec) DeepCopyInto(out *ClusterStateSpec) {
-	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateSpec.
-func (in *ClusterStateSpec) DeepCopy() *ClusterStateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigSpec.
+func (in *HiveConfigSpec) DeepCopy() *HiveConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateSpec)
+	out := new(HiveConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
+func (in *HiveConfigStatus) DeepCopyInto(out *HiveConfigStatus) {
 	*out = *in
-	if in.LastUpdated != nil {
-		in, out := &in.LastUpdated, &out.LastUpdated
-		*out = (*in).DeepCopy()
-	}
-	if in.ClusterOperators != nil {
-		in, out := &in.ClusterOperators, &out.ClusterOperators
-		*out = make([]ClusterOperatorState, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]HiveConfigCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1771,147 +3300,163 @@ func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateStatus.
-func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigStatus.
+func (in *HiveConfigStatus) DeepCopy() *HiveConfigStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateStatus)
+	out := new(HiveConfigStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneAdditionalCertificate) DeepCopyInto(out *ControlPlaneAdditionalCertificate) {
+func (in *HostInventory) DeepCopyInto(out *HostInventory) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneAdditionalCertificate.
-func (in *ControlPlaneAdditionalCertificate) DeepCopy() *ControlPlaneAdditionalCertificate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostInventory.
+func (in *HostInventory) DeepCopy() *HostInventory {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneAdditionalCertificate)
+	out := new(HostInventory)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostInventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneConfigSpec) DeepCopyInto(out *ControlPlaneConfigSpec) {
+func (in *HostInventoryCondition) DeepCopyInto(out *HostInventoryCondition) {
 	*out = *in
-	in.ServingCertificates.DeepCopyInto(&out.ServingCertificates)
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneConfigSpec.
-func (in *ControlPlaneConfigSpec) DeepCopy() *ControlPlaneConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostInventoryCondition.
+func (in *HostInventoryCondition) DeepCopy() *HostInventoryCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneConfigSpec)
+	out := new(HostInventoryCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneServingCertificateSpec) DeepCopyInto(out *ControlPlaneServingCertificateSpec) {
+func (in *HostInventoryList) DeepCopyInto(out *HostInventoryList) {
 	*out = *in
-	if in.Additional != nil {
-		in, out := &in.Additional, &out.Additional
-		*out = make([]ControlPlaneAdditionalCertificate, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneServingCertificateSpec.
-func (in *ControlPlaneServingCertificateSpec) DeepCopy() *ControlPlaneServingCertificateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostInventoryList.
+func (in *HostInventoryList) DeepCopy() *HostInventoryList {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneServingCertificateSpec)
+	out := new(HostInventoryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostInventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllerConfig) DeepCopyInto(out *ControllerConfig) {
+func (in *HostInventorySpec) DeepCopyInto(out *HostInventorySpec) {
 	*out = *in
-	if in.ConcurrentReconciles != nil {
-		in, out := &in.ConcurrentReconciles, &out.ConcurrentReconciles
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ClientQPS != nil {
-		in, out := &in.ClientQPS, &out.ClientQPS
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ClientBurst != nil {
-		in, out := &in.ClientBurst, &out.ClientBurst
-		*out = new(int32)
-		**out = **in
-	}
-	if in.QueueQPS != nil {
-		in, out := &in.QueueQPS, &out.QueueQPS
-		*out = new(int32)
-		**out = **in
+	out.ClusterDeploymentRef = in.ClusterDeploymentRef
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.QueueBurst != nil {
-		in, out := &in.QueueBurst, &out.QueueBurst
-		*out = new(int32)
-		**out = **in
+	if in.NMStateConfigLabels != nil {
+		in, out := &in.NMStateConfigLabels, &out.NMStateConfigLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]InventoryHost, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerConfig.
-func (in *ControllerConfig) DeepCopy() *ControllerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostInventorySpec.
+func (in *HostInventorySpec) DeepCopy() *HostInventorySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerConfig)
+	out := new(HostInventorySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in ControllerNames) DeepCopyInto(out *ControllerNames) {
-	{
-		in := &in
-		*out = make(ControllerNames, len(*in))
-		copy(*out, *in)
-		return
+func (in *HostInventoryStatus) DeepCopyInto(out *HostInventoryStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]HostInventoryCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
+	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerNames.
-func (in ControllerNames) DeepCopy() ControllerNames {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostInventoryStatus.
+func (in *HostInventoryStatus) DeepCopy() *HostInventoryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllerNames)
+	out := new(HostInventoryStatus)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
+func (in *HostedClusterConfig) DeepCopyInto(out *HostedClusterConfig) {
 	*out = *in
-	if in.Default != nil {
-		in, out := &in.Default, &out.Default
-		*out = new(ControllerConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Controllers != nil {
-		in, out := &in.Controllers, &out.Controllers
-		*out = make([]SpecificControllerConfig, len(*in))
+	in.HostedClusterSpec.DeepCopyInto(&out.HostedClusterSpec)
+	if in.NodePools != nil {
+		in, out := &in.NodePools, &out.NodePools
+		*out = make([]HostedClusterNodePool, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1919,18 +3464,18 @@ func (in *ControllersConfig) DeepCopyInto(out *ControllersConfig) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllersConfig.
-func (in *ControllersConfig) DeepCopy() *ControllersConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterConfig.
+func (in *HostedClusterConfig) DeepCopy() *HostedClusterConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ControllersConfig)
+	out := new(HostedClusterConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZone) DeepCopyInto(out *DNSZone) {
+func (in *HostedClusterInstall) DeepCopyInto(out *HostedClusterInstall) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1939,18 +3484,18 @@ func (in *DNSZone) DeepCopyInto(out *DNSZone) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZone.
-func (in *DNSZone) DeepCopy() *DNSZone {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterInstall.
+func (in *HostedClusterInstall) DeepCopy() *HostedClusterInstall {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZone)
+	out := new(HostedClusterInstall)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSZone) DeepCopyObject() runtime.Object {
+func (in *HostedClusterInstall) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1958,31 +3503,13 @@ func (in *DNSZone) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneCondition) DeepCopyInto(out *DNSZoneCondition) {
-	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneCondition.
-func (in *DNSZoneCondition) DeepCopy() *DNSZoneCondition {
-	if in == nil {
-		return nil
-	}
-	out := new(DNSZoneCondition)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
+func (in *HostedClusterInstallList) DeepCopyInto(out *HostedClusterInstallList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DNSZone, len(*in))
+		*out = make([]HostedClusterInstall, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1990,18 +3517,18 @@ func (in *DNSZoneList) DeepCopyInto(out *DNSZoneList) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneList.
-func (in *DNSZoneList) DeepCopy() *DNSZoneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterInstallList.
+func (in *HostedClusterInstallList) DeepCopy() *HostedClusterInstallList {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneList)
+	out := new(HostedClusterInstallList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSZoneList) DeepCopyObject() runtime.Object {
+func (in *HostedClusterInstallList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2009,433 +3536,399 @@ func (in *DNSZoneList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneSpec) DeepCopyInto(out *DNSZoneSpec) {
+func (in *HostedClusterInstallSpec) DeepCopyInto(out *HostedClusterInstallSpec) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSDNSZoneSpec)
+	out.ClusterDeploymentRef = in.ClusterDeploymentRef
+	out.ImageSetRef = in.ImageSetRef
+	if in.ClusterMetadata != nil {
+		in, out := &in.ClusterMetadata, &out.ClusterMetadata
+		*out = new(ClusterMetadata)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(GCPDNSZoneSpec)
-		**out = **in
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureDNSZoneSpec)
-		**out = **in
-	}
+	in.HostedClusterConfig.DeepCopyInto(&out.HostedClusterConfig)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneSpec.
-func (in *DNSZoneSpec) DeepCopy() *DNSZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterInstallSpec.
+func (in *HostedClusterInstallSpec) DeepCopy() *HostedClusterInstallSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneSpec)
+	out := new(HostedClusterInstallSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSZoneStatus) DeepCopyInto(out *DNSZoneStatus) {
+func (in *HostedClusterInstallStatus) DeepCopyInto(out *HostedClusterInstallStatus) {
 	*out = *in
-	if in.LastSyncTimestamp != nil {
-		in, out := &in.LastSyncTimestamp, &out.LastSyncTimestamp
-		*out = (*in).DeepCopy()
-	}
-	if in.NameServers != nil {
-		in, out := &in.NameServers, &out.NameServers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(AWSDNSZoneStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.GCP != nil {
-		in, out := &in.GCP, &out.GCP
-		*out = new(GCPDNSZoneStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(AzureDNSZoneStatus)
-		**out = **in
-	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]DNSZoneCondition, len(*in))
+		*out = make([]ClusterInstallCondition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HostedClusterRef != nil {
+		in, out := &in.HostedClusterRef, &out.HostedClusterRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterInstallStatus.
+func (in *HostedClusterInstallStatus) DeepCopy() *HostedClusterInstallStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedClusterInstallStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedClusterNodePool) DeepCopyInto(out *HostedClusterNodePool) {
+	*out = *in
+	in.Platform.DeepCopyInto(&out.Platform)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneStatus.
-func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterNodePool.
+func (in *HostedClusterNodePool) DeepCopy() *HostedClusterNodePool {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSZoneStatus)
+	out := new(HostedClusterNodePool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailedProvisionAWSConfig) DeepCopyInto(out *FailedProvisionAWSConfig) {
+func (in *IdentityProviderStatus) DeepCopyInto(out *IdentityProviderStatus) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAWSConfig.
-func (in *FailedProvisionAWSConfig) DeepCopy() *FailedProvisionAWSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderStatus.
+func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FailedProvisionAWSConfig)
+	out := new(IdentityProviderStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
+func (in *ImageBasedInstall) DeepCopyInto(out *ImageBasedInstall) {
 	*out = *in
-	if in.AWS != nil {
-		in, out := &in.AWS, &out.AWS
-		*out = new(FailedProvisionAWSConfig)
-		**out = **in
-	}
-	if in.RetryReasons != nil {
-		in, out := &in.RetryReasons, &out.RetryReasons
-		*out = new([]string)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]string, len(*in))
-			copy(*out, *in)
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionConfig.
-func (in *FailedProvisionConfig) DeepCopy() *FailedProvisionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageBasedInstall.
+func (in *ImageBasedInstall) DeepCopy() *ImageBasedInstall {
 	if in == nil {
 		return nil
 	}
-	out := new(FailedProvisionConfig)
+	out := new(ImageBasedInstall)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FeatureGateSelection) DeepCopyInto(out *FeatureGateSelection) {
+func (in *ImageBasedInstallProgress) DeepCopyInto(out *ImageBasedInstallProgress) {
 	*out = *in
-	if in.Custom != nil {
-		in, out := &in.Custom, &out.Custom
-		*out = new(FeatureGatesEnabled)
-		(*in).DeepCopyInto(*out)
-	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGateSelection.
-func (in *FeatureGateSelection) DeepCopy() *FeatureGateSelection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageBasedInstallProgress.
+func (in *ImageBasedInstallProgress) DeepCopy() *ImageBasedInstallProgress {
 	if in == nil {
 		return nil
 	}
-	out := new(FeatureGateSelection)
+	out := new(ImageBasedInstallProgress)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FeatureGatesEnabled) DeepCopyInto(out *FeatureGatesEnabled) {
+func (in *IngressEndpointPublishingStrategy) DeepCopyInto(out *IngressEndpointPublishingStrategy) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureGatesEnabled.
-func (in *FeatureGatesEnabled) DeepCopy() *FeatureGatesEnabled {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressEndpointPublishingStrategy.
+func (in *IngressEndpointPublishingStrategy) DeepCopy() *IngressEndpointPublishingStrategy {
 	if in == nil {
 		return nil
 	}
-	out := new(FeatureGatesEnabled)
+	out := new(IngressEndpointPublishingStrategy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterDeprovision) DeepCopyInto(out *GCPClusterDeprovision) {
+func (in *IngressNodePlacement) DeepCopyInto(out *IngressNodePlacement) {
 	*out = *in
-	if in.CredentialsSecretRef != nil {
-		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterDeprovision.
-func (in *GCPClusterDeprovision) DeepCopy() *GCPClusterDeprovision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressNodePlacement.
+func (in *IngressNodePlacement) DeepCopy() *IngressNodePlacement {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterDeprovision)
+	out := new(IngressNodePlacement)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPDNSZoneSpec) DeepCopyInto(out *GCPDNSZoneSpec) {
+func (in *InstallManagerImageOverride) DeepCopyInto(out *InstallManagerImageOverride) {
 	*out = *in
-	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneSpec.
-func (in *GCPDNSZoneSpec) DeepCopy() *GCPDNSZoneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallManagerImageOverride.
+func (in *InstallManagerImageOverride) DeepCopy() *InstallManagerImageOverride {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPDNSZoneSpec)
+	out := new(InstallManagerImageOverride)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPDNSZoneStatus) DeepCopyInto(out *GCPDNSZoneStatus) {
+func (in *InstanceFamilyQuota) DeepCopyInto(out *InstanceFamilyQuota) {
 	*out = *in
-	if in.ZoneName != nil {
-		in, out := &in.ZoneName, &out.ZoneName
-		*out = new(string)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPDNSZoneStatus.
-func (in *GCPDNSZoneStatus) DeepCopy() *GCPDNSZoneStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceFamilyQuota.
+func (in *InstanceFamilyQuota) DeepCopy() *InstanceFamilyQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPDNSZoneStatus)
+	out := new(InstanceFamilyQuota)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
+func (in *InstanceFamilyUsage) DeepCopyInto(out *InstanceFamilyUsage) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfig.
-func (in *HiveConfig) DeepCopy() *HiveConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceFamilyUsage.
+func (in *InstanceFamilyUsage) DeepCopy() *InstanceFamilyUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfig)
+	out := new(InstanceFamilyUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HiveConfig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigCondition) DeepCopyInto(out *HiveConfigCondition) {
+func (in *InstanceTypePrice) DeepCopyInto(out *InstanceTypePrice) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigCondition.
-func (in *HiveConfigCondition) DeepCopy() *HiveConfigCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceTypePrice.
+func (in *InstanceTypePrice) DeepCopy() *InstanceTypePrice {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigCondition)
+	out := new(InstanceTypePrice)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigList) DeepCopyInto(out *HiveConfigList) {
+func (in *InventoryHost) DeepCopyInto(out *InventoryHost) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]HiveConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]InventoryHostInterface, len(*in))
+		copy(*out, *in)
+	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigList.
-func (in *HiveConfigList) DeepCopy() *HiveConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryHost.
+func (in *InventoryHost) DeepCopy() *InventoryHost {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigList)
+	out := new(InventoryHost)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HiveConfigList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryHostInterface) DeepCopyInto(out *InventoryHostInterface) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryHostInterface.
+func (in *InventoryHostInterface) DeepCopy() *InventoryHostInterface {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(InventoryHostInterface)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
+func (in *KMSConfig) DeepCopyInto(out *KMSConfig) {
 	*out = *in
-	if in.ManagedDomains != nil {
-		in, out := &in.ManagedDomains, &out.ManagedDomains
-		*out = make([]ManageDNSConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.AdditionalCertificateAuthoritiesSecretRef != nil {
-		in, out := &in.AdditionalCertificateAuthoritiesSecretRef, &out.AdditionalCertificateAuthoritiesSecretRef
-		*out = make([]corev1.LocalObjectReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.GlobalPullSecretRef != nil {
-		in, out := &in.GlobalPullSecretRef, &out.GlobalPullSecretRef
-		*out = new(corev1.LocalObjectReference)
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSKMSConfig)
 		**out = **in
 	}
-	in.Backup.DeepCopyInto(&out.Backup)
-	in.FailedProvisionConfig.DeepCopyInto(&out.FailedProvisionConfig)
-	in.ServiceProviderCredentialsConfig.DeepCopyInto(&out.ServiceProviderCredentialsConfig)
-	if in.MaintenanceMode != nil {
-		in, out := &in.MaintenanceMode, &out.MaintenanceMode
-		*out = new(bool)
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPKMSConfig)
 		**out = **in
 	}
-	if in.DeprovisionsDisabled != nil {
-		in, out := &in.DeprovisionsDisabled, &out.DeprovisionsDisabled
-		*out = new(bool)
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureKeyVaultConfig)
 		**out = **in
 	}
-	if in.DisabledControllers != nil {
-		in, out := &in.DisabledControllers, &out.DisabledControllers
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ControllersConfig != nil {
-		in, out := &in.ControllersConfig, &out.ControllersConfig
-		*out = new(ControllersConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AWSPrivateLink != nil {
-		in, out := &in.AWSPrivateLink, &out.AWSPrivateLink
-		*out = new(AWSPrivateLinkConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ReleaseImageVerificationConfigMapRef != nil {
-		in, out := &in.ReleaseImageVerificationConfigMapRef, &out.ReleaseImageVerificationConfigMapRef
-		*out = new(ReleaseImageVerificationConfigMapReference)
+	if in.Local != nil {
+		in, out := &in.Local, &out.Local
+		*out = new(LocalKeyConfig)
 		**out = **in
 	}
-	out.ArgoCD = in.ArgoCD
-	if in.FeatureGates != nil {
-		in, out := &in.FeatureGates, &out.FeatureGates
-		*out = new(FeatureGateSelection)
-		(*in).DeepCopyInto(*out)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSConfig.
+func (in *KMSConfig) DeepCopy() *KMSConfig {
+	if in == nil {
+		return nil
 	}
+	out := new(KMSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigSecretReference) DeepCopyInto(out *KubeconfigSecretReference) {
+	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigSpec.
-func (in *HiveConfigSpec) DeepCopy() *HiveConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigSecretReference.
+func (in *KubeconfigSecretReference) DeepCopy() *KubeconfigSecretReference {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigSpec)
+	out := new(KubeconfigSecretReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HiveConfigStatus) DeepCopyInto(out *HiveConfigStatus) {
+func (in *LocalKeyConfig) DeepCopyInto(out *LocalKeyConfig) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]HiveConfigCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out.SecretRef = in.SecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalKeyConfig.
+func (in *LocalKeyConfig) DeepCopy() *LocalKeyConfig {
+	if in == nil {
+		return nil
 	}
+	out := new(LocalKeyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogArchiveAWSConfig) DeepCopyInto(out *LogArchiveAWSConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveConfigStatus.
-func (in *HiveConfigStatus) DeepCopy() *HiveConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogArchiveAWSConfig.
+func (in *LogArchiveAWSConfig) DeepCopy() *LogArchiveAWSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(HiveConfigStatus)
+	out := new(LogArchiveAWSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IdentityProviderStatus) DeepCopyInto(out *IdentityProviderStatus) {
+func (in *LogArchiveConfig) DeepCopyInto(out *LogArchiveConfig) {
 	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(LogArchiveAWSConfig)
+		**out = **in
+	}
+	if in.RetentionDays != nil {
+		in, out := &in.RetentionDays, &out.RetentionDays
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdentityProviderStatus.
-func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogArchiveConfig.
+func (in *LogArchiveConfig) DeepCopy() *LogArchiveConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(IdentityProviderStatus)
+	out := new(LogArchiveConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigSecretReference) DeepCopyInto(out *KubeconfigSecretReference) {
+func (in *MachinePhaseCounts) DeepCopyInto(out *MachinePhaseCounts) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigSecretReference.
-func (in *KubeconfigSecretReference) DeepCopy() *KubeconfigSecretReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePhaseCounts.
+func (in *MachinePhaseCounts) DeepCopy() *MachinePhaseCounts {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeconfigSecretReference)
+	out := new(MachinePhaseCounts)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2479,25 +3972,62 @@ func (in *MachinePoolAutoscaling) DeepCopy() *MachinePoolAutoscaling {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolAutoscaling)
+	out := new(MachinePoolAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolCondition) DeepCopyInto(out *MachinePoolCondition) {
+	*out = *in
+	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolCondition.
+func (in *MachinePoolCondition) DeepCopy() *MachinePoolCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MachinePoolCondition) DeepCopyInto(out *MachinePoolCondition) {
+func (in *MachinePoolDefaults) DeepCopyInto(out *MachinePoolDefaults) {
 	*out = *in
-	in.LastProbeTime.DeepCopyInto(&out.LastProbeTime)
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]corev1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UserTags != nil {
+		in, out := &in.UserTags, &out.UserTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolCondition.
-func (in *MachinePoolCondition) DeepCopy() *MachinePoolCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolDefaults.
+func (in *MachinePoolDefaults) DeepCopy() *MachinePoolDefaults {
 	if in == nil {
 		return nil
 	}
-	out := new(MachinePoolCondition)
+	out := new(MachinePoolDefaults)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2535,6 +4065,54 @@ func (in *MachinePoolList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolMachineConfig) DeepCopyInto(out *MachinePoolMachineConfig) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolMachineConfig.
+func (in *MachinePoolMachineConfig) DeepCopy() *MachinePoolMachineConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolMachineConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolMachineHealthCheck) DeepCopyInto(out *MachinePoolMachineHealthCheck) {
+	*out = *in
+	if in.UnhealthyConditions != nil {
+		in, out := &in.UnhealthyConditions, &out.UnhealthyConditions
+		*out = make([]MachinePoolUnhealthyCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxUnhealthy != nil {
+		in, out := &in.MaxUnhealthy, &out.MaxUnhealthy
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.NodeStartupTimeout != nil {
+		in, out := &in.NodeStartupTimeout, &out.NodeStartupTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolMachineHealthCheck.
+func (in *MachinePoolMachineHealthCheck) DeepCopy() *MachinePoolMachineHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolMachineHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolNameLease) DeepCopyInto(out *MachinePoolNameLease) {
 	*out = *in
@@ -2674,6 +4252,48 @@ func (in *MachinePoolPlatform) DeepCopy() *MachinePoolPlatform {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolRolloutStatus) DeepCopyInto(out *MachinePoolRolloutStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolRolloutStatus.
+func (in *MachinePoolRolloutStatus) DeepCopy() *MachinePoolRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolRolloutStrategy) DeepCopyInto(out *MachinePoolRolloutStrategy) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolRolloutStrategy.
+func (in *MachinePoolRolloutStrategy) DeepCopy() *MachinePoolRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachinePoolSpec) DeepCopyInto(out *MachinePoolSpec) {
 	*out = *in
@@ -2689,6 +4309,13 @@ func (in *MachinePoolSpec) DeepCopyInto(out *MachinePoolSpec) {
 		**out = **in
 	}
 	in.Platform.DeepCopyInto(&out.Platform)
+	if in.ZoneDistribution != nil {
+		in, out := &in.ZoneDistribution, &out.ZoneDistribution
+		*out = make(map[string]MachinePoolZoneReplicas, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -2703,6 +4330,33 @@ func (in *MachinePoolSpec) DeepCopyInto(out *MachinePoolSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.KubeletConfig != nil {
+		in, out := &in.KubeletConfig, &out.KubeletConfig
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineConfigs != nil {
+		in, out := &in.MachineConfigs, &out.MachineConfigs
+		*out = make([]MachinePoolMachineConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(MachinePoolRolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineSetSelector != nil {
+		in, out := &in.MachineSetSelector, &out.MachineSetSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineHealthCheck != nil {
+		in, out := &in.MachineHealthCheck, &out.MachineHealthCheck
+		*out = new(MachinePoolMachineHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2733,6 +4387,21 @@ func (in *MachinePoolStatus) DeepCopyInto(out *MachinePoolStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(MachinePoolRolloutStatus)
+		**out = **in
+	}
+	if in.SkippedZones != nil {
+		in, out := &in.SkippedZones, &out.SkippedZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]MachinePoolZoneStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -2746,9 +4415,116 @@ func (in *MachinePoolStatus) DeepCopy() *MachinePoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolSummary) DeepCopyInto(out *MachinePoolSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolSummary.
+func (in *MachinePoolSummary) DeepCopy() *MachinePoolSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolUnhealthyCondition) DeepCopyInto(out *MachinePoolUnhealthyCondition) {
+	*out = *in
+	out.Timeout = in.Timeout
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolUnhealthyCondition.
+func (in *MachinePoolUnhealthyCondition) DeepCopy() *MachinePoolUnhealthyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolUnhealthyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolZoneReplicas) DeepCopyInto(out *MachinePoolZoneReplicas) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolZoneReplicas.
+func (in *MachinePoolZoneReplicas) DeepCopy() *MachinePoolZoneReplicas {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolZoneReplicas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolZoneStatus) DeepCopyInto(out *MachinePoolZoneStatus) {
+	*out = *in
+	out.Machines = in.Machines
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolZoneStatus.
+func (in *MachinePoolZoneStatus) DeepCopy() *MachinePoolZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineSetInfrastructure) DeepCopyInto(out *MachineSetInfrastructure) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineSetInfrastructure.
+func (in *MachineSetInfrastructure) DeepCopy() *MachineSetInfrastructure {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineSetInfrastructure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineSetStatus) DeepCopyInto(out *MachineSetStatus) {
 	*out = *in
+	if in.Infrastructure != nil {
+		in, out := &in.Infrastructure, &out.Infrastructure
+		*out = new(MachineSetInfrastructure)
+		**out = **in
+	}
+	if in.Machines != nil {
+		in, out := &in.Machines, &out.Machines
+		*out = new(MachinePhaseCounts)
+		**out = **in
+	}
 	if in.ErrorReason != nil {
 		in, out := &in.ErrorReason, &out.ErrorReason
 		*out = new(string)
@@ -2772,6 +4548,28 @@ func (in *MachineSetStatus) DeepCopy() *MachineSetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]MaintenanceWindowDay, len(*in))
+		copy(*out, *in)
+	}
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManageDNSAWSConfig) DeepCopyInto(out *ManageDNSAWSConfig) {
 	*out = *in
@@ -2806,6 +4604,27 @@ func (in *ManageDNSAzureConfig) DeepCopy() *ManageDNSAzureConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManageDNSChildZonesConfig) DeepCopyInto(out *ManageDNSChildZonesConfig) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManageDNSChildZonesConfig.
+func (in *ManageDNSChildZonesConfig) DeepCopy() *ManageDNSChildZonesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ManageDNSChildZonesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManageDNSConfig) DeepCopyInto(out *ManageDNSConfig) {
 	*out = *in
@@ -2829,6 +4648,11 @@ func (in *ManageDNSConfig) DeepCopyInto(out *ManageDNSConfig) {
 		*out = new(ManageDNSAzureConfig)
 		**out = **in
 	}
+	if in.ChildZones != nil {
+		in, out := &in.ChildZones, &out.ChildZones
+		*out = new(ManageDNSChildZonesConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2859,6 +4683,50 @@ func (in *ManageDNSGCPConfig) DeepCopy() *ManageDNSGCPConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationSink) DeepCopyInto(out *NotificationSink) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]NotificationEventType, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSink.
+func (in *NotificationSink) DeepCopy() *NotificationSink {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationsConfig) DeepCopyInto(out *NotificationsConfig) {
+	*out = *in
+	if in.Sinks != nil {
+		in, out := &in.Sinks, &out.Sinks
+		*out = make([]NotificationSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationsConfig.
+func (in *NotificationsConfig) DeepCopy() *NotificationsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenStackClusterDeprovision) DeepCopyInto(out *OpenStackClusterDeprovision) {
 	*out = *in
@@ -2914,7 +4782,7 @@ func (in *Platform) DeepCopyInto(out *Platform) {
 	if in.Azure != nil {
 		in, out := &in.Azure, &out.Azure
 		*out = new(azure.Platform)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.BareMetal != nil {
 		in, out := &in.BareMetal, &out.BareMetal
@@ -2924,7 +4792,7 @@ func (in *Platform) DeepCopyInto(out *Platform) {
 	if in.GCP != nil {
 		in, out := &in.GCP, &out.GCP
 		*out = new(gcp.Platform)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.OpenStack != nil {
 		in, out := &in.OpenStack, &out.OpenStack
@@ -2967,6 +4835,16 @@ func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
 		*out = new(aws.PlatformStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(azure.PlatformStatus)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(gcp.PlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3015,6 +4893,21 @@ func (in *Provisioning) DeepCopyInto(out *Provisioning) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FIPS != nil {
+		in, out := &in.FIPS, &out.FIPS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EtcdEncryption != nil {
+		in, out := &in.EtcdEncryption, &out.EtcdEncryption
+		*out = new(configv1.APIServerEncryption)
+		**out = **in
+	}
+	if in.ImageBasedInstall != nil {
+		in, out := &in.ImageBasedInstall, &out.ImageBasedInstall
+		*out = new(ImageBasedInstall)
+		**out = **in
+	}
 	return
 }
 
@@ -3044,6 +4937,108 @@ func (in *ReleaseImageVerificationConfigMapReference) DeepCopy() *ReleaseImageVe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationAction) DeepCopyInto(out *RemediationAction) {
+	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(RemediationWebhook)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationAction.
+func (in *RemediationAction) DeepCopy() *RemediationAction {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationAttempt) DeepCopyInto(out *RemediationAttempt) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationAttempt.
+func (in *RemediationAttempt) DeepCopy() *RemediationAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationPolicy) DeepCopyInto(out *RemediationPolicy) {
+	*out = *in
+	out.UnhealthyDuration = in.UnhealthyDuration
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]RemediationAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationPolicy.
+func (in *RemediationPolicy) DeepCopy() *RemediationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationStatus) DeepCopyInto(out *RemediationStatus) {
+	*out = *in
+	in.UnhealthySince.DeepCopyInto(&out.UnhealthySince)
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = make([]RemediationAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationStatus.
+func (in *RemediationStatus) DeepCopy() *RemediationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationWebhook) DeepCopyInto(out *RemediationWebhook) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationWebhook.
+func (in *RemediationWebhook) DeepCopy() *RemediationWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretMapping) DeepCopyInto(out *SecretMapping) {
 	*out = *in
@@ -3252,6 +5247,27 @@ func (in *SelectorSyncSetStatus) DeepCopy() *SelectorSyncSetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SensitiveArtifactsConfig) DeepCopyInto(out *SensitiveArtifactsConfig) {
+	*out = *in
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		*out = new(KMSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SensitiveArtifactsConfig.
+func (in *SensitiveArtifactsConfig) DeepCopy() *SensitiveArtifactsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SensitiveArtifactsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceProviderCredentials) DeepCopyInto(out *ServiceProviderCredentials) {
 	*out = *in
@@ -3290,6 +5306,22 @@ func (in *SpecificControllerConfig) DeepCopy() *SpecificControllerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpokeStatusConfig) DeepCopyInto(out *SpokeStatusConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpokeStatusConfig.
+func (in *SpokeStatusConfig) DeepCopy() *SpokeStatusConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SpokeStatusConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncCondition) DeepCopyInto(out *SyncCondition) {
 	*out = *in
@@ -3430,6 +5462,22 @@ func (in *SyncObjectPatch) DeepCopy() *SyncObjectPatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncObjectReference) DeepCopyInto(out *SyncObjectReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncObjectReference.
+func (in *SyncObjectReference) DeepCopy() *SyncObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSet) DeepCopyInto(out *SyncSet) {
 	*out = *in
@@ -3478,6 +5526,11 @@ func (in *SyncSetCommonSpec) DeepCopyInto(out *SyncSetCommonSpec) {
 		*out = make([]SecretMapping, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResourcesToDelete != nil {
+		in, out := &in.ResourcesToDelete, &out.ResourcesToDelete
+		*out = make([]SyncObjectReference, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -3629,6 +5682,171 @@ func (in *SyncStatus) DeepCopy() *SyncStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeardownHook) DeepCopyInto(out *TeardownHook) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownHook.
+func (in *TeardownHook) DeepCopy() *TeardownHook {
+	if in == nil {
+		return nil
+	}
+	out := new(TeardownHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeardownHookStatus) DeepCopyInto(out *TeardownHookStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownHookStatus.
+func (in *TeardownHookStatus) DeepCopy() *TeardownHookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeardownHookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantQuota) DeepCopyInto(out *TenantQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantQuota.
+func (in *TenantQuota) DeepCopy() *TenantQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantQuotaList) DeepCopyInto(out *TenantQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TenantQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantQuotaList.
+func (in *TenantQuotaList) DeepCopy() *TenantQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantQuotaSpec) DeepCopyInto(out *TenantQuotaSpec) {
+	*out = *in
+	if in.MaxClusterDeployments != nil {
+		in, out := &in.MaxClusterDeployments, &out.MaxClusterDeployments
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxMachineReplicas != nil {
+		in, out := &in.MaxMachineReplicas, &out.MaxMachineReplicas
+		*out = new(int64)
+		**out = **in
+	}
+	if in.InstanceFamilies != nil {
+		in, out := &in.InstanceFamilies, &out.InstanceFamilies
+		*out = make([]InstanceFamilyQuota, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantQuotaSpec.
+func (in *TenantQuotaSpec) DeepCopy() *TenantQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantQuotaStatus) DeepCopyInto(out *TenantQuotaStatus) {
+	*out = *in
+	if in.InstanceFamilies != nil {
+		in, out := &in.InstanceFamilies, &out.InstanceFamilies
+		*out = make([]InstanceFamilyUsage, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantQuotaStatus.
+func (in *TenantQuotaStatus) DeepCopy() *TenantQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereClusterDeprovision) DeepCopyInto(out *VSphereClusterDeprovision) {
 	*out = *in
@@ -3647,6 +5865,22 @@ func (in *VSphereClusterDeprovision) DeepCopy() *VSphereClusterDeprovision {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultSecretProviderConfig) DeepCopyInto(out *VaultSecretProviderConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultSecretProviderConfig.
+func (in *VaultSecretProviderConfig) DeepCopy() *VaultSecretProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSecretProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VeleroBackupConfig) DeepCopyInto(out *VeleroBackupConfig) {
 	*out = *in
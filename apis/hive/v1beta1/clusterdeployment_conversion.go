@@ -0,0 +1,91 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// ConvertClusterDeploymentToV1 converts a v1beta1 ClusterDeployment to v1. The observed generations of the conditions,
+// which v1 does not have, are kept in the ConversionDataAnnotation, and their last probe times, which v1beta1 does not
+// have, are restored from it.
+func ConvertClusterDeploymentToV1(in *ClusterDeployment, out *hivev1.ClusterDeployment, _ conversion.Scope) error {
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	restored := popConversionData(&out.ObjectMeta)
+	data := &conversionData{}
+	out.Spec = *in.Spec.DeepCopy()
+
+	status := in.Status.DeepCopy()
+	out.Status = hivev1.ClusterDeploymentStatus{
+		InstallRestarts:                   status.InstallRestarts,
+		APIURL:                            status.APIURL,
+		WebConsoleURL:                     status.WebConsoleURL,
+		InstallerImage:                    status.InstallerImage,
+		InstallVersion:                    status.InstallVersion,
+		CLIImage:                          status.CLIImage,
+		CertificateBundles:                status.CertificateBundles,
+		InstallStartedTimestamp:           status.InstallStartedTimestamp,
+		InstalledTimestamp:                status.InstalledTimestamp,
+		PowerState:                        status.PowerState,
+		ProvisionRef:                      status.ProvisionRef,
+		Platform:                          status.Platform,
+		CostEstimate:                      status.CostEstimate,
+		Remediation:                       status.Remediation,
+		MachinePools:                      status.MachinePools,
+		KubeadminPasswordRotatedTimestamp: status.KubeadminPasswordRotatedTimestamp,
+		ClaimReleaseHooks:                 status.ClaimReleaseHooks,
+	}
+	for _, c := range status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, hivev1.ClusterDeploymentCondition{
+			Type:               hivev1.ClusterDeploymentConditionType(c.Type),
+			Status:             corev1.ConditionStatus(c.Status),
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastProbeTime:      restoreLastProbeTime(restored.condition(c.Type)),
+		})
+		data.setCondition(c.Type, conditionConversionData{ObservedGeneration: c.ObservedGeneration})
+	}
+	return setConversionData(&out.ObjectMeta, data)
+}
+
+// ConvertClusterDeploymentFromV1 converts a v1 ClusterDeployment to v1beta1. The last probe times of the conditions,
+// which v1beta1 does not have, are kept in the ConversionDataAnnotation, and their observed generations, which v1 does
+// not have, are restored from it.
+func ConvertClusterDeploymentFromV1(in *hivev1.ClusterDeployment, out *ClusterDeployment, _ conversion.Scope) error {
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	restored := popConversionData(&out.ObjectMeta)
+	data := &conversionData{}
+	out.Spec = *in.Spec.DeepCopy()
+
+	status := in.Status.DeepCopy()
+	out.Status = ClusterDeploymentStatus{
+		InstallRestarts:                   status.InstallRestarts,
+		APIURL:                            status.APIURL,
+		WebConsoleURL:                     status.WebConsoleURL,
+		InstallerImage:                    status.InstallerImage,
+		InstallVersion:                    status.InstallVersion,
+		CLIImage:                          status.CLIImage,
+		CertificateBundles:                status.CertificateBundles,
+		InstallStartedTimestamp:           status.InstallStartedTimestamp,
+		InstalledTimestamp:                status.InstalledTimestamp,
+		PowerState:                        status.PowerState,
+		ProvisionRef:                      status.ProvisionRef,
+		Platform:                          status.Platform,
+		CostEstimate:                      status.CostEstimate,
+		Remediation:                       status.Remediation,
+		MachinePools:                      status.MachinePools,
+		KubeadminPasswordRotatedTimestamp: status.KubeadminPasswordRotatedTimestamp,
+		ClaimReleaseHooks:                 status.ClaimReleaseHooks,
+	}
+	for i := range status.Conditions {
+		condition := status.Conditions[i].ToCondition()
+		condition.ObservedGeneration = restored.condition(condition.Type).ObservedGeneration
+		out.Status.Conditions = append(out.Status.Conditions, condition)
+		data.setCondition(condition.Type, conditionConversionData{
+			LastProbeTime: keepLastProbeTime(status.Conditions[i].LastProbeTime),
+		})
+	}
+	return setConversionData(&out.ObjectMeta, data)
+}
@@ -0,0 +1,123 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// ClusterDeploymentStatus defines the observed state of ClusterDeployment. It differs from the v1
+// ClusterDeploymentStatus in its conditions, which are standard metav1.Conditions.
+type ClusterDeploymentStatus struct {
+	// InstallRestarts is the total count of container restarts on the clusters install job.
+	InstallRestarts int `json:"installRestarts,omitempty"`
+
+	// APIURL is the URL where the cluster's API can be accessed.
+	APIURL string `json:"apiURL,omitempty"`
+
+	// WebConsoleURL is the URL for the cluster's web console UI.
+	WebConsoleURL string `json:"webConsoleURL,omitempty"`
+
+	// InstallerImage is the name of the installer image to use when installing the target cluster
+	// +optional
+	InstallerImage *string `json:"installerImage,omitempty"`
+
+	// InstallVersion is the version of OpenShift as reported by the release image
+	// resolved for the installation.
+	// +optional
+	InstallVersion *string `json:"installVersion,omitempty"`
+
+	// CLIImage is the name of the oc cli image to use when installing the target cluster
+	// +optional
+	CLIImage *string `json:"cliImage,omitempty"`
+
+	// Conditions includes more detailed status for the cluster deployment. The types are those of the v1 conditions.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// CertificateBundles contains of the status of the certificate bundles associated with this cluster deployment.
+	// +optional
+	CertificateBundles []hivev1.CertificateBundleStatus `json:"certificateBundles,omitempty"`
+
+	// InstallStartedTimestamp is the time when all pre-requisites were met and cluster installation was launched.
+	InstallStartedTimestamp *metav1.Time `json:"installStartedTimestamp,omitempty"`
+
+	// InstalledTimestamp is the time we first detected that the cluster has been successfully installed.
+	InstalledTimestamp *metav1.Time `json:"installedTimestamp,omitempty"`
+
+	// PowerState indicates the powerstate of cluster
+	// +optional
+	PowerState string `json:"powerState,omitempty"`
+
+	// ProvisionRef is a reference to the last ClusterProvision created for the deployment
+	// +optional
+	ProvisionRef *corev1.LocalObjectReference `json:"provisionRef,omitempty"`
+
+	// Platform contains the observed state for the specific platform upon which to
+	// perform the installation.
+	// +optional
+	Platform *hivev1.PlatformStatus `json:"platformStatus,omitempty"`
+
+	// CostEstimate is the estimated cost of running the cluster, reported when cost estimation is configured
+	// in HiveConfig.
+	// +optional
+	CostEstimate *hivev1.CostEstimate `json:"costEstimate,omitempty"`
+
+	// Remediation is the status of the remediation of the cluster under its RemediationPolicy.
+	// +optional
+	Remediation *hivev1.RemediationStatus `json:"remediation,omitempty"`
+
+	// MachinePools summarizes the replicas of the MachinePools of the cluster, so that its compute capacity can be
+	// read from the ClusterDeployment alone.
+	// +optional
+	MachinePools []hivev1.MachinePoolSummary `json:"machinePools,omitempty"`
+
+	// KubeadminPasswordRotatedTimestamp is the time the kubeadmin password of the cluster was last rotated at the
+	// request of the rotate-kubeadmin-password annotation.
+	// +optional
+	KubeadminPasswordRotatedTimestamp *metav1.Time `json:"kubeadminPasswordRotatedTimestamp,omitempty"`
+
+	// ClaimReleaseHooks is the status of the claim release hooks of the pool while the released cluster is being
+	// sanitized for reuse by the pool.
+	// +optional
+	ClaimReleaseHooks []hivev1.TeardownHookStatus `json:"claimReleaseHooks,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDeployment is the Schema for the clusterdeployments API. Its spec is the same as in v1.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="InfraID",type="string",JSONPath=".spec.clusterMetadata.infraID"
+// +kubebuilder:printcolumn:name="Platform",type="string",JSONPath=".metadata.labels.hive\\.openshift\\.io/cluster-platform"
+// +kubebuilder:printcolumn:name="Region",type="string",JSONPath=".metadata.labels.hive\\.openshift\\.io/cluster-region"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".metadata.labels.hive\\.openshift\\.io/version-major-minor-patch"
+// +kubebuilder:printcolumn:name="ClusterType",type="string",JSONPath=".metadata.labels.hive\\.openshift\\.io/cluster-type"
+// +kubebuilder:printcolumn:name="ProvisionStatus",type="string",JSONPath=".status.conditions[?(@.type=='Provisioned')].reason"
+// +kubebuilder:printcolumn:name="PowerState",type="string",JSONPath=".status.powerState"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=clusterdeployments,shortName=cd,scope=Namespaced
+type ClusterDeployment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   hivev1.ClusterDeploymentSpec `json:"spec,omitempty"`
+	Status ClusterDeploymentStatus      `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterDeploymentList contains a list of ClusterDeployment
+type ClusterDeploymentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterDeployment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterDeployment{}, &ClusterDeploymentList{})
+}
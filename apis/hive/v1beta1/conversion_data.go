@@ -0,0 +1,77 @@
+package v1beta1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConversionDataAnnotation holds, as JSON, the fields of an object that the version it was converted to has no place
+// for. Converting the object back restores them from the annotation and removes it, so that a round trip through
+// either version loses nothing.
+const ConversionDataAnnotation = "hive.openshift.io/conversion-data"
+
+// conversionData is the content of the ConversionDataAnnotation.
+type conversionData struct {
+	// Conditions holds the fields of the conditions, keyed by condition type.
+	Conditions map[string]conditionConversionData `json:"conditions,omitempty"`
+}
+
+// conditionConversionData holds the fields of a condition that only one of the versions has.
+type conditionConversionData struct {
+	// LastProbeTime is the last probe time of a v1 condition.
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+	// ObservedGeneration is the observed generation of a v1beta1 ClusterDeployment condition.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// popConversionData removes the ConversionDataAnnotation from the metadata and returns its content. An annotation
+// that cannot be decoded is dropped rather than failing the conversion, as it only ever holds what is already lost
+// without it.
+func popConversionData(meta *metav1.ObjectMeta) *conversionData {
+	data := &conversionData{}
+	raw, ok := meta.Annotations[ConversionDataAnnotation]
+	if !ok {
+		return data
+	}
+	delete(meta.Annotations, ConversionDataAnnotation)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+	if err := json.Unmarshal([]byte(raw), data); err != nil {
+		return &conversionData{}
+	}
+	return data
+}
+
+// condition returns the data kept for the condition of the given type.
+func (d *conversionData) condition(conditionType string) conditionConversionData {
+	return d.Conditions[conditionType]
+}
+
+// setCondition keeps the data for the condition of the given type, unless there is none.
+func (d *conversionData) setCondition(conditionType string, data conditionConversionData) {
+	if data == (conditionConversionData{}) {
+		return
+	}
+	if d.Conditions == nil {
+		d.Conditions = map[string]conditionConversionData{}
+	}
+	d.Conditions[conditionType] = data
+}
+
+// setConversionData stores the data in the ConversionDataAnnotation of the metadata, unless there is nothing to keep.
+func setConversionData(meta *metav1.ObjectMeta, data *conversionData) error {
+	if len(data.Conditions) == 0 {
+		return nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	meta.Annotations[ConversionDataAnnotation] = string(raw)
+	return nil
+}
@@ -0,0 +1,7 @@
+// Package v1beta1 contains API Schema definitions for the hive v1beta1 API group
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=package,register
+// +k8s:conversion-gen=github.com/openshift/hive/apis/hive
+// +k8s:defaulter-gen=TypeMeta
+// +groupName=hive.openshift.io
+package v1beta1
@@ -0,0 +1,154 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func init() {
+	SchemeBuilder.SchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions registers the conversions between the v1beta1 types and the v1 types they are stored as.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddConversionFunc((*MachinePool)(nil), (*hivev1.MachinePool)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return ConvertMachinePoolToV1(a.(*MachinePool), b.(*hivev1.MachinePool), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*hivev1.MachinePool)(nil), (*MachinePool)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return ConvertMachinePoolFromV1(a.(*hivev1.MachinePool), b.(*MachinePool), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*ClusterDeployment)(nil), (*hivev1.ClusterDeployment)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return ConvertClusterDeploymentToV1(a.(*ClusterDeployment), b.(*hivev1.ClusterDeployment), scope)
+	}); err != nil {
+		return err
+	}
+	return s.AddConversionFunc((*hivev1.ClusterDeployment)(nil), (*ClusterDeployment)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return ConvertClusterDeploymentFromV1(a.(*hivev1.ClusterDeployment), b.(*ClusterDeployment), scope)
+	})
+}
+
+// ConvertMachinePoolToV1 converts a v1beta1 MachinePool to v1. The last probe times of the conditions, which v1beta1
+// does not have, are restored from the ConversionDataAnnotation.
+func ConvertMachinePoolToV1(in *MachinePool, out *hivev1.MachinePool, _ conversion.Scope) error {
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	data := popConversionData(&out.ObjectMeta)
+	spec := in.Spec.DeepCopy()
+	out.Spec = hivev1.MachinePoolSpec{
+		ClusterDeploymentRef: spec.ClusterDeploymentRef,
+		Name:                 spec.Name,
+		Autoscaling:          spec.Autoscaling,
+		Platform:             spec.Platform,
+		ZoneDistribution:     spec.ZoneDistribution,
+		Labels:               spec.NodeLabels,
+		Taints:               spec.NodeTaints,
+		KubeletConfig:        spec.KubeletConfig,
+		MachineConfigs:       spec.MachineConfigs,
+		TagReconciliation:    spec.TagReconciliation,
+		RolloutStrategy:      spec.RolloutStrategy,
+		MachineSetSelector:   spec.MachineSetSelector,
+		Paused:               spec.Paused,
+		AdoptExisting:        spec.AdoptExisting,
+		MachineHealthCheck:   spec.MachineHealthCheck,
+	}
+	if spec.Replicas != nil {
+		replicas := int64(*spec.Replicas)
+		out.Spec.Replicas = &replicas
+	}
+
+	status := in.Status.DeepCopy()
+	out.Status = hivev1.MachinePoolStatus{
+		Replicas:           status.Replicas,
+		MachineSets:        status.MachineSets,
+		Rollout:            status.Rollout,
+		ObservedGeneration: status.ObservedGeneration,
+		SkippedZones:       status.SkippedZones,
+		AuthoritativeAPI:   status.AuthoritativeAPI,
+		Zones:              status.Zones,
+	}
+	for _, c := range status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, hivev1.MachinePoolCondition{
+			Type:               hivev1.MachinePoolConditionType(c.Type),
+			Status:             corev1.ConditionStatus(c.Status),
+			LastTransitionTime: c.LastTransitionTime,
+			Reason:             c.Reason,
+			Message:            c.Message,
+			ObservedGeneration: c.ObservedGeneration,
+			LastProbeTime:      restoreLastProbeTime(data.condition(c.Type)),
+		})
+	}
+	return nil
+}
+
+// ConvertMachinePoolFromV1 converts a v1 MachinePool to v1beta1. The last probe times of the conditions, which v1beta1
+// does not have, are kept in the ConversionDataAnnotation. Replicas beyond the range of an int32 are not expected, as
+// they are well beyond the size of any cluster.
+func ConvertMachinePoolFromV1(in *hivev1.MachinePool, out *MachinePool, _ conversion.Scope) error {
+	out.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	popConversionData(&out.ObjectMeta)
+	data := &conversionData{}
+	spec := in.Spec.DeepCopy()
+	out.Spec = MachinePoolSpec{
+		ClusterDeploymentRef: spec.ClusterDeploymentRef,
+		Name:                 spec.Name,
+		Autoscaling:          spec.Autoscaling,
+		Platform:             spec.Platform,
+		ZoneDistribution:     spec.ZoneDistribution,
+		NodeLabels:           spec.Labels,
+		NodeTaints:           spec.Taints,
+		KubeletConfig:        spec.KubeletConfig,
+		MachineConfigs:       spec.MachineConfigs,
+		TagReconciliation:    spec.TagReconciliation,
+		RolloutStrategy:      spec.RolloutStrategy,
+		MachineSetSelector:   spec.MachineSetSelector,
+		Paused:               spec.Paused,
+		AdoptExisting:        spec.AdoptExisting,
+		MachineHealthCheck:   spec.MachineHealthCheck,
+	}
+	if spec.Replicas != nil {
+		replicas := int32(*spec.Replicas)
+		out.Spec.Replicas = &replicas
+	}
+
+	status := in.Status.DeepCopy()
+	out.Status = MachinePoolStatus{
+		Replicas:           status.Replicas,
+		MachineSets:        status.MachineSets,
+		Rollout:            status.Rollout,
+		ObservedGeneration: status.ObservedGeneration,
+		SkippedZones:       status.SkippedZones,
+		AuthoritativeAPI:   status.AuthoritativeAPI,
+		Zones:              status.Zones,
+	}
+	for i := range status.Conditions {
+		out.Status.Conditions = append(out.Status.Conditions, status.Conditions[i].ToCondition())
+		data.setCondition(string(status.Conditions[i].Type), conditionConversionData{
+			LastProbeTime: keepLastProbeTime(status.Conditions[i].LastProbeTime),
+		})
+	}
+	return setConversionData(&out.ObjectMeta, data)
+}
+
+// keepLastProbeTime returns the last probe time of a v1 condition to keep in the ConversionDataAnnotation, or nil
+// when it is not set.
+func keepLastProbeTime(t metav1.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// restoreLastProbeTime returns the last probe time kept in the ConversionDataAnnotation for a v1 condition.
+func restoreLastProbeTime(data conditionConversionData) metav1.Time {
+	if data.LastProbeTime == nil {
+		return metav1.Time{}
+	}
+	return *data.LastProbeTime
+}
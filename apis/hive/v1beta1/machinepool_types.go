@@ -0,0 +1,159 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// MachinePoolSpec defines the desired state of MachinePool. It differs from the v1 MachinePoolSpec in the names of
+// the node labels and taints, and in replicas being an int32 like the replicas of MachineSets.
+type MachinePoolSpec struct {
+	// ClusterDeploymentRef references the cluster deployment to which this
+	// machine pool belongs.
+	ClusterDeploymentRef corev1.LocalObjectReference `json:"clusterDeploymentRef"`
+
+	// Name is the name of the machine pool.
+	Name string `json:"name"`
+
+	// Replicas is the count of machines for this machine pool.
+	// Replicas and autoscaling cannot be used together.
+	// Default is 1, if autoscaling is not used.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Autoscaling is the details for auto-scaling the machine pool.
+	// Replicas and autoscaling cannot be used together.
+	// +optional
+	Autoscaling *hivev1.MachinePoolAutoscaling `json:"autoscaling,omitempty"`
+
+	// Platform is configuration for machine pool specific to the platform.
+	Platform hivev1.MachinePoolPlatform `json:"platform"`
+
+	// ZoneDistribution pins the replicas of the MachineSets of specific availability zones, keyed by zone, instead
+	// of spreading the replicas of the machine pool evenly across its zones.
+	// +optional
+	ZoneDistribution map[string]hivev1.MachinePoolZoneReplicas `json:"zoneDistribution,omitempty"`
+
+	// NodeLabels are applied to the machine template of the MachineSets of the machine pool, and so to the nodes of
+	// new machines. This is labels in v1.
+	// +optional
+	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
+
+	// NodeTaints are applied to the machine template of the MachineSets of the machine pool, and so to the nodes of
+	// new machines. This is taints in v1.
+	// +optional
+	NodeTaints []corev1.Taint `json:"nodeTaints,omitempty"`
+
+	// KubeletConfig is the kubelet configuration applied to the nodes of the machine pool, for example
+	// {"maxPods": 500}.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	KubeletConfig *runtime.RawExtension `json:"kubeletConfig,omitempty"`
+
+	// MachineConfigs are machineconfiguration.openshift.io MachineConfigs applied to the nodes of the machine pool.
+	// +optional
+	MachineConfigs []hivev1.MachinePoolMachineConfig `json:"machineConfigs,omitempty"`
+
+	// TagReconciliation controls which cloud resources of the machine pool are kept tagged with the tags configured
+	// for the cluster.
+	// +kubebuilder:validation:Enum=MachineSets;Instances
+	// +optional
+	TagReconciliation hivev1.MachinePoolTagReconciliation `json:"tagReconciliation,omitempty"`
+
+	// RolloutStrategy makes the platform of the machine pool mutable.
+	// +optional
+	RolloutStrategy *hivev1.MachinePoolRolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// MachineSetSelector makes the machine pool externally managed, for clusters where another system owns the
+	// MachineSets.
+	// +optional
+	MachineSetSelector *metav1.LabelSelector `json:"machineSetSelector,omitempty"`
+
+	// Paused freezes the machine pool, so that its resources in the cluster are not synced.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// AdoptExisting makes the machine pool adopt MachineSets which already exist in the cluster instead of creating
+	// duplicates of them.
+	// +optional
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+
+	// MachineHealthCheck makes Hive create a MachineHealthCheck in the cluster for each MachineSet of the machine pool.
+	// +optional
+	MachineHealthCheck *hivev1.MachinePoolMachineHealthCheck `json:"machineHealthCheck,omitempty"`
+}
+
+// MachinePoolStatus defines the observed state of MachinePool. It differs from the v1 MachinePoolStatus in its
+// conditions, which are standard metav1.Conditions.
+type MachinePoolStatus struct {
+	// Replicas is the current number of replicas for the machine pool.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// MachineSets is the status of the machine sets for the machine pool on the remote cluster.
+	// +optional
+	MachineSets []hivev1.MachineSetStatus `json:"machineSets,omitempty"`
+
+	// Conditions includes more detailed status for the machine pool. The types are those of the v1 conditions.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Rollout is the progress of replacing the machines of the machine pool which do not match its MachineSets.
+	// +optional
+	Rollout *hivev1.MachinePoolRolloutStatus `json:"rollout,omitempty"`
+
+	// ObservedGeneration is the generation of the machine pool which was last synced to the remote cluster.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// SkippedZones is the list of zones of the region which are not used by the machine pool because its instance type
+	// is not offered in them.
+	// +optional
+	SkippedZones []string `json:"skippedZones,omitempty"`
+
+	// AuthoritativeAPI is the API which manages the machines of the remote cluster.
+	// +optional
+	AuthoritativeAPI hivev1.MachineAuthority `json:"authoritativeAPI,omitempty"`
+
+	// Zones are the numbers of machines of the machine pool in each phase, aggregated by availability zone.
+	// +optional
+	Zones []hivev1.MachinePoolZoneStatus `json:"zones,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MachinePool is the Schema for the machinepools API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas
+// +kubebuilder:printcolumn:name="PoolName",type="string",JSONPath=".spec.name"
+// +kubebuilder:printcolumn:name="ClusterDeployment",type="string",JSONPath=".spec.clusterDeploymentRef.name"
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas"
+// +kubebuilder:resource:path=machinepools,scope=Namespaced
+type MachinePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MachinePoolSpec   `json:"spec,omitempty"`
+	Status MachinePoolStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MachinePoolList contains a list of MachinePool
+type MachinePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MachinePool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MachinePool{}, &MachinePoolList{})
+}
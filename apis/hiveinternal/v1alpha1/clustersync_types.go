@@ -57,6 +57,11 @@ type SyncStatus struct {
 	// +optional
 	ResourcesToDelete []SyncResourceReference `json:"resourcesToDelete,omitempty"`
 
+	// ResourcesDeleted is the list of resources in the ResourcesToDelete of the SyncSet or SelectorSyncSet that have
+	// been confirmed as deleted from the cluster.
+	// +optional
+	ResourcesDeleted []SyncResourceReference `json:"resourcesDeleted,omitempty"`
+
 	// Result is the result of the last attempt to apply the SyncSet or SelectorSyncSet to the cluster.
 	Result SyncSetResult `json:"result"`
 
@@ -65,6 +70,12 @@ type SyncStatus struct {
 	// +optional
 	FailureMessage string `json:"failureMessage,omitempty"`
 
+	// FailedResources is the list of resources and secrets of the SyncSet or SelectorSyncSet that failed to apply to the
+	// cluster. A failing resource does not prevent the other resources from being applied. Failing resources are retried
+	// with an exponential backoff, independently of the reapply interval of the other resources.
+	// +optional
+	FailedResources []SyncResourceFailure `json:"failedResources,omitempty"`
+
 	// LastTransitionTime is the time when this status last changed.
 	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
 
@@ -90,6 +101,26 @@ type SyncResourceReference struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// SyncResourceFailure is a resource of a SyncSet or SelectorSyncSet that failed to apply to the cluster.
+type SyncResourceFailure struct {
+	SyncResourceReference `json:",inline"`
+
+	// Message is the error from the last attempt to apply the resource.
+	Message string `json:"message"`
+
+	// ConsecutiveFailures is the number of consecutive attempts to apply the resource that have failed.
+	ConsecutiveFailures int32 `json:"consecutiveFailures"`
+
+	// FirstFailureTime is the time of the first of the consecutive failed attempts to apply the resource.
+	FirstFailureTime metav1.Time `json:"firstFailureTime"`
+
+	// LastFailureTime is the time of the last failed attempt to apply the resource.
+	LastFailureTime metav1.Time `json:"lastFailureTime"`
+
+	// NextRetryTime is the time after which the resource will be retried.
+	NextRetryTime metav1.Time `json:"nextRetryTime"`
+}
+
 // SyncSetResult is the result of a sync attempt.
 // +kubebuilder:validation:Enum=Success;Failure
 type SyncSetResult string
@@ -123,6 +154,18 @@ type ClusterSyncCondition struct {
 	Message string `json:"message,omitempty"`
 }
 
+// ToCondition returns the cluster sync condition as a metav1.Condition. The ClusterSync keeps the observed
+// generation of each SyncSet in its sync statuses, not in its conditions.
+func (c *ClusterSyncCondition) ToCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:               string(c.Type),
+		Status:             metav1.ConditionStatus(c.Status),
+		LastTransitionTime: c.LastTransitionTime,
+		Reason:             c.Reason,
+		Message:            c.Message,
+	}
+}
+
 // ClusterSyncConditionType is a valid value for ClusterSyncCondition.Type
 type ClusterSyncConditionType string
 
@@ -130,6 +173,9 @@ const (
 	// ClusterSyncFailed is the type of condition used to indicate whether there are SyncSets or SelectorSyncSets which
 	// have not been applied due to an error.
 	ClusterSyncFailed ClusterSyncConditionType = "Failed"
+	// ClusterSyncClusterSleeping is the type of condition used to indicate that syncing is paused because the cluster is
+	// hibernating, or its machines are stopping or starting. Syncing resumes as soon as the cluster wakes up.
+	ClusterSyncClusterSleeping ClusterSyncConditionType = "ClusterSleeping"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
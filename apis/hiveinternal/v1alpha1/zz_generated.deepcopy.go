@@ -329,6 +329,26 @@ func (in *FakeClusterInstallStatus) DeepCopy() *FakeClusterInstallStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncResourceFailure) DeepCopyInto(out *SyncResourceFailure) {
+	*out = *in
+	out.SyncResourceReference = in.SyncResourceReference
+	in.FirstFailureTime.DeepCopyInto(&out.FirstFailureTime)
+	in.LastFailureTime.DeepCopyInto(&out.LastFailureTime)
+	in.NextRetryTime.DeepCopyInto(&out.NextRetryTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncResourceFailure.
+func (in *SyncResourceFailure) DeepCopy() *SyncResourceFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncResourceFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncResourceReference) DeepCopyInto(out *SyncResourceReference) {
 	*out = *in
@@ -353,6 +373,18 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		*out = make([]SyncResourceReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResourcesDeleted != nil {
+		in, out := &in.ResourcesDeleted, &out.ResourcesDeleted
+		*out = make([]SyncResourceReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedResources != nil {
+		in, out := &in.FailedResources, &out.FailedResources
+		*out = make([]SyncResourceFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 	if in.FirstSuccessTime != nil {
 		in, out := &in.FirstSuccessTime, &out.FirstSuccessTime
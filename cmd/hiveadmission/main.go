@@ -1,13 +1,19 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"net/http"
+
 	admissionCmd "github.com/openshift/generic-admission-server/pkg/cmd"
 	log "github.com/sirupsen/logrus"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveconversionwebhooks "github.com/openshift/hive/pkg/conversion-webhooks/hive"
 	hivevalidatingwebhooks "github.com/openshift/hive/pkg/validating-webhooks/hive/v1"
 	"github.com/openshift/hive/pkg/version"
 )
@@ -21,6 +27,8 @@ func main() {
 
 	decoder := createDecoder()
 
+	go serveConversionWebhooks()
+
 	admissionCmd.RunAdmissionServer(
 		hivevalidatingwebhooks.NewDNSZoneValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterDeploymentValidatingAdmissionHook(decoder),
@@ -28,11 +36,51 @@ func main() {
 		hivevalidatingwebhooks.NewClusterImageSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterProvisionValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewMachinePoolValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewHostInventoryValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewSyncSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewSelectorSyncSetValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewClusterAccessTokenValidatingAdmissionHook(decoder),
 	)
 }
 
+const (
+	// conversionWebhookAddress is the address the conversion webhooks of the hive CRDs are served at. The
+	// aggregated API server of the validating webhooks serves on 9443.
+	conversionWebhookAddress = ":9444"
+
+	servingCertFile = "/var/serving-cert/tls.crt"
+	servingKeyFile  = "/var/serving-cert/tls.key"
+)
+
+// serveConversionWebhooks serves the conversion webhooks of the CRDs with more than one version. They are called
+// directly by the kube apiserver rather than through the aggregated API, so they need a server of their own.
+func serveConversionWebhooks() {
+	watcher, err := certwatcher.New(servingCertFile, servingKeyFile)
+	if err != nil {
+		log.WithError(err).Fatal("could not load the serving cert for the conversion webhooks")
+	}
+	go func() {
+		if err := watcher.Start(context.Background()); err != nil {
+			log.WithError(err).Fatal("could not watch the serving cert for the conversion webhooks")
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle(hiveconversionwebhooks.ConversionPath, hiveconversionwebhooks.NewConversionWebhook())
+	server := &http.Server{
+		Addr:    conversionWebhookAddress,
+		Handler: mux,
+		TLSConfig: &tls.Config{
+			GetCertificate: watcher.GetCertificate,
+			MinVersion:     tls.VersionTLS12,
+		},
+	}
+	log.WithField("address", conversionWebhookAddress).Info("Starting CRD Conversion Webhooks.")
+	if err := server.ListenAndServeTLS("", ""); err != nil {
+		log.WithError(err).Fatal("conversion webhook server failed")
+	}
+}
+
 func createDecoder() *admission.Decoder {
 	scheme := runtime.NewScheme()
 	hivev1.AddToScheme(scheme)
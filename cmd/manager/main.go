@@ -35,8 +35,10 @@ import (
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/adminsecretencryption"
 	"github.com/openshift/hive/pkg/controller/argocdregister"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	"github.com/openshift/hive/pkg/controller/clusteraccesstoken"
 	"github.com/openshift/hive/pkg/controller/clusterclaim"
 	"github.com/openshift/hive/pkg/controller/clusterdeployment"
 	"github.com/openshift/hive/pkg/controller/clusterdeprovision"
@@ -48,14 +50,26 @@ import (
 	"github.com/openshift/hive/pkg/controller/clustersync"
 	"github.com/openshift/hive/pkg/controller/clusterversion"
 	"github.com/openshift/hive/pkg/controller/controlplanecerts"
+	"github.com/openshift/hive/pkg/controller/costestimation"
+	"github.com/openshift/hive/pkg/controller/deletionmonitor"
 	"github.com/openshift/hive/pkg/controller/dnsendpoint"
 	"github.com/openshift/hive/pkg/controller/dnszone"
 	"github.com/openshift/hive/pkg/controller/fakeclusterinstall"
+	"github.com/openshift/hive/pkg/controller/garbagecollection"
 	"github.com/openshift/hive/pkg/controller/hibernation"
+	"github.com/openshift/hive/pkg/controller/hostedclusterinstall"
+	"github.com/openshift/hive/pkg/controller/hostinventory"
+	"github.com/openshift/hive/pkg/controller/kubeadminpassword"
+	"github.com/openshift/hive/pkg/controller/logarchive"
 	"github.com/openshift/hive/pkg/controller/machinepool"
 	"github.com/openshift/hive/pkg/controller/metrics"
+	"github.com/openshift/hive/pkg/controller/remediation"
 	"github.com/openshift/hive/pkg/controller/remoteingress"
+	"github.com/openshift/hive/pkg/controller/restorerepair"
+	"github.com/openshift/hive/pkg/controller/sensitiveartifacts"
+	"github.com/openshift/hive/pkg/controller/spokestatus"
 	"github.com/openshift/hive/pkg/controller/syncidentityprovider"
+	"github.com/openshift/hive/pkg/controller/tenantquota"
 	"github.com/openshift/hive/pkg/controller/unreachable"
 	"github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/controller/velerobackup"
@@ -74,29 +88,43 @@ const (
 type controllerSetupFunc func(manager.Manager) error
 
 var controllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
-	clusterclaim.ControllerName:         clusterclaim.Add,
-	clusterdeployment.ControllerName:    clusterdeployment.Add,
-	clusterdeprovision.ControllerName:   clusterdeprovision.Add,
-	clusterpoolnamespace.ControllerName: clusterpoolnamespace.Add,
-	clusterprovision.ControllerName:     clusterprovision.Add,
-	clusterrelocate.ControllerName:      clusterrelocate.Add,
-	clusterstate.ControllerName:         clusterstate.Add,
-	clustersync.ControllerName:          clustersync.Add,
-	clusterversion.ControllerName:       clusterversion.Add,
-	controlplanecerts.ControllerName:    controlplanecerts.Add,
-	dnsendpoint.ControllerName:          dnsendpoint.Add,
-	dnszone.ControllerName:              dnszone.Add,
-	fakeclusterinstall.ControllerName:   fakeclusterinstall.Add,
-	metrics.ControllerName:              metrics.Add,
-	remoteingress.ControllerName:        remoteingress.Add,
-	machinepool.ControllerName:          machinepool.Add,
-	syncidentityprovider.ControllerName: syncidentityprovider.Add,
-	unreachable.ControllerName:          unreachable.Add,
-	velerobackup.ControllerName:         velerobackup.Add,
-	clusterpool.ControllerName:          clusterpool.Add,
-	hibernation.ControllerName:          hibernation.Add,
-	awsprivatelink.ControllerName:       awsprivatelink.Add,
-	argocdregister.ControllerName:       argocdregister.Add,
+	clusterclaim.ControllerName:          clusterclaim.Add,
+	clusterdeployment.ControllerName:     clusterdeployment.Add,
+	clusterdeprovision.ControllerName:    clusterdeprovision.Add,
+	clusterpoolnamespace.ControllerName:  clusterpoolnamespace.Add,
+	clusterprovision.ControllerName:      clusterprovision.Add,
+	clusterrelocate.ControllerName:       clusterrelocate.Add,
+	clusterstate.ControllerName:          clusterstate.Add,
+	clustersync.ControllerName:           clustersync.Add,
+	clusterversion.ControllerName:        clusterversion.Add,
+	controlplanecerts.ControllerName:     controlplanecerts.Add,
+	dnsendpoint.ControllerName:           dnsendpoint.Add,
+	dnszone.ControllerName:               dnszone.Add,
+	fakeclusterinstall.ControllerName:    fakeclusterinstall.Add,
+	metrics.ControllerName:               metrics.Add,
+	remoteingress.ControllerName:         remoteingress.Add,
+	machinepool.ControllerName:           machinepool.Add,
+	syncidentityprovider.ControllerName:  syncidentityprovider.Add,
+	unreachable.ControllerName:           unreachable.Add,
+	velerobackup.ControllerName:          velerobackup.Add,
+	clusterpool.ControllerName:           clusterpool.Add,
+	hibernation.ControllerName:           hibernation.Add,
+	awsprivatelink.ControllerName:        awsprivatelink.Add,
+	argocdregister.ControllerName:        argocdregister.Add,
+	tenantquota.ControllerName:           tenantquota.Add,
+	costestimation.ControllerName:        costestimation.Add,
+	remediation.ControllerName:           remediation.Add,
+	kubeadminpassword.ControllerName:     kubeadminpassword.Add,
+	clusteraccesstoken.ControllerName:    clusteraccesstoken.Add,
+	hostedclusterinstall.ControllerName:  hostedclusterinstall.Add,
+	hostinventory.ControllerName:         hostinventory.Add,
+	restorerepair.ControllerName:         restorerepair.Add,
+	garbagecollection.ControllerName:     garbagecollection.Add,
+	spokestatus.ControllerName:           spokestatus.Add,
+	deletionmonitor.ControllerName:       deletionmonitor.Add,
+	logarchive.ControllerName:            logarchive.Add,
+	sensitiveartifacts.ControllerName:    sensitiveartifacts.Add,
+	adminsecretencryption.ControllerName: adminsecretencryption.Add,
 }
 
 // disabledControllerEquivalents contains a mapping of old controller names to their new equivalent so that CLI parameters like --controllers and --disabled-controllers continue to work
@@ -179,6 +207,7 @@ func newRootCommand() *cobra.Command {
 				mgr, err := manager.New(cfg, manager.Options{
 					MetricsBindAddress: ":2112",
 					Logger:             utillogrus.NewLogr(log.StandardLogger()),
+					NewCache:           utils.NewCache(),
 				})
 				if err != nil {
 					log.Fatal(err)
@@ -211,6 +240,10 @@ func newRootCommand() *cobra.Command {
 					log.Fatal(err)
 				}
 
+				if err := utils.IndexDNSZonesByClusterDeployment(mgr.GetFieldIndexer()); err != nil {
+					log.Fatal(err)
+				}
+
 				disabledControllersSet := sets.NewString(opts.DisabledControllers...)
 				// Setup all Controllers
 				for _, name := range opts.Controllers {
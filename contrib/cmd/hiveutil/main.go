@@ -12,6 +12,8 @@ import (
 	"github.com/openshift/hive/contrib/pkg/clusterpool"
 	"github.com/openshift/hive/contrib/pkg/createcluster"
 	"github.com/openshift/hive/contrib/pkg/deprovision"
+	"github.com/openshift/hive/contrib/pkg/logs"
+	"github.com/openshift/hive/contrib/pkg/render"
 	"github.com/openshift/hive/contrib/pkg/report"
 	"github.com/openshift/hive/contrib/pkg/testresource"
 	"github.com/openshift/hive/contrib/pkg/verification"
@@ -54,6 +56,8 @@ func newHiveutilCommand() *cobra.Command {
 	cmd.AddCommand(adm.NewAdmCommand())
 	cmd.AddCommand(version.NewVersionCommand())
 	cmd.AddCommand(clusterpool.NewClusterPoolCommand())
+	cmd.AddCommand(logs.NewLogsCommand())
+	cmd.AddCommand(render.NewRenderCommand())
 
 	return cmd
 }
@@ -2,6 +2,7 @@ package certificate
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -15,8 +16,8 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
 
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 )
@@ -143,7 +144,7 @@ func (o *Options) getBaseDomainID() (string, error) {
 	if err != nil {
 		return "", errors.Wrap(err, "cannot create AWS client; make sure your environment is setup to communicate with AWS")
 	}
-	result, err := client.ListHostedZonesByName(&route53.ListHostedZonesByNameInput{
+	result, err := client.ListHostedZonesByName(context.TODO(), &route53.ListHostedZonesByNameInput{
 		DNSName: aws.String(o.BaseDomain),
 	})
 	if err != nil {
@@ -151,9 +152,9 @@ func (o *Options) getBaseDomainID() (string, error) {
 	}
 	hostedZoneID := ""
 	for _, zone := range result.HostedZones {
-		if strings.TrimSuffix(aws.StringValue(zone.Name), ".") == o.BaseDomain {
-			if zone.Config == nil || !aws.BoolValue(zone.Config.PrivateZone) {
-				hostedZoneID = aws.StringValue(zone.Id)
+		if strings.TrimSuffix(aws.ToString(zone.Name), ".") == o.BaseDomain {
+			if zone.Config == nil || !zone.Config.PrivateZone {
+				hostedZoneID = aws.ToString(zone.Id)
 				break
 			}
 			continue
@@ -31,6 +31,7 @@ import (
 	ovirtutils "github.com/openshift/hive/contrib/pkg/utils/ovirt"
 	"github.com/openshift/hive/pkg/clusterresource"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/gcpclient"
 	"github.com/openshift/installer/pkg/validate"
 )
@@ -143,12 +144,14 @@ type Options struct {
 	UninstallOnce                     bool
 	SimulateBootstrapFailure          bool
 	WorkerNodesCount                  int64
+	Topology                          string
 	CreateSampleSyncsets              bool
 	ManifestsDir                      string
 	Adopt                             bool
 	AdoptAdminKubeConfig              string
 	AdoptInfraID                      string
 	AdoptClusterID                    string
+	AdoptMetadataFile                 string
 	AdoptAdminUsername                string
 	AdoptAdminPassword                string
 	MachineNetwork                    string
@@ -270,6 +273,7 @@ create-cluster CLUSTER_DEPLOYMENT_NAME --cloud=ovirt --ovirt-api-vip 192.168.1.2
 	flags.BoolVar(&opt.UninstallOnce, "uninstall-once", false, "Run the uninstall only one time and fail if not successful")
 	flags.BoolVar(&opt.SimulateBootstrapFailure, "simulate-bootstrap-failure", false, "Simulate an install bootstrap failure by injecting an invalid manifest.")
 	flags.Int64Var(&opt.WorkerNodesCount, "workers", 3, "Number of worker nodes to create.")
+	flags.StringVar(&opt.Topology, "topology", "", "Topology of the cluster. Valid values: HighlyAvailable, Compact (three schedulable control plane nodes and no workers), SingleNode (one schedulable control plane node and no workers)")
 	flags.BoolVar(&opt.CreateSampleSyncsets, "create-sample-syncsets", false, "Create a set of sample syncsets for testing")
 	flags.StringVar(&opt.ManifestsDir, "manifests", "", "Directory containing manifests to add during installation")
 	flags.StringVar(&opt.MachineNetwork, "machine-network", "10.0.0.0/16", "Cluster's MachineNetwork to pass to the installer")
@@ -283,8 +287,9 @@ OpenShift Installer publishes all the services of the cluster like API server an
 	// Flags related to adoption.
 	flags.BoolVar(&opt.Adopt, "adopt", false, "Enable adoption mode for importing a pre-existing cluster into Hive. Will require additional flags for adoption info.")
 	flags.StringVar(&opt.AdoptAdminKubeConfig, "adopt-admin-kubeconfig", "", "Path to a cluster admin kubeconfig file for a cluster being adopted. (required if using --adopt)")
-	flags.StringVar(&opt.AdoptInfraID, "adopt-infra-id", "", "Infrastructure ID for this cluster's cloud provider. (required if using --adopt)")
-	flags.StringVar(&opt.AdoptClusterID, "adopt-cluster-id", "", "Cluster UUID used for telemetry. (required if using --adopt)")
+	flags.StringVar(&opt.AdoptInfraID, "adopt-infra-id", "", "Infrastructure ID for this cluster's cloud provider. (required if using --adopt without --adopt-metadata-file)")
+	flags.StringVar(&opt.AdoptClusterID, "adopt-cluster-id", "", "Cluster UUID used for telemetry. (required if using --adopt without --adopt-metadata-file)")
+	flags.StringVar(&opt.AdoptMetadataFile, "adopt-metadata-file", "", "Path to the metadata.json generated by the installer for a cluster being adopted. Provides the infrastructure ID and cluster UUID when not otherwise specified. (optional)")
 	flags.StringVar(&opt.AdoptAdminUsername, "adopt-admin-username", "", "Username for cluster web console administrator. (optional)")
 	flags.StringVar(&opt.AdoptAdminPassword, "adopt-admin-password", "", "Password for cluster web console administrator. (optional)")
 
@@ -399,21 +404,27 @@ func (o *Options) Validate(cmd *cobra.Command) error {
 	}
 
 	if o.Adopt {
-		if o.AdoptAdminKubeConfig == "" || o.AdoptInfraID == "" || o.AdoptClusterID == "" {
-			return fmt.Errorf("must specify the following options when using --adopt: --adopt-admin-kube-config, --adopt-infra-id, --adopt-cluster-id")
+		if o.AdoptAdminKubeConfig == "" || (o.AdoptMetadataFile == "" && (o.AdoptInfraID == "" || o.AdoptClusterID == "")) {
+			return fmt.Errorf("must specify the following options when using --adopt: --adopt-admin-kube-config, and either --adopt-metadata-file or --adopt-infra-id and --adopt-cluster-id")
 		}
 
 		if _, err := os.Stat(o.AdoptAdminKubeConfig); os.IsNotExist(err) {
 			return fmt.Errorf("--adopt-admin-kubeconfig does not exist: %s", o.AdoptAdminKubeConfig)
 		}
 
+		if o.AdoptMetadataFile != "" {
+			if _, err := os.Stat(o.AdoptMetadataFile); os.IsNotExist(err) {
+				return fmt.Errorf("--adopt-metadata-file does not exist: %s", o.AdoptMetadataFile)
+			}
+		}
+
 		// Admin username and password must both be specified if either are.
 		if (o.AdoptAdminUsername != "" || o.AdoptAdminPassword != "") && !(o.AdoptAdminUsername != "" && o.AdoptAdminPassword != "") {
 			return fmt.Errorf("--adopt-admin-username and --adopt-admin-password must be used together")
 		}
 	} else {
-		if o.AdoptAdminKubeConfig != "" || o.AdoptInfraID != "" || o.AdoptClusterID != "" || o.AdoptAdminUsername != "" || o.AdoptAdminPassword != "" {
-			return fmt.Errorf("cannot use adoption options without --adopt: --adopt-admin-kube-config, --adopt-infra-id, --adopt-cluster-id, --adopt-admin-username, --adopt-admin-password")
+		if o.AdoptAdminKubeConfig != "" || o.AdoptInfraID != "" || o.AdoptClusterID != "" || o.AdoptMetadataFile != "" || o.AdoptAdminUsername != "" || o.AdoptAdminPassword != "" {
+			return fmt.Errorf("cannot use adoption options without --adopt: --adopt-admin-kube-config, --adopt-infra-id, --adopt-cluster-id, --adopt-metadata-file, --adopt-admin-username, --adopt-admin-password")
 		}
 	}
 
@@ -533,6 +544,7 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 		Name:                  o.Name,
 		Namespace:             o.Namespace,
 		WorkerNodesCount:      o.WorkerNodesCount,
+		Topology:              hivev1.ClusterTopology(o.Topology),
 		PullSecret:            pullSecret,
 		SSHPrivateKey:         sshPrivateKey,
 		SSHPublicKey:          sshPublicKey,
@@ -556,6 +568,23 @@ func (o *Options) GenerateObjects() ([]runtime.Object, error) {
 		builder.Adopt = o.Adopt
 		builder.AdoptInfraID = o.AdoptInfraID
 		builder.AdoptClusterID = o.AdoptClusterID
+		if o.AdoptMetadataFile != "" {
+			metadataBytes, err := ioutil.ReadFile(o.AdoptMetadataFile)
+			if err != nil {
+				return nil, err
+			}
+			metadata, err := controllerutils.ParseInstallerMetadata(metadataBytes)
+			if err != nil {
+				return nil, err
+			}
+			if builder.AdoptInfraID == "" {
+				builder.AdoptInfraID = metadata.InfraID
+			}
+			if builder.AdoptClusterID == "" {
+				builder.AdoptClusterID = metadata.ClusterID
+			}
+			builder.AdoptPlatformMetadata = controllerutils.ClusterPlatformMetadata(metadata)
+		}
 		builder.AdoptAdminKubeconfig = kubeconfigBytes
 		builder.AdoptAdminUsername = o.AdoptAdminUsername
 		builder.AdoptAdminPassword = o.AdoptAdminPassword
@@ -17,8 +17,10 @@ import (
 
 // AzureOptions is the set of options to deprovision an Azure cluster
 type AzureOptions struct {
-	logLevel  string
-	cloudName string
+	logLevel                    string
+	cloudName                   string
+	resourceGroupName           string
+	baseDomainResourceGroupName string
 }
 
 // NewDeprovisionAzureCommand is the entrypoint to create the azure deprovision subcommand
@@ -32,7 +34,7 @@ func NewDeprovisionAzureCommand() *cobra.Command {
 			if err := validate(); err != nil {
 				log.WithError(err).Fatal("Failed validating Azure credentials")
 			}
-			uninstaller, err := completeAzureUninstaller(opt.logLevel, opt.cloudName, args)
+			uninstaller, err := completeAzureUninstaller(opt, args)
 			if err != nil {
 				log.WithError(err).Error("Cannot complete command")
 				return
@@ -47,6 +49,8 @@ func NewDeprovisionAzureCommand() *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVar(&opt.logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
 	flags.StringVar(&opt.cloudName, "azure-cloud-name", installertypesazure.PublicCloud.Name(), "cloudName is the name of the Azure cloud environment used to configure the Azure SDK")
+	flags.StringVar(&opt.resourceGroupName, "azure-resource-group-name", "", "resourceGroupName is the name of the resource group of the cluster, if not the one named after the infra ID")
+	flags.StringVar(&opt.baseDomainResourceGroupName, "azure-base-domain-resource-group-name", "", "baseDomainResourceGroupName is the name of the resource group holding the DNS zone for the base domain")
 	return cmd
 }
 
@@ -59,10 +63,10 @@ func validate() error {
 	return nil
 }
 
-func completeAzureUninstaller(logLevel, cloudName string, args []string) (providers.Destroyer, error) {
+func completeAzureUninstaller(opt *AzureOptions, args []string) (providers.Destroyer, error) {
 
 	// Set log level
-	level, err := log.ParseLevel(logLevel)
+	level, err := log.ParseLevel(opt.logLevel)
 	if err != nil {
 		log.WithError(err).Error("cannot parse log level")
 		return nil, err
@@ -81,7 +85,9 @@ func completeAzureUninstaller(logLevel, cloudName string, args []string) (provid
 		InfraID: args[0],
 		ClusterPlatformMetadata: types.ClusterPlatformMetadata{
 			Azure: &installertypesazure.Metadata{
-				CloudName: installertypesazure.CloudEnvironment(cloudName),
+				CloudName:                   installertypesazure.CloudEnvironment(opt.cloudName),
+				ResourceGroupName:           opt.resourceGroupName,
+				BaseDomainResourceGroupName: opt.baseDomainResourceGroupName,
 			},
 		},
 	}
@@ -0,0 +1,91 @@
+package deprovision
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/fakecloud"
+)
+
+// fakeOptions is the set of options to deprovision a fake cluster
+type fakeOptions struct {
+	logLevel      string
+	infraID       string
+	inventoryFile string
+}
+
+// NewDeprovisionFakeCommand is the entrypoint to create the fake deprovision subcommand
+func NewDeprovisionFakeCommand() *cobra.Command {
+	opt := &fakeOptions{}
+	cmd := &cobra.Command{
+		Use:   "fake INFRAID",
+		Short: "Deprovision a fake cluster from a simulated cloud inventory",
+		Long: fmt.Sprintf("Deprovision a fake cluster from a simulated cloud inventory, read from --inventory-file or "+
+			"the %s environment variable, or a typical AWS inventory owned by the cluster otherwise. Fails when resources "+
+			"owned by the cluster are leaked.", constants.FakeCloudInventoryEnvVar),
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opt.Complete(cmd, args); err != nil {
+				log.WithError(err).Fatal("failed to complete options")
+			}
+			if err := opt.Run(); err != nil {
+				log.WithError(err).Fatal("Runtime error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVar(&opt.logLevel, "loglevel", "info", "log level, one of: debug, info, warn, error, fatal, panic")
+	flags.StringVar(&opt.inventoryFile, "inventory-file", "", "JSON file holding the simulated cloud inventory")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *fakeOptions) Complete(cmd *cobra.Command, args []string) error {
+	o.infraID = args[0]
+	return nil
+}
+
+// Run executes the command
+func (o *fakeOptions) Run() error {
+	level, err := log.ParseLevel(o.logLevel)
+	if err != nil {
+		log.WithError(err).Error("cannot parse log level")
+		return err
+	}
+	log.SetLevel(level)
+	logger := log.WithField("infraID", o.infraID)
+
+	var data []byte
+	switch {
+	case o.inventoryFile != "":
+		if data, err = ioutil.ReadFile(o.inventoryFile); err != nil {
+			return err
+		}
+	case os.Getenv(constants.FakeCloudInventoryEnvVar) != "":
+		data = []byte(os.Getenv(constants.FakeCloudInventoryEnvVar))
+	}
+	inventory := fakecloud.DefaultInventory(o.infraID)
+	if data != nil {
+		if inventory, err = fakecloud.Parse(data); err != nil {
+			return err
+		}
+	}
+
+	logger.WithField("resources", len(inventory.Resources)).Info("deprovisioning fake cluster")
+	leaked := fakecloud.Destroy(inventory, o.infraID, logger)
+	if len(leaked) > 0 {
+		ids := make([]string, len(leaked))
+		for i, r := range leaked {
+			ids[i] = r.ID
+		}
+		return fmt.Errorf("leaked %d resources owned by the cluster: %s", len(leaked), strings.Join(ids, ", "))
+	}
+	logger.WithField("remaining", len(inventory.Resources)).Info("deprovisioned fake cluster without leaking resources")
+	return nil
+}
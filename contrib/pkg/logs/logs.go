@@ -0,0 +1,142 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/logarchive"
+)
+
+// Options is the set of options for printing the URLs of archived logs.
+type Options struct {
+	Name      string
+	Namespace string
+	Expiry    time.Duration
+
+	log log.FieldLogger
+}
+
+// NewLogsCommand creates a command that prints signed URLs to the archived install and uninstall logs of a
+// ClusterDeployment.
+func NewLogsCommand() *cobra.Command {
+	opt := &Options{log: log.WithField("command", "logs")}
+
+	cmd := &cobra.Command{
+		Use:   "logs CLUSTER_DEPLOYMENT_NAME",
+		Short: "prints URLs to the archived install and uninstall logs of a ClusterDeployment",
+		Long: "prints signed URLs to the install and uninstall pod logs of a ClusterDeployment which were archived in " +
+			"the log archive configured in HiveConfig",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			opt.Name = args[0]
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Namespace of the cluster deployment")
+	flags.DurationVar(&opt.Expiry, "expiry", time.Hour, "How long the printed URLs are valid")
+
+	return cmd
+}
+
+func (o *Options) run() error {
+	c, err := utils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "could not create the kube client")
+	}
+	if len(o.Namespace) == 0 {
+		o.Namespace, err = utils.DefaultNamespace()
+		if err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+
+	hiveConfig := &hivev1.HiveConfig{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: constants.HiveConfigName}, hiveConfig); err != nil {
+		return errors.Wrap(err, "could not get HiveConfig")
+	}
+	if hiveConfig.Spec.LogArchive == nil {
+		return errors.New("no log archive is configured in HiveConfig")
+	}
+	hiveNamespace := hiveConfig.Spec.TargetNamespace
+	if hiveNamespace == "" {
+		hiveNamespace = constants.DefaultHiveNamespace
+	}
+	archive, err := logarchive.New(c, hiveNamespace, hiveConfig.Spec.LogArchive)
+	if err != nil {
+		return err
+	}
+
+	owners, err := o.archivedOwners(c)
+	if err != nil {
+		return err
+	}
+	if len(owners) == 0 {
+		o.log.Info("no archived logs found")
+		return nil
+	}
+	for _, owner := range owners {
+		keys := []string{}
+		if err := json.Unmarshal([]byte(owner.GetAnnotations()[constants.ArchivedLogsAnnotation]), &keys); err != nil {
+			return errors.Wrapf(err, "could not parse the archived logs of %s", owner.GetName())
+		}
+		for _, key := range keys {
+			url, err := archive.SignURL(key, o.Expiry)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s: %s\n", key, url)
+		}
+	}
+	return nil
+}
+
+// archivedOwners returns the ClusterProvisions, in order of attempt, and the ClusterDeprovision of the
+// ClusterDeployment whose logs were archived.
+func (o *Options) archivedOwners(c client.Client) ([]client.Object, error) {
+	provisions := &hivev1.ClusterProvisionList{}
+	if err := c.List(context.Background(), provisions,
+		client.InNamespace(o.Namespace),
+		client.MatchingLabels{constants.ClusterDeploymentNameLabel: o.Name},
+	); err != nil {
+		return nil, errors.Wrap(err, "could not list cluster provisions")
+	}
+	sort.Slice(provisions.Items, func(i, j int) bool {
+		return provisions.Items[i].Spec.Attempt < provisions.Items[j].Spec.Attempt
+	})
+
+	owners := []client.Object{}
+	for i := range provisions.Items {
+		if _, ok := provisions.Items[i].Annotations[constants.ArchivedLogsAnnotation]; ok {
+			owners = append(owners, &provisions.Items[i])
+		}
+	}
+
+	deprovision := &hivev1.ClusterDeprovision{}
+	switch err := c.Get(context.Background(), client.ObjectKey{Namespace: o.Namespace, Name: o.Name}, deprovision); {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return nil, errors.Wrap(err, "could not get cluster deprovision")
+	default:
+		if _, ok := deprovision.Annotations[constants.ArchivedLogsAnnotation]; ok {
+			owners = append(owners, deprovision)
+		}
+	}
+	return owners, nil
+}
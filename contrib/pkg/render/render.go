@@ -0,0 +1,334 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/cli-runtime/pkg/printers"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	autoscalingv1 "github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1"
+	autoscalingv1beta1 "github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1beta1"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/clusterdeployment"
+	"github.com/openshift/hive/pkg/controller/machinepool"
+	"github.com/openshift/hive/pkg/installmanager"
+	"github.com/openshift/hive/pkg/manageddns"
+)
+
+// installConfigKey is the key of the install-config in install-config secrets.
+const installConfigKey = "install-config.yaml"
+
+// Options is the set of options for rendering the objects Hive derives from a spec file.
+type Options struct {
+	SpecFile       string
+	Output         string
+	MachinePool    string
+	InfraID        string
+	ClusterVersion string
+
+	log log.FieldLogger
+}
+
+// spec is the content of a spec file.
+type spec struct {
+	clusterDeployment *hivev1.ClusterDeployment
+	machinePools      []*hivev1.MachinePool
+	secrets           []*corev1.Secret
+	hiveConfig        *hivev1.HiveConfig
+	masterMachine     *machineapi.Machine
+}
+
+// NewRenderCommand creates a command that renders the objects Hive derives from ClusterDeployments and MachinePools,
+// without a hub.
+func NewRenderCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "renders the objects Hive derives from a ClusterDeployment or MachinePool without a hub",
+		Long: "renders the objects Hive derives from a ClusterDeployment or MachinePool read from a spec file, " +
+			"without a hub, so that pipelines can review what Hive will do before the spec is applied",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(newRenderClusterDeploymentCommand())
+	cmd.AddCommand(newRenderMachinePoolCommand())
+	return cmd
+}
+
+func newRenderClusterDeploymentCommand() *cobra.Command {
+	opt := &Options{log: log.WithField("command", "render clusterdeployment")}
+	cmd := &cobra.Command{
+		Use:   "clusterdeployment",
+		Short: "renders the install-config and DNSZone of a ClusterDeployment",
+		Long: "renders the install-config the installer is run with, and the DNSZone of clusters with managed DNS. " +
+			"The spec file holds the ClusterDeployment, its install-config secret, and optionally the HiveConfig " +
+			"configuring the managed domains.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opt.run(opt.renderClusterDeployment); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	opt.addFlags(cmd)
+	return cmd
+}
+
+func newRenderMachinePoolCommand() *cobra.Command {
+	opt := &Options{log: log.WithField("command", "render machinepool")}
+	cmd := &cobra.Command{
+		Use:   "machinepool",
+		Short: "renders the MachineSets and autoscalers of MachinePools",
+		Long: "renders the MachineSets, MachineAutoscalers, ClusterAutoscaler and machine config resources Hive syncs " +
+			"to a cluster for its MachinePools. The spec file holds the ClusterDeployment, the MachinePools, and " +
+			"optionally a master Machine of the cluster, which some platforms need to find the image of the machines.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opt.run(opt.renderMachinePools); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	opt.addFlags(cmd)
+	flags := cmd.Flags()
+	flags.StringVar(&opt.MachinePool, "name", "", "Name of the MachinePool to render. Defaults to all the MachinePools of the spec file")
+	flags.StringVar(&opt.InfraID, "infra-id", "", "Infrastructure ID of the cluster, when the ClusterDeployment has no cluster metadata yet")
+	flags.StringVar(&opt.ClusterVersion, "cluster-version", "", "Version of the cluster, such as 4.8.2, when the ClusterDeployment does not have the version label yet")
+	return cmd
+}
+
+func (o *Options) addFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVarP(&o.SpecFile, "filename", "f", "", "Spec file holding the objects to render from, or - for standard input")
+	flags.StringVarP(&o.Output, "output", "o", "yaml", "Output format of the rendered objects, yaml or json")
+	cmd.MarkFlagRequired("filename")
+}
+
+func (o *Options) run(render func(*spec) ([]runtime.Object, error)) error {
+	var printer printers.ResourcePrinter
+	switch o.Output {
+	case "yaml":
+		printer = &printers.YAMLPrinter{}
+	case "json":
+		printer = &printers.JSONPrinter{}
+	default:
+		return fmt.Errorf("unsupported output format %q", o.Output)
+	}
+
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		apis.AddToScheme,
+		corev1.AddToScheme,
+		machineapi.Install,
+		autoscalingv1.SchemeBuilder.AddToScheme,
+		autoscalingv1beta1.SchemeBuilder.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			return err
+		}
+	}
+
+	var in io.Reader = os.Stdin
+	if o.SpecFile != "-" {
+		f, err := os.Open(o.SpecFile)
+		if err != nil {
+			return errors.Wrap(err, "could not open the spec file")
+		}
+		defer f.Close()
+		in = f
+	}
+	s, err := readSpec(in, scheme, o.log)
+	if err != nil {
+		return err
+	}
+	objects, err := render(s)
+	if err != nil {
+		return err
+	}
+	return printObjects(objects, scheme, printer)
+}
+
+// readSpec decodes the YAML or JSON documents of the spec file.
+func readSpec(in io.Reader, scheme *runtime.Scheme, logger log.FieldLogger) (*spec, error) {
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read the spec file")
+	}
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	docs := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	s := &spec{}
+	for {
+		raw := runtime.RawExtension{}
+		if err := docs.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Wrap(err, "could not parse the spec file")
+		}
+		if len(bytes.TrimSpace(raw.Raw)) == 0 || string(bytes.TrimSpace(raw.Raw)) == "null" {
+			continue
+		}
+		obj, gvk, err := decoder.Decode(raw.Raw, nil, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not decode an object of the spec file")
+		}
+		switch obj := obj.(type) {
+		case *hivev1.ClusterDeployment:
+			if s.clusterDeployment != nil {
+				return nil, errors.New("the spec file must hold a single ClusterDeployment")
+			}
+			s.clusterDeployment = obj
+		case *hivev1.MachinePool:
+			s.machinePools = append(s.machinePools, obj)
+		case *corev1.Secret:
+			s.secrets = append(s.secrets, obj)
+		case *hivev1.HiveConfig:
+			s.hiveConfig = obj
+		case *machineapi.Machine:
+			s.masterMachine = obj
+		default:
+			logger.WithField("kind", gvk.Kind).Info("ignoring object which Hive derives nothing from")
+		}
+	}
+	if s.clusterDeployment == nil {
+		return nil, errors.New("the spec file does not hold a ClusterDeployment")
+	}
+	return s, nil
+}
+
+func (o *Options) renderClusterDeployment(s *spec) ([]runtime.Object, error) {
+	cd := s.clusterDeployment
+	var objects []runtime.Object
+
+	if cd.Spec.Provisioning != nil && cd.Spec.Provisioning.InstallConfigSecretRef != nil {
+		name := cd.Spec.Provisioning.InstallConfigSecretRef.Name
+		secret := s.secret(name)
+		if secret == nil {
+			return nil, fmt.Errorf("the spec file does not hold the install-config secret %s", name)
+		}
+		icData, ok := secret.Data[installConfigKey]
+		if !ok {
+			icData = []byte(secret.StringData[installConfigKey])
+		}
+		if len(icData) == 0 {
+			return nil, fmt.Errorf("the install-config secret %s does not contain %q data", name, installConfigKey)
+		}
+		if err := clusterdeployment.ValidateInstallConfig(cd, icData); err != nil {
+			return nil, errors.Wrap(err, "the install-config is not valid for the ClusterDeployment")
+		}
+		icData, err := installmanager.InstallConfigForClusterDeployment(icData, cd)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: name},
+			StringData: map[string]string{installConfigKey: string(icData)},
+		})
+	}
+
+	if cd.Spec.ManageDNS {
+		var managedDomains []hivev1.ManageDNSConfig
+		if s.hiveConfig != nil {
+			managedDomains = s.hiveConfig.Spec.ManagedDomains
+		}
+		managedDomain := manageddns.FindChildZonesManagedDomain(managedDomains, cd.Spec.BaseDomain)
+		if managedDomain == nil && cd.Spec.Platform.AWS == nil && cd.Spec.Platform.GCP == nil && cd.Spec.Platform.Azure == nil {
+			return nil, errors.New("managed DNS is not supported on the platform of the ClusterDeployment")
+		}
+		objects = append(objects, clusterdeployment.GenerateDNSZone(cd, managedDomain))
+	}
+	return objects, nil
+}
+
+func (o *Options) renderMachinePools(s *spec) ([]runtime.Object, error) {
+	cd := s.clusterDeployment
+	if cd.Spec.ClusterMetadata == nil {
+		if o.InfraID == "" {
+			return nil, errors.New("the ClusterDeployment has no cluster metadata, so --infra-id is required")
+		}
+		cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{InfraID: o.InfraID}
+	}
+	if _, ok := cd.Labels[constants.VersionMajorMinorPatchLabel]; !ok && o.ClusterVersion != "" {
+		if cd.Labels == nil {
+			cd.Labels = map[string]string{}
+		}
+		cd.Labels[constants.VersionMajorMinorPatchLabel] = o.ClusterVersion
+	}
+
+	var objects []runtime.Object
+	clusterAutoscaler := false
+	found := false
+	for _, pool := range s.machinePools {
+		if pool.Spec.ClusterDeploymentRef.Name != cd.Name || (o.MachinePool != "" && pool.Name != o.MachinePool) {
+			continue
+		}
+		found = true
+		poolObjects, err := machinepool.Render(cd, pool, s.masterMachine, o.log.WithField("machinePool", pool.Name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not render MachinePool %s", pool.Name)
+		}
+		for _, obj := range poolObjects {
+			// Auto-scaling pools share the ClusterAutoscaler of the cluster.
+			if _, ok := obj.(*autoscalingv1.ClusterAutoscaler); ok {
+				if clusterAutoscaler {
+					continue
+				}
+				clusterAutoscaler = true
+			}
+			objects = append(objects, obj)
+		}
+	}
+	if !found {
+		return nil, errors.New("the spec file does not hold a MachinePool of the ClusterDeployment to render")
+	}
+	return objects, nil
+}
+
+func (s *spec) secret(name string) *corev1.Secret {
+	for _, secret := range s.secrets {
+		if secret.Name == name && (secret.Namespace == "" || secret.Namespace == s.clusterDeployment.Namespace) {
+			return secret
+		}
+	}
+	return nil
+}
+
+func printObjects(objects []runtime.Object, scheme *runtime.Scheme, printer printers.ResourcePrinter) error {
+	list := &metav1.List{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "List",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+	}
+	for _, obj := range objects {
+		// Unstructured objects carry their own type, which the type setter cannot look up in the scheme.
+		if _, ok := obj.(runtime.Unstructured); ok {
+			continue
+		}
+		gvks, _, err := scheme.ObjectKinds(obj)
+		if err != nil {
+			return err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvks[0])
+	}
+	if err := meta.SetList(list, objects); err != nil {
+		return err
+	}
+	return printer.PrintObj(list, os.Stdout)
+}
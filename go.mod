@@ -7,8 +7,21 @@ require (
 	github.com/Azure/go-autorest/autorest v0.11.18
 	github.com/Azure/go-autorest/autorest/azure/auth v0.4.1
 	github.com/Azure/go-autorest/autorest/to v0.4.0
-	github.com/aws/aws-sdk-go v1.38.41
+	github.com/aws/aws-sdk-go v1.38.41 // indirect
+	github.com/aws/aws-sdk-go-v2 v1.16.16
+	github.com/aws/aws-sdk-go-v2/config v1.17.8
+	github.com/aws/aws-sdk-go-v2/credentials v1.12.21
+	github.com/aws/aws-sdk-go-v2/feature/s3/manager v1.11.33
+	github.com/aws/aws-sdk-go-v2/service/ec2 v1.63.1
+	github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2 v1.18.19
+	github.com/aws/aws-sdk-go-v2/service/kms v1.18.11
+	github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi v1.13.19
+	github.com/aws/aws-sdk-go-v2/service/route53 v1.22.1
+	github.com/aws/aws-sdk-go-v2/service/s3 v1.27.11
+	github.com/aws/aws-sdk-go-v2/service/sts v1.16.19
+	github.com/aws/smithy-go v1.13.3
 	github.com/blang/semver/v4 v4.0.0
+	github.com/coreos/stream-metadata-go v0.1.3
 	github.com/davecgh/go-spew v1.1.1
 	github.com/davegardnerisme/deephash v0.0.0-20210406090112-6d072427d830
 	github.com/evanphx/json-patch v4.11.0+incompatible
@@ -17,7 +30,8 @@ require (
 	github.com/go-logr/logr v0.4.0
 	github.com/golang/mock v1.6.0
 	github.com/golangci/golangci-lint v1.42.1
-	github.com/google/go-cmp v0.5.6
+	github.com/google/go-cmp v0.5.8
+	github.com/google/gofuzz v1.2.0
 	github.com/google/uuid v1.2.0
 	github.com/gophercloud/utils v0.0.0-20210323225332-7b186010c04f
 	github.com/heptio/velero v1.0.0
@@ -33,6 +33,8 @@ then
 fi
 
 
+SIMULATED=${SIMULATED:-false}
+
 for (( i=${START}; i<=${END}; i++ ))
 do
 	cluster_name="c${i}"
@@ -43,5 +45,6 @@ do
 		--namespace=${ns} \
 		-l scaletest=true --skip-machine-pools \
 		-a "hive.openshift.io/fake-cluster=true" \
+		-a "hive.openshift.io/simulated-cluster=${SIMULATED}" \
 		${cluster_name}
 done
@@ -0,0 +1,84 @@
+#!/usr/bin/env python
+
+import os
+import re
+import sys
+import yaml
+
+usage="""
+Usage: %s crdvalidations_dir crds_dir
+
+Verify that the CEL validation rules of the yq write scripts in
+`crdvalidations_dir`, which are their only source, are written to the CRDs of
+the same name in `crds_dir`, and that the CRDs have no other rules. Run
+`make crd` to write them.
+"""
+
+if len(sys.argv) != 3 or any('-h' in x for x in sys.argv[1:]):
+    print(usage % sys.argv[0])
+    sys.exit(-1)
+
+crdvalidations_dir = sys.argv[1]
+crds_dir = sys.argv[2]
+
+path_element = re.compile(r'^([^\[]+)((?:\[\d+\])*)$')
+
+
+def lookup(obj, path):
+    """Return the value at the yq path in obj, or None if it does not exist."""
+    for element in path.split('.'):
+        match = path_element.match(element)
+        if match is None:
+            raise ValueError("unsupported path element %s" % element)
+        if not isinstance(obj, dict) or match.group(1) not in obj:
+            return None
+        obj = obj[match.group(1)]
+        for index in re.findall(r'\[(\d+)\]', match.group(2)):
+            if not isinstance(obj, list) or int(index) >= len(obj):
+                return None
+            obj = obj[int(index)]
+    return obj
+
+
+def validation_paths(obj, path=''):
+    """Yield the yq paths of the x-kubernetes-validations in obj."""
+    if isinstance(obj, dict):
+        for key, value in obj.items():
+            child = '%s.%s' % (path, key) if path else key
+            if key == 'x-kubernetes-validations':
+                yield child
+            else:
+                for p in validation_paths(value, child):
+                    yield p
+    elif isinstance(obj, list):
+        for i, value in enumerate(obj):
+            for p in validation_paths(value, '%s[%d]' % (path, i)):
+                yield p
+
+
+failed = False
+for name in sorted(os.listdir(crdvalidations_dir)):
+    if not name.endswith('.yaml'):
+        continue
+    with open(os.path.join(crdvalidations_dir, name), 'r') as f:
+        rules = yaml.load(f, Loader=yaml.SafeLoader)
+    crd_file = os.path.join(crds_dir, name)
+    if not os.path.isfile(crd_file):
+        print("%s: No such file" % crd_file)
+        failed = True
+        continue
+    with open(crd_file, 'r') as f:
+        crd = yaml.load(f, Loader=yaml.SafeLoader)
+    for path, expected in rules.items():
+        if lookup(crd, path) != expected:
+            print("%s: the CEL validation rules at %s differ from %s. Run 'make crd'" %
+                  (crd_file, path, os.path.join(crdvalidations_dir, name)))
+            failed = True
+    for path in validation_paths(crd):
+        if path not in rules:
+            print("%s: the CEL validation rules at %s are not in %s" %
+                  (crd_file, path, os.path.join(crdvalidations_dir, name)))
+            failed = True
+
+if failed:
+    sys.exit(1)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -16,29 +17,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
-	"github.com/aws/aws-sdk-go/aws/endpoints"
-	"github.com/aws/aws-sdk-go/aws/request"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
-	"github.com/aws/aws-sdk-go/service/elb"
-	"github.com/aws/aws-sdk-go/service/elb/elbiface"
-	"github.com/aws/aws-sdk-go/service/elbv2"
-	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
-	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
-	"github.com/aws/aws-sdk-go/service/route53"
-	"github.com/aws/aws-sdk-go/service/route53/route53iface"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/aws/aws-sdk-go/service/s3/s3iface"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
-	"github.com/aws/aws-sdk-go/service/sts"
-	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
 
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/externalsecret"
 )
 
 var (
@@ -60,242 +56,281 @@ func init() {
 // Client is a wrapper object for actual AWS SDK clients to allow for easier testing.
 type Client interface {
 	// EC2
-	DescribeAvailabilityZones(*ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error)
-	DescribeSubnets(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
-	DescribeRouteTables(*ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error)
-	DescribeInstances(*ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error)
-	StopInstances(*ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error)
-	TerminateInstances(*ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error)
-	StartInstances(*ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error)
-	CreateVpcEndpointServiceConfiguration(*ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error)
-	DescribeVpcEndpointServiceConfigurations(*ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error)
-	ModifyVpcEndpointServiceConfiguration(*ec2.ModifyVpcEndpointServiceConfigurationInput) (*ec2.ModifyVpcEndpointServiceConfigurationOutput, error)
-	DeleteVpcEndpointServiceConfigurations(*ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error)
-	DescribeVpcEndpointServicePermissions(*ec2.DescribeVpcEndpointServicePermissionsInput) (*ec2.DescribeVpcEndpointServicePermissionsOutput, error)
-	ModifyVpcEndpointServicePermissions(*ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error)
-	DescribeVpcEndpointServices(*ec2.DescribeVpcEndpointServicesInput) (*ec2.DescribeVpcEndpointServicesOutput, error)
-	DescribeVpcEndpoints(*ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error)
-	DescribeNetworkInterfaces(input *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error)
-	CreateVpcEndpoint(*ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error)
-	DeleteVpcEndpoints(*ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error)
+	DescribeAvailabilityZones(context.Context, *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error)
+	DescribeInstanceTypeOfferings(context.Context, *ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error)
+	DescribeSubnets(context.Context, *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
+	DescribeRouteTables(context.Context, *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error)
+	DescribeNatGateways(context.Context, *ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error)
+	DescribeInstances(context.Context, *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error)
+	StopInstances(context.Context, *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error)
+	TerminateInstances(context.Context, *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error)
+	StartInstances(context.Context, *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error)
+	CreateTags(context.Context, *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error)
+	CreateVpcEndpointServiceConfiguration(context.Context, *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error)
+	DescribeVpcEndpointServiceConfigurations(context.Context, *ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error)
+	ModifyVpcEndpointServiceConfiguration(context.Context, *ec2.ModifyVpcEndpointServiceConfigurationInput) (*ec2.ModifyVpcEndpointServiceConfigurationOutput, error)
+	DeleteVpcEndpointServiceConfigurations(context.Context, *ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error)
+	DescribeVpcEndpointServicePermissions(context.Context, *ec2.DescribeVpcEndpointServicePermissionsInput) (*ec2.DescribeVpcEndpointServicePermissionsOutput, error)
+	ModifyVpcEndpointServicePermissions(context.Context, *ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error)
+	DescribeVpcEndpointServices(context.Context, *ec2.DescribeVpcEndpointServicesInput) (*ec2.DescribeVpcEndpointServicesOutput, error)
+	DescribeVpcEndpoints(context.Context, *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error)
+	DescribeNetworkInterfaces(context.Context, *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error)
+	CreateVpcEndpoint(context.Context, *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error)
+	DeleteVpcEndpoints(context.Context, *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error)
 
 	// ELBV2
-	DescribeLoadBalancers(*elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error)
+	DescribeLoadBalancers(context.Context, *elasticloadbalancingv2.DescribeLoadBalancersInput) (*elasticloadbalancingv2.DescribeLoadBalancersOutput, error)
 
-	// S3 Manager
-	Upload(*s3manager.UploadInput) (*s3manager.UploadOutput, error)
+	// S3
+	GetBucketLifecycleConfiguration(context.Context, *s3.GetBucketLifecycleConfigurationInput) (*s3.GetBucketLifecycleConfigurationOutput, error)
+	PutBucketLifecycleConfiguration(context.Context, *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error)
+	PresignGetObject(context.Context, *s3.GetObjectInput, time.Duration) (*v4.PresignedHTTPRequest, error)
 
-	// Custom
-	GetS3API() s3iface.S3API
+	// S3 Manager
+	Upload(context.Context, *s3.PutObjectInput) (*manager.UploadOutput, error)
 
 	// Route53
-	CreateHostedZone(input *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error)
-	GetHostedZone(*route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error)
-	ListTagsForResource(*route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error)
-	ChangeTagsForResource(input *route53.ChangeTagsForResourceInput) (*route53.ChangeTagsForResourceOutput, error)
-	DeleteHostedZone(input *route53.DeleteHostedZoneInput) (*route53.DeleteHostedZoneOutput, error)
-	ListResourceRecordSets(input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error)
-	ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error)
-	ListHostedZonesByVPC(input *route53.ListHostedZonesByVPCInput) (*route53.ListHostedZonesByVPCOutput, error)
-	ChangeResourceRecordSets(*route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error)
-	CreateVPCAssociationAuthorization(*route53.CreateVPCAssociationAuthorizationInput) (*route53.CreateVPCAssociationAuthorizationOutput, error)
-	DeleteVPCAssociationAuthorization(*route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error)
-	AssociateVPCWithHostedZone(*route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error)
-	DisassociateVPCFromHostedZone(input *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error)
+	CreateHostedZone(context.Context, *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error)
+	GetHostedZone(context.Context, *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error)
+	ListTagsForResource(context.Context, *route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error)
+	ChangeTagsForResource(context.Context, *route53.ChangeTagsForResourceInput) (*route53.ChangeTagsForResourceOutput, error)
+	DeleteHostedZone(context.Context, *route53.DeleteHostedZoneInput) (*route53.DeleteHostedZoneOutput, error)
+	ListResourceRecordSets(context.Context, *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error)
+	ListHostedZonesByName(context.Context, *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error)
+	ListHostedZonesByVPC(context.Context, *route53.ListHostedZonesByVPCInput) (*route53.ListHostedZonesByVPCOutput, error)
+	ChangeResourceRecordSets(context.Context, *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error)
+	CreateVPCAssociationAuthorization(context.Context, *route53.CreateVPCAssociationAuthorizationInput) (*route53.CreateVPCAssociationAuthorizationOutput, error)
+	DeleteVPCAssociationAuthorization(context.Context, *route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error)
+	AssociateVPCWithHostedZone(context.Context, *route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error)
+	DisassociateVPCFromHostedZone(context.Context, *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error)
 	// ResourceTagging
-	GetResourcesPages(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error
+	GetResourcesPages(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error
 
 	// STS
-	GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error)
+	GetCallerIdentity(context.Context, *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error)
 }
 
 type awsClient struct {
-	ec2Client     ec2iface.EC2API
-	elbClient     elbiface.ELBAPI
-	elbv2Client   elbv2iface.ELBV2API
-	route53Client route53iface.Route53API
-	s3Client      s3iface.S3API
-	s3Uploader    *s3manager.Uploader
-	stsClient     stsiface.STSAPI
-	tagClient     *resourcegroupstaggingapi.ResourceGroupsTaggingAPI
+	ec2Client     *ec2.Client
+	elbv2Client   *elasticloadbalancingv2.Client
+	route53Client *route53.Client
+	s3Client      *s3.Client
+	s3Uploader    *manager.Uploader
+	stsClient     *sts.Client
+	tagClient     *resourcegroupstaggingapi.Client
 }
 
-func (c *awsClient) DescribeAvailabilityZones(input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
+func (c *awsClient) DescribeAvailabilityZones(ctx context.Context, input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeAvailabilityZones").Inc()
-	return c.ec2Client.DescribeAvailabilityZones(input)
+	return c.ec2Client.DescribeAvailabilityZones(ctx, input)
 }
 
-func (c *awsClient) DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+func (c *awsClient) DescribeInstanceTypeOfferings(ctx context.Context, input *ec2.DescribeInstanceTypeOfferingsInput) (*ec2.DescribeInstanceTypeOfferingsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeInstanceTypeOfferings").Inc()
+	return c.ec2Client.DescribeInstanceTypeOfferings(ctx, input)
+}
+
+func (c *awsClient) DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeSubnets").Inc()
-	return c.ec2Client.DescribeSubnets(input)
+	return c.ec2Client.DescribeSubnets(ctx, input)
 }
 
-func (c *awsClient) DescribeRouteTables(input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
+func (c *awsClient) DescribeRouteTables(ctx context.Context, input *ec2.DescribeRouteTablesInput) (*ec2.DescribeRouteTablesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeRouteTables").Inc()
-	return c.ec2Client.DescribeRouteTables(input)
+	return c.ec2Client.DescribeRouteTables(ctx, input)
+}
+
+func (c *awsClient) DescribeNatGateways(ctx context.Context, input *ec2.DescribeNatGatewaysInput) (*ec2.DescribeNatGatewaysOutput, error) {
+	metricAWSAPICalls.WithLabelValues("DescribeNatGateways").Inc()
+	return c.ec2Client.DescribeNatGateways(ctx, input)
 }
 
-func (c *awsClient) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+func (c *awsClient) DescribeInstances(ctx context.Context, input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeInstances").Inc()
-	return c.ec2Client.DescribeInstances(input)
+	return c.ec2Client.DescribeInstances(ctx, input)
 }
 
-func (c *awsClient) StopInstances(input *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
+func (c *awsClient) StopInstances(ctx context.Context, input *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("StopInstances").Inc()
-	return c.ec2Client.StopInstances(input)
+	return c.ec2Client.StopInstances(ctx, input)
 }
 
-func (c *awsClient) TerminateInstances(input *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) {
+func (c *awsClient) TerminateInstances(ctx context.Context, input *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("TerminateInstances").Inc()
-	return c.ec2Client.TerminateInstances(input)
+	return c.ec2Client.TerminateInstances(ctx, input)
 }
 
-func (c *awsClient) StartInstances(input *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
+func (c *awsClient) StartInstances(ctx context.Context, input *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("StartInstances").Inc()
-	return c.ec2Client.StartInstances(input)
+	return c.ec2Client.StartInstances(ctx, input)
+}
+
+func (c *awsClient) CreateTags(ctx context.Context, input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("CreateTags").Inc()
+	return c.ec2Client.CreateTags(ctx, input)
 }
 
-func (c *awsClient) CreateVpcEndpointServiceConfiguration(input *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error) {
+func (c *awsClient) CreateVpcEndpointServiceConfiguration(ctx context.Context, input *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error) {
 	metricAWSAPICalls.WithLabelValues("CreateVpcEndpointServiceConfiguration").Inc()
-	return c.ec2Client.CreateVpcEndpointServiceConfiguration(input)
+	return c.ec2Client.CreateVpcEndpointServiceConfiguration(ctx, input)
 }
 
-func (c *awsClient) DescribeVpcEndpointServiceConfigurations(input *ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error) {
+func (c *awsClient) DescribeVpcEndpointServiceConfigurations(ctx context.Context, input *ec2.DescribeVpcEndpointServiceConfigurationsInput) (*ec2.DescribeVpcEndpointServiceConfigurationsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeVpcEndpointServiceConfigurations").Inc()
-	return c.ec2Client.DescribeVpcEndpointServiceConfigurations(input)
+	return c.ec2Client.DescribeVpcEndpointServiceConfigurations(ctx, input)
 }
 
-func (c *awsClient) ModifyVpcEndpointServiceConfiguration(input *ec2.ModifyVpcEndpointServiceConfigurationInput) (*ec2.ModifyVpcEndpointServiceConfigurationOutput, error) {
+func (c *awsClient) ModifyVpcEndpointServiceConfiguration(ctx context.Context, input *ec2.ModifyVpcEndpointServiceConfigurationInput) (*ec2.ModifyVpcEndpointServiceConfigurationOutput, error) {
 	metricAWSAPICalls.WithLabelValues("ModifyVpcEndpointServiceConfiguration").Inc()
-	return c.ec2Client.ModifyVpcEndpointServiceConfiguration(input)
+	return c.ec2Client.ModifyVpcEndpointServiceConfiguration(ctx, input)
 }
 
-func (c *awsClient) DeleteVpcEndpointServiceConfigurations(input *ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error) {
+func (c *awsClient) DeleteVpcEndpointServiceConfigurations(ctx context.Context, input *ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DeleteVpcEndpointServiceConfigurations").Inc()
-	return c.ec2Client.DeleteVpcEndpointServiceConfigurations(input)
+	return c.ec2Client.DeleteVpcEndpointServiceConfigurations(ctx, input)
 }
 
-func (c *awsClient) DescribeVpcEndpointServicePermissions(input *ec2.DescribeVpcEndpointServicePermissionsInput) (*ec2.DescribeVpcEndpointServicePermissionsOutput, error) {
+func (c *awsClient) DescribeVpcEndpointServicePermissions(ctx context.Context, input *ec2.DescribeVpcEndpointServicePermissionsInput) (*ec2.DescribeVpcEndpointServicePermissionsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeVpcEndpointServicePermissions").Inc()
-	return c.ec2Client.DescribeVpcEndpointServicePermissions(input)
+	return c.ec2Client.DescribeVpcEndpointServicePermissions(ctx, input)
 }
 
-func (c *awsClient) ModifyVpcEndpointServicePermissions(input *ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error) {
+func (c *awsClient) ModifyVpcEndpointServicePermissions(ctx context.Context, input *ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("ModifyVpcEndpointServicePermissions").Inc()
-	return c.ec2Client.ModifyVpcEndpointServicePermissions(input)
+	return c.ec2Client.ModifyVpcEndpointServicePermissions(ctx, input)
 }
 
-func (c *awsClient) DescribeVpcEndpointServices(input *ec2.DescribeVpcEndpointServicesInput) (*ec2.DescribeVpcEndpointServicesOutput, error) {
+func (c *awsClient) DescribeVpcEndpointServices(ctx context.Context, input *ec2.DescribeVpcEndpointServicesInput) (*ec2.DescribeVpcEndpointServicesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeVpcEndpointServices").Inc()
-	return c.ec2Client.DescribeVpcEndpointServices(input)
+	return c.ec2Client.DescribeVpcEndpointServices(ctx, input)
 }
 
-func (c *awsClient) DescribeVpcEndpoints(input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
+func (c *awsClient) DescribeVpcEndpoints(ctx context.Context, input *ec2.DescribeVpcEndpointsInput) (*ec2.DescribeVpcEndpointsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeVpcEndpoints").Inc()
-	return c.ec2Client.DescribeVpcEndpoints(input)
+	return c.ec2Client.DescribeVpcEndpoints(ctx, input)
 }
 
-func (c *awsClient) DescribeNetworkInterfaces(input *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
+func (c *awsClient) DescribeNetworkInterfaces(ctx context.Context, input *ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeNetworkInterfaces").Inc()
-	return c.ec2Client.DescribeNetworkInterfaces(input)
+	return c.ec2Client.DescribeNetworkInterfaces(ctx, input)
 }
 
-func (c *awsClient) CreateVpcEndpoint(input *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error) {
+func (c *awsClient) CreateVpcEndpoint(ctx context.Context, input *ec2.CreateVpcEndpointInput) (*ec2.CreateVpcEndpointOutput, error) {
 	metricAWSAPICalls.WithLabelValues("CreateVpcEndpoint").Inc()
-	return c.ec2Client.CreateVpcEndpoint(input)
+	return c.ec2Client.CreateVpcEndpoint(ctx, input)
 }
 
-func (c *awsClient) DeleteVpcEndpoints(input *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error) {
+func (c *awsClient) DeleteVpcEndpoints(ctx context.Context, input *ec2.DeleteVpcEndpointsInput) (*ec2.DeleteVpcEndpointsOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DeleteVpcEndpoints").Inc()
-	return c.ec2Client.DeleteVpcEndpoints(input)
+	return c.ec2Client.DeleteVpcEndpoints(ctx, input)
 }
 
-func (c *awsClient) DescribeLoadBalancers(input *elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+func (c *awsClient) DescribeLoadBalancers(ctx context.Context, input *elasticloadbalancingv2.DescribeLoadBalancersInput) (*elasticloadbalancingv2.DescribeLoadBalancersOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DescribeLoadBalancers").Inc()
-	return c.elbv2Client.DescribeLoadBalancers(input)
+	return c.elbv2Client.DescribeLoadBalancers(ctx, input)
 }
 
-func (c *awsClient) GetS3API() s3iface.S3API {
-	return c.s3Client
+func (c *awsClient) GetBucketLifecycleConfiguration(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+	metricAWSAPICalls.WithLabelValues("GetBucketLifecycleConfiguration").Inc()
+	return c.s3Client.GetBucketLifecycleConfiguration(ctx, input)
 }
 
-func (c *awsClient) Upload(input *s3manager.UploadInput) (*s3manager.UploadOutput, error) {
-	return c.s3Uploader.Upload(input)
+func (c *awsClient) PutBucketLifecycleConfiguration(ctx context.Context, input *s3.PutBucketLifecycleConfigurationInput) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+	metricAWSAPICalls.WithLabelValues("PutBucketLifecycleConfiguration").Inc()
+	return c.s3Client.PutBucketLifecycleConfiguration(ctx, input)
 }
 
-func (c *awsClient) ListHostedZonesByName(input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
-	metricAWSAPICalls.WithLabelValues("ListHostedZonesByName").Inc()
-	return c.route53Client.ListHostedZonesByName(input)
+func (c *awsClient) PresignGetObject(ctx context.Context, input *s3.GetObjectInput, expiry time.Duration) (*v4.PresignedHTTPRequest, error) {
+	return s3.NewPresignClient(c.s3Client).PresignGetObject(ctx, input, s3.WithPresignExpires(expiry))
 }
 
-func (c *awsClient) ListHostedZonesByVPC(input *route53.ListHostedZonesByVPCInput) (*route53.ListHostedZonesByVPCOutput, error) {
-	metricAWSAPICalls.WithLabelValues("ListHostedZonesByVPC").Inc()
-	return c.route53Client.ListHostedZonesByVPC(input)
+func (c *awsClient) Upload(ctx context.Context, input *s3.PutObjectInput) (*manager.UploadOutput, error) {
+	return c.s3Uploader.Upload(ctx, input)
 }
 
-func (c *awsClient) CreateHostedZone(input *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error) {
+func (c *awsClient) CreateHostedZone(ctx context.Context, input *route53.CreateHostedZoneInput) (*route53.CreateHostedZoneOutput, error) {
 	metricAWSAPICalls.WithLabelValues("CreateHostedZone").Inc()
-	return c.route53Client.CreateHostedZone(input)
+	return c.route53Client.CreateHostedZone(ctx, input)
 }
 
-func (c *awsClient) GetHostedZone(input *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
+func (c *awsClient) GetHostedZone(ctx context.Context, input *route53.GetHostedZoneInput) (*route53.GetHostedZoneOutput, error) {
 	metricAWSAPICalls.WithLabelValues("GetHostedZone").Inc()
-	return c.route53Client.GetHostedZone(input)
+	return c.route53Client.GetHostedZone(ctx, input)
 }
 
-func (c *awsClient) ListTagsForResource(input *route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error) {
+func (c *awsClient) ListTagsForResource(ctx context.Context, input *route53.ListTagsForResourceInput) (*route53.ListTagsForResourceOutput, error) {
 	metricAWSAPICalls.WithLabelValues("ListTagsForResource").Inc()
-	return c.route53Client.ListTagsForResource(input)
+	return c.route53Client.ListTagsForResource(ctx, input)
 }
 
-func (c *awsClient) ChangeTagsForResource(input *route53.ChangeTagsForResourceInput) (*route53.ChangeTagsForResourceOutput, error) {
+func (c *awsClient) ChangeTagsForResource(ctx context.Context, input *route53.ChangeTagsForResourceInput) (*route53.ChangeTagsForResourceOutput, error) {
 	metricAWSAPICalls.WithLabelValues("ChangeTagsForResource").Inc()
-	return c.route53Client.ChangeTagsForResource(input)
+	return c.route53Client.ChangeTagsForResource(ctx, input)
 }
 
-func (c *awsClient) DeleteHostedZone(input *route53.DeleteHostedZoneInput) (*route53.DeleteHostedZoneOutput, error) {
+func (c *awsClient) DeleteHostedZone(ctx context.Context, input *route53.DeleteHostedZoneInput) (*route53.DeleteHostedZoneOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DeleteHostedZone").Inc()
-	return c.route53Client.DeleteHostedZone(input)
+	return c.route53Client.DeleteHostedZone(ctx, input)
 }
 
-func (c *awsClient) GetResourcesPages(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
-	metricAWSAPICalls.WithLabelValues("GetResourcesPages").Inc()
-	return c.tagClient.GetResourcesPages(input, fn)
+func (c *awsClient) ListResourceRecordSets(ctx context.Context, input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("ListResourceRecordSets").Inc()
+	return c.route53Client.ListResourceRecordSets(ctx, input)
 }
 
-func (c *awsClient) ListResourceRecordSets(input *route53.ListResourceRecordSetsInput) (*route53.ListResourceRecordSetsOutput, error) {
-	metricAWSAPICalls.WithLabelValues("ListResourceRecordSets").Inc()
-	return c.route53Client.ListResourceRecordSets(input)
+func (c *awsClient) ListHostedZonesByName(ctx context.Context, input *route53.ListHostedZonesByNameInput) (*route53.ListHostedZonesByNameOutput, error) {
+	metricAWSAPICalls.WithLabelValues("ListHostedZonesByName").Inc()
+	return c.route53Client.ListHostedZonesByName(ctx, input)
 }
 
-func (c *awsClient) ChangeResourceRecordSets(input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
-	metricAWSAPICalls.WithLabelValues("ChangeResourceRecordSets").Inc()
-	return c.route53Client.ChangeResourceRecordSets(input)
+func (c *awsClient) ListHostedZonesByVPC(ctx context.Context, input *route53.ListHostedZonesByVPCInput) (*route53.ListHostedZonesByVPCOutput, error) {
+	metricAWSAPICalls.WithLabelValues("ListHostedZonesByVPC").Inc()
+	return c.route53Client.ListHostedZonesByVPC(ctx, input)
 }
 
-func (c *awsClient) AssociateVPCWithHostedZone(input *route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error) {
-	metricAWSAPICalls.WithLabelValues("AssociateVPCWithHostedZone").Inc()
-	return c.route53Client.AssociateVPCWithHostedZone(input)
+func (c *awsClient) ChangeResourceRecordSets(ctx context.Context, input *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	metricAWSAPICalls.WithLabelValues("ChangeResourceRecordSets").Inc()
+	return c.route53Client.ChangeResourceRecordSets(ctx, input)
 }
 
-func (c *awsClient) CreateVPCAssociationAuthorization(input *route53.CreateVPCAssociationAuthorizationInput) (*route53.CreateVPCAssociationAuthorizationOutput, error) {
+func (c *awsClient) CreateVPCAssociationAuthorization(ctx context.Context, input *route53.CreateVPCAssociationAuthorizationInput) (*route53.CreateVPCAssociationAuthorizationOutput, error) {
 	metricAWSAPICalls.WithLabelValues("CreateVPCAssociationAuthorization").Inc()
-	return c.route53Client.CreateVPCAssociationAuthorization(input)
+	return c.route53Client.CreateVPCAssociationAuthorization(ctx, input)
 }
 
-func (c *awsClient) DeleteVPCAssociationAuthorization(input *route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error) {
+func (c *awsClient) DeleteVPCAssociationAuthorization(ctx context.Context, input *route53.DeleteVPCAssociationAuthorizationInput) (*route53.DeleteVPCAssociationAuthorizationOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DeleteVPCAssociationAuthorization").Inc()
-	return c.route53Client.DeleteVPCAssociationAuthorization(input)
+	return c.route53Client.DeleteVPCAssociationAuthorization(ctx, input)
 }
 
-func (c *awsClient) DisassociateVPCFromHostedZone(input *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error) {
+func (c *awsClient) AssociateVPCWithHostedZone(ctx context.Context, input *route53.AssociateVPCWithHostedZoneInput) (*route53.AssociateVPCWithHostedZoneOutput, error) {
+	metricAWSAPICalls.WithLabelValues("AssociateVPCWithHostedZone").Inc()
+	return c.route53Client.AssociateVPCWithHostedZone(ctx, input)
+}
+
+func (c *awsClient) DisassociateVPCFromHostedZone(ctx context.Context, input *route53.DisassociateVPCFromHostedZoneInput) (*route53.DisassociateVPCFromHostedZoneOutput, error) {
 	metricAWSAPICalls.WithLabelValues("DisassociateVPCFromHostedZone").Inc()
-	return c.route53Client.DisassociateVPCFromHostedZone(input)
+	return c.route53Client.DisassociateVPCFromHostedZone(ctx, input)
+}
+
+func (c *awsClient) GetResourcesPages(ctx context.Context, input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+	metricAWSAPICalls.WithLabelValues("GetResourcesPages").Inc()
+	paginator := resourcegroupstaggingapi.NewGetResourcesPaginator(c.tagClient, input)
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		if !fn(output, !paginator.HasMorePages()) {
+			return nil
+		}
+	}
+	return nil
 }
 
-func (c *awsClient) GetCallerIdentity(input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+func (c *awsClient) GetCallerIdentity(ctx context.Context, input *sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
 	metricAWSAPICalls.WithLabelValues("GetCallerIdentity").Inc()
-	return c.stsClient.GetCallerIdentity(input)
+	return c.stsClient.GetCallerIdentity(ctx, input)
 }
 
 // Options provides the means to control how a client is created and what
@@ -426,20 +461,19 @@ func newClientAssumeRole(kubeClient client.Client,
 		}
 	}
 
-	sess, err := NewSessionFromSecret(secret, region)
+	cfg, err := NewConfigFromSecret(secret, region)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create AWS session")
+		return nil, errors.Wrap(err, "failed to create AWS config")
 	}
 
-	duration := stscreds.DefaultDuration
-	sess.Config.Credentials = stscreds.NewCredentials(sess, role.RoleARN, func(p *stscreds.AssumeRoleProvider) {
-		p.Duration = duration
+	cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), role.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.Duration = stscreds.DefaultDuration
 		if role.ExternalID != "" {
-			p.ExternalID = &role.ExternalID
+			o.ExternalID = &role.ExternalID
 		}
-	})
+	}))
 
-	return newClientFromSession(sess)
+	return newClientFromConfig(cfg)
 }
 
 // NewClient creates our client wrapper object for the actual AWS clients we use.
@@ -477,66 +511,79 @@ func NewClient(kubeClient client.Client, secretName, namespace, region string) (
 //
 // Pass a nil secret to load credentials from the standard AWS environment variables.
 func NewClientFromSecret(secret *corev1.Secret, region string) (Client, error) {
-	s, err := NewSessionFromSecret(secret, region)
+	cfg, err := NewConfigFromSecret(secret, region)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create AWS session")
+		return nil, errors.Wrap(err, "failed to create AWS config")
 	}
-	return newClientFromSession(s)
+	return newClientFromConfig(cfg)
 }
 
-func newClientFromSession(s *session.Session, cfgs ...*aws.Config) (Client, error) {
+func newClientFromConfig(cfg aws.Config) (Client, error) {
+	calls := callConfigFromEnvironment()
+	s3Client := s3.NewFromConfig(cfg, func(o *s3.Options) { o.Retryer = calls.retryerFor(s3.ServiceID, cfg.Region) })
 	return &awsClient{
-		ec2Client:     ec2.New(s, cfgs...),
-		elbClient:     elb.New(s, cfgs...),
-		elbv2Client:   elbv2.New(s, cfgs...),
-		s3Client:      s3.New(s, cfgs...),
-		s3Uploader:    s3manager.NewUploader(s),
-		route53Client: route53.New(s, cfgs...),
-		stsClient:     sts.New(s, cfgs...),
-		tagClient:     resourcegroupstaggingapi.New(s, cfgs...),
+		ec2Client: ec2.NewFromConfig(cfg, func(o *ec2.Options) {
+			o.Retryer = calls.retryerFor(ec2.ServiceID, cfg.Region)
+		}),
+		elbv2Client: elasticloadbalancingv2.NewFromConfig(cfg, func(o *elasticloadbalancingv2.Options) {
+			o.Retryer = calls.retryerFor(elasticloadbalancingv2.ServiceID, cfg.Region)
+		}),
+		s3Client:   s3Client,
+		s3Uploader: manager.NewUploader(s3Client),
+		route53Client: route53.NewFromConfig(cfg, func(o *route53.Options) {
+			o.Retryer = calls.retryerFor(route53.ServiceID, cfg.Region)
+		}),
+		stsClient: sts.NewFromConfig(cfg, func(o *sts.Options) {
+			o.Retryer = calls.retryerFor(sts.ServiceID, cfg.Region)
+		}),
+		tagClient: resourcegroupstaggingapi.NewFromConfig(cfg, func(o *resourcegroupstaggingapi.Options) {
+			o.Retryer = calls.retryerFor(resourcegroupstaggingapi.ServiceID, cfg.Region)
+		}),
 	}, nil
 }
 
-// NewSessionFromSecret creates a new AWS session using the configuration in the secret. If the secret
-// was nil, it initializes a new session using configuration of the envionment.
-func NewSessionFromSecret(secret *corev1.Secret, region string) (*session.Session, error) {
-	options := session.Options{
-		Config: aws.Config{
-			Region:           aws.String(region),
-			EndpointResolver: endpoints.ResolverFunc(awsChinaEndpointResolver),
-		},
-		SharedConfigState: session.SharedConfigEnable,
+// NewConfigFromSecret creates a new AWS config using the configuration in the secret. If the secret
+// was nil, it loads the configuration of the envionment.
+func NewConfigFromSecret(secret *corev1.Secret, region string) (aws.Config, error) {
+	calls := callConfigFromEnvironment()
+	options := []func(*config.LoadOptions) error{
+		config.WithRegion(region),
+		config.WithEndpointResolverWithOptions(aws.EndpointResolverWithOptionsFunc(awsChinaEndpointResolver)),
+		config.WithRetryer(calls.newRetryer),
+		config.WithAPIOptions([]func(*middleware.Stack) error{
+			awsmiddleware.AddUserAgentKeyValue("openshift.io hive", "v1"),
+			calls.addMiddleware,
+		}),
 	}
 
 	// Special case to not use a secret to gather credentials.
 	if secret != nil {
-		config := awsCLIConfigFromSecret(secret)
+		secret, err := externalsecret.Resolve(secret)
+		if err != nil {
+			return aws.Config{}, err
+		}
+		cliConfig := awsCLIConfigFromSecret(secret)
 		f, err := ioutil.TempFile("", "hive-aws-config")
 		if err != nil {
-			return nil, err
+			return aws.Config{}, err
 		}
 		defer f.Close()
-		if _, err := f.Write(config); err != nil {
-			return nil, err
+		if _, err := f.Write(cliConfig); err != nil {
+			return aws.Config{}, err
 		}
 		defer os.Remove(f.Name())
 
-		options.SharedConfigFiles = []string{f.Name()}
-		options.Profile = "default"
+		// The credentials are read from the same file as the config, rather than from the shared credentials file
+		// of the environment.
+		options = append(options,
+			config.WithSharedConfigFiles([]string{f.Name()}),
+			config.WithSharedCredentialsFiles([]string{f.Name()}),
+			config.WithSharedConfigProfile("default"),
+		)
 	}
 
 	// Otherwise default to relying on the environment where the actuator is running:
-	s, err := session.NewSessionWithOptions(options)
-	if err != nil {
-		return nil, err
-	}
-
-	s.Handlers.Build.PushBackNamed(request.NamedHandler{
-		Name: "openshift.io/hive",
-		Fn:   request.MakeAddToUserAgentHandler("openshift.io hive", "v1"),
-	})
-
-	return s, nil
+	return config.LoadDefaultConfig(context.TODO(), options...)
 }
 
 // awsCLIConfigFromSecret returns an AWS CLI config using the data available in the secret.
@@ -553,13 +600,15 @@ func awsCLIConfigFromSecret(secret *corev1.Secret) []byte {
 	return buf.Bytes()
 }
 
-func awsChinaEndpointResolver(service, region string, optFns ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
-	if service != route53.EndpointsID || region != constants.AWSChinaRoute53Region {
-		return endpoints.DefaultResolver().EndpointFor(service, region, optFns...)
+func awsChinaEndpointResolver(service, region string, _ ...interface{}) (aws.Endpoint, error) {
+	if service != route53.ServiceID || region != constants.AWSChinaRoute53Region {
+		// Fall back to the default endpoint of the service.
+		return aws.Endpoint{}, &aws.EndpointNotFoundError{}
 	}
 
-	return endpoints.ResolvedEndpoint{
-		URL:         "https://route53.amazonaws.com.cn",
-		PartitionID: "aws-cn",
+	return aws.Endpoint{
+		URL:           "https://route53.amazonaws.com.cn",
+		PartitionID:   "aws-cn",
+		SigningRegion: region,
 	}, nil
 }
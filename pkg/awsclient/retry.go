@@ -0,0 +1,184 @@
+package awsclient
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// defaultMaxAttempts is how many times a call is attempted when not configured.
+	defaultMaxAttempts = 4
+
+	// defaultCallTimeout is how long a call may take when not configured.
+	defaultCallTimeout = 2 * time.Minute
+)
+
+var (
+	metricAWSAPICallDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "hive_aws_api_call_duration_seconds",
+			Help:    "Duration of calls to AWS APIs, including their retries, partitioned by service and operation.",
+			Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120},
+		},
+		[]string{"service", "operation"},
+	)
+	metricAWSAPIRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_aws_api_retries_total",
+			Help: "Number of retries of calls to AWS APIs, partitioned by service and operation.",
+		},
+		[]string{"service", "operation"},
+	)
+	metricAWSAPIThrottles = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_aws_api_throttles_total",
+			Help: "Number of attempts of calls to AWS APIs which were throttled, partitioned by service and operation.",
+		},
+		[]string{"service", "operation"},
+	)
+	metricAWSAPICallTimeouts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "hive_aws_api_call_timeouts_total",
+			Help: "Number of calls to AWS APIs abandoned after the call timeout, partitioned by service and operation.",
+		},
+		[]string{"service", "operation"},
+	)
+
+	// adaptiveRetryers holds the retryers of the adaptive retry mode, which are shared by the clients of each service
+	// in each region.
+	adaptiveRetryers     = map[adaptiveRetryerKey]aws.Retryer{}
+	adaptiveRetryersLock sync.Mutex
+
+	throttles = retry.IsErrorThrottles(retry.DefaultThrottles)
+)
+
+type adaptiveRetryerKey struct {
+	service     string
+	region      string
+	maxAttempts int
+}
+
+func init() {
+	metrics.Registry.MustRegister(metricAWSAPICallDuration)
+	metrics.Registry.MustRegister(metricAWSAPIRetries)
+	metrics.Registry.MustRegister(metricAWSAPIThrottles)
+	metrics.Registry.MustRegister(metricAWSAPICallTimeouts)
+}
+
+// callConfig is how the calls of the clients are retried and timed out.
+type callConfig struct {
+	retryMode   hivev1.AWSRetryMode
+	maxAttempts int
+	callTimeout time.Duration
+}
+
+// callConfigFromEnvironment loads the call configuration that the operator passes to the controllers from HiveConfig.
+// Invalid values are logged and replaced with the defaults.
+func callConfigFromEnvironment() callConfig {
+	config := callConfig{
+		retryMode:   hivev1.StandardAWSRetryMode,
+		maxAttempts: defaultMaxAttempts,
+		callTimeout: defaultCallTimeout,
+	}
+	switch mode := hivev1.AWSRetryMode(os.Getenv(constants.AWSRetryModeEnvVar)); mode {
+	case "":
+	case hivev1.StandardAWSRetryMode, hivev1.AdaptiveAWSRetryMode:
+		config.retryMode = mode
+	default:
+		log.WithField("retryMode", mode).Warn("ignoring unknown AWS retry mode")
+	}
+	if value := os.Getenv(constants.AWSMaxAttemptsEnvVar); value != "" {
+		if maxAttempts, err := strconv.Atoi(value); err != nil || maxAttempts < 1 {
+			log.WithField("maxAttempts", value).Warn("ignoring invalid AWS max attempts")
+		} else {
+			config.maxAttempts = maxAttempts
+		}
+	}
+	if value := os.Getenv(constants.AWSCallTimeoutEnvVar); value != "" {
+		if callTimeout, err := time.ParseDuration(value); err != nil || callTimeout <= 0 {
+			log.WithField("callTimeout", value).Warn("ignoring invalid AWS call timeout")
+		} else {
+			config.callTimeout = callTimeout
+		}
+	}
+	return config
+}
+
+// newRetryer returns a retryer of the configured mode, which attempts calls the configured number of times.
+func (c callConfig) newRetryer() aws.Retryer {
+	if c.retryMode == hivev1.AdaptiveAWSRetryMode {
+		return retry.NewAdaptiveMode(func(o *retry.AdaptiveModeOptions) {
+			o.StandardOptions = append(o.StandardOptions, func(o *retry.StandardOptions) {
+				o.MaxAttempts = c.maxAttempts
+			})
+		})
+	}
+	return retry.NewStandard(func(o *retry.StandardOptions) {
+		o.MaxAttempts = c.maxAttempts
+	})
+}
+
+// retryerFor returns the retryer of the clients of a service in a region. In the adaptive retry mode, the retryer,
+// which limits the rate of calls once the service throttles them, is shared by all clients, since the controllers
+// create new clients for every reconcile.
+func (c callConfig) retryerFor(service, region string) aws.Retryer {
+	if c.retryMode != hivev1.AdaptiveAWSRetryMode {
+		return c.newRetryer()
+	}
+	key := adaptiveRetryerKey{service: service, region: region, maxAttempts: c.maxAttempts}
+	adaptiveRetryersLock.Lock()
+	defer adaptiveRetryersLock.Unlock()
+	retryer, ok := adaptiveRetryers[key]
+	if !ok {
+		retryer = c.newRetryer()
+		adaptiveRetryers[key] = retryer
+	}
+	return retryer
+}
+
+// addMiddleware adds the middleware which times out the calls of the clients according to the configuration, and
+// reports them in the metrics.
+func (c callConfig) addMiddleware(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("openshift.io/hive/calls", func(
+		ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+	) (middleware.InitializeOutput, middleware.Metadata, error) {
+		service, operation := awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx)
+		start := time.Now()
+		callCtx, cancel := context.WithTimeout(ctx, c.callTimeout)
+		defer cancel()
+
+		out, metadata, err := next.HandleInitialize(callCtx, in)
+
+		metricAWSAPICallDuration.WithLabelValues(service, operation).Observe(time.Since(start).Seconds())
+		if results, ok := retry.GetAttemptResults(metadata); ok {
+			if retries := len(results.Results) - 1; retries > 0 {
+				metricAWSAPIRetries.WithLabelValues(service, operation).Add(float64(retries))
+			}
+			for _, result := range results.Results {
+				if result.Err != nil && throttles.IsErrorThrottle(result.Err).Bool() {
+					metricAWSAPIThrottles.WithLabelValues(service, operation).Inc()
+				}
+			}
+		}
+		if ctx.Err() == nil && callCtx.Err() == context.DeadlineExceeded {
+			metricAWSAPICallTimeouts.WithLabelValues(service, operation).Inc()
+		}
+		return out, metadata, err
+	}), middleware.After)
+}
@@ -0,0 +1,159 @@
+package awsclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestCallConfigFromEnvironment(t *testing.T) {
+	cases := []struct {
+		name     string
+		env      map[string]string
+		expected callConfig
+	}{
+		{
+			name: "defaults",
+			expected: callConfig{
+				retryMode:   hivev1.StandardAWSRetryMode,
+				maxAttempts: 4,
+				callTimeout: 2 * time.Minute,
+			},
+		},
+		{
+			name: "configured",
+			env: map[string]string{
+				constants.AWSRetryModeEnvVar:   "Adaptive",
+				constants.AWSMaxAttemptsEnvVar: "10",
+				constants.AWSCallTimeoutEnvVar: "30s",
+			},
+			expected: callConfig{
+				retryMode:   hivev1.AdaptiveAWSRetryMode,
+				maxAttempts: 10,
+				callTimeout: 30 * time.Second,
+			},
+		},
+		{
+			name: "invalid",
+			env: map[string]string{
+				constants.AWSRetryModeEnvVar:   "Eager",
+				constants.AWSMaxAttemptsEnvVar: "0",
+				constants.AWSCallTimeoutEnvVar: "soon",
+			},
+			expected: callConfig{
+				retryMode:   hivev1.StandardAWSRetryMode,
+				maxAttempts: 4,
+				callTimeout: 2 * time.Minute,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, name := range []string{constants.AWSRetryModeEnvVar, constants.AWSMaxAttemptsEnvVar, constants.AWSCallTimeoutEnvVar} {
+				value, ok := tc.env[name]
+				if !ok {
+					os.Unsetenv(name)
+					continue
+				}
+				os.Setenv(name, value)
+				defer os.Unsetenv(name)
+			}
+			assert.Equal(t, tc.expected, callConfigFromEnvironment())
+		})
+	}
+}
+
+func TestCallMiddleware(t *testing.T) {
+	cases := []struct {
+		name             string
+		config           callConfig
+		handler          http.HandlerFunc
+		expectedError    func(t *testing.T, err error)
+		expectedAttempts int32
+	}{
+		{
+			name:   "retries throttled calls",
+			config: callConfig{retryMode: hivev1.StandardAWSRetryMode, maxAttempts: 2, callTimeout: time.Minute},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`<ErrorResponse><Error><Code>Throttling</Code><Message>Rate exceeded</Message></Error></ErrorResponse>`))
+			},
+			expectedError: func(t *testing.T, err error) {
+				var apiErr smithy.APIError
+				if assert.True(t, errors.As(err, &apiErr), "expected an API error") {
+					assert.Equal(t, "Throttling", apiErr.ErrorCode(), "unexpected error code")
+				}
+			},
+			expectedAttempts: 2,
+		},
+		{
+			name:   "times out slow calls",
+			config: callConfig{retryMode: hivev1.AdaptiveAWSRetryMode, maxAttempts: 4, callTimeout: 100 * time.Millisecond},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(time.Second):
+				}
+			},
+			expectedError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, context.DeadlineExceeded), "expected the call to time out")
+			},
+			expectedAttempts: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				tc.handler(w, r)
+			}))
+			defer server.Close()
+
+			cfg := aws.Config{
+				Region:      "us-east-1",
+				Credentials: credentials.NewStaticCredentialsProvider("id", "secret", ""),
+				EndpointResolverWithOptions: aws.EndpointResolverWithOptionsFunc(func(service, region string, _ ...interface{}) (aws.Endpoint, error) {
+					return aws.Endpoint{URL: server.URL}, nil
+				}),
+				Retryer:    tc.config.newRetryer,
+				APIOptions: []func(*middleware.Stack) error{tc.config.addMiddleware},
+			}
+
+			_, err := sts.NewFromConfig(cfg).GetCallerIdentity(context.Background(), &sts.GetCallerIdentityInput{})
+			if assert.Error(t, err, "expected call to fail") {
+				tc.expectedError(t, err)
+			}
+			assert.Equal(t, tc.expectedAttempts, atomic.LoadInt32(&attempts), "unexpected number of attempts")
+		})
+	}
+}
+
+func TestRetryerFor(t *testing.T) {
+	standard := callConfig{retryMode: hivev1.StandardAWSRetryMode, maxAttempts: 4}
+	assert.NotSame(t, standard.retryerFor("EC2", "us-east-1"), standard.retryerFor("EC2", "us-east-1"),
+		"standard retryers should not be shared")
+
+	adaptive := callConfig{retryMode: hivev1.AdaptiveAWSRetryMode, maxAttempts: 4}
+	retryer := adaptive.retryerFor("EC2", "us-east-1")
+	assert.Same(t, retryer, adaptive.retryerFor("EC2", "us-east-1"), "adaptive retryers should be shared by a service in a region")
+	assert.NotSame(t, retryer, adaptive.retryerFor("EC2", "us-west-2"), "adaptive retryers should not be shared across regions")
+	assert.NotSame(t, retryer, adaptive.retryerFor("Route 53", "us-east-1"), "adaptive retryers should not be shared across services")
+	assert.Equal(t, 4, retryer.MaxAttempts(), "unexpected max attempts")
+}
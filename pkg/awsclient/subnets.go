@@ -0,0 +1,90 @@
+package awsclient
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	log "github.com/sirupsen/logrus"
+)
+
+// TagNameSubnetPublicELB is the tag name used on a subnet to designate that
+// it should be used for internet ELBs
+const TagNameSubnetPublicELB = "kubernetes.io/role/elb"
+
+// SubnetRouteTable finds the route table of the subnet among the route tables of its VPC.
+func SubnetRouteTable(rt []ec2types.RouteTable, subnet *ec2types.Subnet, logger log.FieldLogger) (*ec2types.RouteTable, error) {
+	subnetID := aws.ToString(subnet.SubnetId)
+	var subnetTable *ec2types.RouteTable
+	for i := range rt {
+		for _, assoc := range rt[i].Associations {
+			if aws.ToString(assoc.SubnetId) == subnetID {
+				subnetTable = &rt[i]
+				break
+			}
+		}
+	}
+
+	if subnetTable == nil {
+		// If there is no explicit association, the subnet will be implicitly
+		// associated with the VPC's main routing table.
+		for i := range rt {
+			for _, assoc := range rt[i].Associations {
+				if aws.ToBool(assoc.Main) {
+					logger.Debugf("Assuming implicit use of main routing table %s for %s",
+						aws.ToString(rt[i].RouteTableId), subnetID)
+					subnetTable = &rt[i]
+					break
+				}
+			}
+		}
+	}
+
+	if subnetTable == nil {
+		return nil, fmt.Errorf("could not locate routing table for %s", subnetID)
+	}
+	return subnetTable, nil
+}
+
+// IsSubnetPublic returns true if the subnet routes traffic through an internet gateway, or is tagged for internet
+// ELBs.
+// https://github.com/kubernetes/kubernetes/blob/9f036cd43d35a9c41d7ac4ca82398a6d0bef957b/staging/src/k8s.io/legacy-cloud-providers/aws/aws.go#L3376-L3419
+func IsSubnetPublic(rt []ec2types.RouteTable, subnet *ec2types.Subnet, logger log.FieldLogger) (bool, error) {
+	subnetTable, err := SubnetRouteTable(rt, subnet, logger)
+	if err != nil {
+		return false, err
+	}
+
+	for _, route := range subnetTable.Routes {
+		// There is no direct way in the AWS API to determine if a subnet is public or private.
+		// A public subnet is one which has an internet gateway route
+		// we look for the gatewayId and make sure it has the prefix of igw to differentiate
+		// from the default in-subnet route which is called "local"
+		// or other virtual gateway (starting with vgv)
+		// or vpc peering connections (starting with pcx).
+		if strings.HasPrefix(aws.ToString(route.GatewayId), "igw") {
+			return true, nil
+		}
+	}
+
+	// If we couldn't use the subnet table to figure out whether the subnet is public,
+	// we let the users define whether this subnet should be used for internet-facing things
+	// by looking for TagNameSubnetPublicELB tag.
+	tagVal, subnetHasTag := FindTag(subnet.Tags, TagNameSubnetPublicELB)
+	if subnetHasTag && (tagVal == "" || tagVal == "1") {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// FindTag finds the value for a given tag.
+func FindTag(tags []ec2types.Tag, key string) (string, bool) {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == key {
+			return aws.ToString(tag.Value), true
+		}
+	}
+	return "", false
+}
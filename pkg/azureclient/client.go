@@ -7,6 +7,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2017-10-01/network"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
@@ -15,6 +16,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/externalsecret"
 )
 
 //go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
@@ -37,6 +39,9 @@ type Client interface {
 	ListAllVirtualMachines(ctx context.Context, statusOnly string) (compute.VirtualMachineListResultPage, error)
 	DeallocateVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesDeallocateFuture, error)
 	StartVirtualMachine(ctx context.Context, resourceGroup, name string) (compute.VirtualMachinesStartFuture, error)
+
+	// Subnets
+	GetSubnet(ctx context.Context, resourceGroupName, virtualNetwork, subnet string) (network.Subnet, error)
 }
 
 // ResourceSKUsPage is a page of results from listing resource SKUs.
@@ -58,6 +63,7 @@ type azureClient struct {
 	recordSetsClient      *dns.RecordSetsClient
 	zonesClient           *dns.ZonesClient
 	virtualMachinesClient *compute.VirtualMachinesClient
+	subnetsClient         *network.SubnetsClient
 }
 
 func (c *azureClient) ListResourceSKUs(ctx context.Context, filter string) (ResourceSKUsPage, error) {
@@ -113,6 +119,10 @@ func (c *azureClient) StartVirtualMachine(ctx context.Context, resourceGroup, na
 	return c.virtualMachinesClient.Start(ctx, resourceGroup, name)
 }
 
+func (c *azureClient) GetSubnet(ctx context.Context, resourceGroupName, virtualNetwork, subnet string) (network.Subnet, error) {
+	return c.subnetsClient.Get(ctx, resourceGroupName, virtualNetwork, subnet, "")
+}
+
 // NewClientFromSecret creates our client wrapper object for interacting with Azure. The Azure creds are read from the
 // specified secret.
 func NewClientFromSecret(secret *corev1.Secret, environmentName string) (Client, error) {
@@ -182,11 +192,15 @@ func newClient(authJSONSource func() ([]byte, error), environmentName string) (*
 	virtualMachinesClient := compute.NewVirtualMachinesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
 	virtualMachinesClient.Authorizer = authorizer
 
+	subnetsClient := network.NewSubnetsClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	subnetsClient.Authorizer = authorizer
+
 	return &azureClient{
 		resourceSKUsClient:    &resourceSKUsClient,
 		recordSetsClient:      &recordSetsClient,
 		zonesClient:           &zonesClient,
 		virtualMachinesClient: &virtualMachinesClient,
+		subnetsClient:         &subnetsClient,
 	}, nil
 }
 
@@ -198,6 +212,10 @@ func authJSONFromBytes(creds []byte) func() ([]byte, error) {
 
 func authJSONFromSecretSource(secret *corev1.Secret) func() ([]byte, error) {
 	return func() ([]byte, error) {
+		secret, err := externalsecret.Resolve(secret)
+		if err != nil {
+			return nil, err
+		}
 		authJSON, ok := secret.Data[constants.AzureCredentialsName]
 		if !ok {
 			return nil, errors.New("creds secret does not contain \"" + constants.AzureCredentialsName + "\" data")
@@ -10,6 +10,7 @@ import (
 
 	compute "github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	dns "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	network "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2017-10-01/network"
 	gomock "github.com/golang/mock/gomock"
 	azureclient "github.com/openshift/hive/pkg/azureclient"
 )
@@ -110,6 +111,21 @@ func (mr *MockClientMockRecorder) DeleteZone(ctx, resourceGroupName, zone interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteZone", reflect.TypeOf((*MockClient)(nil).DeleteZone), ctx, resourceGroupName, zone)
 }
 
+// GetSubnet mocks base method.
+func (m *MockClient) GetSubnet(ctx context.Context, resourceGroupName, virtualNetwork, subnet string) (network.Subnet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubnet", ctx, resourceGroupName, virtualNetwork, subnet)
+	ret0, _ := ret[0].(network.Subnet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubnet indicates an expected call of GetSubnet.
+func (mr *MockClientMockRecorder) GetSubnet(ctx, resourceGroupName, virtualNetwork, subnet interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubnet", reflect.TypeOf((*MockClient)(nil).GetSubnet), ctx, resourceGroupName, virtualNetwork, subnet)
+}
+
 // GetZone mocks base method.
 func (m *MockClient) GetZone(ctx context.Context, resourceGroupName, zone string) (dns.Zone, error) {
 	m.ctrl.T.Helper()
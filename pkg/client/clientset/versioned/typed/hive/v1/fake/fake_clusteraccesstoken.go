@@ -0,0 +1,126 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterAccessTokens implements ClusterAccessTokenInterface
+type FakeClusterAccessTokens struct {
+	Fake *FakeHiveV1
+	ns   string
+}
+
+var clusteraccesstokensResource = schema.GroupVersionResource{Group: "hive.openshift.io", Version: "v1", Resource: "clusteraccesstokens"}
+
+var clusteraccesstokensKind = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "ClusterAccessToken"}
+
+// Get takes name of the clusterAccessToken, and returns the corresponding clusterAccessToken object, and an error if there is any.
+func (c *FakeClusterAccessTokens) Get(ctx context.Context, name string, options v1.GetOptions) (result *hivev1.ClusterAccessToken, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(clusteraccesstokensResource, c.ns, name), &hivev1.ClusterAccessToken{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ClusterAccessToken), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterAccessTokens that match those selectors.
+func (c *FakeClusterAccessTokens) List(ctx context.Context, opts v1.ListOptions) (result *hivev1.ClusterAccessTokenList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(clusteraccesstokensResource, clusteraccesstokensKind, c.ns, opts), &hivev1.ClusterAccessTokenList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &hivev1.ClusterAccessTokenList{ListMeta: obj.(*hivev1.ClusterAccessTokenList).ListMeta}
+	for _, item := range obj.(*hivev1.ClusterAccessTokenList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterAccessTokens.
+func (c *FakeClusterAccessTokens) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(clusteraccesstokensResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a clusterAccessToken and creates it.  Returns the server's representation of the clusterAccessToken, and an error, if there is any.
+func (c *FakeClusterAccessTokens) Create(ctx context.Context, clusterAccessToken *hivev1.ClusterAccessToken, opts v1.CreateOptions) (result *hivev1.ClusterAccessToken, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(clusteraccesstokensResource, c.ns, clusterAccessToken), &hivev1.ClusterAccessToken{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ClusterAccessToken), err
+}
+
+// Update takes the representation of a clusterAccessToken and updates it. Returns the server's representation of the clusterAccessToken, and an error, if there is any.
+func (c *FakeClusterAccessTokens) Update(ctx context.Context, clusterAccessToken *hivev1.ClusterAccessToken, opts v1.UpdateOptions) (result *hivev1.ClusterAccessToken, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(clusteraccesstokensResource, c.ns, clusterAccessToken), &hivev1.ClusterAccessToken{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ClusterAccessToken), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeClusterAccessTokens) UpdateStatus(ctx context.Context, clusterAccessToken *hivev1.ClusterAccessToken, opts v1.UpdateOptions) (*hivev1.ClusterAccessToken, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(clusteraccesstokensResource, "status", c.ns, clusterAccessToken), &hivev1.ClusterAccessToken{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ClusterAccessToken), err
+}
+
+// Delete takes name of the clusterAccessToken and deletes it. Returns an error if one occurs.
+func (c *FakeClusterAccessTokens) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(clusteraccesstokensResource, c.ns, name), &hivev1.ClusterAccessToken{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterAccessTokens) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(clusteraccesstokensResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &hivev1.ClusterAccessTokenList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterAccessToken.
+func (c *FakeClusterAccessTokens) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *hivev1.ClusterAccessToken, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(clusteraccesstokensResource, c.ns, name, pt, data, subresources...), &hivev1.ClusterAccessToken{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.ClusterAccessToken), err
+}
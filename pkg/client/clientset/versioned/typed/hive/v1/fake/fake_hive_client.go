@@ -16,6 +16,10 @@ func (c *FakeHiveV1) Checkpoints(namespace string) v1.CheckpointInterface {
 	return &FakeCheckpoints{c, namespace}
 }
 
+func (c *FakeHiveV1) ClusterAccessTokens(namespace string) v1.ClusterAccessTokenInterface {
+	return &FakeClusterAccessTokens{c, namespace}
+}
+
 func (c *FakeHiveV1) ClusterClaims(namespace string) v1.ClusterClaimInterface {
 	return &FakeClusterClaims{c, namespace}
 }
@@ -56,6 +60,14 @@ func (c *FakeHiveV1) HiveConfigs() v1.HiveConfigInterface {
 	return &FakeHiveConfigs{c}
 }
 
+func (c *FakeHiveV1) HostInventories(namespace string) v1.HostInventoryInterface {
+	return &FakeHostInventories{c, namespace}
+}
+
+func (c *FakeHiveV1) HostedClusterInstalls(namespace string) v1.HostedClusterInstallInterface {
+	return &FakeHostedClusterInstalls{c, namespace}
+}
+
 func (c *FakeHiveV1) MachinePools(namespace string) v1.MachinePoolInterface {
 	return &FakeMachinePools{c, namespace}
 }
@@ -80,6 +92,10 @@ func (c *FakeHiveV1) SyncSets(namespace string) v1.SyncSetInterface {
 	return &FakeSyncSets{c, namespace}
 }
 
+func (c *FakeHiveV1) TenantQuotas(namespace string) v1.TenantQuotaInterface {
+	return &FakeTenantQuotas{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeHiveV1) RESTClient() rest.Interface {
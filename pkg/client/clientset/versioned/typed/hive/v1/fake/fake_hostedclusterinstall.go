@@ -0,0 +1,126 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeHostedClusterInstalls implements HostedClusterInstallInterface
+type FakeHostedClusterInstalls struct {
+	Fake *FakeHiveV1
+	ns   string
+}
+
+var hostedclusterinstallsResource = schema.GroupVersionResource{Group: "hive.openshift.io", Version: "v1", Resource: "hostedclusterinstalls"}
+
+var hostedclusterinstallsKind = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "HostedClusterInstall"}
+
+// Get takes name of the hostedClusterInstall, and returns the corresponding hostedClusterInstall object, and an error if there is any.
+func (c *FakeHostedClusterInstalls) Get(ctx context.Context, name string, options v1.GetOptions) (result *hivev1.HostedClusterInstall, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(hostedclusterinstallsResource, c.ns, name), &hivev1.HostedClusterInstall{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.HostedClusterInstall), err
+}
+
+// List takes label and field selectors, and returns the list of HostedClusterInstalls that match those selectors.
+func (c *FakeHostedClusterInstalls) List(ctx context.Context, opts v1.ListOptions) (result *hivev1.HostedClusterInstallList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(hostedclusterinstallsResource, hostedclusterinstallsKind, c.ns, opts), &hivev1.HostedClusterInstallList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &hivev1.HostedClusterInstallList{ListMeta: obj.(*hivev1.HostedClusterInstallList).ListMeta}
+	for _, item := range obj.(*hivev1.HostedClusterInstallList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested hostedClusterInstalls.
+func (c *FakeHostedClusterInstalls) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(hostedclusterinstallsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a hostedClusterInstall and creates it.  Returns the server's representation of the hostedClusterInstall, and an error, if there is any.
+func (c *FakeHostedClusterInstalls) Create(ctx context.Context, hostedClusterInstall *hivev1.HostedClusterInstall, opts v1.CreateOptions) (result *hivev1.HostedClusterInstall, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(hostedclusterinstallsResource, c.ns, hostedClusterInstall), &hivev1.HostedClusterInstall{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.HostedClusterInstall), err
+}
+
+// Update takes the representation of a hostedClusterInstall and updates it. Returns the server's representation of the hostedClusterInstall, and an error, if there is any.
+func (c *FakeHostedClusterInstalls) Update(ctx context.Context, hostedClusterInstall *hivev1.HostedClusterInstall, opts v1.UpdateOptions) (result *hivev1.HostedClusterInstall, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(hostedclusterinstallsResource, c.ns, hostedClusterInstall), &hivev1.HostedClusterInstall{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.HostedClusterInstall), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeHostedClusterInstalls) UpdateStatus(ctx context.Context, hostedClusterInstall *hivev1.HostedClusterInstall, opts v1.UpdateOptions) (*hivev1.HostedClusterInstall, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(hostedclusterinstallsResource, "status", c.ns, hostedClusterInstall), &hivev1.HostedClusterInstall{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.HostedClusterInstall), err
+}
+
+// Delete takes name of the hostedClusterInstall and deletes it. Returns an error if one occurs.
+func (c *FakeHostedClusterInstalls) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(hostedclusterinstallsResource, c.ns, name), &hivev1.HostedClusterInstall{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeHostedClusterInstalls) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(hostedclusterinstallsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &hivev1.HostedClusterInstallList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched hostedClusterInstall.
+func (c *FakeHostedClusterInstalls) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *hivev1.HostedClusterInstall, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(hostedclusterinstallsResource, c.ns, name, pt, data, subresources...), &hivev1.HostedClusterInstall{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.HostedClusterInstall), err
+}
@@ -0,0 +1,126 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeHostInventories implements HostInventoryInterface
+type FakeHostInventories struct {
+	Fake *FakeHiveV1
+	ns   string
+}
+
+var hostinventoriesResource = schema.GroupVersionResource{Group: "hive.openshift.io", Version: "v1", Resource: "hostinventories"}
+
+var hostinventoriesKind = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "HostInventory"}
+
+// Get takes name of the hostInventory, and returns the corresponding hostInventory object, and an error if there is any.
+func (c *FakeHostInventories) Get(ctx context.Context, name string, options v1.GetOptions) (result *hivev1.HostInventory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(hostinventoriesResource, c.ns, name), &hivev1.HostInventory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.HostInventory), err
+}
+
+// List takes label and field selectors, and returns the list of HostInventories that match those selectors.
+func (c *FakeHostInventories) List(ctx context.Context, opts v1.ListOptions) (result *hivev1.HostInventoryList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(hostinventoriesResource, hostinventoriesKind, c.ns, opts), &hivev1.HostInventoryList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &hivev1.HostInventoryList{ListMeta: obj.(*hivev1.HostInventoryList).ListMeta}
+	for _, item := range obj.(*hivev1.HostInventoryList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested hostInventories.
+func (c *FakeHostInventories) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(hostinventoriesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a hostInventory and creates it.  Returns the server's representation of the hostInventory, and an error, if there is any.
+func (c *FakeHostInventories) Create(ctx context.Context, hostInventory *hivev1.HostInventory, opts v1.CreateOptions) (result *hivev1.HostInventory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(hostinventoriesResource, c.ns, hostInventory), &hivev1.HostInventory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.HostInventory), err
+}
+
+// Update takes the representation of a hostInventory and updates it. Returns the server's representation of the hostInventory, and an error, if there is any.
+func (c *FakeHostInventories) Update(ctx context.Context, hostInventory *hivev1.HostInventory, opts v1.UpdateOptions) (result *hivev1.HostInventory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(hostinventoriesResource, c.ns, hostInventory), &hivev1.HostInventory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.HostInventory), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeHostInventories) UpdateStatus(ctx context.Context, hostInventory *hivev1.HostInventory, opts v1.UpdateOptions) (*hivev1.HostInventory, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(hostinventoriesResource, "status", c.ns, hostInventory), &hivev1.HostInventory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.HostInventory), err
+}
+
+// Delete takes name of the hostInventory and deletes it. Returns an error if one occurs.
+func (c *FakeHostInventories) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(hostinventoriesResource, c.ns, name), &hivev1.HostInventory{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeHostInventories) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(hostinventoriesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &hivev1.HostInventoryList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched hostInventory.
+func (c *FakeHostInventories) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *hivev1.HostInventory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(hostinventoriesResource, c.ns, name, pt, data, subresources...), &hivev1.HostInventory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.HostInventory), err
+}
@@ -0,0 +1,126 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeTenantQuotas implements TenantQuotaInterface
+type FakeTenantQuotas struct {
+	Fake *FakeHiveV1
+	ns   string
+}
+
+var tenantquotasResource = schema.GroupVersionResource{Group: "hive.openshift.io", Version: "v1", Resource: "tenantquotas"}
+
+var tenantquotasKind = schema.GroupVersionKind{Group: "hive.openshift.io", Version: "v1", Kind: "TenantQuota"}
+
+// Get takes name of the tenantQuota, and returns the corresponding tenantQuota object, and an error if there is any.
+func (c *FakeTenantQuotas) Get(ctx context.Context, name string, options v1.GetOptions) (result *hivev1.TenantQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(tenantquotasResource, c.ns, name), &hivev1.TenantQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.TenantQuota), err
+}
+
+// List takes label and field selectors, and returns the list of TenantQuotas that match those selectors.
+func (c *FakeTenantQuotas) List(ctx context.Context, opts v1.ListOptions) (result *hivev1.TenantQuotaList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(tenantquotasResource, tenantquotasKind, c.ns, opts), &hivev1.TenantQuotaList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &hivev1.TenantQuotaList{ListMeta: obj.(*hivev1.TenantQuotaList).ListMeta}
+	for _, item := range obj.(*hivev1.TenantQuotaList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested tenantQuotas.
+func (c *FakeTenantQuotas) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(tenantquotasResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a tenantQuota and creates it.  Returns the server's representation of the tenantQuota, and an error, if there is any.
+func (c *FakeTenantQuotas) Create(ctx context.Context, tenantQuota *hivev1.TenantQuota, opts v1.CreateOptions) (result *hivev1.TenantQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(tenantquotasResource, c.ns, tenantQuota), &hivev1.TenantQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.TenantQuota), err
+}
+
+// Update takes the representation of a tenantQuota and updates it. Returns the server's representation of the tenantQuota, and an error, if there is any.
+func (c *FakeTenantQuotas) Update(ctx context.Context, tenantQuota *hivev1.TenantQuota, opts v1.UpdateOptions) (result *hivev1.TenantQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(tenantquotasResource, c.ns, tenantQuota), &hivev1.TenantQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.TenantQuota), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeTenantQuotas) UpdateStatus(ctx context.Context, tenantQuota *hivev1.TenantQuota, opts v1.UpdateOptions) (*hivev1.TenantQuota, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(tenantquotasResource, "status", c.ns, tenantQuota), &hivev1.TenantQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.TenantQuota), err
+}
+
+// Delete takes name of the tenantQuota and deletes it. Returns an error if one occurs.
+func (c *FakeTenantQuotas) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(tenantquotasResource, c.ns, name), &hivev1.TenantQuota{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeTenantQuotas) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(tenantquotasResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &hivev1.TenantQuotaList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched tenantQuota.
+func (c *FakeTenantQuotas) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *hivev1.TenantQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(tenantquotasResource, c.ns, name, pt, data, subresources...), &hivev1.TenantQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*hivev1.TenantQuota), err
+}
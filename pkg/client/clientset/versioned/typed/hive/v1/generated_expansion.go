@@ -4,6 +4,8 @@ package v1
 
 type CheckpointExpansion interface{}
 
+type ClusterAccessTokenExpansion interface{}
+
 type ClusterClaimExpansion interface{}
 
 type ClusterDeploymentExpansion interface{}
@@ -24,6 +26,10 @@ type DNSZoneExpansion interface{}
 
 type HiveConfigExpansion interface{}
 
+type HostInventoryExpansion interface{}
+
+type HostedClusterInstallExpansion interface{}
+
 type MachinePoolExpansion interface{}
 
 type MachinePoolNameLeaseExpansion interface{}
@@ -35,3 +41,5 @@ type SelectorSyncSetExpansion interface{}
 type SyncIdentityProviderExpansion interface{}
 
 type SyncSetExpansion interface{}
+
+type TenantQuotaExpansion interface{}
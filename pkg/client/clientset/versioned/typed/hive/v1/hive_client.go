@@ -11,6 +11,7 @@ import (
 type HiveV1Interface interface {
 	RESTClient() rest.Interface
 	CheckpointsGetter
+	ClusterAccessTokensGetter
 	ClusterClaimsGetter
 	ClusterDeploymentsGetter
 	ClusterDeprovisionsGetter
@@ -21,12 +22,15 @@ type HiveV1Interface interface {
 	ClusterStatesGetter
 	DNSZonesGetter
 	HiveConfigsGetter
+	HostInventoriesGetter
+	HostedClusterInstallsGetter
 	MachinePoolsGetter
 	MachinePoolNameLeasesGetter
 	SelectorSyncIdentityProvidersGetter
 	SelectorSyncSetsGetter
 	SyncIdentityProvidersGetter
 	SyncSetsGetter
+	TenantQuotasGetter
 }
 
 // HiveV1Client is used to interact with features provided by the hive.openshift.io group.
@@ -38,6 +42,10 @@ func (c *HiveV1Client) Checkpoints(namespace string) CheckpointInterface {
 	return newCheckpoints(c, namespace)
 }
 
+func (c *HiveV1Client) ClusterAccessTokens(namespace string) ClusterAccessTokenInterface {
+	return newClusterAccessTokens(c, namespace)
+}
+
 func (c *HiveV1Client) ClusterClaims(namespace string) ClusterClaimInterface {
 	return newClusterClaims(c, namespace)
 }
@@ -78,6 +86,14 @@ func (c *HiveV1Client) HiveConfigs() HiveConfigInterface {
 	return newHiveConfigs(c)
 }
 
+func (c *HiveV1Client) HostInventories(namespace string) HostInventoryInterface {
+	return newHostInventories(c, namespace)
+}
+
+func (c *HiveV1Client) HostedClusterInstalls(namespace string) HostedClusterInstallInterface {
+	return newHostedClusterInstalls(c, namespace)
+}
+
 func (c *HiveV1Client) MachinePools(namespace string) MachinePoolInterface {
 	return newMachinePools(c, namespace)
 }
@@ -102,6 +118,10 @@ func (c *HiveV1Client) SyncSets(namespace string) SyncSetInterface {
 	return newSyncSets(c, namespace)
 }
 
+func (c *HiveV1Client) TenantQuotas(namespace string) TenantQuotaInterface {
+	return newTenantQuotas(c, namespace)
+}
+
 // NewForConfig creates a new HiveV1Client for the given config.
 func NewForConfig(c *rest.Config) (*HiveV1Client, error) {
 	config := *c
@@ -0,0 +1,179 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/openshift/hive/apis/hive/v1"
+	scheme "github.com/openshift/hive/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// HostInventoriesGetter has a method to return a HostInventoryInterface.
+// A group's client should implement this interface.
+type HostInventoriesGetter interface {
+	HostInventories(namespace string) HostInventoryInterface
+}
+
+// HostInventoryInterface has methods to work with HostInventory resources.
+type HostInventoryInterface interface {
+	Create(ctx context.Context, hostInventory *v1.HostInventory, opts metav1.CreateOptions) (*v1.HostInventory, error)
+	Update(ctx context.Context, hostInventory *v1.HostInventory, opts metav1.UpdateOptions) (*v1.HostInventory, error)
+	UpdateStatus(ctx context.Context, hostInventory *v1.HostInventory, opts metav1.UpdateOptions) (*v1.HostInventory, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.HostInventory, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.HostInventoryList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.HostInventory, err error)
+	HostInventoryExpansion
+}
+
+// hostInventories implements HostInventoryInterface
+type hostInventories struct {
+	client rest.Interface
+	ns     string
+}
+
+// newHostInventories returns a HostInventories
+func newHostInventories(c *HiveV1Client, namespace string) *hostInventories {
+	return &hostInventories{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the hostInventory, and returns the corresponding hostInventory object, and an error if there is any.
+func (c *hostInventories) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.HostInventory, err error) {
+	result = &v1.HostInventory{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("hostinventories").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of HostInventories that match those selectors.
+func (c *hostInventories) List(ctx context.Context, opts metav1.ListOptions) (result *v1.HostInventoryList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.HostInventoryList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("hostinventories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested hostInventories.
+func (c *hostInventories) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("hostinventories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a hostInventory and creates it.  Returns the server's representation of the hostInventory, and an error, if there is any.
+func (c *hostInventories) Create(ctx context.Context, hostInventory *v1.HostInventory, opts metav1.CreateOptions) (result *v1.HostInventory, err error) {
+	result = &v1.HostInventory{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("hostinventories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(hostInventory).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a hostInventory and updates it. Returns the server's representation of the hostInventory, and an error, if there is any.
+func (c *hostInventories) Update(ctx context.Context, hostInventory *v1.HostInventory, opts metav1.UpdateOptions) (result *v1.HostInventory, err error) {
+	result = &v1.HostInventory{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("hostinventories").
+		Name(hostInventory.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(hostInventory).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *hostInventories) UpdateStatus(ctx context.Context, hostInventory *v1.HostInventory, opts metav1.UpdateOptions) (result *v1.HostInventory, err error) {
+	result = &v1.HostInventory{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("hostinventories").
+		Name(hostInventory.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(hostInventory).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the hostInventory and deletes it. Returns an error if one occurs.
+func (c *hostInventories) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("hostinventories").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *hostInventories) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("hostinventories").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched hostInventory.
+func (c *hostInventories) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.HostInventory, err error) {
+	result = &v1.HostInventory{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("hostinventories").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
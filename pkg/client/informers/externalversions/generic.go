@@ -40,6 +40,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=hive.openshift.io, Version=v1
 	case v1.SchemeGroupVersion.WithResource("checkpoints"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().Checkpoints().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("clusteraccesstokens"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterAccessTokens().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterclaims"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterClaims().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterdeployments"):
@@ -60,6 +62,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().DNSZones().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("hiveconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().HiveConfigs().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("hostinventories"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().HostInventories().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("hostedclusterinstalls"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().HostedClusterInstalls().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("machinepools"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().MachinePools().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("machinepoolnameleases"):
@@ -72,6 +78,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().SyncIdentityProviders().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("syncsets"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().SyncSets().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("tenantquotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().TenantQuotas().Informer()}, nil
 
 		// Group=hiveinternal.openshift.io, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("clustersyncs"):
@@ -10,6 +10,8 @@ import (
 type Interface interface {
 	// Checkpoints returns a CheckpointInformer.
 	Checkpoints() CheckpointInformer
+	// ClusterAccessTokens returns a ClusterAccessTokenInformer.
+	ClusterAccessTokens() ClusterAccessTokenInformer
 	// ClusterClaims returns a ClusterClaimInformer.
 	ClusterClaims() ClusterClaimInformer
 	// ClusterDeployments returns a ClusterDeploymentInformer.
@@ -30,6 +32,10 @@ type Interface interface {
 	DNSZones() DNSZoneInformer
 	// HiveConfigs returns a HiveConfigInformer.
 	HiveConfigs() HiveConfigInformer
+	// HostInventories returns a HostInventoryInformer.
+	HostInventories() HostInventoryInformer
+	// HostedClusterInstalls returns a HostedClusterInstallInformer.
+	HostedClusterInstalls() HostedClusterInstallInformer
 	// MachinePools returns a MachinePoolInformer.
 	MachinePools() MachinePoolInformer
 	// MachinePoolNameLeases returns a MachinePoolNameLeaseInformer.
@@ -42,6 +48,8 @@ type Interface interface {
 	SyncIdentityProviders() SyncIdentityProviderInformer
 	// SyncSets returns a SyncSetInformer.
 	SyncSets() SyncSetInformer
+	// TenantQuotas returns a TenantQuotaInformer.
+	TenantQuotas() TenantQuotaInformer
 }
 
 type version struct {
@@ -60,6 +68,11 @@ func (v *version) Checkpoints() CheckpointInformer {
 	return &checkpointInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterAccessTokens returns a ClusterAccessTokenInformer.
+func (v *version) ClusterAccessTokens() ClusterAccessTokenInformer {
+	return &clusterAccessTokenInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterClaims returns a ClusterClaimInformer.
 func (v *version) ClusterClaims() ClusterClaimInformer {
 	return &clusterClaimInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -110,6 +123,16 @@ func (v *version) HiveConfigs() HiveConfigInformer {
 	return &hiveConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// HostInventories returns a HostInventoryInformer.
+func (v *version) HostInventories() HostInventoryInformer {
+	return &hostInventoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// HostedClusterInstalls returns a HostedClusterInstallInformer.
+func (v *version) HostedClusterInstalls() HostedClusterInstallInformer {
+	return &hostedClusterInstallInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // MachinePools returns a MachinePoolInformer.
 func (v *version) MachinePools() MachinePoolInformer {
 	return &machinePoolInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -139,3 +162,8 @@ func (v *version) SyncIdentityProviders() SyncIdentityProviderInformer {
 func (v *version) SyncSets() SyncSetInformer {
 	return &syncSetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// TenantQuotas returns a TenantQuotaInformer.
+func (v *version) TenantQuotas() TenantQuotaInformer {
+	return &tenantQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
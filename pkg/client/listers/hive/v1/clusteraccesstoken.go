@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/openshift/hive/apis/hive/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ClusterAccessTokenLister helps list ClusterAccessTokens.
+// All objects returned here must be treated as read-only.
+type ClusterAccessTokenLister interface {
+	// List lists all ClusterAccessTokens in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ClusterAccessToken, err error)
+	// ClusterAccessTokens returns an object that can list and get ClusterAccessTokens.
+	ClusterAccessTokens(namespace string) ClusterAccessTokenNamespaceLister
+	ClusterAccessTokenListerExpansion
+}
+
+// clusterAccessTokenLister implements the ClusterAccessTokenLister interface.
+type clusterAccessTokenLister struct {
+	indexer cache.Indexer
+}
+
+// NewClusterAccessTokenLister returns a new ClusterAccessTokenLister.
+func NewClusterAccessTokenLister(indexer cache.Indexer) ClusterAccessTokenLister {
+	return &clusterAccessTokenLister{indexer: indexer}
+}
+
+// List lists all ClusterAccessTokens in the indexer.
+func (s *clusterAccessTokenLister) List(selector labels.Selector) (ret []*v1.ClusterAccessToken, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ClusterAccessToken))
+	})
+	return ret, err
+}
+
+// ClusterAccessTokens returns an object that can list and get ClusterAccessTokens.
+func (s *clusterAccessTokenLister) ClusterAccessTokens(namespace string) ClusterAccessTokenNamespaceLister {
+	return clusterAccessTokenNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ClusterAccessTokenNamespaceLister helps list and get ClusterAccessTokens.
+// All objects returned here must be treated as read-only.
+type ClusterAccessTokenNamespaceLister interface {
+	// List lists all ClusterAccessTokens in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.ClusterAccessToken, err error)
+	// Get retrieves the ClusterAccessToken from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.ClusterAccessToken, error)
+	ClusterAccessTokenNamespaceListerExpansion
+}
+
+// clusterAccessTokenNamespaceLister implements the ClusterAccessTokenNamespaceLister
+// interface.
+type clusterAccessTokenNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ClusterAccessTokens in the indexer for a given namespace.
+func (s clusterAccessTokenNamespaceLister) List(selector labels.Selector) (ret []*v1.ClusterAccessToken, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ClusterAccessToken))
+	})
+	return ret, err
+}
+
+// Get retrieves the ClusterAccessToken from the indexer for a given namespace and name.
+func (s clusterAccessTokenNamespaceLister) Get(name string) (*v1.ClusterAccessToken, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("clusteraccesstoken"), name)
+	}
+	return obj.(*v1.ClusterAccessToken), nil
+}
@@ -10,6 +10,14 @@ type CheckpointListerExpansion interface{}
 // CheckpointNamespaceLister.
 type CheckpointNamespaceListerExpansion interface{}
 
+// ClusterAccessTokenListerExpansion allows custom methods to be added to
+// ClusterAccessTokenLister.
+type ClusterAccessTokenListerExpansion interface{}
+
+// ClusterAccessTokenNamespaceListerExpansion allows custom methods to be added to
+// ClusterAccessTokenNamespaceLister.
+type ClusterAccessTokenNamespaceListerExpansion interface{}
+
 // ClusterClaimListerExpansion allows custom methods to be added to
 // ClusterClaimLister.
 type ClusterClaimListerExpansion interface{}
@@ -78,6 +86,22 @@ type DNSZoneNamespaceListerExpansion interface{}
 // HiveConfigLister.
 type HiveConfigListerExpansion interface{}
 
+// HostInventoryListerExpansion allows custom methods to be added to
+// HostInventoryLister.
+type HostInventoryListerExpansion interface{}
+
+// HostInventoryNamespaceListerExpansion allows custom methods to be added to
+// HostInventoryNamespaceLister.
+type HostInventoryNamespaceListerExpansion interface{}
+
+// HostedClusterInstallListerExpansion allows custom methods to be added to
+// HostedClusterInstallLister.
+type HostedClusterInstallListerExpansion interface{}
+
+// HostedClusterInstallNamespaceListerExpansion allows custom methods to be added to
+// HostedClusterInstallNamespaceLister.
+type HostedClusterInstallNamespaceListerExpansion interface{}
+
 // MachinePoolListerExpansion allows custom methods to be added to
 // MachinePoolLister.
 type MachinePoolListerExpansion interface{}
@@ -117,3 +141,11 @@ type SyncSetListerExpansion interface{}
 // SyncSetNamespaceListerExpansion allows custom methods to be added to
 // SyncSetNamespaceLister.
 type SyncSetNamespaceListerExpansion interface{}
+
+// TenantQuotaListerExpansion allows custom methods to be added to
+// TenantQuotaLister.
+type TenantQuotaListerExpansion interface{}
+
+// TenantQuotaNamespaceListerExpansion allows custom methods to be added to
+// TenantQuotaNamespaceLister.
+type TenantQuotaNamespaceListerExpansion interface{}
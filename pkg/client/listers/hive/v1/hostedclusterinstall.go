@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/openshift/hive/apis/hive/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// HostedClusterInstallLister helps list HostedClusterInstalls.
+// All objects returned here must be treated as read-only.
+type HostedClusterInstallLister interface {
+	// List lists all HostedClusterInstalls in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.HostedClusterInstall, err error)
+	// HostedClusterInstalls returns an object that can list and get HostedClusterInstalls.
+	HostedClusterInstalls(namespace string) HostedClusterInstallNamespaceLister
+	HostedClusterInstallListerExpansion
+}
+
+// hostedClusterInstallLister implements the HostedClusterInstallLister interface.
+type hostedClusterInstallLister struct {
+	indexer cache.Indexer
+}
+
+// NewHostedClusterInstallLister returns a new HostedClusterInstallLister.
+func NewHostedClusterInstallLister(indexer cache.Indexer) HostedClusterInstallLister {
+	return &hostedClusterInstallLister{indexer: indexer}
+}
+
+// List lists all HostedClusterInstalls in the indexer.
+func (s *hostedClusterInstallLister) List(selector labels.Selector) (ret []*v1.HostedClusterInstall, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.HostedClusterInstall))
+	})
+	return ret, err
+}
+
+// HostedClusterInstalls returns an object that can list and get HostedClusterInstalls.
+func (s *hostedClusterInstallLister) HostedClusterInstalls(namespace string) HostedClusterInstallNamespaceLister {
+	return hostedClusterInstallNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// HostedClusterInstallNamespaceLister helps list and get HostedClusterInstalls.
+// All objects returned here must be treated as read-only.
+type HostedClusterInstallNamespaceLister interface {
+	// List lists all HostedClusterInstalls in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.HostedClusterInstall, err error)
+	// Get retrieves the HostedClusterInstall from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.HostedClusterInstall, error)
+	HostedClusterInstallNamespaceListerExpansion
+}
+
+// hostedClusterInstallNamespaceLister implements the HostedClusterInstallNamespaceLister
+// interface.
+type hostedClusterInstallNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all HostedClusterInstalls in the indexer for a given namespace.
+func (s hostedClusterInstallNamespaceLister) List(selector labels.Selector) (ret []*v1.HostedClusterInstall, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.HostedClusterInstall))
+	})
+	return ret, err
+}
+
+// Get retrieves the HostedClusterInstall from the indexer for a given namespace and name.
+func (s hostedClusterInstallNamespaceLister) Get(name string) (*v1.HostedClusterInstall, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("hostedclusterinstall"), name)
+	}
+	return obj.(*v1.HostedClusterInstall), nil
+}
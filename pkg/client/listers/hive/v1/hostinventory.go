@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/openshift/hive/apis/hive/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// HostInventoryLister helps list HostInventories.
+// All objects returned here must be treated as read-only.
+type HostInventoryLister interface {
+	// List lists all HostInventories in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.HostInventory, err error)
+	// HostInventories returns an object that can list and get HostInventories.
+	HostInventories(namespace string) HostInventoryNamespaceLister
+	HostInventoryListerExpansion
+}
+
+// hostInventoryLister implements the HostInventoryLister interface.
+type hostInventoryLister struct {
+	indexer cache.Indexer
+}
+
+// NewHostInventoryLister returns a new HostInventoryLister.
+func NewHostInventoryLister(indexer cache.Indexer) HostInventoryLister {
+	return &hostInventoryLister{indexer: indexer}
+}
+
+// List lists all HostInventories in the indexer.
+func (s *hostInventoryLister) List(selector labels.Selector) (ret []*v1.HostInventory, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.HostInventory))
+	})
+	return ret, err
+}
+
+// HostInventories returns an object that can list and get HostInventories.
+func (s *hostInventoryLister) HostInventories(namespace string) HostInventoryNamespaceLister {
+	return hostInventoryNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// HostInventoryNamespaceLister helps list and get HostInventories.
+// All objects returned here must be treated as read-only.
+type HostInventoryNamespaceLister interface {
+	// List lists all HostInventories in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.HostInventory, err error)
+	// Get retrieves the HostInventory from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.HostInventory, error)
+	HostInventoryNamespaceListerExpansion
+}
+
+// hostInventoryNamespaceLister implements the HostInventoryNamespaceLister
+// interface.
+type hostInventoryNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all HostInventories in the indexer for a given namespace.
+func (s hostInventoryNamespaceLister) List(selector labels.Selector) (ret []*v1.HostInventory, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.HostInventory))
+	})
+	return ret, err
+}
+
+// Get retrieves the HostInventory from the indexer for a given namespace and name.
+func (s hostInventoryNamespaceLister) Get(name string) (*v1.HostInventory, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("hostinventory"), name)
+	}
+	return obj.(*v1.HostInventory), nil
+}
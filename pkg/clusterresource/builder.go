@@ -58,9 +58,13 @@ type Builder struct {
 	// BaseDomain is the DNS base domain to be used for the cluster.
 	BaseDomain string
 
-	// WorkerNodesCount is the number of worker nodes to create in the cluster initially.
+	// WorkerNodesCount is the number of worker nodes to create in the cluster initially. It is ignored for the
+	// Compact and SingleNode topologies, which have no workers.
 	WorkerNodesCount int64
 
+	// Topology is the shape of the cluster. Defaults to HighlyAvailable.
+	Topology hivev1.ClusterTopology
+
 	// ManageDNS can be set to true to enable Hive's automatic DNS zone creation and forwarding. (assuming
 	// this is properly configured in HiveConfig)
 	ManageDNS bool
@@ -100,6 +104,10 @@ type Builder struct {
 	// Required when adopting pre-existing clusters.
 	AdoptInfraID string
 
+	// AdoptPlatformMetadata is the platform-specific metadata generated by the installer for a cluster being adopted.
+	// This field is optional when adopting.
+	AdoptPlatformMetadata *hivev1.ClusterPlatformMetadata
+
 	// AdoptAdminUsername is the admin username for an adopted cluster, typically written to disk
 	// after openshift-install create-cluster. This field is optional when adopting.
 	AdoptAdminUsername string
@@ -137,6 +145,11 @@ type Builder struct {
 
 	// PublishStrategy defines the publishing strategy for the install-config.
 	PublishStrategy string
+
+	// HostedCluster, when set, makes the cluster a HyperShift hosted cluster. The ClusterDeployment is installed by a
+	// HostedClusterInstall with this configuration instead of by the installer, so no install-config or MachinePool
+	// is generated.
+	HostedCluster *hivev1.HostedClusterConfig
 }
 
 // Validate ensures that the builder's fields are logically configured and usable to generate the cluster resources.
@@ -170,11 +183,29 @@ func (o *Builder) Validate() error {
 			return fmt.Errorf("either both AdoptAdminPassword and AdoptAdminUsername must be set, or neither")
 		}
 	} else {
-		if len(o.AdoptAdminKubeconfig) > 0 || o.AdoptInfraID != "" || o.AdoptClusterID != "" || o.AdoptAdminUsername != "" || o.AdoptAdminPassword != "" {
+		if len(o.AdoptAdminKubeconfig) > 0 || o.AdoptInfraID != "" || o.AdoptClusterID != "" || o.AdoptPlatformMetadata != nil || o.AdoptAdminUsername != "" || o.AdoptAdminPassword != "" {
 			return fmt.Errorf("cannot set adoption fields if Adopt is false")
 		}
 	}
 
+	switch o.Topology {
+	case "", hivev1.HighlyAvailableClusterTopology, hivev1.CompactClusterTopology, hivev1.SingleNodeClusterTopology:
+	default:
+		return fmt.Errorf("unsupported topology %q", o.Topology)
+	}
+
+	if o.HostedCluster != nil {
+		if len(o.ImageSet) == 0 {
+			return fmt.Errorf("hosted clusters must use an image set")
+		}
+		if o.Adopt {
+			return fmt.Errorf("cannot adopt a hosted cluster")
+		}
+		if len(o.InstallConfigTemplate) > 0 {
+			return fmt.Errorf("cannot set both HostedCluster and InstallConfigTemplate")
+		}
+	}
+
 	if len(o.AdditionalTrustBundle) > 0 {
 		if err := validate.CABundle(o.AdditionalTrustBundle); err != nil {
 			return fmt.Errorf("AdditionalTrustBundle is not valid: %s", err.Error())
@@ -194,17 +225,22 @@ func (o *Builder) Build() ([]runtime.Object, error) {
 	var allObjects []runtime.Object
 	allObjects = append(allObjects, o.generateClusterDeployment())
 
-	if mp := o.generateMachinePool(); mp != nil && !o.SkipMachinePools {
-		allObjects = append(allObjects, o.generateMachinePool())
+	if o.HostedCluster != nil {
+		allObjects = append(allObjects, o.generateHostedClusterInstall())
+	} else if mp := o.generateMachinePool(); mp != nil && !o.SkipMachinePools {
+		allObjects = append(allObjects, mp)
 	}
 
-	if o.InstallConfigTemplate != "" {
+	switch {
+	case o.HostedCluster != nil:
+		// The HostedClusterInstall takes the place of the install-config.
+	case o.InstallConfigTemplate != "":
 		installConfigSecret, err := o.mergeInstallConfigTemplate()
 		if err != nil {
 			return nil, fmt.Errorf("Encountered problems merging InstallConfigTemplate: %s", err.Error())
 		}
 		allObjects = append(allObjects, installConfigSecret)
-	} else {
+	default:
 		installConfigSecret, err := o.generateInstallConfigSecret()
 		if err != nil {
 			return nil, err
@@ -274,10 +310,12 @@ func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 			Labels:      o.Labels,
 		},
 		Spec: hivev1.ClusterDeploymentSpec{
-			ClusterName:  o.Name,
-			BaseDomain:   o.BaseDomain,
-			ManageDNS:    o.ManageDNS,
-			Provisioning: &hivev1.Provisioning{},
+			ClusterName: o.Name,
+			BaseDomain:  o.BaseDomain,
+			ManageDNS:   o.ManageDNS,
+			Provisioning: &hivev1.Provisioning{
+				Topology: o.Topology,
+			},
 		},
 	}
 
@@ -327,6 +365,7 @@ func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 			ClusterID:                o.AdoptClusterID,
 			InfraID:                  o.AdoptInfraID,
 			AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: o.getAdoptAdminKubeconfigSecretName()},
+			Platform:                 o.AdoptPlatformMetadata,
 		}
 		cd.Spec.Installed = true
 		if o.AdoptAdminUsername != "" {
@@ -357,9 +396,37 @@ func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 	cd.Spec.Provisioning.InstallConfigSecretRef = &corev1.LocalObjectReference{Name: o.getInstallConfigSecretName()}
 	cd.Spec.Platform = o.CloudBuilder.GetCloudPlatform(o)
 
+	if o.HostedCluster != nil {
+		cd.Spec.Provisioning = nil
+		cd.Spec.ClusterInstallRef = &hivev1.ClusterInstallLocalReference{
+			Group:   hivev1.SchemeGroupVersion.Group,
+			Version: hivev1.SchemeGroupVersion.Version,
+			Kind:    "HostedClusterInstall",
+			Name:    o.Name,
+		}
+	}
+
 	return cd
 }
 
+func (o *Builder) generateHostedClusterInstall() *hivev1.HostedClusterInstall {
+	return &hivev1.HostedClusterInstall{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HostedClusterInstall",
+			APIVersion: hivev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      o.Name,
+			Namespace: o.Namespace,
+		},
+		Spec: hivev1.HostedClusterInstallSpec{
+			ClusterDeploymentRef: corev1.LocalObjectReference{Name: o.Name},
+			ImageSetRef:          hivev1.ClusterImageSetReference{Name: o.ImageSet},
+			HostedClusterConfig:  *o.HostedCluster.DeepCopy(),
+		},
+	}
+}
+
 func (o *Builder) generateInstallConfigSecret() (*corev1.Secret, error) {
 	installConfig := &installertypes.InstallConfig{
 		ObjectMeta: metav1.ObjectMeta{
@@ -387,12 +454,12 @@ func (o *Builder) generateInstallConfigSecret() (*corev1.Secret, error) {
 		},
 		ControlPlane: &installertypes.MachinePool{
 			Name:     "master",
-			Replicas: pointer.Int64Ptr(3),
+			Replicas: pointer.Int64Ptr(o.controlPlaneReplicas()),
 		},
 		Compute: []installertypes.MachinePool{
 			{
 				Name:     "worker",
-				Replicas: &o.WorkerNodesCount,
+				Replicas: pointer.Int64Ptr(o.workerReplicas()),
 			},
 		},
 		AdditionalTrustBundle: o.AdditionalTrustBundle,
@@ -454,7 +521,13 @@ func (o *Builder) mergeInstallConfigTemplate() (*corev1.Secret, error) {
 	}, nil
 }
 
+// generateMachinePool returns the worker MachinePool of the cluster, or nil if the topology of the cluster has no
+// workers.
 func (o *Builder) generateMachinePool() *hivev1.MachinePool {
+	switch o.Topology {
+	case hivev1.CompactClusterTopology, hivev1.SingleNodeClusterTopology:
+		return nil
+	}
 	mp := &hivev1.MachinePool{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "MachinePool",
@@ -476,6 +549,23 @@ func (o *Builder) generateMachinePool() *hivev1.MachinePool {
 	return mp
 }
 
+// controlPlaneReplicas returns the number of control plane nodes for the topology of the cluster.
+func (o *Builder) controlPlaneReplicas() int64 {
+	if o.Topology == hivev1.SingleNodeClusterTopology {
+		return 1
+	}
+	return 3
+}
+
+// workerReplicas returns the number of worker nodes for the topology of the cluster.
+func (o *Builder) workerReplicas() int64 {
+	switch o.Topology {
+	case hivev1.CompactClusterTopology, hivev1.SingleNodeClusterTopology:
+		return 0
+	}
+	return o.WorkerNodesCount
+}
+
 func (o *Builder) getInstallConfigSecretName() string {
 	return fmt.Sprintf("%s-install-config", o.Name)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	installertypes "github.com/openshift/installer/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -177,6 +178,27 @@ func TestBuildClusterResources(t *testing.T) {
 				assert.Equal(t, awsInstanceType, workerPool.Spec.Platform.AWS.InstanceType)
 			},
 		},
+		{
+			name: "single node AWS cluster",
+			builder: func() *Builder {
+				awsBuilder := createAWSClusterBuilder()
+				awsBuilder.Topology = hivev1.SingleNodeClusterTopology
+				return awsBuilder
+			}(),
+			validate: func(t *testing.T, allObjects []runtime.Object) {
+				cd := findClusterDeployment(allObjects, clusterName)
+				assert.Equal(t, hivev1.SingleNodeClusterTopology, cd.Spec.Provisioning.Topology)
+				assert.Nil(t, findMachinePool(allObjects, fmt.Sprintf("%s-%s", clusterName, "worker")), "unexpected worker pool")
+
+				installConfigSecret := findSecret(allObjects, fmt.Sprintf("%s-install-config", clusterName))
+				require.NotNil(t, installConfigSecret)
+				installConfig := &installertypes.InstallConfig{}
+				require.NoError(t, yaml.Unmarshal([]byte(installConfigSecret.StringData["install-config.yaml"]), installConfig))
+				assert.Equal(t, int64(1), *installConfig.ControlPlane.Replicas, "unexpected control plane replicas")
+				require.Len(t, installConfig.Compute, 1, "unexpected compute pools")
+				assert.Equal(t, int64(0), *installConfig.Compute[0].Replicas, "unexpected compute replicas")
+			},
+		},
 		{
 			name: "adopt AWS cluster",
 			builder: func() *Builder {
@@ -199,6 +221,28 @@ func TestBuildClusterResources(t *testing.T) {
 				assert.Equal(t, adminKubeconfig.Name, cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name)
 			},
 		},
+		{
+			name: "adopt Azure cluster with platform metadata",
+			builder: func() *Builder {
+				azureBuilder := createAzureClusterBuilder()
+				azureBuilder.Adopt = true
+				azureBuilder.AdoptInfraID = adoptInfraID
+				azureBuilder.AdoptClusterID = adoptClusterID
+				azureBuilder.AdoptAdminKubeconfig = []byte(adoptAdminKubeconfig)
+				azureBuilder.AdoptPlatformMetadata = &hivev1.ClusterPlatformMetadata{
+					Azure: &hivev1azure.Metadata{Region: "eastus", ResourceGroupName: "adopted-rg"},
+				}
+				return azureBuilder
+			}(),
+			validate: func(t *testing.T, allObjects []runtime.Object) {
+				cd := findClusterDeployment(allObjects, clusterName)
+
+				assert.Equal(t, true, cd.Spec.Installed)
+				assert.Equal(t, &hivev1.ClusterPlatformMetadata{
+					Azure: &hivev1azure.Metadata{Region: "eastus", ResourceGroupName: "adopted-rg"},
+				}, cd.Spec.ClusterMetadata.Platform)
+			},
+		},
 		{
 			name:    "Azure cluster",
 			builder: createAzureClusterBuilder(),
@@ -338,10 +382,12 @@ metadata:
 			require.NotNil(t, sshKeySecret)
 			assert.Equal(t, sshKeySecret.Name, cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name)
 
-			workerPool := findMachinePool(allObjects, fmt.Sprintf("%s-%s", clusterName, "worker"))
-			require.NotNil(t, workerPool)
-			nc := int64(workerNodeCount)
-			assert.Equal(t, &nc, workerPool.Spec.Replicas)
+			if test.builder.Topology == "" {
+				workerPool := findMachinePool(allObjects, fmt.Sprintf("%s-%s", clusterName, "worker"))
+				require.NotNil(t, workerPool)
+				nc := int64(workerNodeCount)
+				assert.Equal(t, &nc, workerPool.Spec.Replicas)
+			}
 
 			manifestsConfigMap := findConfigMap(allObjects, fmt.Sprintf("%s-%s", clusterName, "manifests"))
 			require.NotNil(t, manifestsConfigMap)
@@ -353,6 +399,40 @@ metadata:
 
 }
 
+func TestBuildHostedClusterResources(t *testing.T) {
+	builder := createAWSClusterBuilder()
+	builder.HostedCluster = &hivev1.HostedClusterConfig{
+		NodePools: []hivev1.HostedClusterNodePool{{Name: "workers", Replicas: 2}},
+	}
+	allObjects, err := builder.Build()
+	require.NoError(t, err)
+
+	cd := findClusterDeployment(allObjects, clusterName)
+	require.NotNil(t, cd)
+	assert.Nil(t, cd.Spec.Provisioning, "unexpected provisioning")
+	assert.Equal(t, &hivev1.ClusterInstallLocalReference{
+		Group:   "hive.openshift.io",
+		Version: "v1",
+		Kind:    "HostedClusterInstall",
+		Name:    clusterName,
+	}, cd.Spec.ClusterInstallRef)
+
+	var hci *hivev1.HostedClusterInstall
+	for _, obj := range allObjects {
+		if i, ok := obj.(*hivev1.HostedClusterInstall); ok {
+			hci = i
+		}
+	}
+	require.NotNil(t, hci, "missing HostedClusterInstall")
+	assert.Equal(t, clusterName, hci.Spec.ClusterDeploymentRef.Name)
+	assert.Equal(t, imageSetName, hci.Spec.ImageSetRef.Name)
+	assert.Equal(t, builder.HostedCluster.NodePools, hci.Spec.NodePools)
+
+	assert.Nil(t, findSecret(allObjects, fmt.Sprintf("%s-install-config", clusterName)), "unexpected install-config")
+	assert.Nil(t, findMachinePool(allObjects, fmt.Sprintf("%s-%s", clusterName, "worker")), "unexpected worker pool")
+	assert.NotNil(t, findSecret(allObjects, builder.GetPullSecretSecretName()), "missing pull secret")
+}
+
 func findSecret(allObjects []runtime.Object, name string) *corev1.Secret {
 	for _, ro := range allObjects {
 		obj, ok := ro.(*corev1.Secret)
@@ -30,6 +30,47 @@ const (
 	// MinBackupPeriodSecondsEnvVar is the name of the environment variable used to tell the controller manager the minimum period of time between backups.
 	MinBackupPeriodSecondsEnvVar = "HIVE_MIN_BACKUP_PERIOD_SECONDS"
 
+	// RepairAfterRestoreEnvVar is the name of the environment variable used to tell the controller manager to repair
+	// the ClusterDeployments restored by Velero.
+	RepairAfterRestoreEnvVar = "HIVE_REPAIR_AFTER_RESTORE"
+
+	// GarbageCollectionEnvVar is the name of the environment variable used to tell the controller manager to
+	// periodically delete orphaned Hive artifacts.
+	GarbageCollectionEnvVar = "HIVE_GARBAGE_COLLECTION"
+
+	// GarbageCollectionDryRunEnvVar is the name of the environment variable used to tell the garbage collector to
+	// only report orphaned Hive artifacts without deleting them.
+	GarbageCollectionDryRunEnvVar = "HIVE_GARBAGE_COLLECTION_DRY_RUN"
+
+	// GarbageCollectionIntervalEnvVar is the name of the environment variable used to tell the garbage collector the
+	// time between runs.
+	GarbageCollectionIntervalEnvVar = "HIVE_GARBAGE_COLLECTION_INTERVAL"
+
+	// SpokeStatusHubNameEnvVar is the name of the environment variable used to tell the controller manager the name of
+	// the hub to report in the "hive" ClusterOperator of the clusters it manages. The ClusterOperator is only
+	// maintained when it is set.
+	SpokeStatusHubNameEnvVar = "HIVE_SPOKE_STATUS_HUB_NAME"
+
+	// ClusterAccessTokenAllowedClusterRolesEnvVar is the name of the environment variable used to tell the controller
+	// manager and the admission webhooks the comma-separated ClusterRoles which ClusterAccessTokens may bind.
+	ClusterAccessTokenAllowedClusterRolesEnvVar = "HIVE_CLUSTER_ACCESS_TOKEN_ALLOWED_CLUSTER_ROLES"
+
+	// DeletionBlockedTimeoutEnvVar is the name of the environment variable used to tell the controller manager how long
+	// objects may wait on their finalizers while being deleted before they are reported as blocked.
+	DeletionBlockedTimeoutEnvVar = "HIVE_DELETION_BLOCKED_TIMEOUT"
+
+	// AWSRetryModeEnvVar is the name of the environment variable used to tell the AWS clients how to retry failed
+	// calls. The value is one of the AWSRetryModes of HiveConfig.
+	AWSRetryModeEnvVar = "HIVE_AWS_RETRY_MODE"
+
+	// AWSMaxAttemptsEnvVar is the name of the environment variable used to tell the AWS clients how many times to
+	// attempt a call, including its retries.
+	AWSMaxAttemptsEnvVar = "HIVE_AWS_MAX_ATTEMPTS"
+
+	// AWSCallTimeoutEnvVar is the name of the environment variable used to tell the AWS clients how long a call,
+	// including its retries, may take.
+	AWSCallTimeoutEnvVar = "HIVE_AWS_CALL_TIMEOUT"
+
 	// InstallJobLabel is the label used for artifacts specific to Hive cluster installations.
 	InstallJobLabel = "hive.openshift.io/install"
 
@@ -48,11 +89,23 @@ const (
 	// ClusterProvisionNameLabel is the label that is used to identify a relationship to a given cluster provision object.
 	ClusterProvisionNameLabel = "hive.openshift.io/cluster-provision-name"
 
+	// CertManagerCertificateLabel is the label that is used to identify certificate secrets issued by cert-manager
+	// for a certificate bundle of a cluster deployment.
+	CertManagerCertificateLabel = "hive.openshift.io/cert-manager-certificate"
+
 	// ClusterPoolNameLabel is the label that is used to signal that a namespace was created to house a
 	// ClusterDeployment created for a ClusterPool. The label is used to reap namespaces after the ClusterDeployment
 	// has been deleted.
 	ClusterPoolNameLabel = "hive.openshift.io/cluster-pool-name"
 
+	// ClusterClaimNameLabel is the label that is used to identify the ClusterClaim that has claimed the cluster housed
+	// in a ClusterPool namespace.
+	ClusterClaimNameLabel = "hive.openshift.io/cluster-claim-name"
+
+	// ClusterClaimNamespaceLabel is the label that is used to identify the namespace of the ClusterClaim that has
+	// claimed the cluster housed in a ClusterPool namespace.
+	ClusterClaimNamespaceLabel = "hive.openshift.io/cluster-claim-namespace"
+
 	// SyncSetNameLabel is the label that is used to identify a relationship to a given syncset object.
 	SyncSetNameLabel = "hive.openshift.io/syncset-name"
 
@@ -143,9 +196,19 @@ const (
 	WaitForInstallCompleteExecutionsAnnotation = "hive.openshift.io/wait-for-install-complete-executions"
 
 	// ProtectedDeleteAnnotation is an annotation used on ClusterDeployments to indicate that the ClusterDeployment
-	// cannot be deleted. The annotation must be removed in order to delete the ClusterDeployment.
+	// cannot be deleted. The annotation must be removed in order to delete the ClusterDeployment. Admins of a cluster
+	// can also set it on the MachineSets and MachineAutoscalers of machine pools in the cluster, which Hive then never
+	// deletes.
 	ProtectedDeleteAnnotation = "hive.openshift.io/protected-delete"
 
+	// AdoptedMachineSetAnnotation is set on the pre-existing MachineSets of a cluster which a MachinePool with
+	// adoptExisting has adopted. The value is the name of the machine pool.
+	AdoptedMachineSetAnnotation = "hive.openshift.io/adopted-by-machine-pool"
+
+	// ConfirmDeleteAnnotation is an annotation used on ClusterDeployments with deletion protection enabled to confirm
+	// that the ClusterDeployment is to be deleted. The value of the annotation must be the name of the ClusterDeployment.
+	ConfirmDeleteAnnotation = "hive.openshift.io/confirm-delete"
+
 	// ProtectedDeleteEnvVar is the name of the environment variable used to tell the controller manager whether
 	// protected delete is enabled.
 	ProtectedDeleteEnvVar = "PROTECTED_DELETE"
@@ -160,6 +223,12 @@ const (
 	// An incoming status indicates that the resource is on the destination side of an in-progress relocate.
 	RelocateAnnotation = "hive.openshift.io/relocate"
 
+	// RelocationFencingTokenAnnotation is an annotation holding the fencing token of the hub which owns a cluster.
+	// ClusterRelocates set a new token on the kube-system namespace of the managed cluster, and on the copy of the
+	// ClusterDeployment in the destination hub. Controllers which write to the managed cluster skip clusters fenced
+	// with a token other than that of their ClusterDeployment.
+	RelocationFencingTokenAnnotation = "hive.openshift.io/relocation-fencing-token"
+
 	// ManagedDomainsFileEnvVar if present, points to a simple text
 	// file that includes a valid managed domain per line. Cluster deployments
 	// requesting that their domains be managed must have a base domain
@@ -207,6 +276,10 @@ const (
 	// a fake install.
 	FakeClusterInstallEnvVar = "FAKE_INSTALL"
 
+	// FakeCloudInventoryEnvVar is the environment variable Hive will set for the uninstall pod of a fake cluster to
+	// the JSON resource graph of the simulated cloud inventory to deprovision.
+	FakeCloudInventoryEnvVar = "FAKE_CLOUD_INVENTORY"
+
 	// ControlPlaneCertificateSuffix is the suffix used when naming objects having to do control plane certificates.
 	ControlPlaneCertificateSuffix = "cp-certs"
 
@@ -320,6 +393,78 @@ const (
 	// provisions resources, and all communication with the cluster will be faked.
 	HiveFakeClusterAnnotation = "hive.openshift.io/fake-cluster"
 
+	// HiveSimulatedClusterAnnotation can be set to true on a fake cluster deployment to back its remote client with an
+	// in-memory simulated cluster that persists for the life of the hive controllers, so that objects written to the
+	// cluster (e.g. MachineSets) can be read back on later reconciles. Has no effect unless HiveFakeClusterAnnotation
+	// is also true.
+	HiveSimulatedClusterAnnotation = "hive.openshift.io/simulated-cluster"
+
+	// HiveFakeCloudInventoryAnnotation can be set on a fake cluster deployment to the JSON resource graph of the
+	// simulated cloud inventory its deprovision runs against. A typical AWS inventory owned by the cluster is simulated
+	// when it is not set. Has no effect unless HiveFakeClusterAnnotation is also true.
+	HiveFakeCloudInventoryAnnotation = "hive.openshift.io/fake-cloud-inventory"
+
+	// FakeMachinePoolActuatorAnnotation can be set to true on a MachinePool for the machinepool controller to generate
+	// synthetic MachineSets for it instead of generating them from the cloud provider of the cluster. It is meant for
+	// development, and is implied for the MachinePools of fake clusters.
+	FakeMachinePoolActuatorAnnotation = "hive.openshift.io/fake-actuator"
+
+	// KeepAwakeUntilAnnotation can be set on ClusterDeployments to an RFC 3339 timestamp before which HibernateAfter does
+	// not hibernate the cluster, for example to keep it running through a long test window. It has no effect once the
+	// timestamp has passed.
+	KeepAwakeUntilAnnotation = "hive.openshift.io/keep-awake-until"
+
+	// OverrideMaintenanceWindowsUntilAnnotation can be set on ClusterDeployments to an RFC 3339 timestamp before which
+	// disruptive actions may start outside of the maintenance windows of the cluster, for example to roll out an
+	// emergency fix. It has no effect once the timestamp has passed.
+	OverrideMaintenanceWindowsUntilAnnotation = "hive.openshift.io/override-maintenance-windows-until"
+
+	// AllowImmutableChangesAnnotation can be set on ClusterDeployments to a comma-separated list of the otherwise
+	// immutable fields which the ClusterDeployment validating webhook allows to change: platform, baseDomain,
+	// clusterName and installed. It is a break-glass for adopting clusters and fixing up ClusterDeployments, and should
+	// be removed once the change is made.
+	AllowImmutableChangesAnnotation = "hive.openshift.io/allow-immutable-changes"
+
+	// RepairAfterRestoreAnnotation can be set to true on a ClusterDeployment restored into a hub for the restorerepair
+	// controller to repair its owner references, finalizers and ClusterSync lease. The annotation is removed once the
+	// ClusterDeployment has been repaired.
+	RepairAfterRestoreAnnotation = "hive.openshift.io/repair-after-restore"
+
+	// RestoreRepairedAnnotation is set by the restorerepair controller on ClusterDeployments to the name of the Velero
+	// restore after which they were repaired, so that they are repaired once per restore.
+	RestoreRepairedAnnotation = "hive.openshift.io/restore-repaired"
+
+	// AWSHostedZoneIDAnnotation can be set on ClusterDeployments with managed DNS to the ID of an existing route53 hosted
+	// zone for the base domain, which the DNSZone of the cluster then uses instead of creating a hosted zone. It is
+	// only read when the DNSZone is created.
+	AWSHostedZoneIDAnnotation = "hive.openshift.io/aws-hosted-zone-id"
+
+	// RotateKubeadminPasswordAnnotation can be set to "true" on installed ClusterDeployments to have Hive generate a new
+	// kubeadmin password, set it on the cluster and store it in the admin password secret. The annotation is removed
+	// once the password has been rotated.
+	RotateKubeadminPasswordAnnotation = "hive.openshift.io/rotate-kubeadmin-password"
+
+	// SyncSetReapplyIntervalAnnotation can be set on ClusterDeployments to a duration overriding how often the
+	// clustersync controller fully reapplies the SyncSets of the cluster.
+	SyncSetReapplyIntervalAnnotation = "hive.openshift.io/syncset-reapply-interval"
+
+	// MachinePoolResyncIntervalAnnotation can be set on ClusterDeployments to a duration overriding how often the
+	// machinepool controller resyncs the MachinePools of the cluster while their machines are not all ready.
+	MachinePoolResyncIntervalAnnotation = "hive.openshift.io/machinepool-resync-interval"
+
+	// UnreachableRecheckIntervalAnnotation can be set on ClusterDeployments to a duration overriding how often the
+	// unreachable controller rechecks connectivity to a reachable cluster.
+	UnreachableRecheckIntervalAnnotation = "hive.openshift.io/unreachable-recheck-interval"
+
+	// MachinePoolGenerationAnnotation is set on the MachineSets of MachinePools in remote clusters to the generation
+	// of the MachinePool which was last applied to them.
+	MachinePoolGenerationAnnotation = "hive.openshift.io/machine-pool-generation"
+
+	// PropagatedNodeLabelsAnnotation is set on Machines in remote clusters to the comma-separated keys of the labels
+	// which Hive propagated to them from the ClusterDeployment, so that the labels which stop being propagated can be
+	// removed.
+	PropagatedNodeLabelsAnnotation = "hive.openshift.io/propagated-node-labels"
+
 	// ReconcileIDLen is the length of the random strings we generate for contextual loggers in controller
 	// Reconcile functions.
 	ReconcileIDLen = 8
@@ -339,6 +484,28 @@ const (
 	// stale, allowing it to set the ClusterPool's "ClusterDeploymentsCurrent" status condition.
 	ClusterDeploymentPoolSpecHashAnnotation = "hive.openshift.io/cluster-pool-spec-hash"
 
+	// ExternalSecretPathAnnotation annotates a credentials secret whose data is held in the external secret
+	// provider configured in HiveConfig.Spec.ExternalSecrets. Its value is the path of the secret in the provider,
+	// relative to the path prefix of the namespace of the credentials secret.
+	ExternalSecretPathAnnotation = "hive.openshift.io/external-secret-path"
+
+	// ArchivedLogsAnnotation annotates a ClusterProvision or ClusterDeprovision whose pod logs were archived in the
+	// log archive configured in HiveConfig.Spec.LogArchive. Its value is the JSON list of the keys of the logs.
+	ArchivedLogsAnnotation = "hive.openshift.io/archived-logs"
+
+	// SensitiveArtifactsAnnotation annotates an installed ClusterDeployment whose sensitive install artifacts were
+	// handled according to HiveConfig.Spec.SensitiveArtifacts. Its value is the policy which was applied.
+	SensitiveArtifactsAnnotation = "hive.openshift.io/sensitive-artifacts"
+
+	// EncryptedDataKeyAnnotation annotates a secret whose data was envelope-encrypted according to
+	// HiveConfig.Spec.SensitiveArtifacts or HiveConfig.Spec.AdminSecretEncryption. Its value is the base64-encoded
+	// data key, encrypted by the KMS key.
+	EncryptedDataKeyAnnotation = "hive.openshift.io/encrypted-data-key"
+
+	// EncryptionProviderAnnotation annotates a secret whose data was envelope-encrypted. Its value is the key
+	// management service which encrypted the data key: AWS, GCP, Azure or Local.
+	EncryptionProviderAnnotation = "hive.openshift.io/encryption-provider"
+
 	// HiveAWSServiceProviderCredentialsSecretRefEnvVar is the environment variable specifying what secret to use for
 	// assuming the service provider credentials for AWS clusters.
 	HiveAWSServiceProviderCredentialsSecretRefEnvVar = "HIVE_AWS_SERVICE_PROVIDER_CREDENTIALS_SECRET"
@@ -355,6 +522,54 @@ const (
 	// desired behavior when provisions fail. See HiveConfig.Spec.FailedProvisionConfig.
 	FailedProvisionConfigFileEnvVar = "FAILED_PROVISION_CONFIG_FILE"
 
+	// CostEstimationConfigFileEnvVar points to a text file containing the configuration for
+	// the costestimation controller. See HiveConfig.Spec.CostEstimation.
+	CostEstimationConfigFileEnvVar = "COST_ESTIMATION_CONFIG_FILE"
+
+	// NotificationsConfigFileEnvVar points to a text file containing the configuration for
+	// the notification of ClusterDeployment lifecycle events. See HiveConfig.Spec.Notifications.
+	NotificationsConfigFileEnvVar = "NOTIFICATIONS_CONFIG_FILE"
+
+	// ExternalSecretsConfigFileEnvVar points to a text file containing the configuration of the
+	// external secret provider. See HiveConfig.Spec.ExternalSecrets.
+	ExternalSecretsConfigFileEnvVar = "EXTERNAL_SECRETS_CONFIG_FILE"
+
+	// ExternalSecretsConfigEnvVar contains the configuration of the external secret provider, for
+	// install pods which do not have the configuration file mounted. See HiveConfig.Spec.ExternalSecrets.
+	ExternalSecretsConfigEnvVar = "EXTERNAL_SECRETS_CONFIG"
+
+	// CredentialsBrokerConfigFileEnvVar points to a text file containing the configuration of the
+	// credentials broker. See HiveConfig.Spec.CredentialsBroker.
+	CredentialsBrokerConfigFileEnvVar = "CREDENTIALS_BROKER_CONFIG_FILE"
+
+	// InstallManagerImageOverridesFileEnvVar points to a text file containing the install manager image overrides.
+	// See HiveConfig.Spec.InstallManagerImageOverrides.
+	InstallManagerImageOverridesFileEnvVar = "INSTALL_MANAGER_IMAGE_OVERRIDES_FILE"
+
+	// ExecutionClusterConfigFileEnvVar points to a text file containing the configuration of the execution cluster
+	// running install and uninstall jobs. See HiveConfig.Spec.ExecutionCluster.
+	ExecutionClusterConfigFileEnvVar = "EXECUTION_CLUSTER_CONFIG_FILE"
+
+	// LogArchiveConfigFileEnvVar points to a text file containing the configuration of the archive of install and
+	// uninstall pod logs. See HiveConfig.Spec.LogArchive.
+	LogArchiveConfigFileEnvVar = "LOG_ARCHIVE_CONFIG_FILE"
+
+	// SensitiveArtifactsConfigFileEnvVar points to a text file containing the configuration of the handling of the
+	// sensitive artifacts of installations. See HiveConfig.Spec.SensitiveArtifacts.
+	SensitiveArtifactsConfigFileEnvVar = "SENSITIVE_ARTIFACTS_CONFIG_FILE"
+
+	// AdminSecretEncryptionConfigFileEnvVar points to a text file containing the configuration of the encryption of
+	// the admin secrets of clusters. See HiveConfig.Spec.AdminSecretEncryption.
+	AdminSecretEncryptionConfigFileEnvVar = "ADMIN_SECRET_ENCRYPTION_CONFIG_FILE"
+
+	// MachinePoolDefaultsConfigFileEnvVar points to a text file containing the defaults applied to the MachineSets of
+	// every MachinePool. See HiveConfig.Spec.MachinePoolDefaults.
+	MachinePoolDefaultsConfigFileEnvVar = "MACHINE_POOL_DEFAULTS_CONFIG_FILE"
+
+	// HubKubeconfigSecretName is the name of the secret holding the kubeconfig of the hub, which is copied
+	// next to jobs run on an execution cluster.
+	HubKubeconfigSecretName = "hive-hub-kubeconfig"
+
 	// HiveReleaseImageVerificationConfigMapNamespaceEnvVar is used to configure the config map that will be used
 	// to verify the release images being used for cluster deployments.
 	HiveReleaseImageVerificationConfigMapNamespaceEnvVar = "HIVE_RELEASE_IMAGE_VERIFICATION_CONFIGMAP_NS"
@@ -0,0 +1,163 @@
+// Package adminsecretencryption provides a controller which envelope-encrypts the admin kubeconfig and password
+// secrets of installed clusters with the key management service configured in HiveConfig.
+package adminsecretencryption
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/secretencryption"
+)
+
+const (
+	ControllerName = hivev1.AdminSecretEncryptionControllerName
+)
+
+// Add creates a new AdminSecretEncryption Controller and adds it to the Manager with default RBAC when the encryption
+// of admin secrets is configured in HiveConfig. The Manager will set fields on the Controller and Start it when the
+// Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	config, err := secretencryption.ReadConfig()
+	if err != nil {
+		logger.WithError(err).Error("could not read the admin secret encryption config")
+		return err
+	}
+	if config == nil {
+		logger.Debug("admin secret encryption is not configured")
+		return nil
+	}
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, config, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, config *hivev1.AdminSecretEncryptionConfig, rateLimiter flowcontrol.RateLimiter) *ReconcileAdminSecretEncryption {
+	r := &ReconcileAdminSecretEncryption{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+	}
+	r.providerFn = func() (secretencryption.Provider, error) {
+		return secretencryption.NewProvider(r.Client, controllerutils.GetHiveNamespace(), &config.KMS)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileAdminSecretEncryption, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileAdminSecretEncryption{}
+
+// ReconcileAdminSecretEncryption reconciles an installed ClusterDeployment to encrypt its admin secrets.
+type ReconcileAdminSecretEncryption struct {
+	client.Client
+
+	// providerFn returns the provider encrypting the data keys of the secrets. It is overridden in tests.
+	providerFn func() (secretencryption.Provider, error)
+}
+
+// Reconcile encrypts the admin kubeconfig and password secrets of an installed ClusterDeployment which are not
+// encrypted yet.
+func (r *ReconcileAdminSecretEncryption) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(ctx, request.NamespacedName, cd); {
+	case apierrors.IsNotFound(err):
+		cdLog.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		cdLog.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	if cd.DeletionTimestamp != nil || !cd.Spec.Installed || cd.Spec.ClusterMetadata == nil {
+		cdLog.Debug("cluster deployment is not installed or is being deleted")
+		return reconcile.Result{}, nil
+	}
+	if controllerutils.IsClusterPausedOrRelocating(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
+	refs := []corev1.LocalObjectReference{cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef}
+	if ref := cd.Spec.ClusterMetadata.AdminPasswordSecretRef; ref != nil {
+		refs = append(refs, *ref)
+	}
+	for _, ref := range refs {
+		if ref.Name == "" {
+			continue
+		}
+		secretLog := cdLog.WithField("secret", ref.Name)
+		if err := r.encryptSecret(ctx, cd.Namespace, ref.Name, secretLog); err != nil {
+			secretLog.WithError(err).Log(controllerutils.LogLevel(err), "could not encrypt admin secret")
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileAdminSecretEncryption) encryptSecret(ctx context.Context, namespace, name string, logger log.FieldLogger) error {
+	secret := &corev1.Secret{}
+	switch err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("secret not found")
+		return nil
+	case err != nil:
+		return err
+	}
+	if secretencryption.IsEncrypted(secret) {
+		logger.Debug("secret is already encrypted")
+		return nil
+	}
+	provider, err := r.providerFn()
+	if err != nil {
+		return err
+	}
+	if err := secretencryption.Encrypt(ctx, provider, secret); err != nil {
+		return err
+	}
+	logger.Info("encrypting admin secret")
+	return r.Update(ctx, secret)
+}
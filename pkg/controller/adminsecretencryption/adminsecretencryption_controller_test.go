@@ -0,0 +1,141 @@
+package adminsecretencryption
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/secretencryption"
+)
+
+const (
+	namespace            = "test-namespace"
+	cdName               = "test-cluster"
+	kubeconfigSecretName = "test-cluster-admin-kubeconfig"
+	passwordSecretName   = "test-cluster-admin-password"
+)
+
+// fakeProvider "encrypts" data keys by leaving them alone.
+type fakeProvider struct{}
+
+func (fakeProvider) Name() string {
+	return "Fake"
+}
+
+func (fakeProvider) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	return key, nil
+}
+
+func (fakeProvider) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	return wrapped, nil
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	clusterDeployment := func(installed bool) *hivev1.ClusterDeployment {
+		cd := &hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: cdName},
+			Spec:       hivev1.ClusterDeploymentSpec{Installed: installed},
+		}
+		if installed {
+			cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: kubeconfigSecretName},
+				AdminPasswordSecretRef:   &corev1.LocalObjectReference{Name: passwordSecretName},
+			}
+		}
+		return cd
+	}
+	secret := func(name string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data:       map[string][]byte{"data": []byte(name)},
+		}
+	}
+	encryptedSecret := func(name string) *corev1.Secret {
+		s := secret(name)
+		require.NoError(t, secretencryption.Encrypt(context.TODO(), fakeProvider{}, s))
+		return s
+	}
+
+	tests := []struct {
+		name            string
+		existing        []runtime.Object
+		expectEncrypted []string
+		expectPlaintext []string
+		expectUnchanged []string
+	}{
+		{
+			name:            "not installed",
+			existing:        []runtime.Object{clusterDeployment(false), secret(kubeconfigSecretName), secret(passwordSecretName)},
+			expectPlaintext: []string{kubeconfigSecretName, passwordSecretName},
+		},
+		{
+			name:            "installed",
+			existing:        []runtime.Object{clusterDeployment(true), secret(kubeconfigSecretName), secret(passwordSecretName)},
+			expectEncrypted: []string{kubeconfigSecretName, passwordSecretName},
+		},
+		{
+			name:            "already encrypted",
+			existing:        []runtime.Object{clusterDeployment(true), encryptedSecret(kubeconfigSecretName), secret(passwordSecretName)},
+			expectEncrypted: []string{passwordSecretName},
+			expectUnchanged: []string{kubeconfigSecretName},
+		},
+		{
+			name:            "missing password secret",
+			existing:        []runtime.Object{clusterDeployment(true), secret(kubeconfigSecretName)},
+			expectEncrypted: []string{kubeconfigSecretName},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(test.existing...).Build()
+			before := map[string]*corev1.Secret{}
+			for _, name := range test.expectUnchanged {
+				s := &corev1.Secret{}
+				require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, s))
+				before[name] = s
+			}
+			r := &ReconcileAdminSecretEncryption{
+				Client:     c,
+				providerFn: func() (secretencryption.Provider, error) { return fakeProvider{}, nil },
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			get := func(name string) *corev1.Secret {
+				s := &corev1.Secret{}
+				require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, s), "expected secret %s to exist", name)
+				return s
+			}
+			for _, name := range test.expectPlaintext {
+				assert.Equal(t, []byte(name), get(name).Data["data"], "expected secret %s to be untouched", name)
+			}
+			for _, name := range test.expectUnchanged {
+				assert.Equal(t, before[name].ResourceVersion, get(name).ResourceVersion, "expected secret %s not to be updated", name)
+			}
+			for _, name := range test.expectEncrypted {
+				s := get(name)
+				assert.True(t, secretencryption.IsEncrypted(s), "expected secret %s to be encrypted", name)
+				require.NoError(t, secretencryption.Decrypt(context.TODO(), fakeProvider{}, s), "could not decrypt secret %s", name)
+				assert.Equal(t, []byte(name), s.Data["data"], "unexpected decrypted data of secret %s", name)
+			}
+		})
+	}
+}
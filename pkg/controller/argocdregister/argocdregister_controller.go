@@ -53,6 +53,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/secretencryption"
 )
 
 const (
@@ -92,7 +93,7 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("argocdregister-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -375,6 +376,9 @@ func (r *ArgoCDRegisterController) loadSecretData(secretName, namespace, dataKey
 	if err != nil {
 		return "", err
 	}
+	if _, err := secretencryption.DecryptSecret(context.TODO(), r, s); err != nil {
+		return "", fmt.Errorf("could not decrypt secret %s: %v", secretName, err)
+	}
 	retStr, ok := s.Data[dataKey]
 	if !ok {
 		return "", fmt.Errorf("secret %s did not contain key %s", secretName, dataKey)
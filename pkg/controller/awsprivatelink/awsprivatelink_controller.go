@@ -14,12 +14,15 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/aws/aws-sdk-go/service/elbv2"
-	"github.com/aws/aws-sdk-go/service/route53"
-	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -43,6 +46,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/secretencryption"
 )
 
 const (
@@ -99,7 +103,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (*R
 func AddToManager(mgr manager.Manager, r *ReconcileAWSPrivateLink, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("awsprivatelink-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -175,9 +179,10 @@ func (r *ReconcileAWSPrivateLink) Reconcile(ctx context.Context, request reconci
 	// Initialize cluster deployment conditions if not present
 	newConditions := controllerutils.InitializeClusterDeploymentConditions(cd.Status.Conditions, clusterDeploymentAWSPrivateLinkConditions)
 	if len(newConditions) > len(cd.Status.Conditions) {
+		origConditions := cd.Status.Conditions
 		cd.Status.Conditions = newConditions
 		logger.Info("initializing AWS private link controller conditions")
-		if err := r.Status().Update(context.TODO(), cd); err != nil {
+		if err := controllerutils.PatchClusterDeploymentConditions(r.Client, cd, origConditions); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
 			return reconcile.Result{}, err
 		}
@@ -589,23 +594,23 @@ func (r *ReconcileAWSPrivateLink) reconcilePrivateLink(cd *hivev1.ClusterDeploym
 // The NLB created by the installer is named as {infraID}-int
 func discoverNLBForCluster(client awsclient.Client, infraID string, logger log.FieldLogger) (string, error) {
 	nlbName := infraID + "-int"
-	nlbs, err := client.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
-		Names: aws.StringSlice([]string{nlbName}),
+	nlbs, err := client.DescribeLoadBalancers(context.TODO(), &elbv2.DescribeLoadBalancersInput{
+		Names: []string{nlbName},
 	})
 	if err != nil {
 		return "", errors.Wrap(err, "failed to describe load balancer for the cluster")
 	}
-	nlbARN := aws.StringValue(nlbs.LoadBalancers[0].LoadBalancerArn)
+	nlbARN := aws.ToString(nlbs.LoadBalancers[0].LoadBalancerArn)
 	nlbLog := logger.WithField("nlbARN", nlbARN)
 
-	if err := waitForState(elbv2.LoadBalancerStateEnumActive, 1*time.Minute, func() (string, error) {
-		resp, err := client.DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
-			LoadBalancerArns: aws.StringSlice([]string{nlbARN}),
+	if err := waitForState(string(elbv2types.LoadBalancerStateEnumActive), 1*time.Minute, func() (string, error) {
+		resp, err := client.DescribeLoadBalancers(context.TODO(), &elbv2.DescribeLoadBalancersInput{
+			LoadBalancerArns: []string{nlbARN},
 		})
 		if err != nil {
 			return "", errors.Wrap(err, "failed to find the NLB")
 		}
-		return *resp.LoadBalancers[0].State.Code, nil
+		return string(resp.LoadBalancers[0].State.Code), nil
 	}, nlbLog); err != nil {
 		nlbLog.WithError(err).Error("NLB did not become Available in time.")
 		return "", err
@@ -638,7 +643,7 @@ func waitForState(state string, timeout time.Duration, currentState func() (stri
 func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClient,
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
 	nlbARN string,
-	logger log.FieldLogger) (bool, *ec2.ServiceConfiguration, error) {
+	logger log.FieldLogger) (bool, *ec2types.ServiceConfiguration, error) {
 	modified := false
 
 	serviceModified, serviceConfig, err := r.ensureVPCEndpointService(awsClient.user, cd, metadata, nlbARN, logger)
@@ -650,9 +655,9 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClie
 
 	serviceLog := logger.WithField("serviceID", *serviceConfig.ServiceId)
 
-	oldNLBs := sets.NewString(aws.StringValueSlice(serviceConfig.NetworkLoadBalancerArns)...)
+	oldNLBs := sets.NewString(serviceConfig.NetworkLoadBalancerArns...)
 	desiredNLBs := sets.NewString(nlbARN)
-	if aws.BoolValue(serviceConfig.AcceptanceRequired) ||
+	if aws.ToBool(serviceConfig.AcceptanceRequired) ||
 		!desiredNLBs.Equal(oldNLBs) {
 		modified = true
 		modification := &ec2.ModifyVpcEndpointServiceConfigurationInput{
@@ -661,26 +666,26 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClie
 		}
 
 		if added := desiredNLBs.Difference(oldNLBs).List(); len(added) > 0 {
-			modification.AddNetworkLoadBalancerArns = aws.StringSlice(added)
+			modification.AddNetworkLoadBalancerArns = added
 		}
 		if removed := oldNLBs.Difference(desiredNLBs).List(); len(removed) > 0 {
-			modification.RemoveNetworkLoadBalancerArns = aws.StringSlice(removed)
+			modification.RemoveNetworkLoadBalancerArns = removed
 		}
 
-		_, err := awsClient.user.ModifyVpcEndpointServiceConfiguration(modification)
+		_, err := awsClient.user.ModifyVpcEndpointServiceConfiguration(context.TODO(), modification)
 		if err != nil {
 			serviceLog.WithError(err).Error("error updating VPC Endpoint Service configuration to match the desired state")
 			return modified, nil, err
 		}
 	}
 
-	stsResp, err := awsClient.hub.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	stsResp, err := awsClient.hub.GetCallerIdentity(context.TODO(), &sts.GetCallerIdentityInput{})
 	if err != nil {
 		serviceLog.WithError(err).Error("error getting the identity of the user that will create the VPC Endpoint")
 		return modified, nil, err
 	}
 
-	permResp, err := awsClient.user.DescribeVpcEndpointServicePermissions(&ec2.DescribeVpcEndpointServicePermissionsInput{
+	permResp, err := awsClient.user.DescribeVpcEndpointServicePermissions(context.TODO(), &ec2.DescribeVpcEndpointServicePermissionsInput{
 		ServiceId: serviceConfig.ServiceId,
 	})
 	if err != nil {
@@ -690,9 +695,9 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClie
 
 	oldPerms := sets.NewString()
 	for _, allowed := range permResp.AllowedPrincipals {
-		oldPerms.Insert(aws.StringValue(allowed.Principal))
+		oldPerms.Insert(aws.ToString(allowed.Principal))
 	}
-	desriredPerms := sets.NewString(aws.StringValue(stsResp.Arn))
+	desriredPerms := sets.NewString(aws.ToString(stsResp.Arn))
 
 	if !desriredPerms.Equal(oldPerms) {
 		modified = true
@@ -700,15 +705,15 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClie
 			ServiceId: serviceConfig.ServiceId,
 		}
 		if added := desriredPerms.Difference(oldPerms).List(); len(added) > 0 {
-			input.AddAllowedPrincipals = aws.StringSlice(added)
+			input.AddAllowedPrincipals = added
 		}
 		if removed := oldPerms.Difference(desriredPerms).List(); len(removed) > 0 {
-			input.RemoveAllowedPrincipals = aws.StringSlice(removed)
+			input.RemoveAllowedPrincipals = removed
 		}
-		_, err := awsClient.user.ModifyVpcEndpointServicePermissions(input)
+		_, err := awsClient.user.ModifyVpcEndpointServicePermissions(context.TODO(), input)
 		if err != nil {
-			serviceLog.WithField("addAllowed", aws.StringValueSlice(input.AddAllowedPrincipals)).
-				WithField("removeAllowed", aws.StringValueSlice(input.RemoveAllowedPrincipals)).
+			serviceLog.WithField("addAllowed", input.AddAllowedPrincipals).
+				WithField("removeAllowed", input.RemoveAllowedPrincipals).
 				WithError(err).Error("error updating VPC Endpoint Service permission to match the desired state")
 			return modified, nil, err
 		}
@@ -717,14 +722,14 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpointService(awsClient *awsClie
 	return modified, serviceConfig, nil
 }
 
-func (r *ReconcileAWSPrivateLink) ensureVPCEndpointService(awsClient awsclient.Client, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, clusterNLB string, logger log.FieldLogger) (bool, *ec2.ServiceConfiguration, error) {
+func (r *ReconcileAWSPrivateLink) ensureVPCEndpointService(awsClient awsclient.Client, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, clusterNLB string, logger log.FieldLogger) (bool, *ec2types.ServiceConfiguration, error) {
 	modified := false
 	tag := ec2FilterForCluster(metadata)
-	serviceLog := logger.WithField("tag:key", aws.StringValue(tag.Name)).WithField("tag:value", aws.StringValueSlice(tag.Values))
+	serviceLog := logger.WithField("tag:key", aws.ToString(tag.Name)).WithField("tag:value", tag.Values)
 
-	var serviceConfig *ec2.ServiceConfiguration
-	resp, err := awsClient.DescribeVpcEndpointServiceConfigurations(&ec2.DescribeVpcEndpointServiceConfigurationsInput{
-		Filters: []*ec2.Filter{tag},
+	var serviceConfig *ec2types.ServiceConfiguration
+	resp, err := awsClient.DescribeVpcEndpointServiceConfigurations(context.TODO(), &ec2.DescribeVpcEndpointServiceConfigurationsInput{
+		Filters: []ec2types.Filter{tag},
 	})
 	if err != nil {
 		serviceLog.WithError(err).Error("failed to get VPC Endpoint Service for cluster")
@@ -738,7 +743,7 @@ func (r *ReconcileAWSPrivateLink) ensureVPCEndpointService(awsClient awsclient.C
 			return modified, nil, errors.Wrap(err, "failed to create VPC Enpoint Service for cluster")
 		}
 	} else {
-		serviceConfig = resp.ServiceConfigurations[0]
+		serviceConfig = &resp.ServiceConfigurations[0]
 	}
 
 	initPrivateLinkStatus(cd)
@@ -754,11 +759,11 @@ func (r *ReconcileAWSPrivateLink) ensureVPCEndpointService(awsClient awsclient.C
 	return modified, serviceConfig, nil
 }
 
-func createVPCEndpointService(awsClient awsclient.Client, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, clusterNLB string, logger log.FieldLogger) (*ec2.ServiceConfiguration, error) {
-	resp, err := awsClient.CreateVpcEndpointServiceConfiguration(&ec2.CreateVpcEndpointServiceConfigurationInput{
+func createVPCEndpointService(awsClient awsclient.Client, cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata, clusterNLB string, logger log.FieldLogger) (*ec2types.ServiceConfiguration, error) {
+	resp, err := awsClient.CreateVpcEndpointServiceConfiguration(context.TODO(), &ec2.CreateVpcEndpointServiceConfigurationInput{
 		AcceptanceRequired:      aws.Bool(false),
-		NetworkLoadBalancerArns: aws.StringSlice([]string{clusterNLB}),
-		TagSpecifications:       []*ec2.TagSpecification{ec2TagSpecification(metadata, "vpc-endpoint-service")},
+		NetworkLoadBalancerArns: []string{clusterNLB},
+		TagSpecifications:       []ec2types.TagSpecification{ec2TagSpecification(metadata, ec2types.ResourceTypeVpcEndpointService)},
 	})
 	if err != nil {
 		logger.WithError(err).Error("failed to create endpoint service for cluster")
@@ -767,14 +772,14 @@ func createVPCEndpointService(awsClient awsclient.Client, cd *hivev1.ClusterDepl
 
 	serviceLog := logger.WithField("serviceID", *resp.ServiceConfiguration.ServiceId)
 
-	if err := waitForState(ec2.ServiceStateAvailable, 1*time.Minute, func() (string, error) {
-		resp, err := awsClient.DescribeVpcEndpointServiceConfigurations(&ec2.DescribeVpcEndpointServiceConfigurationsInput{
-			ServiceIds: aws.StringSlice([]string{*resp.ServiceConfiguration.ServiceId}),
+	if err := waitForState(string(ec2types.ServiceStateAvailable), 1*time.Minute, func() (string, error) {
+		resp, err := awsClient.DescribeVpcEndpointServiceConfigurations(context.TODO(), &ec2.DescribeVpcEndpointServiceConfigurationsInput{
+			ServiceIds: []string{*resp.ServiceConfiguration.ServiceId},
 		})
 		if err != nil {
 			return "", errors.Wrap(err, "failed to find the VPC endpoint service")
 		}
-		return *resp.ServiceConfigurations[0].ServiceState, nil
+		return string(resp.ServiceConfigurations[0].ServiceState), nil
 	}, serviceLog); err != nil {
 		serviceLog.WithError(err).Error("VPC Endpoint Service did not become Available in time.")
 		return nil, err
@@ -792,15 +797,15 @@ func createVPCEndpointService(awsClient awsclient.Client, cd *hivev1.ClusterDepl
 // It currently doesn't manage any properties of the VPC endpoint once it is created.
 func (r *ReconcileAWSPrivateLink) reconcileVPCEndpoint(awsClient *awsClient,
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
-	vpcEndpointService *ec2.ServiceConfiguration,
-	logger log.FieldLogger) (bool, *ec2.VpcEndpoint, error) {
+	vpcEndpointService *ec2types.ServiceConfiguration,
+	logger log.FieldLogger) (bool, *ec2types.VpcEndpoint, error) {
 	modified := false
 	tag := ec2FilterForCluster(metadata)
-	endpointLog := logger.WithField("tag:key", aws.StringValue(tag.Name)).WithField("tag:value", aws.StringValueSlice(tag.Values))
+	endpointLog := logger.WithField("tag:key", aws.ToString(tag.Name)).WithField("tag:value", tag.Values)
 
-	var vpcEndpoint *ec2.VpcEndpoint
-	resp, err := awsClient.hub.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
-		Filters: []*ec2.Filter{tag},
+	var vpcEndpoint *ec2types.VpcEndpoint
+	resp, err := awsClient.hub.DescribeVpcEndpoints(context.TODO(), &ec2.DescribeVpcEndpointsInput{
+		Filters: []ec2types.Filter{tag},
 	})
 	if err != nil {
 		endpointLog.WithError(err).Error("error getting VPC Endpoint")
@@ -814,7 +819,7 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpoint(awsClient *awsClient,
 			return modified, nil, err
 		}
 	} else {
-		vpcEndpoint = resp.VpcEndpoints[0]
+		vpcEndpoint = &resp.VpcEndpoints[0]
 	}
 
 	initPrivateLinkStatus(cd)
@@ -829,8 +834,8 @@ func (r *ReconcileAWSPrivateLink) reconcileVPCEndpoint(awsClient *awsClient,
 
 func (r *ReconcileAWSPrivateLink) createVPCEndpoint(awsClient awsclient.Client,
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
-	vpcEndpointService *ec2.ServiceConfiguration,
-	logger log.FieldLogger) (*ec2.VpcEndpoint, error) {
+	vpcEndpointService *ec2types.ServiceConfiguration,
+	logger log.FieldLogger) (*ec2types.VpcEndpoint, error) {
 	chosen, err := r.chooseVPCForVPCEndpoint(awsClient, cd, *vpcEndpointService.ServiceName, logger)
 	if err != nil {
 		logger.WithError(err).Error("failed to choose VPC for the VPC Endpoint from the inventory")
@@ -841,12 +846,12 @@ func (r *ReconcileAWSPrivateLink) createVPCEndpoint(awsClient awsclient.Client,
 	for _, subnet := range chosen.Subnets {
 		subnetIDs = append(subnetIDs, subnet.SubnetID)
 	}
-	resp, err := awsClient.CreateVpcEndpoint(&ec2.CreateVpcEndpointInput{
+	resp, err := awsClient.CreateVpcEndpoint(context.TODO(), &ec2.CreateVpcEndpointInput{
 		PrivateDnsEnabled: aws.Bool(false),
 		ServiceName:       vpcEndpointService.ServiceName,
-		SubnetIds:         aws.StringSlice(subnetIDs),
-		TagSpecifications: []*ec2.TagSpecification{ec2TagSpecification(metadata, "vpc-endpoint")},
-		VpcEndpointType:   aws.String(ec2.VpcEndpointTypeInterface),
+		SubnetIds:         subnetIDs,
+		TagSpecifications: []ec2types.TagSpecification{ec2TagSpecification(metadata, ec2types.ResourceTypeVpcEndpoint)},
+		VpcEndpointType:   ec2types.VpcEndpointTypeInterface,
 		VpcId:             aws.String(chosen.VPCID),
 	})
 	if err != nil {
@@ -856,13 +861,13 @@ func (r *ReconcileAWSPrivateLink) createVPCEndpoint(awsClient awsclient.Client,
 	endpointLog := logger.WithField("endpointID", *resp.VpcEndpoint.VpcEndpointId)
 
 	if err := waitForState("available", 1*time.Minute, func() (string, error) {
-		resp, err := awsClient.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
-			VpcEndpointIds: aws.StringSlice([]string{*resp.VpcEndpoint.VpcEndpointId}),
+		resp, err := awsClient.DescribeVpcEndpoints(context.TODO(), &ec2.DescribeVpcEndpointsInput{
+			VpcEndpointIds: []string{*resp.VpcEndpoint.VpcEndpointId},
 		})
 		if err != nil {
 			return "", errors.Wrap(err, "failed to get VPC endpoint")
 		}
-		return *resp.VpcEndpoints[0].State, nil
+		return string(resp.VpcEndpoints[0].State), nil
 	}, endpointLog); err != nil {
 		endpointLog.WithError(err).Error("VPC Endpoint did not become Available in time")
 		return nil, err
@@ -876,7 +881,7 @@ func (r *ReconcileAWSPrivateLink) createVPCEndpoint(awsClient awsclient.Client,
 // to the regional DNS name of the VPC endpoint.
 func (r *ReconcileAWSPrivateLink) reconcileHostedZone(awsClient *awsClient,
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
-	vpcEndpoint *ec2.VpcEndpoint, apiDomain string,
+	vpcEndpoint *ec2types.VpcEndpoint, apiDomain string,
 	logger log.FieldLogger) (bool, string, error) {
 	modified, hostedZoneID, err := r.ensureHostedZone(awsClient.hub, cd, vpcEndpoint, apiDomain, logger)
 	if err != nil {
@@ -888,16 +893,16 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZone(awsClient *awsClient,
 
 	rSet, err := r.recordSet(awsClient.hub, apiDomain, vpcEndpoint)
 	if err != nil {
-		hzLog.WithField("vpcEndpoint", aws.StringValue(vpcEndpoint.VpcEndpointId)).
+		hzLog.WithField("vpcEndpoint", aws.ToString(vpcEndpoint.VpcEndpointId)).
 			WithError(err).Error("error generating DNS records")
 		return modified, "", err
 	}
 
-	_, err = awsClient.hub.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+	_, err = awsClient.hub.ChangeResourceRecordSets(context.TODO(), &route53.ChangeResourceRecordSetsInput{
 		HostedZoneId: aws.String(hostedZoneID),
-		ChangeBatch: &route53.ChangeBatch{
-			Changes: []*route53.Change{{
-				Action:            aws.String(route53.ChangeActionUpsert),
+		ChangeBatch: &route53types.ChangeBatch{
+			Changes: []route53types.Change{{
+				Action:            route53types.ChangeActionUpsert,
 				ResourceRecordSet: rSet,
 			}},
 		},
@@ -909,13 +914,13 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZone(awsClient *awsClient,
 	return modified, hostedZoneID, nil
 }
 
-func (r *ReconcileAWSPrivateLink) recordSet(awsClient awsclient.Client, apiDomain string, vpcEndpoint *ec2.VpcEndpoint) (*route53.ResourceRecordSet, error) {
-	rSet := &route53.ResourceRecordSet{
+func (r *ReconcileAWSPrivateLink) recordSet(awsClient awsclient.Client, apiDomain string, vpcEndpoint *ec2types.VpcEndpoint) (*route53types.ResourceRecordSet, error) {
+	rSet := &route53types.ResourceRecordSet{
 		Name: aws.String(apiDomain),
 	}
 	switch r.controllerconfig.DNSRecordType {
 	case hivev1.ARecordAWSPrivateLinkDNSRecordType:
-		rSet.Type = aws.String("A")
+		rSet.Type = route53types.RRTypeA
 		rSet.TTL = aws.Int64(10)
 
 		// get the ips from the elastic networking interfaces attached to the VPC endpoint
@@ -923,7 +928,7 @@ func (r *ReconcileAWSPrivateLink) recordSet(awsClient awsclient.Client, apiDomai
 		if len(enis) == 0 {
 			return nil, errors.New("No network interfaces attached to the vpc endpoint")
 		}
-		res, err := awsClient.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: vpcEndpoint.NetworkInterfaceIds})
+		res, err := awsClient.DescribeNetworkInterfaces(context.TODO(), &ec2.DescribeNetworkInterfacesInput{NetworkInterfaceIds: vpcEndpoint.NetworkInterfaceIds})
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to list network interfaces attached to the vpc endpoint")
 		}
@@ -933,22 +938,22 @@ func (r *ReconcileAWSPrivateLink) recordSet(awsClient awsclient.Client, apiDomai
 
 		var ips []string
 		for _, eni := range res.NetworkInterfaces {
-			ips = append(ips, aws.StringValue(eni.PrivateIpAddress))
+			ips = append(ips, aws.ToString(eni.PrivateIpAddress))
 		}
 		sort.Strings(ips)
 
 		for _, ip := range ips {
-			rSet.ResourceRecords = append(rSet.ResourceRecords, &route53.ResourceRecord{
+			rSet.ResourceRecords = append(rSet.ResourceRecords, route53types.ResourceRecord{
 				Value: aws.String(ip),
 			})
 		}
 
 	default: // Alias is the default case.
-		rSet.Type = aws.String("A")
-		rSet.AliasTarget = &route53.AliasTarget{
+		rSet.Type = route53types.RRTypeA
+		rSet.AliasTarget = &route53types.AliasTarget{
 			DNSName:              vpcEndpoint.DnsEntries[0].DnsName,
 			HostedZoneId:         vpcEndpoint.DnsEntries[0].HostedZoneId,
-			EvaluateTargetHealth: aws.Bool(false),
+			EvaluateTargetHealth: false,
 		}
 	}
 	return rSet, nil
@@ -956,7 +961,7 @@ func (r *ReconcileAWSPrivateLink) recordSet(awsClient awsclient.Client, apiDomai
 
 func (r *ReconcileAWSPrivateLink) ensureHostedZone(awsClient awsclient.Client,
 	cd *hivev1.ClusterDeployment,
-	endpoint *ec2.VpcEndpoint, apiDomain string,
+	endpoint *ec2types.VpcEndpoint, apiDomain string,
 	logger log.FieldLogger) (bool, string, error) {
 	modified := false
 	hzID, err := findHostedZone(awsClient, *endpoint.VpcId, cd.Spec.Platform.AWS.Region, apiDomain, logger)
@@ -990,19 +995,19 @@ var errNoHostedZoneFoundForVPC = errors.New("no hosted zone found")
 func findHostedZone(awsClient awsclient.Client, vpcID, vpcRegion, apiDomain string, logger log.FieldLogger) (string, error) {
 	input := &route53.ListHostedZonesByVPCInput{
 		VPCId:     aws.String(vpcID),
-		VPCRegion: aws.String(vpcRegion),
+		VPCRegion: route53types.VPCRegion(vpcRegion),
 
-		MaxItems: aws.String("100"),
+		MaxItems: aws.Int32(100),
 	}
 	var nextToken *string
 	for {
 		input.NextToken = nextToken
-		resp, err := awsClient.ListHostedZonesByVPC(input)
+		resp, err := awsClient.ListHostedZonesByVPC(context.TODO(), input)
 		if err != nil {
 			return "", err
 		}
 		for _, summary := range resp.HostedZoneSummaries {
-			if strings.EqualFold(apiDomain, strings.TrimSuffix(aws.StringValue(summary.Name), ".")) {
+			if strings.EqualFold(apiDomain, strings.TrimSuffix(aws.ToString(summary.Name), ".")) {
 				return *summary.HostedZoneId, nil
 			}
 		}
@@ -1016,18 +1021,18 @@ func findHostedZone(awsClient awsclient.Client, vpcID, vpcRegion, apiDomain stri
 
 func (r *ReconcileAWSPrivateLink) createHostedZone(awsClient awsclient.Client,
 	cd *hivev1.ClusterDeployment,
-	endpoint *ec2.VpcEndpoint, apiDomain string,
+	endpoint *ec2types.VpcEndpoint, apiDomain string,
 	logger log.FieldLogger) (string, error) {
 	hzLog := logger.WithField("vpcID", *endpoint.VpcId).WithField("apiDomain", apiDomain)
-	resp, err := awsClient.CreateHostedZone(&route53.CreateHostedZoneInput{
+	resp, err := awsClient.CreateHostedZone(context.TODO(), &route53.CreateHostedZoneInput{
 		CallerReference: aws.String(time.Now().String()),
 		Name:            aws.String(apiDomain),
-		HostedZoneConfig: &route53.HostedZoneConfig{
-			PrivateZone: aws.Bool(true),
+		HostedZoneConfig: &route53types.HostedZoneConfig{
+			PrivateZone: true,
 		},
-		VPC: &route53.VPC{
+		VPC: &route53types.VPC{
 			VPCId:     endpoint.VpcId,
-			VPCRegion: aws.String(cd.Spec.Platform.AWS.Region),
+			VPCRegion: route53types.VPCRegion(cd.Spec.Platform.AWS.Region),
 		},
 	})
 	if err != nil {
@@ -1042,7 +1047,7 @@ func (r *ReconcileAWSPrivateLink) createHostedZone(awsClient awsclient.Client,
 // the controller config are associated to the PHZ hostedZoneID.
 func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *awsClient,
 	cd *hivev1.ClusterDeployment,
-	hostedZoneID string, vpcEndpoint *ec2.VpcEndpoint,
+	hostedZoneID string, vpcEndpoint *ec2types.VpcEndpoint,
 	logger log.FieldLogger) (bool, error) {
 	hzLog := logger.WithField("hostedZoneID", hostedZoneID)
 	modified := false
@@ -1052,7 +1057,7 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 		vpcIdx[v.VPCID] = i
 	}
 
-	zoneResp, err := awsClient.hub.GetHostedZone(&route53.GetHostedZoneInput{
+	zoneResp, err := awsClient.hub.GetHostedZone(context.TODO(), &route53.GetHostedZoneInput{
 		Id: aws.String(hostedZoneID),
 	})
 	if err != nil {
@@ -1062,13 +1067,13 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 
 	oldVPCs := sets.NewString()
 	for _, vpc := range zoneResp.VPCs {
-		id := aws.StringValue(vpc.VPCId)
+		id := aws.ToString(vpc.VPCId)
 		oldVPCs.Insert(id)
 		if _, ok := vpcIdx[id]; !ok { // make sure we have info for all VPCs for later use
 			vpcInfo = append(vpcInfo, hivev1.AWSAssociatedVPC{
 				AWSPrivateLinkVPC: hivev1.AWSPrivateLinkVPC{
 					VPCID:  id,
-					Region: aws.StringValue(vpc.VPCRegion),
+					Region: string(vpc.VPCRegion),
 				},
 			})
 			vpcIdx[id] = len(vpcInfo) - 1
@@ -1096,11 +1101,11 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 		awsAssociationClient := awsClient.hub
 		if info.CredentialsSecretRef != nil {
 			// since this VPC is in different account we need to authorize before continuing
-			_, err := awsClient.hub.CreateVPCAssociationAuthorization(&route53.CreateVPCAssociationAuthorizationInput{
+			_, err := awsClient.hub.CreateVPCAssociationAuthorization(context.TODO(), &route53.CreateVPCAssociationAuthorizationInput{
 				HostedZoneId: aws.String(hostedZoneID),
-				VPC: &route53.VPC{
+				VPC: &route53types.VPC{
 					VPCId:     aws.String(vpc),
-					VPCRegion: aws.String(info.Region),
+					VPCRegion: route53types.VPCRegion(info.Region),
 				},
 			})
 			if err != nil {
@@ -1123,11 +1128,11 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 			}
 		}
 
-		_, err = awsAssociationClient.AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+		_, err = awsAssociationClient.AssociateVPCWithHostedZone(context.TODO(), &route53.AssociateVPCWithHostedZoneInput{
 			HostedZoneId: aws.String(hostedZoneID),
-			VPC: &route53.VPC{
+			VPC: &route53types.VPC{
 				VPCId:     aws.String(vpc),
-				VPCRegion: aws.String(info.Region),
+				VPCRegion: route53types.VPCRegion(info.Region),
 			},
 		})
 		if err != nil {
@@ -1138,11 +1143,11 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 		if info.CredentialsSecretRef != nil {
 			// since we created an authorization and association is complete, we should remove the object
 			// as recommended by AWS best practices.
-			_, err := awsClient.hub.DeleteVPCAssociationAuthorization(&route53.DeleteVPCAssociationAuthorizationInput{
+			_, err := awsClient.hub.DeleteVPCAssociationAuthorization(context.TODO(), &route53.DeleteVPCAssociationAuthorizationInput{
 				HostedZoneId: aws.String(hostedZoneID),
-				VPC: &route53.VPC{
+				VPC: &route53types.VPC{
 					VPCId:     aws.String(vpc),
-					VPCRegion: aws.String(info.Region),
+					VPCRegion: route53types.VPCRegion(info.Region),
 				},
 			})
 			if err != nil {
@@ -1154,11 +1159,11 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 	for _, vpc := range removed {
 		vpcLog := hzLog.WithField("vpc", vpc)
 		info := vpcInfo[vpcIdx[vpc]]
-		_, err = awsClient.hub.DisassociateVPCFromHostedZone(&route53.DisassociateVPCFromHostedZoneInput{
+		_, err = awsClient.hub.DisassociateVPCFromHostedZone(context.TODO(), &route53.DisassociateVPCFromHostedZoneInput{
 			HostedZoneId: aws.String(hostedZoneID),
-			VPC: &route53.VPC{
+			VPC: &route53types.VPC{
 				VPCId:     aws.String(vpc),
-				VPCRegion: aws.String(info.Region),
+				VPCRegion: route53types.VPCRegion(info.Region),
 			},
 		})
 		if err != nil {
@@ -1171,30 +1176,30 @@ func (r *ReconcileAWSPrivateLink) reconcileHostedZoneAssociations(awsClient *aws
 }
 
 // ec2FilterForCluster is the filter that is used to find the resources tied to the cluster.
-func ec2FilterForCluster(metadata *hivev1.ClusterMetadata) *ec2.Filter {
-	return &ec2.Filter{
+func ec2FilterForCluster(metadata *hivev1.ClusterMetadata) ec2types.Filter {
+	return ec2types.Filter{
 		Name:   aws.String("tag:hive.openshift.io/private-link-access-for"),
-		Values: aws.StringSlice([]string{metadata.InfraID}),
+		Values: []string{metadata.InfraID},
 	}
 }
 
 // ec2TagSpecification is the list of tags that should be added to the resources
 // created for the cluster.
-func ec2TagSpecification(metadata *hivev1.ClusterMetadata, resource string) *ec2.TagSpecification {
-	return &ec2.TagSpecification{
-		ResourceType: aws.String(resource),
-		Tags: []*ec2.Tag{{
+func ec2TagSpecification(metadata *hivev1.ClusterMetadata, resource ec2types.ResourceType) ec2types.TagSpecification {
+	return ec2types.TagSpecification{
+		ResourceType: resource,
+		Tags: []ec2types.Tag{{
 			Key:   aws.String("hive.openshift.io/private-link-access-for"),
 			Value: aws.String(metadata.InfraID),
 		}, {
 			Key:   aws.String("Name"),
-			Value: aws.String(metadata.InfraID + "-" + resource),
+			Value: aws.String(metadata.InfraID + "-" + string(resource)),
 		}},
 	}
 }
 
 func filterErrorMessage(err error) string {
-	skipRequestIDRE := regexp.MustCompile(`(request id|Request ID): ([-0-9a-f]+)`)
+	skipRequestIDRE := regexp.MustCompile(`(request id|Request ID|RequestID): ([-0-9a-f]+)`)
 	return skipRequestIDRE.ReplaceAllString(err.Error(), "${1}: XXXX")
 }
 
@@ -1248,6 +1253,9 @@ func initialURL(c client.Client, key client.ObjectKey) (string, error) {
 	); err != nil {
 		return "", err
 	}
+	if _, err := secretencryption.DecryptSecret(context.TODO(), c, kubeconfigSecret); err != nil {
+		return "", errors.Wrap(err, "failed to decrypt the kubeconfig")
+	}
 	cfg, err := restConfigFromSecret(kubeconfigSecret)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to load the kubeconfig")
@@ -1277,15 +1285,15 @@ func restConfigFromSecret(kubeconfigSecret *corev1.Secret) (*rest.Config, error)
 }
 
 // awsErrCodeEquals returns true if the error matches all these conditions:
-//  * err is of type awserr.Error
-//  * Error.Code() equals code
+//  * err is of type smithy.APIError
+//  * APIError.ErrorCode() equals code
 func awsErrCodeEquals(err error, code string) bool {
 	if err == nil {
 		return false
 	}
-	var awsErr awserr.Error
-	if errors.As(err, &awsErr) {
-		return awsErr.Code() == code
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode() == code
 	}
 	return false
 }
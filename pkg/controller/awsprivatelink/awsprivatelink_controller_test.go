@@ -7,12 +7,15 @@ import (
 	"testing"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/aws/aws-sdk-go/service/elbv2"
-	"github.com/aws/aws-sdk-go/service/route53"
-	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	elbv2 "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbv2types "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/golang/mock/gomock"
 	"github.com/pkg/errors"
@@ -495,136 +498,136 @@ users:
 	}
 
 	mockDiscoverLB := func(m *mock.MockClient) string {
-		clusternlb := &elbv2.LoadBalancer{
+		clusternlb := &elbv2types.LoadBalancer{
 			LoadBalancerArn: aws.String("aws:elb:12345:nlb-arn"),
-			State: &elbv2.LoadBalancerState{
-				Code: aws.String(elbv2.LoadBalancerStateEnumActive),
+			State: &elbv2types.LoadBalancerState{
+				Code: elbv2types.LoadBalancerStateEnumActive,
 			},
 		}
-		m.EXPECT().DescribeLoadBalancers(gomock.Any()).
+		m.EXPECT().DescribeLoadBalancers(gomock.Any(), gomock.Any()).
 			Return(&elbv2.DescribeLoadBalancersOutput{
-				LoadBalancers: []*elbv2.LoadBalancer{clusternlb},
+				LoadBalancers: []elbv2types.LoadBalancer{*clusternlb},
 			}, nil).AnyTimes()
 		return *clusternlb.LoadBalancerArn
 	}
 
-	mockCreateService := func(m *mock.MockClient, clusternlb string) *ec2.ServiceConfiguration {
-		service := &ec2.ServiceConfiguration{
+	mockCreateService := func(m *mock.MockClient, clusternlb string) *ec2types.ServiceConfiguration {
+		service := &ec2types.ServiceConfiguration{
 			AcceptanceRequired:      aws.Bool(false),
 			ServiceId:               aws.String("vpce-svc-12345"),
 			ServiceName:             aws.String("vpce-svc-12345.vpc.amazon.com"),
-			ServiceState:            aws.String(ec2.ServiceStateAvailable),
-			NetworkLoadBalancerArns: aws.StringSlice([]string{clusternlb}),
-			AvailabilityZones:       aws.StringSlice([]string{"us-east-1b", "us-east-1c"}),
+			ServiceState:            ec2types.ServiceStateAvailable,
+			NetworkLoadBalancerArns: []string{clusternlb},
+			AvailabilityZones:       []string{"us-east-1b", "us-east-1c"},
 		}
-		m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).
+		m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any(), gomock.Any()).
 			Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{}, nil)
-		m.EXPECT().CreateVpcEndpointServiceConfiguration(gomock.Any()).
+		m.EXPECT().CreateVpcEndpointServiceConfiguration(gomock.Any(), gomock.Any()).
 			Return(&ec2.CreateVpcEndpointServiceConfigurationOutput{
 				ServiceConfiguration: service,
 			}, nil)
-		m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).
+		m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any(), gomock.Any()).
 			Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{
-				ServiceConfigurations: []*ec2.ServiceConfiguration{service},
+				ServiceConfigurations: []ec2types.ServiceConfiguration{*service},
 			}, nil)
 		return service
 	}
-	mockServicePerms := func(m *mock.MockClient, service *ec2.ServiceConfiguration) {
-		m.EXPECT().GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String("aws:iam:12345:hub-user")}, nil)
-		m.EXPECT().DescribeVpcEndpointServicePermissions(gomock.Any()).
+	mockServicePerms := func(m *mock.MockClient, service *ec2types.ServiceConfiguration) {
+		m.EXPECT().GetCallerIdentity(gomock.Any(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String("aws:iam:12345:hub-user")}, nil)
+		m.EXPECT().DescribeVpcEndpointServicePermissions(gomock.Any(), gomock.Any()).
 			Return(&ec2.DescribeVpcEndpointServicePermissionsOutput{}, nil)
-		m.EXPECT().ModifyVpcEndpointServicePermissions(&ec2.ModifyVpcEndpointServicePermissionsInput{
-			AddAllowedPrincipals: aws.StringSlice([]string{"aws:iam:12345:hub-user"}),
+		m.EXPECT().ModifyVpcEndpointServicePermissions(gomock.Any(), &ec2.ModifyVpcEndpointServicePermissionsInput{
+			AddAllowedPrincipals: []string{"aws:iam:12345:hub-user"},
 			ServiceId:            service.ServiceId,
 		}).Return(nil, nil)
 	}
-	mockExistingService := func(m *mock.MockClient, clusternlb string, modify func(*ec2.ServiceConfiguration)) *ec2.ServiceConfiguration {
-		service := &ec2.ServiceConfiguration{
+	mockExistingService := func(m *mock.MockClient, clusternlb string, modify func(*ec2types.ServiceConfiguration)) *ec2types.ServiceConfiguration {
+		service := &ec2types.ServiceConfiguration{
 			AcceptanceRequired:      aws.Bool(false),
 			ServiceId:               aws.String("vpce-svc-12345"),
 			ServiceName:             aws.String("vpce-svc-12345.vpc.amazon.com"),
-			ServiceState:            aws.String(ec2.ServiceStateAvailable),
-			NetworkLoadBalancerArns: aws.StringSlice([]string{clusternlb}),
+			ServiceState:            ec2types.ServiceStateAvailable,
+			NetworkLoadBalancerArns: []string{clusternlb},
 		}
 		modify(service)
-		m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).
+		m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any(), gomock.Any()).
 			Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{
-				ServiceConfigurations: []*ec2.ServiceConfiguration{service},
+				ServiceConfigurations: []ec2types.ServiceConfiguration{*service},
 			}, nil)
 		return service
 	}
 
-	mockCreateEndpoint := func(m *mock.MockClient, service *ec2.ServiceConfiguration) *ec2.VpcEndpoint {
-		m.EXPECT().DescribeVpcEndpoints(gomock.Any()).
+	mockCreateEndpoint := func(m *mock.MockClient, service *ec2types.ServiceConfiguration) *ec2types.VpcEndpoint {
+		m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).
 			Return(&ec2.DescribeVpcEndpointsOutput{}, nil).Times(2)
-		m.EXPECT().DescribeVpcEndpointServices(&ec2.DescribeVpcEndpointServicesInput{
-			ServiceNames: aws.StringSlice([]string{*service.ServiceName}),
+		m.EXPECT().DescribeVpcEndpointServices(gomock.Any(), &ec2.DescribeVpcEndpointServicesInput{
+			ServiceNames: []string{*service.ServiceName},
 		}).Return(&ec2.DescribeVpcEndpointServicesOutput{
-			ServiceDetails: []*ec2.ServiceDetail{{AvailabilityZones: service.AvailabilityZones}},
+			ServiceDetails: []ec2types.ServiceDetail{{AvailabilityZones: service.AvailabilityZones}},
 		}, nil)
 
-		endpoint := &ec2.VpcEndpoint{
+		endpoint := &ec2types.VpcEndpoint{
 			VpcEndpointId: aws.String("vpce-12345"),
 			VpcId:         aws.String("vpc-1"),
-			State:         aws.String("available"),
-			DnsEntries: []*ec2.DnsEntry{{
+			State:         "available",
+			DnsEntries: []ec2types.DnsEntry{{
 				DnsName:      aws.String("vpce-12345-us-east-1.vpce-svc-12345.vpc.amazonaws.com"),
 				HostedZoneId: aws.String("HZ23456"),
 			}},
 		}
-		m.EXPECT().CreateVpcEndpoint(gomock.Any()).
+		m.EXPECT().CreateVpcEndpoint(gomock.Any(), gomock.Any()).
 			Return(&ec2.CreateVpcEndpointOutput{VpcEndpoint: endpoint}, nil)
-		m.EXPECT().DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
-			VpcEndpointIds: aws.StringSlice([]string{*endpoint.VpcEndpointId}),
+		m.EXPECT().DescribeVpcEndpoints(gomock.Any(), &ec2.DescribeVpcEndpointsInput{
+			VpcEndpointIds: []string{*endpoint.VpcEndpointId},
 		}).Return(&ec2.DescribeVpcEndpointsOutput{
-			VpcEndpoints: []*ec2.VpcEndpoint{endpoint},
+			VpcEndpoints: []ec2types.VpcEndpoint{*endpoint},
 		}, nil)
 		return endpoint
 	}
 
-	mockPHZ := func(m *mock.MockClient, endpoint *ec2.VpcEndpoint, apiDomain string, existingSummary *route53.HostedZoneSummary) string {
+	mockPHZ := func(m *mock.MockClient, endpoint *ec2types.VpcEndpoint, apiDomain string, existingSummary *route53types.HostedZoneSummary) string {
 		byVPCOut := &route53.ListHostedZonesByVPCOutput{}
 		if existingSummary != nil {
-			byVPCOut.HostedZoneSummaries = []*route53.HostedZoneSummary{existingSummary}
+			byVPCOut.HostedZoneSummaries = []route53types.HostedZoneSummary{*existingSummary}
 		}
-		m.EXPECT().ListHostedZonesByVPC(&route53.ListHostedZonesByVPCInput{
-			MaxItems:  aws.String("100"),
+		m.EXPECT().ListHostedZonesByVPC(gomock.Any(), &route53.ListHostedZonesByVPCInput{
+			MaxItems:  aws.Int32(100),
 			VPCId:     endpoint.VpcId,
-			VPCRegion: aws.String("us-east-1"),
+			VPCRegion: route53types.VPCRegion("us-east-1"),
 		}).Return(byVPCOut, nil)
 		var hzID string
 		if existingSummary == nil {
 			hzID = "HZ12345"
-			m.EXPECT().CreateHostedZone(newCreateHostedZoneInputMatcher(&route53.CreateHostedZoneInput{
-				HostedZoneConfig: &route53.HostedZoneConfig{
-					PrivateZone: aws.Bool(true),
+			m.EXPECT().CreateHostedZone(gomock.Any(), newCreateHostedZoneInputMatcher(&route53.CreateHostedZoneInput{
+				HostedZoneConfig: &route53types.HostedZoneConfig{
+					PrivateZone: true,
 				},
 				Name: aws.String(apiDomain),
-				VPC: &route53.VPC{
+				VPC: &route53types.VPC{
 					VPCId:     endpoint.VpcId,
-					VPCRegion: aws.String("us-east-1"),
+					VPCRegion: route53types.VPCRegion("us-east-1"),
 				},
 			})).Return(&route53.CreateHostedZoneOutput{
-				HostedZone: &route53.HostedZone{
+				HostedZone: &route53types.HostedZone{
 					Id: aws.String(hzID),
 				},
 			}, nil)
 		} else {
-			hzID = aws.StringValue(existingSummary.HostedZoneId)
+			hzID = aws.ToString(existingSummary.HostedZoneId)
 		}
 
-		m.EXPECT().ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
-			ChangeBatch: &route53.ChangeBatch{
-				Changes: []*route53.Change{{
-					Action: aws.String("UPSERT"),
-					ResourceRecordSet: &route53.ResourceRecordSet{
-						AliasTarget: &route53.AliasTarget{
+		m.EXPECT().ChangeResourceRecordSets(gomock.Any(), &route53.ChangeResourceRecordSetsInput{
+			ChangeBatch: &route53types.ChangeBatch{
+				Changes: []route53types.Change{{
+					Action: route53types.ChangeActionUpsert,
+					ResourceRecordSet: &route53types.ResourceRecordSet{
+						AliasTarget: &route53types.AliasTarget{
 							DNSName:              endpoint.DnsEntries[0].DnsName,
-							EvaluateTargetHealth: aws.Bool(false),
+							EvaluateTargetHealth: false,
 							HostedZoneId:         endpoint.DnsEntries[0].HostedZoneId,
 						},
 						Name: aws.String(apiDomain),
-						Type: aws.String("A"),
+						Type: route53types.RRTypeA,
 					},
 				}},
 			},
@@ -633,61 +636,61 @@ users:
 		return hzID
 	}
 
-	mockPHZARecords := func(m *mock.MockClient, endpoint *ec2.VpcEndpoint, apiDomain string, existingSummary *route53.HostedZoneSummary, knownENIs map[string]string) string {
+	mockPHZARecords := func(m *mock.MockClient, endpoint *ec2types.VpcEndpoint, apiDomain string, existingSummary *route53types.HostedZoneSummary, knownENIs map[string]string) string {
 		byVPCOut := &route53.ListHostedZonesByVPCOutput{}
 		if existingSummary != nil {
-			byVPCOut.HostedZoneSummaries = []*route53.HostedZoneSummary{existingSummary}
+			byVPCOut.HostedZoneSummaries = []route53types.HostedZoneSummary{*existingSummary}
 		}
-		m.EXPECT().ListHostedZonesByVPC(&route53.ListHostedZonesByVPCInput{
-			MaxItems:  aws.String("100"),
+		m.EXPECT().ListHostedZonesByVPC(gomock.Any(), &route53.ListHostedZonesByVPCInput{
+			MaxItems:  aws.Int32(100),
 			VPCId:     endpoint.VpcId,
-			VPCRegion: aws.String("us-east-1"),
+			VPCRegion: route53types.VPCRegion("us-east-1"),
 		}).Return(byVPCOut, nil)
 		var hzID string
 		if existingSummary == nil {
 			hzID = "HZ12345"
-			m.EXPECT().CreateHostedZone(newCreateHostedZoneInputMatcher(&route53.CreateHostedZoneInput{
-				HostedZoneConfig: &route53.HostedZoneConfig{
-					PrivateZone: aws.Bool(true),
+			m.EXPECT().CreateHostedZone(gomock.Any(), newCreateHostedZoneInputMatcher(&route53.CreateHostedZoneInput{
+				HostedZoneConfig: &route53types.HostedZoneConfig{
+					PrivateZone: true,
 				},
 				Name: aws.String(apiDomain),
-				VPC: &route53.VPC{
+				VPC: &route53types.VPC{
 					VPCId:     endpoint.VpcId,
-					VPCRegion: aws.String("us-east-1"),
+					VPCRegion: route53types.VPCRegion("us-east-1"),
 				},
 			})).Return(&route53.CreateHostedZoneOutput{
-				HostedZone: &route53.HostedZone{
+				HostedZone: &route53types.HostedZone{
 					Id: aws.String(hzID),
 				},
 			}, nil)
 		} else {
-			hzID = aws.StringValue(existingSummary.HostedZoneId)
+			hzID = aws.ToString(existingSummary.HostedZoneId)
 		}
 
 		eniReq := &ec2.DescribeNetworkInterfacesInput{
 			NetworkInterfaceIds: endpoint.NetworkInterfaceIds,
 		}
 		eniResp := &ec2.DescribeNetworkInterfacesOutput{}
-		var rr []*route53.ResourceRecord
-		for _, eni := range aws.StringValueSlice(eniReq.NetworkInterfaceIds) {
+		var rr []route53types.ResourceRecord
+		for _, eni := range eniReq.NetworkInterfaceIds {
 			ip, ok := knownENIs[eni]
 			if ok {
-				eniResp.NetworkInterfaces = append(eniResp.NetworkInterfaces, &ec2.NetworkInterface{PrivateIpAddress: aws.String(ip)})
-				rr = append(rr, &route53.ResourceRecord{Value: aws.String(ip)})
+				eniResp.NetworkInterfaces = append(eniResp.NetworkInterfaces, ec2types.NetworkInterface{PrivateIpAddress: aws.String(ip)})
+				rr = append(rr, route53types.ResourceRecord{Value: aws.String(ip)})
 			}
 		}
-		m.EXPECT().DescribeNetworkInterfaces(eniReq).Return(eniResp, nil)
+		m.EXPECT().DescribeNetworkInterfaces(gomock.Any(), eniReq).Return(eniResp, nil)
 
 		sort.Slice(rr, func(i, j int) bool {
 			return *rr[i].Value < *rr[j].Value
 		})
-		m.EXPECT().ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
-			ChangeBatch: &route53.ChangeBatch{
-				Changes: []*route53.Change{{
-					Action: aws.String("UPSERT"),
-					ResourceRecordSet: &route53.ResourceRecordSet{
+		m.EXPECT().ChangeResourceRecordSets(gomock.Any(), &route53.ChangeResourceRecordSetsInput{
+			ChangeBatch: &route53types.ChangeBatch{
+				Changes: []route53types.Change{{
+					Action: route53types.ChangeActionUpsert,
+					ResourceRecordSet: &route53types.ResourceRecordSet{
 						Name:            aws.String(apiDomain),
-						Type:            aws.String("A"),
+						Type:            route53types.RRTypeA,
 						TTL:             aws.Int64(10),
 						ResourceRecords: rr,
 					},
@@ -805,14 +808,14 @@ users:
 		},
 		inventory: validInventory,
 		configureAWSClient: func(m *mock.MockClient) {
-			m.EXPECT().DescribeLoadBalancers(gomock.Any()).
-				Return(nil, awserr.New("AccessDenied", "not authorized to DescribeLoadBalancers", nil))
+			m.EXPECT().DescribeLoadBalancers(gomock.Any(), gomock.Any()).
+				Return(nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized to DescribeLoadBalancers"})
 		},
 
 		hasFinalizer: true,
 		expectedConditions: getExpectedConditions(true, "DiscoveringNLBFailed",
-			"failed to describe load balancer for the cluster: AccessDenied: not authorized to DescribeLoadBalancers"),
-		err: "failed to describe load balancer for the cluster: AccessDenied: not authorized to DescribeLoadBalancers",
+			"failed to describe load balancer for the cluster: api error AccessDenied: not authorized to DescribeLoadBalancers"),
+		err: "failed to describe load balancer for the cluster: api error AccessDenied: not authorized to DescribeLoadBalancers",
 	}, {
 		name: "cd with privatelink enabled, provision started, nlb not found",
 
@@ -824,8 +827,8 @@ users:
 		},
 		inventory: validInventory,
 		configureAWSClient: func(m *mock.MockClient) {
-			m.EXPECT().DescribeLoadBalancers(gomock.Any()).
-				Return(nil, awserr.New("LoadBalancerNotFound", "Loadbalance could not be found", nil))
+			m.EXPECT().DescribeLoadBalancers(gomock.Any(), gomock.Any()).
+				Return(nil, &smithy.GenericAPIError{Code: "LoadBalancerNotFound", Message: "Loadbalance could not be found"})
 		},
 		expectedConditions: []hivev1.ClusterDeploymentCondition{{
 			Status:  corev1.ConditionFalse,
@@ -850,7 +853,7 @@ users:
 			service := mockCreateService(m, clusternlb)
 			mockServicePerms(m, service)
 
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).Return(nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized to DescribeVpcEndpoints"})
 		},
 
 		hasFinalizer: true,
@@ -858,8 +861,8 @@ users:
 			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
 		},
 		expectedConditions: getExpectedConditions(true, "VPCEndpointReconcileFailed",
-			"AccessDenied: not authorized to DescribeVpcEndpoints"),
-		err: "failed to reconcile the VPC Endpoint: AccessDenied: not authorized to DescribeVpcEndpoints",
+			"api error AccessDenied: not authorized to DescribeVpcEndpoints"),
+		err: "failed to reconcile the VPC Endpoint: api error AccessDenied: not authorized to DescribeVpcEndpoints",
 	}, {
 		name: "cd with privatelink enabled, provision started, nlb found, previous service exists, acceptance required set to true, endpoint access denied",
 
@@ -872,18 +875,18 @@ users:
 		inventory: validInventory,
 		configureAWSClient: func(m *mock.MockClient) {
 			clusternlb := mockDiscoverLB(m)
-			service := mockExistingService(m, clusternlb, func(s *ec2.ServiceConfiguration) {
+			service := mockExistingService(m, clusternlb, func(s *ec2types.ServiceConfiguration) {
 				s.AcceptanceRequired = aws.Bool(true)
 			})
 
-			m.EXPECT().ModifyVpcEndpointServiceConfiguration(&ec2.ModifyVpcEndpointServiceConfigurationInput{
+			m.EXPECT().ModifyVpcEndpointServiceConfiguration(gomock.Any(), &ec2.ModifyVpcEndpointServiceConfigurationInput{
 				ServiceId:          service.ServiceId,
 				AcceptanceRequired: aws.Bool(false),
 			}).Return(&ec2.ModifyVpcEndpointServiceConfigurationOutput{}, nil)
 
 			mockServicePerms(m, service)
 
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).Return(nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized to DescribeVpcEndpoints"})
 		},
 
 		hasFinalizer: true,
@@ -891,8 +894,8 @@ users:
 			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
 		},
 		expectedConditions: getExpectedConditions(true, "VPCEndpointReconcileFailed",
-			"AccessDenied: not authorized to DescribeVpcEndpoints"),
-		err: "failed to reconcile the VPC Endpoint: AccessDenied: not authorized to DescribeVpcEndpoints",
+			"api error AccessDenied: not authorized to DescribeVpcEndpoints"),
+		err: "failed to reconcile the VPC Endpoint: api error AccessDenied: not authorized to DescribeVpcEndpoints",
 	}, {
 		name: "cd with privatelink enabled, provision started, nlb found, previous service exists, additional NLB added, endpoint access denied",
 
@@ -905,19 +908,19 @@ users:
 		inventory: validInventory,
 		configureAWSClient: func(m *mock.MockClient) {
 			clusternlb := mockDiscoverLB(m)
-			service := mockExistingService(m, clusternlb, func(s *ec2.ServiceConfiguration) {
-				s.NetworkLoadBalancerArns = aws.StringSlice([]string{clusternlb, "aws:elb:12345:not-cluster-nlb-arn"})
+			service := mockExistingService(m, clusternlb, func(s *ec2types.ServiceConfiguration) {
+				s.NetworkLoadBalancerArns = []string{clusternlb, "aws:elb:12345:not-cluster-nlb-arn"}
 			})
 
-			m.EXPECT().ModifyVpcEndpointServiceConfiguration(&ec2.ModifyVpcEndpointServiceConfigurationInput{
+			m.EXPECT().ModifyVpcEndpointServiceConfiguration(gomock.Any(), &ec2.ModifyVpcEndpointServiceConfigurationInput{
 				ServiceId:                     service.ServiceId,
 				AcceptanceRequired:            aws.Bool(false),
-				RemoveNetworkLoadBalancerArns: aws.StringSlice([]string{"aws:elb:12345:not-cluster-nlb-arn"}),
+				RemoveNetworkLoadBalancerArns: []string{"aws:elb:12345:not-cluster-nlb-arn"},
 			}).Return(&ec2.ModifyVpcEndpointServiceConfigurationOutput{}, nil)
 
 			mockServicePerms(m, service)
 
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).Return(nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized to DescribeVpcEndpoints"})
 		},
 
 		hasFinalizer: true,
@@ -925,8 +928,8 @@ users:
 			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
 		},
 		expectedConditions: getExpectedConditions(true, "VPCEndpointReconcileFailed",
-			"AccessDenied: not authorized to DescribeVpcEndpoints"),
-		err: "failed to reconcile the VPC Endpoint: AccessDenied: not authorized to DescribeVpcEndpoints",
+			"api error AccessDenied: not authorized to DescribeVpcEndpoints"),
+		err: "failed to reconcile the VPC Endpoint: api error AccessDenied: not authorized to DescribeVpcEndpoints",
 	}, {
 		name: "cd with privatelink enabled, provision started, nlb found, previous service exists, permissions change, endpoint access denied",
 
@@ -939,22 +942,22 @@ users:
 		inventory: validInventory,
 		configureAWSClient: func(m *mock.MockClient) {
 			clusternlb := mockDiscoverLB(m)
-			service := mockExistingService(m, clusternlb, func(s *ec2.ServiceConfiguration) {})
+			service := mockExistingService(m, clusternlb, func(s *ec2types.ServiceConfiguration) {})
 
-			m.EXPECT().GetCallerIdentity(gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String("aws:iam:12345:hub-user")}, nil)
-			m.EXPECT().DescribeVpcEndpointServicePermissions(gomock.Any()).
+			m.EXPECT().GetCallerIdentity(gomock.Any(), gomock.Any()).Return(&sts.GetCallerIdentityOutput{Arn: aws.String("aws:iam:12345:hub-user")}, nil)
+			m.EXPECT().DescribeVpcEndpointServicePermissions(gomock.Any(), gomock.Any()).
 				Return(&ec2.DescribeVpcEndpointServicePermissionsOutput{
-					AllowedPrincipals: []*ec2.AllowedPrincipal{{
+					AllowedPrincipals: []ec2types.AllowedPrincipal{{
 						Principal: aws.String("aws:iam:12345:some-that-should-not-be-allowed"),
 					}},
 				}, nil)
-			m.EXPECT().ModifyVpcEndpointServicePermissions(&ec2.ModifyVpcEndpointServicePermissionsInput{
-				AddAllowedPrincipals:    aws.StringSlice([]string{"aws:iam:12345:hub-user"}),
-				RemoveAllowedPrincipals: aws.StringSlice([]string{"aws:iam:12345:some-that-should-not-be-allowed"}),
+			m.EXPECT().ModifyVpcEndpointServicePermissions(gomock.Any(), &ec2.ModifyVpcEndpointServicePermissionsInput{
+				AddAllowedPrincipals:    []string{"aws:iam:12345:hub-user"},
+				RemoveAllowedPrincipals: []string{"aws:iam:12345:some-that-should-not-be-allowed"},
 				ServiceId:               service.ServiceId,
 			}).Return(nil, nil)
 
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).Return(nil, awserr.New("AccessDenied", "not authorized to DescribeVpcEndpoints", nil))
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).Return(nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized to DescribeVpcEndpoints"})
 		},
 
 		hasFinalizer: true,
@@ -962,8 +965,8 @@ users:
 			VPCEndpointService: hivev1aws.VPCEndpointService{Name: "vpce-svc-12345.vpc.amazon.com", ID: "vpce-svc-12345"},
 		},
 		expectedConditions: getExpectedConditions(true, "VPCEndpointReconcileFailed",
-			"AccessDenied: not authorized to DescribeVpcEndpoints"),
-		err: "failed to reconcile the VPC Endpoint: AccessDenied: not authorized to DescribeVpcEndpoints",
+			"api error AccessDenied: not authorized to DescribeVpcEndpoints"),
+		err: "failed to reconcile the VPC Endpoint: api error AccessDenied: not authorized to DescribeVpcEndpoints",
 	}, {
 		name: "cd with privatelink enabled, provision started, nlb found, no previous service, no previous endpoint, no matching az",
 
@@ -984,12 +987,12 @@ users:
 			service := mockCreateService(m, clusternlb)
 			mockServicePerms(m, service)
 
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).
 				Return(&ec2.DescribeVpcEndpointsOutput{}, nil)
-			m.EXPECT().DescribeVpcEndpointServices(&ec2.DescribeVpcEndpointServicesInput{
-				ServiceNames: aws.StringSlice([]string{*service.ServiceName}),
+			m.EXPECT().DescribeVpcEndpointServices(gomock.Any(), &ec2.DescribeVpcEndpointServicesInput{
+				ServiceNames: []string{*service.ServiceName},
 			}).Return(&ec2.DescribeVpcEndpointServicesOutput{
-				ServiceDetails: []*ec2.ServiceDetail{{AvailabilityZones: service.AvailabilityZones}},
+				ServiceDetails: []ec2types.ServiceDetail{{AvailabilityZones: service.AvailabilityZones}},
 			}, nil)
 		},
 
@@ -1015,25 +1018,25 @@ users:
 			service := mockCreateService(m, clusternlb)
 			mockServicePerms(m, service)
 
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).
 				Return(&ec2.DescribeVpcEndpointsOutput{}, nil)
-			m.EXPECT().DescribeVpcEndpointServices(&ec2.DescribeVpcEndpointServicesInput{
-				ServiceNames: aws.StringSlice([]string{*service.ServiceName}),
+			m.EXPECT().DescribeVpcEndpointServices(gomock.Any(), &ec2.DescribeVpcEndpointServicesInput{
+				ServiceNames: []string{*service.ServiceName},
 			}).Return(&ec2.DescribeVpcEndpointServicesOutput{
-				ServiceDetails: []*ec2.ServiceDetail{{AvailabilityZones: service.AvailabilityZones}},
+				ServiceDetails: []ec2types.ServiceDetail{{AvailabilityZones: service.AvailabilityZones}},
 			}, nil)
 
 			out := &ec2.DescribeVpcEndpointsOutput{}
 			for i := 0; i < 255; i++ {
-				out.VpcEndpoints = append(out.VpcEndpoints, &ec2.VpcEndpoint{
+				out.VpcEndpoints = append(out.VpcEndpoints, ec2types.VpcEndpoint{
 					VpcEndpointId: aws.String(fmt.Sprintf("vpce-%d", i)),
 					VpcId:         aws.String("vpc-1"),
 				})
 			}
-			m.EXPECT().DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
-				Filters: []*ec2.Filter{{
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), &ec2.DescribeVpcEndpointsInput{
+				Filters: []ec2types.Filter{{
 					Name:   aws.String("vpc-id"),
-					Values: aws.StringSlice([]string{"vpc-1"}),
+					Values: []string{"vpc-1"},
 				}},
 			}).Return(out, nil)
 		},
@@ -1089,13 +1092,13 @@ users:
 
 			hzID := mockPHZ(m, endpoint, "api.test-cluster", nil)
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
-				HostedZone: &route53.HostedZone{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+				HostedZone: &route53types.HostedZone{
 					Id: aws.String(hzID),
 				},
-				VPCs: []*route53.VPC{{
+				VPCs: []route53types.VPC{{
 					VPCId:     endpoint.VpcId,
-					VPCRegion: aws.String("us-east-1"),
+					VPCRegion: route53types.VPCRegion("us-east-1"),
 				}},
 			}, nil)
 		},
@@ -1131,17 +1134,17 @@ users:
 				"eni-2": "ip-2",
 				"eni-3": "ip-3",
 			}
-			endpoint.NetworkInterfaceIds = aws.StringSlice([]string{"eni-2", "eni-1"})
+			endpoint.NetworkInterfaceIds = []string{"eni-2", "eni-1"}
 
 			hzID := mockPHZARecords(m, endpoint, "api.test-cluster", nil, knownENI)
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
-				HostedZone: &route53.HostedZone{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+				HostedZone: &route53types.HostedZone{
 					Id: aws.String(hzID),
 				},
-				VPCs: []*route53.VPC{{
+				VPCs: []route53types.VPC{{
 					VPCId:     endpoint.VpcId,
-					VPCRegion: aws.String("us-east-1"),
+					VPCRegion: route53types.VPCRegion("us-east-1"),
 				}},
 			}, nil)
 		},
@@ -1171,18 +1174,18 @@ users:
 			mockServicePerms(m, service)
 			endpoint := mockCreateEndpoint(m, service)
 
-			hzID := mockPHZ(m, endpoint, "api.test-cluster", &route53.HostedZoneSummary{
+			hzID := mockPHZ(m, endpoint, "api.test-cluster", &route53types.HostedZoneSummary{
 				HostedZoneId: aws.String("HZ12345"),
 				Name:         aws.String("api.test-cluster"),
 			})
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
-				HostedZone: &route53.HostedZone{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+				HostedZone: &route53types.HostedZone{
 					Id: aws.String(hzID),
 				},
-				VPCs: []*route53.VPC{{
+				VPCs: []route53types.VPC{{
 					VPCId:     endpoint.VpcId,
-					VPCRegion: aws.String("us-east-1"),
+					VPCRegion: route53types.VPCRegion("us-east-1"),
 				}},
 			}, nil)
 		},
@@ -1218,20 +1221,20 @@ users:
 				"eni-2": "ip-2",
 				"eni-3": "ip-3",
 			}
-			endpoint.NetworkInterfaceIds = aws.StringSlice([]string{"eni-2", "eni-1"})
+			endpoint.NetworkInterfaceIds = []string{"eni-2", "eni-1"}
 
-			hzID := mockPHZARecords(m, endpoint, "api.test-cluster", &route53.HostedZoneSummary{
+			hzID := mockPHZARecords(m, endpoint, "api.test-cluster", &route53types.HostedZoneSummary{
 				HostedZoneId: aws.String("HZ12345"),
 				Name:         aws.String("api.test-cluster"),
 			}, knownENI)
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
-				HostedZone: &route53.HostedZone{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+				HostedZone: &route53types.HostedZone{
 					Id: aws.String(hzID),
 				},
-				VPCs: []*route53.VPC{{
+				VPCs: []route53types.VPC{{
 					VPCId:     endpoint.VpcId,
-					VPCRegion: aws.String("us-east-1"),
+					VPCRegion: route53types.VPCRegion("us-east-1"),
 				}},
 			}, nil)
 		},
@@ -1267,28 +1270,28 @@ users:
 			mockServicePerms(m, service)
 			endpoint := mockCreateEndpoint(m, service)
 
-			hzID := mockPHZ(m, endpoint, "api.test-cluster", &route53.HostedZoneSummary{
+			hzID := mockPHZ(m, endpoint, "api.test-cluster", &route53types.HostedZoneSummary{
 				HostedZoneId: aws.String("HZ12345"),
 				Name:         aws.String("api.test-cluster"),
 			})
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
-				HostedZone: &route53.HostedZone{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+				HostedZone: &route53types.HostedZone{
 					Id: aws.String(hzID),
 				},
-				VPCs: []*route53.VPC{{
+				VPCs: []route53types.VPC{{
 					VPCId:     endpoint.VpcId,
-					VPCRegion: aws.String("us-east-1"),
+					VPCRegion: route53types.VPCRegion("us-east-1"),
 				}},
 			}, nil)
 
-			m.EXPECT().AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+			m.EXPECT().AssociateVPCWithHostedZone(gomock.Any(), &route53.AssociateVPCWithHostedZoneInput{
 				HostedZoneId: aws.String("HZ12345"),
-				VPC: &route53.VPC{
+				VPC: &route53types.VPC{
 					VPCId:     aws.String("vpc-hive1"),
-					VPCRegion: aws.String("us-west-1"),
+					VPCRegion: route53types.VPCRegion("us-west-1"),
 				},
-			}).Return(nil, awserr.New("AccessDenied", "AssociateVPCWithHostedZone access denied", nil))
+			}).Return(nil, &smithy.GenericAPIError{Code: "AccessDenied", Message: "AssociateVPCWithHostedZone access denied"})
 		},
 
 		hasFinalizer: true,
@@ -1298,8 +1301,8 @@ users:
 			HostedZoneID:       "HZ12345",
 		},
 		expectedConditions: getExpectedConditions(true, "AssociatingVPCsToHostedZoneFailed",
-			"AccessDenied: AssociateVPCWithHostedZone access denied"),
-		err: "AccessDenied: AssociateVPCWithHostedZone access denied",
+			"api error AccessDenied: AssociateVPCWithHostedZone access denied"),
+		err: "api error AccessDenied: AssociateVPCWithHostedZone access denied",
 	}, {
 		name: "cd with privatelink enabled, no previous private link, associate vpcs",
 
@@ -1323,26 +1326,26 @@ users:
 			mockServicePerms(m, service)
 			endpoint := mockCreateEndpoint(m, service)
 
-			hzID := mockPHZ(m, endpoint, "api.test-cluster", &route53.HostedZoneSummary{
+			hzID := mockPHZ(m, endpoint, "api.test-cluster", &route53types.HostedZoneSummary{
 				HostedZoneId: aws.String("HZ12345"),
 				Name:         aws.String("api.test-cluster"),
 			})
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
-				HostedZone: &route53.HostedZone{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+				HostedZone: &route53types.HostedZone{
 					Id: aws.String(hzID),
 				},
-				VPCs: []*route53.VPC{{
+				VPCs: []route53types.VPC{{
 					VPCId:     endpoint.VpcId,
-					VPCRegion: aws.String("us-east-1"),
+					VPCRegion: route53types.VPCRegion("us-east-1"),
 				}},
 			}, nil)
 
-			m.EXPECT().AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+			m.EXPECT().AssociateVPCWithHostedZone(gomock.Any(), &route53.AssociateVPCWithHostedZoneInput{
 				HostedZoneId: aws.String("HZ12345"),
-				VPC: &route53.VPC{
+				VPC: &route53types.VPC{
 					VPCId:     aws.String("vpc-hive1"),
-					VPCRegion: aws.String("us-west-1"),
+					VPCRegion: route53types.VPCRegion("us-west-1"),
 				},
 			}).Return(nil, nil)
 		},
@@ -1378,36 +1381,36 @@ users:
 			mockServicePerms(m, service)
 			endpoint := mockCreateEndpoint(m, service)
 
-			hzID := mockPHZ(m, endpoint, "api.test-cluster", &route53.HostedZoneSummary{
+			hzID := mockPHZ(m, endpoint, "api.test-cluster", &route53types.HostedZoneSummary{
 				HostedZoneId: aws.String("HZ12345"),
 				Name:         aws.String("api.test-cluster"),
 			})
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
-				HostedZone: &route53.HostedZone{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+				HostedZone: &route53types.HostedZone{
 					Id: aws.String(hzID),
 				},
-				VPCs: []*route53.VPC{{
+				VPCs: []route53types.VPC{{
 					VPCId:     endpoint.VpcId,
-					VPCRegion: aws.String("us-east-1"),
+					VPCRegion: route53types.VPCRegion("us-east-1"),
 				}, {
 					VPCId:     aws.String("vpc-hive1-removed"),
-					VPCRegion: aws.String("us-east-1"),
+					VPCRegion: route53types.VPCRegion("us-east-1"),
 				}},
 			}, nil)
 
-			m.EXPECT().AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+			m.EXPECT().AssociateVPCWithHostedZone(gomock.Any(), &route53.AssociateVPCWithHostedZoneInput{
 				HostedZoneId: aws.String("HZ12345"),
-				VPC: &route53.VPC{
+				VPC: &route53types.VPC{
 					VPCId:     aws.String("vpc-hive1"),
-					VPCRegion: aws.String("us-west-1"),
+					VPCRegion: route53types.VPCRegion("us-west-1"),
 				},
 			}).Return(nil, nil)
-			m.EXPECT().DisassociateVPCFromHostedZone(&route53.DisassociateVPCFromHostedZoneInput{
+			m.EXPECT().DisassociateVPCFromHostedZone(gomock.Any(), &route53.DisassociateVPCFromHostedZoneInput{
 				HostedZoneId: aws.String("HZ12345"),
-				VPC: &route53.VPC{
+				VPC: &route53types.VPC{
 					VPCId:     aws.String("vpc-hive1-removed"),
-					VPCRegion: aws.String("us-east-1"),
+					VPCRegion: route53types.VPCRegion("us-east-1"),
 				},
 			}).Return(nil, nil)
 		},
@@ -1446,40 +1449,40 @@ users:
 			mockServicePerms(m, service)
 			endpoint := mockCreateEndpoint(m, service)
 
-			hzID := mockPHZ(m, endpoint, "api.test-cluster", &route53.HostedZoneSummary{
+			hzID := mockPHZ(m, endpoint, "api.test-cluster", &route53types.HostedZoneSummary{
 				HostedZoneId: aws.String("HZ12345"),
 				Name:         aws.String("api.test-cluster"),
 			})
 
-			m.EXPECT().GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
-				HostedZone: &route53.HostedZone{
+			m.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+				HostedZone: &route53types.HostedZone{
 					Id: aws.String(hzID),
 				},
-				VPCs: []*route53.VPC{{
+				VPCs: []route53types.VPC{{
 					VPCId:     endpoint.VpcId,
-					VPCRegion: aws.String("us-east-1"),
+					VPCRegion: route53types.VPCRegion("us-east-1"),
 				}},
 			}, nil)
 
-			m.EXPECT().CreateVPCAssociationAuthorization(&route53.CreateVPCAssociationAuthorizationInput{
+			m.EXPECT().CreateVPCAssociationAuthorization(gomock.Any(), &route53.CreateVPCAssociationAuthorizationInput{
 				HostedZoneId: aws.String("HZ12345"),
-				VPC: &route53.VPC{
+				VPC: &route53types.VPC{
 					VPCId:     aws.String("vpc-hive1"),
-					VPCRegion: aws.String("us-west-1"),
+					VPCRegion: route53types.VPCRegion("us-west-1"),
 				},
 			}).Return(nil, nil)
-			m.EXPECT().AssociateVPCWithHostedZone(&route53.AssociateVPCWithHostedZoneInput{
+			m.EXPECT().AssociateVPCWithHostedZone(gomock.Any(), &route53.AssociateVPCWithHostedZoneInput{
 				HostedZoneId: aws.String("HZ12345"),
-				VPC: &route53.VPC{
+				VPC: &route53types.VPC{
 					VPCId:     aws.String("vpc-hive1"),
-					VPCRegion: aws.String("us-west-1"),
+					VPCRegion: route53types.VPCRegion("us-west-1"),
 				},
 			}).Return(nil, nil)
-			m.EXPECT().DeleteVPCAssociationAuthorization(&route53.DeleteVPCAssociationAuthorizationInput{
+			m.EXPECT().DeleteVPCAssociationAuthorization(gomock.Any(), &route53.DeleteVPCAssociationAuthorizationInput{
 				HostedZoneId: aws.String("HZ12345"),
-				VPC: &route53.VPC{
+				VPC: &route53types.VPC{
 					VPCId:     aws.String("vpc-hive1"),
-					VPCRegion: aws.String("us-west-1"),
+					VPCRegion: route53types.VPCRegion("us-west-1"),
 				},
 			}).Return(nil, nil)
 		},
@@ -1521,58 +1524,58 @@ users:
 		},
 		inventory: validInventory,
 		configureAWSClient: func(m *mock.MockClient) {
-			rr := &route53.ResourceRecordSet{
-				Type: aws.String("A"),
+			rr := &route53types.ResourceRecordSet{
+				Type: route53types.RRTypeA,
 				Name: aws.String("api.test-cluster"),
-				AliasTarget: &route53.AliasTarget{
+				AliasTarget: &route53types.AliasTarget{
 					DNSName: aws.String("vpc.."),
 				},
 			}
-			m.EXPECT().ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+			m.EXPECT().ListResourceRecordSets(gomock.Any(), &route53.ListResourceRecordSetsInput{
 				HostedZoneId: aws.String("HZ12345"),
 			}).Return(&route53.ListResourceRecordSetsOutput{
-				ResourceRecordSets: []*route53.ResourceRecordSet{{
-					Type: aws.String("NS"),
+				ResourceRecordSets: []route53types.ResourceRecordSet{{
+					Type: route53types.RRTypeNs,
 				}, {
-					Type: aws.String("SOA"),
-				}, rr},
+					Type: route53types.RRTypeSoa,
+				}, *rr},
 			}, nil)
-			m.EXPECT().ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+			m.EXPECT().ChangeResourceRecordSets(gomock.Any(), &route53.ChangeResourceRecordSetsInput{
 				HostedZoneId: aws.String("HZ12345"),
-				ChangeBatch: &route53.ChangeBatch{
-					Changes: []*route53.Change{{
-						Action:            aws.String("DELETE"),
+				ChangeBatch: &route53types.ChangeBatch{
+					Changes: []route53types.Change{{
+						Action:            route53types.ChangeActionDelete,
 						ResourceRecordSet: rr,
 					}},
 				},
 			}).Return(nil, nil)
-			m.EXPECT().DeleteHostedZone(&route53.DeleteHostedZoneInput{
+			m.EXPECT().DeleteHostedZone(gomock.Any(), &route53.DeleteHostedZoneInput{
 				Id: aws.String("HZ12345"),
 			}).Return(nil, nil)
 
-			endpoint := &ec2.VpcEndpoint{
+			endpoint := &ec2types.VpcEndpoint{
 				VpcEndpointId: aws.String("vpce-12345"),
 				VpcId:         aws.String("vpc-1"),
 			}
-			m.EXPECT().DescribeVpcEndpoints(gomock.Any()).
+			m.EXPECT().DescribeVpcEndpoints(gomock.Any(), gomock.Any()).
 				Return(&ec2.DescribeVpcEndpointsOutput{
-					VpcEndpoints: []*ec2.VpcEndpoint{{
+					VpcEndpoints: []ec2types.VpcEndpoint{{
 						VpcEndpointId: endpoint.VpcEndpointId,
 						VpcId:         endpoint.VpcId,
 					}},
 				}, nil).Times(1)
-			m.EXPECT().DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{
-				VpcEndpointIds: aws.StringSlice([]string{*endpoint.VpcEndpointId}),
+			m.EXPECT().DeleteVpcEndpoints(gomock.Any(), &ec2.DeleteVpcEndpointsInput{
+				VpcEndpointIds: []string{*endpoint.VpcEndpointId},
 			}).Return(nil, nil)
 
-			m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any()).
+			m.EXPECT().DescribeVpcEndpointServiceConfigurations(gomock.Any(), gomock.Any()).
 				Return(&ec2.DescribeVpcEndpointServiceConfigurationsOutput{
-					ServiceConfigurations: []*ec2.ServiceConfiguration{{
+					ServiceConfigurations: []ec2types.ServiceConfiguration{{
 						ServiceId: aws.String("vpce-svc-12345"),
 					}},
 				}, nil)
-			m.EXPECT().DeleteVpcEndpointServiceConfigurations(&ec2.DeleteVpcEndpointServiceConfigurationsInput{
-				ServiceIds: aws.StringSlice([]string{"vpce-svc-12345"}),
+			m.EXPECT().DeleteVpcEndpointServiceConfigurations(gomock.Any(), &ec2.DeleteVpcEndpointServiceConfigurationsInput{
+				ServiceIds: []string{"vpce-svc-12345"},
 			}).Return(nil, nil)
 		},
 
@@ -1976,6 +1979,9 @@ func Test_filterErrorMessage(t *testing.T) {
 	}, {
 		err:  errors.New(`AccessDenied: User: arn:aws:iam::12345:user/test-user is not authorized to perform: route53:ChangeResourceRecordSets on resource: arn:aws:route53:::hostedzone/12345\n\tstatus code: 403, request id: 22bc2e2e-9381-485f-8a46-c7ce8aad2a4d`),
 		want: `AccessDenied: User: arn:aws:iam::12345:user/test-user is not authorized to perform: route53:ChangeResourceRecordSets on resource: arn:aws:route53:::hostedzone/12345\n\tstatus code: 403, request id: XXXX`,
+	}, {
+		err:  errors.New(`operation error Route 53: ChangeResourceRecordSets, https response error StatusCode: 403, RequestID: 22bc2e2e-9381-485f-8a46-c7ce8aad2a4d, api error AccessDenied: User: arn:aws:iam::12345:user/test-user is not authorized to perform: route53:ChangeResourceRecordSets on resource: arn:aws:route53:::hostedzone/12345`),
+		want: `operation error Route 53: ChangeResourceRecordSets, https response error StatusCode: 403, RequestID: XXXX, api error AccessDenied: User: arn:aws:iam::12345:user/test-user is not authorized to perform: route53:ChangeResourceRecordSets on resource: arn:aws:route53:::hostedzone/12345`,
 	}}
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
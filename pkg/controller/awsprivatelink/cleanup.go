@@ -6,9 +6,11 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/ec2"
-	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -141,8 +143,8 @@ func (r *ReconcileAWSPrivateLink) cleanupHostedZone(awsClient awsclient.Client,
 		}
 
 		idLog := logger.WithField("infraID", metadata.InfraID)
-		endpointResp, err := awsClient.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
-			Filters: []*ec2.Filter{ec2FilterForCluster(metadata)},
+		endpointResp, err := awsClient.DescribeVpcEndpoints(context.TODO(), &ec2.DescribeVpcEndpointsInput{
+			Filters: []ec2types.Filter{ec2FilterForCluster(metadata)},
 		})
 		if err != nil {
 			idLog.WithError(err).Error("error getting the VPC Endpoint")
@@ -164,7 +166,7 @@ func (r *ReconcileAWSPrivateLink) cleanupHostedZone(awsClient awsclient.Client,
 	}
 
 	hzLog := logger.WithField("hostedZoneID", hzID)
-	recordsResp, err := awsClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+	recordsResp, err := awsClient.ListResourceRecordSets(context.TODO(), &route53.ListResourceRecordSetsInput{
 		HostedZoneId: aws.String(hzID),
 	})
 	if awsErrCodeEquals(err, "NoSuchHostedZone") {
@@ -175,16 +177,16 @@ func (r *ReconcileAWSPrivateLink) cleanupHostedZone(awsClient awsclient.Client,
 		return err
 	}
 	for _, record := range recordsResp.ResourceRecordSets {
-		if *record.Type == "SOA" || *record.Type == "NS" {
+		if record.Type == route53types.RRTypeSoa || record.Type == route53types.RRTypeNs {
 			// can't delete SOA and NS types
 			continue
 		}
-		_, err := awsClient.ChangeResourceRecordSets(&route53.ChangeResourceRecordSetsInput{
+		_, err := awsClient.ChangeResourceRecordSets(context.TODO(), &route53.ChangeResourceRecordSetsInput{
 			HostedZoneId: aws.String(hzID),
-			ChangeBatch: &route53.ChangeBatch{
-				Changes: []*route53.Change{{
-					Action:            aws.String("DELETE"),
-					ResourceRecordSet: record,
+			ChangeBatch: &route53types.ChangeBatch{
+				Changes: []route53types.Change{{
+					Action:            route53types.ChangeActionDelete,
+					ResourceRecordSet: &record,
 				}},
 			},
 		})
@@ -194,7 +196,7 @@ func (r *ReconcileAWSPrivateLink) cleanupHostedZone(awsClient awsclient.Client,
 		}
 	}
 
-	_, err = awsClient.DeleteHostedZone(&route53.DeleteHostedZoneInput{
+	_, err = awsClient.DeleteHostedZone(context.TODO(), &route53.DeleteHostedZoneInput{
 		Id: aws.String(hzID),
 	})
 	if err != nil && !awsErrCodeEquals(err, "NoSuchHostedZone") {
@@ -210,8 +212,8 @@ func (r *ReconcileAWSPrivateLink) cleanupVPCEndpoint(awsClient awsclient.Client,
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
 	logger log.FieldLogger) error {
 	idLog := logger.WithField("infraID", metadata.InfraID)
-	resp, err := awsClient.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
-		Filters: []*ec2.Filter{ec2FilterForCluster(metadata)},
+	resp, err := awsClient.DescribeVpcEndpoints(context.TODO(), &ec2.DescribeVpcEndpointsInput{
+		Filters: []ec2types.Filter{ec2FilterForCluster(metadata)},
 	})
 	if err != nil {
 		idLog.WithError(err).Error("error getting the VPC Endpoint")
@@ -224,8 +226,8 @@ func (r *ReconcileAWSPrivateLink) cleanupVPCEndpoint(awsClient awsclient.Client,
 	vpcEndpoint := resp.VpcEndpoints[0]
 	endpointLog := logger.WithField("vpcEndpointID", *vpcEndpoint.VpcEndpointId)
 
-	_, err = awsClient.DeleteVpcEndpoints(&ec2.DeleteVpcEndpointsInput{
-		VpcEndpointIds: aws.StringSlice([]string{*vpcEndpoint.VpcEndpointId}),
+	_, err = awsClient.DeleteVpcEndpoints(context.TODO(), &ec2.DeleteVpcEndpointsInput{
+		VpcEndpointIds: []string{*vpcEndpoint.VpcEndpointId},
 	})
 	if err != nil && !awsErrCodeEquals(err, "InvalidVpcEndpointId.NotFound") {
 		endpointLog.WithError(err).Error("error deleting the VPC Endpoint")
@@ -239,8 +241,8 @@ func (r *ReconcileAWSPrivateLink) cleanupVPCEndpointService(awsClient awsclient.
 	cd *hivev1.ClusterDeployment, metadata *hivev1.ClusterMetadata,
 	logger log.FieldLogger) error {
 	idLog := logger.WithField("infraID", metadata.InfraID)
-	resp, err := awsClient.DescribeVpcEndpointServiceConfigurations(&ec2.DescribeVpcEndpointServiceConfigurationsInput{
-		Filters: []*ec2.Filter{ec2FilterForCluster(metadata)},
+	resp, err := awsClient.DescribeVpcEndpointServiceConfigurations(context.TODO(), &ec2.DescribeVpcEndpointServiceConfigurationsInput{
+		Filters: []ec2types.Filter{ec2FilterForCluster(metadata)},
 	})
 	if err != nil {
 		idLog.WithError(err).Error("error getting the VPC Endpoint Service")
@@ -253,8 +255,8 @@ func (r *ReconcileAWSPrivateLink) cleanupVPCEndpointService(awsClient awsclient.
 	service := resp.ServiceConfigurations[0]
 	serviceLog := logger.WithField("vpcEndpointServiceID", *service.ServiceId)
 
-	_, err = awsClient.DeleteVpcEndpointServiceConfigurations(&ec2.DeleteVpcEndpointServiceConfigurationsInput{
-		ServiceIds: aws.StringSlice([]string{*service.ServiceId}),
+	_, err = awsClient.DeleteVpcEndpointServiceConfigurations(context.TODO(), &ec2.DeleteVpcEndpointServiceConfigurationsInput{
+		ServiceIds: []string{*service.ServiceId},
 	})
 	if err != nil && !awsErrCodeEquals(err, "InvalidVpcEndpointService.NotFound") {
 		serviceLog.WithError(err).Error("error deleting the VPC Endpoint Service")
@@ -1,13 +1,15 @@
 package awsprivatelink
 
 import (
+	"context"
 	"strings"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/openshift/hive/pkg/awsclient"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -31,8 +33,8 @@ func (r *ReconcileAWSPrivateLink) chooseVPCForVPCEndpoint(awsClient awsclient.Cl
 	}
 
 	// Figure out the AZs supported by the service.
-	servicesResp, err := awsClient.DescribeVpcEndpointServices(&ec2.DescribeVpcEndpointServicesInput{
-		ServiceNames: aws.StringSlice([]string{vpcEndpointServiceName}),
+	servicesResp, err := awsClient.DescribeVpcEndpointServices(context.TODO(), &ec2.DescribeVpcEndpointServicesInput{
+		ServiceNames: []string{vpcEndpointServiceName},
 	})
 	if err != nil {
 		serviceLog.WithError(err).Error("error getting VPC Endpoint Service in hub account")
@@ -40,7 +42,7 @@ func (r *ReconcileAWSPrivateLink) chooseVPCForVPCEndpoint(awsClient awsclient.Cl
 	}
 
 	// Filter candidates that don't have at least one subnet in supported AZs.
-	supportedAZSet := sets.NewString(aws.StringValueSlice(servicesResp.ServiceDetails[0].AvailabilityZones)...)
+	supportedAZSet := sets.NewString(servicesResp.ServiceDetails[0].AvailabilityZones...)
 	candidates = filterVPCInventory(candidates, toSupportedSubnets(supportedAZSet))
 	if len(candidates) == 0 {
 		logger.WithField("region", cd.Spec.Platform.AWS.Region).
@@ -56,15 +58,15 @@ func (r *ReconcileAWSPrivateLink) chooseVPCForVPCEndpoint(awsClient awsclient.Cl
 		vpcs = append(vpcs, cand.VPCID)
 		endpointsPerVPC[cand.VPCID] = 0
 	}
-	endpointsResp, err := awsClient.DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
-		Filters: []*ec2.Filter{{Name: aws.String("vpc-id"), Values: aws.StringSlice(vpcs)}},
+	endpointsResp, err := awsClient.DescribeVpcEndpoints(context.TODO(), &ec2.DescribeVpcEndpointsInput{
+		Filters: []ec2types.Filter{{Name: aws.String("vpc-id"), Values: vpcs}},
 	})
 	if err != nil {
 		logger.WithField("vpcs", vpcs).WithError(err).Error("error getting VPC Endpoints in the selected VPCs")
 		return nil, err
 	}
 	for _, vEnd := range endpointsResp.VpcEndpoints {
-		vpcID := aws.StringValue(vEnd.VpcId)
+		vpcID := aws.ToString(vEnd.VpcId)
 		endpointsPerVPC[vpcID] = endpointsPerVPC[vpcID] + 1
 	}
 
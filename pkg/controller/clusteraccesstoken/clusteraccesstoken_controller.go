@@ -0,0 +1,485 @@
+// Package clusteraccesstoken provides a controller which issues time-limited kubeconfigs for clusters, bound to a
+// ClusterRole in the cluster, as requested by ClusterAccessTokens.
+package clusteraccesstoken
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sigs.k8s.io/yaml"
+
+	apihelpers "github.com/openshift/hive/apis/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	ControllerName = hivev1.ClusterAccessTokenControllerName
+	finalizer      = "hive.openshift.io/clusteraccesstoken"
+
+	// serviceAccountNamespace is the namespace in the remote cluster of the service accounts for ClusterAccessTokens.
+	serviceAccountNamespace = "kube-system"
+
+	defaultLifetime = time.Hour
+	// minTokenExpiration is the shortest expiration the API server accepts in a token request. Tokens for shorter
+	// lifetimes are still revoked when the lifetime elapses, by deleting their service account.
+	minTokenExpiration = 10 * time.Minute
+)
+
+// Add creates a new ClusterAccessToken Controller and adds it to the Manager with default RBAC. The Manager will set
+// fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterAccessToken {
+	r := &ReconcileClusterAccessToken{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme: mgr.GetScheme(),
+	}
+	r.remoteClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileClusterAccessToken, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterAccessTokens
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterAccessToken{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments, so that tokens are issued and revoked once clusters are reachable
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, handler.EnqueueRequestsFromMapFunc(r.requestsForClusterDeployment)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReconcileClusterAccessToken) requestsForClusterDeployment(o client.Object) []reconcile.Request {
+	tokens := &hivev1.ClusterAccessTokenList{}
+	if err := r.List(context.TODO(), tokens, client.InNamespace(o.GetNamespace())); err != nil {
+		log.WithField("controller", ControllerName).WithError(err).Log(controllerutils.LogLevel(err), "could not list cluster access tokens")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, token := range tokens.Items {
+		if token.Spec.ClusterDeploymentRef.Name == o.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: token.Namespace, Name: token.Name}})
+		}
+	}
+	return requests
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterAccessToken{}
+
+// ReconcileClusterAccessToken reconciles a ClusterAccessToken object
+type ReconcileClusterAccessToken struct {
+	client.Client
+	scheme *runtime.Scheme
+
+	// remoteClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+}
+
+// Reconcile issues the token of a ClusterAccessToken, and deletes the ClusterAccessToken once its lifetime has
+// elapsed. The access is revoked in the cluster when the ClusterAccessToken is deleted.
+func (r *ReconcileClusterAccessToken) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterAccessToken", request.NamespacedName)
+	logger.Info("reconciling cluster access token")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	token := &hivev1.ClusterAccessToken{}
+	switch err := r.Get(ctx, request.NamespacedName, token); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("cluster access token not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Error("error getting cluster access token")
+		return reconcile.Result{}, err
+	}
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(ctx, types.NamespacedName{Namespace: token.Namespace, Name: token.Spec.ClusterDeploymentRef.Name}, cd); {
+	case apierrors.IsNotFound(err):
+		cd = nil
+	case err != nil:
+		logger.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	if token.DeletionTimestamp != nil {
+		return r.reconcileDeletedToken(token, cd, logger)
+	}
+
+	if !controllerutils.HasFinalizer(token, finalizer) {
+		logger.Debug("adding finalizer to cluster access token")
+		controllerutils.AddFinalizer(token, finalizer)
+		if err := r.Update(ctx, token); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error adding finalizer to cluster access token")
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Delete the token once its lifetime elapses
+	if expiration := token.Status.ExpirationTimestamp; expiration != nil {
+		if remaining := time.Until(expiration.Time); remaining > 0 {
+			logger.WithField("expiration", expiration.Time).Debug("token has been issued")
+			return reconcile.Result{RequeueAfter: remaining}, nil
+		}
+		logger.Info("deleting cluster access token because its lifetime has elapsed")
+		if err := r.Delete(ctx, token); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete cluster access token")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if role := token.Spec.ClusterRoleName; !controllerutils.ClusterAccessTokenAllowedClusterRoles().Has(role) {
+		// The webhook rejects such tokens, but tokens created before the role was disallowed are not issued either.
+		logger.WithField("clusterRole", role).Warn("not issuing token for a cluster role which is not allowed in HiveConfig")
+		return reconcile.Result{}, nil
+	}
+
+	switch {
+	case cd == nil:
+		logger.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	case cd.DeletionTimestamp != nil:
+		logger.Debug("cluster deployment is being deleted")
+		return reconcile.Result{}, nil
+	case !cd.Spec.Installed:
+		logger.Debug("cluster installation is not complete")
+		return reconcile.Result{}, nil
+	}
+
+	remoteBuilder := r.remoteClientBuilder(cd)
+	remoteClient, unreachable, requeue := remoteclient.ConnectToRemoteCluster(cd, remoteBuilder, r.Client, logger)
+	if unreachable {
+		return reconcile.Result{Requeue: requeue}, nil
+	}
+	switch fence, err := controllerutils.GetRemoteRelocationFence(remoteClient); {
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get relocation fence")
+		return reconcile.Result{}, err
+	case controllerutils.IsFencedOut(cd, fence, logger):
+		// Tokens are issued by the hub which owns the cluster.
+		return reconcile.Result{}, nil
+	}
+	if err := r.issueToken(token, cd, remoteBuilder, remoteClient, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not issue token")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: time.Until(token.Status.ExpirationTimestamp.Time)}, nil
+}
+
+// issueToken creates the service account and role binding of the token in the remote cluster, requests a token for
+// the service account, and stores a kubeconfig using the token in a secret.
+func (r *ReconcileClusterAccessToken) issueToken(token *hivev1.ClusterAccessToken, cd *hivev1.ClusterDeployment, remoteBuilder remoteclient.Builder, remoteClient client.Client, logger log.FieldLogger) error {
+	name := remoteResourceName(token)
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Namespace: serviceAccountNamespace, Name: name},
+	}
+	if err := createIfNotExists(remoteClient, sa); err != nil {
+		return errors.Wrap(err, "could not create service account")
+	}
+	binding := roleBinding(token)
+	if ref, recorded := roleBindingReference(binding), token.Status.RoleBindingRef; recorded == nil || *recorded != *ref {
+		// The binding is recorded before it is created, so that it is revoked even if the spec changes afterwards.
+		if recorded != nil {
+			// The spec changed before the token was issued, so the binding created for the previous spec is revoked.
+			if err := remoteClient.Delete(context.TODO(), roleBindingObject(recorded)); err != nil && !apierrors.IsNotFound(err) {
+				return errors.Wrap(err, "could not delete previous role binding")
+			}
+		}
+		token.Status.RoleBindingRef = ref
+		if err := r.Status().Update(context.TODO(), token); err != nil {
+			return errors.Wrap(err, "could not record role binding")
+		}
+	}
+	if err := ensureRoleBinding(remoteClient, binding); err != nil {
+		return errors.Wrap(err, "could not create role binding")
+	}
+
+	lifetime := defaultLifetime
+	if token.Spec.Lifetime != nil {
+		lifetime = token.Spec.Lifetime.Duration
+	}
+	expirationSeconds := int64(lifetime.Seconds())
+	if lifetime < minTokenExpiration {
+		expirationSeconds = int64(minTokenExpiration.Seconds())
+	}
+	kubeClient, err := remoteBuilder.BuildKubeClient()
+	if err != nil {
+		return errors.Wrap(err, "could not build kube client")
+	}
+	tokenRequest, err := kubeClient.CoreV1().ServiceAccounts(serviceAccountNamespace).CreateToken(
+		context.TODO(),
+		name,
+		&authenticationv1.TokenRequest{Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &expirationSeconds}},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return errors.Wrap(err, "could not request token")
+	}
+	issued := metav1.Now()
+
+	restConfig, err := remoteBuilder.RESTConfig()
+	if err != nil {
+		return errors.Wrap(err, "could not get REST config")
+	}
+	// The kubeconfig carries only the token, and none of the credentials of the admin kubeconfig.
+	kubeconfig, err := yaml.Marshal(&clientcmdv1.Config{
+		Kind:       "Config",
+		APIVersion: "v1",
+		Clusters: []clientcmdv1.NamedCluster{{
+			Name: cd.Name,
+			Cluster: clientcmdv1.Cluster{
+				Server:                   restConfig.Host,
+				InsecureSkipTLSVerify:    restConfig.Insecure,
+				CertificateAuthorityData: restConfig.CAData,
+			},
+		}},
+		AuthInfos: []clientcmdv1.NamedAuthInfo{{
+			Name:     name,
+			AuthInfo: clientcmdv1.AuthInfo{Token: tokenRequest.Status.Token},
+		}},
+		Contexts: []clientcmdv1.NamedContext{{
+			Name:    cd.Name,
+			Context: clientcmdv1.Context{Cluster: cd.Name, AuthInfo: name},
+		}},
+		CurrentContext: cd.Name,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not write kubeconfig")
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: token.Namespace,
+			Name:      kubeconfigSecretName(token),
+			Labels:    k8slabels.AddLabel(nil, constants.ClusterDeploymentNameLabel, cd.Name),
+		},
+		Data: map[string][]byte{constants.KubeconfigSecretKey: kubeconfig},
+	}
+	if err := controllerutil.SetControllerReference(token, secret, r.scheme); err != nil {
+		return errors.Wrap(err, "could not set owner reference")
+	}
+	// A token issued before the status could be updated is replaced.
+	if err := r.Delete(context.TODO(), secret); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "could not delete existing kubeconfig secret")
+	}
+	if err := r.Create(context.TODO(), secret); err != nil {
+		return errors.Wrap(err, "could not create kubeconfig secret")
+	}
+
+	token.Status.KubeconfigSecretRef = &corev1.LocalObjectReference{Name: secret.Name}
+	token.Status.ExpirationTimestamp = &metav1.Time{Time: issued.Add(lifetime)}
+	if err := r.Status().Update(context.TODO(), token); err != nil {
+		return errors.Wrap(err, "could not update status")
+	}
+	logger.WithField("expiration", token.Status.ExpirationTimestamp.Time).Info("issued token")
+	return nil
+}
+
+// reconcileDeletedToken revokes the access of the token by deleting its role binding and service account in the
+// remote cluster, and removes the finalizer of the ClusterAccessToken.
+func (r *ReconcileClusterAccessToken) reconcileDeletedToken(token *hivev1.ClusterAccessToken, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+	if !controllerutils.HasFinalizer(token, finalizer) {
+		return reconcile.Result{}, nil
+	}
+	switch {
+	case cd == nil || cd.DeletionTimestamp != nil:
+		logger.Debug("cluster deployment is gone, no access to revoke")
+	case !cd.Spec.Installed:
+		logger.Debug("cluster installation is not complete, no access to revoke")
+	default:
+		// The removal of the finalizer waits for the cluster to be reachable, so that the service account of the
+		// token does not outlive it.
+		remoteClient, unreachable, requeue := remoteclient.ConnectToRemoteCluster(cd, r.remoteClientBuilder(cd), r.Client, logger)
+		if unreachable {
+			return reconcile.Result{Requeue: requeue}, nil
+		}
+		switch fence, err := controllerutils.GetRemoteRelocationFence(remoteClient); {
+		case err != nil:
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get relocation fence")
+			return reconcile.Result{}, err
+		case controllerutils.IsFencedOut(cd, fence, logger):
+			// The remote resources of the token are left to the hub which owns the cluster.
+			return r.removeFinalizer(token, logger)
+		}
+		name := remoteResourceName(token)
+		ref := token.Status.RoleBindingRef
+		if ref == nil {
+			ref = roleBindingReference(roleBinding(token))
+		}
+		for _, obj := range []client.Object{
+			roleBindingObject(ref),
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: serviceAccountNamespace, Name: name}},
+		} {
+			if err := remoteClient.Delete(context.TODO(), obj); err != nil && !apierrors.IsNotFound(err) {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "could not revoke access")
+				return reconcile.Result{}, err
+			}
+		}
+		logger.Info("revoked access")
+	}
+	return r.removeFinalizer(token, logger)
+}
+
+func (r *ReconcileClusterAccessToken) removeFinalizer(token *hivev1.ClusterAccessToken, logger log.FieldLogger) (reconcile.Result, error) {
+	controllerutils.DeleteFinalizer(token, finalizer)
+	if err := r.Update(context.TODO(), token); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not remove finalizer from cluster access token")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// roleBinding returns the binding of the ClusterRole of the token to its service account: a RoleBinding when the
+// access is restricted to a namespace, or a ClusterRoleBinding.
+func roleBinding(token *hivev1.ClusterAccessToken) client.Object {
+	name := remoteResourceName(token)
+	subjects := []rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Namespace: serviceAccountNamespace,
+		Name:      name,
+	}}
+	roleRef := rbacv1.RoleRef{
+		APIGroup: rbacv1.GroupName,
+		Kind:     "ClusterRole",
+		Name:     token.Spec.ClusterRoleName,
+	}
+	if token.Spec.Namespace != "" {
+		return &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: token.Spec.Namespace, Name: name},
+			Subjects:   subjects,
+			RoleRef:    roleRef,
+		}
+	}
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Subjects:   subjects,
+		RoleRef:    roleRef,
+	}
+}
+
+// roleBindingReference returns the reference recorded in the status of a token to its role binding.
+func roleBindingReference(binding client.Object) *hivev1.ClusterAccessTokenRoleBindingReference {
+	kind := "ClusterRoleBinding"
+	if _, ok := binding.(*rbacv1.RoleBinding); ok {
+		kind = "RoleBinding"
+	}
+	return &hivev1.ClusterAccessTokenRoleBindingReference{
+		Kind:      kind,
+		Namespace: binding.GetNamespace(),
+		Name:      binding.GetName(),
+	}
+}
+
+// roleBindingObject returns an empty object for the referenced role binding, with which it can be deleted.
+func roleBindingObject(ref *hivev1.ClusterAccessTokenRoleBindingReference) client.Object {
+	meta := metav1.ObjectMeta{Namespace: ref.Namespace, Name: ref.Name}
+	if ref.Kind == "RoleBinding" {
+		return &rbacv1.RoleBinding{ObjectMeta: meta}
+	}
+	return &rbacv1.ClusterRoleBinding{ObjectMeta: meta}
+}
+
+// ensureRoleBinding creates the role binding, replacing an existing binding of the same name which binds another role
+// or other subjects. The role of a binding cannot be changed in place.
+func ensureRoleBinding(c client.Client, binding client.Object) error {
+	existing := binding.DeepCopyObject().(client.Object)
+	switch err := c.Get(context.TODO(), client.ObjectKeyFromObject(binding), existing); {
+	case apierrors.IsNotFound(err):
+		return c.Create(context.TODO(), binding)
+	case err != nil:
+		return err
+	}
+	existingSubjects, existingRoleRef := roleBindingGrant(existing)
+	subjects, roleRef := roleBindingGrant(binding)
+	if existingRoleRef == roleRef && reflect.DeepEqual(existingSubjects, subjects) {
+		return nil
+	}
+	if err := c.Delete(context.TODO(), existing); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return c.Create(context.TODO(), binding)
+}
+
+func roleBindingGrant(binding client.Object) ([]rbacv1.Subject, rbacv1.RoleRef) {
+	switch b := binding.(type) {
+	case *rbacv1.RoleBinding:
+		return b.Subjects, b.RoleRef
+	case *rbacv1.ClusterRoleBinding:
+		return b.Subjects, b.RoleRef
+	default:
+		return nil, rbacv1.RoleRef{}
+	}
+}
+
+func createIfNotExists(c client.Client, obj client.Object) error {
+	if err := c.Create(context.TODO(), obj); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// remoteResourceName returns the name of the service account and role binding of the token in the remote cluster.
+func remoteResourceName(token *hivev1.ClusterAccessToken) string {
+	return apihelpers.GetResourceName("hive-access", token.Name)
+}
+
+func kubeconfigSecretName(token *hivev1.ClusterAccessToken) string {
+	return apihelpers.GetResourceName(token.Name, constants.KubeconfigSecretKey)
+}
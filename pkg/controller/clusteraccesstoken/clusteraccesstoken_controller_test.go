@@ -0,0 +1,349 @@
+package clusteraccesstoken
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+const (
+	namespace   = "test-namespace"
+	cdName      = "test-cluster-deployment"
+	tokenName   = "test-token"
+	clusterRole = "view"
+	apiURL      = "https://api.test-cluster:6443"
+	tokenValue  = "test-token-value"
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	rbacv1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionFalse,
+		}),
+	)
+	buildToken := func(opts ...func(*hivev1.ClusterAccessToken)) *hivev1.ClusterAccessToken {
+		token := &hivev1.ClusterAccessToken{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: tokenName},
+			Spec: hivev1.ClusterAccessTokenSpec{
+				ClusterDeploymentRef: corev1.LocalObjectReference{Name: cdName},
+				ClusterRoleName:      clusterRole,
+			},
+		}
+		for _, opt := range opts {
+			opt(token)
+		}
+		return token
+	}
+	issued := func(expiration time.Time) func(*hivev1.ClusterAccessToken) {
+		return func(token *hivev1.ClusterAccessToken) {
+			token.Finalizers = []string{finalizer}
+			token.Status.KubeconfigSecretRef = &corev1.LocalObjectReference{Name: kubeconfigSecretName(token)}
+			token.Status.ExpirationTimestamp = &metav1.Time{Time: expiration}
+		}
+	}
+	deleted := func(token *hivev1.ClusterAccessToken) {
+		now := metav1.Now()
+		token.DeletionTimestamp = &now
+	}
+	saName := remoteResourceName(buildToken())
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: serviceAccountNamespace, Name: saName}}
+	crb := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: saName}}
+	ciRoleBinding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "ci", Name: saName}}
+	fence := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        controllerutils.RelocationFenceNamespace,
+			Annotations: map[string]string{constants.RelocationFencingTokenAnnotation: "other-hub"},
+		},
+	}
+	recordedCIRoleBinding := func(token *hivev1.ClusterAccessToken) {
+		token.Status.RoleBindingRef = &hivev1.ClusterAccessTokenRoleBindingReference{Kind: "RoleBinding", Namespace: "ci", Name: saName}
+	}
+
+	tests := []struct {
+		name               string
+		cd                 *hivev1.ClusterDeployment
+		token              *hivev1.ClusterAccessToken
+		remoteObjects      []runtime.Object
+		expectIssue        bool
+		expectExpiration   int64
+		expectConnect      bool
+		expectRequeueAfter time.Duration
+		expectTokenDeleted bool
+		validate           func(t *testing.T, token *hivev1.ClusterAccessToken, remoteClient client.Client)
+	}{
+		{
+			name:  "not installed",
+			cd:    cdBuilder.Build(func(cd *hivev1.ClusterDeployment) { cd.Spec.Installed = false }),
+			token: buildToken(),
+			validate: func(t *testing.T, token *hivev1.ClusterAccessToken, remoteClient client.Client) {
+				assert.Contains(t, token.Finalizers, finalizer, "expected finalizer")
+				assert.Nil(t, token.Status.ExpirationTimestamp, "unexpected expiration")
+			},
+		},
+		{
+			name:               "issue cluster-wide token",
+			cd:                 cdBuilder.Build(),
+			token:              buildToken(),
+			expectIssue:        true,
+			expectExpiration:   3600,
+			expectConnect:      true,
+			expectRequeueAfter: time.Hour,
+			validate: func(t *testing.T, token *hivev1.ClusterAccessToken, remoteClient client.Client) {
+				binding := &rbacv1.ClusterRoleBinding{}
+				require.NoError(t, remoteClient.Get(context.TODO(), types.NamespacedName{Name: saName}, binding), "expected cluster role binding")
+				assert.Equal(t, clusterRole, binding.RoleRef.Name, "unexpected role")
+				require.Len(t, binding.Subjects, 1, "unexpected subjects")
+				assert.Equal(t, saName, binding.Subjects[0].Name, "unexpected subject")
+				assert.Equal(t, &hivev1.ClusterAccessTokenRoleBindingReference{Kind: "ClusterRoleBinding", Name: saName},
+					token.Status.RoleBindingRef, "unexpected recorded role binding")
+			},
+		},
+		{
+			name:  "replace binding of another role",
+			cd:    cdBuilder.Build(),
+			token: buildToken(),
+			remoteObjects: []runtime.Object{&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: saName},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+			}},
+			expectIssue:        true,
+			expectExpiration:   3600,
+			expectConnect:      true,
+			expectRequeueAfter: time.Hour,
+			validate: func(t *testing.T, token *hivev1.ClusterAccessToken, remoteClient client.Client) {
+				binding := &rbacv1.ClusterRoleBinding{}
+				require.NoError(t, remoteClient.Get(context.TODO(), types.NamespacedName{Name: saName}, binding), "expected cluster role binding")
+				assert.Equal(t, clusterRole, binding.RoleRef.Name, "unexpected role")
+			},
+		},
+		{
+			name:               "spec changed before issue",
+			cd:                 cdBuilder.Build(),
+			token:              buildToken(recordedCIRoleBinding),
+			remoteObjects:      []runtime.Object{ciRoleBinding},
+			expectIssue:        true,
+			expectExpiration:   3600,
+			expectConnect:      true,
+			expectRequeueAfter: time.Hour,
+			validate: func(t *testing.T, token *hivev1.ClusterAccessToken, remoteClient client.Client) {
+				err := remoteClient.Get(context.TODO(), client.ObjectKeyFromObject(ciRoleBinding), &rbacv1.RoleBinding{})
+				assert.True(t, apierrors.IsNotFound(err), "expected role binding of previous spec to be deleted")
+				require.NoError(t, remoteClient.Get(context.TODO(), types.NamespacedName{Name: saName}, &rbacv1.ClusterRoleBinding{}), "expected cluster role binding")
+				assert.Equal(t, "ClusterRoleBinding", token.Status.RoleBindingRef.Kind, "unexpected recorded role binding")
+			},
+		},
+		{
+			name: "issue namespaced token",
+			cd:   cdBuilder.Build(),
+			token: buildToken(func(token *hivev1.ClusterAccessToken) {
+				token.Spec.Namespace = "ci"
+				token.Spec.Lifetime = &metav1.Duration{Duration: 5 * time.Minute}
+			}),
+			expectIssue:        true,
+			expectExpiration:   600,
+			expectConnect:      true,
+			expectRequeueAfter: 5 * time.Minute,
+			validate: func(t *testing.T, token *hivev1.ClusterAccessToken, remoteClient client.Client) {
+				binding := &rbacv1.RoleBinding{}
+				require.NoError(t, remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: "ci", Name: saName}, binding), "expected role binding")
+				assert.Equal(t, "ClusterRole", binding.RoleRef.Kind, "unexpected role kind")
+				assert.Equal(t, clusterRole, binding.RoleRef.Name, "unexpected role")
+			},
+		},
+		{
+			name:               "issued",
+			cd:                 cdBuilder.Build(),
+			token:              buildToken(issued(time.Now().Add(30 * time.Minute))),
+			expectRequeueAfter: 30 * time.Minute,
+		},
+		{
+			name:  "expired",
+			cd:    cdBuilder.Build(),
+			token: buildToken(issued(time.Now().Add(-time.Minute))),
+			validate: func(t *testing.T, token *hivev1.ClusterAccessToken, remoteClient client.Client) {
+				assert.NotNil(t, token.DeletionTimestamp, "expected cluster access token to be deleted")
+			},
+		},
+		{
+			name:               "revoke",
+			cd:                 cdBuilder.Build(),
+			token:              buildToken(issued(time.Now()), deleted),
+			remoteObjects:      []runtime.Object{sa, crb},
+			expectConnect:      true,
+			expectTokenDeleted: true,
+			validate: func(t *testing.T, token *hivev1.ClusterAccessToken, remoteClient client.Client) {
+				err := remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: serviceAccountNamespace, Name: saName}, &corev1.ServiceAccount{})
+				assert.True(t, apierrors.IsNotFound(err), "expected service account to be deleted")
+				err = remoteClient.Get(context.TODO(), types.NamespacedName{Name: saName}, &rbacv1.ClusterRoleBinding{})
+				assert.True(t, apierrors.IsNotFound(err), "expected cluster role binding to be deleted")
+			},
+		},
+		{
+			name:  "cluster role not allowed",
+			cd:    cdBuilder.Build(),
+			token: buildToken(func(token *hivev1.ClusterAccessToken) { token.Spec.ClusterRoleName = "cluster-admin" }),
+			validate: func(t *testing.T, token *hivev1.ClusterAccessToken, remoteClient client.Client) {
+				assert.Nil(t, token.Status.ExpirationTimestamp, "unexpected expiration")
+				assert.Nil(t, token.Status.RoleBindingRef, "unexpected recorded role binding")
+			},
+		},
+		{
+			name:          "fenced out",
+			cd:            cdBuilder.Build(),
+			token:         buildToken(),
+			remoteObjects: []runtime.Object{fence},
+			expectConnect: true,
+			validate: func(t *testing.T, token *hivev1.ClusterAccessToken, remoteClient client.Client) {
+				assert.Nil(t, token.Status.ExpirationTimestamp, "unexpected expiration")
+				err := remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: serviceAccountNamespace, Name: saName}, &corev1.ServiceAccount{})
+				assert.True(t, apierrors.IsNotFound(err), "expected no service account")
+			},
+		},
+		{
+			name:               "revoke fenced out",
+			cd:                 cdBuilder.Build(),
+			token:              buildToken(issued(time.Now()), deleted),
+			remoteObjects:      []runtime.Object{fence, sa, crb},
+			expectConnect:      true,
+			expectTokenDeleted: true,
+			validate: func(t *testing.T, token *hivev1.ClusterAccessToken, remoteClient client.Client) {
+				require.NoError(t, remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: serviceAccountNamespace, Name: saName}, &corev1.ServiceAccount{}), "expected service account to be kept")
+				require.NoError(t, remoteClient.Get(context.TODO(), types.NamespacedName{Name: saName}, &rbacv1.ClusterRoleBinding{}), "expected cluster role binding to be kept")
+			},
+		},
+		{
+			name:               "revoke recorded binding after spec change",
+			cd:                 cdBuilder.Build(),
+			token:              buildToken(issued(time.Now()), recordedCIRoleBinding, deleted),
+			remoteObjects:      []runtime.Object{sa, ciRoleBinding},
+			expectConnect:      true,
+			expectTokenDeleted: true,
+			validate: func(t *testing.T, token *hivev1.ClusterAccessToken, remoteClient client.Client) {
+				err := remoteClient.Get(context.TODO(), client.ObjectKeyFromObject(ciRoleBinding), &rbacv1.RoleBinding{})
+				assert.True(t, apierrors.IsNotFound(err), "expected recorded role binding to be deleted")
+			},
+		},
+		{
+			name:  "revoke while hibernating",
+			cd:    cdBuilder.Build(testcd.WithStatusPowerState(hivev1.HibernatingHibernationReason)),
+			token: buildToken(issued(time.Now()), deleted),
+			validate: func(t *testing.T, token *hivev1.ClusterAccessToken, remoteClient client.Client) {
+				assert.Contains(t, token.Finalizers, finalizer, "expected finalizer to be kept")
+			},
+		},
+		{
+			name:               "cluster deployment deleted",
+			token:              buildToken(issued(time.Now()), deleted),
+			expectTokenDeleted: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			remoteClient := fake.NewFakeClientWithScheme(scheme, test.remoteObjects...)
+			mockBuilder := remoteclientmock.NewMockBuilder(ctrl)
+			if test.expectConnect {
+				mockBuilder.EXPECT().Build().Return(remoteClient, nil)
+			}
+			var expirationSeconds int64
+			if test.expectIssue {
+				kubeClient := fakekubeclient.NewSimpleClientset()
+				kubeClient.PrependReactor("create", "serviceaccounts", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					tokenRequest := action.(clienttesting.CreateAction).GetObject().(*authenticationv1.TokenRequest)
+					expirationSeconds = *tokenRequest.Spec.ExpirationSeconds
+					tokenRequest.Status.Token = tokenValue
+					return true, tokenRequest, nil
+				})
+				mockBuilder.EXPECT().BuildKubeClient().Return(kubeClient, nil)
+				mockBuilder.EXPECT().RESTConfig().Return(&rest.Config{
+					Host:            apiURL,
+					TLSClientConfig: rest.TLSClientConfig{CAData: []byte("test-ca")},
+				}, nil)
+			}
+
+			objects := []runtime.Object{test.token}
+			if test.cd != nil {
+				objects = append(objects, test.cd)
+			}
+			c := fake.NewFakeClientWithScheme(scheme, objects...)
+			reconciler := &ReconcileClusterAccessToken{
+				Client: c,
+				scheme: scheme,
+				remoteClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					return mockBuilder
+				},
+			}
+			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: tokenName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+			assert.InDelta(t, test.expectRequeueAfter.Seconds(), result.RequeueAfter.Seconds(), 10, "unexpected requeue after")
+
+			token := &hivev1.ClusterAccessToken{}
+			err = c.Get(context.TODO(), client.ObjectKeyFromObject(test.token), token)
+			if test.expectTokenDeleted {
+				// The fake client deletes the ClusterAccessToken once its finalizer is removed.
+				assert.True(t, apierrors.IsNotFound(err), "expected cluster access token to be deleted")
+			} else {
+				require.NoError(t, err, "unexpected error getting cluster access token")
+			}
+
+			if test.expectIssue {
+				assert.Equal(t, test.expectExpiration, expirationSeconds, "unexpected token expiration")
+				require.NotNil(t, token.Status.ExpirationTimestamp, "expected expiration")
+				require.NotNil(t, token.Status.KubeconfigSecretRef, "expected kubeconfig secret")
+				require.NoError(t, remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: serviceAccountNamespace, Name: saName}, &corev1.ServiceAccount{}), "expected service account")
+
+				secret := &corev1.Secret{}
+				require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: token.Status.KubeconfigSecretRef.Name}, secret), "expected kubeconfig secret")
+				cfg, err := clientcmd.Load(secret.Data[constants.KubeconfigSecretKey])
+				require.NoError(t, err, "could not load kubeconfig")
+				require.Contains(t, cfg.Clusters, cdName, "expected cluster in kubeconfig")
+				assert.Equal(t, apiURL, cfg.Clusters[cdName].Server, "unexpected server")
+				assert.Equal(t, []byte("test-ca"), cfg.Clusters[cdName].CertificateAuthorityData, "unexpected CA")
+				require.Contains(t, cfg.AuthInfos, saName, "expected user in kubeconfig")
+				assert.Equal(t, tokenValue, cfg.AuthInfos[saName].Token, "unexpected token")
+				assert.Equal(t, cdName, cfg.CurrentContext, "unexpected current context")
+			}
+			if test.validate != nil {
+				test.validate(t, token, remoteClient)
+			}
+		})
+	}
+}
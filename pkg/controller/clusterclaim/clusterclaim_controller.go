@@ -66,7 +66,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *Re
 func AddToManager(mgr manager.Manager, r *ReconcileClusterClaim, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("clusterclaim-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -181,6 +181,7 @@ func (r *ReconcileClusterClaim) Reconcile(ctx context.Context, request reconcile
 	newConditions := controllerutils.InitializeClusterClaimConditions(claim.Status.Conditions, clusterClaimConditions)
 	if len(newConditions) > len(claim.Status.Conditions) {
 		claim.Status.Conditions = newConditions
+		setReadyCondition(claim)
 		logger.Infof("initializing cluster claim conditions")
 		if err := r.Status().Update(context.TODO(), claim); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster claim status")
@@ -221,6 +222,7 @@ func (r *ReconcileClusterClaim) Reconcile(ctx context.Context, request reconcile
 	if (lifetime != nil) != (claim.Status.Lifetime != nil) ||
 		lifetime != nil && claim.Status.Lifetime != nil && lifetime.Duration != claim.Status.Lifetime.Duration {
 		claim.Status.Lifetime = lifetime
+		setReadyCondition(claim)
 		if err := r.Status().Update(context.Background(), claim); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterClaim lifetime")
 			return reconcile.Result{}, errors.Wrap(err, "could not update ClusterClaim lifetime")
@@ -283,6 +285,7 @@ func (r *ReconcileClusterClaim) setDeletingStatus(claim *hivev1.ClusterClaim, lo
 		"Assigned cluster has been marked for deletion",
 		controllerutils.UpdateConditionIfReasonOrMessageChange); changed {
 		claim.Status.Conditions = conds
+		setReadyCondition(claim)
 		if err := r.Status().Update(context.Background(), claim); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
 			return reconcile.Result{}, err
@@ -438,6 +441,7 @@ func (r *ReconcileClusterClaim) reconcileForDeletedCluster(claim *hivev1.Cluster
 	)
 	if changed {
 		claim.Status.Conditions = conds
+		setReadyCondition(claim)
 		if err := r.Status().Update(context.Background(), claim); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status")
 			return reconcile.Result{}, err
@@ -451,6 +455,9 @@ func (r *ReconcileClusterClaim) reconcileForExistingAssignment(claim *hivev1.Clu
 	if err := r.createRBAC(claim, cd, logger); err != nil {
 		return reconcile.Result{}, err
 	}
+	if err := r.labelClusterNamespace(claim, cd, logger); err != nil {
+		return reconcile.Result{}, err
+	}
 	var statusChanged bool
 	var changed bool
 	conds := claim.Status.Conditions
@@ -487,9 +494,9 @@ func (r *ReconcileClusterClaim) reconcileForExistingAssignment(claim *hivev1.Clu
 		)
 		statusChanged = statusChanged || changed
 	}
-	if statusChanged {
+	claim.Status.Conditions = conds
+	if setReadyCondition(claim) || statusChanged {
 		log.Debug("conditions changed, updating claim status")
-		claim.Status.Conditions = conds
 		if err := r.Status().Update(context.Background(), claim); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update status of ClusterClaim")
 			return reconcile.Result{}, err
@@ -498,6 +505,36 @@ func (r *ReconcileClusterClaim) reconcileForExistingAssignment(claim *hivev1.Clu
 	return reconcile.Result{}, nil
 }
 
+// setReadyCondition sets the Ready condition of the claim from its Pending and ClusterRunning conditions, and records
+// the generation of the claim in its status and conditions. It returns whether the status of the claim changed.
+func setReadyCondition(claim *hivev1.ClusterClaim) bool {
+	origStatus := claim.Status.DeepCopy()
+	status, reason, message := corev1.ConditionFalse, "Pending", "Waiting for a cluster to be assigned to the claim"
+	pendingCond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterClaimPendingCondition)
+	runningCond := controllerutils.FindClusterClaimCondition(claim.Status.Conditions, hivev1.ClusterRunningCondition)
+	switch {
+	case runningCond != nil && runningCond.Status == corev1.ConditionTrue:
+		status, reason, message = corev1.ConditionTrue, runningCond.Reason, runningCond.Message
+	case runningCond != nil && runningCond.Status == corev1.ConditionFalse:
+		reason, message = runningCond.Reason, runningCond.Message
+	case pendingCond != nil && pendingCond.Message != "":
+		message = pendingCond.Message
+	}
+	claim.Status.Conditions = controllerutils.SetClusterClaimCondition(
+		claim.Status.Conditions,
+		hivev1.ClusterClaimReadyCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	claim.Status.ObservedGeneration = claim.Generation
+	for i := range claim.Status.Conditions {
+		claim.Status.Conditions[i].ObservedGeneration = claim.Generation
+	}
+	return !reflect.DeepEqual(origStatus, &claim.Status)
+}
+
 func (r *ReconcileClusterClaim) reconcileForAssignmentConflict(claim *hivev1.ClusterClaim, logger log.FieldLogger) (reconcile.Result, error) {
 	logger.Info("claim assigned a cluster that has already been claimed by another ClusterClaim")
 	claim.Spec.Namespace = ""
@@ -601,6 +638,45 @@ func (r *ReconcileClusterClaim) applyHiveClaimOwnerRoleBinding(claim *hivev1.Clu
 	return nil
 }
 
+// labelClusterNamespace labels the namespace of the claimed cluster with the name and namespace of the claim and with
+// the labels of the claim, so that the namespace can be selected by claim for RBAC and cleanup.
+func (r *ReconcileClusterClaim) labelClusterNamespace(claim *hivev1.ClusterClaim, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	ns := &corev1.Namespace{}
+	if err := r.Get(context.Background(), client.ObjectKey{Name: cd.Namespace}, ns); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get cluster namespace")
+		return errors.Wrap(err, "could not get cluster namespace")
+	}
+	changed := false
+	setLabel := func(key, value string) {
+		if ns.Labels[key] == value {
+			return
+		}
+		if ns.Labels == nil {
+			ns.Labels = map[string]string{}
+		}
+		ns.Labels[key] = value
+		changed = true
+	}
+	for key, value := range claim.Labels {
+		// The pool label is used to reap the namespace, so it must not be replaced.
+		if key == constants.ClusterPoolNameLabel {
+			continue
+		}
+		setLabel(key, value)
+	}
+	setLabel(constants.ClusterClaimNameLabel, claim.Name)
+	setLabel(constants.ClusterClaimNamespaceLabel, claim.Namespace)
+	if !changed {
+		return nil
+	}
+	logger.WithField("namespace", ns.Name).Info("labeling cluster namespace")
+	if err := r.Update(context.Background(), ns); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not label cluster namespace")
+		return errors.Wrap(err, "could not label cluster namespace")
+	}
+	return nil
+}
+
 func (r *ReconcileClusterClaim) applyResource(desired, observed hivev1.MetaRuntimeObject, update func() bool, logger log.FieldLogger) error {
 	key := client.ObjectKey{
 		Namespace: desired.GetNamespace(),
@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	testclaim "github.com/openshift/hive/pkg/test/clusterclaim"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
@@ -58,6 +59,7 @@ func TestReconcileClusterClaim(t *testing.T) {
 	scheme := runtime.NewScheme()
 	hivev1.AddToScheme(scheme)
 	rbacv1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
 
 	poolBuilder := testcp.FullBuilder(claimNamespace, testLeasePoolName, scheme).
 		GenericOptions(
@@ -105,6 +107,7 @@ func TestReconcileClusterClaim(t *testing.T) {
 		expectHibernating                      bool
 		expectDeleted                          bool
 		expectedRequeueAfter                   *time.Duration
+		expectedNamespaceLabels                map[string]string
 	}{
 		{
 			name:  "initialize conditions",
@@ -163,11 +166,35 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Resuming",
 					Message: "Waiting for cluster to be running",
 				},
+				{
+					Type:   hivev1.ClusterClaimReadyCondition,
+					Status: corev1.ConditionFalse,
+					Reason: "Resuming",
+				},
+			},
+		},
+		{
+			name: "existing assignment labels cluster namespace",
+			claim: initializedClaimBuilder.GenericOptions(
+				testgeneric.WithLabel("team", "test-team"),
+				testgeneric.WithLabel(constants.ClusterPoolNameLabel, "other-pool"),
+			).Build(testclaim.WithCluster(clusterName)),
+			cd: cdBuilder.Build(
+				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
+				testcd.WithStatusPowerState(hivev1.StartingMachinesReadyReason),
+			),
+			expectCompletedClaim: true,
+			expectRBAC:           true,
+			expectedNamespaceLabels: map[string]string{
+				constants.ClusterPoolNameLabel:       "test-pool",
+				constants.ClusterClaimNameLabel:      claimName,
+				constants.ClusterClaimNamespaceLabel: claimNamespace,
+				"team":                               "test-team",
 			},
 		},
 		{
 			name:  "existing assignment running",
-			claim: initializedClaimBuilder.Build(testclaim.WithCluster(clusterName)),
+			claim: initializedClaimBuilder.GenericOptions(testgeneric.WithGeneration(2)).Build(testclaim.WithCluster(clusterName)),
 			cd: cdBuilder.Build(
 				testcd.WithClusterPoolReference(claimNamespace, "test-pool", claimName),
 				testcd.WithStatusPowerState(hivev1.RunningReadyReason),
@@ -187,6 +214,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 					Reason:  "Running",
 					Message: "Cluster is running",
 				},
+				{
+					Type:               hivev1.ClusterClaimReadyCondition,
+					Status:             corev1.ConditionTrue,
+					Reason:             "Running",
+					ObservedGeneration: 2,
+				},
 			},
 		},
 		{
@@ -218,12 +251,19 @@ func TestReconcileClusterClaim(t *testing.T) {
 		{
 			name:  "deleted cluster",
 			claim: initializedClaimBuilder.Build(testclaim.WithCluster(clusterName)),
-			expectedConditions: []hivev1.ClusterClaimCondition{{
-				Type:    hivev1.ClusterRunningCondition,
-				Status:  corev1.ConditionFalse,
-				Reason:  "ClusterDeleted",
-				Message: "Assigned cluster has been deleted",
-			}},
+			expectedConditions: []hivev1.ClusterClaimCondition{
+				{
+					Type:    hivev1.ClusterRunningCondition,
+					Status:  corev1.ConditionFalse,
+					Reason:  "ClusterDeleted",
+					Message: "Assigned cluster has been deleted",
+				},
+				{
+					Type:   hivev1.ClusterClaimReadyCondition,
+					Status: corev1.ConditionFalse,
+					Reason: "ClusterDeleted",
+				},
+			},
 			expectAssignedClusterDeploymentDeleted: true,
 		},
 		{
@@ -607,6 +647,12 @@ func TestReconcileClusterClaim(t *testing.T) {
 			if test.cd != nil {
 				test.existing = append(test.existing, test.cd)
 			}
+			test.existing = append(test.existing, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   clusterName,
+					Labels: map[string]string{constants.ClusterPoolNameLabel: "test-pool"},
+				},
+			})
 			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(test.existing...).Build()
 			logger := log.New()
 			logger.SetLevel(log.DebugLevel)
@@ -697,9 +743,19 @@ func TestReconcileClusterClaim(t *testing.T) {
 					if expectedCond.Message != "" {
 						assert.Equal(t, expectedCond.Message, cond.Message, "condition found with unexpected message")
 					}
+					if expectedCond.ObservedGeneration != 0 {
+						assert.Equal(t, expectedCond.ObservedGeneration, cond.ObservedGeneration, "condition found with unexpected observed generation")
+						assert.Equal(t, expectedCond.ObservedGeneration, claim.Status.ObservedGeneration, "unexpected observed generation")
+					}
 				}
 			}
 
+			if test.expectedNamespaceLabels != nil {
+				ns := &corev1.Namespace{}
+				require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: clusterName}, ns), "unexpected error getting cluster namespace")
+				assert.Equal(t, test.expectedNamespaceLabels, ns.Labels, "unexpected cluster namespace labels")
+			}
+
 			role := &rbacv1.Role{}
 			getRoleError := c.Get(context.Background(), client.ObjectKey{Namespace: clusterName, Name: hiveClaimOwnerRoleName}, role)
 			roleBinding := &rbacv1.RoleBinding{}
@@ -47,7 +47,9 @@ import (
 	"github.com/openshift/hive/pkg/controller/utils"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/imageset"
+	"github.com/openshift/hive/pkg/manageddns"
 	"github.com/openshift/hive/pkg/remoteclient"
+	"github.com/openshift/hive/pkg/secretencryption"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
 
@@ -91,13 +93,14 @@ const (
 	clusterImageSetNotFoundReason = "ClusterImageSetNotFound"
 	clusterImageSetFoundReason    = "ClusterImageSetFound"
 
-	defaultDNSNotReadyTimeout     = 10 * time.Minute
-	dnsNotReadyReason             = "DNSNotReady"
-	dnsNotReadyTimedoutReason     = "DNSNotReadyTimedOut"
-	dnsUnsupportedPlatformReason  = "DNSUnsupportedPlatform"
-	dnsZoneResourceConflictReason = "DNSZoneResourceConflict"
-	dnsReadyReason                = "DNSReady"
-	dnsReadyAnnotation            = "hive.openshift.io/dnsready"
+	defaultDNSNotReadyTimeout      = 10 * time.Minute
+	dnsNotReadyReason              = "DNSNotReady"
+	dnsNotReadyTimedoutReason      = "DNSNotReadyTimedOut"
+	dnsUnsupportedPlatformReason   = "DNSUnsupportedPlatform"
+	dnsProviderNotConfiguredReason = "DNSProviderNotConfigured"
+	dnsZoneResourceConflictReason  = "DNSZoneResourceConflict"
+	dnsReadyReason                 = "DNSReady"
+	dnsReadyAnnotation             = "hive.openshift.io/dnsready"
 
 	installAttemptsLimitReachedReason = "InstallAttemptsLimitReached"
 	installOnlyOnceSetReason          = "InstallOnlyOnceSet"
@@ -141,6 +144,12 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 		r.protectedDelete = true
 	}
 
+	managedDomains, err := manageddns.ReadManagedDomainsFile()
+	if err != nil {
+		logger.WithError(err).Error("could not read managed domains file")
+	}
+	r.managedDomains = managedDomains
+
 	verifier, err := LoadReleaseImageVerifier(mgr.GetConfig())
 	if err == nil {
 		logger.Info("Release Image verification enabled")
@@ -161,7 +170,7 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 
 	logger := log.WithField("controller", ControllerName)
 	c, err := controller.New("clusterdeployment-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -179,6 +188,12 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		return err
 	}
 
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &hivev1.ClusterProvision{},
+		clusterProvisionIndexFieldName, indexClusterProvision); err != nil {
+		logger.WithError(err).Error("Error indexing cluster provision for cluster deployment")
+		return err
+	}
+
 	// Watch for changes to ClusterDeployment
 	err = c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}},
 		controllerutils.NewRateLimitedUpdateEventHandler(&handler.EnqueueRequestForObject{}, controllerutils.IsClusterDeploymentErrorUpdateEvent))
@@ -273,6 +288,9 @@ type ReconcileClusterDeployment struct {
 	releaseImageVerifier verify.Interface
 
 	protectedDelete bool
+
+	// managedDomains are the domains which hive manages DNS for.
+	managedDomains []hivev1.ManageDNSConfig
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeployment object and makes changes based on the state read
@@ -309,9 +327,10 @@ func (r *ReconcileClusterDeployment) Reconcile(ctx context.Context, request reco
 	// Initialize cluster deployment conditions if not set
 	newConditions := controllerutils.InitializeClusterDeploymentConditions(cd.Status.Conditions, clusterDeploymentConditions)
 	if len(newConditions) > len(cd.Status.Conditions) {
+		origConditions := cd.Status.Conditions
 		cd.Status.Conditions = newConditions
 		cdLog.Info("initializing cluster deployment controller conditions")
-		if err := r.Status().Update(context.TODO(), cd); err != nil {
+		if err := controllerutils.PatchClusterDeploymentConditions(r.Client, cd, origConditions); err != nil {
 			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
 			return reconcile.Result{}, err
 		}
@@ -428,6 +447,11 @@ func (r *ReconcileClusterDeployment) addAdditionalKubeconfigCAs(cd *hivev1.Clust
 		cdLog.WithError(err).Error("failed to get admin kubeconfig secret")
 		return err
 	}
+	encrypted, err := secretencryption.DecryptSecret(context.TODO(), r, adminKubeconfigSecret)
+	if err != nil {
+		cdLog.WithError(err).Error("failed to decrypt admin kubeconfig secret")
+		return err
+	}
 
 	originalSecret := adminKubeconfigSecret.DeepCopy()
 
@@ -437,7 +461,6 @@ func (r *ReconcileClusterDeployment) addAdditionalKubeconfigCAs(cd *hivev1.Clust
 		rawData = adminKubeconfigSecret.Data[constants.KubeconfigSecretKey]
 	}
 
-	var err error
 	adminKubeconfigSecret.Data[constants.KubeconfigSecretKey], err = controllerutils.AddAdditionalKubeconfigCAs(rawData)
 	if err != nil {
 		cdLog.WithError(err).Errorf("error adding additional CAs to admin kubeconfig")
@@ -449,6 +472,12 @@ func (r *ReconcileClusterDeployment) addAdditionalKubeconfigCAs(cd *hivev1.Clust
 		return nil
 	}
 
+	if encrypted {
+		if err := secretencryption.EncryptSecret(context.TODO(), r, adminKubeconfigSecret); err != nil {
+			cdLog.WithError(err).Error("failed to encrypt admin kubeconfig secret")
+			return err
+		}
+	}
 	cdLog.Info("admin kubeconfig has been modified, updating")
 	err = r.Update(context.TODO(), adminKubeconfigSecret)
 	if err != nil {
@@ -1340,6 +1369,20 @@ func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.Clu
 		return reconcile.Result{}, nil
 	}
 
+	// The deletion hold is checked before any deprovision work, even when a ClusterDeprovision already exists, so
+	// that an existing ClusterDeprovision cannot be used to bypass the confirmation and the grace period.
+	if controllerutils.HasDeletionProtection(cd) {
+		if !controllerutils.IsDeleteConfirmed(cd) {
+			cdLog.WithField("annotation", constants.ConfirmDeleteAnnotation).
+				Warn("deprovision held for ClusterDeployment with deletion protection, deletion has not been confirmed")
+			return reconcile.Result{}, nil
+		}
+		if remaining := controllerutils.DeletionGracePeriodRemaining(cd); remaining > 0 {
+			cdLog.WithField("remaining", remaining).Info("waiting for deletion grace period to elapse before deprovisioning")
+			return reconcile.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
 	dnsZoneGone, err := r.ensureManagedDNSZoneDeleted(cd, cdLog)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -1389,6 +1432,10 @@ func (r *ReconcileClusterDeployment) removeClusterDeploymentFinalizer(cd *hivev1
 
 	clearDeprovisionUnderwaySecondsMetric(cd, cdLog)
 
+	if controllerutils.IsSimulatedCluster(cd) {
+		remoteclient.ForgetSimulatedCluster(cd)
+	}
+
 	// Increment the clusters deleted counter:
 	metricClustersDeleted.WithLabelValues(hivemetrics.GetClusterDeploymentType(cd)).Inc()
 
@@ -1458,7 +1505,30 @@ func (r *ReconcileClusterDeployment) ensureDNSZonePreserveOnDelete(cd *hivev1.Cl
 }
 
 func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (*hivev1.DNSZone, error) {
+	managedDomain := manageddns.FindChildZonesManagedDomain(r.managedDomains, cd.Spec.BaseDomain)
 	switch p := cd.Spec.Platform; {
+	case managedDomain != nil:
+		// The managed domain configures the DNS provider for the DNSZone, regardless of the platform.
+		credentialsSecretRef, err := managedDNSChildZonesCredentials(managedDomain)
+		if err != nil {
+			cdLog.WithError(err).Error("managed domain does not configure the DNS provider of the dnszone")
+			if err := r.updateCondition(cd, hivev1.DNSNotReadyCondition, corev1.ConditionTrue, dnsProviderNotConfiguredReason, err.Error(), cdLog); err != nil {
+				cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not update DNSNotReadyCondition for DNSProviderNotConfigured reason")
+				return nil, err
+			}
+			return nil, err
+		}
+		// Copy the credentials on every reconcile so that rotated credentials reach the DNSZone.
+		if err := controllerutils.CopySecret(
+			r,
+			types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: credentialsSecretRef.Name},
+			types.NamespacedName{Namespace: cd.Namespace, Name: managedDNSCredentialsSecretName(cd)},
+			cd,
+			r.scheme,
+		); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not copy the credentials of the managed domain")
+			return nil, err
+		}
 	case p.AWS != nil:
 	case p.GCP != nil:
 	case p.Azure != nil:
@@ -1478,7 +1548,7 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDepl
 	switch err := r.Get(context.TODO(), dnsZoneNamespacedName, dnsZone); {
 	case apierrors.IsNotFound(err):
 		logger.Info("creating new DNSZone for cluster deployment")
-		return nil, r.createManagedDNSZone(cd, logger)
+		return nil, r.createManagedDNSZone(cd, managedDomain, logger)
 	case err != nil:
 		logger.WithError(err).Error("failed to fetch DNS zone")
 		return nil, err
@@ -1551,7 +1621,52 @@ func (r *ReconcileClusterDeployment) ensureManagedDNSZone(cd *hivev1.ClusterDepl
 	return dnsZone, nil
 }
 
-func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+// managedDNSChildZonesCredentials returns the secret in the hive namespace with the credentials to manage the DNSZones
+// of clusters using the managed domain, after checking that the managed domain configures its DNS provider.
+func managedDNSChildZonesCredentials(managedDomain *hivev1.ManageDNSConfig) (*corev1.LocalObjectReference, error) {
+	var credentialsSecretRef *corev1.LocalObjectReference
+	switch provider := managedDomain.ChildZones.Provider; {
+	case provider == hivev1.ManageDNSProviderAWS && managedDomain.AWS != nil:
+		credentialsSecretRef = &managedDomain.AWS.CredentialsSecretRef
+	case provider == hivev1.ManageDNSProviderGCP && managedDomain.GCP != nil:
+		credentialsSecretRef = &managedDomain.GCP.CredentialsSecretRef
+	case provider == hivev1.ManageDNSProviderAzure && managedDomain.Azure != nil:
+		credentialsSecretRef = &managedDomain.Azure.CredentialsSecretRef
+	default:
+		return nil, fmt.Errorf("The managed domain of the cluster does not configure its DNS provider %q", provider)
+	}
+	if managedDomain.ChildZones.CredentialsSecretRef != nil {
+		credentialsSecretRef = managedDomain.ChildZones.CredentialsSecretRef
+	}
+	return credentialsSecretRef, nil
+}
+
+// managedDNSCredentialsSecretName returns the name of the secret with the credentials for the DNSZone of the cluster
+// when the managed domain configures the DNS provider of the DNSZone.
+func managedDNSCredentialsSecretName(cd *hivev1.ClusterDeployment) string {
+	return fmt.Sprintf("%s-dns-creds", cd.Name)
+}
+
+func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDeployment, managedDomain *hivev1.ManageDNSConfig, logger log.FieldLogger) error {
+	dnsZone := GenerateDNSZone(cd, managedDomain)
+	if err := controllerutil.SetControllerReference(cd, dnsZone, r.scheme); err != nil {
+		logger.WithError(err).Error("error setting controller reference on dnszone")
+		return err
+	}
+
+	err := r.Create(context.TODO(), dnsZone)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "cannot create DNS zone")
+		return err
+	}
+	logger.Info("dns zone created")
+	return nil
+}
+
+// GenerateDNSZone returns the DNSZone created for a ClusterDeployment with managed DNS. The DNS provider of the zone
+// is configured by the managed domain with child zones of the base domain of the cluster, if any, and by the platform
+// of the cluster otherwise.
+func GenerateDNSZone(cd *hivev1.ClusterDeployment, managedDomain *hivev1.ManageDNSConfig) *hivev1.DNSZone {
 	dnsZone := &hivev1.DNSZone{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      controllerutils.DNSZoneName(cd.Name),
@@ -1565,6 +1680,25 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 	}
 
 	switch {
+	case managedDomain != nil:
+		credentialsSecretRef := corev1.LocalObjectReference{Name: managedDNSCredentialsSecretName(cd)}
+		switch managedDomain.ChildZones.Provider {
+		case hivev1.ManageDNSProviderAWS:
+			dnsZone.Spec.AWS = &hivev1.AWSDNSZoneSpec{
+				CredentialsSecretRef: credentialsSecretRef,
+				Region:               managedDomain.AWS.Region,
+			}
+		case hivev1.ManageDNSProviderGCP:
+			dnsZone.Spec.GCP = &hivev1.GCPDNSZoneSpec{
+				CredentialsSecretRef: credentialsSecretRef,
+			}
+		case hivev1.ManageDNSProviderAzure:
+			dnsZone.Spec.Azure = &hivev1.AzureDNSZoneSpec{
+				CredentialsSecretRef: credentialsSecretRef,
+				ResourceGroupName:    managedDomain.Azure.ResourceGroupName,
+				CloudName:            managedDomain.Azure.CloudName,
+			}
+		}
 	case cd.Spec.Platform.AWS != nil:
 		additionalTags := make([]hivev1.AWSResourceTag, 0, len(cd.Spec.Platform.AWS.UserTags))
 		for k, v := range cd.Spec.Platform.AWS.UserTags {
@@ -1592,21 +1726,13 @@ func (r *ReconcileClusterDeployment) createManagedDNSZone(cd *hivev1.ClusterDepl
 		}
 	}
 
-	logger.WithField("derivedObject", dnsZone.Name).Debug("Setting labels on derived object")
-	dnsZone.Labels = k8slabels.AddLabel(dnsZone.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
-	dnsZone.Labels = k8slabels.AddLabel(dnsZone.Labels, constants.DNSZoneTypeLabel, constants.DNSZoneTypeChild)
-	if err := controllerutil.SetControllerReference(cd, dnsZone, r.scheme); err != nil {
-		logger.WithError(err).Error("error setting controller reference on dnszone")
-		return err
+	if zoneID := cd.Annotations[constants.AWSHostedZoneIDAnnotation]; zoneID != "" && dnsZone.Spec.AWS != nil {
+		dnsZone.Spec.AWS.ZoneID = zoneID
 	}
 
-	err := r.Create(context.TODO(), dnsZone)
-	if err != nil {
-		logger.WithError(err).Log(controllerutils.LogLevel(err), "cannot create DNS zone")
-		return err
-	}
-	logger.Info("dns zone created")
-	return nil
+	dnsZone.Labels = k8slabels.AddLabel(dnsZone.Labels, constants.ClusterDeploymentNameLabel, cd.Name)
+	dnsZone.Labels = k8slabels.AddLabel(dnsZone.Labels, constants.DNSZoneTypeLabel, constants.DNSZoneTypeChild)
+	return dnsZone
 }
 
 func selectorPodWatchHandler(a client.Object) []reconcile.Request {
@@ -1696,6 +1822,14 @@ func generateDeprovision(cd *hivev1.ClusterDeployment) (*hivev1.ClusterDeprovisi
 		},
 	}
 
+	// Fake clusters are deprovisioned from a simulated cloud inventory.
+	if controllerutils.IsFakeCluster(cd) {
+		req.Annotations = map[string]string{constants.HiveFakeClusterAnnotation: "true"}
+		if inventory, ok := cd.Annotations[constants.HiveFakeCloudInventoryAnnotation]; ok {
+			req.Annotations[constants.HiveFakeCloudInventoryAnnotation] = inventory
+		}
+	}
+
 	switch {
 	case cd.Spec.Platform.AWS != nil:
 		req.Spec.Platform.AWS = &hivev1.AWSClusterDeprovision{
@@ -1708,6 +1842,10 @@ func generateDeprovision(cd *hivev1.ClusterDeployment) (*hivev1.ClusterDeprovisi
 			CredentialsSecretRef: &cd.Spec.Platform.Azure.CredentialsSecretRef,
 			CloudName:            &cd.Spec.Platform.Azure.CloudName,
 		}
+		if platformMetadata := cd.Spec.ClusterMetadata.Platform; platformMetadata != nil && platformMetadata.Azure != nil {
+			req.Spec.Platform.Azure.ResourceGroupName = platformMetadata.Azure.ResourceGroupName
+			req.Spec.Platform.Azure.BaseDomainResourceGroupName = platformMetadata.Azure.BaseDomainResourceGroupName
+		}
 	case cd.Spec.Platform.GCP != nil:
 		req.Spec.Platform.GCP = &hivev1.GCPClusterDeprovision{
 			Region:               cd.Spec.Platform.GCP.Region,
@@ -1899,6 +2037,16 @@ func calculateNextProvisionTime(failureTime time.Time, retries int, cdLog log.Fi
 	return failureTime.Add((1 << uint(retries)) * time.Minute)
 }
 
+const clusterProvisionIndexFieldName = "spec.clusterdeploymentref.name"
+
+func indexClusterProvision(o client.Object) []string {
+	provision := o.(*hivev1.ClusterProvision)
+	if provision.Spec.ClusterDeploymentRef.Name == "" {
+		return nil
+	}
+	return []string{provision.Spec.ClusterDeploymentRef.Name}
+}
+
 // existingProvisions returns the list of ClusterProvisions associated with the specified
 // ClusterDeployment, sorted by age, oldest first.
 func (r *ReconcileClusterDeployment) existingProvisions(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) ([]*hivev1.ClusterProvision, error) {
@@ -1907,6 +2055,7 @@ func (r *ReconcileClusterDeployment) existingProvisions(cd *hivev1.ClusterDeploy
 		context.TODO(),
 		provisionList,
 		client.InNamespace(cd.Namespace),
+		client.MatchingFields{clusterProvisionIndexFieldName: cd.Name},
 		client.MatchingLabels(map[string]string{constants.ClusterDeploymentNameLabel: cd.Name}),
 	); err != nil {
 		cdLog.WithError(err).Warn("could not list provisions for clusterdeployment")
@@ -1996,6 +2145,31 @@ func checkForFailedSync(clusterSync *hiveintv1alpha1.ClusterSync) bool {
 	return false
 }
 
+// failedSyncMessage returns a message naming the SyncSets and SelectorSyncSets which failed to apply to the cluster.
+func failedSyncMessage(clusterSync *hiveintv1alpha1.ClusterSync) string {
+	failedNames := func(statuses []hiveintv1alpha1.SyncStatus) []string {
+		var names []string
+		for _, s := range statuses {
+			if s.Result == hiveintv1alpha1.FailureSyncSetResult {
+				names = append(names, s.Name)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+	var parts []string
+	if names := failedNames(clusterSync.Status.SyncSets); len(names) > 0 {
+		parts = append(parts, fmt.Sprintf("SyncSets failed to apply: %s", strings.Join(names, ", ")))
+	}
+	if names := failedNames(clusterSync.Status.SelectorSyncSets); len(names) > 0 {
+		parts = append(parts, fmt.Sprintf("SelectorSyncSets failed to apply: %s", strings.Join(names, ", ")))
+	}
+	if len(parts) == 0 {
+		return "One of the SyncSet applies has failed"
+	}
+	return strings.Join(parts, "; ")
+}
+
 // setSyncSetFailedCondition updates the hivev1.SyncSetFailedCondition
 func (r *ReconcileClusterDeployment) setSyncSetFailedCondition(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
 	var (
@@ -2022,7 +2196,11 @@ func (r *ReconcileClusterDeployment) setSyncSetFailedCondition(cd *hivev1.Cluste
 	case checkForFailedSync(clusterSync):
 		status = corev1.ConditionTrue
 		reason = "SyncSetApplyFailure"
-		message = "One of the SyncSet applies has failed"
+		message = failedSyncMessage(clusterSync)
+	case clusterSync.Status.FirstSuccessTime == nil:
+		status = corev1.ConditionFalse
+		reason = "SyncSetApplyInProgress"
+		message = "SyncSets have not yet all been applied successfully"
 	default:
 		status = corev1.ConditionFalse
 		reason = "SyncSetApplySuccess"
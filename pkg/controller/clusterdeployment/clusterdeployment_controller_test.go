@@ -359,6 +359,49 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Completed provision with platform status",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision())
+					cd.Status.Platform = &hivev1.PlatformStatus{
+						AWS: &hivev1aws.PlatformStatus{
+							PrivateLink: &hivev1aws.PrivateLinkAccessStatus{VPCEndpointID: "vpce-1"},
+						},
+					}
+					return cd
+				}(),
+				func() *hivev1.ClusterProvision {
+					provision := testSuccessfulProvision()
+					provision.Spec.PlatformStatus = &hivev1.PlatformStatus{
+						AWS: &hivev1aws.PlatformStatus{
+							VPCID:               "vpc-1",
+							SubnetIDs:           []string{"subnet-1", "subnet-2"},
+							PrivateHostedZoneID: "private-zone",
+						},
+					}
+					return provision
+				}(),
+				testMetadataConfigMap(),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Equal(t, &hivev1.PlatformStatus{
+						AWS: &hivev1aws.PlatformStatus{
+							PrivateLink:         &hivev1aws.PrivateLinkAccessStatus{VPCEndpointID: "vpce-1"},
+							VPCID:               "vpc-1",
+							SubnetIDs:           []string{"subnet-1", "subnet-2"},
+							PrivateHostedZoneID: "private-zone",
+						},
+					}, cd.Status.Platform, "unexpected platform status")
+				}
+			},
+		},
 		{
 			name: "Completed provision with protected delete",
 			existing: []runtime.Object{
@@ -423,6 +466,112 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Hold deprovision when deletion protection unconfirmed",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testDeletedClusterDeployment())
+					cd.Spec.DeletionProtection = &hivev1.DeletionProtection{Enabled: true}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getDeprovision(c), "expected no deprovision request")
+				assert.Contains(t, getCD(c).Finalizers, hivev1.FinalizerDeprovision, "expected finalizer")
+			},
+		},
+		{
+			name: "Hold deprovision when deletion protection unconfirmed and deprovision exists",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testDeletedClusterDeployment())
+					cd.Spec.DeletionProtection = &hivev1.DeletionProtection{Enabled: true}
+					return cd
+				}(),
+				testclusterdeprovision.Build(
+					testclusterdeprovision.WithNamespace(testNamespace),
+					testclusterdeprovision.WithName(testName),
+					testclusterdeprovision.Completed(),
+				),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "expected ClusterDeployment to be kept")
+				assert.Contains(t, cd.Finalizers, hivev1.FinalizerDeprovision, "expected finalizer")
+			},
+		},
+		{
+			name: "Wait for deletion grace period before deprovision",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testDeletedClusterDeployment())
+					cd.Spec.DeletionProtection = &hivev1.DeletionProtection{
+						Enabled:     true,
+						GracePeriod: &metav1.Duration{Duration: time.Hour},
+					}
+					cd.Annotations = map[string]string{constants.ConfirmDeleteAnnotation: cd.Name}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: time.Hour,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getDeprovision(c), "expected no deprovision request")
+			},
+		},
+		{
+			name: "Wait for deletion grace period when deprovision exists",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testDeletedClusterDeployment())
+					cd.Spec.DeletionProtection = &hivev1.DeletionProtection{
+						Enabled:     true,
+						GracePeriod: &metav1.Duration{Duration: time.Hour},
+					}
+					cd.Annotations = map[string]string{constants.ConfirmDeleteAnnotation: cd.Name}
+					return cd
+				}(),
+				testclusterdeprovision.Build(
+					testclusterdeprovision.WithNamespace(testNamespace),
+					testclusterdeprovision.WithName(testName),
+					testclusterdeprovision.Completed(),
+				),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectedRequeueAfter: time.Hour,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				require.NotNil(t, cd, "expected ClusterDeployment to be kept")
+				assert.Contains(t, cd.Finalizers, hivev1.FinalizerDeprovision, "expected finalizer")
+			},
+		},
+		{
+			name: "Deprovision after deletion grace period",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testDeletedClusterDeployment())
+					cd.Spec.DeletionProtection = &hivev1.DeletionProtection{
+						Enabled:     true,
+						GracePeriod: &metav1.Duration{Duration: time.Hour},
+					}
+					cd.Annotations = map[string]string{constants.ConfirmDeleteAnnotation: cd.Name}
+					deleted := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+					cd.DeletionTimestamp = &deleted
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.NotNil(t, getDeprovision(c), "expected deprovision request to be created")
+			},
+		},
 		{
 			name: "Block deprovision when protected delete on",
 			existing: []runtime.Object{
@@ -818,6 +967,25 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.True(t, zone.Spec.PreserveOnDelete, "PreserveOnDelete did not transfer to DNSZone")
 			},
 		},
+		{
+			name: "Create DNSZone with existing hosted zone",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeployment())
+					cd.Annotations = map[string]string{constants.AWSHostedZoneIDAnnotation: "Z1234"}
+					cd.Spec.ManageDNS = true
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				zone := getDNSZone(c)
+				require.NotNil(t, zone, "dns zone should exist")
+				require.NotNil(t, zone.Spec.AWS, "dns zone should be on AWS")
+				assert.Equal(t, "Z1234", zone.Spec.AWS.ZoneID, "hosted zone ID did not transfer to DNSZone")
+			},
+		},
 		{
 			name: "Create DNSZone with Azure CloudName",
 			existing: []runtime.Object{
@@ -875,6 +1043,87 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				assert.Equal(t, azure.CloudEnvironment(""), zone.Spec.Azure.CloudName, "CloudName incorrectly set for DNSZone")
 			},
 		},
+		{
+			name: "Create DNSZone on the DNS provider of the managed domain",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					baseCD := testClusterDeployment()
+					baseCD.Labels[hivev1.HiveClusterPlatformLabel] = "azure"
+					baseCD.Labels[hivev1.HiveClusterRegionLabel] = "eastus"
+					baseCD.Spec.Platform.AWS = nil
+					baseCD.Spec.Platform.Azure = &azure.Platform{
+						CredentialsSecretRef: corev1.LocalObjectReference{
+							Name: "azure-credentials",
+						},
+						Region:                      "eastus",
+						BaseDomainResourceGroupName: "os4-common",
+					}
+					baseCD.Spec.BaseDomain = "mycluster.example.com"
+					baseCD.Spec.ManageDNS = true
+					return testClusterDeploymentWithInitializedConditions(baseCD)
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				testSecretWithNamespace(corev1.SecretTypeOpaque, "route53-creds", "hive", "aws_access_key_id", "key"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.managedDomains = []hivev1.ManageDNSConfig{{
+					Domains: []string{"example.com"},
+					AWS: &hivev1.ManageDNSAWSConfig{
+						CredentialsSecretRef: corev1.LocalObjectReference{Name: "route53-creds"},
+						Region:               "us-east-2",
+					},
+					ChildZones: &hivev1.ManageDNSChildZonesConfig{Provider: hivev1.ManageDNSProviderAWS},
+				}}
+			},
+			validate: func(c client.Client, t *testing.T) {
+				zone := getDNSZone(c)
+				require.NotNil(t, zone, "dns zone should exist")
+				assert.Nil(t, zone.Spec.Azure, "DNSZone should not be hosted on the platform of the cluster")
+				if assert.NotNil(t, zone.Spec.AWS, "DNSZone should be hosted on the DNS provider of the managed domain") {
+					assert.Equal(t, testName+"-dns-creds", zone.Spec.AWS.CredentialsSecretRef.Name, "unexpected credentials for DNSZone")
+					assert.Equal(t, "us-east-2", zone.Spec.AWS.Region, "unexpected region for DNSZone")
+				}
+				secret := &corev1.Secret{}
+				err := c.Get(context.TODO(), client.ObjectKey{Namespace: testNamespace, Name: testName + "-dns-creds"}, secret)
+				require.NoError(t, err, "credentials of the managed domain should be copied")
+				assert.Equal(t, "key", string(secret.Data["aws_access_key_id"]), "unexpected credentials copied")
+			},
+		},
+		{
+			name: "Set condition when the managed domain does not configure its DNS provider",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeployment())
+					cd.Spec.BaseDomain = "mycluster.example.com"
+					cd.Spec.ManageDNS = true
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.managedDomains = []hivev1.ManageDNSConfig{{
+					Domains: []string{"example.com"},
+					AWS: &hivev1.ManageDNSAWSConfig{
+						CredentialsSecretRef: corev1.LocalObjectReference{Name: "route53-creds"},
+					},
+					ChildZones: &hivev1.ManageDNSChildZonesConfig{Provider: hivev1.ManageDNSProviderGCP},
+				}}
+			},
+			expectErr: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Nil(t, getDNSZone(c), "dns zone should not exist")
+				cd := getCD(c)
+				testassert.AssertConditions(t, cd, []hivev1.ClusterDeploymentCondition{
+					{
+						Type:   hivev1.DNSNotReadyCondition,
+						Status: corev1.ConditionTrue,
+						Reason: dnsProviderNotConfiguredReason,
+					},
+				})
+			},
+		},
 		{
 			name: "Update DNSZone when PreserveOnDelete changes",
 			existing: []runtime.Object{
@@ -1462,6 +1711,14 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 						Name:      testName,
 					},
 					Status: hiveintv1alpha1.ClusterSyncStatus{
+						SyncSets: []hiveintv1alpha1.SyncStatus{
+							{Name: "ss-ok", Result: hiveintv1alpha1.SuccessSyncSetResult},
+							{Name: "ss-b", Result: hiveintv1alpha1.FailureSyncSetResult},
+							{Name: "ss-a", Result: hiveintv1alpha1.FailureSyncSetResult},
+						},
+						SelectorSyncSets: []hiveintv1alpha1.SyncStatus{
+							{Name: "sss-a", Result: hiveintv1alpha1.FailureSyncSetResult},
+						},
 						Conditions: []hiveintv1alpha1.ClusterSyncCondition{{
 							Type:    hiveintv1alpha1.ClusterSyncFailed,
 							Status:  corev1.ConditionTrue,
@@ -1477,6 +1734,9 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 					cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.SyncSetFailedCondition)
 					if assert.NotNil(t, cond, "missing SyncSetFailedCondition status condition") {
 						assert.Equal(t, corev1.ConditionTrue, cond.Status, "did not get expected state for SyncSetFailedCondition condition")
+						assert.Equal(t, "SyncSetApplyFailure", cond.Reason, "did not get expected reason for SyncSetFailedCondition condition")
+						assert.Equal(t, "SyncSets failed to apply: ss-a, ss-b; SelectorSyncSets failed to apply: sss-a", cond.Message,
+							"did not get expected message for SyncSetFailedCondition condition")
 					}
 				}
 			},
@@ -1515,6 +1775,38 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.SyncSetFailedCondition)
 				if assert.NotNil(t, cond, "missing SyncSetFailedCondition status condition") {
 					assert.Equal(t, corev1.ConditionFalse, cond.Status, "did not get expected state for SyncSetFailedCondition condition")
+					assert.Equal(t, "SyncSetApplyInProgress", cond.Reason, "did not get expected reason for SyncSetFailedCondition condition")
+				}
+			},
+		},
+		{
+			name: "SyncSetFailedCondition reports success after first success",
+			existing: []runtime.Object{
+				testClusterDeploymentWithInitializedConditions(testInstalledClusterDeployment(time.Now())),
+				testSecret(corev1.SecretTypeOpaque, adminKubeconfigSecret, "kubeconfig", adminKubeconfig),
+				testSecret(corev1.SecretTypeOpaque, adminPasswordSecret, "password", adminPassword),
+				&hiveintv1alpha1.ClusterSync{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testNamespace,
+						Name:      testName,
+					},
+					Status: hiveintv1alpha1.ClusterSyncStatus{
+						Conditions: []hiveintv1alpha1.ClusterSyncCondition{{
+							Type:    hiveintv1alpha1.ClusterSyncFailed,
+							Status:  corev1.ConditionFalse,
+							Reason:  "SuccessReason",
+							Message: "Success message",
+						}},
+						FirstSuccessTime: &metav1.Time{Time: time.Now()},
+					},
+				},
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.SyncSetFailedCondition)
+				if assert.NotNil(t, cond, "missing SyncSetFailedCondition status condition") {
+					assert.Equal(t, corev1.ConditionFalse, cond.Status, "did not get expected state for SyncSetFailedCondition condition")
+					assert.Equal(t, "SyncSetApplySuccess", cond.Reason, "did not get expected reason for SyncSetFailedCondition condition")
 				}
 			},
 		},
@@ -1591,6 +1883,34 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Ensure platform metadata set from provision metadata",
+			existing: []runtime.Object{
+				testInstallConfigSecret(),
+				func() runtime.Object {
+					cd := testClusterDeploymentWithInitializedConditions(testClusterDeploymentWithProvision())
+					cd.Spec.ClusterMetadata = nil
+					return cd
+				}(),
+				func() runtime.Object {
+					provision := testSuccessfulProvision()
+					provision.Spec.Metadata = &runtime.RawExtension{Raw: []byte(`{"clusterName":"test","clusterID":"testFooClusterUUID","infraID":"testFooInfraID","aws":{"region":"us-east-1","identifier":[{"openshiftClusterID":"testFooClusterUUID"}],"clusterDomain":"test.example.com"}}`)}
+					return provision
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					if assert.NotNil(t, cd.Spec.ClusterMetadata, "expected cluster metadata to be set") {
+						assert.Equal(t, &hivev1.ClusterPlatformMetadata{
+							AWS: &hivev1aws.Metadata{Region: "us-east-1", ClusterDomain: "test.example.com"},
+						}, cd.Spec.ClusterMetadata.Platform, "unexpected platform metadata")
+					}
+				}
+			},
+		},
 		{
 			name: "Ensure cluster metadata overwrites from provision",
 			existing: []runtime.Object{
@@ -3976,3 +4296,47 @@ func (testReleaseVerifier) Verifiers() map[string]openpgp.EntityList {
 
 func (testReleaseVerifier) AddStore(_ store.Store) {
 }
+
+func TestInstallManagerImageOverrideForVersion(t *testing.T) {
+	overrides := []hivev1.InstallManagerImageOverride{
+		{OpenShiftVersion: "4.8", InstallManagerImage: "install-manager-4.8"},
+		{OpenShiftVersion: "4.9", InstallManagerImage: "install-manager-4.9"},
+	}
+	cases := []struct {
+		name     string
+		version  *string
+		expected string
+	}{
+		{
+			name:     "matching minor version",
+			version:  pointer.StringPtr("4.9.12"),
+			expected: "install-manager-4.9",
+		},
+		{
+			name:     "matching pre-release version",
+			version:  pointer.StringPtr("4.8.0-fc.3"),
+			expected: "install-manager-4.8",
+		},
+		{
+			name:    "no matching version",
+			version: pointer.StringPtr("4.10.3"),
+		},
+		{
+			name:    "unparseable version",
+			version: pointer.StringPtr("latest"),
+		},
+		{
+			name: "unknown version",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			override := installManagerImageOverrideForVersion(overrides, tc.version)
+			if tc.expected == "" {
+				assert.Nil(t, override, "expected no override")
+			} else if assert.NotNil(t, override, "expected an override") {
+				assert.Equal(t, tc.expected, override.InstallManagerImage, "unexpected override")
+			}
+		})
+	}
+}
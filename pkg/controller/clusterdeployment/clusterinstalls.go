@@ -2,6 +2,7 @@ package clusterdeployment
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/notification"
 )
 
 func (r *ReconcileClusterDeployment) reconcileExistingInstallingClusterInstall(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
@@ -208,6 +210,10 @@ func (r *ReconcileClusterDeployment) reconcileExistingInstallingClusterInstall(c
 			logger.WithError(err).Error("failed to update the spec of clusterdeployment")
 			return reconcile.Result{}, err
 		}
+		if completed.Status == corev1.ConditionTrue {
+			notification.Notify(notification.NewEvent(hivev1.ProvisionSucceededNotificationEvent, cd,
+				fmt.Sprintf("Cluster install %s completed", ci.Name)), logger)
+		}
 	}
 	if statusModified {
 		cd.Status.Conditions = conditions
@@ -218,6 +224,7 @@ func (r *ReconcileClusterDeployment) reconcileExistingInstallingClusterInstall(c
 		// If we declared the provision terminally failed, bump our metric
 		if provisionFailedTerminal {
 			incProvisionFailedTerminal(cd)
+			notification.Notify(notification.NewEvent(hivev1.ProvisionFailedNotificationEvent, cd, msg), logger)
 		}
 	}
 
@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
@@ -27,10 +28,14 @@ import (
 
 	apihelpers "github.com/openshift/hive/apis/helpers"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/credentialsbroker"
+	"github.com/openshift/hive/pkg/externalsecret"
 	"github.com/openshift/hive/pkg/install"
+	"github.com/openshift/hive/pkg/notification"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
 
@@ -88,6 +93,7 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 				return reconcile.Result{}, err
 			}
 			incProvisionFailedTerminal(cd)
+			notification.Notify(notification.NewEvent(hivev1.ProvisionFailedNotificationEvent, cd, message), logger)
 		}
 		return reconcile.Result{}, nil
 	}
@@ -142,7 +148,34 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		logger.WithError(err).Error("failed to read failed provision config file")
 		return reconcile.Result{}, err
 	}
-	extraEnvVars = append(extraEnvVars, getAWSServiceProviderEnvVars(cd, cd.Name)...)
+	credentialsBrokerConfig, err := credentialsbroker.ReadConfig()
+	if err != nil {
+		logger.WithError(err).Error("failed to read credentials broker config")
+		return reconcile.Result{}, err
+	}
+	// When the credentials broker mints the AWS credentials of the provision, the install pod has no use for the
+	// service provider credentials, so they are not copied to the namespace of the cluster.
+	brokerAWSCredentials := cd.Spec.Platform.AWS != nil &&
+		credentialsbroker.BrokersAWS(credentialsBrokerConfig, cd.Spec.Platform.AWS.CredentialsSecretRef.Name, cd.Spec.Platform.AWS.CredentialsAssumeRole)
+	if !brokerAWSCredentials {
+		extraEnvVars = append(extraEnvVars, getAWSServiceProviderEnvVars(cd, cd.Name)...)
+	}
+	externalSecretsEnvVars, err := getExternalSecretsEnvVars()
+	if err != nil {
+		logger.WithError(err).Error("failed to read external secrets config")
+		return reconcile.Result{}, err
+	}
+	extraEnvVars = append(extraEnvVars, externalSecretsEnvVars...)
+
+	imageOverrides, err := readInstallManagerImageOverrides()
+	if err != nil {
+		logger.WithError(err).Error("failed to read install manager image overrides")
+		return reconcile.Result{}, err
+	}
+	imageOverride := installManagerImageOverrideForVersion(imageOverrides, cd.Status.InstallVersion)
+	if imageOverride != nil {
+		logger.WithField("openShiftVersion", imageOverride.OpenShiftVersion).Info("overriding install manager images")
+	}
 
 	podSpec, err := install.InstallerPodSpec(
 		cd,
@@ -153,6 +186,7 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		os.Getenv("HTTPS_PROXY"),
 		os.Getenv("NO_PROXY"),
 		extraEnvVars,
+		imageOverride,
 	)
 	if err != nil {
 		logger.WithError(err).Error("could not generate installer pod spec")
@@ -203,7 +237,13 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		return reconcile.Result{}, err
 	}
 
-	if err := r.setupAWSCredentialForAssumeRole(cd); err != nil {
+	if brokerAWSCredentials {
+		if err := credentialsbroker.MintAWSCredentials(r.Client, credentialsBrokerConfig.AWS, cd.Spec.Platform.AWS.CredentialsAssumeRole,
+			provisionName, install.AWSAssumeRoleSecretName(cd.Name), cd.Namespace, cd, r.scheme); err != nil {
+			logger.WithError(err).Error("could not mint AWS credentials for provision")
+			return reconcile.Result{}, err
+		}
+	} else if err := r.setupAWSCredentialForAssumeRole(cd); err != nil {
 		if !apierrors.IsAlreadyExists(err) {
 			// Couldn't create the assume role credential secret for a reason other than it already exists.
 			// If the secret already exists, then we should just use that secret.
@@ -302,6 +342,13 @@ func (r *ReconcileClusterDeployment) reconcileExistingProvision(cd *hivev1.Clust
 		if provision.Spec.AdminPasswordSecretRef != nil {
 			clusterMetadata.AdminPasswordSecretRef = provision.Spec.AdminPasswordSecretRef
 		}
+		if provision.Spec.Metadata != nil {
+			if metadata, err := controllerutils.ParseInstallerMetadata(provision.Spec.Metadata.Raw); err != nil {
+				logger.WithError(err).Warn("could not parse installer metadata of provision")
+			} else {
+				clusterMetadata.Platform = controllerutils.ClusterPlatformMetadata(metadata)
+			}
+		}
 		if !reflect.DeepEqual(clusterMetadata, cd.Spec.ClusterMetadata) {
 			cd.Spec.ClusterMetadata = clusterMetadata
 			logger.Infof("Saving infra ID %q for cluster", cd.Spec.ClusterMetadata.InfraID)
@@ -468,6 +515,9 @@ func (r *ReconcileClusterDeployment) reconcileCompletedProvision(cd *hivev1.Clus
 		statusChange = true
 		cd.Status.Conditions = conds
 	}
+	if setPlatformStatusFromProvision(cd, provision) {
+		statusChange = true
+	}
 	if statusChange {
 		if err := r.Status().Update(context.TODO(), cd); err != nil {
 			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
@@ -512,9 +562,46 @@ func (r *ReconcileClusterDeployment) reconcileCompletedProvision(cd *hivev1.Clus
 
 	metricClustersInstalled.WithLabelValues(hivemetrics.GetClusterDeploymentType(cd)).Inc()
 
+	notification.Notify(notification.NewEvent(hivev1.ProvisionSucceededNotificationEvent, cd,
+		fmt.Sprintf("Provision %s succeeded", provision.Name)), cdLog)
+
 	return reconcile.Result{}, nil
 }
 
+// setPlatformStatusFromProvision copies the platform resources captured by the provision into the platform status of
+// the ClusterDeployment. The platform status maintained by other controllers, such as the PrivateLink status, is kept.
+// Returns true if the platform status changed.
+func setPlatformStatusFromProvision(cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision) bool {
+	provisioned := provision.Spec.PlatformStatus
+	if provisioned == nil {
+		return false
+	}
+	status := cd.Status.Platform.DeepCopy()
+	if status == nil {
+		status = &hivev1.PlatformStatus{}
+	}
+	if provisioned.AWS != nil {
+		if status.AWS == nil {
+			status.AWS = &hivev1aws.PlatformStatus{}
+		}
+		status.AWS.VPCID = provisioned.AWS.VPCID
+		status.AWS.SubnetIDs = provisioned.AWS.SubnetIDs
+		status.AWS.PrivateHostedZoneID = provisioned.AWS.PrivateHostedZoneID
+		status.AWS.UserProvidedNetwork = provisioned.AWS.UserProvidedNetwork.DeepCopy()
+	}
+	if provisioned.Azure != nil {
+		status.Azure = provisioned.Azure.DeepCopy()
+	}
+	if provisioned.GCP != nil {
+		status.GCP = provisioned.GCP.DeepCopy()
+	}
+	if reflect.DeepEqual(status, cd.Status.Platform) {
+		return false
+	}
+	cd.Status.Platform = status
+	return true
+}
+
 func getClusterImageSetFromProvisioning(cd *hivev1.ClusterDeployment) string {
 	if cd.Spec.Provisioning.ImageSetRef != nil {
 		return cd.Spec.Provisioning.ImageSetRef.Name
@@ -586,6 +673,46 @@ func readProvisionFailedConfig() (*hivev1.FailedProvisionConfig, error) {
 	return config, nil
 }
 
+func readInstallManagerImageOverrides() ([]hivev1.InstallManagerImageOverride, error) {
+	path := os.Getenv(constants.InstallManagerImageOverridesFileEnvVar)
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	fileBytes, err := readFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil || len(fileBytes) == 0 {
+		return nil, err
+	}
+	var overrides []hivev1.InstallManagerImageOverride
+	if err := json.Unmarshal(fileBytes, &overrides); err != nil {
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+// installManagerImageOverrideForVersion returns the image override for the minor version of the given OpenShift
+// version, or nil if there is none or the version is unknown.
+func installManagerImageOverrideForVersion(overrides []hivev1.InstallManagerImageOverride, version *string) *hivev1.InstallManagerImageOverride {
+	if len(overrides) == 0 || version == nil {
+		return nil
+	}
+	v, err := semver.ParseTolerant(*version)
+	if err != nil {
+		return nil
+	}
+	minorVersion := fmt.Sprintf("%d.%d", v.Major, v.Minor)
+	for i := range overrides {
+		if overrides[i].OpenShiftVersion == minorVersion {
+			return &overrides[i]
+		}
+	}
+	return nil
+}
+
 func getInstallLogEnvVars(secretPrefix string) ([]corev1.EnvVar, error) {
 	var extraEnvVars = []corev1.EnvVar{}
 	fpConfig, err := readProvisionFailedConfig()
@@ -639,6 +766,23 @@ func getAWSServiceProviderEnvVars(cd *hivev1.ClusterDeployment, secretPrefix str
 	return extraEnvVars
 }
 
+// getExternalSecretsEnvVars passes the configuration of the external secret provider to the install pod, so that
+// the installmanager can resolve credentials secrets referencing the provider.
+func getExternalSecretsEnvVars() ([]corev1.EnvVar, error) {
+	config, err := externalsecret.ReadConfig()
+	if err != nil || config == nil {
+		return nil, err
+	}
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the external secrets config")
+	}
+	return []corev1.EnvVar{{
+		Name:  constants.ExternalSecretsConfigEnvVar,
+		Value: string(configJSON),
+	}}, nil
+}
+
 func (r *ReconcileClusterDeployment) setupAWSCredentialForAssumeRole(cd *hivev1.ClusterDeployment) error {
 	if cd.Spec.Platform.AWS == nil ||
 		cd.Spec.Platform.AWS.CredentialsSecretRef.Name != "" ||
@@ -1,13 +1,14 @@
 package clusterdeprovision
 
 import (
+	"context"
 	"os"
 
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
@@ -40,7 +41,7 @@ func (a *awsActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovis
 		return err
 	}
 
-	_, err = awsClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	_, err = awsClient.GetCallerIdentity(context.TODO(), &sts.GetCallerIdentityInput{})
 	if err != nil {
 		// Code "InvalidClientTokenId" means aws_access_key_id is invalid
 		// Code "SignatureDoesNotMatch" means aws_secret_access_key is invalid
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
@@ -32,7 +34,11 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/credentialsbroker"
+	"github.com/openshift/hive/pkg/executioncluster"
 	"github.com/openshift/hive/pkg/install"
+	"github.com/openshift/hive/pkg/notification"
+	"github.com/openshift/hive/pkg/remoteclient"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
 
@@ -41,6 +47,10 @@ const (
 	jobHashAnnotation             = "hive.openshift.io/jobhash"
 	authenticationFailedReason    = "AuthenticationFailed"
 	authenticationSucceededReason = "AuthenticationSucceeded"
+
+	// executionClusterPollInterval is how often uninstall jobs running on an execution cluster are checked, since
+	// they cannot be watched.
+	executionClusterPollInterval = 30 * time.Second
 )
 
 var (
@@ -96,18 +106,24 @@ func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (re
 			return nil, err
 		}
 	}
-	return &ReconcileClusterDeprovision{
+	r := &ReconcileClusterDeprovision{
 		Client:               controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		scheme:               mgr.GetScheme(),
 		deprovisionsDisabled: deprovisionsDisabled,
-	}, nil
+
+		buildExecutionClusterClient: executioncluster.BuildClient,
+	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r, nil
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("clusterdeprovision-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -143,6 +159,14 @@ type ReconcileClusterDeprovision struct {
 	client.Client
 	scheme               *runtime.Scheme
 	deprovisionsDisabled bool
+
+	// buildExecutionClusterClient builds the client for the execution cluster running uninstall jobs, when one is
+	// configured in HiveConfig.
+	buildExecutionClusterClient func(client.Client, *hivev1.ExecutionClusterConfig) (client.Client, error)
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server, against which teardown hooks are run.
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeprovision object and makes changes based on the state read
@@ -181,6 +205,13 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		return reconcile.Result{}, nil
 	}
 
+	if setReadyCondition(instance) {
+		if err := r.Status().Update(context.Background(), instance); err != nil {
+			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating status")
+			return reconcile.Result{}, err
+		}
+	}
+
 	if instance.Status.Completed {
 		rLog.Debug("clusterdeprovision is complete, skipping")
 		return reconcile.Result{}, nil
@@ -220,6 +251,16 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		return reconcile.Result{}, nil
 	}
 
+	// Teardown hooks need the cluster, so they are run before anything is done towards deprovisioning it.
+	hooksDone, err := r.runTeardownHooks(instance, cd, rLog)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !hooksDone {
+		rLog.Info("waiting for teardown hooks")
+		return reconcile.Result{RequeueAfter: controllerutils.TeardownHookPollInterval}, nil
+	}
+
 	actuator := r.getActuator(instance)
 	if actuator == nil {
 		rLog.Debug("No actuator found for this provider")
@@ -240,6 +281,7 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 
 			if changed {
 				instance.Status.Conditions = conditions
+				setReadyCondition(instance)
 				if updateErr := r.Status().Update(context.Background(), instance); updateErr != nil {
 					return reconcile.Result{}, updateErr
 				}
@@ -260,25 +302,40 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 
 		if changed {
 			instance.Status.Conditions = conditions
+			setReadyCondition(instance)
 			if err := r.Status().Update(context.Background(), instance); err != nil {
 				return reconcile.Result{}, err
 			}
 		}
 	}
 
-	extraEnvVars := getAWSServiceProviderEnvVars(instance, instance.Name)
+	credentialsBrokerConfig, err := credentialsbroker.ReadConfig()
+	if err != nil {
+		rLog.WithError(err).Error("failed to read credentials broker config")
+		return reconcile.Result{}, err
+	}
+	// When the credentials broker mints the AWS credentials of the uninstall job, the job has no use for the service
+	// provider credentials, so they are not copied to the namespace of the cluster.
+	brokerAWSCredentials := brokersAWSCredentials(instance, credentialsBrokerConfig)
+	var extraEnvVars []corev1.EnvVar
+	if !brokerAWSCredentials {
+		extraEnvVars = getAWSServiceProviderEnvVars(instance, instance.Name)
+	}
 
 	if err := install.CopyAWSServiceProviderSecret(r.Client, instance.Namespace, extraEnvVars, instance, r.scheme); err != nil {
 		rLog.WithError(err).Error("could not copy AWS service provider secret")
 		return reconcile.Result{}, err
 	}
 
-	if err := r.setupAWSCredentialForAssumeRole(instance); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			// Couldn't create the assume role credentials secret for a reason other than it already exists.
-			// If the secret already exists, then we should just use that secret.
-			rLog.WithError(err).Error("could not create assume role AWS secret")
-			return reconcile.Result{}, err
+	// Brokered credentials are instead minted when the uninstall job is created, below.
+	if !brokerAWSCredentials {
+		if err := r.setupAWSCredentialForAssumeRole(instance); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				// Couldn't create the assume role credentials secret for a reason other than it already exists.
+				// If the secret already exists, then we should just use that secret.
+				rLog.WithError(err).Error("could not create assume role AWS secret")
+				return reconcile.Result{}, err
+			}
 		}
 	}
 
@@ -287,6 +344,24 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		return reconcile.Result{}, err
 	}
 
+	// Uninstall jobs run on the hub unless an execution cluster is configured.
+	var jobClient client.Client = r.Client
+	execConfig, err := executioncluster.ReadConfig()
+	if err != nil {
+		rLog.WithError(err).Error("failed to read execution cluster config")
+		return reconcile.Result{}, err
+	}
+	// Uninstall jobs on the execution cluster are not watched, so they are polled until the deprovision completes.
+	var requeueResult reconcile.Result
+	if execConfig != nil {
+		jobClient, err = r.buildExecutionClusterClient(r.Client, execConfig)
+		if err != nil {
+			rLog.WithError(err).Error("failed to build client for execution cluster")
+			return reconcile.Result{}, err
+		}
+		requeueResult.RequeueAfter = executionClusterPollInterval
+	}
+
 	// Generate an uninstall job
 	rLog.Debug("generating uninstall job")
 	uninstallJob, err := install.GenerateUninstallerJobForDeprovision(instance,
@@ -322,15 +397,28 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 
 	// Check if uninstall job already exists:
 	existingJob := &batchv1.Job{}
-	err = r.Get(context.TODO(), types.NamespacedName{Name: uninstallJob.Name, Namespace: uninstallJob.Namespace}, existingJob)
+	err = jobClient.Get(context.TODO(), types.NamespacedName{Name: uninstallJob.Name, Namespace: uninstallJob.Namespace}, existingJob)
 	if err != nil && errors.IsNotFound(err) {
 		rLog.Debug("uninstall job does not exist, creating it")
-		err = r.Create(context.TODO(), uninstallJob)
+		if brokerAWSCredentials {
+			if err := credentialsbroker.MintAWSCredentials(r.Client, credentialsBrokerConfig.AWS, instance.Spec.Platform.AWS.CredentialsAssumeRole,
+				uninstallJob.Name, install.AWSAssumeRoleSecretName(instance.Name), instance.Namespace, instance, r.scheme); err != nil {
+				rLog.WithError(err).Error("could not mint AWS credentials for uninstall job")
+				return reconcile.Result{}, err
+			}
+		}
+		if execConfig != nil {
+			if err := executioncluster.PrepareJob(r.Client, jobClient, execConfig, uninstallJob, rLog); err != nil {
+				rLog.WithError(err).Error("error preparing execution cluster for uninstall job")
+				return reconcile.Result{}, err
+			}
+		}
+		err = jobClient.Create(context.TODO(), uninstallJob)
 		if err != nil {
 			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error creating uninstall job")
 			return reconcile.Result{}, err
 		}
-		return reconcile.Result{}, nil
+		return requeueResult, nil
 	} else if err != nil {
 		rLog.WithError(err).Errorf("error getting uninstall job")
 		return reconcile.Result{}, err
@@ -354,12 +442,21 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		jobDuration := existingJob.Status.CompletionTime.Time.Sub(existingJob.Status.StartTime.Time)
 		rLog.WithField("duration", jobDuration.Seconds()).Debug("uninstall job completed")
 		instance.Status.Completed = true
+		setReadyCondition(instance)
 		err = r.Status().Update(context.TODO(), instance)
 		if err != nil {
 			rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating request status")
 			return reconcile.Result{}, err
 		}
 		metricUninstallJobDuration.Observe(float64(jobDuration.Seconds()))
+		notification.Notify(notification.Event{
+			Type:              hivev1.DeprovisionCompletedNotificationEvent,
+			ClusterDeployment: instance.Name,
+			Namespace:         instance.Namespace,
+			InfraID:           instance.Spec.InfraID,
+			Message:           "Deprovision has succeeded",
+			Time:              metav1.Now(),
+		}, rLog)
 		return reconcile.Result{}, nil
 	}
 
@@ -389,6 +486,7 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		)
 		if changed {
 			instance.Status.Conditions = conditions
+			setReadyCondition(instance)
 			if err := r.Status().Update(context.Background(), instance); err != nil {
 				return reconcile.Result{}, err
 			}
@@ -398,17 +496,58 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 	if newJobNeeded {
 		if existingJob.DeletionTimestamp == nil {
 			rLog.Info("deleting existing deprovision job due to updated/missing hash detected")
-			err := r.Delete(context.TODO(), existingJob, client.PropagationPolicy(metav1.DeletePropagationForeground))
+			err := jobClient.Delete(context.TODO(), existingJob, client.PropagationPolicy(metav1.DeletePropagationForeground))
 			if err != nil {
 				rLog.WithError(err).Log(controllerutils.LogLevel(err), "error deleting outdated deprovision job")
 				return reconcile.Result{}, err
 			}
 		}
-		return reconcile.Result{}, nil
+		return requeueResult, nil
 	}
 
 	rLog.Infof("uninstall job not yet successful")
-	return reconcile.Result{}, nil
+	return requeueResult, nil
+}
+
+// setReadyCondition sets the Ready condition of the deprovision from its completion, conditions and teardown hooks, and
+// records the generation of the deprovision in its status and conditions. It returns whether the status changed.
+func setReadyCondition(instance *hivev1.ClusterDeprovision) bool {
+	origStatus := instance.Status.DeepCopy()
+	status, reason, message := corev1.ConditionFalse, "DeprovisionInProgress", "The cluster is being uninstalled"
+	authCond := controllerutils.FindClusterDeprovisionCondition(instance.Status.Conditions, hivev1.AuthenticationFailureClusterDeprovisionCondition)
+	failedCond := controllerutils.FindClusterDeprovisionCondition(instance.Status.Conditions, hivev1.DeprovisionFailedClusterDeprovisionCondition)
+	switch {
+	case instance.Status.Completed:
+		status, reason, message = corev1.ConditionTrue, "DeprovisionCompleted", "The uninstall of the cluster has completed"
+	case authCond != nil && authCond.Status == corev1.ConditionTrue:
+		reason, message = authCond.Reason, authCond.Message
+	case failedCond != nil && failedCond.Status == corev1.ConditionTrue:
+		reason, message = failedCond.Reason, failedCond.Message
+	default:
+		for _, hook := range instance.Status.TeardownHooks {
+			if hook.State == hivev1.RunningTeardownHookState {
+				reason, message = "TeardownHooksRunning", fmt.Sprintf("Waiting for teardown hook %s", hook.Name)
+				break
+			}
+		}
+	}
+	instance.Status.Conditions = controllerutils.InitializeClusterDeprovisionConditions(
+		instance.Status.Conditions,
+		[]hivev1.ClusterDeprovisionConditionType{hivev1.ReadyClusterDeprovisionCondition},
+	)
+	instance.Status.Conditions = controllerutils.SetClusterDeprovisionCondition(
+		instance.Status.Conditions,
+		hivev1.ReadyClusterDeprovisionCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	instance.Status.ObservedGeneration = instance.Generation
+	for i := range instance.Status.Conditions {
+		instance.Status.Conditions[i].ObservedGeneration = instance.Generation
+	}
+	return !reflect.DeepEqual(origStatus, &instance.Status)
 }
 
 func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerutils.OwnershipUniqueKey {
@@ -425,6 +564,10 @@ func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerut
 }
 
 func (r *ReconcileClusterDeprovision) getActuator(cd *hivev1.ClusterDeprovision) Actuator {
+	// Fake clusters are deprovisioned from a simulated cloud inventory, so they have no credentials to test.
+	if controllerutils.IsFakeClusterDeprovision(cd) {
+		return nil
+	}
 	for _, a := range actuators {
 		if a.CanHandle(cd) {
 			return a
@@ -440,7 +583,7 @@ func getAWSServiceProviderEnvVars(cd *hivev1.ClusterDeprovision, secretPrefix st
 		return extraEnvVars
 	}
 
-	if cd.Spec.Platform.AWS == nil {
+	if cd.Spec.Platform.AWS == nil || controllerutils.IsFakeClusterDeprovision(cd) {
 		return extraEnvVars
 	}
 
@@ -451,8 +594,19 @@ func getAWSServiceProviderEnvVars(cd *hivev1.ClusterDeprovision, secretPrefix st
 	return extraEnvVars
 }
 
+func brokersAWSCredentials(cd *hivev1.ClusterDeprovision, config *hivev1.CredentialsBrokerConfig) bool {
+	if cd.Spec.Platform.AWS == nil || controllerutils.IsFakeClusterDeprovision(cd) {
+		return false
+	}
+	var credentialsSecretName string
+	if cd.Spec.Platform.AWS.CredentialsSecretRef != nil {
+		credentialsSecretName = cd.Spec.Platform.AWS.CredentialsSecretRef.Name
+	}
+	return credentialsbroker.BrokersAWS(config, credentialsSecretName, cd.Spec.Platform.AWS.CredentialsAssumeRole)
+}
+
 func (r *ReconcileClusterDeprovision) setupAWSCredentialForAssumeRole(cd *hivev1.ClusterDeprovision) error {
-	if cd.Spec.Platform.AWS == nil ||
+	if cd.Spec.Platform.AWS == nil || controllerutils.IsFakeClusterDeprovision(cd) ||
 		cd.Spec.Platform.AWS.CredentialsSecretRef.Name != "" ||
 		cd.Spec.Platform.AWS.CredentialsAssumeRole == nil {
 		// no setup required
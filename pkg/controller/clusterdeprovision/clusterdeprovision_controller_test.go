@@ -2,10 +2,9 @@ package clusterdeprovision
 
 import (
 	"context"
-	"fmt"
 	"testing"
 
-	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/smithy-go"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -116,6 +115,45 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 				validateJobExists(t, c)
 			},
 		},
+		{
+			name:        "create uninstall job after skipping teardown hooks",
+			deprovision: testClusterDeprovision(),
+			deployment: func() *hivev1.ClusterDeployment {
+				cd := testDeletedClusterDeployment()
+				cd.Spec.PowerState = hivev1.HibernatingClusterPowerState
+				cd.Spec.TeardownHooks = []hivev1.TeardownHook{{Name: "deregister"}}
+				return cd
+			}(),
+			mockGetCallerIdentity: true,
+			validate: func(t *testing.T, c client.Client) {
+				validateJobExists(t, c)
+				req := &hivev1.ClusterDeprovision{}
+				require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, req))
+				require.Len(t, req.Status.TeardownHooks, 1, "expected teardown hook status")
+				assert.Equal(t, hivev1.SkippedTeardownHookState, req.Status.TeardownHooks[0].State, "unexpected teardown hook state")
+			},
+		},
+		{
+			name: "create fake uninstall job without checking credentials",
+			deprovision: func() *hivev1.ClusterDeprovision {
+				req := testClusterDeprovision()
+				req.Annotations = map[string]string{
+					constants.HiveFakeClusterAnnotation:        "true",
+					constants.HiveFakeCloudInventoryAnnotation: `{"resources":[]}`,
+				}
+				return req
+			}(),
+			deployment: testDeletedClusterDeployment(),
+			validate: func(t *testing.T, c client.Client) {
+				validateJobExists(t, c)
+				job := &batchv1.Job{}
+				require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName + "-uninstall"}, job))
+				containers := job.Spec.Template.Spec.Containers
+				require.Len(t, containers, 1, "expected a single container")
+				assert.Equal(t, []string{"deprovision", "fake", "--loglevel", "debug", "test-infra-id"}, containers[0].Args, "unexpected args")
+				assert.Contains(t, containers[0].Env, corev1.EnvVar{Name: constants.FakeCloudInventoryEnvVar, Value: `{"resources":[]}`}, "expected inventory env var")
+			},
+		},
 		{
 			name:                 "do not create uninstall job when deprovisions are disabled",
 			deprovision:          testClusterDeprovision(),
@@ -179,6 +217,11 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 			mockGetCallerIdentity: true,
 			validate: func(t *testing.T, c client.Client) {
 				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.ReadyClusterDeprovisionCondition,
+						Reason: "UnknownError",
+						Status: corev1.ConditionFalse,
+					},
 					{
 						Type:    hivev1.DeprovisionFailedClusterDeprovisionCondition,
 						Reason:  "UnknownError",
@@ -202,6 +245,11 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 			mockGetCallerIdentity: true,
 			validate: func(t *testing.T, c client.Client) {
 				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.ReadyClusterDeprovisionCondition,
+						Reason: "AttemptDeadlineExceeded",
+						Status: corev1.ConditionFalse,
+					},
 					{
 						Type:    hivev1.DeprovisionFailedClusterDeprovisionCondition,
 						Reason:  "AttemptDeadlineExceeded",
@@ -222,9 +270,14 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 				}),
 			},
 			mockGetCallerIdentity:          true,
-			expectedGetCallerIdentityError: awserr.New("InvalidClientTokenId", "", fmt.Errorf("")),
+			expectedGetCallerIdentityError: &smithy.GenericAPIError{Code: "InvalidClientTokenId"},
 			validate: func(t *testing.T, c client.Client) {
 				validateCondition(t, c, []hivev1.ClusterDeprovisionCondition{
+					{
+						Type:   hivev1.ReadyClusterDeprovisionCondition,
+						Reason: "AuthenticationFailed",
+						Status: corev1.ConditionFalse,
+					},
 					{
 						Type:   hivev1.AuthenticationFailureClusterDeprovisionCondition,
 						Reason: "AuthenticationFailed",
@@ -288,7 +341,7 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 
 			if test.mockGetCallerIdentity {
 				mocks.mockAWSClient.EXPECT().
-					GetCallerIdentity(gomock.Any()).
+					GetCallerIdentity(gomock.Any(), gomock.Any()).
 					Return(nil, test.expectedGetCallerIdentityError)
 			}
 
@@ -456,4 +509,10 @@ func validateCompleted(t *testing.T, c client.Client) {
 	if !req.Status.Completed {
 		t.Errorf("request is expected to be in completed state")
 	}
+	readyCondition := controllerutils.FindClusterDeprovisionCondition(req.Status.Conditions, hivev1.ReadyClusterDeprovisionCondition)
+	if assert.NotNil(t, readyCondition, "expected Ready condition") {
+		assert.Equal(t, corev1.ConditionTrue, readyCondition.Status, "unexpected Ready condition status")
+		assert.Equal(t, req.Generation, readyCondition.ObservedGeneration, "unexpected Ready condition observed generation")
+	}
+	assert.Equal(t, req.Generation, req.Status.ObservedGeneration, "unexpected observed generation")
 }
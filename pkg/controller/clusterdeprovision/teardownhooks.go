@@ -0,0 +1,51 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// runTeardownHooks runs the teardown hooks of the ClusterDeployment one at a time, recording their progress in the
+// status of the ClusterDeprovision. It returns true once no hook holds back the uninstall job any longer.
+func (r *ReconcileClusterDeprovision) runTeardownHooks(instance *hivev1.ClusterDeprovision, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (bool, error) {
+	if len(cd.Spec.TeardownHooks) == 0 {
+		return true, nil
+	}
+	original := instance.Status.DeepCopy().TeardownHooks
+	done := controllerutils.ProgressTeardownHooks(
+		cd,
+		cd.Spec.TeardownHooks,
+		&instance.Status.TeardownHooks,
+		teardownHookSkipReason(cd),
+		func() (client.Client, error) { return r.remoteClusterAPIClientBuilder(cd).Build() },
+		logger,
+	)
+	if reflect.DeepEqual(original, instance.Status.TeardownHooks) {
+		return done, nil
+	}
+	setReadyCondition(instance)
+	if err := r.Status().Update(context.TODO(), instance); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating teardown hook status")
+		return false, err
+	}
+	return done, nil
+}
+
+// teardownHookSkipReason returns why teardown hooks cannot be run against the cluster, if they cannot.
+func teardownHookSkipReason(cd *hivev1.ClusterDeployment) string {
+	switch {
+	case !cd.Spec.Installed:
+		return "Cluster was never installed"
+	case controllerutils.IsFakeCluster(cd):
+		return "Cluster is fake"
+	case cd.Spec.PowerState == hivev1.HibernatingClusterPowerState:
+		return "Cluster is hibernating"
+	}
+	return ""
+}
@@ -0,0 +1,245 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+)
+
+func TestRunTeardownHooks(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	job := func(name string, conditionType batchv1.JobConditionType) *batchv1.Job {
+		j := &batchv1.Job{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "hooks", Name: name},
+		}
+		if conditionType != "" {
+			j.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+		}
+		return j
+	}
+	configMap := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "hooks", Name: "deregister-config"},
+		Data:       map[string]string{"endpoint": "https://registry.example.com"},
+	}
+	hook := func(name string, objs ...runtime.Object) hivev1.TeardownHook {
+		h := hivev1.TeardownHook{Name: name}
+		for _, obj := range objs {
+			raw, err := json.Marshal(obj)
+			require.NoError(t, err, "unexpected error marshalling resource")
+			h.Resources = append(h.Resources, runtime.RawExtension{Raw: raw})
+		}
+		return h
+	}
+	started := func(name string, ago time.Duration) hivev1.TeardownHookStatus {
+		return hivev1.TeardownHookStatus{
+			Name:      name,
+			State:     hivev1.RunningTeardownHookState,
+			StartTime: &metav1.Time{Time: time.Now().Add(-ago)},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		hooks              []hivev1.TeardownHook
+		status             []hivev1.TeardownHookStatus
+		mutateCD           func(*hivev1.ClusterDeployment)
+		remote             []runtime.Object
+		buildErr           error
+		expectDone         bool
+		expectStates       map[string]hivev1.TeardownHookState
+		expectMessages     map[string]string
+		expectRemote       []client.Object
+		expectNoRemoteJobs bool
+	}{
+		{
+			name:       "no hooks",
+			expectDone: true,
+		},
+		{
+			name:         "start hook",
+			hooks:        []hivev1.TeardownHook{hook("deregister", configMap, job("deregister", ""))},
+			expectStates: map[string]hivev1.TeardownHookState{"deregister": hivev1.RunningTeardownHookState},
+			expectMessages: map[string]string{
+				"deregister": "Waiting for Jobs [hooks/deregister]",
+			},
+			expectRemote: []client.Object{&corev1.ConfigMap{}, &batchv1.Job{}},
+		},
+		{
+			name: "hooks run one at a time",
+			hooks: []hivev1.TeardownHook{
+				hook("deregister", job("deregister", "")),
+				hook("drain", job("drain", "")),
+			},
+			expectStates: map[string]hivev1.TeardownHookState{"deregister": hivev1.RunningTeardownHookState},
+		},
+		{
+			name: "hook succeeded",
+			hooks: []hivev1.TeardownHook{
+				hook("deregister", job("deregister", "")),
+				hook("drain", configMap),
+			},
+			status:     []hivev1.TeardownHookStatus{started("deregister", time.Minute)},
+			remote:     []runtime.Object{job("deregister", batchv1.JobComplete)},
+			expectDone: true,
+			expectStates: map[string]hivev1.TeardownHookState{
+				"deregister": hivev1.SucceededTeardownHookState,
+				"drain":      hivev1.SucceededTeardownHookState,
+			},
+		},
+		{
+			name:           "hook failed",
+			hooks:          []hivev1.TeardownHook{hook("deregister", job("deregister", ""))},
+			status:         []hivev1.TeardownHookStatus{started("deregister", time.Minute)},
+			remote:         []runtime.Object{job("deregister", batchv1.JobFailed)},
+			expectDone:     true,
+			expectStates:   map[string]hivev1.TeardownHookState{"deregister": hivev1.FailedTeardownHookState},
+			expectMessages: map[string]string{"deregister": "Job hooks/deregister failed"},
+		},
+		{
+			name: "hook timed out",
+			hooks: func() []hivev1.TeardownHook {
+				h := hook("deregister", job("deregister", ""))
+				h.Timeout = &metav1.Duration{Duration: 5 * time.Minute}
+				return []hivev1.TeardownHook{h}
+			}(),
+			status:       []hivev1.TeardownHookStatus{started("deregister", 6*time.Minute)},
+			remote:       []runtime.Object{job("deregister", "")},
+			expectDone:   true,
+			expectStates: map[string]hivev1.TeardownHookState{"deregister": hivev1.TimedOutTeardownHookState},
+		},
+		{
+			name:         "wait for unreachable cluster",
+			hooks:        []hivev1.TeardownHook{hook("deregister", job("deregister", ""))},
+			status:       []hivev1.TeardownHookStatus{started("deregister", time.Minute)},
+			buildErr:     fmt.Errorf("connection refused"),
+			expectStates: map[string]hivev1.TeardownHookState{"deregister": hivev1.RunningTeardownHookState},
+			expectMessages: map[string]string{
+				"deregister": "Could not connect to the cluster: connection refused",
+			},
+		},
+		{
+			name:         "skip for hibernating cluster",
+			hooks:        []hivev1.TeardownHook{hook("deregister", job("deregister", ""))},
+			mutateCD:     func(cd *hivev1.ClusterDeployment) { cd.Spec.PowerState = hivev1.HibernatingClusterPowerState },
+			expectDone:   true,
+			expectStates: map[string]hivev1.TeardownHookState{"deregister": hivev1.SkippedTeardownHookState},
+		},
+		{
+			name:  "skip for fake cluster",
+			hooks: []hivev1.TeardownHook{hook("deregister", job("deregister", ""))},
+			mutateCD: func(cd *hivev1.ClusterDeployment) {
+				cd.Annotations = map[string]string{constants.HiveFakeClusterAnnotation: "true"}
+			},
+			expectDone:   true,
+			expectStates: map[string]hivev1.TeardownHookState{"deregister": hivev1.SkippedTeardownHookState},
+		},
+		{
+			name:  "skip for cluster fenced to another hub",
+			hooks: []hivev1.TeardownHook{hook("deregister", job("deregister", "")), hook("drain", job("drain", ""))},
+			remote: []runtime.Object{&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        controllerutils.RelocationFenceNamespace,
+					Annotations: map[string]string{constants.RelocationFencingTokenAnnotation: "other-hub"},
+				},
+			}},
+			expectDone: true,
+			expectStates: map[string]hivev1.TeardownHookState{
+				"deregister": hivev1.SkippedTeardownHookState,
+				"drain":      hivev1.SkippedTeardownHookState,
+			},
+			expectMessages: map[string]string{
+				"deregister": "Cluster is fenced to another hub by a relocation",
+				"drain":      "Cluster is fenced to another hub by a relocation",
+			},
+			expectNoRemoteJobs: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cd := testDeletedClusterDeployment()
+			cd.Spec.TeardownHooks = test.hooks
+			if test.mutateCD != nil {
+				test.mutateCD(cd)
+			}
+			deprovision := testClusterDeprovision()
+			deprovision.Status.TeardownHooks = test.status
+			hubClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(deprovision).Build()
+			remoteClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(test.remote...).Build()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, test.buildErr).AnyTimes()
+
+			r := &ReconcileClusterDeprovision{
+				Client: hubClient,
+				scheme: scheme.Scheme,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder {
+					return mockRemoteClientBuilder
+				},
+			}
+			done, err := r.runTeardownHooks(deprovision, cd, log.WithField("test", test.name))
+			require.NoError(t, err, "unexpected error running teardown hooks")
+			assert.Equal(t, test.expectDone, done, "unexpected done")
+
+			actual := &hivev1.ClusterDeprovision{}
+			require.NoError(t, hubClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, actual))
+			states := map[string]hivev1.TeardownHookState{}
+			for _, status := range actual.Status.TeardownHooks {
+				states[status.Name] = status.State
+				if status.State != hivev1.SkippedTeardownHookState {
+					assert.NotNil(t, status.StartTime, "expected start time for hook %s", status.Name)
+				}
+				if status.State != hivev1.RunningTeardownHookState {
+					assert.NotNil(t, status.CompletionTime, "expected completion time for hook %s", status.Name)
+				}
+				if expected, ok := test.expectMessages[status.Name]; ok {
+					assert.Equal(t, expected, status.Message, "unexpected message for hook %s", status.Name)
+				}
+			}
+			if len(test.expectStates) == 0 {
+				assert.Empty(t, states, "unexpected teardown hook status")
+			} else {
+				assert.Equal(t, test.expectStates, states, "unexpected teardown hook states")
+			}
+			for _, obj := range test.expectRemote {
+				key := client.ObjectKey{Namespace: "hooks", Name: "deregister"}
+				if _, ok := obj.(*corev1.ConfigMap); ok {
+					key.Name = "deregister-config"
+				}
+				assert.NoError(t, remoteClient.Get(context.TODO(), key, obj), "expected %T %s to be created", obj, key)
+			}
+			if test.expectNoRemoteJobs {
+				jobs := &batchv1.JobList{}
+				require.NoError(t, remoteClient.List(context.TODO(), jobs))
+				assert.Empty(t, jobs.Items, "expected no jobs to be created in the cluster")
+			}
+		})
+	}
+}
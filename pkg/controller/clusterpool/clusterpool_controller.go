@@ -33,6 +33,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
 )
 
 const (
@@ -57,6 +58,7 @@ var (
 		hivev1.ClusterPoolMissingDependenciesCondition,
 		hivev1.ClusterPoolCapacityAvailableCondition,
 		hivev1.ClusterPoolAllClustersCurrentCondition,
+		hivev1.ClusterPoolCredentialsCurrentCondition,
 	}
 )
 
@@ -79,18 +81,22 @@ func Add(mgr manager.Manager) error {
 // NewReconciler returns a new ReconcileClusterPool
 func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileClusterPool {
 	logger := log.WithField("controller", ControllerName)
-	return &ReconcileClusterPool{
+	r := &ReconcileClusterPool{
 		Client:       controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		logger:       logger,
 		expectations: controllerutils.NewExpectations(logger),
 	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
 }
 
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r *ReconcileClusterPool, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("clusterpool-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -163,6 +169,15 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterPool, concurrentReconc
 		return err
 	}
 
+	// Watch for changes to the credentials Secrets of pools
+	if err := c.Watch(
+		&source.Kind{Type: &corev1.Secret{}},
+		handler.EnqueueRequestsFromMapFunc(
+			requestsForCredentialsSecret(r.Client, r.logger)),
+	); err != nil {
+		return err
+	}
+
 	// Watch for changes to the hive-cluster-pool-admin-binding RoleBinding
 	if err := c.Watch(
 		&source.Kind{Type: &rbacv1.RoleBinding{}},
@@ -230,6 +245,10 @@ type ReconcileClusterPool struct {
 	logger log.FieldLogger
 	// A TTLCache of ClusterDeployment creates each ClusterPool expects to see
 	expectations controllerutils.ExpectationsInterface
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server, against which claim release hooks are run when clusters are reused.
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
 }
 
 // Reconcile reads the state of the ClusterPool, checks if we currently have enough ClusterDeployments waiting, and
@@ -304,6 +323,7 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	origStatus := clp.Status.DeepCopy()
 	clp.Status.Size = int32(len(cds.Installing()) + len(cds.Assignable()) + len(cds.Broken()))
 	clp.Status.Ready = int32(len(cds.Assignable()))
+	setReadyCondition(clp)
 	if !reflect.DeepEqual(origStatus, &clp.Status) {
 		if err := r.Status().Update(context.Background(), clp); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool status")
@@ -329,8 +349,11 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
+	// Released clusters which are being sanitized for reuse will return to the pool, so they count as reserve.
+	toReuse, toRemoveClaimedCDs := partitionReleasedClusters(clp, cds.MarkedForDeletion(), poolVersion, logger)
+
 	// reserveSize is the number of clusters that the pool currently has in reserve
-	reserveSize := len(cds.Installing()) + len(cds.Assignable()) + len(cds.Broken()) - len(claims.Unassigned())
+	reserveSize := len(cds.Installing()) + len(cds.Assignable()) + len(cds.Broken()) + len(toReuse) - len(claims.Unassigned())
 
 	// excessSize is the number of clusters in excess of the pool's capacity (Size) that we are
 	// creating to satisfy unassigned claims. For example:
@@ -359,11 +382,37 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		}
 	}
 
+	// sanitize released clusters for reuse, destroying the ones which turn out to be unfit.
+	sanitizing, returned := false, false
+	for _, cd := range toReuse {
+		cdLog := logger.WithField("cluster", cd.Name)
+		state, err := r.reuseCluster(clp, cd, cdLog)
+		if err != nil {
+			cdLog.WithError(err).Error("error reusing cluster deployment")
+			return reconcile.Result{}, err
+		}
+		switch state {
+		case sanitizingReuseState:
+			sanitizing = true
+		case returnedReuseState:
+			metricClusterDeploymentsReused.WithLabelValues(clp.Namespace, clp.Name).Inc()
+			returned = true
+		case unfitReuseState:
+			toRemoveClaimedCDs = append(toRemoveClaimedCDs, cd)
+		}
+	}
+	if returned {
+		// The returned clusters are assignable now, which the collection does not reflect.
+		return reconcile.Result{Requeue: true}, nil
+	}
+
 	// remove clusters that were previously claimed but now not required.
-	toRemoveClaimedCDs := cds.MarkedForDeletion()
 	toDel := minIntVarible(len(toRemoveClaimedCDs), availableCurrent)
 	for _, cd := range toRemoveClaimedCDs[:toDel] {
 		cdLog := logger.WithField("cluster", cd.Name)
+		if err := r.addClaimReleaseHooks(clp, cd, cdLog); err != nil {
+			return reconcile.Result{}, err
+		}
 		cdLog.Info("deleting cluster deployment for previous claim")
 		if err := cds.Delete(r.Client, cd.Name); err != nil {
 			cdLog.WithError(err).Error("error deleting cluster deployment")
@@ -429,7 +478,18 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, nil
+	if err := r.reconcileCredentials(clp, cds, logger); err != nil {
+		log.WithError(err).Error("error propagating credentials")
+		return reconcile.Result{}, err
+	}
+
+	// Screen unhealthy clusters again once they have been unhealthy long enough to be deemed broken.
+	result := reconcile.Result{RequeueAfter: cds.BrokenRecheckAfter()}
+	// Check on the claim release hooks of clusters being sanitized, since their Jobs cannot be watched.
+	if sanitizing && (result.RequeueAfter == 0 || result.RequeueAfter > controllerutils.TeardownHookPollInterval) {
+		result.RequeueAfter = controllerutils.TeardownHookPollInterval
+	}
+	return result, nil
 }
 
 // reconcileRunningClusters ensures the oldest pool.spec.runningCount unassigned clusters are
@@ -445,6 +505,10 @@ func (r *ReconcileClusterPool) reconcileRunningClusters(
 	// of running clusters back down to runningCount once the pool reaches steady state.
 	runningCount := int(clp.Spec.RunningCount) + excessCount
 	cdList := append(cds.Assignable(), cds.Installing()...)
+	// Hive cannot hibernate hosted clusters, so all of them are kept running.
+	if clp.Spec.HostedCluster != nil {
+		runningCount = len(cdList)
+	}
 	// Sort by age, oldest first
 	sort.Slice(
 		cdList,
@@ -490,6 +554,9 @@ func calculatePoolVersion(clp *hivev1.ClusterPool) string {
 	ba = append(ba, deephash.Hash(clp.Spec.BaseDomain)...)
 	ba = append(ba, deephash.Hash(clp.Spec.ImageSetRef)...)
 	ba = append(ba, deephash.Hash(clp.Spec.InstallConfigSecretTemplateRef)...)
+	if clp.Spec.HostedCluster != nil {
+		ba = append(ba, deephash.Hash(clp.Spec.HostedCluster)...)
+	}
 	// Hash of hashes to ensure fixed length
 	return fmt.Sprintf("%x", deephash.Hash(ba))
 }
@@ -692,6 +759,7 @@ func (r *ReconcileClusterPool) createCluster(
 		InstallConfigTemplate: installConfigTemplate,
 		InstallAttemptsLimit:  clp.Spec.InstallAttemptsLimit,
 		SkipMachinePools:      clp.Spec.SkipMachinePools,
+		HostedCluster:         clp.Spec.HostedCluster,
 	}
 
 	if clp.Spec.HibernateAfter != nil {
@@ -730,7 +798,11 @@ func (r *ReconcileClusterPool) createCluster(
 }
 
 func (r *ReconcileClusterPool) createRandomNamespace(clp *hivev1.ClusterPool) (*corev1.Namespace, error) {
-	namespaceName := apihelpers.GetResourceName(clp.Name, utilrand.String(5))
+	prefix, err := controllerutils.ClusterPoolNamespacePrefix(clp)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not render namespace template")
+	}
+	namespaceName := apihelpers.GetResourceName(prefix, utilrand.String(5))
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: namespaceName,
@@ -740,7 +812,7 @@ func (r *ReconcileClusterPool) createRandomNamespace(clp *hivev1.ClusterPool) (*
 			},
 		},
 	}
-	err := r.Create(context.Background(), ns)
+	err = r.Create(context.Background(), ns)
 	return ns, err
 }
 
@@ -781,6 +853,36 @@ func (r *ReconcileClusterPool) deleteExcessClusters(
 	return nil
 }
 
+// addClaimReleaseHooks adds the claim release hooks of the pool to the teardown hooks of a previously claimed cluster,
+// so that they are run before the cluster is deprovisioned. Hooks of the cluster with the same name take precedence.
+func (r *ReconcileClusterPool) addClaimReleaseHooks(clp *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	existing := map[string]hivev1.TeardownHook{}
+	for _, hook := range cd.Spec.TeardownHooks {
+		existing[hook.Name] = hook
+	}
+	added := false
+	for _, hook := range clp.Spec.ClaimReleaseHooks {
+		if cur, ok := existing[hook.Name]; ok {
+			// The hooks may already have been added by an earlier reconcile which failed to delete the cluster.
+			if !reflect.DeepEqual(cur, hook) {
+				logger.WithField("teardownHook", hook.Name).Warn("cluster already has a teardown hook of the same name as a claim release hook")
+			}
+			continue
+		}
+		cd.Spec.TeardownHooks = append(cd.Spec.TeardownHooks, *hook.DeepCopy())
+		added = true
+	}
+	if !added {
+		return nil
+	}
+	logger.Info("adding claim release hooks to cluster deployment")
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not add claim release hooks to cluster deployment")
+		return errors.Wrap(err, "could not add claim release hooks to ClusterDeployment")
+	}
+	return nil
+}
+
 func (r *ReconcileClusterPool) deleteBrokenClusters(cds *cdCollection, maxToDelete int, logger log.FieldLogger) error {
 	numToDel := minIntVarible(maxToDelete, len(cds.Broken()))
 	logger.WithField("numberToDelete", numToDel).Info("deleting broken clusters")
@@ -842,6 +944,32 @@ func (r *ReconcileClusterPool) getCredentialsSecret(pool *hivev1.ClusterPool, se
 	return credsSecret, nil
 }
 
+// setReadyCondition sets the Ready condition of the pool from its ready count and MissingDependencies condition, and
+// records the generation of the pool in its status and conditions.
+func setReadyCondition(pool *hivev1.ClusterPool) {
+	status, reason, message := corev1.ConditionTrue, "ClustersReady", "The pool has as many clusters ready to be claimed as its size"
+	depsCond := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolMissingDependenciesCondition)
+	switch {
+	case depsCond != nil && depsCond.Status == corev1.ConditionTrue:
+		status, reason, message = corev1.ConditionFalse, "MissingDependencies", depsCond.Message
+	case pool.Status.Ready < pool.Spec.Size:
+		status, reason = corev1.ConditionFalse, "ClustersNotReady"
+		message = fmt.Sprintf("%d of %d clusters of the pool are ready to be claimed", pool.Status.Ready, pool.Spec.Size)
+	}
+	pool.Status.Conditions = controllerutils.SetClusterPoolCondition(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolReadyCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	pool.Status.ObservedGeneration = pool.Generation
+	for i := range pool.Status.Conditions {
+		pool.Status.Conditions[i].ObservedGeneration = pool.Generation
+	}
+}
+
 func (r *ReconcileClusterPool) setMissingDependenciesCondition(pool *hivev1.ClusterPool, err error, logger log.FieldLogger) error {
 	status := corev1.ConditionFalse
 	reason := "Verified"
@@ -863,6 +991,7 @@ func (r *ReconcileClusterPool) setMissingDependenciesCondition(pool *hivev1.Clus
 	)
 	if changed {
 		pool.Status.Conditions = conds
+		setReadyCondition(pool)
 		if err := r.Status().Update(context.Background(), pool); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool conditions")
 			return fmt.Errorf("could not update ClusterPool conditions: %w", err)
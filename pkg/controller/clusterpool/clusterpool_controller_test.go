@@ -3,6 +3,7 @@ package clusterpool
 import (
 	"context"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -49,7 +50,7 @@ func TestReconcileClusterPool(t *testing.T) {
 
 	// See calculatePoolVersion. If this changes, the easiest way to figure out the new value is
 	// to pull it from the test failure :)
-	initialPoolVersion := "3533907469253ac5"
+	initialPoolVersion := "d369439a4cccaefe"
 
 	poolBuilder := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme).
 		GenericOptions(
@@ -73,6 +74,10 @@ func TestReconcileClusterPool(t *testing.T) {
 			Status: corev1.ConditionUnknown,
 			Type:   hivev1.ClusterPoolAllClustersCurrentCondition,
 		}),
+		testcp.WithCondition(hivev1.ClusterPoolCondition{
+			Status: corev1.ConditionUnknown,
+			Type:   hivev1.ClusterPoolCredentialsCurrentCondition,
+		}),
 	)
 	cdBuilder := func(name string) testcd.Builder {
 		return testcd.FullBuilder(name, name, scheme).Options(
@@ -87,27 +92,52 @@ func TestReconcileClusterPool(t *testing.T) {
 	}
 
 	nowish := time.Now()
+	// resumingConditions make a CD look like it has been failing to start its machines since the given time.
+	resumingConditions := func(since time.Time) []testcd.Option {
+		return []testcd.Option{
+			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.ProvisionStoppedCondition,
+				Status: corev1.ConditionFalse,
+			}),
+			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:          hivev1.ClusterHibernatingCondition,
+				Status:        corev1.ConditionFalse,
+				Reason:        hivev1.ResumingOrRunningHibernationReason,
+				LastProbeTime: metav1.NewTime(since),
+			}),
+			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.ClusterReadyCondition,
+				Status: corev1.ConditionFalse,
+				Reason: hivev1.FailedToStartMachinesReadyReason,
+			}),
+		}
+	}
 
 	tests := []struct {
-		name                               string
-		existing                           []runtime.Object
-		noClusterImageSet                  bool
-		noCredsSecret                      bool
-		expectError                        bool
-		expectedTotalClusters              int
-		expectedObservedSize               int32
-		expectedObservedReady              int32
-		expectedDeletedClusters            []string
+		name                    string
+		existing                []runtime.Object
+		noClusterImageSet       bool
+		noCredsSecret           bool
+		expectError             bool
+		expectedTotalClusters   int
+		expectedObservedSize    int32
+		expectedObservedReady   int32
+		expectedDeletedClusters []string
+		// Map, keyed by cluster name, of the expected names of the teardown hooks of the cluster.
+		expectedTeardownHooks              map[string][]string
 		expectFinalizerRemoved             bool
 		expectedMissingDependenciesStatus  corev1.ConditionStatus
 		expectedCapacityStatus             corev1.ConditionStatus
 		expectedCDCurrentStatus            corev1.ConditionStatus
 		expectedMissingDependenciesMessage string
+		expectedReadyReason                string
 		expectedAssignedClaims             int
 		expectedUnassignedClaims           int
 		expectedAssignedCDs                int
 		expectedRunning                    int
 		expectedLabels                     map[string]string // Tested on all clusters, so will not work if your test has pre-existing cds in the pool.
+		expectedNamePrefix                 string            // Tested on all clusters, so will not work if your test has pre-existing cds in the pool.
+		expectedRequeueAfter               time.Duration
 		// Map, keyed by claim name, of expected Status.Conditions['Pending'].Reason.
 		// (The clusterpool controller always sets this condition's Status to True.)
 		// Not checked if nil.
@@ -295,6 +325,22 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedDeletedClusters: []string{"c1", "c2"},
 			expectedAssignedCDs:     2,
 		},
+		{
+			name: "delete unclaimed clusters that stay unhealthy",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(3), testcp.WithBrokenClusterTimeout(30*time.Minute)),
+				// Failed to resume long ago
+				unclaimedCDBuilder("c1").Build(append(resumingConditions(nowish.Add(-time.Hour)), testcd.Installed())...),
+				// Still has time to recover
+				unclaimedCDBuilder("c2").Build(append(resumingConditions(nowish.Add(-10*time.Minute)), testcd.Installed())...),
+				unclaimedCDBuilder("c3").Build(testcd.Installed()),
+			},
+			expectedTotalClusters:   2,
+			expectedObservedSize:    3,
+			expectedObservedReady:   2,
+			expectedDeletedClusters: []string{"c1"},
+			expectedRequeueAfter:    20 * time.Minute,
+		},
 		{
 			name: "deleting broken clusters is bounded by maxConcurrent",
 			existing: []runtime.Object{
@@ -356,6 +402,16 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedReady: 0,
 			expectedLabels:        map[string]string{"foo": "bar"},
 		},
+		{
+			name: "create clusters from namespace template",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2), testcp.WithNamespaceTemplate("ci-{{.PoolName}}")),
+			},
+			expectedTotalClusters: 2,
+			expectedObservedSize:  0,
+			expectedObservedReady: 0,
+			expectedNamePrefix:    "ci-" + testLeasePoolName + "-",
+		},
 		{
 			name: "scale up",
 			existing: []runtime.Object{
@@ -380,6 +436,7 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedSize:   3,
 			expectedObservedReady:  2,
 			expectedCapacityStatus: corev1.ConditionFalse,
+			expectedReadyReason:    "ClustersNotReady",
 		},
 		{
 			name: "scale up with some capacity",
@@ -551,6 +608,18 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedSize:  6,
 			expectedObservedReady: 6,
 		},
+		{
+			name: "ready when all clusters of the pool are ready",
+			existing: []runtime.Object{
+				initializedPoolBuilder.GenericOptions(generic.WithGeneration(3)).Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+			},
+			expectedTotalClusters: 2,
+			expectedObservedSize:  2,
+			expectedObservedReady: 2,
+			expectedReadyReason:   "ClustersReady",
+		},
 		{
 			name: "scale down with max concurrent enough",
 			existing: []runtime.Object{
@@ -717,6 +786,7 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedMissingDependenciesStatus:  corev1.ConditionTrue,
 			expectedMissingDependenciesMessage: `cluster image set: clusterimagesets.hive.openshift.io "test-image-set" not found`,
 			expectedCDCurrentStatus:            corev1.ConditionUnknown,
+			expectedReadyReason:                "MissingDependencies",
 		},
 		{
 			name: "missing creds secret",
@@ -1006,6 +1076,65 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedObservedReady:   2,
 			expectedDeletedClusters: []string{"c4"},
 		},
+		{
+			name: "add claim release hooks to previously claimed clusters",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(3),
+					testcp.WithClaimReleaseHooks(hivev1.TeardownHook{Name: "cleanup"}, hivev1.TeardownHook{Name: "deregister"}),
+				),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				cdBuilder("c4").
+					GenericOptions(
+						testgeneric.WithAnnotation(constants.ClusterClaimRemoveClusterAnnotation, "true"),
+						testgeneric.WithFinalizer(hivev1.FinalizerDeprovision),
+					).
+					Build(
+						testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test-claim"),
+						func(cd *hivev1.ClusterDeployment) {
+							cd.Spec.TeardownHooks = []hivev1.TeardownHook{{Name: "deregister", Timeout: &metav1.Duration{Duration: time.Minute}}}
+						},
+					),
+			},
+			expectedTotalClusters: 4,
+			expectedObservedSize:  3,
+			expectedObservedReady: 2,
+			expectedAssignedCDs:   1,
+			expectedTeardownHooks: map[string][]string{
+				// The hook of the cluster takes precedence over the claim release hook of the same name.
+				"c4": {"deregister", "cleanup"},
+			},
+		},
+		{
+			name: "destroy previously claimed clusters which cannot be reused",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(3),
+					testcp.WithClaimReleaseHooks(hivev1.TeardownHook{Name: "cleanup"}),
+					testcp.WithReleasePolicy(hivev1.ReuseClusterPoolReleasePolicy),
+				),
+				unclaimedCDBuilder("c1").Build(testcd.Installed()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed()),
+				unclaimedCDBuilder("c3").Build(),
+				// Hibernating clusters cannot be sanitized.
+				cdBuilder("c4").
+					GenericOptions(
+						testgeneric.WithAnnotation(constants.ClusterClaimRemoveClusterAnnotation, "true"),
+						testgeneric.WithFinalizer(hivev1.FinalizerDeprovision),
+					).
+					Build(
+						testcd.Installed(),
+						testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test-claim"),
+					),
+			},
+			expectedTotalClusters: 4,
+			expectedObservedSize:  3,
+			expectedObservedReady: 2,
+			expectedAssignedCDs:   1,
+			expectedTeardownHooks: map[string][]string{"c4": {"cleanup"}},
+		},
 		{
 			name: "deleting previously claimed clusters should use max concurrent",
 			existing: []runtime.Object{
@@ -1317,6 +1446,19 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedTotalClusters: 4,
 			expectedRunning:       4,
 		},
+		{
+			name: "hosted clusters are kept running",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(3),
+					testcp.WithRunningCount(1),
+					testcp.WithHostedCluster(&hivev1.HostedClusterConfig{}),
+				),
+			},
+			expectedTotalClusters:    3,
+			expectedRunning:          3,
+			expectPoolVersionChanged: true,
+		},
 		{
 			name: "runningCount restored after deletion",
 			existing: []runtime.Object{
@@ -1439,11 +1581,13 @@ func TestReconcileClusterPool(t *testing.T) {
 				},
 			}
 
-			_, err := rcp.Reconcile(context.TODO(), reconcileRequest)
+			result, err := rcp.Reconcile(context.TODO(), reconcileRequest)
 			if test.expectError {
 				assert.Error(t, err, "expected error from reconcile")
 			} else {
 				assert.NoError(t, err, "expected no error from reconcile")
+				// Allow for the time taken by the test, and for the fake client storing times at second precision.
+				assert.InDelta(t, test.expectedRequeueAfter.Seconds(), result.RequeueAfter.Seconds(), 5, "unexpected requeue after")
 			}
 
 			pool := &hivev1.ClusterPool{}
@@ -1483,6 +1627,16 @@ func TestReconcileClusterPool(t *testing.T) {
 					}
 				}
 			}
+			if test.expectedReadyReason != "" {
+				readyCondition := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolReadyCondition)
+				if assert.NotNil(t, readyCondition, "did not find Ready condition") {
+					assert.Equal(t, test.expectedReadyReason, readyCondition.Reason, "unexpected Ready condition reason")
+					assert.Equal(t, test.expectedReadyReason == "ClustersReady", readyCondition.Status == corev1.ConditionTrue,
+						"unexpected Ready condition status")
+					assert.Equal(t, pool.Generation, readyCondition.ObservedGeneration, "unexpected Ready condition observed generation")
+				}
+				assert.Equal(t, pool.Generation, pool.Status.ObservedGeneration, "unexpected observed generation")
+			}
 			if test.expectedCapacityStatus != "" {
 				capacityAvailableCondition := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolCapacityAvailableCondition)
 				if assert.NotNil(t, capacityAvailableCondition, "did not find CapacityAvailable condition") {
@@ -1503,6 +1657,19 @@ func TestReconcileClusterPool(t *testing.T) {
 				}
 			}
 
+			for _, cd := range cds.Items {
+				expected, ok := test.expectedTeardownHooks[cd.Name]
+				if !ok {
+					continue
+				}
+				var names []string
+				for _, hook := range cd.Spec.TeardownHooks {
+					names = append(names, hook.Name)
+				}
+				assert.Equal(t, expected, names, "unexpected teardown hooks for cluster %s", cd.Name)
+				assert.NotNil(t, cd.DeletionTimestamp, "expected cluster %s to be deleted", cd.Name)
+			}
+
 			var actualAssignedCDs, actualUnassignedCDs, actualRunning, actualHibernating int
 			for _, cd := range cds.Items {
 				poolRef := cd.Spec.ClusterPoolRef
@@ -1521,6 +1688,11 @@ func TestReconcileClusterPool(t *testing.T) {
 							assert.Equal(t, *pool.Spec.InstallAttemptsLimit, *cd.Spec.InstallAttemptsLimit, "expected InstallAttemptsLimit to match")
 						}
 					}
+					if pool.Spec.HostedCluster != nil {
+						if assert.NotNil(t, cd.Spec.ClusterInstallRef, "expected ClusterInstallRef to be set") {
+							assert.Equal(t, "HostedClusterInstall", cd.Spec.ClusterInstallRef.Kind, "unexpected ClusterInstallRef kind")
+						}
+					}
 				}
 				switch powerState := cd.Spec.PowerState; powerState {
 				case hivev1.RunningClusterPowerState:
@@ -1534,6 +1706,9 @@ func TestReconcileClusterPool(t *testing.T) {
 						assert.Equal(t, v, cd.Labels[k])
 					}
 				}
+				if test.expectedNamePrefix != "" {
+					assert.True(t, strings.HasPrefix(cd.Namespace, test.expectedNamePrefix), "unexpected cluster namespace %s", cd.Namespace)
+				}
 			}
 			assert.Equal(t, test.expectedAssignedCDs, actualAssignedCDs, "unexpected number of assigned CDs")
 			assert.Equal(t, test.expectedTotalClusters-test.expectedAssignedCDs, actualUnassignedCDs, "unexpected number of unassigned CDs")
@@ -19,6 +19,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/utils"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/notification"
 )
 
 type claimCollection struct {
@@ -200,6 +201,8 @@ type cdCollection struct {
 	deleting []*hivev1.ClusterDeployment
 	// Clusters we've declared unusable (e.g. provision failed terminally).
 	broken []*hivev1.ClusterDeployment
+	// How long until an unhealthy, but not yet broken, cluster will be considered broken. Zero if there is none.
+	brokenRecheckAfter time.Duration
 	// Clusters with the ClusterClaimRemoveClusterAnnotation. Mutually exclusive with deleting.
 	markedForDeletion []*hivev1.ClusterDeployment
 	// Clusters with a missing or empty pool version annotation
@@ -212,18 +215,82 @@ type cdCollection struct {
 	byClaimName map[string]*hivev1.ClusterDeployment
 }
 
-func isBroken(cd *hivev1.ClusterDeployment) bool {
+// defaultBrokenClusterTimeout is how long an unclaimed cluster may stay unhealthy before it is considered broken, when
+// the pool does not specify a BrokenClusterTimeout.
+const defaultBrokenClusterTimeout = time.Hour
+
+func brokenClusterTimeout(pool *hivev1.ClusterPool) time.Duration {
+	if pool.Spec.BrokenClusterTimeout != nil {
+		return pool.Spec.BrokenClusterTimeout.Duration
+	}
+	return defaultBrokenClusterTimeout
+}
+
+// isBroken returns true, along with the reason, if the unclaimed CD is unusable and should be replaced. If the CD is
+// unhealthy but has not been so for long enough to be considered broken, the time remaining until it will be is also
+// returned, so that the pool can screen it again then.
+func isBroken(cd *hivev1.ClusterDeployment, timeout time.Duration, now time.Time) (broken bool, reason string, recheckAfter time.Duration) {
 	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition)
 	if cond == nil {
 		// Since we should be initializing conditions, this probably means the CD is super fresh.
 		// Don't declare it broken yet -- give it a chance to come to life.
-		return false
+		return false, "", 0
 	}
 	if cond.Status == corev1.ConditionTrue {
-		return true
+		return true, "provisioning stopped", 0
+	}
+	if !cd.Spec.Installed {
+		return false, "", 0
+	}
+	since, reason := unhealthySince(cd)
+	if since.IsZero() {
+		return false, "", 0
+	}
+	if unhealthyFor := now.Sub(since); unhealthyFor < timeout {
+		return false, "", timeout - unhealthyFor
+	}
+	return true, reason, 0
+}
+
+// unhealthySince returns the time since which the installed CD has been unhealthy, along with the reason. It returns
+// the zero time if the CD is healthy.
+func unhealthySince(cd *hivev1.ClusterDeployment) (time.Time, string) {
+	hibernating := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	ready := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ClusterReadyCondition)
+	if hibernating == nil || ready == nil {
+		return time.Time{}, ""
 	}
-	// Other causes of broken-ness to be added here later. E.g. resume failed.
-	return false
+	switch {
+	// The Ready condition is set with a fixed message when the cluster starts stopping, so its probe time is when
+	// hibernation began.
+	case ready.Reason == hivev1.StoppingOrHibernatingReadyReason &&
+		(hibernating.Reason == hivev1.StoppingHibernationReason ||
+			hibernating.Reason == hivev1.FailedToStopHibernationReason ||
+			hibernating.Reason == hivev1.WaitingForMachinesToStopHibernatingReason):
+		return ready.LastProbeTime.Time, "failed to hibernate"
+	// Likewise, the Hibernating condition is set with a fixed message when the cluster starts resuming. This covers
+	// clusters whose machines fail to start as well as those whose ClusterOperators never become ready.
+	case hibernating.Reason == hivev1.ResumingOrRunningHibernationReason &&
+		ready.Status == corev1.ConditionFalse &&
+		ready.Reason != hivev1.StoppingOrHibernatingReadyReason:
+		if ready.Reason == hivev1.WaitingForClusterOperatorsReadyReason {
+			return hibernating.LastProbeTime.Time, "ClusterOperators not ready"
+		}
+		return hibernating.LastProbeTime.Time, "failed to resume"
+	case hibernating.Reason == hivev1.ResumingOrRunningHibernationReason &&
+		ready.Reason == hivev1.RunningReadyReason:
+		unreachable := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition)
+		if unreachable == nil || unreachable.Status != corev1.ConditionTrue {
+			return time.Time{}, ""
+		}
+		// A cluster is unreachable while it is hibernating, so only count the time since it has been running.
+		since := unreachable.LastTransitionTime.Time
+		if ready.LastTransitionTime.Time.After(since) {
+			since = ready.LastTransitionTime.Time
+		}
+		return since, "unreachable"
+	}
+	return time.Time{}, ""
 }
 
 func isRunning(cd *hivev1.ClusterDeployment) bool {
@@ -268,6 +335,8 @@ func getAllClusterDeploymentsForPool(c client.Client, pool *hivev1.ClusterPool,
 		byClaimName:           make(map[string]*hivev1.ClusterDeployment),
 	}
 	running := 0
+	timeout := brokenClusterTimeout(pool)
+	now := time.Now()
 	for i, cd := range cdList.Items {
 		poolRef := cd.Spec.ClusterPoolRef
 		if poolRef == nil || poolRef.Namespace != pool.Namespace || poolRef.PoolName != pool.Name {
@@ -288,7 +357,12 @@ func getAllClusterDeploymentsForPool(c client.Client, pool *hivev1.ClusterPool,
 			// Do *not* double count "deleting" and "marked for deletion"
 			cdCol.markedForDeletion = append(cdCol.markedForDeletion, ref)
 		} else if claimName == "" {
-			if isBroken(&cd) {
+			broken, reason, recheckAfter := isBroken(&cd, timeout, now)
+			if recheckAfter > 0 && (cdCol.brokenRecheckAfter == 0 || recheckAfter < cdCol.brokenRecheckAfter) {
+				cdCol.brokenRecheckAfter = recheckAfter
+			}
+			if broken {
+				logger.WithFields(log.Fields{"cluster": cd.Name, "reason": reason}).Info("cluster is broken")
 				cdCol.broken = append(cdCol.broken, ref)
 			} else if cd.Spec.Installed {
 				cdCol.assignable = append(cdCol.assignable, ref)
@@ -409,6 +483,12 @@ func (cds *cdCollection) Broken() []*hivev1.ClusterDeployment {
 	return cds.broken
 }
 
+// BrokenRecheckAfter returns how long until an unhealthy cluster will be deemed broken if it does not recover, or zero
+// if no cluster is unhealthy.
+func (cds *cdCollection) BrokenRecheckAfter() time.Duration {
+	return cds.brokenRecheckAfter
+}
+
 // UnknownPoolVersion returns the list of ClusterDeployments whose pool version annotation is
 // missing or empty.
 func (cds *cdCollection) UnknownPoolVersion() []*hivev1.ClusterDeployment {
@@ -631,6 +711,8 @@ func ensureClaimAssignment(c client.Client, claim *hivev1.ClusterClaim, claims *
 		if err := cds.Assign(c, cd, claim); err != nil {
 			return err
 		}
+		notification.Notify(notification.NewEvent(hivev1.ClaimAssignedNotificationEvent, cd,
+			fmt.Sprintf("Assigned to ClusterClaim %s", claim.Name)), logger)
 	} else {
 		logger.Debug("cluster already assigned")
 	}
@@ -0,0 +1,180 @@
+package clusterpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+func Test_isBroken(t *testing.T) {
+	now := time.Now()
+	ago := func(d time.Duration) metav1.Time {
+		return metav1.NewTime(now.Add(-d))
+	}
+	provisionStopped := func(status corev1.ConditionStatus) testcd.Option {
+		return testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.ProvisionStoppedCondition,
+			Status: status,
+		})
+	}
+	hibernating := func(reason string, probed metav1.Time) testcd.Option {
+		return testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:          hivev1.ClusterHibernatingCondition,
+			Status:        corev1.ConditionFalse,
+			Reason:        reason,
+			LastProbeTime: probed,
+		})
+	}
+	ready := func(status corev1.ConditionStatus, reason string, transitioned, probed metav1.Time) testcd.Option {
+		return testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:               hivev1.ClusterReadyCondition,
+			Status:             status,
+			Reason:             reason,
+			LastTransitionTime: transitioned,
+			LastProbeTime:      probed,
+		})
+	}
+	unreachable := func(transitioned metav1.Time) testcd.Option {
+		return testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:               hivev1.UnreachableCondition,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: transitioned,
+		})
+	}
+	cases := []struct {
+		name                 string
+		options              []testcd.Option
+		expectBroken         bool
+		expectedReason       string
+		expectedRecheckAfter time.Duration
+	}{
+		{
+			name: "no conditions",
+		},
+		{
+			name:           "provision stopped",
+			options:        []testcd.Option{provisionStopped(corev1.ConditionTrue)},
+			expectBroken:   true,
+			expectedReason: "provisioning stopped",
+		},
+		{
+			name: "installing",
+			options: []testcd.Option{
+				provisionStopped(corev1.ConditionFalse),
+				hibernating(hivev1.ResumingOrRunningHibernationReason, ago(2*time.Hour)),
+				ready(corev1.ConditionFalse, hivev1.WaitingForClusterOperatorsReadyReason, ago(2*time.Hour), ago(2*time.Hour)),
+			},
+		},
+		{
+			name: "running",
+			options: []testcd.Option{
+				testcd.Installed(),
+				provisionStopped(corev1.ConditionFalse),
+				hibernating(hivev1.ResumingOrRunningHibernationReason, ago(2*time.Hour)),
+				ready(corev1.ConditionTrue, hivev1.RunningReadyReason, ago(2*time.Hour), ago(2*time.Hour)),
+			},
+		},
+		{
+			name: "hibernating",
+			options: []testcd.Option{
+				testcd.Installed(),
+				provisionStopped(corev1.ConditionFalse),
+				hibernating(hivev1.HibernatingHibernationReason, ago(2*time.Hour)),
+				ready(corev1.ConditionFalse, hivev1.StoppingOrHibernatingReadyReason, ago(2*time.Hour), ago(2*time.Hour)),
+				unreachable(ago(2 * time.Hour)),
+			},
+		},
+		{
+			name: "failing to stop",
+			options: []testcd.Option{
+				testcd.Installed(),
+				provisionStopped(corev1.ConditionFalse),
+				hibernating(hivev1.FailedToStopHibernationReason, ago(time.Minute)),
+				ready(corev1.ConditionFalse, hivev1.StoppingOrHibernatingReadyReason, ago(3*time.Hour), ago(20*time.Minute)),
+			},
+			expectedRecheckAfter: 40 * time.Minute,
+		},
+		{
+			name: "failed to stop",
+			options: []testcd.Option{
+				testcd.Installed(),
+				provisionStopped(corev1.ConditionFalse),
+				hibernating(hivev1.FailedToStopHibernationReason, ago(time.Minute)),
+				ready(corev1.ConditionFalse, hivev1.StoppingOrHibernatingReadyReason, ago(3*time.Hour), ago(2*time.Hour)),
+			},
+			expectBroken:   true,
+			expectedReason: "failed to hibernate",
+		},
+		{
+			name: "resuming",
+			options: []testcd.Option{
+				testcd.Installed(),
+				provisionStopped(corev1.ConditionFalse),
+				hibernating(hivev1.ResumingOrRunningHibernationReason, ago(10*time.Minute)),
+				ready(corev1.ConditionFalse, hivev1.WaitingForNodesReadyReason, ago(3*time.Hour), ago(time.Minute)),
+			},
+			expectedRecheckAfter: 50 * time.Minute,
+		},
+		{
+			name: "failed to start machines",
+			options: []testcd.Option{
+				testcd.Installed(),
+				provisionStopped(corev1.ConditionFalse),
+				hibernating(hivev1.ResumingOrRunningHibernationReason, ago(2*time.Hour)),
+				ready(corev1.ConditionFalse, hivev1.FailedToStartMachinesReadyReason, ago(3*time.Hour), ago(time.Minute)),
+			},
+			expectBroken:   true,
+			expectedReason: "failed to resume",
+		},
+		{
+			name: "degraded operators",
+			options: []testcd.Option{
+				testcd.Installed(),
+				provisionStopped(corev1.ConditionFalse),
+				hibernating(hivev1.ResumingOrRunningHibernationReason, ago(2*time.Hour)),
+				ready(corev1.ConditionFalse, hivev1.WaitingForClusterOperatorsReadyReason, ago(3*time.Hour), ago(time.Hour)),
+			},
+			expectBroken:   true,
+			expectedReason: "ClusterOperators not ready",
+		},
+		{
+			name: "unreachable since resuming",
+			options: []testcd.Option{
+				testcd.Installed(),
+				provisionStopped(corev1.ConditionFalse),
+				hibernating(hivev1.ResumingOrRunningHibernationReason, ago(30*time.Minute)),
+				ready(corev1.ConditionTrue, hivev1.RunningReadyReason, ago(15*time.Minute), ago(15*time.Minute)),
+				unreachable(ago(3 * time.Hour)),
+			},
+			expectedRecheckAfter: 45 * time.Minute,
+		},
+		{
+			name: "unreachable",
+			options: []testcd.Option{
+				testcd.Installed(),
+				provisionStopped(corev1.ConditionFalse),
+				hibernating(hivev1.ResumingOrRunningHibernationReason, ago(3*time.Hour)),
+				ready(corev1.ConditionTrue, hivev1.RunningReadyReason, ago(3*time.Hour), ago(3*time.Hour)),
+				unreachable(ago(90 * time.Minute)),
+			},
+			expectBroken:   true,
+			expectedReason: "unreachable",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := testcd.Build(tc.options...)
+			broken, reason, recheckAfter := isBroken(cd, time.Hour, now)
+			assert.Equal(t, tc.expectBroken, broken, "unexpected broken")
+			assert.Equal(t, tc.expectedReason, reason, "unexpected reason")
+			assert.Equal(t, int64(tc.expectedRecheckAfter), int64(recheckAfter), "unexpected recheck after")
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package clusterpool
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/clusterresource"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// poolCredentialsSecretName returns the name of the pool's cloud credentials secret, or an empty string if the pool
+// does not use one (e.g. AWS with an assumed role).
+func poolCredentialsSecretName(pool *hivev1.ClusterPool) string {
+	switch p := pool.Spec.Platform; {
+	case p.AWS != nil:
+		return p.AWS.CredentialsSecretRef.Name
+	case p.GCP != nil:
+		return p.GCP.CredentialsSecretRef.Name
+	case p.Azure != nil:
+		return p.Azure.CredentialsSecretRef.Name
+	default:
+		return ""
+	}
+}
+
+// requestsForCredentialsSecret enqueues the pools in the namespace of the secret which use it as their credentials.
+func requestsForCredentialsSecret(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		if _, ok := o.(*corev1.Secret); !ok {
+			return nil
+		}
+		cpList := &hivev1.ClusterPoolList{}
+		if err := c.List(context.Background(), cpList, client.InNamespace(o.GetNamespace())); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list cluster pools for credentials secret")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, cp := range cpList.Items {
+			if poolCredentialsSecretName(&cp) != o.GetName() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKey{Namespace: cp.Namespace, Name: cp.Name},
+			})
+		}
+		return requests
+	}
+}
+
+// reconcileCredentials copies the pool's cloud credentials to the credentials secrets of all of the pool's
+// ClusterDeployments, claimed or not, so that rotating the pool's credentials does not leave existing clusters with
+// stale copies. Clusters being deprovisioned need the current credentials as much as running ones. The progress of the
+// rollout is reported in the CredentialsCurrent condition of the pool.
+func (r *ReconcileClusterPool) reconcileCredentials(pool *hivev1.ClusterPool, cds *cdCollection, logger log.FieldLogger) error {
+	if poolCredentialsSecretName(pool) == "" {
+		return r.setCredentialsCurrentCondition(pool, corev1.ConditionTrue, "NoCredentialsSecret",
+			"The pool does not use a credentials Secret", logger)
+	}
+	cloudBuilder, err := r.createCloudBuilder(pool, logger)
+	if err != nil {
+		// A missing credentials secret is reported in the MissingDependencies condition.
+		logger.WithError(err).Debug("cannot propagate credentials")
+		return nil
+	}
+
+	var names []string
+	for name := range cds.byCDName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var failed []string
+	var errs []error
+	for _, name := range names {
+		cd := cds.byCDName[name]
+		if err := r.updateCredentialsSecret(cd, cloudBuilder, logger); err != nil {
+			failed = append(failed, cd.Name)
+			errs = append(errs, err)
+		}
+	}
+
+	if len(failed) > 0 {
+		message := fmt.Sprintf("Credentials of %d of %d ClusterDeployments are current. Could not update: %s",
+			len(names)-len(failed), len(names), strings.Join(failed, ", "))
+		if err := r.setCredentialsCurrentCondition(pool, corev1.ConditionFalse, "CredentialsRollingOut", message, logger); err != nil {
+			return err
+		}
+		return errors.Wrap(errs[0], "could not update credentials of all ClusterDeployments")
+	}
+	return r.setCredentialsCurrentCondition(pool, corev1.ConditionTrue, "CredentialsCurrent",
+		"The credentials of all ClusterDeployments match the pool", logger)
+}
+
+// updateCredentialsSecret updates the credentials secret of the ClusterDeployment if it differs from the one that the
+// pool would create now.
+func (r *ReconcileClusterPool) updateCredentialsSecret(cd *hivev1.ClusterDeployment, cloudBuilder clusterresource.CloudBuilder, logger log.FieldLogger) error {
+	secretName := controllerutils.CredentialsSecretName(cd)
+	if secretName == "" {
+		return nil
+	}
+	logger = logger.WithFields(log.Fields{"cluster": cd.Name, "secret": secretName})
+	desired := cloudBuilder.GenerateCredentialsSecret(&clusterresource.Builder{Name: cd.Name, Namespace: cd.Namespace})
+	desiredData := map[string][]byte{}
+	for k, v := range desired.Data {
+		desiredData[k] = v
+	}
+	for k, v := range desired.StringData {
+		desiredData[k] = []byte(v)
+	}
+
+	secret := &corev1.Secret{}
+	switch err := r.Get(context.Background(), client.ObjectKey{Namespace: cd.Namespace, Name: secretName}, secret); {
+	case apierrors.IsNotFound(err):
+		// The secret is created along with the ClusterDeployment, so it is either not in the cache yet or already
+		// gone with the namespace.
+		logger.Debug("credentials secret not found")
+		return nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get credentials secret")
+		return errors.Wrap(err, "could not get credentials secret")
+	}
+	if reflect.DeepEqual(secret.Data, desiredData) {
+		return nil
+	}
+	logger.Info("updating credentials secret from the pool")
+	secret.Data = desiredData
+	if err := r.Update(context.Background(), secret); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update credentials secret")
+		return errors.Wrapf(err, "could not update credentials secret of %s", cd.Name)
+	}
+	return nil
+}
+
+func (r *ReconcileClusterPool) setCredentialsCurrentCondition(pool *hivev1.ClusterPool, status corev1.ConditionStatus, reason, message string, logger log.FieldLogger) error {
+	conds, changed := controllerutils.SetClusterPoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.ClusterPoolCredentialsCurrentCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		pool.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool conditions")
+			return errors.Wrap(err, "could not update ClusterPool conditions")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package clusterpool
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+)
+
+func TestReconcileCredentials(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	awsCredsSecret := func(namespace, name, accessKeyID string) *corev1.Secret {
+		return testsecret.FullBuilder(namespace, name, scheme).Build(
+			testsecret.WithDataKeyValue(constants.AWSAccessKeyIDSecretKey, []byte(accessKeyID)),
+			testsecret.WithDataKeyValue(constants.AWSSecretAccessKeySecretKey, []byte("secret-"+accessKeyID)),
+		)
+	}
+	poolCD := func(name, claimName string) *hivev1.ClusterDeployment {
+		return testcd.FullBuilder(name, name, scheme).Build(
+			testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, claimName),
+			testcd.WithAWSPlatform(&hivev1aws.Platform{
+				CredentialsSecretRef: corev1.LocalObjectReference{Name: name + "-aws-creds"},
+				Region:               "us-east-1",
+			}),
+		)
+	}
+
+	cases := []struct {
+		name              string
+		existing          []runtime.Object
+		expectedStatus    corev1.ConditionStatus
+		expectedReason    string
+		expectedKeyIDs    map[string]string
+		expectedNotExists []string
+	}{
+		{
+			name: "rotated credentials",
+			existing: []runtime.Object{
+				awsCredsSecret(testNamespace, credsSecretName, "new"),
+				poolCD("c1", ""),
+				awsCredsSecret("c1", "c1-aws-creds", "old"),
+				poolCD("c2", ""),
+				awsCredsSecret("c2", "c2-aws-creds", "new"),
+				poolCD("c3", "test-claim"),
+				awsCredsSecret("c3", "c3-aws-creds", "old"),
+				// The secret of a cluster in a namespace being deleted may already be gone.
+				poolCD("c4", ""),
+			},
+			expectedStatus:    corev1.ConditionTrue,
+			expectedReason:    "CredentialsCurrent",
+			expectedKeyIDs:    map[string]string{"c1": "new", "c2": "new", "c3": "new"},
+			expectedNotExists: []string{"c4"},
+		},
+		{
+			name: "missing pool credentials",
+			existing: []runtime.Object{
+				poolCD("c1", ""),
+				awsCredsSecret("c1", "c1-aws-creds", "old"),
+			},
+			expectedStatus: corev1.ConditionUnknown,
+			expectedKeyIDs: map[string]string{"c1": "old"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme).Build(
+				testcp.ForAWS(credsSecretName, "us-east-1"),
+				testcp.WithCondition(hivev1.ClusterPoolCondition{
+					Status: corev1.ConditionUnknown,
+					Type:   hivev1.ClusterPoolCredentialsCurrentCondition,
+				}),
+			)
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(append(tc.existing, pool)...).Build()
+			logger := log.New()
+			r := &ReconcileClusterPool{Client: c, logger: logger}
+			cds, err := getAllClusterDeploymentsForPool(c, pool, "", logger)
+			require.NoError(t, err, "unexpected error getting ClusterDeployments")
+
+			err = r.reconcileCredentials(pool, cds, logger)
+			require.NoError(t, err, "unexpected error reconciling credentials")
+
+			for cdName, keyID := range tc.expectedKeyIDs {
+				secret := &corev1.Secret{}
+				if assert.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: cdName, Name: cdName + "-aws-creds"}, secret), "unexpected error getting credentials of %s", cdName) {
+					assert.Equal(t, keyID, string(secret.Data[constants.AWSAccessKeyIDSecretKey]), "unexpected access key ID for %s", cdName)
+					assert.Equal(t, "secret-"+keyID, string(secret.Data[constants.AWSSecretAccessKeySecretKey]), "unexpected secret access key for %s", cdName)
+				}
+			}
+			for _, cdName := range tc.expectedNotExists {
+				err := c.Get(context.Background(), client.ObjectKey{Namespace: cdName, Name: cdName + "-aws-creds"}, &corev1.Secret{})
+				assert.Error(t, err, "expected no credentials for %s", cdName)
+			}
+			cond := controllerutils.FindClusterPoolCondition(pool.Status.Conditions, hivev1.ClusterPoolCredentialsCurrentCondition)
+			if assert.NotNil(t, cond, "expected CredentialsCurrent condition") {
+				assert.Equal(t, tc.expectedStatus, cond.Status, "unexpected condition status")
+				assert.Equal(t, tc.expectedReason, cond.Reason, "unexpected condition reason")
+			}
+		})
+	}
+}
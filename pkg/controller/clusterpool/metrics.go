@@ -47,6 +47,12 @@ var (
 		Name: "hive_clusterpool_stale_clusterdeployments_deleted",
 		Help: "The number of ClusterDeployments deleted because they no longer match the spec of their ClusterPool.",
 	}, []string{"clusterpool_namespace", "clusterpool_name"})
+	// metricClusterDeploymentsReused tracks the total number of released CDs we sanitized and returned to the pool
+	// instead of deleting, under the Reuse release policy.
+	metricClusterDeploymentsReused = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_clusterpool_clusterdeployments_reused",
+		Help: "The number of released ClusterDeployments returned to their ClusterPool instead of being deleted.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
 	// metricClaimDelaySeconds tracks how long it takes for a claim to be assigned, labeled by
 	// cluster pool.
 	metricClaimDelaySeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
@@ -75,5 +81,6 @@ func init() {
 	metrics.Registry.MustRegister(metricClusterDeploymentsStale)
 	metrics.Registry.MustRegister(metricClusterDeploymentsBroken)
 	metrics.Registry.MustRegister(metricStaleClusterDeploymentsDeleted)
+	metrics.Registry.MustRegister(metricClusterDeploymentsReused)
 	metrics.Registry.MustRegister(metricClaimDelaySeconds)
 }
@@ -0,0 +1,199 @@
+package clusterpool
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// reuseState is how far a released cluster has come on its way back into the pool.
+type reuseState string
+
+const (
+	// sanitizingReuseState means the cluster is still being sanitized.
+	sanitizingReuseState reuseState = "Sanitizing"
+	// returnedReuseState means the cluster has been returned to the pool as an unclaimed cluster.
+	returnedReuseState reuseState = "Returned"
+	// unfitReuseState means the cluster could not be sanitized, and must be destroyed instead.
+	unfitReuseState reuseState = "Unfit"
+)
+
+// partitionReleasedClusters splits the clusters whose claims have been deleted into those the pool sanitizes and
+// returns to itself, and those it destroys.
+func partitionReleasedClusters(clp *hivev1.ClusterPool, released []*hivev1.ClusterDeployment, poolVersion string, logger log.FieldLogger) (toReuse, toDestroy []*hivev1.ClusterDeployment) {
+	if clp.Spec.ReleasePolicy != hivev1.ReuseClusterPoolReleasePolicy {
+		return nil, released
+	}
+	for _, cd := range released {
+		if reason := notReusableReason(cd, poolVersion); reason != "" {
+			logger.WithField("cluster", cd.Name).WithField("reason", reason).Info("released cluster cannot be reused")
+			toDestroy = append(toDestroy, cd)
+			continue
+		}
+		toReuse = append(toReuse, cd)
+	}
+	return toReuse, toDestroy
+}
+
+// notReusableReason returns why the released cluster cannot be returned to the pool, if it cannot.
+func notReusableReason(cd *hivev1.ClusterDeployment, poolVersion string) string {
+	switch {
+	case !cd.Spec.Installed:
+		return "cluster was never installed"
+	case cd.Spec.PowerState == hivev1.HibernatingClusterPowerState:
+		return "cluster is hibernating"
+	case cd.Annotations[constants.ClusterDeploymentPoolSpecHashAnnotation] != poolVersion:
+		return "cluster does not match the current pool spec"
+	}
+	return claimReleaseHookFailure(cd)
+}
+
+// claimReleaseHookFailure returns which claim release hook left the cluster unsanitized, if one did.
+func claimReleaseHookFailure(cd *hivev1.ClusterDeployment) string {
+	for _, status := range cd.Status.ClaimReleaseHooks {
+		switch status.State {
+		case hivev1.FailedTeardownHookState, hivev1.TimedOutTeardownHookState:
+			return fmt.Sprintf("claim release hook %s did not succeed", status.Name)
+		}
+	}
+	return ""
+}
+
+// reuseCluster takes a released cluster one step further on its way back into the pool. The claim release hooks of
+// the pool are run against the cluster, then the kubeadmin password is rotated, and finally the SyncSets of the
+// cluster are reapplied and its claim is cleared.
+func (r *ReconcileClusterPool) reuseCluster(clp *hivev1.ClusterPool, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reuseState, error) {
+	fake := controllerutils.IsFakeCluster(cd)
+	skipReason := ""
+	if fake {
+		skipReason = "Cluster is fake"
+	}
+	original := cd.Status.DeepCopy().ClaimReleaseHooks
+	done := controllerutils.ProgressTeardownHooks(
+		cd,
+		clp.Spec.ClaimReleaseHooks,
+		&cd.Status.ClaimReleaseHooks,
+		skipReason,
+		func() (client.Client, error) { return r.remoteClusterAPIClientBuilder(cd).Build() },
+		logger,
+	)
+	if !reflect.DeepEqual(original, cd.Status.ClaimReleaseHooks) {
+		if err := r.Status().Update(context.Background(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update claim release hook status")
+			return "", errors.Wrap(err, "could not update claim release hook status")
+		}
+	}
+	if !done {
+		return sanitizingReuseState, nil
+	}
+	if reason := claimReleaseHookFailure(cd); reason != "" {
+		logger.WithField("reason", reason).Info("released cluster cannot be reused")
+		return unfitReuseState, nil
+	}
+
+	// The hook resources are removed from the cluster so that the hooks run afresh on its next release.
+	cleanUp := func() error {
+		if fake {
+			return nil
+		}
+		remoteClient, err := r.remoteClusterAPIClientBuilder(cd).Build()
+		if err != nil {
+			logger.WithError(err).Warn("could not build client to delete claim release hook resources")
+			return errors.Wrap(err, "could not build client for cluster")
+		}
+		return controllerutils.DeleteTeardownHookResources(cd, remoteClient, clp.Spec.ClaimReleaseHooks, logger)
+	}
+	if !fake && cd.Spec.ClusterMetadata != nil && cd.Spec.ClusterMetadata.AdminPasswordSecretRef != nil {
+		rotatedAt := cd.Status.KubeadminPasswordRotatedTimestamp
+		if rotatedAt == nil || rotatedAt.Before(claimReleaseHooksCompletionTime(cd)) {
+			if cd.Annotations[constants.RotateKubeadminPasswordAnnotation] == "true" {
+				logger.Debug("waiting for kubeadmin password of released cluster to be rotated")
+				return sanitizingReuseState, nil
+			}
+			if err := cleanUp(); err != nil {
+				return "", err
+			}
+			logger.Info("requesting rotation of kubeadmin password of released cluster")
+			if cd.Annotations == nil {
+				cd.Annotations = map[string]string{}
+			}
+			cd.Annotations[constants.RotateKubeadminPasswordAnnotation] = "true"
+			if err := r.Update(context.Background(), cd); err != nil {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "could not request kubeadmin password rotation")
+				return "", errors.Wrap(err, "could not request kubeadmin password rotation")
+			}
+			return sanitizingReuseState, nil
+		}
+	} else if err := cleanUp(); err != nil {
+		return "", err
+	}
+
+	if err := r.returnClusterToPool(cd, logger); err != nil {
+		return "", err
+	}
+	return returnedReuseState, nil
+}
+
+// claimReleaseHooksCompletionTime returns when the last claim release hook of the cluster completed.
+func claimReleaseHooksCompletionTime(cd *hivev1.ClusterDeployment) *metav1.Time {
+	latest := &metav1.Time{}
+	for _, status := range cd.Status.ClaimReleaseHooks {
+		if status.CompletionTime != nil && latest.Before(status.CompletionTime) {
+			latest = status.CompletionTime
+		}
+	}
+	return latest
+}
+
+// returnClusterToPool clears the claim of a sanitized cluster, so that the pool can assign it to another claim.
+func (r *ReconcileClusterPool) returnClusterToPool(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	ns := &corev1.Namespace{}
+	if err := r.Get(context.Background(), client.ObjectKey{Name: cd.Namespace}, ns); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get cluster namespace")
+		return errors.Wrap(err, "could not get cluster namespace")
+	}
+	if _, ok := ns.Labels[constants.ClusterClaimNameLabel]; ok {
+		delete(ns.Labels, constants.ClusterClaimNameLabel)
+		delete(ns.Labels, constants.ClusterClaimNamespaceLabel)
+		if err := r.Update(context.Background(), ns); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not remove claim labels from cluster namespace")
+			return errors.Wrap(err, "could not remove claim labels from cluster namespace")
+		}
+	}
+
+	// Without its lease, the clustersync controller reapplies all SyncSets of the cluster.
+	lease := &hiveintv1alpha1.ClusterSyncLease{ObjectMeta: metav1.ObjectMeta{Namespace: cd.Namespace, Name: cd.Name}}
+	if err := r.Delete(context.Background(), lease); err != nil && !apierrors.IsNotFound(err) {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not delete ClusterSyncLease")
+		return errors.Wrap(err, "could not delete ClusterSyncLease")
+	}
+
+	if len(cd.Status.ClaimReleaseHooks) > 0 {
+		cd.Status.ClaimReleaseHooks = nil
+		if err := r.Status().Update(context.Background(), cd); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not clear claim release hook status")
+			return errors.Wrap(err, "could not clear claim release hook status")
+		}
+	}
+	cd.Spec.ClusterPoolRef.ClaimName = ""
+	cd.Spec.ClusterPoolRef.ClaimedTimestamp = nil
+	delete(cd.Annotations, constants.ClusterClaimRemoveClusterAnnotation)
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not return cluster deployment to pool")
+		return errors.Wrap(err, "could not return ClusterDeployment to pool")
+	}
+	logger.Info("returned released cluster to pool")
+	return nil
+}
@@ -0,0 +1,278 @@
+package clusterpool
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+)
+
+func TestReuseCluster(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	const cdName = "c1"
+
+	cleanupJob := func(conditionType batchv1.JobConditionType) *batchv1.Job {
+		j := &batchv1.Job{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "batch/v1", Kind: "Job"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "hooks", Name: "cleanup"},
+		}
+		if conditionType != "" {
+			j.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+		}
+		return j
+	}
+	raw, err := json.Marshal(cleanupJob(""))
+	require.NoError(t, err, "unexpected error marshalling job")
+	hook := hivev1.TeardownHook{Name: "cleanup", Resources: []runtime.RawExtension{{Raw: raw}}}
+
+	completedAt := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	completed := func(state hivev1.TeardownHookState) func(*hivev1.ClusterDeployment) {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Status.ClaimReleaseHooks = []hivev1.TeardownHookStatus{{
+				Name:           "cleanup",
+				State:          state,
+				StartTime:      &metav1.Time{Time: completedAt.Add(-time.Minute)},
+				CompletionTime: &completedAt,
+			}}
+		}
+	}
+	withAnnotation := func(key string) func(*hivev1.ClusterDeployment) {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Annotations[key] = "true"
+		}
+	}
+	fence := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        controllerutils.RelocationFenceNamespace,
+			Annotations: map[string]string{constants.RelocationFencingTokenAnnotation: "other-hub"},
+		},
+	}
+	rotatedAt := func(ts time.Time) func(*hivev1.ClusterDeployment) {
+		return func(cd *hivev1.ClusterDeployment) {
+			cd.Status.KubeadminPasswordRotatedTimestamp = &metav1.Time{Time: ts}
+		}
+	}
+
+	tests := []struct {
+		name              string
+		mutateCD          []func(*hivev1.ClusterDeployment)
+		remote            []runtime.Object
+		expectedState     reuseState
+		expectedHookState hivev1.TeardownHookState
+		expectRotation    bool
+		expectJobDeleted  bool
+		expectJobKept     bool
+	}{
+		{
+			name:              "run claim release hooks",
+			expectedState:     sanitizingReuseState,
+			expectedHookState: hivev1.RunningTeardownHookState,
+		},
+		{
+			name:              "wait for claim release hooks",
+			mutateCD:          []func(*hivev1.ClusterDeployment){completed(hivev1.RunningTeardownHookState)},
+			remote:            []runtime.Object{cleanupJob("")},
+			expectedState:     sanitizingReuseState,
+			expectedHookState: hivev1.RunningTeardownHookState,
+		},
+		{
+			name:              "failed claim release hook",
+			mutateCD:          []func(*hivev1.ClusterDeployment){completed(hivev1.FailedTeardownHookState)},
+			remote:            []runtime.Object{cleanupJob(batchv1.JobFailed)},
+			expectedState:     unfitReuseState,
+			expectedHookState: hivev1.FailedTeardownHookState,
+		},
+		{
+			name:              "request kubeadmin password rotation",
+			mutateCD:          []func(*hivev1.ClusterDeployment){completed(hivev1.SucceededTeardownHookState)},
+			remote:            []runtime.Object{cleanupJob(batchv1.JobComplete)},
+			expectedState:     sanitizingReuseState,
+			expectedHookState: hivev1.SucceededTeardownHookState,
+			expectRotation:    true,
+			expectJobDeleted:  true,
+		},
+		{
+			name:              "skip claim release hooks of fenced cluster",
+			remote:            []runtime.Object{fence},
+			expectedState:     sanitizingReuseState,
+			expectedHookState: hivev1.SkippedTeardownHookState,
+			expectRotation:    true,
+		},
+		{
+			name:              "keep claim release hook resources of fenced cluster",
+			mutateCD:          []func(*hivev1.ClusterDeployment){completed(hivev1.SucceededTeardownHookState)},
+			remote:            []runtime.Object{fence, cleanupJob(batchv1.JobComplete)},
+			expectedState:     sanitizingReuseState,
+			expectedHookState: hivev1.SucceededTeardownHookState,
+			expectRotation:    true,
+			expectJobKept:     true,
+		},
+		{
+			name: "wait for kubeadmin password rotation",
+			mutateCD: []func(*hivev1.ClusterDeployment){
+				completed(hivev1.SucceededTeardownHookState),
+				withAnnotation(constants.RotateKubeadminPasswordAnnotation),
+				rotatedAt(completedAt.Add(-time.Hour)),
+			},
+			expectedState:     sanitizingReuseState,
+			expectedHookState: hivev1.SucceededTeardownHookState,
+			expectRotation:    true,
+		},
+		{
+			name: "return sanitized cluster",
+			mutateCD: []func(*hivev1.ClusterDeployment){
+				completed(hivev1.SucceededTeardownHookState),
+				rotatedAt(completedAt.Add(time.Second)),
+			},
+			expectedState: returnedReuseState,
+		},
+		{
+			name: "return fake cluster",
+			mutateCD: []func(*hivev1.ClusterDeployment){
+				withAnnotation(constants.HiveFakeClusterAnnotation),
+			},
+			expectedState: returnedReuseState,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme.Scheme).Build(
+				testcp.WithClaimReleaseHooks(hook),
+				testcp.WithReleasePolicy(hivev1.ReuseClusterPoolReleasePolicy),
+			)
+			cd := testcd.FullBuilder(cdName, cdName, scheme.Scheme).Build(
+				testcd.Installed(),
+				testcd.WithPowerState(hivev1.RunningClusterPowerState),
+				testcd.WithClusterPoolReference(testNamespace, testLeasePoolName, "test-claim"),
+				testcd.WithAnnotation(constants.ClusterClaimRemoveClusterAnnotation, "true"),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+						AdminPasswordSecretRef: &corev1.LocalObjectReference{Name: "admin-password"},
+					}
+				},
+			)
+			for _, mutate := range test.mutateCD {
+				mutate(cd)
+			}
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name: cdName,
+				Labels: map[string]string{
+					constants.ClusterPoolNameLabel:       testLeasePoolName,
+					constants.ClusterClaimNameLabel:      "test-claim",
+					constants.ClusterClaimNamespaceLabel: testNamespace,
+				},
+			}}
+			lease := &hiveintv1alpha1.ClusterSyncLease{ObjectMeta: metav1.ObjectMeta{Namespace: cdName, Name: cdName}}
+			hubClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(pool, cd, ns, lease).Build()
+			remoteClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(test.remote...).Build()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil).AnyTimes()
+
+			r := &ReconcileClusterPool{
+				Client: hubClient,
+				logger: log.WithField("test", test.name),
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder {
+					return mockRemoteClientBuilder
+				},
+			}
+			state, err := r.reuseCluster(pool, cd, log.WithField("test", test.name))
+			require.NoError(t, err, "unexpected error reusing cluster")
+			assert.Equal(t, test.expectedState, state, "unexpected reuse state")
+
+			actual := &hivev1.ClusterDeployment{}
+			require.NoError(t, hubClient.Get(context.TODO(), client.ObjectKey{Namespace: cdName, Name: cdName}, actual))
+			assert.Equal(t, test.expectRotation, actual.Annotations[constants.RotateKubeadminPasswordAnnotation] == "true",
+				"unexpected kubeadmin password rotation request")
+			err = remoteClient.Get(context.TODO(), client.ObjectKey{Namespace: "hooks", Name: "cleanup"}, &batchv1.Job{})
+			if test.expectJobDeleted {
+				assert.True(t, apierrors.IsNotFound(err), "expected claim release hook job to be deleted")
+			}
+			if test.expectJobKept {
+				assert.NoError(t, err, "expected claim release hook job to be kept")
+			}
+
+			actualNS := &corev1.Namespace{}
+			require.NoError(t, hubClient.Get(context.TODO(), client.ObjectKey{Name: cdName}, actualNS))
+			err = hubClient.Get(context.TODO(), client.ObjectKey{Namespace: cdName, Name: cdName}, &hiveintv1alpha1.ClusterSyncLease{})
+			if test.expectedState != returnedReuseState {
+				assert.Equal(t, "test-claim", actual.Spec.ClusterPoolRef.ClaimName, "expected cluster to remain claimed")
+				if assert.Len(t, actual.Status.ClaimReleaseHooks, 1, "expected claim release hook status") {
+					assert.Equal(t, test.expectedHookState, actual.Status.ClaimReleaseHooks[0].State, "unexpected claim release hook state")
+				}
+				assert.NoError(t, err, "expected ClusterSyncLease to remain")
+				return
+			}
+			assert.Empty(t, actual.Spec.ClusterPoolRef.ClaimName, "expected claim to be cleared")
+			assert.Nil(t, actual.Spec.ClusterPoolRef.ClaimedTimestamp, "expected claimed timestamp to be cleared")
+			assert.NotContains(t, actual.Annotations, constants.ClusterClaimRemoveClusterAnnotation, "expected removal annotation to be cleared")
+			assert.Empty(t, actual.Status.ClaimReleaseHooks, "expected claim release hook status to be cleared")
+			assert.Equal(t, map[string]string{constants.ClusterPoolNameLabel: testLeasePoolName}, actualNS.Labels,
+				"expected claim labels to be removed from cluster namespace")
+			assert.True(t, apierrors.IsNotFound(err), "expected ClusterSyncLease to be deleted")
+		})
+	}
+}
+
+func TestPartitionReleasedClusters(t *testing.T) {
+	const poolVersion = "abc123"
+	released := func(name string, opts ...testcd.Option) *hivev1.ClusterDeployment {
+		opts = append([]testcd.Option{
+			testcd.Installed(),
+			testcd.WithPowerState(hivev1.RunningClusterPowerState),
+			testcd.WithPoolVersion(poolVersion),
+		}, opts...)
+		return testcd.FullBuilder(name, name, scheme.Scheme).Build(opts...)
+	}
+	cds := []*hivev1.ClusterDeployment{
+		released("reusable"),
+		released("hibernating", testcd.WithPowerState(hivev1.HibernatingClusterPowerState)),
+		released("stale", testcd.WithPoolVersion("def456")),
+		released("failed-hook", func(cd *hivev1.ClusterDeployment) {
+			cd.Status.ClaimReleaseHooks = []hivev1.TeardownHookStatus{{Name: "cleanup", State: hivev1.TimedOutTeardownHookState}}
+		}),
+	}
+	names := func(cds []*hivev1.ClusterDeployment) []string {
+		var n []string
+		for _, cd := range cds {
+			n = append(n, cd.Name)
+		}
+		return n
+	}
+
+	pool := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme.Scheme).Build()
+	toReuse, toDestroy := partitionReleasedClusters(pool, cds, poolVersion, log.StandardLogger())
+	assert.Empty(t, toReuse, "expected no clusters to be reused by default")
+	assert.Len(t, toDestroy, len(cds), "expected all clusters to be destroyed by default")
+
+	pool.Spec.ReleasePolicy = hivev1.ReuseClusterPoolReleasePolicy
+	toReuse, toDestroy = partitionReleasedClusters(pool, cds, poolVersion, log.StandardLogger())
+	assert.Equal(t, []string{"reusable"}, names(toReuse), "unexpected clusters to reuse")
+	assert.Equal(t, []string{"hibernating", "stale", "failed-hook"}, names(toDestroy), "unexpected clusters to destroy")
+}
@@ -59,7 +59,7 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		fmt.Sprintf("%s-controller", ControllerName),
 		mgr,
 		controller.Options{
-			Reconciler:              r,
+			Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
 			MaxConcurrentReconciles: concurrentReconciles,
 			RateLimiter:             rateLimiter,
 		},
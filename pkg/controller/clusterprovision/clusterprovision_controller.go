@@ -3,6 +3,7 @@ package clusterprovision
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/pkg/errors"
@@ -30,6 +31,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/executioncluster"
 	"github.com/openshift/hive/pkg/install"
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
 )
@@ -45,6 +47,10 @@ const (
 	resultFailure = "failure"
 
 	podStatusCheckDelay = 60 * time.Second
+
+	// executionClusterPollInterval is how often install jobs running on an execution cluster are checked, since they
+	// cannot be watched.
+	executionClusterPollInterval = 30 * time.Second
 )
 
 var (
@@ -90,6 +96,8 @@ func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 		scheme:       mgr.GetScheme(),
 		logger:       logger,
 		expectations: controllerutils.NewExpectations(logger),
+
+		buildExecutionClusterClient: executioncluster.BuildClient,
 	}
 }
 
@@ -102,7 +110,7 @@ func add(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int,
 
 	// Create a new controller
 	c, err := controller.New("clusterprovision-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -137,6 +145,10 @@ type ReconcileClusterProvision struct {
 	logger log.FieldLogger
 	// A TTLCache of job creates each clusterprovision expects to see
 	expectations controllerutils.ExpectationsInterface
+
+	// buildExecutionClusterClient builds the client for the execution cluster running install jobs, when one is
+	// configured in HiveConfig.
+	buildExecutionClusterClient func(client.Client, *hivev1.ExecutionClusterConfig) (client.Client, error)
 }
 
 // Reconcile reads that state of the cluster for a ClusterProvision object and makes changes based on the state read
@@ -178,6 +190,15 @@ func (r *ReconcileClusterProvision) Reconcile(ctx context.Context, request recon
 		return reconcile.Result{}, nil
 	}
 
+	// The stage is in the spec, so the Ready condition catches up with a transition when the new generation is
+	// observed.
+	if setReadyCondition(instance) {
+		if err := r.Status().Update(context.TODO(), instance); err != nil {
+			pLog.WithError(err).Log(controllerutils.LogLevel(err), "cannot update status")
+			return reconcile.Result{}, err
+		}
+	}
+
 	switch instance.Spec.Stage {
 	case hivev1.ClusterProvisionStageInitializing:
 		if instance.Status.JobRef != nil {
@@ -206,14 +227,37 @@ func (r *ReconcileClusterProvision) Reconcile(ctx context.Context, request recon
 	}
 }
 
+// jobClient returns the client for the cluster running install jobs. When the jobs run on an execution cluster
+// rather than on the hub, the configuration of the execution cluster is returned as well.
+func (r *ReconcileClusterProvision) jobClient(pLog log.FieldLogger) (client.Client, *hivev1.ExecutionClusterConfig, error) {
+	config, err := executioncluster.ReadConfig()
+	if err != nil {
+		pLog.WithError(err).Error("could not read execution cluster config")
+		return nil, nil, err
+	}
+	if config == nil {
+		return r.Client, nil, nil
+	}
+	c, err := r.buildExecutionClusterClient(r.Client, config)
+	if err != nil {
+		pLog.WithError(err).Error("could not build client for execution cluster")
+		return nil, nil, err
+	}
+	return c, config, nil
+}
+
 func (r *ReconcileClusterProvision) reconcileNewProvision(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (reconcile.Result, error) {
-	existingJobs, err := r.existingJobs(instance, pLog)
+	jobClient, execConfig, err := r.jobClient(pLog)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	existingJobs, err := r.existingJobs(jobClient, instance, pLog)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 	switch len(existingJobs) {
 	case 0:
-		return r.createJob(instance, pLog)
+		return r.createJob(instance, jobClient, execConfig, pLog)
 	case 1:
 		return r.adoptJob(instance, existingJobs[0], pLog)
 	default:
@@ -221,7 +265,7 @@ func (r *ReconcileClusterProvision) reconcileNewProvision(instance *hivev1.Clust
 	}
 }
 
-func (r *ReconcileClusterProvision) createJob(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (reconcile.Result, error) {
+func (r *ReconcileClusterProvision) createJob(instance *hivev1.ClusterProvision, jobClient client.Client, execConfig *hivev1.ExecutionClusterConfig, pLog log.FieldLogger) (reconcile.Result, error) {
 	job, err := install.GenerateInstallerJob(instance)
 	if err != nil {
 		pLog.WithError(err).Error("error generating install job")
@@ -239,6 +283,21 @@ func (r *ReconcileClusterProvision) createJob(instance *hivev1.ClusterProvision,
 		return reconcile.Result{}, err
 	}
 
+	if execConfig != nil {
+		if err := executioncluster.PrepareJob(r.Client, jobClient, execConfig, job, pLog); err != nil {
+			pLog.WithError(err).Error("error preparing execution cluster for install job")
+			return reconcile.Result{}, err
+		}
+		pLog.Info("creating install job on execution cluster")
+		if err := jobClient.Create(context.TODO(), job); err != nil {
+			pLog.WithError(err).Error("error creating job on execution cluster")
+			return reconcile.Result{}, err
+		}
+		// Jobs on the execution cluster are not watched, so the job is adopted straight away rather than when its
+		// creation is observed.
+		return r.adoptJob(instance, job, pLog)
+	}
+
 	pLog.Infof("creating install job")
 	r.expectations.ExpectCreations(types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}.String(), 1)
 	if err := r.Create(context.TODO(), job); err != nil {
@@ -259,8 +318,12 @@ func (r *ReconcileClusterProvision) adoptJob(instance *hivev1.ClusterProvision,
 func (r *ReconcileClusterProvision) reconcileRunningJob(instance *hivev1.ClusterProvision, pLog log.FieldLogger) (reconcile.Result, error) {
 	pLog.Debug("reconciling running job")
 
+	jobClient, execConfig, err := r.jobClient(pLog)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 	job := &batchv1.Job{}
-	switch err := r.Get(context.TODO(), types.NamespacedName{Name: instance.Status.JobRef.Name, Namespace: instance.Namespace}, job); {
+	switch err := jobClient.Get(context.TODO(), types.NamespacedName{Name: instance.Status.JobRef.Name, Namespace: instance.Namespace}, job); {
 	case apierrors.IsNotFound(err):
 		if cond := controllerutils.FindClusterProvisionCondition(instance.Status.Conditions, hivev1.ClusterProvisionFailedCondition); cond == nil {
 			pLog.Error("install job lost")
@@ -292,7 +355,7 @@ func (r *ReconcileClusterProvision) reconcileRunningJob(instance *hivev1.Cluster
 	pLog.Debug("install job still running")
 
 	if time.Since(job.CreationTimestamp.Time) > podStatusCheckDelay {
-		installPod, err := r.getInstallPod(job, pLog)
+		installPod, err := r.getInstallPod(jobClient, job, pLog)
 		if err != nil {
 			pLog.WithError(err).Error("could not get install pod")
 			if err := r.setCondition(instance, hivev1.InstallPodStuckCondition, corev1.ConditionTrue, "InstallPodMissing", err.Error(), controllerutils.UpdateConditionIfReasonOrMessageChange, pLog); err != nil {
@@ -337,19 +400,25 @@ func (r *ReconcileClusterProvision) reconcileRunningJob(instance *hivev1.Cluster
 	}
 
 	if timeUntilNextPodStatusCheck := podStatusCheckDelay - time.Since(job.CreationTimestamp.Time); timeUntilNextPodStatusCheck > 0 {
+		if execConfig != nil && timeUntilNextPodStatusCheck > executionClusterPollInterval {
+			timeUntilNextPodStatusCheck = executionClusterPollInterval
+		}
 		return reconcile.Result{RequeueAfter: timeUntilNextPodStatusCheck}, nil
 	}
+	if execConfig != nil {
+		return reconcile.Result{RequeueAfter: executionClusterPollInterval}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
-func (r *ReconcileClusterProvision) getInstallPod(job *batchv1.Job, pLog log.FieldLogger) (*corev1.Pod, error) {
+func (r *ReconcileClusterProvision) getInstallPod(jobClient client.Client, job *batchv1.Job, pLog log.FieldLogger) (*corev1.Pod, error) {
 	podList := &corev1.PodList{}
 	podLabelSelector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
 	if err != nil {
 		pLog.WithError(err).Error("could not create pod selector from job")
 		return nil, fmt.Errorf("could not create pod selector from job")
 	}
-	if err := r.List(
+	if err := jobClient.List(
 		context.TODO(),
 		podList,
 		client.MatchingLabelsSelector{Selector: podLabelSelector},
@@ -408,8 +477,12 @@ func (r *ReconcileClusterProvision) abortProvision(instance *hivev1.ClusterProvi
 	if err := r.setCondition(instance, hivev1.ClusterProvisionFailedCondition, corev1.ConditionTrue, reason, message, controllerutils.UpdateConditionAlways, pLog); err != nil {
 		return reconcile.Result{}, err
 	}
+	jobClient, _, err := r.jobClient(pLog)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 	job := &batchv1.Job{}
-	switch err := r.Get(context.TODO(), client.ObjectKey{Namespace: instance.Namespace, Name: instance.Status.JobRef.Name}, job); {
+	switch err := jobClient.Get(context.TODO(), client.ObjectKey{Namespace: instance.Namespace, Name: instance.Status.JobRef.Name}, job); {
 	case apierrors.IsNotFound(err):
 		pLog.Warn("install job for aborted provision already gone before it was deleted")
 		return reconcile.Result{}, nil
@@ -417,7 +490,7 @@ func (r *ReconcileClusterProvision) abortProvision(instance *hivev1.ClusterProvi
 		pLog.WithError(err).Error("could not get install job")
 		return reconcile.Result{}, err
 	}
-	if err := r.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil {
+	if err := jobClient.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil {
 		pLog.WithError(err).Log(controllerutils.LogLevel(err), "could not delete install job")
 		return reconcile.Result{}, err
 	}
@@ -471,6 +544,7 @@ func (r *ReconcileClusterProvision) setCondition(
 		message,
 		updateConditionCheck,
 	)
+	setReadyCondition(instance)
 	if err := r.Status().Update(context.TODO(), instance); err != nil {
 		pLog.WithError(err).Error("cannot update status conditions")
 		return err
@@ -478,6 +552,44 @@ func (r *ReconcileClusterProvision) setCondition(
 	return nil
 }
 
+// setReadyCondition sets the Ready condition of the provision from its stage, and records the generation of the
+// provision in its status and conditions. It returns whether the status of the provision changed.
+func setReadyCondition(instance *hivev1.ClusterProvision) bool {
+	origStatus := instance.Status.DeepCopy()
+	var status corev1.ConditionStatus
+	var reason, message string
+	switch instance.Spec.Stage {
+	case hivev1.ClusterProvisionStageComplete:
+		status, reason, message = corev1.ConditionTrue, "Complete", "The cluster provision has completed"
+	case hivev1.ClusterProvisionStageFailed:
+		status, reason, message = corev1.ConditionFalse, "Failed", "The cluster provision has failed"
+		if cond := controllerutils.FindClusterProvisionCondition(instance.Status.Conditions, hivev1.ClusterProvisionFailedCondition); cond != nil {
+			message = cond.Message
+		}
+	case hivev1.ClusterProvisionStageProvisioning:
+		status, reason, message = corev1.ConditionFalse, "Provisioning", "The cluster is being provisioned"
+	default:
+		status, reason, message = corev1.ConditionFalse, "Initializing", "The cluster provision is initializing"
+	}
+	instance.Status.Conditions = controllerutils.InitializeClusterProvisionConditions(
+		instance.Status.Conditions,
+		[]hivev1.ClusterProvisionConditionType{hivev1.ClusterProvisionReadyCondition},
+	)
+	instance.Status.Conditions = controllerutils.SetClusterProvisionCondition(
+		instance.Status.Conditions,
+		hivev1.ClusterProvisionReadyCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	instance.Status.ObservedGeneration = instance.Generation
+	for i := range instance.Status.Conditions {
+		instance.Status.Conditions[i].ObservedGeneration = instance.Generation
+	}
+	return !reflect.DeepEqual(origStatus, &instance.Status)
+}
+
 func (r *ReconcileClusterProvision) setStage(instance *hivev1.ClusterProvision, stage hivev1.ClusterProvisionStage, pLog log.FieldLogger) error {
 	instance.Spec.Stage = stage
 	if err := r.Update(context.TODO(), instance); err != nil {
@@ -487,9 +599,9 @@ func (r *ReconcileClusterProvision) setStage(instance *hivev1.ClusterProvision,
 	return nil
 }
 
-func (r *ReconcileClusterProvision) existingJobs(provision *hivev1.ClusterProvision, pLog log.FieldLogger) ([]*batchv1.Job, error) {
+func (r *ReconcileClusterProvision) existingJobs(jobClient client.Client, provision *hivev1.ClusterProvision, pLog log.FieldLogger) ([]*batchv1.Job, error) {
 	jobList := &batchv1.JobList{}
-	if err := r.List(
+	if err := jobClient.List(
 		context.TODO(),
 		jobList,
 		client.InNamespace(provision.Namespace),
@@ -559,8 +671,12 @@ func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerut
 // deleteInstallJob deletes the install job of a successful provision
 func (r *ReconcileClusterProvision) deleteInstallJob(provision *hivev1.ClusterProvision, pLog log.FieldLogger) (reconcile.Result, error) {
 	pLog.Info("deleting successful install job")
+	jobClient, _, err := r.jobClient(pLog)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 	job := &batchv1.Job{}
-	switch err := r.Get(context.TODO(), types.NamespacedName{Name: provision.Status.JobRef.Name, Namespace: provision.Namespace}, job); {
+	switch err := jobClient.Get(context.TODO(), types.NamespacedName{Name: provision.Status.JobRef.Name, Namespace: provision.Namespace}, job); {
 	case apierrors.IsNotFound(err):
 		pLog.Info("install job has already been deleted")
 	case err != nil:
@@ -568,7 +684,7 @@ func (r *ReconcileClusterProvision) deleteInstallJob(provision *hivev1.ClusterPr
 		return reconcile.Result{}, err
 	default:
 		// deleting install job with background propagation policy to cascade delete install pod
-		if err := r.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+		if err := jobClient.Delete(context.TODO(), job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
 			pLog.WithField("job", job.Name).WithError(err).Log(controllerutils.LogLevel(err), "error deleting successful install job")
 			return reconcile.Result{}, err
 		}
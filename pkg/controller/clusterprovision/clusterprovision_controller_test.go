@@ -3,6 +3,9 @@ package clusterprovision
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -59,6 +62,7 @@ func TestClusterProvisionReconcile(t *testing.T) {
 		expectErr             bool
 		expectedStage         hivev1.ClusterProvisionStage
 		expectedFailReason    string
+		expectedReadyReason   string
 		expectNoJob           bool
 		expectNoJobReference  bool
 		expectPendingCreation bool
@@ -180,6 +184,28 @@ func TestClusterProvisionReconcile(t *testing.T) {
 			expectNoJob:          true,
 			expectNoJobReference: true,
 		},
+		{
+			name: "ready once complete stage is observed",
+			existing: []runtime.Object{
+				testProvision(
+					tcp.WithStage(hivev1.ClusterProvisionStageComplete),
+					tcp.WithJob(installJobName),
+					tcp.WithCreationTimestamp(time.Now()),
+					tcp.Generic(testgeneric.WithGeneration(3))),
+				testJob(),
+			},
+			expectedStage:       hivev1.ClusterProvisionStageComplete,
+			expectedReadyReason: "Complete",
+		},
+		{
+			name: "not ready after failure",
+			existing: []runtime.Object{
+				testProvision(tcp.Failed(), tcp.WithJob(installJobName)),
+				testJob(),
+			},
+			expectedStage:       hivev1.ClusterProvisionStageFailed,
+			expectedReadyReason: "Failed",
+		},
 		{
 			name: "keep job after failure",
 			existing: []runtime.Object{
@@ -311,6 +337,16 @@ func TestClusterProvisionReconcile(t *testing.T) {
 				} else {
 					assert.Nil(t, failedCond, "expected not to find a Failed condition")
 				}
+				if test.expectedReadyReason != "" {
+					readyCond := controllerutils.FindClusterProvisionCondition(provision.Status.Conditions, hivev1.ClusterProvisionReadyCondition)
+					if assert.NotNil(t, readyCond, "expected to find a Ready condition") {
+						assert.Equal(t, test.expectedReadyReason, readyCond.Reason, "unexpected Ready reason")
+						assert.Equal(t, test.expectedStage == hivev1.ClusterProvisionStageComplete, readyCond.Status == corev1.ConditionTrue,
+							"unexpected Ready status")
+						assert.Equal(t, provision.Generation, readyCond.ObservedGeneration, "unexpected Ready observed generation")
+					}
+					assert.Equal(t, provision.Generation, provision.Status.ObservedGeneration, "unexpected observed generation")
+				}
 				if test.expectNoJobReference {
 					assert.Nil(t, provision.Status.JobRef, "expected no job reference from provision")
 				} else {
@@ -337,6 +373,86 @@ func TestClusterProvisionReconcile(t *testing.T) {
 	}
 }
 
+func TestClusterProvisionReconcileOnExecutionCluster(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	dir, err := ioutil.TempDir("", "clusterprovision")
+	require.NoError(t, err, "unexpected error creating temp dir")
+	defer os.RemoveAll(dir)
+	configPath := filepath.Join(dir, "config")
+	require.NoError(t, ioutil.WriteFile(configPath, []byte(`{"kubeconfigSecretRef":{"name":"exec"},"hubKubeconfigSecretRef":{"name":"hub"}}`), 0600),
+		"unexpected error writing config")
+	os.Setenv(constants.ExecutionClusterConfigFileEnvVar, configPath)
+	defer os.Unsetenv(constants.ExecutionClusterConfigFileEnvVar)
+
+	hubKubeconfig := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: "hub"},
+		Data:       map[string][]byte{constants.KubeconfigSecretKey: []byte("hub")},
+	}
+
+	tests := []struct {
+		name          string
+		existing      []runtime.Object
+		existingJobs  []runtime.Object
+		expectedStage hivev1.ClusterProvisionStage
+		validate      func(hubClient, execClient client.Client, t *testing.T)
+	}{
+		{
+			name:          "create job",
+			existing:      []runtime.Object{testProvision(), hubKubeconfig},
+			expectedStage: hivev1.ClusterProvisionStageInitializing,
+			validate: func(hubClient, execClient client.Client, t *testing.T) {
+				assert.Nil(t, getJob(hubClient), "expected no job on hub")
+				job := getJob(execClient)
+				if assert.NotNil(t, job, "expected job on execution cluster") {
+					assert.Empty(t, job.OwnerReferences, "expected no owner references on job")
+				}
+				provision := getProvision(hubClient)
+				if assert.NotNil(t, provision.Status.JobRef, "expected job reference from provision") {
+					assert.Equal(t, installJobName, provision.Status.JobRef.Name, "unexpected job name referenced from provision")
+				}
+				assertConditionStatus(t, provision, hivev1.ClusterProvisionJobCreated, corev1.ConditionTrue)
+			},
+		},
+		{
+			name:          "completed job",
+			existing:      []runtime.Object{testProvision(tcp.WithStage(hivev1.ClusterProvisionStageProvisioning), tcp.WithJob(installJobName))},
+			existingJobs:  []runtime.Object{testJob(completed())},
+			expectedStage: hivev1.ClusterProvisionStageComplete,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger := log.WithField("controller", "clusterProvision")
+			hubClient := fake.NewFakeClient(test.existing...)
+			execClient := fake.NewFakeClient(test.existingJobs...)
+			rcp := &ReconcileClusterProvision{
+				Client:       hubClient,
+				scheme:       scheme.Scheme,
+				logger:       logger,
+				expectations: controllerutils.NewExpectations(logger),
+				buildExecutionClusterClient: func(client.Client, *hivev1.ExecutionClusterConfig) (client.Client, error) {
+					return execClient, nil
+				},
+			}
+
+			_, err := rcp.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: testProvisionName, Namespace: testNamespace},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			provision := getProvision(hubClient)
+			if assert.NotNil(t, provision, "provision lost") {
+				assert.Equal(t, string(test.expectedStage), string(provision.Spec.Stage), "unexpected provision stage")
+			}
+			if test.validate != nil {
+				test.validate(hubClient, execClient, t)
+			}
+		})
+	}
+}
+
 func testProvision(opts ...tcp.Option) *hivev1.ClusterProvision {
 	provision := tcp.
 		FullBuilder(testNamespace, testProvisionName).
@@ -34,6 +34,9 @@ import (
 
 const (
 	ControllerName = hivev1.ClusterRelocateControllerName
+
+	// cdClusterRelocateIndex indexes ClusterDeployments by the name of the ClusterRelocate relocating them.
+	cdClusterRelocateIndex = "metadata.annotations.relocate"
 )
 
 var (
@@ -85,9 +88,12 @@ func Add(mgr manager.Manager) error {
 	r.remoteClusterAPIClientBuilder = func(secret *corev1.Secret) remoteclient.Builder {
 		return remoteclient.NewBuilderFromKubeconfig(r.Client, secret)
 	}
+	r.managedClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
 
 	c, err := controller.New("clusterrelocate-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(r, queueRateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             queueRateLimiter,
 	})
@@ -96,6 +102,18 @@ func Add(mgr manager.Manager) error {
 		return err
 	}
 
+	// Index ClusterDeployments by ClusterRelocate name
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &hivev1.ClusterDeployment{}, cdClusterRelocateIndex,
+		func(o client.Object) []string {
+			if relocateName, _, _ := controllerutils.IsRelocating(o); relocateName != "" {
+				return []string{relocateName}
+			}
+			return []string{}
+		}); err != nil {
+		logger.WithError(err).Error("Error indexing ClusterDeployments by ClusterRelocate")
+		return err
+	}
+
 	// Watch for changes to ClusterDeployment
 	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
 		logger.WithError(err).Error("Error watching ClusterDeployment")
@@ -123,21 +141,36 @@ func (r *ReconcileClusterRelocate) clusterRelocateHandlerFunc(a client.Object) (
 		return
 	}
 
-	clusterDeployments := &hivev1.ClusterDeploymentList{}
-	if err := r.List(context.Background(), clusterDeployments); err != nil {
+	// Enqueue the ClusterDeployments matching the selector, and those still being relocated by the ClusterRelocate
+	// even though they no longer match the selector.
+	matching := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.Background(), matching, client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
 		r.logger.WithError(err).
 			WithField("clusterRelocate", clusterRelocate.Name).
 			Log(controllerutils.LogLevel(err), "failed to list clusterdeployments")
 		return
 	}
+	relocating := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.Background(), relocating, client.MatchingFields{cdClusterRelocateIndex: clusterRelocate.Name}); err != nil {
+		r.logger.WithError(err).
+			WithField("clusterRelocate", clusterRelocate.Name).
+			Log(controllerutils.LogLevel(err), "failed to list relocating clusterdeployments")
+		return
+	}
 
-	for _, cd := range clusterDeployments.Items {
+	enqueued := map[types.NamespacedName]bool{}
+	for _, cd := range append(matching.Items, relocating.Items...) {
+		// This should only happen in unit tests: the fakeclient doesn't support index filters
 		if relocateName, _, _ := controllerutils.IsRelocating(&cd); relocateName != clusterRelocate.Name &&
 			!labelSelector.Matches(labels.Set(cd.Labels)) {
 			continue
 		}
-		requests = append(requests,
-			reconcile.Request{NamespacedName: types.NamespacedName{Name: cd.Name, Namespace: cd.Namespace}})
+		key := types.NamespacedName{Name: cd.Name, Namespace: cd.Namespace}
+		if enqueued[key] {
+			continue
+		}
+		enqueued[key] = true
+		requests = append(requests, reconcile.Request{NamespacedName: key})
 	}
 
 	return
@@ -152,6 +185,10 @@ type ReconcileClusterRelocate struct {
 	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
 	// for the remote cluster's API server
 	remoteClusterAPIClientBuilder func(secret *corev1.Secret) remoteclient.Builder
+
+	// managedClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the API server of the cluster of a ClusterDeployment, which is fenced during relocations
+	managedClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
 }
 
 // Reconcile relocates ClusterDeployments matching with a ClusterRelocate to another Hive instance.
@@ -177,9 +214,10 @@ func (r *ReconcileClusterRelocate) Reconcile(ctx context.Context, request reconc
 	newConditions := controllerutils.InitializeClusterDeploymentConditions(cd.Status.Conditions,
 		clusterDeploymentClusterRelocateConditions)
 	if len(newConditions) > len(cd.Status.Conditions) {
+		origConditions := cd.Status.Conditions
 		cd.Status.Conditions = newConditions
 		logger.Info("initializing cluster relocate controller conditions")
-		if err := r.Status().Update(context.TODO(), cd); err != nil {
+		if err := controllerutils.PatchClusterDeploymentConditions(r.Client, cd, origConditions); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
 			return reconcile.Result{}, err
 		}
@@ -232,7 +270,7 @@ func (r *ReconcileClusterRelocate) Reconcile(ctx context.Context, request reconc
 
 	// Do not do any relocation for a ClusterDeployment that does not match with exactly one ClusterRelocate
 	if len(desiredRelocates) != 1 {
-		return r.reconcileNoSingleMatch(cd, currentRelocateName, desiredRelocates, logger)
+		return r.reconcileNoSingleMatch(cd, relocateStatus, currentRelocateName, desiredRelocates, logger)
 	}
 
 	return r.reconcileSingleMatch(cd, relocateStatus, currentRelocateName, desiredRelocates[0], logger)
@@ -300,8 +338,23 @@ func (r *ReconcileClusterRelocate) reconcileSingleMatch(cd *hivev1.ClusterDeploy
 		return reconcile.Result{}, errors.Wrap(err, "could not set relocate status to outgoing")
 	}
 
+	// Fence the cluster to the destination before the ClusterDeployment is copied, so that this hub stops writing to
+	// the cluster even if the relocation is aborted once the destination hub has taken over.
+	fencingToken, err := r.fenceCluster(cd, controllerutils.NewRelocationFencingToken(), logger)
+	if err != nil {
+		r.setRelocationFailedCondition(
+			cd,
+			corev1.ConditionTrue,
+			"FencingFailed",
+			fmt.Sprintf("could not fence the cluster to the destination: %v", err),
+			logger,
+		)
+		// return the fencing error rather than the update error
+		return reconcile.Result{}, err
+	}
+
 	// Copy resources to destination cluster
-	if err := r.copy(cd, destClient, logger); err != nil {
+	if err := r.copy(cd, fencingToken, destClient, logger); err != nil {
 		r.setRelocationFailedCondition(
 			cd,
 			corev1.ConditionTrue,
@@ -435,12 +488,19 @@ func (r *ReconcileClusterRelocate) stopRelocating(cd *hivev1.ClusterDeployment,
 
 // reconcileNoSingleMatch reconciles a ClusterDeployment that does not match with exactly one ClusterRelocate.
 // Any in-progress relocates will be aborted.
-func (r *ReconcileClusterRelocate) reconcileNoSingleMatch(cd *hivev1.ClusterDeployment, currentRelocateName string, desiredRelocates []*hivev1.ClusterRelocate, logger log.FieldLogger) (reconcile.Result, error) {
+func (r *ReconcileClusterRelocate) reconcileNoSingleMatch(cd *hivev1.ClusterDeployment, relocateStatus hivev1.RelocateStatus, currentRelocateName string, desiredRelocates []*hivev1.ClusterRelocate, logger log.FieldLogger) (reconcile.Result, error) {
 	names := make([]string, len(desiredRelocates))
 	for i, cr := range desiredRelocates {
 		names[i] = cr.Name
 	}
 	logger = logger.WithField("matchingRelocates", names)
+	// The cluster may have been fenced to the destination already. It is fenced back before the relocation is
+	// stopped, so that the reclaim is retried until it succeeds.
+	if relocateStatus == hivev1.RelocateOutgoing {
+		if err := r.reclaimCluster(cd, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
 	if err := r.stopRelocating(cd, currentRelocateName, logger); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to stop relocation")
 		return reconcile.Result{}, errors.Wrap(err, "failed to stop relocation")
@@ -519,7 +579,7 @@ func (r *ReconcileClusterRelocate) checkForExistingClusterDeployment(cd *hivev1.
 	return
 }
 
-func (r *ReconcileClusterRelocate) copy(cd *hivev1.ClusterDeployment, destClient client.Client, logger log.FieldLogger) error {
+func (r *ReconcileClusterRelocate) copy(cd *hivev1.ClusterDeployment, fencingToken string, destClient client.Client, logger log.FieldLogger) error {
 	// create namespace
 	switch err := destClient.Create(context.Background(), &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -554,9 +614,13 @@ func (r *ReconcileClusterRelocate) copy(cd *hivev1.ClusterDeployment, destClient
 		}
 	}
 
-	// copy clusterdeployment
+	// copy clusterdeployment, with the fencing token of the destination
 	{
 		logger := logger.WithField("type", reflect.TypeOf(cd)).WithField("resource", cd.Name)
+		cd := cd.DeepCopy()
+		if fencingToken != "" {
+			controllerutils.SetRelocationFencingToken(cd, fencingToken)
+		}
 		if err := r.copyResource(cd, destClient, true, logger); err != nil {
 			return errors.Wrap(err, "failed to copy clusterdeployment")
 		}
@@ -565,6 +629,49 @@ func (r *ReconcileClusterRelocate) copy(cd *hivev1.ClusterDeployment, destClient
 	return nil
 }
 
+// fenceCluster fences the cluster of the ClusterDeployment with the given token, so that only the hub whose
+// ClusterDeployment carries the token writes to the cluster. Clusters which are not installed, or cannot be reached,
+// are not fenced, and an empty token is returned for them.
+func (r *ReconcileClusterRelocate) fenceCluster(cd *hivev1.ClusterDeployment, token string, logger log.FieldLogger) (string, error) {
+	if !cd.Spec.Installed || controllerutils.IsFakeCluster(cd) || controllerutils.IsClusterSleeping(cd) {
+		logger.Info("not fencing cluster which cannot be reached")
+		return "", nil
+	}
+	if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
+		logger.Warn("not fencing unreachable cluster")
+		return "", nil
+	}
+	remoteClient, err := r.managedClusterAPIClientBuilder(cd).Build()
+	if err != nil {
+		logger.WithError(err).Warn("could not create a client for the cluster")
+		return "", errors.Wrap(err, "could not create a client for the cluster")
+	}
+	if err := controllerutils.SetRemoteRelocationFence(remoteClient, token); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not fence cluster")
+		return "", errors.Wrap(err, "could not fence cluster")
+	}
+	logger.Info("fenced cluster")
+	return token, nil
+}
+
+// reclaimCluster fences the cluster of the ClusterDeployment with a new token of the ClusterDeployment, when an
+// outgoing relocation is aborted. Clusters which cannot be reached are left as they are.
+func (r *ReconcileClusterRelocate) reclaimCluster(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	token, err := r.fenceCluster(cd, controllerutils.NewRelocationFencingToken(), logger)
+	if err != nil {
+		return errors.Wrap(err, "failed to reclaim cluster")
+	}
+	if token == "" {
+		return nil
+	}
+	controllerutils.SetRelocationFencingToken(cd, token)
+	if err := r.Update(context.Background(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to set relocation fencing token")
+		return errors.Wrap(err, "failed to set relocation fencing token")
+	}
+	return nil
+}
+
 // copyResources copies all of the resources of the given object type in the namespace of the ClusterDeployment to the
 // destination cluster
 func (r *ReconcileClusterRelocate) copyResources(cd *hivev1.ClusterDeployment, destClient client.Client, objectList client.ObjectList, logger log.FieldLogger) error {
@@ -1193,6 +1193,146 @@ func TestReconcileClusterRelocate_Reconcile_RelocateStatus(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterRelocate_Reconcile_Fencing(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).GenericOptions(
+		testgeneric.WithLabel(labelKey, labelValue),
+	).Options(
+		testcd.Installed(),
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.RelocationFailedCondition,
+			Status: corev1.ConditionUnknown,
+		}),
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionFalse,
+		}),
+	)
+	crBuilder := testcr.FullBuilder(crName, scheme).Options(
+		testcr.WithKubeconfigSecret(kubeconfigNamespace, kubeconfigName),
+		testcr.WithClusterDeploymentSelector(labelKey, labelValue),
+	)
+
+	cases := []struct {
+		name          string
+		cd            *hivev1.ClusterDeployment
+		srcResources  []runtime.Object
+		expectDeleted bool
+	}{
+		{
+			name:          "relocate fences cluster to destination",
+			cd:            cdBuilder.Build(),
+			srcResources:  []runtime.Object{crBuilder.Build()},
+			expectDeleted: true,
+		},
+		{
+			name: "abort reclaims cluster",
+			cd: cdBuilder.Build(
+				testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+				testcd.Generic(testgeneric.WithAnnotation(constants.RelocationFencingTokenAnnotation, "old-token")),
+			),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeconfigSecret := testsecret.FullBuilder(kubeconfigNamespace, kubeconfigName, scheme).Build(
+				testsecret.WithDataKeyValue("kubeconfig", []byte("some-kubeconfig-data")),
+			)
+			srcClient := &deleteBlockingClientWrapper{fake.NewFakeClientWithScheme(scheme, append(tc.srcResources, tc.cd, kubeconfigSecret)...)}
+			destClient := fake.NewFakeClientWithScheme(scheme)
+			managedClient := fake.NewFakeClientWithScheme(scheme, testnamespace.FullBuilder(controllerutils.RelocationFenceNamespace, scheme).Build())
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockRemoteClientBuilder.EXPECT().Build().Return(destClient, nil).AnyTimes()
+			mockManagedClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			mockManagedClientBuilder.EXPECT().Build().Return(managedClient, nil).Times(1)
+
+			reconciler := &ReconcileClusterRelocate{
+				Client: srcClient,
+				logger: logger,
+				remoteClusterAPIClientBuilder: func(*corev1.Secret) remoteclient.Builder {
+					return mockRemoteClientBuilder
+				},
+				managedClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder {
+					return mockManagedClientBuilder
+				},
+			}
+			_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      cdName,
+					Namespace: namespace,
+				},
+			})
+			require.NoError(t, err, "unexpected error during reconcile")
+
+			fence, err := controllerutils.GetRemoteRelocationFence(managedClient)
+			require.NoError(t, err, "unexpected error getting relocation fence")
+			require.NotEmpty(t, fence, "expected cluster to be fenced")
+			assert.NotEqual(t, "old-token", fence, "expected a new fencing token")
+
+			srcCD := &hivev1.ClusterDeployment{}
+			err = srcClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: cdName}, srcCD)
+			if tc.expectDeleted {
+				require.True(t, apierrors.IsNotFound(err), "expected source clusterdeployment to be deleted")
+				destCD := &hivev1.ClusterDeployment{}
+				require.NoError(t, destClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: cdName}, destCD), "unexpected error fetching destination clusterdeployment")
+				assert.Equal(t, fence, controllerutils.RelocationFencingToken(destCD), "unexpected fencing token on destination clusterdeployment")
+			} else {
+				require.NoError(t, err, "unexpected error fetching source clusterdeployment")
+				assert.Equal(t, fence, controllerutils.RelocationFencingToken(srcCD), "unexpected fencing token on source clusterdeployment")
+				assert.NotContains(t, srcCD.Annotations, constants.RelocateAnnotation, "unexpected relocate annotation on source clusterdeployment")
+			}
+		})
+	}
+}
+
+func TestReconcileClusterRelocate_clusterRelocateHandlerFunc(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	cr := testcr.FullBuilder(crName, scheme).Build(testcr.WithClusterDeploymentSelector(labelKey, labelValue))
+	existing := []runtime.Object{
+		// matches the selector
+		testcd.FullBuilder(namespace, "matching", scheme).GenericOptions(
+			testgeneric.WithLabel(labelKey, labelValue),
+		).Build(),
+		// matches the selector and is being relocated
+		testcd.FullBuilder(namespace, "matching-relocating", scheme).GenericOptions(
+			testgeneric.WithLabel(labelKey, labelValue),
+			withRelocateAnnotation(crName, hivev1.RelocateOutgoing),
+		).Build(),
+		// no longer matches the selector but is still being relocated
+		testcd.FullBuilder(namespace, "relocating", scheme).GenericOptions(
+			withRelocateAnnotation(crName, hivev1.RelocateOutgoing),
+		).Build(),
+		// being relocated by another ClusterRelocate
+		testcd.FullBuilder(namespace, "other-relocating", scheme).GenericOptions(
+			withRelocateAnnotation("other", hivev1.RelocateOutgoing),
+		).Build(),
+		testcd.FullBuilder(namespace, "other", scheme).Build(),
+	}
+	rcr := &ReconcileClusterRelocate{
+		Client: fake.NewFakeClientWithScheme(scheme, existing...),
+		logger: log.New(),
+	}
+
+	requests := rcr.clusterRelocateHandlerFunc(cr)
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "matching"}},
+		{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "matching-relocating"}},
+		{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "relocating"}},
+	}, requests, "unexpected requests")
+}
+
 func withRelocateAnnotation(clusterRelocateName string, status hivev1.RelocateStatus) testgeneric.Option {
 	return testgeneric.WithAnnotation(
 		constants.RelocateAnnotation,
@@ -0,0 +1,102 @@
+package clustersync
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	crdGroup        = "apiextensions.k8s.io"
+	crdKind         = "CustomResourceDefinition"
+	apiServiceGroup = "apiregistration.k8s.io"
+	apiServiceKind  = "APIService"
+)
+
+// isAPIProvider returns true if the resource adds types to the API of the cluster. API providers in a SyncSet are
+// applied before the other resources of the SyncSet.
+func isAPIProvider(u *unstructured.Unstructured) bool {
+	gk := u.GroupVersionKind().GroupKind()
+	return gk == schema.GroupKind{Group: crdGroup, Kind: crdKind} ||
+		gk == schema.GroupKind{Group: apiServiceGroup, Kind: apiServiceKind}
+}
+
+// providesAPIFor returns true if the resource is of a type added to the API of the cluster by the provider.
+func providesAPIFor(provider, u *unstructured.Unstructured) bool {
+	gvk := u.GroupVersionKind()
+	group, _, _ := unstructured.NestedString(provider.Object, "spec", "group")
+	if group == "" || group != gvk.Group {
+		return false
+	}
+	switch provider.GetKind() {
+	case crdKind:
+		kind, _, _ := unstructured.NestedString(provider.Object, "spec", "names", "kind")
+		return kind == gvk.Kind
+	case apiServiceKind:
+		version, _, _ := unstructured.NestedString(provider.Object, "spec", "version")
+		return version == gvk.Version
+	}
+	return false
+}
+
+// apiProviderReadyCondition returns the type of the condition reporting that the API provider is serving its types.
+func apiProviderReadyCondition(provider *unstructured.Unstructured) string {
+	if provider.GetKind() == apiServiceKind {
+		return "Available"
+	}
+	return "Established"
+}
+
+// checkAPIProvidersReady returns an error if any of the API providers that the dependent resources need is not yet
+// serving its types in the target cluster.
+func checkAPIProvidersReady(
+	providers []*unstructured.Unstructured,
+	dependents []*unstructured.Unstructured,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) error {
+	for _, provider := range providers {
+		needed := false
+		for _, u := range dependents {
+			if providesAPIFor(provider, u) {
+				needed = true
+				break
+			}
+		}
+		if !needed {
+			continue
+		}
+		logger := logger.WithField("resourceName", provider.GetName()).WithField("resourceKind", provider.GetKind())
+		obj, err := resourceHelper.Get(provider.GetAPIVersion(), provider.GetKind(), "", provider.GetName())
+		if err != nil {
+			logger.WithError(err).Warn("could not get API provider")
+			return errors.Wrapf(err, "failed to get %s %s", provider.GetKind(), provider.GetName())
+		}
+		condType := apiProviderReadyCondition(provider)
+		if !hasTrueCondition(obj, condType) {
+			logger.WithField("condition", condType).Info("waiting for API provider to become ready")
+			return fmt.Errorf("waiting for %s %s to be %s", provider.GetKind(), provider.GetName(), condType)
+		}
+	}
+	return nil
+}
+
+func hasTrueCondition(u *unstructured.Unstructured, condType string) bool {
+	conds, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conds {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cond["type"] == condType {
+			return cond["status"] == "True"
+		}
+	}
+	return false
+}
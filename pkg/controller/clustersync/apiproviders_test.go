@@ -0,0 +1,69 @@
+package clustersync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestProvidesAPIFor(t *testing.T) {
+	apiService := &unstructured.Unstructured{}
+	apiService.SetAPIVersion("apiregistration.k8s.io/v1")
+	apiService.SetKind("APIService")
+	apiService.SetName("v1beta1.metrics.example.com")
+	unstructured.SetNestedField(apiService.Object, "metrics.example.com", "spec", "group")
+	unstructured.SetNestedField(apiService.Object, "v1beta1", "spec", "version")
+
+	cases := []struct {
+		name       string
+		provider   *unstructured.Unstructured
+		apiVersion string
+		kind       string
+		expected   bool
+	}{
+		{
+			name:       "crd kind",
+			provider:   testCRD("widgets.example.com", "example.com", "Widget"),
+			apiVersion: "example.com/v1",
+			kind:       "Widget",
+			expected:   true,
+		},
+		{
+			name:       "crd other kind",
+			provider:   testCRD("widgets.example.com", "example.com", "Widget"),
+			apiVersion: "example.com/v1",
+			kind:       "Gadget",
+		},
+		{
+			name:       "crd other group",
+			provider:   testCRD("widgets.example.com", "example.com", "Widget"),
+			apiVersion: "other.example.com/v1",
+			kind:       "Widget",
+		},
+		{
+			name:       "api service version",
+			provider:   apiService,
+			apiVersion: "metrics.example.com/v1beta1",
+			kind:       "NodeMetrics",
+			expected:   true,
+		},
+		{
+			name:       "api service other version",
+			provider:   apiService,
+			apiVersion: "metrics.example.com/v1",
+			kind:       "NodeMetrics",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			u := &unstructured.Unstructured{}
+			u.SetAPIVersion(tc.apiVersion)
+			u.SetKind(tc.kind)
+			assert.True(t, isAPIProvider(tc.provider), "expected API provider")
+			assert.False(t, isAPIProvider(u), "unexpected API provider")
+			assert.Equal(t, tc.expected, providesAPIFor(tc.provider, u), "unexpected result")
+		})
+	}
+}
@@ -3,6 +3,7 @@ package clustersync
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/big"
 	"math/rand"
 	"os"
@@ -18,6 +19,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -57,6 +59,9 @@ const (
 	metricResultSuccess    = "success"
 	metricResultError      = "error"
 	stsName                = "hive-clustersync"
+
+	// syncSetClusterDeploymentIndex indexes SyncSets by the names of the ClusterDeployments they apply to.
+	syncSetClusterDeploymentIndex = "spec.clusterdeploymentrefs.name"
 )
 
 var (
@@ -100,6 +105,14 @@ var (
 			Buckets: []float64{60, 300, 600, 1200, 1800, 2400, 3000, 3600},
 		},
 	)
+
+	metricReapplyLag = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "hive_clustersync_reapply_lag_seconds",
+			Help:    "Time by which the full reapply of the syncsets of a cluster was overdue when it ran. Growing lag indicates that the clustersync replica is saturated.",
+			Buckets: []float64{1, 10, 30, 60, 300, 600, 1800, 3600},
+		},
+	)
 )
 
 func init() {
@@ -108,6 +121,7 @@ func init() {
 	metrics.Registry.MustRegister(metricResourcesApplied)
 	metrics.Registry.MustRegister(metricTimeToApplySyncSetResource)
 	metrics.Registry.MustRegister(metricTimeToApplySyncSets)
+	metrics.Registry.MustRegister(metricReapplyLag)
 }
 
 // Add creates a new clustersync Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -192,11 +206,27 @@ func resourceHelperBuilderFunc(
 	return resource.NewHelperFromRESTConfig(restConfig, logger)
 }
 
+// getRemoteRelocationFence returns the fencing token of the managed cluster, empty if it has never been fenced.
+func getRemoteRelocationFence(resourceHelper resource.Helper) (string, error) {
+	ns, err := resourceHelper.Get("v1", "Namespace", "", controllerutils.RelocationFenceNamespace)
+	switch {
+	case apierrors.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", err
+	}
+	return controllerutils.RelocationFencingToken(ns), nil
+}
+
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r *ReconcileClusterSync, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	laneReconciler, err := controllerutils.NewUnreachableLaneReconciler(r, mgr.GetClient(), ControllerName)
+	if err != nil {
+		return err
+	}
 	// Create a new controller
 	c, err := controller.New("clusterSync-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(laneReconciler, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -204,6 +234,20 @@ func AddToManager(mgr manager.Manager, r *ReconcileClusterSync, concurrentReconc
 		return err
 	}
 
+	// Index SyncSets by ClusterDeployment name
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &hivev1.SyncSet{}, syncSetClusterDeploymentIndex,
+		func(o client.Object) []string {
+			ss := o.(*hivev1.SyncSet)
+			names := make([]string, len(ss.Spec.ClusterDeploymentRefs))
+			for i, cdRef := range ss.Spec.ClusterDeploymentRefs {
+				names[i] = cdRef.Name
+			}
+			return names
+		}); err != nil {
+		r.logger.WithError(err).Error("Error indexing SyncSets by ClusterDeployment")
+		return err
+	}
+
 	// Watch for changes to ClusterDeployment
 	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
 		return err
@@ -327,7 +371,10 @@ func (r *ReconcileClusterSync) isSyncAssignedToMe(sts *appsv1.StatefulSet, cd *h
 	replicas := int64(*sts.Spec.Replicas)
 
 	logger.Debug("determining who is assigned to sync this cluster")
-	ordinalIDOfAssignee := uidAsBigInt.Mod(&uidAsBigInt, big.NewInt(replicas)).Int64()
+	// Use the low 64 bits of the uid as the key for consistent hashing, so that scaling the statefulset only moves the
+	// clusters that need to move to or from the added or removed replicas.
+	key := uidAsBigInt.And(&uidAsBigInt, new(big.Int).SetUint64(math.MaxUint64)).Uint64()
+	ordinalIDOfAssignee := jumpHash(key, replicas)
 	assignedToMe := ordinalIDOfAssignee == r.ordinalID
 
 	logger.WithFields(log.Fields{
@@ -393,6 +440,12 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, nil
 	}
 
+	if controllerutils.IsClusterSleeping(cd) {
+		logger.WithField("powerState", cd.Status.PowerState).Debug("cluster is sleeping")
+		// The change to the power state when the cluster wakes up will trigger a new reconcile.
+		return reconcile.Result{}, r.setClusterSleepingCondition(cd, logger)
+	}
+
 	if unreachable, _ := remoteclient.Unreachable(cd); unreachable {
 		logger.Debug("cluster is unreachable")
 		return reconcile.Result{}, nil
@@ -405,6 +458,14 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
+	switch fence, err := getRemoteRelocationFence(resourceHelper); {
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get relocation fence")
+		return reconcile.Result{}, err
+	case controllerutils.IsFencedOut(cd, fence, logger):
+		return reconcile.Result{}, nil
+	}
+
 	needToCreateClusterSync := false
 	clusterSync := &hiveintv1alpha1.ClusterSync{}
 	switch err := r.Get(context.Background(), request.NamespacedName, clusterSync); {
@@ -449,9 +510,19 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
-	needToDoFullReapply := needToCreateClusterSync || r.timeUntilFullReapply(lease) <= 0
+	reapplyInterval := r.reapplyInterval
+	if override := controllerutils.GetClusterOverrides(cd, logger).SyncSetReapplyInterval; override != nil {
+		reapplyInterval = *override
+	}
+
+	needToDoFullReapply := needToCreateClusterSync || timeUntilFullReapply(lease, reapplyInterval) <= 0
 	if needToDoFullReapply {
 		logger.Info("need to reapply all syncsets")
+		if !needToCreateLease {
+			lag := reapplyLag(lease, reapplyInterval)
+			logger.WithField("lag", lag).Debug("observed reapply lag")
+			metricReapplyLag.Observe(lag.Seconds())
+		}
 	}
 	recobsrv.SetOutcome(hivemetrics.ReconcileOutcomeFullSync)
 
@@ -482,6 +553,15 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 	clusterSync.Status.SelectorSyncSets = syncStatusesForSelectorSyncSets
 
 	setFailedCondition(clusterSync)
+	if findClusterSyncCondition(clusterSync.Status.Conditions, hiveintv1alpha1.ClusterSyncClusterSleeping) != nil {
+		setClusterSyncCondition(
+			clusterSync,
+			hiveintv1alpha1.ClusterSyncClusterSleeping,
+			corev1.ConditionFalse,
+			"ClusterAwake",
+			"The cluster is awake and syncing has resumed",
+		)
+	}
 
 	// Set clusterSync.Status.FirstSyncSetsSuccessTime
 	syncStatuses := append(syncStatusesForSyncSets, syncStatusesForSelectorSyncSets...)
@@ -521,7 +601,11 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 		}
 	}
 
-	result := reconcile.Result{Requeue: true, RequeueAfter: r.timeUntilFullReapply(lease)}
+	result := reconcile.Result{Requeue: true, RequeueAfter: timeUntilFullReapply(lease, reapplyInterval)}
+	// Come back when the first of the resources that failed to apply is due to be retried.
+	if timeUntilRetry, ok := timeUntilNextResourceRetry(syncStatuses); ok && timeUntilRetry < result.RequeueAfter {
+		result.RequeueAfter = timeUntilRetry
+	}
 	if syncSetsNeedRequeue || selectorSyncSetsNeedRequeue {
 		result.RequeueAfter = 0
 	}
@@ -580,20 +664,29 @@ func (r *ReconcileClusterSync) applySyncSets(
 		}
 	}
 
+	now := metav1.Now()
 	for _, syncSet := range syncSets {
 		logger := logger.WithField(syncSetType, syncSet.AsMetaObject().GetName())
 		oldSyncStatus, indexOfOldStatus := getOldSyncStatus(syncSet, syncStatuses)
+		dueForRetry, waitingForRetry := partitionFailedResources(oldSyncStatus.FailedResources, now.Time)
 
 		// Determine if the syncset needs to be applied
+		var shouldApply func(hiveintv1alpha1.SyncResourceReference) bool
+		retryOnly := false
 		switch {
 		case needToDoFullReapply:
 			logger.Debug("applying syncset because it is time to do a full re-apply")
 		case indexOfOldStatus < 0:
 			logger.Debug("applying syncset because the syncset is new")
-		case oldSyncStatus.Result != hiveintv1alpha1.SuccessSyncSetResult:
-			logger.Debug("applying syncset because the last attempt to apply failed")
 		case oldSyncStatus.ObservedGeneration != syncSet.AsMetaObject().GetGeneration():
 			logger.Debug("applying syncset because the syncset generation has changed")
+		case oldSyncStatus.Result != hiveintv1alpha1.SuccessSyncSetResult && !hasOnlyResourceFailures(oldSyncStatus):
+			logger.Debug("applying syncset because the last attempt to apply failed")
+			shouldApply = func(r hiveintv1alpha1.SyncResourceReference) bool { return !waitingForRetry[r] }
+		case len(dueForRetry) > 0:
+			logger.Debug("retrying resources of syncset which failed to apply")
+			shouldApply = func(r hiveintv1alpha1.SyncResourceReference) bool { return dueForRetry[r] }
+			retryOnly = true
 		default:
 			logger.Debug("skipping apply of syncset since it is up-to-date and it is not time to do a full re-apply")
 			newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
@@ -601,10 +694,16 @@ func (r *ReconcileClusterSync) applySyncSets(
 		}
 
 		// Apply the syncset
-		resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, resourceHelper, logger)
+		resourcesApplied, resourcesInSyncSet, resourcesDeleted, resourceErrors, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, shouldApply, retryOnly, resourceHelper, logger)
+		if retryOnly {
+			// Patches and deletions were not attempted, so the deletions confirmed by the last apply still hold.
+			resourcesDeleted = oldSyncStatus.ResourcesDeleted
+		}
 		newSyncStatus := hiveintv1alpha1.SyncStatus{
 			Name:               syncSet.AsMetaObject().GetName(),
 			ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
+			ResourcesDeleted:   resourcesDeleted,
+			FailedResources:    updateResourceFailures(oldSyncStatus.FailedResources, resourcesInSyncSet, resourcesApplied, resourceErrors, now),
 			Result:             hiveintv1alpha1.SuccessSyncSetResult,
 		}
 		applyMode := syncSet.GetSpec().ResourceApplyMode
@@ -616,9 +715,16 @@ func (r *ReconcileClusterSync) applySyncSets(
 			logger.Infof("resource apply mode is %v but there are resources to delete in clustersync status", hivev1.UpsertResourceApplyMode)
 			oldSyncStatus.ResourcesToDelete = nil
 		}
+		var failureMessages []string
 		if err != nil {
+			failureMessages = append(failureMessages, err.Error())
+		}
+		if len(newSyncStatus.FailedResources) > 0 {
+			failureMessages = append(failureMessages, resourceFailuresMessage(newSyncStatus.FailedResources))
+		}
+		if len(failureMessages) > 0 {
 			newSyncStatus.Result = hiveintv1alpha1.FailureSyncSetResult
-			newSyncStatus.FailureMessage = err.Error()
+			newSyncStatus.FailureMessage = strings.Join(failureMessages, "\n")
 		}
 		if syncSetNeedsRequeue {
 			requeue = true
@@ -709,16 +815,25 @@ func getOldSyncStatus(syncSet CommonSyncSet, syncSetStatuses []hiveintv1alpha1.S
 	return hiveintv1alpha1.SyncStatus{}, -1
 }
 
+// applySyncSet applies the resources, secrets, patches and deletions of the syncset to the cluster. Resources and
+// secrets for which shouldApply returns false are skipped. A resource or secret that fails to apply does not prevent
+// the others from being applied; its error is returned in resourceErrors. When retryOnly is true, only resources and
+// secrets are applied.
 func (r *ReconcileClusterSync) applySyncSet(
 	syncSet CommonSyncSet,
+	shouldApply func(hiveintv1alpha1.SyncResourceReference) bool,
+	retryOnly bool,
 	resourceHelper resource.Helper,
 	logger log.FieldLogger,
 ) (
 	resourcesApplied []hiveintv1alpha1.SyncResourceReference,
 	resourcesInSyncSet []hiveintv1alpha1.SyncResourceReference,
+	resourcesDeleted []hiveintv1alpha1.SyncResourceReference,
+	resourceErrors map[hiveintv1alpha1.SyncResourceReference]error,
 	requeue bool,
 	returnErr error,
 ) {
+	resourceErrors = map[hiveintv1alpha1.SyncResourceReference]error{}
 	resources, referencesToResources, decodeErr := decodeResources(syncSet, logger)
 	referencesToSecrets := referencesToSecrets(syncSet)
 	resourcesInSyncSet = append(referencesToResources, referencesToSecrets...)
@@ -738,25 +853,56 @@ func (r *ReconcileClusterSync) applySyncSet(
 		applyFnMetricsLabel = labelCreateOnly
 	}
 
-	// Apply Resources
+	// Apply Resources. Resources which add types to the API of the cluster, such as CRDs, are applied first. The
+	// remaining resources are applied once the API is serving the types they need.
+	var providers, dependents []*unstructured.Unstructured
+	var providerIndices, dependentIndices []int
 	for i, resource := range resources {
-		returnErr, requeue = r.applyResource(i, resource, referencesToResources[i], applyFn, applyFnMetricsLabel, logger)
-		if returnErr != nil {
-			resourcesApplied = referencesToResources[:i]
-			return
+		if shouldApply != nil && !shouldApply(referencesToResources[i]) {
+			logger.WithField("resourceIndex", i).Debug("skipping resource which is waiting to be retried")
+			continue
+		}
+		if isAPIProvider(resource) {
+			providers = append(providers, resource)
+			providerIndices = append(providerIndices, i)
+		} else {
+			dependents = append(dependents, resource)
+			dependentIndices = append(dependentIndices, i)
+		}
+	}
+	applyResources := func(indices []int) {
+		for _, i := range indices {
+			if err, _ := r.applyResource(i, resources[i], referencesToResources[i], applyFn, applyFnMetricsLabel, logger); err != nil {
+				resourceErrors[referencesToResources[i]] = err
+				continue
+			}
+			resourcesApplied = append(resourcesApplied, referencesToResources[i])
 		}
 	}
-	resourcesApplied = referencesToResources
+	applyResources(providerIndices)
+	if err := checkAPIProvidersReady(providers, dependents, resourceHelper, logger); err != nil {
+		returnErr, requeue = err, true
+		return
+	}
+	applyResources(dependentIndices)
 
 	// Apply Secrets
 	for i, secretMapping := range syncSet.GetSpec().Secrets {
-		returnErr, requeue = r.applySecret(syncSet, i, secretMapping, referencesToSecrets[i], applyFn, applyFnMetricsLabel, logger)
-		if returnErr != nil {
-			resourcesApplied = append(resourcesApplied, referencesToSecrets[:i]...)
-			return
+		if shouldApply != nil && !shouldApply(referencesToSecrets[i]) {
+			logger.WithField("secretIndex", i).Debug("skipping secret which is waiting to be retried")
+			continue
+		}
+		if err, _ := r.applySecret(syncSet, i, secretMapping, referencesToSecrets[i], applyFn, applyFnMetricsLabel, logger); err != nil {
+			resourceErrors[referencesToSecrets[i]] = err
+			continue
 		}
+		resourcesApplied = append(resourcesApplied, referencesToSecrets[i])
+	}
+
+	if retryOnly {
+		logger.Info("failed resources retried")
+		return
 	}
-	resourcesApplied = append(resourcesApplied, referencesToSecrets...)
 
 	// Apply Patches
 	for i, patch := range syncSet.GetSpec().Patches {
@@ -766,6 +912,21 @@ func (r *ReconcileClusterSync) applySyncSet(
 		}
 	}
 
+	// Delete Resources
+	for i, ref := range syncSet.GetSpec().ResourcesToDelete {
+		reference := hiveintv1alpha1.SyncResourceReference{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Namespace:  ref.Namespace,
+			Name:       ref.Name,
+		}
+		returnErr, requeue = r.deleteResource(i, reference, resourceHelper, logger)
+		if returnErr != nil {
+			return
+		}
+		resourcesDeleted = append(resourcesDeleted, reference)
+	}
+
 	logger.Info("syncset applied")
 	return
 }
@@ -898,6 +1059,30 @@ func (r *ReconcileClusterSync) applyPatch(
 	return nil, false
 }
 
+func (r *ReconcileClusterSync) deleteResource(
+	deleteIndex int,
+	reference hiveintv1alpha1.SyncResourceReference,
+	resourceHelper resource.Helper,
+	logger log.FieldLogger,
+) (returnErr error, requeue bool) {
+	logger = logger.WithField("deleteIndex", deleteIndex).
+		WithField("resourceNamespace", reference.Namespace).
+		WithField("resourceName", reference.Name).
+		WithField("resourceAPIVersion", reference.APIVersion).
+		WithField("resourceKind", reference.Kind)
+	logger.Debug("deleting resource")
+	if err := resourceHelper.Delete(reference.APIVersion, reference.Kind, reference.Namespace, reference.Name); err != nil {
+		// A resource whose type is no longer served by the cluster cannot exist.
+		if meta.IsNoMatchError(errors.Cause(err)) {
+			logger.Debug("resource type is not served by the cluster")
+			return nil, false
+		}
+		logger.WithError(err).Warn("could not delete resource")
+		return errors.Wrapf(err, "failed to delete resource %d", deleteIndex), true
+	}
+	return nil, false
+}
+
 func applyToTargetCluster(
 	obj hivev1.MetaRuntimeObject,
 	applyFnMetricLabel string,
@@ -963,12 +1148,15 @@ func deleteFromTargetCluster(
 
 func (r *ReconcileClusterSync) getSyncSetsForClusterDeployment(cd *hivev1.ClusterDeployment, logger log.FieldLogger) ([]CommonSyncSet, error) {
 	syncSetsList := &hivev1.SyncSetList{}
-	if err := r.List(context.Background(), syncSetsList, client.InNamespace(cd.Namespace)); err != nil {
+	if err := r.List(context.Background(), syncSetsList,
+		client.MatchingFields{syncSetClusterDeploymentIndex: cd.Name},
+		client.InNamespace(cd.Namespace)); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list SyncSets")
 		return nil, err
 	}
 	var syncSets []CommonSyncSet
 	for i, ss := range syncSetsList.Items {
+		// This should only happen in unit tests: the fakeclient doesn't support index filters
 		if !doesSyncSetApplyToClusterDeployment(&ss, cd) {
 			continue
 		}
@@ -1011,6 +1199,35 @@ func doesSelectorSyncSetApplyToClusterDeployment(selectorSyncSet *hivev1.Selecto
 	return labelSelector.Matches(labels.Set(cd.Labels))
 }
 
+// setClusterSleepingCondition records on the ClusterSync, if there is one, that syncing is paused because the cluster
+// is sleeping.
+func (r *ReconcileClusterSync) setClusterSleepingCondition(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	switch err := r.Get(context.Background(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, clusterSync); {
+	case apierrors.IsNotFound(err):
+		// The ClusterSync will be created once the cluster wakes up.
+		return nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get ClusterSync")
+		return err
+	}
+	if !setClusterSyncCondition(
+		clusterSync,
+		hiveintv1alpha1.ClusterSyncClusterSleeping,
+		corev1.ConditionTrue,
+		cd.Status.PowerState,
+		"Syncing is paused until the cluster wakes up",
+	) {
+		return nil
+	}
+	logger.Info("updating ClusterSync")
+	if err := r.Status().Update(context.Background(), clusterSync); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterSync")
+		return err
+	}
+	return nil
+}
+
 func setFailedCondition(clusterSync *hiveintv1alpha1.ClusterSync) {
 	status := corev1.ConditionFalse
 	reason := "Success"
@@ -1033,22 +1250,51 @@ func setFailedCondition(clusterSync *hiveintv1alpha1.ClusterSync) {
 		}
 		message = fmt.Sprintf("%s %s failing", strings.Join(failureNames, " and "), verb)
 	}
-	if len(clusterSync.Status.Conditions) > 0 {
-		cond := clusterSync.Status.Conditions[0]
-		if status == cond.Status &&
-			reason == cond.Reason &&
-			message == cond.Message {
-			return
-		}
-	}
-	clusterSync.Status.Conditions = []hiveintv1alpha1.ClusterSyncCondition{{
-		Type:               hiveintv1alpha1.ClusterSyncFailed,
+	setClusterSyncCondition(clusterSync, hiveintv1alpha1.ClusterSyncFailed, status, reason, message)
+}
+
+// setClusterSyncCondition sets the condition of the specified type on the ClusterSync, leaving the other conditions
+// alone. Returns true if the condition changed.
+func setClusterSyncCondition(
+	clusterSync *hiveintv1alpha1.ClusterSync,
+	conditionType hiveintv1alpha1.ClusterSyncConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+) bool {
+	newCond := hiveintv1alpha1.ClusterSyncCondition{
+		Type:               conditionType,
 		Status:             status,
 		Reason:             reason,
 		Message:            message,
 		LastProbeTime:      metav1.Now(),
 		LastTransitionTime: metav1.Now(),
-	}}
+	}
+	for i, cond := range clusterSync.Status.Conditions {
+		if cond.Type != conditionType {
+			continue
+		}
+		if status == cond.Status &&
+			reason == cond.Reason &&
+			message == cond.Message {
+			return false
+		}
+		clusterSync.Status.Conditions[i] = newCond
+		return true
+	}
+	clusterSync.Status.Conditions = append(clusterSync.Status.Conditions, newCond)
+	return true
+}
+
+// findClusterSyncCondition finds the condition that has the specified condition type in the given list. If none exists,
+// then returns nil.
+func findClusterSyncCondition(conditions []hiveintv1alpha1.ClusterSyncCondition, conditionType hiveintv1alpha1.ClusterSyncConditionType) *hiveintv1alpha1.ClusterSyncCondition {
+	for i, condition := range conditions {
+		if condition.Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
 }
 
 func getFailingSyncSets(syncStatuses []hiveintv1alpha1.SyncStatus) []string {
@@ -1127,9 +1373,18 @@ func orderResources(a, b hiveintv1alpha1.SyncResourceReference) bool {
 	return a.Name < b.Name
 }
 
-func (r *ReconcileClusterSync) timeUntilFullReapply(lease *hiveintv1alpha1.ClusterSyncLease) time.Duration {
-	timeUntilNext := r.reapplyInterval - time.Since(lease.Spec.RenewTime.Time) +
-		time.Duration(reapplyIntervalJitter*rand.Float64()*r.reapplyInterval.Seconds())*time.Second
+// reapplyLag returns how long after it was due, at the latest, the full reapply is running.
+func reapplyLag(lease *hiveintv1alpha1.ClusterSyncLease, reapplyInterval time.Duration) time.Duration {
+	latestDue := lease.Spec.RenewTime.Add(time.Duration(float64(reapplyInterval) * (1 + reapplyIntervalJitter)))
+	if lag := time.Since(latestDue); lag > 0 {
+		return lag
+	}
+	return 0
+}
+
+func timeUntilFullReapply(lease *hiveintv1alpha1.ClusterSyncLease, reapplyInterval time.Duration) time.Duration {
+	timeUntilNext := reapplyInterval - time.Since(lease.Spec.RenewTime.Time) +
+		time.Duration(reapplyIntervalJitter*rand.Float64()*reapplyInterval.Seconds())*time.Second
 	if timeUntilNext < 0 {
 		return 0
 	}
@@ -18,9 +18,11 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -67,6 +69,9 @@ type reconcileTest struct {
 	mockRemoteClientBuilder *remoteclientmock.MockBuilder
 	expectedFailedMessage   string
 
+	// remoteRelocationFence is the relocation fencing token of the remote cluster.
+	remoteRelocationFence string
+
 	// A zero LastTransitionTime indicates that the time should be set to now.
 	// A FirstSuccessTime that points to a zero time indicates that the time should be set to now.
 	expectedSyncSetStatuses         []hiveintv1alpha1.SyncStatus
@@ -74,6 +79,7 @@ type reconcileTest struct {
 
 	expectUnchangedLeaseRenewTime bool
 	expectRequeue                 bool
+	expectResourceRetryWithin     time.Duration
 	expectNoWorkDone              bool
 }
 
@@ -103,7 +109,7 @@ func newReconcileTest(t *testing.T, mockCtrl *gomock.Controller, scheme *runtime
 		},
 	}
 
-	return &reconcileTest{
+	rt := &reconcileTest{
 		logger:                  logger,
 		c:                       c,
 		r:                       r,
@@ -111,6 +117,19 @@ func newReconcileTest(t *testing.T, mockCtrl *gomock.Controller, scheme *runtime
 		mockResourceHelper:      mockResourceHelper,
 		mockRemoteClientBuilder: mockRemoteClientBuilder,
 	}
+	mockResourceHelper.EXPECT().Get("v1", "Namespace", "", "kube-system").DoAndReturn(
+		func(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+			ns := &unstructured.Unstructured{}
+			ns.SetAPIVersion(apiVersion)
+			ns.SetKind(kind)
+			ns.SetName(name)
+			if rt.remoteRelocationFence != "" {
+				ns.SetAnnotations(map[string]string{constants.RelocationFencingTokenAnnotation: rt.remoteRelocationFence})
+			}
+			return ns, nil
+		},
+	).AnyTimes()
+	return rt
 }
 
 func (rt *reconcileTest) run(t *testing.T) {
@@ -149,6 +168,9 @@ func (rt *reconcileTest) run(t *testing.T) {
 	assert.True(t, result.Requeue, "expected requeue to be true")
 	if rt.expectRequeue {
 		assert.Zero(t, result.RequeueAfter, "unexpected requeue after")
+	} else if rt.expectResourceRetryWithin != 0 {
+		assert.Positive(t, int64(result.RequeueAfter), "expected requeue after for resource retry")
+		assert.LessOrEqual(t, int64(result.RequeueAfter), int64(rt.expectResourceRetryWithin), "requeue after too large for resource retry")
 	} else {
 		var minRequeueAfter, maxRequeueAfter float64
 		if rt.expectUnchangedLeaseRenewTime {
@@ -230,6 +252,20 @@ func areSyncStatusesEqual(t *testing.T, syncSetType string, expectedStatuses, ac
 			hiveassert.BetweenTimes(t, actual.Time, startTime, endTime, "expected %s status %d to have LastTransitionTime of now", syncSetType, i)
 			expectedStatuses[i].LastTransitionTime = actual
 		}
+		// A zero LastFailureTime indicates that the resource failed now.
+		for j, expectedFailure := range expectedStatus.FailedResources {
+			if !expectedFailure.LastFailureTime.IsZero() || j >= len(actualStatuses[i].FailedResources) {
+				continue
+			}
+			actual := actualStatuses[i].FailedResources[j].LastFailureTime
+			hiveassert.BetweenTimes(t, actual.Time, startTime, endTime, "expected %s status %d failed resource %d to have LastFailureTime of now", syncSetType, i, j)
+			expectedFailure.LastFailureTime = actual
+			if expectedFailure.FirstFailureTime.IsZero() {
+				expectedFailure.FirstFailureTime = actual
+			}
+			expectedFailure.NextRetryTime = metav1.NewTime(actual.Add(resourceRetryDelay(expectedFailure.ConsecutiveFailures)))
+			expectedStatuses[i].FailedResources[j] = expectedFailure
+		}
 		if expectedStatus.FirstSuccessTime != nil && expectedStatus.FirstSuccessTime.IsZero() {
 			if actualStatuses[i].FirstSuccessTime != nil {
 				actual := actualStatuses[i].FirstSuccessTime
@@ -270,13 +306,24 @@ func TestReconcileClusterSync_NewClusterDeployment(t *testing.T) {
 func TestReconcileClusterSync_NoWorkToDo(t *testing.T) {
 	scheme := newScheme()
 	cases := []struct {
-		name string
-		cd   *hivev1.ClusterDeployment
+		name  string
+		cd    *hivev1.ClusterDeployment
+		fence string
 	}{
 		{
 			name: "no ClusterDeployment",
 			cd:   nil,
 		},
+		{
+			name:  "fenced out by relocation",
+			cd:    cdBuilder(scheme).GenericOptions(testgeneric.WithAnnotation(constants.RelocationFencingTokenAnnotation, "source-token")).Build(),
+			fence: "destination-token",
+		},
+		{
+			name:  "fenced out without fencing token",
+			cd:    cdBuilder(scheme).Build(),
+			fence: "destination-token",
+		},
 		{
 			name: "deleted ClusterDeployment",
 			cd:   cdBuilder(scheme).GenericOptions(testgeneric.Deleted()).Build(),
@@ -302,6 +349,10 @@ func TestReconcileClusterSync_NoWorkToDo(t *testing.T) {
 						Status: corev1.ConditionUnknown,
 					})).Build(),
 		},
+		{
+			name: "hibernating",
+			cd:   cdBuilder(scheme).Build(testcd.WithStatusPowerState(hivev1.HibernatingHibernationReason)),
+		},
 		{
 			name: "syncset pause",
 			cd:   cdBuilder(scheme).GenericOptions(testgeneric.WithAnnotation(constants.SyncsetPauseAnnotation, "true")).Build(),
@@ -321,6 +372,7 @@ func TestReconcileClusterSync_NoWorkToDo(t *testing.T) {
 					))
 			}
 			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
+			rt.remoteRelocationFence = tc.fence
 			rt.expectNoWorkDone = true
 			rt.run(t)
 		})
@@ -374,6 +426,30 @@ func TestReconcileClusterSync_ApplyResource(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_ApplyResourceWithRelocationFence(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	resourceToApply := testConfigMap("dest-namespace", "dest-name")
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(resourceToApply),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).GenericOptions(testgeneric.WithAnnotation(constants.RelocationFencingTokenAnnotation, "destination-token")).Build(),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet)
+	rt.remoteRelocationFence = "destination-token"
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{newSyncStatusBuilder("test-syncset").Build()}
+	rt.run(t)
+}
+
 func TestGetAndCheckClustersyncStatefulSet(t *testing.T) {
 	scheme := newScheme()
 
@@ -459,7 +535,7 @@ func TestIsSyncAssignedToMe(t *testing.T) {
 				teststatefulset.WithReplicas(3),
 			),
 			clusterDeployment: testclusterdeployment.FullBuilder(testNamespace, testCDName, scheme).Build(
-				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800195")),
+				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800190")),
 			),
 			expectedAssignedToMe: true,
 			expectedErr:          false,
@@ -472,7 +548,7 @@ func TestIsSyncAssignedToMe(t *testing.T) {
 				teststatefulset.WithReplicas(3),
 			),
 			clusterDeployment: testclusterdeployment.FullBuilder(testNamespace, testCDName, scheme).Build(
-				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800196")),
+				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800191")),
 			),
 			expectedErr: false,
 		},
@@ -484,7 +560,7 @@ func TestIsSyncAssignedToMe(t *testing.T) {
 				teststatefulset.WithReplicas(3),
 			),
 			clusterDeployment: testclusterdeployment.FullBuilder(testNamespace, testCDName, scheme).Build(
-				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800196")),
+				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800193")),
 			),
 			expectedAssignedToMe: true,
 			expectedErr:          false,
@@ -497,7 +573,7 @@ func TestIsSyncAssignedToMe(t *testing.T) {
 				teststatefulset.WithReplicas(3),
 			),
 			clusterDeployment: testclusterdeployment.FullBuilder(testNamespace, testCDName, scheme).Build(
-				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800197")),
+				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800190")),
 			),
 			expectedErr: false,
 		},
@@ -509,7 +585,7 @@ func TestIsSyncAssignedToMe(t *testing.T) {
 				teststatefulset.WithReplicas(3),
 			),
 			clusterDeployment: testclusterdeployment.FullBuilder(testNamespace, testCDName, scheme).Build(
-				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800197")),
+				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800191")),
 			),
 			expectedAssignedToMe: true,
 			expectedErr:          false,
@@ -522,7 +598,7 @@ func TestIsSyncAssignedToMe(t *testing.T) {
 				teststatefulset.WithReplicas(3),
 			),
 			clusterDeployment: testclusterdeployment.FullBuilder(testNamespace, testCDName, scheme).Build(
-				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800198")),
+				testclusterdeployment.Generic(testgeneric.WithUID("1138528c-c36e-11e9-a1a7-42010a800193")),
 			),
 			expectedErr: false,
 		},
@@ -549,6 +625,37 @@ func TestIsSyncAssignedToMe(t *testing.T) {
 	}
 }
 
+func TestReapplyLag(t *testing.T) {
+	cases := []struct {
+		name          string
+		lastReapply   time.Duration
+		expectedMin   time.Duration
+		expectedUpper time.Duration
+	}{
+		{
+			name:        "not yet due",
+			lastReapply: time.Hour,
+		},
+		{
+			name:        "within jitter",
+			lastReapply: defaultReapplyInterval + time.Minute,
+		},
+		{
+			name:          "overdue",
+			lastReapply:   3 * time.Hour,
+			expectedMin:   47 * time.Minute,
+			expectedUpper: 49 * time.Minute,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lag := reapplyLag(buildSyncLease(time.Now().Add(-tc.lastReapply)), defaultReapplyInterval)
+			assert.GreaterOrEqual(t, int64(lag), int64(tc.expectedMin), "lag too small")
+			assert.LessOrEqual(t, int64(lag), int64(tc.expectedUpper), "lag too large")
+		})
+	}
+}
+
 func TestReconcileClusterSync_ApplySecret(t *testing.T) {
 	cases := []struct {
 		applyMode                hivev1.SyncSetResourceApplyMode
@@ -654,6 +761,70 @@ func TestReconcileClusterSync_ApplyPatch(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_DeleteResources(t *testing.T) {
+	cases := []struct {
+		name             string
+		deleteErr        error
+		expectedStatuses []hiveintv1alpha1.SyncStatus
+		expectRequeue    bool
+	}{
+		{
+			name: "deleted",
+			expectedStatuses: []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+				withResourcesDeleted(testConfigMapRef("dest-namespace", "dest-name")),
+			)},
+		},
+		{
+			name:      "type not served",
+			deleteErr: errors.Wrap(&meta.NoKindMatchError{GroupKind: schema.GroupKind{Kind: "ConfigMap"}}, "could not get mapping"),
+			expectedStatuses: []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+				withResourcesDeleted(testConfigMapRef("dest-namespace", "dest-name")),
+			)},
+		},
+		{
+			name:      "delete error",
+			deleteErr: errors.New("test delete error"),
+			expectedStatuses: []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+				withFailureResult("failed to delete resource 0: test delete error"),
+				withNoFirstSuccessTime(),
+			)},
+			expectRequeue: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResourcesToDelete(hivev1.SyncObjectReference{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Namespace:  "dest-namespace",
+					Name:       "dest-name",
+				}),
+			)
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet)
+			rt.mockResourceHelper.EXPECT().Delete("v1", "ConfigMap", "dest-namespace", "dest-name").Return(tc.deleteErr)
+			if tc.expectRequeue {
+				rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+			}
+			rt.expectedSyncSetStatuses = tc.expectedStatuses
+			rt.expectRequeue = tc.expectRequeue
+			rt.run(t)
+		})
+	}
+}
+
 func TestReconcileClusterSync_ApplyAllTypes(t *testing.T) {
 	cases := []struct {
 		applyMode                hivev1.SyncSetResourceApplyMode
@@ -1024,6 +1195,77 @@ func TestReconcileClusterSync_ResourceRemovedFromSyncSet(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_ApplyCRDsBeforeCustomResources(t *testing.T) {
+	cases := []struct {
+		name            string
+		established     bool
+		expectCRApplied bool
+	}{
+		{
+			name:            "crd established",
+			established:     true,
+			expectCRApplied: true,
+		},
+		{
+			name: "crd not established",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			crd := testCRD("widgets.example.com", "example.com", "Widget")
+			cr := &unstructured.Unstructured{}
+			cr.SetAPIVersion("example.com/v1")
+			cr.SetKind("Widget")
+			cr.SetNamespace("dest-namespace")
+			cr.SetName("dest-name")
+			configMap := testConfigMap("dest-namespace", "dest-name")
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResources(cr, crd, configMap),
+			)
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet)
+			appliedCRD := crd.DeepCopy()
+			if tc.established {
+				unstructured.SetNestedSlice(appliedCRD.Object, []interface{}{
+					map[string]interface{}{"type": "Established", "status": "True"},
+				}, "status", "conditions")
+			}
+			calls := []*gomock.Call{
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(crd)).Return(resource.CreatedApplyResult, nil),
+				rt.mockResourceHelper.EXPECT().Get("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "widgets.example.com").
+					Return(appliedCRD, nil),
+			}
+			if tc.expectCRApplied {
+				calls = append(calls,
+					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(cr)).Return(resource.CreatedApplyResult, nil),
+					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(configMap)).Return(resource.CreatedApplyResult, nil),
+				)
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset")}
+			} else {
+				rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+					withFailureResult("waiting for CustomResourceDefinition widgets.example.com to be Established"),
+					withNoFirstSuccessTime(),
+				)}
+				rt.expectRequeue = true
+			}
+			gomock.InOrder(calls...)
+			rt.run(t)
+		})
+	}
+}
+
 func TestReconcileClusterSync_ErrorApplyingResource(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1046,10 +1288,10 @@ func TestReconcileClusterSync_ErrorApplyingResource(t *testing.T) {
 		Return(resource.ApplyResult(""), errors.New("test apply error"))
 	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
-		withFailureResult("failed to apply resource 0: test apply error"),
+		withFailedResources(testResourceFailure(testConfigMapRef("dest-namespace", "dest-name"), "failed to apply resource 0: test apply error")),
 		withNoFirstSuccessTime(),
 	)}
-	rt.expectRequeue = true
+	rt.expectResourceRetryWithin = resourceRetryBaseDelay
 	rt.run(t)
 }
 
@@ -1112,10 +1354,10 @@ func TestReconcileClusterSync_ErrorApplyingSecret(t *testing.T) {
 		Return(resource.ApplyResult(""), errors.New("test apply error"))
 	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
-		withFailureResult("failed to apply secret 0: test apply error"),
+		withFailedResources(testResourceFailure(testSecretRef("dest-namespace", "dest-name"), "failed to apply secret 0: test apply error")),
 		withNoFirstSuccessTime(),
 	)}
-	rt.expectRequeue = true
+	rt.expectResourceRetryWithin = resourceRetryBaseDelay
 	rt.run(t)
 }
 
@@ -1159,67 +1401,67 @@ func TestReconcileClusterSync_ErrorApplyingPatch(t *testing.T) {
 	rt.run(t)
 }
 
-func TestReconcileClusterSync_SkipAfterFailingResource(t *testing.T) {
+func TestReconcileClusterSync_FailingResourceDoesNotBlockOthers(t *testing.T) {
 	cases := []struct {
-		name                string
-		successfulResources int
-		successfulSecrets   int
-		successfulPatches   int
-		failureMessage      string
+		name            string
+		failingResource int
+		failingSecret   int
+		failingPatch    int
+		failedResource  hiveintv1alpha1.SyncResourceReference
+		failureMessage  string
 	}{
 		{
-			name:                "resource 0 fails",
-			successfulResources: 0,
-			failureMessage:      "failed to apply resource 0: test apply error",
-		},
-		{
-			name:                "resource 1 fails",
-			successfulResources: 1,
-			failureMessage:      "failed to apply resource 1: test apply error",
+			name:            "resource 0 fails",
+			failingResource: 0,
+			failingSecret:   -1,
+			failingPatch:    -1,
+			failedResource:  testConfigMapRef("resource-namespace-0", "resource-name-0"),
+			failureMessage:  "failed to apply resource 0: test apply error",
 		},
 		{
-			name:                "resource 2 fails",
-			successfulResources: 2,
-			failureMessage:      "failed to apply resource 2: test apply error",
+			name:            "resource 2 fails",
+			failingResource: 2,
+			failingSecret:   -1,
+			failingPatch:    -1,
+			failedResource:  testConfigMapRef("resource-namespace-2", "resource-name-2"),
+			failureMessage:  "failed to apply resource 2: test apply error",
 		},
 		{
-			name:                "secret 0 fails",
-			successfulResources: 3,
-			successfulSecrets:   0,
-			failureMessage:      "failed to apply secret 0: test apply error",
+			name:            "secret 0 fails",
+			failingResource: -1,
+			failingSecret:   0,
+			failingPatch:    -1,
+			failedResource:  testSecretRef("secret-namespace-0", "secret-name-0"),
+			failureMessage:  "failed to apply secret 0: test apply error",
 		},
 		{
-			name:                "secret 1 fails",
-			successfulResources: 3,
-			successfulSecrets:   1,
-			failureMessage:      "failed to apply secret 1: test apply error",
+			name:            "secret 2 fails",
+			failingResource: -1,
+			failingSecret:   2,
+			failingPatch:    -1,
+			failedResource:  testSecretRef("secret-namespace-2", "secret-name-2"),
+			failureMessage:  "failed to apply secret 2: test apply error",
 		},
 		{
-			name:                "secret 2 fails",
-			successfulResources: 3,
-			successfulSecrets:   2,
-			failureMessage:      "failed to apply secret 2: test apply error",
+			name:            "patch 0 fails",
+			failingResource: -1,
+			failingSecret:   -1,
+			failingPatch:    0,
+			failureMessage:  "failed to apply patch 0: test patch error",
 		},
 		{
-			name:                "patch 0 fails",
-			successfulResources: 3,
-			successfulSecrets:   3,
-			successfulPatches:   0,
-			failureMessage:      "failed to apply patch 0: test patch error",
+			name:            "patch 1 fails",
+			failingResource: -1,
+			failingSecret:   -1,
+			failingPatch:    1,
+			failureMessage:  "failed to apply patch 1: test patch error",
 		},
 		{
-			name:                "patch 1 fails",
-			successfulResources: 3,
-			successfulSecrets:   3,
-			successfulPatches:   1,
-			failureMessage:      "failed to apply patch 1: test patch error",
-		},
-		{
-			name:                "patch 2 fails",
-			successfulResources: 3,
-			successfulSecrets:   3,
-			successfulPatches:   2,
-			failureMessage:      "failed to apply patch 2: test patch error",
+			name:            "patch 2 fails",
+			failingResource: -1,
+			failingSecret:   -1,
+			failingPatch:    2,
+			failureMessage:  "failed to apply patch 2: test patch error",
 		},
 	}
 	for _, tc := range cases {
@@ -1291,39 +1533,30 @@ func TestReconcileClusterSync_SkipAfterFailingResource(t *testing.T) {
 			}
 			rt := newReconcileTest(t, mockCtrl, scheme, existing...)
 			var resourceHelperCalls []*gomock.Call
-			for i := 0; i < tc.successfulResources; i++ {
+			for i := range resourcesToApply {
+				var err error
+				if i == tc.failingResource {
+					err = errors.New("test apply error")
+				}
 				resourceHelperCalls = append(resourceHelperCalls,
 					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourcesToApply[i])).
-						Return(resource.CreatedApplyResult, nil))
+						Return(resource.CreatedApplyResult, err))
 			}
-			if tc.successfulResources < len(resourcesToApply) {
-				resourceHelperCalls = append(resourceHelperCalls,
-					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourcesToApply[tc.successfulResources])).
-						Return(resource.ApplyResult(""), errors.New("test apply error")))
-			}
-			for i := 0; i < tc.successfulSecrets; i++ {
+			for i := range secretsToApply {
+				var err error
+				if i == tc.failingSecret {
+					err = errors.New("test apply error")
+				}
 				resourceHelperCalls = append(resourceHelperCalls,
 					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretsToApply[i])).
-						Return(resource.CreatedApplyResult, nil))
+						Return(resource.CreatedApplyResult, err))
 			}
-			if tc.successfulResources == len(resourcesToApply) && tc.successfulSecrets < len(srcSecrets) {
-				resourceHelperCalls = append(resourceHelperCalls,
-					rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(secretsToApply[tc.successfulSecrets])).
-						Return(resource.ApplyResult(""), errors.New("test apply error")))
-			}
-			for i := 0; i < tc.successfulPatches; i++ {
-				patch := patchesToApply[i]
-				resourceHelperCalls = append(resourceHelperCalls,
-					rt.mockResourceHelper.EXPECT().Patch(
-						types.NamespacedName{Namespace: patch.Namespace, Name: patch.Name},
-						patch.Kind,
-						patch.APIVersion,
-						[]byte(patch.Patch),
-						patch.PatchType,
-					).Return(nil))
-			}
-			if tc.successfulResources == len(resourcesToApply) && tc.successfulSecrets == len(secretsToApply) && tc.successfulPatches < len(patchesToApply) {
-				patch := patchesToApply[tc.successfulPatches]
+			// Patches are still applied in order, stopping at the first failure.
+			for i, patch := range patchesToApply {
+				var err error
+				if i == tc.failingPatch {
+					err = errors.New("test patch error")
+				}
 				resourceHelperCalls = append(resourceHelperCalls,
 					rt.mockResourceHelper.EXPECT().Patch(
 						types.NamespacedName{Namespace: patch.Namespace, Name: patch.Name},
@@ -1331,15 +1564,136 @@ func TestReconcileClusterSync_SkipAfterFailingResource(t *testing.T) {
 						patch.APIVersion,
 						[]byte(patch.Patch),
 						patch.PatchType,
-					).Return(errors.New("test patch error")))
+					).Return(err))
+				if err != nil {
+					break
+				}
 			}
 			gomock.InOrder(resourceHelperCalls...)
 			rt.expectedFailedMessage = "SyncSet test-syncset is failing"
-			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
-				withFailureResult(tc.failureMessage),
+			if tc.failingPatch >= 0 {
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+					withFailureResult(tc.failureMessage),
+					withNoFirstSuccessTime(),
+				)}
+				rt.expectRequeue = true
+			} else {
+				rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+					withFailedResources(testResourceFailure(tc.failedResource, tc.failureMessage)),
+					withNoFirstSuccessTime(),
+				)}
+				rt.expectResourceRetryWithin = resourceRetryBaseDelay
+			}
+			rt.run(t)
+		})
+	}
+}
+
+func TestReconcileClusterSync_FailedResourceBackoff(t *testing.T) {
+	nextRetryInTheFuture := metav1.NewTime(time.Now().Add(time.Minute).Truncate(time.Second))
+	nextRetryInThePast := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	existingFailure := func(nextRetryTime metav1.Time) hiveintv1alpha1.SyncResourceFailure {
+		return hiveintv1alpha1.SyncResourceFailure{
+			SyncResourceReference: testConfigMapRef("dest-namespace", "failing-resource"),
+			Message:               "failed to apply resource 1: test apply error",
+			ConsecutiveFailures:   2,
+			FirstFailureTime:      timeInThePast,
+			LastFailureTime:       timeInThePast,
+			NextRetryTime:         nextRetryTime,
+		}
+	}
+	cases := []struct {
+		name                  string
+		existingFailure       hiveintv1alpha1.SyncResourceFailure
+		retryErr              error
+		expectRetry           bool
+		expectedSyncStatus    hiveintv1alpha1.SyncStatus
+		expectedFailedMessage string
+		expectRetryWithin     time.Duration
+	}{
+		{
+			name:            "waiting for retry",
+			existingFailure: existingFailure(nextRetryInTheFuture),
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withFailedResources(existingFailure(nextRetryInTheFuture)),
+				withTransitionInThePast(),
 				withNoFirstSuccessTime(),
-			)}
-			rt.expectRequeue = true
+			),
+			expectedFailedMessage: "SyncSet test-syncset is failing",
+			expectRetryWithin:     time.Minute,
+		},
+		{
+			name:            "retry fails",
+			existingFailure: existingFailure(nextRetryInThePast),
+			retryErr:        errors.New("test apply error"),
+			expectRetry:     true,
+			expectedSyncStatus: buildSyncStatus("test-syncset",
+				withFailedResources(hiveintv1alpha1.SyncResourceFailure{
+					SyncResourceReference: testConfigMapRef("dest-namespace", "failing-resource"),
+					Message:               "failed to apply resource 1: test apply error",
+					ConsecutiveFailures:   3,
+					FirstFailureTime:      timeInThePast,
+				}),
+				withNoFirstSuccessTime(),
+			),
+			expectedFailedMessage: "SyncSet test-syncset is failing",
+			expectRetryWithin:     resourceRetryDelay(3),
+		},
+		{
+			name:               "retry succeeds",
+			existingFailure:    existingFailure(nextRetryInThePast),
+			expectRetry:        true,
+			expectedSyncStatus: buildSyncStatus("test-syncset"),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			failingResource := testConfigMap("dest-namespace", "failing-resource")
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithResources(
+					testConfigMap("dest-namespace", "healthy-resource"),
+					failingResource,
+				),
+				testsyncset.WithPatches(hivev1.SyncObjectPatch{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Namespace:  "dest-namespace",
+					Name:       "healthy-resource",
+					PatchType:  "merge",
+					Patch:      "test-patch",
+				}),
+			)
+			clusterSync := clusterSyncBuilder(scheme).Build(
+				testcs.WithSyncSetStatus(buildSyncStatus("test-syncset",
+					withFailedResources(tc.existingFailure),
+					withTransitionInThePast(),
+					withNoFirstSuccessTime(),
+				)),
+			)
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet,
+				clusterSync,
+				buildSyncLease(time.Now().Add(-time.Hour)),
+			)
+			// Only the failed resource is retried. The healthy resource and the patch wait for the next full reapply.
+			if tc.expectRetry {
+				rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(failingResource)).
+					Return(resource.CreatedApplyResult, tc.retryErr)
+			}
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{tc.expectedSyncStatus}
+			rt.expectedFailedMessage = tc.expectedFailedMessage
+			rt.expectUnchangedLeaseRenewTime = true
+			rt.expectResourceRetryWithin = tc.expectRetryWithin
 			rt.run(t)
 		})
 	}
@@ -1560,13 +1914,16 @@ func TestReconcileClusterSync_FailingSyncSetDoesNotBlockOtherSyncSets(t *testing
 				expectedSyncSetStatusBuilder := newSyncStatusBuilder(s.Name)
 				if i == tc.failingSyncSet {
 					expectedSyncSetStatusBuilder = expectedSyncSetStatusBuilder.Options(
-						withFailureResult("failed to apply resource 0: test apply error"),
+						withFailedResources(testResourceFailure(
+							testConfigMapRef(fmt.Sprintf("resource-namespace-%d", i), fmt.Sprintf("resource-name-%d", i)),
+							"failed to apply resource 0: test apply error",
+						)),
 						withNoFirstSuccessTime(),
 					)
 				}
 				rt.expectedSyncSetStatuses = append(rt.expectedSyncSetStatuses, expectedSyncSetStatusBuilder.Build())
 			}
-			rt.expectRequeue = true
+			rt.expectResourceRetryWithin = resourceRetryBaseDelay
 			rt.run(t)
 		})
 	}
@@ -1646,7 +2003,10 @@ func TestReconcileClusterSync_FailureMessage(t *testing.T) {
 				rt.expectedSyncSetStatuses = make([]hiveintv1alpha1.SyncStatus, tc.failingSyncSets)
 				for i := range rt.expectedSyncSetStatuses {
 					rt.expectedSyncSetStatuses[i] = buildSyncStatus(fmt.Sprintf("test-syncset-%d", i),
-						withFailureResult("failed to apply resource 0: test apply error"),
+						withFailedResources(testResourceFailure(
+							testConfigMapRef(fmt.Sprintf("syncset-namespace-%d", i), fmt.Sprintf("syncset-name-%d", i)),
+							"failed to apply resource 0: test apply error",
+						)),
 						withNoFirstSuccessTime(),
 					)
 				}
@@ -1655,12 +2015,15 @@ func TestReconcileClusterSync_FailureMessage(t *testing.T) {
 				rt.expectedSelectorSyncSetStatuses = make([]hiveintv1alpha1.SyncStatus, tc.failingSelectorSyncSets)
 				for i := range rt.expectedSelectorSyncSetStatuses {
 					rt.expectedSelectorSyncSetStatuses[i] = buildSyncStatus(fmt.Sprintf("test-selectorsyncset-%d", i),
-						withFailureResult("failed to apply resource 0: test apply error"),
+						withFailedResources(testResourceFailure(
+							testConfigMapRef(fmt.Sprintf("selectorsyncset-namespace-%d", i), fmt.Sprintf("selectorsyncset-name-%d", i)),
+							"failed to apply resource 0: test apply error",
+						)),
 						withNoFirstSuccessTime(),
 					)
 				}
 			}
-			rt.expectRequeue = true
+			rt.expectResourceRetryWithin = resourceRetryBaseDelay
 			rt.run(t)
 		})
 	}
@@ -2027,9 +2390,10 @@ func TestReconcileClusterSync_MissingSecretNamespaceForSelectorSyncSet(t *testin
 		srcSecret)
 	rt.expectedFailedMessage = "SelectorSyncSet test-selectorsyncset is failing"
 	rt.expectedSelectorSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-selectorsyncset",
-		withFailureResult("source namespace missing for secret 0"),
+		withFailedResources(testResourceFailure(testSecretRef("dest-namespace", "dest-name"), "source namespace missing for secret 0")),
 		withNoFirstSuccessTime(),
 	)}
+	rt.expectResourceRetryWithin = resourceRetryBaseDelay
 	rt.run(t)
 }
 
@@ -2099,9 +2463,10 @@ func TestReconcileClusterSync_InvalidSecretNamespaceForSyncSet(t *testing.T) {
 		srcSecret)
 	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
-		withFailureResult("source in wrong namespace for secret 0"),
+		withFailedResources(testResourceFailure(testSecretRef("dest-namespace", "dest-name"), "source in wrong namespace for secret 0")),
 		withNoFirstSuccessTime(),
 	)}
+	rt.expectResourceRetryWithin = resourceRetryBaseDelay
 	rt.run(t)
 }
 
@@ -2126,10 +2491,10 @@ func TestReconcileClusterSync_MissingSourceSecret(t *testing.T) {
 		syncSet)
 	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
-		withFailureResult(`failed to read secret 0: secrets "test-secret" not found`),
+		withFailedResources(testResourceFailure(testSecretRef("dest-namespace", "dest-name"), `failed to read secret 0: secrets "test-secret" not found`)),
 		withNoFirstSuccessTime(),
 	)}
-	rt.expectRequeue = true
+	rt.expectResourceRetryWithin = resourceRetryBaseDelay
 	rt.run(t)
 }
 
@@ -2169,10 +2534,10 @@ func TestReconcileClusterSync_ConditionNotMutatedWhenMessageNotChanged(t *testin
 		Return(resource.ApplyResult(""), errors.New("test apply error"))
 	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
-		withFailureResult("failed to apply resource 0: test apply error"),
+		withFailedResources(testResourceFailure(testConfigMapRef("dest-namespace", "dest-name"), "failed to apply resource 0: test apply error")),
 		withNoFirstSuccessTime(),
 	)}
-	rt.expectRequeue = true
+	rt.expectResourceRetryWithin = resourceRetryBaseDelay
 	rt.run(t)
 	actualClusterSync := &hiveintv1alpha1.ClusterSync{}
 	err := rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testClusterSyncName}, actualClusterSync)
@@ -2185,6 +2550,77 @@ func TestReconcileClusterSync_ConditionNotMutatedWhenMessageNotChanged(t *testin
 	assert.Equal(t, timeInThePast, cond.LastProbeTime, "expected no change in last probe time")
 }
 
+func TestReconcileClusterSync_ClusterSleeping(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(testConfigMap("dest-namespace", "dest-name")),
+	)
+	failedCond := hiveintv1alpha1.ClusterSyncCondition{
+		Type:               hiveintv1alpha1.ClusterSyncFailed,
+		Status:             corev1.ConditionFalse,
+		Reason:             "Success",
+		Message:            "All SyncSets and SelectorSyncSets have been applied to the cluster",
+		LastTransitionTime: timeInThePast,
+		LastProbeTime:      timeInThePast,
+	}
+	existingClusterSync := clusterSyncBuilder(scheme).Build(
+		testcs.WithSyncSetStatus(buildSyncStatus("test-syncset",
+			withTransitionInThePast(),
+			withFirstSuccessTimeInThePast(),
+		)),
+		testcs.WithCondition(failedCond),
+	)
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(testcd.WithStatusPowerState(hivev1.HibernatingHibernationReason)),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		existingClusterSync,
+		buildSyncLease(time.Now()),
+		syncSet)
+	reconcileRequest := reconcile.Request{
+		NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testCDName},
+	}
+
+	// While the cluster is hibernating, nothing is applied and the ClusterSync only reports that the cluster is sleeping.
+	result, err := rt.r.Reconcile(context.TODO(), reconcileRequest)
+	require.NoError(t, err, "unexpected error from Reconcile")
+	assert.Equal(t, reconcile.Result{}, result, "unexpected result from Reconcile")
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	err = rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testClusterSyncName}, clusterSync)
+	require.NoError(t, err, "unexpected error getting ClusterSync")
+	require.Len(t, clusterSync.Status.Conditions, 2, "expected exactly 2 conditions")
+	assert.Equal(t, failedCond, clusterSync.Status.Conditions[0], "expected Failed condition to be unchanged")
+	sleepingCond := clusterSync.Status.Conditions[1]
+	assert.Equal(t, hiveintv1alpha1.ClusterSyncClusterSleeping, sleepingCond.Type, "expected ClusterSleeping condition")
+	assert.Equal(t, string(corev1.ConditionTrue), string(sleepingCond.Status), "expected ClusterSleeping condition to be true")
+	assert.Equal(t, hivev1.HibernatingHibernationReason, sleepingCond.Reason, "unexpected ClusterSleeping reason")
+
+	// Once the cluster wakes up, syncing resumes.
+	cd := &hivev1.ClusterDeployment{}
+	err = rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testCDName}, cd)
+	require.NoError(t, err, "unexpected error getting ClusterDeployment")
+	cd.Status.PowerState = hivev1.RunningReadyReason
+	err = rt.c.Status().Update(context.Background(), cd)
+	require.NoError(t, err, "unexpected error updating ClusterDeployment")
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
+		buildSyncStatus("test-syncset", withTransitionInThePast(), withFirstSuccessTimeInThePast()),
+	}
+	rt.expectUnchangedLeaseRenewTime = true
+	rt.run(t)
+	err = rt.c.Get(context.Background(), types.NamespacedName{Namespace: testNamespace, Name: testClusterSyncName}, clusterSync)
+	require.NoError(t, err, "unexpected error getting ClusterSync")
+	require.Len(t, clusterSync.Status.Conditions, 2, "expected exactly 2 conditions")
+	sleepingCond = clusterSync.Status.Conditions[1]
+	assert.Equal(t, string(corev1.ConditionFalse), string(sleepingCond.Status), "expected ClusterSleeping condition to be false")
+	assert.Equal(t, "ClusterAwake", sleepingCond.Reason, "unexpected ClusterSleeping reason")
+}
+
 func TestReconcileClusterSync_FirstSuccessTime(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -2272,13 +2708,13 @@ func TestReconcileClusterSync_NoFirstSuccessTimeSet(t *testing.T) {
 	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{
 		buildSyncStatus("test-syncset",
 			withNoFirstSuccessTime(),
-			withFailureResult("failed to apply resource 0: test apply error")),
+			withFailedResources(testResourceFailure(testConfigMapRef("dest-namespace", "dest-name"), "failed to apply resource 0: test apply error"))),
 	}
 	rt.mockResourceHelper.EXPECT().Apply(gomock.Any()).
 		Return(resource.ApplyResult(""), errors.New("test apply error")).Times(1)
 	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
 	rt.expectUnchangedLeaseRenewTime = true
-	rt.expectRequeue = true
+	rt.expectResourceRetryWithin = resourceRetryBaseDelay
 	rt.run(t)
 	updatedClusterSync := &hiveintv1alpha1.ClusterSync{}
 	err := rt.c.Get(context.TODO(), client.ObjectKey{Name: testCDName, Namespace: testNamespace}, updatedClusterSync)
@@ -2489,6 +2925,16 @@ func testConfigMap(namespace, name string) *corev1.ConfigMap {
 	}
 }
 
+func testCRD(name, group, kind string) *unstructured.Unstructured {
+	crd := &unstructured.Unstructured{}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind("CustomResourceDefinition")
+	crd.SetName(name)
+	unstructured.SetNestedField(crd.Object, group, "spec", "group")
+	unstructured.SetNestedField(crd.Object, kind, "spec", "names", "kind")
+	return crd
+}
+
 func testConfigMapRef(namespace, name string) hiveintv1alpha1.SyncResourceReference {
 	return hiveintv1alpha1.SyncResourceReference{
 		APIVersion: "v1",
@@ -2635,12 +3081,36 @@ func withFailureResult(message string) syncStatusOption {
 	}
 }
 
+// withFailedResources sets the failed resources, and a failure result due only to those resources.
+func withFailedResources(failures ...hiveintv1alpha1.SyncResourceFailure) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.Result = hiveintv1alpha1.FailureSyncSetResult
+		syncStatus.FailedResources = failures
+		syncStatus.FailureMessage = resourceFailuresMessage(failures)
+	}
+}
+
+// testResourceFailure returns a first failure of a resource. A zero LastFailureTime is expected to be set to now.
+func testResourceFailure(reference hiveintv1alpha1.SyncResourceReference, message string) hiveintv1alpha1.SyncResourceFailure {
+	return hiveintv1alpha1.SyncResourceFailure{
+		SyncResourceReference: reference,
+		Message:               message,
+		ConsecutiveFailures:   1,
+	}
+}
+
 func withResourcesToDelete(resourcesToDelete ...hiveintv1alpha1.SyncResourceReference) syncStatusOption {
 	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
 		syncStatus.ResourcesToDelete = resourcesToDelete
 	}
 }
 
+func withResourcesDeleted(resourcesDeleted ...hiveintv1alpha1.SyncResourceReference) syncStatusOption {
+	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
+		syncStatus.ResourcesDeleted = resourcesDeleted
+	}
+}
+
 func withTransitionInThePast() syncStatusOption {
 	return func(syncStatus *hiveintv1alpha1.SyncStatus) {
 		syncStatus.LastTransitionTime = timeInThePast
@@ -0,0 +1,128 @@
+package clustersync
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+)
+
+const (
+	// resourceRetryBaseDelay is the delay before the first retry of a resource that failed to apply. The delay doubles
+	// with each consecutive failure.
+	resourceRetryBaseDelay = 10 * time.Second
+	// resourceRetryMaxDelay is the longest delay between retries of a resource that failed to apply. Failing resources
+	// are also retried with the rest of the syncset at every full reapply.
+	resourceRetryMaxDelay = time.Hour
+)
+
+// resourceRetryDelay returns the delay before retrying a resource that has failed to apply the given number of times in
+// a row.
+func resourceRetryDelay(consecutiveFailures int32) time.Duration {
+	delay := resourceRetryBaseDelay
+	for i := int32(1); i < consecutiveFailures; i++ {
+		delay *= 2
+		if delay >= resourceRetryMaxDelay {
+			return resourceRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+// updateResourceFailures returns the failed resources of a syncset after an attempt to apply it. Resources that were
+// applied are no longer failing, resources that failed again have their backoff extended, and resources that were not
+// attempted keep their previous failure, as long as they are still in the syncset.
+func updateResourceFailures(
+	oldFailures []hiveintv1alpha1.SyncResourceFailure,
+	resourcesInSyncSet []hiveintv1alpha1.SyncResourceReference,
+	resourcesApplied []hiveintv1alpha1.SyncResourceReference,
+	resourceErrors map[hiveintv1alpha1.SyncResourceReference]error,
+	now metav1.Time,
+) []hiveintv1alpha1.SyncResourceFailure {
+	var newFailures []hiveintv1alpha1.SyncResourceFailure
+	for _, old := range oldFailures {
+		if _, failed := resourceErrors[old.SyncResourceReference]; failed ||
+			containsResource(resourcesApplied, old.SyncResourceReference) ||
+			!containsResource(resourcesInSyncSet, old.SyncResourceReference) {
+			continue
+		}
+		newFailures = append(newFailures, old)
+	}
+	for ref, err := range resourceErrors {
+		failure := hiveintv1alpha1.SyncResourceFailure{
+			SyncResourceReference: ref,
+			Message:               err.Error(),
+			ConsecutiveFailures:   1,
+			FirstFailureTime:      now,
+			LastFailureTime:       now,
+		}
+		for _, old := range oldFailures {
+			if old.SyncResourceReference == ref {
+				failure.ConsecutiveFailures = old.ConsecutiveFailures + 1
+				failure.FirstFailureTime = old.FirstFailureTime
+				break
+			}
+		}
+		failure.NextRetryTime = metav1.NewTime(now.Add(resourceRetryDelay(failure.ConsecutiveFailures)))
+		newFailures = append(newFailures, failure)
+	}
+	// Sort the failures to prevent update thrashing.
+	sort.Slice(newFailures, func(i, j int) bool {
+		return orderResources(newFailures[i].SyncResourceReference, newFailures[j].SyncResourceReference)
+	})
+	return newFailures
+}
+
+// resourceFailuresMessage returns the failure message of a syncset whose only failures are the given failed resources.
+func resourceFailuresMessage(failures []hiveintv1alpha1.SyncResourceFailure) string {
+	messages := make([]string, len(failures))
+	for i, failure := range failures {
+		messages[i] = failure.Message
+	}
+	return strings.Join(messages, "\n")
+}
+
+// hasOnlyResourceFailures returns true if the syncset is failing only because some of its resources failed to apply.
+// Such a syncset does not need to be reapplied; only its failed resources are retried, once their backoff expires.
+func hasOnlyResourceFailures(syncStatus hiveintv1alpha1.SyncStatus) bool {
+	return len(syncStatus.FailedResources) > 0 &&
+		syncStatus.FailureMessage == resourceFailuresMessage(syncStatus.FailedResources)
+}
+
+// partitionFailedResources splits the failed resources into those whose backoff has expired and those which are still
+// waiting to be retried.
+func partitionFailedResources(failures []hiveintv1alpha1.SyncResourceFailure, now time.Time) (due, waiting map[hiveintv1alpha1.SyncResourceReference]bool) {
+	due = map[hiveintv1alpha1.SyncResourceReference]bool{}
+	waiting = map[hiveintv1alpha1.SyncResourceReference]bool{}
+	for _, failure := range failures {
+		if failure.NextRetryTime.Time.After(now) {
+			waiting[failure.SyncResourceReference] = true
+		} else {
+			due[failure.SyncResourceReference] = true
+		}
+	}
+	return
+}
+
+// timeUntilNextResourceRetry returns the time until the backoff of the earliest failed resource expires. It returns
+// false if there are no failed resources.
+func timeUntilNextResourceRetry(syncStatuses []hiveintv1alpha1.SyncStatus) (time.Duration, bool) {
+	var next *metav1.Time
+	for _, status := range syncStatuses {
+		for i, failure := range status.FailedResources {
+			if next == nil || failure.NextRetryTime.Before(next) {
+				next = &status.FailedResources[i].NextRetryTime
+			}
+		}
+	}
+	if next == nil {
+		return 0, false
+	}
+	if timeUntil := time.Until(next.Time); timeUntil > 0 {
+		return timeUntil, true
+	}
+	return 0, true
+}
@@ -0,0 +1,81 @@
+package clustersync
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+)
+
+func TestResourceRetryDelay(t *testing.T) {
+	cases := []struct {
+		consecutiveFailures int32
+		expected            time.Duration
+	}{
+		{consecutiveFailures: 1, expected: 10 * time.Second},
+		{consecutiveFailures: 2, expected: 20 * time.Second},
+		{consecutiveFailures: 4, expected: 80 * time.Second},
+		{consecutiveFailures: 9, expected: 2560 * time.Second},
+		{consecutiveFailures: 10, expected: time.Hour},
+		{consecutiveFailures: 1000, expected: time.Hour},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, resourceRetryDelay(tc.consecutiveFailures), "unexpected delay for %d failures", tc.consecutiveFailures)
+	}
+}
+
+func TestUpdateResourceFailures(t *testing.T) {
+	now := metav1.Now()
+	applied := testConfigMapRef("ns", "applied")
+	failedAgain := testConfigMapRef("ns", "failed-again")
+	failedFirst := testConfigMapRef("ns", "failed-first")
+	skipped := testConfigMapRef("ns", "skipped")
+	removed := testConfigMapRef("ns", "removed")
+	oldFailure := func(ref hiveintv1alpha1.SyncResourceReference) hiveintv1alpha1.SyncResourceFailure {
+		return hiveintv1alpha1.SyncResourceFailure{
+			SyncResourceReference: ref,
+			Message:               "old error",
+			ConsecutiveFailures:   3,
+			FirstFailureTime:      timeInThePast,
+			LastFailureTime:       timeInThePast,
+			NextRetryTime:         timeInThePast,
+		}
+	}
+
+	actual := updateResourceFailures(
+		[]hiveintv1alpha1.SyncResourceFailure{oldFailure(applied), oldFailure(failedAgain), oldFailure(skipped), oldFailure(removed)},
+		[]hiveintv1alpha1.SyncResourceReference{applied, failedAgain, failedFirst, skipped},
+		[]hiveintv1alpha1.SyncResourceReference{applied},
+		map[hiveintv1alpha1.SyncResourceReference]error{
+			failedAgain: errors.New("new error"),
+			failedFirst: errors.New("first error"),
+		},
+		now,
+	)
+
+	expected := []hiveintv1alpha1.SyncResourceFailure{
+		{
+			SyncResourceReference: failedAgain,
+			Message:               "new error",
+			ConsecutiveFailures:   4,
+			FirstFailureTime:      timeInThePast,
+			LastFailureTime:       now,
+			NextRetryTime:         metav1.NewTime(now.Add(80 * time.Second)),
+		},
+		{
+			SyncResourceReference: failedFirst,
+			Message:               "first error",
+			ConsecutiveFailures:   1,
+			FirstFailureTime:      now,
+			LastFailureTime:       now,
+			NextRetryTime:         metav1.NewTime(now.Add(10 * time.Second)),
+		},
+		oldFailure(skipped),
+	}
+	assert.Equal(t, expected, actual)
+}
@@ -0,0 +1,14 @@
+package clustersync
+
+// jumpHash assigns the key to one of the given number of buckets using jump consistent hashing
+// (https://arxiv.org/abs/1406.2294). When the number of buckets grows from n to n+1, only 1/(n+1) of the keys are
+// reassigned, all of them to the new bucket; when it shrinks, only the keys of the removed bucket are reassigned.
+func jumpHash(key uint64, buckets int64) int64 {
+	var b, j int64 = -1, 0
+	for j < buckets {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return b
+}
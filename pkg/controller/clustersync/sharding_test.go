@@ -0,0 +1,32 @@
+package clustersync
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJumpHash(t *testing.T) {
+	const keys = 10000
+	rnd := rand.New(rand.NewSource(1))
+	for replicas := int64(1); replicas < 10; replicas++ {
+		moved := 0
+		counts := make([]int, replicas+1)
+		for i := 0; i < keys; i++ {
+			key := rnd.Uint64()
+			before, after := jumpHash(key, replicas), jumpHash(key, replicas+1)
+			if before != after {
+				moved++
+				assert.Equal(t, replicas, after, "expected keys to only move to the added replica")
+			}
+			counts[after]++
+		}
+		// Only about 1/(n+1) of the keys move when scaling from n to n+1 replicas.
+		expectedMoved := keys / int(replicas+1)
+		assert.InDelta(t, expectedMoved, moved, float64(expectedMoved)*0.1, "unexpected number of keys moved scaling from %d replicas", replicas)
+		for ordinal, count := range counts {
+			assert.InDelta(t, keys/int(replicas+1), count, float64(keys)*0.02, "unbalanced assignment to replica %d of %d", ordinal, replicas+1)
+		}
+	}
+}
@@ -59,9 +59,13 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	laneReconciler, err := controllerutils.NewUnreachableLaneReconciler(r, mgr.GetClient(), ControllerName)
+	if err != nil {
+		return err
+	}
 	// Create a new controller
 	c, err := controller.New("clusterversion-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(laneReconciler, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
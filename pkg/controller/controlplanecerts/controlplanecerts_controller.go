@@ -16,6 +16,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -50,6 +51,11 @@ const (
 	certsFoundMessage    = "Control plane certificates are present"
 
 	kubeAPIServerPatchTemplate = `[ {"op": "replace", "path": "/spec/forceRedeploymentReason", "value": %q } ]`
+
+	certManagerGroup       = "cert-manager.io"
+	certManagerVersion     = "v1"
+	certManagerIssuerKind  = "Issuer"
+	certManagerCertificate = "Certificate"
 )
 
 var (
@@ -97,9 +103,13 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	laneReconciler, err := controllerutils.NewUnreachableLaneReconciler(r, mgr.GetClient(), ControllerName)
+	if err != nil {
+		return err
+	}
 	// Create a new controller
 	c, err := controller.New("controlplanecerts-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(laneReconciler, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -113,9 +123,24 @@ func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconci
 		return err
 	}
 
+	// Watch for changes to certificate secrets issued by cert-manager, so that renewed certificates are pushed to
+	// the cluster
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(clusterDeploymentForCertificateSecret))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func clusterDeploymentForCertificateSecret(o client.Object) []reconcile.Request {
+	cdName, ok := o.GetLabels()[constants.ClusterDeploymentNameLabel]
+	if !ok || o.GetLabels()[constants.CertManagerCertificateLabel] != "true" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: cdName}}}
+}
+
 var _ reconcile.Reconciler = &ReconcileControlPlaneCerts{}
 
 // ReconcileControlPlaneCerts reconciles a ClusterDeployment object
@@ -146,9 +171,10 @@ func (r *ReconcileControlPlaneCerts) Reconcile(ctx context.Context, request reco
 	// Initialize cluster deployment conditions if not present
 	newConditions := controllerutils.InitializeClusterDeploymentConditions(cd.Status.Conditions, clusterDeploymentControlPlaneCertsConditions)
 	if len(newConditions) > len(cd.Status.Conditions) {
+		origConditions := cd.Status.Conditions
 		cd.Status.Conditions = newConditions
 		cdLog.Info("initializing control plane certs controller conditions")
-		if err := r.Status().Update(context.TODO(), cd); err != nil {
+		if err := controllerutils.PatchClusterDeploymentConditions(r.Client, cd, origConditions); err != nil {
 			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
 			return reconcile.Result{}, err
 		}
@@ -167,6 +193,13 @@ func (r *ReconcileControlPlaneCerts) Reconcile(ctx context.Context, request reco
 		return reconcile.Result{}, nil
 	}
 
+	// Request certificates from cert-manager before the cluster is installed so that they are likely to be
+	// issued by the time they are needed.
+	if err := r.reconcileCertManagerCertificates(cd, cdLog); err != nil {
+		cdLog.WithError(err).Error("failed to apply cert-manager certificates")
+		return reconcile.Result{}, err
+	}
+
 	if !cd.Spec.Installed {
 		return reconcile.Result{}, nil
 	}
@@ -222,6 +255,68 @@ func (r *ReconcileControlPlaneCerts) Reconcile(ctx context.Context, request reco
 	return reconcile.Result{}, nil
 }
 
+// reconcileCertManagerCertificates applies a cert-manager Certificate for each certificate bundle of the
+// ClusterDeployment which is to be issued by cert-manager.
+func (r *ReconcileControlPlaneCerts) reconcileCertManagerCertificates(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	for i := range cd.Spec.CertificateBundles {
+		bundle := &cd.Spec.CertificateBundles[i]
+		if bundle.CertManager == nil {
+			continue
+		}
+		cert, err := r.generateCertManagerCertificate(cd, bundle)
+		if err != nil {
+			return err
+		}
+		cdLog.WithField("certificate", cert.GetName()).Debug("applying cert-manager certificate")
+		if _, err := r.applier.ApplyRuntimeObject(cert, r.scheme); err != nil {
+			return errors.Wrapf(err, "failed to apply cert-manager certificate for certificate bundle %s", bundle.Name)
+		}
+	}
+	return nil
+}
+
+func (r *ReconcileControlPlaneCerts) generateCertManagerCertificate(cd *hivev1.ClusterDeployment, bundle *hivev1.CertificateBundleSpec) (*unstructured.Unstructured, error) {
+	issuerRef := map[string]interface{}{
+		"name":  bundle.CertManager.IssuerRef.Name,
+		"kind":  certManagerIssuerKind,
+		"group": certManagerGroup,
+	}
+	if kind := bundle.CertManager.IssuerRef.Kind; kind != "" {
+		issuerRef["kind"] = kind
+	}
+	if group := bundle.CertManager.IssuerRef.Group; group != "" {
+		issuerRef["group"] = group
+	}
+	dnsNames := make([]interface{}, len(bundle.CertManager.DNSNames))
+	for i, name := range bundle.CertManager.DNSNames {
+		dnsNames[i] = name
+	}
+	// The secret is labelled so that renewals can be traced back to the ClusterDeployment.
+	secretLabels := map[string]interface{}{
+		constants.ClusterDeploymentNameLabel:  cd.Name,
+		constants.CertManagerCertificateLabel: "true",
+	}
+
+	cert := &unstructured.Unstructured{}
+	cert.SetAPIVersion(certManagerGroup + "/" + certManagerVersion)
+	cert.SetKind(certManagerCertificate)
+	cert.SetNamespace(cd.Namespace)
+	cert.SetName(GenerateCertManagerCertificateName(cd.Name, bundle.Name))
+	cert.SetLabels(k8slabels.AddLabel(nil, constants.ClusterDeploymentNameLabel, cd.Name))
+	cert.Object["spec"] = map[string]interface{}{
+		"secretName": bundle.CertificateSecretRef.Name,
+		"dnsNames":   dnsNames,
+		"issuerRef":  issuerRef,
+		"secretTemplate": map[string]interface{}{
+			"labels": secretLabels,
+		},
+	}
+	if err := controllerutil.SetControllerReference(cd, cert, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "error setting owner reference")
+	}
+	return cert, nil
+}
+
 func (r *ReconcileControlPlaneCerts) getControlPlaneSecrets(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) ([]*corev1.Secret, bool, error) {
 	secretsNeeded, err := getControlPlaneSecretNames(cd, cdLog)
 	if err != nil {
@@ -398,6 +493,7 @@ func (r *ReconcileControlPlaneCerts) setCertsNotFoundCondition(cd *hivev1.Cluste
 		message = certsNotFoundMessage
 	}
 
+	origConditions := cd.Status.DeepCopy().Conditions
 	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 		cd.Status.Conditions,
 		hivev1.ControlPlaneCertificateNotFoundCondition,
@@ -412,7 +508,7 @@ func (r *ReconcileControlPlaneCerts) setCertsNotFoundCondition(cd *hivev1.Cluste
 	}
 
 	cd.Status.Conditions = conds
-	return true, r.Status().Update(context.TODO(), cd)
+	return true, controllerutils.PatchClusterDeploymentConditions(r.Client, cd, origConditions)
 }
 
 // defaultControlPlaneDomain will attempt to return the domain/hostname for the secondary API URL
@@ -443,6 +539,12 @@ func certificateBundle(cd *hivev1.ClusterDeployment, name string) *hivev1.Certif
 	return nil
 }
 
+// GenerateCertManagerCertificateName generates the name of the cert-manager Certificate issuing a certificate bundle
+// of a ClusterDeployment.
+func GenerateCertManagerCertificateName(cdName, bundleName string) string {
+	return apihelpers.GetResourceName(cdName, bundleName)
+}
+
 // GenerateControlPlaneCertsSyncSetName generates the name of the SyncSet that holds the control plane certificates to sync.
 func GenerateControlPlaneCertsSyncSetName(name string) string {
 	return apihelpers.GetResourceName(name, constants.ControlPlaneCertificateSuffix)
@@ -13,6 +13,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -233,6 +234,63 @@ func TestReconcileControlPlaneCerts(t *testing.T) {
 	}
 }
 
+func TestReconcileControlPlaneCerts_CertManager(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	openshiftapiv1.Install(scheme.Scheme)
+
+	cd := fakeClusterDeployment().
+		defaultCert("default-cert", "default-secret").
+		withNotFoundCondition(corev1.ConditionUnknown).obj()
+	cd.Spec.CertificateBundles[0].CertManager = &hivev1.CertManagerCertificate{
+		IssuerRef: hivev1.CertManagerIssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"},
+		DNSNames:  []string{"api.fake-cluster.example.com"},
+	}
+	fakeClient := fake.NewFakeClient(cd)
+	applier := &fakeApplier{}
+	r := &ReconcileControlPlaneCerts{
+		Client:  fakeClient,
+		scheme:  scheme.Scheme,
+		applier: applier,
+	}
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      fakeName,
+			Namespace: fakeNamespace,
+		},
+	})
+	require.NoError(t, err)
+
+	// The secret has not been issued yet, so only the certificate is expected
+	require.Len(t, applier.appliedObjects, 1, "single apply expected")
+	require.IsType(t, &unstructured.Unstructured{}, applier.appliedObjects[0], "certificate apply expected")
+	cert := applier.appliedObjects[0].(*unstructured.Unstructured)
+	assert.Equal(t, "cert-manager.io/v1", cert.GetAPIVersion(), "unexpected certificate API version")
+	assert.Equal(t, "Certificate", cert.GetKind(), "unexpected certificate kind")
+	assert.Equal(t, GenerateCertManagerCertificateName(fakeName, "default-cert"), cert.GetName(), "unexpected certificate name")
+	assert.Equal(t, fakeName, cert.GetLabels()[constants.ClusterDeploymentNameLabel], "incorrect cluster deployment name label")
+	require.Len(t, cert.GetOwnerReferences(), 1, "expected owner reference to cluster deployment")
+
+	secretName, _, _ := unstructured.NestedString(cert.Object, "spec", "secretName")
+	assert.Equal(t, "default-secret", secretName, "unexpected certificate secret name")
+	dnsNames, _, _ := unstructured.NestedSlice(cert.Object, "spec", "dnsNames")
+	assert.Equal(t, []interface{}{"api.fake-cluster.example.com"}, dnsNames, "unexpected certificate DNS names")
+	issuerRef, _, _ := unstructured.NestedStringMap(cert.Object, "spec", "issuerRef")
+	assert.Equal(t, map[string]string{"name": "letsencrypt", "kind": "ClusterIssuer", "group": "cert-manager.io"}, issuerRef, "unexpected certificate issuer")
+
+	// The issued secret maps back to the cluster deployment so that renewals are synced
+	secret := fakeCertSecret("default-secret")
+	secret.Labels = map[string]string{
+		constants.ClusterDeploymentNameLabel:  fakeName,
+		constants.CertManagerCertificateLabel: "true",
+	}
+	assert.Equal(t,
+		[]reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: fakeNamespace, Name: fakeName}}},
+		clusterDeploymentForCertificateSecret(secret),
+		"unexpected requests for certificate secret")
+	assert.Empty(t, clusterDeploymentForCertificateSecret(fakeCertSecret("other-secret")), "unexpected requests for unrelated secret")
+}
+
 func TestGetControlPlaneSecretNames(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -0,0 +1,308 @@
+// Package costestimation provides a controller which estimates the hourly cost of ClusterDeployments from their
+// MachinePools and control plane, using the pricing table configured in HiveConfig.
+package costestimation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.CostEstimationControllerName
+
+	defaultCurrency             = "USD"
+	defaultControlPlaneReplicas = 3
+	hourlyCostDecimalPlaces     = 4
+)
+
+var (
+	metricClusterDeploymentHourlyCost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deployment_hourly_cost",
+		Help: "Estimated hourly cost of an installed cluster deployment.",
+	}, []string{"cluster_deployment", "namespace", "currency"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricClusterDeploymentHourlyCost)
+}
+
+// Add creates a new CostEstimation Controller and adds it to the Manager with default RBAC. The Manager will set fields
+// on the Controller and Start it when the Manager is Started. The controller is not started when cost estimation is
+// not configured in HiveConfig.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	config, err := readCostEstimationConfig()
+	if err != nil {
+		logger.WithError(err).Error("could not read cost estimation configuration")
+		return err
+	}
+	if config == nil {
+		logger.Info("cost estimation is not configured in hive config")
+		return nil
+	}
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	r, err := NewReconciler(mgr, config, clientRateLimiter)
+	if err != nil {
+		logger.WithError(err).Error("invalid cost estimation configuration")
+		return err
+	}
+	return AddToManager(mgr, r, concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, config *hivev1.CostEstimationConfig, rateLimiter flowcontrol.RateLimiter) (*ReconcileCostEstimation, error) {
+	logger := log.WithField("controller", ControllerName)
+	estimator, err := newEstimator(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ReconcileCostEstimation{
+		Client:    controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:    logger,
+		estimator: estimator,
+	}, nil
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileCostEstimation, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to MachinePools, which change the cost of their ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hivev1.MachinePool{}}, handler.EnqueueRequestsFromMapFunc(clusterDeploymentForMachinePool)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func clusterDeploymentForMachinePool(o client.Object) []reconcile.Request {
+	pool, ok := o.(*hivev1.MachinePool)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: pool.Namespace,
+		Name:      pool.Spec.ClusterDeploymentRef.Name,
+	}}}
+}
+
+var _ reconcile.Reconciler = &ReconcileCostEstimation{}
+
+// ReconcileCostEstimation reconciles a ClusterDeployment to report its estimated cost.
+type ReconcileCostEstimation struct {
+	client.Client
+	logger    log.FieldLogger
+	estimator *estimator
+}
+
+// Reconcile estimates the hourly cost of a ClusterDeployment and reports it in status and metrics.
+func (r *ReconcileCostEstimation) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(ctx, request.NamespacedName, cd); {
+	case apierrors.IsNotFound(err):
+		cdLog.Debug("cluster deployment not found")
+		r.clearMetric(request.NamespacedName)
+		return reconcile.Result{}, nil
+	case err != nil:
+		cdLog.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	if cd.DeletionTimestamp != nil {
+		cdLog.Debug("cluster deployment is being deleted")
+		r.clearMetric(request.NamespacedName)
+		return reconcile.Result{}, nil
+	}
+
+	if !cd.Spec.Installed {
+		cdLog.Debug("cluster installation is not complete")
+		return reconcile.Result{}, nil
+	}
+
+	pools := &hivev1.MachinePoolList{}
+	if err := r.List(ctx, pools, client.InNamespace(cd.Namespace)); err != nil {
+		cdLog.WithError(err).Error("error listing machine pools")
+		return reconcile.Result{}, err
+	}
+	var cdPools []hivev1.MachinePool
+	for _, pool := range pools.Items {
+		if pool.Spec.ClusterDeploymentRef.Name == cd.Name {
+			cdPools = append(cdPools, pool)
+		}
+	}
+
+	estimate, hourlyCost := r.estimator.estimate(cd, cdPools)
+	metricClusterDeploymentHourlyCost.WithLabelValues(cd.Name, cd.Namespace, estimate.Currency).Set(hourlyCost)
+
+	if reflect.DeepEqual(estimate, cd.Status.CostEstimate) {
+		cdLog.Debug("cost estimate unchanged")
+		return reconcile.Result{}, nil
+	}
+	cdLog.WithField("hourlyCost", estimate.HourlyCost).Info("updating cost estimate")
+	cd.Status.CostEstimate = estimate
+	if err := r.Status().Update(ctx, cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *ReconcileCostEstimation) clearMetric(cd types.NamespacedName) {
+	metricClusterDeploymentHourlyCost.DeleteLabelValues(cd.Name, cd.Namespace, r.estimator.currency)
+}
+
+// estimator estimates the cost of clusters from a pricing table.
+type estimator struct {
+	currency             string
+	prices               map[string]float64
+	controlPlaneType     string
+	controlPlaneReplicas int64
+}
+
+func newEstimator(config *hivev1.CostEstimationConfig) (*estimator, error) {
+	e := &estimator{
+		currency: config.Currency,
+		prices:   make(map[string]float64, len(config.InstanceTypes)),
+	}
+	if e.currency == "" {
+		e.currency = defaultCurrency
+	}
+	for _, price := range config.InstanceTypes {
+		cost, err := strconv.ParseFloat(price.HourlyCost, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid hourly cost for instance type %s", price.InstanceType)
+		}
+		e.prices[price.InstanceType] = cost
+	}
+	if cp := config.ControlPlane; cp != nil {
+		e.controlPlaneType = cp.InstanceType
+		e.controlPlaneReplicas = defaultControlPlaneReplicas
+		if cp.Replicas != nil {
+			e.controlPlaneReplicas = int64(*cp.Replicas)
+		}
+	}
+	return e, nil
+}
+
+// estimate returns the cost estimate of the ClusterDeployment with the given MachinePools, along with the hourly cost
+// as a number. Hibernating clusters have no machines running, and so are estimated to cost nothing.
+func (e *estimator) estimate(cd *hivev1.ClusterDeployment, pools []hivev1.MachinePool) (*hivev1.CostEstimate, float64) {
+	var hourlyCost float64
+	unpriced := sets.NewString()
+	addMachines := func(instanceType string, replicas int64) {
+		if instanceType == "" || replicas == 0 {
+			return
+		}
+		price, ok := e.prices[instanceType]
+		if !ok {
+			unpriced.Insert(instanceType)
+			return
+		}
+		hourlyCost += price * float64(replicas)
+	}
+	if cd.Status.PowerState != hivev1.HibernatingHibernationReason {
+		addMachines(e.controlPlaneType, e.controlPlaneReplicas)
+		for i := range pools {
+			addMachines(controllerutils.MachinePoolInstanceType(&pools[i]), machinePoolRunningReplicas(&pools[i]))
+		}
+	}
+	estimate := &hivev1.CostEstimate{
+		HourlyCost: strconv.FormatFloat(hourlyCost, 'f', hourlyCostDecimalPlaces, 64),
+		Currency:   e.currency,
+	}
+	if unpriced.Len() > 0 {
+		estimate.UnpricedInstanceTypes = unpriced.List()
+	}
+	return estimate, hourlyCost
+}
+
+// machinePoolRunningReplicas returns the number of replicas expected to be running for the MachinePool. For autoscaling
+// MachinePools this is the current number of replicas.
+func machinePoolRunningReplicas(pool *hivev1.MachinePool) int64 {
+	switch {
+	case pool.Spec.Autoscaling != nil:
+		return int64(pool.Status.Replicas)
+	case pool.Spec.Replicas != nil:
+		return *pool.Spec.Replicas
+	default:
+		return 0
+	}
+}
+
+// readCostEstimationConfig reads the cost estimation configuration from the file pointed to by the
+// CostEstimationConfigFileEnvVar environment variable. A nil configuration is returned when cost estimation
+// is not configured.
+func readCostEstimationConfig() (*hivev1.CostEstimationConfig, error) {
+	path := os.Getenv(constants.CostEstimationConfigFileEnvVar)
+	if len(path) == 0 {
+		return nil, nil
+	}
+	fileBytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the cost estimation config file")
+	}
+	if len(fileBytes) == 0 {
+		return nil, nil
+	}
+	config := &hivev1.CostEstimationConfig{}
+	if err := json.Unmarshal(fileBytes, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the cost estimation config")
+	}
+	return config, nil
+}
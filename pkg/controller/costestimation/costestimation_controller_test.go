@@ -0,0 +1,154 @@
+package costestimation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testmp "github.com/openshift/hive/pkg/test/machinepool"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testCDName    = "test-cluster"
+)
+
+func withAWSMachines(instanceType string, replicas int64) testmp.Option {
+	return func(pool *hivev1.MachinePool) {
+		pool.Spec.Replicas = pointer.Int64Ptr(replicas)
+		pool.Spec.Platform.AWS = &hivev1aws.MachinePoolPlatform{InstanceType: instanceType}
+	}
+}
+
+func withAutoscaling(current int32) testmp.Option {
+	return func(pool *hivev1.MachinePool) {
+		pool.Spec.Replicas = nil
+		pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 1, MaxReplicas: 10}
+		pool.Status.Replicas = current
+	}
+}
+
+func TestReconcileCostEstimation(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	config := &hivev1.CostEstimationConfig{
+		InstanceTypes: []hivev1.InstanceTypePrice{
+			{InstanceType: "m5.xlarge", HourlyCost: "0.192"},
+			{InstanceType: "m5.large", HourlyCost: "0.096"},
+		},
+		ControlPlane: &hivev1.ControlPlaneCostEstimation{InstanceType: "m5.xlarge"},
+	}
+
+	cdBuilder := testcd.FullBuilder(testNamespace, testCDName, scheme)
+	poolBuilder := func(name string) testmp.Builder {
+		return testmp.FullBuilder(testNamespace, name, testCDName, scheme)
+	}
+
+	cases := []struct {
+		name             string
+		cd               *hivev1.ClusterDeployment
+		pools            []client.Object
+		expectedEstimate *hivev1.CostEstimate
+		expectedMetric   float64
+	}{
+		{
+			name: "not installed",
+			cd:   cdBuilder.Build(),
+		},
+		{
+			name: "control plane only",
+			cd:   cdBuilder.Build(testcd.Installed()),
+			expectedEstimate: &hivev1.CostEstimate{
+				HourlyCost: "0.5760",
+				Currency:   "USD",
+			},
+			expectedMetric: 0.576,
+		},
+		{
+			name: "machine pools",
+			cd:   cdBuilder.Build(testcd.Installed()),
+			pools: []client.Object{
+				poolBuilder("worker").Build(withAWSMachines("m5.large", 3)),
+				poolBuilder("autoscaling").Build(withAWSMachines("m5.large", 0), withAutoscaling(2)),
+				poolBuilder("other").Build(withAWSMachines("m5.large", 4), testmp.WithPoolNameForClusterDeployment("other", "other-cluster")),
+			},
+			expectedEstimate: &hivev1.CostEstimate{
+				HourlyCost: "1.0560",
+				Currency:   "USD",
+			},
+			expectedMetric: 1.056,
+		},
+		{
+			name: "unpriced instance type",
+			cd:   cdBuilder.Build(testcd.Installed()),
+			pools: []client.Object{
+				poolBuilder("gpu").Build(withAWSMachines("p3.2xlarge", 1)),
+			},
+			expectedEstimate: &hivev1.CostEstimate{
+				HourlyCost:            "0.5760",
+				Currency:              "USD",
+				UnpricedInstanceTypes: []string{"p3.2xlarge"},
+			},
+			expectedMetric: 0.576,
+		},
+		{
+			name: "hibernating",
+			cd:   cdBuilder.Build(testcd.Installed(), testcd.WithStatusPowerState(hivev1.HibernatingHibernationReason)),
+			pools: []client.Object{
+				poolBuilder("worker").Build(withAWSMachines("m5.large", 3)),
+			},
+			expectedEstimate: &hivev1.CostEstimate{
+				HourlyCost: "0.0000",
+				Currency:   "USD",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			metricClusterDeploymentHourlyCost.Reset()
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(tc.pools, tc.cd)...).Build()
+			e, err := newEstimator(config)
+			require.NoError(t, err, "unexpected error creating estimator")
+			r := &ReconcileCostEstimation{Client: c, logger: logger, estimator: e}
+
+			_, err = r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testCDName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testCDName}, cd))
+			assert.Equal(t, tc.expectedEstimate, cd.Status.CostEstimate, "unexpected cost estimate")
+			if tc.expectedEstimate != nil {
+				metric := metricClusterDeploymentHourlyCost.WithLabelValues(testCDName, testNamespace, "USD")
+				assert.InDelta(t, tc.expectedMetric, testutil.ToFloat64(metric), 0.0001, "unexpected hourly cost metric")
+			} else {
+				assert.Zero(t, testutil.CollectAndCount(metricClusterDeploymentHourlyCost), "unexpected hourly cost metric")
+			}
+		})
+	}
+}
+
+func TestNewEstimator_InvalidPrice(t *testing.T) {
+	_, err := newEstimator(&hivev1.CostEstimationConfig{
+		InstanceTypes: []hivev1.InstanceTypePrice{{InstanceType: "m5.large", HourlyCost: "cheap"}},
+	})
+	assert.Error(t, err, "expected error for invalid hourly cost")
+}
@@ -0,0 +1,311 @@
+// Package deletionmonitor provides a periodic monitor which reports the ClusterDeployments, MachinePools and DNSZones
+// whose deletion has been blocked by their finalizers for too long.
+package deletionmonitor
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.DeletionMonitorControllerName
+
+	// defaultTimeout is how long objects may wait on their finalizers when HiveConfig does not specify it.
+	defaultTimeout = time.Hour
+
+	// interval is the length of time between checks for blocked deletions.
+	interval = time.Minute
+
+	// deletionBlockedReason is the reason of the DeletionBlocked conditions.
+	deletionBlockedReason = "DeletionTimeoutExceeded"
+)
+
+var (
+	metricDeletionBlockedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_deletion_blocked_objects",
+		Help: "Number of objects whose deletion has been blocked by a finalizer for longer than the deletion blocked timeout, labeled by kind and finalizer.",
+	},
+		[]string{"kind", "finalizer"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricDeletionBlockedObjects)
+}
+
+// Add creates a new DeletionMonitor and adds it to the Manager.
+func Add(mgr manager.Manager) error {
+	m := &DeletionMonitor{
+		Client:   mgr.GetClient(),
+		Interval: interval,
+		Timeout:  defaultTimeout,
+	}
+	if timeout := os.Getenv(constants.DeletionBlockedTimeoutEnvVar); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			return errors.Wrapf(err, "could not parse %s", constants.DeletionBlockedTimeoutEnvVar)
+		}
+		m.Timeout = d
+	}
+	return mgr.Add(m)
+}
+
+// DeletionMonitor runs in a goroutine and periodically sets the DeletionBlocked condition on the ClusterDeployments,
+// MachinePools and DNSZones which have been waiting on their finalizers for longer than the timeout, naming the
+// finalizers and what blocks them. Like the metrics Calculator, it is not a standard controller watching Kube
+// resources; it runs periodically and then goes to sleep.
+type DeletionMonitor struct {
+	Client client.Client
+
+	// Interval is the length of time we sleep between checks.
+	Interval time.Duration
+
+	// Timeout is how long objects may wait on their finalizers before their deletion is reported as blocked.
+	Timeout time.Duration
+
+	// now returns the current time. It is overridden in tests.
+	now func() time.Time
+}
+
+// blockedChecker sets the DeletionBlocked condition on the blocked objects of a kind and returns them.
+type blockedChecker func(ctx context.Context, logger log.FieldLogger) ([]client.Object, error)
+
+// Start begins the monitoring loop.
+func (m *DeletionMonitor) Start(ctx context.Context) error {
+	log.WithField("controller", ControllerName).Info("started deletion monitor goroutine")
+	wait.UntilWithContext(ctx, m.check, m.Interval)
+	return nil
+}
+
+// check reports the blocked objects of every kind.
+func (m *DeletionMonitor) check(ctx context.Context) {
+	mLog := log.WithField("controller", ControllerName)
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, mLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	metricDeletionBlockedObjects.Reset()
+	for _, kind := range []struct {
+		name  string
+		check blockedChecker
+	}{
+		{name: "ClusterDeployment", check: m.checkClusterDeployments},
+		{name: "MachinePool", check: m.checkMachinePools},
+		{name: "DNSZone", check: m.checkDNSZones},
+	} {
+		kindLog := mLog.WithField("kind", kind.name)
+		blocked, err := kind.check(ctx, kindLog)
+		if err != nil {
+			kindLog.WithError(err).Error("error checking for blocked deletions")
+			continue
+		}
+		for _, obj := range blocked {
+			for _, finalizer := range obj.GetFinalizers() {
+				metricDeletionBlockedObjects.WithLabelValues(kind.name, finalizer).Inc()
+			}
+		}
+	}
+}
+
+// checkClusterDeployments reports the ClusterDeployments whose deletion is blocked, with the failures of their
+// deprovision as the cause.
+func (m *DeletionMonitor) checkClusterDeployments(ctx context.Context, logger log.FieldLogger) ([]client.Object, error) {
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := m.Client.List(ctx, cds); err != nil {
+		return nil, errors.Wrap(err, "could not list cluster deployments")
+	}
+	var blocked []client.Object
+	for i := range cds.Items {
+		cd := &cds.Items[i]
+		if !m.isBlocked(cd) {
+			continue
+		}
+		blocked = append(blocked, cd)
+		cause, err := m.clusterDeploymentCause(ctx, cd)
+		if err != nil {
+			return nil, err
+		}
+		conditions, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			hivev1.DeletionBlockedClusterDeploymentCondition,
+			corev1.ConditionTrue,
+			deletionBlockedReason,
+			blockedMessage(cd, cause),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if !changed {
+			continue
+		}
+		cd.Status.Conditions = conditions
+		m.updateStatus(ctx, cd, logger)
+	}
+	return blocked, nil
+}
+
+func (m *DeletionMonitor) clusterDeploymentCause(ctx context.Context, cd *hivev1.ClusterDeployment) (string, error) {
+	for _, t := range []hivev1.ClusterDeploymentConditionType{
+		hivev1.DeprovisionLaunchErrorCondition,
+		hivev1.AuthenticationFailureClusterDeploymentCondition,
+	} {
+		if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, t); cond != nil && cond.Status == corev1.ConditionTrue {
+			return cond.Message, nil
+		}
+	}
+	deprovision := &hivev1.ClusterDeprovision{}
+	switch err := m.Client.Get(ctx, types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, deprovision); {
+	case apierrors.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", errors.Wrapf(err, "could not get cluster deprovision %s/%s", cd.Namespace, cd.Name)
+	}
+	if deprovision.Status.Completed {
+		return "", nil
+	}
+	for _, t := range []hivev1.ClusterDeprovisionConditionType{
+		hivev1.AuthenticationFailureClusterDeprovisionCondition,
+		hivev1.DeprovisionFailedClusterDeprovisionCondition,
+	} {
+		if cond := controllerutils.FindClusterDeprovisionCondition(deprovision.Status.Conditions, t); cond != nil && cond.Status == corev1.ConditionTrue {
+			return fmt.Sprintf("ClusterDeprovision %s: %s", deprovision.Name, cond.Message), nil
+		}
+	}
+	return fmt.Sprintf("waiting for ClusterDeprovision %s to complete", deprovision.Name), nil
+}
+
+// checkMachinePools reports the MachinePools whose deletion is blocked, with their unreachable cluster as the cause.
+func (m *DeletionMonitor) checkMachinePools(ctx context.Context, logger log.FieldLogger) ([]client.Object, error) {
+	pools := &hivev1.MachinePoolList{}
+	if err := m.Client.List(ctx, pools); err != nil {
+		return nil, errors.Wrap(err, "could not list machine pools")
+	}
+	var blocked []client.Object
+	for i := range pools.Items {
+		pool := &pools.Items[i]
+		if !m.isBlocked(pool) {
+			continue
+		}
+		blocked = append(blocked, pool)
+		cause := ""
+		cd := &hivev1.ClusterDeployment{}
+		switch err := m.Client.Get(ctx, types.NamespacedName{Namespace: pool.Namespace, Name: pool.Spec.ClusterDeploymentRef.Name}, cd); {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			return nil, errors.Wrapf(err, "could not get cluster deployment %s/%s", pool.Namespace, pool.Spec.ClusterDeploymentRef.Name)
+		default:
+			if cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+				cause = fmt.Sprintf("cluster is unreachable: %s", cond.Message)
+			}
+		}
+		conditions, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+			pool.Status.Conditions,
+			hivev1.DeletionBlockedMachinePoolCondition,
+			corev1.ConditionTrue,
+			deletionBlockedReason,
+			blockedMessage(pool, cause),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if !changed {
+			continue
+		}
+		pool.Status.Conditions = conditions
+		m.updateStatus(ctx, pool, logger)
+	}
+	return blocked, nil
+}
+
+// checkDNSZones reports the DNSZones whose deletion is blocked, with their DNS errors as the cause.
+func (m *DeletionMonitor) checkDNSZones(ctx context.Context, logger log.FieldLogger) ([]client.Object, error) {
+	zones := &hivev1.DNSZoneList{}
+	if err := m.Client.List(ctx, zones); err != nil {
+		return nil, errors.Wrap(err, "could not list dns zones")
+	}
+	var blocked []client.Object
+	for i := range zones.Items {
+		zone := &zones.Items[i]
+		if !m.isBlocked(zone) {
+			continue
+		}
+		blocked = append(blocked, zone)
+		cause := ""
+		for _, t := range []hivev1.DNSZoneConditionType{
+			hivev1.InsufficientCredentialsCondition,
+			hivev1.AuthenticationFailureCondition,
+			hivev1.APIOptInRequiredCondition,
+			hivev1.GenericDNSErrorsCondition,
+		} {
+			if cond := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, t); cond != nil && cond.Status == corev1.ConditionTrue {
+				cause = cond.Message
+				break
+			}
+		}
+		conditions, changed := controllerutils.SetDNSZoneConditionWithChangeCheck(
+			zone.Status.Conditions,
+			hivev1.DeletionBlockedDNSZoneCondition,
+			corev1.ConditionTrue,
+			deletionBlockedReason,
+			blockedMessage(zone, cause),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if !changed {
+			continue
+		}
+		zone.Status.Conditions = conditions
+		m.updateStatus(ctx, zone, logger)
+	}
+	return blocked, nil
+}
+
+// isBlocked returns true when the object has been waiting on its finalizers for longer than the timeout.
+func (m *DeletionMonitor) isBlocked(obj client.Object) bool {
+	ts := obj.GetDeletionTimestamp()
+	if ts == nil || len(obj.GetFinalizers()) == 0 {
+		return false
+	}
+	now := time.Now
+	if m.now != nil {
+		now = m.now
+	}
+	return now().Sub(ts.Time) > m.Timeout
+}
+
+// updateStatus updates the status of a blocked object. Errors are logged, as the object is checked again in the next
+// run.
+func (m *DeletionMonitor) updateStatus(ctx context.Context, obj client.Object, logger log.FieldLogger) {
+	objLog := logger.WithFields(log.Fields{"namespace": obj.GetNamespace(), "name": obj.GetName()})
+	if err := m.Client.Status().Update(ctx, obj); err != nil {
+		objLog.WithError(err).Log(controllerutils.LogLevel(err), "could not set deletion blocked condition")
+		return
+	}
+	objLog.WithField("finalizers", obj.GetFinalizers()).Warn("deletion is blocked")
+}
+
+// blockedMessage returns the message of the DeletionBlocked condition of an object, naming the finalizers which block
+// its deletion and the cause, if known.
+func blockedMessage(obj client.Object, cause string) string {
+	msg := fmt.Sprintf("Deletion has been blocked since %s by finalizers: %s",
+		obj.GetDeletionTimestamp().UTC().Format(time.RFC3339), strings.Join(obj.GetFinalizers(), ", "))
+	if cause != "" {
+		msg = fmt.Sprintf("%s. %s", msg, cause)
+	}
+	return msg
+}
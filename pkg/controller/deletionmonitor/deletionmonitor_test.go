@@ -0,0 +1,158 @@
+package deletionmonitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	namespace = "test-namespace"
+	finalizer = "hive.openshift.io/test"
+)
+
+var (
+	now = time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func objectMeta(name string, deletedFor time.Duration) metav1.ObjectMeta {
+	deleted := metav1.NewTime(now.Add(-deletedFor))
+	return metav1.ObjectMeta{
+		Namespace:         namespace,
+		Name:              name,
+		Finalizers:        []string{finalizer},
+		DeletionTimestamp: &deleted,
+	}
+}
+
+func TestCheck(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		expectBlocked  bool
+		expectMessage  string
+		expectedMetric map[string]float64
+	}{
+		{
+			name:    "recently deleted cluster deployment",
+			objects: []client.Object{&hivev1.ClusterDeployment{ObjectMeta: objectMeta("cd", time.Minute)}},
+		},
+		{
+			name: "blocked cluster deployment",
+			objects: []client.Object{&hivev1.ClusterDeployment{
+				ObjectMeta: objectMeta("cd", 2*time.Hour),
+				Status: hivev1.ClusterDeploymentStatus{Conditions: []hivev1.ClusterDeploymentCondition{{
+					Type:    hivev1.DeprovisionLaunchErrorCondition,
+					Status:  corev1.ConditionTrue,
+					Message: "could not launch deprovision",
+				}}},
+			}},
+			expectBlocked:  true,
+			expectMessage:  "Deletion has been blocked since 2026-01-01T10:00:00Z by finalizers: hive.openshift.io/test. could not launch deprovision",
+			expectedMetric: map[string]float64{"ClusterDeployment": 1},
+		},
+		{
+			name: "cluster deployment blocked on deprovision",
+			objects: []client.Object{
+				&hivev1.ClusterDeployment{ObjectMeta: objectMeta("cd", 2*time.Hour)},
+				&hivev1.ClusterDeprovision{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cd"},
+					Status: hivev1.ClusterDeprovisionStatus{Conditions: []hivev1.ClusterDeprovisionCondition{{
+						Type:    hivev1.AuthenticationFailureClusterDeprovisionCondition,
+						Status:  corev1.ConditionTrue,
+						Message: "credentials are invalid",
+					}}},
+				},
+			},
+			expectBlocked:  true,
+			expectMessage:  "Deletion has been blocked since 2026-01-01T10:00:00Z by finalizers: hive.openshift.io/test. ClusterDeprovision cd: credentials are invalid",
+			expectedMetric: map[string]float64{"ClusterDeployment": 1},
+		},
+		{
+			name: "machine pool blocked on unreachable cluster",
+			objects: []client.Object{
+				&hivev1.MachinePool{
+					ObjectMeta: objectMeta("pool", 2*time.Hour),
+					Spec:       hivev1.MachinePoolSpec{ClusterDeploymentRef: corev1.LocalObjectReference{Name: "cd"}},
+				},
+				&hivev1.ClusterDeployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "cd"},
+					Status: hivev1.ClusterDeploymentStatus{Conditions: []hivev1.ClusterDeploymentCondition{{
+						Type:    hivev1.UnreachableCondition,
+						Status:  corev1.ConditionTrue,
+						Message: "connection refused",
+					}}},
+				},
+			},
+			expectBlocked:  true,
+			expectMessage:  "Deletion has been blocked since 2026-01-01T10:00:00Z by finalizers: hive.openshift.io/test. cluster is unreachable: connection refused",
+			expectedMetric: map[string]float64{"MachinePool": 1},
+		},
+		{
+			name: "blocked dns zone",
+			objects: []client.Object{&hivev1.DNSZone{
+				ObjectMeta: objectMeta("zone", 2*time.Hour),
+				Status: hivev1.DNSZoneStatus{Conditions: []hivev1.DNSZoneCondition{{
+					Type:    hivev1.GenericDNSErrorsCondition,
+					Status:  corev1.ConditionTrue,
+					Message: "zone is not empty",
+				}}},
+			}},
+			expectBlocked:  true,
+			expectMessage:  "Deletion has been blocked since 2026-01-01T10:00:00Z by finalizers: hive.openshift.io/test. zone is not empty",
+			expectedMetric: map[string]float64{"DNSZone": 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(test.objects...).Build()
+			m := &DeletionMonitor{
+				Client:  c,
+				Timeout: time.Hour,
+				now:     func() time.Time { return now },
+			}
+
+			m.check(context.TODO())
+
+			obj := test.objects[0]
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj), "could not get object")
+			var message string
+			var found bool
+			switch o := obj.(type) {
+			case *hivev1.ClusterDeployment:
+				if cond := controllerutils.FindClusterDeploymentCondition(o.Status.Conditions, hivev1.DeletionBlockedClusterDeploymentCondition); cond != nil {
+					found, message = true, cond.Message
+				}
+			case *hivev1.MachinePool:
+				if cond := controllerutils.FindMachinePoolCondition(o.Status.Conditions, hivev1.DeletionBlockedMachinePoolCondition); cond != nil {
+					found, message = true, cond.Message
+				}
+			case *hivev1.DNSZone:
+				if cond := controllerutils.FindDNSZoneCondition(o.Status.Conditions, hivev1.DeletionBlockedDNSZoneCondition); cond != nil {
+					found, message = true, cond.Message
+				}
+			}
+			assert.Equal(t, test.expectBlocked, found, "unexpected deletion blocked condition")
+			assert.Equal(t, test.expectMessage, message, "unexpected deletion blocked message")
+			for _, kind := range []string{"ClusterDeployment", "MachinePool", "DNSZone"} {
+				assert.Equal(t, test.expectedMetric[kind], testutil.ToFloat64(metricDeletionBlockedObjects.WithLabelValues(kind, finalizer)), "unexpected metric for %s", kind)
+			}
+		})
+	}
+}
@@ -56,7 +56,7 @@ func Add(mgr manager.Manager) error {
 		ControllerName.String(),
 		mgr,
 		controller.Options{
-			Reconciler:              reconciler,
+			Reconciler:              controllerutils.NewStartupRampReconciler(reconciler, queueRateLimiter),
 			MaxConcurrentReconciles: concurrentReconciles,
 			RateLimiter:             queueRateLimiter,
 		},
@@ -1,10 +1,14 @@
 package nameserver
 
 import (
+	"context"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
 	"github.com/pkg/errors"
 
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -14,6 +18,16 @@ import (
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
+const (
+	// awsChangeBatchWindow is how long changes to the name servers in a hosted zone are collected before they are
+	// submitted together.
+	awsChangeBatchWindow = time.Second
+
+	// awsMaxChangesPerBatch is the most changes that are submitted in a single change batch. Route53 accepts up to 1000
+	// records per request, counting the records of upserts twice, and a domain usually has four name servers.
+	awsMaxChangesPerBatch = 100
+)
+
 // NewAWSQuery creates a new name server query for AWS.
 func NewAWSQuery(c client.Client, credsSecretName string, region string) Query {
 	return &awsQuery{
@@ -21,11 +35,16 @@ func NewAWSQuery(c client.Client, credsSecretName string, region string) Query {
 			awsClient, err := awsclient.NewClient(c, credsSecretName, controllerutils.GetHiveNamespace(), region)
 			return awsClient, errors.Wrap(err, "error creating AWS client")
 		},
+		batcher: newAWSChangeBatcher(awsChangeBatchWindow),
 	}
 }
 
 type awsQuery struct {
 	getAWSClient func() (awsclient.Client, error)
+
+	// batcher combines the concurrent changes to the name servers in a hosted zone. When nil, each change is
+	// submitted on its own.
+	batcher *awsChangeBatcher
 }
 
 var _ Query = (*awsQuery)(nil)
@@ -61,7 +80,7 @@ func (q *awsQuery) Create(rootDomain string, domain string, values sets.String)
 		return errors.New("no public hosted zone found for domain")
 	}
 	return errors.Wrap(
-		q.changeNameServers(awsClient, *zoneID, domain, values, route53.ChangeActionUpsert),
+		q.changeNameServers(awsClient, *zoneID, domain, values, route53types.ChangeActionUpsert),
 		"error creating the name server",
 	)
 }
@@ -82,15 +101,16 @@ func (q *awsQuery) Delete(rootDomain string, domain string, values sets.String)
 	if len(values) != 0 {
 		// If values were provided for the name servers, attempt to perform a
 		// delete using those values.
-		err = q.changeNameServers(awsClient, *zoneID, domain, values, route53.ChangeActionDelete)
-		awsErr, ok := err.(awserr.Error)
-		if !ok || awsErr.Code() != route53.ErrCodeInvalidChangeBatch {
+		err = q.changeNameServers(awsClient, *zoneID, domain, values, route53types.ChangeActionDelete)
+		var changeErr *route53types.InvalidChangeBatch
+		if !errors.As(err, &changeErr) {
 			return errors.Wrap(err, "error deleting the name server")
 		}
-		if strings.HasSuffix(awsErr.Message(), "not found]") {
+		message := strings.Join(changeErr.Messages, ", ")
+		if strings.HasSuffix(message, "not found") {
 			return nil
 		}
-		if !strings.HasSuffix(awsErr.Message(), "not match the current values]") {
+		if !strings.HasSuffix(message, "not match the current values") {
 			return errors.Wrap(err, "error deleting the name server")
 		}
 	}
@@ -104,34 +124,33 @@ func (q *awsQuery) Delete(rootDomain string, domain string, values sets.String)
 		return nil
 	}
 	return errors.Wrap(
-		q.changeNameServers(awsClient, *zoneID, domain, values, route53.ChangeActionDelete),
+		q.changeNameServers(awsClient, *zoneID, domain, values, route53types.ChangeActionDelete),
 		"error deleting the name server with recently read values",
 	)
 }
 
 // queryZoneID queries AWS for the public hosted zone for the specified domain.
 func (q *awsQuery) queryZoneID(awsClient awsclient.Client, domain string) (*string, error) {
-	maxItems := "5"
 	domain = controllerutils.Dotted(domain)
 	listInput := &route53.ListHostedZonesByNameInput{
 		DNSName:  &domain,
-		MaxItems: &maxItems,
+		MaxItems: aws.Int32(5),
 	}
 	for {
-		listOutput, err := awsClient.ListHostedZonesByName(listInput)
+		listOutput, err := awsClient.ListHostedZonesByName(context.TODO(), listInput)
 		if err != nil {
 			return nil, err
 		}
 		for _, zone := range listOutput.HostedZones {
-			if zone == nil || zone.Name == nil || *zone.Name != domain {
+			if zone.Name == nil || *zone.Name != domain {
 				return nil, nil
 			}
-			if zone.Config == nil || zone.Config.PrivateZone == nil || *zone.Config.PrivateZone {
+			if zone.Config == nil || zone.Config.PrivateZone {
 				continue
 			}
 			return zone.Id, nil
 		}
-		if listOutput.IsTruncated == nil || !*listOutput.IsTruncated {
+		if !listOutput.IsTruncated {
 			return nil, nil
 		}
 		listInput.DNSName = listOutput.NextDNSName
@@ -142,13 +161,12 @@ func (q *awsQuery) queryZoneID(awsClient awsclient.Client, domain string) (*stri
 // queryNameServers queries AWS for the name servers in the specified hosted zone.
 func (q *awsQuery) queryNameServers(awsClient awsclient.Client, hostedZoneID string) (map[string]sets.String, error) {
 	nameServers := map[string]sets.String{}
-	maxItems := "100"
 	listInput := &route53.ListResourceRecordSetsInput{
 		HostedZoneId: &hostedZoneID,
-		MaxItems:     &maxItems,
+		MaxItems:     aws.Int32(100),
 	}
 	for {
-		listOutput, err := awsClient.ListResourceRecordSets(listInput)
+		listOutput, err := awsClient.ListResourceRecordSets(context.TODO(), listInput)
 		if err != nil {
 			return nil, err
 		}
@@ -156,7 +174,7 @@ func (q *awsQuery) queryNameServers(awsClient awsclient.Client, hostedZoneID str
 			if recordSet.Name == nil {
 				continue
 			}
-			if recordSet.Type == nil || *recordSet.Type != route53.RRTypeNs {
+			if recordSet.Type != route53types.RRTypeNs {
 				continue
 			}
 			values := sets.NewString()
@@ -165,7 +183,7 @@ func (q *awsQuery) queryNameServers(awsClient awsclient.Client, hostedZoneID str
 			}
 			nameServers[controllerutils.Undotted(*recordSet.Name)] = values
 		}
-		if listOutput.IsTruncated == nil || !*listOutput.IsTruncated {
+		if !listOutput.IsTruncated {
 			return nameServers, nil
 		}
 		listInput.StartRecordIdentifier = listOutput.NextRecordIdentifier
@@ -176,13 +194,11 @@ func (q *awsQuery) queryNameServers(awsClient awsclient.Client, hostedZoneID str
 
 // queryNameServer queries AWS for the name servers in the specified hosted zone for the specified domain.
 func (q *awsQuery) queryNameServer(awsClient awsclient.Client, hostedZoneID string, domain string) (sets.String, error) {
-	maxItems := "1"
-	recordType := route53.RRTypeNs
-	listOutput, err := awsClient.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+	listOutput, err := awsClient.ListResourceRecordSets(context.TODO(), &route53.ListResourceRecordSetsInput{
 		HostedZoneId:    &hostedZoneID,
-		MaxItems:        &maxItems,
+		MaxItems:        aws.Int32(1),
 		StartRecordName: &domain,
-		StartRecordType: &recordType,
+		StartRecordType: route53types.RRTypeNs,
 	})
 	if err != nil {
 		return nil, err
@@ -197,7 +213,7 @@ func (q *awsQuery) queryNameServer(awsClient awsclient.Client, hostedZoneID stri
 	if controllerutils.Undotted(*recordSet.Name) != domain {
 		return nil, nil
 	}
-	if recordSet.Type == nil || *recordSet.Type != route53.RRTypeNs {
+	if recordSet.Type != route53types.RRTypeNs {
 		return nil, nil
 	}
 	values := sets.NewString()
@@ -208,28 +224,110 @@ func (q *awsQuery) queryNameServer(awsClient awsclient.Client, hostedZoneID stri
 }
 
 // changeNameServers changes the name servers for the specified domain in the specified hosted zone.
-func (q *awsQuery) changeNameServers(awsClient awsclient.Client, hostedZoneID string, domain string, values sets.String, action string) error {
-	recordType := route53.RRTypeNs
-	ttl := int64(60)
-	records := make([]*route53.ResourceRecord, 0, len(values))
+func (q *awsQuery) changeNameServers(awsClient awsclient.Client, hostedZoneID string, domain string, values sets.String, action route53types.ChangeAction) error {
+	records := make([]route53types.ResourceRecord, 0, len(values))
 	for v := range values {
-		value := v
-		records = append(records, &route53.ResourceRecord{Value: &value})
+		records = append(records, route53types.ResourceRecord{Value: aws.String(v)})
 	}
-	changeInput := &route53.ChangeResourceRecordSetsInput{
-		HostedZoneId: &hostedZoneID,
-		ChangeBatch: &route53.ChangeBatch{
-			Changes: []*route53.Change{{
-				Action: &action,
-				ResourceRecordSet: &route53.ResourceRecordSet{
-					Name:            &domain,
-					Type:            &recordType,
-					TTL:             &ttl,
-					ResourceRecords: records,
-				},
-			}},
+	change := route53types.Change{
+		Action: action,
+		ResourceRecordSet: &route53types.ResourceRecordSet{
+			Name:            &domain,
+			Type:            route53types.RRTypeNs,
+			TTL:             aws.Int64(60),
+			ResourceRecords: records,
 		},
 	}
-	_, err := awsClient.ChangeResourceRecordSets(changeInput)
+	if q.batcher != nil {
+		return q.batcher.submit(awsClient, hostedZoneID, change)
+	}
+	return submitAWSChanges(awsClient, hostedZoneID, []route53types.Change{change})
+}
+
+func submitAWSChanges(awsClient awsclient.Client, hostedZoneID string, changes []route53types.Change) error {
+	_, err := awsClient.ChangeResourceRecordSets(context.TODO(), &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: &hostedZoneID,
+		ChangeBatch:  &route53types.ChangeBatch{Changes: changes},
+	})
 	return err
 }
+
+// awsChangeBatcher submits the changes to the records in a hosted zone which are made within a short window of each
+// other in a single change batch, so that syncing many DNSZones at once stays under the Route53 API limits.
+type awsChangeBatcher struct {
+	window time.Duration
+
+	mutex sync.Mutex
+	// pending are the batches collecting changes, by hosted zone ID.
+	pending map[string]*awsChangeBatch
+}
+
+type awsChangeBatch struct {
+	changes []route53types.Change
+	errs    []error
+	// full is closed when the batch cannot take more changes.
+	full chan struct{}
+	// done is closed when the batch has been submitted and errs are set.
+	done chan struct{}
+}
+
+func newAWSChangeBatcher(window time.Duration) *awsChangeBatcher {
+	return &awsChangeBatcher{
+		window:  window,
+		pending: map[string]*awsChangeBatch{},
+	}
+}
+
+// submit adds the change to the pending batch for the hosted zone, and waits for the batch to be submitted.
+func (b *awsChangeBatcher) submit(awsClient awsclient.Client, hostedZoneID string, change route53types.Change) error {
+	b.mutex.Lock()
+	batch := b.pending[hostedZoneID]
+	if batch == nil {
+		batch = &awsChangeBatch{
+			full: make(chan struct{}),
+			done: make(chan struct{}),
+		}
+		b.pending[hostedZoneID] = batch
+		go b.send(awsClient, hostedZoneID, batch)
+	}
+	i := len(batch.changes)
+	batch.changes = append(batch.changes, change)
+	if len(batch.changes) == awsMaxChangesPerBatch {
+		delete(b.pending, hostedZoneID)
+		close(batch.full)
+	}
+	b.mutex.Unlock()
+
+	<-batch.done
+	return batch.errs[i]
+}
+
+// send submits the batch once the window has passed or the batch is full.
+func (b *awsChangeBatcher) send(awsClient awsclient.Client, hostedZoneID string, batch *awsChangeBatch) {
+	select {
+	case <-time.After(b.window):
+		b.mutex.Lock()
+		if b.pending[hostedZoneID] == batch {
+			delete(b.pending, hostedZoneID)
+		}
+		b.mutex.Unlock()
+	case <-batch.full:
+	}
+
+	// No more changes are added to the batch once it is no longer pending.
+	batch.errs = make([]error, len(batch.changes))
+	err := submitAWSChanges(awsClient, hostedZoneID, batch.changes)
+	var changeErr *route53types.InvalidChangeBatch
+	if len(batch.changes) > 1 && errors.As(err, &changeErr) {
+		// Route53 rejects the whole batch when any of its changes is invalid. Submit the changes one at a time so
+		// that each one is applied or rejected on its own.
+		for i, change := range batch.changes {
+			batch.errs[i] = submitAWSChanges(awsClient, hostedZoneID, []route53types.Change{change})
+		}
+	} else {
+		for i := range batch.errs {
+			batch.errs[i] = err
+		}
+	}
+	close(batch.done)
+}
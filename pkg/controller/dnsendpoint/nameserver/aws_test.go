@@ -1,11 +1,17 @@
 package nameserver
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
-	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/smithy-go"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
@@ -184,27 +190,27 @@ func TestAWSGet(t *testing.T) {
 			for i, out := range tc.listHostedZonesOutputs {
 				in := &route53.ListHostedZonesByNameInput{
 					DNSName:  pointer.StringPtr("test-domain."),
-					MaxItems: pointer.StringPtr("5"),
+					MaxItems: pointer.Int32Ptr(5),
 				}
 				if i > 0 {
 					in.DNSName = pointer.StringPtr("next-dns-name")
 					in.HostedZoneId = pointer.StringPtr("next-hosted-zone-id")
 				}
 				mockAWSClient.EXPECT().
-					ListHostedZonesByName(gomock.Eq(in)).
+					ListHostedZonesByName(gomock.Any(), gomock.Eq(in)).
 					Return(out, nil)
 			}
 			for i, out := range tc.listResourceRecordSetsOutputs {
 				in := &route53.ListResourceRecordSetsInput{
 					HostedZoneId: pointer.StringPtr("test-zone-id"),
-					MaxItems:     pointer.StringPtr("100"),
+					MaxItems:     pointer.Int32Ptr(100),
 				}
 				if i > 0 {
 					in.StartRecordName = pointer.StringPtr("next-record-name")
-					in.StartRecordType = pointer.StringPtr("next-record-type")
+					in.StartRecordType = "next-record-type"
 				}
 				mockAWSClient.EXPECT().
-					ListResourceRecordSets(gomock.Eq(in)).
+					ListResourceRecordSets(gomock.Any(), gomock.Eq(in)).
 					Return(out, nil)
 			}
 			actualNameServers, err := awsQuery.Get("test-domain")
@@ -218,6 +224,91 @@ func TestAWSGet(t *testing.T) {
 	}
 }
 
+func TestAWSChangeBatcher(t *testing.T) {
+	invalidChangeBatch := &route53types.InvalidChangeBatch{
+		Messages: []string{"Tried to delete resource record set [name='bad-domain.', type='NS'] but it was not found"},
+	}
+	throttling := &smithy.GenericAPIError{Code: "Throttling", Message: "Rate exceeded"}
+	cases := []struct {
+		name        string
+		domains     []string
+		batchErr    error
+		changeErrs  map[string]error
+		expectedErr map[string]error
+	}{
+		{
+			name:    "single change",
+			domains: []string{"domain-1"},
+		},
+		{
+			name:    "concurrent changes",
+			domains: []string{"domain-1", "domain-2", "domain-3"},
+		},
+		{
+			name:     "batch failure",
+			domains:  []string{"domain-1", "domain-2"},
+			batchErr: throttling,
+			expectedErr: map[string]error{
+				"domain-1": throttling,
+				"domain-2": throttling,
+			},
+		},
+		{
+			name:       "invalid change in batch",
+			domains:    []string{"domain-1", "bad-domain", "domain-2"},
+			batchErr:   invalidChangeBatch,
+			changeErrs: map[string]error{"bad-domain": invalidChangeBatch},
+			expectedErr: map[string]error{
+				"bad-domain": invalidChangeBatch,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockAWSClient := mock.NewMockClient(mockCtrl)
+			mockAWSClient.EXPECT().
+				ChangeResourceRecordSets(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, in *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+					assert.Equal(t, "test-zone-id", *in.HostedZoneId, "unexpected hosted zone")
+					assert.Len(t, in.ChangeBatch.Changes, len(tc.domains), "expected all changes in one batch")
+					return &route53.ChangeResourceRecordSetsOutput{}, tc.batchErr
+				})
+			if tc.batchErr == invalidChangeBatch {
+				mockAWSClient.EXPECT().
+					ChangeResourceRecordSets(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, in *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+						require.Len(t, in.ChangeBatch.Changes, 1, "expected changes to be submitted one at a time")
+						return &route53.ChangeResourceRecordSetsOutput{}, tc.changeErrs[*in.ChangeBatch.Changes[0].ResourceRecordSet.Name]
+					}).
+					Times(len(tc.domains))
+			}
+			awsQuery := &awsQuery{
+				getAWSClient: func() (awsclient.Client, error) {
+					return mockAWSClient, nil
+				},
+				batcher: newAWSChangeBatcher(100 * time.Millisecond),
+			}
+
+			errs := make([]error, len(tc.domains))
+			var wg sync.WaitGroup
+			for i, domain := range tc.domains {
+				wg.Add(1)
+				go func(i int, domain string) {
+					defer wg.Done()
+					errs[i] = awsQuery.changeNameServers(mockAWSClient, "test-zone-id", domain, sets.NewString("test-ns"), route53types.ChangeActionUpsert)
+				}(i, domain)
+			}
+			wg.Wait()
+			for i, domain := range tc.domains {
+				assert.Equal(t, tc.expectedErr[domain], errs[i], "unexpected error for %s", domain)
+			}
+			assert.Empty(t, awsQuery.batcher.pending, "expected no pending batches")
+		})
+	}
+}
+
 type listHostedZonesOutputOption func(*route53.ListHostedZonesByNameOutput)
 
 func testListHostedZonesOutput(opts ...listHostedZonesOutputOption) *route53.ListHostedZonesByNameOutput {
@@ -228,7 +319,7 @@ func testListHostedZonesOutput(opts ...listHostedZonesOutputOption) *route53.Lis
 	return out
 }
 
-func withHostedZones(hostedZones ...*route53.HostedZone) listHostedZonesOutputOption {
+func withHostedZones(hostedZones ...route53types.HostedZone) listHostedZonesOutputOption {
 	return func(out *route53.ListHostedZonesByNameOutput) {
 		out.HostedZones = hostedZones
 	}
@@ -236,31 +327,31 @@ func withHostedZones(hostedZones ...*route53.HostedZone) listHostedZonesOutputOp
 
 func hzTruncated() listHostedZonesOutputOption {
 	return func(out *route53.ListHostedZonesByNameOutput) {
-		out.IsTruncated = pointer.BoolPtr(true)
+		out.IsTruncated = true
 		out.NextDNSName = pointer.StringPtr("next-dns-name")
 		out.NextHostedZoneId = pointer.StringPtr("next-hosted-zone-id")
 	}
 }
 
-type hostedZoneOption func(*route53.HostedZone)
+type hostedZoneOption func(*route53types.HostedZone)
 
-func testHostedZone(name string, zoneID string, opts ...hostedZoneOption) *route53.HostedZone {
-	zone := &route53.HostedZone{
+func testHostedZone(name string, zoneID string, opts ...hostedZoneOption) route53types.HostedZone {
+	zone := route53types.HostedZone{
 		Name: &name,
 		Id:   &zoneID,
-		Config: &route53.HostedZoneConfig{
-			PrivateZone: pointer.BoolPtr(false),
+		Config: &route53types.HostedZoneConfig{
+			PrivateZone: false,
 		},
 	}
 	for _, o := range opts {
-		o(zone)
+		o(&zone)
 	}
 	return zone
 }
 
 func private() hostedZoneOption {
-	return func(zone *route53.HostedZone) {
-		zone.Config.PrivateZone = pointer.BoolPtr(true)
+	return func(zone *route53types.HostedZone) {
+		zone.Config.PrivateZone = true
 	}
 }
 
@@ -274,7 +365,7 @@ func testListResourceRecordSetsOutput(opts ...listResourceRecordSetsOutputOption
 	return out
 }
 
-func withRecordSets(recordSets ...*route53.ResourceRecordSet) listResourceRecordSetsOutputOption {
+func withRecordSets(recordSets ...route53types.ResourceRecordSet) listResourceRecordSetsOutputOption {
 	return func(out *route53.ListResourceRecordSetsOutput) {
 		out.ResourceRecordSets = recordSets
 	}
@@ -282,20 +373,20 @@ func withRecordSets(recordSets ...*route53.ResourceRecordSet) listResourceRecord
 
 func rrsTruncated() listResourceRecordSetsOutputOption {
 	return func(out *route53.ListResourceRecordSetsOutput) {
-		out.IsTruncated = pointer.BoolPtr(true)
+		out.IsTruncated = true
 		out.NextRecordName = pointer.StringPtr("next-record-name")
-		out.NextRecordType = pointer.StringPtr("next-record-type")
+		out.NextRecordType = "next-record-type"
 	}
 }
 
-func testRecordSet(name string, recordType string, values ...string) *route53.ResourceRecordSet {
-	recordSet := &route53.ResourceRecordSet{
+func testRecordSet(name string, recordType route53types.RRType, values ...string) route53types.ResourceRecordSet {
+	recordSet := route53types.ResourceRecordSet{
 		Name:            &name,
-		Type:            &recordType,
-		ResourceRecords: make([]*route53.ResourceRecord, len(values)),
+		Type:            recordType,
+		ResourceRecords: make([]route53types.ResourceRecord, len(values)),
 	}
 	for i, value := range values {
-		recordSet.ResourceRecords[i] = &route53.ResourceRecord{
+		recordSet.ResourceRecords[i] = route53types.ResourceRecord{
 			Value: pointer.StringPtr(value),
 		}
 	}
@@ -1,14 +1,15 @@
 package dnszone
 
 import (
-	"fmt"
+	"context"
 	"testing"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/service/kinesis"
-	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
-	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+	tagtypes "github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/aws/smithy-go"
 	"github.com/golang/mock/gomock"
 	"github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/awsclient/mock"
@@ -65,11 +66,11 @@ func TestNewAWSActuator(t *testing.T) {
 
 func mockAWSZoneExists(expect *mock.MockClientMockRecorder, zone *hivev1.DNSZone) {
 
-	if zone.Status.AWS == nil || aws.StringValue(zone.Status.AWS.ZoneID) == "" {
-		expect.GetResourcesPages(gomock.Any(), gomock.Any()).
-			Do(func(input *resourcegroupstaggingapi.GetResourcesInput, f func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) {
+	if zone.Status.AWS == nil || aws.ToString(zone.Status.AWS.ZoneID) == "" {
+		expect.GetResourcesPages(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_ context.Context, input *resourcegroupstaggingapi.GetResourcesInput, f func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) {
 				f(&resourcegroupstaggingapi.GetResourcesOutput{
-					ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					ResourceTagMappingList: []tagtypes.ResourceTagMapping{
 						{
 							ResourceARN: aws.String("arn:aws:route53:::hostedzone/1234"),
 						},
@@ -77,8 +78,8 @@ func mockAWSZoneExists(expect *mock.MockClientMockRecorder, zone *hivev1.DNSZone
 				}, true)
 			}).Return(nil).Times(1)
 	}
-	expect.GetHostedZone(gomock.Any()).Return(&route53.GetHostedZoneOutput{
-		HostedZone: &route53.HostedZone{
+	expect.GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+		HostedZone: &route53types.HostedZone{
 			Id:   aws.String("1234"),
 			Name: aws.String("blah.example.com."),
 		},
@@ -86,23 +87,23 @@ func mockAWSZoneExists(expect *mock.MockClientMockRecorder, zone *hivev1.DNSZone
 }
 
 func mockAWSZoneDoesntExist(expect *mock.MockClientMockRecorder, zone *hivev1.DNSZone) {
-	if zone.Status.AWS != nil && aws.StringValue(zone.Status.AWS.ZoneID) != "" {
-		expect.GetHostedZone(gomock.Any()).
-			Return(nil, awserr.New(route53.ErrCodeNoSuchHostedZone, "doesnt exist", fmt.Errorf("doesnt exist"))).Times(1)
+	if zone.Status.AWS != nil && aws.ToString(zone.Status.AWS.ZoneID) != "" {
+		expect.GetHostedZone(gomock.Any(), gomock.Any()).
+			Return(nil, &route53types.NoSuchHostedZone{Message: aws.String("doesnt exist")}).Times(1)
 		return
 	}
-	expect.GetResourcesPages(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	expect.GetResourcesPages(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
 }
 
 func mockAWSZoneCreationError(expect *mock.MockClientMockRecorder) {
-	expect.CreateHostedZone(gomock.Any()).
-		Return(nil, awserr.New(kinesis.ErrCodeKMSOptInRequired, "error creating hosted zone", fmt.Errorf("error creating hosted zone"))).Times(1)
+	expect.CreateHostedZone(gomock.Any(), gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{Code: "KMSOptInRequired", Message: "error creating hosted zone"}).Times(1)
 	return
 }
 
 func mockCreateAWSZone(expect *mock.MockClientMockRecorder) {
-	expect.CreateHostedZone(gomock.Any()).Return(&route53.CreateHostedZoneOutput{
-		HostedZone: &route53.HostedZone{
+	expect.CreateHostedZone(gomock.Any(), gomock.Any()).Return(&route53.CreateHostedZoneOutput{
+		HostedZone: &route53types.HostedZone{
 			Id:   aws.String("1234"),
 			Name: aws.String("blah.example.com."),
 		},
@@ -110,23 +111,23 @@ func mockCreateAWSZone(expect *mock.MockClientMockRecorder) {
 }
 
 func mockCreateAWSZoneDuplicateFailure(expect *mock.MockClientMockRecorder) {
-	expect.CreateHostedZone(gomock.Any()).Return(nil, awserr.New(route53.ErrCodeHostedZoneAlreadyExists, "already exists", fmt.Errorf("already exists"))).Times(1)
+	expect.CreateHostedZone(gomock.Any(), gomock.Any()).Return(nil, &route53types.HostedZoneAlreadyExists{Message: aws.String("already exists")}).Times(1)
 }
 
 func mockNoExistingAWSTags(expect *mock.MockClientMockRecorder) {
-	expect.ListTagsForResource(gomock.Any()).Return(&route53.ListTagsForResourceOutput{
-		ResourceTagSet: &route53.ResourceTagSet{
+	expect.ListTagsForResource(gomock.Any(), gomock.Any()).Return(&route53.ListTagsForResourceOutput{
+		ResourceTagSet: &route53types.ResourceTagSet{
 			ResourceId: aws.String("1234"),
-			Tags:       []*route53.Tag{},
+			Tags:       []route53types.Tag{},
 		},
 	}, nil).Times(1)
 }
 
 func mockExistingAWSTags(expect *mock.MockClientMockRecorder) {
-	expect.ListTagsForResource(gomock.Any()).Return(&route53.ListTagsForResourceOutput{
-		ResourceTagSet: &route53.ResourceTagSet{
+	expect.ListTagsForResource(gomock.Any(), gomock.Any()).Return(&route53.ListTagsForResourceOutput{
+		ResourceTagSet: &route53types.ResourceTagSet{
 			ResourceId: aws.String("1234"),
-			Tags: []*route53.Tag{
+			Tags: []route53types.Tag{
 				{
 					Key:   aws.String(hiveDNSZoneAWSTag),
 					Value: aws.String("ns/dnszoneobject"),
@@ -141,16 +142,16 @@ func mockExistingAWSTags(expect *mock.MockClientMockRecorder) {
 }
 
 func mockSyncAWSTags(expect *mock.MockClientMockRecorder) {
-	expect.ChangeTagsForResource(gomock.Any()).Return(&route53.ChangeTagsForResourceOutput{}, nil).AnyTimes()
+	expect.ChangeTagsForResource(gomock.Any(), gomock.Any()).Return(&route53.ChangeTagsForResourceOutput{}, nil).AnyTimes()
 }
 
 func mockAWSGetNSRecord(expect *mock.MockClientMockRecorder) {
-	expect.ListResourceRecordSets(gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{
-		ResourceRecordSets: []*route53.ResourceRecordSet{
+	expect.ListResourceRecordSets(gomock.Any(), gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{
+		ResourceRecordSets: []route53types.ResourceRecordSet{
 			{
-				Type: aws.String("NS"),
+				Type: route53types.RRTypeNs,
 				Name: aws.String("blah.example.com."),
-				ResourceRecords: []*route53.ResourceRecord{
+				ResourceRecords: []route53types.ResourceRecord{
 					{
 						Value: aws.String("ns1.example.com"),
 					},
@@ -164,8 +165,8 @@ func mockAWSGetNSRecord(expect *mock.MockClientMockRecorder) {
 }
 
 func mockListAWSZonesByNameFound(expect *mock.MockClientMockRecorder, zone *hivev1.DNSZone) {
-	expect.ListHostedZonesByName(gomock.Any()).Return(&route53.ListHostedZonesByNameOutput{
-		HostedZones: []*route53.HostedZone{
+	expect.ListHostedZonesByName(gomock.Any(), gomock.Any()).Return(&route53.ListHostedZonesByNameOutput{
+		HostedZones: []route53types.HostedZone{
 			{
 				Id:              aws.String("1234"),
 				Name:            aws.String("blah.example.com."),
@@ -176,14 +177,14 @@ func mockListAWSZonesByNameFound(expect *mock.MockClientMockRecorder, zone *hive
 }
 
 func mockDeleteAWSZone(expect *mock.MockClientMockRecorder) {
-	expect.ListResourceRecordSets(gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{}, nil).Times(1)
-	expect.DeleteHostedZone(gomock.Any()).Return(nil, nil).Times(1)
+	expect.ListResourceRecordSets(gomock.Any(), gomock.Any()).Return(&route53.ListResourceRecordSetsOutput{}, nil).Times(1)
+	expect.DeleteHostedZone(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 }
 
 func mockGetResourcePages(expect *mock.MockClientMockRecorder) {
-	expect.GetResourcesPages(gomock.Any(), gomock.Any()).Return(nil).Do(func(i *resourcegroupstaggingapi.GetResourcesInput, f func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) {
+	expect.GetResourcesPages(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Do(func(_ context.Context, i *resourcegroupstaggingapi.GetResourcesInput, f func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) {
 		getResourcesOutput := &resourcegroupstaggingapi.GetResourcesOutput{
-			ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+			ResourceTagMappingList: []tagtypes.ResourceTagMapping{
 				{
 					ResourceARN: aws.String("arn:aws:route53:::hostedzone/Z055920326CHQAW0WSG5N"),
 				},
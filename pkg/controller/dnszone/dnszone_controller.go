@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,6 +41,7 @@ import (
 const (
 	ControllerName                  = hivev1.DNSZoneControllerName
 	zoneResyncDuration              = 2 * time.Hour
+	maxZoneResyncDuration           = 24 * time.Hour
 	domainAvailabilityCheckInterval = 30 * time.Second
 	dnsClientTimeout                = 30 * time.Second
 	resolverConfigFile              = "/etc/resolv.conf"
@@ -93,7 +95,7 @@ func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *Re
 func add(mgr manager.Manager, r *ReconcileDNSZone, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New(ControllerName.String(), mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -164,7 +166,7 @@ func (r *ReconcileDNSZone) Reconcile(ctx context.Context, request reconcile.Requ
 			"RelocationError",
 			controllerutils.ErrorScrub(err),
 			controllerutils.UpdateConditionIfReasonOrMessageChange)
-		if changed {
+		if setReadyCondition(desiredState) || changed {
 			if err := r.Status().Update(context.Background(), desiredState); err != nil {
 				dnsLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update dnszone status")
 			}
@@ -184,7 +186,11 @@ func (r *ReconcileDNSZone) Reconcile(ctx context.Context, request reconcile.Requ
 			"lastSyncedGeneration": desiredState.Status.LastSyncGeneration,
 		}).Debug("Sync not needed")
 
-		return reconcile.Result{}, nil
+		result := reconcile.Result{}
+		if next := desiredState.Status.NextSyncTimestamp; next != nil {
+			result.RequeueAfter = time.Until(next.Time)
+		}
+		return result, nil
 	}
 
 	actuator, actErr := r.getActuator(desiredState, dnsLog)
@@ -229,7 +235,7 @@ func (r *ReconcileDNSZone) Reconcile(ctx context.Context, request reconcile.Requ
 			"ActuatorNotInitialized",
 			"error instantiating actuator: "+controllerutils.ErrorScrub(actErr),
 			controllerutils.UpdateConditionIfReasonOrMessageChange)
-		if changed {
+		if setReadyCondition(desiredState) || changed {
 			if err := r.Status().Update(context.Background(), desiredState); err != nil {
 				dnsLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update dnszone status")
 			}
@@ -246,7 +252,7 @@ func (r *ReconcileDNSZone) Reconcile(ctx context.Context, request reconcile.Requ
 	result, err := r.reconcileDNSProvider(actuator, desiredState)
 	conditionsChanged := actuator.SetConditionsForError(err)
 
-	if conditionsChanged {
+	if setReadyCondition(desiredState) || conditionsChanged {
 		if err := r.Status().Update(context.Background(), desiredState); err != nil {
 			return reconcile.Result{}, err
 		}
@@ -335,13 +341,18 @@ func (r *ReconcileDNSZone) reconcileDNSProvider(actuator Actuator, dnsZone *hive
 		r.logger.WithError(err).Error("error looking up SOA record for zone")
 	}
 
+	if err := r.updateStatus(nameServers, isZoneSOAAvailable, dnsZone); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	reconcileResult := reconcile.Result{}
 	if !isZoneSOAAvailable {
 		r.logger.Info("SOA record for DNS zone not available")
 		reconcileResult.RequeueAfter = domainAvailabilityCheckInterval
+	} else if next := dnsZone.Status.NextSyncTimestamp; next != nil {
+		reconcileResult.RequeueAfter = time.Until(next.Time)
 	}
-
-	return reconcileResult, r.updateStatus(nameServers, isZoneSOAAvailable, dnsZone)
+	return reconcileResult, nil
 }
 
 func (r *ReconcileDNSZone) removeDNSZoneFinalizer(dnsZone *hivev1.DNSZone) error {
@@ -380,6 +391,10 @@ func shouldSync(desiredState *hivev1.DNSZone) (bool, time.Duration) {
 	}
 
 	delta := time.Now().Sub(desiredState.Status.LastSyncTimestamp.Time)
+	if next := desiredState.Status.NextSyncTimestamp; next != nil {
+		// The next sync has been scheduled with backoff.
+		return !time.Now().Before(next.Time), delta
+	}
 	if delta >= zoneResyncDuration {
 		// We haven't sync'd in over zoneResyncDuration time, sync now.
 		return true, delta
@@ -452,7 +467,9 @@ func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable boo
 	if isSOAAvailable {
 		// We need to keep track of the last time we synced to rate limit our dns provider calls.
 		tmpTime := metav1.Now()
+		nextTime := metav1.NewTime(tmpTime.Add(zoneResyncInterval(orig, nameServers)))
 		dnsZone.Status.LastSyncTimestamp = &tmpTime
+		dnsZone.Status.NextSyncTimestamp = &nextTime
 
 		availableStatus = corev1.ConditionTrue
 		availableReason = "ZoneAvailable"
@@ -470,6 +487,7 @@ func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable boo
 		availableReason,
 		availableMessage,
 		controllerutils.UpdateConditionNever)
+	setReadyCondition(dnsZone)
 
 	if !reflect.DeepEqual(orig.Status, dnsZone.Status) {
 		err := r.Client.Status().Update(context.TODO(), dnsZone)
@@ -481,6 +499,69 @@ func (r *ReconcileDNSZone) updateStatus(nameServers []string, isSOAAvailable boo
 	return nil
 }
 
+// setReadyCondition sets the Ready condition of the zone from its ZoneAvailable and error conditions, and records the
+// generation of the zone in its status and conditions. It returns whether the status of the zone changed.
+func setReadyCondition(dnsZone *hivev1.DNSZone) bool {
+	origStatus := dnsZone.Status.DeepCopy()
+	status, reason, message := corev1.ConditionFalse, "ZoneUnavailable", "Waiting for the zone to be available"
+	availableCond := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
+	if availableCond != nil && availableCond.Status == corev1.ConditionTrue {
+		status, reason, message = corev1.ConditionTrue, availableCond.Reason, availableCond.Message
+	}
+	for _, condType := range []hivev1.DNSZoneConditionType{
+		hivev1.InsufficientCredentialsCondition,
+		hivev1.AuthenticationFailureCondition,
+		hivev1.APIOptInRequiredCondition,
+		hivev1.GenericDNSErrorsCondition,
+	} {
+		if cond := controllerutils.FindDNSZoneCondition(dnsZone.Status.Conditions, condType); cond != nil && cond.Status == corev1.ConditionTrue {
+			status, reason, message = corev1.ConditionFalse, string(condType), cond.Message
+			break
+		}
+	}
+	dnsZone.Status.Conditions = controllerutils.InitializeDNSZoneConditions(
+		dnsZone.Status.Conditions,
+		[]hivev1.DNSZoneConditionType{hivev1.ReadyDNSZoneCondition})
+	dnsZone.Status.Conditions = controllerutils.SetDNSZoneCondition(
+		dnsZone.Status.Conditions,
+		hivev1.ReadyDNSZoneCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	dnsZone.Status.ObservedGeneration = dnsZone.Generation
+	for i := range dnsZone.Status.Conditions {
+		dnsZone.Status.Conditions[i].ObservedGeneration = dnsZone.Generation
+	}
+	return !reflect.DeepEqual(origStatus, &dnsZone.Status)
+}
+
+// zoneResyncInterval returns how long to wait after the current sync of the zone before syncing it again. The interval
+// doubles for each sync which finds the zone unchanged, up to maxZoneResyncDuration, so that zones which do not change
+// make fewer calls to the DNS provider.
+func zoneResyncInterval(dnsZone *hivev1.DNSZone, nameServers []string) time.Duration {
+	status := dnsZone.Status
+	availableCondition := controllerutils.FindDNSZoneCondition(status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
+	switch {
+	case status.LastSyncTimestamp == nil, status.NextSyncTimestamp == nil:
+		return zoneResyncDuration
+	case status.LastSyncGeneration != dnsZone.Generation:
+		return zoneResyncDuration
+	case availableCondition == nil || availableCondition.Status != corev1.ConditionTrue:
+		return zoneResyncDuration
+	case !sets.NewString(status.NameServers...).Equal(sets.NewString(nameServers...)):
+		return zoneResyncDuration
+	}
+	interval := 2 * status.NextSyncTimestamp.Sub(status.LastSyncTimestamp.Time)
+	switch {
+	case interval < zoneResyncDuration:
+		return zoneResyncDuration
+	case interval > maxZoneResyncDuration:
+		return maxZoneResyncDuration
+	}
+	return interval
+}
+
 func lookupSOARecord(zone string, logger log.FieldLogger) (bool, error) {
 	// TODO: determine if there's a better way to obtain resolver endpoints
 	clientConfig, _ := dns.ClientConfigFromFile(resolverConfigFile)
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
-	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -13,6 +15,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -169,7 +172,47 @@ func TestReconcileDNSProviderForAWS(t *testing.T) {
 			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
 				condition := controllerutils.FindDNSZoneCondition(zone.Status.Conditions, hivev1.ZoneAvailableDNSZoneCondition)
 				assert.NotNil(t, condition, "zone available condition should be set on dnszone")
+				if assert.NotNil(t, zone.Status.NextSyncTimestamp, "next sync should be scheduled") {
+					assert.Equal(t, zoneResyncDuration, zone.Status.NextSyncTimestamp.Sub(zone.Status.LastSyncTimestamp.Time), "unexpected resync interval")
+				}
+			},
+		},
+		{
+			name:    "Use existing hosted zone",
+			dnsZone: validDNSZoneWithExistingHostedZone(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				// The zone is neither looked up by tag nor tagged.
+				mockAWSZoneExists(expect, validDNSZone())
+				mockAWSGetNSRecord(expect)
+			},
+			validateZone: func(t *testing.T, zone *hivev1.DNSZone) {
+				if assert.NotNil(t, zone.Status.AWS) {
+					assert.Equal(t, "1234", aws.ToString(zone.Status.AWS.ZoneID))
+				}
+				assert.Equal(t, zone.Status.NameServers, []string{"ns1.example.com", "ns2.example.com"}, "nameservers must be set in status")
+			},
+		},
+		{
+			name:    "Existing hosted zone not found",
+			dnsZone: validDNSZoneWithExistingHostedZone(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				// The zone is not created.
+				mockAWSZoneDoesntExist(expect, validDNSZone())
 			},
+			errorExpected: true,
+		},
+		{
+			name: "Delete DNSZone with existing hosted zone",
+			dnsZone: func() *hivev1.DNSZone {
+				zone := validDNSZoneWithExistingHostedZone()
+				zone.DeletionTimestamp = kubeTimeNow
+				return zone
+			}(),
+			setupAWSMock: func(expect *mock.MockClientMockRecorder) {
+				// Neither the records nor the zone are deleted.
+				mockAWSZoneExists(expect, validDNSZone())
+			},
+			expectZoneDeleted: true,
 		},
 	}
 
@@ -545,7 +588,7 @@ func TestReconcileDNSProviderForAWSWithConditions(t *testing.T) {
 				Type:    hivev1.GenericDNSErrorsCondition,
 				Status:  corev1.ConditionTrue,
 				Reason:  dnsCloudErrorReason,
-				Message: "KMSOptInRequired: error creating hosted zone\ncaused by: error creating hosted zone",
+				Message: "api error KMSOptInRequired: error creating hosted zone",
 			},
 		},
 		{
@@ -1095,36 +1138,254 @@ func assertDNSZoneConditions(t *testing.T, dnsZone *hivev1.DNSZone, expectedCond
 }
 
 func testCloudError() error {
-	dnsCloudErr := awserr.New("ErrCodeKMSOptInRequired",
-		"The AWS Access Key Id needs a subscription for the service\n\tstatus code: 403, request id: 0604c1a4-0a68-4d1a-b8e6-cdcf68176d71",
-		fmt.Errorf("some cloud error"))
+	dnsCloudErr := &smithy.GenericAPIError{
+		Code:    "ErrCodeKMSOptInRequired",
+		Message: "The AWS Access Key Id needs a subscription for the service\n\tstatus code: 403, request id: 0604c1a4-0a68-4d1a-b8e6-cdcf68176d71",
+	}
 	return dnsCloudErr
 }
 
 func testAccessDeniedExceptionError() error {
-	accessDeniedErr := awserr.New("AccessDeniedException",
-		"User: arn:aws:iam::0123456789:user/testAdmin is not authorized to perform: tag:GetResources with an explicit deny",
-		fmt.Errorf("User: arn:aws:iam::0123456789:user/testAdmin is not authorized to perform: tag:GetResources with an explicit deny"))
+	accessDeniedErr := &smithy.GenericAPIError{
+		Code:    "AccessDeniedException",
+		Message: "User: arn:aws:iam::0123456789:user/testAdmin is not authorized to perform: tag:GetResources with an explicit deny",
+	}
 	return accessDeniedErr
 }
 
 func testUnrecognizedClientExceptionError() error {
-	unrecognizedClientErr := awserr.New("UnrecognizedClientException",
-		"The security token included in the request is invalid.",
-		fmt.Errorf("The security token included in the request is invalid"))
+	unrecognizedClientErr := &smithy.GenericAPIError{
+		Code:    "UnrecognizedClientException",
+		Message: "The security token included in the request is invalid.",
+	}
 	return unrecognizedClientErr
 }
 
 func testInvalidSignatureExceptionError() error {
-	invalidSignatureErr := awserr.New("InvalidSignatureException",
-		"The request signature we calculated does not match the signature you provided. Check your AWS Secret Access Key and signing method. Consult the service documentation for details.",
-		fmt.Errorf("The request signature we calculated does not match the signature you provided. Check your AWS Secret Access Key and signing method. Consult the service documentation for details"))
+	invalidSignatureErr := &smithy.GenericAPIError{
+		Code:    "InvalidSignatureException",
+		Message: "The request signature we calculated does not match the signature you provided. Check your AWS Secret Access Key and signing method. Consult the service documentation for details.",
+	}
 	return invalidSignatureErr
 }
 
 func testOptInRequiredError() error {
-	optInReqErr := awserr.New("OptInRequired",
-		"The AWS Access Key Id needs a subscription for the service\n\tstatus code: 403, request id: f5afff49-3e3d-46d7-92e5-5f9992757398",
-		fmt.Errorf("The AWS Access Key Id needs a subscription for the service"))
+	optInReqErr := &smithy.GenericAPIError{
+		Code:    "OptInRequired",
+		Message: "The AWS Access Key Id needs a subscription for the service\n\tstatus code: 403, request id: f5afff49-3e3d-46d7-92e5-5f9992757398",
+	}
 	return optInReqErr
 }
+
+func TestShouldSync(t *testing.T) {
+	now := time.Now()
+	syncedZone := func(lastSync time.Duration, nextSync *time.Duration) *hivev1.DNSZone {
+		zone := validDNSZone()
+		zone.Status.LastSyncGeneration = zone.Generation
+		lastSyncTime := metav1.NewTime(now.Add(-lastSync))
+		zone.Status.LastSyncTimestamp = &lastSyncTime
+		if nextSync != nil {
+			nextSyncTime := metav1.NewTime(now.Add(*nextSync))
+			zone.Status.NextSyncTimestamp = &nextSyncTime
+		}
+		return zone
+	}
+	duration := func(d time.Duration) *time.Duration { return &d }
+
+	cases := []struct {
+		name     string
+		dnsZone  *hivev1.DNSZone
+		expected bool
+	}{
+		{
+			name:     "never synced",
+			dnsZone:  validDNSZone(),
+			expected: true,
+		},
+		{
+			name: "spec changed",
+			dnsZone: func() *hivev1.DNSZone {
+				zone := syncedZone(time.Minute, duration(time.Hour))
+				zone.Generation++
+				return zone
+			}(),
+			expected: true,
+		},
+		{
+			name:    "synced recently without next sync",
+			dnsZone: syncedZone(time.Minute, nil),
+		},
+		{
+			name:     "synced long ago without next sync",
+			dnsZone:  syncedZone(3*time.Hour, nil),
+			expected: true,
+		},
+		{
+			name:    "next sync in the future",
+			dnsZone: syncedZone(3*time.Hour, duration(time.Hour)),
+		},
+		{
+			name:     "next sync passed",
+			dnsZone:  syncedZone(time.Minute, duration(-time.Second)),
+			expected: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, _ := shouldSync(tc.dnsZone)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestZoneResyncInterval(t *testing.T) {
+	nameServers := []string{"ns1.example.com", "ns2.example.com"}
+	syncedZone := func(interval time.Duration) *hivev1.DNSZone {
+		zone := validDNSZone()
+		zone.Status.LastSyncGeneration = zone.Generation
+		zone.Status.NameServers = nameServers
+		lastSyncTime := metav1.NewTime(time.Now().Add(-interval))
+		nextSyncTime := metav1.NewTime(lastSyncTime.Add(interval))
+		zone.Status.LastSyncTimestamp = &lastSyncTime
+		zone.Status.NextSyncTimestamp = &nextSyncTime
+		zone.Status.Conditions = controllerutils.SetDNSZoneCondition(
+			zone.Status.Conditions,
+			hivev1.ZoneAvailableDNSZoneCondition,
+			corev1.ConditionTrue,
+			"ZoneAvailable",
+			"DNS SOA record for zone is reachable",
+			controllerutils.UpdateConditionNever)
+		return zone
+	}
+
+	cases := []struct {
+		name        string
+		dnsZone     *hivev1.DNSZone
+		nameServers []string
+		expected    time.Duration
+	}{
+		{
+			name:        "first sync",
+			dnsZone:     validDNSZone(),
+			nameServers: nameServers,
+			expected:    zoneResyncDuration,
+		},
+		{
+			name:        "unchanged",
+			dnsZone:     syncedZone(zoneResyncDuration),
+			nameServers: nameServers,
+			expected:    2 * zoneResyncDuration,
+		},
+		{
+			name:        "unchanged with name servers in another order",
+			dnsZone:     syncedZone(4 * time.Hour),
+			nameServers: []string{"ns2.example.com", "ns1.example.com"},
+			expected:    8 * time.Hour,
+		},
+		{
+			name:        "unchanged at maximum",
+			dnsZone:     syncedZone(16 * time.Hour),
+			nameServers: nameServers,
+			expected:    maxZoneResyncDuration,
+		},
+		{
+			name:        "name servers changed",
+			dnsZone:     syncedZone(16 * time.Hour),
+			nameServers: []string{"ns3.example.com"},
+			expected:    zoneResyncDuration,
+		},
+		{
+			name: "spec changed",
+			dnsZone: func() *hivev1.DNSZone {
+				zone := syncedZone(16 * time.Hour)
+				zone.Generation++
+				return zone
+			}(),
+			nameServers: nameServers,
+			expected:    zoneResyncDuration,
+		},
+		{
+			name: "zone was unavailable",
+			dnsZone: func() *hivev1.DNSZone {
+				zone := syncedZone(16 * time.Hour)
+				zone.Status.Conditions = nil
+				return zone
+			}(),
+			nameServers: nameServers,
+			expected:    zoneResyncDuration,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, int64(tc.expected), int64(zoneResyncInterval(tc.dnsZone, tc.nameServers)))
+		})
+	}
+}
+
+func TestSetReadyCondition(t *testing.T) {
+	withCondition := func(zone *hivev1.DNSZone, condType hivev1.DNSZoneConditionType, status corev1.ConditionStatus, reason, message string) *hivev1.DNSZone {
+		zone.Status.Conditions = controllerutils.SetDNSZoneCondition(
+			zone.Status.Conditions,
+			condType,
+			status,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange)
+		return zone
+	}
+
+	cases := []struct {
+		name           string
+		dnsZone        *hivev1.DNSZone
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "new zone",
+			dnsZone:        validDNSZone(),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: "ZoneUnavailable",
+		},
+		{
+			name: "zone available",
+			dnsZone: withCondition(validDNSZone(), hivev1.ZoneAvailableDNSZoneCondition,
+				corev1.ConditionTrue, "ZoneAvailable", "DNS SOA record for zone is reachable"),
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: "ZoneAvailable",
+		},
+		{
+			name: "zone available with DNS error",
+			dnsZone: withCondition(
+				withCondition(validDNSZone(), hivev1.ZoneAvailableDNSZoneCondition,
+					corev1.ConditionTrue, "ZoneAvailable", "DNS SOA record for zone is reachable"),
+				hivev1.GenericDNSErrorsCondition, corev1.ConditionTrue, dnsCloudErrorReason, "error creating hosted zone"),
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: string(hivev1.GenericDNSErrorsCondition),
+		},
+		{
+			name: "zone available with cleared DNS error",
+			dnsZone: withCondition(
+				withCondition(validDNSZone(), hivev1.ZoneAvailableDNSZoneCondition,
+					corev1.ConditionTrue, "ZoneAvailable", "DNS SOA record for zone is reachable"),
+				hivev1.GenericDNSErrorsCondition, corev1.ConditionFalse, dnsNoErrorReason, "No errors occurred"),
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: "ZoneAvailable",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.dnsZone.Generation = 2
+			assert.True(t, setReadyCondition(tc.dnsZone), "expected status to change")
+			cond := controllerutils.FindDNSZoneCondition(tc.dnsZone.Status.Conditions, hivev1.ReadyDNSZoneCondition)
+			if assert.NotNil(t, cond, "expected Ready condition") {
+				assert.Equal(t, tc.expectedStatus, cond.Status, "unexpected Ready condition status")
+				assert.Equal(t, tc.expectedReason, cond.Reason, "unexpected Ready condition reason")
+			}
+			assert.Equal(t, int64(2), tc.dnsZone.Status.ObservedGeneration, "unexpected observed generation")
+			for _, cond := range tc.dnsZone.Status.Conditions {
+				assert.Equal(t, int64(2), cond.ObservedGeneration, "unexpected observed generation of %s condition", cond.Type)
+			}
+			assert.False(t, setReadyCondition(tc.dnsZone), "expected status to be unchanged")
+		})
+	}
+}
@@ -16,7 +16,7 @@ import (
 	azureclient "github.com/openshift/hive/pkg/azureclient"
 	gcpclient "github.com/openshift/hive/pkg/gcpclient"
 
-	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/golang/mock/gomock"
 	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
 	mockazure "github.com/openshift/hive/pkg/azureclient/mock"
@@ -150,6 +150,12 @@ var (
 		return zone
 	}
 
+	validDNSZoneWithExistingHostedZone = func() *hivev1.DNSZone {
+		zone := validDNSZoneWithoutID()
+		zone.Spec.AWS.ZoneID = "1234"
+		return zone
+	}
+
 	validDNSZoneBeingDeleted = func() *hivev1.DNSZone {
 		// Take a copy of the default validDNSZone object
 		zone := validDNSZone()
@@ -0,0 +1,318 @@
+// Package garbagecollection provides a periodic garbage collector which deletes the artifacts that Hive leaves behind
+// when their owners are gone and which are not cleaned up by the Kubernetes garbage collector.
+package garbagecollection
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+)
+
+const (
+	ControllerName = hivev1.GarbageCollectionControllerName
+
+	// defaultInterval is the length of time between garbage collections when HiveConfig does not specify one.
+	defaultInterval = time.Hour
+
+	// minOrphanAge is the minimum age of an object before it is collected, so that objects are not collected while
+	// their owners are still being created.
+	minOrphanAge = time.Hour
+
+	// jobNameLabel is the label set by the job controller on the pods of a job.
+	jobNameLabel = "job-name"
+)
+
+var (
+	metricOrphanedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_garbage_collection_orphaned_objects",
+		Help: "Number of orphaned objects found by the last garbage collection, labeled by kind.",
+	},
+		[]string{"kind"},
+	)
+	metricDeletedObjectsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_garbage_collection_deleted_objects_total",
+		Help: "Counter incremented each time the garbage collector deletes an orphaned object, labeled by kind.",
+	},
+		[]string{"kind"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricOrphanedObjects)
+	metrics.Registry.MustRegister(metricDeletedObjectsTotal)
+}
+
+// Add creates a new GarbageCollector and adds it to the Manager if garbage collection is enabled in HiveConfig.
+func Add(mgr manager.Manager) error {
+	if !strings.EqualFold(os.Getenv(constants.GarbageCollectionEnvVar), "true") {
+		log.WithField("controller", ControllerName).Debug("garbage collection is disabled")
+		return nil
+	}
+	gc := &GarbageCollector{
+		Client:   mgr.GetClient(),
+		Reader:   mgr.GetAPIReader(),
+		Interval: defaultInterval,
+		DryRun:   strings.EqualFold(os.Getenv(constants.GarbageCollectionDryRunEnvVar), "true"),
+	}
+	if interval := os.Getenv(constants.GarbageCollectionIntervalEnvVar); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return errors.Wrapf(err, "could not parse %s", constants.GarbageCollectionIntervalEnvVar)
+		}
+		gc.Interval = d
+	}
+	return mgr.Add(gc)
+}
+
+// GarbageCollector runs in a goroutine and periodically deletes the install and uninstall pods, provision secrets,
+// MachinePoolNameLeases and child DNSZones whose owners no longer exist. Like the metrics Calculator, it is not a
+// standard controller watching Kube resources; it runs periodically and then goes to sleep.
+type GarbageCollector struct {
+	// Client deletes the orphaned objects.
+	Client client.Client
+
+	// Reader lists and gets objects from the API server, so that the manager does not cache all pods and secrets.
+	Reader client.Reader
+
+	// Interval is the length of time we sleep between garbage collections.
+	Interval time.Duration
+
+	// DryRun is true when orphaned objects are only logged and counted, and not deleted.
+	DryRun bool
+
+	// now returns the current time. It is overridden in tests.
+	now func() time.Time
+}
+
+// orphanFinder returns the orphaned objects of a kind.
+type orphanFinder func(ctx context.Context) ([]client.Object, error)
+
+// Start begins the garbage collection loop.
+func (gc *GarbageCollector) Start(ctx context.Context) error {
+	log.WithField("controller", ControllerName).Info("started garbage collector goroutine")
+	wait.UntilWithContext(ctx, gc.collect, gc.Interval)
+	return nil
+}
+
+// collect finds and deletes the orphaned objects of every kind.
+func (gc *GarbageCollector) collect(ctx context.Context) {
+	gcLog := log.WithFields(log.Fields{"controller": ControllerName, "dryRun": gc.DryRun})
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, gcLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	gcLog.Info("collecting orphaned objects")
+	for _, kind := range []struct {
+		name string
+		find orphanFinder
+	}{
+		{name: "Pod", find: gc.orphanedJobPods},
+		{name: "Secret", find: gc.orphanedProvisionSecrets},
+		{name: "MachinePoolNameLease", find: gc.orphanedMachinePoolNameLeases},
+		{name: "DNSZone", find: gc.orphanedDNSZones},
+	} {
+		kindLog := gcLog.WithField("kind", kind.name)
+		orphans, err := kind.find(ctx)
+		if err != nil {
+			kindLog.WithError(err).Error("error finding orphaned objects")
+			continue
+		}
+		metricOrphanedObjects.WithLabelValues(kind.name).Set(float64(len(orphans)))
+		for _, obj := range orphans {
+			objLog := kindLog.WithFields(log.Fields{"namespace": obj.GetNamespace(), "name": obj.GetName()})
+			if gc.DryRun {
+				objLog.Info("found orphaned object, skipping deletion in dry-run mode")
+				continue
+			}
+			if err := gc.Client.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+				objLog.WithError(err).Error("error deleting orphaned object")
+				continue
+			}
+			metricDeletedObjectsTotal.WithLabelValues(kind.name).Inc()
+			objLog.Info("deleted orphaned object")
+		}
+	}
+}
+
+// orphanedJobPods returns the install and uninstall pods whose job no longer exists. Such pods are left behind when a
+// job is deleted with orphan propagation.
+func (gc *GarbageCollector) orphanedJobPods(ctx context.Context) ([]client.Object, error) {
+	var orphans []client.Object
+	for _, label := range []string{constants.InstallJobLabel, constants.UninstallJobLabel} {
+		pods := &corev1.PodList{}
+		if err := gc.Reader.List(ctx, pods, client.MatchingLabels{label: "true"}); err != nil {
+			return nil, errors.Wrap(err, "could not list pods")
+		}
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if !gc.collectable(pod) {
+				continue
+			}
+			jobName := pod.Labels[jobNameLabel]
+			if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "Job" {
+				jobName = owner.Name
+			}
+			if jobName == "" {
+				continue
+			}
+			exists, err := gc.exists(ctx, pod.Namespace, jobName, &batchv1.Job{})
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				orphans = append(orphans, pod)
+			}
+		}
+	}
+	return orphans, nil
+}
+
+// orphanedProvisionSecrets returns the secrets created for a ClusterProvision which no longer exists, and which are
+// not referenced by the ClusterDeployment as its admin kubeconfig or admin password.
+func (gc *GarbageCollector) orphanedProvisionSecrets(ctx context.Context) ([]client.Object, error) {
+	secrets := &corev1.SecretList{}
+	if err := gc.Reader.List(ctx, secrets, client.HasLabels{constants.ClusterProvisionNameLabel}); err != nil {
+		return nil, errors.Wrap(err, "could not list secrets")
+	}
+	// referenced holds the names of the secrets referenced by ClusterDeployments, by namespace.
+	referenced := map[string]map[string]bool{}
+	var orphans []client.Object
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if !gc.collectable(secret) {
+			continue
+		}
+		exists, err := gc.exists(ctx, secret.Namespace, secret.Labels[constants.ClusterProvisionNameLabel], &hivev1.ClusterProvision{})
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			continue
+		}
+		if _, ok := referenced[secret.Namespace]; !ok {
+			if referenced[secret.Namespace], err = gc.referencedSecrets(ctx, secret.Namespace); err != nil {
+				return nil, err
+			}
+		}
+		if !referenced[secret.Namespace][secret.Name] {
+			orphans = append(orphans, secret)
+		}
+	}
+	return orphans, nil
+}
+
+// referencedSecrets returns the names of the admin kubeconfig and admin password secrets of the ClusterDeployments in
+// the namespace.
+func (gc *GarbageCollector) referencedSecrets(ctx context.Context, namespace string) (map[string]bool, error) {
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := gc.Reader.List(ctx, cds, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrap(err, "could not list cluster deployments")
+	}
+	names := map[string]bool{}
+	for _, cd := range cds.Items {
+		if cd.Spec.ClusterMetadata == nil {
+			continue
+		}
+		names[cd.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name] = true
+		if ref := cd.Spec.ClusterMetadata.AdminPasswordSecretRef; ref != nil {
+			names[ref.Name] = true
+		}
+	}
+	return names, nil
+}
+
+// orphanedMachinePoolNameLeases returns the MachinePoolNameLeases whose MachinePool no longer exists.
+func (gc *GarbageCollector) orphanedMachinePoolNameLeases(ctx context.Context) ([]client.Object, error) {
+	leases := &hivev1.MachinePoolNameLeaseList{}
+	if err := gc.Reader.List(ctx, leases); err != nil {
+		return nil, errors.Wrap(err, "could not list machine pool name leases")
+	}
+	var orphans []client.Object
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		if !gc.collectable(lease) {
+			continue
+		}
+		poolName := lease.Labels[constants.MachinePoolNameLabel]
+		if poolName == "" {
+			continue
+		}
+		exists, err := gc.exists(ctx, lease.Namespace, poolName, &hivev1.MachinePool{})
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			orphans = append(orphans, lease)
+		}
+	}
+	return orphans, nil
+}
+
+// orphanedDNSZones returns the child DNSZones whose ClusterDeployment no longer exists.
+func (gc *GarbageCollector) orphanedDNSZones(ctx context.Context) ([]client.Object, error) {
+	zones := &hivev1.DNSZoneList{}
+	if err := gc.Reader.List(ctx, zones, client.MatchingLabels{constants.DNSZoneTypeLabel: constants.DNSZoneTypeChild}); err != nil {
+		return nil, errors.Wrap(err, "could not list dns zones")
+	}
+	var orphans []client.Object
+	for i := range zones.Items {
+		zone := &zones.Items[i]
+		if !gc.collectable(zone) {
+			continue
+		}
+		cdName := zone.Labels[constants.ClusterDeploymentNameLabel]
+		if cdName == "" {
+			continue
+		}
+		exists, err := gc.exists(ctx, zone.Namespace, cdName, &hivev1.ClusterDeployment{})
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			orphans = append(orphans, zone)
+		}
+	}
+	return orphans, nil
+}
+
+// collectable returns true when the object is old enough to be collected and is not already being deleted.
+func (gc *GarbageCollector) collectable(obj client.Object) bool {
+	if obj.GetDeletionTimestamp() != nil {
+		return false
+	}
+	now := time.Now
+	if gc.now != nil {
+		now = gc.now
+	}
+	return obj.GetCreationTimestamp().Add(minOrphanAge).Before(now())
+}
+
+// exists returns true when the object with the name exists in the namespace.
+func (gc *GarbageCollector) exists(ctx context.Context, namespace, name string, obj client.Object) (bool, error) {
+	switch err := gc.Reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj); {
+	case apierrors.IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, errors.Wrapf(err, "could not get %s/%s", namespace, name)
+	}
+	return true, nil
+}
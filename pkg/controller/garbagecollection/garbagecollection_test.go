@@ -0,0 +1,182 @@
+package garbagecollection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	namespace = "test-namespace"
+)
+
+var (
+	now = time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	old = metav1.NewTime(now.Add(-2 * minOrphanAge))
+)
+
+func objectMeta(name string, labels map[string]string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace:         namespace,
+		Name:              name,
+		Labels:            labels,
+		CreationTimestamp: old,
+	}
+}
+
+func TestCollect(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	batchv1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	existing := []runtime.Object{
+		&batchv1.Job{ObjectMeta: objectMeta("live-job", nil)},
+		&hivev1.ClusterProvision{ObjectMeta: objectMeta("live-provision", nil)},
+		&hivev1.MachinePool{ObjectMeta: objectMeta("live-pool", nil)},
+		&hivev1.ClusterDeployment{
+			ObjectMeta: objectMeta("live-cd", nil),
+			Spec: hivev1.ClusterDeploymentSpec{
+				ClusterMetadata: &hivev1.ClusterMetadata{
+					AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "referenced-kubeconfig"},
+					AdminPasswordSecretRef:   &corev1.LocalObjectReference{Name: "referenced-password"},
+				},
+			},
+		},
+	}
+	installPod := func(name, job string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: objectMeta(name, map[string]string{constants.InstallJobLabel: "true", jobNameLabel: job})}
+	}
+	uninstallPod := func(name, job string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: objectMeta(name, map[string]string{constants.UninstallJobLabel: "true", jobNameLabel: job})}
+	}
+	provisionSecret := func(name, provision string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: objectMeta(name, map[string]string{constants.ClusterProvisionNameLabel: provision})}
+	}
+	lease := func(name, pool string) *hivev1.MachinePoolNameLease {
+		return &hivev1.MachinePoolNameLease{ObjectMeta: objectMeta(name, map[string]string{constants.MachinePoolNameLabel: pool})}
+	}
+	childZone := func(name, cd string) *hivev1.DNSZone {
+		return &hivev1.DNSZone{ObjectMeta: objectMeta(name, map[string]string{
+			constants.DNSZoneTypeLabel:           constants.DNSZoneTypeChild,
+			constants.ClusterDeploymentNameLabel: cd,
+		})}
+	}
+	young := func(obj client.Object) client.Object {
+		obj.SetCreationTimestamp(metav1.NewTime(now.Add(-time.Minute)))
+		return obj
+	}
+
+	tests := []struct {
+		name         string
+		objects      []client.Object
+		dryRun       bool
+		expectDelete bool
+	}{
+		{
+			name:         "install pod of deleted job",
+			objects:      []client.Object{installPod("orphan", "deleted-job")},
+			expectDelete: true,
+		},
+		{
+			name:         "uninstall pod of deleted job",
+			objects:      []client.Object{uninstallPod("orphan", "deleted-job")},
+			expectDelete: true,
+		},
+		{
+			name:    "install pod of existing job",
+			objects: []client.Object{installPod("owned", "live-job")},
+		},
+		{
+			name:    "young install pod of deleted job",
+			objects: []client.Object{young(installPod("young", "deleted-job"))},
+		},
+		{
+			name:    "unlabeled pod",
+			objects: []client.Object{&corev1.Pod{ObjectMeta: objectMeta("unlabeled", nil)}},
+		},
+		{
+			name:         "secret of deleted provision",
+			objects:      []client.Object{provisionSecret("orphan", "deleted-provision")},
+			expectDelete: true,
+		},
+		{
+			name:    "secret of existing provision",
+			objects: []client.Object{provisionSecret("owned", "live-provision")},
+		},
+		{
+			name:    "admin kubeconfig of deleted provision",
+			objects: []client.Object{provisionSecret("referenced-kubeconfig", "deleted-provision")},
+		},
+		{
+			name:    "admin password of deleted provision",
+			objects: []client.Object{provisionSecret("referenced-password", "deleted-provision")},
+		},
+		{
+			name:         "lease of deleted machine pool",
+			objects:      []client.Object{lease("orphan", "deleted-pool")},
+			expectDelete: true,
+		},
+		{
+			name:    "lease of existing machine pool",
+			objects: []client.Object{lease("owned", "live-pool")},
+		},
+		{
+			name:         "child dns zone of deleted cluster deployment",
+			objects:      []client.Object{childZone("orphan", "deleted-cd")},
+			expectDelete: true,
+		},
+		{
+			name:    "child dns zone of existing cluster deployment",
+			objects: []client.Object{childZone("owned", "live-cd")},
+		},
+		{
+			name:    "dry run",
+			objects: []client.Object{installPod("orphan", "deleted-job"), provisionSecret("orphan", "deleted-provision"), lease("orphan", "deleted-pool"), childZone("orphan", "deleted-cd")},
+			dryRun:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objects := append([]runtime.Object{}, existing...)
+			for _, obj := range test.objects {
+				objects = append(objects, obj)
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objects...).Build()
+			gc := &GarbageCollector{
+				Client: c,
+				Reader: c,
+				DryRun: test.dryRun,
+				now:    func() time.Time { return now },
+			}
+
+			gc.collect(context.TODO())
+
+			for _, obj := range test.objects {
+				err := c.Get(context.TODO(), client.ObjectKeyFromObject(obj), obj)
+				if test.expectDelete {
+					assert.True(t, apierrors.IsNotFound(err), "expected %s to be deleted", obj.GetName())
+				} else {
+					assert.NoError(t, err, "expected %s to be kept", obj.GetName())
+				}
+			}
+			for _, obj := range existing {
+				require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(obj.(client.Object)), obj.(client.Object)), "expected owner to be kept")
+			}
+		})
+	}
+}
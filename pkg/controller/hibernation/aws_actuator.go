@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
@@ -80,8 +81,8 @@ func (a *awsActuator) stopOnDemandInstances(awsClient awsclient.Client, instance
 	}
 
 	logger.WithField("instanceIDs", instanceIDs).Info("Stopping on-demand cluster instances")
-	_, err := awsClient.StopInstances(&ec2.StopInstancesInput{
-		InstanceIds: aws.StringSlice(instanceIDs),
+	_, err := awsClient.StopInstances(context.TODO(), &ec2.StopInstancesInput{
+		InstanceIds: instanceIDs,
 	})
 	if err != nil {
 		logger.WithError(err).Error("failed to stop on-demand instances")
@@ -95,8 +96,8 @@ func (a *awsActuator) stopSpotInstances(awsClient awsclient.Client, instanceIDs
 		return nil
 	}
 	logger.WithField("instanceIDs", instanceIDs).Info("Terminating spot cluster instances")
-	_, err := awsClient.TerminateInstances(&ec2.TerminateInstancesInput{
-		InstanceIds: aws.StringSlice(instanceIDs),
+	_, err := awsClient.TerminateInstances(context.TODO(), &ec2.TerminateInstancesInput{
+		InstanceIds: instanceIDs,
 	})
 	if err != nil {
 		logger.WithError(err).Error("failed to terminate spot instances")
@@ -124,8 +125,8 @@ func (a *awsActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient cli
 
 	ids := instanceIDs(instances)
 	logger.WithField("instanceIDs", ids).Info("Starting on-demand cluster instances")
-	_, err = awsClient.StartInstances(&ec2.StartInstancesInput{
-		InstanceIds: aws.StringSlice(ids),
+	_, err = awsClient.StartInstances(context.TODO(), &ec2.StartInstancesInput{
+		InstanceIds: ids,
 	})
 	if err != nil {
 		logger.WithError(err).Error("failed to start on-demand instances")
@@ -207,6 +208,18 @@ func (a *awsActuator) ReplaceMachines(cd *hivev1.ClusterDeployment, remoteClient
 		toBeReplaced = append(toBeReplaced, m)
 	}
 
+	if len(toBeReplaced) == 0 {
+		return false, nil
+	}
+	switch fence, err := controllerutils.GetRemoteRelocationFence(remoteClient); {
+	case err != nil:
+		logger.WithError(err).Error("Failed to get relocation fence")
+		return false, errors.Wrap(err, "failed to get relocation fence")
+	case controllerutils.IsFencedOut(cd, fence, logger):
+		// The machines are left to the hub which owns the cluster.
+		return false, nil
+	}
+
 	logger.WithField("machines", machineNames(toBeReplaced)).Debug("Preemptible Machine objects will be replaced")
 	var replaced bool
 	var errs []error
@@ -274,11 +287,11 @@ func getAWSClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.Fiel
 
 // filterOutSpotInstances removes the spot instances from the list and returns it. It
 // also returned the spot instances that were filtered out in a separate list.
-func filterOutSpotInstances(instances []*ec2.Instance) ([]*ec2.Instance, []*ec2.Instance) {
-	var spotInstances []*ec2.Instance
+func filterOutSpotInstances(instances []ec2types.Instance) ([]ec2types.Instance, []ec2types.Instance) {
+	var spotInstances []ec2types.Instance
 	n := 0
 	for _, i := range instances {
-		if aws.StringValue(i.InstanceLifecycle) == "spot" {
+		if i.InstanceLifecycle == ec2types.InstanceLifecycleTypeSpot {
 			spotInstances = append(spotInstances, i)
 			continue
 		}
@@ -289,23 +302,23 @@ func filterOutSpotInstances(instances []*ec2.Instance) ([]*ec2.Instance, []*ec2.
 	return instances, spotInstances
 }
 
-func instanceIDs(instances []*ec2.Instance) []string {
+func instanceIDs(instances []ec2types.Instance) []string {
 	result := make([]string, len(instances))
 	for idx, i := range instances {
-		result[idx] = aws.StringValue(i.InstanceId)
+		result[idx] = aws.ToString(i.InstanceId)
 	}
 	return result
 }
 
-func getClusterInstances(cd *hivev1.ClusterDeployment, c awsclient.Client, states sets.String, logger log.FieldLogger) ([]*ec2.Instance, error) {
+func getClusterInstances(cd *hivev1.ClusterDeployment, c awsclient.Client, states sets.String, logger log.FieldLogger) ([]ec2types.Instance, error) {
 	infraID := cd.Spec.ClusterMetadata.InfraID
 	logger = logger.WithField("infraID", infraID)
 	logger.Debug("listing cluster instances")
-	out, err := c.DescribeInstances(&ec2.DescribeInstancesInput{
-		Filters: []*ec2.Filter{
+	out, err := c.DescribeInstances(context.TODO(), &ec2.DescribeInstancesInput{
+		Filters: []ec2types.Filter{
 			{
 				Name:   aws.String(fmt.Sprintf("tag:kubernetes.io/cluster/%s", infraID)),
-				Values: []*string{aws.String("owned")},
+				Values: []string{"owned"},
 			},
 		},
 	})
@@ -313,10 +326,10 @@ func getClusterInstances(cd *hivev1.ClusterDeployment, c awsclient.Client, state
 		logger.WithError(err).Error("failed to list instances")
 		return nil, err
 	}
-	var result []*ec2.Instance
+	var result []ec2types.Instance
 	for _, r := range out.Reservations {
 		for idx, i := range r.Instances {
-			if states.Has(aws.StringValue(i.State.Name)) {
+			if states.Has(string(i.State.Name)) {
 				result = append(result, r.Instances[idx])
 			}
 		}
@@ -6,8 +6,9 @@ import (
 	"testing"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -25,6 +26,8 @@ import (
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/awsclient"
 	mockawsclient "github.com/openshift/hive/pkg/awsclient/mock"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
 )
 
@@ -56,8 +59,8 @@ func TestStopAndStartMachines(t *testing.T) {
 			testFunc:  "StopMachines",
 			instances: map[string]int{"terminated": 2, "running": 2},
 			setupClient: func(t *testing.T, c *mockawsclient.MockClient) {
-				c.EXPECT().StopInstances(gomock.Any()).Do(
-					func(input *ec2.StopInstancesInput) {
+				c.EXPECT().StopInstances(gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, input *ec2.StopInstancesInput) {
 						matchInstanceIDs(t, input.InstanceIds, map[string]int{"running": 2})
 					}).Return(nil, nil)
 			},
@@ -67,8 +70,8 @@ func TestStopAndStartMachines(t *testing.T) {
 			testFunc:  "StopMachines",
 			instances: map[string]int{"terminated": 5, "shutting-down": 3, "stopped": 4, "pending": 1, "running": 3},
 			setupClient: func(t *testing.T, c *mockawsclient.MockClient) {
-				c.EXPECT().StopInstances(gomock.Any()).Do(
-					func(input *ec2.StopInstancesInput) {
+				c.EXPECT().StopInstances(gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, input *ec2.StopInstancesInput) {
 						matchInstanceIDs(t, input.InstanceIds, map[string]int{"pending": 1, "running": 3})
 					}).Return(nil, nil)
 			},
@@ -83,8 +86,8 @@ func TestStopAndStartMachines(t *testing.T) {
 			testFunc:  "StartMachines",
 			instances: map[string]int{"stopped": 3, "terminated": 2, "running": 3},
 			setupClient: func(t *testing.T, c *mockawsclient.MockClient) {
-				c.EXPECT().StartInstances(gomock.Any()).Do(
-					func(input *ec2.StartInstancesInput) {
+				c.EXPECT().StartInstances(gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, input *ec2.StartInstancesInput) {
 						matchInstanceIDs(t, input.InstanceIds, map[string]int{"stopped": 3})
 					}).Return(nil, nil)
 			},
@@ -94,8 +97,8 @@ func TestStopAndStartMachines(t *testing.T) {
 			testFunc:  "StartMachines",
 			instances: map[string]int{"stopped": 3, "stopping": 1, "terminated": 3},
 			setupClient: func(t *testing.T, c *mockawsclient.MockClient) {
-				c.EXPECT().StartInstances(gomock.Any()).Do(
-					func(input *ec2.StartInstancesInput) {
+				c.EXPECT().StartInstances(gomock.Any(), gomock.Any()).Do(
+					func(_ context.Context, input *ec2.StartInstancesInput) {
 						matchInstanceIDs(t, input.InstanceIds, map[string]int{"stopped": 3, "stopping": 1})
 					}).Return(nil, nil)
 			},
@@ -137,18 +140,18 @@ func TestStopPreemptibleMachines(t *testing.T) {
 		name:     "only on-demand instances",
 		onDemand: 1,
 		setupClient: func(t *testing.T, c *mockawsclient.MockClient) {
-			c.EXPECT().StopInstances(gomock.Any()).Do(
-				func(input *ec2.StopInstancesInput) {
-					input.InstanceIds = aws.StringSlice([]string{"onDemand-0"})
+			c.EXPECT().StopInstances(gomock.Any(), gomock.Any()).Do(
+				func(_ context.Context, input *ec2.StopInstancesInput) {
+					input.InstanceIds = []string{"onDemand-0"}
 				}).Return(nil, nil)
 		},
 	}, {
 		name: "only spot instances",
 		spot: 1,
 		setupClient: func(t *testing.T, c *mockawsclient.MockClient) {
-			c.EXPECT().TerminateInstances(gomock.Any()).Do(
-				func(input *ec2.TerminateInstancesInput) {
-					input.InstanceIds = aws.StringSlice([]string{"spot-0"})
+			c.EXPECT().TerminateInstances(gomock.Any(), gomock.Any()).Do(
+				func(_ context.Context, input *ec2.TerminateInstancesInput) {
+					input.InstanceIds = []string{"spot-0"}
 				}).Return(nil, nil)
 		},
 	}, {
@@ -156,13 +159,13 @@ func TestStopPreemptibleMachines(t *testing.T) {
 		onDemand: 1,
 		spot:     2,
 		setupClient: func(t *testing.T, c *mockawsclient.MockClient) {
-			c.EXPECT().StopInstances(gomock.Any()).Do(
-				func(input *ec2.StopInstancesInput) {
-					input.InstanceIds = aws.StringSlice([]string{"onDemand-0"})
+			c.EXPECT().StopInstances(gomock.Any(), gomock.Any()).Do(
+				func(_ context.Context, input *ec2.StopInstancesInput) {
+					input.InstanceIds = []string{"onDemand-0"}
 				}).Return(nil, nil)
-			c.EXPECT().TerminateInstances(gomock.Any()).Do(
-				func(input *ec2.TerminateInstancesInput) {
-					input.InstanceIds = aws.StringSlice([]string{"spot-0", "spot-1"})
+			c.EXPECT().TerminateInstances(gomock.Any(), gomock.Any()).Do(
+				func(_ context.Context, input *ec2.TerminateInstancesInput) {
+					input.InstanceIds = []string{"spot-0", "spot-1"}
 				}).Return(nil, nil)
 		},
 	}}
@@ -258,6 +261,7 @@ func TestReplacePreemptibleMachines(t *testing.T) {
 	logger.SetLevel(log.DebugLevel)
 
 	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
 	hivev1.AddToScheme(scheme)
 	machineapi.AddToScheme(scheme)
 
@@ -317,6 +321,19 @@ func TestReplacePreemptibleMachines(t *testing.T) {
 		},
 		expectedReplaced: true,
 		expectedMachines: []string{"spot-3"},
+	}, {
+		name: "failed spot instances of cluster fenced to another hub",
+		existingMachines: []runtime.Object{
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        controllerutils.RelocationFenceNamespace,
+					Annotations: map[string]string{constants.RelocationFencingTokenAnnotation: "other-hub"},
+				},
+			},
+			testMachine("spot-1", true, "Running", time.Now().Add(-1*time.Hour)),
+			testMachine("spot-2", true, "Failed", time.Now()),
+		},
+		expectedMachines: []string{"spot-1", "spot-2"},
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -368,17 +385,14 @@ func testMachine(name string, interruptible bool, phase string, lastUpdated time
 	return &ms
 }
 
-func matchInstanceIDs(t *testing.T, actual []*string, states map[string]int) {
+func matchInstanceIDs(t *testing.T, actual []string, states map[string]int) {
 	expected := sets.NewString()
 	for state, count := range states {
 		for i := 0; i < count; i++ {
 			expected.Insert(fmt.Sprintf("%s-%d", state, i))
 		}
 	}
-	actualSet := sets.NewString()
-	for _, a := range actual {
-		actualSet.Insert(aws.StringValue(a))
-	}
+	actualSet := sets.NewString(actual...)
 	assert.True(t, expected.Equal(actualSet), "Unexpected set of instance IDs: %v", actualSet.List())
 }
 
@@ -399,20 +413,20 @@ func testClusterDeployment() *hivev1.ClusterDeployment {
 }
 
 func setupClientInstances(awsClient *mockawsclient.MockClient, states map[string]int) {
-	instances := []*ec2.Instance{}
+	instances := []ec2types.Instance{}
 	for state, count := range states {
 		for i := 0; i < count; i++ {
-			instances = append(instances, &ec2.Instance{
+			instances = append(instances, ec2types.Instance{
 				InstanceId: aws.String(fmt.Sprintf("%s-%d", state, i)),
-				State: &ec2.InstanceState{
-					Name: aws.String(state),
+				State: &ec2types.InstanceState{
+					Name: ec2types.InstanceStateName(state),
 				},
 			})
 		}
 	}
-	reservation := &ec2.Reservation{Instances: instances}
-	reservations := []*ec2.Reservation{reservation}
-	awsClient.EXPECT().DescribeInstances(gomock.Any()).Times(1).Return(
+	reservation := ec2types.Reservation{Instances: instances}
+	reservations := []ec2types.Reservation{reservation}
+	awsClient.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).Times(1).Return(
 		&ec2.DescribeInstancesOutput{
 			Reservations: reservations,
 		},
@@ -420,28 +434,28 @@ func setupClientInstances(awsClient *mockawsclient.MockClient, states map[string
 }
 
 func setupOnDemandAndSpotInstances(awsClient *mockawsclient.MockClient, onDemand, spot int) {
-	instances := []*ec2.Instance{}
+	instances := []ec2types.Instance{}
 	for i := 0; i < onDemand; i++ {
-		instances = append(instances, &ec2.Instance{
+		instances = append(instances, ec2types.Instance{
 			InstanceId: aws.String(fmt.Sprintf("%s-%d", "onDemand", i)),
-			State: &ec2.InstanceState{
-				Name: aws.String("running"),
+			State: &ec2types.InstanceState{
+				Name: ec2types.InstanceStateName("running"),
 			},
 		})
 	}
 	for i := 0; i < spot; i++ {
-		instances = append(instances, &ec2.Instance{
+		instances = append(instances, ec2types.Instance{
 			InstanceId: aws.String(fmt.Sprintf("%s-%d", "spot", i)),
-			State: &ec2.InstanceState{
-				Name: aws.String("running"),
+			State: &ec2types.InstanceState{
+				Name: ec2types.InstanceStateName("running"),
 			},
-			InstanceLifecycle: aws.String("spot"),
+			InstanceLifecycle: ec2types.InstanceLifecycleTypeSpot,
 		})
 	}
 
-	reservation := &ec2.Reservation{Instances: instances}
-	reservations := []*ec2.Reservation{reservation}
-	awsClient.EXPECT().DescribeInstances(gomock.Any()).Times(1).Return(
+	reservation := ec2types.Reservation{Instances: instances}
+	reservations := []ec2types.Reservation{reservation}
+	awsClient.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).Times(1).Return(
 		&ec2.DescribeInstancesOutput{
 			Reservations: reservations,
 		},
@@ -15,6 +15,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/notification"
 	"github.com/openshift/hive/pkg/remoteclient"
 )
 
@@ -137,7 +139,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *hi
 // AddToManager adds a new Controller to the controller manager
 func AddToManager(mgr manager.Manager, r *hibernationReconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	c, err := controller.New("hibernation-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -186,9 +188,11 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 	// Initialize cluster deployment conditions if not present
 	newConditions := controllerutils.InitializeClusterDeploymentConditions(cd.Status.Conditions, clusterDeploymentHibernationConditions)
 	if len(newConditions) > len(cd.Status.Conditions) {
+		origConditions := cd.Status.Conditions
 		cd.Status.Conditions = newConditions
 		cdLog.Info("initializing hibernating controller conditions")
-		if err := r.updateClusterDeploymentStatus(cd, cdLog); err != nil {
+		if err := controllerutils.PatchClusterDeploymentConditions(r.Client, cd, origConditions); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
 			return reconcile.Result{}, err
 		}
 	}
@@ -287,8 +291,20 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 					return stamps[i].After(stamps[j])
 				})
 				expiry := stamps[0].Add(hibernateAfterDur)
+				if keepAwakeUntil, ok := getKeepAwakeUntil(cd, hibLog); ok && keepAwakeUntil.After(expiry) {
+					hibLog = hibLog.WithField("keepAwakeUntil", keepAwakeUntil)
+					expiry = keepAwakeUntil
+				}
 				hibLog.Debugf("cluster should be hibernating after: %s", expiry)
-				if time.Now().After(expiry) {
+				now := time.Now()
+				if now.After(expiry) {
+					// Hibernating is disruptive, so it waits for a maintenance window.
+					if open, opensIn := controllerutils.MaintenanceWindowOpen(cd, now, hibLog); !open {
+						hibLog.WithField("opensIn", opensIn).Info("waiting for a maintenance window to hibernate")
+						expiry = now.Add(opensIn)
+					}
+				}
+				if now.After(expiry) {
 					hibLog.WithField("expiry", expiry).Debug("cluster has been running longer than hibernate-after duration, moving to hibernating powerState")
 					readyToHibernate = true
 				} else {
@@ -473,6 +489,7 @@ func (r *hibernationReconciler) checkClusterStopped(cd *hivev1.ClusterDeployment
 		if err := r.updateClusterDeploymentStatus(cd, logger); err != nil {
 			return reconcile.Result{}, err
 		}
+		notification.Notify(notification.NewEvent(hivev1.HibernatedNotificationEvent, cd, "Cluster is stopped"), logger)
 	}
 	return reconcile.Result{}, nil
 }
@@ -590,9 +607,12 @@ func (r *hibernationReconciler) checkClusterRunning(cd *hivev1.ClusterDeployment
 		corev1.ConditionTrue, logger)
 	if rChanged {
 		cd.Status.PowerState = hivev1.RunningReadyReason
-		err = r.updateClusterDeploymentStatus(cd, logger)
+		if err := r.updateClusterDeploymentStatus(cd, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		notification.Notify(notification.NewEvent(hivev1.RunningNotificationEvent, cd, clusterRunningMsg), logger)
 	}
-	return reconcile.Result{}, err
+	return reconcile.Result{}, nil
 }
 
 // timeBeforeClusterSyncCheck returns a duration for requeue use when we find that (Selector)SyncSets
@@ -649,6 +669,11 @@ func (r *hibernationReconciler) updateClusterDeploymentStatus(cd *hivev1.Cluster
 }
 
 func (r *hibernationReconciler) getActuator(cd *hivev1.ClusterDeployment) HibernationActuator {
+	return ActuatorFor(cd)
+}
+
+// ActuatorFor returns the registered actuator which can handle the given ClusterDeployment, or nil if there is none.
+func ActuatorFor(cd *hivev1.ClusterDeployment) HibernationActuator {
 	for _, a := range actuators {
 		if a.CanHandle(cd) {
 			return a
@@ -745,30 +770,46 @@ func (r *hibernationReconciler) checkCSRs(cd *hivev1.ClusterDeployment, remoteCl
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to get kube client to target cluster")
 		return reconcile.Result{}, errors.Wrap(err, "failed to get kube client to target cluster")
 	}
+	if _, err := approveCSRs(r.csrUtil, kubeClient, remoteClient, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	// Requeue quickly after so we can recheck whether more CSRs need to be approved
+	// TODO: remove wait time if all CSRs are approved
+	return reconcile.Result{RequeueAfter: csrCheckInterval}, nil
+}
+
+// ApproveNodeCSRs approves the pending certificate signing requests of the nodes of the remote cluster which can be
+// authorized against the cluster's machines. It returns the number of CSRs approved.
+func ApproveNodeCSRs(kubeClient kubeclient.Interface, remoteClient client.Client, logger log.FieldLogger) (int, error) {
+	return approveCSRs(&csrUtility{}, kubeClient, remoteClient, logger)
+}
+
+func approveCSRs(csrUtil csrHelper, kubeClient kubeclient.Interface, remoteClient client.Client, logger log.FieldLogger) (int, error) {
 	machineList := &machineapi.MachineList{}
-	err = remoteClient.List(context.TODO(), machineList)
+	err := remoteClient.List(context.TODO(), machineList)
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to list machines")
-		return reconcile.Result{}, errors.Wrap(err, "failed to list machines")
+		return 0, errors.Wrap(err, "failed to list machines")
 	}
 	csrList, err := kubeClient.CertificatesV1().CertificateSigningRequests().List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to list CSRs")
-		return reconcile.Result{}, errors.Wrap(err, "failed to list CSRs")
+		return 0, errors.Wrap(err, "failed to list CSRs")
 	}
+	approved := 0
 	for i := range csrList.Items {
 		csr := &csrList.Items[i]
 		csrLogger := logger.WithField("csr", csr.Name)
-		if r.csrUtil.IsApproved(csr) {
+		if csrUtil.IsApproved(csr) {
 			csrLogger.Debug("CSR is already approved")
 			continue
 		}
-		parsedCSR, err := r.csrUtil.Parse(csr)
+		parsedCSR, err := csrUtil.Parse(csr)
 		if err != nil {
 			csrLogger.WithError(err).Log(controllerutils.LogLevel(err), "failed to parse CSR")
-			return reconcile.Result{}, errors.Wrap(err, "failed to parse CSR")
+			return approved, errors.Wrap(err, "failed to parse CSR")
 		}
-		if err := r.csrUtil.Authorize(
+		if err := csrUtil.Authorize(
 			machineList.Items,
 			kubeClient,
 			csr,
@@ -776,15 +817,14 @@ func (r *hibernationReconciler) checkCSRs(cd *hivev1.ClusterDeployment, remoteCl
 			csrLogger.WithError(err).Log(controllerutils.LogLevel(err), "CSR authorization failed")
 			continue
 		}
-		if err = r.csrUtil.Approve(kubeClient, &csrList.Items[i]); err != nil {
+		if err = csrUtil.Approve(kubeClient, &csrList.Items[i]); err != nil {
 			csrLogger.WithError(err).Log(controllerutils.LogLevel(err), "Failed to approve CSR")
 			continue
 		}
 		csrLogger.Info("CSR approved")
+		approved++
 	}
-	// Requeue quickly after so we can recheck whether more CSRs need to be approved
-	// TODO: remove wait time if all CSRs are approved
-	return reconcile.Result{RequeueAfter: csrCheckInterval}, nil
+	return approved, nil
 }
 
 func isNodeReady(node *corev1.Node) bool {
@@ -832,3 +872,18 @@ func shouldStartMachines(cd *hivev1.ClusterDeployment, hibernatingCondition *hiv
 	}
 	return true
 }
+
+// getKeepAwakeUntil returns the time before which HibernateAfter must not hibernate the cluster, from the
+// keep-awake-until annotation of the ClusterDeployment.
+func getKeepAwakeUntil(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (time.Time, bool) {
+	value, ok := cd.Annotations[constants.KeepAwakeUntilAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	keepAwakeUntil, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		logger.WithError(err).WithField("annotation", constants.KeepAwakeUntilAnnotation).Warn("ignoring invalid annotation")
+		return time.Time{}, false
+	}
+	return keepAwakeUntil, true
+}
@@ -29,6 +29,7 @@ import (
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/hibernation/mock"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
@@ -788,6 +789,17 @@ func TestHibernateAfter(t *testing.T) {
 		testcs.WithFirstSuccessTime(time.Now().Add(-10 * time.Hour)),
 	)
 
+	// A maintenance window which opens in three hours, and one which is open now.
+	windowOpens := time.Now().UTC().Add(3 * time.Hour).Truncate(time.Minute).Add(time.Minute)
+	closedWindow := hivev1.MaintenanceWindow{
+		StartTime: windowOpens.Format(controllerutils.MaintenanceWindowStartTimeLayout),
+		Duration:  metav1.Duration{Duration: time.Hour},
+	}
+	openWindow := hivev1.MaintenanceWindow{
+		StartTime: time.Now().UTC().Add(-time.Hour).Format(controllerutils.MaintenanceWindowStartTimeLayout),
+		Duration:  metav1.Duration{Duration: 2 * time.Hour},
+	}
+
 	tests := []struct {
 		name          string
 		setupActuator func(actuator *mock.MockHibernationActuator)
@@ -838,6 +850,65 @@ func TestHibernateAfter(t *testing.T) {
 			expectRequeueAfter: 2 * time.Hour,
 			expectedPowerState: hivev1.RunningClusterPowerState,
 		},
+		{
+			name: "cluster due for hibernate kept awake",
+			cd: cdBuilder.Build(
+				testcd.WithHibernateAfter(8*time.Hour),
+				testcd.WithPowerState(hivev1.RunningClusterPowerState),
+				testcd.WithAnnotation(constants.KeepAwakeUntilAnnotation, time.Now().Add(3*time.Hour).Format(time.RFC3339)),
+				testcd.InstalledTimestamp(time.Now().Add(-10*time.Hour))),
+			cs:                 csBuilder.Build(),
+			expectRequeueAfter: 3 * time.Hour,
+			expectedPowerState: hivev1.RunningClusterPowerState,
+		},
+		{
+			name: "cluster due for hibernate no longer kept awake",
+			cd: cdBuilder.Build(
+				testcd.WithHibernateAfter(8*time.Hour),
+				testcd.WithAnnotation(constants.KeepAwakeUntilAnnotation, time.Now().Add(-1*time.Hour).Format(time.RFC3339)),
+				testcd.InstalledTimestamp(time.Now().Add(-10*time.Hour))),
+			cs:                 csBuilder.Build(),
+			expectedPowerState: hivev1.HibernatingClusterPowerState,
+		},
+		{
+			name: "cluster due for hibernate invalid keep awake",
+			cd: cdBuilder.Build(
+				testcd.WithHibernateAfter(8*time.Hour),
+				testcd.WithAnnotation(constants.KeepAwakeUntilAnnotation, "tomorrow"),
+				testcd.InstalledTimestamp(time.Now().Add(-10*time.Hour))),
+			cs:                 csBuilder.Build(),
+			expectedPowerState: hivev1.HibernatingClusterPowerState,
+		},
+		{
+			name: "cluster due for hibernate outside maintenance window",
+			cd: cdBuilder.Build(
+				testcd.WithHibernateAfter(8*time.Hour),
+				testcd.WithPowerState(hivev1.RunningClusterPowerState),
+				testcd.WithMaintenanceWindows(closedWindow),
+				testcd.InstalledTimestamp(time.Now().Add(-10*time.Hour))),
+			cs:                 csBuilder.Build(),
+			expectRequeueAfter: time.Until(windowOpens),
+			expectedPowerState: hivev1.RunningClusterPowerState,
+		},
+		{
+			name: "cluster due for hibernate in maintenance window",
+			cd: cdBuilder.Build(
+				testcd.WithHibernateAfter(8*time.Hour),
+				testcd.WithMaintenanceWindows(closedWindow, openWindow),
+				testcd.InstalledTimestamp(time.Now().Add(-10*time.Hour))),
+			cs:                 csBuilder.Build(),
+			expectedPowerState: hivev1.HibernatingClusterPowerState,
+		},
+		{
+			name: "cluster due for hibernate with maintenance windows overridden",
+			cd: cdBuilder.Build(
+				testcd.WithHibernateAfter(8*time.Hour),
+				testcd.WithMaintenanceWindows(closedWindow),
+				testcd.WithAnnotation(constants.OverrideMaintenanceWindowsUntilAnnotation, time.Now().Add(time.Hour).Format(time.RFC3339)),
+				testcd.InstalledTimestamp(time.Now().Add(-10*time.Hour))),
+			cs:                 csBuilder.Build(),
+			expectedPowerState: hivev1.HibernatingClusterPowerState,
+		},
 		{
 			name: "cluster with running condition due for hibernate",
 			cd: cdBuilder.Build(
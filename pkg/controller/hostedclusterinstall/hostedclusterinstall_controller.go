@@ -0,0 +1,511 @@
+// Package hostedclusterinstall provides a controller which installs clusters with HyperShift hosted control planes.
+// It implements the clusterinstall contract: instead of running the installer, it creates a HostedCluster and
+// NodePools for the ClusterDeployment, and reports the install as completed once the hosted control plane is
+// available.
+package hostedclusterinstall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	ControllerName = hivev1.HostedClusterInstallControllerName
+
+	// pollInterval is how often a HostedClusterInstall is reconciled while the hosted control plane is coming up.
+	// HostedClusters are not watched, as HyperShift need not be installed on the hub.
+	pollInterval = time.Minute
+
+	// hostedClusterAvailableCondition is the condition of a HostedCluster which is True once the hosted control
+	// plane is available.
+	hostedClusterAvailableCondition = "Available"
+
+	// kubeadminUsername is the username of the kubeadmin password of a HostedCluster.
+	kubeadminUsername = "kubeadmin"
+	// hostedClusterPasswordKey is the key of the password in the kubeadmin password secret of a HostedCluster.
+	hostedClusterPasswordKey = "password"
+)
+
+var (
+	hostedClusterGVK = schema.GroupVersionKind{Group: "hypershift.openshift.io", Version: "v1alpha1", Kind: "HostedCluster"}
+	nodePoolGVK      = schema.GroupVersionKind{Group: "hypershift.openshift.io", Version: "v1alpha1", Kind: "NodePool"}
+)
+
+// Add creates a new HostedClusterInstall controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileHostedClusterInstall {
+	return &ReconcileHostedClusterInstall{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme: mgr.GetScheme(),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileHostedClusterInstall, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to HostedClusterInstalls
+	if err := c.Watch(&source.Kind{Type: &hivev1.HostedClusterInstall{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments, so that installs waiting on the ClusterDeployment are resumed
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, handler.EnqueueRequestsFromMapFunc(requestsForClusterDeployment)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func requestsForClusterDeployment(o client.Object) []reconcile.Request {
+	cd, ok := o.(*hivev1.ClusterDeployment)
+	if !ok {
+		return nil
+	}
+	ref := cd.Spec.ClusterInstallRef
+	if ref == nil || ref.Group != hivev1.SchemeGroupVersion.Group || ref.Kind != "HostedClusterInstall" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: cd.Namespace, Name: ref.Name}}}
+}
+
+var _ reconcile.Reconciler = &ReconcileHostedClusterInstall{}
+
+// ReconcileHostedClusterInstall reconciles a HostedClusterInstall object
+type ReconcileHostedClusterInstall struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+// Reconcile creates the HostedCluster and NodePools of a HostedClusterInstall, and sets the conditions and cluster
+// metadata required by the clusterinstall contract as the hosted control plane comes up.
+func (r *ReconcileHostedClusterInstall) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "hostedClusterInstall", request.NamespacedName)
+	logger.Info("reconciling hosted cluster install")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	hci := &hivev1.HostedClusterInstall{}
+	switch err := r.Get(ctx, request.NamespacedName, hci); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("hosted cluster install not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Error("error getting hosted cluster install")
+		return reconcile.Result{}, err
+	}
+	if hci.DeletionTimestamp != nil {
+		logger.Debug("hosted cluster install is being deleted")
+		return reconcile.Result{}, nil
+	}
+
+	// Ensure our conditions are present, default state should be Unknown per Kube guidelines.
+	initialized := false
+	for _, condType := range []string{
+		hivev1.ClusterInstallCompleted,
+		hivev1.ClusterInstallFailed,
+		hivev1.ClusterInstallStopped,
+		hivev1.ClusterInstallRequirementsMet,
+	} {
+		if controllerutils.FindClusterInstallCondition(hci.Status.Conditions, condType) == nil {
+			initialized = r.setCondition(hci, condType, corev1.ConditionUnknown, "", "") || initialized
+		}
+	}
+	if initialized {
+		return reconcile.Result{}, r.updateStatus(hci, logger)
+	}
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(ctx, types.NamespacedName{Namespace: hci.Namespace, Name: hci.Spec.ClusterDeploymentRef.Name}, cd); {
+	case apierrors.IsNotFound(err):
+		logger.Info("cluster deployment not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+	if cd.DeletionTimestamp != nil {
+		logger.Debug("cluster deployment is being deleted")
+		return reconcile.Result{}, nil
+	}
+	logger = logger.WithField("clusterDeployment", cd.Name)
+
+	// The HostedClusterInstall is owned by the ClusterDeployment, so that it and the HostedCluster it owns are deleted
+	// with the ClusterDeployment.
+	origOwners := append([]metav1.OwnerReference(nil), hci.GetOwnerReferences()...)
+	if err := controllerutil.SetOwnerReference(cd, hci, r.scheme); err != nil {
+		logger.WithError(err).Error("could not set owner reference to cluster deployment")
+		return reconcile.Result{}, err
+	}
+	if !reflect.DeepEqual(origOwners, hci.GetOwnerReferences()) {
+		logger.Info("added owner reference to cluster deployment")
+		return reconcile.Result{}, r.Update(ctx, hci)
+	}
+
+	if controllerutils.FindClusterInstallCondition(hci.Status.Conditions, hivev1.ClusterInstallCompleted).Status == corev1.ConditionTrue {
+		changed := r.setCondition(hci, hivev1.ClusterInstallStopped, corev1.ConditionTrue, "ClusterInstalled", "Cluster install completed successfully")
+		changed = r.setCondition(hci, hivev1.ClusterInstallFailed, corev1.ConditionFalse, "ClusterInstalled", "Cluster install completed successfully") || changed
+		if changed {
+			return reconcile.Result{}, r.updateStatus(hci, logger)
+		}
+		logger.Debug("cluster install completed, no work left to be done")
+		return reconcile.Result{}, nil
+	}
+
+	origStatus := hci.Status.DeepCopy()
+	result, err := r.reconcileInstall(hci, cd, logger)
+	if !reflect.DeepEqual(*origStatus, hci.Status) {
+		if statusErr := r.updateStatus(hci, logger); statusErr != nil && err == nil {
+			err = statusErr
+		}
+	}
+	return result, err
+}
+
+// reconcileInstall creates the HostedCluster and NodePools, and completes the install once the hosted control plane
+// is available. It sets the conditions of the HostedClusterInstall, which the caller saves.
+func (r *ReconcileHostedClusterInstall) reconcileInstall(hci *hivev1.HostedClusterInstall, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
+	r.setCondition(hci, hivev1.ClusterInstallStopped, corev1.ConditionFalse, "InProgress", "Cluster install in progress")
+
+	hostedCluster, reason, message, err := r.ensureHostedCluster(hci, cd, logger)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not ensure hosted cluster")
+		return reconcile.Result{}, err
+	}
+	if hostedCluster == nil {
+		logger.WithField("reason", reason).Info(message)
+		r.setCondition(hci, hivev1.ClusterInstallRequirementsMet, corev1.ConditionFalse, reason, message)
+		return reconcile.Result{RequeueAfter: pollInterval}, nil
+	}
+	r.setCondition(hci, hivev1.ClusterInstallRequirementsMet, corev1.ConditionTrue, "AllRequirementsMet", "All requirements met")
+	hci.Status.HostedClusterRef = &corev1.LocalObjectReference{Name: hostedCluster.GetName()}
+
+	if err := r.ensureNodePools(hci, hostedCluster, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not ensure node pools")
+		return reconcile.Result{}, err
+	}
+
+	if err := r.ensureClusterMetadata(hci, cd, hostedCluster, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not set cluster metadata")
+		return reconcile.Result{}, err
+	}
+	if hci.Spec.ClusterMetadata == nil {
+		r.setCondition(hci, hivev1.ClusterInstallCompleted, corev1.ConditionFalse, "InProgress", "Waiting for the kubeconfig of the hosted cluster")
+		return reconcile.Result{RequeueAfter: pollInterval}, nil
+	}
+
+	if !isAvailable(hostedCluster) {
+		r.setCondition(hci, hivev1.ClusterInstallCompleted, corev1.ConditionFalse, "InProgress", "Waiting for the hosted control plane to be available")
+		return reconcile.Result{RequeueAfter: pollInterval}, nil
+	}
+	logger.Info("hosted control plane is available")
+	r.setCondition(hci, hivev1.ClusterInstallCompleted, corev1.ConditionTrue, "ClusterInstalled", "Cluster install completed successfully")
+	return reconcile.Result{}, nil
+}
+
+// ensureHostedCluster gets the HostedCluster of the HostedClusterInstall, creating it if it does not exist. If the
+// HostedCluster cannot be created yet, it returns nil and the reason and message for the RequirementsMet condition.
+func (r *ReconcileHostedClusterInstall) ensureHostedCluster(hci *hivev1.HostedClusterInstall, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (*unstructured.Unstructured, string, string, error) {
+	hostedCluster := &unstructured.Unstructured{}
+	hostedCluster.SetGroupVersionKind(hostedClusterGVK)
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: hci.Namespace, Name: cd.Spec.ClusterName}, hostedCluster); {
+	case meta.IsNoMatchError(err):
+		return nil, "HyperShiftNotInstalled", "The HostedCluster API is not installed on the cluster", nil
+	case err == nil:
+		return hostedCluster, "", "", nil
+	case !apierrors.IsNotFound(err):
+		return nil, "", "", errors.Wrap(err, "could not get hosted cluster")
+	}
+
+	imageSet := &hivev1.ClusterImageSet{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Name: hci.Spec.ImageSetRef.Name}, imageSet); {
+	case apierrors.IsNotFound(err):
+		return nil, "ClusterImageSetNotFound", fmt.Sprintf("ClusterImageSet %s not found", hci.Spec.ImageSetRef.Name), nil
+	case err != nil:
+		return nil, "", "", errors.Wrap(err, "could not get cluster image set")
+	}
+	if cd.Spec.PullSecretRef == nil || cd.Spec.PullSecretRef.Name == "" {
+		return nil, "PullSecretMissing", "The ClusterDeployment has no pull secret", nil
+	}
+
+	hostedCluster, err := generateHostedCluster(hci, cd, imageSet.Spec.ReleaseImage)
+	if err != nil {
+		return nil, "InvalidHostedClusterSpec", err.Error(), nil
+	}
+	if err := controllerutil.SetControllerReference(hci, hostedCluster, r.scheme); err != nil {
+		return nil, "", "", errors.Wrap(err, "could not set controller reference on hosted cluster")
+	}
+	logger.WithField("hostedCluster", hostedCluster.GetName()).Info("creating hosted cluster")
+	switch err := r.Create(context.TODO(), hostedCluster); {
+	case meta.IsNoMatchError(err):
+		return nil, "HyperShiftNotInstalled", "The HostedCluster API is not installed on the cluster", nil
+	case err != nil:
+		return nil, "", "", errors.Wrap(err, "could not create hosted cluster")
+	}
+	return hostedCluster, "", "", nil
+}
+
+// generateHostedCluster generates the HostedCluster of the HostedClusterInstall. The release image, pull secret and
+// base domain come from the ClusterDeployment, and an infra ID is generated unless the spec sets one.
+func generateHostedCluster(hci *hivev1.HostedClusterInstall, cd *hivev1.ClusterDeployment, releaseImage string) (*unstructured.Unstructured, error) {
+	spec := map[string]interface{}{}
+	if raw := hci.Spec.HostedClusterSpec.Raw; len(raw) > 0 {
+		if err := json.Unmarshal(raw, &spec); err != nil {
+			return nil, errors.Wrap(err, "could not unmarshal hosted cluster spec")
+		}
+	}
+	spec["release"] = map[string]interface{}{"image": releaseImage}
+	spec["pullSecret"] = map[string]interface{}{"name": cd.Spec.PullSecretRef.Name}
+	dns, _ := spec["dns"].(map[string]interface{})
+	if dns == nil {
+		dns = map[string]interface{}{}
+	}
+	dns["baseDomain"] = cd.Spec.BaseDomain
+	spec["dns"] = dns
+	if infraID, _ := spec["infraID"].(string); infraID == "" {
+		spec["infraID"] = fmt.Sprintf("%s-%s", cd.Spec.ClusterName, utilrand.String(5))
+	}
+
+	hostedCluster := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	hostedCluster.SetGroupVersionKind(hostedClusterGVK)
+	hostedCluster.SetNamespace(hci.Namespace)
+	hostedCluster.SetName(cd.Spec.ClusterName)
+	hostedCluster.SetLabels(k8slabels.AddLabel(nil, constants.ClusterDeploymentNameLabel, cd.Name))
+	return hostedCluster, nil
+}
+
+// ensureNodePools creates the NodePools of the HostedClusterInstall which do not exist.
+func (r *ReconcileHostedClusterInstall) ensureNodePools(hci *hivev1.HostedClusterInstall, hostedCluster *unstructured.Unstructured, logger log.FieldLogger) error {
+	releaseImage, _, _ := unstructured.NestedString(hostedCluster.Object, "spec", "release", "image")
+	for _, pool := range hci.Spec.NodePools {
+		name := fmt.Sprintf("%s-%s", hostedCluster.GetName(), pool.Name)
+		nodePool := &unstructured.Unstructured{}
+		nodePool.SetGroupVersionKind(nodePoolGVK)
+		switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: hci.Namespace, Name: name}, nodePool); {
+		case err == nil:
+			continue
+		case !apierrors.IsNotFound(err):
+			return errors.Wrapf(err, "could not get node pool %s", name)
+		}
+
+		platform := map[string]interface{}{}
+		if raw := pool.Platform.Raw; len(raw) > 0 {
+			if err := json.Unmarshal(raw, &platform); err != nil {
+				return errors.Wrapf(err, "could not unmarshal platform of node pool %s", pool.Name)
+			}
+		}
+		nodePool = &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"clusterName": hostedCluster.GetName(),
+				"replicas":    int64(pool.Replicas),
+				"platform":    platform,
+				"release":     map[string]interface{}{"image": releaseImage},
+				"management":  map[string]interface{}{"upgradeType": "Replace"},
+			},
+		}}
+		nodePool.SetGroupVersionKind(nodePoolGVK)
+		nodePool.SetNamespace(hci.Namespace)
+		nodePool.SetName(name)
+		nodePool.SetLabels(hostedCluster.GetLabels())
+		if err := controllerutil.SetControllerReference(hci, nodePool, r.scheme); err != nil {
+			return errors.Wrapf(err, "could not set controller reference on node pool %s", name)
+		}
+		logger.WithField("nodePool", name).Info("creating node pool")
+		if err := r.Create(context.TODO(), nodePool); err != nil {
+			return errors.Wrapf(err, "could not create node pool %s", name)
+		}
+	}
+	return nil
+}
+
+// ensureClusterMetadata copies the kubeconfig and kubeadmin password of the HostedCluster, once HyperShift has
+// published them, into secrets in the format Hive expects, and sets the cluster metadata of the HostedClusterInstall.
+func (r *ReconcileHostedClusterInstall) ensureClusterMetadata(hci *hivev1.HostedClusterInstall, cd *hivev1.ClusterDeployment, hostedCluster *unstructured.Unstructured, logger log.FieldLogger) error {
+	kubeconfigName, _, _ := unstructured.NestedString(hostedCluster.Object, "status", "kubeconfig", "name")
+	passwordName, _, _ := unstructured.NestedString(hostedCluster.Object, "status", "kubeadminPassword", "name")
+	if kubeconfigName == "" || passwordName == "" {
+		logger.Debug("hosted cluster has not published its kubeconfig and kubeadmin password")
+		return nil
+	}
+
+	kubeconfig := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: hci.Namespace, Name: kubeconfigName}, kubeconfig); err != nil {
+		return errors.Wrap(err, "could not get kubeconfig secret of hosted cluster")
+	}
+	password := &corev1.Secret{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: hci.Namespace, Name: passwordName}, password); err != nil {
+		return errors.Wrap(err, "could not get kubeadmin password secret of hosted cluster")
+	}
+
+	adminKubeconfigName := fmt.Sprintf("%s-admin-kubeconfig", hci.Name)
+	if err := r.syncSecret(hci, cd, adminKubeconfigName, constants.SecretTypeKubeConfig, map[string][]byte{
+		constants.KubeconfigSecretKey: kubeconfig.Data[constants.KubeconfigSecretKey],
+	}, logger); err != nil {
+		return err
+	}
+	adminPasswordName := fmt.Sprintf("%s-admin-password", hci.Name)
+	if err := r.syncSecret(hci, cd, adminPasswordName, constants.SecretTypeKubeAdminCreds, map[string][]byte{
+		constants.UsernameSecretKey: []byte(kubeadminUsername),
+		constants.PasswordSecretKey: password.Data[hostedClusterPasswordKey],
+	}, logger); err != nil {
+		return err
+	}
+
+	infraID, _, _ := unstructured.NestedString(hostedCluster.Object, "spec", "infraID")
+	clusterID, _, _ := unstructured.NestedString(hostedCluster.Object, "spec", "clusterID")
+	if clusterID == "" {
+		clusterID = string(hostedCluster.GetUID())
+	}
+	metadata := &hivev1.ClusterMetadata{
+		ClusterID:                clusterID,
+		InfraID:                  infraID,
+		AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: adminKubeconfigName},
+		AdminPasswordSecretRef:   &corev1.LocalObjectReference{Name: adminPasswordName},
+	}
+	if reflect.DeepEqual(hci.Spec.ClusterMetadata, metadata) {
+		return nil
+	}
+	logger.Info("setting cluster metadata")
+	hci.Spec.ClusterMetadata = metadata
+	// The update returns the stored status, so the status set during this reconcile is restored after it.
+	status := hci.Status.DeepCopy()
+	if err := r.Update(context.TODO(), hci); err != nil {
+		return err
+	}
+	hci.Status = *status
+	return nil
+}
+
+// syncSecret creates or updates a secret owned by the HostedClusterInstall so that it holds the given data. Other
+// keys of the secret are kept.
+func (r *ReconcileHostedClusterInstall) syncSecret(hci *hivev1.HostedClusterInstall, cd *hivev1.ClusterDeployment, name, secretType string, data map[string][]byte, logger log.FieldLogger) error {
+	secret := &corev1.Secret{}
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: hci.Namespace, Name: name}, secret); {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: hci.Namespace,
+				Name:      name,
+				Labels: map[string]string{
+					constants.ClusterDeploymentNameLabel: cd.Name,
+					constants.SecretTypeLabel:            secretType,
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		}
+		if err := controllerutil.SetControllerReference(hci, secret, r.scheme); err != nil {
+			return errors.Wrapf(err, "could not set controller reference on secret %s", name)
+		}
+		logger.WithField("secret", name).Info("creating secret")
+		return errors.Wrapf(r.Create(context.TODO(), secret), "could not create secret %s", name)
+	case err != nil:
+		return errors.Wrapf(err, "could not get secret %s", name)
+	}
+
+	changed := false
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for k, v := range data {
+		if !bytes.Equal(secret.Data[k], v) {
+			secret.Data[k] = v
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	logger.WithField("secret", name).Info("updating secret")
+	return errors.Wrapf(r.Update(context.TODO(), secret), "could not update secret %s", name)
+}
+
+// isAvailable returns true if the hosted control plane of the HostedCluster is available.
+func isAvailable(hostedCluster *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(hostedCluster.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cond["type"] == hostedClusterAvailableCondition {
+			return cond["status"] == string(metav1.ConditionTrue)
+		}
+	}
+	return false
+}
+
+func (r *ReconcileHostedClusterInstall) setCondition(hci *hivev1.HostedClusterInstall, condType string, status corev1.ConditionStatus, reason, message string) bool {
+	conditions, changed := controllerutils.SetClusterInstallConditionWithChangeCheck(
+		hci.Status.Conditions,
+		condType,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+	hci.Status.Conditions = conditions
+	return changed
+}
+
+func (r *ReconcileHostedClusterInstall) updateStatus(hci *hivev1.HostedClusterInstall, logger log.FieldLogger) error {
+	logger.Debug("updating status")
+	if err := r.Status().Update(context.TODO(), hci); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update status")
+		return err
+	}
+	return nil
+}
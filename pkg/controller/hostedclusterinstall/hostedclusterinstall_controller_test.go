@@ -0,0 +1,351 @@
+package hostedclusterinstall
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+const (
+	namespace      = "test-namespace"
+	cdName         = "test-cluster-deployment"
+	clusterName    = "test-cluster"
+	hciName        = "test-hosted-cluster-install"
+	imageSetName   = "test-image-set"
+	releaseImage   = "quay.io/openshift-release-dev/ocp-release:4.9.0-x86_64"
+	pullSecretName = "test-pull-secret"
+	baseDomain     = "example.com"
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	cd := testcd.FullBuilder(namespace, cdName, scheme).Build(func(cd *hivev1.ClusterDeployment) {
+		cd.UID = "cd-uid"
+		cd.Spec.ClusterName = clusterName
+		cd.Spec.BaseDomain = baseDomain
+		cd.Spec.PullSecretRef = &corev1.LocalObjectReference{Name: pullSecretName}
+		cd.Spec.ClusterInstallRef = &hivev1.ClusterInstallLocalReference{
+			Group:   hivev1.SchemeGroupVersion.Group,
+			Version: hivev1.SchemeGroupVersion.Version,
+			Kind:    "HostedClusterInstall",
+			Name:    hciName,
+		}
+	})
+	imageSet := &hivev1.ClusterImageSet{
+		ObjectMeta: metav1.ObjectMeta{Name: imageSetName},
+		Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: releaseImage},
+	}
+
+	buildHCI := func(opts ...func(*hivev1.HostedClusterInstall)) *hivev1.HostedClusterInstall {
+		hci := &hivev1.HostedClusterInstall{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: hciName, UID: "hci-uid"},
+			Spec: hivev1.HostedClusterInstallSpec{
+				ClusterDeploymentRef: corev1.LocalObjectReference{Name: cdName},
+				ImageSetRef:          hivev1.ClusterImageSetReference{Name: imageSetName},
+				HostedClusterConfig: hivev1.HostedClusterConfig{
+					HostedClusterSpec: runtime.RawExtension{Raw: []byte(`{"platform":{"type":"AWS"},"dns":{"privateZoneID":"zone"}}`)},
+					NodePools: []hivev1.HostedClusterNodePool{{
+						Name:     "workers",
+						Replicas: 2,
+						Platform: runtime.RawExtension{Raw: []byte(`{"type":"AWS"}`)},
+					}},
+				},
+			},
+		}
+		for _, opt := range opts {
+			opt(hci)
+		}
+		return hci
+	}
+	withConditions := func(completed corev1.ConditionStatus) func(*hivev1.HostedClusterInstall) {
+		return func(hci *hivev1.HostedClusterInstall) {
+			for _, condType := range []string{
+				hivev1.ClusterInstallFailed,
+				hivev1.ClusterInstallStopped,
+				hivev1.ClusterInstallRequirementsMet,
+			} {
+				hci.Status.Conditions = append(hci.Status.Conditions, hivev1.ClusterInstallCondition{Type: condType, Status: corev1.ConditionUnknown})
+			}
+			hci.Status.Conditions = append(hci.Status.Conditions, hivev1.ClusterInstallCondition{Type: hivev1.ClusterInstallCompleted, Status: completed})
+		}
+	}
+	ownedByCD := func(hci *hivev1.HostedClusterInstall) {
+		hci.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: hivev1.SchemeGroupVersion.String(),
+			Kind:       "ClusterDeployment",
+			Name:       cdName,
+			UID:        cd.UID,
+		}}
+	}
+	buildHostedCluster := func(available bool) *unstructured.Unstructured {
+		hc := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"infraID": "test-infra-id",
+				"release": map[string]interface{}{"image": releaseImage},
+			},
+			"status": map[string]interface{}{
+				"kubeconfig":        map[string]interface{}{"name": "hc-kubeconfig"},
+				"kubeadminPassword": map[string]interface{}{"name": "hc-password"},
+				"conditions": []interface{}{
+					map[string]interface{}{"type": hostedClusterAvailableCondition, "status": map[bool]string{true: "True", false: "False"}[available]},
+				},
+			},
+		}}
+		hc.SetGroupVersionKind(hostedClusterGVK)
+		hc.SetNamespace(namespace)
+		hc.SetName(clusterName)
+		hc.SetUID("hc-uid")
+		return hc
+	}
+	hostedClusterSecrets := []runtime.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "hc-kubeconfig"},
+			Data:       map[string][]byte{constants.KubeconfigSecretKey: []byte("hc kubeconfig")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "hc-password"},
+			Data:       map[string][]byte{hostedClusterPasswordKey: []byte("hc password")},
+		},
+	}
+
+	tests := []struct {
+		name                  string
+		existing              []runtime.Object
+		expectRequeue         bool
+		expectedConditions    map[string]corev1.ConditionStatus
+		expectedReason        map[string]string
+		expectOwnerRef        bool
+		expectHostedCluster   bool
+		expectClusterMetadata bool
+	}{
+		{
+			name:     "initialize conditions",
+			existing: []runtime.Object{cd, imageSet, buildHCI()},
+			expectedConditions: map[string]corev1.ConditionStatus{
+				hivev1.ClusterInstallCompleted:       corev1.ConditionUnknown,
+				hivev1.ClusterInstallFailed:          corev1.ConditionUnknown,
+				hivev1.ClusterInstallStopped:         corev1.ConditionUnknown,
+				hivev1.ClusterInstallRequirementsMet: corev1.ConditionUnknown,
+			},
+		},
+		{
+			name:           "set owner reference",
+			existing:       []runtime.Object{cd, imageSet, buildHCI(withConditions(corev1.ConditionUnknown))},
+			expectOwnerRef: true,
+		},
+		{
+			name:          "missing cluster image set",
+			existing:      []runtime.Object{cd, buildHCI(withConditions(corev1.ConditionUnknown), ownedByCD)},
+			expectRequeue: true,
+			expectedConditions: map[string]corev1.ConditionStatus{
+				hivev1.ClusterInstallRequirementsMet: corev1.ConditionFalse,
+				hivev1.ClusterInstallStopped:         corev1.ConditionFalse,
+			},
+			expectedReason: map[string]string{
+				hivev1.ClusterInstallRequirementsMet: "ClusterImageSetNotFound",
+			},
+			expectOwnerRef: true,
+		},
+		{
+			name: "missing pull secret",
+			existing: []runtime.Object{
+				testcd.FullBuilder(namespace, cdName, scheme).Build(func(c *hivev1.ClusterDeployment) {
+					c.UID = cd.UID
+					c.Spec.ClusterName = clusterName
+				}),
+				imageSet,
+				buildHCI(withConditions(corev1.ConditionUnknown), ownedByCD),
+			},
+			expectRequeue: true,
+			expectedConditions: map[string]corev1.ConditionStatus{
+				hivev1.ClusterInstallRequirementsMet: corev1.ConditionFalse,
+			},
+			expectedReason: map[string]string{
+				hivev1.ClusterInstallRequirementsMet: "PullSecretMissing",
+			},
+			expectOwnerRef: true,
+		},
+		{
+			name:          "create hosted cluster",
+			existing:      []runtime.Object{cd, imageSet, buildHCI(withConditions(corev1.ConditionUnknown), ownedByCD)},
+			expectRequeue: true,
+			expectedConditions: map[string]corev1.ConditionStatus{
+				hivev1.ClusterInstallRequirementsMet: corev1.ConditionTrue,
+				hivev1.ClusterInstallCompleted:       corev1.ConditionFalse,
+				hivev1.ClusterInstallStopped:         corev1.ConditionFalse,
+			},
+			expectOwnerRef:      true,
+			expectHostedCluster: true,
+		},
+		{
+			name: "hosted control plane not available",
+			existing: append([]runtime.Object{
+				cd, imageSet, buildHostedCluster(false), buildHCI(withConditions(corev1.ConditionUnknown), ownedByCD),
+			}, hostedClusterSecrets...),
+			expectRequeue: true,
+			expectedConditions: map[string]corev1.ConditionStatus{
+				hivev1.ClusterInstallRequirementsMet: corev1.ConditionTrue,
+				hivev1.ClusterInstallCompleted:       corev1.ConditionFalse,
+			},
+			expectOwnerRef:        true,
+			expectHostedCluster:   true,
+			expectClusterMetadata: true,
+		},
+		{
+			name: "hosted control plane available",
+			existing: append([]runtime.Object{
+				cd, imageSet, buildHostedCluster(true), buildHCI(withConditions(corev1.ConditionUnknown), ownedByCD),
+			}, hostedClusterSecrets...),
+			expectedConditions: map[string]corev1.ConditionStatus{
+				hivev1.ClusterInstallRequirementsMet: corev1.ConditionTrue,
+				hivev1.ClusterInstallCompleted:       corev1.ConditionTrue,
+			},
+			expectOwnerRef:        true,
+			expectHostedCluster:   true,
+			expectClusterMetadata: true,
+		},
+		{
+			name:     "install completed",
+			existing: []runtime.Object{cd, buildHCI(withConditions(corev1.ConditionTrue), ownedByCD)},
+			expectedConditions: map[string]corev1.ConditionStatus{
+				hivev1.ClusterInstallCompleted: corev1.ConditionTrue,
+				hivev1.ClusterInstallStopped:   corev1.ConditionTrue,
+				hivev1.ClusterInstallFailed:    corev1.ConditionFalse,
+			},
+			expectOwnerRef: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(test.existing...).Build()
+			r := &ReconcileHostedClusterInstall{Client: fakeClient, scheme: scheme}
+
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: hciName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+			assert.Equal(t, test.expectRequeue, result.RequeueAfter > 0, "unexpected requeue")
+
+			hci := &hivev1.HostedClusterInstall{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: hciName}, hci))
+			for condType, status := range test.expectedConditions {
+				cond := controllerutils.FindClusterInstallCondition(hci.Status.Conditions, condType)
+				if assert.NotNil(t, cond, "missing %s condition", condType) {
+					assert.Equal(t, status, cond.Status, "unexpected status of %s condition", condType)
+				}
+			}
+			for condType, reason := range test.expectedReason {
+				cond := controllerutils.FindClusterInstallCondition(hci.Status.Conditions, condType)
+				if assert.NotNil(t, cond, "missing %s condition", condType) {
+					assert.Equal(t, reason, cond.Reason, "unexpected reason of %s condition", condType)
+				}
+			}
+			if test.expectOwnerRef {
+				if assert.Len(t, hci.OwnerReferences, 1, "unexpected owner references") {
+					assert.Equal(t, cdName, hci.OwnerReferences[0].Name, "unexpected owner")
+				}
+			} else {
+				assert.Empty(t, hci.OwnerReferences, "unexpected owner references")
+			}
+
+			hc := &unstructured.Unstructured{}
+			hc.SetGroupVersionKind(hostedClusterGVK)
+			err = fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: clusterName}, hc)
+			if !test.expectHostedCluster {
+				assert.Error(t, err, "unexpected hosted cluster")
+				return
+			}
+			require.NoError(t, err, "missing hosted cluster")
+			image, _, _ := unstructured.NestedString(hc.Object, "spec", "release", "image")
+			assert.Equal(t, releaseImage, image, "unexpected release image")
+			if assert.NotNil(t, hci.Status.HostedClusterRef, "missing hosted cluster ref") {
+				assert.Equal(t, clusterName, hci.Status.HostedClusterRef.Name, "unexpected hosted cluster ref")
+			}
+
+			nodePool := &unstructured.Unstructured{}
+			nodePool.SetGroupVersionKind(nodePoolGVK)
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: clusterName + "-workers"}, nodePool), "missing node pool")
+			replicas, _, _ := unstructured.NestedInt64(nodePool.Object, "spec", "replicas")
+			assert.Equal(t, int64(2), replicas, "unexpected node pool replicas")
+
+			if !test.expectClusterMetadata {
+				assert.Nil(t, hci.Spec.ClusterMetadata, "unexpected cluster metadata")
+				return
+			}
+			if assert.NotNil(t, hci.Spec.ClusterMetadata, "missing cluster metadata") {
+				assert.Equal(t, "test-infra-id", hci.Spec.ClusterMetadata.InfraID, "unexpected infra ID")
+				assert.Equal(t, "hc-uid", hci.Spec.ClusterMetadata.ClusterID, "unexpected cluster ID")
+			}
+			kubeconfig := &corev1.Secret{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: hci.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name}, kubeconfig))
+			assert.Equal(t, "hc kubeconfig", string(kubeconfig.Data[constants.KubeconfigSecretKey]), "unexpected kubeconfig")
+			password := &corev1.Secret{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: hci.Spec.ClusterMetadata.AdminPasswordSecretRef.Name}, password))
+			assert.Equal(t, kubeadminUsername, string(password.Data[constants.UsernameSecretKey]), "unexpected username")
+			assert.Equal(t, "hc password", string(password.Data[constants.PasswordSecretKey]), "unexpected password")
+		})
+	}
+}
+
+func TestGenerateHostedCluster(t *testing.T) {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: cdName},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName:   clusterName,
+			BaseDomain:    baseDomain,
+			PullSecretRef: &corev1.LocalObjectReference{Name: pullSecretName},
+		},
+	}
+	hci := &hivev1.HostedClusterInstall{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: hciName},
+		Spec: hivev1.HostedClusterInstallSpec{
+			HostedClusterConfig: hivev1.HostedClusterConfig{
+				HostedClusterSpec: runtime.RawExtension{Raw: []byte(`{"platform":{"type":"AWS"},"dns":{"privateZoneID":"zone"}}`)},
+			},
+		},
+	}
+
+	hc, err := generateHostedCluster(hci, cd, releaseImage)
+	require.NoError(t, err)
+	assert.Equal(t, clusterName, hc.GetName())
+	assert.Equal(t, cdName, hc.GetLabels()[constants.ClusterDeploymentNameLabel])
+	for _, field := range []struct {
+		path     []string
+		expected string
+	}{
+		{path: []string{"platform", "type"}, expected: "AWS"},
+		{path: []string{"release", "image"}, expected: releaseImage},
+		{path: []string{"pullSecret", "name"}, expected: pullSecretName},
+		{path: []string{"dns", "baseDomain"}, expected: baseDomain},
+		{path: []string{"dns", "privateZoneID"}, expected: "zone"},
+	} {
+		actual, _, _ := unstructured.NestedString(hc.Object, append([]string{"spec"}, field.path...)...)
+		assert.Equal(t, field.expected, actual, "unexpected spec.%s", strings.Join(field.path, "."))
+	}
+	infraID, _, _ := unstructured.NestedString(hc.Object, "spec", "infraID")
+	assert.True(t, strings.HasPrefix(infraID, clusterName+"-"), "unexpected infra ID %s", infraID)
+
+	hci.Spec.HostedClusterSpec.Raw = []byte(`not json`)
+	_, err = generateHostedCluster(hci, cd, releaseImage)
+	assert.Error(t, err, "expected error for invalid hosted cluster spec")
+}
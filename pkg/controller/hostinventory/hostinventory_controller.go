@@ -0,0 +1,249 @@
+// Package hostinventory provides a controller which generates the NMStateConfigs of agent based installs from
+// HostInventories. The static network configuration of each host is rendered from the template of the inventory, and
+// validated, before the NMStateConfigs used by the assisted installer are created.
+package hostinventory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	k8slabels "github.com/openshift/hive/pkg/util/labels"
+)
+
+const (
+	ControllerName = hivev1.HostInventoryControllerName
+
+	// hostInventoryNameLabel is set on the NMStateConfigs generated for a HostInventory to the name of the inventory.
+	hostInventoryNameLabel = "hive.openshift.io/host-inventory-name"
+)
+
+var nmStateConfigGVK = schema.GroupVersionKind{Group: "agent-install.openshift.io", Version: "v1beta1", Kind: "NMStateConfig"}
+
+// Add creates a new HostInventory controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileHostInventory {
+	return &ReconcileHostInventory{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		scheme: mgr.GetScheme(),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileHostInventory, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to HostInventories. NMStateConfigs are not watched, as the assisted installer need not be
+	// installed on the hub.
+	if err := c.Watch(&source.Kind{Type: &hivev1.HostInventory{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileHostInventory{}
+
+// ReconcileHostInventory reconciles a HostInventory object
+type ReconcileHostInventory struct {
+	client.Client
+	scheme *runtime.Scheme
+}
+
+// Reconcile validates the hosts of a HostInventory and generates an NMStateConfig for each of them.
+func (r *ReconcileHostInventory) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "hostInventory", request.NamespacedName)
+	logger.Info("reconciling host inventory")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	inventory := &hivev1.HostInventory{}
+	switch err := r.Get(ctx, request.NamespacedName, inventory); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("host inventory not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Error("error getting host inventory")
+		return reconcile.Result{}, err
+	}
+	if inventory.DeletionTimestamp != nil {
+		logger.Debug("host inventory is being deleted")
+		return reconcile.Result{}, nil
+	}
+
+	origStatus := inventory.Status.DeepCopy()
+	err := r.reconcileNMStateConfigs(inventory, logger)
+	if !reflect.DeepEqual(*origStatus, inventory.Status) {
+		logger.Debug("updating status")
+		if statusErr := r.Status().Update(ctx, inventory); statusErr != nil {
+			logger.WithError(statusErr).Log(controllerutils.LogLevel(statusErr), "failed to update status")
+			if err == nil {
+				err = statusErr
+			}
+		}
+	}
+	return reconcile.Result{}, err
+}
+
+// reconcileNMStateConfigs renders the network configurations of the hosts and, if they are all valid, ensures that
+// the NMStateConfigs of the inventory match them. It sets the conditions of the inventory, which the caller saves.
+func (r *ReconcileHostInventory) reconcileNMStateConfigs(inventory *hivev1.HostInventory, logger log.FieldLogger) error {
+	configs, errs := controllerutils.RenderHostNetworkConfigs(inventory)
+	if len(errs) > 0 {
+		logger.WithError(errs.ToAggregate()).Info("host inventory is invalid")
+		r.setCondition(inventory, hivev1.HostInventoryValidCondition, corev1.ConditionFalse, "InvalidHosts", errs.ToAggregate().Error())
+		r.setCondition(inventory, hivev1.HostInventoryNMStateConfigsCurrentCondition, corev1.ConditionFalse, "InvalidHosts", "NMStateConfigs are not generated for an invalid inventory")
+		return nil
+	}
+	r.setCondition(inventory, hivev1.HostInventoryValidCondition, corev1.ConditionTrue, "Valid", "The network configuration of every host is valid")
+
+	existing := &unstructured.UnstructuredList{}
+	existing.SetGroupVersionKind(nmStateConfigGVK.GroupVersion().WithKind(nmStateConfigGVK.Kind + "List"))
+	switch err := r.List(context.TODO(), existing, client.InNamespace(inventory.Namespace), client.MatchingLabels{hostInventoryNameLabel: inventory.Name}); {
+	case meta.IsNoMatchError(err):
+		logger.Info("the NMStateConfig API is not installed on the cluster")
+		r.setCondition(inventory, hivev1.HostInventoryNMStateConfigsCurrentCondition, corev1.ConditionFalse, "AgentInstallNotInstalled", "The NMStateConfig API is not installed on the cluster")
+		return nil
+	case err != nil:
+		logger.WithError(err).Error("could not list NMStateConfigs")
+		return err
+	}
+	existingByName := map[string]*unstructured.Unstructured{}
+	for i := range existing.Items {
+		existingByName[existing.Items[i].GetName()] = &existing.Items[i]
+	}
+
+	for _, host := range inventory.Spec.Hosts {
+		desired, err := r.generateNMStateConfig(inventory, host, configs[host.Name])
+		if err != nil {
+			return err
+		}
+		if err := r.syncNMStateConfig(desired, existingByName[desired.GetName()], logger); err != nil {
+			r.setCondition(inventory, hivev1.HostInventoryNMStateConfigsCurrentCondition, corev1.ConditionFalse, "SyncFailed", err.Error())
+			return err
+		}
+		delete(existingByName, desired.GetName())
+	}
+	// What is left over belongs to hosts which have been removed from the inventory.
+	for name, obj := range existingByName {
+		logger.WithField("nmStateConfig", name).Info("deleting NMStateConfig of removed host")
+		if err := r.Delete(context.TODO(), obj); err != nil && !apierrors.IsNotFound(err) {
+			logger.WithError(err).WithField("nmStateConfig", name).Error("could not delete NMStateConfig")
+			return err
+		}
+	}
+
+	r.setCondition(inventory, hivev1.HostInventoryNMStateConfigsCurrentCondition, corev1.ConditionTrue, "NMStateConfigsCurrent", "The NMStateConfigs of all hosts are current")
+	return nil
+}
+
+// generateNMStateConfig generates the NMStateConfig of a host of the inventory.
+func (r *ReconcileHostInventory) generateNMStateConfig(inventory *hivev1.HostInventory, host hivev1.InventoryHost, config map[string]interface{}) (*unstructured.Unstructured, error) {
+	interfaces := make([]interface{}, len(host.Interfaces))
+	for i, iface := range host.Interfaces {
+		interfaces[i] = map[string]interface{}{
+			"name":       iface.Name,
+			"macAddress": iface.MACAddress,
+		}
+	}
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"config":     config,
+			"interfaces": interfaces,
+		},
+	}}
+	obj.SetGroupVersionKind(nmStateConfigGVK)
+	obj.SetNamespace(inventory.Namespace)
+	obj.SetName(fmt.Sprintf("%s-%s", inventory.Name, host.Name))
+
+	labels := map[string]string{}
+	for k, v := range inventory.Spec.NMStateConfigLabels {
+		labels[k] = v
+	}
+	labels = k8slabels.AddLabel(labels, hostInventoryNameLabel, inventory.Name)
+	labels = k8slabels.AddLabel(labels, constants.ClusterDeploymentNameLabel, inventory.Spec.ClusterDeploymentRef.Name)
+	obj.SetLabels(labels)
+
+	if err := controllerutil.SetControllerReference(inventory, obj, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "could not set controller reference on NMStateConfig")
+	}
+	return obj, nil
+}
+
+// syncNMStateConfig creates the NMStateConfig if it does not exist, and updates its spec, labels and owner otherwise.
+func (r *ReconcileHostInventory) syncNMStateConfig(desired, existing *unstructured.Unstructured, logger log.FieldLogger) error {
+	logger = logger.WithField("nmStateConfig", desired.GetName())
+	if existing == nil {
+		logger.Info("creating NMStateConfig")
+		err := r.Create(context.TODO(), desired)
+		if apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("NMStateConfig %s exists, but was not generated for this inventory", desired.GetName())
+		}
+		return errors.Wrapf(err, "could not create NMStateConfig %s", desired.GetName())
+	}
+	if reflect.DeepEqual(existing.Object["spec"], desired.Object["spec"]) &&
+		reflect.DeepEqual(existing.GetLabels(), desired.GetLabels()) &&
+		reflect.DeepEqual(existing.GetOwnerReferences(), desired.GetOwnerReferences()) {
+		return nil
+	}
+	existing.Object["spec"] = desired.Object["spec"]
+	existing.SetLabels(desired.GetLabels())
+	existing.SetOwnerReferences(desired.GetOwnerReferences())
+	logger.Info("updating NMStateConfig")
+	return errors.Wrapf(r.Update(context.TODO(), existing), "could not update NMStateConfig %s", desired.GetName())
+}
+
+func (r *ReconcileHostInventory) setCondition(inventory *hivev1.HostInventory, condType hivev1.HostInventoryConditionType, status corev1.ConditionStatus, reason, message string) {
+	inventory.Status.Conditions = controllerutils.SetHostInventoryCondition(
+		inventory.Status.Conditions,
+		condType,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange)
+}
@@ -0,0 +1,230 @@
+package hostinventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	namespace     = "test-namespace"
+	inventoryName = "test-inventory"
+	cdName        = "test-cluster-deployment"
+
+	networkConfigTemplate = `interfaces:
+- name: {{ (index .Interfaces 0).Name }}
+  type: ethernet
+  state: up
+  ipv4:
+    enabled: true
+    address:
+    - ip: {{ .Vars.ip }}
+      prefix-length: 24
+`
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	host := func(name, mac, ip string) hivev1.InventoryHost {
+		return hivev1.InventoryHost{
+			Name:       name,
+			Interfaces: []hivev1.InventoryHostInterface{{Name: "eth0", MACAddress: mac}},
+			Variables:  map[string]string{"ip": ip},
+		}
+	}
+	buildInventory := func(hosts ...hivev1.InventoryHost) *hivev1.HostInventory {
+		return &hivev1.HostInventory{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: inventoryName, UID: "inventory-uid"},
+			Spec: hivev1.HostInventorySpec{
+				ClusterDeploymentRef:  corev1.LocalObjectReference{Name: cdName},
+				NetworkConfigTemplate: networkConfigTemplate,
+				NMStateConfigLabels:   map[string]string{"infraenv": "test-infraenv"},
+				Hosts:                 hosts,
+			},
+		}
+	}
+	buildNMStateConfig := func(name string, labels map[string]string, spec map[string]interface{}) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+		obj.SetGroupVersionKind(nmStateConfigGVK)
+		obj.SetNamespace(namespace)
+		obj.SetName(name)
+		obj.SetLabels(labels)
+		return obj
+	}
+	inventoryLabels := map[string]string{hostInventoryNameLabel: inventoryName}
+	master0 := host("master-0", "52:54:00:00:00:01", "192.168.111.10")
+	master1 := host("master-1", "52:54:00:00:00:02", "192.168.111.11")
+
+	tests := []struct {
+		name               string
+		existing           []runtime.Object
+		expectErr          bool
+		expectedConditions map[hivev1.HostInventoryConditionType]corev1.ConditionStatus
+		expectedReason     map[hivev1.HostInventoryConditionType]string
+		expectedIPs        map[string]string
+		expectedMissing    []string
+		expectedUnchanged  []string
+	}{
+		{
+			name:     "generate NMStateConfigs",
+			existing: []runtime.Object{buildInventory(master0, master1)},
+			expectedConditions: map[hivev1.HostInventoryConditionType]corev1.ConditionStatus{
+				hivev1.HostInventoryValidCondition:                 corev1.ConditionTrue,
+				hivev1.HostInventoryNMStateConfigsCurrentCondition: corev1.ConditionTrue,
+			},
+			expectedIPs: map[string]string{
+				inventoryName + "-master-0": "192.168.111.10",
+				inventoryName + "-master-1": "192.168.111.11",
+			},
+		},
+		{
+			name: "invalid inventory",
+			existing: []runtime.Object{buildInventory(
+				master0,
+				host("master-1", "52:54:00:00:00:01", "192.168.111.11"),
+			)},
+			expectedConditions: map[hivev1.HostInventoryConditionType]corev1.ConditionStatus{
+				hivev1.HostInventoryValidCondition:                 corev1.ConditionFalse,
+				hivev1.HostInventoryNMStateConfigsCurrentCondition: corev1.ConditionFalse,
+			},
+			expectedReason: map[hivev1.HostInventoryConditionType]string{
+				hivev1.HostInventoryValidCondition:                 "InvalidHosts",
+				hivev1.HostInventoryNMStateConfigsCurrentCondition: "InvalidHosts",
+			},
+			expectedMissing: []string{inventoryName + "-master-0", inventoryName + "-master-1"},
+		},
+		{
+			name: "update changed NMStateConfig",
+			existing: []runtime.Object{
+				buildInventory(master0),
+				buildNMStateConfig(inventoryName+"-master-0", inventoryLabels, map[string]interface{}{"config": "stale"}),
+			},
+			expectedConditions: map[hivev1.HostInventoryConditionType]corev1.ConditionStatus{
+				hivev1.HostInventoryNMStateConfigsCurrentCondition: corev1.ConditionTrue,
+			},
+			expectedIPs: map[string]string{
+				inventoryName + "-master-0": "192.168.111.10",
+			},
+		},
+		{
+			name: "delete NMStateConfig of removed host",
+			existing: []runtime.Object{
+				buildInventory(master0),
+				buildNMStateConfig(inventoryName+"-master-1", inventoryLabels, map[string]interface{}{}),
+			},
+			expectedConditions: map[hivev1.HostInventoryConditionType]corev1.ConditionStatus{
+				hivev1.HostInventoryNMStateConfigsCurrentCondition: corev1.ConditionTrue,
+			},
+			expectedIPs: map[string]string{
+				inventoryName + "-master-0": "192.168.111.10",
+			},
+			expectedMissing: []string{inventoryName + "-master-1"},
+		},
+		{
+			name: "NMStateConfig not generated for this inventory",
+			existing: []runtime.Object{
+				buildInventory(master0),
+				buildNMStateConfig(inventoryName+"-master-0", nil, map[string]interface{}{"config": "other"}),
+			},
+			expectErr: true,
+			expectedConditions: map[hivev1.HostInventoryConditionType]corev1.ConditionStatus{
+				hivev1.HostInventoryValidCondition:                 corev1.ConditionTrue,
+				hivev1.HostInventoryNMStateConfigsCurrentCondition: corev1.ConditionFalse,
+			},
+			expectedReason: map[hivev1.HostInventoryConditionType]string{
+				hivev1.HostInventoryNMStateConfigsCurrentCondition: "SyncFailed",
+			},
+			expectedUnchanged: []string{inventoryName + "-master-0"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(test.existing...).Build()
+			r := &ReconcileHostInventory{Client: fakeClient, scheme: scheme}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: inventoryName},
+			})
+			if test.expectErr {
+				assert.Error(t, err, "expected error from reconcile")
+			} else {
+				require.NoError(t, err, "unexpected error from reconcile")
+			}
+
+			inventory := &hivev1.HostInventory{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: inventoryName}, inventory))
+			for condType, status := range test.expectedConditions {
+				cond := controllerutils.FindHostInventoryCondition(inventory.Status.Conditions, condType)
+				if assert.NotNil(t, cond, "missing %s condition", condType) {
+					assert.Equal(t, status, cond.Status, "unexpected status of %s condition", condType)
+				}
+			}
+			for condType, reason := range test.expectedReason {
+				cond := controllerutils.FindHostInventoryCondition(inventory.Status.Conditions, condType)
+				if assert.NotNil(t, cond, "missing %s condition", condType) {
+					assert.Equal(t, reason, cond.Reason, "unexpected reason of %s condition", condType)
+				}
+			}
+
+			for name, ip := range test.expectedIPs {
+				config := getNMStateConfig(t, fakeClient, name)
+				if !assert.NotNil(t, config, "missing NMStateConfig %s", name) {
+					continue
+				}
+				interfaces, _, _ := unstructured.NestedSlice(config.Object, "spec", "config", "interfaces")
+				if assert.Len(t, interfaces, 1, "unexpected interfaces in config") {
+					addresses, _, _ := unstructured.NestedSlice(interfaces[0].(map[string]interface{}), "ipv4", "address")
+					if assert.Len(t, addresses, 1, "unexpected addresses") {
+						assert.Equal(t, ip, addresses[0].(map[string]interface{})["ip"], "unexpected IP")
+					}
+				}
+				macs, _, _ := unstructured.NestedSlice(config.Object, "spec", "interfaces")
+				assert.Len(t, macs, 1, "unexpected interfaces")
+				labels := config.GetLabels()
+				assert.Equal(t, inventoryName, labels[hostInventoryNameLabel], "unexpected inventory label")
+				assert.Equal(t, cdName, labels[constants.ClusterDeploymentNameLabel], "unexpected cluster deployment label")
+				assert.Equal(t, "test-infraenv", labels["infraenv"], "unexpected NMStateConfig label")
+				if assert.Len(t, config.GetOwnerReferences(), 1, "unexpected owner references") {
+					assert.Equal(t, inventoryName, config.GetOwnerReferences()[0].Name, "unexpected owner")
+				}
+			}
+			for _, name := range test.expectedMissing {
+				assert.Nil(t, getNMStateConfig(t, fakeClient, name), "unexpected NMStateConfig %s", name)
+			}
+			for _, name := range test.expectedUnchanged {
+				config := getNMStateConfig(t, fakeClient, name)
+				if assert.NotNil(t, config, "missing NMStateConfig %s", name) {
+					assert.Empty(t, config.GetLabels(), "unexpected labels")
+					assert.Equal(t, "other", config.Object["spec"].(map[string]interface{})["config"], "unexpected config")
+				}
+			}
+		})
+	}
+}
+
+func getNMStateConfig(t *testing.T, c client.Client, name string) *unstructured.Unstructured {
+	config := &unstructured.Unstructured{}
+	config.SetGroupVersionKind(nmStateConfigGVK)
+	if err := c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, config); err != nil {
+		return nil
+	}
+	return config
+}
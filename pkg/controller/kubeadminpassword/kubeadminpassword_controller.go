@@ -0,0 +1,268 @@
+// Package kubeadminpassword provides a controller which rotates the kubeadmin password of a cluster when the
+// ClusterDeployment is annotated to request it.
+package kubeadminpassword
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	"github.com/openshift/hive/pkg/secretencryption"
+)
+
+const (
+	ControllerName = hivev1.KubeadminPasswordControllerName
+
+	// kubeadminSecretNamespace, kubeadminSecretName and kubeadminSecretKey locate the bcrypt hash of the kubeadmin
+	// password in the remote cluster.
+	kubeadminSecretNamespace = "kube-system"
+	kubeadminSecretName      = "kubeadmin"
+	kubeadminSecretKey       = "kubeadmin"
+
+	// passwordCharacters are the characters of generated passwords. Like the passwords generated by the installer,
+	// they leave out characters which are easily confused with each other.
+	passwordCharacters = "abcdefghijkmnopqrstuvwxyzABCDEFGHIJKLMNPQRSTUVWXYZ23456789"
+	// passwordGroups and passwordGroupLength give generated passwords the xxxxx-xxxxx-xxxxx-xxxxx format of the
+	// passwords generated by the installer.
+	passwordGroups      = 4
+	passwordGroupLength = 5
+)
+
+// Add creates a new KubeadminPassword Controller and adds it to the Manager with default RBAC. The Manager will set
+// fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileKubeadminPassword {
+	r := &ReconcileKubeadminPassword{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+	}
+	r.remoteClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileKubeadminPassword, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	laneReconciler, err := controllerutils.NewUnreachableLaneReconciler(r, mgr.GetClient(), ControllerName)
+	if err != nil {
+		return err
+	}
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewStartupRampReconciler(laneReconciler, rateLimiter),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileKubeadminPassword{}
+
+// ReconcileKubeadminPassword reconciles a ClusterDeployment to rotate the kubeadmin password of the cluster.
+type ReconcileKubeadminPassword struct {
+	client.Client
+
+	// remoteClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+}
+
+// Reconcile rotates the kubeadmin password of the cluster when the ClusterDeployment carries the
+// rotate-kubeadmin-password annotation, and removes the annotation once the password has been rotated.
+func (r *ReconcileKubeadminPassword) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(ctx, request.NamespacedName, cd); {
+	case apierrors.IsNotFound(err):
+		cdLog.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		cdLog.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	if cd.Annotations[constants.RotateKubeadminPasswordAnnotation] != "true" {
+		return reconcile.Result{}, nil
+	}
+	if cd.DeletionTimestamp != nil {
+		cdLog.Debug("cluster deployment is being deleted")
+		return reconcile.Result{}, nil
+	}
+	if controllerutils.IsClusterPausedOrRelocating(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+	// The rotation waits for the cluster to be installed, awake and reachable. Each of these changes the
+	// ClusterDeployment, so the rotation is retried then.
+	if !cd.Spec.Installed {
+		cdLog.Debug("cluster installation is not complete")
+		return reconcile.Result{}, nil
+	}
+
+	rotated := false
+	if cd.Spec.ClusterMetadata == nil || cd.Spec.ClusterMetadata.AdminPasswordSecretRef == nil {
+		cdLog.Warn("cluster has no admin password secret, not rotating kubeadmin password")
+	} else {
+		remoteClient, unreachable, requeue := remoteclient.ConnectToRemoteCluster(
+			cd,
+			r.remoteClientBuilder(cd),
+			r.Client,
+			cdLog,
+		)
+		if unreachable {
+			return reconcile.Result{Requeue: requeue}, nil
+		}
+		switch fence, err := controllerutils.GetRemoteRelocationFence(remoteClient); {
+		case err != nil:
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get relocation fence")
+			return reconcile.Result{}, err
+		case controllerutils.IsFencedOut(cd, fence, cdLog):
+			// The password is rotated by the hub which owns the cluster.
+			return reconcile.Result{}, nil
+		}
+		var err error
+		if rotated, err = r.rotatePassword(cd, remoteClient, cdLog); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to rotate kubeadmin password")
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Record the rotation before removing the annotation, so that a failure to record it is retried rather than lost.
+	if rotated {
+		now := metav1.Now()
+		cd.Status.KubeadminPasswordRotatedTimestamp = &now
+		if err := r.Status().Update(ctx, cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+			return reconcile.Result{}, err
+		}
+	}
+	delete(cd.Annotations, constants.RotateKubeadminPasswordAnnotation)
+	if err := r.Update(ctx, cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to remove rotate-kubeadmin-password annotation")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// rotatePassword sets a new kubeadmin password on the remote cluster and in the admin password secret of the
+// ClusterDeployment. It returns false without error if the kubeadmin user has been removed from the cluster.
+func (r *ReconcileKubeadminPassword) rotatePassword(cd *hivev1.ClusterDeployment, remoteClient client.Client, cdLog log.FieldLogger) (bool, error) {
+	kubeadminSecret := &corev1.Secret{}
+	switch err := remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: kubeadminSecretNamespace, Name: kubeadminSecretName}, kubeadminSecret); {
+	case apierrors.IsNotFound(err):
+		cdLog.Warn("kubeadmin user has been removed from the cluster, not rotating kubeadmin password")
+		return false, nil
+	case err != nil:
+		return false, errors.Wrap(err, "could not get kubeadmin secret of the cluster")
+	}
+
+	passwordSecret := &corev1.Secret{}
+	passwordSecretName := types.NamespacedName{Namespace: cd.Namespace, Name: cd.Spec.ClusterMetadata.AdminPasswordSecretRef.Name}
+	if err := r.Get(context.TODO(), passwordSecretName, passwordSecret); err != nil {
+		return false, errors.Wrap(err, "could not get admin password secret")
+	}
+	encrypted, err := secretencryption.DecryptSecret(context.TODO(), r, passwordSecret)
+	if err != nil {
+		return false, errors.Wrap(err, "could not decrypt admin password secret")
+	}
+
+	password, err := generatePassword()
+	if err != nil {
+		return false, errors.Wrap(err, "could not generate password")
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return false, errors.Wrap(err, "could not hash password")
+	}
+
+	// The new password is stored before it is set on the cluster so that it cannot be lost. If the cluster cannot be
+	// updated, the rotation is retried with another new password.
+	if passwordSecret.Data == nil {
+		passwordSecret.Data = map[string][]byte{}
+	}
+	passwordSecret.Data[constants.PasswordSecretKey] = []byte(password)
+	if encrypted {
+		if err := secretencryption.EncryptSecret(context.TODO(), r, passwordSecret); err != nil {
+			return false, errors.Wrap(err, "could not encrypt admin password secret")
+		}
+	}
+	if err := r.Update(context.TODO(), passwordSecret); err != nil {
+		return false, errors.Wrap(err, "could not update admin password secret")
+	}
+	if kubeadminSecret.Data == nil {
+		kubeadminSecret.Data = map[string][]byte{}
+	}
+	kubeadminSecret.Data[kubeadminSecretKey] = hash
+	if err := remoteClient.Update(context.TODO(), kubeadminSecret); err != nil {
+		return false, errors.Wrap(err, "could not update kubeadmin secret of the cluster")
+	}
+	cdLog.Info("rotated kubeadmin password")
+	return true, nil
+}
+
+// generatePassword generates a random password in the format of the kubeadmin passwords generated by the installer.
+func generatePassword() (string, error) {
+	groups := make([]string, passwordGroups)
+	for i := range groups {
+		group := make([]byte, passwordGroupLength)
+		for j := range group {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(passwordCharacters))))
+			if err != nil {
+				return "", err
+			}
+			group[j] = passwordCharacters[n.Int64()]
+		}
+		groups[i] = string(group)
+	}
+	return strings.Join(groups, "-"), nil
+}
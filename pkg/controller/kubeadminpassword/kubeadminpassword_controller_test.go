@@ -0,0 +1,203 @@
+package kubeadminpassword
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+const (
+	namespace          = "test-namespace"
+	cdName             = "test-cluster-deployment"
+	passwordSecretName = "test-admin-password"
+	oldPassword        = "old-password"
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionFalse,
+		}),
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+				AdminPasswordSecretRef: &corev1.LocalObjectReference{Name: passwordSecretName},
+			}
+		},
+	)
+	rotate := testcd.WithAnnotation(constants.RotateKubeadminPasswordAnnotation, "true")
+
+	tests := []struct {
+		name                string
+		cd                  *hivev1.ClusterDeployment
+		noKubeadminSecret   bool
+		failStatusUpdate    bool
+		expectConnect       bool
+		expectErr           bool
+		expectRotated       bool
+		expectAnnotationSet bool
+	}{
+		{
+			name: "not requested",
+			cd:   cdBuilder.Build(),
+		},
+		{
+			name:                "not installed",
+			cd:                  cdBuilder.Build(rotate, func(cd *hivev1.ClusterDeployment) { cd.Spec.Installed = false }),
+			expectAnnotationSet: true,
+		},
+		{
+			name:                "hibernating",
+			cd:                  cdBuilder.Build(rotate, testcd.WithStatusPowerState(hivev1.HibernatingHibernationReason)),
+			expectAnnotationSet: true,
+		},
+		{
+			name:          "rotate",
+			cd:            cdBuilder.Build(rotate),
+			expectConnect: true,
+			expectRotated: true,
+		},
+		{
+			name:                "status update fails",
+			cd:                  cdBuilder.Build(rotate),
+			failStatusUpdate:    true,
+			expectConnect:       true,
+			expectErr:           true,
+			expectAnnotationSet: true,
+		},
+		{
+			name:              "kubeadmin removed",
+			cd:                cdBuilder.Build(rotate),
+			noKubeadminSecret: true,
+			expectConnect:     true,
+		},
+		{
+			name: "no admin password secret",
+			cd: cdBuilder.Build(rotate, func(cd *hivev1.ClusterDeployment) {
+				cd.Spec.ClusterMetadata.AdminPasswordSecretRef = nil
+			}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			oldHash, err := bcrypt.GenerateFromPassword([]byte(oldPassword), bcrypt.MinCost)
+			require.NoError(t, err)
+			var remoteObjects []runtime.Object
+			if !test.noKubeadminSecret {
+				remoteObjects = append(remoteObjects, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: kubeadminSecretNamespace, Name: kubeadminSecretName},
+					Data:       map[string][]byte{kubeadminSecretKey: oldHash},
+				})
+			}
+			remoteClient := fake.NewFakeClientWithScheme(scheme, remoteObjects...)
+			mockBuilder := remoteclientmock.NewMockBuilder(ctrl)
+			if test.expectConnect {
+				mockBuilder.EXPECT().Build().Return(remoteClient, nil)
+			}
+
+			c := fake.NewFakeClientWithScheme(scheme, test.cd, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: passwordSecretName},
+				Data: map[string][]byte{
+					constants.UsernameSecretKey: []byte("kubeadmin"),
+					constants.PasswordSecretKey: []byte(oldPassword),
+				},
+			})
+			reconciler := &ReconcileKubeadminPassword{
+				Client: c,
+				remoteClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					return mockBuilder
+				},
+			}
+			if test.failStatusUpdate {
+				reconciler.Client = &failingStatusClient{Client: c}
+			}
+			_, err = reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			if test.expectErr {
+				require.Error(t, err, "expected error from reconcile")
+			} else {
+				require.NoError(t, err, "unexpected error from reconcile")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(test.cd), cd))
+			_, annotationSet := cd.Annotations[constants.RotateKubeadminPasswordAnnotation]
+			assert.Equal(t, test.expectAnnotationSet, annotationSet, "unexpected rotate-kubeadmin-password annotation")
+
+			passwordSecret := &corev1.Secret{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: passwordSecretName}, passwordSecret))
+			password := string(passwordSecret.Data[constants.PasswordSecretKey])
+			if test.failStatusUpdate {
+				// The rotation is retried while the annotation remains, since it could not be recorded.
+				assert.NotEqual(t, oldPassword, password, "expected new password")
+				return
+			}
+			if !test.expectRotated {
+				assert.Nil(t, cd.Status.KubeadminPasswordRotatedTimestamp, "unexpected rotation timestamp")
+				assert.Equal(t, oldPassword, password, "unexpected password")
+				return
+			}
+			assert.NotNil(t, cd.Status.KubeadminPasswordRotatedTimestamp, "expected rotation timestamp")
+			assert.NotEqual(t, oldPassword, password, "expected new password")
+			kubeadminSecret := &corev1.Secret{}
+			require.NoError(t, remoteClient.Get(context.TODO(), types.NamespacedName{Namespace: kubeadminSecretNamespace, Name: kubeadminSecretName}, kubeadminSecret))
+			assert.NoError(t, bcrypt.CompareHashAndPassword(kubeadminSecret.Data[kubeadminSecretKey], []byte(password)), "kubeadmin secret does not match new password")
+		})
+	}
+}
+
+// failingStatusClient fails all status updates.
+type failingStatusClient struct {
+	client.Client
+}
+
+func (c *failingStatusClient) Status() client.StatusWriter {
+	return &failingStatusWriter{}
+}
+
+type failingStatusWriter struct {
+	client.StatusWriter
+}
+
+func (w *failingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return errors.New("status update failed")
+}
+
+func TestGeneratePassword(t *testing.T) {
+	password, err := generatePassword()
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[a-km-zA-NP-Z2-9]{5}(-[a-km-zA-NP-Z2-9]{5}){3}$`), password, "unexpected password format")
+	other, err := generatePassword()
+	require.NoError(t, err)
+	assert.NotEqual(t, password, other, "expected different passwords")
+}
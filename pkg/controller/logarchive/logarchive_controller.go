@@ -0,0 +1,258 @@
+// Package logarchive provides a controller which archives the logs of install and uninstall pods once their jobs
+// finish, so that troubleshooting does not depend on the lifetime of the pods.
+package logarchive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/logarchive"
+)
+
+const (
+	ControllerName = hivev1.LogArchiveControllerName
+
+	// jobNameLabel is the label set by Kubernetes on the pods of a job to the name of the job.
+	jobNameLabel = "job-name"
+)
+
+// Add creates a new LogArchive Controller and adds it to the Manager with default RBAC when a log archive is configured
+// in HiveConfig. The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	config, err := logarchive.ReadConfig()
+	if err != nil {
+		logger.WithError(err).Error("could not read the log archive config")
+		return err
+	}
+	if config == nil || config.AWS == nil {
+		logger.Debug("log archive is not configured")
+		return nil
+	}
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, config, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, config *hivev1.LogArchiveConfig, rateLimiter flowcontrol.RateLimiter) *ReconcileLogArchive {
+	r := &ReconcileLogArchive{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		pods:   kubernetes.NewForConfigOrDie(mgr.GetConfig()).CoreV1(),
+	}
+	r.archiveFn = func() (logarchive.Archive, error) {
+		return logarchive.New(r.Client, controllerutils.GetHiveNamespace(), config)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileLogArchive, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to install and uninstall jobs
+	if err := c.Watch(
+		&source.Kind{Type: &batchv1.Job{}},
+		&handler.EnqueueRequestForObject{},
+		predicate.NewPredicateFuncs(isInstallOrUninstallJob),
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func isInstallOrUninstallJob(obj client.Object) bool {
+	labels := obj.GetLabels()
+	return labels[constants.InstallJobLabel] == "true" || labels[constants.UninstallJobLabel] == "true"
+}
+
+var _ reconcile.Reconciler = &ReconcileLogArchive{}
+
+// ReconcileLogArchive reconciles an install or uninstall job to archive the logs of its pods.
+type ReconcileLogArchive struct {
+	client.Client
+
+	// pods gets the pods of the jobs and their logs.
+	pods corev1client.PodsGetter
+
+	// archiveFn returns the archive the logs are stored in. It is overridden in tests.
+	archiveFn func() (logarchive.Archive, error)
+}
+
+// Reconcile archives the logs of the pods of a finished install or uninstall job, and records the keys of the logs in
+// an annotation of the ClusterProvision or ClusterDeprovision owning the job.
+func (r *ReconcileLogArchive) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	jobLog := controllerutils.BuildControllerLogger(ControllerName, "job", request.NamespacedName)
+	jobLog.Debug("reconciling job")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, jobLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	job := &batchv1.Job{}
+	switch err := r.Get(ctx, request.NamespacedName, job); {
+	case apierrors.IsNotFound(err):
+		jobLog.Debug("job not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		jobLog.WithError(err).Error("error getting job")
+		return reconcile.Result{}, err
+	}
+	if !isFinished(job) {
+		jobLog.Debug("job has not finished")
+		return reconcile.Result{}, nil
+	}
+
+	owner, cdName, err := r.getOwner(ctx, job)
+	if err != nil {
+		jobLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get the owner of the job")
+		return reconcile.Result{}, err
+	}
+	if owner == nil {
+		jobLog.Debug("job is not owned by a cluster provision or deprovision")
+		return reconcile.Result{}, nil
+	}
+	if _, ok := owner.GetAnnotations()[constants.ArchivedLogsAnnotation]; ok {
+		jobLog.Debug("logs of the job are already archived")
+		return reconcile.Result{}, nil
+	}
+
+	keys, err := r.archiveLogs(ctx, job, owner.GetName(), cdName, jobLog)
+	if err != nil {
+		jobLog.WithError(err).Error("could not archive the logs of the job")
+		return reconcile.Result{}, err
+	}
+
+	value, err := json.Marshal(keys)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	annotations := owner.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[constants.ArchivedLogsAnnotation] = string(value)
+	owner.SetAnnotations(annotations)
+	if err := r.Update(ctx, owner); err != nil {
+		jobLog.WithError(err).Log(controllerutils.LogLevel(err), "could not record the archived logs")
+		return reconcile.Result{}, err
+	}
+	jobLog.WithField("logs", len(keys)).Info("archived the logs of the job")
+	return reconcile.Result{}, nil
+}
+
+// getOwner returns the ClusterProvision or ClusterDeprovision controlling the job, and the name of its
+// ClusterDeployment. A nil owner is returned when the job is not controlled by either, or the owner is gone.
+func (r *ReconcileLogArchive) getOwner(ctx context.Context, job *batchv1.Job) (client.Object, string, error) {
+	ref := metav1.GetControllerOf(job)
+	if ref == nil {
+		return nil, "", nil
+	}
+	key := client.ObjectKey{Namespace: job.Namespace, Name: ref.Name}
+	var owner client.Object
+	var cdName string
+	switch ref.Kind {
+	case "ClusterProvision":
+		provision := &hivev1.ClusterProvision{}
+		if err := r.Get(ctx, key, provision); err != nil {
+			return nil, "", client.IgnoreNotFound(err)
+		}
+		owner, cdName = provision, provision.Spec.ClusterDeploymentRef.Name
+	case "ClusterDeprovision":
+		deprovision := &hivev1.ClusterDeprovision{}
+		if err := r.Get(ctx, key, deprovision); err != nil {
+			return nil, "", client.IgnoreNotFound(err)
+		}
+		// ClusterDeprovisions share the name of their ClusterDeployment.
+		owner, cdName = deprovision, deprovision.Name
+	default:
+		return nil, "", nil
+	}
+	return owner, cdName, nil
+}
+
+// archiveLogs stores the logs of every container of the pods of the job, and returns their keys.
+func (r *ReconcileLogArchive) archiveLogs(ctx context.Context, job *batchv1.Job, ownerName, cdName string, logger log.FieldLogger) ([]string, error) {
+	pods, err := r.pods.Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", jobNameLabel, job.Name),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list the pods of the job")
+	}
+	archive, err := r.archiveFn()
+	if err != nil {
+		return nil, err
+	}
+	if err := archive.EnsureRetention(); err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for _, pod := range pods.Items {
+		containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, container := range containers {
+			key := logarchive.Key(job.Namespace, cdName, ownerName, pod.Name, container.Name)
+			logger.WithField("key", key).Debug("archiving log")
+			if err := r.archiveLog(ctx, archive, &pod, container.Name, key); err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (r *ReconcileLogArchive) archiveLog(ctx context.Context, archive logarchive.Archive, pod *corev1.Pod, container, key string) error {
+	stream, err := r.pods.Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: container}).Stream(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "could not get the log of container %s of pod %s", container, pod.Name)
+	}
+	defer stream.Close()
+	return archive.Put(key, stream)
+}
+
+func isFinished(job *batchv1.Job) bool {
+	for _, cond := range job.Status.Conditions {
+		if (cond.Type == batchv1.JobComplete || cond.Type == batchv1.JobFailed) && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package logarchive
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/logarchive"
+)
+
+const (
+	namespace       = "test-namespace"
+	cdName          = "test-cluster"
+	provisionName   = "test-cluster-0-abcde"
+	jobName         = "test-cluster-0-abcde-provision"
+	podName         = "test-cluster-0-abcde-provision-xyz"
+	otherJobPodName = "other-pod"
+)
+
+type fakeArchive struct {
+	logs             map[string]string
+	retentionEnsured bool
+}
+
+func (a *fakeArchive) Put(key string, body io.Reader) error {
+	b, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	a.logs[key] = string(b)
+	return nil
+}
+
+func (a *fakeArchive) EnsureRetention() error {
+	a.retentionEnsured = true
+	return nil
+}
+
+func (a *fakeArchive) SignURL(key string, expiry time.Duration) (string, error) {
+	return "https://example.com/" + key, nil
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	batchv1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	provision := func(annotations map[string]string) *hivev1.ClusterProvision {
+		return &hivev1.ClusterProvision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        provisionName,
+				UID:         types.UID("provision-uid"),
+				Annotations: annotations,
+			},
+			Spec: hivev1.ClusterProvisionSpec{ClusterDeploymentRef: corev1.LocalObjectReference{Name: cdName}},
+		}
+	}
+	job := func(conditions ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      jobName,
+				Labels:    map[string]string{constants.InstallJobLabel: "true"},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: hivev1.SchemeGroupVersion.String(),
+					Kind:       "ClusterProvision",
+					Name:       provisionName,
+					UID:        types.UID("provision-uid"),
+					Controller: pointer.BoolPtr(true),
+				}},
+			},
+			Status: batchv1.JobStatus{Conditions: conditions},
+		}
+	}
+	pod := func(name, job string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels:    map[string]string{jobNameLabel: job},
+			},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "installer"}},
+				Containers:     []corev1.Container{{Name: "hive"}},
+			},
+		}
+	}
+	failed := batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}
+
+	tests := []struct {
+		name         string
+		existing     []runtime.Object
+		expectedKeys []string
+	}{
+		{
+			name:     "job running",
+			existing: []runtime.Object{provision(nil), job()},
+		},
+		{
+			name:     "job failed",
+			existing: []runtime.Object{provision(nil), job(failed)},
+			expectedKeys: []string{
+				logarchive.Key(namespace, cdName, provisionName, podName, "installer"),
+				logarchive.Key(namespace, cdName, provisionName, podName, "hive"),
+			},
+		},
+		{
+			name:     "logs already archived",
+			existing: []runtime.Object{provision(map[string]string{constants.ArchivedLogsAnnotation: "[]"}), job(failed)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(test.existing...).Build()
+			kubeClient := kubefake.NewSimpleClientset(pod(podName, jobName), pod(otherJobPodName, "other-job"))
+			archive := &fakeArchive{logs: map[string]string{}}
+			r := &ReconcileLogArchive{
+				Client:    c,
+				pods:      kubeClient.CoreV1(),
+				archiveFn: func() (logarchive.Archive, error) { return archive, nil },
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: jobName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			keys := []string{}
+			for key := range archive.logs {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, test.expectedKeys, keys, "unexpected archived logs")
+			if len(test.expectedKeys) == 0 {
+				return
+			}
+			assert.True(t, archive.retentionEnsured, "expected retention to be ensured")
+
+			p := &hivev1.ClusterProvision{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: provisionName}, p))
+			recorded := []string{}
+			require.NoError(t, json.Unmarshal([]byte(p.Annotations[constants.ArchivedLogsAnnotation]), &recorded), "could not parse archived logs annotation")
+			assert.Equal(t, test.expectedKeys, recorded, "unexpected recorded logs")
+		})
+	}
+}
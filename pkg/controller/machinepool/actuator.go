@@ -20,3 +20,21 @@ type Actuator interface {
 	// to wait before we can proceed with reconciling. (e.g. obtaining a pool name lease)
 	GenerateMachineSets(*hivev1.ClusterDeployment, *hivev1.MachinePool, log.FieldLogger) (msets []*machineapi.MachineSet, proceed bool, genError error)
 }
+
+// InstanceTagReconciler is implemented by actuators which can update the tags of the running instances of a
+// MachinePool, for pools with the Instances tag reconciliation mode.
+type InstanceTagReconciler interface {
+
+	// ReconcileInstanceTags ensures that the instances backing the given machines of the MachinePool carry the tags
+	// the actuator generates for new machines.
+	ReconcileInstanceTags(*hivev1.ClusterDeployment, *hivev1.MachinePool, []machineapi.Machine, log.FieldLogger) error
+}
+
+// MachineSetInfrastructureReporter is implemented by actuators which can report the cloud infrastructure that the
+// machines of a MachineSet are created with.
+type MachineSetInfrastructureReporter interface {
+
+	// MachineSetInfrastructure returns the zone, subnet, instance type and image resolved in the provider spec of the
+	// MachineSet.
+	MachineSetInfrastructure(*machineapi.MachineSet) (*hivev1.MachineSetInfrastructure, error)
+}
@@ -0,0 +1,111 @@
+package machinepool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/machinesetgen"
+)
+
+// adoptExistingMachineSets renames the generated MachineSets of a machine pool with adoptExisting to the remote
+// MachineSets they adopt, so that the remote MachineSets are synced in place instead of being duplicated. A generated
+// MachineSet without a remote MachineSet of its own name adopts an adoptable remote MachineSet of the same zone. The
+// names of the adopted MachineSets are returned sorted.
+func adoptExistingMachineSets(
+	cd *hivev1.ClusterDeployment,
+	pool *hivev1.MachinePool,
+	generatedMachineSets []*machineapi.MachineSet,
+	remoteMachineSets *machineapi.MachineSetList,
+	logger log.FieldLogger,
+) []string {
+	generatedNames := sets.NewString()
+	for _, ms := range generatedMachineSets {
+		generatedNames.Insert(ms.Name)
+	}
+	remoteNames := sets.NewString()
+	for _, rMS := range remoteMachineSets.Items {
+		remoteNames.Insert(rMS.Name)
+	}
+
+	adopted := sets.NewString()
+	for _, ms := range generatedMachineSets {
+		if remoteNames.Has(ms.Name) {
+			continue
+		}
+		zone := machinesetgen.Zone(ms)
+		for i := range remoteMachineSets.Items {
+			rMS := &remoteMachineSets.Items[i]
+			if generatedNames.Has(rMS.Name) || adopted.Has(rMS.Name) || !isAdoptable(cd, pool, rMS) ||
+				machinesetgen.Zone(rMS) != zone {
+				continue
+			}
+			logger.WithField("machineset", rMS.Name).WithField("generated", ms.Name).Info("adopting existing machineset")
+			ms.Name = rMS.Name
+			if ms.Annotations == nil {
+				ms.Annotations = map[string]string{}
+			}
+			ms.Annotations[constants.AdoptedMachineSetAnnotation] = pool.Spec.Name
+			adopted.Insert(rMS.Name)
+			break
+		}
+	}
+	return adopted.List()
+}
+
+// isAdoptable returns true if a remote MachineSet can be adopted by the machine pool. It must be named like the
+// generated MachineSets of the pool, after the infra ID of the cluster and the pool, and either not be managed by Hive
+// or already be adopted by the pool.
+func isAdoptable(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, ms *machineapi.MachineSet) bool {
+	if cd.Spec.ClusterMetadata == nil {
+		return false
+	}
+	prefix := strings.Join([]string{cd.Spec.ClusterMetadata.InfraID, pool.Spec.Name, ""}, "-")
+	if ms.Namespace != machineAPINamespace || !strings.HasPrefix(ms.Name, prefix) {
+		return false
+	}
+	if adoptedBy, ok := ms.Annotations[constants.AdoptedMachineSetAnnotation]; ok {
+		return adoptedBy == pool.Spec.Name
+	}
+	_, managed := ms.Labels[machinesetgen.MachinePoolNameLabel]
+	return !managed
+}
+
+// setAdoptedCondition reports the remote MachineSets adopted by the machine pool. The condition is only added to the
+// pool once MachineSets have been adopted.
+func (r *ReconcileMachinePool) setAdoptedCondition(pool *hivev1.MachinePool, adopted []string, logger log.FieldLogger) error {
+	status, reason, message := corev1.ConditionFalse, "NoMachineSetsAdopted", "No existing MachineSets are adopted"
+	if len(adopted) > 0 {
+		status, reason = corev1.ConditionTrue, "MachineSetsAdopted"
+		message = fmt.Sprintf("Existing MachineSets were adopted: %s", strings.Join(adopted, ", "))
+	} else if cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.AdoptedMachinePoolCondition); cond == nil || cond.Status != corev1.ConditionTrue {
+		return nil
+	}
+	conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.AdoptedMachinePoolCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	pool.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), pool); err != nil {
+		logger.WithError(err).Error("failed to update MachinePool conditions")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package machinepool
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	awsproviderapis "sigs.k8s.io/cluster-api-provider-aws/pkg/apis"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/machinesetgen"
+)
+
+func TestAdoptExistingMachineSets(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	awsproviderapis.AddToScheme(scheme.Scheme)
+	machineSet := func(name, zone string) *machineapi.MachineSet {
+		ms := testMachineSet(name, "worker", false, 1, 0)
+		spec := testAWSProviderSpec()
+		spec.Placement.AvailabilityZone = zone
+		rawSpec, err := encodeAWSMachineProviderSpec(spec, scheme.Scheme)
+		require.NoError(t, err, "unexpected error encoding provider spec")
+		ms.Spec.Template.Spec.ProviderSpec.Value = rawSpec
+		return ms
+	}
+	handMade := func(name, zone string) *machineapi.MachineSet {
+		ms := machineSet(name, zone)
+		delete(ms.Labels, machinesetgen.MachinePoolNameLabel)
+		delete(ms.Labels, constants.HiveManagedLabel)
+		return ms
+	}
+	adoptedBy := func(ms *machineapi.MachineSet, pool string) *machineapi.MachineSet {
+		ms.Annotations[constants.AdoptedMachineSetAnnotation] = pool
+		return ms
+	}
+	cases := []struct {
+		name          string
+		remote        []*machineapi.MachineSet
+		expectedNames []string
+		expectAdopted []string
+	}{
+		{
+			name:          "nothing to adopt",
+			expectedNames: []string{"foo-12345-worker-us-east-1a", "foo-12345-worker-us-east-1b"},
+		},
+		{
+			name: "generated machine sets exist",
+			remote: []*machineapi.MachineSet{
+				machineSet("foo-12345-worker-us-east-1a", "us-east-1a"),
+				handMade("foo-12345-worker-custom-a", "us-east-1a"),
+			},
+			expectedNames: []string{"foo-12345-worker-us-east-1a", "foo-12345-worker-us-east-1b"},
+		},
+		{
+			name: "hand-made machine sets adopted by zone",
+			remote: []*machineapi.MachineSet{
+				handMade("foo-12345-worker-custom-b", "us-east-1b"),
+				handMade("foo-12345-worker-custom-a", "us-east-1a"),
+			},
+			expectedNames: []string{"foo-12345-worker-custom-a", "foo-12345-worker-custom-b"},
+			expectAdopted: []string{"foo-12345-worker-custom-a", "foo-12345-worker-custom-b"},
+		},
+		{
+			name: "one machine set adopted per zone",
+			remote: []*machineapi.MachineSet{
+				handMade("foo-12345-worker-custom-a1", "us-east-1a"),
+				handMade("foo-12345-worker-custom-a2", "us-east-1a"),
+			},
+			expectedNames: []string{"foo-12345-worker-custom-a1", "foo-12345-worker-us-east-1b"},
+			expectAdopted: []string{"foo-12345-worker-custom-a1"},
+		},
+		{
+			name: "already adopted",
+			remote: []*machineapi.MachineSet{
+				adoptedBy(machineSet("foo-12345-worker-custom-a", "us-east-1a"), "worker"),
+			},
+			expectedNames: []string{"foo-12345-worker-custom-a", "foo-12345-worker-us-east-1b"},
+			expectAdopted: []string{"foo-12345-worker-custom-a"},
+		},
+		{
+			name: "adopted by another pool",
+			remote: []*machineapi.MachineSet{
+				adoptedBy(machineSet("foo-12345-worker-custom-a", "us-east-1a"), "other"),
+			},
+			expectedNames: []string{"foo-12345-worker-us-east-1a", "foo-12345-worker-us-east-1b"},
+		},
+		{
+			name: "managed by hive",
+			remote: []*machineapi.MachineSet{
+				machineSet("foo-12345-worker-custom-a", "us-east-1a"),
+			},
+			expectedNames: []string{"foo-12345-worker-us-east-1a", "foo-12345-worker-us-east-1b"},
+		},
+		{
+			name: "other name prefix",
+			remote: []*machineapi.MachineSet{
+				handMade("foo-12345-infra-a", "us-east-1a"),
+			},
+			expectedNames: []string{"foo-12345-worker-us-east-1a", "foo-12345-worker-us-east-1b"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			generated := []*machineapi.MachineSet{
+				machineSet("foo-12345-worker-us-east-1a", "us-east-1a"),
+				machineSet("foo-12345-worker-us-east-1b", "us-east-1b"),
+			}
+			remote := &machineapi.MachineSetList{}
+			for _, ms := range tc.remote {
+				remote.Items = append(remote.Items, *ms)
+			}
+
+			adopted := adoptExistingMachineSets(testClusterDeployment(), testMachinePool(), generated, remote, log.WithField("test", tc.name))
+			assert.ElementsMatch(t, tc.expectAdopted, adopted, "unexpected adopted machine sets")
+			var names []string
+			for _, ms := range generated {
+				names = append(names, ms.Name)
+				_, annotated := ms.Annotations[constants.AdoptedMachineSetAnnotation]
+				assert.Equal(t, sets.NewString(tc.expectAdopted...).Has(ms.Name), annotated, "unexpected adopted annotation on %s", ms.Name)
+			}
+			assert.Equal(t, tc.expectedNames, names, "unexpected generated machine set names")
+		})
+	}
+}
+
+func TestSetAdoptedCondition(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	cases := []struct {
+		name           string
+		existing       *corev1.ConditionStatus
+		adopted        []string
+		expectedStatus corev1.ConditionStatus
+	}{
+		{
+			name: "nothing adopted",
+		},
+		{
+			name:           "adopted",
+			adopted:        []string{"foo-12345-worker-custom-a"},
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name:           "no longer adopted",
+			existing:       func() *corev1.ConditionStatus { s := corev1.ConditionTrue; return &s }(),
+			expectedStatus: corev1.ConditionFalse,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testMachinePool()
+			if tc.existing != nil {
+				pool.Status.Conditions = append(pool.Status.Conditions, hivev1.MachinePoolCondition{
+					Type:   hivev1.AdoptedMachinePoolCondition,
+					Status: *tc.existing,
+				})
+			}
+			r := &ReconcileMachinePool{Client: fake.NewClientBuilder().WithRuntimeObjects(pool).Build()}
+
+			require.NoError(t, r.setAdoptedCondition(pool, tc.adopted, log.WithField("test", tc.name)), "unexpected error")
+			cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.AdoptedMachinePoolCondition)
+			if tc.expectedStatus == "" {
+				assert.Nil(t, cond, "unexpected Adopted condition")
+			} else if assert.NotNil(t, cond, "expected Adopted condition") {
+				assert.Equal(t, tc.expectedStatus, cond.Status, "unexpected condition status")
+			}
+		})
+	}
+}
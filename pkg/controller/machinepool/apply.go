@@ -0,0 +1,169 @@
+package machinepool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// fieldManager is the field manager of the fields of remote MachineSets which Hive applies.
+	fieldManager = "hive"
+)
+
+// supersededFieldManagers are the field managers of remote MachineSets whose fields Hive takes over without reporting
+// a conflict: Hive itself, which creates MachineSets, Hive before it applied MachineSets, whose updates were recorded
+// under the name of its binary, and the installer, which creates the MachineSets of the worker pool.
+var supersededFieldManagers = sets.NewString(fieldManager, "manager", "cluster-bootstrap")
+
+// machineSetApplyConfiguration returns the fields of the MachineSet which Hive syncs, as an apply configuration.
+// Fields which Hive does not change must still be applied with their current value, as fields Hive applied before
+// and leaves out are removed.
+func machineSetApplyConfiguration(ms *machineapi.MachineSet, authority hivev1.MachineAuthority) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	u.SetGroupVersionKind(machineapi.SchemeGroupVersion.WithKind("MachineSet"))
+	u.SetNamespace(ms.Namespace)
+	u.SetName(ms.Name)
+	u.SetLabels(ms.Labels)
+	u.SetAnnotations(ms.Annotations)
+	if ms.Spec.Replicas != nil {
+		if err := unstructured.SetNestedField(u.Object, int64(*ms.Spec.Replicas), "spec", "replicas"); err != nil {
+			return nil, err
+		}
+	}
+	if authority == hivev1.ClusterAPIMachineAuthority {
+		if err := unstructured.SetNestedField(u.Object, string(authority), "spec", "authoritativeAPI"); err != nil {
+			return nil, err
+		}
+	}
+	machineSpec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&machineapi.MachineSpec{
+		ObjectMeta:   machineapi.ObjectMeta{Labels: ms.Spec.Template.Spec.Labels},
+		Taints:       ms.Spec.Template.Spec.Taints,
+		ProviderSpec: ms.Spec.Template.Spec.ProviderSpec,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not convert machineset %s", ms.Name)
+	}
+	// The conversion of the machine spec leaves empty structs, which would be applied as such.
+	if metadata, ok := machineSpec["metadata"].(map[string]interface{}); ok && len(metadata) == 0 {
+		delete(machineSpec, "metadata")
+	}
+	if len(machineSpec) > 0 {
+		if err := unstructured.SetNestedMap(u.Object, machineSpec, "spec", "template", "spec"); err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+// applyMachineSet applies the synced fields of the MachineSet to the remote cluster. Conflicts with field managers
+// Hive supersedes are forced, as are all conflicts when force is true. The names of the conflicting fields and their
+// managers are returned when the MachineSet conflicts with other field managers, in which case it is left alone.
+func applyMachineSet(
+	ms *machineapi.MachineSet,
+	authority hivev1.MachineAuthority,
+	force bool,
+	remoteClusterAPIClient client.Client,
+) (*machineapi.MachineSet, []string, error) {
+	u, err := machineSetApplyConfiguration(ms, authority)
+	if err != nil {
+		return nil, nil, err
+	}
+	apply := func(force bool) error {
+		opts := []client.PatchOption{client.FieldOwner(fieldManager)}
+		if force {
+			opts = append(opts, client.ForceOwnership)
+		}
+		return remoteClusterAPIClient.Patch(context.Background(), u, client.Apply, opts...)
+	}
+	err = apply(force)
+	if conflicts, managers := applyConflicts(err); len(conflicts) > 0 {
+		if !supersededFieldManagers.HasAll(managers...) {
+			return nil, conflicts, nil
+		}
+		err = apply(true)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	applied := &machineapi.MachineSet{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, applied); err != nil {
+		return nil, nil, errors.Wrapf(err, "could not convert machineset %s", ms.Name)
+	}
+	return applied, nil, nil
+}
+
+// applyConflicts returns the conflicting fields, with their managers, and the names of the conflicting managers of a
+// failed apply. Nothing is returned for other errors.
+func applyConflicts(err error) ([]string, []string) {
+	if !apierrors.IsConflict(err) {
+		return nil, nil
+	}
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return nil, nil
+	}
+	var conflicts []string
+	managers := sets.NewString()
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		// The message of a cause reads `conflict with "<manager>"`, followed by details of updates.
+		manager := strings.TrimPrefix(cause.Message, "conflict with ")
+		if parts := strings.SplitN(manager, `"`, 3); len(parts) == 3 && parts[0] == "" {
+			manager = parts[1]
+		}
+		managers.Insert(manager)
+		conflicts = append(conflicts, fmt.Sprintf("%s (%s)", cause.Field, manager))
+	}
+	sort.Strings(conflicts)
+	return conflicts, managers.List()
+}
+
+// setMachineSetConflictCondition reports the remote MachineSets of the machine pool which were not applied because
+// their fields are managed by other field managers. The condition is only added to the pool once there are conflicts.
+func (r *ReconcileMachinePool) setMachineSetConflictCondition(pool *hivev1.MachinePool, conflicts []string, logger log.FieldLogger) error {
+	status, reason, message := corev1.ConditionFalse, "NoConflicts", "Remote MachineSets do not conflict with other field managers"
+	if len(conflicts) > 0 {
+		status, reason = corev1.ConditionTrue, "FieldManagerConflict"
+		message = fmt.Sprintf("Remote MachineSets were not applied as their fields are managed by others, annotate the pool with %s=true to take them over: %s",
+			hivev1.MachinePoolForceApplyAnnotation, strings.Join(conflicts, "; "))
+	} else if controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.MachineSetConflictMachinePoolCondition) == nil {
+		return nil
+	}
+	conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.MachineSetConflictMachinePoolCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	pool.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), pool); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update MachineSetConflict condition")
+		return errors.Wrap(err, "could not update MachinePool status")
+	}
+	return nil
+}
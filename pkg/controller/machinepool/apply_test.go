@@ -0,0 +1,203 @@
+package machinepool
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// applyingClient applies server-side apply patches as merge patches, which the fake client does not support, and
+// increments the generation of objects whose spec changed, as the API server would.
+type applyingClient struct {
+	client.Client
+}
+
+func (c *applyingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	}
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		return err
+	}
+	if err := c.Client.Patch(ctx, obj, client.RawPatch(types.MergePatchType, data)); err != nil {
+		return err
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok || reflect.DeepEqual(existing.Object["spec"], u.Object["spec"]) {
+		return nil
+	}
+	u.SetGeneration(existing.GetGeneration() + 1)
+	return c.Update(ctx, u)
+}
+
+// conflictingClient fails applies which are not forced with conflicts of its fields with their managers.
+type conflictingClient struct {
+	applyingClient
+	conflicts map[string]string
+	forced    bool
+}
+
+func (c *conflictingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	patchOpts := &client.PatchOptions{}
+	patchOpts.ApplyOptions(opts)
+	if patch.Type() == types.ApplyPatchType && len(c.conflicts) > 0 {
+		if patchOpts.Force == nil || !*patchOpts.Force {
+			var causes []metav1.StatusCause
+			for field, manager := range c.conflicts {
+				causes = append(causes, metav1.StatusCause{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "` + manager + `" using machine.openshift.io/v1beta1`,
+					Field:   field,
+				})
+			}
+			return apierrors.NewApplyConflict(causes, "Apply failed")
+		}
+		c.forced = true
+	}
+	return c.applyingClient.Patch(ctx, obj, patch, opts...)
+}
+
+func TestApplyConflicts(t *testing.T) {
+	err := apierrors.NewApplyConflict([]metav1.StatusCause{
+		{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "machine-api-operator" using machine.openshift.io/v1beta1 at 2026-10-18T17:30:35Z`,
+			Field:   ".spec.replicas",
+		},
+		{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kubectl"`,
+			Field:   ".metadata.labels.team",
+		},
+		{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "unrelated",
+			Field:   ".spec",
+		},
+	}, "Apply failed with 2 conflicts")
+
+	conflicts, managers := applyConflicts(err)
+	assert.Equal(t, []string{".metadata.labels.team (kubectl)", ".spec.replicas (machine-api-operator)"}, conflicts, "unexpected conflicts")
+	assert.Equal(t, []string{"kubectl", "machine-api-operator"}, managers, "unexpected managers")
+
+	conflicts, managers = applyConflicts(apierrors.NewConflict(machineapi.Resource("machinesets"), "foo", nil))
+	assert.Empty(t, conflicts, "unexpected conflicts of a failed update")
+	assert.Empty(t, managers, "unexpected managers of a failed update")
+}
+
+func TestApplyMachineSet(t *testing.T) {
+	machineapi.AddToScheme(scheme.Scheme)
+
+	tests := []struct {
+		name               string
+		conflicts          map[string]string
+		force              bool
+		expectConflicts    []string
+		expectForced       bool
+		expectedReplicas   int32
+		expectedGeneration int64
+	}{
+		{
+			name:               "no conflicts",
+			expectedReplicas:   3,
+			expectedGeneration: 2,
+		},
+		{
+			name:               "conflict with superseded manager",
+			conflicts:          map[string]string{".spec.replicas": "manager"},
+			expectForced:       true,
+			expectedReplicas:   3,
+			expectedGeneration: 2,
+		},
+		{
+			name:               "conflict with other manager",
+			conflicts:          map[string]string{".spec.replicas": "kubectl-edit"},
+			expectConflicts:    []string{".spec.replicas (kubectl-edit)"},
+			expectedReplicas:   1,
+			expectedGeneration: 1,
+		},
+		{
+			name:               "forced conflict with other manager",
+			conflicts:          map[string]string{".spec.replicas": "kubectl-edit"},
+			force:              true,
+			expectForced:       true,
+			expectedReplicas:   3,
+			expectedGeneration: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			existing := testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 1)
+			c := &conflictingClient{
+				applyingClient: applyingClient{fake.NewClientBuilder().WithRuntimeObjects(existing).Build()},
+				conflicts:      test.conflicts,
+			}
+			ms := testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 3, 0)
+
+			applied, conflicts, err := applyMachineSet(ms, hivev1.MachineAPIMachineAuthority, test.force, c)
+			require.NoError(t, err, "unexpected error applying machineset")
+			assert.Equal(t, test.expectConflicts, conflicts, "unexpected conflicts")
+			assert.Equal(t, test.expectForced, c.forced, "unexpected forced apply")
+			if test.expectConflicts == nil {
+				if assert.NotNil(t, applied, "missing applied machineset") {
+					assert.Equal(t, test.expectedReplicas, *applied.Spec.Replicas, "unexpected applied replicas")
+				}
+			}
+
+			remote := &machineapi.MachineSet{}
+			require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(ms), remote))
+			assert.Equal(t, test.expectedReplicas, *remote.Spec.Replicas, "unexpected remote replicas")
+			assert.Equal(t, test.expectedGeneration, remote.Generation, "unexpected remote generation")
+		})
+	}
+}
+
+func TestMachineSetApplyConfiguration(t *testing.T) {
+	ms := testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 2, 0)
+	ms.Status.Replicas = 2
+
+	u, err := machineSetApplyConfiguration(ms, hivev1.ClusterAPIMachineAuthority)
+	require.NoError(t, err, "unexpected error")
+
+	assert.Equal(t, machineapi.SchemeGroupVersion.WithKind("MachineSet"), u.GroupVersionKind(), "unexpected kind")
+	assert.Equal(t, ms.Labels, u.GetLabels(), "unexpected labels")
+	assert.Equal(t, ms.Annotations, u.GetAnnotations(), "unexpected annotations")
+	replicas, _, _ := unstructured.NestedInt64(u.Object, "spec", "replicas")
+	assert.Equal(t, int64(2), replicas, "unexpected replicas")
+	authority, _, _ := unstructured.NestedString(u.Object, "spec", "authoritativeAPI")
+	assert.Equal(t, string(hivev1.ClusterAPIMachineAuthority), authority, "unexpected authoritative api")
+	_, hasStatus := u.Object["status"]
+	assert.False(t, hasStatus, "status must not be applied")
+	_, hasSelector, _ := unstructured.NestedFieldNoCopy(u.Object, "spec", "selector")
+	assert.False(t, hasSelector, "selector must not be applied")
+
+	applied := &machineapi.MachineSet{}
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, applied))
+	assert.Equal(t, ms.Spec.Template.Spec.Labels, applied.Spec.Template.Spec.Labels, "unexpected template labels")
+	assert.Equal(t, ms.Spec.Template.Spec.Taints, applied.Spec.Template.Spec.Taints, "unexpected taints")
+	matches, err := providerSpecMatches(ms.Spec.Template.Spec.ProviderSpec.Value, applied.Spec.Template.Spec.ProviderSpec.Value)
+	require.NoError(t, err, "unexpected error comparing provider specs")
+	assert.True(t, matches, "unexpected provider spec")
+}
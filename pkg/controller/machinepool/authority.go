@@ -0,0 +1,105 @@
+package machinepool
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1 "github.com/openshift/api/config/v1"
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	// machineAPIMigrationFeatureGate is the feature gate of the remote cluster which lets its Machine API resources be
+	// made authoritative in the Cluster API.
+	machineAPIMigrationFeatureGate = "MachineAPIMigration"
+	featureGateName                = "cluster"
+)
+
+// detectMachineAuthority returns the API which manages the machines of the remote cluster. The Cluster API is
+// authoritative when the MachineAPIMigration feature gate is enabled for the version of the cluster. The feature gate
+// is read as unstructured, as its status is not known to the vendored API.
+func detectMachineAuthority(cd *hivev1.ClusterDeployment, remoteClusterAPIClient client.Client, logger log.FieldLogger) (hivev1.MachineAuthority, error) {
+	featureGate := &unstructured.Unstructured{}
+	featureGate.SetGroupVersionKind(configv1.GroupVersion.WithKind("FeatureGate"))
+	switch err := remoteClusterAPIClient.Get(context.TODO(), types.NamespacedName{Name: featureGateName}, featureGate); {
+	case apierrors.IsNotFound(err), apierrors.IsForbidden(err):
+		logger.WithError(err).Debug("could not read feature gates, assuming the machine api is authoritative")
+		return hivev1.MachineAPIMachineAuthority, nil
+	case err != nil:
+		return "", errors.Wrap(err, "could not get feature gates")
+	}
+
+	featureSet, _, _ := unstructured.NestedString(featureGate.Object, "spec", "featureSet")
+	if configv1.FeatureSet(featureSet) == configv1.CustomNoUpgrade {
+		enabled, _, _ := unstructured.NestedStringSlice(featureGate.Object, "spec", "customNoUpgrade", "enabled")
+		if sets.NewString(enabled...).Has(machineAPIMigrationFeatureGate) {
+			return hivev1.ClusterAPIMachineAuthority, nil
+		}
+	}
+
+	// The status lists the enabled feature gates of each version of the cluster. During an upgrade there may be more
+	// than one version, so prefer the version of the cluster.
+	versions, _, _ := unstructured.NestedSlice(featureGate.Object, "status", "featureGates")
+	clusterVersion, _ := getClusterVersion(cd)
+	var current map[string]interface{}
+	for _, v := range versions {
+		version, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if current == nil {
+			current = version
+		}
+		if name, _, _ := unstructured.NestedString(version, "version"); name != "" && name == clusterVersion {
+			current = version
+			break
+		}
+	}
+	if current == nil {
+		return hivev1.MachineAPIMachineAuthority, nil
+	}
+	enabled, _, _ := unstructured.NestedSlice(current, "enabled")
+	for _, e := range enabled {
+		gate, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, _, _ := unstructured.NestedString(gate, "name"); name == machineAPIMigrationFeatureGate {
+			return hivev1.ClusterAPIMachineAuthority, nil
+		}
+	}
+	return hivev1.MachineAPIMachineAuthority, nil
+}
+
+// writeMachineSet creates or updates a MachineSet in the remote cluster with write. When the Cluster API is
+// authoritative, the MachineSet is written as unstructured with spec.authoritativeAPI set, which the vendored
+// MachineSet does not know and would otherwise drop, so that the MachineSet is mirrored to the Cluster API.
+func writeMachineSet(ms *machineapi.MachineSet, authority hivev1.MachineAuthority, write func(client.Object) error) error {
+	if authority != hivev1.ClusterAPIMachineAuthority {
+		return write(ms)
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ms)
+	if err != nil {
+		return errors.Wrapf(err, "could not convert machineset %s", ms.Name)
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(machineapi.SchemeGroupVersion.WithKind("MachineSet"))
+	if err := unstructured.SetNestedField(u.Object, string(authority), "spec", "authoritativeAPI"); err != nil {
+		return errors.Wrapf(err, "could not set authoritative api of machineset %s", ms.Name)
+	}
+	if err := write(u); err != nil {
+		return err
+	}
+	return errors.Wrapf(runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, ms), "could not convert machineset %s", ms.Name)
+}
@@ -0,0 +1,136 @@
+package machinepool
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configv1 "github.com/openshift/api/config/v1"
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestDetectMachineAuthority(t *testing.T) {
+	// The vendored FeatureGate has no status, so it is not registered to keep the feature gates unstructured.
+	scheme := runtime.NewScheme()
+
+	featureGate := func(spec, status map[string]interface{}) *unstructured.Unstructured {
+		fg := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec":   spec,
+			"status": status,
+		}}
+		fg.SetGroupVersionKind(configv1.GroupVersion.WithKind("FeatureGate"))
+		fg.SetName(featureGateName)
+		return fg
+	}
+	enabledFor := func(version string, gates ...string) map[string]interface{} {
+		enabled := []interface{}{}
+		for _, g := range gates {
+			enabled = append(enabled, map[string]interface{}{"name": g})
+		}
+		return map[string]interface{}{"version": version, "enabled": enabled}
+	}
+
+	tests := []struct {
+		name     string
+		existing []client.Object
+		expected hivev1.MachineAuthority
+	}{
+		{
+			name:     "no feature gates",
+			expected: hivev1.MachineAPIMachineAuthority,
+		},
+		{
+			name: "migration disabled",
+			existing: []client.Object{featureGate(nil, map[string]interface{}{
+				"featureGates": []interface{}{enabledFor("4.4.0", "SomeOtherGate")},
+			})},
+			expected: hivev1.MachineAPIMachineAuthority,
+		},
+		{
+			name: "migration enabled",
+			existing: []client.Object{featureGate(nil, map[string]interface{}{
+				"featureGates": []interface{}{enabledFor("4.4.0", "SomeOtherGate", machineAPIMigrationFeatureGate)},
+			})},
+			expected: hivev1.ClusterAPIMachineAuthority,
+		},
+		{
+			name: "migration enabled for other version",
+			existing: []client.Object{featureGate(nil, map[string]interface{}{
+				"featureGates": []interface{}{
+					enabledFor("4.5.0", machineAPIMigrationFeatureGate),
+					enabledFor("4.4.0"),
+				},
+			})},
+			expected: hivev1.MachineAPIMachineAuthority,
+		},
+		{
+			name: "migration enabled in custom feature set",
+			existing: []client.Object{featureGate(map[string]interface{}{
+				"featureSet": string(configv1.CustomNoUpgrade),
+				"customNoUpgrade": map[string]interface{}{
+					"enabled": []interface{}{machineAPIMigrationFeatureGate},
+				},
+			}, nil)},
+			expected: hivev1.ClusterAPIMachineAuthority,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(test.existing...).Build()
+			authority, err := detectMachineAuthority(testClusterDeployment(), c, log.WithField("test", test.name))
+			require.NoError(t, err, "unexpected error detecting machine authority")
+			assert.Equal(t, test.expected, authority, "unexpected machine authority")
+		})
+	}
+}
+
+func TestWriteMachineSet(t *testing.T) {
+	tests := []struct {
+		name                     string
+		authority                hivev1.MachineAuthority
+		expectedAuthoritativeAPI string
+	}{
+		{
+			name:      "machine api",
+			authority: hivev1.MachineAPIMachineAuthority,
+		},
+		{
+			name:                     "cluster api",
+			authority:                hivev1.ClusterAPIMachineAuthority,
+			expectedAuthoritativeAPI: "ClusterAPI",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ms := testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0)
+			var written client.Object
+			err := writeMachineSet(ms, test.authority, func(obj client.Object) error {
+				written = obj
+				obj.SetResourceVersion("1")
+				return nil
+			})
+			require.NoError(t, err, "unexpected error writing machineset")
+			assert.Equal(t, "1", ms.ResourceVersion, "expected machineset to be updated from the written object")
+			u, ok := written.(*unstructured.Unstructured)
+			if test.expectedAuthoritativeAPI == "" {
+				assert.False(t, ok, "expected typed machineset to be written")
+				return
+			}
+			require.True(t, ok, "expected unstructured machineset to be written")
+			assert.Equal(t, machineapi.SchemeGroupVersion.WithKind("MachineSet"), u.GroupVersionKind(), "unexpected kind")
+			authoritativeAPI, _, _ := unstructured.NestedString(u.Object, "spec", "authoritativeAPI")
+			assert.Equal(t, test.expectedAuthoritativeAPI, authoritativeAPI, "unexpected authoritative api")
+			replicas, _, _ := unstructured.NestedInt64(u.Object, "spec", "replicas")
+			assert.Equal(t, int64(1), replicas, "unexpected replicas")
+		})
+	}
+}
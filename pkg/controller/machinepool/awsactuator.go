@@ -2,19 +2,26 @@ package machinepool
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"regexp"
+	"reflect"
+	"sort"
 	"strings"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
 	"github.com/blang/semver/v4"
+	"github.com/coreos/stream-metadata-go/stream"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	awsprovider "sigs.k8s.io/cluster-api-provider-aws/pkg/apis"
 	awsproviderv1beta1 "sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1beta1"
@@ -25,10 +32,23 @@ import (
 	installertypesaws "github.com/openshift/installer/pkg/types/aws"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/awsclient"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
+const (
+	// bootImagesNamespace and bootImagesConfigMapName name the ConfigMap in which the machine-config-operator
+	// publishes the RHCOS boot images of the release of the cluster, as CoreOS stream metadata under
+	// bootImagesStreamKey.
+	bootImagesNamespace     = "openshift-machine-config-operator"
+	bootImagesConfigMapName = "coreos-bootimages"
+	bootImagesStreamKey     = "stream"
+
+	bootImageArchitectureX86 = "x86_64"
+	bootImageArchitectureARM = "aarch64"
+)
+
 // AWSActuator encapsulates the pieces necessary to be able to generate
 // a list of MachineSets to sync to the remote cluster.
 type AWSActuator struct {
@@ -37,14 +57,15 @@ type AWSActuator struct {
 	logger    log.FieldLogger
 	region    string
 	amiID     string
+	// lookups caches the zones, instance type offerings and subnets of the account and region across reconciles.
+	lookups cloudLookups
+	// defaultUserTags are the tags of HiveConfig added to the machines of every MachinePool.
+	defaultUserTags map[string]string
 }
 
 var (
 	_ Actuator = &AWSActuator{}
 
-	// reg is a regex used to fetch condition message from error when subnets specified in the MachinePool are invalid
-	reg = regexp.MustCompile(`^InvalidSubnetID\.NotFound:\s+([^\t]+)\t`)
-
 	versionsSupportingSpotInstances = semver.MustParseRange(">=4.5.0")
 )
 
@@ -59,7 +80,10 @@ func NewAWSActuator(
 	region string,
 	pool *hivev1.MachinePool,
 	masterMachine *machineapi.Machine,
+	remoteClusterAPIClient client.Client,
 	scheme *runtime.Scheme,
+	lookups cloudLookups,
+	defaultUserTags map[string]string,
 	logger log.FieldLogger,
 ) (*AWSActuator, error) {
 	awsClient, err := awsclient.New(client, awsclient.Options{Region: region, CredentialsSource: credentials})
@@ -67,15 +91,27 @@ func NewAWSActuator(
 		logger.WithError(err).Warn("failed to create AWS client")
 		return nil, err
 	}
-	amiID := pool.Annotations[hivev1.MachinePoolImageIDOverrideAnnotation]
+	var amiID string
+	if pool.Spec.Platform.AWS != nil {
+		amiID = pool.Spec.Platform.AWS.AMIID
+	}
 	if amiID != "" {
+		logger.WithField("ami", amiID).Info("using AMI from machine pool platform")
+	} else if amiID = pool.Annotations[hivev1.MachinePoolImageIDOverrideAnnotation]; amiID != "" {
 		log.Infof("using AMI override from %s annotation: %s", hivev1.MachinePoolImageIDOverrideAnnotation, amiID)
 	} else {
-		amiID, err = getAWSAMIID(masterMachine, scheme, logger)
+		amiID, err = getAWSBootImageAMIID(remoteClusterAPIClient, masterMachine, region, logger)
 		if err != nil {
-			logger.WithError(err).Warn("failed to get AMI ID")
+			logger.WithError(err).Warn("failed to get AMI ID from boot images of release")
 			return nil, err
 		}
+		if amiID == "" {
+			amiID, err = getAWSAMIID(masterMachine, scheme, logger)
+			if err != nil {
+				logger.WithError(err).Warn("failed to get AMI ID")
+				return nil, err
+			}
+		}
 	}
 	actuator := &AWSActuator{
 		client:    client,
@@ -83,6 +119,9 @@ func NewAWSActuator(
 		logger:    logger,
 		region:    region,
 		amiID:     amiID,
+		lookups:   lookups,
+
+		defaultUserTags: defaultUserTags,
 	}
 	return actuator, nil
 }
@@ -99,6 +138,7 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 	if pool.Spec.Platform.AWS == nil {
 		return nil, false, errors.New("MachinePool is not for AWS")
 	}
+	invalidateSubnetLookupsOfInvalidPool(a.lookups, pool)
 	clusterVersion, err := getClusterVersion(cd)
 	if err != nil {
 		return nil, false, fmt.Errorf("Unable to get cluster version: %v", err)
@@ -145,6 +185,20 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		Zones: pool.Spec.Platform.AWS.Zones,
 	}
 
+	// Pools without subnets of clusters installed into an existing VPC default to the private subnets the cluster
+	// was installed into, as the installer only creates subnets for the VPCs it creates.
+	var defaultSubnets []hivev1aws.SubnetStatus
+	if len(pool.Spec.Platform.AWS.Subnets) == 0 && cd.Status.Platform != nil && cd.Status.Platform.AWS != nil &&
+		cd.Status.Platform.AWS.UserProvidedNetwork != nil {
+		defaultSubnets = cd.Status.Platform.AWS.UserProvidedNetwork.PrivateSubnets
+	}
+	if len(computePool.Platform.AWS.Zones) == 0 {
+		for _, subnet := range defaultSubnets {
+			computePool.Platform.AWS.Zones = append(computePool.Platform.AWS.Zones, subnet.AvailabilityZone)
+		}
+	}
+
+	var skippedZones []string
 	if len(computePool.Platform.AWS.Zones) == 0 {
 		zones, err := a.fetchAvailabilityZones()
 		if err != nil {
@@ -153,8 +207,25 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		if len(zones) == 0 {
 			return nil, false, fmt.Errorf("zero zones returned for region %s", cd.Spec.Platform.AWS.Region)
 		}
+		if pool.Spec.Platform.AWS.ZoneSelection == hivev1aws.InstanceTypeAvailableZoneSelection {
+			zones, skippedZones, err = a.filterZonesByInstanceTypeOfferings(zones, pool.Spec.Platform.AWS.InstanceType)
+			if err != nil {
+				return nil, false, errors.Wrap(err, "failed to fetch instance type offerings")
+			}
+			if len(zones) == 0 {
+				return nil, false, fmt.Errorf("instance type %s is not offered in any zone of region %s",
+					pool.Spec.Platform.AWS.InstanceType, cd.Spec.Platform.AWS.Region)
+			}
+			if len(skippedZones) > 0 {
+				logger.WithField("skippedZones", skippedZones).Debug("skipping zones which do not offer the instance type")
+			}
+		}
 		computePool.Platform.AWS.Zones = zones
 	}
+	if !reflect.DeepEqual(pool.Status.SkippedZones, skippedZones) {
+		pool.Status.SkippedZones = skippedZones
+		statusChanged = true
+	}
 
 	subnets := map[string]string{}
 	// Fetching private subnets from the machinepool and then mapping availability zones to subnets
@@ -165,10 +236,10 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		}
 		subnets = subnetsByAvailabilityZone
 	}
-	// userTags are settings available in the installconfig that we are choosing
-	// to ignore for the timebeing. These empty settings should be updated to feed
-	// from the machinepool / installconfig in the future.
-	userTags := map[string]string{}
+	for _, subnet := range defaultSubnets {
+		subnets[subnet.AvailabilityZone] = subnet.ID
+	}
+	userTags := a.userTags(cd)
 
 	installerMachineSets, err := installaws.MachineSets(
 		cd.Spec.ClusterMetadata.InfraID,
@@ -223,6 +294,172 @@ func (a *AWSActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 	return installerMachineSets, true, nil
 }
 
+// userTags returns the tags of the machines of the ClusterDeployment: the default tags of HiveConfig, overridden by
+// the userTags of the ClusterDeployment.
+func (a *AWSActuator) userTags(cd *hivev1.ClusterDeployment) map[string]string {
+	userTags := make(map[string]string, len(a.defaultUserTags)+len(cd.Spec.Platform.AWS.UserTags))
+	for k, v := range a.defaultUserTags {
+		userTags[k] = v
+	}
+	for k, v := range cd.Spec.Platform.AWS.UserTags {
+		userTags[k] = v
+	}
+	return userTags
+}
+
+// ReconcileInstanceTags adds the userTags of the ClusterDeployment, and the default tags of HiveConfig, to the
+// running instances of the given machines, and to their volumes, when the instances are missing any of them. Tags
+// removed from the userTags are left on the instances, as they cannot be told apart from tags added outside of Hive.
+func (a *AWSActuator) ReconcileInstanceTags(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, machines []machineapi.Machine, logger log.FieldLogger) error {
+	userTags := a.userTags(cd)
+	if len(userTags) == 0 {
+		return nil
+	}
+	var instanceIDs []string
+	for _, machine := range machines {
+		// Machines get a provider ID once their instance has been created.
+		if machine.Spec.ProviderID == nil {
+			continue
+		}
+		providerID := *machine.Spec.ProviderID
+		instanceIDs = append(instanceIDs, providerID[strings.LastIndex(providerID, "/")+1:])
+	}
+	if len(instanceIDs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(userTags))
+	for k := range userTags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	tags := make([]ec2types.Tag, len(keys))
+	for i, k := range keys {
+		tags[i] = ec2types.Tag{Key: aws.String(k), Value: aws.String(userTags[k])}
+	}
+
+	input := &ec2.DescribeInstancesInput{
+		// Filtering rather than listing the instance IDs tolerates instances which no longer exist.
+		Filters: []ec2types.Filter{
+			{Name: aws.String("instance-id"), Values: instanceIDs},
+			{Name: aws.String("instance-state-name"), Values: []string{"pending", "running", "stopping", "stopped"}},
+		},
+	}
+	for {
+		output, err := a.awsClient.DescribeInstances(context.TODO(), input)
+		if err != nil {
+			return errors.Wrap(err, "failed to describe instances")
+		}
+		for _, reservation := range output.Reservations {
+			for _, instance := range reservation.Instances {
+				if hasTags(instance.Tags, userTags) {
+					continue
+				}
+				resources := []string{aws.ToString(instance.InstanceId)}
+				for _, mapping := range instance.BlockDeviceMappings {
+					if mapping.Ebs != nil && mapping.Ebs.VolumeId != nil {
+						resources = append(resources, *mapping.Ebs.VolumeId)
+					}
+				}
+				logger.WithField("instance", aws.ToString(instance.InstanceId)).Info("updating tags of instance")
+				if _, err := a.awsClient.CreateTags(context.TODO(), &ec2.CreateTagsInput{Resources: resources, Tags: tags}); err != nil {
+					return errors.Wrapf(err, "failed to tag instance %s", aws.ToString(instance.InstanceId))
+				}
+			}
+		}
+		if aws.ToString(output.NextToken) == "" {
+			return nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
+
+// MachineSetInfrastructure returns the availability zone, subnet, instance type and AMI of the machines of the
+// MachineSet.
+func (a *AWSActuator) MachineSetInfrastructure(ms *machineapi.MachineSet) (*hivev1.MachineSetInfrastructure, error) {
+	spec := &awsproviderv1beta1.AWSMachineProviderConfig{}
+	if err := unmarshalProviderSpec(ms.Spec.Template.Spec.ProviderSpec.Value, spec); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal AWS provider spec")
+	}
+	infra := &hivev1.MachineSetInfrastructure{
+		Zone:         spec.Placement.AvailabilityZone,
+		Subnet:       aws.ToString(spec.Subnet.ID),
+		InstanceType: spec.InstanceType,
+		Image:        aws.ToString(spec.AMI.ID),
+	}
+	if infra.Subnet == "" {
+		for _, filter := range spec.Subnet.Filters {
+			if len(filter.Values) > 0 {
+				infra.Subnet = strings.Join(filter.Values, ",")
+				break
+			}
+		}
+	}
+	return infra, nil
+}
+
+// hasTags returns true if all of the wanted tags are among the given tags.
+func hasTags(tags []ec2types.Tag, wanted map[string]string) bool {
+	have := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		have[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	for k, v := range wanted {
+		if value, ok := have[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// getAWSBootImageAMIID returns the RHCOS AMI for the region from the boot images published by the release of the
+// cluster, for the architecture of the master machine. It returns "" when the release does not publish boot images,
+// as with releases before 4.10, or publishes none for the region.
+func getAWSBootImageAMIID(remoteClusterAPIClient client.Client, masterMachine *machineapi.Machine, region string, logger log.FieldLogger) (string, error) {
+	bootImages := &corev1.ConfigMap{}
+	switch err := remoteClusterAPIClient.Get(
+		context.TODO(),
+		types.NamespacedName{Namespace: bootImagesNamespace, Name: bootImagesConfigMapName},
+		bootImages,
+	); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("release of cluster does not publish boot images")
+		return "", nil
+	case err != nil:
+		return "", errors.Wrap(err, "could not get boot images of release")
+	}
+	st := &stream.Stream{}
+	if err := json.Unmarshal([]byte(bootImages.Data[bootImagesStreamKey]), st); err != nil {
+		return "", errors.Wrap(err, "could not parse boot images of release")
+	}
+	arch := bootImageArchitecture(remoteClusterAPIClient, masterMachine, logger)
+	amiID, err := st.GetAMI(arch, region)
+	if err != nil {
+		logger.WithError(err).WithField("architecture", arch).Warn("release of cluster does not publish an AMI for the region")
+		return "", nil
+	}
+	logger.WithFields(log.Fields{"ami": amiID, "release": st.Architectures[arch].Images.Aws.Regions[region].Release}).
+		Debug("resolved AMI to use for new machinesets from boot images of release")
+	return amiID, nil
+}
+
+// bootImageArchitecture returns the architecture of the node of the master machine, as named in CoreOS stream
+// metadata. It defaults to x86_64 when the node cannot be read.
+func bootImageArchitecture(remoteClusterAPIClient client.Client, masterMachine *machineapi.Machine, logger log.FieldLogger) string {
+	if masterMachine.Status.NodeRef == nil {
+		return bootImageArchitectureX86
+	}
+	node := &corev1.Node{}
+	if err := remoteClusterAPIClient.Get(context.TODO(), types.NamespacedName{Name: masterMachine.Status.NodeRef.Name}, node); err != nil {
+		logger.WithError(err).Warn("could not get node of master machine, assuming x86_64")
+		return bootImageArchitectureX86
+	}
+	if node.Status.NodeInfo.Architecture == "arm64" {
+		return bootImageArchitectureARM
+	}
+	return bootImageArchitectureX86
+}
+
 // Get the AMI ID from an existing master machine.
 func getAWSAMIID(masterMachine *machineapi.Machine, scheme *runtime.Scheme, logger log.FieldLogger) (string, error) {
 	providerSpec, err := decodeAWSMachineProviderSpec(masterMachine.Spec.ProviderSpec.Value, scheme)
@@ -241,14 +478,24 @@ func getAWSAMIID(masterMachine *machineapi.Machine, scheme *runtime.Scheme, logg
 
 // fetchAvailabilityZones fetches availability zones for the AWS region
 func (a *AWSActuator) fetchAvailabilityZones() ([]string, error) {
-	zoneFilter := &ec2.Filter{
+	zones, err := a.lookups.get("zones", func() (interface{}, error) {
+		return a.describeAvailabilityZones()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{}, zones.([]string)...), nil
+}
+
+func (a *AWSActuator) describeAvailabilityZones() ([]string, error) {
+	zoneFilter := ec2types.Filter{
 		Name:   aws.String("region-name"),
-		Values: []*string{aws.String(a.region)},
+		Values: []string{a.region},
 	}
 	req := &ec2.DescribeAvailabilityZonesInput{
-		Filters: []*ec2.Filter{zoneFilter},
+		Filters: []ec2types.Filter{zoneFilter},
 	}
-	resp, err := a.awsClient.DescribeAvailabilityZones(req)
+	resp, err := a.awsClient.DescribeAvailabilityZones(context.TODO(), req)
 	if err != nil {
 		return nil, err
 	}
@@ -259,6 +506,50 @@ func (a *AWSActuator) fetchAvailabilityZones() ([]string, error) {
 	return zones, nil
 }
 
+// filterZonesByInstanceTypeOfferings splits the zones into those which offer the instance type and those which do not.
+func (a *AWSActuator) filterZonesByInstanceTypeOfferings(zones []string, instanceType string) (offered, skipped []string, err error) {
+	offeredZones, err := a.lookups.get("instanceTypeOfferings/"+instanceType, func() (interface{}, error) {
+		return a.describeInstanceTypeOfferings(instanceType)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, zone := range zones {
+		if offeredZones.(sets.String).Has(zone) {
+			offered = append(offered, zone)
+		} else {
+			skipped = append(skipped, zone)
+		}
+	}
+	return offered, skipped, nil
+}
+
+// describeInstanceTypeOfferings returns the zones of the region which offer the instance type.
+func (a *AWSActuator) describeInstanceTypeOfferings(instanceType string) (sets.String, error) {
+	input := &ec2.DescribeInstanceTypeOfferingsInput{
+		LocationType: ec2types.LocationTypeAvailabilityZone,
+		Filters: []ec2types.Filter{{
+			Name:   aws.String("instance-type"),
+			Values: []string{instanceType},
+		}},
+	}
+	offeredZones := sets.NewString()
+	for {
+		resp, err := a.awsClient.DescribeInstanceTypeOfferings(context.TODO(), input)
+		if err != nil {
+			return nil, err
+		}
+		for _, offering := range resp.InstanceTypeOfferings {
+			offeredZones.Insert(aws.ToString(offering.Location))
+		}
+		if resp.NextToken == nil {
+			break
+		}
+		input.NextToken = resp.NextToken
+	}
+	return offeredZones, nil
+}
+
 func decodeAWSMachineProviderSpec(rawExt *runtime.RawExtension, scheme *runtime.Scheme) (*awsproviderv1beta1.AWSMachineProviderConfig, error) {
 	codecFactory := serializer.NewCodecFactory(scheme)
 	decoder := codecFactory.UniversalDecoder(awsproviderv1beta1.SchemeGroupVersion)
@@ -302,6 +593,12 @@ func (a *AWSActuator) updateProviderConfig(machineSet *machineapi.MachineSet, in
 			Values: []string{fmt.Sprintf("%s-worker-sg", infraID)},
 		}},
 	}}
+	for _, id := range pool.Spec.Platform.AWS.AdditionalSecurityGroupIDs {
+		providerConfig.SecurityGroups = append(providerConfig.SecurityGroups, awsproviderv1beta1.AWSResourceReference{ID: aws.String(id)})
+	}
+	if pool.Spec.Platform.AWS.AssociatePublicIP != nil {
+		providerConfig.PublicIP = aws.Bool(*pool.Spec.Platform.AWS.AssociatePublicIP)
+	}
 	if pool.Spec.Platform.AWS.SpotMarketOptions != nil {
 		providerConfig.SpotMarketOptions = &awsproviderv1beta1.SpotMarketOptions{
 			MaxPrice: pool.Spec.Platform.AWS.SpotMarketOptions.MaxPrice,
@@ -316,20 +613,21 @@ func (a *AWSActuator) updateProviderConfig(machineSet *machineapi.MachineSet, in
 
 // getPrivateSubnetsByAvailabilityZones maps availability zones to private subnet
 func (a *AWSActuator) getPrivateSubnetsByAvailabilityZone(pool *hivev1.MachinePool) (map[string]string, error) {
-	idPointers := make([]*string, len(pool.Spec.Platform.AWS.Subnets))
-	for i, id := range pool.Spec.Platform.AWS.Subnets {
-		idPointers[i] = aws.String(id)
+	cached, err := a.lookups.get("subnets/"+strings.Join(pool.Spec.Platform.AWS.Subnets, ","), func() (interface{}, error) {
+		return a.awsClient.DescribeSubnets(context.TODO(), &ec2.DescribeSubnetsInput{SubnetIds: pool.Spec.Platform.AWS.Subnets})
+	})
+	var results *ec2.DescribeSubnetsOutput
+	if err == nil {
+		results = cached.(*ec2.DescribeSubnetsOutput)
 	}
-
-	results, err := a.awsClient.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: idPointers})
 	if err != nil || len(results.Subnets) == 0 {
 		if strings.Contains(err.Error(), "InvalidSubnet") {
 			conditionMessage := err.Error()
-			if submatches := reg.FindStringSubmatch(err.Error()); submatches != nil {
-				// formatting error message before adding it to condition when
-				// sample error message: InvalidSubnetID.NotFound: The subnet ID 'subnet-1,subnet-2' does not exist\tstatus code: 400, request id: ea8b3bb7-de56-405f-9345-e5690a3ea8b2
-				// message after formatting: The subnet ID 'subnet-1,subnet-2' does not exist
-				conditionMessage = submatches[1]
+			// Only the message of the AWS error goes in the condition, without the operation and request ID, e.g.
+			// The subnet ID 'subnet-1,subnet-2' does not exist
+			var apiErr smithy.APIError
+			if errors.As(err, &apiErr) && apiErr.ErrorCode() == "InvalidSubnetID.NotFound" {
+				conditionMessage = apiErr.ErrorMessage()
 			}
 			conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
 				pool.Status.Conditions,
@@ -354,26 +652,29 @@ func (a *AWSActuator) getPrivateSubnetsByAvailabilityZone(pool *hivev1.MachinePo
 		return nil, errors.Errorf("%s has no VPC", *results.Subnets[0].SubnetId)
 	}
 
-	routeTables, err := a.awsClient.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
-		Filters: []*ec2.Filter{{
-			Name:   aws.String("vpc-id"),
-			Values: []*string{aws.String(vpc)},
-		}},
+	cached, err = a.lookups.get("subnets/routeTables/"+vpc, func() (interface{}, error) {
+		return a.awsClient.DescribeRouteTables(context.TODO(), &ec2.DescribeRouteTablesInput{
+			Filters: []ec2types.Filter{{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpc},
+			}},
+		})
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "error describing route tables")
 	}
+	routeTables := cached.(*ec2.DescribeRouteTablesOutput)
 
-	var privateSubnets, publicSubnets = map[string]ec2.Subnet{}, map[string]ec2.Subnet{}
+	var privateSubnets, publicSubnets = map[string]ec2types.Subnet{}, map[string]ec2types.Subnet{}
 	for _, subnet := range results.Subnets {
-		isPublic, err := isSubnetPublic(routeTables.RouteTables, subnet, a.logger)
+		isPublic, err := awsclient.IsSubnetPublic(routeTables.RouteTables, &subnet, a.logger)
 		if err != nil {
 			return nil, errors.Wrap(err, "error describing route tables")
 		}
 		if isPublic {
-			publicSubnets[*subnet.SubnetId] = *subnet
+			publicSubnets[*subnet.SubnetId] = subnet
 		} else {
-			privateSubnets[*subnet.SubnetId] = *subnet
+			privateSubnets[*subnet.SubnetId] = subnet
 		}
 	}
 
@@ -428,78 +729,9 @@ func isUsingUnsupportedSpotMarketOptions(pool *hivev1.MachinePool, clusterVersio
 	return !versionsSupportingSpotInstances(parsedVersion)
 }
 
-// tagNameSubnetPublicELB is the tag name used on a subnet to designate that
-// it should be used for internet ELBs
-const tagNameSubnetPublicELB = "kubernetes.io/role/elb"
-
-// https://github.com/kubernetes/kubernetes/blob/9f036cd43d35a9c41d7ac4ca82398a6d0bef957b/staging/src/k8s.io/legacy-cloud-providers/aws/aws.go#L3376-L3419
-func isSubnetPublic(rt []*ec2.RouteTable, subnet *ec2.Subnet, logger log.FieldLogger) (bool, error) {
-	subnetID := aws.StringValue(subnet.SubnetId)
-	var subnetTable *ec2.RouteTable
-	for _, table := range rt {
-		for _, assoc := range table.Associations {
-			if aws.StringValue(assoc.SubnetId) == subnetID {
-				subnetTable = table
-				break
-			}
-		}
-	}
-
-	if subnetTable == nil {
-		// If there is no explicit association, the subnet will be implicitly
-		// associated with the VPC's main routing table.
-		for _, table := range rt {
-			for _, assoc := range table.Associations {
-				if aws.BoolValue(assoc.Main) {
-					logger.Debugf("Assuming implicit use of main routing table %s for %s",
-						aws.StringValue(table.RouteTableId), subnetID)
-					subnetTable = table
-					break
-				}
-			}
-		}
-	}
-
-	if subnetTable == nil {
-		return false, fmt.Errorf("could not locate routing table for %s", subnetID)
-	}
-
-	for _, route := range subnetTable.Routes {
-		// There is no direct way in the AWS API to determine if a subnet is public or private.
-		// A public subnet is one which has an internet gateway route
-		// we look for the gatewayId and make sure it has the prefix of igw to differentiate
-		// from the default in-subnet route which is called "local"
-		// or other virtual gateway (starting with vgv)
-		// or vpc peering connections (starting with pcx).
-		if strings.HasPrefix(aws.StringValue(route.GatewayId), "igw") {
-			return true, nil
-		}
-	}
-
-	// If we couldn't use the subnet table to figure out whether the subnet is public,
-	// we let the users define whether this subnet should be used for internet-facing things
-	// by looking for tagNameSubnetPublicELB tag.
-	tagVal, subnetHasTag := findTag(subnet.Tags, tagNameSubnetPublicELB)
-	if subnetHasTag && (tagVal == "" || tagVal == "1") {
-		return true, nil
-	}
-
-	return false, nil
-}
-
-// Finds the value for a given tag.
-func findTag(tags []*ec2.Tag, key string) (string, bool) {
-	for _, tag := range tags {
-		if aws.StringValue(tag.Key) == key {
-			return aws.StringValue(tag.Value), true
-		}
-	}
-	return "", false
-}
-
 // validateSubnets ensures there's only one public or private subnet per availability zone, and returns
 // the mapping of subnets by availability zone
-func (a *AWSActuator) validateSubnets(subnets map[string]ec2.Subnet, pool *hivev1.MachinePool) (map[string]string, error) {
+func (a *AWSActuator) validateSubnets(subnets map[string]ec2types.Subnet, pool *hivev1.MachinePool) (map[string]string, error) {
 	conflictingSubnets := sets.NewString()
 	subnetsByAvailabilityZone := make(map[string]string, len(subnets))
 	for _, subnet := range subnets {
@@ -6,14 +6,16 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	jsonserializer "k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"k8s.io/apimachinery/pkg/types"
@@ -27,6 +29,7 @@ import (
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	awshivev1 "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/awsclient"
 	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
@@ -47,6 +50,10 @@ func TestAWSActuator(t *testing.T) {
 		expectedErr                  bool
 		expectedCondition            *hivev1.MachinePoolCondition
 		expectedKMSKey               string
+		expectedSecurityGroupIDs     []string
+		expectedPublicIP             *bool
+		expectedSkippedZones         []string
+		expectedSpotMarketOptions    *awsprovider.SpotMarketOptions
 	}{
 		{
 			name:              "generate single machineset for single zone",
@@ -128,6 +135,31 @@ func TestAWSActuator(t *testing.T) {
 			},
 			expectedSubnetIDInMachineSet: true,
 		},
+		{
+			name: "generate machinesets for subnets of user-provided network",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Status.Platform = &hivev1.PlatformStatus{AWS: &awshivev1.PlatformStatus{
+					UserProvidedNetwork: &awshivev1.UserProvidedNetworkStatus{
+						VPCID: "vpc-1",
+						PrivateSubnets: []awshivev1.SubnetStatus{
+							{ID: "subnet-zone1", AvailabilityZone: "zone1"},
+							{ID: "subnet-zone2", AvailabilityZone: "zone2"},
+						},
+					},
+				}}
+				return cd
+			}(),
+			poolName: testMachinePool().Name,
+			existing: []runtime.Object{
+				testMachinePool(),
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 2,
+				generateAWSMachineSetName("zone2"): 1,
+			},
+			expectedSubnetIDInMachineSet: true,
+		},
 		{
 			name:              "list zones returns zero",
 			clusterDeployment: testClusterDeployment(),
@@ -324,6 +356,26 @@ func TestAWSActuator(t *testing.T) {
 			expectedMachineSetReplicas: map[string]int64{
 				generateAWSMachineSetName("zone1"): 3,
 			},
+			expectedSpotMarketOptions: &awsprovider.SpotMarketOptions{},
+		},
+		{
+			name:              "spot market options with max price",
+			clusterDeployment: withClusterVersion(testClusterDeployment(), "4.5.0"),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() *hivev1.MachinePool {
+					pool := withSpotMarketOptions(testMachinePool())
+					pool.Spec.Platform.AWS.SpotMarketOptions.MaxPrice = pointer.StringPtr("0.25")
+					return pool
+				}(),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeAvailabilityZones(client, []string{"zone1"})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 3,
+			},
+			expectedSpotMarketOptions: &awsprovider.SpotMarketOptions{MaxPrice: pointer.StringPtr("0.25")},
 		},
 		{
 			name:              "unsupported spot market options",
@@ -353,6 +405,57 @@ func TestAWSActuator(t *testing.T) {
 			},
 			expectedKMSKey: fakeKMSKeyARN,
 		},
+		{
+			name:              "network interface options",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				func() runtime.Object {
+					pool := testMachinePool()
+					pool.Spec.Platform.AWS.AdditionalSecurityGroupIDs = []string{"sg-1", "sg-2"}
+					pool.Spec.Platform.AWS.AssociatePublicIP = pointer.BoolPtr(true)
+					return pool
+				}(),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeAvailabilityZones(client, []string{"zone1"})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 3,
+			},
+			expectedSecurityGroupIDs: []string{"sg-1", "sg-2"},
+			expectedPublicIP:         pointer.BoolPtr(true),
+		},
+		{
+			name:              "zones selected by instance type availability",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				withInstanceTypeAvailableZoneSelection(testMachinePool()),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeAvailabilityZones(client, []string{"zone1", "zone2", "zone3"})
+				mockDescribeInstanceTypeOfferings(client, []string{"zone1", "zone3"})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAWSMachineSetName("zone1"): 2,
+				generateAWSMachineSetName("zone3"): 1,
+			},
+			expectedSkippedZones: []string{"zone2"},
+		},
+		{
+			name:              "instance type not offered in any zone",
+			clusterDeployment: testClusterDeployment(),
+			poolName:          testMachinePool().Name,
+			existing: []runtime.Object{
+				withInstanceTypeAvailableZoneSelection(testMachinePool()),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				mockDescribeAvailabilityZones(client, []string{"zone1", "zone2"})
+				mockDescribeInstanceTypeOfferings(client, []string{})
+			},
+			expectedErr: true,
+		},
 		{
 			name:              "unsupported configuration condition cleared",
 			clusterDeployment: withClusterVersion(testClusterDeployment(), "4.4.0"),
@@ -427,6 +530,21 @@ func TestAWSActuator(t *testing.T) {
 			} else {
 				validateAWSMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas, test.expectedSubnetIDInMachineSet, test.expectedKMSKey)
 			}
+			for _, ms := range generatedMachineSets {
+				awsProvider := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*awsprovider.AWSMachineProviderConfig)
+				var securityGroupIDs []string
+				for _, sg := range awsProvider.SecurityGroups {
+					if sg.ID != nil {
+						securityGroupIDs = append(securityGroupIDs, *sg.ID)
+					}
+				}
+				assert.Equal(t, test.expectedSecurityGroupIDs, securityGroupIDs, "unexpected additional security groups")
+				assert.Equal(t, test.expectedPublicIP, awsProvider.PublicIP, "unexpected public IP")
+				if test.expectedSpotMarketOptions != nil {
+					assert.Equal(t, test.expectedSpotMarketOptions, awsProvider.SpotMarketOptions, "unexpected spot market options")
+				}
+			}
+			assert.Equal(t, test.expectedSkippedZones, pool.Status.SkippedZones, "unexpected skipped zones")
 			if test.expectedCondition != nil {
 				cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, test.expectedCondition.Type)
 				if assert.NotNilf(t, cond, "did not find expected condition type: %v", test.expectedCondition.Type) {
@@ -475,6 +593,251 @@ func TestGetAWSAMIID(t *testing.T) {
 	}
 }
 
+func TestGetAWSBootImageAMIID(t *testing.T) {
+	bootImages := func(stream string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: bootImagesNamespace, Name: bootImagesConfigMapName},
+			Data:       map[string]string{bootImagesStreamKey: stream},
+		}
+	}
+	const testStream = `{
+  "stream": "rhcos-4.12",
+  "architectures": {
+    "x86_64": {"images": {"aws": {"regions": {"us-east-1": {"release": "412.86.1", "image": "ami-x86"}}}}},
+    "aarch64": {"images": {"aws": {"regions": {"us-east-1": {"release": "412.86.1", "image": "ami-arm"}}}}}
+  }
+}`
+	armNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "master-node"},
+		Status:     corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{Architecture: "arm64"}},
+	}
+	withNodeRef := func(m *machineapi.Machine) *machineapi.Machine {
+		m.Status.NodeRef = &corev1.ObjectReference{Name: "master-node"}
+		return m
+	}
+	cases := []struct {
+		name          string
+		region        string
+		masterMachine *machineapi.Machine
+		remote        []runtime.Object
+		expectedAMIID string
+		expectError   bool
+	}{
+		{
+			name:          "no boot images",
+			region:        "us-east-1",
+			masterMachine: testMachine("master1", "master"),
+		},
+		{
+			name:          "boot image for region",
+			region:        "us-east-1",
+			masterMachine: testMachine("master1", "master"),
+			remote:        []runtime.Object{bootImages(testStream)},
+			expectedAMIID: "ami-x86",
+		},
+		{
+			name:          "boot image for architecture of master node",
+			region:        "us-east-1",
+			masterMachine: withNodeRef(testMachine("master1", "master")),
+			remote:        []runtime.Object{bootImages(testStream), armNode},
+			expectedAMIID: "ami-arm",
+		},
+		{
+			name:          "no boot image for region",
+			region:        "ap-southeast-7",
+			masterMachine: testMachine("master1", "master"),
+			remote:        []runtime.Object{bootImages(testStream)},
+		},
+		{
+			name:          "invalid boot images",
+			region:        "us-east-1",
+			masterMachine: testMachine("master1", "master"),
+			remote:        []runtime.Object{bootImages("not json")},
+			expectError:   true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			remoteClient := fake.NewClientBuilder().WithRuntimeObjects(tc.remote...).Build()
+			actualAMIID, actualErr := getAWSBootImageAMIID(remoteClient, tc.masterMachine, tc.region, log.StandardLogger())
+			if tc.expectError {
+				assert.Error(t, actualErr, "expected an error")
+				return
+			}
+			if assert.NoError(t, actualErr, "unexpected error") {
+				assert.Equal(t, tc.expectedAMIID, actualAMIID, "unexpected AMI ID")
+			}
+		})
+	}
+}
+
+func TestAWSActuatorReconcileInstanceTags(t *testing.T) {
+	machine := func(name, providerID string) machineapi.Machine {
+		m := machineapi.Machine{}
+		m.Name = name
+		if providerID != "" {
+			m.Spec.ProviderID = pointer.StringPtr(providerID)
+		}
+		return m
+	}
+	instance := func(id, volumeID string, tags map[string]string) ec2types.Instance {
+		i := ec2types.Instance{
+			InstanceId: aws.String(id),
+			BlockDeviceMappings: []ec2types.InstanceBlockDeviceMapping{{
+				DeviceName: aws.String("/dev/xvda"),
+				Ebs:        &ec2types.EbsInstanceBlockDevice{VolumeId: aws.String(volumeID)},
+			}},
+		}
+		for k, v := range tags {
+			i.Tags = append(i.Tags, ec2types.Tag{Key: aws.String(k), Value: aws.String(v)})
+		}
+		return i
+	}
+	userTags := map[string]string{"team": "hive", "env": "prod"}
+
+	cases := []struct {
+		name            string
+		userTags        map[string]string
+		defaultUserTags map[string]string
+		machines        []machineapi.Machine
+		mockAWSClient   func(*mockaws.MockClient)
+	}{
+		{
+			name:     "no user tags",
+			machines: []machineapi.Machine{machine("m1", "aws:///us-east-1a/i-1")},
+		},
+		{
+			name:     "no running machines",
+			userTags: userTags,
+			machines: []machineapi.Machine{machine("m1", "")},
+		},
+		{
+			name:     "tag instances missing user tags",
+			userTags: userTags,
+			machines: []machineapi.Machine{
+				machine("m1", "aws:///us-east-1a/i-1"),
+				machine("m2", "aws:///us-east-1b/i-2"),
+				machine("m3", "aws:///us-east-1c/i-3"),
+				machine("m4", ""),
+			},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				client.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+					assert.Equal(t, []string{"i-1", "i-2", "i-3"}, input.Filters[0].Values, "unexpected instance IDs")
+					return &ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+						instance("i-1", "vol-1", map[string]string{"team": "hive", "env": "prod", "Name": "m1"}),
+						instance("i-2", "vol-2", map[string]string{"team": "hive", "Name": "m2"}),
+						instance("i-3", "vol-3", map[string]string{"team": "hive", "env": "dev"}),
+					}}}}, nil
+				})
+				expectedTags := []ec2types.Tag{
+					{Key: aws.String("env"), Value: aws.String("prod")},
+					{Key: aws.String("team"), Value: aws.String("hive")},
+				}
+				client.EXPECT().CreateTags(gomock.Any(), &ec2.CreateTagsInput{Resources: []string{"i-2", "vol-2"}, Tags: expectedTags}).
+					Return(&ec2.CreateTagsOutput{}, nil)
+				client.EXPECT().CreateTags(gomock.Any(), &ec2.CreateTagsInput{Resources: []string{"i-3", "vol-3"}, Tags: expectedTags}).
+					Return(&ec2.CreateTagsOutput{}, nil)
+			},
+		},
+		{
+			name:            "default user tags",
+			userTags:        map[string]string{"env": "prod"},
+			defaultUserTags: map[string]string{"env": "dev", "org": "acme"},
+			machines:        []machineapi.Machine{machine("m1", "aws:///us-east-1a/i-1")},
+			mockAWSClient: func(client *mockaws.MockClient) {
+				client.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).Return(&ec2.DescribeInstancesOutput{Reservations: []ec2types.Reservation{{Instances: []ec2types.Instance{
+					instance("i-1", "vol-1", map[string]string{"env": "prod"}),
+				}}}}, nil)
+				client.EXPECT().CreateTags(gomock.Any(), &ec2.CreateTagsInput{
+					Resources: []string{"i-1", "vol-1"},
+					Tags: []ec2types.Tag{
+						{Key: aws.String("env"), Value: aws.String("prod")},
+						{Key: aws.String("org"), Value: aws.String("acme")},
+					},
+				}).Return(&ec2.CreateTagsOutput{}, nil)
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			awsClient := mockaws.NewMockClient(mockCtrl)
+			if tc.mockAWSClient != nil {
+				tc.mockAWSClient(awsClient)
+			}
+			actuator := &AWSActuator{
+				awsClient:       awsClient,
+				logger:          log.WithField("actuator", "awsactuator"),
+				region:          testRegion,
+				defaultUserTags: tc.defaultUserTags,
+			}
+			cd := testClusterDeployment()
+			cd.Spec.Platform.AWS.UserTags = tc.userTags
+			err := actuator.ReconcileInstanceTags(cd, testMachinePool(), tc.machines, actuator.logger)
+			assert.NoError(t, err, "unexpected error reconciling instance tags")
+		})
+	}
+}
+
+func TestAWSActuatorMachineSetInfrastructure(t *testing.T) {
+	tests := []struct {
+		name          string
+		providerSpec  *awsprovider.AWSMachineProviderConfig
+		raw           bool
+		expectedInfra *hivev1.MachineSetInfrastructure
+	}{
+		{
+			name: "subnet id",
+			providerSpec: func() *awsprovider.AWSMachineProviderConfig {
+				spec := testAWSProviderSpec()
+				spec.InstanceType = "m5.xlarge"
+				spec.Placement.AvailabilityZone = "us-east-1a"
+				spec.Subnet.ID = aws.String("subnet-1a")
+				return spec
+			}(),
+			raw: true,
+			expectedInfra: &hivev1.MachineSetInfrastructure{
+				Zone:         "us-east-1a",
+				Subnet:       "subnet-1a",
+				InstanceType: "m5.xlarge",
+				Image:        testAMI,
+			},
+		},
+		{
+			name: "subnet filter",
+			providerSpec: func() *awsprovider.AWSMachineProviderConfig {
+				spec := testAWSProviderSpec()
+				spec.InstanceType = "m5.xlarge"
+				spec.Placement.AvailabilityZone = "us-east-1b"
+				spec.Subnet.Filters = []awsprovider.Filter{{Name: "tag:Name", Values: []string{"infra-private-us-east-1b"}}}
+				return spec
+			}(),
+			expectedInfra: &hivev1.MachineSetInfrastructure{
+				Zone:         "us-east-1b",
+				Subnet:       "infra-private-us-east-1b",
+				InstanceType: "m5.xlarge",
+				Image:        testAMI,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ms := testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0)
+			if test.raw {
+				rawProviderSpec, err := encodeAWSMachineProviderSpec(test.providerSpec, scheme.Scheme)
+				require.NoError(t, err, "unexpected error encoding provider spec")
+				ms.Spec.Template.Spec.ProviderSpec.Value = rawProviderSpec
+			} else {
+				ms.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{Object: test.providerSpec}
+			}
+			infra, err := (&AWSActuator{}).MachineSetInfrastructure(ms)
+			require.NoError(t, err, "unexpected error getting machine set infrastructure")
+			assert.Equal(t, test.expectedInfra, infra, "unexpected machine set infrastructure")
+		})
+	}
+}
+
 func validateAWSMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expectedMSReplicas map[string]int64, expectedSubnetID bool, expectedKMSKey string) {
 	assert.Equal(t, len(expectedMSReplicas), len(mSets), "different number of machine sets generated than expected")
 
@@ -505,49 +868,58 @@ func validateAWSMachineSets(t *testing.T, mSets []*machineapi.MachineSet, expect
 
 func mockDescribeAvailabilityZones(client *mockaws.MockClient, zones []string) {
 	input := &ec2.DescribeAvailabilityZonesInput{
-		Filters: []*ec2.Filter{{
+		Filters: []ec2types.Filter{{
 			Name:   pointer.StringPtr("region-name"),
-			Values: []*string{pointer.StringPtr(testRegion)},
+			Values: []string{testRegion},
 		}},
 	}
-	availabilityZones := make([]*ec2.AvailabilityZone, len(zones))
+	availabilityZones := make([]ec2types.AvailabilityZone, len(zones))
 	for i := range zones {
-		availabilityZones[i] = &ec2.AvailabilityZone{
+		availabilityZones[i] = ec2types.AvailabilityZone{
 			ZoneName: &zones[i],
 		}
 	}
 	output := &ec2.DescribeAvailabilityZonesOutput{
 		AvailabilityZones: availabilityZones,
 	}
-	client.EXPECT().DescribeAvailabilityZones(input).Return(output, nil)
+	client.EXPECT().DescribeAvailabilityZones(gomock.Any(), input).Return(output, nil)
 }
 
-func mockDescribeSubnets(client *mockaws.MockClient, zones []string, privateSubnetIDs []string, pubSubnetIDs []string, vpcID string) {
-	idPointers := make([]*string, 0, len(privateSubnetIDs)+len(pubSubnetIDs))
-	for _, id := range privateSubnetIDs {
-		idPointers = append(idPointers, aws.String(id))
-	}
-	for _, id := range pubSubnetIDs {
-		idPointers = append(idPointers, aws.String(id))
+func mockDescribeInstanceTypeOfferings(client *mockaws.MockClient, zones []string) {
+	offerings := make([]ec2types.InstanceTypeOffering, len(zones))
+	for i, zone := range zones {
+		offerings[i] = ec2types.InstanceTypeOffering{
+			InstanceType: ec2types.InstanceType(testInstanceType),
+			Location:     aws.String(zone),
+			LocationType: ec2types.LocationTypeAvailabilityZone,
+		}
 	}
+	client.EXPECT().DescribeInstanceTypeOfferings(gomock.Any(), gomock.Any()).Return(
+		&ec2.DescribeInstanceTypeOfferingsOutput{InstanceTypeOfferings: offerings}, nil)
+}
+
+func mockDescribeSubnets(client *mockaws.MockClient, zones []string, privateSubnetIDs []string, pubSubnetIDs []string, vpcID string) {
+	subnetIDs := make([]string, 0, len(privateSubnetIDs)+len(pubSubnetIDs))
+	subnetIDs = append(subnetIDs, privateSubnetIDs...)
+	subnetIDs = append(subnetIDs, pubSubnetIDs...)
 	input := &ec2.DescribeSubnetsInput{
-		SubnetIds: idPointers,
+		SubnetIds: subnetIDs,
 	}
-	subnets := make([]*ec2.Subnet, len(privateSubnetIDs)+len(pubSubnetIDs))
+	subnets := make([]ec2types.Subnet, len(privateSubnetIDs)+len(pubSubnetIDs))
 	for i := range privateSubnetIDs {
-		subnets[i] = &ec2.Subnet{
+		subnets[i] = ec2types.Subnet{
 			SubnetId:         &privateSubnetIDs[i],
 			AvailabilityZone: &zones[i],
 			VpcId:            &vpcID,
 		}
 	}
 	for i := range pubSubnetIDs {
-		subnets[len(privateSubnetIDs)+i] = &ec2.Subnet{
+		subnets[len(privateSubnetIDs)+i] = ec2types.Subnet{
 			SubnetId:         &pubSubnetIDs[i],
 			AvailabilityZone: &zones[i],
 			VpcId:            &vpcID,
-			Tags: []*ec2.Tag{{
-				Key:   aws.String(tagNameSubnetPublicELB),
+			Tags: []ec2types.Tag{{
+				Key:   aws.String(awsclient.TagNameSubnetPublicELB),
 				Value: aws.String("1"),
 			}},
 		}
@@ -555,25 +927,21 @@ func mockDescribeSubnets(client *mockaws.MockClient, zones []string, privateSubn
 	output := &ec2.DescribeSubnetsOutput{
 		Subnets: subnets,
 	}
-	client.EXPECT().DescribeSubnets(input).Return(output, nil)
+	client.EXPECT().DescribeSubnets(gomock.Any(), input).Return(output, nil)
 }
 
 func mockDescribeMissingSubnets(client *mockaws.MockClient, subnetIDs []string) {
-	idPointers := make([]*string, 0, len(subnetIDs))
-	for _, id := range subnetIDs {
-		idPointers = append(idPointers, aws.String(id))
-	}
 	input := &ec2.DescribeSubnetsInput{
-		SubnetIds: idPointers,
+		SubnetIds: subnetIDs,
 	}
-	client.EXPECT().DescribeSubnets(input).Return(nil, fmt.Errorf("InvalidSubnets"))
+	client.EXPECT().DescribeSubnets(gomock.Any(), input).Return(nil, fmt.Errorf("InvalidSubnets"))
 }
 
 func mockDescribeRouteTables(client *mockaws.MockClient, subnets map[string]bool, vpc string) {
 	input := &ec2.DescribeRouteTablesInput{
-		Filters: []*ec2.Filter{{
+		Filters: []ec2types.Filter{{
 			Name:   aws.String("vpc-id"),
-			Values: []*string{aws.String(vpc)},
+			Values: []string{vpc},
 		}},
 	}
 
@@ -581,15 +949,15 @@ func mockDescribeRouteTables(client *mockaws.MockClient, subnets map[string]bool
 		RouteTables: constructRouteTables(subnets),
 	}
 
-	client.EXPECT().DescribeRouteTables(input).Return(output, nil)
+	client.EXPECT().DescribeRouteTables(gomock.Any(), input).Return(output, nil)
 }
 
 // Takes a list of subnets with bool indicating if the corresponding subnet is public
-func constructRouteTables(subnets map[string]bool) (routeTablesOut []*ec2.RouteTable) {
+func constructRouteTables(subnets map[string]bool) (routeTablesOut []ec2types.RouteTable) {
 	routeTablesOut = append(routeTablesOut,
-		&ec2.RouteTable{
-			Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}},
-			Routes: []*ec2.Route{{
+		ec2types.RouteTable{
+			Associations: []ec2types.RouteTableAssociation{{Main: aws.Bool(true)}},
+			Routes: []ec2types.Route{{
 				DestinationCidrBlock: aws.String("0.0.0.0/0"),
 				GatewayId:            aws.String("igw-main"),
 			}},
@@ -607,16 +975,16 @@ func constructRouteTables(subnets map[string]bool) (routeTablesOut []*ec2.RouteT
 	return
 }
 
-func constructRouteTable(subnetID string, public bool) *ec2.RouteTable {
+func constructRouteTable(subnetID string, public bool) ec2types.RouteTable {
 	var gatewayID string
 	if public {
 		gatewayID = "igw-" + subnetID[len(subnetID)-8:8]
 	} else {
 		gatewayID = "vgw-" + subnetID[len(subnetID)-8:8]
 	}
-	return &ec2.RouteTable{
-		Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String(subnetID)}},
-		Routes: []*ec2.Route{{
+	return ec2types.RouteTable{
+		Associations: []ec2types.RouteTableAssociation{{SubnetId: aws.String(subnetID)}},
+		Routes: []ec2types.Route{{
 			DestinationCidrBlock: aws.String("0.0.0.0/0"),
 			GatewayId:            aws.String(gatewayID),
 		}},
@@ -648,3 +1016,8 @@ func withKMSKey(pool *hivev1.MachinePool) *hivev1.MachinePool {
 	pool.Spec.Platform.AWS.EC2RootVolume.KMSKeyARN = fakeKMSKeyARN
 	return pool
 }
+
+func withInstanceTypeAvailableZoneSelection(pool *hivev1.MachinePool) *hivev1.MachinePool {
+	pool.Spec.Platform.AWS.ZoneSelection = awshivev1.InstanceTypeAvailableZoneSelection
+	return pool
+}
@@ -3,14 +3,20 @@ package machinepool
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/blang/semver/v4"
 	machineapi "github.com/openshift/api/machine/v1beta1"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	azureprovider "sigs.k8s.io/cluster-api-provider-azure/pkg/apis/azureprovider/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	installazure "github.com/openshift/installer/pkg/asset/machines/azure"
 	installertypes "github.com/openshift/installer/pkg/types"
@@ -18,27 +24,43 @@ import (
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/azureclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// unsupportedSpotVMOptionsReason is the reason of the UnsupportedConfiguration condition of pools using Spot VMs in
+	// clusters whose version does not support them.
+	unsupportedSpotVMOptionsReason = "UnsupportedSpotVMOptions"
+)
+
+var (
+	versionsSupportingAzureSpotVMs = semver.MustParseRange(">=4.6.0")
 )
 
 // AzureActuator encapsulates the pieces necessary to be able to generate
 // a list of MachineSets to sync to the remote cluster.
 type AzureActuator struct {
-	client azureclient.Client
-	logger log.FieldLogger
+	client     azureclient.Client
+	kubeClient client.Client
+	logger     log.FieldLogger
+	// lookups caches the zones and subnets of the subscription across reconciles.
+	lookups cloudLookups
 }
 
 var _ Actuator = &AzureActuator{}
 
 // NewAzureActuator is the constructor for building a AzureActuator
-func NewAzureActuator(azureCreds *corev1.Secret, cloudName string, logger log.FieldLogger) (*AzureActuator, error) {
+func NewAzureActuator(kubeClient client.Client, azureCreds *corev1.Secret, cloudName string, lookups cloudLookups, logger log.FieldLogger) (*AzureActuator, error) {
 	azureClient, err := azureclient.NewClientFromSecret(azureCreds, cloudName)
 	if err != nil {
 		logger.WithError(err).Warn("failed to create Azure client with creds in clusterDeployment's secret")
 		return nil, err
 	}
 	actuator := &AzureActuator{
-		client: azureClient,
-		logger: logger,
+		client:     azureClient,
+		kubeClient: kubeClient,
+		logger:     logger,
+		lookups:    lookups,
 	}
 	return actuator, nil
 }
@@ -55,6 +77,11 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 	if pool.Spec.Platform.Azure == nil {
 		return nil, false, errors.New("MachinePool is not for Azure")
 	}
+	invalidateSubnetLookupsOfInvalidPool(a.lookups, pool)
+
+	if proceed, err := a.checkSpotVMOptions(cd, pool, logger); err != nil || !proceed {
+		return nil, false, err
+	}
 
 	ic := &installertypes.InstallConfig{
 		Platform: installertypes.Platform{
@@ -84,7 +111,22 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 		computePool.Platform.Azure.Zones = zones
 	}
 
-	// The imageID parameter is not used. The image is determined by the infraID.
+	platform := pool.Spec.Platform.Azure
+	if platform.VirtualNetwork != "" {
+		ic.Platform.Azure.NetworkResourceGroupName = platform.NetworkResourceGroupName
+		ic.Platform.Azure.VirtualNetwork = platform.VirtualNetwork
+		ic.Platform.Azure.ComputeSubnet = platform.ComputeSubnet
+	}
+	var subnetsByZone map[string]string
+	if platform.VirtualNetwork != "" || platform.ComputeSubnet != "" || len(platform.ZoneSubnets) > 0 {
+		var err error
+		subnetsByZone, err = a.getSubnetsByZone(cd, pool, computePool.Platform.Azure.Zones)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	// The imageID parameter is not used. The image is determined by the infraID, unless the pool has an osImage.
 	const imageID = ""
 
 	installerMachineSets, err := installazure.MachineSets(
@@ -95,10 +137,217 @@ func (a *AzureActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *
 		workerRole,
 		workerUserDataName,
 	)
-	return installerMachineSets, err == nil, errors.Wrap(err, "failed to generate machinesets")
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to generate machinesets")
+	}
+
+	for _, ms := range installerMachineSets {
+		providerSpec, ok := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
+		if !ok {
+			return nil, false, errors.New("failed to convert to Azure provider spec")
+		}
+		if subnet, ok := subnetsByZone[to.String(providerSpec.Zone)]; ok {
+			providerSpec.Subnet = subnet
+		}
+		if osImage := platform.OSImage; osImage != nil {
+			providerSpec.Image = azureprovider.Image{
+				ResourceID: osImage.ResourceID,
+				Publisher:  osImage.Publisher,
+				Offer:      osImage.Offer,
+				SKU:        osImage.SKU,
+				Version:    osImage.Version,
+			}
+		}
+		if spot := platform.SpotVMOptions; spot != nil {
+			providerSpec.SpotVMOptions = &azureprovider.SpotVMOptions{}
+			if spot.MaxPrice != nil {
+				maxPrice, err := resource.ParseQuantity(*spot.MaxPrice)
+				if err != nil {
+					return nil, false, errors.Wrap(err, "could not parse spot VM max price")
+				}
+				providerSpec.SpotVMOptions.MaxPrice = &maxPrice
+			}
+		}
+	}
+	return installerMachineSets, true, nil
+}
+
+// checkSpotVMOptions checks that the version of the cluster supports the Spot VMs requested by the pool. When it does
+// not, the UnsupportedConfiguration condition of the pool is set and the pool does not proceed.
+func (a *AzureActuator) checkSpotVMOptions(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) (bool, error) {
+	if pool.Spec.Platform.Azure.SpotVMOptions != nil {
+		clusterVersion, err := getClusterVersion(cd)
+		if err != nil {
+			return false, fmt.Errorf("Unable to get cluster version: %v", err)
+		}
+		parsedVersion, err := semver.ParseTolerant(clusterVersion)
+		if err != nil {
+			logger.WithError(err).WithField("clusterVersion", clusterVersion).Warn("could not parse the cluster version")
+		} else {
+			// Use only major, minor, and patch so that pre-release versions of 4.6.0 are within the >=4.6.0 range.
+			parsedVersion = semver.Version{
+				Major: parsedVersion.Major,
+				Minor: parsedVersion.Minor,
+				Patch: parsedVersion.Patch,
+			}
+		}
+		if err != nil || !versionsSupportingAzureSpotVMs(parsedVersion) {
+			logger.WithField("clusterVersion", clusterVersion).Debug("cluster does not support spot VMs")
+			return false, a.setUnsupportedConfigurationCondition(pool, corev1.ConditionTrue, unsupportedSpotVMOptionsReason,
+				"The version of the cluster does not support using Spot VMs")
+		}
+	}
+	if cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.UnsupportedConfigurationMachinePoolCondition); cond != nil && cond.Reason == unsupportedSpotVMOptionsReason {
+		if err := a.setUnsupportedConfigurationCondition(pool, corev1.ConditionFalse, "ConfigurationSupported", "The configuration is supported"); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// MachineSetInfrastructure returns the availability zone, subnet, VM size and image of the machines of the MachineSet.
+// Marketplace images are reported as publisher:offer:sku:version.
+func (a *AzureActuator) MachineSetInfrastructure(ms *machineapi.MachineSet) (*hivev1.MachineSetInfrastructure, error) {
+	spec := &azureprovider.AzureMachineProviderSpec{}
+	if err := unmarshalProviderSpec(ms.Spec.Template.Spec.ProviderSpec.Value, spec); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal Azure provider spec")
+	}
+	infra := &hivev1.MachineSetInfrastructure{
+		Zone:         to.String(spec.Zone),
+		Subnet:       spec.Subnet,
+		InstanceType: spec.VMSize,
+		Image:        spec.Image.ResourceID,
+	}
+	if infra.Image == "" && spec.Image.Offer != "" {
+		infra.Image = strings.Join([]string{spec.Image.Publisher, spec.Image.Offer, spec.Image.SKU, spec.Image.Version}, ":")
+	}
+	return infra, nil
+}
+
+// getSubnetsByZone returns the subnet to create the machines of each zone of the pool in, and ensures that the subnets
+// exist in the virtual network of the pool. The InvalidSubnets condition of the pool reports missing subnets.
+func (a *AzureActuator) getSubnetsByZone(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, zones []string) (map[string]string, error) {
+	platform := pool.Spec.Platform.Azure
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	resourceGroup, virtualNetwork, defaultSubnet := platform.NetworkResourceGroupName, platform.VirtualNetwork, platform.ComputeSubnet
+	if virtualNetwork == "" {
+		resourceGroup = fmt.Sprintf("%s-rg", infraID)
+		if p := cd.Status.Platform; p != nil && p.Azure != nil && p.Azure.ResourceGroupName != "" {
+			resourceGroup = p.Azure.ResourceGroupName
+		}
+		virtualNetwork = fmt.Sprintf("%s-vnet", infraID)
+		if defaultSubnet == "" {
+			defaultSubnet = fmt.Sprintf("%s-%s-subnet", infraID, workerRole)
+		}
+	}
+
+	subnetsByZone := make(map[string]string, len(zones))
+	for _, zone := range zones {
+		subnet := platform.ZoneSubnets[zone]
+		if subnet == "" {
+			subnet = defaultSubnet
+		}
+		if subnet == "" {
+			message := fmt.Sprintf("no subnet for zone %s", zone)
+			if err := a.setInvalidSubnetsCondition(pool, corev1.ConditionTrue, "NoSubnetForAvailabilityZone", message); err != nil {
+				return nil, err
+			}
+			return nil, errors.New(message)
+		}
+		subnetsByZone[zone] = subnet
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+	checked := map[string]bool{}
+	var missing []string
+	for _, subnet := range subnetsByZone {
+		if checked[subnet] {
+			continue
+		}
+		checked[subnet] = true
+		exists, err := a.lookups.get(fmt.Sprintf("subnets/%s/%s/%s", resourceGroup, virtualNetwork, subnet), func() (interface{}, error) {
+			resp, err := a.client.GetSubnet(ctx, resourceGroup, virtualNetwork, subnet)
+			if err != nil {
+				if resp.Response.Response != nil && resp.StatusCode == http.StatusNotFound {
+					return false, nil
+				}
+				return nil, err
+			}
+			return true, nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get subnet %s", subnet)
+		}
+		if !exists.(bool) {
+			missing = append(missing, subnet)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		message := fmt.Sprintf("subnets %s do not exist in virtual network %s of resource group %s",
+			strings.Join(missing, ", "), virtualNetwork, resourceGroup)
+		if err := a.setInvalidSubnetsCondition(pool, corev1.ConditionTrue, "SubnetsNotFound", message); err != nil {
+			return nil, err
+		}
+		return nil, errors.New(message)
+	}
+
+	if err := a.setInvalidSubnetsCondition(pool, corev1.ConditionFalse, "ValidSubnets", "Subnets are valid"); err != nil {
+		return nil, err
+	}
+	return subnetsByZone, nil
+}
+
+// setUnsupportedConfigurationCondition sets the UnsupportedConfiguration condition of the pool, and updates the status
+// of the pool when the condition changed.
+func (a *AzureActuator) setUnsupportedConfigurationCondition(pool *hivev1.MachinePool, status corev1.ConditionStatus, reason, message string) error {
+	conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.UnsupportedConfigurationMachinePoolCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	pool.Status.Conditions = conds
+	return errors.Wrap(a.kubeClient.Status().Update(context.Background(), pool), "could not update MachinePool status")
+}
+
+func (a *AzureActuator) setInvalidSubnetsCondition(pool *hivev1.MachinePool, status corev1.ConditionStatus, reason, message string) error {
+	updateCheck := controllerutils.UpdateConditionIfReasonOrMessageChange
+	if status == corev1.ConditionFalse {
+		updateCheck = controllerutils.UpdateConditionNever
+	}
+	conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.InvalidSubnetsMachinePoolCondition,
+		status,
+		reason,
+		message,
+		updateCheck,
+	)
+	if !changed {
+		return nil
+	}
+	pool.Status.Conditions = conds
+	return a.kubeClient.Status().Update(context.Background(), pool)
 }
 
 func (a *AzureActuator) getZones(region string, instanceType string) ([]string, error) {
+	zones, err := a.lookups.get(fmt.Sprintf("zones/%s/%s", region, instanceType), func() (interface{}, error) {
+		return a.listZones(region, instanceType)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{}, zones.([]string)...), nil
+}
+
+func (a *AzureActuator) listZones(region string, instanceType string) ([]string, error) {
 	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
 	defer cancel()
 
@@ -1,22 +1,36 @@
 package machinepool
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2017-10-01/network"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	machineapi "github.com/openshift/api/machine/v1beta1"
 	azureprovider "sigs.k8s.io/cluster-api-provider-azure/pkg/apis/azureprovider/v1beta1"
 
+	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	mockazure "github.com/openshift/hive/pkg/azureclient/mock"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	testGalleryImageID = "/subscriptions/test-subscription/resourceGroups/images-rg/providers/Microsoft.Compute/galleries/gallery/images/golden/versions/1.0.0"
 )
 
 func TestAzureActuator(t *testing.T) {
@@ -26,7 +40,11 @@ func TestAzureActuator(t *testing.T) {
 		clusterDeployment          *hivev1.ClusterDeployment
 		pool                       *hivev1.MachinePool
 		expectedMachineSetReplicas map[string]int64
+		expectedSubnets            map[string]string
+		expectedImage              *azureprovider.Image
+		expectedSpotVMOptions      *azureprovider.SpotVMOptions
 		expectedErr                bool
+		expectedCondition          *hivev1.MachinePoolCondition
 	}{
 		{
 			name:              "generate single machineset for single zone",
@@ -108,9 +126,190 @@ func TestAzureActuator(t *testing.T) {
 			},
 			expectedErr: true,
 		},
+		{
+			name:              "subnets of virtual network",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				pool := testAzurePool()
+				pool.Spec.Platform.Azure.Zones = []string{"zone1", "zone2", "zone3"}
+				pool.Spec.Platform.Azure.NetworkResourceGroupName = "network-rg"
+				pool.Spec.Platform.Azure.VirtualNetwork = "peered-vnet"
+				pool.Spec.Platform.Azure.ComputeSubnet = "compute-subnet"
+				pool.Spec.Platform.Azure.ZoneSubnets = map[string]string{"zone2": "zone2-subnet"}
+				return pool
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockGetSubnet(client, "network-rg", "peered-vnet", "compute-subnet", true)
+				mockGetSubnet(client, "network-rg", "peered-vnet", "zone2-subnet", true)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 1,
+				generateAzureMachineSetName("zone2"): 1,
+				generateAzureMachineSetName("zone3"): 1,
+			},
+			expectedSubnets: map[string]string{
+				generateAzureMachineSetName("zone1"): "compute-subnet",
+				generateAzureMachineSetName("zone2"): "zone2-subnet",
+				generateAzureMachineSetName("zone3"): "compute-subnet",
+			},
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.InvalidSubnetsMachinePoolCondition,
+				Status: corev1.ConditionFalse,
+				Reason: "ValidSubnets",
+			},
+		},
+		{
+			name:              "zone subnet of cluster virtual network",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				pool := testAzurePool()
+				pool.Spec.Platform.Azure.Zones = []string{"zone1", "zone2"}
+				pool.Spec.Platform.Azure.ZoneSubnets = map[string]string{"zone1": "zone1-subnet"}
+				return pool
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockGetSubnet(client, testInfraID+"-rg", testInfraID+"-vnet", "zone1-subnet", true)
+				mockGetSubnet(client, testInfraID+"-rg", testInfraID+"-vnet", testInfraID+"-worker-subnet", true)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 2,
+				generateAzureMachineSetName("zone2"): 1,
+			},
+			expectedSubnets: map[string]string{
+				generateAzureMachineSetName("zone1"): "zone1-subnet",
+				generateAzureMachineSetName("zone2"): testInfraID + "-worker-subnet",
+			},
+		},
+		{
+			name:              "no subnet for zone",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				pool := testAzurePool()
+				pool.Spec.Platform.Azure.NetworkResourceGroupName = "network-rg"
+				pool.Spec.Platform.Azure.VirtualNetwork = "peered-vnet"
+				pool.Spec.Platform.Azure.ZoneSubnets = map[string]string{"zone1": "zone1-subnet"}
+				return pool
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockListResourceSKUs(mockCtrl, client, []string{"zone1", "zone2"})
+			},
+			expectedErr: true,
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.InvalidSubnetsMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "NoSubnetForAvailabilityZone",
+			},
+		},
+		{
+			name:              "missing subnet",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				pool := testAzurePool()
+				pool.Spec.Platform.Azure.Zones = []string{"zone1"}
+				pool.Spec.Platform.Azure.NetworkResourceGroupName = "network-rg"
+				pool.Spec.Platform.Azure.VirtualNetwork = "peered-vnet"
+				pool.Spec.Platform.Azure.ComputeSubnet = "missing-subnet"
+				return pool
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockGetSubnet(client, "network-rg", "peered-vnet", "missing-subnet", false)
+			},
+			expectedErr: true,
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.InvalidSubnetsMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: "SubnetsNotFound",
+			},
+		},
+		{
+			name:              "gallery image",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				pool := testAzurePool()
+				pool.Spec.Platform.Azure.OSImage = &hivev1azure.OSImage{ResourceID: testGalleryImageID}
+				return pool
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockListResourceSKUs(mockCtrl, client, []string{"zone1"})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 3,
+			},
+			expectedImage: &azureprovider.Image{ResourceID: testGalleryImageID},
+		},
+		{
+			name:              "marketplace image",
+			clusterDeployment: testAzureClusterDeployment(),
+			pool: func() *hivev1.MachinePool {
+				pool := testAzurePool()
+				pool.Spec.Platform.Azure.OSImage = &hivev1azure.OSImage{
+					Publisher: "redhat",
+					Offer:     "rh-ocp-worker",
+					SKU:       "rh-ocp-worker",
+					Version:   "4.8.2021122100",
+				}
+				return pool
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockListResourceSKUs(mockCtrl, client, []string{"zone1"})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 3,
+			},
+			expectedImage: &azureprovider.Image{
+				Publisher: "redhat",
+				Offer:     "rh-ocp-worker",
+				SKU:       "rh-ocp-worker",
+				Version:   "4.8.2021122100",
+			},
+		},
+		{
+			name: "spot VMs",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testAzureClusterDeployment()
+				cd.Labels[constants.VersionMajorMinorPatchLabel] = "4.6.0"
+				return cd
+			}(),
+			pool: func() *hivev1.MachinePool {
+				pool := testAzurePool()
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{MaxPrice: pointer.StringPtr("0.05")}
+				return pool
+			}(),
+			mockAzureClient: func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {
+				mockListResourceSKUs(mockCtrl, client, []string{"zone1"})
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateAzureMachineSetName("zone1"): 3,
+			},
+			expectedSpotVMOptions: &azureprovider.SpotVMOptions{MaxPrice: func() *resource.Quantity {
+				maxPrice := resource.MustParse("0.05")
+				return &maxPrice
+			}()},
+		},
+		{
+			name: "spot VMs unsupported by cluster version",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testAzureClusterDeployment()
+				cd.Labels[constants.VersionMajorMinorPatchLabel] = "4.5.9"
+				return cd
+			}(),
+			pool: func() *hivev1.MachinePool {
+				pool := testAzurePool()
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{}
+				return pool
+			}(),
+			mockAzureClient:            func(mockCtrl *gomock.Controller, client *mockazure.MockClient) {},
+			expectedMachineSetReplicas: map[string]int64{},
+			expectedCondition: &hivev1.MachinePoolCondition{
+				Type:   hivev1.UnsupportedConfigurationMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+				Reason: unsupportedSpotVMOptionsReason,
+			},
+		},
 	}
 
 	for _, test := range tests {
+		apis.AddToScheme(scheme.Scheme)
 		t.Run(test.name, func(t *testing.T) {
 
 			mockCtrl := gomock.NewController(t)
@@ -122,8 +321,9 @@ func TestAzureActuator(t *testing.T) {
 			test.mockAzureClient(mockCtrl, aClient)
 
 			actuator := &AzureActuator{
-				client: aClient,
-				logger: log.WithField("actuator", "azureactuator"),
+				client:     aClient,
+				kubeClient: fake.NewFakeClient(test.pool),
+				logger:     log.WithField("actuator", "azureactuator"),
 			}
 
 			generatedMachineSets, _, err := actuator.GenerateMachineSets(test.clusterDeployment, test.pool, actuator.logger)
@@ -133,6 +333,25 @@ func TestAzureActuator(t *testing.T) {
 			} else {
 				validateAzureMachineSets(t, generatedMachineSets, test.expectedMachineSetReplicas)
 			}
+			for _, ms := range generatedMachineSets {
+				if expectedSubnet, ok := test.expectedSubnets[ms.Name]; ok {
+					azureProvider := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
+					assert.Equal(t, expectedSubnet, azureProvider.Subnet, "unexpected subnet")
+				}
+				if test.expectedImage != nil {
+					azureProvider := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
+					assert.Equal(t, *test.expectedImage, azureProvider.Image, "unexpected image")
+				}
+				azureProvider := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*azureprovider.AzureMachineProviderSpec)
+				assert.Equal(t, test.expectedSpotVMOptions, azureProvider.SpotVMOptions, "unexpected spot VM options")
+			}
+			if test.expectedCondition != nil {
+				cond := controllerutils.FindMachinePoolCondition(test.pool.Status.Conditions, test.expectedCondition.Type)
+				if assert.NotNilf(t, cond, "did not find expected condition type: %v", test.expectedCondition.Type) {
+					assert.Equal(t, test.expectedCondition.Status, cond.Status, "condition found with unexpected status")
+					assert.Equal(t, test.expectedCondition.Reason, cond.Reason, "condition found with unexpected reason")
+				}
+			}
 		})
 	}
 }
@@ -172,6 +391,17 @@ func mockListResourceSKUs(mockCtrl *gomock.Controller, client *mockazure.MockCli
 	)
 }
 
+func mockGetSubnet(client *mockazure.MockClient, resourceGroup, virtualNetwork, subnet string, exists bool) {
+	if exists {
+		client.EXPECT().GetSubnet(gomock.Any(), resourceGroup, virtualNetwork, subnet).
+			Return(network.Subnet{Name: pointer.StringPtr(subnet)}, nil)
+		return
+	}
+	client.EXPECT().GetSubnet(gomock.Any(), resourceGroup, virtualNetwork, subnet).
+		Return(network.Subnet{Response: autorest.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}},
+			errors.New("subnet not found"))
+}
+
 func generateAzureMachineSetName(zone string) string {
 	return fmt.Sprintf("%s-%s-%s%s", testInfraID, testPoolName, testRegion, zone)
 }
@@ -0,0 +1,327 @@
+package machinepool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	machineSetLabel = "machine.openshift.io/cluster-api-machineset"
+
+	// blueGreenVersionSeparator separates the name of a generated MachineSet from the version of the MachineSets
+	// which replace it in BlueGreen rollouts.
+	blueGreenVersionSeparator = "-v"
+)
+
+// blueGreenStep is a step of a BlueGreen rollout, reported as the reason of the RolloutInProgress condition. Steps are
+// ordered from the furthest from completion.
+type blueGreenStep int
+
+const (
+	blueGreenComplete blueGreenStep = iota
+	blueGreenDeletingOld
+	blueGreenScalingDownOld
+	blueGreenWaitingForNew
+	blueGreenWaitingForMaintenanceWindow
+)
+
+func (s blueGreenStep) reason() string {
+	switch s {
+	case blueGreenWaitingForMaintenanceWindow:
+		return "WaitingForMaintenanceWindow"
+	case blueGreenWaitingForNew:
+		return "WaitingForNewMachineSets"
+	case blueGreenScalingDownOld:
+		return "ScalingDownOldMachineSets"
+	case blueGreenDeletingOld:
+		return "DeletingOldMachineSets"
+	default:
+		return "RolloutComplete"
+	}
+}
+
+func (s blueGreenStep) message() string {
+	switch s {
+	case blueGreenWaitingForMaintenanceWindow:
+		return "Waiting for a maintenance window of the cluster to replace MachineSets"
+	case blueGreenWaitingForNew:
+		return "Waiting for the machines of the new MachineSets to be ready"
+	case blueGreenScalingDownOld:
+		return "Scaling down the replaced MachineSets"
+	case blueGreenDeletingOld:
+		return "Deleting the replaced MachineSets"
+	default:
+		return "The MachineSets of the machine pool are up to date"
+	}
+}
+
+// replacesMachineSets returns true if the MachineSets of the pool are replaced by new MachineSets when its platform
+// changes, either all at once with the BlueGreen strategy or a few machines at a time with the RollingReplacement
+// strategy, rather than having their machines replaced.
+func replacesMachineSets(pool *hivev1.MachinePool) bool {
+	return pool.Spec.RolloutStrategy != nil && (pool.Spec.RolloutStrategy.Type == hivev1.BlueGreenMachinePoolRolloutStrategyType ||
+		pool.Spec.RolloutStrategy.Type == hivev1.RollingReplacementMachinePoolRolloutStrategyType)
+}
+
+// isRollingReplacement returns true if the replicas of replaced MachineSets are moved to the new MachineSets a few at a
+// time, within the bounds of maxSurge and maxUnavailable.
+func isRollingReplacement(pool *hivev1.MachinePool) bool {
+	return pool.Spec.RolloutStrategy != nil && pool.Spec.RolloutStrategy.Type == hivev1.RollingReplacementMachinePoolRolloutStrategyType
+}
+
+// planMachineSetReplacement returns the MachineSets to sync to the remote cluster for pools with the BlueGreen or
+// RollingReplacement rollout strategy. Each generated MachineSet is named after the newest version of it in the remote
+// cluster, or after a new version when the provider spec of the newest version is outdated. Older versions are kept
+// until the newest version is ready, then scaled down, and finally left out so that they are deleted. In
+// RollingReplacement rollouts, the newest version is instead scaled up while the older versions are scaled down, as
+// planned by planRollingReplacement. While no maintenance window of the cluster is open, new versions are not created
+// and older versions are not scaled down.
+func (r *ReconcileMachinePool) planMachineSetReplacement(
+	pool *hivev1.MachinePool,
+	generatedMachineSets []*machineapi.MachineSet,
+	remoteMachineSets *machineapi.MachineSetList,
+	windowOpen bool,
+	logger log.FieldLogger,
+) ([]*machineapi.MachineSet, *hivev1.MachinePoolRolloutStatus, error) {
+	status := &hivev1.MachinePoolRolloutStatus{Generation: pool.Generation}
+	step := blueGreenComplete
+	rolling := isRollingReplacement(pool)
+	var result []*machineapi.MachineSet
+	for _, ms := range generatedMachineSets {
+		baseName := ms.Name
+		var versions []*machineapi.MachineSet
+		var newest *machineapi.MachineSet
+		newestVersion := 0
+		for i := range remoteMachineSets.Items {
+			rMS := &remoteMachineSets.Items[i]
+			version, ok := machineSetVersion(baseName, rMS.Name)
+			if !ok {
+				continue
+			}
+			versions = append(versions, rMS)
+			if version > newestVersion {
+				newest, newestVersion = rMS, version
+			}
+		}
+		if newest == nil {
+			result = append(result, ms)
+			continue
+		}
+
+		matches, err := providerSpecMatches(ms.Spec.Template.Spec.ProviderSpec.Value, newest.Spec.Template.Spec.ProviderSpec.Value)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to compare provider spec of machineset %s", newest.Name)
+		}
+		newReady := false
+		var newReplicas, newReadyReplicas int32
+		switch {
+		case matches:
+			renameMachineSet(ms, newest.Name)
+			newReady = ms.Spec.Replicas == nil || newest.Status.ReadyReplicas >= *ms.Spec.Replicas
+			status.UpdatedReplicas += newest.Status.ReadyReplicas
+			if newest.Spec.Replicas != nil {
+				newReplicas = *newest.Spec.Replicas
+			}
+			newReadyReplicas = newest.Status.ReadyReplicas
+		case !windowOpen:
+			logger.WithField("machineset", newest.Name).Info("waiting for a maintenance window to replace outdated machineset")
+			step = maxBlueGreenStep(step, blueGreenWaitingForMaintenanceWindow)
+			renameMachineSet(ms, newest.Name)
+			ms.Spec.Template.Spec.ProviderSpec = newest.Spec.Template.Spec.ProviderSpec
+			status.OutdatedReplicas += newest.Status.Replicas
+		default:
+			name := fmt.Sprintf("%s%s%d", baseName, blueGreenVersionSeparator, newestVersion+1)
+			logger.WithField("machineset", newest.Name).WithField("replacement", name).Info("replacing outdated machineset")
+			renameMachineSet(ms, name)
+		}
+		result = append(result, ms)
+
+		var olds []*machineapi.MachineSet
+		for _, old := range versions {
+			if old.Name != ms.Name {
+				olds = append(olds, old)
+			}
+		}
+		// Older versions are scaled down first.
+		sort.Slice(olds, func(i, j int) bool {
+			vi, _ := machineSetVersion(baseName, olds[i].Name)
+			vj, _ := machineSetVersion(baseName, olds[j].Name)
+			return vi < vj
+		})
+		var oldTargets []int32
+		switch {
+		case !rolling || len(olds) == 0:
+		case !windowOpen:
+			// Replicas are not moved between the versions until a maintenance window opens.
+			step = maxBlueGreenStep(step, blueGreenWaitingForMaintenanceWindow)
+			ms.Spec.Replicas = newest.Spec.Replicas
+		default:
+			maxSurge, maxUnavailable, err := resolveRolloutStrategy(pool, *ms.Spec.Replicas)
+			if err != nil {
+				return nil, nil, err
+			}
+			var newTarget int32
+			newTarget, oldTargets = planRollingReplacement(*ms.Spec.Replicas, maxSurge, maxUnavailable, newReplicas, newReadyReplicas, olds)
+			ms.Spec.Replicas = &newTarget
+		}
+
+		for i, old := range olds {
+			msLog := logger.WithField("machineset", old.Name)
+			replicas := machineSetReplicas(old)
+			status.OutdatedReplicas += replicas
+			old := old.DeepCopy()
+			switch {
+			case rolling && replicas == 0:
+				msLog.Info("deleting replaced machineset")
+				step = maxBlueGreenStep(step, blueGreenDeletingOld)
+			case rolling && oldTargets == nil:
+				result = append(result, old)
+			case rolling && oldTargets[i] < replicas:
+				msLog.WithField("replicas", oldTargets[i]).Info("scaling down replaced machineset")
+				step = maxBlueGreenStep(step, blueGreenScalingDownOld)
+				old.Spec.Replicas = &oldTargets[i]
+				result = append(result, old)
+			case rolling:
+				msLog.Debug("waiting for replacement machines to be ready")
+				step = maxBlueGreenStep(step, blueGreenWaitingForNew)
+				result = append(result, old)
+			case !newReady:
+				msLog.Debug("waiting for replacement machineset to be ready")
+				step = maxBlueGreenStep(step, blueGreenWaitingForNew)
+				result = append(result, old)
+			case replicas > 0 && !windowOpen:
+				msLog.Info("waiting for a maintenance window to scale down replaced machineset")
+				step = maxBlueGreenStep(step, blueGreenWaitingForMaintenanceWindow)
+				result = append(result, old)
+			case replicas > 0:
+				msLog.Info("scaling down replaced machineset")
+				step = maxBlueGreenStep(step, blueGreenScalingDownOld)
+				zero := int32(0)
+				old.Spec.Replicas = &zero
+				result = append(result, old)
+			default:
+				// Left out of the MachineSets to sync, so that it is deleted.
+				msLog.Info("deleting replaced machineset")
+				step = maxBlueGreenStep(step, blueGreenDeletingOld)
+			}
+		}
+	}
+
+	condStatus := corev1.ConditionFalse
+	if step != blueGreenComplete {
+		condStatus = corev1.ConditionTrue
+	}
+	conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.RolloutInProgressMachinePoolCondition,
+		condStatus,
+		step.reason(),
+		step.message(),
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if changed {
+		pool.Status.Conditions = conds
+		if err := r.Status().Update(context.Background(), pool); err != nil {
+			return nil, nil, errors.Wrap(err, "could not update MachinePool status")
+		}
+	}
+	return result, status, nil
+}
+
+// planRollingReplacement returns the replicas of the newest version of a MachineSet, and of each of its older
+// versions, in a RollingReplacement rollout. The newest version is scaled up as long as the versions have no more than
+// maxSurge machines above the desired replicas, and the older versions are scaled down as long as no more than
+// maxUnavailable of the desired replicas are not ready, within the same rolloutBudget as the machines replaced by
+// planRollout.
+func planRollingReplacement(desired, maxSurge, maxUnavailable, newReplicas, newReadyReplicas int32, olds []*machineapi.MachineSet) (int32, []int32) {
+	var oldReplicas, oldReadyReplicas int32
+	for _, old := range olds {
+		oldReplicas += machineSetReplicas(old)
+		oldReadyReplicas += old.Status.ReadyReplicas
+	}
+
+	newTarget := desired + maxSurge - oldReplicas
+	if newTarget < newReplicas {
+		newTarget = newReplicas
+	}
+	if newTarget > desired {
+		newTarget = desired
+	}
+	if newTarget < 0 {
+		newTarget = 0
+	}
+
+	budget := newRolloutBudget(desired, maxUnavailable, newReadyReplicas+oldReadyReplicas)
+	oldTargets := make([]int32, len(olds))
+	for i, old := range olds {
+		replicas := machineSetReplicas(old)
+		ready := old.Status.ReadyReplicas
+		if ready > replicas {
+			ready = replicas
+		}
+		oldTargets[i] = ready - budget.take(ready)
+		// Never scale an older version back up while its machines are being removed.
+		if old.Spec.Replicas != nil && *old.Spec.Replicas < oldTargets[i] {
+			oldTargets[i] = *old.Spec.Replicas
+		}
+	}
+	return newTarget, oldTargets
+}
+
+// machineSetReplicas returns the number of machines of a remote MachineSet, including those it is still creating or
+// deleting.
+func machineSetReplicas(ms *machineapi.MachineSet) int32 {
+	replicas := ms.Status.Replicas
+	if ms.Spec.Replicas != nil && *ms.Spec.Replicas > replicas {
+		replicas = *ms.Spec.Replicas
+	}
+	return replicas
+}
+
+// machineSetVersion returns the version of the remote MachineSet with the given name in BlueGreen rollouts of the
+// generated MachineSet with the given base name. The MachineSet named after the generated MachineSet is version 1.
+func machineSetVersion(baseName, name string) (int, bool) {
+	if name == baseName {
+		return 1, true
+	}
+	suffix := strings.TrimPrefix(name, baseName+blueGreenVersionSeparator)
+	if suffix == name {
+		return 0, false
+	}
+	version, err := strconv.Atoi(suffix)
+	if err != nil || version < 2 {
+		return 0, false
+	}
+	return version, true
+}
+
+// renameMachineSet renames a generated MachineSet, along with the label selecting its machines.
+func renameMachineSet(ms *machineapi.MachineSet, name string) {
+	if ms.Spec.Selector.MatchLabels[machineSetLabel] == ms.Name {
+		ms.Spec.Selector.MatchLabels[machineSetLabel] = name
+	}
+	if ms.Spec.Template.Labels[machineSetLabel] == ms.Name {
+		ms.Spec.Template.Labels[machineSetLabel] = name
+	}
+	ms.Name = name
+}
+
+func maxBlueGreenStep(a, b blueGreenStep) blueGreenStep {
+	if a > b {
+		return a
+	}
+	return b
+}
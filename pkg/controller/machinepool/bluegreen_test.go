@@ -0,0 +1,226 @@
+package machinepool
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+func TestPlanBlueGreenRollout(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	const msName = "foo-12345-worker-us-east-1a"
+	remoteMachineSet := func(name string, updated bool, replicas, readyReplicas int) machineapi.MachineSet {
+		ms := testMachineSet(name, "worker", false, replicas, 0)
+		ms.Spec.Selector.MatchLabels[machineSetLabel] = name
+		ms.Spec.Template.Labels[machineSetLabel] = name
+		if updated {
+			ms.Spec.Template.Spec.ProviderSpec = updatedProviderSpec()
+		}
+		ms.Status.Replicas = int32(replicas)
+		ms.Status.ReadyReplicas = int32(readyReplicas)
+		return *ms
+	}
+
+	cases := []struct {
+		name              string
+		remoteMachineSets []machineapi.MachineSet
+		rolling           bool
+		windowClosed      bool
+		expectedSets      map[string]int32
+		expectedStatus    *hivev1.MachinePoolRolloutStatus
+		expectedReason    string
+	}{
+		{
+			name:           "new machine pool",
+			expectedSets:   map[string]int32{msName: 3},
+			expectedStatus: &hivev1.MachinePoolRolloutStatus{},
+			expectedReason: "RolloutComplete",
+		},
+		{
+			name:              "up to date",
+			remoteMachineSets: []machineapi.MachineSet{remoteMachineSet(msName, true, 3, 3)},
+			expectedSets:      map[string]int32{msName: 3},
+			expectedStatus:    &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 3},
+			expectedReason:    "RolloutComplete",
+		},
+		{
+			name:              "create new machineset",
+			remoteMachineSets: []machineapi.MachineSet{remoteMachineSet(msName, false, 3, 3)},
+			expectedSets:      map[string]int32{msName: 3, msName + "-v2": 3},
+			expectedStatus:    &hivev1.MachinePoolRolloutStatus{OutdatedReplicas: 3},
+			expectedReason:    "WaitingForNewMachineSets",
+		},
+		{
+			name:              "wait for maintenance window to create new machineset",
+			remoteMachineSets: []machineapi.MachineSet{remoteMachineSet(msName, false, 3, 3)},
+			windowClosed:      true,
+			expectedSets:      map[string]int32{msName: 3},
+			expectedStatus:    &hivev1.MachinePoolRolloutStatus{OutdatedReplicas: 3},
+			expectedReason:    "WaitingForMaintenanceWindow",
+		},
+		{
+			name: "wait for new machineset",
+			remoteMachineSets: []machineapi.MachineSet{
+				remoteMachineSet(msName, false, 3, 3),
+				remoteMachineSet(msName+"-v2", true, 3, 2),
+			},
+			expectedSets:   map[string]int32{msName: 3, msName + "-v2": 3},
+			expectedStatus: &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 2, OutdatedReplicas: 3},
+			expectedReason: "WaitingForNewMachineSets",
+		},
+		{
+			name: "scale down old machineset",
+			remoteMachineSets: []machineapi.MachineSet{
+				remoteMachineSet(msName, false, 3, 3),
+				remoteMachineSet(msName+"-v2", true, 3, 3),
+			},
+			expectedSets:   map[string]int32{msName: 0, msName + "-v2": 3},
+			expectedStatus: &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 3, OutdatedReplicas: 3},
+			expectedReason: "ScalingDownOldMachineSets",
+		},
+		{
+			name: "wait for maintenance window to scale down old machineset",
+			remoteMachineSets: []machineapi.MachineSet{
+				remoteMachineSet(msName, false, 3, 3),
+				remoteMachineSet(msName+"-v2", true, 3, 3),
+			},
+			windowClosed:   true,
+			expectedSets:   map[string]int32{msName: 3, msName + "-v2": 3},
+			expectedStatus: &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 3, OutdatedReplicas: 3},
+			expectedReason: "WaitingForMaintenanceWindow",
+		},
+		{
+			name: "delete old machineset",
+			remoteMachineSets: []machineapi.MachineSet{
+				remoteMachineSet(msName, false, 0, 0),
+				remoteMachineSet(msName+"-v2", true, 3, 3),
+			},
+			expectedSets:   map[string]int32{msName + "-v2": 3},
+			expectedStatus: &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 3},
+			expectedReason: "DeletingOldMachineSets",
+		},
+		{
+			name: "replace outdated new machineset",
+			remoteMachineSets: []machineapi.MachineSet{
+				remoteMachineSet(msName, false, 3, 3),
+				remoteMachineSet(msName+"-v2", false, 3, 1),
+			},
+			expectedSets:   map[string]int32{msName: 3, msName + "-v2": 3, msName + "-v3": 3},
+			expectedStatus: &hivev1.MachinePoolRolloutStatus{OutdatedReplicas: 6},
+			expectedReason: "WaitingForNewMachineSets",
+		},
+		{
+			name:              "rolling: create new machineset",
+			remoteMachineSets: []machineapi.MachineSet{remoteMachineSet(msName, false, 3, 3)},
+			rolling:           true,
+			expectedSets:      map[string]int32{msName: 3, msName + "-v2": 1},
+			expectedStatus:    &hivev1.MachinePoolRolloutStatus{OutdatedReplicas: 3},
+			expectedReason:    "WaitingForNewMachineSets",
+		},
+		{
+			name: "rolling: scale down old machineset",
+			remoteMachineSets: []machineapi.MachineSet{
+				remoteMachineSet(msName, false, 3, 3),
+				remoteMachineSet(msName+"-v2", true, 1, 1),
+			},
+			rolling:        true,
+			expectedSets:   map[string]int32{msName: 2, msName + "-v2": 1},
+			expectedStatus: &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 1, OutdatedReplicas: 3},
+			expectedReason: "ScalingDownOldMachineSets",
+		},
+		{
+			name: "rolling: scale up new machineset",
+			remoteMachineSets: []machineapi.MachineSet{
+				remoteMachineSet(msName, false, 2, 2),
+				remoteMachineSet(msName+"-v2", true, 1, 1),
+			},
+			rolling:        true,
+			expectedSets:   map[string]int32{msName: 2, msName + "-v2": 2},
+			expectedStatus: &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 1, OutdatedReplicas: 2},
+			expectedReason: "WaitingForNewMachineSets",
+		},
+		{
+			name: "rolling: remove unready old machines",
+			remoteMachineSets: []machineapi.MachineSet{
+				remoteMachineSet(msName, false, 3, 2),
+				remoteMachineSet(msName+"-v2", true, 1, 1),
+			},
+			rolling:        true,
+			expectedSets:   map[string]int32{msName: 2, msName + "-v2": 1},
+			expectedStatus: &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 1, OutdatedReplicas: 3},
+			expectedReason: "ScalingDownOldMachineSets",
+		},
+		{
+			name: "rolling: wait for maintenance window to move replicas",
+			remoteMachineSets: []machineapi.MachineSet{
+				remoteMachineSet(msName, false, 3, 3),
+				remoteMachineSet(msName+"-v2", true, 1, 1),
+			},
+			rolling:        true,
+			windowClosed:   true,
+			expectedSets:   map[string]int32{msName: 3, msName + "-v2": 1},
+			expectedStatus: &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 1, OutdatedReplicas: 3},
+			expectedReason: "WaitingForMaintenanceWindow",
+		},
+		{
+			name: "rolling: delete old machineset",
+			remoteMachineSets: []machineapi.MachineSet{
+				remoteMachineSet(msName, false, 0, 0),
+				remoteMachineSet(msName+"-v2", true, 3, 3),
+			},
+			rolling:        true,
+			expectedSets:   map[string]int32{msName + "-v2": 3},
+			expectedStatus: &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 3},
+			expectedReason: "DeletingOldMachineSets",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testMachinePool()
+			pool.Spec.RolloutStrategy = &hivev1.MachinePoolRolloutStrategy{Type: hivev1.BlueGreenMachinePoolRolloutStrategyType}
+			if tc.rolling {
+				pool.Spec.RolloutStrategy.Type = hivev1.RollingReplacementMachinePoolRolloutStrategyType
+			}
+			r := &ReconcileMachinePool{Client: fake.NewClientBuilder().WithRuntimeObjects(pool).Build()}
+			generatedMachineSet := testMachineSet(msName, "worker", false, 3, 0)
+			generatedMachineSet.Spec.Selector.MatchLabels[machineSetLabel] = msName
+			generatedMachineSet.Spec.Template.Labels[machineSetLabel] = msName
+			generatedMachineSet.Spec.Template.Spec.ProviderSpec = updatedProviderSpec()
+
+			machineSets, status, err := r.planMachineSetReplacement(pool, []*machineapi.MachineSet{generatedMachineSet},
+				&machineapi.MachineSetList{Items: tc.remoteMachineSets}, !tc.windowClosed, log.WithField("test", tc.name))
+			require.NoError(t, err, "unexpected error planning blue/green rollout")
+
+			sets := map[string]int32{}
+			for _, ms := range machineSets {
+				sets[ms.Name] = *ms.Spec.Replicas
+				assert.Equal(t, ms.Name, ms.Spec.Selector.MatchLabels[machineSetLabel], "unexpected machineset selector")
+				assert.Equal(t, ms.Name, ms.Spec.Template.Labels[machineSetLabel], "unexpected machineset template label")
+			}
+			assert.Equal(t, tc.expectedSets, sets, "unexpected machinesets")
+			assert.Equal(t, tc.expectedStatus, status, "unexpected rollout status")
+			cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.RolloutInProgressMachinePoolCondition)
+			if assert.NotNil(t, cond, "expected rollout condition") {
+				assert.Equal(t, tc.expectedReason, cond.Reason, "unexpected rollout condition reason")
+				expectedStatus := corev1.ConditionTrue
+				if tc.expectedReason == "RolloutComplete" {
+					expectedStatus = corev1.ConditionFalse
+				}
+				assert.Equal(t, expectedStatus, cond.Status, "unexpected rollout condition status")
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package machinepool
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// cloudLookupTTL is how long the results of cloud API lookups are reused. Zones and instance type offerings hardly
+	// ever change, and changes to subnets are picked up early through invalidation when they make a pool invalid.
+	cloudLookupTTL = 5 * time.Minute
+)
+
+var (
+	metricCloudLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_machinepool_cloud_lookups_total",
+		Help: "Counter incremented for every cloud API lookup of the machine pool actuators, partitioned by whether the result was cached.",
+	}, []string{"lookup", "cached"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricCloudLookups)
+}
+
+// cloudLookupCache caches the results of the cloud API lookups of the actuators, such as zones, instance type
+// offerings and subnets, across reconciles. Without it, every reconcile of every pool repeats the same lookups.
+type cloudLookupCache struct {
+	lock    sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]cloudLookupEntry
+}
+
+type cloudLookupEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+func newCloudLookupCache(ttl time.Duration) *cloudLookupCache {
+	return &cloudLookupCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]cloudLookupEntry{},
+	}
+}
+
+// scope returns the lookups of a cloud account in a region. Each part identifies the credentials or the region, so
+// that results are never shared between accounts which may see different resources.
+func (c *cloudLookupCache) scope(parts ...string) cloudLookups {
+	return cloudLookups{cache: c, scope: strings.Join(parts, "/") + "/"}
+}
+
+// cloudLookupCredentialsKey identifies the credentials in the secret. The resource version is part of the key so that
+// lookups are not shared with credentials the secret no longer holds.
+func cloudLookupCredentialsKey(creds *corev1.Secret) string {
+	return fmt.Sprintf("secret:%s/%s@%s", creds.Namespace, creds.Name, creds.ResourceVersion)
+}
+
+// cloudLookups are the cached lookups of a cloud account in a region. The zero value caches nothing.
+type cloudLookups struct {
+	cache *cloudLookupCache
+	scope string
+}
+
+// get returns the cached result of the lookup with the given name, or runs the lookup and caches its result. Failed
+// lookups are not cached.
+func (l cloudLookups) get(name string, lookup func() (interface{}, error)) (interface{}, error) {
+	lookupType := strings.SplitN(name, "/", 2)[0]
+	if l.cache == nil {
+		metricCloudLookups.WithLabelValues(lookupType, "false").Inc()
+		return lookup()
+	}
+	key := l.scope + name
+	now := l.cache.now()
+	l.cache.lock.Lock()
+	entry, ok := l.cache.entries[key]
+	l.cache.lock.Unlock()
+	if ok && now.Before(entry.expires) {
+		metricCloudLookups.WithLabelValues(lookupType, "true").Inc()
+		return entry.value, nil
+	}
+
+	metricCloudLookups.WithLabelValues(lookupType, "false").Inc()
+	value, err := lookup()
+	if err != nil {
+		return nil, err
+	}
+	l.cache.lock.Lock()
+	defer l.cache.lock.Unlock()
+	for k, e := range l.cache.entries {
+		if !now.Before(e.expires) {
+			delete(l.cache.entries, k)
+		}
+	}
+	l.cache.entries[key] = cloudLookupEntry{value: value, expires: now.Add(l.cache.ttl)}
+	return value, nil
+}
+
+// invalidate drops the cached results of the lookups whose names start with the given prefix, so that they are
+// looked up again.
+func (l cloudLookups) invalidate(prefix string) {
+	if l.cache == nil {
+		return
+	}
+	l.cache.lock.Lock()
+	defer l.cache.lock.Unlock()
+	for k := range l.cache.entries {
+		if strings.HasPrefix(k, l.scope+prefix) {
+			delete(l.cache.entries, k)
+		}
+	}
+}
+
+// invalidateSubnetLookupsOfInvalidPool drops the cached subnets while the pool has invalid subnets, so that fixes to
+// the subnets are seen by the next reconcile rather than once the cached subnets expire.
+func invalidateSubnetLookupsOfInvalidPool(lookups cloudLookups, pool *hivev1.MachinePool) {
+	cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.InvalidSubnetsMachinePoolCondition)
+	if cond != nil && cond.Status == corev1.ConditionTrue {
+		lookups.invalidate("subnets/")
+	}
+}
@@ -0,0 +1,98 @@
+package machinepool
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestCloudLookups(t *testing.T) {
+	now := time.Now()
+	cache := newCloudLookupCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	calls := 0
+	lookup := func() (interface{}, error) {
+		calls++
+		return calls, nil
+	}
+	account1 := cache.scope("aws", "account1", "us-east-1")
+	account2 := cache.scope("aws", "account2", "us-east-1")
+
+	value, err := account1.get("zones", lookup)
+	assert.NoError(t, err, "unexpected error")
+	assert.Equal(t, 1, value, "expected lookup to run")
+	value, _ = account1.get("zones", lookup)
+	assert.Equal(t, 1, value, "expected cached result")
+	value, _ = account2.get("zones", lookup)
+	assert.Equal(t, 2, value, "expected results not to be shared between scopes")
+
+	now = now.Add(time.Minute)
+	value, _ = account1.get("zones", lookup)
+	assert.Equal(t, 3, value, "expected expired result to be looked up again")
+
+	_, err = account1.get("subnets/a", func() (interface{}, error) { return nil, errors.New("boom") })
+	assert.Error(t, err, "expected lookup error")
+	value, _ = account1.get("subnets/a", lookup)
+	assert.Equal(t, 4, value, "expected failed lookup not to be cached")
+
+	account1.invalidate("subnets/")
+	value, _ = account1.get("subnets/a", lookup)
+	assert.Equal(t, 5, value, "expected invalidated result to be looked up again")
+	value, _ = account1.get("zones", lookup)
+	assert.Equal(t, 3, value, "expected other lookups to stay cached")
+
+	var uncached cloudLookups
+	value, _ = uncached.get("zones", lookup)
+	assert.Equal(t, 6, value, "expected zero value not to cache")
+	value, _ = uncached.get("zones", lookup)
+	assert.Equal(t, 7, value, "expected zero value not to cache")
+}
+
+func TestInvalidateSubnetLookupsOfInvalidPool(t *testing.T) {
+	cases := []struct {
+		name              string
+		conditions        []hivev1.MachinePoolCondition
+		expectInvalidated bool
+	}{
+		{
+			name: "no condition",
+		},
+		{
+			name: "valid subnets",
+			conditions: []hivev1.MachinePoolCondition{{
+				Type:   hivev1.InvalidSubnetsMachinePoolCondition,
+				Status: corev1.ConditionFalse,
+			}},
+		},
+		{
+			name: "invalid subnets",
+			conditions: []hivev1.MachinePoolCondition{{
+				Type:   hivev1.InvalidSubnetsMachinePoolCondition,
+				Status: corev1.ConditionTrue,
+			}},
+			expectInvalidated: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lookups := newCloudLookupCache(time.Minute).scope("aws", "account", "us-east-1")
+			lookups.get("subnets/a", func() (interface{}, error) { return "cached", nil })
+			pool := &hivev1.MachinePool{Status: hivev1.MachinePoolStatus{Conditions: tc.conditions}}
+
+			invalidateSubnetLookupsOfInvalidPool(lookups, pool)
+
+			value, _ := lookups.get("subnets/a", func() (interface{}, error) { return "fresh", nil })
+			if tc.expectInvalidated {
+				assert.Equal(t, "fresh", value, "expected subnets to be looked up again")
+			} else {
+				assert.Equal(t, "cached", value, "expected subnets to stay cached")
+			}
+		})
+	}
+}
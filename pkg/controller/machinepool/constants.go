@@ -3,4 +3,6 @@ package machinepool
 const (
 	// workerRole is used to locate installer created cloud resources such as subnets.
 	workerRole = "worker"
+	// machineAPINamespace is the namespace of the MachineSets in the remote cluster.
+	machineAPINamespace = "openshift-machine-api"
 )
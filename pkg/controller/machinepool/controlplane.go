@@ -0,0 +1,170 @@
+package machinepool
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// controlPlaneMachineSetName is the name of the ControlPlaneMachineSet of a cluster. There is at most one.
+const controlPlaneMachineSetName = "cluster"
+
+var controlPlaneMachineSetGVK = schema.GroupVersionKind{
+	Group:   machineapi.SchemeGroupVersion.Group,
+	Version: "v1",
+	Kind:    "ControlPlaneMachineSet",
+}
+
+// controlPlaneInstanceTypeField returns the field of the provider spec of the control plane machines which holds
+// their instance type on the platform of the cluster, or an empty string when the platform is not supported.
+func controlPlaneInstanceTypeField(cd *hivev1.ClusterDeployment) string {
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		return "instanceType"
+	case cd.Spec.Platform.GCP != nil:
+		return "machineType"
+	case cd.Spec.Platform.Azure != nil:
+		return "vmSize"
+	}
+	return ""
+}
+
+// syncControlPlaneMachines applies the ControlPlaneMachines of the ClusterDeployment to the ControlPlaneMachineSet of
+// the remote cluster, which replaces the control plane machines to match it, and reports the progress in the
+// conditions of the ClusterDeployment. The ControlPlaneMachineSet is read as unstructured, as it is not known to the
+// vendored API.
+func (r *ReconcileMachinePool) syncControlPlaneMachines(
+	pool *hivev1.MachinePool,
+	cd *hivev1.ClusterDeployment,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) error {
+	if pool.DeletionTimestamp != nil {
+		return nil
+	}
+	conditions := cd.Status.Conditions
+	changed := false
+	setCondition := func(conditionType hivev1.ClusterDeploymentConditionType, status corev1.ConditionStatus, reason, message string) {
+		var c bool
+		conditions, c = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			conditions,
+			conditionType,
+			status,
+			reason,
+			message,
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		changed = changed || c
+	}
+
+	if cd.Spec.ControlPlaneMachines == nil {
+		for _, t := range []hivev1.ClusterDeploymentConditionType{
+			hivev1.ControlPlaneResizingCondition,
+			hivev1.ControlPlaneResizeUnsupportedCondition,
+		} {
+			if cond := controllerutils.FindClusterDeploymentCondition(conditions, t); cond != nil && cond.Status == corev1.ConditionTrue {
+				setCondition(t, corev1.ConditionFalse, "ControlPlaneMachinesNotConfigured", "the control plane machines are not configured")
+			}
+		}
+		return r.updateControlPlaneConditions(cd, conditions, changed, logger)
+	}
+
+	field := controlPlaneInstanceTypeField(cd)
+	if field == "" {
+		setCondition(hivev1.ControlPlaneResizeUnsupportedCondition, corev1.ConditionTrue,
+			"UnsupportedPlatform", "the control plane can only be resized on AWS, GCP and Azure")
+		return r.updateControlPlaneConditions(cd, conditions, changed, logger)
+	}
+
+	cpms := &unstructured.Unstructured{}
+	cpms.SetGroupVersionKind(controlPlaneMachineSetGVK)
+	switch err := remoteClusterAPIClient.Get(
+		context.TODO(),
+		types.NamespacedName{Namespace: machineAPINamespace, Name: controlPlaneMachineSetName},
+		cpms,
+	); {
+	case apierrors.IsNotFound(err), meta.IsNoMatchError(err):
+		// Clusters older than 4.12 have no ControlPlaneMachineSet API.
+		setCondition(hivev1.ControlPlaneResizeUnsupportedCondition, corev1.ConditionTrue,
+			"ControlPlaneMachineSetNotFound", "the cluster has no ControlPlaneMachineSet")
+		return r.updateControlPlaneConditions(cd, conditions, changed, logger)
+	case err != nil:
+		return errors.Wrap(err, "could not get control plane machine set")
+	}
+	// An inactive ControlPlaneMachineSet does not replace the control plane machines.
+	if state, _, _ := unstructured.NestedString(cpms.Object, "spec", "state"); state != "Active" {
+		setCondition(hivev1.ControlPlaneResizeUnsupportedCondition, corev1.ConditionTrue,
+			"ControlPlaneMachineSetInactive", "the ControlPlaneMachineSet of the cluster is not active")
+		return r.updateControlPlaneConditions(cd, conditions, changed, logger)
+	}
+	setCondition(hivev1.ControlPlaneResizeUnsupportedCondition, corev1.ConditionFalse,
+		"ControlPlaneMachineSetActive", "the ControlPlaneMachineSet of the cluster is active")
+
+	instanceTypePath := []string{"spec", "template", "machines_v1beta1_machine_openshift_io", "spec", "providerSpec", "value", field}
+	current, _, _ := unstructured.NestedString(cpms.Object, instanceTypePath...)
+	if desired := cd.Spec.ControlPlaneMachines.Type; current != desired {
+		logger.WithField("from", current).WithField("to", desired).Info("resizing control plane machines")
+		if err := unstructured.SetNestedField(cpms.Object, desired, instanceTypePath...); err != nil {
+			return errors.Wrap(err, "could not set instance type of control plane machine set")
+		}
+		if err := remoteClusterAPIClient.Update(context.TODO(), cpms); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update control plane machine set")
+			return err
+		}
+	}
+
+	status, reason, message := controlPlaneRollout(cpms)
+	setCondition(hivev1.ControlPlaneResizingCondition, status, reason, message)
+	return r.updateControlPlaneConditions(cd, conditions, changed, logger)
+}
+
+// controlPlaneRollout returns the status, reason and message of the ControlPlaneResizing condition for the rollout
+// of the ControlPlaneMachineSet. The rollout is in progress until the ControlPlaneMachineSet has observed its latest
+// spec and all of its machines are updated to it.
+func controlPlaneRollout(cpms *unstructured.Unstructured) (corev1.ConditionStatus, string, string) {
+	replicas, _, _ := unstructured.NestedInt64(cpms.Object, "spec", "replicas")
+	updated, _, _ := unstructured.NestedInt64(cpms.Object, "status", "updatedReplicas")
+	observedGeneration, _, _ := unstructured.NestedInt64(cpms.Object, "status", "observedGeneration")
+	if observedGeneration >= cpms.GetGeneration() && updated >= replicas {
+		return corev1.ConditionFalse, "Resized", fmt.Sprintf("all %d control plane machines are updated", replicas)
+	}
+	if strategy, _, _ := unstructured.NestedString(cpms.Object, "spec", "strategy", "type"); strategy == "OnDelete" {
+		return corev1.ConditionTrue, "WaitingForMachineDeletion",
+			fmt.Sprintf("%d of %d control plane machines are updated, the others are replaced once they are deleted", updated, replicas)
+	}
+	return corev1.ConditionTrue, "RollingUpdate", fmt.Sprintf("%d of %d control plane machines are updated", updated, replicas)
+}
+
+// controlPlaneResizing returns whether the control plane machines of the cluster are being replaced. Changes to the
+// ControlPlaneMachineSet do not trigger reconciles, so its rollout is polled like the rollouts of machine pools.
+func controlPlaneResizing(cd *hivev1.ClusterDeployment) bool {
+	cond := controllerutils.FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.ControlPlaneResizingCondition)
+	return cond != nil && cond.Status == corev1.ConditionTrue
+}
+
+func (r *ReconcileMachinePool) updateControlPlaneConditions(cd *hivev1.ClusterDeployment, conditions []hivev1.ClusterDeploymentCondition, changed bool, logger log.FieldLogger) error {
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conditions
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update control plane conditions of clusterdeployment")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package machinepool
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+func TestSyncControlPlaneMachines(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+
+	cpms := func(state, strategy string, replicas, updated int64) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"state":    state,
+				"replicas": replicas,
+				"strategy": map[string]interface{}{"type": strategy},
+				"template": map[string]interface{}{
+					"machines_v1beta1_machine_openshift_io": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"providerSpec": map[string]interface{}{
+								"value": map[string]interface{}{"instanceType": "m6i.xlarge"},
+							},
+						},
+					},
+				},
+			},
+			"status": map[string]interface{}{
+				"replicas":        replicas,
+				"updatedReplicas": updated,
+			},
+		}}
+		u.SetGroupVersionKind(controlPlaneMachineSetGVK)
+		u.SetNamespace(machineAPINamespace)
+		u.SetName(controlPlaneMachineSetName)
+		return u
+	}
+	condition := func(conditionType hivev1.ClusterDeploymentConditionType, status corev1.ConditionStatus, reason string) hivev1.ClusterDeploymentCondition {
+		return hivev1.ClusterDeploymentCondition{Type: conditionType, Status: status, Reason: reason}
+	}
+
+	tests := []struct {
+		name                 string
+		controlPlaneMachines *hivev1.ControlPlaneMachineSpec
+		vsphere              bool
+		existingConditions   []hivev1.ClusterDeploymentCondition
+		remote               []client.Object
+		expectInstanceType   string
+		expectConditions     []hivev1.ClusterDeploymentCondition
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "no longer configured",
+			existingConditions: []hivev1.ClusterDeploymentCondition{
+				condition(hivev1.ControlPlaneResizingCondition, corev1.ConditionTrue, "RollingUpdate"),
+			},
+			expectConditions: []hivev1.ClusterDeploymentCondition{
+				condition(hivev1.ControlPlaneResizingCondition, corev1.ConditionFalse, "ControlPlaneMachinesNotConfigured"),
+			},
+		},
+		{
+			name:                 "unsupported platform",
+			controlPlaneMachines: &hivev1.ControlPlaneMachineSpec{Type: "large"},
+			vsphere:              true,
+			expectConditions: []hivev1.ClusterDeploymentCondition{
+				condition(hivev1.ControlPlaneResizeUnsupportedCondition, corev1.ConditionTrue, "UnsupportedPlatform"),
+			},
+		},
+		{
+			name:                 "no control plane machine set",
+			controlPlaneMachines: &hivev1.ControlPlaneMachineSpec{Type: "m6i.2xlarge"},
+			expectConditions: []hivev1.ClusterDeploymentCondition{
+				condition(hivev1.ControlPlaneResizeUnsupportedCondition, corev1.ConditionTrue, "ControlPlaneMachineSetNotFound"),
+			},
+		},
+		{
+			name:                 "inactive control plane machine set",
+			controlPlaneMachines: &hivev1.ControlPlaneMachineSpec{Type: "m6i.2xlarge"},
+			remote:               []client.Object{cpms("Inactive", "RollingUpdate", 3, 3)},
+			expectInstanceType:   "m6i.xlarge",
+			expectConditions: []hivev1.ClusterDeploymentCondition{
+				condition(hivev1.ControlPlaneResizeUnsupportedCondition, corev1.ConditionTrue, "ControlPlaneMachineSetInactive"),
+			},
+		},
+		{
+			name:                 "resize",
+			controlPlaneMachines: &hivev1.ControlPlaneMachineSpec{Type: "m6i.2xlarge"},
+			remote:               []client.Object{cpms("Active", "RollingUpdate", 3, 1)},
+			expectInstanceType:   "m6i.2xlarge",
+			expectConditions: []hivev1.ClusterDeploymentCondition{
+				condition(hivev1.ControlPlaneResizeUnsupportedCondition, corev1.ConditionFalse, "ControlPlaneMachineSetActive"),
+				condition(hivev1.ControlPlaneResizingCondition, corev1.ConditionTrue, "RollingUpdate"),
+			},
+		},
+		{
+			name:                 "resize on delete",
+			controlPlaneMachines: &hivev1.ControlPlaneMachineSpec{Type: "m6i.2xlarge"},
+			remote:               []client.Object{cpms("Active", "OnDelete", 3, 0)},
+			expectInstanceType:   "m6i.2xlarge",
+			expectConditions: []hivev1.ClusterDeploymentCondition{
+				condition(hivev1.ControlPlaneResizeUnsupportedCondition, corev1.ConditionFalse, "ControlPlaneMachineSetActive"),
+				condition(hivev1.ControlPlaneResizingCondition, corev1.ConditionTrue, "WaitingForMachineDeletion"),
+			},
+		},
+		{
+			name:                 "resized",
+			controlPlaneMachines: &hivev1.ControlPlaneMachineSpec{Type: "m6i.xlarge"},
+			existingConditions: []hivev1.ClusterDeploymentCondition{
+				condition(hivev1.ControlPlaneResizingCondition, corev1.ConditionTrue, "RollingUpdate"),
+			},
+			remote:             []client.Object{cpms("Active", "RollingUpdate", 3, 3)},
+			expectInstanceType: "m6i.xlarge",
+			expectConditions: []hivev1.ClusterDeploymentCondition{
+				condition(hivev1.ControlPlaneResizeUnsupportedCondition, corev1.ConditionFalse, "ControlPlaneMachineSetActive"),
+				condition(hivev1.ControlPlaneResizingCondition, corev1.ConditionFalse, "Resized"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			cd.Spec.ControlPlaneMachines = test.controlPlaneMachines
+			if test.vsphere {
+				cd.Spec.Platform = hivev1.Platform{VSphere: &hivev1vsphere.Platform{}}
+			}
+			cd.Status.Conditions = test.existingConditions
+			fakeClient := fake.NewClientBuilder().WithRuntimeObjects(cd).Build()
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(cd), cd))
+			// The vendored API has no ControlPlaneMachineSet, so it is not registered to keep it unstructured.
+			remoteClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithObjects(test.remote...).Build()
+			r := &ReconcileMachinePool{Client: fakeClient}
+
+			require.NoError(t, r.syncControlPlaneMachines(testMachinePool(), cd, remoteClient, log.WithField("test", test.name)))
+
+			if test.expectInstanceType != "" {
+				actual := &unstructured.Unstructured{}
+				actual.SetGroupVersionKind(controlPlaneMachineSetGVK)
+				require.NoError(t, remoteClient.Get(context.TODO(), client.ObjectKey{Namespace: machineAPINamespace, Name: controlPlaneMachineSetName}, actual))
+				instanceType, _, _ := unstructured.NestedString(actual.Object,
+					"spec", "template", "machines_v1beta1_machine_openshift_io", "spec", "providerSpec", "value", "instanceType")
+				assert.Equal(t, test.expectInstanceType, instanceType, "unexpected instance type of control plane machine set")
+			}
+
+			actual := &hivev1.ClusterDeployment{}
+			require.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(cd), actual))
+			for _, expected := range test.expectConditions {
+				cond := controllerutils.FindClusterDeploymentCondition(actual.Status.Conditions, expected.Type)
+				if assert.NotNil(t, cond, "missing condition %s", expected.Type) {
+					assert.Equal(t, expected.Status, cond.Status, "unexpected status of condition %s", expected.Type)
+					assert.Equal(t, expected.Reason, cond.Reason, "unexpected reason of condition %s", expected.Type)
+				}
+			}
+			if len(test.expectConditions) == 0 {
+				assert.Empty(t, actual.Status.Conditions, "unexpected conditions")
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package machinepool
+
+import (
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// reconcileExternallyManagedPool syncs a machine pool whose MachineSets are owned by another system. The MachineSets
+// selected by the pool are only observed: they are reported in the status of the pool and given the MachineAutoscalers
+// and MachineHealthChecks the pool configures, but never created, updated or deleted.
+func (r *ReconcileMachinePool) reconcileExternallyManagedPool(
+	pool *hivev1.MachinePool,
+	cd *hivev1.ClusterDeployment,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) (reconcile.Result, error) {
+	logger = logger.WithField("externallyManaged", true)
+
+	remoteMachineSets, err := r.getRemoteMachineSets(remoteClusterAPIClient, logger)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not getRemoteMachineSets")
+		return reconcile.Result{}, err
+	}
+
+	authority, err := detectMachineAuthority(cd, remoteClusterAPIClient, logger)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not detectMachineAuthority")
+		return reconcile.Result{}, err
+	}
+	logger = logger.WithField("authoritativeAPI", authority)
+
+	machineSets, err := selectMachineSets(pool, remoteMachineSets)
+	if err != nil {
+		logger.WithError(err).Error("could not select machine sets")
+		return reconcile.Result{}, err
+	}
+	logger.Infof("selected %v remote machine sets", len(machineSets))
+
+	protected, err := r.syncMachineAutoscalers(pool, cd, machineSets, remoteClusterAPIClient, logger)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncMachineAutoscalers")
+		return reconcile.Result{}, err
+	}
+
+	protectedMachineHealthChecks, err := r.syncMachineHealthChecks(pool, machineSets, remoteClusterAPIClient, logger)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncMachineHealthChecks")
+		return reconcile.Result{}, err
+	}
+	protected = append(protected, protectedMachineHealthChecks...)
+
+	if err := r.syncClusterAutoscaler(pool, cd, remoteClusterAPIClient, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncClusterAutoscaler")
+		return reconcile.Result{}, err
+	}
+
+	// Only the master machines are labelled, as the machines of the selected MachineSets are not Hive's to change.
+	if err := r.syncPropagatedNodeLabels(pool, cd, nil, remoteClusterAPIClient, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncPropagatedNodeLabels")
+		return reconcile.Result{}, err
+	}
+
+	if err := r.setDeletionProtectedCondition(pool, protected, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if pool.DeletionTimestamp != nil {
+		if err := r.updateClusterDeploymentSummary(cd, pool, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(protected) > 0 {
+			logger.Info("waiting for protected remote resources to be unprotected or deleted")
+			return reconcile.Result{RequeueAfter: defaultResyncInterval}, nil
+		}
+		return r.removeFinalizer(pool, logger)
+	}
+
+	result, err := r.updatePoolStatusForMachineSets(pool, cd, nil, machineSets, nil, 0, authority, remoteClusterAPIClient, logger)
+	if err != nil {
+		return result, err
+	}
+	return result, r.updateClusterDeploymentSummary(cd, pool, logger)
+}
+
+// selectMachineSets returns the remote MachineSets matching the selector of an externally managed machine pool,
+// sorted by name so that auto-scaling replicas are spread across them consistently.
+func selectMachineSets(pool *hivev1.MachinePool, remoteMachineSets *machineapi.MachineSetList) ([]*machineapi.MachineSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(pool.Spec.MachineSetSelector)
+	if err != nil {
+		return nil, err
+	}
+	machineSets := []*machineapi.MachineSet{}
+	for i := range remoteMachineSets.Items {
+		ms := remoteMachineSets.Items[i].DeepCopy()
+		if ms.Namespace != machineAPINamespace || !selector.Matches(labels.Set(ms.Labels)) {
+			continue
+		}
+		// Listed objects do not carry their kind, which MachineAutoscalers reference.
+		ms.SetGroupVersionKind(machineapi.SchemeGroupVersion.WithKind("MachineSet"))
+		if ms.Spec.Replicas == nil {
+			// The machine API defaults unset replicas to 1.
+			ms.Spec.Replicas = pointer.Int32Ptr(1)
+		}
+		machineSets = append(machineSets, ms)
+	}
+	sort.Slice(machineSets, func(i, j int) bool { return machineSets[i].Name < machineSets[j].Name })
+	return machineSets, nil
+}
@@ -0,0 +1,123 @@
+package machinepool
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// fakeZones are the zones the FakeActuator spreads the machines of a pool across, one MachineSet per zone, like the
+// actuators of platforms with availability zones.
+var fakeZones = []string{"fake-zone-a", "fake-zone-b", "fake-zone-c"}
+
+// FakeActuator generates synthetic MachineSets without contacting a cloud provider, so that the sync logic of the
+// controller can be exercised in development without cloud credentials.
+type FakeActuator struct {
+	logger log.FieldLogger
+}
+
+var _ Actuator = &FakeActuator{}
+
+// NewFakeActuator is the constructor for building a FakeActuator
+func NewFakeActuator(logger log.FieldLogger) *FakeActuator {
+	return &FakeActuator{
+		logger: logger,
+	}
+}
+
+// useFakeActuator returns true when the MachineSets of the pool are generated by the FakeActuator: for fake clusters,
+// which have no cloud resources, and for pools with the fake actuator annotation.
+func useFakeActuator(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool) bool {
+	return controllerutils.IsFakeCluster(cd) || pool.Annotations[constants.FakeMachinePoolActuatorAnnotation] == "true"
+}
+
+// GenerateMachineSets satisfies the Actuator interface and will take a clusterDeployment and return a list of MachineSets
+// to sync to the remote cluster.
+func (a *FakeActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
+	if cd.Spec.ClusterMetadata == nil {
+		return nil, false, errors.New("ClusterDeployment does not have cluster metadata")
+	}
+	infraID := cd.Spec.ClusterMetadata.InfraID
+
+	var total int32
+	if pool.Spec.Replicas != nil {
+		total = int32(*pool.Spec.Replicas)
+	}
+	numZones := int32(len(fakeZones))
+
+	machineSets := make([]*machineapi.MachineSet, len(fakeZones))
+	for i, zone := range fakeZones {
+		replicas := total / numZones
+		if int32(i) < total%numZones {
+			replicas++
+		}
+		providerSpec, err := fakeProviderSpec(zone)
+		if err != nil {
+			return nil, false, err
+		}
+		name := fmt.Sprintf("%s-%s-%s", infraID, pool.Spec.Name, zone)
+		machineSets[i] = &machineapi.MachineSet{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: machineapi.SchemeGroupVersion.String(),
+				Kind:       "MachineSet",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: machineAPINamespace,
+				Name:      name,
+				Labels: map[string]string{
+					"machine.openshift.io/cluster-api-cluster": infraID,
+				},
+			},
+			Spec: machineapi.MachineSetSpec{
+				Replicas: &replicas,
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"machine.openshift.io/cluster-api-machineset": name,
+						"machine.openshift.io/cluster-api-cluster":    infraID,
+					},
+				},
+				Template: machineapi.MachineTemplateSpec{
+					ObjectMeta: machineapi.ObjectMeta{
+						Labels: map[string]string{
+							"machine.openshift.io/cluster-api-machineset":   name,
+							"machine.openshift.io/cluster-api-cluster":      infraID,
+							"machine.openshift.io/cluster-api-machine-role": workerRole,
+							"machine.openshift.io/cluster-api-machine-type": workerRole,
+						},
+					},
+					Spec: machineapi.MachineSpec{
+						ProviderSpec: machineapi.ProviderSpec{
+							Value: providerSpec,
+						},
+					},
+				},
+			},
+		}
+	}
+	logger.WithField("zones", fakeZones).Debug("generated fake machinesets")
+	return machineSets, true, nil
+}
+
+// fakeProviderSpec returns the provider spec of the machines of a fake MachineSet in the given zone.
+func fakeProviderSpec(zone string) (*runtime.RawExtension, error) {
+	raw, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "fake.hive.openshift.io/v1",
+		"kind":       "FakeMachineProviderSpec",
+		"zone":       zone,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encode fake provider spec")
+	}
+	return &runtime.RawExtension{Raw: raw}, nil
+}
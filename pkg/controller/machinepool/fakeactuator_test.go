@@ -0,0 +1,147 @@
+package machinepool
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+func TestFakeActuatorGenerateMachineSets(t *testing.T) {
+	pool := testMachinePool()
+	pool.Spec.Replicas = pointer.Int64Ptr(4)
+
+	machineSets, proceed, err := NewFakeActuator(log.WithField("actuator", "fake")).
+		GenerateMachineSets(testClusterDeployment(), pool, log.WithField("test", "fake"))
+	require.NoError(t, err, "unexpected error generating machinesets")
+	assert.True(t, proceed, "expected to proceed")
+	if assert.Len(t, machineSets, 3, "unexpected number of machinesets") {
+		for i, expected := range []struct {
+			name     string
+			replicas int32
+		}{
+			{name: "foo-12345-worker-fake-zone-a", replicas: 2},
+			{name: "foo-12345-worker-fake-zone-b", replicas: 1},
+			{name: "foo-12345-worker-fake-zone-c", replicas: 1},
+		} {
+			ms := machineSets[i]
+			assert.Equal(t, expected.name, ms.Name, "unexpected machineset name")
+			assert.Equal(t, machineAPINamespace, ms.Namespace, "unexpected machineset namespace")
+			assert.Equal(t, expected.replicas, *ms.Spec.Replicas, "unexpected replicas for %s", ms.Name)
+			assert.Equal(t, ms.Name, ms.Spec.Template.Labels["machine.openshift.io/cluster-api-machineset"], "unexpected template labels")
+			assert.NotNil(t, ms.Spec.Template.Spec.ProviderSpec.Value, "expected provider spec")
+		}
+	}
+
+	cd := testClusterDeployment()
+	cd.Spec.ClusterMetadata = nil
+	_, _, err = NewFakeActuator(log.WithField("actuator", "fake")).GenerateMachineSets(cd, pool, log.WithField("test", "fake"))
+	assert.Error(t, err, "expected error for cluster without metadata")
+}
+
+func TestUseFakeActuator(t *testing.T) {
+	cases := []struct {
+		name               string
+		cdAnnotations      map[string]string
+		poolAnnotations    map[string]string
+		expectFakeActuator bool
+	}{
+		{
+			name: "real cluster",
+		},
+		{
+			name:               "fake cluster",
+			cdAnnotations:      map[string]string{constants.HiveFakeClusterAnnotation: "true"},
+			expectFakeActuator: true,
+		},
+		{
+			name:               "pool annotation",
+			poolAnnotations:    map[string]string{constants.FakeMachinePoolActuatorAnnotation: "true"},
+			expectFakeActuator: true,
+		},
+		{
+			name:            "pool annotation false",
+			poolAnnotations: map[string]string{constants.FakeMachinePoolActuatorAnnotation: "false"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			cd.Annotations = tc.cdAnnotations
+			pool := testMachinePool()
+			pool.Annotations = tc.poolAnnotations
+			assert.Equal(t, tc.expectFakeActuator, useFakeActuator(cd, pool), "unexpected fake actuator selection")
+		})
+	}
+}
+
+func TestFakeActuatorSimulatedClusterReconcile(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	machineapi.AddToScheme(scheme.Scheme)
+
+	cd := testClusterDeployment()
+	cd.Annotations = map[string]string{
+		constants.HiveFakeClusterAnnotation:      "true",
+		constants.HiveSimulatedClusterAnnotation: "true",
+	}
+	defer remoteclient.ForgetSimulatedCluster(cd)
+	pool := testMachinePool()
+	for _, condType := range machinePoolConditions {
+		if controllerutils.FindMachinePoolCondition(pool.Status.Conditions, condType) == nil {
+			pool.Status.Conditions = append(pool.Status.Conditions, hivev1.MachinePoolCondition{Type: condType})
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().WithRuntimeObjects(cd, pool).Build()
+	logger := log.WithField("controller", "machinepool")
+	r := &ReconcileMachinePool{
+		Client: fakeClient,
+		scheme: scheme.Scheme,
+		logger: logger,
+		remoteClusterAPIClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+			return remoteclient.NewBuilder(fakeClient, cd, ControllerName)
+		},
+		expectations: controllerutils.NewExpectations(logger),
+	}
+	r.actuatorBuilder = r.createActuator
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name},
+	})
+	require.NoError(t, err, "unexpected error reconciling")
+
+	remoteClient, err := remoteclient.NewBuilder(fakeClient, cd, ControllerName).Build()
+	require.NoError(t, err, "unexpected error building simulated cluster client")
+	machineSets := &machineapi.MachineSetList{}
+	require.NoError(t, remoteClient.List(context.TODO(), machineSets, client.InNamespace(machineAPINamespace)),
+		"unexpected error listing machinesets")
+	var names []string
+	var replicas int32
+	for _, ms := range machineSets.Items {
+		names = append(names, ms.Name)
+		replicas += *ms.Spec.Replicas
+	}
+	assert.ElementsMatch(t, []string{
+		"foo-12345-worker-fake-zone-a",
+		"foo-12345-worker-fake-zone-b",
+		"foo-12345-worker-fake-zone-c",
+	}, names, "unexpected machinesets in simulated cluster")
+	assert.Equal(t, int32(3), replicas, "unexpected total replicas")
+}
@@ -2,13 +2,16 @@ package machinepool
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"google.golang.org/api/googleapi"
 
 	gcpprovider "github.com/openshift/cluster-api-provider-gcp/pkg/apis"
 	gcpproviderv1beta1 "github.com/openshift/cluster-api-provider-gcp/pkg/apis/gcpprovider/v1beta1"
@@ -26,6 +29,7 @@ import (
 	installertypesgcp "github.com/openshift/installer/pkg/types/gcp"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/gcpclient"
@@ -38,10 +42,21 @@ const (
 
 	defaultGCPDiskType   = "pd-ssd"
 	defaultGCPDiskSizeGB = 128
+
+	// invalidOSImageReason is the reason of the UnsupportedConfiguration condition of pools whose os image does not
+	// exist.
+	invalidOSImageReason = "InvalidOSImage"
+
+	// unsupportedProvisioningModelReason is the reason of the UnsupportedConfiguration condition of pools using
+	// preemptible or Spot VMs in clusters whose version does not support them.
+	unsupportedProvisioningModelReason = "UnsupportedProvisioningModel"
 )
 
 var (
 	versionsSupportingFullNames = semver.MustParseRange(">=4.4.7")
+
+	versionsSupportingGCPPreemptibleVMs = semver.MustParseRange(">=4.6.0")
+	versionsSupportingGCPSpotVMs        = semver.MustParseRange(">=4.14.0")
 )
 
 // GCPActuator encapsulates the pieces necessary to be able to generate
@@ -59,6 +74,8 @@ type GCPActuator struct {
 	// expects to see.
 	expectations   controllerutils.ExpectationsInterface
 	leasesRequired bool
+	// lookups caches the zones of the project across reconciles.
+	lookups cloudLookups
 }
 
 var _ Actuator = &GCPActuator{}
@@ -76,6 +93,7 @@ func NewGCPActuator(
 	remoteMachineSets []machineapi.MachineSet,
 	scheme *runtime.Scheme,
 	expectations controllerutils.ExpectationsInterface,
+	lookups cloudLookups,
 	logger log.FieldLogger,
 ) (*GCPActuator, error) {
 	gcpClient, err := gcpclient.NewClientFromSecret(gcpCreds)
@@ -113,6 +131,7 @@ func NewGCPActuator(
 		network:        network,
 		subnet:         subnet,
 		leasesRequired: requireLeases(clusterVersion, remoteMachineSets, logger),
+		lookups:        lookups,
 	}
 	return actuator, nil
 }
@@ -130,6 +149,10 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		return nil, false, errors.New("MachinePool is not for GCP")
 	}
 
+	if proceed, err := a.checkProvisioningModel(cd, pool, logger); err != nil || !proceed {
+		return nil, false, err
+	}
+
 	leases := &hivev1.MachinePoolNameLeaseList{}
 	if err := a.client.List(
 		context.TODO(),
@@ -227,19 +250,152 @@ func (a *GCPActuator) GenerateMachineSets(cd *hivev1.ClusterDeployment, pool *hi
 		computePool.Platform.GCP.Zones = zones
 	}
 
+	imageID := a.imageID
+	if poolGCP.OSImage != nil {
+		var proceed bool
+		var err error
+		imageID, proceed, err = a.resolveOSImage(pool, logger)
+		if err != nil || !proceed {
+			return nil, false, err
+		}
+		logger.WithField("image", imageID).Debug("using os image of machine pool")
+	}
+
 	// Assuming all machine pools are workers at this time.
 	installerMachineSets, err := installgcp.MachineSets(
 		cd.Spec.ClusterMetadata.InfraID,
 		ic,
 		computePool,
-		a.imageID,
+		imageID,
 		workerRole,
 		workerUserDataName,
 	)
-	return installerMachineSets, err == nil, errors.Wrap(err, "failed to generate machinesets")
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to generate machinesets")
+	}
+	for _, ms := range installerMachineSets {
+		if err := setProvisioningModel(ms, poolGCP); err != nil {
+			return nil, false, errors.Wrap(err, "failed to set provisioning model of machinesets")
+		}
+	}
+	return installerMachineSets, true, nil
+}
+
+// checkProvisioningModel checks that the version of the cluster supports the preemptible or Spot VMs requested by the
+// pool. When it does not, the UnsupportedConfiguration condition of the pool is set and the pool does not proceed.
+func (a *GCPActuator) checkProvisioningModel(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) (bool, error) {
+	poolGCP := pool.Spec.Platform.GCP
+	var supportedVersions semver.Range
+	var message string
+	switch {
+	case poolGCP.ProvisioningModel != nil && *poolGCP.ProvisioningModel == hivev1gcp.SpotProvisioningModel:
+		supportedVersions = versionsSupportingGCPSpotVMs
+		message = "The version of the cluster does not support using Spot VMs"
+	case poolGCP.Preemptible:
+		supportedVersions = versionsSupportingGCPPreemptibleVMs
+		message = "The version of the cluster does not support using preemptible VMs"
+	}
+	if supportedVersions != nil {
+		clusterVersion, err := getClusterVersion(cd)
+		if err != nil {
+			return false, fmt.Errorf("Unable to get cluster version: %v", err)
+		}
+		parsedVersion, err := semver.ParseTolerant(clusterVersion)
+		if err != nil {
+			logger.WithError(err).WithField("clusterVersion", clusterVersion).Warn("could not parse the cluster version")
+		} else {
+			// Use only major, minor, and patch so that pre-release versions are within the supported ranges.
+			parsedVersion = semver.Version{
+				Major: parsedVersion.Major,
+				Minor: parsedVersion.Minor,
+				Patch: parsedVersion.Patch,
+			}
+		}
+		if err != nil || !supportedVersions(parsedVersion) {
+			logger.WithField("clusterVersion", clusterVersion).Debug("cluster does not support provisioning model of machine pool")
+			return false, a.setUnsupportedConfigurationCondition(pool, corev1.ConditionTrue, unsupportedProvisioningModelReason, message)
+		}
+	}
+	if cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.UnsupportedConfigurationMachinePoolCondition); cond != nil && cond.Reason == unsupportedProvisioningModelReason {
+		if err := a.setUnsupportedConfigurationCondition(pool, corev1.ConditionFalse, "ConfigurationSupported", "The configuration is supported"); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// setProvisioningModel makes the machines of the MachineSet preemptible or Spot VMs as requested by the pool. The
+// provider spec vendored from the installer has no provisioning model, so for Spot VMs it is added to the encoded
+// provider spec.
+func setProvisioningModel(ms *machineapi.MachineSet, poolGCP *hivev1gcp.MachinePool) error {
+	spot := poolGCP.ProvisioningModel != nil && *poolGCP.ProvisioningModel == hivev1gcp.SpotProvisioningModel
+	if !spot && !poolGCP.Preemptible {
+		return nil
+	}
+	providerSpec, ok := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*gcpproviderv1beta1.GCPMachineProviderSpec)
+	if !ok {
+		return errors.New("MachineSet does not have a GCPMachineProviderSpec")
+	}
+	if !spot {
+		providerSpec.Preemptible = true
+		return nil
+	}
+	raw, err := json.Marshal(providerSpec)
+	if err != nil {
+		return err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return err
+	}
+	fields["provisioningModel"] = string(hivev1gcp.SpotProvisioningModel)
+	// Spot VMs cannot be migrated or restarted by GCP.
+	fields["onHostMaintenance"] = "Terminate"
+	fields["restartPolicy"] = "Never"
+	if raw, err = json.Marshal(fields); err != nil {
+		return err
+	}
+	ms.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: raw}
+	return nil
+}
+
+// MachineSetInfrastructure returns the zone, subnetwork, machine type and boot disk image of the machines of the
+// MachineSet.
+func (a *GCPActuator) MachineSetInfrastructure(ms *machineapi.MachineSet) (*hivev1.MachineSetInfrastructure, error) {
+	spec := &gcpproviderv1beta1.GCPMachineProviderSpec{}
+	if err := unmarshalProviderSpec(ms.Spec.Template.Spec.ProviderSpec.Value, spec); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal GCP provider spec")
+	}
+	infra := &hivev1.MachineSetInfrastructure{
+		Zone:         spec.Zone,
+		InstanceType: spec.MachineType,
+	}
+	for _, ni := range spec.NetworkInterfaces {
+		if ni != nil && ni.Subnetwork != "" {
+			infra.Subnet = ni.Subnetwork
+			break
+		}
+	}
+	for _, disk := range spec.Disks {
+		if disk != nil && disk.Boot {
+			infra.Image = disk.Image
+			break
+		}
+	}
+	return infra, nil
 }
 
 func (a *GCPActuator) getZones(region string) ([]string, error) {
+	zones, err := a.lookups.get("zones/"+region, func() (interface{}, error) {
+		return a.listZones(region)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{}, zones.([]string)...), nil
+}
+
+func (a *GCPActuator) listZones(region string) ([]string, error) {
 	zones := []string{}
 
 	// Filter to regions matching '.*<region>.*' (where the zone is actually UP)
@@ -448,6 +604,58 @@ func requireLeases(clusterVersion string, remoteMachineSets []machineapi.Machine
 }
 
 // Get the image ID from an existing master machine.
+// resolveOSImage returns the image reference of the osImage of the pool, after checking that the image, or the image
+// family, exists in its project. When it does not exist, the UnsupportedConfiguration condition of the pool is set
+// and the pool does not proceed.
+func (a *GCPActuator) resolveOSImage(pool *hivev1.MachinePool, logger log.FieldLogger) (string, bool, error) {
+	osImage := pool.Spec.Platform.GCP.OSImage
+	project := osImage.Project
+	if project == "" {
+		project = a.projectID
+	}
+	var imageID string
+	var err error
+	if osImage.Family != "" {
+		imageID = fmt.Sprintf("projects/%s/global/images/family/%s", project, osImage.Family)
+		_, err = a.gcpClient.GetComputeImageFromFamily(project, osImage.Family)
+	} else {
+		imageID = fmt.Sprintf("projects/%s/global/images/%s", project, osImage.Name)
+		_, err = a.gcpClient.GetComputeImage(project, osImage.Name)
+	}
+	if gcpErr, ok := err.(*googleapi.Error); ok && gcpErr.Code == http.StatusNotFound {
+		logger.WithField("image", imageID).Warn("os image of machine pool not found")
+		return "", false, a.setUnsupportedConfigurationCondition(pool, corev1.ConditionTrue, invalidOSImageReason,
+			fmt.Sprintf("The OS image %s was not found", imageID))
+	}
+	if err != nil {
+		return "", false, errors.Wrapf(err, "could not get os image %s", imageID)
+	}
+	if cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.UnsupportedConfigurationMachinePoolCondition); cond != nil && cond.Reason == invalidOSImageReason {
+		if err := a.setUnsupportedConfigurationCondition(pool, corev1.ConditionFalse, "ConfigurationSupported", "The configuration is supported"); err != nil {
+			return "", false, err
+		}
+	}
+	return imageID, true, nil
+}
+
+// setUnsupportedConfigurationCondition sets the UnsupportedConfiguration condition of the pool, and updates the status of the
+// pool when the condition changed.
+func (a *GCPActuator) setUnsupportedConfigurationCondition(pool *hivev1.MachinePool, status corev1.ConditionStatus, reason, message string) error {
+	conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.UnsupportedConfigurationMachinePoolCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	pool.Status.Conditions = conds
+	return errors.Wrap(a.client.Status().Update(context.Background(), pool), "could not update MachinePool status")
+}
+
 func getGCPImageID(masterMachine *machineapi.Machine, scheme *runtime.Scheme, logger log.FieldLogger) (string, error) {
 	providerSpec, err := decodeGCPMachineProviderSpec(masterMachine.Spec.ProviderSpec.Value, scheme)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -29,6 +31,7 @@ import (
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	gcpclient "github.com/openshift/hive/pkg/gcpclient"
 	mockgcp "github.com/openshift/hive/pkg/gcpclient/mock"
+	"github.com/openshift/hive/pkg/machinesetgen"
 )
 
 const (
@@ -46,8 +49,13 @@ func TestGCPActuator(t *testing.T) {
 		existing                        []runtime.Object
 		mockGCPClient                   func(*mockgcp.MockClient)
 		setupPendingCreationExpectation bool
+		clusterVersion                  string
 
 		expectedMachineSetReplicas map[string]int64
+		expectedImage              string
+		expectedPreemptible        bool
+		expectedProvisioningModel  string
+		expectedConditionReason    string
 		expectedErr                bool
 	}{
 		{
@@ -85,6 +93,39 @@ func TestGCPActuator(t *testing.T) {
 				generateGCPMachineSetName("worker", "zone3"): 1,
 			},
 		},
+		{
+			name: "os image by name",
+			pool: withGCPOSImage(testGCPPool(testPoolName), hivev1gcp.OSImage{Name: "golden"}),
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone1"}, testRegion)
+				client.EXPECT().GetComputeImage(testProjectID, "golden").Return(&compute.Image{Name: "golden"}, nil)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 3,
+			},
+			expectedImage: fmt.Sprintf("projects/%s/global/images/golden", testProjectID),
+		},
+		{
+			name: "os image family in other project",
+			pool: withGCPOSImage(testGCPPool(testPoolName), hivev1gcp.OSImage{Project: "images-project", Family: "golden"}),
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone1"}, testRegion)
+				client.EXPECT().GetComputeImageFromFamily("images-project", "golden").Return(&compute.Image{Name: "golden-1"}, nil)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 3,
+			},
+			expectedImage: "projects/images-project/global/images/family/golden",
+		},
+		{
+			name: "os image not found",
+			pool: withGCPOSImage(testGCPPool(testPoolName), hivev1gcp.OSImage{Name: "missing"}),
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone1"}, testRegion)
+				client.EXPECT().GetComputeImage(testProjectID, "missing").Return(nil, &googleapi.Error{Code: http.StatusNotFound})
+			},
+			expectedConditionReason: invalidOSImageReason,
+		},
 		{
 			name: "list zones returns zero",
 			pool: testGCPPool(testPoolName),
@@ -171,6 +212,61 @@ func TestGCPActuator(t *testing.T) {
 				generateGCPMachineSetName("worker", "zone1"): 3,
 			},
 		},
+		{
+			name: "generate machinesets with preemptible VMs",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.Preemptible = true
+				return pool
+			}(),
+			clusterVersion: "4.6.0",
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone1"}, testRegion)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 3,
+			},
+			expectedPreemptible: true,
+		},
+		{
+			name: "preemptible VMs unsupported by cluster version",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				pool.Spec.Platform.GCP.Preemptible = true
+				return pool
+			}(),
+			clusterVersion:          "4.5.9",
+			expectedConditionReason: unsupportedProvisioningModelReason,
+		},
+		{
+			name: "generate machinesets with spot VMs",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				spot := hivev1gcp.SpotProvisioningModel
+				pool.Spec.Platform.GCP.ProvisioningModel = &spot
+				return pool
+			}(),
+			clusterVersion: "4.14.0-rc.1",
+			mockGCPClient: func(client *mockgcp.MockClient) {
+				mockListComputeZones(client, []string{"zone1", "zone2"}, testRegion)
+			},
+			expectedMachineSetReplicas: map[string]int64{
+				generateGCPMachineSetName("worker", "zone1"): 2,
+				generateGCPMachineSetName("worker", "zone2"): 1,
+			},
+			expectedProvisioningModel: "Spot",
+		},
+		{
+			name: "spot VMs unsupported by cluster version",
+			pool: func() *hivev1.MachinePool {
+				pool := testGCPPool(testPoolName)
+				spot := hivev1gcp.SpotProvisioningModel
+				pool.Spec.Platform.GCP.ProvisioningModel = &spot
+				return pool
+			}(),
+			clusterVersion:          "4.13.12",
+			expectedConditionReason: unsupportedProvisioningModelReason,
+		},
 	}
 
 	for _, test := range tests {
@@ -182,6 +278,9 @@ func TestGCPActuator(t *testing.T) {
 
 			gClient := mockgcp.NewMockClient(mockCtrl)
 			clusterDeployment := testGCPClusterDeployment(testName, testInfraID)
+			if test.clusterVersion != "" {
+				clusterDeployment.Labels[constants.VersionMajorMinorPatchLabel] = test.clusterVersion
+			}
 
 			logger := log.WithField("actuator", "gcpactuator")
 			controllerExpectations := controllerutils.NewExpectations(logger)
@@ -192,7 +291,7 @@ func TestGCPActuator(t *testing.T) {
 				}.String(), 1)
 			}
 
-			test.existing = append(test.existing, clusterDeployment)
+			test.existing = append(test.existing, clusterDeployment, test.pool)
 			fakeClient := fake.NewFakeClient(test.existing...)
 
 			// set up mock expectations
@@ -214,6 +313,14 @@ func TestGCPActuator(t *testing.T) {
 
 			generatedMachineSets, _, err := ga.GenerateMachineSets(clusterDeployment, test.pool, ga.logger)
 
+			if test.expectedConditionReason != "" {
+				cond := controllerutils.FindMachinePoolCondition(test.pool.Status.Conditions, hivev1.UnsupportedConfigurationMachinePoolCondition)
+				if assert.NotNil(t, cond, "expected UnsupportedConfiguration condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected condition status")
+					assert.Equal(t, test.expectedConditionReason, cond.Reason, "unexpected condition reason")
+				}
+			}
+
 			if test.expectedErr {
 				assert.Error(t, err, "expected error for test case")
 			} else {
@@ -225,10 +332,14 @@ func TestGCPActuator(t *testing.T) {
 						assert.Equal(t, expectedReplicas, int64(*ms.Spec.Replicas), "replica mismatch")
 					}
 
-					gcpProvider, ok := ms.Spec.Template.Spec.ProviderSpec.Value.Object.(*gcpprovider.GCPMachineProviderSpec)
-					assert.True(t, ok, "failed to convert to gcpProviderSpec")
+					gcpProvider := &gcpprovider.GCPMachineProviderSpec{}
+					err := unmarshalProviderSpec(ms.Spec.Template.Spec.ProviderSpec.Value, gcpProvider)
+					assert.NoError(t, err, "failed to convert to gcpProviderSpec")
 
 					assert.Equal(t, testInstanceType, gcpProvider.MachineType, "unexpected instance type")
+					if test.expectedImage != "" {
+						assert.Equal(t, test.expectedImage, gcpProvider.Disks[0].Image, "unexpected image")
+					}
 
 					// Ensure network details are propagated correctly.
 					assert.Equal(t, ga.network, gcpProvider.NetworkInterfaces[0].Network)
@@ -256,6 +367,18 @@ func TestGCPActuator(t *testing.T) {
 						assert.Equal(t, encKey.KMSKey.Location, gcpProvider.Disks[0].EncryptionKey.KMSKey.Location)
 					}
 
+					// Ensure the provisioning model made it to the resulting MachineSets:
+					assert.Equal(t, test.expectedPreemptible, gcpProvider.Preemptible, "unexpected preemptible")
+					fields := map[string]interface{}{}
+					err = unmarshalProviderSpec(ms.Spec.Template.Spec.ProviderSpec.Value, &fields)
+					assert.NoError(t, err, "failed to decode provider spec")
+					if test.expectedProvisioningModel != "" {
+						assert.Equal(t, test.expectedProvisioningModel, fields["provisioningModel"], "unexpected provisioning model")
+						assert.Equal(t, "Terminate", fields["onHostMaintenance"], "unexpected on host maintenance")
+					} else {
+						assert.NotContains(t, fields, "provisioningModel", "unexpected provisioning model")
+					}
+
 				}
 			}
 		})
@@ -732,7 +855,7 @@ func mockMachineSet(name string, machineType string, unstompedAnnotation bool, r
 			Name:      name,
 			Namespace: machineAPINamespace,
 			Labels: map[string]string{
-				machinePoolNameLabel:                       machineType,
+				machinesetgen.MachinePoolNameLabel:         machineType,
 				"machine.openshift.io/cluster-api-cluster": testInfraID,
 				constants.HiveManagedLabel:                 "true",
 			},
@@ -859,6 +982,11 @@ func testGCPPool(name string) *hivev1.MachinePool {
 	return p
 }
 
+func withGCPOSImage(pool *hivev1.MachinePool, osImage hivev1gcp.OSImage) *hivev1.MachinePool {
+	pool.Spec.Platform.GCP.OSImage = &osImage
+	return pool
+}
+
 func testGCPPoolForCluster(poolName, poolSpecName, clusterName string) *hivev1.MachinePool {
 	p := testMachinePool()
 	// validation ensures that all machine pools must be named [cdname]-[spec.name]
@@ -0,0 +1,169 @@
+package machinepool
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/machinesetgen"
+)
+
+const (
+	// defaultMaxUnhealthy and defaultNodeStartupTimeout are the defaults of the machine API for MachineHealthChecks.
+	// They are set explicitly so that the generated MachineHealthChecks match the defaulted remote ones.
+	defaultMaxUnhealthy       = "100%"
+	defaultNodeStartupTimeout = 10 * time.Minute
+)
+
+// syncMachineHealthChecks creates, updates and deletes the MachineHealthChecks of the MachineSets of the machine pool
+// in the remote cluster. The names of the remote MachineHealthChecks which were not deleted because they are
+// protected are returned.
+func (r *ReconcileMachinePool) syncMachineHealthChecks(
+	pool *hivev1.MachinePool,
+	machineSets []*machineapi.MachineSet,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) ([]string, error) {
+	remoteMachineHealthChecks := &machineapi.MachineHealthCheckList{}
+	if err := remoteClusterAPIClient.List(
+		context.Background(),
+		remoteMachineHealthChecks,
+		client.InNamespace(machineAPINamespace),
+	); err != nil {
+		logger.WithError(err).Error("unable to fetch remote machine health checks")
+		return nil, err
+	}
+	logger.Infof("found %v remote machine health checks", len(remoteMachineHealthChecks.Items))
+
+	machineHealthChecksToDelete := []*machineapi.MachineHealthCheck{}
+	machineHealthChecksToCreate := []*machineapi.MachineHealthCheck{}
+	machineHealthChecksToUpdate := []*machineapi.MachineHealthCheck{}
+
+	wanted := map[string]bool{}
+	if pool.DeletionTimestamp == nil && pool.Spec.MachineHealthCheck != nil {
+		for _, ms := range machineSets {
+			wanted[ms.Name] = true
+			generated := generateMachineHealthCheck(pool, ms)
+			found := false
+			for i, rMHC := range remoteMachineHealthChecks.Items {
+				if rMHC.Name != generated.Name {
+					continue
+				}
+				found = true
+				rMHC := &remoteMachineHealthChecks.Items[i]
+				objectModified := false
+				if rMHC.Labels[machinesetgen.MachinePoolNameLabel] != pool.Spec.Name {
+					if rMHC.Labels == nil {
+						rMHC.Labels = map[string]string{}
+					}
+					rMHC.Labels[machinesetgen.MachinePoolNameLabel] = pool.Spec.Name
+					objectModified = true
+				}
+				if !reflect.DeepEqual(rMHC.Spec, generated.Spec) {
+					logger.WithField("machinehealthcheck", rMHC.Name).Info("machine health check spec out of sync")
+					rMHC.Spec = generated.Spec
+					objectModified = true
+				}
+				if objectModified {
+					machineHealthChecksToUpdate = append(machineHealthChecksToUpdate, rMHC)
+				}
+				break
+			}
+			if !found {
+				machineHealthChecksToCreate = append(machineHealthChecksToCreate, generated)
+			}
+		}
+	}
+
+	// Only the MachineHealthChecks labelled for the pool are deleted. Unlike MachineAutoscalers, MachineHealthChecks
+	// named after the MachineSets of the pool are commonly created by hand, and are left alone until the pool takes
+	// them over.
+	var protected []string
+	for i, rMHC := range remoteMachineHealthChecks.Items {
+		if rMHC.Labels[machinesetgen.MachinePoolNameLabel] != pool.Spec.Name || wanted[rMHC.Name] {
+			continue
+		}
+		if isDeleteProtected(&rMHC) {
+			logger.WithField("machinehealthcheck", rMHC.Name).Warn("not deleting protected machinehealthcheck")
+			protected = append(protected, "machinehealthcheck/"+rMHC.Name)
+			continue
+		}
+		machineHealthChecksToDelete = append(machineHealthChecksToDelete, &remoteMachineHealthChecks.Items[i])
+	}
+
+	for _, mhc := range machineHealthChecksToCreate {
+		logger.WithField("machinehealthcheck", mhc.Name).Info("creating machinehealthcheck")
+		if err := remoteClusterAPIClient.Create(context.Background(), mhc); err != nil {
+			logger.WithError(err).Error("unable to create machine health check")
+			return nil, err
+		}
+	}
+
+	for _, mhc := range machineHealthChecksToUpdate {
+		logger.WithField("machinehealthcheck", mhc.Name).Info("updating machinehealthcheck")
+		if err := remoteClusterAPIClient.Update(context.Background(), mhc); err != nil {
+			logger.WithError(err).Error("unable to update machine health check")
+			return nil, err
+		}
+	}
+
+	for _, mhc := range machineHealthChecksToDelete {
+		logger.WithField("machinehealthcheck", mhc.Name).Info("deleting machinehealthcheck")
+		if err := remoteClusterAPIClient.Delete(context.Background(), mhc); err != nil {
+			logger.WithError(err).Error("unable to delete machine health check")
+			return nil, err
+		}
+	}
+
+	logger.Info("done reconciling machine health checks for machine pool")
+	return protected, nil
+}
+
+// generateMachineHealthCheck returns the MachineHealthCheck of the machines of the MachineSet of the pool.
+func generateMachineHealthCheck(pool *hivev1.MachinePool, ms *machineapi.MachineSet) *machineapi.MachineHealthCheck {
+	config := pool.Spec.MachineHealthCheck
+	maxUnhealthy := intstr.FromString(defaultMaxUnhealthy)
+	if config.MaxUnhealthy != nil {
+		maxUnhealthy = *config.MaxUnhealthy
+	}
+	nodeStartupTimeout := metav1.Duration{Duration: defaultNodeStartupTimeout}
+	if config.NodeStartupTimeout != nil {
+		nodeStartupTimeout = *config.NodeStartupTimeout
+	}
+	unhealthyConditions := make([]machineapi.UnhealthyCondition, len(config.UnhealthyConditions))
+	for i, c := range config.UnhealthyConditions {
+		unhealthyConditions[i] = machineapi.UnhealthyCondition{
+			Type:    c.Type,
+			Status:  c.Status,
+			Timeout: c.Timeout,
+		}
+	}
+	return &machineapi.MachineHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ms.Namespace,
+			Name:      ms.Name,
+			Labels: map[string]string{
+				machinesetgen.MachinePoolNameLabel: pool.Spec.Name,
+			},
+		},
+		Spec: machineapi.MachineHealthCheckSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					machineSetLabel: ms.Name,
+				},
+			},
+			UnhealthyConditions: unhealthyConditions,
+			MaxUnhealthy:        &maxUnhealthy,
+			NodeStartupTimeout:  &nodeStartupTimeout,
+		},
+	}
+}
@@ -0,0 +1,178 @@
+package machinepool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestSyncMachineHealthChecks(t *testing.T) {
+	machineapi.AddToScheme(scheme.Scheme)
+
+	healthCheckConfig := func() *hivev1.MachinePoolMachineHealthCheck {
+		maxUnhealthy := intstr.FromInt(1)
+		return &hivev1.MachinePoolMachineHealthCheck{
+			UnhealthyConditions: []hivev1.MachinePoolUnhealthyCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+			},
+			MaxUnhealthy: &maxUnhealthy,
+		}
+	}
+	machineHealthCheck := func(name string) *machineapi.MachineHealthCheck {
+		pool := testMachinePool()
+		pool.Spec.MachineHealthCheck = healthCheckConfig()
+		return generateMachineHealthCheck(pool, testMachineSet(name, "worker", false, 1, 0))
+	}
+	outdated := func(mhc *machineapi.MachineHealthCheck) *machineapi.MachineHealthCheck {
+		mhc.Spec.UnhealthyConditions[0].Timeout = metav1.Duration{Duration: time.Minute}
+		return mhc
+	}
+	unlabelled := func(mhc *machineapi.MachineHealthCheck) *machineapi.MachineHealthCheck {
+		mhc.Labels = nil
+		return mhc
+	}
+	protected := func(mhc *machineapi.MachineHealthCheck) *machineapi.MachineHealthCheck {
+		mhc.Annotations = map[string]string{constants.ProtectedDeleteAnnotation: "true"}
+		return mhc
+	}
+
+	cases := []struct {
+		name            string
+		healthCheck     *hivev1.MachinePoolMachineHealthCheck
+		deleted         bool
+		remoteExisting  []runtime.Object
+		expectedNames   []string
+		expectProtected []string
+	}{
+		{
+			name:        "create",
+			healthCheck: healthCheckConfig(),
+			expectedNames: []string{
+				"foo-12345-worker-us-east-1a",
+				"foo-12345-worker-us-east-1b",
+			},
+		},
+		{
+			name:        "update",
+			healthCheck: healthCheckConfig(),
+			remoteExisting: []runtime.Object{
+				outdated(machineHealthCheck("foo-12345-worker-us-east-1a")),
+				unlabelled(machineHealthCheck("foo-12345-worker-us-east-1b")),
+			},
+			expectedNames: []string{
+				"foo-12345-worker-us-east-1a",
+				"foo-12345-worker-us-east-1b",
+			},
+		},
+		{
+			name:        "delete for removed machine set",
+			healthCheck: healthCheckConfig(),
+			remoteExisting: []runtime.Object{
+				machineHealthCheck("foo-12345-worker-us-east-1a"),
+				machineHealthCheck("foo-12345-worker-us-east-1b"),
+				machineHealthCheck("foo-12345-worker-us-east-1c"),
+			},
+			expectedNames: []string{
+				"foo-12345-worker-us-east-1a",
+				"foo-12345-worker-us-east-1b",
+			},
+		},
+		{
+			name: "delete when not configured",
+			remoteExisting: []runtime.Object{
+				machineHealthCheck("foo-12345-worker-us-east-1a"),
+				machineHealthCheck("foo-12345-worker-us-east-1b"),
+			},
+		},
+		{
+			name:        "delete when pool deleted",
+			healthCheck: healthCheckConfig(),
+			deleted:     true,
+			remoteExisting: []runtime.Object{
+				machineHealthCheck("foo-12345-worker-us-east-1a"),
+				machineHealthCheck("foo-12345-worker-us-east-1b"),
+			},
+		},
+		{
+			name: "keep protected",
+			remoteExisting: []runtime.Object{
+				protected(machineHealthCheck("foo-12345-worker-us-east-1a")),
+				machineHealthCheck("foo-12345-worker-us-east-1b"),
+			},
+			expectedNames:   []string{"foo-12345-worker-us-east-1a"},
+			expectProtected: []string{"machinehealthcheck/foo-12345-worker-us-east-1a"},
+		},
+		{
+			name: "keep hand-made",
+			remoteExisting: []runtime.Object{
+				unlabelled(machineHealthCheck("foo-12345-worker-us-east-1a")),
+			},
+			expectedNames: []string{"foo-12345-worker-us-east-1a"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testMachinePool()
+			pool.Spec.MachineHealthCheck = tc.healthCheck
+			if tc.deleted {
+				now := metav1.Now()
+				pool.DeletionTimestamp = &now
+			}
+			machineSets := []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+			}
+			remoteFakeClient := fake.NewClientBuilder().WithRuntimeObjects(tc.remoteExisting...).Build()
+			r := &ReconcileMachinePool{logger: log.WithField("controller", "machinepool")}
+
+			protected, err := r.syncMachineHealthChecks(pool, machineSets, remoteFakeClient, r.logger)
+			require.NoError(t, err, "unexpected error syncing machine health checks")
+			assert.Equal(t, tc.expectProtected, protected, "unexpected protected machine health checks")
+
+			remote := &machineapi.MachineHealthCheckList{}
+			require.NoError(t, remoteFakeClient.List(context.Background(), remote, client.InNamespace(machineAPINamespace)))
+			var names []string
+			for _, mhc := range remote.Items {
+				names = append(names, mhc.Name)
+				if tc.healthCheck == nil || tc.deleted {
+					continue
+				}
+				expected := generateMachineHealthCheck(pool, testMachineSet(mhc.Name, "worker", false, 1, 0))
+				assert.Equal(t, expected.Labels, mhc.Labels, "unexpected labels of %s", mhc.Name)
+				assert.Equal(t, expected.Spec, mhc.Spec, "unexpected spec of %s", mhc.Name)
+			}
+			assert.ElementsMatch(t, tc.expectedNames, names, "unexpected remote machine health checks")
+		})
+	}
+}
+
+func TestGenerateMachineHealthCheckDefaults(t *testing.T) {
+	pool := testMachinePool()
+	pool.Spec.MachineHealthCheck = &hivev1.MachinePoolMachineHealthCheck{
+		UnhealthyConditions: []hivev1.MachinePoolUnhealthyCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+		},
+	}
+	mhc := generateMachineHealthCheck(pool, testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0))
+	assert.Equal(t, machineAPINamespace, mhc.Namespace, "unexpected namespace")
+	assert.Equal(t, map[string]string{machineSetLabel: "foo-12345-worker-us-east-1a"}, mhc.Spec.Selector.MatchLabels, "unexpected selector")
+	assert.Equal(t, defaultMaxUnhealthy, mhc.Spec.MaxUnhealthy.String(), "unexpected max unhealthy")
+	assert.Equal(t, defaultNodeStartupTimeout, mhc.Spec.NodeStartupTimeout.Duration, "unexpected node startup timeout")
+}
@@ -0,0 +1,238 @@
+package machinepool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/machinesetgen"
+)
+
+const (
+	machineConfigRoleLabel    = "machineconfiguration.openshift.io/role"
+	machineConfigPoolLabelFmt = "pools.operator.machineconfiguration.openshift.io/%s"
+	machineConfigNameFmt      = "99-%s-hive-%s"
+	kubeletConfigNameFmt      = "hive-%s-kubelet"
+	machineConfigGroupVersion = "machineconfiguration.openshift.io/v1"
+)
+
+var (
+	machineConfigGVK     = schema.FromAPIVersionAndKind(machineConfigGroupVersion, "MachineConfig")
+	kubeletConfigGVK     = schema.FromAPIVersionAndKind(machineConfigGroupVersion, "KubeletConfig")
+	machineConfigPoolGVK = schema.FromAPIVersionAndKind(machineConfigGroupVersion, "MachineConfigPool")
+)
+
+// generateMachineConfigResources generates the MachineConfigPool, MachineConfigs and KubeletConfig to be applied to
+// the remote cluster for the MachinePool. The role of the nodes of the MachinePool is the name of the pool.
+func generateMachineConfigResources(pool *hivev1.MachinePool) ([]*unstructured.Unstructured, error) {
+	if pool.DeletionTimestamp != nil || !machinesetgen.HasNodeConfig(pool) {
+		return nil, nil
+	}
+	role := pool.Spec.Name
+	var resources []*unstructured.Unstructured
+
+	if machinesetgen.UsesCustomMachineConfigPool(pool) {
+		// Custom machine config pools inherit the worker MachineConfigs in addition to their own.
+		mcp := newMachineConfigResource(machineConfigPoolGVK, role, pool)
+		mcp.SetLabels(mergeLabels(mcp.GetLabels(), map[string]string{fmt.Sprintf(machineConfigPoolLabelFmt, role): ""}))
+		mcp.Object["spec"] = map[string]interface{}{
+			"machineConfigSelector": map[string]interface{}{
+				"matchExpressions": []interface{}{
+					map[string]interface{}{
+						"key":      machineConfigRoleLabel,
+						"operator": "In",
+						"values":   []interface{}{workerRole, role},
+					},
+				},
+			},
+			"nodeSelector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{
+					machinesetgen.NodeRoleLabel(role): "",
+				},
+			},
+		}
+		resources = append(resources, mcp)
+	}
+
+	for _, config := range pool.Spec.MachineConfigs {
+		mc := newMachineConfigResource(machineConfigGVK, fmt.Sprintf(machineConfigNameFmt, role, config.Name), pool)
+		mc.SetLabels(mergeLabels(mc.GetLabels(), map[string]string{machineConfigRoleLabel: role}))
+		spec, err := rawToMap(config.Spec.Raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid spec for machine config %s", config.Name)
+		}
+		mc.Object["spec"] = spec
+		resources = append(resources, mc)
+	}
+
+	if pool.Spec.KubeletConfig != nil {
+		kc := newMachineConfigResource(kubeletConfigGVK, fmt.Sprintf(kubeletConfigNameFmt, role), pool)
+		kubeletConfig, err := rawToMap(pool.Spec.KubeletConfig.Raw)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid kubelet config")
+		}
+		kc.Object["spec"] = map[string]interface{}{
+			"kubeletConfig": kubeletConfig,
+			"machineConfigPoolSelector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{
+					fmt.Sprintf(machineConfigPoolLabelFmt, role): "",
+				},
+			},
+		}
+		resources = append(resources, kc)
+	}
+
+	return resources, nil
+}
+
+func newMachineConfigResource(gvk schema.GroupVersionKind, name string, pool *hivev1.MachinePool) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetLabels(map[string]string{
+		machinesetgen.MachinePoolNameLabel: pool.Spec.Name,
+		constants.HiveManagedLabel:         "true",
+	})
+	return obj
+}
+
+func mergeLabels(labels, extra map[string]string) map[string]string {
+	for k, v := range extra {
+		labels[k] = v
+	}
+	return labels
+}
+
+func rawToMap(raw []byte) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	if len(raw) == 0 {
+		return m, nil
+	}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// syncMachineConfigs applies the MachineConfigPool, MachineConfigs and KubeletConfig of the MachinePool to the remote
+// cluster, and deletes those previously applied for the MachinePool that are no longer wanted.
+func (r *ReconcileMachinePool) syncMachineConfigs(
+	pool *hivev1.MachinePool,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) error {
+	generated, err := generateMachineConfigResources(pool)
+	if err != nil {
+		logger.WithError(err).Error("could not generate machine config resources")
+		return err
+	}
+	wanted := map[schema.GroupVersionKind]map[string]bool{}
+	for _, obj := range generated {
+		gvk := obj.GroupVersionKind()
+		if wanted[gvk] == nil {
+			wanted[gvk] = map[string]bool{}
+		}
+		wanted[gvk][obj.GetName()] = true
+		if err := applyMachineConfigResource(remoteClusterAPIClient, obj, logger); err != nil {
+			return err
+		}
+	}
+
+	// Delete the pool last, once nothing selects it anymore.
+	for _, gvk := range []schema.GroupVersionKind{kubeletConfigGVK, machineConfigGVK, machineConfigPoolGVK} {
+		existing := &unstructured.UnstructuredList{}
+		existing.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := remoteClusterAPIClient.List(
+			context.Background(),
+			existing,
+			client.MatchingLabels{machinesetgen.MachinePoolNameLabel: pool.Spec.Name, constants.HiveManagedLabel: "true"},
+		); err != nil {
+			if len(generated) == 0 && meta.IsNoMatchError(err) {
+				// Clusters without the machine config API cannot have anything to clean up.
+				logger.WithField("kind", gvk.Kind).Debug("machine config API not available in cluster")
+				continue
+			}
+			logger.WithError(err).WithField("kind", gvk.Kind).Error("unable to list remote machine config resources")
+			return err
+		}
+		for i := range existing.Items {
+			obj := &existing.Items[i]
+			if wanted[gvk][obj.GetName()] {
+				continue
+			}
+			objLog := logger.WithField("kind", gvk.Kind).WithField("name", obj.GetName())
+			objLog.Info("deleting machine config resource")
+			if err := remoteClusterAPIClient.Delete(context.Background(), obj); err != nil && !apierrors.IsNotFound(err) {
+				objLog.WithError(err).Error("unable to delete machine config resource")
+				return err
+			}
+		}
+	}
+
+	logger.Info("done reconciling machine configs for machine pool")
+	return nil
+}
+
+func applyMachineConfigResource(remoteClusterAPIClient client.Client, obj *unstructured.Unstructured, logger log.FieldLogger) error {
+	objLog := logger.WithField("kind", obj.GetKind()).WithField("name", obj.GetName())
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	switch err := remoteClusterAPIClient.Get(context.Background(), client.ObjectKey{Name: obj.GetName()}, existing); {
+	case apierrors.IsNotFound(err):
+		objLog.Info("creating machine config resource")
+		if err := remoteClusterAPIClient.Create(context.Background(), obj); err != nil {
+			objLog.WithError(err).Error("unable to create machine config resource")
+			return err
+		}
+		return nil
+	case err != nil:
+		objLog.WithError(err).Error("unable to fetch machine config resource")
+		return err
+	}
+
+	labels := existing.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	modified := false
+	for k, v := range obj.GetLabels() {
+		if cur, ok := labels[k]; !ok || cur != v {
+			labels[k] = v
+			modified = true
+		}
+	}
+	// Only the fields set by hive are compared, as the machine config operator sets fields of its own in the spec.
+	spec, _ := existing.Object["spec"].(map[string]interface{})
+	if spec == nil {
+		spec = map[string]interface{}{}
+	}
+	for k, v := range obj.Object["spec"].(map[string]interface{}) {
+		if !reflect.DeepEqual(spec[k], v) {
+			spec[k] = v
+			modified = true
+		}
+	}
+	existing.Object["spec"] = spec
+	if !modified {
+		return nil
+	}
+	existing.SetLabels(labels)
+	objLog.Info("updating machine config resource")
+	if err := remoteClusterAPIClient.Update(context.Background(), existing); err != nil {
+		objLog.WithError(err).Error("unable to update machine config resource")
+		return err
+	}
+	return nil
+}
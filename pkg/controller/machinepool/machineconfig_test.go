@@ -0,0 +1,151 @@
+package machinepool
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/machinesetgen"
+)
+
+func testMachineConfigResource(gvk schema.GroupVersionKind, name, poolName string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetLabels(map[string]string{
+		machinesetgen.MachinePoolNameLabel: poolName,
+		constants.HiveManagedLabel:         "true",
+	})
+	return obj
+}
+
+func getMachineConfigResources(t *testing.T, c client.Client, gvk schema.GroupVersionKind) map[string]*unstructured.Unstructured {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	require.NoError(t, c.List(context.TODO(), list), "unexpected error listing %s", gvk.Kind)
+	objs := make(map[string]*unstructured.Unstructured, len(list.Items))
+	for i := range list.Items {
+		objs[list.Items[i].GetName()] = &list.Items[i]
+	}
+	return objs
+}
+
+func TestSyncMachineConfigs(t *testing.T) {
+	cases := []struct {
+		name                       string
+		poolName                   string
+		kubeletConfig              string
+		machineConfigs             []hivev1.MachinePoolMachineConfig
+		remoteExisting             []runtime.Object
+		expectedMachineConfigPools []string
+		expectedMachineConfigs     []string
+		expectedKubeletConfigs     []string
+		validate                   func(t *testing.T, c client.Client)
+	}{
+		{
+			name:     "no node config",
+			poolName: "worker",
+		},
+		{
+			name:                   "worker kubelet config",
+			poolName:               "worker",
+			kubeletConfig:          `{"maxPods": 500}`,
+			expectedKubeletConfigs: []string{"hive-worker-kubelet"},
+			validate: func(t *testing.T, c client.Client) {
+				kc := getMachineConfigResources(t, c, kubeletConfigGVK)["hive-worker-kubelet"]
+				maxPods, _, _ := unstructured.NestedFieldNoCopy(kc.Object, "spec", "kubeletConfig", "maxPods")
+				assert.EqualValues(t, 500, maxPods, "unexpected maxPods")
+				selector, _, _ := unstructured.NestedStringMap(kc.Object, "spec", "machineConfigPoolSelector", "matchLabels")
+				assert.Equal(t, map[string]string{"pools.operator.machineconfiguration.openshift.io/worker": ""}, selector, "unexpected machine config pool selector")
+			},
+		},
+		{
+			name:     "custom pool machine config",
+			poolName: "infra",
+			machineConfigs: []hivev1.MachinePoolMachineConfig{{
+				Name: "hugepages",
+				Spec: runtime.RawExtension{Raw: []byte(`{"kernelArguments": ["hugepagesz=1G", "hugepages=8"]}`)},
+			}},
+			expectedMachineConfigPools: []string{"infra"},
+			expectedMachineConfigs:     []string{"99-infra-hive-hugepages"},
+			validate: func(t *testing.T, c client.Client) {
+				mc := getMachineConfigResources(t, c, machineConfigGVK)["99-infra-hive-hugepages"]
+				assert.Equal(t, "infra", mc.GetLabels()[machineConfigRoleLabel], "unexpected machine config role")
+				mcp := getMachineConfigResources(t, c, machineConfigPoolGVK)["infra"]
+				nodeSelector, _, _ := unstructured.NestedStringMap(mcp.Object, "spec", "nodeSelector", "matchLabels")
+				assert.Equal(t, map[string]string{"node-role.kubernetes.io/infra": ""}, nodeSelector, "unexpected node selector")
+			},
+		},
+		{
+			name:          "existing pool fields set by machine config operator are kept",
+			poolName:      "infra",
+			kubeletConfig: `{"maxPods": 250}`,
+			remoteExisting: []runtime.Object{
+				testMachineConfigResource(machineConfigPoolGVK, "infra", "infra", map[string]interface{}{
+					"configuration": map[string]interface{}{"name": "rendered-infra-1234"},
+				}),
+			},
+			expectedMachineConfigPools: []string{"infra"},
+			expectedKubeletConfigs:     []string{"hive-infra-kubelet"},
+			validate: func(t *testing.T, c client.Client) {
+				mcp := getMachineConfigResources(t, c, machineConfigPoolGVK)["infra"]
+				rendered, _, _ := unstructured.NestedString(mcp.Object, "spec", "configuration", "name")
+				assert.Equal(t, "rendered-infra-1234", rendered, "unexpected rendered configuration")
+				_, found, _ := unstructured.NestedMap(mcp.Object, "spec", "nodeSelector")
+				assert.True(t, found, "expected node selector to be added")
+			},
+		},
+		{
+			name:     "stale resources deleted",
+			poolName: "infra",
+			remoteExisting: []runtime.Object{
+				testMachineConfigResource(machineConfigPoolGVK, "infra", "infra", map[string]interface{}{}),
+				testMachineConfigResource(machineConfigGVK, "99-infra-hive-old", "infra", map[string]interface{}{}),
+				testMachineConfigResource(kubeletConfigGVK, "hive-infra-kubelet", "infra", map[string]interface{}{}),
+				testMachineConfigResource(machineConfigGVK, "99-gpu-hive-other", "gpu", map[string]interface{}{}),
+			},
+			expectedMachineConfigs: []string{"99-gpu-hive-other"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testMachinePool()
+			pool.Spec.Name = tc.poolName
+			pool.Spec.MachineConfigs = tc.machineConfigs
+			if tc.kubeletConfig != "" {
+				pool.Spec.KubeletConfig = &runtime.RawExtension{Raw: []byte(tc.kubeletConfig)}
+			}
+			remoteClient := fake.NewClientBuilder().WithRuntimeObjects(tc.remoteExisting...).Build()
+			r := &ReconcileMachinePool{}
+
+			err := r.syncMachineConfigs(pool, remoteClient, log.WithField("controller", "machinepool"))
+			require.NoError(t, err, "unexpected error syncing machine configs")
+
+			for gvk, expected := range map[schema.GroupVersionKind][]string{
+				machineConfigPoolGVK: tc.expectedMachineConfigPools,
+				machineConfigGVK:     tc.expectedMachineConfigs,
+				kubeletConfigGVK:     tc.expectedKubeletConfigs,
+			} {
+				var actual []string
+				for name := range getMachineConfigResources(t, remoteClient, gvk) {
+					actual = append(actual, name)
+				}
+				assert.ElementsMatch(t, expected, actual, "unexpected %s resources", gvk.Kind)
+			}
+			if tc.validate != nil {
+				tc.validate(t, remoteClient)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package machinepool
+
+import (
+	"context"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/machinesetgen"
+)
+
+// countMachinePhases returns the numbers of machines of each of the MachineSets in each phase, keyed by MachineSet
+// name. The machines of the remote cluster are listed once for all of the MachineSets. Nil is returned when they
+// cannot be listed.
+func countMachinePhases(remoteClusterAPIClient client.Client, machineSets []*machineapi.MachineSet, logger log.FieldLogger) map[string]*hivev1.MachinePhaseCounts {
+	machines := &machineapi.MachineList{}
+	if err := remoteClusterAPIClient.List(context.TODO(), machines, client.InNamespace(machineAPINamespace)); err != nil {
+		logger.WithError(err).Warn("could not list machines to count their phases")
+		return nil
+	}
+	counts := make(map[string]*hivev1.MachinePhaseCounts, len(machineSets))
+	for _, ms := range machineSets {
+		counts[ms.Name] = &hivev1.MachinePhaseCounts{}
+	}
+	for _, machine := range machines.Items {
+		c, ok := counts[machine.Labels[machineSetLabel]]
+		if !ok {
+			continue
+		}
+		var phase string
+		if machine.Status.Phase != nil {
+			phase = *machine.Status.Phase
+		}
+		switch {
+		case machine.DeletionTimestamp != nil || phase == "Deleting":
+			c.Deleting++
+		case phase == "Failed":
+			c.Failed++
+		case phase == "Running":
+			c.Running++
+		case phase == "Provisioned":
+			c.Provisioned++
+		default:
+			// Machines have no phase until the machine controller starts provisioning them.
+			c.Provisioning++
+		}
+	}
+	return counts
+}
+
+// aggregateZoneStatuses adds up the machine phase counts of the MachineSets by their availability zone. MachineSets
+// without counts are skipped. The zones are sorted by name.
+func aggregateZoneStatuses(machineSets []*machineapi.MachineSet, statuses []hivev1.MachineSetStatus) []hivev1.MachinePoolZoneStatus {
+	byZone := map[string]*hivev1.MachinePhaseCounts{}
+	for i, ms := range machineSets {
+		counts := statuses[i].Machines
+		if counts == nil {
+			continue
+		}
+		zone := machinesetgen.Zone(ms)
+		if infra := statuses[i].Infrastructure; infra != nil && infra.Zone != "" {
+			zone = infra.Zone
+		}
+		total, ok := byZone[zone]
+		if !ok {
+			total = &hivev1.MachinePhaseCounts{}
+			byZone[zone] = total
+		}
+		total.Provisioning += counts.Provisioning
+		total.Provisioned += counts.Provisioned
+		total.Running += counts.Running
+		total.Failed += counts.Failed
+		total.Deleting += counts.Deleting
+	}
+	if len(byZone) == 0 {
+		return nil
+	}
+	zones := make([]hivev1.MachinePoolZoneStatus, 0, len(byZone))
+	for zone, counts := range byZone {
+		zones = append(zones, hivev1.MachinePoolZoneStatus{Zone: zone, Machines: *counts})
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		return zones[i].Zone < zones[j].Zone
+	})
+	return zones
+}
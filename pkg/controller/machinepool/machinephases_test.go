@@ -0,0 +1,102 @@
+package machinepool
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestCountMachinePhases(t *testing.T) {
+	scheme := runtime.NewScheme()
+	machineapi.AddToScheme(scheme)
+
+	machine := func(name, machineSet string, phase *string) runtime.Object {
+		return &machineapi.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: machineAPINamespace,
+				Name:      name,
+				Labels:    map[string]string{machineSetLabel: machineSet},
+			},
+			Status: machineapi.MachineStatus{Phase: phase},
+		}
+	}
+	deleting := &machineapi.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         machineAPINamespace,
+			Name:              "a-deleting",
+			Labels:            map[string]string{machineSetLabel: "worker-a"},
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{"machine.machine.openshift.io"},
+		},
+		Status: machineapi.MachineStatus{Phase: pointer.StringPtr("Running")},
+	}
+	remoteClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+		machine("a-new", "worker-a", nil),
+		machine("a-provisioning", "worker-a", pointer.StringPtr("Provisioning")),
+		machine("a-provisioned", "worker-a", pointer.StringPtr("Provisioned")),
+		machine("a-running", "worker-a", pointer.StringPtr("Running")),
+		deleting,
+		machine("b-running", "worker-b", pointer.StringPtr("Running")),
+		machine("b-failed", "worker-b", pointer.StringPtr("Failed")),
+		machine("other", "infra-a", pointer.StringPtr("Running")),
+	).Build()
+	machineSets := []*machineapi.MachineSet{
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-b"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-c"}},
+	}
+
+	counts := countMachinePhases(remoteClient, machineSets, log.StandardLogger())
+	assert.Equal(t, map[string]*hivev1.MachinePhaseCounts{
+		"worker-a": {Provisioning: 2, Provisioned: 1, Running: 1, Deleting: 1},
+		"worker-b": {Running: 1, Failed: 1},
+		"worker-c": {},
+	}, counts, "unexpected machine phase counts")
+}
+
+func TestAggregateZoneStatuses(t *testing.T) {
+	machineSets := []*machineapi.MachineSet{
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-b"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-a-v2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "worker-c"}},
+	}
+	statuses := []hivev1.MachineSetStatus{
+		{
+			Name:           "worker-b",
+			Infrastructure: &hivev1.MachineSetInfrastructure{Zone: "us-east-1b"},
+			Machines:       &hivev1.MachinePhaseCounts{Running: 2, Failed: 1},
+		},
+		{
+			Name:           "worker-a",
+			Infrastructure: &hivev1.MachineSetInfrastructure{Zone: "us-east-1a"},
+			Machines:       &hivev1.MachinePhaseCounts{Running: 1, Deleting: 1},
+		},
+		{
+			Name:           "worker-a-v2",
+			Infrastructure: &hivev1.MachineSetInfrastructure{Zone: "us-east-1a"},
+			Machines:       &hivev1.MachinePhaseCounts{Running: 1, Provisioning: 1},
+		},
+		{
+			Name:           "worker-c",
+			Infrastructure: &hivev1.MachineSetInfrastructure{Zone: "us-east-1c"},
+		},
+	}
+
+	assert.Equal(t, []hivev1.MachinePoolZoneStatus{
+		{Zone: "us-east-1a", Machines: hivev1.MachinePhaseCounts{Provisioning: 1, Running: 2, Deleting: 1}},
+		{Zone: "us-east-1b", Machines: hivev1.MachinePhaseCounts{Running: 2, Failed: 1}},
+	}, aggregateZoneStatuses(machineSets, statuses), "unexpected zone statuses")
+	assert.Nil(t, aggregateZoneStatuses(nil, nil), "expected no zone statuses without machine sets")
+}
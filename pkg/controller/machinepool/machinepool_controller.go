@@ -3,28 +3,33 @@ package machinepool
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
-	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -40,14 +45,27 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/machinesetgen"
 	"github.com/openshift/hive/pkg/remoteclient"
 )
 
 const (
 	ControllerName             = hivev1.MachinePoolControllerName
-	machinePoolNameLabel       = "hive.openshift.io/machine-pool"
 	finalizer                  = "hive.openshift.io/remotemachineset"
 	masterMachineLabelSelector = "machine.openshift.io/cluster-api-machine-type=master"
+
+	// defaultResyncInterval is how often machine pools are reconciled while their machines are not all ready, as the
+	// machine sets of remote clusters are not watched.
+	defaultResyncInterval = 10 * time.Minute
+	// autoscaledResyncInterval is how often autoscaled machine pools are reconciled while their machines are ready, so
+	// that their status follows the scaling of their remote machine sets.
+	autoscaledResyncInterval = 30 * time.Minute
+	// steadyStateResyncInterval is how often machine pools are reconciled while their machines are ready, so that
+	// changes made to their remote machine sets are eventually reverted.
+	steadyStateResyncInterval = 2 * time.Hour
+
+	// machinePoolClusterDeploymentIndex indexes MachinePools by the name of their ClusterDeployment.
+	machinePoolClusterDeploymentIndex = "spec.clusterdeploymentref.name"
 )
 
 var (
@@ -61,8 +79,22 @@ var (
 		hivev1.InvalidSubnetsMachinePoolCondition,
 		hivev1.UnsupportedConfigurationMachinePoolCondition,
 	}
+
+	// expectationsStuckThreshold is the length of time a machine pool's expectations may remain unsatisfied
+	// before they are considered stuck and reset. This guards against watch events for MachinePoolNameLease
+	// creates being lost, which would otherwise leave the pool waiting until the expectations expire.
+	expectationsStuckThreshold = 2 * time.Minute
+
+	metricExpectationsReset = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "hive_machinepool_expectations_reset_total",
+		Help: "Counter incremented every time machine pool expectations remain unsatisfied beyond the threshold and are reset.",
+	})
 )
 
+func init() {
+	metrics.Registry.MustRegister(metricExpectationsReset)
+}
+
 // Add creates a new MachinePool Controller and adds it to the Manager with default RBAC. The Manager will set fields on the
 // Controller and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
@@ -90,14 +122,22 @@ func Add(mgr manager.Manager) error {
 		return err
 	}
 
+	machinePoolDefaults, err := readMachinePoolDefaults()
+	if err != nil {
+		logger.WithError(err).Error("could not read machine pool defaults")
+		return err
+	}
+
 	r := &ReconcileMachinePool{
-		Client:       controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &clientRateLimiter),
-		scheme:       mgr.GetScheme(),
-		logger:       logger,
-		expectations: controllerutils.NewExpectations(logger),
+		Client:              controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &clientRateLimiter),
+		scheme:              mgr.GetScheme(),
+		logger:              logger,
+		expectations:        controllerutils.NewExpectations(logger),
+		cloudLookups:        newCloudLookupCache(cloudLookupTTL),
+		machinePoolDefaults: machinePoolDefaults,
 	}
-	r.actuatorBuilder = func(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, logger log.FieldLogger) (Actuator, error) {
-		return r.createActuator(cd, pool, masterMachine, remoteMachineSets, logger)
+	r.actuatorBuilder = func(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, remoteClusterAPIClient client.Client, logger log.FieldLogger) (Actuator, error) {
+		return r.createActuator(cd, pool, masterMachine, remoteMachineSets, remoteClusterAPIClient, logger)
 	}
 	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
 		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
@@ -105,7 +145,7 @@ func Add(mgr manager.Manager) error {
 
 	// Create a new controller
 	c, err := controller.New("machinepool-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(r, queueRateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             queueRateLimiter,
 	})
@@ -113,9 +153,24 @@ func Add(mgr manager.Manager) error {
 		return err
 	}
 
-	// Watch for changes to MachinePools
+	// Index MachinePools by ClusterDeployment name, so that ClusterDeployment events do not list every pool
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &hivev1.MachinePool{}, machinePoolClusterDeploymentIndex,
+		func(o client.Object) []string {
+			pool := o.(*hivev1.MachinePool)
+			if name := pool.Spec.ClusterDeploymentRef.Name; name != "" {
+				return []string{name}
+			}
+			return []string{}
+		}); err != nil {
+		logger.WithError(err).Error("Error indexing MachinePools by ClusterDeployment")
+		return err
+	}
+
+	// Watch for changes to MachinePools. Updates of only the status of a pool, which are mostly made by this controller,
+	// are ignored so that they do not cause the remote cluster to be contacted again.
 	err = c.Watch(&source.Kind{Type: &hivev1.MachinePool{}},
-		controllerutils.NewRateLimitedUpdateEventHandler(&handler.EnqueueRequestForObject{}, IsErrorUpdateEvent))
+		controllerutils.NewRateLimitedUpdateEventHandler(&handler.EnqueueRequestForObject{}, IsErrorUpdateEvent),
+		machinePoolChangedPredicate)
 	if err != nil {
 		return err
 	}
@@ -134,12 +189,6 @@ func Add(mgr manager.Manager) error {
 		return err
 	}
 
-	// Periodically watch MachinePools for syncing status from external clusters
-	err = c.Watch(newPeriodicSource(r.Client, 30*time.Minute, r.logger), &handler.EnqueueRequestForObject{})
-	if err != nil {
-		return err
-	}
-
 	return nil
 }
 
@@ -154,7 +203,9 @@ func (r *ReconcileMachinePool) clusterDeploymentWatchHandler(a client.Object) []
 	}
 
 	pools := &hivev1.MachinePoolList{}
-	err := r.List(context.TODO(), pools)
+	err := r.List(context.TODO(), pools,
+		client.MatchingFields{machinePoolClusterDeploymentIndex: cd.Name},
+		client.InNamespace(cd.Namespace))
 	if err != nil {
 		// Could not list machine pools
 		r.logger.Errorf("Error listing machine pools. Value: %+v", a)
@@ -162,6 +213,7 @@ func (r *ReconcileMachinePool) clusterDeploymentWatchHandler(a client.Object) []
 	}
 
 	for _, pool := range pools.Items {
+		// This should only happen in unit tests: the fakeclient doesn't support index filters
 		if pool.Spec.ClusterDeploymentRef.Name != cd.Name {
 			continue
 		}
@@ -172,6 +224,30 @@ func (r *ReconcileMachinePool) clusterDeploymentWatchHandler(a client.Object) []
 	return retval
 }
 
+// readMachinePoolDefaults reads the defaults applied to the MachineSets of every MachinePool from the file pointed to
+// by the MachinePoolDefaultsConfigFileEnvVar environment variable. Nil is returned when there are no defaults.
+func readMachinePoolDefaults() (*hivev1.MachinePoolDefaults, error) {
+	path := os.Getenv(constants.MachinePoolDefaultsConfigFileEnvVar)
+	if len(path) == 0 {
+		return nil, nil
+	}
+	fileBytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the machine pool defaults config file")
+	}
+	if len(fileBytes) == 0 || string(fileBytes) == "null" {
+		return nil, nil
+	}
+	defaults := &hivev1.MachinePoolDefaults{}
+	if err := json.Unmarshal(fileBytes, defaults); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the machine pool defaults config")
+	}
+	return defaults, nil
+}
+
 var _ reconcile.Reconciler = &ReconcileMachinePool{}
 
 // ReconcileMachinePool reconciles the MachineSets generated from a MachinePool object
@@ -191,12 +267,21 @@ type ReconcileMachinePool struct {
 		pool *hivev1.MachinePool,
 		masterMachine *machineapi.Machine,
 		remoteMachineSets []machineapi.MachineSet,
+		remoteClusterAPIClient client.Client,
 		logger log.FieldLogger,
 	) (Actuator, error)
 
 	// A TTLCache of machinepoolnamelease creates each machinepool expects to see. Note that not all actuators make use
 	// of expectations.
 	expectations controllerutils.ExpectationsInterface
+
+	// cloudLookups caches the cloud API lookups of the actuators across reconciles. Note that not all actuators make
+	// use of it.
+	cloudLookups *cloudLookupCache
+
+	// machinePoolDefaults are the defaults of HiveConfig applied to the MachineSets of every MachinePool. Nil when
+	// there are none.
+	machinePoolDefaults *hivev1.MachinePoolDefaults
 }
 
 // Reconcile reads that state of the cluster for a MachinePool object and makes changes to the
@@ -230,7 +315,8 @@ func (r *ReconcileMachinePool) Reconcile(ctx context.Context, request reconcile.
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update machine pool status")
 			return reconcile.Result{}, err
 		}
-		return reconcile.Result{}, nil
+		// Status updates do not trigger reconciles, so the pool is requeued to sync it.
+		return reconcile.Result{Requeue: true}, nil
 	}
 
 	if !controllerutils.HasFinalizer(pool, finalizer) {
@@ -239,9 +325,9 @@ func (r *ReconcileMachinePool) Reconcile(ctx context.Context, request reconcile.
 		}
 	}
 
-	if !r.expectations.SatisfiedExpectations(request.String()) {
+	if satisfied, requeueAfter := r.expectationsSatisfied(request.String(), logger); !satisfied {
 		logger.Debug("waiting for expectations to be satisfied")
-		return reconcile.Result{}, nil
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
 	}
 
 	cd := &hivev1.ClusterDeployment{}
@@ -258,8 +344,10 @@ func (r *ReconcileMachinePool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
+	// Like the status sync at the end of a reconcile, the early returns below requeue the pool: changes to remote
+	// clusters do not trigger reconciles.
 	if controllerutils.IsClusterPausedOrRelocating(cd, logger) {
-		return reconcile.Result{}, nil
+		return reconcile.Result{RequeueAfter: poolResyncInterval(pool, cd, pool.Status.Rollout, 0, logger)}, nil
 	}
 
 	// If the clusterdeployment is deleted, do not reconcile.
@@ -270,12 +358,12 @@ func (r *ReconcileMachinePool) Reconcile(ctx context.Context, request reconcile.
 	if !cd.Spec.Installed {
 		// Cluster isn't installed yet, return
 		logger.Debug("cluster installation is not complete")
-		return reconcile.Result{}, nil
+		return reconcile.Result{RequeueAfter: poolResyncInterval(pool, cd, pool.Status.Rollout, 0, logger)}, nil
 	}
 
 	if cd.Spec.ClusterMetadata == nil {
 		logger.Error("installed cluster with no cluster metadata")
-		return reconcile.Result{}, nil
+		return reconcile.Result{RequeueAfter: poolResyncInterval(pool, cd, pool.Status.Rollout, 0, logger)}, nil
 	}
 
 	if !controllerutils.HasFinalizer(pool, finalizer) {
@@ -287,7 +375,8 @@ func (r *ReconcileMachinePool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
-	if controllerutils.IsFakeCluster(cd) {
+	// Anything written to a fake cluster which is not simulated is discarded, so there is nothing to sync.
+	if controllerutils.IsFakeCluster(cd) && !controllerutils.IsSimulatedCluster(cd) {
 		logger.Info("skipping reconcile for fake cluster")
 		return reconcile.Result{}, nil
 	}
@@ -302,8 +391,31 @@ func (r *ReconcileMachinePool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{Requeue: requeue}, nil
 	}
 
+	switch fence, err := controllerutils.GetRemoteRelocationFence(remoteClusterAPIClient); {
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get relocation fence")
+		return reconcile.Result{}, err
+	case controllerutils.IsFencedOut(cd, fence, logger):
+		// The remote resources of the pool are left to the hub which owns the cluster.
+		if pool.DeletionTimestamp != nil {
+			return r.removeFinalizer(pool, logger)
+		}
+		return reconcile.Result{RequeueAfter: poolResyncInterval(pool, cd, pool.Status.Rollout, 0, logger)}, nil
+	}
+
 	logger.Info("reconciling machine pool for cluster deployment")
 
+	if pool.Spec.Paused && pool.DeletionTimestamp == nil {
+		return r.reconcilePausedPool(pool, cd, remoteClusterAPIClient, logger)
+	}
+	if err := r.clearPausedCondition(pool, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if pool.Spec.MachineSetSelector != nil {
+		return r.reconcileExternallyManagedPool(pool, cd, remoteClusterAPIClient, logger)
+	}
+
 	masterMachine, err := r.getMasterMachine(cd, remoteClusterAPIClient, logger)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -315,13 +427,28 @@ func (r *ReconcileMachinePool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
-	generatedMachineSets, proceed, err := r.generateMachineSets(pool, cd, masterMachine, remoteMachineSets, logger)
+	authority, err := detectMachineAuthority(cd, remoteClusterAPIClient, logger)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not detectMachineAuthority")
+		return reconcile.Result{}, err
+	}
+	logger = logger.WithField("authoritativeAPI", authority)
+
+	actuator, generatedMachineSets, proceed, err := r.generateMachineSets(pool, cd, masterMachine, remoteMachineSets, remoteClusterAPIClient, logger)
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not generateMachineSets")
 		return reconcile.Result{}, err
 	} else if !proceed {
 		logger.Info("machineSets generator indicated not to proceed, returning")
-		return reconcile.Result{}, nil
+		return reconcile.Result{RequeueAfter: poolResyncInterval(pool, cd, pool.Status.Rollout, 0, logger)}, nil
+	}
+
+	var adopted []string
+	if pool.Spec.AdoptExisting {
+		adopted = adoptExistingMachineSets(cd, pool, generatedMachineSets, remoteMachineSets, logger)
+	}
+	if err := r.setAdoptedCondition(pool, adopted, logger); err != nil {
+		return reconcile.Result{}, err
 	}
 
 	switch result, err := r.ensureEnoughReplicas(pool, generatedMachineSets, cd, logger); {
@@ -332,27 +459,102 @@ func (r *ReconcileMachinePool) Reconcile(ctx context.Context, request reconcile.
 		return *result, nil
 	}
 
-	machineSets, err := r.syncMachineSets(pool, cd, generatedMachineSets, remoteMachineSets, remoteClusterAPIClient, logger)
+	switch result, err := r.ensureValidZoneDistribution(pool, generatedMachineSets, logger); {
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not ensureValidZoneDistribution")
+		return reconcile.Result{}, err
+	case result != nil:
+		return *result, nil
+	}
+
+	windowOpen, windowOpensIn := controllerutils.MaintenanceWindowOpen(cd, time.Now(), logger)
+	rollout, machinesToReplace, err := r.planRollout(pool, generatedMachineSets, remoteMachineSets, remoteClusterAPIClient, windowOpen, logger)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not planRollout")
+		return reconcile.Result{}, err
+	}
+	if replacesMachineSets(pool) && pool.DeletionTimestamp == nil {
+		generatedMachineSets, rollout, err = r.planMachineSetReplacement(pool, generatedMachineSets, remoteMachineSets, windowOpen, logger)
+		if err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not planMachineSetReplacement")
+			return reconcile.Result{}, err
+		}
+	}
+
+	machineSets, protectedMachineSets, conflicts, err := r.syncMachineSets(pool, cd, generatedMachineSets, remoteMachineSets, authority, remoteClusterAPIClient, logger)
 	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncMachineSets")
 		return reconcile.Result{}, err
 	}
+	if err := r.setMachineSetConflictCondition(pool, conflicts, logger); err != nil {
+		return reconcile.Result{}, err
+	}
 
-	if err := r.syncMachineAutoscalers(pool, cd, machineSets, remoteClusterAPIClient, logger); err != nil {
+	if err := r.replaceMachines(machinesToReplace, remoteClusterAPIClient, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not replaceMachines")
+		return reconcile.Result{}, err
+	}
+
+	protectedMachineAutoscalers, err := r.syncMachineAutoscalers(pool, cd, machineSets, remoteClusterAPIClient, logger)
+	if err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncMachineAutoscalers")
 		return reconcile.Result{}, err
 	}
 
+	protectedMachineHealthChecks, err := r.syncMachineHealthChecks(pool, machineSets, remoteClusterAPIClient, logger)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncMachineHealthChecks")
+		return reconcile.Result{}, err
+	}
+
 	if err := r.syncClusterAutoscaler(pool, cd, remoteClusterAPIClient, logger); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncClusterAutoscaler")
 		return reconcile.Result{}, err
 	}
 
+	if err := r.syncMachineConfigs(pool, remoteClusterAPIClient, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncMachineConfigs")
+		return reconcile.Result{}, err
+	}
+
+	if err := r.syncInstanceTags(pool, cd, actuator, machineSets, remoteClusterAPIClient, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncInstanceTags")
+		return reconcile.Result{}, err
+	}
+
+	if err := r.syncPropagatedNodeLabels(pool, cd, machineSets, remoteClusterAPIClient, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncPropagatedNodeLabels")
+		return reconcile.Result{}, err
+	}
+
+	if err := r.syncControlPlaneMachines(pool, cd, remoteClusterAPIClient, logger); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not syncControlPlaneMachines")
+		return reconcile.Result{}, err
+	}
+
+	protected := append(protectedMachineSets, protectedMachineAutoscalers...)
+	protected = append(protected, protectedMachineHealthChecks...)
+	if err := r.setDeletionProtectedCondition(pool, protected, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if pool.DeletionTimestamp != nil {
+		if err := r.updateClusterDeploymentSummary(cd, pool, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		if len(protected) > 0 {
+			// Changes to the remote cluster do not trigger reconciles.
+			logger.Info("waiting for protected remote resources to be unprotected or deleted")
+			return reconcile.Result{RequeueAfter: defaultResyncInterval}, nil
+		}
 		return r.removeFinalizer(pool, logger)
 	}
 
-	return r.updatePoolStatusForMachineSets(pool, machineSets, remoteClusterAPIClient, logger)
+	result, err := r.updatePoolStatusForMachineSets(pool, cd, actuator, machineSets, rollout, windowOpensIn, authority, remoteClusterAPIClient, logger)
+	if err != nil {
+		return result, err
+	}
+	return result, r.updateClusterDeploymentSummary(cd, pool, logger)
 }
 
 func (r *ReconcileMachinePool) getMasterMachine(
@@ -407,53 +609,31 @@ func (r *ReconcileMachinePool) generateMachineSets(
 	cd *hivev1.ClusterDeployment,
 	masterMachine *machineapi.Machine,
 	remoteMachineSets *machineapi.MachineSetList,
+	remoteClusterAPIClient client.Client,
 	logger log.FieldLogger,
-) ([]*machineapi.MachineSet, bool, error) {
+) (Actuator, []*machineapi.MachineSet, bool, error) {
 	if pool.DeletionTimestamp != nil {
-		return nil, true, nil
+		return nil, nil, true, nil
 	}
 
-	actuator, err := r.actuatorBuilder(cd, pool, masterMachine, remoteMachineSets.Items, logger)
+	actuator, err := r.actuatorBuilder(cd, pool, masterMachine, remoteMachineSets.Items, remoteClusterAPIClient, logger)
 	if err != nil {
 		logger.WithError(err).Error("unable to create actuator")
-		return nil, false, err
+		return nil, nil, false, err
 	}
 
 	// Generate expected MachineSets for Platform from InstallConfig
-	generatedMachineSets, proceed, err := actuator.GenerateMachineSets(cd, pool, logger)
+	generatedMachineSets, proceed, err := machinesetgen.Generate(actuator, cd, pool, r.machinePoolDefaults, logger)
 	if err != nil {
-		return nil, false, errors.Wrap(err, "could not generate machinesets")
+		return nil, nil, false, errors.Wrap(err, "could not generate machinesets")
 	} else if !proceed {
 		logger.Info("actuator indicated not to proceed, returning")
-		return nil, false, nil
-	}
-
-	for i, ms := range generatedMachineSets {
-		if pool.Spec.Autoscaling != nil {
-			min, _ := getMinMaxReplicasForMachineSet(pool, generatedMachineSets, i)
-			ms.Spec.Replicas = &min
-		}
-
-		if ms.Labels == nil {
-			ms.Labels = make(map[string]string, 2)
-		}
-		ms.Labels[machinePoolNameLabel] = pool.Spec.Name
-		// Add the managed-by-Hive label:
-		ms.Labels[constants.HiveManagedLabel] = "true"
-
-		// Apply hive MachinePool labels to MachineSet MachineSpec.
-		ms.Spec.Template.Spec.ObjectMeta.Labels = make(map[string]string, len(pool.Spec.Labels))
-		for key, value := range pool.Spec.Labels {
-			ms.Spec.Template.Spec.ObjectMeta.Labels[key] = value
-		}
-
-		// Apply hive MachinePool taints to MachineSet MachineSpec.
-		ms.Spec.Template.Spec.Taints = pool.Spec.Taints
+		return nil, nil, false, nil
 	}
 
 	logger.Infof("generated %v worker machine sets", len(generatedMachineSets))
 
-	return generatedMachineSets, true, nil
+	return actuator, generatedMachineSets, true, nil
 }
 
 // ensureEnoughReplicas ensures that the min replicas in the machine pool is
@@ -504,10 +684,58 @@ func (r *ReconcileMachinePool) ensureEnoughReplicas(
 	)
 	if changed {
 		pool.Status.Conditions = conds
-		err := r.Status().Update(context.Background(), pool)
-		if err != nil {
+		if err := r.Status().Update(context.Background(), pool); err != nil {
 			logger.WithError(err).Error("failed to update MachinePool conditions")
+			return &reconcile.Result{}, err
 		}
+		// Status updates do not trigger reconciles, so the pool is requeued to sync it.
+		return &reconcile.Result{Requeue: true}, nil
+	}
+	return nil, nil
+}
+
+// ensureValidZoneDistribution ensures that the zone distribution of the machine pool only pins zones of the generated
+// MachineSets, and that its pinned replicas add up to the replicas of the machine pool. If the reconcile.Result
+// returned is non-nil, then the reconciliation loop should stop, returning that result. As with ensureEnoughReplicas,
+// an invalid machine pool is not requeued, since only updates to the machine pool can fix it.
+func (r *ReconcileMachinePool) ensureValidZoneDistribution(
+	pool *hivev1.MachinePool,
+	generatedMachineSets []*machineapi.MachineSet,
+	logger log.FieldLogger,
+) (*reconcile.Result, error) {
+	if err := machinesetgen.ValidateZoneDistribution(pool, generatedMachineSets); err != nil {
+		logger.WithError(err).Warning("invalid zone distribution")
+		conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+			pool.Status.Conditions,
+			hivev1.InvalidZoneDistributionMachinePoolCondition,
+			corev1.ConditionTrue,
+			"ZoneDistributionMismatch",
+			fmt.Sprintf("The zone distribution does not match the machine pool: %v", err),
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		if changed {
+			pool.Status.Conditions = conds
+			if err := r.Status().Update(context.Background(), pool); err != nil {
+				logger.WithError(err).Error("failed to update MachinePool conditions")
+				return &reconcile.Result{}, err
+			}
+		}
+		return &reconcile.Result{}, nil
+	}
+	// The condition is only added to machine pools with an invalid zone distribution, and cleared once it is fixed.
+	if cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.InvalidZoneDistributionMachinePoolCondition); cond == nil || cond.Status != corev1.ConditionTrue {
+		return nil, nil
+	}
+	pool.Status.Conditions = controllerutils.SetMachinePoolCondition(
+		pool.Status.Conditions,
+		hivev1.InvalidZoneDistributionMachinePoolCondition,
+		corev1.ConditionFalse,
+		"ValidZoneDistribution",
+		"The zone distribution matches the machine pool",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if err := r.Status().Update(context.Background(), pool); err != nil {
+		logger.WithError(err).Error("failed to update MachinePool conditions")
 		return &reconcile.Result{}, err
 	}
 	return nil, nil
@@ -518,14 +746,17 @@ func (r *ReconcileMachinePool) syncMachineSets(
 	cd *hivev1.ClusterDeployment,
 	generatedMachineSets []*machineapi.MachineSet,
 	remoteMachineSets *machineapi.MachineSetList,
+	authority hivev1.MachineAuthority,
 	remoteClusterAPIClient client.Client,
 	logger log.FieldLogger,
-) ([]*machineapi.MachineSet, error) {
+) ([]*machineapi.MachineSet, []string, []string, error) {
 	result := make([]*machineapi.MachineSet, len(generatedMachineSets))
 
 	machineSetsToDelete := []*machineapi.MachineSet{}
 	machineSetsToCreate := []*machineapi.MachineSet{}
 	machineSetsToUpdate := []*machineapi.MachineSet{}
+	updateIndexes := map[string]int{}
+	observedMachineSets := map[string]*machineapi.MachineSet{}
 
 	// Find MachineSets that need updating/creating
 	for i, ms := range generatedMachineSets {
@@ -533,6 +764,7 @@ func (r *ReconcileMachinePool) syncMachineSets(
 		for _, rMS := range remoteMachineSets.Items {
 			if ms.Name == rMS.Name {
 				found = true
+				observed := rMS.DeepCopy()
 				objectModified := false
 				objectMetaModified := false
 				resourcemerge.EnsureObjectMeta(&objectMetaModified, &rMS.ObjectMeta, ms.ObjectMeta)
@@ -552,7 +784,7 @@ func (r *ReconcileMachinePool) syncMachineSets(
 					// even if the replicas in the machineset is not equal to the min and max.
 					// To ensure that the replicas falls within min and max regardless, Hive needs
 					// to set the replicas to explicitly be within the desired range.
-					min, max := getMinMaxReplicasForMachineSet(pool, generatedMachineSets, i)
+					min, max := machinesetgen.MinMaxReplicas(pool, generatedMachineSets, i)
 					switch {
 					case rMS.Spec.Replicas == nil:
 						msLog.WithField("observed", nil).WithField("min", min).WithField("max", max).Info("setting replicas to min")
@@ -579,6 +811,20 @@ func (r *ReconcileMachinePool) syncMachineSets(
 					objectModified = true
 				}
 
+				// Update the provider spec of the remote machineset if the machine pool can be rolled out. Otherwise the
+				// platform of the machine pool is immutable.
+				if pool.Spec.RolloutStrategy != nil {
+					matches, err := providerSpecMatches(ms.Spec.Template.Spec.ProviderSpec.Value, rMS.Spec.Template.Spec.ProviderSpec.Value)
+					if err != nil {
+						return nil, nil, nil, errors.Wrapf(err, "failed to compare provider spec of machineset %s", rMS.Name)
+					}
+					if !matches {
+						msLog.Info("provider spec out of sync")
+						rMS.Spec.Template.Spec.ProviderSpec = ms.Spec.Template.Spec.ProviderSpec
+						objectModified = true
+					}
+				}
+
 				// Update if the taints on the remote machineset are different than the taints on the generated machineset.
 				// If the length of both taints is zero, then they match, even if one is a nil slice and the other is an empty slice.
 				if rt, t := rMS.Spec.Template.Spec.Taints, ms.Spec.Template.Spec.Taints; (len(rt) != 0 || len(t) != 0) && !reflect.DeepEqual(rt, t) {
@@ -588,8 +834,9 @@ func (r *ReconcileMachinePool) syncMachineSets(
 				}
 
 				if objectMetaModified || objectModified {
-					rMS.Generation++
-					machineSetsToUpdate = append(machineSetsToUpdate, &rMS)
+					machineSetsToUpdate = append(machineSetsToUpdate, appliedMachineSet(pool, ms, &rMS))
+					updateIndexes[ms.Name] = i
+					observedMachineSets[ms.Name] = observed
 				}
 
 				result[i] = &rMS
@@ -604,6 +851,7 @@ func (r *ReconcileMachinePool) syncMachineSets(
 	}
 
 	// Find MachineSets that need deleting
+	var protected []string
 	for i, rMS := range remoteMachineSets.Items {
 		if !isControlledByMachinePool(cd, pool, &rMS) {
 			continue
@@ -617,37 +865,71 @@ func (r *ReconcileMachinePool) syncMachineSets(
 				}
 			}
 		}
-		if delete {
-			machineSetsToDelete = append(machineSetsToDelete, &remoteMachineSets.Items[i])
+		if !delete {
+			continue
 		}
+		if isDeleteProtected(&rMS) {
+			logger.WithField("machineset", rMS.Name).Warn("not deleting protected machineset")
+			protected = append(protected, "machineset/"+rMS.Name)
+			continue
+		}
+		machineSetsToDelete = append(machineSetsToDelete, &remoteMachineSets.Items[i])
 	}
 
 	for _, ms := range machineSetsToCreate {
 		logger.WithField("machineset", ms.Name).Info("creating machineset")
-		if err := remoteClusterAPIClient.Create(context.Background(), ms); err != nil {
+		if err := writeMachineSet(ms, authority, func(obj client.Object) error {
+			return remoteClusterAPIClient.Create(context.Background(), obj, client.FieldOwner(fieldManager))
+		}); err != nil {
 			logger.WithError(err).Error("unable to create machine set")
-			return nil, err
+			return nil, nil, nil, err
 		}
 	}
 
+	force := pool.Annotations[hivev1.MachinePoolForceApplyAnnotation] == "true"
+	var conflicts []string
 	for _, ms := range machineSetsToUpdate {
-		logger.WithField("machineset", ms.Name).Info("updating machineset")
-		if err := remoteClusterAPIClient.Update(context.Background(), ms); err != nil {
-			logger.WithError(err).Error("unable to update machine set")
-			return nil, err
+		msLog := logger.WithField("machineset", ms.Name)
+		msLog.Info("applying machineset")
+		applied, msConflicts, err := applyMachineSet(ms, authority, force, remoteClusterAPIClient)
+		if err != nil {
+			msLog.WithError(err).Error("unable to apply machine set")
+			return nil, nil, nil, err
 		}
+		if len(msConflicts) > 0 {
+			// Leave the MachineSet as it is, so that the conflicting changes are not overwritten.
+			msLog.WithField("conflicts", msConflicts).Warn("machineset fields are managed by other field managers, not applying")
+			conflicts = append(conflicts, fmt.Sprintf("machineset/%s: %s", ms.Name, strings.Join(msConflicts, ", ")))
+			result[updateIndexes[ms.Name]] = observedMachineSets[ms.Name]
+			continue
+		}
+		result[updateIndexes[ms.Name]] = applied
 	}
 
 	for _, ms := range machineSetsToDelete {
 		logger.WithField("machineset", ms.Name).Info("deleting machineset")
 		if err := remoteClusterAPIClient.Delete(context.Background(), ms); err != nil {
 			logger.WithError(err).Error("unable to delete machine set")
-			return nil, err
+			return nil, nil, nil, err
 		}
 	}
 
 	logger.Info("done reconciling machine sets for machine pool")
-	return result, nil
+	return result, protected, conflicts, nil
+}
+
+// appliedMachineSet returns the fields of the remote MachineSet which Hive applies. The replicas of autoscaled pools
+// and the provider spec of pools which cannot be rolled out are kept as observed, so that Hive keeps managing them
+// without changing them.
+func appliedMachineSet(pool *hivev1.MachinePool, generated, remote *machineapi.MachineSet) *machineapi.MachineSet {
+	ms := generated.DeepCopy()
+	if pool.Spec.Autoscaling != nil {
+		ms.Spec.Replicas = remote.Spec.Replicas
+	}
+	if pool.Spec.RolloutStrategy == nil {
+		ms.Spec.Template.Spec.ProviderSpec = remote.Spec.Template.Spec.ProviderSpec
+	}
+	return ms
 }
 
 func (r *ReconcileMachinePool) syncMachineAutoscalers(
@@ -656,7 +938,7 @@ func (r *ReconcileMachinePool) syncMachineAutoscalers(
 	machineSets []*machineapi.MachineSet,
 	remoteClusterAPIClient client.Client,
 	logger log.FieldLogger,
-) error {
+) ([]string, error) {
 	// List MachineAutoscalers from remote cluster
 	remoteMachineAutoscalers := &autoscalingv1beta1.MachineAutoscalerList{}
 	tm := metav1.TypeMeta{}
@@ -667,7 +949,7 @@ func (r *ReconcileMachinePool) syncMachineAutoscalers(
 		&client.ListOptions{Raw: &metav1.ListOptions{TypeMeta: tm}},
 	); err != nil {
 		logger.WithError(err).Error("unable to fetch remote machine autoscalers")
-		return err
+		return nil, err
 	}
 	logger.Infof("found %v remote machine autoscalers", len(remoteMachineAutoscalers.Items))
 
@@ -678,7 +960,7 @@ func (r *ReconcileMachinePool) syncMachineAutoscalers(
 	if pool.DeletionTimestamp == nil && pool.Spec.Autoscaling != nil {
 		// Find MachineAutoscalers that need updating/creating
 		for i, ms := range machineSets {
-			minReplicas, maxReplicas := getMinMaxReplicasForMachineSet(pool, machineSets, i)
+			minReplicas, maxReplicas := machinesetgen.MinMaxReplicas(pool, machineSets, i)
 			found := false
 			for _, rMA := range remoteMachineAutoscalers.Items {
 				if ms.Name == rMA.Name {
@@ -710,31 +992,14 @@ func (r *ReconcileMachinePool) syncMachineAutoscalers(
 			}
 
 			if !found {
-				ma := &autoscalingv1beta1.MachineAutoscaler{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: ms.Namespace,
-						Name:      ms.Name,
-						Labels: map[string]string{
-							machinePoolNameLabel: pool.Spec.Name,
-						},
-					},
-					Spec: autoscalingv1beta1.MachineAutoscalerSpec{
-						MinReplicas: minReplicas,
-						MaxReplicas: maxReplicas,
-						ScaleTargetRef: autoscalingv1beta1.CrossVersionObjectReference{
-							APIVersion: ms.APIVersion,
-							Kind:       ms.Kind,
-							Name:       ms.Name,
-						},
-					},
-				}
-				machineAutoscalersToCreate = append(machineAutoscalersToCreate, ma)
+				machineAutoscalersToCreate = append(machineAutoscalersToCreate, generateMachineAutoscaler(pool, ms, minReplicas, maxReplicas))
 			}
 
 		}
 	}
 
 	// Find MachineAutoscalers that need deleting
+	var protected []string
 	for i, rMA := range remoteMachineAutoscalers.Items {
 		if !isControlledByMachinePool(cd, pool, &rMA) {
 			continue
@@ -748,16 +1013,22 @@ func (r *ReconcileMachinePool) syncMachineAutoscalers(
 				}
 			}
 		}
-		if delete {
-			machineAutoscalersToDelete = append(machineAutoscalersToDelete, &remoteMachineAutoscalers.Items[i])
+		if !delete {
+			continue
 		}
+		if isDeleteProtected(&rMA) {
+			logger.WithField("machineautoscaler", rMA.Name).Warn("not deleting protected machineautoscaler")
+			protected = append(protected, "machineautoscaler/"+rMA.Name)
+			continue
+		}
+		machineAutoscalersToDelete = append(machineAutoscalersToDelete, &remoteMachineAutoscalers.Items[i])
 	}
 
 	for _, ma := range machineAutoscalersToCreate {
 		logger.WithField("machineautoscaler", ma.Name).Info("creating machineautoscaler")
 		if err := remoteClusterAPIClient.Create(context.Background(), ma); err != nil {
 			logger.WithError(err).Error("unable to create machine autoscaler")
-			return err
+			return nil, err
 		}
 	}
 
@@ -765,7 +1036,7 @@ func (r *ReconcileMachinePool) syncMachineAutoscalers(
 		logger.WithField("machineautoscaler", ma.Name).Info("updating machineautoscaler")
 		if err := remoteClusterAPIClient.Update(context.Background(), ma); err != nil {
 			logger.WithError(err).Error("unable to update machine autoscaler")
-			return err
+			return nil, err
 		}
 	}
 
@@ -773,11 +1044,102 @@ func (r *ReconcileMachinePool) syncMachineAutoscalers(
 		logger.WithField("machineautoscaler", ma.Name).Info("deleting machineautoscaler")
 		if err := remoteClusterAPIClient.Delete(context.Background(), ma); err != nil {
 			logger.WithError(err).Error("unable to delete machine autoscaler")
-			return err
+			return nil, err
 		}
 	}
 
 	logger.Info("done reconciling machine autoscalers for cluster deployment")
+	return protected, nil
+}
+
+// generateMachineAutoscaler returns the MachineAutoscaler scaling the MachineSet of the pool between the given
+// replicas.
+func generateMachineAutoscaler(pool *hivev1.MachinePool, ms *machineapi.MachineSet, minReplicas, maxReplicas int32) *autoscalingv1beta1.MachineAutoscaler {
+	return &autoscalingv1beta1.MachineAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ms.Namespace,
+			Name:      ms.Name,
+			Labels: map[string]string{
+				machinesetgen.MachinePoolNameLabel: pool.Spec.Name,
+			},
+		},
+		Spec: autoscalingv1beta1.MachineAutoscalerSpec{
+			MinReplicas: minReplicas,
+			MaxReplicas: maxReplicas,
+			ScaleTargetRef: autoscalingv1beta1.CrossVersionObjectReference{
+				APIVersion: ms.APIVersion,
+				Kind:       ms.Kind,
+				Name:       ms.Name,
+			},
+		},
+	}
+}
+
+// generateClusterAutoscaler returns the default ClusterAutoscaler created in clusters with auto-scaling pools, with the
+// settings of the ClusterDeployment. The utilization threshold is not part of the vendored ClusterAutoscaler API, and
+// is synced by syncUtilizationThreshold.
+func generateClusterAutoscaler(cd *hivev1.ClusterDeployment) *autoscalingv1.ClusterAutoscaler {
+	ca := &autoscalingv1.ClusterAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default",
+		},
+		Spec: autoscalingv1.ClusterAutoscalerSpec{
+			ScaleDown: &autoscalingv1.ScaleDownConfig{
+				Enabled: true,
+			},
+		},
+	}
+	settings := cd.Spec.ClusterAutoscaler
+	if settings == nil {
+		return ca
+	}
+	if scaleDown := settings.ScaleDown; scaleDown != nil {
+		ca.Spec.ScaleDown.DelayAfterAdd = durationString(scaleDown.DelayAfterAdd)
+		ca.Spec.ScaleDown.DelayAfterDelete = durationString(scaleDown.DelayAfterDelete)
+		ca.Spec.ScaleDown.DelayAfterFailure = durationString(scaleDown.DelayAfterFailure)
+		ca.Spec.ScaleDown.UnneededTime = durationString(scaleDown.UnneededTime)
+	}
+	if settings.BalanceSimilarNodeGroups != nil {
+		balance := *settings.BalanceSimilarNodeGroups
+		ca.Spec.BalanceSimilarNodeGroups = &balance
+	}
+	if limits := settings.ResourceLimits; limits != nil {
+		ca.Spec.ResourceLimits = &autoscalingv1.ResourceLimits{}
+		if limits.MaxNodesTotal != nil {
+			maxNodesTotal := *limits.MaxNodesTotal
+			ca.Spec.ResourceLimits.MaxNodesTotal = &maxNodesTotal
+		}
+		if limits.Cores != nil {
+			ca.Spec.ResourceLimits.Cores = &autoscalingv1.ResourceRange{Min: limits.Cores.Min, Max: limits.Cores.Max}
+		}
+		if limits.Memory != nil {
+			ca.Spec.ResourceLimits.Memory = &autoscalingv1.ResourceRange{Min: limits.Memory.Min, Max: limits.Memory.Max}
+		}
+		for _, gpu := range limits.GPUs {
+			ca.Spec.ResourceLimits.GPUS = append(ca.Spec.ResourceLimits.GPUS, autoscalingv1.GPULimit{
+				Type: gpu.Type,
+				Min:  gpu.Min,
+				Max:  gpu.Max,
+			})
+		}
+	}
+	return ca
+}
+
+func durationString(d *metav1.Duration) *string {
+	if d == nil {
+		return nil
+	}
+	s := d.Duration.String()
+	return &s
+}
+
+// clusterAutoscalerUtilizationThreshold returns the scale down utilization threshold of the ClusterDeployment, nil if
+// it has none.
+func clusterAutoscalerUtilizationThreshold(cd *hivev1.ClusterDeployment) *string {
+	if settings := cd.Spec.ClusterAutoscaler; settings != nil && settings.ScaleDown != nil {
+		return settings.ScaleDown.UtilizationThreshold
+	}
 	return nil
 }
 
@@ -809,13 +1171,35 @@ func (r *ReconcileMachinePool) syncClusterAutoscaler(
 			break
 		}
 	}
+	generated := generateClusterAutoscaler(cd)
 	if defaultClusterAutoscaler != nil {
-		if spec := &defaultClusterAutoscaler.Spec; spec.ScaleDown == nil || !spec.ScaleDown.Enabled {
-			logger.Info("updaing cluster autoscaler")
-			if spec.ScaleDown == nil {
-				spec.ScaleDown = &autoscalingv1.ScaleDownConfig{}
+		spec := &defaultClusterAutoscaler.Spec
+		objectModified := false
+		if cd.Spec.ClusterAutoscaler == nil {
+			// Without settings, the ClusterAutoscaler can be tuned in the cluster as long as scale down is enabled.
+			if spec.ScaleDown == nil || !spec.ScaleDown.Enabled {
+				if spec.ScaleDown == nil {
+					spec.ScaleDown = &autoscalingv1.ScaleDownConfig{}
+				}
+				spec.ScaleDown.Enabled = true
+				objectModified = true
+			}
+		} else {
+			if !reflect.DeepEqual(spec.ScaleDown, generated.Spec.ScaleDown) {
+				spec.ScaleDown = generated.Spec.ScaleDown
+				objectModified = true
+			}
+			if !reflect.DeepEqual(spec.BalanceSimilarNodeGroups, generated.Spec.BalanceSimilarNodeGroups) {
+				spec.BalanceSimilarNodeGroups = generated.Spec.BalanceSimilarNodeGroups
+				objectModified = true
 			}
-			spec.ScaleDown.Enabled = true
+			if !reflect.DeepEqual(spec.ResourceLimits, generated.Spec.ResourceLimits) {
+				spec.ResourceLimits = generated.Spec.ResourceLimits
+				objectModified = true
+			}
+		}
+		if objectModified {
+			logger.Info("updating cluster autoscaler")
 			if err := remoteClusterAPIClient.Update(context.Background(), defaultClusterAutoscaler); err != nil {
 				logger.WithError(err).Error("could not update cluster autoscaler")
 				return err
@@ -823,41 +1207,124 @@ func (r *ReconcileMachinePool) syncClusterAutoscaler(
 		}
 	} else {
 		logger.Info("creating cluster autoscaler")
-		defaultClusterAutoscaler = &autoscalingv1.ClusterAutoscaler{
-			ObjectMeta: metav1.ObjectMeta{
-				Name: "default",
-			},
-			Spec: autoscalingv1.ClusterAutoscalerSpec{
-				ScaleDown: &autoscalingv1.ScaleDownConfig{
-					Enabled: true,
-				},
-			},
-		}
-		if err := remoteClusterAPIClient.Create(context.Background(), defaultClusterAutoscaler); err != nil {
+		if err := remoteClusterAPIClient.Create(context.Background(), generated); err != nil {
 			logger.WithError(err).Error("could not create cluster autoscaler")
 			return err
 		}
 	}
+	return r.syncUtilizationThreshold(cd, remoteClusterAPIClient, logger)
+}
+
+// syncUtilizationThreshold sets the scale down utilization threshold of the ClusterDeployment on the default
+// ClusterAutoscaler. As the threshold is not part of the vendored ClusterAutoscaler API, the ClusterAutoscaler is read
+// and patched as an unstructured object.
+func (r *ReconcileMachinePool) syncUtilizationThreshold(
+	cd *hivev1.ClusterDeployment,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) error {
+	if cd.Spec.ClusterAutoscaler == nil {
+		return nil
+	}
+	ca := &unstructured.Unstructured{}
+	ca.SetGroupVersionKind(autoscalingv1.SchemeGroupVersion.WithKind("ClusterAutoscaler"))
+	if err := remoteClusterAPIClient.Get(context.Background(), client.ObjectKey{Name: "default"}, ca); err != nil {
+		logger.WithError(err).Error("could not get cluster autoscaler")
+		return err
+	}
+	current, found, err := unstructured.NestedString(ca.Object, "spec", "scaleDown", "utilizationThreshold")
+	if err != nil {
+		return errors.Wrap(err, "could not read utilization threshold of cluster autoscaler")
+	}
+	desired := clusterAutoscalerUtilizationThreshold(cd)
+	if desired == nil && !found || desired != nil && found && current == *desired {
+		return nil
+	}
+	// A nil threshold removes the threshold of the cluster autoscaler.
+	var threshold interface{}
+	if desired != nil {
+		threshold = *desired
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"scaleDown": map[string]interface{}{
+				"utilizationThreshold": threshold,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	logger.WithField("utilizationThreshold", threshold).Info("updating utilization threshold of cluster autoscaler")
+	if err := remoteClusterAPIClient.Patch(context.Background(), ca, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		logger.WithError(err).Error("could not update utilization threshold of cluster autoscaler")
+		return err
+	}
 	return nil
 }
 
+// syncInstanceTags updates the tags of the running instances of pools with the Instances tag reconciliation mode.
+// MachineSets only apply changed tags to the instances of new machines.
+func (r *ReconcileMachinePool) syncInstanceTags(
+	pool *hivev1.MachinePool,
+	cd *hivev1.ClusterDeployment,
+	actuator Actuator,
+	machineSets []*machineapi.MachineSet,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) error {
+	if pool.Spec.TagReconciliation != hivev1.InstancesTagReconciliation || actuator == nil {
+		return nil
+	}
+	tagReconciler, ok := actuator.(InstanceTagReconciler)
+	if !ok {
+		logger.Debug("tags of running instances are not reconciled for the platform of the cluster")
+		return nil
+	}
+
+	var machines []machineapi.Machine
+	for _, ms := range machineSets {
+		sel, err := metav1.LabelSelectorAsSelector(&ms.Spec.Selector)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create label selector for machineset %s", ms.Name)
+		}
+		list := &machineapi.MachineList{}
+		if err := remoteClusterAPIClient.List(context.TODO(), list,
+			client.InNamespace(ms.Namespace),
+			client.MatchingLabelsSelector{Selector: sel}); err != nil {
+			return errors.Wrapf(err, "failed to list machines for machineset %s", ms.Name)
+		}
+		machines = append(machines, list.Items...)
+	}
+	return tagReconciler.ReconcileInstanceTags(cd, pool, machines, logger)
+}
+
 func (r *ReconcileMachinePool) updatePoolStatusForMachineSets(
 	pool *hivev1.MachinePool,
+	cd *hivev1.ClusterDeployment,
+	actuator Actuator,
 	machineSets []*machineapi.MachineSet,
+	rollout *hivev1.MachinePoolRolloutStatus,
+	windowOpensIn time.Duration,
+	authority hivev1.MachineAuthority,
 	remoteClusterAPIClient client.Client,
 	logger log.FieldLogger,
 ) (reconcile.Result, error) {
 	origPool := pool.DeepCopy()
 
+	pool.Status.Rollout = rollout
+	pool.Status.AuthoritativeAPI = authority
+
 	pool.Status.MachineSets = make([]hivev1.MachineSetStatus, len(machineSets))
 	pool.Status.Replicas = 0
+	machinePhases := countMachinePhases(remoteClusterAPIClient, machineSets, logger)
 	for i, ms := range machineSets {
 		var min, max int32
 		if pool.Spec.Autoscaling == nil {
 			min = *ms.Spec.Replicas
 			max = *ms.Spec.Replicas
 		} else {
-			min, max = getMinMaxReplicasForMachineSet(pool, machineSets, i)
+			min, max = machinesetgen.MinMaxReplicas(pool, machineSets, i)
 		}
 		s := hivev1.MachineSetStatus{
 			Name:          ms.Name,
@@ -865,9 +1332,20 @@ func (r *ReconcileMachinePool) updatePoolStatusForMachineSets(
 			ReadyReplicas: ms.Status.ReadyReplicas,
 			MinReplicas:   min,
 			MaxReplicas:   max,
+			Machines:      machinePhases[ms.Name],
 			ErrorReason:   (*string)(ms.Status.ErrorReason),
 			ErrorMessage:  ms.Status.ErrorMessage,
 		}
+		if generation, err := strconv.ParseInt(ms.Annotations[constants.MachinePoolGenerationAnnotation], 10, 64); err == nil {
+			s.AppliedGeneration = generation
+		}
+		if reporter, ok := actuator.(MachineSetInfrastructureReporter); ok {
+			infra, err := reporter.MachineSetInfrastructure(ms)
+			if err != nil {
+				logger.WithError(err).WithField("machineset", ms.Name).Warn("could not determine infrastructure of machineset")
+			}
+			s.Infrastructure = infra
+		}
 		if s.Replicas != s.ReadyReplicas && s.ErrorReason == nil {
 			r, m := summarizeMachinesError(remoteClusterAPIClient, ms, logger)
 			s.ErrorReason = &r
@@ -877,16 +1355,38 @@ func (r *ReconcileMachinePool) updatePoolStatusForMachineSets(
 		pool.Status.MachineSets[i] = s
 		pool.Status.Replicas += *ms.Spec.Replicas
 	}
+	pool.Status.Zones = aggregateZoneStatuses(machineSets, pool.Status.MachineSets)
+
+	requeueAfter := poolResyncInterval(pool, cd, rollout, windowOpensIn, logger)
 
-	var requeueAfter time.Duration
+	var readyReplicas int32
 	for _, ms := range pool.Status.MachineSets {
-		if ms.Replicas != ms.ReadyReplicas {
-			// remote cluster machinesets cannot trigger reconcile and therefore
-			// since we know we are not steady state, we need to ensure that we
-			// requeue to keep the status in sync from remote cluster.
-			requeueAfter = 10 * time.Minute
-			break
-		}
+		readyReplicas += ms.ReadyReplicas
+	}
+	readyStatus, readyReason, readyMessage := corev1.ConditionTrue, "MachinesReady", "All machines of the machine pool are ready"
+	switch {
+	case rollout != nil && rollout.OutdatedReplicas > 0 && windowOpensIn > 0:
+		readyStatus, readyReason = corev1.ConditionFalse, "WaitingForMaintenanceWindow"
+		readyMessage = fmt.Sprintf("%d machines of the machine pool will be replaced in the next maintenance window", rollout.OutdatedReplicas)
+	case rollout != nil && rollout.OutdatedReplicas > 0:
+		readyStatus, readyReason = corev1.ConditionFalse, "RolloutInProgress"
+		readyMessage = fmt.Sprintf("%d machines of the machine pool remain to be replaced", rollout.OutdatedReplicas)
+	case readyReplicas < pool.Status.Replicas:
+		readyStatus, readyReason = corev1.ConditionFalse, "MachinesNotReady"
+		readyMessage = fmt.Sprintf("%d of %d machines of the machine pool are ready", readyReplicas, pool.Status.Replicas)
+	}
+	pool.Status.Conditions = controllerutils.SetMachinePoolCondition(
+		pool.Status.Conditions,
+		hivev1.ReadyMachinePoolCondition,
+		readyStatus,
+		readyReason,
+		readyMessage,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	// The conditions were all evaluated while syncing this generation of the machine pool.
+	pool.Status.ObservedGeneration = pool.Generation
+	for i := range pool.Status.Conditions {
+		pool.Status.Conditions[i].ObservedGeneration = pool.Generation
 	}
 
 	if (len(origPool.Status.MachineSets) == 0 && len(pool.Status.MachineSets) == 0) ||
@@ -897,6 +1397,45 @@ func (r *ReconcileMachinePool) updatePoolStatusForMachineSets(
 	return reconcile.Result{RequeueAfter: requeueAfter}, errors.Wrap(r.Status().Update(context.Background(), pool), "failed to update pool status")
 }
 
+// poolResyncInterval returns when the machine pool is next reconciled based on its state, as changes to the machine
+// sets of remote clusters do not trigger reconciles. Pools which are rolling out or whose machines are not ready are
+// polled often, while pools in a steady state are only resynced occasionally, with jitter so that they are spread out.
+func poolResyncInterval(
+	pool *hivev1.MachinePool,
+	cd *hivev1.ClusterDeployment,
+	rollout *hivev1.MachinePoolRolloutStatus,
+	windowOpensIn time.Duration,
+	logger log.FieldLogger,
+) time.Duration {
+	notReadyInterval := defaultResyncInterval
+	if override := controllerutils.GetClusterOverrides(cd, logger).MachinePoolResyncInterval; override != nil {
+		notReadyInterval = *override
+	}
+	machinesReady := true
+	for _, ms := range pool.Status.MachineSets {
+		if ms.Replicas != ms.ReadyReplicas {
+			machinesReady = false
+			break
+		}
+	}
+
+	switch {
+	case rollout != nil && rollout.OutdatedReplicas > 0 && windowOpensIn > 0:
+		if !machinesReady && notReadyInterval < windowOpensIn {
+			return notReadyInterval
+		}
+		return windowOpensIn
+	case rollout != nil && rollout.OutdatedReplicas > 0, controlPlaneResizing(cd):
+		return rolloutPollInterval
+	case !machinesReady:
+		return notReadyInterval
+	case pool.Spec.Autoscaling != nil:
+		return wait.Jitter(autoscaledResyncInterval, 0.1)
+	default:
+		return wait.Jitter(steadyStateResyncInterval, 0.1)
+	}
+}
+
 // summarizeMachinesError returns reason and message for error state of machineSets by
 // summarizing error reasons and messages from machines the belong to the machineset.
 // If all the machines are in good state, it returns empty reason and message.
@@ -960,9 +1499,12 @@ func (r *ReconcileMachinePool) createActuator(
 	pool *hivev1.MachinePool,
 	masterMachine *machineapi.Machine,
 	remoteMachineSets []machineapi.MachineSet,
+	remoteClusterAPIClient client.Client,
 	logger log.FieldLogger,
 ) (Actuator, error) {
 	switch {
+	case useFakeActuator(cd, pool):
+		return NewFakeActuator(logger), nil
 	case cd.Spec.Platform.AWS != nil:
 		creds := awsclient.CredentialsSource{
 			Secret: &awsclient.SecretCredentialsSource{
@@ -977,7 +1519,16 @@ func (r *ReconcileMachinePool) createActuator(
 				Role: cd.Spec.Platform.AWS.CredentialsAssumeRole,
 			},
 		}
-		return NewAWSActuator(r.Client, creds, cd.Spec.Platform.AWS.Region, pool, masterMachine, r.scheme, logger)
+		credsKey := fmt.Sprintf("secret:%s/%s", cd.Namespace, cd.Spec.Platform.AWS.CredentialsSecretRef.Name)
+		if cd.Spec.Platform.AWS.CredentialsSecretRef.Name == "" && cd.Spec.Platform.AWS.CredentialsAssumeRole != nil {
+			credsKey = "role:" + cd.Spec.Platform.AWS.CredentialsAssumeRole.RoleARN
+		}
+		lookups := r.cloudLookups.scope("aws", credsKey, cd.Spec.Platform.AWS.Region)
+		var defaultUserTags map[string]string
+		if r.machinePoolDefaults != nil {
+			defaultUserTags = r.machinePoolDefaults.UserTags
+		}
+		return NewAWSActuator(r.Client, creds, cd.Spec.Platform.AWS.Region, pool, masterMachine, remoteClusterAPIClient, r.scheme, lookups, defaultUserTags, logger)
 	case cd.Spec.Platform.GCP != nil:
 		creds := &corev1.Secret{}
 		if err := r.Get(
@@ -994,7 +1545,8 @@ func (r *ReconcileMachinePool) createActuator(
 		if err != nil {
 			return nil, err
 		}
-		return NewGCPActuator(r.Client, creds, clusterVersion, masterMachine, remoteMachineSets, r.scheme, r.expectations, logger)
+		lookups := r.cloudLookups.scope("gcp", cloudLookupCredentialsKey(creds))
+		return NewGCPActuator(r.Client, creds, clusterVersion, masterMachine, remoteMachineSets, r.scheme, r.expectations, lookups, logger)
 	case cd.Spec.Platform.Azure != nil:
 		creds := &corev1.Secret{}
 		if err := r.Get(
@@ -1007,7 +1559,8 @@ func (r *ReconcileMachinePool) createActuator(
 		); err != nil {
 			return nil, err
 		}
-		return NewAzureActuator(creds, cd.Spec.Platform.Azure.CloudName.Name(), logger)
+		lookups := r.cloudLookups.scope("azure", cloudLookupCredentialsKey(creds), cd.Spec.Platform.Azure.CloudName.Name())
+		return NewAzureActuator(r.Client, creds, cd.Spec.Platform.Azure.CloudName.Name(), lookups, logger)
 	case cd.Spec.Platform.OpenStack != nil:
 		return NewOpenStackActuator(masterMachine, r.scheme, r.Client, logger)
 	case cd.Spec.Platform.VSphere != nil:
@@ -1029,7 +1582,33 @@ func baseMachinePool(pool *hivev1.MachinePool) *installertypes.MachinePool {
 func isControlledByMachinePool(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, obj metav1.Object) bool {
 	prefix := strings.Join([]string{cd.Spec.ClusterName, pool.Spec.Name, ""}, "-")
 	return strings.HasPrefix(obj.GetName(), prefix) ||
-		obj.GetLabels()[machinePoolNameLabel] == pool.Spec.Name
+		obj.GetLabels()[machinesetgen.MachinePoolNameLabel] == pool.Spec.Name
+}
+
+// expectationsSatisfied returns true if the expectations for the pool with the given key have been satisfied.
+// Expectations which have remained unsatisfied for longer than expectationsStuckThreshold are reset so the pool can
+// self-heal. While still waiting, the returned duration indicates when the expectations should be checked again.
+func (r *ReconcileMachinePool) expectationsSatisfied(key string, logger log.FieldLogger) (bool, time.Duration) {
+	if r.expectations.SatisfiedExpectations(key) {
+		return true, 0
+	}
+	exp, exists, err := r.expectations.GetExpectations(key)
+	if err != nil || !exists {
+		return true, 0
+	}
+	age := exp.Age()
+	if age < expectationsStuckThreshold {
+		return false, expectationsStuckThreshold - age
+	}
+	adds, dels := exp.GetExpectations()
+	logger.WithFields(log.Fields{
+		"age":            age,
+		"pendingAdds":    adds,
+		"pendingDeletes": dels,
+	}).Warn("expectations have been unsatisfied beyond threshold, resetting")
+	metricExpectationsReset.Inc()
+	r.expectations.DeleteExpectations(key)
+	return true, 0
 }
 
 func (r *ReconcileMachinePool) removeFinalizer(pool *hivev1.MachinePool, logger log.FieldLogger) (reconcile.Result, error) {
@@ -1044,22 +1623,6 @@ func (r *ReconcileMachinePool) removeFinalizer(pool *hivev1.MachinePool, logger
 	return reconcile.Result{}, err
 }
 
-func getMinMaxReplicasForMachineSet(pool *hivev1.MachinePool, machineSets []*machineapi.MachineSet, machineSetIndex int) (min, max int32) {
-	noOfMachineSets := int32(len(machineSets))
-	min = pool.Spec.Autoscaling.MinReplicas / noOfMachineSets
-	if int32(machineSetIndex) < pool.Spec.Autoscaling.MinReplicas%noOfMachineSets {
-		min++
-	}
-	max = pool.Spec.Autoscaling.MaxReplicas / noOfMachineSets
-	if int32(machineSetIndex) < pool.Spec.Autoscaling.MaxReplicas%noOfMachineSets {
-		max++
-	}
-	if max < min {
-		max = min
-	}
-	return
-}
-
 func getClusterVersion(cd *hivev1.ClusterDeployment) (string, error) {
 	version, versionPresent := cd.Labels[constants.VersionMajorMinorPatchLabel]
 	if !versionPresent {
@@ -1104,68 +1667,21 @@ func platformAllowsZeroAutoscalingMinReplicas(cd *hivev1.ClusterDeployment) bool
 	return false
 }
 
-// periodicSource uses the client to list the machinepools
-// every duration (including some jitter) and creates a Generic
-// event for each object.
-// this is useful to create a steady stream of reconcile requests
-// when some of the changes cannot be models in Watches.
-type periodicSource struct {
-	client   client.Client
-	duration time.Duration
-
-	logger log.FieldLogger
-}
-
-func newPeriodicSource(c client.Client, d time.Duration, logger log.FieldLogger) *periodicSource {
-	return &periodicSource{
-		client:   c,
-		duration: d,
-		logger:   logger,
-	}
-}
-
-// Start is internal and should be called only by the Controller to register an EventHandler with the Informer
-// to enqueue reconcile.Requests.
-func (ps *periodicSource) Start(ctx context.Context,
-	handler handler.EventHandler,
-	queue workqueue.RateLimitingInterface,
-	prcts ...predicate.Predicate) error {
-
-	go wait.JitterUntilWithContext(ctx, ps.syncFunc(handler, queue, prcts...), ps.duration, 0.1, true)
-	return nil
-}
-
-func (ps *periodicSource) syncFunc(handler handler.EventHandler,
-	queue workqueue.RateLimitingInterface,
-	prcts ...predicate.Predicate) func(context.Context) {
-
-	return func(ctx context.Context) {
-		mpList := &hivev1.MachinePoolList{}
-		err := ps.client.List(ctx, mpList)
-		if err != nil {
-			ps.logger.WithError(err).Error("failed to list MachinePools")
-			return
-		}
-
-		for idx := range mpList.Items {
-			evt := event.GenericEvent{Object: &mpList.Items[idx]}
-
-			shouldHandle := true
-			for _, p := range prcts {
-				if !p.Generic(evt) {
-					shouldHandle = false
-				}
-			}
+// machinePoolChangedPredicate filters out updates of MachinePools which change neither their spec nor their metadata.
+var machinePoolChangedPredicate = predicate.Or(
+	predicate.GenerationChangedPredicate{},
+	predicate.LabelChangedPredicate{},
+	predicate.AnnotationChangedPredicate{},
+	predicate.Funcs{UpdateFunc: isDeletionUpdateEvent},
+)
 
-			if shouldHandle {
-				handler.Generic(evt, queue)
-			}
-		}
+// isDeletionUpdateEvent returns true when the deletion timestamp or the finalizers of the MachinePool changed.
+func isDeletionUpdateEvent(evt event.UpdateEvent) bool {
+	if evt.ObjectOld == nil || evt.ObjectNew == nil {
+		return false
 	}
-}
-
-func (ps *periodicSource) String() string {
-	return fmt.Sprintf("periodic source for MachinePools every %s", ps.duration)
+	return !reflect.DeepEqual(evt.ObjectOld.GetDeletionTimestamp(), evt.ObjectNew.GetDeletionTimestamp()) ||
+		!reflect.DeepEqual(evt.ObjectOld.GetFinalizers(), evt.ObjectNew.GetFinalizers())
 }
 
 // IsErrorUpdateEvent returns true when the update event for MachinePool is from
@@ -6,10 +6,11 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 
-	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +18,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -25,6 +27,7 @@ import (
 	awsprovider "sigs.k8s.io/cluster-api-provider-aws/pkg/apis/awsprovider/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	machineapi "github.com/openshift/api/machine/v1beta1"
@@ -36,20 +39,20 @@ import (
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/machinepool/mock"
+	"github.com/openshift/hive/pkg/machinesetgen"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 )
 
 const (
-	testName            = "foo"
-	testNamespace       = "default"
-	testClusterID       = "foo-12345-uuid"
-	testInfraID         = "foo-12345"
-	machineAPINamespace = "openshift-machine-api"
-	testAMI             = "ami-totallyfake"
-	testRegion          = "test-region"
-	testPoolName        = "worker"
-	testInstanceType    = "test-instance-type"
+	testName         = "foo"
+	testNamespace    = "default"
+	testClusterID    = "foo-12345-uuid"
+	testInfraID      = "foo-12345"
+	testAMI          = "ami-totallyfake"
+	testRegion       = "test-region"
+	testPoolName     = "worker"
+	testInstanceType = "test-instance-type"
 )
 
 func init() {
@@ -110,6 +113,7 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 		clusterDeployment    *hivev1.ClusterDeployment
 		machinePool          *hivev1.MachinePool
 		remoteExisting       []runtime.Object
+		remoteConflicts      map[string]string
 		generatedMachineSets []*machineapi.MachineSet
 		actuatorDoNotProceed bool
 		expectErr            bool
@@ -119,6 +123,10 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 		expectedRemoteMachineSets        []*machineapi.MachineSet
 		expectedRemoteMachineAutoscalers []autoscalingv1beta1.MachineAutoscaler
 		expectedRemoteClusterAutoscalers []autoscalingv1.ClusterAutoscaler
+		expectedStatusMachineSets        []string
+		expectDeletionProtected          bool
+		expectMachineSetConflict         bool
+		expectRequeue                    bool
 	}{
 		{
 			name: "Cluster not installed yet",
@@ -127,7 +135,8 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				cd.Spec.Installed = false
 				return cd
 			}(),
-			machinePool: testMachinePool(),
+			machinePool:   testMachinePool(),
+			expectRequeue: true,
 		},
 		{
 			name:              "No-op",
@@ -155,6 +164,7 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 			clusterDeployment:    testClusterDeployment(),
 			machinePool:          testMachinePool(),
 			actuatorDoNotProceed: true,
+			expectRequeue:        true,
 			remoteExisting: []runtime.Object{
 				testMachine("master1", "master"),
 				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
@@ -193,6 +203,78 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 1),
 			},
 		},
+		{
+			name:              "Leave conflicting machine set",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+			},
+			remoteConflicts: map[string]string{".spec.replicas": "kubectl-edit"},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+			},
+			expectMachineSetConflict: true,
+		},
+		{
+			name:              "Force conflicting machine set",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Annotations = map[string]string{hivev1.MachinePoolForceApplyAnnotation: "true"}
+				return pool
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+			},
+			remoteConflicts: map[string]string{".spec.replicas": "kubectl-edit"},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 1),
+			},
+		},
+		{
+			name:              "Apply new machine pool generation",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Generation = 2
+				return pool
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				func() *machineapi.MachineSet {
+					ms := testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 1)
+					ms.Annotations[constants.MachinePoolGenerationAnnotation] = "2"
+					return ms
+				}(),
+			},
+		},
 		{
 			name:              "Create missing machine set",
 			clusterDeployment: testClusterDeployment(),
@@ -255,6 +337,28 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 1, 0),
 			},
 		},
+		{
+			name:              "Create zero replica machine sets on cluster without workers",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Replicas = pointer.Int64Ptr(0)
+				return pool
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 0, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 0, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 0, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 0, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 0, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", false, 0, 0),
+			},
+		},
 		{
 			name:              "Other machinesets ignored",
 			clusterDeployment: testClusterDeployment(),
@@ -648,6 +752,181 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				*testClusterAutoscaler("1"),
 			},
 		},
+		{
+			name:              "Keep protected machine set",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testProtectedMachineSet("foo-12345-worker-us-east-1c", "worker", 1),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", false, 1, 0),
+				testProtectedMachineSet("foo-12345-worker-us-east-1c", "worker", 1),
+			},
+			expectDeletionProtected: true,
+		},
+		{
+			name:              "Keep finalizer of deleted machinepool with protected machine autoscaler",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				mp := testAutoscalingMachinePool(3, 5)
+				now := metav1.Now()
+				mp.DeletionTimestamp = &now
+				return mp
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testClusterAutoscaler("1"),
+				func() runtime.Object {
+					ma := testMachineAutoscaler("foo-12345-worker-us-east-1a", "1", 1, 2)
+					ma.Annotations = map[string]string{constants.ProtectedDeleteAnnotation: "true"}
+					return ma
+				}(),
+			},
+			expectedRemoteMachineAutoscalers: []autoscalingv1beta1.MachineAutoscaler{
+				func() autoscalingv1beta1.MachineAutoscaler {
+					ma := testMachineAutoscaler("foo-12345-worker-us-east-1a", "1", 1, 2)
+					ma.Annotations = map[string]string{constants.ProtectedDeleteAnnotation: "true"}
+					return *ma
+				}(),
+			},
+			expectedRemoteClusterAutoscalers: []autoscalingv1.ClusterAutoscaler{
+				*testClusterAutoscaler("1"),
+			},
+			expectDeletionProtected: true,
+		},
+		{
+			name:              "Observe machine sets of externally managed machinepool",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testExternallyManagedMachinePool(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testExternalMachineSet("external-us-east-1a", 2),
+				testExternalMachineSet("external-us-east-1b", 3),
+				testMachineSet("foo-12345-other-us-east-1a", "other", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testExternalMachineSet("external-us-east-1a", 2),
+				testExternalMachineSet("external-us-east-1b", 3),
+				testMachineSet("foo-12345-other-us-east-1a", "other", false, 1, 0),
+			},
+			expectedStatusMachineSets: []string{"external-us-east-1a", "external-us-east-1b"},
+		},
+		{
+			name:              "Leave machine sets of paused machinepool untouched",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				mp := testMachinePool()
+				mp.Spec.Paused = true
+				return mp
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+				testMachineSet("foo-12345-other-us-east-1a", "other", true, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1b", "worker", true, 1, 0),
+				testMachineSet("foo-12345-worker-us-east-1c", "worker", true, 0, 0),
+				testMachineSet("foo-12345-other-us-east-1a", "other", true, 1, 0),
+			},
+			expectedStatusMachineSets: []string{"foo-12345-worker-us-east-1a", "foo-12345-worker-us-east-1b", "foo-12345-worker-us-east-1c"},
+		},
+		{
+			name:              "Adopt existing hand-made machine set",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				mp := testMachinePool()
+				mp.Spec.AdoptExisting = true
+				return mp
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				func() *machineapi.MachineSet {
+					ms := testMachineSet("foo-12345-worker-custom", "worker", false, 1, 0)
+					delete(ms.Labels, machinesetgen.MachinePoolNameLabel)
+					delete(ms.Labels, constants.HiveManagedLabel)
+					ms.Annotations = nil
+					return ms
+				}(),
+			},
+			generatedMachineSets: []*machineapi.MachineSet{
+				testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 1, 0),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				func() *machineapi.MachineSet {
+					ms := testMachineSet("foo-12345-worker-custom", "worker", false, 1, 1)
+					ms.Annotations[constants.AdoptedMachineSetAnnotation] = "worker"
+					return ms
+				}(),
+			},
+			expectedStatusMachineSets: []string{"foo-12345-worker-custom"},
+		},
+		{
+			name:              "Create machine autoscalers for externally managed machinepool",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				mp := testExternallyManagedMachinePool()
+				mp.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 5}
+				return mp
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testExternalMachineSet("external-us-east-1a", 2),
+				testExternalMachineSet("external-us-east-1b", 1),
+			},
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testExternalMachineSet("external-us-east-1a", 2),
+				testExternalMachineSet("external-us-east-1b", 1),
+			},
+			expectedRemoteMachineAutoscalers: []autoscalingv1beta1.MachineAutoscaler{
+				*testMachineAutoscaler("external-us-east-1a", "1", 2, 3),
+				*testMachineAutoscaler("external-us-east-1b", "1", 1, 2),
+			},
+			expectedRemoteClusterAutoscalers: []autoscalingv1.ClusterAutoscaler{
+				*testClusterAutoscaler("1"),
+			},
+			expectedStatusMachineSets: []string{"external-us-east-1a", "external-us-east-1b"},
+		},
+		{
+			name:              "Keep machine sets of deleted externally managed machinepool",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				mp := testExternallyManagedMachinePool()
+				mp.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 5}
+				now := metav1.Now()
+				mp.DeletionTimestamp = &now
+				return mp
+			}(),
+			remoteExisting: []runtime.Object{
+				testMachine("master1", "master"),
+				testExternalMachineSet("external-us-east-1a", 2),
+				testExternalMachineSet("external-us-east-1b", 1),
+				testClusterAutoscaler("1"),
+				testMachineAutoscaler("external-us-east-1a", "1", 2, 3),
+				testMachineAutoscaler("external-us-east-1b", "1", 1, 2),
+			},
+			expectNoFinalizer: true,
+			expectedRemoteMachineSets: []*machineapi.MachineSet{
+				testExternalMachineSet("external-us-east-1a", 2),
+				testExternalMachineSet("external-us-east-1b", 1),
+			},
+			expectedRemoteClusterAutoscalers: []autoscalingv1.ClusterAutoscaler{
+				*testClusterAutoscaler("1"),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -664,7 +943,10 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				localExisting = append(localExisting, test.machinePool)
 			}
 			fakeClient := fake.NewClientBuilder().WithRuntimeObjects(localExisting...).Build()
-			remoteFakeClient := fake.NewClientBuilder().WithRuntimeObjects(test.remoteExisting...).Build()
+			remoteFakeClient := &conflictingClient{
+				applyingClient: applyingClient{fake.NewClientBuilder().WithRuntimeObjects(test.remoteExisting...).Build()},
+				conflicts:      test.remoteConflicts,
+			}
 
 			mockCtrl := gomock.NewController(t)
 			defer mockCtrl.Finish()
@@ -687,12 +969,12 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				scheme:                        scheme.Scheme,
 				logger:                        logger,
 				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
-				actuatorBuilder: func(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, cdLog log.FieldLogger) (Actuator, error) {
+				actuatorBuilder: func(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, remoteMachineSets []machineapi.MachineSet, remoteClusterAPIClient client.Client, cdLog log.FieldLogger) (Actuator, error) {
 					return mockActuator, nil
 				},
 				expectations: controllerExpectations,
 			}
-			_, err := rcd.Reconcile(context.TODO(), reconcile.Request{
+			result, err := rcd.Reconcile(context.TODO(), reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Name:      fmt.Sprintf("%s-worker", testName),
 					Namespace: testNamespace,
@@ -710,6 +992,9 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 				t.Errorf("unexpected error: %v", err)
 				return
 			}
+			if test.expectRequeue {
+				assert.Positive(t, int64(result.RequeueAfter), "expected the machine pool to be requeued")
+			}
 
 			pool := getPool(fakeClient, "worker")
 			if test.expectNoFinalizer {
@@ -782,6 +1067,407 @@ func TestRemoteMachineSetReconcile(t *testing.T) {
 			if rCAL, err := getRCAL(remoteFakeClient); assert.NoError(t, err, "error getting cluster autoscalers") {
 				assert.ElementsMatch(t, test.expectedRemoteClusterAutoscalers, rCAL.Items, "unexpected remote cluster autoscalers")
 			}
+
+			if test.expectDeletionProtected && assert.NotNil(t, pool, "missing machinepool") {
+				cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.DeletionProtectedMachinePoolCondition)
+				if assert.NotNil(t, cond, "missing DeletionProtected condition") {
+					assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected DeletionProtected condition status")
+				}
+			}
+
+			if pool != nil {
+				cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.MachineSetConflictMachinePoolCondition)
+				if test.expectMachineSetConflict {
+					if assert.NotNil(t, cond, "missing MachineSetConflict condition") {
+						assert.Equal(t, corev1.ConditionTrue, cond.Status, "unexpected MachineSetConflict condition status")
+						assert.Contains(t, cond.Message, "machineset/foo-12345-worker-us-east-1c: .spec.replicas (kubectl-edit)", "unexpected MachineSetConflict condition message")
+					}
+				} else {
+					assert.Nil(t, cond, "unexpected MachineSetConflict condition")
+				}
+			}
+
+			if test.expectedStatusMachineSets != nil && assert.NotNil(t, pool, "missing machinepool") {
+				var names []string
+				for _, ms := range pool.Status.MachineSets {
+					names = append(names, ms.Name)
+				}
+				assert.Equal(t, test.expectedStatusMachineSets, names, "unexpected machine sets in status")
+			}
+		})
+	}
+}
+
+func TestSyncClusterAutoscaler(t *testing.T) {
+	autoscalingv1.SchemeBuilder.AddToScheme(scheme.Scheme)
+
+	settings := func() *hivev1.ClusterAutoscalerSettings {
+		return &hivev1.ClusterAutoscalerSettings{
+			ScaleDown: &hivev1.ClusterAutoscalerScaleDown{
+				DelayAfterAdd: &metav1.Duration{Duration: 5 * time.Minute},
+				UnneededTime:  &metav1.Duration{Duration: 90 * time.Second},
+			},
+			BalanceSimilarNodeGroups: pointer.BoolPtr(true),
+			ResourceLimits: &hivev1.ClusterAutoscalerResourceLimits{
+				MaxNodesTotal: pointer.Int32Ptr(20),
+				Cores:         &hivev1.ClusterAutoscalerResourceRange{Min: 8, Max: 128},
+				GPUs: []hivev1.ClusterAutoscalerGPULimit{
+					{Type: "nvidia.com/gpu", Min: 0, Max: 4},
+				},
+			},
+		}
+	}
+	tuned := func() *autoscalingv1.ClusterAutoscaler {
+		ca := testClusterAutoscaler("1")
+		ca.Spec.ScaleDown.Enabled = false
+		ca.Spec.ScaleDown.UnneededTime = pointer.StringPtr("1h")
+		ca.Spec.MaxPodGracePeriod = pointer.Int32Ptr(60)
+		return ca
+	}
+
+	cases := []struct {
+		name     string
+		settings *hivev1.ClusterAutoscalerSettings
+		existing *autoscalingv1.ClusterAutoscaler
+		expected autoscalingv1.ClusterAutoscalerSpec
+	}{
+		{
+			name:     "create without settings",
+			expected: testClusterAutoscaler("").Spec,
+		},
+		{
+			name:     "enable scale down without settings",
+			existing: tuned(),
+			expected: func() autoscalingv1.ClusterAutoscalerSpec {
+				spec := tuned().Spec
+				spec.ScaleDown.Enabled = true
+				return spec
+			}(),
+		},
+		{
+			name:     "create with settings",
+			settings: settings(),
+			expected: autoscalingv1.ClusterAutoscalerSpec{
+				ScaleDown: &autoscalingv1.ScaleDownConfig{
+					Enabled:       true,
+					DelayAfterAdd: pointer.StringPtr("5m0s"),
+					UnneededTime:  pointer.StringPtr("1m30s"),
+				},
+				BalanceSimilarNodeGroups: pointer.BoolPtr(true),
+				ResourceLimits: &autoscalingv1.ResourceLimits{
+					MaxNodesTotal: pointer.Int32Ptr(20),
+					Cores:         &autoscalingv1.ResourceRange{Min: 8, Max: 128},
+					GPUS: []autoscalingv1.GPULimit{
+						{Type: "nvidia.com/gpu", Min: 0, Max: 4},
+					},
+				},
+			},
+		},
+		{
+			name:     "update with settings",
+			settings: &hivev1.ClusterAutoscalerSettings{BalanceSimilarNodeGroups: pointer.BoolPtr(false)},
+			existing: tuned(),
+			expected: autoscalingv1.ClusterAutoscalerSpec{
+				ScaleDown:                &autoscalingv1.ScaleDownConfig{Enabled: true},
+				BalanceSimilarNodeGroups: pointer.BoolPtr(false),
+				MaxPodGracePeriod:        pointer.Int32Ptr(60),
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			cd.Spec.ClusterAutoscaler = tc.settings
+			var existing []runtime.Object
+			if tc.existing != nil {
+				existing = append(existing, tc.existing)
+			}
+			remoteFakeClient := &utilizationThresholdIgnoringClient{fake.NewClientBuilder().WithRuntimeObjects(existing...).Build()}
+			r := &ReconcileMachinePool{logger: log.WithField("controller", "machinepool")}
+
+			err := r.syncClusterAutoscaler(testAutoscalingMachinePool(3, 10), cd, remoteFakeClient, r.logger)
+			require.NoError(t, err, "unexpected error syncing cluster autoscaler")
+
+			ca := &autoscalingv1.ClusterAutoscaler{}
+			require.NoError(t, remoteFakeClient.Get(context.Background(), client.ObjectKey{Name: "default"}, ca))
+			assert.Equal(t, tc.expected, ca.Spec, "unexpected cluster autoscaler spec")
+		})
+	}
+}
+
+// utilizationThresholdIgnoringClient drops the patches of the utilization threshold, which the fake client cannot
+// apply to the typed ClusterAutoscalers it stores.
+type utilizationThresholdIgnoringClient struct {
+	client.Client
+}
+
+func (c *utilizationThresholdIgnoringClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return nil
+}
+
+func TestSyncUtilizationThreshold(t *testing.T) {
+	clusterAutoscaler := func(threshold string) *unstructured.Unstructured {
+		ca := &unstructured.Unstructured{}
+		ca.SetGroupVersionKind(autoscalingv1.SchemeGroupVersion.WithKind("ClusterAutoscaler"))
+		ca.SetName("default")
+		unstructured.SetNestedField(ca.Object, true, "spec", "scaleDown", "enabled")
+		if threshold != "" {
+			unstructured.SetNestedField(ca.Object, threshold, "spec", "scaleDown", "utilizationThreshold")
+		}
+		return ca
+	}
+
+	cases := []struct {
+		name      string
+		settings  *hivev1.ClusterAutoscalerSettings
+		existing  string
+		threshold string
+	}{
+		{
+			name:     "no settings",
+			existing: "0.7",
+			// The threshold is left alone when the ClusterAutoscaler is not managed by Hive.
+			threshold: "0.7",
+		},
+		{
+			name: "set",
+			settings: &hivev1.ClusterAutoscalerSettings{
+				ScaleDown: &hivev1.ClusterAutoscalerScaleDown{UtilizationThreshold: pointer.StringPtr("0.4")},
+			},
+			threshold: "0.4",
+		},
+		{
+			name: "update",
+			settings: &hivev1.ClusterAutoscalerSettings{
+				ScaleDown: &hivev1.ClusterAutoscalerScaleDown{UtilizationThreshold: pointer.StringPtr("0.4")},
+			},
+			existing:  "0.7",
+			threshold: "0.4",
+		},
+		{
+			name:     "clear",
+			settings: &hivev1.ClusterAutoscalerSettings{},
+			existing: "0.7",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			cd.Spec.ClusterAutoscaler = tc.settings
+			// The ClusterAutoscaler type is left out of the scheme so that the fake client keeps the unstructured
+			// threshold.
+			remoteFakeClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).
+				WithRuntimeObjects(clusterAutoscaler(tc.existing)).Build()
+			r := &ReconcileMachinePool{logger: log.WithField("controller", "machinepool")}
+
+			err := r.syncUtilizationThreshold(cd, remoteFakeClient, r.logger)
+			require.NoError(t, err, "unexpected error syncing utilization threshold")
+
+			ca := &unstructured.Unstructured{}
+			ca.SetGroupVersionKind(autoscalingv1.SchemeGroupVersion.WithKind("ClusterAutoscaler"))
+			require.NoError(t, remoteFakeClient.Get(context.Background(), client.ObjectKey{Name: "default"}, ca))
+			threshold, _, _ := unstructured.NestedString(ca.Object, "spec", "scaleDown", "utilizationThreshold")
+			assert.Equal(t, tc.threshold, threshold, "unexpected utilization threshold")
+		})
+	}
+}
+
+func TestUpdatePoolStatusReadyCondition(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	machineapi.AddToScheme(scheme.Scheme)
+
+	cases := []struct {
+		name            string
+		readyReplicas   int32
+		rollout         *hivev1.MachinePoolRolloutStatus
+		windowOpensIn   time.Duration
+		resyncInterval  string
+		autoscaling     bool
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+		expectedRequeue time.Duration
+		expectJitter    bool
+	}{
+		{
+			name:            "machines ready",
+			readyReplicas:   3,
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  "MachinesReady",
+			expectedMessage: "All machines of the machine pool are ready",
+			expectedRequeue: steadyStateResyncInterval,
+			expectJitter:    true,
+		},
+		{
+			name:            "autoscaled machines ready",
+			readyReplicas:   3,
+			autoscaling:     true,
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  "MachinesReady",
+			expectedMessage: "All machines of the machine pool are ready",
+			expectedRequeue: autoscaledResyncInterval,
+			expectJitter:    true,
+		},
+		{
+			name:            "machines not ready",
+			readyReplicas:   1,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  "MachinesNotReady",
+			expectedMessage: "1 of 3 machines of the machine pool are ready",
+			expectedRequeue: defaultResyncInterval,
+		},
+		{
+			name:            "machines not ready, resync interval override",
+			readyReplicas:   1,
+			resyncInterval:  "2m",
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  "MachinesNotReady",
+			expectedMessage: "1 of 3 machines of the machine pool are ready",
+			expectedRequeue: 2 * time.Minute,
+		},
+		{
+			name:            "rollout in progress",
+			readyReplicas:   3,
+			rollout:         &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 1, OutdatedReplicas: 2},
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  "RolloutInProgress",
+			expectedMessage: "2 machines of the machine pool remain to be replaced",
+			expectedRequeue: rolloutPollInterval,
+		},
+		{
+			name:            "rollout waiting for maintenance window",
+			readyReplicas:   3,
+			rollout:         &hivev1.MachinePoolRolloutStatus{OutdatedReplicas: 3},
+			windowOpensIn:   5 * time.Hour,
+			expectedStatus:  corev1.ConditionFalse,
+			expectedReason:  "WaitingForMaintenanceWindow",
+			expectedMessage: "3 machines of the machine pool will be replaced in the next maintenance window",
+			expectedRequeue: 5 * time.Hour,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testMachinePool()
+			pool.Generation = 2
+			if tc.autoscaling {
+				pool.Spec.Replicas = nil
+				pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 6}
+			}
+			r := &ReconcileMachinePool{Client: fake.NewFakeClient(pool)}
+			ms := testMachineSet("foo-12345-worker-us-east-1a", "worker", false, 3, 0)
+			ms.Status.ReadyReplicas = tc.readyReplicas
+			ms.Annotations[constants.MachinePoolGenerationAnnotation] = "2"
+			cd := testClusterDeployment()
+			if tc.resyncInterval != "" {
+				cd.Annotations = map[string]string{constants.MachinePoolResyncIntervalAnnotation: tc.resyncInterval}
+			}
+
+			result, err := r.updatePoolStatusForMachineSets(pool, cd, nil, []*machineapi.MachineSet{ms}, tc.rollout, tc.windowOpensIn, hivev1.MachineAPIMachineAuthority,
+				fake.NewFakeClient(), log.WithField("test", tc.name))
+			require.NoError(t, err, "unexpected error updating pool status")
+			if tc.expectJitter {
+				assert.GreaterOrEqual(t, int64(result.RequeueAfter), int64(tc.expectedRequeue), "requeue after too short")
+				assert.LessOrEqual(t, int64(result.RequeueAfter), int64(tc.expectedRequeue*11/10), "requeue after too long")
+			} else {
+				assert.Equal(t, tc.expectedRequeue, result.RequeueAfter, "unexpected requeue after")
+			}
+
+			assert.Equal(t, int64(2), pool.Status.ObservedGeneration, "unexpected observed generation")
+			if assert.Len(t, pool.Status.MachineSets, 1, "unexpected machineset statuses") {
+				assert.Equal(t, int64(2), pool.Status.MachineSets[0].AppliedGeneration, "unexpected applied generation")
+			}
+			cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.ReadyMachinePoolCondition)
+			if assert.NotNil(t, cond, "expected ready condition") {
+				assert.Equal(t, tc.expectedStatus, cond.Status, "unexpected ready condition status")
+				assert.Equal(t, tc.expectedReason, cond.Reason, "unexpected ready condition reason")
+				assert.Equal(t, tc.expectedMessage, cond.Message, "unexpected ready condition message")
+			}
+			for _, c := range pool.Status.Conditions {
+				assert.Equal(t, int64(2), c.ObservedGeneration, "unexpected observed generation of condition %s", c.Type)
+			}
+		})
+	}
+}
+
+func TestEnsureValidZoneDistribution(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	awsproviderapis.AddToScheme(scheme.Scheme)
+	machineSet := func(name, zone string) *machineapi.MachineSet {
+		ms := testMachineSet(name, "worker", false, 1, 0)
+		spec := testAWSProviderSpec()
+		spec.Placement.AvailabilityZone = zone
+		rawSpec, err := encodeAWSMachineProviderSpec(spec, scheme.Scheme)
+		require.NoError(t, err, "unexpected error encoding provider spec")
+		ms.Spec.Template.Spec.ProviderSpec.Value = rawSpec
+		return ms
+	}
+	machineSets := []*machineapi.MachineSet{
+		machineSet("foo-12345-worker-us-east-1a", "us-east-1a"),
+		machineSet("foo-12345-worker-us-east-1b", "us-east-1b"),
+	}
+	cases := []struct {
+		name             string
+		distribution     map[string]hivev1.MachinePoolZoneReplicas
+		invalidCondition bool
+		expectStop       bool
+		expectedStatus   corev1.ConditionStatus
+	}{
+		{
+			name: "no distribution",
+		},
+		{
+			name:         "valid distribution",
+			distribution: map[string]hivev1.MachinePoolZoneReplicas{"us-east-1a": {Replicas: pointer.Int32Ptr(2)}},
+		},
+		{
+			name:             "fixed distribution",
+			distribution:     map[string]hivev1.MachinePoolZoneReplicas{"us-east-1a": {Replicas: pointer.Int32Ptr(2)}},
+			invalidCondition: true,
+			expectedStatus:   corev1.ConditionFalse,
+		},
+		{
+			name:           "unknown zone",
+			distribution:   map[string]hivev1.MachinePoolZoneReplicas{"us-east-1c": {Replicas: pointer.Int32Ptr(2)}},
+			expectStop:     true,
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name:           "too many pinned replicas",
+			distribution:   map[string]hivev1.MachinePoolZoneReplicas{"us-east-1a": {Replicas: pointer.Int32Ptr(4)}},
+			expectStop:     true,
+			expectedStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "every zone pinned with too few replicas",
+			distribution: map[string]hivev1.MachinePoolZoneReplicas{
+				"us-east-1a": {Replicas: pointer.Int32Ptr(1)},
+				"us-east-1b": {Replicas: pointer.Int32Ptr(1)},
+			},
+			expectStop:     true,
+			expectedStatus: corev1.ConditionTrue,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testMachinePool()
+			pool.Spec.ZoneDistribution = tc.distribution
+			if tc.invalidCondition {
+				pool.Status.Conditions = append(pool.Status.Conditions, hivev1.MachinePoolCondition{
+					Type:   hivev1.InvalidZoneDistributionMachinePoolCondition,
+					Status: corev1.ConditionTrue,
+				})
+			}
+			r := &ReconcileMachinePool{Client: fake.NewFakeClient(pool)}
+
+			result, err := r.ensureValidZoneDistribution(pool, machineSets, log.WithField("test", tc.name))
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectStop, result != nil, "unexpected result")
+			cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.InvalidZoneDistributionMachinePoolCondition)
+			if tc.expectedStatus == "" {
+				assert.Nil(t, cond, "unexpected zone distribution condition")
+			} else if assert.NotNil(t, cond, "expected zone distribution condition") {
+				assert.Equal(t, tc.expectedStatus, cond.Status, "unexpected condition status")
+			}
 		})
 	}
 }
@@ -864,6 +1550,141 @@ Machine machine-3 failed (InsufficientResources): No available quota,
 	}
 }
 
+func Test_expectationsSatisfied(t *testing.T) {
+	cases := []struct {
+		name              string
+		setExpectations   bool
+		threshold         time.Duration
+		expectSatisfied   bool
+		expectRequeue     bool
+		expectExpectation bool
+	}{{
+		name:            "no expectations",
+		threshold:       time.Hour,
+		expectSatisfied: true,
+	}, {
+		name:              "pending expectations within threshold",
+		setExpectations:   true,
+		threshold:         time.Hour,
+		expectRequeue:     true,
+		expectExpectation: true,
+	}, {
+		name:            "pending expectations beyond threshold are reset",
+		setExpectations: true,
+		threshold:       0,
+		expectSatisfied: true,
+	}}
+
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			defer func(orig time.Duration) { expectationsStuckThreshold = orig }(expectationsStuckThreshold)
+			expectationsStuckThreshold = test.threshold
+
+			logger := log.WithField("controller", "machinepool")
+			key := types.NamespacedName{Namespace: testNamespace, Name: testName}.String()
+			r := &ReconcileMachinePool{
+				logger:       logger,
+				expectations: controllerutils.NewExpectations(logger),
+			}
+			if test.setExpectations {
+				require.NoError(t, r.expectations.ExpectCreations(key, 1))
+			}
+
+			satisfied, requeueAfter := r.expectationsSatisfied(key, logger)
+			assert.Equal(t, test.expectSatisfied, satisfied, "unexpected satisfied")
+			assert.Equal(t, test.expectRequeue, requeueAfter > 0, "unexpected requeue")
+			_, exists, err := r.expectations.GetExpectations(key)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectExpectation, exists, "unexpected expectations presence")
+		})
+	}
+}
+
+func Test_clusterDeploymentWatchHandler(t *testing.T) {
+	otherCluster := testMachinePool()
+	otherCluster.Name = "other-worker"
+	otherCluster.Spec.ClusterDeploymentRef.Name = "other"
+	otherNamespace := testMachinePool()
+	otherNamespace.Namespace = "other-namespace"
+	infra := testMachinePool()
+	infra.Name = fmt.Sprintf("%s-infra", testName)
+
+	fakeClient := fake.NewClientBuilder().WithRuntimeObjects(testMachinePool(), infra, otherCluster, otherNamespace).Build()
+	r := &ReconcileMachinePool{
+		Client: fakeClient,
+		logger: log.WithField("controller", "machinepool"),
+	}
+
+	requests := r.clusterDeploymentWatchHandler(testClusterDeployment())
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testMachinePool().Name}},
+		{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: infra.Name}},
+	}, requests, "unexpected requests")
+}
+
+func Test_machinePoolChangedPredicate(t *testing.T) {
+	cases := []struct {
+		name            string
+		update          func(*hivev1.MachinePool)
+		expectReconcile bool
+	}{
+		{
+			name: "status changed",
+			update: func(pool *hivev1.MachinePool) {
+				pool.Status.Replicas = 3
+			},
+		},
+		{
+			name: "spec changed",
+			update: func(pool *hivev1.MachinePool) {
+				pool.Spec.Replicas = pointer.Int64Ptr(5)
+				pool.Generation++
+			},
+			expectReconcile: true,
+		},
+		{
+			name: "labels changed",
+			update: func(pool *hivev1.MachinePool) {
+				pool.Labels = map[string]string{"foo": "bar"}
+			},
+			expectReconcile: true,
+		},
+		{
+			name: "annotations changed",
+			update: func(pool *hivev1.MachinePool) {
+				pool.Annotations = map[string]string{"foo": "bar"}
+			},
+			expectReconcile: true,
+		},
+		{
+			name: "deleted",
+			update: func(pool *hivev1.MachinePool) {
+				now := metav1.Now()
+				pool.DeletionTimestamp = &now
+			},
+			expectReconcile: true,
+		},
+		{
+			name: "finalizers changed",
+			update: func(pool *hivev1.MachinePool) {
+				pool.Finalizers = nil
+			},
+			expectReconcile: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldPool := testMachinePool()
+			oldPool.Generation = 1
+			newPool := oldPool.DeepCopy()
+			tc.update(newPool)
+			assert.Equal(t, tc.expectReconcile,
+				machinePoolChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldPool, ObjectNew: newPool}),
+				"unexpected predicate result")
+		})
+	}
+}
+
 func testMachinePool() *hivev1.MachinePool {
 	return &hivev1.MachinePool{
 		TypeMeta: metav1.TypeMeta{
@@ -940,6 +1761,18 @@ func testAutoscalingMachinePool(min, max int) *hivev1.MachinePool {
 	return p
 }
 
+func testExternallyManagedMachinePool() *hivev1.MachinePool {
+	p := testMachinePool()
+	p.Spec.Replicas = nil
+	p.Spec.Platform = hivev1.MachinePoolPlatform{}
+	p.Spec.Labels = nil
+	p.Spec.Taints = nil
+	p.Spec.MachineSetSelector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"owner": "external-operator"},
+	}
+	return p
+}
+
 func testAWSProviderSpec() *awsprovider.AWSMachineProviderConfig {
 	return &awsprovider.AWSMachineProviderConfig{
 		TypeMeta: metav1.TypeMeta{
@@ -1009,7 +1842,7 @@ func testMachineSet(name string, machineType string, unstompedAnnotation bool, r
 			Name:      name,
 			Namespace: machineAPINamespace,
 			Labels: map[string]string{
-				machinePoolNameLabel:                       machineType,
+				machinesetgen.MachinePoolNameLabel:         machineType,
 				"machine.openshift.io/cluster-api-cluster": testInfraID,
 				constants.HiveManagedLabel:                 "true",
 			},
@@ -1034,15 +1867,33 @@ func testMachineSet(name string, machineType string, unstompedAnnotation bool, r
 			},
 		},
 	}
+	ms.Annotations = map[string]string{
+		constants.MachinePoolGenerationAnnotation: "0",
+	}
 	// Add a pre-existing annotation which we will ensure remains in updated machinesets.
 	if unstompedAnnotation {
-		ms.Annotations = map[string]string{
-			"hive.openshift.io/unstomped": "true",
-		}
+		ms.Annotations["hive.openshift.io/unstomped"] = "true"
 	}
 	return &ms
 }
 
+// testExternalMachineSet returns a MachineSet owned by another system, as selected by testExternallyManagedMachinePool.
+func testExternalMachineSet(name string, replicas int) *machineapi.MachineSet {
+	ms := testMachineSet(name, "external", false, replicas, 0)
+	ms.Labels = map[string]string{
+		"machine.openshift.io/cluster-api-cluster": testInfraID,
+		"owner": "external-operator",
+	}
+	ms.Annotations = nil
+	return ms
+}
+
+func testProtectedMachineSet(name string, machineType string, replicas int) *machineapi.MachineSet {
+	ms := testMachineSet(name, machineType, false, replicas, 0)
+	ms.Annotations[constants.ProtectedDeleteAnnotation] = "true"
+	return ms
+}
+
 func testMachineAutoscaler(name string, resourceVersion string, min, max int) *autoscalingv1beta1.MachineAutoscaler {
 	return &autoscalingv1beta1.MachineAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1050,7 +1901,7 @@ func testMachineAutoscaler(name string, resourceVersion string, min, max int) *a
 			Name:            name,
 			ResourceVersion: resourceVersion,
 			Labels: map[string]string{
-				machinePoolNameLabel: "worker",
+				machinesetgen.MachinePoolNameLabel: "worker",
 			},
 		},
 		Spec: autoscalingv1beta1.MachineAutoscalerSpec{
@@ -51,3 +51,78 @@ func (mr *MockActuatorMockRecorder) GenerateMachineSets(arg0, arg1, arg2 interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateMachineSets", reflect.TypeOf((*MockActuator)(nil).GenerateMachineSets), arg0, arg1, arg2)
 }
+
+// MockInstanceTagReconciler is a mock of InstanceTagReconciler interface.
+type MockInstanceTagReconciler struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstanceTagReconcilerMockRecorder
+}
+
+// MockInstanceTagReconcilerMockRecorder is the mock recorder for MockInstanceTagReconciler.
+type MockInstanceTagReconcilerMockRecorder struct {
+	mock *MockInstanceTagReconciler
+}
+
+// NewMockInstanceTagReconciler creates a new mock instance.
+func NewMockInstanceTagReconciler(ctrl *gomock.Controller) *MockInstanceTagReconciler {
+	mock := &MockInstanceTagReconciler{ctrl: ctrl}
+	mock.recorder = &MockInstanceTagReconcilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInstanceTagReconciler) EXPECT() *MockInstanceTagReconcilerMockRecorder {
+	return m.recorder
+}
+
+// ReconcileInstanceTags mocks base method.
+func (m *MockInstanceTagReconciler) ReconcileInstanceTags(arg0 *v1.ClusterDeployment, arg1 *v1.MachinePool, arg2 []v1beta1.Machine, arg3 logrus.FieldLogger) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileInstanceTags", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReconcileInstanceTags indicates an expected call of ReconcileInstanceTags.
+func (mr *MockInstanceTagReconcilerMockRecorder) ReconcileInstanceTags(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileInstanceTags", reflect.TypeOf((*MockInstanceTagReconciler)(nil).ReconcileInstanceTags), arg0, arg1, arg2, arg3)
+}
+
+// MockMachineSetInfrastructureReporter is a mock of MachineSetInfrastructureReporter interface.
+type MockMachineSetInfrastructureReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockMachineSetInfrastructureReporterMockRecorder
+}
+
+// MockMachineSetInfrastructureReporterMockRecorder is the mock recorder for MockMachineSetInfrastructureReporter.
+type MockMachineSetInfrastructureReporterMockRecorder struct {
+	mock *MockMachineSetInfrastructureReporter
+}
+
+// NewMockMachineSetInfrastructureReporter creates a new mock instance.
+func NewMockMachineSetInfrastructureReporter(ctrl *gomock.Controller) *MockMachineSetInfrastructureReporter {
+	mock := &MockMachineSetInfrastructureReporter{ctrl: ctrl}
+	mock.recorder = &MockMachineSetInfrastructureReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMachineSetInfrastructureReporter) EXPECT() *MockMachineSetInfrastructureReporterMockRecorder {
+	return m.recorder
+}
+
+// MachineSetInfrastructure mocks base method.
+func (m *MockMachineSetInfrastructureReporter) MachineSetInfrastructure(arg0 *v1beta1.MachineSet) (*v1.MachineSetInfrastructure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MachineSetInfrastructure", arg0)
+	ret0, _ := ret[0].(*v1.MachineSetInfrastructure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MachineSetInfrastructure indicates an expected call of MachineSetInfrastructure.
+func (mr *MockMachineSetInfrastructureReporterMockRecorder) MachineSetInfrastructure(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MachineSetInfrastructure", reflect.TypeOf((*MockMachineSetInfrastructureReporter)(nil).MachineSetInfrastructure), arg0)
+}
@@ -0,0 +1,114 @@
+package machinepool
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/machinesetgen"
+)
+
+const machineTypeLabel = "machine.openshift.io/cluster-api-machine-type"
+
+// syncPropagatedNodeLabels applies the labels the ClusterDeployment propagates to its nodes to the existing Machines
+// of the pool, whose MachineSets only label new Machines, and to the master Machines of the cluster, which belong to
+// no pool. The machine API copies the labels of the Machines to their nodes.
+func (r *ReconcileMachinePool) syncPropagatedNodeLabels(
+	pool *hivev1.MachinePool,
+	cd *hivev1.ClusterDeployment,
+	machineSets []*machineapi.MachineSet,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) error {
+	if pool.DeletionTimestamp != nil {
+		return nil
+	}
+	machines := &machineapi.MachineList{}
+	if err := remoteClusterAPIClient.List(context.TODO(), machines, client.InNamespace(machineAPINamespace)); err != nil {
+		return errors.Wrap(err, "failed to list machines")
+	}
+	poolMachineSets := make(map[string]bool, len(machineSets))
+	for _, ms := range machineSets {
+		poolMachineSets[ms.Name] = true
+	}
+
+	nodeLabels := machinesetgen.PropagatedNodeLabels(cd)
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+		// The labels of the pool take precedence over the labels of the ClusterDeployment on the machines of the pool.
+		var poolLabels map[string]string
+		switch {
+		case machine.Labels[machineTypeLabel] == "master":
+		case poolMachineSets[machine.Labels[machineSetLabel]]:
+			poolLabels = pool.Spec.Labels
+		default:
+			continue
+		}
+		if !propagateNodeLabels(machine, nodeLabels, poolLabels) {
+			continue
+		}
+		machineLog := logger.WithField("machine", machine.Name)
+		if err := remoteClusterAPIClient.Update(context.TODO(), machine); err != nil {
+			return errors.Wrapf(err, "failed to update labels of machine %s", machine.Name)
+		}
+		machineLog.Info("updated labels propagated from the cluster deployment")
+	}
+	return nil
+}
+
+// propagateNodeLabels sets the node labels of the Machine, except those in the labels of its pool, and removes the
+// labels it was previously given which are no longer propagated. Returns true if the Machine was changed.
+func propagateNodeLabels(machine *machineapi.Machine, nodeLabels, poolLabels map[string]string) bool {
+	changed := false
+	labels := machine.Spec.ObjectMeta.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	previous := machine.Annotations[constants.PropagatedNodeLabelsAnnotation]
+	if previous != "" {
+		for _, key := range strings.Split(previous, ",") {
+			_, propagated := nodeLabels[key]
+			_, inPool := poolLabels[key]
+			if _, ok := labels[key]; ok && !propagated && !inPool {
+				delete(labels, key)
+				changed = true
+			}
+		}
+	}
+	var applied []string
+	for key, value := range nodeLabels {
+		if _, inPool := poolLabels[key]; inPool {
+			continue
+		}
+		applied = append(applied, key)
+		if current, ok := labels[key]; !ok || current != value {
+			labels[key] = value
+			changed = true
+		}
+	}
+	sort.Strings(applied)
+	if keys := strings.Join(applied, ","); keys != previous {
+		if keys == "" {
+			delete(machine.Annotations, constants.PropagatedNodeLabelsAnnotation)
+		} else {
+			if machine.Annotations == nil {
+				machine.Annotations = map[string]string{}
+			}
+			machine.Annotations[constants.PropagatedNodeLabelsAnnotation] = keys
+		}
+		changed = true
+	}
+	if len(labels) > 0 {
+		machine.Spec.ObjectMeta.Labels = labels
+	}
+	return changed
+}
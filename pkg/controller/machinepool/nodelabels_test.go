@@ -0,0 +1,125 @@
+package machinepool
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestSyncPropagatedNodeLabels(t *testing.T) {
+	scheme := runtime.NewScheme()
+	machineapi.AddToScheme(scheme)
+
+	machine := func(name, machineType, machineSet string, labels map[string]string, propagated string) *machineapi.Machine {
+		m := &machineapi.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: machineAPINamespace,
+				Name:      name,
+				Labels:    map[string]string{machineTypeLabel: machineType},
+			},
+			Spec: machineapi.MachineSpec{
+				ObjectMeta: machineapi.ObjectMeta{Labels: labels},
+			},
+		}
+		if machineSet != "" {
+			m.Labels[machineSetLabel] = machineSet
+		}
+		if propagated != "" {
+			m.Annotations = map[string]string{constants.PropagatedNodeLabelsAnnotation: propagated}
+		}
+		return m
+	}
+
+	tests := []struct {
+		name              string
+		propagated        []string
+		machines          []runtime.Object
+		expectLabels      map[string]map[string]string
+		expectAnnotations map[string]string
+	}{
+		{
+			name:       "propagate to masters and pool machines",
+			propagated: []string{"environment", "foo", "missing"},
+			machines: []runtime.Object{
+				machine("master-0", "master", "", nil, ""),
+				machine("worker-a", testPoolName, "foo-worker-a", map[string]string{"existing": "label"}, ""),
+				machine("other-a", "other", "foo-other-a", nil, ""),
+			},
+			expectLabels: map[string]map[string]string{
+				"master-0": {"environment": "prod", "foo": "cluster"},
+				// The labels of the pool take precedence.
+				"worker-a": {"environment": "prod", "existing": "label"},
+				"other-a":  nil,
+			},
+			expectAnnotations: map[string]string{
+				"master-0": "environment,foo",
+				"worker-a": "environment",
+				"other-a":  "",
+			},
+		},
+		{
+			name:       "remove labels no longer propagated",
+			propagated: []string{"environment"},
+			machines: []runtime.Object{
+				machine("master-0", "master", "", map[string]string{"environment": "dev", "tenant": "a", "manual": "label"}, "environment,tenant"),
+			},
+			expectLabels: map[string]map[string]string{
+				"master-0": {"environment": "prod", "manual": "label"},
+			},
+			expectAnnotations: map[string]string{
+				"master-0": "environment",
+			},
+		},
+		{
+			name: "stop propagating",
+			machines: []runtime.Object{
+				machine("master-0", "master", "", map[string]string{"environment": "prod"}, "environment"),
+			},
+			expectLabels: map[string]map[string]string{
+				"master-0": {},
+			},
+			expectAnnotations: map[string]string{
+				"master-0": "",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			cd.Labels["environment"] = "prod"
+			cd.Labels["tenant"] = "a"
+			cd.Labels["foo"] = "cluster"
+			cd.Spec.PropagatedNodeLabels = test.propagated
+			pool := testMachinePool()
+			pool.Spec.Labels = map[string]string{"foo": "bar"}
+			machineSets := []*machineapi.MachineSet{testMachineSet("foo-worker-a", testPoolName, false, 1, 0)}
+			remoteClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(test.machines...).Build()
+
+			r := &ReconcileMachinePool{}
+			require.NoError(t, r.syncPropagatedNodeLabels(pool, cd, machineSets, remoteClient, log.WithField("test", test.name)))
+
+			for name, expected := range test.expectLabels {
+				m := &machineapi.Machine{}
+				require.NoError(t, remoteClient.Get(context.TODO(), client.ObjectKey{Namespace: machineAPINamespace, Name: name}, m))
+				if len(expected) == 0 {
+					assert.Empty(t, m.Spec.ObjectMeta.Labels, "unexpected labels on machine %s", name)
+				} else {
+					assert.Equal(t, expected, m.Spec.ObjectMeta.Labels, "unexpected labels on machine %s", name)
+				}
+				assert.Equal(t, test.expectAnnotations[name], m.Annotations[constants.PropagatedNodeLabelsAnnotation], "unexpected annotation on machine %s", name)
+			}
+		})
+	}
+}
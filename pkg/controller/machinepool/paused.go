@@ -0,0 +1,118 @@
+package machinepool
+
+import (
+	"context"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// reconcilePausedPool syncs a paused machine pool. Its MachineSets in the remote cluster are only observed to report
+// them in the status of the pool, while nothing in the remote cluster is created, updated or deleted.
+func (r *ReconcileMachinePool) reconcilePausedPool(
+	pool *hivev1.MachinePool,
+	cd *hivev1.ClusterDeployment,
+	remoteClusterAPIClient client.Client,
+	logger log.FieldLogger,
+) (reconcile.Result, error) {
+	logger = logger.WithField("paused", true)
+	logger.Info("machine pool is paused, only updating its status")
+
+	if err := r.setPausedCondition(pool, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	remoteMachineSets, err := r.getRemoteMachineSets(remoteClusterAPIClient, logger)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not getRemoteMachineSets")
+		return reconcile.Result{}, err
+	}
+
+	authority, err := detectMachineAuthority(cd, remoteClusterAPIClient, logger)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not detectMachineAuthority")
+		return reconcile.Result{}, err
+	}
+	logger = logger.WithField("authoritativeAPI", authority)
+
+	var machineSets []*machineapi.MachineSet
+	if pool.Spec.MachineSetSelector != nil {
+		if machineSets, err = selectMachineSets(pool, remoteMachineSets); err != nil {
+			logger.WithError(err).Error("could not select machine sets")
+			return reconcile.Result{}, err
+		}
+	} else {
+		machineSets = poolMachineSets(cd, pool, remoteMachineSets)
+	}
+
+	result, err := r.updatePoolStatusForMachineSets(pool, cd, nil, machineSets, nil, 0, authority, remoteClusterAPIClient, logger)
+	if err != nil {
+		return result, err
+	}
+	return result, r.updateClusterDeploymentSummary(cd, pool, logger)
+}
+
+// poolMachineSets returns the remote MachineSets controlled by the machine pool, sorted by name.
+func poolMachineSets(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, remoteMachineSets *machineapi.MachineSetList) []*machineapi.MachineSet {
+	machineSets := []*machineapi.MachineSet{}
+	for i := range remoteMachineSets.Items {
+		ms := &remoteMachineSets.Items[i]
+		if ms.Namespace != machineAPINamespace || !isControlledByMachinePool(cd, pool, ms) {
+			continue
+		}
+		machineSets = append(machineSets, ms.DeepCopy())
+	}
+	sort.Slice(machineSets, func(i, j int) bool { return machineSets[i].Name < machineSets[j].Name })
+	return machineSets
+}
+
+// setPausedCondition sets the Paused condition of a paused machine pool.
+func (r *ReconcileMachinePool) setPausedCondition(pool *hivev1.MachinePool, logger log.FieldLogger) error {
+	conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.PausedMachinePoolCondition,
+		corev1.ConditionTrue,
+		"Paused",
+		"The machine pool is paused, its resources in the cluster are not synced",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	pool.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), pool); err != nil {
+		logger.WithError(err).Error("failed to update MachinePool conditions")
+		return err
+	}
+	return nil
+}
+
+// clearPausedCondition clears the Paused condition of a machine pool which is no longer paused. The condition is only
+// added to machine pools which have been paused.
+func (r *ReconcileMachinePool) clearPausedCondition(pool *hivev1.MachinePool, logger log.FieldLogger) error {
+	if cond := controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.PausedMachinePoolCondition); cond == nil || cond.Status != corev1.ConditionTrue {
+		return nil
+	}
+	pool.Status.Conditions = controllerutils.SetMachinePoolCondition(
+		pool.Status.Conditions,
+		hivev1.PausedMachinePoolCondition,
+		corev1.ConditionFalse,
+		"Unpaused",
+		"The machine pool is not paused",
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if err := r.Status().Update(context.Background(), pool); err != nil {
+		logger.WithError(err).Error("failed to update MachinePool conditions")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package machinepool
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// isDeleteProtected returns true if admins of the remote cluster have annotated the object so that Hive does not
+// delete it.
+func isDeleteProtected(obj metav1.Object) bool {
+	protected, err := strconv.ParseBool(obj.GetAnnotations()[constants.ProtectedDeleteAnnotation])
+	return protected && err == nil
+}
+
+// setDeletionProtectedCondition reports the remote objects of the machine pool which were not deleted because they
+// are protected. The condition is only added to the pool once objects have been protected.
+func (r *ReconcileMachinePool) setDeletionProtectedCondition(pool *hivev1.MachinePool, protected []string, logger log.FieldLogger) error {
+	status, reason, message := corev1.ConditionFalse, "NoProtectedResources", "No protected remote resources are kept"
+	if len(protected) > 0 {
+		status, reason = corev1.ConditionTrue, "ProtectedResourcesKept"
+		message = fmt.Sprintf("Remote resources annotated with %s=true were not deleted: %s",
+			constants.ProtectedDeleteAnnotation, strings.Join(protected, ", "))
+	} else if controllerutils.FindMachinePoolCondition(pool.Status.Conditions, hivev1.DeletionProtectedMachinePoolCondition) == nil {
+		return nil
+	}
+	conds, changed := controllerutils.SetMachinePoolConditionWithChangeCheck(
+		pool.Status.Conditions,
+		hivev1.DeletionProtectedMachinePoolCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	pool.Status.Conditions = conds
+	if err := r.Status().Update(context.Background(), pool); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update DeletionProtected condition")
+		return errors.Wrap(err, "could not update MachinePool status")
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package machinepool
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	autoscalingv1 "github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/machinesetgen"
+)
+
+// Render returns the objects Hive syncs to the cluster for the MachinePool, generated without contacting the hub, the
+// cluster or the cloud: the MachineSets, the MachineAutoscalers and default ClusterAutoscaler of auto-scaling pools,
+// and the machine config resources of pools with node config.
+//
+// As the cloud is not consulted, only some platforms can be rendered. AWS pools must list their zones and must not
+// list subnets, and need an AMI from the pool or from the master machine of the cluster. vSphere and oVirt pools need
+// the master machine of the cluster. MachinePools of fake clusters are rendered with the fake actuator. Other
+// platforms need the cloud to generate their MachineSets. Externally managed pools cannot be rendered, as the
+// MachineSets they select only exist in the cluster.
+func Render(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, masterMachine *machineapi.Machine, logger log.FieldLogger) ([]runtime.Object, error) {
+	if pool.Spec.MachineSetSelector != nil {
+		return nil, errors.New("externally managed MachinePools select MachineSets of the cluster, and cannot be rendered")
+	}
+	scheme, err := renderScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	// The actuators record conditions in the status of the pool. The hub is replaced by an in-memory client so that
+	// these updates go nowhere.
+	hubClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pool.DeepCopy()).Build()
+	key := client.ObjectKey{Namespace: pool.Namespace, Name: pool.Name}
+	pool = &hivev1.MachinePool{}
+	if err := hubClient.Get(context.TODO(), key, pool); err != nil {
+		return nil, err
+	}
+
+	actuator, err := newOfflineActuator(hubClient, cd, pool, masterMachine, scheme, logger)
+	if err != nil {
+		return nil, err
+	}
+	machineSets, proceed, err := machinesetgen.Generate(actuator, cd, pool, nil, logger)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate MachineSets")
+	}
+	if !proceed {
+		return nil, errors.New("MachineSets cannot be generated yet for the MachinePool")
+	}
+
+	var objects []runtime.Object
+	for _, ms := range machineSets {
+		objects = append(objects, ms)
+	}
+	if pool.Spec.Autoscaling != nil {
+		if pool.Spec.Autoscaling.MinReplicas < int32(len(machineSets)) && !platformAllowsZeroAutoscalingMinReplicas(cd) {
+			return nil, fmt.Errorf("when auto-scaling, the MachinePool must have at least one replica for each MachineSet. The minReplicas must be at least %d", len(machineSets))
+		}
+		for i, ms := range machineSets {
+			minReplicas, maxReplicas := machinesetgen.MinMaxReplicas(pool, machineSets, i)
+			objects = append(objects, generateMachineAutoscaler(pool, ms, minReplicas, maxReplicas))
+		}
+		clusterAutoscaler, err := renderClusterAutoscaler(cd)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not render cluster autoscaler")
+		}
+		objects = append(objects, clusterAutoscaler)
+	}
+	machineConfigResources, err := generateMachineConfigResources(pool)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate machine config resources")
+	}
+	for _, obj := range machineConfigResources {
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// renderClusterAutoscaler returns the default ClusterAutoscaler of the cluster. It is rendered as an unstructured
+// object when the ClusterDeployment has a utilization threshold, which the vendored ClusterAutoscaler API lacks.
+func renderClusterAutoscaler(cd *hivev1.ClusterDeployment) (runtime.Object, error) {
+	ca := generateClusterAutoscaler(cd)
+	threshold := clusterAutoscalerUtilizationThreshold(cd)
+	if threshold == nil {
+		return ca, nil
+	}
+	ca.SetGroupVersionKind(autoscalingv1.SchemeGroupVersion.WithKind("ClusterAutoscaler"))
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(ca)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	if err := unstructured.SetNestedField(u.Object, *threshold, "spec", "scaleDown", "utilizationThreshold"); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// newOfflineActuator returns an actuator generating the MachineSets of the pool without contacting the cloud.
+func newOfflineActuator(
+	hubClient client.Client,
+	cd *hivev1.ClusterDeployment,
+	pool *hivev1.MachinePool,
+	masterMachine *machineapi.Machine,
+	scheme *runtime.Scheme,
+	logger log.FieldLogger,
+) (Actuator, error) {
+	switch {
+	case useFakeActuator(cd, pool):
+		return NewFakeActuator(logger), nil
+	case cd.Spec.Platform.AWS != nil:
+		return newOfflineAWSActuator(hubClient, cd, pool, masterMachine, scheme, logger)
+	case cd.Spec.Platform.VSphere != nil:
+		if masterMachine == nil {
+			return nil, errors.New("the master machine of the cluster is needed to render vSphere MachineSets")
+		}
+		return NewVSphereActuator(masterMachine, scheme, logger)
+	case cd.Spec.Platform.Ovirt != nil:
+		if masterMachine == nil {
+			return nil, errors.New("the master machine of the cluster is needed to render oVirt MachineSets")
+		}
+		return NewOvirtActuator(masterMachine, scheme, logger)
+	default:
+		return nil, errors.New("MachineSets of the platform of the cluster cannot be rendered without the cloud")
+	}
+}
+
+// newOfflineAWSActuator returns an AWSActuator without an AWS client, for pools whose MachineSets can be generated
+// without looking up zones and subnets.
+func newOfflineAWSActuator(
+	hubClient client.Client,
+	cd *hivev1.ClusterDeployment,
+	pool *hivev1.MachinePool,
+	masterMachine *machineapi.Machine,
+	scheme *runtime.Scheme,
+	logger log.FieldLogger,
+) (*AWSActuator, error) {
+	platform := pool.Spec.Platform.AWS
+	if platform == nil {
+		return nil, errors.New("MachinePool is not for AWS")
+	}
+	if len(platform.Subnets) > 0 {
+		return nil, errors.New("the zones of the subnets of the MachinePool cannot be looked up to render its MachineSets")
+	}
+	userProvidedNetwork := cd.Status.Platform != nil && cd.Status.Platform.AWS != nil &&
+		cd.Status.Platform.AWS.UserProvidedNetwork != nil
+	if len(platform.Zones) == 0 && !userProvidedNetwork {
+		return nil, errors.New("the MachinePool must list its zones to render its MachineSets")
+	}
+	amiID := platform.AMIID
+	if amiID == "" {
+		amiID = pool.Annotations[hivev1.MachinePoolImageIDOverrideAnnotation]
+	}
+	if amiID == "" {
+		if masterMachine == nil {
+			return nil, errors.New("the MachinePool must specify an AMI, or the master machine of the cluster is needed, to render its MachineSets")
+		}
+		var err error
+		if amiID, err = getAWSAMIID(masterMachine, scheme, logger); err != nil {
+			return nil, err
+		}
+	}
+	return &AWSActuator{
+		client: hubClient,
+		logger: logger,
+		region: cd.Spec.Platform.AWS.Region,
+		amiID:  amiID,
+	}, nil
+}
+
+func renderScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		corev1.AddToScheme,
+		hivev1.AddToScheme,
+		machineapi.Install,
+		addAWSProviderToScheme,
+		addOvirtProviderToScheme,
+		addVSphereProviderToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			return nil, err
+		}
+	}
+	return scheme, nil
+}
@@ -0,0 +1,192 @@
+package machinepool
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	autoscalingv1 "github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1"
+	autoscalingv1beta1 "github.com/openshift/cluster-autoscaler-operator/pkg/apis/autoscaling/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
+)
+
+func TestRender(t *testing.T) {
+	zones := func(pool *hivev1.MachinePool) *hivev1.MachinePool {
+		pool.Spec.Platform.AWS.Zones = []string{"zone1", "zone2", "zone3"}
+		return pool
+	}
+
+	tests := []struct {
+		name                       string
+		clusterDeployment          *hivev1.ClusterDeployment
+		machinePool                *hivev1.MachinePool
+		masterMachine              *machineapi.Machine
+		expectError                bool
+		expectMachineSets          int
+		expectMachineAutoscalers   int
+		expectClusterAutoscaler    bool
+		expectUtilizationThreshold string
+		expectMachineSetReplicas   []int32
+		expectAutoscalerMinMaxSum  [2]int32
+	}{
+		{
+			name:                     "aws with master machine",
+			clusterDeployment:        testClusterDeployment(),
+			machinePool:              zones(testMachinePool()),
+			masterMachine:            testMachine("master0", "master"),
+			expectMachineSets:        3,
+			expectMachineSetReplicas: []int32{1, 1, 1},
+		},
+		{
+			name:              "aws with pool AMI",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				p := zones(testMachinePool())
+				p.Spec.Platform.AWS.AMIID = testAMI
+				return p
+			}(),
+			expectMachineSets:        3,
+			expectMachineSetReplicas: []int32{1, 1, 1},
+		},
+		{
+			name:                      "aws autoscaling",
+			clusterDeployment:         testClusterDeployment(),
+			machinePool:               zones(testAutoscalingMachinePool(3, 10)),
+			masterMachine:             testMachine("master0", "master"),
+			expectMachineSets:         3,
+			expectMachineAutoscalers:  3,
+			expectClusterAutoscaler:   true,
+			expectAutoscalerMinMaxSum: [2]int32{3, 10},
+		},
+		{
+			name: "aws autoscaling with utilization threshold",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				threshold := "0.4"
+				cd.Spec.ClusterAutoscaler = &hivev1.ClusterAutoscalerSettings{
+					ScaleDown: &hivev1.ClusterAutoscalerScaleDown{UtilizationThreshold: &threshold},
+				}
+				return cd
+			}(),
+			machinePool:                zones(testAutoscalingMachinePool(3, 10)),
+			masterMachine:              testMachine("master0", "master"),
+			expectMachineSets:          3,
+			expectMachineAutoscalers:   3,
+			expectClusterAutoscaler:    true,
+			expectUtilizationThreshold: "0.4",
+			expectAutoscalerMinMaxSum:  [2]int32{3, 10},
+		},
+		{
+			name:                      "aws autoscaling with fewer replicas than zones",
+			clusterDeployment:         testClusterDeployment(),
+			machinePool:               zones(testAutoscalingMachinePool(1, 10)),
+			masterMachine:             testMachine("master0", "master"),
+			expectMachineSets:         3,
+			expectMachineAutoscalers:  3,
+			expectClusterAutoscaler:   true,
+			expectAutoscalerMinMaxSum: [2]int32{1, 10},
+		},
+		{
+			name:              "aws without zones",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testMachinePool(),
+			masterMachine:     testMachine("master0", "master"),
+			expectError:       true,
+		},
+		{
+			name:              "aws with subnets",
+			clusterDeployment: testClusterDeployment(),
+			machinePool: func() *hivev1.MachinePool {
+				p := zones(testMachinePool())
+				p.Spec.Platform.AWS.Subnets = []string{"subnet-1"}
+				return p
+			}(),
+			masterMachine: testMachine("master0", "master"),
+			expectError:   true,
+		},
+		{
+			name:              "aws without AMI",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       zones(testMachinePool()),
+			expectError:       true,
+		},
+		{
+			name: "vsphere without master machine",
+			clusterDeployment: func() *hivev1.ClusterDeployment {
+				cd := testClusterDeployment()
+				cd.Spec.Platform.AWS = nil
+				cd.Spec.Platform.VSphere = &hivev1vsphere.Platform{}
+				return cd
+			}(),
+			machinePool: func() *hivev1.MachinePool {
+				p := testMachinePool()
+				p.Spec.Platform.AWS = nil
+				p.Spec.Platform.VSphere = &hivev1vsphere.MachinePool{}
+				return p
+			}(),
+			expectError: true,
+		},
+		{
+			name:              "externally managed",
+			clusterDeployment: testClusterDeployment(),
+			machinePool:       testExternallyManagedMachinePool(),
+			masterMachine:     testMachine("master1", "master"),
+			expectError:       true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objects, err := Render(test.clusterDeployment, test.machinePool, test.masterMachine, log.WithField("test", test.name))
+			if test.expectError {
+				assert.Error(t, err, "expected error rendering")
+				return
+			}
+			require.NoError(t, err, "unexpected error rendering")
+
+			var machineSets []*machineapi.MachineSet
+			var machineAutoscalers []*autoscalingv1beta1.MachineAutoscaler
+			clusterAutoscaler := false
+			for _, obj := range objects {
+				switch obj := obj.(type) {
+				case *machineapi.MachineSet:
+					machineSets = append(machineSets, obj)
+				case *autoscalingv1beta1.MachineAutoscaler:
+					machineAutoscalers = append(machineAutoscalers, obj)
+				case *autoscalingv1.ClusterAutoscaler:
+					clusterAutoscaler = true
+				case *unstructured.Unstructured:
+					if obj.GetKind() == "ClusterAutoscaler" {
+						clusterAutoscaler = true
+						threshold, _, _ := unstructured.NestedString(obj.Object, "spec", "scaleDown", "utilizationThreshold")
+						assert.Equal(t, test.expectUtilizationThreshold, threshold, "unexpected utilization threshold")
+					}
+				}
+			}
+			assert.Len(t, machineSets, test.expectMachineSets, "unexpected number of MachineSets")
+			assert.Len(t, machineAutoscalers, test.expectMachineAutoscalers, "unexpected number of MachineAutoscalers")
+			assert.Equal(t, test.expectClusterAutoscaler, clusterAutoscaler, "unexpected ClusterAutoscaler")
+			if test.expectMachineSetReplicas != nil {
+				var replicas []int32
+				for _, ms := range machineSets {
+					replicas = append(replicas, *ms.Spec.Replicas)
+				}
+				assert.Equal(t, test.expectMachineSetReplicas, replicas, "unexpected MachineSet replicas")
+			}
+			if test.expectMachineAutoscalers > 0 {
+				var min, max int32
+				for _, ma := range machineAutoscalers {
+					min += ma.Spec.MinReplicas
+					max += ma.Spec.MaxReplicas
+				}
+				assert.Equal(t, test.expectAutoscalerMinMaxSum, [2]int32{min, max}, "unexpected total MachineAutoscaler replicas")
+			}
+		})
+	}
+}
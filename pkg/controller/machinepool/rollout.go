@@ -0,0 +1,285 @@
+package machinepool
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	// rolloutPollInterval is how often machine pools are reconciled while their machines are replaced, as the
+	// machines of remote clusters are not watched.
+	rolloutPollInterval = time.Minute
+
+	machinePhaseRunning = "Running"
+)
+
+var (
+	defaultMaxSurge       = intstr.FromInt(1)
+	defaultMaxUnavailable = intstr.FromInt(0)
+)
+
+// planRollout finds the machines of the pool whose provider spec does not match the generated MachineSets, for pools
+// with a rollout strategy. The replicas of the generated MachineSets are raised by the max surge while they have
+// machines to replace. Returns the progress of the rollout, and the machines which can be replaced without going
+// below the available machines required by the strategy. No machines are replaced while no maintenance window of the
+// cluster is open.
+func (r *ReconcileMachinePool) planRollout(
+	pool *hivev1.MachinePool,
+	generatedMachineSets []*machineapi.MachineSet,
+	remoteMachineSets *machineapi.MachineSetList,
+	remoteClusterAPIClient client.Client,
+	windowOpen bool,
+	logger log.FieldLogger,
+) (*hivev1.MachinePoolRolloutStatus, []*machineapi.Machine, error) {
+	if pool.Spec.RolloutStrategy == nil || replacesMachineSets(pool) || pool.DeletionTimestamp != nil {
+		return nil, nil, nil
+	}
+
+	status := &hivev1.MachinePoolRolloutStatus{Generation: pool.Generation}
+	var toReplace []*machineapi.Machine
+	for _, ms := range generatedMachineSets {
+		var rMS *machineapi.MachineSet
+		for i := range remoteMachineSets.Items {
+			if remoteMachineSets.Items[i].Name == ms.Name {
+				rMS = &remoteMachineSets.Items[i]
+				break
+			}
+		}
+		if rMS == nil {
+			// Machines of new MachineSets are created from the generated MachineSet.
+			continue
+		}
+		msLog := logger.WithField("machineset", ms.Name)
+
+		sel, err := metav1.LabelSelectorAsSelector(&rMS.Spec.Selector)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to create label selector for machineset %s", rMS.Name)
+		}
+		machines := &machineapi.MachineList{}
+		if err := remoteClusterAPIClient.List(context.TODO(), machines,
+			client.InNamespace(rMS.Namespace),
+			client.MatchingLabelsSelector{Selector: sel}); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to list machines for machineset %s", rMS.Name)
+		}
+
+		var outdated []*machineapi.Machine
+		var available int32
+		for i := range machines.Items {
+			machine := &machines.Items[i]
+			if machine.DeletionTimestamp != nil {
+				continue
+			}
+			matches, err := providerSpecMatches(ms.Spec.Template.Spec.ProviderSpec.Value, machine.Spec.ProviderSpec.Value)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to compare provider spec of machine %s", machine.Name)
+			}
+			if matches {
+				status.UpdatedReplicas++
+			} else {
+				status.OutdatedReplicas++
+				outdated = append(outdated, machine)
+			}
+			if isMachineAvailable(machine) {
+				available++
+			}
+		}
+		if len(outdated) == 0 {
+			continue
+		}
+		if !windowOpen {
+			msLog.WithField("outdated", len(outdated)).Info("waiting for a maintenance window to roll out machines")
+			continue
+		}
+
+		var replicas int32
+		if pool.Spec.Autoscaling != nil && rMS.Spec.Replicas != nil {
+			replicas = *rMS.Spec.Replicas
+		} else if ms.Spec.Replicas != nil {
+			replicas = *ms.Spec.Replicas
+		}
+		maxSurge, maxUnavailable, err := resolveRolloutStrategy(pool, replicas)
+		if err != nil {
+			return nil, nil, err
+		}
+		if maxSurge > 0 {
+			surged := replicas + maxSurge
+			ms.Spec.Replicas = &surged
+		}
+
+		// Outdated machines which are not available can be replaced without reducing the available machines.
+		budget := newRolloutBudget(replicas, maxUnavailable, available)
+		replacing := 0
+		for _, machine := range outdated {
+			if isMachineAvailable(machine) && budget.take(1) == 0 {
+				continue
+			}
+			toReplace = append(toReplace, machine)
+			replacing++
+		}
+		msLog.WithFields(log.Fields{
+			"outdated":  len(outdated),
+			"available": available,
+			"replacing": replacing,
+		}).Info("rolling out machines")
+	}
+	return status, toReplace, nil
+}
+
+// replaceMachines deletes outdated machines, which their MachineSets recreate from their updated template.
+func (r *ReconcileMachinePool) replaceMachines(machines []*machineapi.Machine, remoteClusterAPIClient client.Client, logger log.FieldLogger) error {
+	for _, machine := range machines {
+		logger.WithField("machine", machine.Name).Info("deleting outdated machine")
+		if err := remoteClusterAPIClient.Delete(context.TODO(), machine); err != nil {
+			return errors.Wrapf(err, "failed to delete machine %s", machine.Name)
+		}
+	}
+	return nil
+}
+
+// resolveRolloutStrategy returns the number of machines which can be surged and be unavailable for a MachineSet with
+// the given replicas.
+func resolveRolloutStrategy(pool *hivev1.MachinePool, replicas int32) (maxSurge, maxUnavailable int32, err error) {
+	surgeValue, unavailableValue := &defaultMaxSurge, &defaultMaxUnavailable
+	if s := pool.Spec.RolloutStrategy.MaxSurge; s != nil {
+		surgeValue = s
+	}
+	if u := pool.Spec.RolloutStrategy.MaxUnavailable; u != nil {
+		unavailableValue = u
+	}
+	surge, err := intstr.GetScaledValueFromIntOrPercent(surgeValue, int(replicas), true)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid maxSurge")
+	}
+	unavailable, err := intstr.GetScaledValueFromIntOrPercent(unavailableValue, int(replicas), false)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "invalid maxUnavailable")
+	}
+	// Surging would fight the cluster autoscaler over the replicas of the MachineSet.
+	if pool.Spec.Autoscaling != nil {
+		surge = 0
+	}
+	if surge == 0 && unavailable == 0 {
+		unavailable = 1
+	}
+	return int32(surge), int32(unavailable), nil
+}
+
+// rolloutBudget is how many available machines a rollout can remove while no more than maxUnavailable of the desired
+// replicas are unavailable. It is shared by rollouts replacing the machines of MachineSets and rollouts replacing the
+// MachineSets themselves. Machines which are not available can always be removed, without taking from the budget.
+type rolloutBudget struct {
+	remaining int32
+}
+
+func newRolloutBudget(desired, maxUnavailable, available int32) *rolloutBudget {
+	return &rolloutBudget{remaining: available - (desired - maxUnavailable)}
+}
+
+// take returns how many of the given available machines can be removed, and takes them from the budget.
+func (b *rolloutBudget) take(available int32) int32 {
+	if b.remaining <= 0 {
+		return 0
+	}
+	if available > b.remaining {
+		available = b.remaining
+	}
+	b.remaining -= available
+	return available
+}
+
+// isMachineAvailable returns true if the machine is running as a node of the cluster.
+func isMachineAvailable(machine *machineapi.Machine) bool {
+	return machine.DeletionTimestamp == nil && machine.Status.NodeRef != nil &&
+		machine.Status.Phase != nil && *machine.Status.Phase == machinePhaseRunning
+}
+
+// providerSpecMatches returns true if all of the fields set in the desired provider spec have the same value in the
+// observed provider spec. Fields only in the observed provider spec, such as those defaulted by the cluster, are
+// ignored.
+func providerSpecMatches(desired, observed *runtime.RawExtension) (bool, error) {
+	if desired == nil {
+		return true, nil
+	}
+	desiredValue, err := providerSpecValue(desired)
+	if err != nil {
+		return false, err
+	}
+	observedValue, err := providerSpecValue(observed)
+	if err != nil {
+		return false, err
+	}
+	return isSubset(desiredValue, observedValue), nil
+}
+
+func providerSpecValue(ext *runtime.RawExtension) (interface{}, error) {
+	if ext == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(ext)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// unmarshalProviderSpec unmarshals a provider spec into spec, whether the provider spec is decoded or raw.
+func unmarshalProviderSpec(ext *runtime.RawExtension, spec interface{}) error {
+	if ext == nil {
+		return errors.New("MachineSet has no ProviderSpec")
+	}
+	raw, err := json.Marshal(ext)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, spec)
+}
+
+func isSubset(desired, observed interface{}) bool {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		o, ok := observed.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range d {
+			if v == nil {
+				continue
+			}
+			if !isSubset(v, o[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		o, ok := observed.([]interface{})
+		if !ok || len(o) != len(d) {
+			return false
+		}
+		for i := range d {
+			if !isSubset(d[i], o[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(desired, observed)
+	}
+}
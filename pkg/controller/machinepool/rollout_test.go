@@ -0,0 +1,205 @@
+package machinepool
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestPlanRollout(t *testing.T) {
+	machineapi.AddToScheme(scheme.Scheme)
+
+	const msName = "foo-12345-worker-us-east-1a"
+	machine := func(name string, updated, available bool) *machineapi.Machine {
+		m := testMachineSetMachine(name, "worker", msName)
+		if updated {
+			m.Spec.ProviderSpec = updatedProviderSpec()
+		}
+		if available {
+			m.Status.NodeRef = &corev1.ObjectReference{Name: name}
+			m.Status.Phase = pointer.StringPtr(machinePhaseRunning)
+		}
+		return m
+	}
+	intOrString := func(v intstr.IntOrString) *intstr.IntOrString { return &v }
+
+	cases := []struct {
+		name             string
+		strategy         *hivev1.MachinePoolRolloutStrategy
+		machines         []runtime.Object
+		windowClosed     bool
+		expectedStatus   *hivev1.MachinePoolRolloutStatus
+		expectedReplicas int32
+		expectedReplaced []string
+	}{
+		{
+			name: "no rollout strategy",
+			machines: []runtime.Object{
+				machine("m1", false, true),
+			},
+			expectedReplicas: 3,
+		},
+		{
+			name:     "up to date",
+			strategy: &hivev1.MachinePoolRolloutStrategy{},
+			machines: []runtime.Object{
+				machine("m1", true, true),
+				machine("m2", true, true),
+				machine("m3", true, true),
+			},
+			expectedStatus:   &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 3},
+			expectedReplicas: 3,
+		},
+		{
+			name:     "surge before replacing",
+			strategy: &hivev1.MachinePoolRolloutStrategy{},
+			machines: []runtime.Object{
+				machine("m1", false, true),
+				machine("m2", false, true),
+				machine("m3", false, true),
+				machine("m4", true, false),
+			},
+			expectedStatus:   &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 1, OutdatedReplicas: 3},
+			expectedReplicas: 4,
+		},
+		{
+			name:     "replace once surged machine is available",
+			strategy: &hivev1.MachinePoolRolloutStrategy{},
+			machines: []runtime.Object{
+				machine("m1", false, true),
+				machine("m2", false, true),
+				machine("m3", false, true),
+				machine("m4", true, true),
+			},
+			expectedStatus:   &hivev1.MachinePoolRolloutStatus{UpdatedReplicas: 1, OutdatedReplicas: 3},
+			expectedReplicas: 4,
+			expectedReplaced: []string{"m1"},
+		},
+		{
+			name:     "wait for maintenance window",
+			strategy: &hivev1.MachinePoolRolloutStrategy{},
+			machines: []runtime.Object{
+				machine("m1", false, false),
+				machine("m2", false, true),
+				machine("m3", false, true),
+			},
+			windowClosed:     true,
+			expectedStatus:   &hivev1.MachinePoolRolloutStatus{OutdatedReplicas: 3},
+			expectedReplicas: 3,
+		},
+		{
+			name: "replace without surge",
+			strategy: &hivev1.MachinePoolRolloutStrategy{
+				MaxSurge:       intOrString(intstr.FromInt(0)),
+				MaxUnavailable: intOrString(intstr.FromString("67%")),
+			},
+			machines: []runtime.Object{
+				machine("m1", false, true),
+				machine("m2", false, true),
+				machine("m3", false, true),
+			},
+			expectedStatus:   &hivev1.MachinePoolRolloutStatus{OutdatedReplicas: 3},
+			expectedReplicas: 3,
+			expectedReplaced: []string{"m1", "m2"},
+		},
+		{
+			name:     "replace unavailable machines",
+			strategy: &hivev1.MachinePoolRolloutStrategy{},
+			machines: []runtime.Object{
+				machine("m1", false, false),
+				machine("m2", false, true),
+				machine("m3", false, true),
+			},
+			expectedStatus:   &hivev1.MachinePoolRolloutStatus{OutdatedReplicas: 3},
+			expectedReplicas: 4,
+			expectedReplaced: []string{"m1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testMachinePool()
+			pool.Spec.RolloutStrategy = tc.strategy
+			remoteMachineSet := testMachineSet(msName, "worker", false, 3, 0)
+			remoteClient := fake.NewClientBuilder().WithRuntimeObjects(append(tc.machines, remoteMachineSet)...).Build()
+			generatedMachineSet := testMachineSet(msName, "worker", false, 3, 0)
+			generatedMachineSet.Spec.Template.Spec.ProviderSpec = updatedProviderSpec()
+
+			r := &ReconcileMachinePool{}
+			status, replaced, err := r.planRollout(pool, []*machineapi.MachineSet{generatedMachineSet},
+				&machineapi.MachineSetList{Items: []machineapi.MachineSet{*remoteMachineSet}}, remoteClient, !tc.windowClosed, log.WithField("test", tc.name))
+			require.NoError(t, err, "unexpected error planning rollout")
+
+			assert.Equal(t, tc.expectedStatus, status, "unexpected rollout status")
+			assert.Equal(t, tc.expectedReplicas, *generatedMachineSet.Spec.Replicas, "unexpected replicas")
+			var replacedNames []string
+			for _, m := range replaced {
+				replacedNames = append(replacedNames, m.Name)
+			}
+			assert.Equal(t, tc.expectedReplaced, replacedNames, "unexpected machines replaced")
+
+			require.NoError(t, r.replaceMachines(replaced, remoteClient, log.WithField("test", tc.name)), "unexpected error replacing machines")
+			machines := &machineapi.MachineList{}
+			require.NoError(t, remoteClient.List(context.TODO(), machines), "unexpected error listing machines")
+			assert.Len(t, machines.Items, len(tc.machines)-len(tc.expectedReplaced), "unexpected number of remaining machines")
+		})
+	}
+}
+
+func TestProviderSpecMatches(t *testing.T) {
+	cases := []struct {
+		name     string
+		desired  string
+		observed string
+		expected bool
+	}{
+		{
+			name:     "equal",
+			desired:  `{"instanceType":"m5.large","tags":[{"name":"a","value":"b"}]}`,
+			observed: `{"instanceType":"m5.large","tags":[{"name":"a","value":"b"}]}`,
+			expected: true,
+		},
+		{
+			name:     "defaulted fields",
+			desired:  `{"instanceType":"m5.large","metadata":{"creationTimestamp":null}}`,
+			observed: `{"instanceType":"m5.large","credentialsSecret":{"name":"aws-cloud-credentials"},"metadata":{}}`,
+			expected: true,
+		},
+		{
+			name:     "changed field",
+			desired:  `{"instanceType":"m5.xlarge"}`,
+			observed: `{"instanceType":"m5.large"}`,
+		},
+		{
+			name:     "changed list",
+			desired:  `{"tags":[{"name":"a","value":"b"}]}`,
+			observed: `{"tags":[{"name":"a","value":"b"},{"name":"c","value":"d"}]}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			matches, err := providerSpecMatches(&runtime.RawExtension{Raw: []byte(tc.desired)}, &runtime.RawExtension{Raw: []byte(tc.observed)})
+			require.NoError(t, err, "unexpected error comparing provider specs")
+			assert.Equal(t, tc.expected, matches, "unexpected match")
+		})
+	}
+}
+
+func updatedProviderSpec() machineapi.ProviderSpec {
+	providerSpec := testAWSProviderSpec()
+	providerSpec.InstanceType = "updated-instance-type"
+	return machineapi.ProviderSpec{Value: &runtime.RawExtension{Object: providerSpec}}
+}
@@ -0,0 +1,75 @@
+package machinepool
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// updateClusterDeploymentSummary updates the summary of the MachinePools of the ClusterDeployment in its status to
+// reflect the given pool. A pool which is being deleted is dropped from the summary.
+func (r *ReconcileMachinePool) updateClusterDeploymentSummary(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, logger log.FieldLogger) error {
+	pools := &hivev1.MachinePoolList{}
+	if err := r.List(context.TODO(), pools,
+		client.MatchingFields{machinePoolClusterDeploymentIndex: cd.Name},
+		client.InNamespace(cd.Namespace)); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list machine pools")
+		return err
+	}
+
+	summary := []hivev1.MachinePoolSummary{}
+	for i := range pools.Items {
+		p := &pools.Items[i]
+		// This should only happen in unit tests: the fakeclient doesn't support index filters
+		if p.Spec.ClusterDeploymentRef.Name != cd.Name {
+			continue
+		}
+		// The cache may not have caught up with the pool being reconciled yet.
+		if p.Name == pool.Name {
+			p = pool
+		}
+		if p.DeletionTimestamp != nil {
+			continue
+		}
+		summary = append(summary, summarizeMachinePool(p))
+	}
+	sort.Slice(summary, func(i, j int) bool { return summary[i].Name < summary[j].Name })
+	if len(summary) == 0 {
+		summary = nil
+	}
+
+	if reflect.DeepEqual(cd.Status.MachinePools, summary) {
+		return nil
+	}
+	logger.Debug("updating machine pool summary of clusterdeployment")
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Get(context.TODO(), client.ObjectKeyFromObject(cd), cd); err != nil {
+			return err
+		}
+		cd.Status.MachinePools = summary
+		return r.Status().Update(context.TODO(), cd)
+	})
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update machine pool summary of clusterdeployment")
+	}
+	return err
+}
+
+func summarizeMachinePool(pool *hivev1.MachinePool) hivev1.MachinePoolSummary {
+	summary := hivev1.MachinePoolSummary{
+		Name:     pool.Spec.Name,
+		Replicas: pool.Status.Replicas,
+	}
+	for _, ms := range pool.Status.MachineSets {
+		summary.ReadyReplicas += ms.ReadyReplicas
+	}
+	return summary
+}
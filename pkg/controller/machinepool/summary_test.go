@@ -0,0 +1,120 @@
+package machinepool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestUpdateClusterDeploymentSummary(t *testing.T) {
+	pool := func(name string, readyReplicas ...int32) *hivev1.MachinePool {
+		p := testMachinePool()
+		p.Name = fmt.Sprintf("%s-%s", testName, name)
+		p.Spec.Name = name
+		for i, ready := range readyReplicas {
+			p.Status.MachineSets = append(p.Status.MachineSets, hivev1.MachineSetStatus{
+				Name:          fmt.Sprintf("%s-%d", name, i),
+				Replicas:      2,
+				ReadyReplicas: ready,
+			})
+			p.Status.Replicas += 2
+		}
+		return p
+	}
+	deleted := func(p *hivev1.MachinePool) *hivev1.MachinePool {
+		now := metav1.Now()
+		p.DeletionTimestamp = &now
+		return p
+	}
+	otherCluster := pool("other", 1)
+	otherCluster.Name = "other-other"
+	otherCluster.Spec.ClusterDeploymentRef.Name = "other"
+
+	cases := []struct {
+		name     string
+		summary  []hivev1.MachinePoolSummary
+		existing []runtime.Object
+		pool     *hivev1.MachinePool
+		expected []hivev1.MachinePoolSummary
+	}{
+		{
+			name:     "first pool",
+			existing: []runtime.Object{pool("worker"), otherCluster},
+			pool:     pool("worker", 2, 1, 0),
+			expected: []hivev1.MachinePoolSummary{{Name: "worker", Replicas: 6, ReadyReplicas: 3}},
+		},
+		{
+			name:     "pools sorted by name",
+			summary:  []hivev1.MachinePoolSummary{{Name: "worker", Replicas: 2, ReadyReplicas: 2}},
+			existing: []runtime.Object{pool("worker", 2), pool("infra")},
+			pool:     pool("infra", 1),
+			expected: []hivev1.MachinePoolSummary{
+				{Name: "infra", Replicas: 2, ReadyReplicas: 1},
+				{Name: "worker", Replicas: 2, ReadyReplicas: 2},
+			},
+		},
+		{
+			name: "unchanged",
+			summary: []hivev1.MachinePoolSummary{
+				{Name: "infra", Replicas: 2, ReadyReplicas: 1},
+				{Name: "worker", Replicas: 2, ReadyReplicas: 2},
+			},
+			existing: []runtime.Object{pool("worker", 2), pool("infra", 1)},
+			pool:     pool("worker", 2),
+			expected: []hivev1.MachinePoolSummary{
+				{Name: "infra", Replicas: 2, ReadyReplicas: 1},
+				{Name: "worker", Replicas: 2, ReadyReplicas: 2},
+			},
+		},
+		{
+			name: "deleted pool",
+			summary: []hivev1.MachinePoolSummary{
+				{Name: "infra", Replicas: 2, ReadyReplicas: 1},
+				{Name: "worker", Replicas: 2, ReadyReplicas: 2},
+			},
+			existing: []runtime.Object{pool("worker", 2), pool("infra", 1)},
+			pool:     deleted(pool("infra", 1)),
+			expected: []hivev1.MachinePoolSummary{{Name: "worker", Replicas: 2, ReadyReplicas: 2}},
+		},
+		{
+			name:     "last pool deleted",
+			summary:  []hivev1.MachinePoolSummary{{Name: "worker", Replicas: 2, ReadyReplicas: 2}},
+			existing: []runtime.Object{pool("worker", 2)},
+			pool:     deleted(pool("worker", 2)),
+		},
+	}
+
+	apis.AddToScheme(scheme.Scheme)
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			cd.Status.MachinePools = test.summary
+			fakeClient := fake.NewClientBuilder().WithRuntimeObjects(append(test.existing, cd)...).Build()
+			r := &ReconcileMachinePool{
+				Client: fakeClient,
+				logger: log.WithField("controller", "machinepool"),
+			}
+
+			err := r.updateClusterDeploymentSummary(cd.DeepCopy(), test.pool, r.logger)
+			require.NoError(t, err)
+
+			actual := &hivev1.ClusterDeployment{}
+			err = fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(cd), actual)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual.Status.MachinePools, "unexpected machine pool summary")
+		})
+	}
+}
@@ -0,0 +1,391 @@
+// Package remediation provides a controller which takes the actions configured in the RemediationPolicy of a
+// ClusterDeployment when the cluster remains unreachable, or fails to resume from hibernation, for too long.
+package remediation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/controller/hibernation"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.RemediationControllerName
+
+	// UnreachableRemediationReason is used as the reason for remediation when the cluster is unreachable.
+	UnreachableRemediationReason = "Unreachable"
+	// ResumeFailedRemediationReason is used as the reason for remediation when the cluster fails to resume from
+	// hibernation.
+	ResumeFailedRemediationReason = "ResumeFailed"
+
+	// restartCheckInterval is the time interval for polling the machines of a cluster being restarted
+	restartCheckInterval = 30 * time.Second
+
+	webhookTimeout = 30 * time.Second
+)
+
+var (
+	metricRemediationAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_cluster_deployment_remediation_attempts_total",
+		Help: "Counter incremented every time a remediation action for an unhealthy cluster deployment completes.",
+	}, []string{"action", "result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricRemediationAttempts)
+}
+
+// Add creates a new Remediation Controller and adds it to the Manager with default RBAC. The Manager will set fields
+// on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileRemediation {
+	r := &ReconcileRemediation{
+		Client:      controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger:      log.WithField("controller", ControllerName),
+		actuatorFor: hibernation.ActuatorFor,
+		approveCSRs: hibernation.ApproveNodeCSRs,
+		httpClient:  &http.Client{Timeout: webhookTimeout},
+	}
+	r.remoteClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileRemediation, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	laneReconciler, err := controllerutils.NewUnreachableLaneReconciler(r, mgr.GetClient(), ControllerName)
+	if err != nil {
+		return err
+	}
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewStartupRampReconciler(laneReconciler, rateLimiter),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileRemediation{}
+
+// ReconcileRemediation reconciles a ClusterDeployment to remediate the cluster when it is unhealthy.
+type ReconcileRemediation struct {
+	client.Client
+	logger log.FieldLogger
+
+	// remoteClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// actuatorFor returns the hibernation actuator used to restart the machines of the cluster
+	actuatorFor func(cd *hivev1.ClusterDeployment) hibernation.HibernationActuator
+
+	// approveCSRs approves the pending node CSRs of the remote cluster
+	approveCSRs func(kubeClient kubeclient.Interface, remoteClient client.Client, logger log.FieldLogger) (int, error)
+
+	httpClient *http.Client
+}
+
+// Reconcile checks whether the cluster is unhealthy, and takes the next remediation action of the RemediationPolicy
+// when it has been unhealthy for long enough.
+func (r *ReconcileRemediation) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Info("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(ctx, request.NamespacedName, cd); {
+	case apierrors.IsNotFound(err):
+		cdLog.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		cdLog.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	if cd.DeletionTimestamp != nil {
+		cdLog.Debug("cluster deployment is being deleted")
+		return reconcile.Result{}, nil
+	}
+
+	if controllerutils.IsClusterPausedOrRelocating(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
+	origStatus := cd.Status.Remediation.DeepCopy()
+	result := r.remediate(cd, cdLog)
+	if reflect.DeepEqual(origStatus, cd.Status.Remediation) {
+		return result, nil
+	}
+	if err := r.Status().Update(ctx, cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
+		return reconcile.Result{}, err
+	}
+	return result, nil
+}
+
+// remediate updates the remediation status of the ClusterDeployment, taking remediation actions as needed.
+func (r *ReconcileRemediation) remediate(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) reconcile.Result {
+	status := cd.Status.Remediation
+	policy := cd.Spec.RemediationPolicy
+
+	// A restart must always be completed, so that the machines of the cluster are not left stopped.
+	if last := lastAttempt(status); last != nil && last.Result == hivev1.InProgressRemediationAttemptResult {
+		return r.continueRestartMachines(cd, last, cdLog)
+	}
+
+	if policy == nil || !cd.Spec.Installed {
+		cd.Status.Remediation = nil
+		return reconcile.Result{}
+	}
+
+	reason, since, unhealthy := unhealthyState(cd)
+	if !unhealthy {
+		if status != nil {
+			cdLog.Info("cluster is healthy, ending remediation")
+		}
+		cd.Status.Remediation = nil
+		return reconcile.Result{}
+	}
+	if status == nil || !status.UnhealthySince.Equal(&since) {
+		cdLog.WithField("reason", reason).WithField("since", since).Info("cluster is unhealthy")
+		status = &hivev1.RemediationStatus{UnhealthySince: since, Reason: reason}
+		cd.Status.Remediation = status
+	}
+
+	if len(status.Attempts) >= len(policy.Actions) {
+		cdLog.Debug("all remediation actions have been attempted")
+		return reconcile.Result{}
+	}
+
+	// The first action is taken once the cluster has been unhealthy for the UnhealthyDuration, and each subsequent
+	// action once the UnhealthyDuration has passed since the previous attempt.
+	next := since.Time
+	if last := lastAttempt(status); last != nil {
+		next = last.StartTime.Time
+	}
+	if wait := policy.UnhealthyDuration.Duration - time.Since(next); wait > 0 {
+		cdLog.WithField("wait", wait).Debug("waiting to take next remediation action")
+		return reconcile.Result{RequeueAfter: wait}
+	}
+
+	action := policy.Actions[len(status.Attempts)]
+	attempt := hivev1.RemediationAttempt{
+		Action:    action.Type,
+		StartTime: metav1.Now(),
+	}
+	actionLog := cdLog.WithField("action", action.Type)
+	actionLog.Info("taking remediation action")
+	switch action.Type {
+	case hivev1.RestartMachinesRemediationAction:
+		if err := r.stopMachines(cd, actionLog); err != nil {
+			completeAttempt(&attempt, "", err, actionLog)
+		} else {
+			attempt.Result = hivev1.InProgressRemediationAttemptResult
+			attempt.Message = "Stopping machines"
+		}
+	case hivev1.ApproveCSRsRemediationAction:
+		approved, err := r.approveNodeCSRs(cd, actionLog)
+		completeAttempt(&attempt, fmt.Sprintf("Approved %d certificate signing requests", approved), err, actionLog)
+	case hivev1.WebhookRemediationAction:
+		completeAttempt(&attempt, "Webhook notified", r.notifyWebhook(cd, action.Webhook), actionLog)
+	default:
+		completeAttempt(&attempt, "", fmt.Errorf("unsupported remediation action %q", action.Type), actionLog)
+	}
+	status.Attempts = append(status.Attempts, attempt)
+
+	if attempt.Result == hivev1.InProgressRemediationAttemptResult {
+		return reconcile.Result{RequeueAfter: restartCheckInterval}
+	}
+	if len(status.Attempts) < len(policy.Actions) {
+		return reconcile.Result{RequeueAfter: policy.UnhealthyDuration.Duration}
+	}
+	return reconcile.Result{}
+}
+
+// unhealthyState returns the reason the cluster is unhealthy and the time since when, if it is unhealthy. A cluster is
+// unhealthy when it is unreachable, or when it has been resuming from hibernation without becoming ready.
+func unhealthyState(cd *hivev1.ClusterDeployment) (string, metav1.Time, bool) {
+	conds := cd.Status.Conditions
+	if cond := controllerutils.FindClusterDeploymentCondition(conds, hivev1.UnreachableCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+		return UnreachableRemediationReason, cond.LastTransitionTime, true
+	}
+	if cd.Spec.PowerState == hivev1.HibernatingClusterPowerState {
+		return "", metav1.Time{}, false
+	}
+	hibernating := controllerutils.FindClusterDeploymentCondition(conds, hivev1.ClusterHibernatingCondition)
+	ready := controllerutils.FindClusterDeploymentCondition(conds, hivev1.ClusterReadyCondition)
+	if hibernating == nil || hibernating.Status != corev1.ConditionFalse || ready == nil || ready.Status != corev1.ConditionFalse {
+		return "", metav1.Time{}, false
+	}
+	// The cluster started resuming when it stopped hibernating, or when it stopped being ready, whichever was last.
+	since := hibernating.LastTransitionTime
+	if since.Before(&ready.LastTransitionTime) {
+		since = ready.LastTransitionTime
+	}
+	return ResumeFailedRemediationReason, since, true
+}
+
+func lastAttempt(status *hivev1.RemediationStatus) *hivev1.RemediationAttempt {
+	if status == nil || len(status.Attempts) == 0 {
+		return nil
+	}
+	return &status.Attempts[len(status.Attempts)-1]
+}
+
+// completeAttempt records the result of a remediation attempt, which failed if err is not nil.
+func completeAttempt(attempt *hivev1.RemediationAttempt, message string, err error, logger log.FieldLogger) {
+	if err != nil {
+		logger.WithError(err).Warn("remediation action failed")
+		attempt.Result = hivev1.FailedRemediationAttemptResult
+		attempt.Message = err.Error()
+	} else {
+		logger.Info("remediation action succeeded")
+		attempt.Result = hivev1.SucceededRemediationAttemptResult
+		attempt.Message = message
+	}
+	metricRemediationAttempts.WithLabelValues(string(attempt.Action), string(attempt.Result)).Inc()
+}
+
+// stopMachines stops the machines of the cluster through its cloud provider. Unlike the approval of CSRs it does not
+// check the relocation fence, which cannot be read from the unreachable clusters that a restart remediates.
+func (r *ReconcileRemediation) stopMachines(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	actuator := r.actuatorFor(cd)
+	if actuator == nil {
+		return errors.New("restarting machines is not supported for the platform of the cluster")
+	}
+	return errors.Wrap(actuator.StopMachines(cd, r.Client, logger), "failed to stop machines")
+}
+
+// continueRestartMachines starts the machines of the cluster once they have stopped, completing the restart.
+func (r *ReconcileRemediation) continueRestartMachines(cd *hivev1.ClusterDeployment, attempt *hivev1.RemediationAttempt, cdLog log.FieldLogger) reconcile.Result {
+	logger := cdLog.WithField("action", attempt.Action)
+	actuator := r.actuatorFor(cd)
+	if actuator == nil {
+		completeAttempt(attempt, "", errors.New("restarting machines is not supported for the platform of the cluster"), logger)
+		return reconcile.Result{}
+	}
+	stopped, remaining, err := actuator.MachinesStopped(cd, r.Client, logger)
+	if err != nil {
+		completeAttempt(attempt, "", errors.Wrap(err, "failed to check whether machines are stopped"), logger)
+		return reconcile.Result{}
+	}
+	if !stopped {
+		logger.WithField("machines", remaining).Debug("waiting for machines to stop")
+		attempt.Message = fmt.Sprintf("Waiting for %d machines to stop", len(remaining))
+		return reconcile.Result{RequeueAfter: restartCheckInterval}
+	}
+	logger.Info("machines stopped, starting machines")
+	err = errors.Wrap(actuator.StartMachines(cd, r.Client, logger), "failed to start machines")
+	completeAttempt(attempt, "Machines restarted", err, logger)
+	return reconcile.Result{}
+}
+
+func (r *ReconcileRemediation) approveNodeCSRs(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (int, error) {
+	builder := r.remoteClientBuilder(cd)
+	remoteClient, err := builder.Build()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to connect to cluster")
+	}
+	switch fence, err := controllerutils.GetRemoteRelocationFence(remoteClient); {
+	case err != nil:
+		return 0, errors.Wrap(err, "could not get relocation fence")
+	case controllerutils.IsFencedOut(cd, fence, logger):
+		// The certificate signing requests are left to the hub which owns the cluster.
+		return 0, errors.New("cluster is fenced to another hub by a relocation")
+	}
+	kubeClient, err := builder.BuildKubeClient()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to connect to cluster")
+	}
+	return r.approveCSRs(kubeClient, remoteClient, logger)
+}
+
+// webhookNotification is the payload POSTed to remediation webhooks.
+type webhookNotification struct {
+	ClusterDeployment string      `json:"clusterDeployment"`
+	Namespace         string      `json:"namespace"`
+	InfraID           string      `json:"infraID,omitempty"`
+	Reason            string      `json:"reason"`
+	UnhealthySince    metav1.Time `json:"unhealthySince"`
+}
+
+func (r *ReconcileRemediation) notifyWebhook(cd *hivev1.ClusterDeployment, webhook *hivev1.RemediationWebhook) error {
+	if webhook == nil || webhook.URL == "" {
+		return errors.New("no webhook configured")
+	}
+	notification := webhookNotification{
+		ClusterDeployment: cd.Name,
+		Namespace:         cd.Namespace,
+		Reason:            cd.Status.Remediation.Reason,
+		UnhealthySince:    cd.Status.Remediation.UnhealthySince,
+	}
+	if cd.Spec.ClusterMetadata != nil {
+		notification.InfraID = cd.Spec.ClusterMetadata.InfraID
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal webhook notification")
+	}
+	resp, err := r.httpClient.Post(webhook.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to notify webhook")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
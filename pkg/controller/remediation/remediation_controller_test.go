@@ -0,0 +1,463 @@
+package remediation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclient "k8s.io/client-go/kubernetes"
+	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/controller/hibernation"
+	"github.com/openshift/hive/pkg/controller/hibernation/mock"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+const (
+	namespace = "test-namespace"
+	cdName    = "test-cluster-deployment"
+	infraID   = "test-infra-id"
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		func(cd *hivev1.ClusterDeployment) {
+			cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{InfraID: infraID}
+		},
+	)
+	unreachableSince := metav1.NewTime(time.Now().Add(-2 * time.Hour).Truncate(time.Second))
+
+	tests := []struct {
+		name               string
+		cd                 *hivev1.ClusterDeployment
+		setupActuator      func(actuator *mock.MockHibernationActuator)
+		setupRemote        func(builder *remoteclientmock.MockBuilder)
+		webhookStatus      int
+		expectRequeueAfter time.Duration
+		expectedStatus     *hivev1.RemediationStatus
+		validate           func(t *testing.T, status *hivev1.RemediationStatus)
+		expectedWebhook    *webhookNotification
+	}{
+		{
+			name: "no remediation policy",
+			cd:   cdBuilder.Build(withUnreachableCondition(unreachableSince)),
+		},
+		{
+			name: "healthy cluster clears status",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.ApproveCSRsRemediationAction),
+				withRemediationStatus(UnreachableRemediationReason, unreachableSince, succeededAttempt(hivev1.ApproveCSRsRemediationAction, time.Hour)),
+			),
+		},
+		{
+			name: "unhealthy for less than the unhealthy duration",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.ApproveCSRsRemediationAction),
+				withUnreachableCondition(metav1.NewTime(time.Now().Add(-10*time.Minute).Truncate(time.Second))),
+			),
+			expectRequeueAfter: 50 * time.Minute,
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				assert.Equal(t, UnreachableRemediationReason, status.Reason, "unexpected reason")
+				assert.Empty(t, status.Attempts, "expected no remediation attempts")
+			},
+		},
+		{
+			name: "approve CSRs",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.ApproveCSRsRemediationAction, hivev1.WebhookRemediationAction),
+				withUnreachableCondition(unreachableSince),
+			),
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				builder.EXPECT().Build().Return(fake.NewFakeClientWithScheme(scheme), nil)
+				builder.EXPECT().BuildKubeClient().Return(fakekubeclient.NewSimpleClientset(), nil)
+			},
+			expectRequeueAfter: time.Hour,
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				assert.True(t, status.UnhealthySince.Equal(&unreachableSince), "unexpected unhealthy since")
+				require.Len(t, status.Attempts, 1, "unexpected number of attempts")
+				assert.Equal(t, hivev1.ApproveCSRsRemediationAction, status.Attempts[0].Action, "unexpected action")
+				assert.Equal(t, hivev1.SucceededRemediationAttemptResult, status.Attempts[0].Result, "unexpected result")
+				assert.Equal(t, "Approved 2 certificate signing requests", status.Attempts[0].Message, "unexpected message")
+			},
+		},
+		{
+			name: "approve CSRs fenced out",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.ApproveCSRsRemediationAction, hivev1.WebhookRemediationAction),
+				withUnreachableCondition(unreachableSince),
+			),
+			setupRemote: func(builder *remoteclientmock.MockBuilder) {
+				builder.EXPECT().Build().Return(fake.NewFakeClientWithScheme(scheme, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        controllerutils.RelocationFenceNamespace,
+						Annotations: map[string]string{constants.RelocationFencingTokenAnnotation: "other-hub"},
+					},
+				}), nil)
+			},
+			expectRequeueAfter: time.Hour,
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				require.Len(t, status.Attempts, 1, "unexpected number of attempts")
+				assert.Equal(t, hivev1.ApproveCSRsRemediationAction, status.Attempts[0].Action, "unexpected action")
+				assert.Equal(t, hivev1.FailedRemediationAttemptResult, status.Attempts[0].Result, "unexpected result")
+				assert.Contains(t, status.Attempts[0].Message, "fenced", "unexpected message")
+			},
+		},
+		{
+			name: "waiting for next action",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.ApproveCSRsRemediationAction, hivev1.WebhookRemediationAction),
+				withUnreachableCondition(unreachableSince),
+				withRemediationStatus(UnreachableRemediationReason, unreachableSince, succeededAttempt(hivev1.ApproveCSRsRemediationAction, 20*time.Minute)),
+			),
+			expectRequeueAfter: 40 * time.Minute,
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				assert.Len(t, status.Attempts, 1, "unexpected number of attempts")
+			},
+		},
+		{
+			name: "notify webhook",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.ApproveCSRsRemediationAction, hivev1.WebhookRemediationAction),
+				withUnreachableCondition(unreachableSince),
+				withRemediationStatus(UnreachableRemediationReason, unreachableSince, succeededAttempt(hivev1.ApproveCSRsRemediationAction, time.Hour)),
+			),
+			webhookStatus: http.StatusOK,
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				require.Len(t, status.Attempts, 2, "unexpected number of attempts")
+				assert.Equal(t, hivev1.WebhookRemediationAction, status.Attempts[1].Action, "unexpected action")
+				assert.Equal(t, hivev1.SucceededRemediationAttemptResult, status.Attempts[1].Result, "unexpected result")
+			},
+			expectedWebhook: &webhookNotification{
+				ClusterDeployment: cdName,
+				Namespace:         namespace,
+				InfraID:           infraID,
+				Reason:            UnreachableRemediationReason,
+				UnhealthySince:    unreachableSince,
+			},
+		},
+		{
+			name: "webhook failure",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.WebhookRemediationAction),
+				withUnreachableCondition(unreachableSince),
+			),
+			webhookStatus: http.StatusInternalServerError,
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				require.Len(t, status.Attempts, 1, "unexpected number of attempts")
+				assert.Equal(t, hivev1.FailedRemediationAttemptResult, status.Attempts[0].Result, "unexpected result")
+				assert.Equal(t, "webhook responded with status 500", status.Attempts[0].Message, "unexpected message")
+			},
+		},
+		{
+			name: "all actions attempted",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.ApproveCSRsRemediationAction),
+				withUnreachableCondition(unreachableSince),
+				withRemediationStatus(UnreachableRemediationReason, unreachableSince, succeededAttempt(hivev1.ApproveCSRsRemediationAction, time.Hour)),
+			),
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				assert.Len(t, status.Attempts, 1, "unexpected number of attempts")
+			},
+		},
+		{
+			name: "new unhealthy period restarts remediation",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.ApproveCSRsRemediationAction),
+				withUnreachableCondition(metav1.NewTime(time.Now().Add(-10*time.Minute).Truncate(time.Second))),
+				withRemediationStatus(UnreachableRemediationReason, unreachableSince, succeededAttempt(hivev1.ApproveCSRsRemediationAction, time.Hour)),
+			),
+			expectRequeueAfter: 50 * time.Minute,
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				assert.False(t, status.UnhealthySince.Equal(&unreachableSince), "expected new unhealthy since")
+				assert.Empty(t, status.Attempts, "expected no remediation attempts")
+			},
+		},
+		{
+			name: "stop machines",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.RestartMachinesRemediationAction),
+				withUnreachableCondition(unreachableSince),
+			),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			expectRequeueAfter: restartCheckInterval,
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				require.Len(t, status.Attempts, 1, "unexpected number of attempts")
+				assert.Equal(t, hivev1.InProgressRemediationAttemptResult, status.Attempts[0].Result, "unexpected result")
+			},
+		},
+		{
+			name: "stop machines failure",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.RestartMachinesRemediationAction),
+				withUnreachableCondition(unreachableSince),
+			),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("cloud error"))
+			},
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				require.Len(t, status.Attempts, 1, "unexpected number of attempts")
+				assert.Equal(t, hivev1.FailedRemediationAttemptResult, status.Attempts[0].Result, "unexpected result")
+				assert.Equal(t, "failed to stop machines: cloud error", status.Attempts[0].Message, "unexpected message")
+			},
+		},
+		{
+			name: "waiting for machines to stop",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.RestartMachinesRemediationAction),
+				withUnreachableCondition(unreachableSince),
+				withRemediationStatus(UnreachableRemediationReason, unreachableSince, inProgressAttempt()),
+			),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, []string{"m1", "m2"}, nil)
+			},
+			expectRequeueAfter: restartCheckInterval,
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				require.Len(t, status.Attempts, 1, "unexpected number of attempts")
+				assert.Equal(t, hivev1.InProgressRemediationAttemptResult, status.Attempts[0].Result, "unexpected result")
+				assert.Equal(t, "Waiting for 2 machines to stop", status.Attempts[0].Message, "unexpected message")
+			},
+		},
+		{
+			name: "start stopped machines",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.RestartMachinesRemediationAction),
+				withUnreachableCondition(unreachableSince),
+				withRemediationStatus(UnreachableRemediationReason, unreachableSince, inProgressAttempt()),
+			),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil, nil)
+				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				require.Len(t, status.Attempts, 1, "unexpected number of attempts")
+				assert.Equal(t, hivev1.SucceededRemediationAttemptResult, status.Attempts[0].Result, "unexpected result")
+			},
+		},
+		{
+			name: "restart completed after cluster becomes healthy",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.RestartMachinesRemediationAction),
+				withRemediationStatus(UnreachableRemediationReason, unreachableSince, inProgressAttempt()),
+			),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil, nil)
+				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				require.Len(t, status.Attempts, 1, "unexpected number of attempts")
+				assert.Equal(t, hivev1.SucceededRemediationAttemptResult, status.Attempts[0].Result, "unexpected result")
+			},
+		},
+		{
+			name: "resume failed",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.WebhookRemediationAction),
+				testcd.WithCondition(condition(hivev1.ClusterHibernatingCondition, corev1.ConditionFalse, 3*time.Hour)),
+				testcd.WithCondition(condition(hivev1.ClusterReadyCondition, corev1.ConditionFalse, 2*time.Hour)),
+			),
+			webhookStatus: http.StatusOK,
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				assert.Equal(t, ResumeFailedRemediationReason, status.Reason, "unexpected reason")
+				require.Len(t, status.Attempts, 1, "unexpected number of attempts")
+				assert.Equal(t, hivev1.SucceededRemediationAttemptResult, status.Attempts[0].Result, "unexpected result")
+			},
+		},
+		{
+			name: "resuming for less than the unhealthy duration",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.WebhookRemediationAction),
+				testcd.WithCondition(condition(hivev1.ClusterHibernatingCondition, corev1.ConditionFalse, 10*time.Minute)),
+				testcd.WithCondition(condition(hivev1.ClusterReadyCondition, corev1.ConditionFalse, 2*time.Hour)),
+			),
+			expectRequeueAfter: 50 * time.Minute,
+			validate: func(t *testing.T, status *hivev1.RemediationStatus) {
+				require.NotNil(t, status, "expected remediation status")
+				assert.Equal(t, ResumeFailedRemediationReason, status.Reason, "unexpected reason")
+				assert.Empty(t, status.Attempts, "expected no remediation attempts")
+			},
+		},
+		{
+			name: "hibernating cluster is not unhealthy",
+			cd: cdBuilder.Build(
+				withPolicy(hivev1.WebhookRemediationAction),
+				testcd.WithPowerState(hivev1.HibernatingClusterPowerState),
+				testcd.WithCondition(condition(hivev1.ClusterHibernatingCondition, corev1.ConditionFalse, 3*time.Hour)),
+				testcd.WithCondition(condition(hivev1.ClusterReadyCondition, corev1.ConditionFalse, 2*time.Hour)),
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockActuator := mock.NewMockHibernationActuator(ctrl)
+			if test.setupActuator != nil {
+				test.setupActuator(mockActuator)
+			}
+			mockBuilder := remoteclientmock.NewMockBuilder(ctrl)
+			if test.setupRemote != nil {
+				test.setupRemote(mockBuilder)
+			}
+
+			var notifications []webhookNotification
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				notification := webhookNotification{}
+				assert.NoError(t, json.NewDecoder(req.Body).Decode(&notification), "unexpected error decoding webhook notification")
+				notifications = append(notifications, notification)
+				w.WriteHeader(test.webhookStatus)
+			}))
+			defer server.Close()
+			if policy := test.cd.Spec.RemediationPolicy; policy != nil {
+				for i := range policy.Actions {
+					if policy.Actions[i].Webhook != nil {
+						policy.Actions[i].Webhook.URL = server.URL
+					}
+				}
+			}
+
+			c := fake.NewFakeClientWithScheme(scheme, test.cd)
+			reconciler := &ReconcileRemediation{
+				Client: c,
+				logger: log.WithField("controller", ControllerName),
+				remoteClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					return mockBuilder
+				},
+				actuatorFor: func(cd *hivev1.ClusterDeployment) hibernation.HibernationActuator {
+					return mockActuator
+				},
+				approveCSRs: func(kubeClient kubeclient.Interface, remoteClient client.Client, logger log.FieldLogger) (int, error) {
+					return 2, nil
+				},
+				httpClient: server.Client(),
+			}
+			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			// Need to do fuzzy requeue after matching
+			if test.expectRequeueAfter == 0 {
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue after")
+			} else {
+				assert.InDelta(t, test.expectRequeueAfter.Seconds(), result.RequeueAfter.Seconds(), 10, "unexpected requeue after")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd), "unexpected error getting cluster deployment")
+			if test.validate != nil {
+				test.validate(t, cd.Status.Remediation)
+			} else {
+				assert.Nil(t, cd.Status.Remediation, "expected no remediation status")
+			}
+
+			if test.expectedWebhook != nil {
+				require.Len(t, notifications, 1, "expected a webhook notification")
+				assert.Equal(t, test.expectedWebhook.ClusterDeployment, notifications[0].ClusterDeployment, "unexpected cluster deployment")
+				assert.Equal(t, test.expectedWebhook.Namespace, notifications[0].Namespace, "unexpected namespace")
+				assert.Equal(t, test.expectedWebhook.InfraID, notifications[0].InfraID, "unexpected infra ID")
+				assert.Equal(t, test.expectedWebhook.Reason, notifications[0].Reason, "unexpected reason")
+				assert.True(t, test.expectedWebhook.UnhealthySince.Equal(&notifications[0].UnhealthySince), "unexpected unhealthy since")
+			}
+		})
+	}
+}
+
+func withPolicy(actions ...hivev1.RemediationActionType) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		policy := &hivev1.RemediationPolicy{UnhealthyDuration: metav1.Duration{Duration: time.Hour}}
+		for _, actionType := range actions {
+			action := hivev1.RemediationAction{Type: actionType}
+			if actionType == hivev1.WebhookRemediationAction {
+				action.Webhook = &hivev1.RemediationWebhook{URL: "set-by-test"}
+			}
+			policy.Actions = append(policy.Actions, action)
+		}
+		cd.Spec.RemediationPolicy = policy
+	}
+}
+
+func withUnreachableCondition(since metav1.Time) testcd.Option {
+	return testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+		Type:               hivev1.UnreachableCondition,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: since,
+	})
+}
+
+func withRemediationStatus(reason string, since metav1.Time, attempts ...hivev1.RemediationAttempt) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.Remediation = &hivev1.RemediationStatus{
+			UnhealthySince: since,
+			Reason:         reason,
+			Attempts:       attempts,
+		}
+	}
+}
+
+func condition(conditionType hivev1.ClusterDeploymentConditionType, status corev1.ConditionStatus, lastTransitionAgo time.Duration) hivev1.ClusterDeploymentCondition {
+	return hivev1.ClusterDeploymentCondition{
+		Type:               conditionType,
+		Status:             status,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-lastTransitionAgo).Truncate(time.Second)),
+	}
+}
+
+func succeededAttempt(action hivev1.RemediationActionType, startedAgo time.Duration) hivev1.RemediationAttempt {
+	return hivev1.RemediationAttempt{
+		Action:    action,
+		StartTime: metav1.NewTime(time.Now().Add(-startedAgo).Truncate(time.Second)),
+		Result:    hivev1.SucceededRemediationAttemptResult,
+	}
+}
+
+func inProgressAttempt() hivev1.RemediationAttempt {
+	return hivev1.RemediationAttempt{
+		Action:    hivev1.RestartMachinesRemediationAction,
+		StartTime: metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second)),
+		Result:    hivev1.InProgressRemediationAttemptResult,
+		Message:   "Stopping machines",
+	}
+}
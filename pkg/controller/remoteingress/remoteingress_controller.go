@@ -97,9 +97,13 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	laneReconciler, err := controllerutils.NewUnreachableLaneReconciler(r, mgr.GetClient(), ControllerName)
+	if err != nil {
+		return err
+	}
 	// Create a new controller
 	c, err := controller.New("remoteingress-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(laneReconciler, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -161,9 +165,10 @@ func (r *ReconcileRemoteClusterIngress) Reconcile(ctx context.Context, request r
 	// Initialize cluster deployment conditions if not present
 	newConditions := controllerutils.InitializeClusterDeploymentConditions(cd.Status.Conditions, clusterDeploymentRemoteIngressConditions)
 	if len(newConditions) > len(cd.Status.Conditions) {
+		origConditions := cd.Status.Conditions
 		cd.Status.Conditions = newConditions
 		cdLog.Info("initializing remote ingress controller conditions")
-		if err := r.Status().Update(context.TODO(), cd); err != nil {
+		if err := controllerutils.PatchClusterDeploymentConditions(r.Client, cd, origConditions); err != nil {
 			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
 			return reconcile.Result{}, err
 		}
@@ -338,12 +343,36 @@ func createIngressController(cd *hivev1.ClusterDeployment, ingress hivev1.Cluste
 			Namespace: remoteIngressControllerNamespace,
 		},
 		Spec: ingresscontroller.IngressControllerSpec{
-			Domain:            ingress.Domain,
-			RouteSelector:     ingress.RouteSelector,
-			NamespaceSelector: ingress.NamespaceSelector,
+			Domain:             ingress.Domain,
+			RouteSelector:      ingress.RouteSelector,
+			NamespaceSelector:  ingress.NamespaceSelector,
+			Replicas:           ingress.Replicas,
+			TLSSecurityProfile: ingress.TLSSecurityProfile,
 		},
 	}
 
+	if strategy := ingress.EndpointPublishingStrategy; strategy != nil {
+		newIngress.Spec.EndpointPublishingStrategy = &ingresscontroller.EndpointPublishingStrategy{
+			Type: ingresscontroller.EndpointPublishingStrategyType(strategy.Type),
+		}
+		if newIngress.Spec.EndpointPublishingStrategy.Type == ingresscontroller.LoadBalancerServiceStrategyType {
+			scope := ingresscontroller.ExternalLoadBalancer
+			if strategy.LoadBalancerScope != "" {
+				scope = ingresscontroller.LoadBalancerScope(strategy.LoadBalancerScope)
+			}
+			newIngress.Spec.EndpointPublishingStrategy.LoadBalancer = &ingresscontroller.LoadBalancerStrategy{
+				Scope: scope,
+			}
+		}
+	}
+
+	if placement := ingress.NodePlacement; placement != nil {
+		newIngress.Spec.NodePlacement = &ingresscontroller.NodePlacement{
+			NodeSelector: placement.NodeSelector,
+			Tolerations:  placement.Tolerations,
+		}
+	}
+
 	// if the ingress entry references a certBundle, make sure to put the appropriate looking
 	// entry in the ingressController object
 	if ingress.ServingCertificate != "" {
@@ -429,7 +458,7 @@ func (r *ReconcileRemoteClusterIngress) setIngressCertificateNotFoundCondition(r
 		hivev1.IngressCertificateNotFoundCondition, status, reason, msg, updateCheck)
 
 	if !reflect.DeepEqual(rContext.clusterDeployment.Status.Conditions, origCD.Status.Conditions) {
-		if err := r.Status().Update(context.TODO(), rContext.clusterDeployment); err != nil {
+		if err := controllerutils.PatchClusterDeploymentConditions(r.Client, rContext.clusterDeployment, origCD.Status.Conditions); err != nil {
 			rContext.logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating clusterDeployment condition")
 			return err
 		}
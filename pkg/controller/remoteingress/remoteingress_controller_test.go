@@ -14,10 +14,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	configv1 "github.com/openshift/api/config/v1"
 	ingresscontroller "github.com/openshift/api/operator/v1"
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -769,6 +771,74 @@ func validateSyncSet(t *testing.T, existingSyncSet createdSyncSetInfo, expectedS
 	return
 }
 
+func TestCreateIngressControllerFullSpec(t *testing.T) {
+	tolerations := []corev1.Toleration{{Key: "infra", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}}
+	tlsProfile := &configv1.TLSSecurityProfile{Type: configv1.TLSProfileModernType, Modern: &configv1.ModernTLSProfile{}}
+
+	tests := []struct {
+		name             string
+		ingress          hivev1.ClusterIngress
+		expectedStrategy *ingresscontroller.EndpointPublishingStrategy
+	}{
+		{
+			name: "internal load balancer",
+			ingress: hivev1.ClusterIngress{
+				EndpointPublishingStrategy: &hivev1.IngressEndpointPublishingStrategy{
+					Type:              hivev1.IngressLoadBalancerServiceStrategyType,
+					LoadBalancerScope: hivev1.IngressInternalLoadBalancerScope,
+				},
+			},
+			expectedStrategy: &ingresscontroller.EndpointPublishingStrategy{
+				Type:         ingresscontroller.LoadBalancerServiceStrategyType,
+				LoadBalancer: &ingresscontroller.LoadBalancerStrategy{Scope: ingresscontroller.InternalLoadBalancer},
+			},
+		},
+		{
+			name: "load balancer defaults to external",
+			ingress: hivev1.ClusterIngress{
+				EndpointPublishingStrategy: &hivev1.IngressEndpointPublishingStrategy{Type: hivev1.IngressLoadBalancerServiceStrategyType},
+			},
+			expectedStrategy: &ingresscontroller.EndpointPublishingStrategy{
+				Type:         ingresscontroller.LoadBalancerServiceStrategyType,
+				LoadBalancer: &ingresscontroller.LoadBalancerStrategy{Scope: ingresscontroller.ExternalLoadBalancer},
+			},
+		},
+		{
+			name: "host network",
+			ingress: hivev1.ClusterIngress{
+				EndpointPublishingStrategy: &hivev1.IngressEndpointPublishingStrategy{Type: hivev1.IngressHostNetworkStrategyType},
+			},
+			expectedStrategy: &ingresscontroller.EndpointPublishingStrategy{
+				Type: ingresscontroller.HostNetworkStrategyType,
+			},
+		},
+		{
+			name: "no strategy",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ingress := test.ingress
+			ingress.Name = "secondingress"
+			ingress.Domain = "moreingress.example.com"
+			ingress.Replicas = pointer.Int32Ptr(3)
+			ingress.NodePlacement = &hivev1.IngressNodePlacement{
+				NodeSelector: testRouteSelector(),
+				Tolerations:  tolerations,
+			}
+			ingress.TLSSecurityProfile = tlsProfile
+
+			ic := createIngressController(testClusterDeploymentWithoutIngress(), ingress, nil)
+
+			assert.Equal(t, pointer.Int32Ptr(3), ic.Spec.Replicas, "unexpected replicas")
+			assert.Equal(t, test.expectedStrategy, ic.Spec.EndpointPublishingStrategy, "unexpected endpoint publishing strategy")
+			assert.Equal(t, &ingresscontroller.NodePlacement{NodeSelector: testRouteSelector(), Tolerations: tolerations}, ic.Spec.NodePlacement, "unexpected node placement")
+			assert.Equal(t, tlsProfile, ic.Spec.TLSSecurityProfile, "unexpected TLS security profile")
+		})
+	}
+}
+
 func TestSecretHash(t *testing.T) {
 	secret1 := &corev1.Secret{
 		Data: map[string][]byte{
@@ -0,0 +1,264 @@
+// Package restorerepair provides a controller which repairs the ClusterDeployments of a hub which has been restored
+// from a Velero backup.
+package restorerepair
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	velerov1 "github.com/heptio/velero/pkg/apis/velero/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.RestoreRepairControllerName
+)
+
+var (
+	// ownedListTypes are the types of the objects in the namespace of a ClusterDeployment whose owner references are
+	// repaired.
+	ownedListTypes = []client.ObjectList{
+		&hivev1.ClusterProvisionList{},
+		&hivev1.ClusterDeprovisionList{},
+		&hivev1.ClusterStateList{},
+		&hivev1.DNSZoneList{},
+		&hivev1.MachinePoolList{},
+		&hivev1.SyncSetList{},
+		&hiveintv1alpha1.ClusterSyncList{},
+		&hiveintv1alpha1.ClusterSyncLeaseList{},
+		&corev1.SecretList{},
+		&corev1.PersistentVolumeClaimList{},
+	}
+
+	// ownerGroups are the API groups of the owners whose references are repaired.
+	ownerGroups = []string{hivev1.SchemeGroupVersion.Group, hiveintv1alpha1.SchemeGroupVersion.Group}
+)
+
+// Add creates a new RestoreRepair Controller and adds it to the Manager with default RBAC. The Manager will set fields
+// on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileRestoreRepair {
+	return &ReconcileRestoreRepair{
+		Client:        controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		repairRestore: strings.EqualFold(os.Getenv(constants.RepairAfterRestoreEnvVar), "true"),
+		argoCDEnabled: strings.EqualFold(os.Getenv(constants.ArgoCDEnvVar), "true"),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileRestoreRepair, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileRestoreRepair{}
+
+// ReconcileRestoreRepair reconciles a ClusterDeployment to repair it after it has been restored into a hub.
+type ReconcileRestoreRepair struct {
+	client.Client
+
+	// repairRestore is true when the ClusterDeployments restored by Velero are repaired without the repair
+	// annotation.
+	repairRestore bool
+
+	// argoCDEnabled is true when the ArgoCD integration is enabled, which removes its finalizer.
+	argoCDEnabled bool
+}
+
+// Reconcile repairs a ClusterDeployment when it carries the repair-after-restore annotation, or when it was restored
+// by Velero and repair after restore is enabled in HiveConfig.
+func (r *ReconcileRestoreRepair) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(ctx, request.NamespacedName, cd); {
+	case apierrors.IsNotFound(err):
+		cdLog.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		cdLog.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	if !r.needsRepair(cd) {
+		return reconcile.Result{}, nil
+	}
+	restoreName := cd.Labels[velerov1.RestoreNameLabel]
+	cdLog = cdLog.WithField("restore", restoreName)
+	cdLog.Info("repairing restored cluster deployment")
+
+	if err := r.repairOwnerReferences(ctx, cd.Namespace, cdLog); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to repair owner references")
+		return reconcile.Result{}, err
+	}
+
+	// The status of the ClusterSync is not restored, so the lease may claim that SyncSets were applied which are not
+	// recorded as applied. Without the lease, the clustersync controller reapplies all SyncSets.
+	lease := &hiveintv1alpha1.ClusterSyncLease{}
+	lease.Namespace, lease.Name = cd.Namespace, cd.Name
+	if err := r.Delete(ctx, lease); err != nil && !apierrors.IsNotFound(err) {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to delete cluster sync lease")
+		return reconcile.Result{}, err
+	}
+
+	if !r.argoCDEnabled && controllerutil.ContainsFinalizer(cd, hivev1.FinalizerArgoCDCluster) {
+		cdLog.Info("removing ArgoCD finalizer as the ArgoCD integration is disabled")
+		controllerutil.RemoveFinalizer(cd, hivev1.FinalizerArgoCDCluster)
+	}
+	delete(cd.Annotations, constants.RepairAfterRestoreAnnotation)
+	if restoreName != "" {
+		if cd.Annotations == nil {
+			cd.Annotations = map[string]string{}
+		}
+		cd.Annotations[constants.RestoreRepairedAnnotation] = restoreName
+	}
+	if err := r.Update(ctx, cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment")
+		return reconcile.Result{}, err
+	}
+	cdLog.Info("repaired restored cluster deployment")
+	return reconcile.Result{}, nil
+}
+
+// needsRepair returns true when the ClusterDeployment is requested to be repaired with the repair annotation, or when
+// it was restored by Velero, has not been repaired after that restore, and repair after restore is enabled.
+func (r *ReconcileRestoreRepair) needsRepair(cd *hivev1.ClusterDeployment) bool {
+	if cd.Annotations[constants.RepairAfterRestoreAnnotation] == "true" {
+		return true
+	}
+	restoreName := cd.Labels[velerov1.RestoreNameLabel]
+	return r.repairRestore && restoreName != "" && cd.Annotations[constants.RestoreRepairedAnnotation] != restoreName
+}
+
+// repairOwnerReferences updates the UIDs of the owner references of the objects in the namespace which refer to Hive
+// objects that have been recreated with new UIDs. Owner references to objects which do not exist are left to the
+// garbage collector.
+func (r *ReconcileRestoreRepair) repairOwnerReferences(ctx context.Context, namespace string, logger log.FieldLogger) error {
+	objects, err := controllerutils.ListRuntimeObjects(r, ownedListTypes, client.InNamespace(namespace))
+	if err != nil {
+		return errors.Wrap(err, "could not list objects in namespace")
+	}
+	owners := map[string]types.UID{}
+	for _, o := range objects {
+		obj, ok := o.(client.Object)
+		if !ok {
+			continue
+		}
+		changed := false
+		refs := obj.GetOwnerReferences()
+		for i, ref := range refs {
+			uid, found, err := r.ownerUID(ctx, namespace, ref, owners)
+			if err != nil {
+				return err
+			}
+			if !found || uid == ref.UID {
+				continue
+			}
+			logger.WithFields(log.Fields{
+				"object":    obj.GetName(),
+				"ownerKind": ref.Kind,
+				"owner":     ref.Name,
+			}).Info("repairing owner reference")
+			refs[i].UID = uid
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		obj.SetOwnerReferences(refs)
+		if err := r.Update(ctx, obj); err != nil {
+			return errors.Wrapf(err, "could not update owner references of %s", obj.GetName())
+		}
+	}
+	return nil
+}
+
+// ownerUID returns the UID of the owner of an owner reference, if it is a Hive object which exists. UIDs are cached in
+// owners, keyed by the kind and name of the owner.
+func (r *ReconcileRestoreRepair) ownerUID(ctx context.Context, namespace string, ref metav1.OwnerReference, owners map[string]types.UID) (types.UID, bool, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil || !isOwnerGroup(gv.Group) {
+		return "", false, nil
+	}
+	key := fmt.Sprintf("%s/%s", gv.WithKind(ref.Kind).GroupKind(), ref.Name)
+	if uid, ok := owners[key]; ok {
+		return uid, uid != "", nil
+	}
+	owner := &unstructured.Unstructured{}
+	owner.SetGroupVersionKind(gv.WithKind(ref.Kind))
+	switch err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, owner); {
+	case apierrors.IsNotFound(err):
+		owners[key] = ""
+		return "", false, nil
+	case err != nil:
+		return "", false, errors.Wrapf(err, "could not get owner %s", key)
+	}
+	owners[key] = owner.GetUID()
+	return owner.GetUID(), true, nil
+}
+
+func isOwnerGroup(group string) bool {
+	for _, g := range ownerGroups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
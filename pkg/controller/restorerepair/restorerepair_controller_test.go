@@ -0,0 +1,179 @@
+package restorerepair
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	velerov1 "github.com/heptio/velero/pkg/apis/velero/v1"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+const (
+	namespace   = "test-namespace"
+	cdName      = "test-cluster-deployment"
+	cdUID       = "new-cd-uid"
+	staleUID    = "old-cd-uid"
+	restoreName = "test-restore"
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).GenericOptions(testgeneric.WithUID(cdUID))
+	restored := testcd.WithLabel(velerov1.RestoreNameLabel, restoreName)
+
+	tests := []struct {
+		name                string
+		cd                  *hivev1.ClusterDeployment
+		repairRestore       bool
+		argoCDEnabled       bool
+		expectRepaired      bool
+		expectArgoFinalizer bool
+	}{
+		{
+			name: "not restored",
+			cd:   cdBuilder.Build(),
+		},
+		{
+			name: "restored, repair disabled",
+			cd:   cdBuilder.Build(restored),
+		},
+		{
+			name:           "restored, repair enabled",
+			cd:             cdBuilder.Build(restored),
+			repairRestore:  true,
+			expectRepaired: true,
+		},
+		{
+			name:          "already repaired after restore",
+			cd:            cdBuilder.Build(restored, testcd.WithAnnotation(constants.RestoreRepairedAnnotation, restoreName)),
+			repairRestore: true,
+		},
+		{
+			name:           "repaired after earlier restore",
+			cd:             cdBuilder.Build(restored, testcd.WithAnnotation(constants.RestoreRepairedAnnotation, "earlier-restore")),
+			repairRestore:  true,
+			expectRepaired: true,
+		},
+		{
+			name:           "repair annotation",
+			cd:             cdBuilder.Build(testcd.WithAnnotation(constants.RepairAfterRestoreAnnotation, "true")),
+			expectRepaired: true,
+		},
+		{
+			name:                "argocd finalizer kept when enabled",
+			cd:                  cdBuilder.Build(restored, testcd.Generic(testgeneric.WithFinalizer(hivev1.FinalizerArgoCDCluster))),
+			repairRestore:       true,
+			argoCDEnabled:       true,
+			expectRepaired:      true,
+			expectArgoFinalizer: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !test.argoCDEnabled {
+				test.cd.Finalizers = append(test.cd.Finalizers, hivev1.FinalizerArgoCDCluster)
+			}
+			staleOwnerRef := metav1.OwnerReference{
+				APIVersion: hivev1.SchemeGroupVersion.String(),
+				Kind:       "ClusterDeployment",
+				Name:       cdName,
+				UID:        staleUID,
+			}
+			clusterSync := &hiveintv1alpha1.ClusterSync{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       namespace,
+					Name:            cdName,
+					OwnerReferences: []metav1.OwnerReference{staleOwnerRef},
+				},
+			}
+			provision := &hivev1.ClusterProvision{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "test-provision",
+					OwnerReferences: []metav1.OwnerReference{
+						staleOwnerRef,
+						{APIVersion: "v1", Kind: "ConfigMap", Name: "unrelated", UID: "unrelated-uid"},
+					},
+				},
+			}
+			lease := &hiveintv1alpha1.ClusterSyncLease{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      cdName,
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: hiveintv1alpha1.SchemeGroupVersion.String(),
+						Kind:       "ClusterSync",
+						Name:       cdName,
+						UID:        "old-clustersync-uid",
+					}},
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(test.cd, clusterSync, provision, lease).Build()
+			r := &ReconcileRestoreRepair{
+				Client:        c,
+				repairRestore: test.repairRestore,
+				argoCDEnabled: test.argoCDEnabled,
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd), "could not get cluster deployment")
+			assert.NotContains(t, cd.Annotations, constants.RepairAfterRestoreAnnotation, "expected repair annotation to be removed")
+
+			err = c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, &hiveintv1alpha1.ClusterSyncLease{})
+			assert.Equal(t, test.expectRepaired, apierrors.IsNotFound(err), "unexpected presence of cluster sync lease")
+
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, clusterSync), "could not get cluster sync")
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: "test-provision"}, provision), "could not get provision")
+			if !test.expectRepaired {
+				if _, ok := test.cd.Annotations[constants.RepairAfterRestoreAnnotation]; !ok {
+					assert.Equal(t, test.cd.Annotations, cd.Annotations, "expected cluster deployment annotations to be unchanged")
+				}
+				assert.Contains(t, cd.Finalizers, hivev1.FinalizerArgoCDCluster, "expected finalizer to be unchanged")
+				assert.Equal(t, types.UID(staleUID), clusterSync.OwnerReferences[0].UID, "expected owner reference to be unchanged")
+				return
+			}
+			assert.Equal(t, types.UID(cdUID), clusterSync.OwnerReferences[0].UID, "expected cluster sync owner reference to be repaired")
+			assert.Equal(t, types.UID(cdUID), provision.OwnerReferences[0].UID, "expected provision owner reference to be repaired")
+			assert.Equal(t, types.UID("unrelated-uid"), provision.OwnerReferences[1].UID, "expected unrelated owner reference to be unchanged")
+			assert.Equal(t, test.expectArgoFinalizer, contains(cd.Finalizers, hivev1.FinalizerArgoCDCluster), "unexpected ArgoCD finalizer")
+			if test.cd.Labels[velerov1.RestoreNameLabel] != "" {
+				assert.Equal(t, restoreName, cd.Annotations[constants.RestoreRepairedAnnotation], "expected restore to be recorded")
+			}
+		})
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,274 @@
+// Package sensitiveartifacts provides a controller which deletes or encrypts the sensitive intermediate artifacts of an
+// installation once the cluster is installed, according to the policy configured in HiveConfig.
+package sensitiveartifacts
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/secretencryption"
+)
+
+const (
+	ControllerName = hivev1.SensitiveArtifactsControllerName
+)
+
+// Add creates a new SensitiveArtifacts Controller and adds it to the Manager with default RBAC when a policy for
+// sensitive artifacts is configured in HiveConfig. The Manager will set fields on the Controller and Start it when the
+// Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	config, err := readConfig()
+	if err != nil {
+		logger.WithError(err).Error("could not read the sensitive artifacts config")
+		return err
+	}
+	if config == nil {
+		logger.Debug("no sensitive artifacts policy is configured")
+		return nil
+	}
+	if config.Policy == hivev1.EncryptSensitiveArtifactsPolicy && config.KMS == nil {
+		return errors.New("the Encrypt sensitive artifacts policy requires a KMS provider")
+	}
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, config, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, config *hivev1.SensitiveArtifactsConfig, rateLimiter flowcontrol.RateLimiter) *ReconcileSensitiveArtifacts {
+	r := &ReconcileSensitiveArtifacts{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		policy: config.Policy,
+	}
+	r.providerFn = func() (secretencryption.Provider, error) {
+		return secretencryption.NewProvider(r.Client, controllerutils.GetHiveNamespace(), config.KMS)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileSensitiveArtifacts, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// readConfig reads the sensitive artifacts configuration from the file pointed to by the
+// SensitiveArtifactsConfigFileEnvVar environment variable. A nil configuration is returned when no policy is
+// configured.
+func readConfig() (*hivev1.SensitiveArtifactsConfig, error) {
+	path := os.Getenv(constants.SensitiveArtifactsConfigFileEnvVar)
+	if len(path) == 0 {
+		return nil, nil
+	}
+	fileBytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the sensitive artifacts config file")
+	}
+	if len(fileBytes) == 0 || string(fileBytes) == "null" {
+		return nil, nil
+	}
+	config := &hivev1.SensitiveArtifactsConfig{}
+	if err := json.Unmarshal(fileBytes, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the sensitive artifacts config")
+	}
+	return config, nil
+}
+
+var _ reconcile.Reconciler = &ReconcileSensitiveArtifacts{}
+
+// ReconcileSensitiveArtifacts reconciles an installed ClusterDeployment to delete or encrypt its sensitive install
+// artifacts.
+type ReconcileSensitiveArtifacts struct {
+	client.Client
+
+	// policy is what is done with the sensitive artifacts.
+	policy hivev1.SensitiveArtifactsPolicy
+
+	// providerFn returns the provider encrypting the data keys of the artifacts. It is overridden in tests.
+	providerFn func() (secretencryption.Provider, error)
+}
+
+// Reconcile applies the policy to the sensitive artifacts of an installed ClusterDeployment once, and records that it
+// did in an annotation of the ClusterDeployment.
+func (r *ReconcileSensitiveArtifacts) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(ctx, request.NamespacedName, cd); {
+	case apierrors.IsNotFound(err):
+		cdLog.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		cdLog.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	if cd.DeletionTimestamp != nil || !cd.Spec.Installed || cd.Spec.Provisioning == nil {
+		cdLog.Debug("cluster deployment was not provisioned by hive or is being deleted")
+		return reconcile.Result{}, nil
+	}
+	if cd.Annotations[constants.SensitiveArtifactsAnnotation] == string(r.policy) {
+		cdLog.Debug("sensitive artifacts policy was already applied")
+		return reconcile.Result{}, nil
+	}
+	if controllerutils.IsClusterPausedOrRelocating(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+
+	secretNames, err := r.sensitiveSecrets(ctx, cd)
+	if err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not find the sensitive artifacts")
+		return reconcile.Result{}, err
+	}
+	for _, name := range secretNames {
+		secretLog := cdLog.WithField("secret", name)
+		if err := r.applyPolicy(ctx, cd.Namespace, name, secretLog); err != nil {
+			secretLog.WithError(err).Log(controllerutils.LogLevel(err), "could not apply the sensitive artifacts policy")
+			return reconcile.Result{}, err
+		}
+	}
+
+	if cd.Annotations == nil {
+		cd.Annotations = map[string]string{}
+	}
+	cd.Annotations[constants.SensitiveArtifactsAnnotation] = string(r.policy)
+	if err := r.Update(ctx, cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not record the applied sensitive artifacts policy")
+		return reconcile.Result{}, err
+	}
+	cdLog.WithField("policy", r.policy).WithField("secrets", secretNames).Info("applied the sensitive artifacts policy")
+	return reconcile.Result{}, nil
+}
+
+// sensitiveSecrets returns the names of the secrets holding the sensitive artifacts of the installation: the
+// install-config secret, unless another ClusterDeployment waiting to be installed shares it, and the admin kubeconfig
+// and password secrets of the provisions other than the one which installed the cluster.
+func (r *ReconcileSensitiveArtifacts) sensitiveSecrets(ctx context.Context, cd *hivev1.ClusterDeployment) ([]string, error) {
+	names := sets.NewString()
+
+	if ref := cd.Spec.Provisioning.InstallConfigSecretRef; ref != nil && ref.Name != "" {
+		cds := &hivev1.ClusterDeploymentList{}
+		if err := r.List(ctx, cds, client.InNamespace(cd.Namespace)); err != nil {
+			return nil, errors.Wrap(err, "could not list cluster deployments")
+		}
+		shared := false
+		for _, other := range cds.Items {
+			if other.Name == cd.Name || other.Spec.Installed || other.Spec.Provisioning == nil {
+				continue
+			}
+			if otherRef := other.Spec.Provisioning.InstallConfigSecretRef; otherRef != nil && otherRef.Name == ref.Name {
+				shared = true
+				break
+			}
+		}
+		if !shared {
+			names.Insert(ref.Name)
+		}
+	}
+
+	inUse := sets.NewString()
+	if metadata := cd.Spec.ClusterMetadata; metadata != nil {
+		inUse.Insert(metadata.AdminKubeconfigSecretRef.Name)
+		if metadata.AdminPasswordSecretRef != nil {
+			inUse.Insert(metadata.AdminPasswordSecretRef.Name)
+		}
+	}
+	provisions := &hivev1.ClusterProvisionList{}
+	if err := r.List(ctx, provisions,
+		client.InNamespace(cd.Namespace),
+		client.MatchingLabels{constants.ClusterDeploymentNameLabel: cd.Name},
+	); err != nil {
+		return nil, errors.Wrap(err, "could not list cluster provisions")
+	}
+	for _, provision := range provisions.Items {
+		for _, ref := range []*corev1.LocalObjectReference{provision.Spec.AdminKubeconfigSecretRef, provision.Spec.AdminPasswordSecretRef} {
+			if ref != nil && ref.Name != "" && !inUse.Has(ref.Name) {
+				names.Insert(ref.Name)
+			}
+		}
+	}
+	return names.List(), nil
+}
+
+// applyPolicy deletes the secret, or envelope-encrypts its data with the KMS key.
+func (r *ReconcileSensitiveArtifacts) applyPolicy(ctx context.Context, namespace, name string, logger log.FieldLogger) error {
+	secret := &corev1.Secret{}
+	switch err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("secret not found")
+		return nil
+	case err != nil:
+		return err
+	}
+
+	switch r.policy {
+	case hivev1.DeleteSensitiveArtifactsPolicy:
+		logger.Info("deleting sensitive secret")
+		return client.IgnoreNotFound(r.Delete(ctx, secret))
+	case hivev1.EncryptSensitiveArtifactsPolicy:
+		if secretencryption.IsEncrypted(secret) {
+			logger.Debug("secret is already encrypted")
+			return nil
+		}
+		provider, err := r.providerFn()
+		if err != nil {
+			return err
+		}
+		if err := secretencryption.Encrypt(ctx, provider, secret); err != nil {
+			return err
+		}
+		logger.Info("encrypting sensitive secret")
+		return r.Update(ctx, secret)
+	default:
+		return fmt.Errorf("unknown sensitive artifacts policy %q", r.policy)
+	}
+}
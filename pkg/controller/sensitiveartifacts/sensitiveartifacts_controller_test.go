@@ -0,0 +1,184 @@
+package sensitiveartifacts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/secretencryption"
+)
+
+const (
+	namespace               = "test-namespace"
+	cdName                  = "test-cluster"
+	installConfigSecretName = "test-cluster-install-config"
+	kubeconfigSecretName    = "test-cluster-1-abcde-admin-kubeconfig"
+	failedKubeconfigName    = "test-cluster-0-fghij-admin-kubeconfig"
+)
+
+// fakeProvider "encrypts" data keys by leaving them alone.
+type fakeProvider struct{}
+
+func (fakeProvider) Name() string {
+	return "Fake"
+}
+
+func (fakeProvider) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	return key, nil
+}
+
+func (fakeProvider) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	return wrapped, nil
+}
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	clusterDeployment := func(name string, installed bool) *hivev1.ClusterDeployment {
+		cd := &hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec: hivev1.ClusterDeploymentSpec{
+				Installed: installed,
+				Provisioning: &hivev1.Provisioning{
+					InstallConfigSecretRef: &corev1.LocalObjectReference{Name: installConfigSecretName},
+				},
+			},
+		}
+		if installed {
+			cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+				AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: kubeconfigSecretName},
+			}
+		}
+		return cd
+	}
+	provision := func(name, kubeconfig string) *hivev1.ClusterProvision {
+		return &hivev1.ClusterProvision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels:    map[string]string{constants.ClusterDeploymentNameLabel: cdName},
+			},
+			Spec: hivev1.ClusterProvisionSpec{
+				ClusterDeploymentRef:     corev1.LocalObjectReference{Name: cdName},
+				AdminKubeconfigSecretRef: &corev1.LocalObjectReference{Name: kubeconfig},
+			},
+		}
+	}
+	secret := func(name string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data:       map[string][]byte{"data": []byte(name)},
+		}
+	}
+	existing := func(extra ...runtime.Object) []runtime.Object {
+		return append([]runtime.Object{
+			provision("test-cluster-0-fghij", failedKubeconfigName),
+			provision("test-cluster-1-abcde", kubeconfigSecretName),
+			secret(installConfigSecretName),
+			secret(kubeconfigSecretName),
+			secret(failedKubeconfigName),
+		}, extra...)
+	}
+
+	tests := []struct {
+		name            string
+		policy          hivev1.SensitiveArtifactsPolicy
+		cd              *hivev1.ClusterDeployment
+		existing        []runtime.Object
+		expectApplied   bool
+		expectSensitive []string
+		expectUntouched []string
+	}{
+		{
+			name:            "not installed",
+			policy:          hivev1.DeleteSensitiveArtifactsPolicy,
+			cd:              clusterDeployment(cdName, false),
+			existing:        existing(),
+			expectUntouched: []string{installConfigSecretName, kubeconfigSecretName, failedKubeconfigName},
+		},
+		{
+			name:            "delete",
+			policy:          hivev1.DeleteSensitiveArtifactsPolicy,
+			cd:              clusterDeployment(cdName, true),
+			existing:        existing(),
+			expectApplied:   true,
+			expectSensitive: []string{installConfigSecretName, failedKubeconfigName},
+			expectUntouched: []string{kubeconfigSecretName},
+		},
+		{
+			name:            "install config shared with pending cluster",
+			policy:          hivev1.DeleteSensitiveArtifactsPolicy,
+			cd:              clusterDeployment(cdName, true),
+			existing:        existing(clusterDeployment("other-cluster", false)),
+			expectApplied:   true,
+			expectSensitive: []string{failedKubeconfigName},
+			expectUntouched: []string{installConfigSecretName, kubeconfigSecretName},
+		},
+		{
+			name:            "encrypt",
+			policy:          hivev1.EncryptSensitiveArtifactsPolicy,
+			cd:              clusterDeployment(cdName, true),
+			existing:        existing(),
+			expectApplied:   true,
+			expectSensitive: []string{installConfigSecretName, failedKubeconfigName},
+			expectUntouched: []string{kubeconfigSecretName},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(append(test.existing, test.cd)...).Build()
+			r := &ReconcileSensitiveArtifacts{
+				Client:     c,
+				policy:     test.policy,
+				providerFn: func() (secretencryption.Provider, error) { return fakeProvider{}, nil },
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd))
+			if test.expectApplied {
+				assert.Equal(t, string(test.policy), cd.Annotations[constants.SensitiveArtifactsAnnotation], "expected policy to be recorded")
+			} else {
+				assert.NotContains(t, cd.Annotations, constants.SensitiveArtifactsAnnotation, "unexpected policy recorded")
+			}
+
+			for _, name := range test.expectUntouched {
+				s := &corev1.Secret{}
+				require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, s), "expected secret %s to exist", name)
+				assert.Equal(t, []byte(name), s.Data["data"], "expected secret %s to be untouched", name)
+			}
+			for _, name := range test.expectSensitive {
+				s := &corev1.Secret{}
+				err := c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, s)
+				if test.policy == hivev1.DeleteSensitiveArtifactsPolicy {
+					assert.True(t, apierrors.IsNotFound(err), "expected secret %s to be deleted", name)
+					continue
+				}
+				require.NoError(t, err, "expected secret %s to exist", name)
+				assert.True(t, secretencryption.IsEncrypted(s), "expected secret %s to be encrypted", name)
+				assert.NotEqual(t, []byte(name), s.Data["data"], "expected data of secret %s to be encrypted", name)
+				require.NoError(t, secretencryption.Decrypt(context.TODO(), fakeProvider{}, s), "could not decrypt secret %s", name)
+				assert.Equal(t, []byte(name), s.Data["data"], "unexpected decrypted data of secret %s", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+// Package spokestatus provides a controller which maintains a "hive" ClusterOperator on each installed cluster, which
+// tells the admins of the cluster which hub manages it and when the hub last acted on it.
+package spokestatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+const (
+	ControllerName = hivev1.SpokeStatusControllerName
+
+	// clusterOperatorName is the name of the ClusterOperator maintained on each cluster.
+	clusterOperatorName = "hive"
+
+	// syncSetsAppliedCondition is the Hive-specific condition of the ClusterOperator which reports whether the SyncSets
+	// of the cluster are applied.
+	syncSetsAppliedCondition configv1.ClusterStatusConditionType = "SyncSetsApplied"
+
+	// contactInterval is how often the ClusterOperator is refreshed when nothing else changed, which bounds how stale
+	// the last hub contact time is while the hub manages the cluster.
+	contactInterval = 30 * time.Minute
+)
+
+// Extension is the status of the hub reported in the extension of the "hive" ClusterOperator.
+type Extension struct {
+	// HubName identifies the hub which manages the cluster.
+	HubName string `json:"hubName"`
+
+	// ClusterDeployment is the namespace and name of the ClusterDeployment of the cluster on the hub.
+	ClusterDeployment string `json:"clusterDeployment"`
+
+	// LastSyncSetApplyTime is the time when the SyncSets of the cluster were last all applied successfully.
+	LastSyncSetApplyTime *metav1.Time `json:"lastSyncSetApplyTime,omitempty"`
+
+	// LastHubContactTime is the time when the hub last updated the ClusterOperator.
+	LastHubContactTime metav1.Time `json:"lastHubContactTime"`
+}
+
+// Add creates a new SpokeStatus Controller and adds it to the Manager with default RBAC when a hub name is configured
+// in HiveConfig. The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	hubName := os.Getenv(constants.SpokeStatusHubNameEnvVar)
+	if hubName == "" {
+		logger.Debug("spoke status is not enabled")
+		return nil
+	}
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, hubName, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, hubName string, rateLimiter flowcontrol.RateLimiter) *ReconcileSpokeStatus {
+	r := &ReconcileSpokeStatus{
+		Client:  controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		hubName: hubName,
+		now:     time.Now,
+	}
+	r.remoteClusterAPIClientBuilder = func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+		return remoteclient.NewBuilder(r.Client, cd, ControllerName)
+	}
+	return r
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileSpokeStatus, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	laneReconciler, err := controllerutils.NewUnreachableLaneReconciler(r, mgr.GetClient(), ControllerName)
+	if err != nil {
+		return err
+	}
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewStartupRampReconciler(laneReconciler, rateLimiter),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterSyncs, which share the name of their ClusterDeployment
+	if err := c.Watch(&source.Kind{Type: &hiveintv1alpha1.ClusterSync{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileSpokeStatus{}
+
+// ReconcileSpokeStatus reconciles a ClusterDeployment to maintain the "hive" ClusterOperator of its cluster.
+type ReconcileSpokeStatus struct {
+	client.Client
+
+	// hubName identifies the hub in the ClusterOperator.
+	hubName string
+
+	// remoteClusterAPIClientBuilder is a function pointer to the function that gets a builder for building a client
+	// for the remote cluster's API server
+	remoteClusterAPIClientBuilder func(cd *hivev1.ClusterDeployment) remoteclient.Builder
+
+	// now returns the current time. It is overridden in tests.
+	now func() time.Time
+}
+
+// Reconcile creates or updates the "hive" ClusterOperator of the cluster of a ClusterDeployment with the identity of
+// the hub and the state of the SyncSets of the cluster.
+func (r *ReconcileSpokeStatus) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cdLog := controllerutils.BuildControllerLogger(ControllerName, "clusterDeployment", request.NamespacedName)
+	cdLog.Debug("reconciling cluster deployment")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, cdLog)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	cd := &hivev1.ClusterDeployment{}
+	switch err := r.Get(ctx, request.NamespacedName, cd); {
+	case apierrors.IsNotFound(err):
+		cdLog.Debug("cluster deployment not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		cdLog.WithError(err).Error("error getting cluster deployment")
+		return reconcile.Result{}, err
+	}
+
+	if cd.DeletionTimestamp != nil || !cd.Spec.Installed {
+		cdLog.Debug("cluster deployment is not installed or is being deleted")
+		return reconcile.Result{}, nil
+	}
+	if controllerutils.IsClusterPausedOrRelocating(cd, cdLog) {
+		return reconcile.Result{}, nil
+	}
+	// Anything written to a fake cluster which is not simulated is discarded.
+	if controllerutils.IsFakeCluster(cd) && !controllerutils.IsSimulatedCluster(cd) {
+		return reconcile.Result{}, nil
+	}
+
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	if err := r.Get(ctx, request.NamespacedName, clusterSync); err != nil && !apierrors.IsNotFound(err) {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get cluster sync")
+		return reconcile.Result{}, err
+	}
+	lease := &hiveintv1alpha1.ClusterSyncLease{}
+	if err := r.Get(ctx, request.NamespacedName, lease); err != nil && !apierrors.IsNotFound(err) {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get cluster sync lease")
+		return reconcile.Result{}, err
+	}
+
+	remoteClient, unreachable, requeue := remoteclient.ConnectToRemoteCluster(
+		cd,
+		r.remoteClusterAPIClientBuilder(cd),
+		r.Client,
+		cdLog,
+	)
+	if unreachable {
+		return reconcile.Result{Requeue: requeue}, nil
+	}
+
+	switch fence, err := controllerutils.GetRemoteRelocationFence(remoteClient); {
+	case err != nil:
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not get relocation fence")
+		return reconcile.Result{}, err
+	case controllerutils.IsFencedOut(cd, fence, cdLog):
+		// The cluster operator reports the hub which owns the cluster.
+		return reconcile.Result{}, nil
+	}
+
+	if err := r.syncClusterOperator(ctx, cd, clusterSync, lease, remoteClient, cdLog); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "could not sync cluster operator")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{RequeueAfter: contactInterval}, nil
+}
+
+// syncClusterOperator creates the ClusterOperator in the cluster if it does not exist, and updates its status when
+// it changed or was last updated more than a contact interval ago.
+func (r *ReconcileSpokeStatus) syncClusterOperator(
+	ctx context.Context,
+	cd *hivev1.ClusterDeployment,
+	clusterSync *hiveintv1alpha1.ClusterSync,
+	lease *hiveintv1alpha1.ClusterSyncLease,
+	remoteClient client.Client,
+	logger log.FieldLogger,
+) error {
+	co := &configv1.ClusterOperator{}
+	switch err := remoteClient.Get(ctx, types.NamespacedName{Name: clusterOperatorName}, co); {
+	case apierrors.IsNotFound(err):
+		logger.Info("creating cluster operator")
+		co.Name = clusterOperatorName
+		if err := remoteClient.Create(ctx, co); err != nil {
+			return errors.Wrap(err, "could not create cluster operator")
+		}
+	case err != nil:
+		return errors.Wrap(err, "could not get cluster operator")
+	}
+
+	previous := &Extension{}
+	if co.Status.Extension.Raw != nil {
+		if err := json.Unmarshal(co.Status.Extension.Raw, previous); err != nil {
+			logger.WithError(err).Warn("could not parse extension of cluster operator")
+		}
+	}
+
+	now := metav1.NewTime(r.now())
+	extension := &Extension{
+		HubName:              r.hubName,
+		ClusterDeployment:    fmt.Sprintf("%s/%s", cd.Namespace, cd.Name),
+		LastSyncSetApplyTime: lastSyncSetApplyTime(clusterSync, lease, previous.LastSyncSetApplyTime),
+		LastHubContactTime:   previous.LastHubContactTime,
+	}
+	origStatus := co.Status.DeepCopy()
+	co.Status.Conditions = clusterOperatorConditions(co.Status.Conditions, extension, clusterSync, now)
+	if equality.Semantic.DeepEqual(origStatus.Conditions, co.Status.Conditions) && equality.Semantic.DeepEqual(previous, extension) &&
+		now.Sub(previous.LastHubContactTime.Time) < contactInterval {
+		logger.Debug("cluster operator is up to date")
+		return nil
+	}
+
+	extension.LastHubContactTime = now
+	raw, err := json.Marshal(extension)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal extension of cluster operator")
+	}
+	co.Status.Extension = runtime.RawExtension{Raw: raw}
+	logger.Debug("updating cluster operator status")
+	return errors.Wrap(remoteClient.Status().Update(ctx, co), "could not update cluster operator status")
+}
+
+// lastSyncSetApplyTime returns the time when the SyncSets of the cluster were last all applied successfully: the
+// latest of the last full reapply and of the changes to the SyncSets while none of them is failing, or the previously
+// reported time otherwise.
+func lastSyncSetApplyTime(clusterSync *hiveintv1alpha1.ClusterSync, lease *hiveintv1alpha1.ClusterSyncLease, previous *metav1.Time) *metav1.Time {
+	if clusterSync.Name == "" {
+		return previous
+	}
+	for _, cond := range clusterSync.Status.Conditions {
+		if cond.Type == hiveintv1alpha1.ClusterSyncFailed && cond.Status != corev1.ConditionFalse {
+			return previous
+		}
+	}
+	last := previous
+	later := func(t metav1.Time) {
+		if t.IsZero() {
+			return
+		}
+		if last == nil || t.After(last.Time) {
+			t := metav1.NewTime(t.Time)
+			last = &t
+		}
+	}
+	later(metav1.NewTime(lease.Spec.RenewTime.Time))
+	for _, status := range append(clusterSync.Status.SyncSets, clusterSync.Status.SelectorSyncSets...) {
+		later(status.LastTransitionTime)
+	}
+	if last != nil {
+		// The extension is serialized with a precision of seconds.
+		t := metav1.NewTime(last.Rfc3339Copy().Time)
+		last = &t
+	}
+	return last
+}
+
+// clusterOperatorConditions returns the conditions of the ClusterOperator. It is always available while the hub
+// manages the cluster, and reports failing SyncSets in its SyncSetsApplied condition. It is not degraded by failing
+// SyncSets, which do not affect the cluster itself: degraded is left for the loss of contact with the hub, which the
+// hub cannot report, and which shows as a stale last hub contact time.
+func clusterOperatorConditions(conditions []configv1.ClusterOperatorStatusCondition, extension *Extension, clusterSync *hiveintv1alpha1.ClusterSync, now metav1.Time) []configv1.ClusterOperatorStatusCondition {
+	syncSetsApplied := configv1.ClusterOperatorStatusCondition{
+		Type:    syncSetsAppliedCondition,
+		Status:  configv1.ConditionTrue,
+		Reason:  "SyncSetsApplied",
+		Message: "All SyncSets are applied",
+	}
+	for _, cond := range clusterSync.Status.Conditions {
+		if cond.Type == hiveintv1alpha1.ClusterSyncFailed && cond.Status == corev1.ConditionTrue {
+			syncSetsApplied.Status, syncSetsApplied.Reason, syncSetsApplied.Message = configv1.ConditionFalse, "SyncSetsFailing", cond.Message
+		}
+	}
+	desired := []configv1.ClusterOperatorStatusCondition{
+		{
+			Type:    configv1.OperatorAvailable,
+			Status:  configv1.ConditionTrue,
+			Reason:  "ManagedByHub",
+			Message: fmt.Sprintf("The cluster is managed by Hive on hub %s as ClusterDeployment %s", extension.HubName, extension.ClusterDeployment),
+		},
+		{
+			Type:    configv1.OperatorProgressing,
+			Status:  configv1.ConditionFalse,
+			Reason:  "ManagedByHub",
+			Message: "Hive does not roll out changes through this operator",
+		},
+		{
+			Type:    configv1.OperatorDegraded,
+			Status:  configv1.ConditionFalse,
+			Reason:  "HubInContact",
+			Message: "The hub is in contact with the cluster",
+		},
+		syncSetsApplied,
+	}
+	result := make([]configv1.ClusterOperatorStatusCondition, len(desired))
+	for i, cond := range desired {
+		cond.LastTransitionTime = now
+		for _, existing := range conditions {
+			if existing.Type == cond.Type && existing.Status == cond.Status {
+				cond.LastTransitionTime = existing.LastTransitionTime
+			}
+		}
+		result[i] = cond
+	}
+	return result
+}
@@ -0,0 +1,214 @@
+package spokestatus
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+const (
+	namespace = "test-namespace"
+	cdName    = "test-cluster-deployment"
+	hubName   = "test-hub"
+)
+
+var (
+	now       = time.Date(2026, time.January, 1, 12, 0, 0, 0, time.UTC)
+	applyTime = metav1.NewTime(now.Add(-time.Hour))
+)
+
+func TestReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+	configv1.Install(scheme)
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:   hivev1.UnreachableCondition,
+			Status: corev1.ConditionFalse,
+		}),
+	)
+	clusterSync := func(failed corev1.ConditionStatus) *hiveintv1alpha1.ClusterSync {
+		return &hiveintv1alpha1.ClusterSync{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: cdName},
+			Status: hiveintv1alpha1.ClusterSyncStatus{
+				SyncSets: []hiveintv1alpha1.SyncStatus{{
+					Name:               "test-syncset",
+					Result:             hiveintv1alpha1.SuccessSyncSetResult,
+					LastTransitionTime: applyTime,
+				}},
+				Conditions: []hiveintv1alpha1.ClusterSyncCondition{{
+					Type:    hiveintv1alpha1.ClusterSyncFailed,
+					Status:  failed,
+					Message: "SyncSet test-syncset is failing",
+				}},
+			},
+		}
+	}
+	clusterOperator := func(extension Extension) *configv1.ClusterOperator {
+		raw, err := json.Marshal(extension)
+		require.NoError(t, err)
+		co := &configv1.ClusterOperator{ObjectMeta: metav1.ObjectMeta{Name: clusterOperatorName}}
+		co.Status.Extension.Raw = raw
+		return co
+	}
+	earlierApplyTime := metav1.NewTime(now.Add(-2 * time.Hour))
+
+	tests := []struct {
+		name                string
+		cd                  *hivev1.ClusterDeployment
+		existing            []runtime.Object
+		remoteExisting      []runtime.Object
+		noRemoteCall        bool
+		expectNoOperator    bool
+		expectSyncSetsFail  bool
+		expectLastApplyTime *metav1.Time
+		expectLastContact   time.Time
+	}{
+		{
+			name:             "not installed",
+			cd:               testcd.FullBuilder(namespace, cdName, scheme).Build(),
+			noRemoteCall:     true,
+			expectNoOperator: true,
+		},
+		{
+			name:              "no cluster sync",
+			cd:                cdBuilder.Build(),
+			expectLastContact: now,
+		},
+		{
+			name:                "syncsets applied",
+			cd:                  cdBuilder.Build(),
+			existing:            []runtime.Object{clusterSync(corev1.ConditionFalse)},
+			expectLastApplyTime: &applyTime,
+			expectLastContact:   now,
+		},
+		{
+			name:     "syncsets failing",
+			cd:       cdBuilder.Build(),
+			existing: []runtime.Object{clusterSync(corev1.ConditionTrue)},
+			remoteExisting: []runtime.Object{clusterOperator(Extension{
+				LastSyncSetApplyTime: &earlierApplyTime,
+				LastHubContactTime:   metav1.NewTime(now.Add(-time.Minute)),
+			})},
+			expectSyncSetsFail:  true,
+			expectLastApplyTime: &earlierApplyTime,
+			expectLastContact:   now,
+		},
+		{
+			name:     "recently contacted",
+			cd:       cdBuilder.Build(),
+			existing: []runtime.Object{clusterSync(corev1.ConditionFalse)},
+			remoteExisting: []runtime.Object{func() runtime.Object {
+				co := clusterOperator(Extension{
+					HubName:              hubName,
+					ClusterDeployment:    namespace + "/" + cdName,
+					LastSyncSetApplyTime: &applyTime,
+					LastHubContactTime:   metav1.NewTime(now.Add(-time.Minute)),
+				})
+				co.Status.Conditions = clusterOperatorConditions(nil, &Extension{HubName: hubName, ClusterDeployment: namespace + "/" + cdName},
+					clusterSync(corev1.ConditionFalse), metav1.NewTime(now.Add(-time.Minute)))
+				return co
+			}()},
+			expectLastApplyTime: &applyTime,
+			expectLastContact:   now.Add(-time.Minute),
+		},
+		{
+			name:     "fenced out by another hub",
+			cd:       cdBuilder.Build(),
+			existing: []runtime.Object{clusterSync(corev1.ConditionFalse)},
+			remoteExisting: []runtime.Object{&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        controllerutils.RelocationFenceNamespace,
+					Annotations: map[string]string{constants.RelocationFencingTokenAnnotation: "other-hub"},
+				},
+			}},
+			expectNoOperator: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(append(test.existing, test.cd)...).Build()
+			remoteClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(test.remoteExisting...).Build()
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			if !test.noRemoteCall {
+				mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil)
+			}
+			r := &ReconcileSpokeStatus{
+				Client:                        c,
+				hubName:                       hubName,
+				remoteClusterAPIClientBuilder: func(*hivev1.ClusterDeployment) remoteclient.Builder { return mockRemoteClientBuilder },
+				now:                           func() time.Time { return now },
+			}
+
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+
+			co := &configv1.ClusterOperator{}
+			err = remoteClient.Get(context.TODO(), client.ObjectKey{Name: clusterOperatorName}, co)
+			if test.expectNoOperator {
+				assert.True(t, apierrors.IsNotFound(err), "expected no cluster operator")
+				return
+			}
+			require.NoError(t, err, "could not get cluster operator")
+
+			extension := &Extension{}
+			require.NoError(t, json.Unmarshal(co.Status.Extension.Raw, extension), "could not parse extension")
+			assert.Equal(t, hubName, extension.HubName, "unexpected hub name")
+			assert.Equal(t, namespace+"/"+cdName, extension.ClusterDeployment, "unexpected cluster deployment")
+			if test.expectLastApplyTime == nil {
+				assert.Nil(t, extension.LastSyncSetApplyTime, "expected no last syncset apply time")
+			} else if assert.NotNil(t, extension.LastSyncSetApplyTime, "expected last syncset apply time") {
+				assert.True(t, test.expectLastApplyTime.Equal(extension.LastSyncSetApplyTime), "unexpected last syncset apply time %s", extension.LastSyncSetApplyTime)
+			}
+			assert.True(t, test.expectLastContact.Equal(extension.LastHubContactTime.Time), "unexpected last hub contact time %s", extension.LastHubContactTime)
+
+			for _, cond := range co.Status.Conditions {
+				switch cond.Type {
+				case configv1.OperatorAvailable:
+					assert.Equal(t, configv1.ConditionTrue, cond.Status, "expected cluster operator to be available")
+					assert.Contains(t, cond.Message, hubName, "expected hub in available message")
+				case configv1.OperatorDegraded:
+					assert.Equal(t, configv1.ConditionFalse, cond.Status, "expected cluster operator not to be degraded")
+				case syncSetsAppliedCondition:
+					expected := configv1.ConditionTrue
+					if test.expectSyncSetsFail {
+						expected = configv1.ConditionFalse
+					}
+					assert.Equal(t, expected, cond.Status, "unexpected syncsets applied status")
+				}
+			}
+		})
+	}
+}
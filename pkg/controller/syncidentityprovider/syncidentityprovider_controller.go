@@ -67,9 +67,13 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 
 // AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	laneReconciler, err := controllerutils.NewUnreachableLaneReconciler(r, mgr.GetClient(), ControllerName)
+	if err != nil {
+		return err
+	}
 	// Create a new controller
 	c, err := controller.New(ControllerName.String()+"-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(laneReconciler, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -130,22 +134,23 @@ func (r *ReconcileSyncIdentityProviders) selectorSyncIdentityProviderWatchHandle
 
 	contextLogger := addSelectorSyncIdentityProviderLoggerFields(r.logger, ssidp)
 
-	clusterDeployments := &hivev1.ClusterDeploymentList{}
-	r.List(context.TODO(), clusterDeployments)
-
 	labelSelector, err := metav1.LabelSelectorAsSelector(&ssidp.Spec.ClusterDeploymentSelector)
 	if err != nil {
 		contextLogger.WithError(err).Error("Error converting LabelSelector to Selector")
 		return retval
 	}
 
+	clusterDeployments := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.TODO(), clusterDeployments, client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+		contextLogger.WithError(err).Error("Error listing ClusterDeployments matching SelectorSyncIdentityProvider")
+		return retval
+	}
+
 	for _, clusterDeployment := range clusterDeployments.Items {
-		if labelSelector.Matches(labels.Set(clusterDeployment.Labels)) {
-			retval = append(retval, reconcile.Request{NamespacedName: types.NamespacedName{
-				Name:      clusterDeployment.Name,
-				Namespace: clusterDeployment.Namespace,
-			}})
-		}
+		retval = append(retval, reconcile.Request{NamespacedName: types.NamespacedName{
+			Name:      clusterDeployment.Name,
+			Namespace: clusterDeployment.Namespace,
+		}})
 	}
 
 	return retval
@@ -0,0 +1,145 @@
+package tenantquota
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/flowcontrol"
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.TenantQuotaControllerName
+)
+
+// Add creates a new TenantQuota Controller and adds it to the Manager with default RBAC. The Manager will set fields on
+// the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	return AddToManager(mgr, NewReconciler(mgr, clientRateLimiter), concurrentReconciles, queueRateLimiter)
+}
+
+// NewReconciler returns a new reconcile.Reconciler
+func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) *ReconcileTenantQuota {
+	return &ReconcileTenantQuota{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileTenantQuota, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New(
+		fmt.Sprintf("%s-controller", ControllerName),
+		mgr,
+		controller.Options{
+			Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
+			MaxConcurrentReconciles: concurrentReconciles,
+			RateLimiter:             rateLimiter,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to TenantQuotas
+	if err := c.Watch(&source.Kind{Type: &hivev1.TenantQuota{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments and MachinePools, which affect the usage of quotas in their namespace
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}}, handler.EnqueueRequestsFromMapFunc(r.quotasInNamespace)); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &hivev1.MachinePool{}}, handler.EnqueueRequestsFromMapFunc(r.quotasInNamespace)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *ReconcileTenantQuota) quotasInNamespace(o client.Object) []reconcile.Request {
+	quotas := &hivev1.TenantQuotaList{}
+	if err := r.List(context.TODO(), quotas, client.InNamespace(o.GetNamespace())); err != nil {
+		r.logger.WithError(err).WithField("namespace", o.GetNamespace()).Error("failed to list TenantQuotas")
+		return nil
+	}
+	requests := make([]reconcile.Request, len(quotas.Items))
+	for i, quota := range quotas.Items {
+		requests[i].NamespacedName = types.NamespacedName{Namespace: quota.Namespace, Name: quota.Name}
+	}
+	return requests
+}
+
+var _ reconcile.Reconciler = &ReconcileTenantQuota{}
+
+// ReconcileTenantQuota reconciles a TenantQuota object to report the usage of its namespace in its status.
+type ReconcileTenantQuota struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// Reconcile updates the status of a TenantQuota with the current usage of its namespace.
+func (r *ReconcileTenantQuota) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "tenantQuota", request.NamespacedName)
+	logger.Info("reconciling tenant quota")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	quota := &hivev1.TenantQuota{}
+	switch err := r.Get(ctx, request.NamespacedName, quota); {
+	case apierrors.IsNotFound(err):
+		logger.Debug("tenant quota not found")
+		return reconcile.Result{}, nil
+	case err != nil:
+		logger.WithError(err).Error("error getting tenant quota")
+		return reconcile.Result{}, err
+	}
+
+	if quota.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := r.List(ctx, cds, client.InNamespace(quota.Namespace)); err != nil {
+		logger.WithError(err).Error("error listing cluster deployments")
+		return reconcile.Result{}, err
+	}
+	pools := &hivev1.MachinePoolList{}
+	if err := r.List(ctx, pools, client.InNamespace(quota.Namespace)); err != nil {
+		logger.WithError(err).Error("error listing machine pools")
+		return reconcile.Result{}, err
+	}
+
+	usage := controllerutils.ComputeTenantQuotaUsage(quota, cds.Items, pools.Items)
+	if reflect.DeepEqual(usage, quota.Status) {
+		logger.Debug("tenant quota usage unchanged")
+		return reconcile.Result{}, nil
+	}
+	quota.Status = usage
+	if err := r.Status().Update(ctx, quota); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update tenant quota status")
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
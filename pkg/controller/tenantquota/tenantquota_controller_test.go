@@ -0,0 +1,73 @@
+package tenantquota
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testmp "github.com/openshift/hive/pkg/test/machinepool"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testQuotaName = "test-quota"
+)
+
+func withReplicas(replicas int64, instanceType string) testmp.Option {
+	return func(pool *hivev1.MachinePool) {
+		pool.Spec.Replicas = pointer.Int64Ptr(replicas)
+		pool.Spec.Platform.AWS = &hivev1aws.MachinePoolPlatform{InstanceType: instanceType}
+	}
+}
+
+func TestReconcileTenantQuota(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	quota := &hivev1.TenantQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testQuotaName},
+		Spec: hivev1.TenantQuotaSpec{
+			MaxClusterDeployments: pointer.Int32Ptr(5),
+			InstanceFamilies:      []hivev1.InstanceFamilyQuota{{Family: "p3", MaxReplicas: 4}},
+		},
+	}
+	existing := []client.Object{
+		quota,
+		testcd.FullBuilder(testNamespace, "cd1", scheme).Build(),
+		testcd.FullBuilder(testNamespace, "cd2", scheme).Build(),
+		testcd.FullBuilder("other-namespace", "cd3", scheme).Build(),
+		testmp.FullBuilder(testNamespace, "worker", "cd1", scheme).Build(withReplicas(3, "m5.large")),
+		testmp.FullBuilder(testNamespace, "gpu", "cd2", scheme).Build(withReplicas(2, "p3.2xlarge")),
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing...).Build()
+	r := &ReconcileTenantQuota{Client: c, logger: logger}
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{
+		NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testQuotaName},
+	})
+	require.NoError(t, err, "unexpected error from Reconcile")
+
+	actual := &hivev1.TenantQuota{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testQuotaName}, actual))
+	assert.Equal(t, hivev1.TenantQuotaStatus{
+		ClusterDeployments: 2,
+		MachineReplicas:    5,
+		InstanceFamilies:   []hivev1.InstanceFamilyUsage{{Family: "p3", Replicas: 2}},
+	}, actual.Status, "unexpected tenant quota status")
+}
@@ -87,7 +87,7 @@ func NewReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) rec
 func AddToManager(mgr manager.Manager, r reconcile.Reconciler, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
 	// Create a new controller
 	c, err := controller.New("unreachable-controller", mgr, controller.Options{
-		Reconciler:              r,
+		Reconciler:              controllerutils.NewStartupRampReconciler(r, rateLimiter),
 		MaxConcurrentReconciles: concurrentReconciles,
 		RateLimiter:             rateLimiter,
 	})
@@ -140,9 +140,10 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 	// Initialize cluster deployment conditions if not present
 	newConditions := controllerutils.InitializeClusterDeploymentConditions(cd.Status.Conditions, clusterDeploymentUnreachableConditions)
 	if len(newConditions) > len(cd.Status.Conditions) {
+		origConditions := cd.Status.Conditions
 		cd.Status.Conditions = newConditions
 		cdLog.Info("initializing unreachable controller conditions")
-		if err := r.Status().Update(context.TODO(), cd); err != nil {
+		if err := controllerutils.PatchClusterDeploymentConditions(r.Client, cd, origConditions); err != nil {
 			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to update cluster deployment status")
 			return reconcile.Result{}, err
 		}
@@ -165,13 +166,24 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 		return reconcile.Result{}, nil
 	}
 
+	// A sleeping cluster is not expected to respond. Leave the conditions as they are until it wakes up, at which point
+	// the change to its power state will trigger a new reconcile.
+	if controllerutils.IsClusterSleeping(cd) {
+		cdLog.WithField("powerState", cd.Status.PowerState).Debug("not checking connectivity to sleeping cluster")
+		return reconcile.Result{}, nil
+	}
+
 	// Check whether, prior to this reconciliation, the remote cluster was considered unreachable. Also, get the
 	// last time that the unreachable check was performed.
 	wasUnreachable, lastCheck := remoteclient.Unreachable(cd)
 	// Check whether, prior to this reconciliation, connectivity to the remote cluster was using the preferred API URL.
 	wasPrimaryActive := remoteclient.IsPrimaryURLActive(cd)
 	// Determine the amount of time to wait before rechecking connectivity to a reachable remote cluster.
-	connectivityRecheckDelay := maxUnreachableDuration - time.Since(lastCheck)
+	recheckInterval := maxUnreachableDuration
+	if override := controllerutils.GetClusterOverrides(cd, cdLog).UnreachableRecheckInterval; override != nil {
+		recheckInterval = *override
+	}
+	connectivityRecheckDelay := recheckInterval - time.Since(lastCheck)
 	// Determine if it is time to recheck connectivity.
 	connectivityRecheckNeeded := wasUnreachable || connectivityRecheckDelay <= 0*time.Second
 
@@ -199,7 +211,7 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 			// to this reconciliation, then attempt to connect to the remote cluster using the fallback API URL.
 			// Even when the controller continues to reconcile a ClusterDeployment waiting for the preferred API URL to
 			// become accessible, the controller should not recheck connectivity via the fallback API URL more often
-			// than once every 2 hours.
+			// than once every recheck interval (2 hours by default).
 			if connectivityRecheckNeeded || wasPrimaryActive {
 				if _, secondaryErr := remoteClientBuilder.UseSecondaryAPIURL().Build(); secondaryErr != nil {
 					cdLog.WithError(secondaryErr).Warn("unable to create remote API client with either the initial API URL or the API URL override, marking cluster unreachable")
@@ -215,6 +227,7 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 	}
 
 	// Update conditions to reflect the current state of connectivity to the remote cluster.
+	origConditions := cd.Status.DeepCopy().Conditions
 	unreachableChanged := false
 	if updateUnreachable {
 		unreachableChanged = remoteclient.SetUnreachableCondition(cd, unreachableError)
@@ -223,10 +236,11 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 
 	// Determine when to requeue the ClusterDeployment. If there is no connectivity to the remote cluster via the
 	// preferred API URL, then requeue the ClusterDeployment using the backoff. If there is connectivity via the
-	// preferred API URL, then requeue the ClusterDeployment to sync again in 2 hours for the next connectivity re-check.
+	// preferred API URL, then requeue the ClusterDeployment to sync again after the recheck interval for the next
+	// connectivity re-check.
 	result := reconcile.Result{Requeue: primaryErr != nil}
 	if !result.Requeue {
-		result.RequeueAfter = maxUnreachableDuration
+		result.RequeueAfter = recheckInterval
 	}
 
 	// If none of the conditions have changed, stop the reconciliation now without updating the ClusterDeployment.
@@ -249,7 +263,7 @@ func (r *ReconcileRemoteMachineSet) Reconcile(ctx context.Context, request recon
 		}
 	}
 
-	err = r.Status().Update(context.TODO(), cd)
+	err = controllerutils.PatchClusterDeploymentConditions(r.Client, cd, origConditions)
 	if err != nil {
 		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating cluster deployment with unreachable condition")
 	}
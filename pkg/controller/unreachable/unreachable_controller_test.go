@@ -34,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 	testassert "github.com/openshift/hive/pkg/test/assert"
@@ -109,6 +110,28 @@ func TestReconcile(t *testing.T) {
 			expectedActiveOverrideStatus: corev1.ConditionUnknown,
 			expectRequeue:                true,
 		},
+		{
+			name: "sleeping with old reachable condition",
+			cd: buildClusterDeployment(
+				withUnreachableCondition(corev1.ConditionFalse, time.Now().Add(-maxUnreachableDuration)),
+				withActiveAPIURLOverrideCondition(corev1.ConditionUnknown),
+				testcd.WithStatusPowerState(hivev1.HibernatingHibernationReason),
+			),
+			expectedUnreachableStatus:    corev1.ConditionFalse,
+			expectedActiveOverrideStatus: corev1.ConditionUnknown,
+		},
+		{
+			name: "waking with unreachable condition",
+			cd: buildClusterDeployment(
+				withUnreachableCondition(corev1.ConditionTrue, time.Now()),
+				withActiveAPIURLOverrideCondition(corev1.ConditionUnknown),
+				testcd.WithStatusPowerState(hivev1.WaitingForNodesReadyReason),
+			),
+			errorConnecting:              pointer.BoolPtr(false),
+			expectedUnreachableStatus:    corev1.ConditionFalse,
+			expectedActiveOverrideStatus: corev1.ConditionUnknown,
+			expectRequeueAfter:           true,
+		},
 		{
 			name: "reachable with no condition",
 			cd: buildClusterDeployment(
@@ -131,6 +154,18 @@ func TestReconcile(t *testing.T) {
 			expectedActiveOverrideStatus: corev1.ConditionUnknown,
 			expectRequeueAfter:           true,
 		},
+		{
+			name: "reachable with reachable condition older than recheck interval override",
+			cd: buildClusterDeployment(
+				testcd.WithAnnotation(constants.UnreachableRecheckIntervalAnnotation, "5m"),
+				withUnreachableCondition(corev1.ConditionFalse, time.Now().Add(-10*time.Minute)),
+				withActiveAPIURLOverrideCondition(corev1.ConditionUnknown),
+			),
+			errorConnecting:              pointer.BoolPtr(false),
+			expectedUnreachableStatus:    corev1.ConditionFalse,
+			expectedActiveOverrideStatus: corev1.ConditionUnknown,
+			expectRequeueAfter:           true,
+		},
 		{
 			name: "reachable with unreachable condition",
 			cd: buildClusterDeployment(
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// NewCache returns the function creating the cache of the controller manager. Pods and Jobs are watched across all
+// namespaces, but Hive only cares about those it created, so the cache is restricted to those labelled by Hive. This
+// keeps hubs with many unrelated workloads from caching all of their pods and jobs.
+func NewCache() cache.NewCacheFunc {
+	return cache.BuilderWithOptions(cache.Options{
+		SelectorsByObject: cache.SelectorsByObject{
+			// Install pods are labelled with the name of their ClusterDeployment.
+			&corev1.Pod{}: {Label: labelExistsSelector(constants.ClusterDeploymentNameLabel)},
+			// Install, uninstall and imageset jobs are labelled with their type.
+			&batchv1.Job{}: {Label: labelExistsSelector(constants.JobTypeLabel)},
+		},
+	})
+}
+
+func labelExistsSelector(key string) labels.Selector {
+	requirement, err := labels.NewRequirement(key, selection.Exists, nil)
+	if err != nil {
+		// The key is a constant, so this can only be a programming error.
+		panic(err)
+	}
+	return labels.NewSelector().Add(*requirement)
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// DefaultClusterAccessTokenAllowedClusterRoles are the ClusterRoles which ClusterAccessTokens may bind when HiveConfig
+// does not list them.
+var DefaultClusterAccessTokenAllowedClusterRoles = []string{"view"}
+
+// ClusterAccessTokenAllowedClusterRoles returns the ClusterRoles which ClusterAccessTokens may bind, as configured in
+// HiveConfig and passed in the ClusterAccessTokenAllowedClusterRolesEnvVar environment variable.
+func ClusterAccessTokenAllowedClusterRoles() sets.String {
+	allowed := sets.NewString()
+	for _, role := range strings.Split(os.Getenv(constants.ClusterAccessTokenAllowedClusterRolesEnvVar), ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			allowed.Insert(role)
+		}
+	}
+	if allowed.Len() == 0 {
+		allowed.Insert(DefaultClusterAccessTokenAllowedClusterRoles...)
+	}
+	return allowed
+}
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestClusterAccessTokenAllowedClusterRoles(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      string
+		expected []string
+	}{
+		{
+			name:     "not configured",
+			expected: []string{"view"},
+		},
+		{
+			name:     "configured",
+			env:      "view,cluster-reader",
+			expected: []string{"cluster-reader", "view"},
+		},
+		{
+			name:     "empty entries",
+			env:      " edit ,,",
+			expected: []string{"edit"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			os.Setenv(constants.ClusterAccessTokenAllowedClusterRolesEnvVar, test.env)
+			defer os.Unsetenv(constants.ClusterAccessTokenAllowedClusterRolesEnvVar)
+			assert.Equal(t, test.expected, ClusterAccessTokenAllowedClusterRoles().List())
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,11 +20,53 @@ func IsDeleteProtected(cd *hivev1.ClusterDeployment) bool {
 	return protectedDelete && err == nil
 }
 
+// HasDeletionProtection returns true if the ClusterDeployment requires its deletion to be confirmed.
+func HasDeletionProtection(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.DeletionProtection != nil && cd.Spec.DeletionProtection.Enabled
+}
+
+// IsDeleteConfirmed returns true if the deletion of the ClusterDeployment has been confirmed via the
+// confirm-delete annotation.
+func IsDeleteConfirmed(cd *hivev1.ClusterDeployment) bool {
+	value, ok := cd.Annotations[constants.ConfirmDeleteAnnotation]
+	return ok && value == cd.Name
+}
+
+// DeletionGracePeriodRemaining returns the length of time remaining before deprovisioning of a deleted
+// ClusterDeployment with deletion protection may start.
+func DeletionGracePeriodRemaining(cd *hivev1.ClusterDeployment) time.Duration {
+	if !HasDeletionProtection(cd) || cd.Spec.DeletionProtection.GracePeriod == nil || cd.DeletionTimestamp == nil {
+		return 0
+	}
+	remaining := time.Until(cd.DeletionTimestamp.Add(cd.Spec.DeletionProtection.GracePeriod.Duration))
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
 func IsFakeCluster(cd *hivev1.ClusterDeployment) bool {
 	fakeCluster, err := strconv.ParseBool(cd.Annotations[constants.HiveFakeClusterAnnotation])
 	return fakeCluster && err == nil
 }
 
+// IsFakeClusterDeprovision returns true if the ClusterDeprovision is for a fake cluster, whose deprovision runs against
+// a simulated cloud inventory.
+func IsFakeClusterDeprovision(req *hivev1.ClusterDeprovision) bool {
+	fakeCluster, err := strconv.ParseBool(req.Annotations[constants.HiveFakeClusterAnnotation])
+	return fakeCluster && err == nil
+}
+
+// IsSimulatedCluster returns true if the ClusterDeployment is a fake cluster whose remote client should be backed by a
+// simulated cluster that persists across reconciles.
+func IsSimulatedCluster(cd *hivev1.ClusterDeployment) bool {
+	if !IsFakeCluster(cd) {
+		return false
+	}
+	simulated, err := strconv.ParseBool(cd.Annotations[constants.HiveSimulatedClusterAnnotation])
+	return simulated && err == nil
+}
+
 // IsClusterPausedOrRelocating checks if the syncing to the cluster is paused or if the cluster is relocating
 func IsClusterPausedOrRelocating(cd *hivev1.ClusterDeployment, logger log.FieldLogger) bool {
 	if paused, err := strconv.ParseBool(cd.Annotations[constants.SyncsetPauseAnnotation]); err == nil && paused {
@@ -38,6 +81,23 @@ func IsClusterPausedOrRelocating(cd *hivev1.ClusterDeployment, logger log.FieldL
 	return false
 }
 
+// IsClusterSleeping returns true if the ClusterDeployment's power state shows that the cluster is hibernating, or
+// that its machines are stopping or starting, so that its API server cannot be expected to respond.
+func IsClusterSleeping(cd *hivev1.ClusterDeployment) bool {
+	switch cd.Status.PowerState {
+	case hivev1.StoppingHibernationReason,
+		hivev1.WaitingForMachinesToStopHibernatingReason,
+		hivev1.FailedToStopHibernationReason,
+		hivev1.HibernatingHibernationReason,
+		hivev1.StartingMachinesReadyReason,
+		hivev1.WaitingForMachinesReadyReason,
+		hivev1.FailedToStartMachinesReadyReason:
+		return true
+	default:
+		return false
+	}
+}
+
 func IsRelocating(obj metav1.Object) (relocateName string, status hivev1.RelocateStatus, err error) {
 	relocateValue, ok := obj.GetAnnotations()[constants.RelocateAnnotation]
 	if !ok {
@@ -60,6 +60,59 @@ func TestIsDeleteProtected(t *testing.T) {
 	}
 }
 
+func TestIsSimulatedCluster(t *testing.T) {
+	cases := []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			name:     "no annotations",
+			expected: false,
+		},
+		{
+			name: "fake cluster",
+			annotations: map[string]string{
+				constants.HiveFakeClusterAnnotation: "true",
+			},
+			expected: false,
+		},
+		{
+			name: "simulated fake cluster",
+			annotations: map[string]string{
+				constants.HiveFakeClusterAnnotation:      "true",
+				constants.HiveSimulatedClusterAnnotation: "true",
+			},
+			expected: true,
+		},
+		{
+			name: "simulated real cluster",
+			annotations: map[string]string{
+				constants.HiveSimulatedClusterAnnotation: "true",
+			},
+			expected: false,
+		},
+		{
+			name: "simulated annotation false",
+			annotations: map[string]string{
+				constants.HiveFakeClusterAnnotation:      "true",
+				constants.HiveSimulatedClusterAnnotation: "false",
+			},
+			expected: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var options []clusterdeployment.Option
+			for k, v := range tc.annotations {
+				options = append(options, clusterdeployment.Generic(generic.WithAnnotation(k, v)))
+			}
+			cd := clusterdeployment.Build(options...)
+			assert.Equal(t, tc.expected, IsSimulatedCluster(cd), "unexpected result")
+		})
+	}
+}
+
 func TestIsClusterPausedOrRelocating(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -108,6 +161,31 @@ func TestIsClusterPausedOrRelocating(t *testing.T) {
 	}
 }
 
+func TestIsClusterSleeping(t *testing.T) {
+	cases := []struct {
+		powerState string
+		expected   bool
+	}{
+		{powerState: "", expected: false},
+		{powerState: hivev1.RunningReadyReason, expected: false},
+		{powerState: hivev1.StoppingHibernationReason, expected: true},
+		{powerState: hivev1.WaitingForMachinesToStopHibernatingReason, expected: true},
+		{powerState: hivev1.FailedToStopHibernationReason, expected: true},
+		{powerState: hivev1.HibernatingHibernationReason, expected: true},
+		{powerState: hivev1.StartingMachinesReadyReason, expected: true},
+		{powerState: hivev1.WaitingForMachinesReadyReason, expected: true},
+		{powerState: hivev1.FailedToStartMachinesReadyReason, expected: true},
+		{powerState: hivev1.WaitingForNodesReadyReason, expected: false},
+		{powerState: hivev1.WaitingForClusterOperatorsReadyReason, expected: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.powerState, func(t *testing.T) {
+			cd := clusterdeployment.Build(clusterdeployment.WithStatusPowerState(tc.powerState))
+			assert.Equal(t, tc.expected, IsClusterSleeping(cd))
+		})
+	}
+}
+
 func TestIsRelocating(t *testing.T) {
 	cases := []struct {
 		name                 string
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+)
+
+// ParseInstallerMetadata parses the metadata.json generated by the installer.
+func ParseInstallerMetadata(metadataJSON []byte) (*installertypes.ClusterMetadata, error) {
+	metadata := &installertypes.ClusterMetadata{}
+	if err := json.Unmarshal(metadataJSON, metadata); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal cluster metadata")
+	}
+	return metadata, nil
+}
+
+// ClusterPlatformMetadata returns the platform-specific fields of the installer metadata kept by Hive in the
+// ClusterMetadata of a ClusterDeployment. Returns nil if the metadata is for a platform Hive keeps no metadata for.
+func ClusterPlatformMetadata(metadata *installertypes.ClusterMetadata) *hivev1.ClusterPlatformMetadata {
+	switch {
+	case metadata.AWS != nil:
+		return &hivev1.ClusterPlatformMetadata{AWS: &hivev1aws.Metadata{
+			Region:        metadata.AWS.Region,
+			ClusterDomain: metadata.AWS.ClusterDomain,
+		}}
+	case metadata.Azure != nil:
+		return &hivev1.ClusterPlatformMetadata{Azure: &hivev1azure.Metadata{
+			Region:                      metadata.Azure.Region,
+			ResourceGroupName:           metadata.Azure.ResourceGroupName,
+			BaseDomainResourceGroupName: metadata.Azure.BaseDomainResourceGroupName,
+		}}
+	case metadata.GCP != nil:
+		return &hivev1.ClusterPlatformMetadata{GCP: &hivev1gcp.Metadata{
+			Region:    metadata.GCP.Region,
+			ProjectID: metadata.GCP.ProjectID,
+		}}
+	default:
+		return nil
+	}
+}
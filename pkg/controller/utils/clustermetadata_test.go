@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+)
+
+func TestClusterPlatformMetadata(t *testing.T) {
+	cases := []struct {
+		name     string
+		metadata string
+		expected *hivev1.ClusterPlatformMetadata
+	}{
+		{
+			name:     "aws",
+			metadata: `{"infraID":"test-infra-id","aws":{"region":"us-east-1","identifier":[{"kubernetes.io/cluster/test-infra-id":"owned"}],"clusterDomain":"test.example.com"}}`,
+			expected: &hivev1.ClusterPlatformMetadata{
+				AWS: &hivev1aws.Metadata{Region: "us-east-1", ClusterDomain: "test.example.com"},
+			},
+		},
+		{
+			name:     "azure",
+			metadata: `{"infraID":"test-infra-id","azure":{"region":"eastus","resourceGroupName":"test-rg","baseDomainResourceGroupName":"dns-rg"}}`,
+			expected: &hivev1.ClusterPlatformMetadata{
+				Azure: &hivev1azure.Metadata{Region: "eastus", ResourceGroupName: "test-rg", BaseDomainResourceGroupName: "dns-rg"},
+			},
+		},
+		{
+			name:     "gcp",
+			metadata: `{"infraID":"test-infra-id","gcp":{"region":"us-east1","projectID":"test-project"}}`,
+			expected: &hivev1.ClusterPlatformMetadata{
+				GCP: &hivev1gcp.Metadata{Region: "us-east1", ProjectID: "test-project"},
+			},
+		},
+		{
+			name:     "other platform",
+			metadata: `{"infraID":"test-infra-id","openstack":{"cloud":"test-cloud"}}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			metadata, err := ParseInstallerMetadata([]byte(tc.metadata))
+			require.NoError(t, err, "unexpected error parsing metadata")
+			assert.Equal(t, "test-infra-id", metadata.InfraID, "unexpected infra ID")
+			assert.Equal(t, tc.expected, ClusterPlatformMetadata(metadata), "unexpected platform metadata")
+		})
+	}
+}
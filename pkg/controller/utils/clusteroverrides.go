@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// ClusterOverrides holds the per-cluster overrides of controller behavior that can be set with annotations on a
+// ClusterDeployment. A nil field means the controller default applies.
+type ClusterOverrides struct {
+	// SyncSetReapplyInterval is how often the SyncSets of the cluster are fully reapplied.
+	SyncSetReapplyInterval *time.Duration
+	// MachinePoolResyncInterval is how often the MachinePools of the cluster are resynced while their machines are
+	// not all ready.
+	MachinePoolResyncInterval *time.Duration
+	// UnreachableRecheckInterval is how often connectivity to the cluster is rechecked while it is reachable.
+	UnreachableRecheckInterval *time.Duration
+}
+
+// clusterOverrideAnnotations is the registry of the annotations which override controller behavior for a cluster.
+// Each value is a duration as accepted by time.ParseDuration and must be positive.
+var clusterOverrideAnnotations = []struct {
+	annotation string
+	field      func(*ClusterOverrides) **time.Duration
+}{
+	{
+		annotation: constants.SyncSetReapplyIntervalAnnotation,
+		field:      func(o *ClusterOverrides) **time.Duration { return &o.SyncSetReapplyInterval },
+	},
+	{
+		annotation: constants.MachinePoolResyncIntervalAnnotation,
+		field:      func(o *ClusterOverrides) **time.Duration { return &o.MachinePoolResyncInterval },
+	},
+	{
+		annotation: constants.UnreachableRecheckIntervalAnnotation,
+		field:      func(o *ClusterOverrides) **time.Duration { return &o.UnreachableRecheckInterval },
+	},
+}
+
+// GetClusterOverrides returns the overrides of controller behavior set by annotations on the ClusterDeployment.
+// Invalid annotations are logged and ignored, leaving the controller default in place.
+func GetClusterOverrides(cd *hivev1.ClusterDeployment, logger log.FieldLogger) *ClusterOverrides {
+	overrides := &ClusterOverrides{}
+	for _, o := range clusterOverrideAnnotations {
+		value, ok := cd.Annotations[o.annotation]
+		if !ok {
+			continue
+		}
+		d, err := parseClusterOverrideDuration(value)
+		if err != nil {
+			logger.WithError(err).WithField("annotation", o.annotation).Warn("ignoring invalid annotation")
+			continue
+		}
+		*o.field(overrides) = &d
+	}
+	return overrides
+}
+
+// ValidateClusterOverrideAnnotations validates the values of the annotations which override controller behavior for
+// a cluster.
+func ValidateClusterOverrideAnnotations(annotations map[string]string, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, o := range clusterOverrideAnnotations {
+		value, ok := annotations[o.annotation]
+		if !ok {
+			continue
+		}
+		if _, err := parseClusterOverrideDuration(value); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Key(o.annotation), value, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func parseClusterOverrideDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	return d, nil
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/test/clusterdeployment"
+	"github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestGetClusterOverrides(t *testing.T) {
+	twoMinutes := 2 * time.Minute
+	oneHour := time.Hour
+	cases := []struct {
+		name        string
+		annotations map[string]string
+		expected    *ClusterOverrides
+		expectErrs  int
+	}{
+		{
+			name:     "no annotations",
+			expected: &ClusterOverrides{},
+		},
+		{
+			name: "all overrides",
+			annotations: map[string]string{
+				constants.SyncSetReapplyIntervalAnnotation:     "1h",
+				constants.MachinePoolResyncIntervalAnnotation:  "2m",
+				constants.UnreachableRecheckIntervalAnnotation: "120s",
+			},
+			expected: &ClusterOverrides{
+				SyncSetReapplyInterval:     &oneHour,
+				MachinePoolResyncInterval:  &twoMinutes,
+				UnreachableRecheckInterval: &twoMinutes,
+			},
+		},
+		{
+			name: "invalid overrides ignored",
+			annotations: map[string]string{
+				constants.SyncSetReapplyIntervalAnnotation:     "often",
+				constants.MachinePoolResyncIntervalAnnotation:  "-2m",
+				constants.UnreachableRecheckIntervalAnnotation: "2m",
+			},
+			expected: &ClusterOverrides{
+				UnreachableRecheckInterval: &twoMinutes,
+			},
+			expectErrs: 2,
+		},
+		{
+			name: "zero override ignored",
+			annotations: map[string]string{
+				constants.MachinePoolResyncIntervalAnnotation: "0s",
+			},
+			expected:   &ClusterOverrides{},
+			expectErrs: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var options []clusterdeployment.Option
+			for k, v := range tc.annotations {
+				options = append(options, clusterdeployment.Generic(generic.WithAnnotation(k, v)))
+			}
+			cd := clusterdeployment.Build(options...)
+			assert.Equal(t, tc.expected, GetClusterOverrides(cd, log.StandardLogger()), "unexpected overrides")
+			errs := ValidateClusterOverrideAnnotations(cd.Annotations, field.NewPath("metadata", "annotations"))
+			assert.Len(t, errs, tc.expectErrs, "unexpected validation errors")
+		})
+	}
+}
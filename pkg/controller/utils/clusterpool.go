@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// clusterPoolNamespaceTemplateData is the data available to the namespace template of a ClusterPool.
+type clusterPoolNamespaceTemplateData struct {
+	PoolName      string
+	PoolNamespace string
+}
+
+// ClusterPoolNamespacePrefix renders the namespace template of the ClusterPool into the prefix of the names of the
+// namespaces created for the clusters in the pool. The pool name is used when the pool does not have a template.
+func ClusterPoolNamespacePrefix(clp *hivev1.ClusterPool) (string, error) {
+	if clp.Spec.NamespaceTemplate == "" {
+		return clp.Name, nil
+	}
+	tmpl, err := template.New("namespace").Option("missingkey=error").Parse(clp.Spec.NamespaceTemplate)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, clusterPoolNamespaceTemplateData{
+		PoolName:      clp.Name,
+		PoolNamespace: clp.Namespace,
+	}); err != nil {
+		return "", err
+	}
+	prefix := buf.String()
+	if errs := validation.IsDNS1123Label(prefix); len(errs) > 0 {
+		return "", fmt.Errorf("namespace prefix %q is invalid: %s", prefix, strings.Join(errs, ", "))
+	}
+	return prefix, nil
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestClusterPoolNamespacePrefix(t *testing.T) {
+	cases := []struct {
+		name           string
+		template       string
+		expectedPrefix string
+		expectErr      bool
+	}{
+		{
+			name:           "no template",
+			expectedPrefix: "test-pool",
+		},
+		{
+			name:           "pool name and namespace",
+			template:       "{{.PoolNamespace}}-{{.PoolName}}",
+			expectedPrefix: "test-pool-namespace-test-pool",
+		},
+		{
+			name:           "constant",
+			template:       "ci",
+			expectedPrefix: "ci",
+		},
+		{
+			name:      "unparseable",
+			template:  "{{.PoolName",
+			expectErr: true,
+		},
+		{
+			name:      "unknown field",
+			template:  "{{.ClaimName}}",
+			expectErr: true,
+		},
+		{
+			name:      "invalid name",
+			template:  "{{.PoolName}}_Pool",
+			expectErr: true,
+		},
+		{
+			name:      "empty name",
+			template:  "{{/* nothing */}}",
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clp := &hivev1.ClusterPool{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-pool-namespace",
+					Name:      "test-pool",
+				},
+				Spec: hivev1.ClusterPoolSpec{
+					NamespaceTemplate: tc.template,
+				},
+			}
+			prefix, err := ClusterPoolNamespacePrefix(clp)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			if assert.NoError(t, err, "unexpected error") {
+				assert.Equal(t, tc.expectedPrefix, prefix, "unexpected prefix")
+			}
+		})
+	}
+}
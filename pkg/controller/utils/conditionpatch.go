@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"context"
+	"reflect"
+
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// PatchClusterDeploymentConditions saves the conditions of the ClusterDeployment which differ from origConditions, a
+// copy of the conditions it was read with. Many controllers set conditions of the same ClusterDeployment, so rather
+// than failing the reconcile when another controller has changed the ClusterDeployment in the meantime, the changed
+// conditions are merged into the latest ClusterDeployment and patched again. Only the conditions are saved, and
+// conditions removed from the ClusterDeployment are not removed.
+func PatchClusterDeploymentConditions(c client.Client, cd *hivev1.ClusterDeployment, origConditions []hivev1.ClusterDeploymentCondition) error {
+	changed := changedClusterDeploymentConditions(origConditions, cd.Status.Conditions)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	base := cd.DeepCopy()
+	base.Status.Conditions = origConditions
+	attempt := 0
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		attempt++
+		if attempt > 1 {
+			if err := c.Get(context.TODO(), client.ObjectKeyFromObject(cd), base); err != nil {
+				return err
+			}
+		}
+		patched := base.DeepCopy()
+		patched.Status.Conditions = mergeClusterDeploymentConditions(base.Status.Conditions, changed)
+		if err := c.Status().Patch(context.TODO(), patched, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})); err != nil {
+			return err
+		}
+		cd.Status.Conditions = patched.Status.Conditions
+		// The resource version is only taken up when the patch applied to what the caller read, so that a later update
+		// by the caller of other fields still conflicts with the changes of other controllers.
+		if attempt == 1 {
+			cd.ResourceVersion = patched.ResourceVersion
+		}
+		return nil
+	})
+	return err
+}
+
+// changedClusterDeploymentConditions returns the conditions which are new or different from the condition of the same
+// type in origConditions.
+func changedClusterDeploymentConditions(origConditions, conditions []hivev1.ClusterDeploymentCondition) []hivev1.ClusterDeploymentCondition {
+	var changed []hivev1.ClusterDeploymentCondition
+	for _, cond := range conditions {
+		orig := FindClusterDeploymentCondition(origConditions, cond.Type)
+		if orig == nil || !reflect.DeepEqual(*orig, cond) {
+			changed = append(changed, cond)
+		}
+	}
+	return changed
+}
+
+// mergeClusterDeploymentConditions returns a sorted copy of conditions in which the changed conditions replace the
+// conditions of the same type, or are added.
+func mergeClusterDeploymentConditions(conditions, changed []hivev1.ClusterDeploymentCondition) []hivev1.ClusterDeploymentCondition {
+	merged := make([]hivev1.ClusterDeploymentCondition, len(conditions), len(conditions)+len(changed))
+	copy(merged, conditions)
+	for _, cond := range changed {
+		found := false
+		for i := range merged {
+			if merged[i].Type == cond.Type {
+				merged[i] = cond
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, cond)
+		}
+	}
+	return SortClusterDeploymentConditions(merged)
+}
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestPatchClusterDeploymentConditions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	setCondition := func(cd *hivev1.ClusterDeployment, condType hivev1.ClusterDeploymentConditionType, status corev1.ConditionStatus) {
+		cd.Status.Conditions = SetClusterDeploymentCondition(cd.Status.Conditions, condType, status, "TestReason", "test message", UpdateConditionIfReasonOrMessageChange)
+	}
+	existingCD := func() *hivev1.ClusterDeployment {
+		cd := &hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-cd"},
+		}
+		setCondition(cd, hivev1.DNSNotReadyCondition, corev1.ConditionFalse)
+		return cd
+	}
+
+	cases := []struct {
+		name string
+		// concurrent is applied to the ClusterDeployment by another controller after it is read.
+		concurrent            func(*hivev1.ClusterDeployment)
+		set                   func(*hivev1.ClusterDeployment)
+		expectedConditions    map[hivev1.ClusterDeploymentConditionType]corev1.ConditionStatus
+		expectResourceVersion bool
+	}{
+		{
+			name: "no change",
+			set:  func(*hivev1.ClusterDeployment) {},
+			expectedConditions: map[hivev1.ClusterDeploymentConditionType]corev1.ConditionStatus{
+				hivev1.DNSNotReadyCondition: corev1.ConditionFalse,
+			},
+			expectResourceVersion: true,
+		},
+		{
+			name: "changed and added conditions",
+			set: func(cd *hivev1.ClusterDeployment) {
+				setCondition(cd, hivev1.DNSNotReadyCondition, corev1.ConditionTrue)
+				setCondition(cd, hivev1.UnreachableCondition, corev1.ConditionFalse)
+			},
+			expectedConditions: map[hivev1.ClusterDeploymentConditionType]corev1.ConditionStatus{
+				hivev1.DNSNotReadyCondition: corev1.ConditionTrue,
+				hivev1.UnreachableCondition: corev1.ConditionFalse,
+			},
+			expectResourceVersion: true,
+		},
+		{
+			name: "merged with conditions set concurrently",
+			concurrent: func(cd *hivev1.ClusterDeployment) {
+				setCondition(cd, hivev1.SyncSetFailedCondition, corev1.ConditionFalse)
+			},
+			set: func(cd *hivev1.ClusterDeployment) {
+				setCondition(cd, hivev1.UnreachableCondition, corev1.ConditionTrue)
+			},
+			expectedConditions: map[hivev1.ClusterDeploymentConditionType]corev1.ConditionStatus{
+				hivev1.DNSNotReadyCondition:   corev1.ConditionFalse,
+				hivev1.SyncSetFailedCondition: corev1.ConditionFalse,
+				hivev1.UnreachableCondition:   corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "changed condition wins over concurrent change",
+			concurrent: func(cd *hivev1.ClusterDeployment) {
+				setCondition(cd, hivev1.DNSNotReadyCondition, corev1.ConditionUnknown)
+			},
+			set: func(cd *hivev1.ClusterDeployment) {
+				setCondition(cd, hivev1.DNSNotReadyCondition, corev1.ConditionTrue)
+			},
+			expectedConditions: map[hivev1.ClusterDeploymentConditionType]corev1.ConditionStatus{
+				hivev1.DNSNotReadyCondition: corev1.ConditionTrue,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(existingCD()).Build()
+			cd := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: "test-namespace", Name: "test-cd"}, cd))
+			if tc.concurrent != nil {
+				other := cd.DeepCopy()
+				tc.concurrent(other)
+				require.NoError(t, c.Status().Update(context.TODO(), other), "unexpected error from concurrent update")
+			}
+
+			origConditions := cd.DeepCopy().Status.Conditions
+			tc.set(cd)
+			require.NoError(t, PatchClusterDeploymentConditions(c, cd, origConditions), "unexpected error patching conditions")
+
+			actual := &hivev1.ClusterDeployment{}
+			require.NoError(t, c.Get(context.TODO(), client.ObjectKeyFromObject(cd), actual))
+			assert.Len(t, actual.Status.Conditions, len(tc.expectedConditions), "unexpected number of conditions")
+			for condType, status := range tc.expectedConditions {
+				cond := FindClusterDeploymentCondition(actual.Status.Conditions, condType)
+				if assert.NotNil(t, cond, "missing %s condition", condType) {
+					assert.Equal(t, status, cond.Status, "unexpected status of %s condition", condType)
+				}
+			}
+			assert.Equal(t, actual.Status.Conditions, cd.Status.Conditions, "conditions of the ClusterDeployment not updated")
+			if tc.expectResourceVersion {
+				assert.Equal(t, actual.ResourceVersion, cd.ResourceVersion, "unexpected resource version")
+			} else {
+				assert.NotEqual(t, actual.ResourceVersion, cd.ResourceVersion, "resource version of the merged ClusterDeployment taken up")
+			}
+		})
+	}
+}
@@ -328,6 +328,27 @@ func SetClusterPoolConditionWithChangeCheck(
 	return conditions, changed
 }
 
+// InitializeClusterProvisionConditions initializes the given set of conditions for the first time, set with Status Unknown
+func InitializeClusterProvisionConditions(existingConditions []hivev1.ClusterProvisionCondition,
+	conditionsToBeAdded []hivev1.ClusterProvisionConditionType) []hivev1.ClusterProvisionCondition {
+	now := metav1.Now()
+	for _, conditionType := range conditionsToBeAdded {
+		if FindClusterProvisionCondition(existingConditions, conditionType) == nil {
+			existingConditions = append(
+				existingConditions,
+				hivev1.ClusterProvisionCondition{
+					Type:               conditionType,
+					Status:             corev1.ConditionUnknown,
+					Reason:             hivev1.InitializedConditionReason,
+					Message:            "Condition Initialized",
+					LastTransitionTime: now,
+					LastProbeTime:      now,
+				})
+		}
+	}
+	return existingConditions
+}
+
 // SetClusterProvisionCondition sets a condition on a ClusterProvision resource's status
 func SetClusterProvisionCondition(
 	conditions []hivev1.ClusterProvisionCondition,
@@ -414,6 +435,27 @@ func SetSyncCondition(
 	return conditions
 }
 
+// InitializeDNSZoneConditions initializes the given set of conditions for the first time, set with Status Unknown
+func InitializeDNSZoneConditions(existingConditions []hivev1.DNSZoneCondition,
+	conditionsToBeAdded []hivev1.DNSZoneConditionType) []hivev1.DNSZoneCondition {
+	now := metav1.Now()
+	for _, conditionType := range conditionsToBeAdded {
+		if FindDNSZoneCondition(existingConditions, conditionType) == nil {
+			existingConditions = append(
+				existingConditions,
+				hivev1.DNSZoneCondition{
+					Type:               conditionType,
+					Status:             corev1.ConditionUnknown,
+					Reason:             hivev1.InitializedConditionReason,
+					Message:            "Condition Initialized",
+					LastTransitionTime: now,
+					LastProbeTime:      now,
+				})
+		}
+	}
+	return existingConditions
+}
+
 // SetDNSZoneCondition sets a condition on a DNSZone resource's status
 func SetDNSZoneCondition(
 	conditions []hivev1.DNSZoneCondition,
@@ -568,6 +610,27 @@ func SetMachinePoolConditionWithChangeCheck(
 	return conditions, changed
 }
 
+// InitializeClusterDeprovisionConditions initializes the given set of conditions for the first time, set with Status Unknown
+func InitializeClusterDeprovisionConditions(existingConditions []hivev1.ClusterDeprovisionCondition,
+	conditionsToBeAdded []hivev1.ClusterDeprovisionConditionType) []hivev1.ClusterDeprovisionCondition {
+	now := metav1.Now()
+	for _, conditionType := range conditionsToBeAdded {
+		if FindClusterDeprovisionCondition(existingConditions, conditionType) == nil {
+			existingConditions = append(
+				existingConditions,
+				hivev1.ClusterDeprovisionCondition{
+					Type:               conditionType,
+					Status:             corev1.ConditionUnknown,
+					Reason:             hivev1.InitializedConditionReason,
+					Message:            "Condition Initialized",
+					LastTransitionTime: now,
+					LastProbeTime:      now,
+				})
+		}
+	}
+	return existingConditions
+}
+
 // SetClusterDeprovisionCondition sets a condition on a ClusterDeprovision resource's status
 func SetClusterDeprovisionCondition(
 	conditions []hivev1.ClusterDeprovisionCondition,
@@ -684,6 +747,46 @@ func SetClusterInstallConditionWithChangeCheck(
 	return conditions, changed
 }
 
+// SetHostInventoryCondition sets a condition on a HostInventory resource's status
+func SetHostInventoryCondition(
+	conditions []hivev1.HostInventoryCondition,
+	conditionType hivev1.HostInventoryConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message string,
+	updateConditionCheck UpdateConditionCheck,
+) []hivev1.HostInventoryCondition {
+	now := metav1.Now()
+	existingCondition := FindHostInventoryCondition(conditions, conditionType)
+	if existingCondition == nil {
+		return append(
+			conditions,
+			hivev1.HostInventoryCondition{
+				Type:               conditionType,
+				Status:             status,
+				Reason:             reason,
+				Message:            message,
+				LastTransitionTime: now,
+				LastProbeTime:      now,
+			},
+		)
+	}
+	if shouldUpdateCondition(
+		existingCondition.Status, existingCondition.Reason, existingCondition.Message,
+		status, reason, message,
+		updateConditionCheck,
+	) {
+		if existingCondition.Status != status {
+			existingCondition.LastTransitionTime = now
+		}
+		existingCondition.Status = status
+		existingCondition.Reason = reason
+		existingCondition.Message = message
+		existingCondition.LastProbeTime = now
+	}
+	return conditions
+}
+
 // FindClusterDeploymentCondition finds in the condition that has the
 // specified condition type in the given list. If none exists, then returns nil.
 func FindClusterDeploymentCondition(conditions []hivev1.ClusterDeploymentCondition, conditionType hivev1.ClusterDeploymentConditionType) *hivev1.ClusterDeploymentCondition {
@@ -783,6 +886,17 @@ func FindClusterInstallCondition(conditions []hivev1.ClusterInstallCondition, co
 	return nil
 }
 
+// FindHostInventoryCondition finds in the condition that has the
+// specified condition type in the given list. If none exists, then returns nil.
+func FindHostInventoryCondition(conditions []hivev1.HostInventoryCondition, conditionType hivev1.HostInventoryConditionType) *hivev1.HostInventoryCondition {
+	for i, condition := range conditions {
+		if condition.Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
 // IsConditionWithPositivePolarity checks if cluster deployment condition has positive polarity
 func IsConditionWithPositivePolarity(conditionType hivev1.ClusterDeploymentConditionType) bool {
 	for _, condition := range hivev1.PositivePolarityClusterDeploymentConditions {
@@ -14,6 +14,21 @@ import (
 	"github.com/openshift/hive/pkg/constants"
 )
 
+// DNSZoneClusterDeploymentIndex indexes DNSZones by the name of the ClusterDeployment they were created for.
+const DNSZoneClusterDeploymentIndex = "metadata.labels.clusterdeploymentname"
+
+// IndexDNSZonesByClusterDeployment adds the DNSZoneClusterDeploymentIndex to the indexer. The index is shared by
+// several controllers, so it is added once for the manager rather than by each controller.
+func IndexDNSZonesByClusterDeployment(indexer client.FieldIndexer) error {
+	return indexer.IndexField(context.TODO(), &hivev1.DNSZone{}, DNSZoneClusterDeploymentIndex,
+		func(o client.Object) []string {
+			if cdName := o.GetLabels()[constants.ClusterDeploymentNameLabel]; cdName != "" {
+				return []string{cdName}
+			}
+			return []string{}
+		})
+}
+
 func EnqueueDNSZonesOwnedByClusterDeployment(c client.Client, logger log.FieldLogger) handler.EventHandler {
 
 	return handler.EnqueueRequestsFromMapFunc(func(mapObj client.Object) []reconcile.Request {
@@ -22,6 +37,7 @@ func EnqueueDNSZonesOwnedByClusterDeployment(c client.Client, logger log.FieldLo
 			context.TODO(),
 			dnsZones,
 			client.InNamespace(mapObj.GetNamespace()),
+			client.MatchingFields{DNSZoneClusterDeploymentIndex: mapObj.GetName()},
 			client.MatchingLabels{
 				constants.DNSZoneTypeLabel:           constants.DNSZoneTypeChild,
 				constants.ClusterDeploymentNameLabel: mapObj.GetName(),
@@ -29,6 +29,11 @@ func TestErrorScrubber(t *testing.T) {
 			input:    errors.New("Request id: 0604c1a4-0a68-4d1a-b8e6-cdcf68176d71, something else"), // shouldn't really happen
 			expected: ", something else",                                                             // not pretty but what I want to verify happens
 		},
+		{
+			name:     "aws sdk v2 request id",
+			input:    errors.New("operation error STS: AssumeRole, https response error StatusCode: 403, RequestID: 0604c1a4-0a68-4d1a-b8e6-cdcf68176d71, api error AccessDenied: not authorized"),
+			expected: "operation error STS: AssumeRole, https response error StatusCode: 403, api error AccessDenied: not authorized",
+		},
 		{
 			name:     "handle nil",
 			input:    nil,
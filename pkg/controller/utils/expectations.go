@@ -207,11 +207,16 @@ func (e *ControlleeExpectations) GetExpectations() (int64, int64) {
 	return atomic.LoadInt64(&e.add), atomic.LoadInt64(&e.del)
 }
 
+// Age returns the length of time since the expectations were set.
+func (e *ControlleeExpectations) Age() time.Duration {
+	return clock.RealClock{}.Since(e.timestamp)
+}
+
 // TODO: Extend ExpirationCache to support explicit expiration.
 // TODO: Make this possible to disable in tests.
 // TODO: Support injection of clock.
 func (e *ControlleeExpectations) isExpired() bool {
-	return clock.RealClock{}.Since(e.timestamp) > ExpectationsTimeout
+	return e.Age() > ExpectationsTimeout
 }
 
 // NewExpectations returns a store for Expectations.
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// hostNetworkConfigTemplateData is the data available to the network configuration template of a HostInventory.
+type hostNetworkConfigTemplateData struct {
+	Name       string
+	Interfaces []hivev1.InventoryHostInterface
+	Vars       map[string]string
+}
+
+// RenderHostNetworkConfigs renders the network configuration template of the HostInventory for each of its hosts and
+// validates the result. It returns the parsed configurations keyed by host name. If any host is invalid, the errors
+// for all invalid hosts are returned instead.
+func RenderHostNetworkConfigs(inventory *hivev1.HostInventory) (map[string]map[string]interface{}, field.ErrorList) {
+	specPath := field.NewPath("spec")
+	templatePath := specPath.Child("networkConfigTemplate")
+	hostsPath := specPath.Child("hosts")
+
+	tmpl, err := template.New("network-config").Option("missingkey=error").Parse(inventory.Spec.NetworkConfigTemplate)
+	if err != nil {
+		return nil, field.ErrorList{field.Invalid(templatePath, inventory.Spec.NetworkConfigTemplate, err.Error())}
+	}
+	if len(inventory.Spec.Hosts) == 0 {
+		return nil, field.ErrorList{field.Required(hostsPath, "at least one host is required")}
+	}
+
+	allErrs := field.ErrorList{}
+	configs := map[string]map[string]interface{}{}
+	hostNames := sets.NewString()
+	macAddresses := sets.NewString()
+	for i, host := range inventory.Spec.Hosts {
+		hostPath := hostsPath.Index(i)
+		hostErrs := field.ErrorList{}
+		for _, msg := range validation.IsDNS1123Label(host.Name) {
+			hostErrs = append(hostErrs, field.Invalid(hostPath.Child("name"), host.Name, msg))
+		}
+		if hostNames.Has(host.Name) {
+			hostErrs = append(hostErrs, field.Duplicate(hostPath.Child("name"), host.Name))
+		}
+		hostNames.Insert(host.Name)
+
+		if len(host.Interfaces) == 0 {
+			hostErrs = append(hostErrs, field.Required(hostPath.Child("interfaces"), "at least one interface is required"))
+		}
+		interfaceNames := sets.NewString()
+		for j, iface := range host.Interfaces {
+			ifacePath := hostPath.Child("interfaces").Index(j)
+			switch {
+			case iface.Name == "":
+				hostErrs = append(hostErrs, field.Required(ifacePath.Child("name"), "interface name is required"))
+			case interfaceNames.Has(iface.Name):
+				hostErrs = append(hostErrs, field.Duplicate(ifacePath.Child("name"), iface.Name))
+			}
+			interfaceNames.Insert(iface.Name)
+			mac, err := net.ParseMAC(iface.MACAddress)
+			if err != nil {
+				hostErrs = append(hostErrs, field.Invalid(ifacePath.Child("macAddress"), iface.MACAddress, err.Error()))
+				continue
+			}
+			if macAddresses.Has(mac.String()) {
+				hostErrs = append(hostErrs, field.Duplicate(ifacePath.Child("macAddress"), iface.MACAddress))
+			}
+			macAddresses.Insert(mac.String())
+		}
+		if len(hostErrs) > 0 {
+			allErrs = append(allErrs, hostErrs...)
+			continue
+		}
+
+		config, err := renderHostNetworkConfig(tmpl, inventory, host)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(hostPath, host.Name, err.Error()))
+			continue
+		}
+		if errs := validateHostNetworkConfig(hostPath, host, interfaceNames, config); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+			continue
+		}
+		configs[host.Name] = config
+	}
+	if len(allErrs) > 0 {
+		return nil, allErrs
+	}
+	return configs, nil
+}
+
+func renderHostNetworkConfig(tmpl *template.Template, inventory *hivev1.HostInventory, host hivev1.InventoryHost) (map[string]interface{}, error) {
+	vars := map[string]string{}
+	for k, v := range inventory.Spec.Variables {
+		vars[k] = v
+	}
+	for k, v := range host.Variables {
+		vars[k] = v
+	}
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, hostNetworkConfigTemplateData{
+		Name:       host.Name,
+		Interfaces: host.Interfaces,
+		Vars:       vars,
+	}); err != nil {
+		return nil, fmt.Errorf("could not render network configuration: %v", err)
+	}
+	// The configuration is decoded the way unstructured objects are, so that it compares equal to what is read back.
+	jsonConfig, err := yaml.YAMLToJSON(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("rendered network configuration is not valid YAML: %v", err)
+	}
+	config := map[string]interface{}{}
+	if err := json.Unmarshal(jsonConfig, &config); err != nil {
+		return nil, fmt.Errorf("rendered network configuration is not a YAML object: %v", err)
+	}
+	return config, nil
+}
+
+// validateHostNetworkConfig checks that the rendered network configuration of a host lists interfaces, and that its
+// ethernet interfaces are interfaces of the host, as the assisted installer identifies them by MAC address.
+func validateHostNetworkConfig(hostPath *field.Path, host hivev1.InventoryHost, interfaceNames sets.String, config map[string]interface{}) field.ErrorList {
+	ifaces, ok := config["interfaces"].([]interface{})
+	if !ok || len(ifaces) == 0 {
+		return field.ErrorList{field.Invalid(hostPath, host.Name, "rendered network configuration has no interfaces")}
+	}
+	allErrs := field.ErrorList{}
+	for _, i := range ifaces {
+		iface, ok := i.(map[string]interface{})
+		if !ok {
+			allErrs = append(allErrs, field.Invalid(hostPath, host.Name, "rendered network configuration has an invalid interface"))
+			continue
+		}
+		name, _ := iface["name"].(string)
+		if name == "" {
+			allErrs = append(allErrs, field.Invalid(hostPath, host.Name, "rendered network configuration has an interface without a name"))
+			continue
+		}
+		if iface["type"] == "ethernet" && !interfaceNames.Has(name) {
+			allErrs = append(allErrs, field.Invalid(hostPath, host.Name,
+				fmt.Sprintf("ethernet interface %s of the rendered network configuration is not an interface of the host", name)))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,208 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const testNetworkConfigTemplate = `interfaces:
+- name: {{ (index .Interfaces 0).Name }}
+  type: ethernet
+  state: up
+  mac-address: {{ (index .Interfaces 0).MACAddress }}
+  ipv4:
+    enabled: true
+    address:
+    - ip: {{ .Vars.ip }}
+      prefix-length: {{ .Vars.prefix }}
+`
+
+func testHostInventory(hosts ...hivev1.InventoryHost) *hivev1.HostInventory {
+	return &hivev1.HostInventory{
+		Spec: hivev1.HostInventorySpec{
+			NetworkConfigTemplate: testNetworkConfigTemplate,
+			Variables:             map[string]string{"prefix": "24"},
+			Hosts:                 hosts,
+		},
+	}
+}
+
+func testInventoryHost(name, mac, ip string) hivev1.InventoryHost {
+	return hivev1.InventoryHost{
+		Name:       name,
+		Interfaces: []hivev1.InventoryHostInterface{{Name: "eth0", MACAddress: mac}},
+		Variables:  map[string]string{"ip": ip},
+	}
+}
+
+func TestRenderHostNetworkConfigs(t *testing.T) {
+	cases := []struct {
+		name            string
+		inventory       *hivev1.HostInventory
+		expectedConfigs map[string]map[string]interface{}
+		expectedErrors  []string
+	}{
+		{
+			name: "valid hosts",
+			inventory: testHostInventory(
+				testInventoryHost("master-0", "52:54:00:00:00:01", "192.168.111.10"),
+				testInventoryHost("master-1", "52:54:00:00:00:02", "192.168.111.11"),
+			),
+			expectedConfigs: map[string]map[string]interface{}{
+				"master-0": testRenderedConfig("52:54:00:00:00:01", "192.168.111.10"),
+				"master-1": testRenderedConfig("52:54:00:00:00:02", "192.168.111.11"),
+			},
+		},
+		{
+			name: "host variables override inventory variables",
+			inventory: func() *hivev1.HostInventory {
+				host := testInventoryHost("master-0", "52:54:00:00:00:01", "192.168.111.10")
+				host.Variables["prefix"] = "16"
+				return testHostInventory(host)
+			}(),
+			expectedConfigs: map[string]map[string]interface{}{
+				"master-0": func() map[string]interface{} {
+					config := testRenderedConfig("52:54:00:00:00:01", "192.168.111.10")
+					config["interfaces"].([]interface{})[0].(map[string]interface{})["ipv4"].(map[string]interface{})["address"].([]interface{})[0].(map[string]interface{})["prefix-length"] = int64(16)
+					return config
+				}(),
+			},
+		},
+		{
+			name:           "no hosts",
+			inventory:      testHostInventory(),
+			expectedErrors: []string{"spec.hosts: Required value"},
+		},
+		{
+			name: "unparseable template",
+			inventory: func() *hivev1.HostInventory {
+				inventory := testHostInventory(testInventoryHost("master-0", "52:54:00:00:00:01", "192.168.111.10"))
+				inventory.Spec.NetworkConfigTemplate = "interfaces: {{ .Vars.ip"
+				return inventory
+			}(),
+			expectedErrors: []string{"spec.networkConfigTemplate: Invalid value"},
+		},
+		{
+			name: "missing variable",
+			inventory: testHostInventory(hivev1.InventoryHost{
+				Name:       "master-0",
+				Interfaces: []hivev1.InventoryHostInterface{{Name: "eth0", MACAddress: "52:54:00:00:00:01"}},
+			}),
+			expectedErrors: []string{`spec.hosts[0]: Invalid value: "master-0": could not render network configuration`},
+		},
+		{
+			name: "invalid host name",
+			inventory: testHostInventory(
+				testInventoryHost("Master_0", "52:54:00:00:00:01", "192.168.111.10"),
+			),
+			expectedErrors: []string{"spec.hosts[0].name: Invalid value"},
+		},
+		{
+			name: "duplicate host name",
+			inventory: testHostInventory(
+				testInventoryHost("master-0", "52:54:00:00:00:01", "192.168.111.10"),
+				testInventoryHost("master-0", "52:54:00:00:00:02", "192.168.111.11"),
+			),
+			expectedErrors: []string{"spec.hosts[1].name: Duplicate value"},
+		},
+		{
+			name: "invalid MAC address",
+			inventory: testHostInventory(
+				testInventoryHost("master-0", "52:54:00:00:00", "192.168.111.10"),
+			),
+			expectedErrors: []string{"spec.hosts[0].interfaces[0].macAddress: Invalid value"},
+		},
+		{
+			name: "duplicate MAC address across hosts",
+			inventory: testHostInventory(
+				testInventoryHost("master-0", "52:54:00:00:00:01", "192.168.111.10"),
+				testInventoryHost("master-1", "52:54:00:00:00:01", "192.168.111.11"),
+			),
+			expectedErrors: []string{"spec.hosts[1].interfaces[0].macAddress: Duplicate value"},
+		},
+		{
+			name: "duplicate interface name",
+			inventory: testHostInventory(hivev1.InventoryHost{
+				Name: "master-0",
+				Interfaces: []hivev1.InventoryHostInterface{
+					{Name: "eth0", MACAddress: "52:54:00:00:00:01"},
+					{Name: "eth0", MACAddress: "52:54:00:00:00:02"},
+				},
+				Variables: map[string]string{"ip": "192.168.111.10"},
+			}),
+			expectedErrors: []string{"spec.hosts[0].interfaces[1].name: Duplicate value"},
+		},
+		{
+			name: "rendered ethernet interface is not an interface of the host",
+			inventory: func() *hivev1.HostInventory {
+				inventory := testHostInventory(testInventoryHost("master-0", "52:54:00:00:00:01", "192.168.111.10"))
+				inventory.Spec.NetworkConfigTemplate = "interfaces:\n- name: eth1\n  type: ethernet\n  state: up\n"
+				return inventory
+			}(),
+			expectedErrors: []string{"ethernet interface eth1 of the rendered network configuration is not an interface of the host"},
+		},
+		{
+			name: "rendered configuration has no interfaces",
+			inventory: func() *hivev1.HostInventory {
+				inventory := testHostInventory(testInventoryHost("master-0", "52:54:00:00:00:01", "192.168.111.10"))
+				inventory.Spec.NetworkConfigTemplate = "dns-resolver:\n  config:\n    server:\n    - {{ .Vars.ip }}\n"
+				return inventory
+			}(),
+			expectedErrors: []string{"rendered network configuration has no interfaces"},
+		},
+		{
+			name: "errors of all invalid hosts are reported",
+			inventory: testHostInventory(
+				testInventoryHost("master-0", "bad-mac", "192.168.111.10"),
+				testInventoryHost("master-1", "52:54:00:00:00:02", "192.168.111.11"),
+				testInventoryHost("master_2", "52:54:00:00:00:03", "192.168.111.12"),
+			),
+			expectedErrors: []string{
+				"spec.hosts[0].interfaces[0].macAddress: Invalid value",
+				"spec.hosts[2].name: Invalid value",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			configs, errs := RenderHostNetworkConfigs(tc.inventory)
+			if len(tc.expectedErrors) == 0 {
+				require.Empty(t, errs, "unexpected errors")
+				assert.Equal(t, tc.expectedConfigs, configs, "unexpected configs")
+				return
+			}
+			assert.Nil(t, configs, "expected no configs for an invalid inventory")
+			if assert.Len(t, errs, len(tc.expectedErrors), "unexpected number of errors") {
+				for i, expected := range tc.expectedErrors {
+					assert.Contains(t, errs[i].Error(), expected, "unexpected error")
+				}
+			}
+		})
+	}
+}
+
+func testRenderedConfig(mac, ip string) map[string]interface{} {
+	return map[string]interface{}{
+		"interfaces": []interface{}{
+			map[string]interface{}{
+				"name":        "eth0",
+				"type":        "ethernet",
+				"state":       "up",
+				"mac-address": mac,
+				"ipv4": map[string]interface{}{
+					"enabled": true,
+					"address": []interface{}{
+						map[string]interface{}{
+							"ip":            ip,
+							"prefix-length": int64(24),
+						},
+					},
+				},
+			},
+		},
+	}
+}
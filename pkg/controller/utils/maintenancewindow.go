@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// MaintenanceWindowStartTimeLayout is the layout of the start time of maintenance windows.
+const MaintenanceWindowStartTimeLayout = "15:04"
+
+// MaintenanceWindowOpen returns true if disruptive actions may start on the cluster at the given time: when the
+// cluster has no maintenance windows, when one of them is open, or when the windows are overridden by annotation.
+// Otherwise it returns how long it is until the next window opens.
+func MaintenanceWindowOpen(cd *hivev1.ClusterDeployment, now time.Time, logger log.FieldLogger) (open bool, opensIn time.Duration) {
+	if len(cd.Spec.MaintenanceWindows) == 0 {
+		return true, 0
+	}
+	if value, ok := cd.Annotations[constants.OverrideMaintenanceWindowsUntilAnnotation]; ok {
+		until, err := time.Parse(time.RFC3339, value)
+		switch {
+		case err != nil:
+			logger.WithError(err).WithField("annotation", constants.OverrideMaintenanceWindowsUntilAnnotation).Warn("ignoring invalid annotation")
+		case now.Before(until):
+			logger.WithField("until", until).Debug("maintenance windows are overridden")
+			return true, 0
+		}
+	}
+
+	now = now.UTC()
+	var next time.Time
+	for _, window := range cd.Spec.MaintenanceWindows {
+		start, err := time.Parse(MaintenanceWindowStartTimeLayout, window.StartTime)
+		if err != nil || window.Duration.Duration <= 0 {
+			logger.WithField("startTime", window.StartTime).WithField("duration", window.Duration.Duration).
+				Warn("ignoring invalid maintenance window")
+			continue
+		}
+		// Look back far enough to find a window which opened on an earlier day and is still open, and ahead a week to
+		// find the next time that the window opens.
+		lookBack := int(window.Duration.Duration/(24*time.Hour)) + 1
+		for d := -lookBack; d <= 7; d++ {
+			opens := time.Date(now.Year(), now.Month(), now.Day()+d, start.Hour(), start.Minute(), 0, 0, time.UTC)
+			if !maintenanceWindowOpensOn(window, opens.Weekday()) {
+				continue
+			}
+			switch {
+			case !now.Before(opens) && now.Before(opens.Add(window.Duration.Duration)):
+				return true, 0
+			case opens.After(now) && (next.IsZero() || opens.Before(next)):
+				next = opens
+			}
+		}
+	}
+	if next.IsZero() {
+		// None of the windows are valid. Do not hold actions back forever.
+		return true, 0
+	}
+	return false, next.Sub(now)
+}
+
+func maintenanceWindowOpensOn(window hivev1.MaintenanceWindow, day time.Weekday) bool {
+	if len(window.Days) == 0 {
+		return true
+	}
+	for _, d := range window.Days {
+		if string(d) == day.String() {
+			return true
+		}
+	}
+	return false
+}
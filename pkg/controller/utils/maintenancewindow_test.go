@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/test/clusterdeployment"
+	"github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestMaintenanceWindowOpen(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2021, time.June, 2, 12, 0, 0, 0, time.UTC)
+	window := func(startTime string, duration time.Duration, days ...hivev1.MaintenanceWindowDay) hivev1.MaintenanceWindow {
+		return hivev1.MaintenanceWindow{Days: days, StartTime: startTime, Duration: metav1.Duration{Duration: duration}}
+	}
+	cases := []struct {
+		name            string
+		windows         []hivev1.MaintenanceWindow
+		annotations     map[string]string
+		now             time.Time
+		expectedOpen    bool
+		expectedOpensIn time.Duration
+	}{
+		{
+			name:         "no windows",
+			expectedOpen: true,
+		},
+		{
+			name:         "open daily window",
+			windows:      []hivev1.MaintenanceWindow{window("11:00", 2*time.Hour)},
+			expectedOpen: true,
+		},
+		{
+			name:            "closed daily window",
+			windows:         []hivev1.MaintenanceWindow{window("13:00", time.Hour)},
+			expectedOpensIn: time.Hour,
+		},
+		{
+			name:            "window on another day",
+			windows:         []hivev1.MaintenanceWindow{window("02:00", 4*time.Hour, "Saturday", "Sunday")},
+			expectedOpensIn: 2*24*time.Hour + 14*time.Hour,
+		},
+		{
+			name:         "window opened on the previous day",
+			windows:      []hivev1.MaintenanceWindow{window("22:00", 16*time.Hour, "Tuesday")},
+			expectedOpen: true,
+		},
+		{
+			name:            "window closed earlier today",
+			windows:         []hivev1.MaintenanceWindow{window("11:00", 30*time.Minute, "Wednesday")},
+			expectedOpensIn: 7*24*time.Hour - time.Hour,
+		},
+		{
+			name: "earliest of several windows",
+			windows: []hivev1.MaintenanceWindow{
+				window("02:00", 4*time.Hour, "Saturday"),
+				window("18:30", time.Hour, "Wednesday", "Thursday"),
+			},
+			expectedOpensIn: 6*time.Hour + 30*time.Minute,
+		},
+		{
+			name:            "time in another zone",
+			windows:         []hivev1.MaintenanceWindow{window("13:00", time.Hour)},
+			now:             now.In(time.FixedZone("UTC+5", 5*60*60)),
+			expectedOpensIn: time.Hour,
+		},
+		{
+			name:         "overridden",
+			windows:      []hivev1.MaintenanceWindow{window("13:00", time.Hour)},
+			annotations:  map[string]string{constants.OverrideMaintenanceWindowsUntilAnnotation: "2021-06-02T12:30:00Z"},
+			expectedOpen: true,
+		},
+		{
+			name:            "override expired",
+			windows:         []hivev1.MaintenanceWindow{window("13:00", time.Hour)},
+			annotations:     map[string]string{constants.OverrideMaintenanceWindowsUntilAnnotation: "2021-06-02T11:30:00Z"},
+			expectedOpensIn: time.Hour,
+		},
+		{
+			name:            "invalid override",
+			windows:         []hivev1.MaintenanceWindow{window("13:00", time.Hour)},
+			annotations:     map[string]string{constants.OverrideMaintenanceWindowsUntilAnnotation: "soon"},
+			expectedOpensIn: time.Hour,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := []clusterdeployment.Option{clusterdeployment.WithMaintenanceWindows(tc.windows...)}
+			for k, v := range tc.annotations {
+				options = append(options, clusterdeployment.Generic(generic.WithAnnotation(k, v)))
+			}
+			cd := clusterdeployment.Build(options...)
+			checkTime := tc.now
+			if checkTime.IsZero() {
+				checkTime = now
+			}
+			open, opensIn := MaintenanceWindowOpen(cd, checkTime, log.StandardLogger())
+			assert.Equal(t, tc.expectedOpen, open, "unexpected open")
+			assert.Equal(t, int64(tc.expectedOpensIn), int64(opensIn), "unexpected opens in")
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// RelocationFenceNamespace is the namespace of managed clusters which is annotated with the fencing token of the
+	// hub which owns the cluster.
+	RelocationFenceNamespace = "kube-system"
+
+	relocationFencingTokenLen = 16
+)
+
+// NewRelocationFencingToken returns a new random fencing token.
+func NewRelocationFencingToken() string {
+	return utilrand.String(relocationFencingTokenLen)
+}
+
+// RelocationFencingToken returns the fencing token of the object, empty if it has none.
+func RelocationFencingToken(obj metav1.Object) string {
+	return obj.GetAnnotations()[constants.RelocationFencingTokenAnnotation]
+}
+
+// SetRelocationFencingToken sets the fencing token of the object.
+func SetRelocationFencingToken(obj metav1.Object, token string) (changed bool) {
+	annotations := obj.GetAnnotations()
+	changed = annotations[constants.RelocationFencingTokenAnnotation] != token
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[constants.RelocationFencingTokenAnnotation] = token
+	obj.SetAnnotations(annotations)
+	return
+}
+
+// IsFencedOut returns true when the managed cluster is fenced with the given token, and the token is not that of the
+// ClusterDeployment. The hub of the ClusterDeployment must then not write to the cluster, as it is owned by the hub the
+// cluster was relocated to, or back from. Clusters which have never been fenced are not fenced out.
+func IsFencedOut(cd *hivev1.ClusterDeployment, fence string, logger log.FieldLogger) bool {
+	if fence == "" || fence == RelocationFencingToken(cd) {
+		return false
+	}
+	logger.WithField("annotation", constants.RelocationFencingTokenAnnotation).
+		Warn("cluster is fenced to another hub by a relocation, not writing to it")
+	return true
+}
+
+// GetRemoteRelocationFence returns the fencing token of the managed cluster, empty if it has never been fenced.
+func GetRemoteRelocationFence(remoteClient client.Client) (string, error) {
+	ns := &corev1.Namespace{}
+	switch err := remoteClient.Get(context.Background(), types.NamespacedName{Name: RelocationFenceNamespace}, ns); {
+	case apierrors.IsNotFound(err):
+		return "", nil
+	case err != nil:
+		return "", err
+	}
+	return RelocationFencingToken(ns), nil
+}
+
+// SetRemoteRelocationFence fences the managed cluster with the given token, so that only the hub whose
+// ClusterDeployment has the token writes to it.
+func SetRemoteRelocationFence(remoteClient client.Client, token string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				constants.RelocationFencingTokenAnnotation: token,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: RelocationFenceNamespace}}
+	return remoteClient.Patch(context.Background(), ns, client.RawPatch(types.MergePatchType, patch))
+}
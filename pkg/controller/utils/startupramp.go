@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// startupRampRateLimiter wraps the queue rate limiter of a controller such that the first reconcile of every object
+// within the ramp window after the controller starts is delayed to a random point in the remainder of the window. This
+// spreads the reconciles of all objects, which are otherwise queued at once when the controller starts, over the
+// window.
+type startupRampRateLimiter struct {
+	workqueue.RateLimiter
+
+	// start is when the window begins. It is set when the controller first uses the rate limiter, which is once the
+	// manager has been elected leader and started the controller, rather than when the controller is created.
+	start  time.Time
+	window time.Duration
+	now    func() time.Time
+
+	lock sync.Mutex
+	// admitted holds the items whose first reconcile has been delayed. It is dropped once the window has passed.
+	admitted map[interface{}]bool
+}
+
+var _ workqueue.RateLimiter = &startupRampRateLimiter{}
+
+func newStartupRampRateLimiter(rateLimiter workqueue.RateLimiter, window time.Duration) *startupRampRateLimiter {
+	return &startupRampRateLimiter{
+		RateLimiter: rateLimiter,
+		window:      window,
+		now:         time.Now,
+		admitted:    map[interface{}]bool{},
+	}
+}
+
+// When implements workqueue.RateLimiter
+func (r *startupRampRateLimiter) When(item interface{}) time.Duration {
+	delay := r.RateLimiter.When(item)
+	if rampDelay := r.rampDelay(item); rampDelay > delay {
+		return rampDelay
+	}
+	return delay
+}
+
+// rampDelay returns how long the first reconcile of the item is delayed, admitting the item.
+func (r *startupRampRateLimiter) rampDelay(item interface{}) time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	remaining := r.remaining()
+	if remaining <= 0 || r.admitted[item] {
+		return 0
+	}
+	r.admitted[item] = true
+	return time.Duration(rand.Int63n(int64(remaining)))
+}
+
+// shouldDelay returns true when the item has not been reconciled yet within the ramp window.
+func (r *startupRampRateLimiter) shouldDelay(item interface{}) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.remaining() > 0 && !r.admitted[item]
+}
+
+// remaining returns how much of the ramp window is left, starting the window if it has not started yet. It must be
+// called with the lock held.
+func (r *startupRampRateLimiter) remaining() time.Duration {
+	if r.start.IsZero() {
+		r.start = r.now()
+	}
+	remaining := r.window - r.now().Sub(r.start)
+	if remaining <= 0 && r.admitted != nil {
+		r.admitted = nil
+	}
+	return remaining
+}
+
+// NewStartupRampReconciler wraps the reconciler of a controller such that, when a startup ramp is configured for the
+// controller, the first reconcile of every object within the ramp window is delayed by the queue rate limiter of the
+// controller. The events which queue objects when the controller starts bypass the rate limiter, so the first
+// reconcile of each object is requeued through it instead. The reconciler is returned as is when no startup ramp is
+// configured.
+func NewStartupRampReconciler(r reconcile.Reconciler, queueRateLimiter workqueue.RateLimiter) reconcile.Reconciler {
+	rampRateLimiter, ok := queueRateLimiter.(*startupRampRateLimiter)
+	if !ok {
+		return r
+	}
+	return &startupRampReconciler{
+		Reconciler:  r,
+		rateLimiter: rampRateLimiter,
+	}
+}
+
+type startupRampReconciler struct {
+	reconcile.Reconciler
+
+	rateLimiter *startupRampRateLimiter
+}
+
+var _ reconcile.Reconciler = &startupRampReconciler{}
+
+// Reconcile implements reconcile.Reconciler
+func (r *startupRampReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	if r.rateLimiter.shouldDelay(request) {
+		return reconcile.Result{Requeue: true}, nil
+	}
+	return r.Reconciler.Reconcile(ctx, request)
+}
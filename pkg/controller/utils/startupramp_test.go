@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type countingReconciler struct {
+	reconciles int
+}
+
+func (r *countingReconciler) Reconcile(context.Context, reconcile.Request) (reconcile.Result, error) {
+	r.reconciles++
+	return reconcile.Result{}, nil
+}
+
+func TestStartupRampRateLimiter(t *testing.T) {
+	start := time.Now()
+	now := start.Add(time.Minute)
+	rateLimiter := newStartupRampRateLimiter(workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, time.Second), 10*time.Minute)
+	rateLimiter.start = start
+	rateLimiter.now = func() time.Time { return now }
+
+	assert.True(t, rateLimiter.shouldDelay("a"), "expected first reconcile to be delayed")
+	delay := rateLimiter.When("a")
+	assert.True(t, delay >= 5*time.Millisecond && delay < 9*time.Minute, "unexpected ramp delay %s", delay)
+	assert.False(t, rateLimiter.shouldDelay("a"), "expected item to be admitted")
+	assert.Equal(t, 10*time.Millisecond, rateLimiter.When("a"), "expected only the wrapped rate limiter to apply once admitted")
+
+	now = start.Add(10 * time.Minute)
+	assert.False(t, rateLimiter.shouldDelay("b"), "expected no delay after the ramp window")
+	assert.Equal(t, 5*time.Millisecond, rateLimiter.When("b"), "expected only the wrapped rate limiter to apply after the ramp window")
+	assert.Nil(t, rateLimiter.admitted, "expected admitted items to be dropped after the ramp window")
+}
+
+func TestStartupRampRateLimiterStartsOnFirstUse(t *testing.T) {
+	now := time.Now()
+	rateLimiter := newStartupRampRateLimiter(workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, time.Second), 10*time.Minute)
+	rateLimiter.now = func() time.Time { return now }
+
+	// Time spent before the controller is started, such as waiting for the leader election, does not use up the window.
+	now = now.Add(time.Hour)
+	assert.True(t, rateLimiter.shouldDelay("a"), "expected first reconcile to be delayed")
+	assert.Equal(t, now, rateLimiter.start, "expected the window to start on first use")
+
+	now = now.Add(9 * time.Minute)
+	assert.True(t, rateLimiter.shouldDelay("b"), "expected first reconcile to be delayed within the window")
+}
+
+func TestGetQueueRateLimiterStartupRamp(t *testing.T) {
+	cases := []struct {
+		name          string
+		value         string
+		expectRamp    bool
+		expectedError bool
+	}{
+		{
+			name:       "ramp set",
+			value:      "10m",
+			expectRamp: true,
+		},
+		{
+			name:  "ramp disabled",
+			value: "0s",
+		},
+		{
+			name:          "ramp set incorrectly",
+			value:         "ten minutes",
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			key := fmt.Sprintf(StartupRampDurationEnvVariableFormat, testControllerName)
+			os.Setenv(key, tc.value)
+			defer os.Unsetenv(key)
+
+			rateLimiter, err := getQueueRateLimiter(testControllerName)
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			rampRateLimiter, isRamp := rateLimiter.(*startupRampRateLimiter)
+			assert.Equal(t, tc.expectRamp, isRamp, "unexpected rate limiter type")
+			if isRamp {
+				assert.Equal(t, 10*time.Minute, rampRateLimiter.window, "unexpected ramp window")
+			}
+		})
+	}
+}
+
+func TestStartupRampReconciler(t *testing.T) {
+	inner := &countingReconciler{}
+	assert.Same(t, inner, NewStartupRampReconciler(inner, workqueue.DefaultControllerRateLimiter()),
+		"expected reconciler to be returned as is without a startup ramp")
+
+	rateLimiter := newStartupRampRateLimiter(workqueue.DefaultControllerRateLimiter(), time.Hour)
+	r := NewStartupRampReconciler(inner, rateLimiter)
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "a"}}
+
+	result, err := r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	assert.True(t, result.Requeue, "expected first reconcile to be requeued through the rate limiter")
+	assert.Zero(t, inner.reconciles, "expected first reconcile to be delayed")
+
+	rateLimiter.When(request)
+	_, err = r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.reconciles, "expected admitted reconcile to run")
+}
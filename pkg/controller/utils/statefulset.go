@@ -29,11 +29,14 @@ func ReadStatefulsetOrDie(objBytes []byte) *appsv1.StatefulSet {
 	return requiredObj.(*appsv1.StatefulSet)
 }
 
-// CalculateStatefulSetSpecHash returns a hash of the statefulset.Spec.
+// CalculateStatefulSetSpecHash returns a hash of the statefulset.Spec. The replicas are excluded, so that a statefulset
+// can be scaled in place rather than being recreated when the hash changes.
 func CalculateStatefulSetSpecHash(statefulset *appsv1.StatefulSet) (string, error) {
 
 	hasher := md5.New()
-	jobSpecBytes, err := statefulset.Spec.Marshal()
+	spec := statefulset.Spec.DeepCopy()
+	spec.Replicas = nil
+	jobSpecBytes, err := spec.Marshal()
 	if err != nil {
 		return "", err
 	}
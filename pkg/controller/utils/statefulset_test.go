@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestCalculateStatefulSetSpecHash(t *testing.T) {
+	statefulSet := func(replicas int32, image string) *appsv1.StatefulSet {
+		sts := &appsv1.StatefulSet{}
+		sts.Spec.Replicas = pointer.Int32Ptr(replicas)
+		sts.Spec.Template.Spec.Containers = append(sts.Spec.Template.Spec.Containers, corev1.Container{Name: "hive", Image: image})
+		return sts
+	}
+	hash := func(sts *appsv1.StatefulSet) string {
+		h, err := CalculateStatefulSetSpecHash(sts)
+		require.NoError(t, err, "unexpected error calculating hash")
+		return h
+	}
+
+	original := statefulSet(1, "hive:a")
+	originalHash := hash(original)
+	assert.Equal(t, originalHash, hash(statefulSet(3, "hive:a")), "expected replicas to be excluded from the hash")
+	assert.NotEqual(t, originalHash, hash(statefulSet(1, "hive:b")), "expected template to be included in the hash")
+	assert.Equal(t, int32(1), *original.Spec.Replicas, "expected replicas of the statefulset to be unchanged")
+}
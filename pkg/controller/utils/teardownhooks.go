@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	// DefaultTeardownHookTimeout is how long a teardown hook without a timeout of its own is awaited.
+	DefaultTeardownHookTimeout = 10 * time.Minute
+
+	// TeardownHookPollInterval is how often running teardown hooks are checked, since the Jobs they create in the
+	// cluster cannot be watched.
+	TeardownHookPollInterval = 15 * time.Second
+)
+
+// ProgressTeardownHooks runs the hooks one at a time, recording their progress in statuses. Hooks are skipped when a
+// skip reason is given, or when the cluster of cd is fenced to another hub. The remote client is only built once a hook
+// needs to be applied. It returns true once every hook has completed.
+func ProgressTeardownHooks(
+	cd *hivev1.ClusterDeployment,
+	hooks []hivev1.TeardownHook,
+	statuses *[]hivev1.TeardownHookStatus,
+	skipReason string,
+	buildClient func() (client.Client, error),
+	logger log.FieldLogger,
+) bool {
+	var remoteClient client.Client
+	for _, hook := range hooks {
+		hookLog := logger.WithField("teardownHook", hook.Name)
+		status := findTeardownHookStatus(statuses, hook.Name)
+		if status.State != "" && status.State != hivev1.RunningTeardownHookState {
+			continue
+		}
+		now := metav1.Now()
+		if skipReason != "" {
+			hookLog.Infof("skipping teardown hook: %s", skipReason)
+			completeTeardownHook(status, hivev1.SkippedTeardownHookState, skipReason, now)
+			continue
+		}
+		if status.State == "" {
+			hookLog.Info("starting teardown hook")
+			status.State = hivev1.RunningTeardownHookState
+			status.StartTime = &now
+		}
+		timeout := DefaultTeardownHookTimeout
+		if hook.Timeout != nil {
+			timeout = hook.Timeout.Duration
+		}
+		if now.Sub(status.StartTime.Time) > timeout {
+			hookLog.Warn("teardown hook timed out")
+			message := fmt.Sprintf("Hook did not complete within %v", timeout)
+			if status.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, status.Message)
+			}
+			completeTeardownHook(status, hivev1.TimedOutTeardownHookState, message, now)
+			continue
+		}
+
+		if remoteClient == nil {
+			c, err := buildClient()
+			if err != nil {
+				// The cluster may only be unreachable for a while, so the hook is awaited until it times out.
+				hookLog.WithError(err).Warn("could not build client for teardown hook")
+				status.Message = fmt.Sprintf("Could not connect to the cluster: %v", err)
+				return false
+			}
+			switch fence, err := GetRemoteRelocationFence(c); {
+			case err != nil:
+				hookLog.WithError(err).Warn("could not get relocation fence for teardown hook")
+				status.Message = fmt.Sprintf("Could not get the relocation fence of the cluster: %v", err)
+				return false
+			case IsFencedOut(cd, fence, hookLog):
+				// The cluster is left to the hub which owns it, so this and the remaining hooks are skipped.
+				skipReason = "Cluster is fenced to another hub by a relocation"
+				hookLog.Infof("skipping teardown hook: %s", skipReason)
+				completeTeardownHook(status, hivev1.SkippedTeardownHookState, skipReason, now)
+				continue
+			}
+			remoteClient = c
+		}
+
+		state, message, err := applyTeardownHook(remoteClient, hook, hookLog)
+		if err != nil {
+			hookLog.WithError(err).Warn("could not apply teardown hook")
+			status.Message = err.Error()
+			return false
+		}
+		if state == hivev1.RunningTeardownHookState {
+			status.Message = message
+			return false
+		}
+		hookLog.WithField("state", state).Info("teardown hook completed")
+		completeTeardownHook(status, state, message, now)
+	}
+	return true
+}
+
+// applyTeardownHook creates the resources of the hook which do not exist in the cluster yet, and returns the state of
+// the hook according to the Jobs among them.
+func applyTeardownHook(remoteClient client.Client, hook hivev1.TeardownHook, logger log.FieldLogger) (hivev1.TeardownHookState, string, error) {
+	var waitingFor []string
+	for i, resource := range hook.Resources {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(resource.Raw); err != nil {
+			return "", "", fmt.Errorf("could not decode resource %d: %v", i, err)
+		}
+		objLog := logger.WithField("kind", obj.GetKind()).WithField("name", obj.GetName())
+
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(obj.GroupVersionKind())
+		switch err := remoteClient.Get(context.TODO(), client.ObjectKey{Namespace: obj.GetNamespace(), Name: obj.GetName()}, existing); {
+		case apierrors.IsNotFound(err):
+			objLog.Info("creating teardown hook resource")
+			if err := remoteClient.Create(context.TODO(), obj); err != nil {
+				return "", "", fmt.Errorf("could not create %s %s: %v", obj.GetKind(), obj.GetName(), err)
+			}
+			existing = obj
+		case err != nil:
+			return "", "", fmt.Errorf("could not get %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+
+		if existing.GroupVersionKind().GroupKind() != batchv1.SchemeGroupVersion.WithKind("Job").GroupKind() {
+			continue
+		}
+		job := &batchv1.Job{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(existing.Object, job); err != nil {
+			return "", "", fmt.Errorf("could not decode Job %s: %v", obj.GetName(), err)
+		}
+		switch {
+		case IsFailed(job):
+			return hivev1.FailedTeardownHookState, fmt.Sprintf("Job %s/%s failed", job.Namespace, job.Name), nil
+		case !IsSuccessful(job):
+			waitingFor = append(waitingFor, fmt.Sprintf("%s/%s", job.Namespace, job.Name))
+		}
+	}
+	if len(waitingFor) > 0 {
+		return hivev1.RunningTeardownHookState, fmt.Sprintf("Waiting for Jobs %v", waitingFor), nil
+	}
+	return hivev1.SucceededTeardownHookState, "", nil
+}
+
+// DeleteTeardownHookResources deletes the resources created in the cluster by the hooks, so that they run afresh the
+// next time they are applied. Nothing is deleted when the cluster of cd is fenced to another hub.
+func DeleteTeardownHookResources(cd *hivev1.ClusterDeployment, remoteClient client.Client, hooks []hivev1.TeardownHook, logger log.FieldLogger) error {
+	switch fence, err := GetRemoteRelocationFence(remoteClient); {
+	case err != nil:
+		return fmt.Errorf("could not get relocation fence: %v", err)
+	case IsFencedOut(cd, fence, logger):
+		return nil
+	}
+	for _, hook := range hooks {
+		for i, resource := range hook.Resources {
+			obj := &unstructured.Unstructured{}
+			if err := obj.UnmarshalJSON(resource.Raw); err != nil {
+				return fmt.Errorf("could not decode resource %d of hook %s: %v", i, hook.Name, err)
+			}
+			err := remoteClient.Delete(context.TODO(), obj, client.PropagationPolicy(metav1.DeletePropagationBackground))
+			if err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("could not delete %s %s: %v", obj.GetKind(), obj.GetName(), err)
+			}
+			logger.WithField("teardownHook", hook.Name).WithField("kind", obj.GetKind()).WithField("name", obj.GetName()).
+				Debug("deleted teardown hook resource")
+		}
+	}
+	return nil
+}
+
+func findTeardownHookStatus(statuses *[]hivev1.TeardownHookStatus, name string) *hivev1.TeardownHookStatus {
+	for i := range *statuses {
+		if (*statuses)[i].Name == name {
+			return &(*statuses)[i]
+		}
+	}
+	*statuses = append(*statuses, hivev1.TeardownHookStatus{Name: name})
+	return &(*statuses)[len(*statuses)-1]
+}
+
+func completeTeardownHook(status *hivev1.TeardownHookStatus, state hivev1.TeardownHookState, message string, now metav1.Time) {
+	status.State = state
+	status.Message = message
+	status.CompletionTime = &now
+}
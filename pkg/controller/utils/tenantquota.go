@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// MachinePoolReplicas returns the number of machine replicas counted against a TenantQuota for the MachinePool.
+// For autoscaling MachinePools the maximum number of replicas is returned.
+func MachinePoolReplicas(pool *hivev1.MachinePool) int64 {
+	switch {
+	case pool.Spec.Autoscaling != nil:
+		return int64(pool.Spec.Autoscaling.MaxReplicas)
+	case pool.Spec.Replicas != nil:
+		return *pool.Spec.Replicas
+	default:
+		return 0
+	}
+}
+
+// MachinePoolInstanceType returns the instance type used by the MachinePool, or an empty string if the platform
+// does not have a notion of instance type.
+func MachinePoolInstanceType(pool *hivev1.MachinePool) string {
+	p := pool.Spec.Platform
+	switch {
+	case p.AWS != nil:
+		return p.AWS.InstanceType
+	case p.Azure != nil:
+		return p.Azure.InstanceType
+	case p.GCP != nil:
+		return p.GCP.InstanceType
+	case p.OpenStack != nil:
+		return p.OpenStack.Flavor
+	default:
+		return ""
+	}
+}
+
+// InstanceFamily returns the family of an instance type: the part before the first "." of AWS instance types
+// (e.g. "m5a" for "m5a.xlarge") or the first "-" of GCP machine types (e.g. "n2" for "n2-standard-4"). Instance types
+// without either separator, such as Azure VM sizes, are their own family.
+func InstanceFamily(instanceType string) string {
+	if i := strings.IndexAny(instanceType, ".-"); i >= 0 {
+		return instanceType[:i]
+	}
+	return instanceType
+}
+
+// ComputeTenantQuotaUsage returns the usage of the given ClusterDeployments and MachinePools measured against the quota.
+func ComputeTenantQuotaUsage(quota *hivev1.TenantQuota, cds []hivev1.ClusterDeployment, pools []hivev1.MachinePool) hivev1.TenantQuotaStatus {
+	usage := hivev1.TenantQuotaStatus{
+		ClusterDeployments: int32(len(cds)),
+	}
+	familyReplicas := make([]int64, len(quota.Spec.InstanceFamilies))
+	for i := range pools {
+		replicas := MachinePoolReplicas(&pools[i])
+		usage.MachineReplicas += replicas
+		instanceFamily := InstanceFamily(MachinePoolInstanceType(&pools[i]))
+		for j, family := range quota.Spec.InstanceFamilies {
+			if instanceFamily != "" && instanceFamily == family.Family {
+				familyReplicas[j] += replicas
+			}
+		}
+	}
+	for i, family := range quota.Spec.InstanceFamilies {
+		usage.InstanceFamilies = append(usage.InstanceFamilies, hivev1.InstanceFamilyUsage{
+			Family:   family.Family,
+			Replicas: familyReplicas[i],
+		})
+	}
+	return usage
+}
+
+// TenantQuotaExceeded returns a description of each limit of the quota exceeded by the new usage. Limits which were
+// already exceeded by the old usage are only reported if the new usage is higher, so that changes which reduce usage
+// are always permitted.
+func TenantQuotaExceeded(quota *hivev1.TenantQuota, oldUsage, newUsage hivev1.TenantQuotaStatus) []string {
+	var exceeded []string
+	if max := quota.Spec.MaxClusterDeployments; max != nil &&
+		newUsage.ClusterDeployments > *max && newUsage.ClusterDeployments > oldUsage.ClusterDeployments {
+		exceeded = append(exceeded, fmt.Sprintf("ClusterDeployments limited to %d by TenantQuota %s", *max, quota.Name))
+	}
+	if max := quota.Spec.MaxMachineReplicas; max != nil &&
+		newUsage.MachineReplicas > *max && newUsage.MachineReplicas > oldUsage.MachineReplicas {
+		exceeded = append(exceeded, fmt.Sprintf("machine replicas limited to %d by TenantQuota %s", *max, quota.Name))
+	}
+	for i, family := range quota.Spec.InstanceFamilies {
+		var oldReplicas, newReplicas int64
+		if i < len(oldUsage.InstanceFamilies) {
+			oldReplicas = oldUsage.InstanceFamilies[i].Replicas
+		}
+		if i < len(newUsage.InstanceFamilies) {
+			newReplicas = newUsage.InstanceFamilies[i].Replicas
+		}
+		if newReplicas > family.MaxReplicas && newReplicas > oldReplicas {
+			exceeded = append(exceeded, fmt.Sprintf("%s instance family replicas limited to %d by TenantQuota %s",
+				family.Family, family.MaxReplicas, quota.Name))
+		}
+	}
+	return exceeded
+}
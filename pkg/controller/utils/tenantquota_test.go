@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+)
+
+func testQuotaPool(name, instanceType string, replicas int64) hivev1.MachinePool {
+	return hivev1.MachinePool{
+		Spec: hivev1.MachinePoolSpec{
+			Name:     name,
+			Replicas: pointer.Int64Ptr(replicas),
+			Platform: hivev1.MachinePoolPlatform{
+				AWS: &hivev1aws.MachinePoolPlatform{InstanceType: instanceType},
+			},
+		},
+	}
+}
+
+func TestComputeTenantQuotaUsage(t *testing.T) {
+	quota := &hivev1.TenantQuota{
+		Spec: hivev1.TenantQuotaSpec{
+			InstanceFamilies: []hivev1.InstanceFamilyQuota{
+				{Family: "p3", MaxReplicas: 4},
+				{Family: "m5", MaxReplicas: 10},
+			},
+		},
+	}
+	autoscaling := testQuotaPool("autoscaling", "m5.xlarge", 0)
+	autoscaling.Spec.Replicas = nil
+	autoscaling.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 1, MaxReplicas: 5}
+	pools := []hivev1.MachinePool{
+		testQuotaPool("gpu", "p3.2xlarge", 2),
+		testQuotaPool("worker", "m5.large", 3),
+		autoscaling,
+		testQuotaPool("other", "c5.large", 1),
+		testQuotaPool("variant", "m5a.large", 2),
+	}
+	cds := []hivev1.ClusterDeployment{{}, {}}
+
+	usage := ComputeTenantQuotaUsage(quota, cds, pools)
+
+	assert.Equal(t, hivev1.TenantQuotaStatus{
+		ClusterDeployments: 2,
+		MachineReplicas:    13,
+		InstanceFamilies: []hivev1.InstanceFamilyUsage{
+			{Family: "p3", Replicas: 2},
+			{Family: "m5", Replicas: 8},
+		},
+	}, usage)
+}
+
+func TestInstanceFamily(t *testing.T) {
+	cases := map[string]string{
+		"m5.xlarge":       "m5",
+		"m5a.xlarge":      "m5a",
+		"m5d.2xlarge":     "m5d",
+		"n2-standard-4":   "n2",
+		"Standard_D4s_v3": "Standard_D4s_v3",
+		"":                "",
+	}
+	for instanceType, expected := range cases {
+		assert.Equal(t, expected, InstanceFamily(instanceType), "unexpected family of %q", instanceType)
+	}
+}
+
+func TestTenantQuotaExceeded(t *testing.T) {
+	quota := &hivev1.TenantQuota{
+		Spec: hivev1.TenantQuotaSpec{
+			MaxClusterDeployments: pointer.Int32Ptr(2),
+			MaxMachineReplicas:    pointer.Int64Ptr(10),
+			InstanceFamilies: []hivev1.InstanceFamilyQuota{
+				{Family: "p3", MaxReplicas: 0},
+			},
+		},
+	}
+	cases := []struct {
+		name             string
+		oldUsage         hivev1.TenantQuotaStatus
+		newUsage         hivev1.TenantQuotaStatus
+		expectedExceeded int
+	}{
+		{
+			name:     "within limits",
+			oldUsage: hivev1.TenantQuotaStatus{ClusterDeployments: 1, MachineReplicas: 3},
+			newUsage: hivev1.TenantQuotaStatus{ClusterDeployments: 2, MachineReplicas: 10},
+		},
+		{
+			name:             "cluster deployments exceeded",
+			oldUsage:         hivev1.TenantQuotaStatus{ClusterDeployments: 2},
+			newUsage:         hivev1.TenantQuotaStatus{ClusterDeployments: 3},
+			expectedExceeded: 1,
+		},
+		{
+			name:             "machine replicas and instance family exceeded",
+			oldUsage:         hivev1.TenantQuotaStatus{MachineReplicas: 8},
+			newUsage:         hivev1.TenantQuotaStatus{MachineReplicas: 11, InstanceFamilies: []hivev1.InstanceFamilyUsage{{Family: "p3", Replicas: 1}}},
+			expectedExceeded: 2,
+		},
+		{
+			name:     "already exceeded and reduced",
+			oldUsage: hivev1.TenantQuotaStatus{ClusterDeployments: 3, MachineReplicas: 20},
+			newUsage: hivev1.TenantQuotaStatus{ClusterDeployments: 3, MachineReplicas: 15},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			exceeded := TenantQuotaExceeded(quota, tc.oldUsage, tc.newUsage)
+			assert.Len(t, exceeded, tc.expectedExceeded, "unexpected number of exceeded limits")
+		})
+	}
+}
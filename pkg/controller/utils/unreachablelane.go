@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// unreachableLaneRequeueAfter is how long the reconcile of an unreachable cluster is deferred when the unreachable
+// lane of the controller is full.
+const unreachableLaneRequeueAfter = time.Minute
+
+var (
+	metricUnreachableLaneDeferred = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_controller_unreachable_lane_deferred_total",
+		Help: "Counter incremented each time the reconcile of an unreachable cluster is deferred because the unreachable lane of the controller is full.",
+	},
+		[]string{"controller"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricUnreachableLaneDeferred)
+}
+
+// NewUnreachableLaneReconciler wraps the reconciler of a controller whose requests are keyed by ClusterDeployment such
+// that, when an unreachable lane is configured for the controller, at most that many reconciles of clusters known to
+// be unreachable run at once. Reconciles of unreachable clusters beyond that are deferred rather than waiting for a
+// slot, so that clusters which time out on every remote call cannot take up all of the workers of the controller and
+// starve healthy clusters during a wide network outage. The reconciler is returned as is when no unreachable lane is
+// configured.
+func NewUnreachableLaneReconciler(r reconcile.Reconciler, c client.Client, controllerName hivev1.ControllerName) (reconcile.Reconciler, error) {
+	value, ok := getValueFromEnvVariable(controllerName, UnreachableConcurrentReconcilesEnvVariableFormat)
+	if !ok {
+		return r, nil
+	}
+	size, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		return r, nil
+	}
+	return &unreachableLaneReconciler{
+		Reconciler:     r,
+		client:         c,
+		controllerName: controllerName,
+		lane:           make(chan struct{}, size),
+	}, nil
+}
+
+type unreachableLaneReconciler struct {
+	reconcile.Reconciler
+
+	client         client.Client
+	controllerName hivev1.ControllerName
+	// lane holds a token for each reconcile of an unreachable cluster in progress.
+	lane chan struct{}
+}
+
+var _ reconcile.Reconciler = &unreachableLaneReconciler{}
+
+// Reconcile implements reconcile.Reconciler
+func (r *unreachableLaneReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.client.Get(ctx, request.NamespacedName, cd); err != nil || !knownUnreachable(cd) {
+		// Leave any error getting the ClusterDeployment to the wrapped reconciler.
+		return r.Reconciler.Reconcile(ctx, request)
+	}
+	select {
+	case r.lane <- struct{}{}:
+		defer func() { <-r.lane }()
+		return r.Reconciler.Reconcile(ctx, request)
+	default:
+		metricUnreachableLaneDeferred.WithLabelValues(r.controllerName.String()).Inc()
+		return reconcile.Result{RequeueAfter: unreachableLaneRequeueAfter}, nil
+	}
+}
+
+// knownUnreachable returns true when the last connectivity check of the cluster failed. Clusters which have not been
+// checked yet are not considered unreachable.
+func knownUnreachable(cd *hivev1.ClusterDeployment) bool {
+	cond := FindClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition)
+	return cond != nil && cond.Status == corev1.ConditionTrue
+}
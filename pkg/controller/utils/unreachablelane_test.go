@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestNewUnreachableLaneReconciler(t *testing.T) {
+	cases := []struct {
+		name          string
+		value         *string
+		expectLane    bool
+		expectedError bool
+	}{
+		{
+			name: "lane not set",
+		},
+		{
+			name:       "lane set",
+			value:      func() *string { s := "2"; return &s }(),
+			expectLane: true,
+		},
+		{
+			name:  "lane disabled",
+			value: func() *string { s := "0"; return &s }(),
+		},
+		{
+			name:          "lane set incorrectly",
+			value:         func() *string { s := "two"; return &s }(),
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.value != nil {
+				key := fmt.Sprintf(UnreachableConcurrentReconcilesEnvVariableFormat, testControllerName)
+				os.Setenv(key, *tc.value)
+				defer os.Unsetenv(key)
+			}
+
+			inner := &countingReconciler{}
+			r, err := NewUnreachableLaneReconciler(inner, fake.NewClientBuilder().Build(), testControllerName)
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			laneReconciler, isLane := r.(*unreachableLaneReconciler)
+			assert.Equal(t, tc.expectLane, isLane, "unexpected reconciler type")
+			if isLane {
+				assert.Equal(t, 2, cap(laneReconciler.lane), "unexpected lane size")
+			} else {
+				assert.Same(t, inner, r, "expected reconciler to be returned as is")
+			}
+		})
+	}
+}
+
+func TestUnreachableLaneReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+
+	clusterDeployment := func(name string, unreachable *corev1.ConditionStatus) *hivev1.ClusterDeployment {
+		cd := &hivev1.ClusterDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: name},
+		}
+		if unreachable != nil {
+			cd.Status.Conditions = SetClusterDeploymentCondition(cd.Status.Conditions, hivev1.UnreachableCondition, *unreachable, "TestReason", "test message", UpdateConditionIfReasonOrMessageChange)
+		}
+		return cd
+	}
+	status := func(s corev1.ConditionStatus) *corev1.ConditionStatus { return &s }
+
+	cases := []struct {
+		name            string
+		cd              *hivev1.ClusterDeployment
+		laneFull        bool
+		expectReconcile bool
+	}{
+		{
+			name:            "reachable",
+			cd:              clusterDeployment("test-cd", status(corev1.ConditionFalse)),
+			laneFull:        true,
+			expectReconcile: true,
+		},
+		{
+			name:            "not checked yet",
+			cd:              clusterDeployment("test-cd", nil),
+			laneFull:        true,
+			expectReconcile: true,
+		},
+		{
+			name:            "missing cluster deployment",
+			laneFull:        true,
+			expectReconcile: true,
+		},
+		{
+			name:            "unreachable with room in the lane",
+			cd:              clusterDeployment("test-cd", status(corev1.ConditionTrue)),
+			expectReconcile: true,
+		},
+		{
+			name:     "unreachable with full lane",
+			cd:       clusterDeployment("test-cd", status(corev1.ConditionTrue)),
+			laneFull: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tc.cd != nil {
+				builder = builder.WithObjects(tc.cd)
+			}
+			inner := &countingReconciler{}
+			r := &unreachableLaneReconciler{
+				Reconciler:     inner,
+				client:         builder.Build(),
+				controllerName: testControllerName,
+				lane:           make(chan struct{}, 1),
+			}
+			if tc.laneFull {
+				r.lane <- struct{}{}
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "test-namespace", Name: "test-cd"}}
+			result, err := r.Reconcile(context.Background(), request)
+			require.NoError(t, err)
+			if tc.expectReconcile {
+				assert.Equal(t, 1, inner.reconciles, "expected reconcile to run")
+				assert.Zero(t, result.RequeueAfter, "unexpected requeue")
+			} else {
+				assert.Zero(t, inner.reconciles, "expected reconcile to be deferred")
+				assert.Equal(t, unreachableLaneRequeueAfter, result.RequeueAfter, "unexpected requeue")
+			}
+			if !tc.laneFull {
+				assert.Empty(t, r.lane, "expected lane to be released")
+			}
+		})
+	}
+}
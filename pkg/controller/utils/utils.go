@@ -61,6 +61,14 @@ const (
 	// QueueBurstEnvVariableFormat is the format of the environment variable that stores
 	// workqueue burst for a controller
 	QueueBurstEnvVariableFormat = "%s-queue-burst"
+
+	// StartupRampDurationEnvVariableFormat is the format of the environment variable that stores
+	// the window over which the first reconciles of a controller are spread after it starts
+	StartupRampDurationEnvVariableFormat = "%s-startup-ramp-duration"
+
+	// UnreachableConcurrentReconcilesEnvVariableFormat is the format of the environment variable that stores
+	// the number of concurrent reconciles a controller spends on clusters known to be unreachable
+	UnreachableConcurrentReconcilesEnvVariableFormat = "%s-unreachable-concurrent-reconciles"
 )
 
 // HasFinalizer returns true if the given object has the given finalizer
@@ -143,10 +151,22 @@ func getQueueRateLimiter(controllerName hivev1.ControllerName) (workqueue.RateLi
 		}
 	}
 
-	return workqueue.NewMaxOfRateLimiter(
+	rateLimiter := workqueue.NewMaxOfRateLimiter(
 		workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 1000*time.Second),
 		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(qps), burst)},
-	), nil
+	)
+
+	if value, ok := getValueFromEnvVariable(controllerName, StartupRampDurationEnvVariableFormat); ok {
+		window, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		if window > 0 {
+			return newStartupRampRateLimiter(rateLimiter, window), nil
+		}
+	}
+
+	return rateLimiter, nil
 }
 
 func GetControllerConfig(client client.Client, controllerName hivev1.ControllerName) (int, flowcontrol.RateLimiter, workqueue.RateLimiter, error) {
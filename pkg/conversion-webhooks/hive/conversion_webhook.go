@@ -0,0 +1,107 @@
+package hive
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openshift/hive/apis"
+)
+
+// ConversionPath is the path the conversion webhook is served at.
+const ConversionPath = "/convert"
+
+// ConversionWebhook converts hive objects between the versions served by their CustomResourceDefinitions, with the
+// conversions registered in the scheme of the hive APIs. The kube apiserver calls it with ConversionReviews when an
+// object is requested in a version other than the one it is stored as.
+type ConversionWebhook struct {
+	scheme *runtime.Scheme
+}
+
+// NewConversionWebhook constructs a new ConversionWebhook
+func NewConversionWebhook() *ConversionWebhook {
+	scheme := runtime.NewScheme()
+	if err := apis.AddToScheme(scheme); err != nil {
+		log.WithError(err).Fatal("could not add the hive apis to the scheme")
+	}
+	return &ConversionWebhook{scheme: scheme}
+}
+
+// ServeHTTP converts the objects of a ConversionReview.
+func (wh *ConversionWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &apiextv1.ConversionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil || review.Request == nil {
+		log.WithError(err).Error("could not decode conversion review")
+		http.Error(w, "could not decode conversion review", http.StatusBadRequest)
+		return
+	}
+	review.Response = wh.convert(review.Request)
+	review.Request = nil
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		log.WithError(err).Error("could not encode conversion review")
+	}
+}
+
+func (wh *ConversionWebhook) convert(request *apiextv1.ConversionRequest) *apiextv1.ConversionResponse {
+	response := &apiextv1.ConversionResponse{UID: request.UID}
+	desired, err := schema.ParseGroupVersion(request.DesiredAPIVersion)
+	if err != nil {
+		return failedConversion(response, errors.Wrap(err, "invalid desired api version"))
+	}
+	for _, obj := range request.Objects {
+		converted, err := wh.convertObject(obj.Raw, desired)
+		if err != nil {
+			return failedConversion(response, err)
+		}
+		response.ConvertedObjects = append(response.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	response.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return response
+}
+
+// convertObject converts the JSON of an object to the desired version, and returns the JSON of the converted object.
+func (wh *ConversionWebhook) convertObject(raw []byte, desired schema.GroupVersion) ([]byte, error) {
+	typeMeta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, typeMeta); err != nil {
+		return nil, errors.Wrap(err, "could not decode object")
+	}
+	gvk := typeMeta.GroupVersionKind()
+	desiredGVK := desired.WithKind(gvk.Kind)
+	if gvk == desiredGVK {
+		return raw, nil
+	}
+	src, err := wh.scheme.New(gvk)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unsupported kind %s", gvk)
+	}
+	if err := json.Unmarshal(raw, src); err != nil {
+		return nil, errors.Wrapf(err, "could not decode %s", gvk)
+	}
+	dst, err := wh.scheme.New(desiredGVK)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unsupported kind %s", desiredGVK)
+	}
+	if err := wh.scheme.Convert(src, dst, nil); err != nil {
+		return nil, errors.Wrapf(err, "could not convert %s to %s", gvk, desiredGVK)
+	}
+	dst.GetObjectKind().SetGroupVersionKind(desiredGVK)
+	return json.Marshal(dst)
+}
+
+func failedConversion(response *apiextv1.ConversionResponse, err error) *apiextv1.ConversionResponse {
+	log.WithError(err).Error("conversion failed")
+	response.ConvertedObjects = nil
+	response.Result = metav1.Status{
+		Status:  metav1.StatusFailure,
+		Message: err.Error(),
+	}
+	return response
+}
@@ -0,0 +1,377 @@
+package hive
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	fuzz "github.com/google/gofuzz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1beta1 "github.com/openshift/hive/apis/hive/v1beta1"
+)
+
+func testV1MachinePool() *hivev1.MachinePool {
+	return &hivev1.MachinePool{
+		TypeMeta:   metav1.TypeMeta{APIVersion: hivev1.SchemeGroupVersion.String(), Kind: "MachinePool"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "foo-worker"},
+		Spec: hivev1.MachinePoolSpec{
+			ClusterDeploymentRef: corev1.LocalObjectReference{Name: "foo"},
+			Name:                 "worker",
+			Replicas:             pointer.Int64Ptr(3),
+			Labels:               map[string]string{"foo": "bar"},
+			Taints:               []corev1.Taint{{Key: "foo", Value: "bar", Effect: corev1.TaintEffectNoSchedule}},
+			Paused:               true,
+		},
+		Status: hivev1.MachinePoolStatus{
+			Replicas: 3,
+			Conditions: []hivev1.MachinePoolCondition{{
+				Type:               hivev1.ReadyMachinePoolCondition,
+				Status:             corev1.ConditionTrue,
+				Reason:             "Ready",
+				Message:            "all machines are ready",
+				ObservedGeneration: 2,
+			}},
+		},
+	}
+}
+
+func testV1beta1MachinePool() *hivev1beta1.MachinePool {
+	return &hivev1beta1.MachinePool{
+		TypeMeta:   metav1.TypeMeta{APIVersion: hivev1beta1.SchemeGroupVersion.String(), Kind: "MachinePool"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "foo-worker"},
+		Spec: hivev1beta1.MachinePoolSpec{
+			ClusterDeploymentRef: corev1.LocalObjectReference{Name: "foo"},
+			Name:                 "worker",
+			Replicas:             pointer.Int32Ptr(3),
+			NodeLabels:           map[string]string{"foo": "bar"},
+			NodeTaints:           []corev1.Taint{{Key: "foo", Value: "bar", Effect: corev1.TaintEffectNoSchedule}},
+			Paused:               true,
+		},
+		Status: hivev1beta1.MachinePoolStatus{
+			Replicas: 3,
+			Conditions: []metav1.Condition{{
+				Type:               string(hivev1.ReadyMachinePoolCondition),
+				Status:             metav1.ConditionTrue,
+				Reason:             "Ready",
+				Message:            "all machines are ready",
+				ObservedGeneration: 2,
+			}},
+		},
+	}
+}
+
+func testV1ClusterDeployment() *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: hivev1.SchemeGroupVersion.String(), Kind: "ClusterDeployment"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "foo"},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: "foo",
+			BaseDomain:  "example.com",
+			Installed:   true,
+		},
+		Status: hivev1.ClusterDeploymentStatus{
+			APIURL:     "https://api.foo.example.com:6443",
+			PowerState: "Running",
+			Conditions: []hivev1.ClusterDeploymentCondition{{
+				Type:    hivev1.ProvisionedCondition,
+				Status:  corev1.ConditionTrue,
+				Reason:  "Provisioned",
+				Message: "Cluster is provisioned",
+			}},
+		},
+	}
+}
+
+func testV1beta1ClusterDeployment() *hivev1beta1.ClusterDeployment {
+	return &hivev1beta1.ClusterDeployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: hivev1beta1.SchemeGroupVersion.String(), Kind: "ClusterDeployment"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "foo"},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: "foo",
+			BaseDomain:  "example.com",
+			Installed:   true,
+		},
+		Status: hivev1beta1.ClusterDeploymentStatus{
+			APIURL:     "https://api.foo.example.com:6443",
+			PowerState: "Running",
+			Conditions: []metav1.Condition{{
+				Type:    string(hivev1.ProvisionedCondition),
+				Status:  metav1.ConditionTrue,
+				Reason:  "Provisioned",
+				Message: "Cluster is provisioned",
+			}},
+		},
+	}
+}
+
+func TestConversionWebhook(t *testing.T) {
+	tests := []struct {
+		name           string
+		object         runtime.Object
+		desiredVersion string
+		expected       runtime.Object
+		expectFailure  bool
+	}{
+		{
+			name:           "v1 to v1beta1",
+			object:         testV1MachinePool(),
+			desiredVersion: hivev1beta1.SchemeGroupVersion.String(),
+			expected:       testV1beta1MachinePool(),
+		},
+		{
+			name:           "v1beta1 to v1",
+			object:         testV1beta1MachinePool(),
+			desiredVersion: hivev1.SchemeGroupVersion.String(),
+			expected:       testV1MachinePool(),
+		},
+		{
+			name:           "same version",
+			object:         testV1MachinePool(),
+			desiredVersion: hivev1.SchemeGroupVersion.String(),
+			expected:       testV1MachinePool(),
+		},
+		{
+			name:           "cluster deployment v1 to v1beta1",
+			object:         testV1ClusterDeployment(),
+			desiredVersion: hivev1beta1.SchemeGroupVersion.String(),
+			expected:       testV1beta1ClusterDeployment(),
+		},
+		{
+			name:           "cluster deployment v1beta1 to v1",
+			object:         testV1beta1ClusterDeployment(),
+			desiredVersion: hivev1.SchemeGroupVersion.String(),
+			expected:       testV1ClusterDeployment(),
+		},
+		{
+			name: "kind without v1beta1",
+			object: &hivev1.ClusterPool{
+				TypeMeta:   metav1.TypeMeta{APIVersion: hivev1.SchemeGroupVersion.String(), Kind: "ClusterPool"},
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "foo"},
+			},
+			desiredVersion: hivev1beta1.SchemeGroupVersion.String(),
+			expectFailure:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			raw, err := json.Marshal(test.object)
+			require.NoError(t, err)
+			review := &apiextv1.ConversionReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: apiextv1.SchemeGroupVersion.String(), Kind: "ConversionReview"},
+				Request: &apiextv1.ConversionRequest{
+					UID:               "1234",
+					DesiredAPIVersion: test.desiredVersion,
+					Objects:           []runtime.RawExtension{{Raw: raw}},
+				},
+			}
+			body, err := json.Marshal(review)
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+			NewConversionWebhook().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, ConversionPath, bytes.NewReader(body)))
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			actual := &apiextv1.ConversionReview{}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), actual))
+			require.NotNil(t, actual.Response, "missing response")
+			assert.Equal(t, review.Request.UID, actual.Response.UID, "unexpected uid")
+			if test.expectFailure {
+				assert.Equal(t, metav1.StatusFailure, actual.Response.Result.Status, "expected conversion to fail")
+				assert.Empty(t, actual.Response.ConvertedObjects, "unexpected converted objects")
+				return
+			}
+			assert.Equal(t, metav1.StatusSuccess, actual.Response.Result.Status, "unexpected result: %s", actual.Response.Result.Message)
+			require.Len(t, actual.Response.ConvertedObjects, 1)
+			expected, err := json.Marshal(test.expected)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual.Response.ConvertedObjects[0].Raw), "unexpected converted object")
+		})
+	}
+}
+
+func TestConversionWebhookKeepsNonConvertibleFields(t *testing.T) {
+	probed := metav1.Unix(1000, 0)
+	pool := testV1MachinePool()
+	pool.Status.Conditions[0].LastProbeTime = probed
+	cd := testV1beta1ClusterDeployment()
+	cd.Status.Conditions[0].ObservedGeneration = 4
+	tests := []struct {
+		name           string
+		object         runtime.Object
+		desiredVersion string
+		expected       runtime.Object
+	}{
+		{
+			name:           "last probe time kept in annotation",
+			object:         pool,
+			desiredVersion: hivev1beta1.SchemeGroupVersion.String(),
+			expected: func() runtime.Object {
+				expected := testV1beta1MachinePool()
+				expected.Annotations = map[string]string{
+					hivev1beta1.ConversionDataAnnotation: `{"conditions":{"Ready":{"lastProbeTime":"1970-01-01T00:16:40Z"}}}`,
+				}
+				return expected
+			}(),
+		},
+		{
+			name:           "observed generation kept in annotation",
+			object:         cd,
+			desiredVersion: hivev1.SchemeGroupVersion.String(),
+			expected: func() runtime.Object {
+				expected := testV1ClusterDeployment()
+				expected.Annotations = map[string]string{
+					hivev1beta1.ConversionDataAnnotation: `{"conditions":{"Provisioned":{"observedGeneration":4}}}`,
+				}
+				return expected
+			}(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			converted := convertThroughWebhook(t, test.object, test.desiredVersion)
+			expected, err := json.Marshal(test.expected)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(converted), "unexpected converted object")
+
+			back := convertThroughWebhook(t, &runtime.Unknown{Raw: converted}, test.object.GetObjectKind().GroupVersionKind().GroupVersion().String())
+			original, err := json.Marshal(test.object)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(original), string(back), "object changed by round trip")
+		})
+	}
+}
+
+// convertThroughWebhook converts the object to the desired version with the conversion webhook, and returns the JSON
+// of the converted object.
+func convertThroughWebhook(t *testing.T, object runtime.Object, desiredVersion string) []byte {
+	raw, err := json.Marshal(object)
+	if unknown, ok := object.(*runtime.Unknown); ok {
+		raw, err = unknown.Raw, nil
+	}
+	require.NoError(t, err)
+	review := &apiextv1.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: apiextv1.SchemeGroupVersion.String(), Kind: "ConversionReview"},
+		Request: &apiextv1.ConversionRequest{
+			UID:               "1234",
+			DesiredAPIVersion: desiredVersion,
+			Objects:           []runtime.RawExtension{{Raw: raw}},
+		},
+	}
+	body, err := json.Marshal(review)
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	NewConversionWebhook().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, ConversionPath, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	actual := &apiextv1.ConversionReview{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), actual))
+	require.NotNil(t, actual.Response, "missing response")
+	require.Equal(t, metav1.StatusSuccess, actual.Response.Result.Status, "unexpected result: %s", actual.Response.Result.Message)
+	require.Len(t, actual.Response.ConvertedObjects, 1)
+	return actual.Response.ConvertedObjects[0].Raw
+}
+
+// TestConversionRoundTrip fuzzes objects of every kind served as v1beta1, and checks that converting them to the other
+// version and back gives the object that was started with.
+func TestConversionRoundTrip(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apis.AddToScheme(scheme))
+	fuzzer := fuzz.NewWithSeed(1).NilChance(0.2).NumElements(0, 3).Funcs(
+		// The raw extensions are copied as is, and the objects they may hold are never set on the hive types.
+		func(e *runtime.RawExtension, c fuzz.Continue) {
+			e.Raw = []byte(`{"` + c.RandString() + `":true}`)
+		},
+		// Replicas beyond the range of an int32 are not expected.
+		func(r *int64, c fuzz.Continue) {
+			*r = int64(c.Int31())
+		},
+		// Conditions are keyed by their type.
+		func(conditions *[]metav1.Condition, c fuzz.Continue) {
+			for i := 0; i < c.Intn(4); i++ {
+				condition := metav1.Condition{}
+				c.FuzzNoCustom(&condition)
+				condition.Type = fmt.Sprintf("Condition%d", i)
+				*conditions = append(*conditions, condition)
+			}
+		},
+		func(conditions *[]hivev1.MachinePoolCondition, c fuzz.Continue) {
+			for i := 0; i < c.Intn(4); i++ {
+				condition := hivev1.MachinePoolCondition{}
+				c.FuzzNoCustom(&condition)
+				condition.Type = hivev1.MachinePoolConditionType(fmt.Sprintf("Condition%d", i))
+				*conditions = append(*conditions, condition)
+			}
+		},
+		func(conditions *[]hivev1.ClusterDeploymentCondition, c fuzz.Continue) {
+			for i := 0; i < c.Intn(4); i++ {
+				condition := hivev1.ClusterDeploymentCondition{}
+				c.FuzzNoCustom(&condition)
+				condition.Type = hivev1.ClusterDeploymentConditionType(fmt.Sprintf("Condition%d", i))
+				*conditions = append(*conditions, condition)
+			}
+		},
+	)
+	tests := []struct {
+		name    string
+		object  func() runtime.Object
+		through func() runtime.Object
+	}{
+		{
+			name:    "machine pool v1",
+			object:  func() runtime.Object { return &hivev1.MachinePool{} },
+			through: func() runtime.Object { return &hivev1beta1.MachinePool{} },
+		},
+		{
+			name:    "machine pool v1beta1",
+			object:  func() runtime.Object { return &hivev1beta1.MachinePool{} },
+			through: func() runtime.Object { return &hivev1.MachinePool{} },
+		},
+		{
+			name:    "cluster deployment v1",
+			object:  func() runtime.Object { return &hivev1.ClusterDeployment{} },
+			through: func() runtime.Object { return &hivev1beta1.ClusterDeployment{} },
+		},
+		{
+			name:    "cluster deployment v1beta1",
+			object:  func() runtime.Object { return &hivev1beta1.ClusterDeployment{} },
+			through: func() runtime.Object { return &hivev1.ClusterDeployment{} },
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				original := test.object()
+				fuzzer.Fuzz(original)
+				original.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+				through := test.through()
+				require.NoError(t, scheme.Convert(original.DeepCopyObject(), through, nil))
+				back := test.object()
+				require.NoError(t, scheme.Convert(through, back, nil))
+				if !equality.Semantic.DeepEqual(original, back) {
+					t.Fatalf("object changed by round trip: %s", cmp.Diff(original, back))
+				}
+			}
+		})
+	}
+}
+
+func TestConversionWebhookInvalidRequest(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	NewConversionWebhook().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, ConversionPath, bytes.NewReader([]byte("{}"))))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
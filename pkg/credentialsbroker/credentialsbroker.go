@@ -0,0 +1,169 @@
+// Package credentialsbroker mints short-lived credentials for provision and deprovision jobs, so that the jobs do not
+// need access to the long-lived credentials the short-lived credentials are minted from.
+package credentialsbroker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	defaultAWSSessionDuration = time.Hour
+
+	// minAWSSessionNameLen and maxAWSSessionNameLen bound the length of the session name of an assumed role.
+	minAWSSessionNameLen = 2
+	maxAWSSessionNameLen = 64
+)
+
+// invalidAWSSessionNameChars matches the characters not allowed in the session name of an assumed role.
+var invalidAWSSessionNameChars = regexp.MustCompile(`[^\w+=,.@-]`)
+
+// awsSessionCredentials are the temporary credentials of an assumed role session.
+type awsSessionCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// assumeAWSRole assumes the role with the AWS service provider credentials of Hive. It is a variable so that it can be
+// replaced in tests.
+var assumeAWSRole = func(c client.Client, role *hivev1aws.AssumeRole, sessionName string, duration time.Duration) (*awsSessionCredentials, error) {
+	var spSecret *corev1.Secret
+	if spSecretName := os.Getenv(constants.HiveAWSServiceProviderCredentialsSecretRefEnvVar); spSecretName != "" {
+		spSecret = &corev1.Secret{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: spSecretName}, spSecret); err != nil {
+			return nil, errors.Wrap(err, "failed to get the service provider secret")
+		}
+	}
+	cfg, err := awsclient.NewConfigFromSecret(spSecret, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AWS config")
+	}
+	input := &sts.AssumeRoleInput{
+		RoleArn:         aws.String(role.RoleARN),
+		RoleSessionName: aws.String(sessionName),
+		DurationSeconds: aws.Int32(int32(duration.Seconds())),
+	}
+	if role.ExternalID != "" {
+		input.ExternalId = aws.String(role.ExternalID)
+	}
+	output, err := sts.NewFromConfig(cfg).AssumeRole(context.TODO(), input)
+	if err != nil {
+		return nil, err
+	}
+	return &awsSessionCredentials{
+		AccessKeyID:     aws.ToString(output.Credentials.AccessKeyId),
+		SecretAccessKey: aws.ToString(output.Credentials.SecretAccessKey),
+		SessionToken:    aws.ToString(output.Credentials.SessionToken),
+	}, nil
+}
+
+// ReadConfig reads the credentials broker configuration from the file pointed to by the
+// CredentialsBrokerConfigFileEnvVar environment variable. A nil configuration is returned when the credentials broker
+// is not configured.
+func ReadConfig() (*hivev1.CredentialsBrokerConfig, error) {
+	path := os.Getenv(constants.CredentialsBrokerConfigFileEnvVar)
+	if len(path) == 0 {
+		return nil, nil
+	}
+	fileBytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the credentials broker config file")
+	}
+	if len(fileBytes) == 0 || string(fileBytes) == "null" {
+		return nil, nil
+	}
+	config := &hivev1.CredentialsBrokerConfig{}
+	if err := json.Unmarshal(fileBytes, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the credentials broker config")
+	}
+	return config, nil
+}
+
+// BrokersAWS returns true if the credentials broker mints the credentials of jobs for AWS clusters with the given
+// credentials. Only clusters which assume a role, rather than use a credentials secret, are brokered.
+func BrokersAWS(config *hivev1.CredentialsBrokerConfig, credentialsSecretName string, role *hivev1aws.AssumeRole) bool {
+	return config != nil && config.AWS != nil && credentialsSecretName == "" && role != nil && role.RoleARN != ""
+}
+
+// MintAWSCredentials assumes the role for a single job, and stores the session credentials in the secret as an AWS
+// CLI config, creating or updating the secret. The session is named after the job so that its use of the credentials
+// can be traced in CloudTrail.
+func MintAWSCredentials(c client.Client, config *hivev1.AWSCredentialsBrokerConfig, role *hivev1aws.AssumeRole, jobName, secretName, namespace string, owner metav1.Object, scheme *runtime.Scheme) error {
+	duration := defaultAWSSessionDuration
+	if config.SessionDuration != nil {
+		duration = config.SessionDuration.Duration
+	}
+	creds, err := assumeAWSRole(c, role, awsSessionName(jobName), duration)
+	if err != nil {
+		return errors.Wrapf(err, "failed to assume role %s", role.RoleARN)
+	}
+	data := map[string][]byte{
+		constants.AWSConfigSecretKey: []byte(fmt.Sprintf(`[default]
+aws_access_key_id = %s
+aws_secret_access_key = %s
+aws_session_token = %s
+`, creds.AccessKeyID, creds.SecretAccessKey, creds.SessionToken)),
+	}
+
+	secret := &corev1.Secret{}
+	switch err := c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: secretName}, secret); {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      secretName,
+			},
+			Data: data,
+		}
+		if err := controllerutil.SetOwnerReference(owner, secret, scheme); err != nil {
+			return err
+		}
+		return c.Create(context.TODO(), secret)
+	case err != nil:
+		return err
+	}
+	// Replace the data entirely, as the secret may hold the credential_process config used when the credentials
+	// broker is not configured.
+	secret.Data = data
+	return c.Update(context.TODO(), secret)
+}
+
+// awsSessionName returns the session name of an assumed role for the job, replacing the characters STS does not allow
+// with "-" and truncating it to the maximum length.
+func awsSessionName(jobName string) string {
+	sessionName := invalidAWSSessionNameChars.ReplaceAllString(jobName, "-")
+	if len(sessionName) > maxAWSSessionNameLen {
+		sessionName = sessionName[:maxAWSSessionNameLen]
+	}
+	if len(sessionName) < minAWSSessionNameLen {
+		sessionName = "hive-" + sessionName
+	}
+	return sessionName
+}
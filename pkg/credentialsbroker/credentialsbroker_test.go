@@ -0,0 +1,166 @@
+package credentialsbroker
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+const (
+	testNamespace  = "test-namespace"
+	testSecretName = "test-cluster-aws-assume-role-config"
+	testRoleARN    = "arn:aws:iam::123456789012:role/hive"
+)
+
+func TestBrokersAWS(t *testing.T) {
+	role := &hivev1aws.AssumeRole{RoleARN: testRoleARN}
+	config := &hivev1.CredentialsBrokerConfig{AWS: &hivev1.AWSCredentialsBrokerConfig{}}
+	cases := []struct {
+		name                  string
+		config                *hivev1.CredentialsBrokerConfig
+		credentialsSecretName string
+		role                  *hivev1aws.AssumeRole
+		expected              bool
+	}{
+		{
+			name:     "assume role",
+			config:   config,
+			role:     role,
+			expected: true,
+		},
+		{
+			name: "not configured",
+			role: role,
+		},
+		{
+			name:   "aws not configured",
+			config: &hivev1.CredentialsBrokerConfig{},
+			role:   role,
+		},
+		{
+			name:                  "credentials secret",
+			config:                config,
+			credentialsSecretName: "aws-creds",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, BrokersAWS(tc.config, tc.credentialsSecretName, tc.role), "unexpected result")
+		})
+	}
+}
+
+func TestMintAWSCredentials(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	cd := testcd.BasicBuilder().Options(testcd.WithName("test-cluster"), testcd.WithNamespace(testNamespace)).Build()
+
+	cases := []struct {
+		name             string
+		existing         []runtime.Object
+		config           *hivev1.AWSCredentialsBrokerConfig
+		jobName          string
+		expectedDuration time.Duration
+		expectedSession  string
+	}{
+		{
+			name:             "new secret",
+			config:           &hivev1.AWSCredentialsBrokerConfig{},
+			jobName:          "test-cluster-0-abcde-provision",
+			expectedDuration: time.Hour,
+			expectedSession:  "test-cluster-0-abcde-provision",
+		},
+		{
+			name: "replaces credential process config",
+			existing: []runtime.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testSecretName},
+				Data: map[string][]byte{
+					constants.AWSConfigSecretKey: []byte("[default]\ncredential_process = /usr/bin/hiveutil install-manager aws-credentials\n"),
+				},
+			}},
+			config:           &hivev1.AWSCredentialsBrokerConfig{SessionDuration: &metav1.Duration{Duration: 3 * time.Hour}},
+			jobName:          "test-cluster-uninstall",
+			expectedDuration: 3 * time.Hour,
+			expectedSession:  "test-cluster-uninstall",
+		},
+		{
+			name:             "long job name",
+			config:           &hivev1.AWSCredentialsBrokerConfig{},
+			jobName:          strings.Repeat("a", 70),
+			expectedDuration: time.Hour,
+			expectedSession:  strings.Repeat("a", 64),
+		},
+		{
+			name:             "invalid session name characters",
+			config:           &hivev1.AWSCredentialsBrokerConfig{},
+			jobName:          "test:cluster/provision job",
+			expectedDuration: time.Hour,
+			expectedSession:  "test-cluster-provision-job",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(f func(client.Client, *hivev1aws.AssumeRole, string, time.Duration) (*awsSessionCredentials, error)) {
+				assumeAWSRole = f
+			}(assumeAWSRole)
+			assumeAWSRole = func(_ client.Client, role *hivev1aws.AssumeRole, sessionName string, duration time.Duration) (*awsSessionCredentials, error) {
+				assert.Equal(t, testRoleARN, role.RoleARN, "unexpected role")
+				assert.Equal(t, tc.expectedSession, sessionName, "unexpected session name")
+				assert.Equal(t, tc.expectedDuration, duration, "unexpected session duration")
+				return &awsSessionCredentials{AccessKeyID: "key-id", SecretAccessKey: "secret-key", SessionToken: "session-token"}, nil
+			}
+			c := fake.NewFakeClientWithScheme(scheme, tc.existing...)
+
+			err := MintAWSCredentials(c, tc.config, &hivev1aws.AssumeRole{RoleARN: testRoleARN}, tc.jobName, testSecretName, testNamespace, cd, scheme)
+			require.NoError(t, err, "unexpected error minting credentials")
+
+			secret := &corev1.Secret{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testSecretName}, secret),
+				"unexpected error getting secret")
+			assert.Equal(t, map[string][]byte{
+				constants.AWSConfigSecretKey: []byte("[default]\naws_access_key_id = key-id\naws_secret_access_key = secret-key\naws_session_token = session-token\n"),
+			}, secret.Data, "unexpected secret data")
+		})
+	}
+}
+
+func TestReadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentialsbroker")
+	require.NoError(t, err, "unexpected error creating temp dir")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"aws":{"sessionDuration":"2h"}}`), 0600), "unexpected error writing config")
+
+	os.Setenv(constants.CredentialsBrokerConfigFileEnvVar, path)
+	defer os.Unsetenv(constants.CredentialsBrokerConfigFileEnvVar)
+	config, err := ReadConfig()
+	require.NoError(t, err, "unexpected error reading config")
+	assert.Equal(t, &hivev1.CredentialsBrokerConfig{
+		AWS: &hivev1.AWSCredentialsBrokerConfig{SessionDuration: &metav1.Duration{Duration: 2 * time.Hour}},
+	}, config, "unexpected config")
+
+	os.Setenv(constants.CredentialsBrokerConfigFileEnvVar, filepath.Join(dir, "missing"))
+	config, err = ReadConfig()
+	require.NoError(t, err, "unexpected error reading missing config")
+	assert.Nil(t, config, "expected no config")
+}
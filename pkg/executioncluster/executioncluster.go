@@ -0,0 +1,269 @@
+// Package executioncluster runs install and uninstall jobs on an external execution cluster rather than on the hub.
+package executioncluster
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	hubKubeconfigVolumeName = "hub-kubeconfig"
+	hubKubeconfigMountPath  = "/hub-kubeconfig"
+)
+
+// ReadConfig reads the execution cluster configuration from the file pointed to by the
+// ExecutionClusterConfigFileEnvVar environment variable. A nil configuration is returned when jobs run on the hub.
+func ReadConfig() (*hivev1.ExecutionClusterConfig, error) {
+	path := os.Getenv(constants.ExecutionClusterConfigFileEnvVar)
+	if len(path) == 0 {
+		return nil, nil
+	}
+	fileBytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the execution cluster config file")
+	}
+	if len(fileBytes) == 0 || string(fileBytes) == "null" {
+		return nil, nil
+	}
+	config := &hivev1.ExecutionClusterConfig{}
+	if err := json.Unmarshal(fileBytes, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the execution cluster config")
+	}
+	return config, nil
+}
+
+// BuildClient builds a client for the execution cluster from the kubeconfig secret in the namespace of Hive.
+func BuildClient(c client.Client, config *hivev1.ExecutionClusterConfig) (client.Client, error) {
+	kubeconfig, err := readKubeconfig(c, config.KubeconfigSecretRef.Name)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the execution cluster kubeconfig")
+	}
+	return client.New(cfg, client.Options{Scheme: scheme.Scheme})
+}
+
+// PrepareJob readies the execution cluster to run a job generated for the hub. The namespace and service account of
+// the job are created, and the secrets and configmaps used by the job are copied from the hub. The job is given the
+// kubeconfig of the hub, through the KUBECONFIG environment variable, so that it can report back to the hub. Owner
+// references, which cannot span clusters, are removed from the job.
+func PrepareJob(hubClient, execClient client.Client, config *hivev1.ExecutionClusterConfig, job *batchv1.Job, logger log.FieldLogger) error {
+	namespace := job.Namespace
+	if err := ensureCreated(execClient, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}); err != nil {
+		return errors.Wrap(err, "failed to create namespace on the execution cluster")
+	}
+	podSpec := &job.Spec.Template.Spec
+	if sa := podSpec.ServiceAccountName; sa != "" {
+		// Jobs talk to the hub with its kubeconfig, so the service account needs no permissions on the execution
+		// cluster.
+		if err := ensureCreated(execClient, &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: sa}}); err != nil {
+			return errors.Wrap(err, "failed to create service account on the execution cluster")
+		}
+	}
+
+	hubKubeconfig, err := readKubeconfig(hubClient, config.HubKubeconfigSecretRef.Name)
+	if err != nil {
+		return err
+	}
+	if err := syncSecret(execClient, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: constants.HubKubeconfigSecretName},
+		Data:       map[string][]byte{constants.KubeconfigSecretKey: hubKubeconfig},
+	}); err != nil {
+		return errors.Wrap(err, "failed to copy the hub kubeconfig to the execution cluster")
+	}
+	addHubKubeconfig(podSpec)
+
+	secretNames, configMapNames := podDependencies(podSpec)
+	for _, name := range secretNames.List() {
+		secret := &corev1.Secret{}
+		if err := hubClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				// The volume or environment variable using the secret may be optional.
+				logger.WithField("secret", name).Warn("secret used by job not found on the hub")
+				continue
+			}
+			return errors.Wrapf(err, "failed to get secret %s", name)
+		}
+		if err := syncSecret(execClient, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: secret.Labels},
+			Type:       secret.Type,
+			Data:       secret.Data,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to copy secret %s to the execution cluster", name)
+		}
+	}
+	for _, name := range configMapNames.List() {
+		configMap := &corev1.ConfigMap{}
+		if err := hubClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, configMap); err != nil {
+			if apierrors.IsNotFound(err) {
+				logger.WithField("configMap", name).Warn("configmap used by job not found on the hub")
+				continue
+			}
+			return errors.Wrapf(err, "failed to get configmap %s", name)
+		}
+		if err := syncConfigMap(execClient, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: configMap.Labels},
+			Data:       configMap.Data,
+			BinaryData: configMap.BinaryData,
+		}); err != nil {
+			return errors.Wrapf(err, "failed to copy configmap %s to the execution cluster", name)
+		}
+	}
+
+	job.OwnerReferences = nil
+	return nil
+}
+
+func readKubeconfig(c client.Client, secretName string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: controllerutils.GetHiveNamespace(), Name: secretName}, secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to get kubeconfig secret %s", secretName)
+	}
+	kubeconfig, ok := secret.Data[constants.KubeconfigSecretKey]
+	if !ok {
+		return nil, errors.Errorf("kubeconfig secret %s has no %q key", secretName, constants.KubeconfigSecretKey)
+	}
+	return kubeconfig, nil
+}
+
+// addHubKubeconfig mounts the hub kubeconfig secret in all of the containers of the pod, and points KUBECONFIG at it.
+func addHubKubeconfig(podSpec *corev1.PodSpec) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: hubKubeconfigVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: constants.HubKubeconfigSecretName},
+		},
+	})
+	add := func(containers []corev1.Container) {
+		for i := range containers {
+			containers[i].VolumeMounts = append(containers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      hubKubeconfigVolumeName,
+				MountPath: hubKubeconfigMountPath,
+				ReadOnly:  true,
+			})
+			containers[i].Env = append(containers[i].Env, corev1.EnvVar{
+				Name:  "KUBECONFIG",
+				Value: hubKubeconfigMountPath + "/" + constants.KubeconfigSecretKey,
+			})
+		}
+	}
+	add(podSpec.InitContainers)
+	add(podSpec.Containers)
+}
+
+// podDependencies returns the names of the secrets and configmaps used by the pod.
+func podDependencies(podSpec *corev1.PodSpec) (secretNames, configMapNames sets.String) {
+	secretNames, configMapNames = sets.NewString(), sets.NewString()
+	for _, ref := range podSpec.ImagePullSecrets {
+		secretNames.Insert(ref.Name)
+	}
+	for _, volume := range podSpec.Volumes {
+		switch {
+		case volume.Secret != nil:
+			secretNames.Insert(volume.Secret.SecretName)
+		case volume.ConfigMap != nil:
+			configMapNames.Insert(volume.ConfigMap.Name)
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					secretNames.Insert(source.Secret.Name)
+				}
+				if source.ConfigMap != nil {
+					configMapNames.Insert(source.ConfigMap.Name)
+				}
+			}
+		}
+	}
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for _, container := range containers {
+			for _, env := range container.Env {
+				if env.ValueFrom == nil {
+					continue
+				}
+				if env.ValueFrom.SecretKeyRef != nil {
+					secretNames.Insert(env.ValueFrom.SecretKeyRef.Name)
+				}
+				if env.ValueFrom.ConfigMapKeyRef != nil {
+					configMapNames.Insert(env.ValueFrom.ConfigMapKeyRef.Name)
+				}
+			}
+			for _, envFrom := range container.EnvFrom {
+				if envFrom.SecretRef != nil {
+					secretNames.Insert(envFrom.SecretRef.Name)
+				}
+				if envFrom.ConfigMapRef != nil {
+					configMapNames.Insert(envFrom.ConfigMapRef.Name)
+				}
+			}
+		}
+	}
+	// The hub kubeconfig is not copied from the namespace of the job.
+	secretNames.Delete(constants.HubKubeconfigSecretName)
+	return secretNames, configMapNames
+}
+
+func ensureCreated(c client.Client, obj client.Object) error {
+	if err := c.Create(context.TODO(), obj); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+func syncSecret(c client.Client, secret *corev1.Secret) error {
+	existing := &corev1.Secret{}
+	switch err := c.Get(context.TODO(), client.ObjectKeyFromObject(secret), existing); {
+	case apierrors.IsNotFound(err):
+		return c.Create(context.TODO(), secret)
+	case err != nil:
+		return err
+	}
+	if reflect.DeepEqual(existing.Data, secret.Data) && reflect.DeepEqual(existing.Labels, secret.Labels) {
+		return nil
+	}
+	existing.Labels = secret.Labels
+	existing.Data = secret.Data
+	return c.Update(context.TODO(), existing)
+}
+
+func syncConfigMap(c client.Client, configMap *corev1.ConfigMap) error {
+	existing := &corev1.ConfigMap{}
+	switch err := c.Get(context.TODO(), client.ObjectKeyFromObject(configMap), existing); {
+	case apierrors.IsNotFound(err):
+		return c.Create(context.TODO(), configMap)
+	case err != nil:
+		return err
+	}
+	if reflect.DeepEqual(existing.Data, configMap.Data) && reflect.DeepEqual(existing.BinaryData, configMap.BinaryData) &&
+		reflect.DeepEqual(existing.Labels, configMap.Labels) {
+		return nil
+	}
+	existing.Labels = configMap.Labels
+	existing.Data = configMap.Data
+	existing.BinaryData = configMap.BinaryData
+	return c.Update(context.TODO(), existing)
+}
@@ -0,0 +1,139 @@
+package executioncluster
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const testNamespace = "test-namespace"
+
+func TestPrepareJob(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	batchv1.AddToScheme(scheme)
+
+	hubClient := fake.NewFakeClientWithScheme(scheme,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: constants.DefaultHiveNamespace, Name: "hub-kubeconfig"},
+			Data:       map[string][]byte{constants.KubeconfigSecretKey: []byte("hub")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "pull-secret"},
+			Type:       corev1.SecretTypeDockerConfigJson,
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "creds"},
+			Data:       map[string][]byte{"key": []byte("new")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "manifests"},
+			Data:       map[string]string{"manifest.yaml": "{}"},
+		},
+	)
+	execClient := fake.NewFakeClientWithScheme(scheme,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "creds"},
+			Data:       map[string][]byte{"key": []byte("old")},
+		},
+	)
+	config := &hivev1.ExecutionClusterConfig{
+		KubeconfigSecretRef:    corev1.LocalObjectReference{Name: "exec-kubeconfig"},
+		HubKubeconfigSecretRef: corev1.LocalObjectReference{Name: "hub-kubeconfig"},
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       testNamespace,
+			Name:            "test-job",
+			OwnerReferences: []metav1.OwnerReference{{Name: "owner"}},
+		},
+		Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			ServiceAccountName: "installer",
+			ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "pull-secret"}},
+			Volumes: []corev1.Volume{{
+				Name: "manifests",
+				VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "manifests"},
+				}},
+			}},
+			Containers: []corev1.Container{{
+				Name: "test",
+				Env: []corev1.EnvVar{{
+					Name: "KEY",
+					ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "creds"},
+						Key:                  "key",
+					}},
+				}},
+			}},
+		}}},
+	}
+
+	err := PrepareJob(hubClient, execClient, config, job, log.WithField("test", "TestPrepareJob"))
+	require.NoError(t, err, "unexpected error preparing job")
+
+	assert.Empty(t, job.OwnerReferences, "expected owner references to be removed")
+	assert.Contains(t, job.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "KUBECONFIG", Value: "/hub-kubeconfig/kubeconfig"},
+		"expected KUBECONFIG to point at the hub kubeconfig")
+
+	ns := &corev1.Namespace{}
+	assert.NoError(t, execClient.Get(context.TODO(), types.NamespacedName{Name: testNamespace}, ns), "expected namespace")
+	sa := &corev1.ServiceAccount{}
+	assert.NoError(t, execClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: "installer"}, sa), "expected service account")
+
+	expectedSecrets := map[string]map[string][]byte{
+		constants.HubKubeconfigSecretName: {constants.KubeconfigSecretKey: []byte("hub")},
+		"pull-secret":                     {corev1.DockerConfigJsonKey: []byte("{}")},
+		"creds":                           {"key": []byte("new")},
+	}
+	for name, data := range expectedSecrets {
+		secret := &corev1.Secret{}
+		if assert.NoError(t, execClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: name}, secret), "expected secret %s", name) {
+			assert.Equal(t, data, secret.Data, "unexpected data in secret %s", name)
+		}
+	}
+	configMap := &corev1.ConfigMap{}
+	if assert.NoError(t, execClient.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: "manifests"}, configMap), "expected configmap") {
+		assert.Equal(t, map[string]string{"manifest.yaml": "{}"}, configMap.Data, "unexpected data in configmap")
+	}
+}
+
+func TestReadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "executioncluster")
+	require.NoError(t, err, "unexpected error creating temp dir")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"kubeconfigSecretRef":{"name":"exec"},"hubKubeconfigSecretRef":{"name":"hub"}}`), 0600),
+		"unexpected error writing config")
+
+	os.Setenv(constants.ExecutionClusterConfigFileEnvVar, path)
+	defer os.Unsetenv(constants.ExecutionClusterConfigFileEnvVar)
+	config, err := ReadConfig()
+	require.NoError(t, err, "unexpected error reading config")
+	assert.Equal(t, &hivev1.ExecutionClusterConfig{
+		KubeconfigSecretRef:    corev1.LocalObjectReference{Name: "exec"},
+		HubKubeconfigSecretRef: corev1.LocalObjectReference{Name: "hub"},
+	}, config, "unexpected config")
+
+	os.Setenv(constants.ExecutionClusterConfigFileEnvVar, filepath.Join(dir, "missing"))
+	config, err = ReadConfig()
+	require.NoError(t, err, "unexpected error reading missing config")
+	assert.Nil(t, config, "expected no config")
+}
@@ -0,0 +1,130 @@
+// Package externalsecret resolves credentials secrets whose data is held in an external secret provider, so that
+// long-lived cloud credentials do not need to be copied into every ClusterDeployment namespace.
+//
+// A credentials secret references the external provider by setting the ExternalSecretPathAnnotation to the path
+// of the credentials in the provider, relative to the path prefix of its namespace. The data of such secrets is read
+// from the provider just-in-time, whenever the credentials are used, and is never stored in the cluster.
+package externalsecret
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// secretPathSegment matches the segments of the path of an external secret. Segments which could escape the path
+// prefix of the namespace, or change the meaning of the request to the provider, are not allowed.
+var secretPathSegment = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// Resolver reads secrets from an external secret provider.
+type Resolver interface {
+	// Resolve returns the data of the secret at the given path in the provider, relative to the path prefix of the
+	// namespace.
+	Resolve(namespace, path string) (map[string][]byte, error)
+}
+
+// NewResolver returns a Resolver for the external secret provider configured in the config.
+func NewResolver(config *hivev1.ExternalSecretsConfig) (Resolver, error) {
+	switch {
+	case config == nil:
+		return nil, errors.New("no external secret provider is configured")
+	case strings.Trim(config.PathPrefix, "/") == "":
+		return nil, errors.New("no path prefix is configured for external secrets")
+	case config.Vault != nil:
+		return newVaultResolver(config.PathPrefix, config.Vault), nil
+	default:
+		return nil, errors.New("unsupported external secret provider")
+	}
+}
+
+// resolverFor returns the Resolver for the configured external secret provider. It is a variable so that it can be
+// replaced in tests.
+var resolverFor = func() (Resolver, error) {
+	config, err := ReadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return NewResolver(config)
+}
+
+// NamespacedPath returns the path in the provider of the secret at the given path relative to the path prefix of the
+// namespace, "<prefix>/<namespace>/<path>". An error is returned if the path is absolute or has segments such as
+// ".." which could escape the prefix of the namespace.
+func NamespacedPath(prefix, namespace, path string) (string, error) {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return "", errors.New("no path prefix is configured for external secrets")
+	}
+	if !secretPathSegment.MatchString(namespace) {
+		return "", errors.Errorf("invalid namespace %q for external secret", namespace)
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "." || segment == ".." || !secretPathSegment.MatchString(segment) {
+			return "", errors.Errorf("invalid external secret path %q: must be a relative path of letters, digits, \"_\", \".\" and \"-\" without \".\" or \"..\" segments", path)
+		}
+	}
+	return prefix + "/" + namespace + "/" + path, nil
+}
+
+// IsExternal returns true if the secret references credentials held in an external secret provider.
+func IsExternal(secret *corev1.Secret) bool {
+	return secret != nil && secret.Annotations[constants.ExternalSecretPathAnnotation] != ""
+}
+
+// Resolve returns the secret with its data read from the external secret provider if it references one. Otherwise
+// the secret is returned as is. The secret passed in is not modified.
+func Resolve(secret *corev1.Secret) (*corev1.Secret, error) {
+	if !IsExternal(secret) {
+		return secret, nil
+	}
+	path := secret.Annotations[constants.ExternalSecretPathAnnotation]
+	resolver, err := resolverFor()
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot resolve external secret %s/%s", secret.Namespace, secret.Name)
+	}
+	data, err := resolver.Resolve(secret.Namespace, path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve external secret %s/%s from %s", secret.Namespace, secret.Name, path)
+	}
+	resolved := secret.DeepCopy()
+	resolved.Data = data
+	return resolved, nil
+}
+
+// ReadConfig reads the external secret provider configuration from the ExternalSecretsConfigEnvVar environment
+// variable, or else from the file pointed to by the ExternalSecretsConfigFileEnvVar environment variable. A nil
+// configuration is returned when no external secret provider is configured.
+func ReadConfig() (*hivev1.ExternalSecretsConfig, error) {
+	configBytes := []byte(os.Getenv(constants.ExternalSecretsConfigEnvVar))
+	if len(configBytes) == 0 {
+		path := os.Getenv(constants.ExternalSecretsConfigFileEnvVar)
+		if len(path) == 0 {
+			return nil, nil
+		}
+		fileBytes, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the external secrets config file")
+		}
+		configBytes = fileBytes
+	}
+	if len(configBytes) == 0 || string(configBytes) == "null" {
+		return nil, nil
+	}
+	config := &hivev1.ExternalSecretsConfig{}
+	if err := json.Unmarshal(configBytes, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the external secrets config")
+	}
+	return config, nil
+}
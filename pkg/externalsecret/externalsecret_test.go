@@ -0,0 +1,335 @@
+package externalsecret
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	testRole  = "hive"
+	testJWT   = "service-account-token"
+	testToken = "vault-token"
+)
+
+type fakeResolver map[string]map[string][]byte
+
+func (f fakeResolver) Resolve(namespace, path string) (map[string][]byte, error) {
+	data, ok := f[namespace+"/"+path]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return data, nil
+}
+
+func testSecret(annotations map[string]string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "test-namespace",
+			Name:        "test-creds",
+			Annotations: annotations,
+		},
+		Data: data,
+	}
+}
+
+func TestResolve(t *testing.T) {
+	resolver := fakeResolver{
+		"test-namespace/aws/prod": {constants.AWSAccessKeyIDSecretKey: []byte("key-id")},
+	}
+	cases := []struct {
+		name         string
+		secret       *corev1.Secret
+		resolverErr  error
+		expectErr    bool
+		expectedData map[string][]byte
+	}{
+		{
+			name:         "not external",
+			secret:       testSecret(nil, map[string][]byte{"key": []byte("value")}),
+			expectedData: map[string][]byte{"key": []byte("value")},
+		},
+		{
+			name:         "external",
+			secret:       testSecret(map[string]string{constants.ExternalSecretPathAnnotation: "aws/prod"}, nil),
+			expectedData: map[string][]byte{constants.AWSAccessKeyIDSecretKey: []byte("key-id")},
+		},
+		{
+			name:      "external not found",
+			secret:    testSecret(map[string]string{constants.ExternalSecretPathAnnotation: "aws/missing"}, nil),
+			expectErr: true,
+		},
+		{
+			name:        "no provider configured",
+			secret:      testSecret(map[string]string{constants.ExternalSecretPathAnnotation: "aws/prod"}, nil),
+			resolverErr: errors.New("no external secret provider is configured"),
+			expectErr:   true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(f func() (Resolver, error)) { resolverFor = f }(resolverFor)
+			resolverFor = func() (Resolver, error) {
+				return resolver, tc.resolverErr
+			}
+			orig := tc.secret.DeepCopy()
+
+			resolved, err := Resolve(tc.secret)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error resolving secret")
+				return
+			}
+			require.NoError(t, err, "unexpected error resolving secret")
+			assert.Equal(t, tc.expectedData, resolved.Data, "unexpected secret data")
+			assert.Equal(t, orig, tc.secret, "secret passed in should not be modified")
+		})
+	}
+}
+
+func TestVaultResolver(t *testing.T) {
+	cases := []struct {
+		name         string
+		prefix       string
+		path         string
+		response     interface{}
+		status       int
+		expectErr    bool
+		expectedData map[string][]byte
+	}{
+		{
+			name:   "kv version 2",
+			prefix: "secret/data/hive",
+			path:   "aws/prod",
+			response: map[string]interface{}{
+				"data": map[string]interface{}{
+					"data":     map[string]interface{}{"aws_access_key_id": "key-id", "aws_secret_access_key": "secret-key"},
+					"metadata": map[string]interface{}{"version": 3},
+				},
+			},
+			status: http.StatusOK,
+			expectedData: map[string][]byte{
+				"aws_access_key_id":     []byte("key-id"),
+				"aws_secret_access_key": []byte("secret-key"),
+			},
+		},
+		{
+			name:   "kv version 1",
+			prefix: "/kv/",
+			path:   "gcp",
+			response: map[string]interface{}{
+				"data": map[string]interface{}{"osServiceAccount.json": `{"project_id": "test"}`},
+			},
+			status:       http.StatusOK,
+			expectedData: map[string][]byte{"osServiceAccount.json": []byte(`{"project_id": "test"}`)},
+		},
+		{
+			name:      "not found",
+			prefix:    "secret/data/hive",
+			path:      "missing",
+			response:  map[string]interface{}{"errors": []string{}},
+			status:    http.StatusNotFound,
+			expectErr: true,
+		},
+		{
+			name:      "escape namespace",
+			prefix:    "secret/data/hive",
+			path:      "../other-namespace/aws",
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fullPath, _ := NamespacedPath(tc.prefix, "test-namespace", tc.path)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				switch req.URL.Path {
+				case "/v1/auth/k8s/login":
+					login := map[string]string{}
+					require.NoError(t, json.NewDecoder(req.Body).Decode(&login), "unexpected error decoding login")
+					if login["role"] != testRole || login["jwt"] != testJWT {
+						w.WriteHeader(http.StatusForbidden)
+						return
+					}
+					json.NewEncoder(w).Encode(map[string]interface{}{"auth": map[string]string{"client_token": testToken}})
+				case "/v1/" + fullPath:
+					if req.Header.Get("X-Vault-Token") != testToken {
+						w.WriteHeader(http.StatusForbidden)
+						return
+					}
+					w.WriteHeader(tc.status)
+					json.NewEncoder(w).Encode(tc.response)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			dir, err := ioutil.TempDir("", "vault")
+			require.NoError(t, err, "unexpected error creating temp dir")
+			defer os.RemoveAll(dir)
+			tokenPath := filepath.Join(dir, "token")
+			require.NoError(t, ioutil.WriteFile(tokenPath, []byte(testJWT+"\n"), 0600), "unexpected error writing token")
+
+			resolver := newVaultResolver(tc.prefix, &hivev1.VaultSecretProviderConfig{
+				Address:       server.URL + "/",
+				Role:          testRole,
+				AuthMountPath: "k8s",
+			})
+			resolver.tokenPath = tokenPath
+			resolver.httpClient = server.Client()
+
+			data, err := resolver.Resolve("test-namespace", tc.path)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error resolving secret")
+				return
+			}
+			require.NoError(t, err, "unexpected error resolving secret")
+			assert.Equal(t, tc.expectedData, data, "unexpected secret data")
+		})
+	}
+}
+
+func TestNamespacedPath(t *testing.T) {
+	cases := []struct {
+		name         string
+		prefix       string
+		path         string
+		expectErr    bool
+		expectedPath string
+	}{
+		{
+			name:         "relative path",
+			prefix:       "secret/data/hive/",
+			path:         "aws/prod",
+			expectedPath: "secret/data/hive/test-namespace/aws/prod",
+		},
+		{
+			name:      "no prefix",
+			path:      "aws/prod",
+			expectErr: true,
+		},
+		{
+			name:      "parent segment",
+			prefix:    "secret/data/hive",
+			path:      "aws/../../other-namespace/aws",
+			expectErr: true,
+		},
+		{
+			name:      "absolute path",
+			prefix:    "secret/data/hive",
+			path:      "/secret/data/other",
+			expectErr: true,
+		},
+		{
+			name:      "empty segment",
+			prefix:    "secret/data/hive",
+			path:      "aws//prod",
+			expectErr: true,
+		},
+		{
+			name:      "query",
+			prefix:    "secret/data/hive",
+			path:      "aws?version=1",
+			expectErr: true,
+		},
+		{
+			name:      "empty path",
+			prefix:    "secret/data/hive",
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := NamespacedPath(tc.prefix, "test-namespace", tc.path)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedPath, path, "unexpected path")
+		})
+	}
+}
+
+func TestVaultResolverCachesToken(t *testing.T) {
+	logins := 0
+	token := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/v1/auth/kubernetes/login":
+			logins++
+			token = fmt.Sprintf("%s-%d", testToken, logins)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"auth": map[string]interface{}{"client_token": token, "lease_duration": 3600},
+			})
+		case "/v1/secret/test-namespace/aws":
+			if req.Header.Get("X-Vault-Token") != token {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"key": "value"}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "vault")
+	require.NoError(t, err, "unexpected error creating temp dir")
+	defer os.RemoveAll(dir)
+	tokenPath := filepath.Join(dir, "token")
+	require.NoError(t, ioutil.WriteFile(tokenPath, []byte(testJWT), 0600), "unexpected error writing token")
+
+	now := time.Now()
+	resolver := newVaultResolver("secret", &hivev1.VaultSecretProviderConfig{Address: server.URL, Role: testRole})
+	resolver.tokenPath = tokenPath
+	resolver.httpClient = server.Client()
+	resolver.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		_, err := resolver.Resolve("test-namespace", "aws")
+		require.NoError(t, err, "unexpected error resolving secret")
+	}
+	assert.Equal(t, 1, logins, "expected cached token to be reused")
+
+	now = now.Add(time.Hour)
+	_, err = resolver.Resolve("test-namespace", "aws")
+	require.NoError(t, err, "unexpected error resolving secret")
+	assert.Equal(t, 2, logins, "expected login once the lease of the token expired")
+
+	token = "revoked"
+	_, err = resolver.Resolve("test-namespace", "aws")
+	require.NoError(t, err, "unexpected error resolving secret")
+	assert.Equal(t, 3, logins, "expected login once the token was revoked")
+}
+
+func TestReadConfig(t *testing.T) {
+	os.Setenv(constants.ExternalSecretsConfigEnvVar, `{"pathPrefix":"secret/data/hive","vault":{"address":"https://vault.example.com","role":"hive"}}`)
+	defer os.Unsetenv(constants.ExternalSecretsConfigEnvVar)
+	config, err := ReadConfig()
+	require.NoError(t, err, "unexpected error reading config")
+	assert.Equal(t, &hivev1.ExternalSecretsConfig{
+		PathPrefix: "secret/data/hive",
+		Vault:      &hivev1.VaultSecretProviderConfig{Address: "https://vault.example.com", Role: "hive"},
+	}, config, "unexpected config")
+
+	os.Setenv(constants.ExternalSecretsConfigEnvVar, "null")
+	config, err = ReadConfig()
+	require.NoError(t, err, "unexpected error reading config")
+	assert.Nil(t, config, "expected no config")
+}
@@ -0,0 +1,206 @@
+package externalsecret
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	defaultVaultAuthMountPath = "kubernetes"
+	serviceAccountTokenPath   = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	vaultRequestTimeout       = 30 * time.Second
+
+	// vaultTokenExpiryMargin is how long before the end of its lease a cached Vault token is replaced, so that it
+	// does not expire while in use.
+	vaultTokenExpiryMargin = time.Minute
+)
+
+// vaultToken is a Vault client token cached until shortly before its lease expires.
+type vaultToken struct {
+	token   string
+	expires time.Time
+}
+
+var (
+	// vaultTokens caches the client tokens of the Vault logins of the process, keyed by the address, auth mount path
+	// and role of the login, so that each secret read does not log in again.
+	vaultTokens     = map[string]vaultToken{}
+	vaultTokensLock sync.Mutex
+)
+
+// vaultResolver reads secrets from the KV secrets engine of HashiCorp Vault, logging in with the service account
+// token of the pod using the Kubernetes auth method.
+type vaultResolver struct {
+	address       string
+	role          string
+	authMountPath string
+	pathPrefix    string
+	tokenPath     string
+	httpClient    *http.Client
+	now           func() time.Time
+}
+
+func newVaultResolver(pathPrefix string, config *hivev1.VaultSecretProviderConfig) *vaultResolver {
+	authMountPath := config.AuthMountPath
+	if authMountPath == "" {
+		authMountPath = defaultVaultAuthMountPath
+	}
+	return &vaultResolver{
+		address:       strings.TrimSuffix(config.Address, "/"),
+		role:          config.Role,
+		authMountPath: strings.Trim(authMountPath, "/"),
+		pathPrefix:    pathPrefix,
+		tokenPath:     serviceAccountTokenPath,
+		httpClient:    &http.Client{Timeout: vaultRequestTimeout},
+		now:           time.Now,
+	}
+}
+
+// Resolve reads the secret at the path relative to the path prefix of the namespace. The prefix is the API path
+// without the "v1/" prefix, e.g. "secret/data/hive" for version 2 of the KV secrets engine.
+func (v *vaultResolver) Resolve(namespace, path string) (map[string][]byte, error) {
+	fullPath, err := NamespacedPath(v.pathPrefix, namespace, path)
+	if err != nil {
+		return nil, err
+	}
+	resp := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	err = v.read(fullPath, &resp)
+	if isForbidden(err) {
+		// The cached token may have been revoked before its lease expired.
+		v.forgetToken()
+		err = v.read(fullPath, &resp)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read secret from vault")
+	}
+	values := resp.Data
+	// Version 2 of the KV secrets engine nests the secret in the data of the response, alongside its metadata.
+	if nested, ok := values["data"].(map[string]interface{}); ok {
+		if _, ok := values["metadata"]; ok {
+			values = nested
+		}
+	}
+	if len(values) == 0 {
+		return nil, errors.New("secret not found in vault")
+	}
+	data := make(map[string][]byte, len(values))
+	for k, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("value of key %q of secret in vault is not a string", k)
+		}
+		data[k] = []byte(s)
+	}
+	return data, nil
+}
+
+func (v *vaultResolver) read(path string, into interface{}) error {
+	token, err := v.token()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/%s", v.address, path), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	return v.do(req, into)
+}
+
+func (v *vaultResolver) tokenKey() string {
+	return v.address + "|" + v.authMountPath + "|" + v.role
+}
+
+// token returns the cached client token of the login, logging in if there is none or it is about to expire.
+func (v *vaultResolver) token() (string, error) {
+	vaultTokensLock.Lock()
+	defer vaultTokensLock.Unlock()
+	if cached, ok := vaultTokens[v.tokenKey()]; ok && v.now().Before(cached.expires) {
+		return cached.token, nil
+	}
+	token, leaseDuration, err := v.login()
+	if err != nil {
+		return "", err
+	}
+	if lifetime := leaseDuration - vaultTokenExpiryMargin; lifetime > 0 {
+		vaultTokens[v.tokenKey()] = vaultToken{token: token, expires: v.now().Add(lifetime)}
+	}
+	return token, nil
+}
+
+func (v *vaultResolver) forgetToken() {
+	vaultTokensLock.Lock()
+	defer vaultTokensLock.Unlock()
+	delete(vaultTokens, v.tokenKey())
+}
+
+// login logs in to Vault, returning the client token and the duration of its lease.
+func (v *vaultResolver) login() (string, time.Duration, error) {
+	jwt, err := ioutil.ReadFile(v.tokenPath)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "failed to read service account token")
+	}
+	body, err := json.Marshal(map[string]string{
+		"role": v.role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/v1/auth/%s/login", v.address, v.authMountPath), bytes.NewReader(body))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp := struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int64  `json:"lease_duration"`
+		} `json:"auth"`
+	}{}
+	if err := v.do(req, &resp); err != nil {
+		return "", 0, errors.Wrap(err, "failed to log in to vault")
+	}
+	if resp.Auth.ClientToken == "" {
+		return "", 0, errors.New("failed to log in to vault: no client token returned")
+	}
+	return resp.Auth.ClientToken, time.Duration(resp.Auth.LeaseDuration) * time.Second, nil
+}
+
+// vaultStatusError is returned for unsuccessful responses from Vault.
+type vaultStatusError struct {
+	statusCode int
+}
+
+func (e *vaultStatusError) Error() string {
+	return fmt.Sprintf("vault responded with status %d", e.statusCode)
+}
+
+func isForbidden(err error) bool {
+	statusErr, ok := err.(*vaultStatusError)
+	return ok && statusErr.statusCode == http.StatusForbidden
+}
+
+func (v *vaultResolver) do(req *http.Request, into interface{}) error {
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &vaultStatusError{statusCode: resp.StatusCode}
+	}
+	return json.NewDecoder(resp.Body).Decode(into)
+}
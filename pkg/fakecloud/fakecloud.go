@@ -0,0 +1,180 @@
+// Package fakecloud simulates the cloud inventory of fake clusters, so that the uninstall flow, and the detection of
+// the resources it leaks, can be exercised without a cloud account.
+package fakecloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+// Resource is a simulated cloud resource.
+type Resource struct {
+	// ID uniquely identifies the resource in the inventory.
+	ID string `json:"id"`
+	// Type is the kind of the resource, such as vpc or instance. It is only used in logs.
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Tags of the resource. Resources tagged with the owned tag of an infra ID belong to that cluster, and are deleted
+	// when it is deprovisioned.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+	// DependsOn lists the IDs of the resources which cannot be deleted while this resource exists, such as the VPC of
+	// a subnet.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// FailedDeletes is the number of attempts to delete the resource which fail before one succeeds, to simulate
+	// transient errors of the cloud.
+	// +optional
+	FailedDeletes int `json:"failedDeletes,omitempty"`
+	// Undeletable resources fail every attempt to delete them, to simulate resources leaked by deprovision.
+	// +optional
+	Undeletable bool `json:"undeletable,omitempty"`
+}
+
+// Inventory is a simulated cloud account: a graph of resources and their dependencies.
+type Inventory struct {
+	Resources []Resource `json:"resources"`
+}
+
+// OwnedTag returns the key and value of the tag of the resources owned by the cluster with the infra ID, as the
+// installer tags them.
+func OwnedTag(infraID string) (string, string) {
+	return fmt.Sprintf("kubernetes.io/cluster/%s", infraID), "owned"
+}
+
+// Parse parses a JSON inventory, checking that the IDs of its resources are unique and that they only depend on
+// resources of the inventory.
+func Parse(data []byte) (*Inventory, error) {
+	inventory := &Inventory{}
+	if err := json.Unmarshal(data, inventory); err != nil {
+		return nil, errors.Wrap(err, "could not parse the inventory")
+	}
+	ids := map[string]bool{}
+	for _, r := range inventory.Resources {
+		if r.ID == "" {
+			return nil, errors.New("resource of the inventory has no ID")
+		}
+		if ids[r.ID] {
+			return nil, fmt.Errorf("resource %s appears more than once in the inventory", r.ID)
+		}
+		ids[r.ID] = true
+	}
+	for _, r := range inventory.Resources {
+		for _, dep := range r.DependsOn {
+			if !ids[dep] {
+				return nil, fmt.Errorf("resource %s depends on %s, which is not in the inventory", r.ID, dep)
+			}
+		}
+	}
+	return inventory, nil
+}
+
+// DefaultInventory returns the inventory of a typical AWS cluster with the infra ID, installed in a shared hosted zone
+// of the base domain which the cluster does not own.
+func DefaultInventory(infraID string) *Inventory {
+	key, value := OwnedTag(infraID)
+	owned := map[string]string{key: value}
+	id := func(suffix string) string {
+		return fmt.Sprintf("%s-%s", infraID, suffix)
+	}
+	resource := func(suffix, resourceType string, dependsOn ...string) Resource {
+		return Resource{ID: id(suffix), Type: resourceType, Tags: owned, DependsOn: dependsOn}
+	}
+	inventory := &Inventory{Resources: []Resource{
+		{ID: "base-domain-zone", Type: "hostedzone"},
+		resource("vpc", "vpc"),
+		resource("igw", "internetgateway", id("vpc")),
+		resource("private-zone", "hostedzone", id("vpc")),
+		resource("api-record", "record", "base-domain-zone", id("ext-lb")),
+		resource("apps-record", "record", "base-domain-zone"),
+		resource("int-api-record", "record", id("private-zone"), id("int-lb")),
+		resource("sg-master", "securitygroup", id("vpc")),
+		resource("sg-worker", "securitygroup", id("vpc")),
+		resource("ext-lb", "loadbalancer", id("vpc"), id("sg-master")),
+		resource("int-lb", "loadbalancer", id("vpc"), id("sg-master")),
+		resource("image-registry-bucket", "bucket"),
+		resource("bootstrap-bucket", "bucket"),
+		resource("master-role", "iamrole"),
+		resource("worker-role", "iamrole"),
+	}}
+	for _, zone := range []string{"a", "b", "c"} {
+		public := resource("public-"+zone, "subnet", id("vpc"))
+		private := resource("private-"+zone, "subnet", id("vpc"))
+		eip := resource("eip-"+zone, "elasticip")
+		nat := resource("nat-"+zone, "natgateway", public.ID, eip.ID)
+		routeTable := resource("rtb-private-"+zone, "routetable", id("vpc"), private.ID, nat.ID)
+		master := resource("master-"+zone, "instance", private.ID, id("sg-master"), id("master-role"))
+		worker := resource("worker-"+zone, "instance", private.ID, id("sg-worker"), id("worker-role"))
+		inventory.Resources = append(inventory.Resources, public, private, eip, nat, routeTable, master, worker)
+	}
+	return inventory
+}
+
+// Destroy deletes the resources of the inventory owned by the cluster with the infra ID, as the uninstaller does,
+// deleting a resource only once the resources depending on it are deleted. Attempts to delete resources are repeated
+// until no resource can be deleted anymore. The inventory is left with the resources which were not deleted, and the
+// owned resources among them are returned as leaked.
+func Destroy(inventory *Inventory, infraID string, logger log.FieldLogger) []Resource {
+	key, value := OwnedTag(infraID)
+	remaining := map[string]*Resource{}
+	for i := range inventory.Resources {
+		r := &inventory.Resources[i]
+		remaining[r.ID] = r
+	}
+	dependents := func(id string) []string {
+		var ids []string
+		for _, r := range remaining {
+			for _, dep := range r.DependsOn {
+				if dep == id {
+					ids = append(ids, r.ID)
+				}
+			}
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	for progress := true; progress; {
+		progress = false
+		for _, r := range inventory.Resources {
+			r := remaining[r.ID]
+			if r == nil || r.Tags[key] != value {
+				continue
+			}
+			resourceLog := logger.WithField("id", r.ID).WithField("type", r.Type)
+			if ids := dependents(r.ID); len(ids) > 0 {
+				resourceLog.WithField("dependents", strings.Join(ids, ",")).Debug("resource still has dependents")
+				continue
+			}
+			switch {
+			case r.Undeletable:
+				resourceLog.Warn("could not delete resource")
+			case r.FailedDeletes > 0:
+				r.FailedDeletes--
+				resourceLog.Info("could not delete resource, retrying")
+				progress = true
+			default:
+				delete(remaining, r.ID)
+				resourceLog.Info("deleted resource")
+				progress = true
+			}
+		}
+	}
+
+	var left, leaked []Resource
+	for _, r := range inventory.Resources {
+		if r := remaining[r.ID]; r != nil {
+			left = append(left, *r)
+			if r.Tags[key] == value {
+				leaked = append(leaked, *r)
+			}
+		}
+	}
+	inventory.Resources = left
+	return leaked
+}
@@ -0,0 +1,121 @@
+package fakecloud
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testInfraID = "test-infra-id"
+
+func TestDestroy(t *testing.T) {
+	key, value := OwnedTag(testInfraID)
+	owned := map[string]string{key: value}
+
+	tests := []struct {
+		name            string
+		inventory       *Inventory
+		expectLeaked    []string
+		expectRemaining []string
+	}{
+		{
+			name:            "default inventory",
+			inventory:       DefaultInventory(testInfraID),
+			expectRemaining: []string{"base-domain-zone"},
+		},
+		{
+			name:            "other cluster",
+			inventory:       DefaultInventory("other-infra-id"),
+			expectRemaining: idsOf(DefaultInventory("other-infra-id").Resources),
+		},
+		{
+			name: "transient failures",
+			inventory: &Inventory{Resources: []Resource{
+				{ID: "vpc", Tags: owned, FailedDeletes: 2},
+				{ID: "subnet", Tags: owned, DependsOn: []string{"vpc"}, FailedDeletes: 3},
+			}},
+		},
+		{
+			name: "undeletable",
+			inventory: &Inventory{Resources: []Resource{
+				{ID: "vpc", Tags: owned},
+				{ID: "subnet", Tags: owned, DependsOn: []string{"vpc"}, Undeletable: true},
+				{ID: "bucket", Tags: owned},
+			}},
+			expectLeaked:    []string{"vpc", "subnet"},
+			expectRemaining: []string{"vpc", "subnet"},
+		},
+		{
+			name: "unowned dependent",
+			inventory: &Inventory{Resources: []Resource{
+				{ID: "vpc", Tags: owned},
+				{ID: "peering", DependsOn: []string{"vpc"}},
+			}},
+			expectLeaked:    []string{"vpc"},
+			expectRemaining: []string{"vpc", "peering"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			leaked := Destroy(test.inventory, testInfraID, log.WithField("test", test.name))
+			assert.Equal(t, test.expectLeaked, idsOf(leaked), "unexpected leaked resources")
+			assert.Equal(t, test.expectRemaining, idsOf(test.inventory.Resources), "unexpected remaining resources")
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expectIDs   []string
+		expectError bool
+	}{
+		{
+			name:      "valid",
+			data:      `{"resources":[{"id":"vpc","tags":{"kubernetes.io/cluster/test-infra-id":"owned"}},{"id":"subnet","dependsOn":["vpc"]}]}`,
+			expectIDs: []string{"vpc", "subnet"},
+		},
+		{
+			name:        "invalid JSON",
+			data:        `{"resources":`,
+			expectError: true,
+		},
+		{
+			name:        "missing ID",
+			data:        `{"resources":[{"type":"vpc"}]}`,
+			expectError: true,
+		},
+		{
+			name:        "duplicate ID",
+			data:        `{"resources":[{"id":"vpc"},{"id":"vpc"}]}`,
+			expectError: true,
+		},
+		{
+			name:        "unknown dependency",
+			data:        `{"resources":[{"id":"subnet","dependsOn":["vpc"]}]}`,
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inventory, err := Parse([]byte(test.data))
+			if test.expectError {
+				assert.Error(t, err, "expected error parsing inventory")
+				return
+			}
+			require.NoError(t, err, "unexpected error parsing inventory")
+			assert.Equal(t, test.expectIDs, idsOf(inventory.Resources), "unexpected resources")
+		})
+	}
+}
+
+func idsOf(resources []Resource) []string {
+	var ids []string
+	for _, r := range resources {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
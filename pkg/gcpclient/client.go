@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/externalsecret"
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2/google"
 	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
@@ -45,8 +46,14 @@ type Client interface {
 
 	ListComputeImages(ListComputeImagesOptions) (*compute.ImageList, error)
 
+	GetComputeImage(project, name string) (*compute.Image, error)
+
+	GetComputeImageFromFamily(project, family string) (*compute.Image, error)
+
 	ListComputeInstances(ListComputeInstancesOptions, func(*compute.InstanceAggregatedList) error) error
 
+	GetComputeRouter(region, name string) (*compute.Router, error)
+
 	StopInstance(*compute.Instance) error
 
 	StartInstance(*compute.Instance) error
@@ -232,6 +239,30 @@ func (c *gcpClient) ListComputeImages(opts ListComputeImagesOptions) (*compute.I
 	return call.Do()
 }
 
+// GetComputeImage returns the image with the name in the project.
+func (c *gcpClient) GetComputeImage(project, name string) (*compute.Image, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+
+	return c.computeClient.Images.Get(project, name).Context(ctx).Do()
+}
+
+// GetComputeRouter returns the Cloud Router with the name in the region of the project.
+func (c *gcpClient) GetComputeRouter(region, name string) (*compute.Router, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+
+	return c.computeClient.Routers.Get(c.projectName, region, name).Context(ctx).Do()
+}
+
+// GetComputeImageFromFamily returns the latest image of the image family in the project which is not deprecated.
+func (c *gcpClient) GetComputeImageFromFamily(project, family string) (*compute.Image, error) {
+	ctx, cancel := contextWithTimeout(context.TODO())
+	defer cancel()
+
+	return c.computeClient.Images.GetFromFamily(project, family).Context(ctx).Do()
+}
+
 func (c *gcpClient) ListComputeInstances(opts ListComputeInstancesOptions, pagesFn func(*compute.InstanceAggregatedList) error) error {
 	req := c.computeClient.Instances.AggregatedList(c.projectName)
 	if len(opts.Fields) > 0 {
@@ -366,6 +397,10 @@ func authJSONPassthroughSource(authJSON []byte) func() ([]byte, error) {
 
 func authJSONFromSecretSource(secret *corev1.Secret) func() ([]byte, error) {
 	return func() ([]byte, error) {
+		secret, err := externalsecret.Resolve(secret)
+		if err != nil {
+			return nil, err
+		}
 		authJSON, ok := secret.Data[constants.GCPCredentialsName]
 		if !ok {
 			return nil, errors.New("creds secret does not contain \"" + constants.GCPCredentialsName + "\" data")
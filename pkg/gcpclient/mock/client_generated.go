@@ -107,6 +107,51 @@ func (mr *MockClientMockRecorder) DeleteResourceRecordSets(managedZone, recordSe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteResourceRecordSets", reflect.TypeOf((*MockClient)(nil).DeleteResourceRecordSets), managedZone, recordSet)
 }
 
+// GetComputeImage mocks base method.
+func (m *MockClient) GetComputeImage(project, name string) (*compute.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComputeImage", project, name)
+	ret0, _ := ret[0].(*compute.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComputeImage indicates an expected call of GetComputeImage.
+func (mr *MockClientMockRecorder) GetComputeImage(project, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComputeImage", reflect.TypeOf((*MockClient)(nil).GetComputeImage), project, name)
+}
+
+// GetComputeImageFromFamily mocks base method.
+func (m *MockClient) GetComputeImageFromFamily(project, family string) (*compute.Image, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComputeImageFromFamily", project, family)
+	ret0, _ := ret[0].(*compute.Image)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComputeImageFromFamily indicates an expected call of GetComputeImageFromFamily.
+func (mr *MockClientMockRecorder) GetComputeImageFromFamily(project, family interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComputeImageFromFamily", reflect.TypeOf((*MockClient)(nil).GetComputeImageFromFamily), project, family)
+}
+
+// GetComputeRouter mocks base method.
+func (m *MockClient) GetComputeRouter(region, name string) (*compute.Router, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComputeRouter", region, name)
+	ret0, _ := ret[0].(*compute.Router)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComputeRouter indicates an expected call of GetComputeRouter.
+func (mr *MockClientMockRecorder) GetComputeRouter(region, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComputeRouter", reflect.TypeOf((*MockClient)(nil).GetComputeRouter), region, name)
+}
+
 // GetManagedZone mocks base method.
 func (m *MockClient) GetManagedZone(managedZone string) (*dns.ManagedZone, error) {
 	m.ctrl.T.Helper()
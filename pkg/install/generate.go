@@ -147,6 +147,7 @@ func InstallerPodSpec(
 	serviceAccountName,
 	httpProxy, httpsProxy, noProxy string,
 	extraEnvVars []corev1.EnvVar,
+	imageOverride *hivev1.InstallManagerImageOverride,
 ) (*corev1.PodSpec, error) {
 
 	if cd.Spec.Provisioning == nil {
@@ -492,6 +493,25 @@ func InstallerPodSpec(
 		return nil, fmt.Errorf("cli image not resolved")
 	}
 	cliImage := *cd.Status.CLIImage
+	cliImagePullPolicy := corev1.PullIfNotPresent
+
+	hiveImage := images.GetHiveImage()
+	hiveImagePullPolicy := images.GetHiveClusterProvisionImagePullPolicy()
+
+	if imageOverride != nil {
+		if imageOverride.InstallManagerImage != "" {
+			hiveImage = imageOverride.InstallManagerImage
+			if imageOverride.ImagePullPolicy != "" {
+				hiveImagePullPolicy = imageOverride.ImagePullPolicy
+			}
+		}
+		if imageOverride.CLIImage != "" {
+			cliImage = imageOverride.CLIImage
+			if imageOverride.ImagePullPolicy != "" {
+				cliImagePullPolicy = imageOverride.ImagePullPolicy
+			}
+		}
+	}
 
 	hiveArg := fmt.Sprintf("/usr/bin/hiveutil install-manager --work-dir /output --log-level debug %s %s", cd.Namespace, provisionName)
 	if cd.Spec.Platform.VSphere != nil {
@@ -528,7 +548,7 @@ func InstallerPodSpec(
 		{
 			Name:            "cli",
 			Image:           cliImage,
-			ImagePullPolicy: corev1.PullIfNotPresent,
+			ImagePullPolicy: cliImagePullPolicy,
 			Env:             env,
 			Command:         []string{"/bin/sh", "-c"},
 			// Large file copy here has shown to cause problems in clusters under load, safer to copy then rename to the file the install manager is waiting for
@@ -538,8 +558,8 @@ func InstallerPodSpec(
 		},
 		{
 			Name:            "hive",
-			Image:           images.GetHiveImage(),
-			ImagePullPolicy: images.GetHiveClusterProvisionImagePullPolicy(),
+			Image:           hiveImage,
+			ImagePullPolicy: hiveImagePullPolicy,
 			Env:             append(env, cd.Spec.Provisioning.InstallerEnv...),
 			Command:         []string{"/bin/sh", "-c"},
 			Args:            []string{hiveArg},
@@ -652,6 +672,8 @@ func GenerateUninstallerJobForDeprovision(
 	}
 
 	switch {
+	case controllerutils.IsFakeClusterDeprovision(req):
+		completeFakeDeprovisionJob(req, job)
 	case req.Spec.Platform.AWS != nil:
 		completeAWSDeprovisionJob(req, job)
 	case req.Spec.Platform.Azure != nil:
@@ -676,6 +698,25 @@ func GenerateUninstallerJobForDeprovision(
 	return job, nil
 }
 
+// completeFakeDeprovisionJob deprovisions a fake cluster from a simulated cloud inventory, so that no cloud
+// credentials are needed.
+func completeFakeDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
+	container := corev1.Container{
+		Name:            "deprovision",
+		Image:           images.GetHiveImage(),
+		ImagePullPolicy: images.GetHiveImagePullPolicy(),
+		Command:         []string{"/usr/bin/hiveutil"},
+		Args:            []string{"deprovision", "fake", "--loglevel", "debug", req.Spec.InfraID},
+	}
+	if inventory, ok := req.Annotations[constants.HiveFakeCloudInventoryAnnotation]; ok {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  constants.FakeCloudInventoryEnvVar,
+			Value: inventory,
+		})
+	}
+	job.Spec.Template.Spec.Containers = []corev1.Container{container}
+}
+
 func completeAWSDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Job) {
 	credentialRef := *req.Spec.Platform.AWS.CredentialsSecretRef
 	if credentialRef.Name == "" {
@@ -790,6 +831,12 @@ func completeAzureDeprovisionJob(req *hivev1.ClusterDeprovision, job *batchv1.Jo
 	if req.Spec.Platform.Azure.CloudName != nil {
 		containers[0].Args = append(containers[0].Args, "--azure-cloud-name", req.Spec.Platform.Azure.CloudName.Name())
 	}
+	if req.Spec.Platform.Azure.ResourceGroupName != "" {
+		containers[0].Args = append(containers[0].Args, "--azure-resource-group-name", req.Spec.Platform.Azure.ResourceGroupName)
+	}
+	if req.Spec.Platform.Azure.BaseDomainResourceGroupName != "" {
+		containers[0].Args = append(containers[0].Args, "--azure-base-domain-resource-group-name", req.Spec.Platform.Azure.BaseDomainResourceGroupName)
+	}
 	job.Spec.Template.Spec.Containers = containers
 	job.Spec.Template.Spec.Volumes = volumes
 
@@ -68,6 +68,7 @@ func TestInstallerPodSpec(t *testing.T) {
 		pvcName            string
 		skipGatherLogs     bool
 		extraEnvVars       []corev1.EnvVar
+		imageOverride      *hivev1.InstallManagerImageOverride
 		validate           func(*testing.T, *corev1.PodSpec, error)
 	}{
 		{
@@ -103,6 +104,34 @@ func TestInstallerPodSpec(t *testing.T) {
 				assert.NoError(t, actualError)
 			},
 		},
+		{
+			name: "Test Provision Pod Image Override",
+			clusterDeployment: &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Provisioning: &hivev1.Provisioning{
+						InstallConfigSecretRef: &corev1.LocalObjectReference{Name: "foo"},
+					},
+				},
+				Status: hivev1.ClusterDeploymentStatus{
+					InstallerImage: &installerImage,
+					CLIImage:       &cliImage,
+				},
+			},
+			provisionName: "testprovision",
+			imageOverride: &hivev1.InstallManagerImageOverride{
+				OpenShiftVersion:    "4.9",
+				InstallManagerImage: "fakeinstallmanagerimage",
+				ImagePullPolicy:     corev1.PullAlways,
+			},
+			validate: func(t *testing.T, actualPodSpec *corev1.PodSpec, actualError error) {
+				assert.NoError(t, actualError)
+				assert.Equal(t, installerImage, actualPodSpec.Containers[0].Image, "unexpected installer image")
+				assert.Equal(t, cliImage, actualPodSpec.Containers[1].Image, "unexpected cli image")
+				assert.Equal(t, corev1.PullIfNotPresent, actualPodSpec.Containers[1].ImagePullPolicy, "unexpected cli image pull policy")
+				assert.Equal(t, "fakeinstallmanagerimage", actualPodSpec.Containers[2].Image, "unexpected install manager image")
+				assert.Equal(t, corev1.PullAlways, actualPodSpec.Containers[2].ImagePullPolicy, "unexpected install manager image pull policy")
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -117,7 +146,8 @@ func TestInstallerPodSpec(t *testing.T) {
 				testHttpProxy,
 				testHttpsProxy,
 				testNoProxy,
-				test.extraEnvVars)
+				test.extraEnvVars,
+				test.imageOverride)
 
 			// Assert
 			test.validate(t, actualPodSpec, actualError)
@@ -9,8 +9,9 @@ import (
 	"os"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
@@ -103,19 +104,19 @@ func (options *AWSCredentials) Run() error {
 		}
 	}
 
-	sess, err := awsclient.NewSessionFromSecret(secret, "")
+	cfg, err := awsclient.NewConfigFromSecret(secret, "")
 	if err != nil {
-		return errors.Wrap(err, "failed to create AWS session")
+		return errors.Wrap(err, "failed to create AWS config")
 	}
 
 	duration := stscreds.DefaultDuration
-	creds := stscreds.NewCredentials(sess, options.RoleARN, func(p *stscreds.AssumeRoleProvider) {
-		p.Duration = duration
+	creds := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), options.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.Duration = duration
 		if options.ExternalID != "" {
-			p.ExternalID = &options.ExternalID
+			o.ExternalID = &options.ExternalID
 		}
 	})
-	v, err := creds.Get()
+	v, err := creds.Retrieve(context.TODO())
 	if err != nil {
 		return errors.Wrap(err, "failed to Assume the require role")
 	}
@@ -128,7 +129,7 @@ func (options *AWSCredentials) Run() error {
 	return err
 }
 
-func newCredentialProcessResponse(v credentials.Value, expiry time.Time) (string, error) {
+func newCredentialProcessResponse(v aws.Credentials, expiry time.Time) (string, error) {
 	resp := &credentialProcessResponse{
 		Version:         1,
 		AccessKeyID:     v.AccessKeyID,
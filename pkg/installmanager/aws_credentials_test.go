@@ -4,7 +4,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -17,11 +17,11 @@ func TestNewCredentialProcessResponse(t *testing.T) {
 
 	cases := []struct {
 		name  string
-		creds credentials.Value
+		creds aws.Credentials
 		resp  string
 	}{{
 		name: "valid credentials",
-		creds: credentials.Value{
+		creds: aws.Credentials{
 			AccessKeyID:     "ASX..ID...",
 			SecretAccessKey: "ASX..SECRET...",
 			SessionToken:    "ASX..TOKEN...",
@@ -57,6 +57,10 @@ func cleanupAWSDNSZone(dnsZone *hivev1.DNSZone, region string, logger log.FieldL
 	}
 
 	zoneLogger := logger.WithField("dnsZoneID", *dnsZone.Status.AWS.ZoneID)
+	if dns.UsesExistingAWSHostedZone(dnsZone) {
+		zoneLogger.Info("not cleaning up existing hosted zone of DNSZone")
+		return nil
+	}
 	zoneLogger.Info("cleaning up DNSZone")
 
 	awsClient, err := awsclient.NewClient(nil, "", "", region)
@@ -0,0 +1,84 @@
+package installmanager
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/externalsecret"
+)
+
+// resolveExternalCredentials reads the cloud credentials of the cluster from the external secret provider when its
+// credentials secret references one, and makes them available to the installer in place of the credentials mounted
+// from the secret.
+func (m *InstallManager) resolveExternalCredentials(cd *hivev1.ClusterDeployment) error {
+	var secretName string
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		secretName = cd.Spec.Platform.AWS.CredentialsSecretRef.Name
+	case cd.Spec.Platform.Azure != nil:
+		secretName = cd.Spec.Platform.Azure.CredentialsSecretRef.Name
+	case cd.Spec.Platform.GCP != nil:
+		secretName = cd.Spec.Platform.GCP.CredentialsSecretRef.Name
+	}
+	if secretName == "" {
+		return nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := m.DynamicClient.Get(context.TODO(), types.NamespacedName{Namespace: m.Namespace, Name: secretName}, secret); err != nil {
+		return errors.Wrap(err, "failed to get credentials secret")
+	}
+	if !externalsecret.IsExternal(secret) {
+		return nil
+	}
+	m.log.WithField("secret", secretName).Info("resolving credentials from external secret provider")
+	secret, err := externalsecret.Resolve(secret)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		for envVar, key := range map[string]string{
+			"AWS_ACCESS_KEY_ID":     constants.AWSAccessKeyIDSecretKey,
+			"AWS_SECRET_ACCESS_KEY": constants.AWSSecretAccessKeySecretKey,
+		} {
+			if value, ok := secret.Data[key]; ok {
+				os.Setenv(envVar, string(value))
+			}
+		}
+		if config, ok := secret.Data[constants.AWSConfigSecretKey]; ok {
+			return writeCredentialsFile(config, "AWS_CONFIG_FILE")
+		}
+	case cd.Spec.Platform.Azure != nil:
+		return writeCredentialsFile(secret.Data[constants.AzureCredentialsName], "AZURE_AUTH_LOCATION")
+	case cd.Spec.Platform.GCP != nil:
+		return writeCredentialsFile(secret.Data[constants.GCPCredentialsName], "GOOGLE_CREDENTIALS")
+	}
+	return nil
+}
+
+// writeCredentialsFile writes the credentials to a file readable only by the installmanager, and points the
+// environment variable at it.
+func writeCredentialsFile(data []byte, envVar string) error {
+	if len(data) == 0 {
+		return errors.Errorf("external secret has no credentials for %s", envVar)
+	}
+	f, err := ioutil.TempFile("", "hive-credentials")
+	if err != nil {
+		return errors.Wrap(err, "failed to create credentials file")
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		return errors.Wrap(err, "failed to write credentials file")
+	}
+	return os.Setenv(envVar, f.Name())
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
 	installertypes "github.com/openshift/installer/pkg/types"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -38,3 +40,18 @@ func fakeProvisionCluster(m *InstallManager) error {
 	m.log.Warn("skipping openshift-install create cluster for fake install")
 	return nil
 }
+
+func fakeProvisionImageBasedCluster(m *InstallManager, cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision) error {
+	m.log.Warn("skipping reimaging of the host for fake install")
+	return nil
+}
+
+func fakeGatherPlatformStatus(cd *hivev1.ClusterDeployment, metadata *installertypes.ClusterMetadata, logger log.FieldLogger) (*hivev1.PlatformStatus, error) {
+	logger.Warn("skipping gathering platform status for fake install")
+	return nil, nil
+}
+
+func fakeValidateUserProvidedNetwork(cd *hivev1.ClusterDeployment, installConfig *installertypes.InstallConfig, logger log.FieldLogger) (*hivev1.PlatformStatus, error) {
+	logger.Warn("skipping validating user-provided network for fake install")
+	return nil, nil
+}
@@ -0,0 +1,250 @@
+package installmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1 "github.com/openshift/api/config/v1"
+	installertypes "github.com/openshift/installer/pkg/types"
+	installertypesbaremetal "github.com/openshift/installer/pkg/types/baremetal"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	imageBasedConfigFilename        = "image-based-config.yaml"
+	imageBasedConfigImageFilename   = "imagebasedconfig.iso"
+	imageBasedExtraManifestsDir     = "extra-manifests"
+	imageBasedConfigImageHostPath   = "/var/tmp/" + imageBasedConfigImageFilename
+	defaultImageBasedInstallSSHUser = "core"
+	imageBasedInstallTimeout        = 60 * time.Minute
+	imageBasedInstallPollInterval   = 30 * time.Second
+	// maxInfraIDBaseLength matches the length of the cluster name the installer keeps in the infra ID.
+	maxInfraIDBaseLength = 27
+)
+
+// imageBasedConfig is the ImageBasedConfig from which openshift-install creates the configuration image of an
+// image-based install.
+type imageBasedConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	Metadata        imageBasedConfigMetadata `json:"metadata"`
+	Hostname        string                   `json:"hostname"`
+	ClusterID       string                   `json:"clusterID"`
+	InfraID         string                   `json:"infraID"`
+}
+
+type imageBasedConfigMetadata struct {
+	Name string `json:"name"`
+}
+
+func isImageBasedInstall(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Provisioning != nil && cd.Spec.Provisioning.ImageBasedInstall != nil
+}
+
+// generateImageBasedAssets creates the configuration image of an image-based install, from which the host pre-installed
+// from a seed image reconfigures itself into the cluster. The installer does not generate the cluster metadata for
+// image-based installs, so it is written here from the cluster and infra IDs given to the configuration.
+func (m *InstallManager) generateImageBasedAssets(cd *hivev1.ClusterDeployment) error {
+	infraID := generateInfraID(cd.Spec.ClusterName)
+	clusterID := uuid.New().String()
+
+	configData, err := generateImageBasedConfig(cd, infraID, clusterID)
+	if err != nil {
+		m.log.WithError(err).Error("error generating image-based config")
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(m.WorkDir, imageBasedConfigFilename), configData, 0644); err != nil {
+		m.log.WithError(err).Error("error writing image-based config")
+		return err
+	}
+
+	// Manifests for an image-based install are applied by the host once it is reconfigured.
+	extraManifestsDir := filepath.Join(m.WorkDir, imageBasedExtraManifestsDir)
+	if err := os.MkdirAll(extraManifestsDir, 0755); err != nil {
+		m.log.WithError(err).Errorf("error creating %s directory", extraManifestsDir)
+		return err
+	}
+	if err := m.writeEtcdEncryptionManifest(cd, extraManifestsDir); err != nil {
+		m.log.WithError(err).Error("error writing etcd encryption manifest")
+		return err
+	}
+	if err := m.copyUserManifests(extraManifestsDir); err != nil {
+		return err
+	}
+
+	m.log.Info("running openshift-install image-based create config-image")
+	if err := m.runOpenShiftInstallCommand("image-based", "create", "config-image"); err != nil {
+		m.log.WithError(err).Error("error generating configuration image")
+		return err
+	}
+
+	metadata := &installertypes.ClusterMetadata{
+		ClusterName: cd.Spec.ClusterName,
+		ClusterID:   clusterID,
+		InfraID:     infraID,
+		ClusterPlatformMetadata: installertypes.ClusterPlatformMetadata{
+			BareMetal: &installertypesbaremetal.Metadata{},
+		},
+	}
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		m.log.WithError(err).Error("error marshalling cluster metadata")
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(m.WorkDir, metadataRelativePath), metadataBytes, 0644); err != nil {
+		m.log.WithError(err).Error("error writing cluster metadata")
+		return err
+	}
+
+	m.log.Info("image-based assets generated successfully")
+	return nil
+}
+
+// generateImageBasedConfig generates the ImageBasedConfig of the cluster.
+func generateImageBasedConfig(cd *hivev1.ClusterDeployment, infraID, clusterID string) ([]byte, error) {
+	hostname := cd.Spec.Provisioning.ImageBasedInstall.Hostname
+	if hostname == "" {
+		hostname = cd.Spec.ClusterName
+	}
+	return yaml.Marshal(&imageBasedConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1beta1",
+			Kind:       "ImageBasedConfig",
+		},
+		Metadata:  imageBasedConfigMetadata{Name: cd.Spec.ClusterName},
+		Hostname:  hostname,
+		ClusterID: clusterID,
+		InfraID:   infraID,
+	})
+}
+
+// generateInfraID generates an infra ID from the name of the cluster the way the installer does.
+func generateInfraID(clusterName string) string {
+	base := clusterName
+	if len(base) > maxInfraIDBaseLength {
+		base = base[:maxInfraIDBaseLength]
+	}
+	return fmt.Sprintf("%s-%s", strings.TrimRight(base, "-"), utilrand.String(5))
+}
+
+// provisionImageBasedCluster attaches the configuration image to the host of an image-based install and waits for
+// the host to reconfigure itself into the cluster, reporting the progress on the ClusterProvision.
+func provisionImageBasedCluster(m *InstallManager, cd *hivev1.ClusterDeployment, provision *hivev1.ClusterProvision) error {
+	ibi := cd.Spec.Provisioning.ImageBasedInstall
+	user := ibi.SSHUser
+	if user == "" {
+		user = defaultImageBasedInstallSSHUser
+	}
+	target := fmt.Sprintf("%s@%s", user, ibi.HostAddress)
+	logger := m.log.WithField("host", ibi.HostAddress)
+
+	m.setImageBasedInstallProgress(provision, hivev1.ImageBasedInstallStageConfigImageCreated,
+		fmt.Sprintf("Attaching the configuration image to host %s", ibi.HostAddress))
+
+	logger.Info("copying configuration image to host")
+	src := filepath.Join(m.WorkDir, imageBasedConfigImageFilename)
+	if out, err := exec.Command("scp", "-o", "BatchMode=yes", src, target+":"+imageBasedConfigImageHostPath).CombinedOutput(); err != nil {
+		logger.WithError(err).WithField("output", string(out)).Error("error copying configuration image to host")
+		return errors.Wrapf(err, "could not copy configuration image to host %s", ibi.HostAddress)
+	}
+	// Attached as a loop device, the image is the block device labelled cluster-config which the host waits for
+	// before reconfiguring itself.
+	logger.Info("attaching configuration image on host")
+	if out, err := exec.Command("ssh", "-o", "BatchMode=yes", target,
+		"sudo", "losetup", "--find", "--read-only", imageBasedConfigImageHostPath).CombinedOutput(); err != nil {
+		logger.WithError(err).WithField("output", string(out)).Error("error attaching configuration image on host")
+		return errors.Wrapf(err, "could not attach configuration image on host %s", ibi.HostAddress)
+	}
+
+	m.setImageBasedInstallProgress(provision, hivev1.ImageBasedInstallStageReimaging, "Waiting for the API of the cluster")
+	if err := m.waitForImageBasedInstall(provision); err != nil {
+		logger.WithError(err).Error("cluster did not become available")
+		return err
+	}
+	m.setImageBasedInstallProgress(provision, hivev1.ImageBasedInstallStageComplete, "The cluster is available")
+	return nil
+}
+
+// waitForImageBasedInstall polls the ClusterVersion of the cluster with the admin kubeconfig until it is available,
+// reporting its progress on the ClusterProvision.
+func (m *InstallManager) waitForImageBasedInstall(provision *hivev1.ClusterProvision) error {
+	config, err := clientcmd.BuildConfigFromFlags("", filepath.Join(m.WorkDir, adminKubeConfigRelativePath))
+	if err != nil {
+		return errors.Wrap(err, "could not load admin kubeconfig")
+	}
+	scheme := runtime.NewScheme()
+	configv1.Install(scheme)
+
+	lastMessage := ""
+	err = wait.PollImmediate(imageBasedInstallPollInterval, imageBasedInstallTimeout, func() (bool, error) {
+		// The client is created on every poll, as it discovers the API of the cluster when it is created.
+		c, err := client.New(config, client.Options{Scheme: scheme})
+		if err != nil {
+			m.log.WithError(err).Debug("cluster API is not available yet")
+			return false, nil
+		}
+		cv := &configv1.ClusterVersion{}
+		if err := c.Get(context.Background(), types.NamespacedName{Name: "version"}, cv); err != nil {
+			m.log.WithError(err).Debug("could not get the ClusterVersion of the cluster")
+			return false, nil
+		}
+		message := "Waiting for the ClusterVersion of the cluster to report its progress"
+		for _, cond := range cv.Status.Conditions {
+			switch cond.Type {
+			case configv1.OperatorAvailable:
+				if cond.Status == configv1.ConditionTrue {
+					m.log.Info("cluster is available")
+					return true, nil
+				}
+			case configv1.OperatorProgressing:
+				if cond.Message != "" {
+					message = cond.Message
+				}
+			}
+		}
+		if message != lastMessage {
+			m.setImageBasedInstallProgress(provision, hivev1.ImageBasedInstallStageReimaging, message)
+			lastMessage = message
+		}
+		return false, nil
+	})
+	return errors.Wrap(err, "cluster did not become available after its host was reimaged")
+}
+
+// setImageBasedInstallProgress records the progress of an image-based install on the ClusterProvision. The progress
+// is informational, so failing to record it does not fail the install.
+func (m *InstallManager) setImageBasedInstallProgress(provision *hivev1.ClusterProvision, stage hivev1.ImageBasedInstallStage, message string) {
+	m.log.WithField("stage", stage).Info(message)
+	if err := m.updateClusterProvision(
+		provision,
+		m,
+		func(provision *hivev1.ClusterProvision) {
+			provision.Spec.ImageBasedInstallProgress = &hivev1.ImageBasedInstallProgress{
+				Stage:              stage,
+				Message:            message,
+				LastTransitionTime: metav1.Now(),
+			}
+		},
+	); err != nil {
+		m.log.WithError(err).Warning("error updating cluster provision with image-based install progress")
+	}
+}
@@ -0,0 +1,130 @@
+package installmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func testImageBasedClusterDeployment(clusterName string) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName: clusterName,
+			Provisioning: &hivev1.Provisioning{
+				Topology:          hivev1.SingleNodeClusterTopology,
+				ImageBasedInstall: &hivev1.ImageBasedInstall{HostAddress: "192.168.111.20"},
+			},
+		},
+	}
+}
+
+func Test_generateImageBasedConfig(t *testing.T) {
+	cases := []struct {
+		name             string
+		hostname         string
+		expectedHostname string
+	}{
+		{
+			name:             "default hostname",
+			expectedHostname: "test-cluster",
+		},
+		{
+			name:             "hostname",
+			hostname:         "sno-0",
+			expectedHostname: "sno-0",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := testImageBasedClusterDeployment("test-cluster")
+			cd.Spec.Provisioning.ImageBasedInstall.Hostname = tc.hostname
+			data, err := generateImageBasedConfig(cd, "test-cluster-abcde", "test-cluster-id")
+			require.NoError(t, err, "unexpected error generating image-based config")
+			config := &imageBasedConfig{}
+			require.NoError(t, yaml.Unmarshal(data, config), "unexpected error unmarshalling image-based config")
+			assert.Equal(t, "ImageBasedConfig", config.Kind, "unexpected kind")
+			assert.Equal(t, "test-cluster", config.Metadata.Name, "unexpected name")
+			assert.Equal(t, tc.expectedHostname, config.Hostname, "unexpected hostname")
+			assert.Equal(t, "test-cluster-abcde", config.InfraID, "unexpected infra ID")
+			assert.Equal(t, "test-cluster-id", config.ClusterID, "unexpected cluster ID")
+		})
+	}
+}
+
+func Test_generateInfraID(t *testing.T) {
+	cases := []struct {
+		clusterName  string
+		expectedBase string
+	}{
+		{
+			clusterName:  "test-cluster",
+			expectedBase: "test-cluster",
+		},
+		{
+			clusterName:  "a-very-long-cluster-name-for-an-edge-site",
+			expectedBase: "a-very-long-cluster-name-fo",
+		},
+		{
+			clusterName:  "a-very-long-cluster-name-f-or-an-edge-site",
+			expectedBase: "a-very-long-cluster-name-f",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.clusterName, func(t *testing.T) {
+			infraID := generateInfraID(tc.clusterName)
+			assert.True(t, strings.HasPrefix(infraID, tc.expectedBase+"-"), "unexpected infra ID %s", infraID)
+			assert.Len(t, infraID, len(tc.expectedBase)+6, "unexpected length of infra ID %s", infraID)
+		})
+	}
+}
+
+func Test_generateImageBasedAssets(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "imagebasedtest")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	defer os.Remove(installerConsoleLogFilePath)
+
+	manifestsDir := filepath.Join(tempDir, "mounted-manifests")
+	require.NoError(t, os.Mkdir(manifestsDir, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(manifestsDir, "user-manifest.yaml"), []byte("kind: ConfigMap"), 0644))
+	require.NoError(t, writeFakeBinary(filepath.Join(tempDir, installerBinary), fmt.Sprintf(fakeInstallerBinary, tempDir)))
+
+	im := &InstallManager{
+		WorkDir:            tempDir,
+		ManifestsMountPath: manifestsDir,
+		binaryDir:          tempDir,
+		log:                log.WithField("test", "Test_generateImageBasedAssets"),
+	}
+	cd := testImageBasedClusterDeployment("test-cluster")
+	require.NoError(t, im.generateImageBasedAssets(cd), "unexpected error generating image-based assets")
+
+	configData, err := ioutil.ReadFile(filepath.Join(tempDir, imageBasedConfigFilename))
+	require.NoError(t, err, "missing image-based config")
+	config := &imageBasedConfig{}
+	require.NoError(t, yaml.Unmarshal(configData, config), "unexpected error unmarshalling image-based config")
+
+	_, err = os.Stat(filepath.Join(tempDir, imageBasedExtraManifestsDir, "user-manifest.yaml"))
+	assert.NoError(t, err, "user manifest not copied to extra manifests")
+
+	metadataData, err := ioutil.ReadFile(filepath.Join(tempDir, metadataRelativePath))
+	require.NoError(t, err, "missing cluster metadata")
+	metadata := &installertypes.ClusterMetadata{}
+	require.NoError(t, json.Unmarshal(metadataData, metadata), "unexpected error unmarshalling cluster metadata")
+	assert.Equal(t, "test-cluster", metadata.ClusterName, "unexpected cluster name")
+	assert.Equal(t, config.InfraID, metadata.InfraID, "infra ID of metadata does not match image-based config")
+	assert.Equal(t, config.ClusterID, metadata.ClusterID, "cluster ID of metadata does not match image-based config")
+	assert.NotNil(t, metadata.BareMetal, "expected bare metal metadata")
+}
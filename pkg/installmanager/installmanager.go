@@ -39,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/destroy/aws"
 	"github.com/openshift/installer/pkg/destroy/azure"
 	"github.com/openshift/installer/pkg/destroy/gcp"
@@ -78,6 +79,7 @@ const (
 	defaultInstallConfigMountPath       = "/installconfig/install-config.yaml"
 	defaultPullSecretMountPath          = "/pullsecret/" + corev1.DockerConfigJsonKey
 	defaultManifestsMountPath           = "/manifests"
+	etcdEncryptionManifestFilename      = "cluster-apiserver-02-config.yml"
 	defaultHomeDir                      = "/home/hive" // Used if no HOME env var set.
 )
 
@@ -108,8 +110,11 @@ type InstallManager struct {
 	uploadAdminPassword              func(*hivev1.ClusterProvision, *InstallManager) (*corev1.Secret, error)
 	loadAdminPassword                func(*InstallManager) (string, error)
 	provisionCluster                 func(*InstallManager) error
+	provisionImageBasedCluster       func(*InstallManager, *hivev1.ClusterDeployment, *hivev1.ClusterProvision) error
 	readInstallerLog                 func(*hivev1.ClusterProvision, *InstallManager, bool) (string, error)
 	waitForProvisioningStage         func(*hivev1.ClusterProvision, *InstallManager) error
+	gatherPlatformStatus             func(*hivev1.ClusterDeployment, *installertypes.ClusterMetadata, log.FieldLogger) (*hivev1.PlatformStatus, error)
+	validateUserProvidedNetwork      func(*hivev1.ClusterDeployment, *installertypes.InstallConfig, log.FieldLogger) (*hivev1.PlatformStatus, error)
 	waitForInstallCompleteExecutions int
 	binaryDir                        string
 	actuator                         LogUploaderActuator
@@ -193,7 +198,10 @@ func (m *InstallManager) Complete(args []string) error {
 	m.readInstallerLog = readInstallerLog
 	m.cleanupFailedProvision = cleanupFailedProvision
 	m.provisionCluster = provisionCluster
+	m.provisionImageBasedCluster = provisionImageBasedCluster
 	m.waitForProvisioningStage = waitForProvisioningStage
+	m.gatherPlatformStatus = gatherPlatformStatus
+	m.validateUserProvidedNetwork = validateUserProvidedNetwork
 
 	// Set log level
 	level, err := log.ParseLevel(m.LogLevel)
@@ -231,6 +239,9 @@ func (m *InstallManager) Complete(args []string) error {
 		m.loadAdminPassword = fakeLoadAdminPassword
 		m.readClusterMetadata = fakeReadClusterMetadata
 		m.provisionCluster = fakeProvisionCluster
+		m.provisionImageBasedCluster = fakeProvisionImageBasedCluster
+		m.gatherPlatformStatus = fakeGatherPlatformStatus
+		m.validateUserProvidedNetwork = fakeValidateUserProvidedNetwork
 	}
 
 	return nil
@@ -312,6 +323,11 @@ func (m *InstallManager) Run() error {
 
 	m.ClusterName = cd.Spec.ClusterName
 
+	if err := m.resolveExternalCredentials(cd); err != nil {
+		m.log.WithError(err).Error("error resolving credentials from external secret provider")
+		return err
+	}
+
 	if err := m.waitForAndCopyInstallerBinaries(); err != nil {
 		m.log.WithError(err).Error("error waiting for/copying binaries")
 		return err
@@ -330,6 +346,11 @@ func (m *InstallManager) Run() error {
 		m.log.WithError(err).Error("error adding pull secret to install-config.yaml")
 		return err
 	}
+	icData, err = InstallConfigForClusterDeployment(icData, cd)
+	if err != nil {
+		m.log.WithError(err).Error("error applying cluster deployment settings to install-config.yaml")
+		return err
+	}
 	destInstallConfigPath := filepath.Join(m.WorkDir, "install-config.yaml")
 	if err := ioutil.WriteFile(destInstallConfigPath, icData, 0644); err != nil {
 		m.log.WithError(err).Error("error writing install-config.yaml")
@@ -372,9 +393,49 @@ func (m *InstallManager) Run() error {
 		return fmt.Errorf("infraID is already set on the ClusterProvision. Unexpected install pod restart detected")
 	}
 
+	// Validate the existing network to install into, if any, before the installer creates any resources in it.
+	m.log.Info("validating user-provided network")
+	installConfig := &installertypes.InstallConfig{}
+	if err := yaml.Unmarshal(icData, installConfig); err != nil {
+		m.log.WithError(err).Error("error unmarshalling install-config.yaml")
+		return err
+	}
+	networkStatus, err := m.validateUserProvidedNetwork(cd, installConfig, m.log)
+	if err != nil {
+		m.log.WithError(err).Error("user-provided network is not valid")
+		// The installer has not run, so the validation failure stands in for the install log, for the install log
+		// regexes to report it on the ClusterProvision.
+		if err := m.updateClusterProvision(
+			provision,
+			m,
+			func(provision *hivev1.ClusterProvision) {
+				provision.Spec.InstallLog = pointer.StringPtr(err.Error())
+			},
+		); err != nil {
+			m.log.WithError(err).Error("error updating cluster provision with network validation failure")
+		}
+		return err
+	}
+	if networkStatus != nil {
+		if err := m.updateClusterProvision(
+			provision,
+			m,
+			func(provision *hivev1.ClusterProvision) {
+				provision.Spec.PlatformStatus = networkStatus
+			},
+		); err != nil {
+			m.log.WithError(err).Error("error updating cluster provision with user-provided network")
+			return err
+		}
+	}
+
 	// Generate installer assets we need to modify or upload.
 	m.log.Info("generating assets")
-	if err := m.generateAssets(cd); err != nil {
+	generateAssets := m.generateAssets
+	if isImageBasedInstall(cd) {
+		generateAssets = m.generateImageBasedAssets
+	}
+	if err := generateAssets(cd); err != nil {
 		m.log.Info("reading installer log")
 		installLog, readErr := m.readInstallerLog(provision, m, scrubInstallLog)
 		if readErr != nil {
@@ -453,7 +514,13 @@ func (m *InstallManager) Run() error {
 		}
 	}
 
-	installErr := m.provisionCluster(m)
+	provisionCluster := m.provisionCluster
+	if isImageBasedInstall(cd) {
+		provisionCluster = func(m *InstallManager) error {
+			return m.provisionImageBasedCluster(m, cd, provision)
+		}
+	}
+	installErr := provisionCluster(m)
 	if installErr != nil {
 		m.log.WithError(installErr).Error("error running openshift-install, running deprovision to clean up")
 
@@ -496,6 +563,25 @@ func (m *InstallManager) Run() error {
 		return installErr
 	}
 
+	// The platform status is informational, so failing to gather it does not fail the install.
+	if platformStatus, err := m.gatherPlatformStatus(cd, metadata, m.log); err != nil {
+		m.log.WithError(err).Warning("error gathering platform status")
+	} else if platformStatus != nil {
+		if err := m.updateClusterProvision(
+			provision,
+			m,
+			func(provision *hivev1.ClusterProvision) {
+				// Keep the network found when it was validated before the install.
+				if prev := provision.Spec.PlatformStatus; prev != nil && prev.AWS != nil && platformStatus.AWS != nil {
+					platformStatus.AWS.UserProvidedNetwork = prev.AWS.UserProvidedNetwork
+				}
+				provision.Spec.PlatformStatus = platformStatus
+			},
+		); err != nil {
+			m.log.WithError(err).Warning("error updating cluster provision with platform status")
+		}
+	}
+
 	m.log.Info("install completed successfully")
 
 	return nil
@@ -724,16 +810,14 @@ func (m *InstallManager) generateAssets(cd *hivev1.ClusterDeployment) error {
 		}
 	}
 
-	if src := m.ManifestsMountPath; isDirNonEmpty(src) {
-		m.log.Info("copying user-provided manifests")
-		dest := filepath.Join(m.WorkDir, "manifests")
-		out, err := exec.Command("bash", "-c", fmt.Sprintf("cp %s %s", filepath.Join(src, "*"), dest)).CombinedOutput()
-		fmt.Printf("%s\n", out)
-		if err != nil {
-			log.WithError(err).Errorf("error copying manifests from %s to %s", src, dest)
-			return err
-		}
-		m.log.Infof("copied %s to %s", src, dest)
+	manifestsDir := filepath.Join(m.WorkDir, "manifests")
+	if err := m.writeEtcdEncryptionManifest(cd, manifestsDir); err != nil {
+		m.log.WithError(err).Error("error writing etcd encryption manifest")
+		return err
+	}
+
+	if err := m.copyUserManifests(manifestsDir); err != nil {
+		return err
 	}
 
 	m.log.Info("running openshift-install create ignition-configs")
@@ -746,6 +830,23 @@ func (m *InstallManager) generateAssets(cd *hivev1.ClusterDeployment) error {
 	return nil
 }
 
+// copyUserManifests copies the user-provided manifests, if any, to the dest directory.
+func (m *InstallManager) copyUserManifests(dest string) error {
+	src := m.ManifestsMountPath
+	if !isDirNonEmpty(src) {
+		return nil
+	}
+	m.log.Info("copying user-provided manifests")
+	out, err := exec.Command("bash", "-c", fmt.Sprintf("cp %s %s", filepath.Join(src, "*"), dest)).CombinedOutput()
+	fmt.Printf("%s\n", out)
+	if err != nil {
+		log.WithError(err).Errorf("error copying manifests from %s to %s", src, dest)
+		return err
+	}
+	m.log.Infof("copied %s to %s", src, dest)
+	return nil
+}
+
 // provisionCluster invokes the openshift-install create cluster command to provision resources
 // in the cloud.
 func provisionCluster(m *InstallManager) error {
@@ -1472,6 +1573,161 @@ func pasteInPullSecret(icData []byte, pullSecretFile string) ([]byte, error) {
 	return yaml.Marshal(icRaw)
 }
 
+// InstallConfigForClusterDeployment returns the install-config with the settings of the ClusterDeployment which Hive
+// applies before running the installer: its fips setting, its topology, its proxy and its egress. The pull secret is
+// pasted in separately.
+func InstallConfigForClusterDeployment(icData []byte, cd *hivev1.ClusterDeployment) ([]byte, error) {
+	icData, err := pasteInFIPS(icData, cd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not set fips")
+	}
+	icData, err = pasteInTopology(icData, cd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not set topology")
+	}
+	icData, err = pasteInProxy(icData, cd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not set proxy")
+	}
+	icData, err = pasteInEgress(icData, cd)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not set egress")
+	}
+	return icData, nil
+}
+
+// pasteInFIPS sets the fips setting of the InstallConfig when the ClusterDeployment specifies one.
+func pasteInFIPS(icData []byte, cd *hivev1.ClusterDeployment) ([]byte, error) {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.FIPS == nil {
+		return icData, nil
+	}
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	icRaw["fips"] = *cd.Spec.Provisioning.FIPS
+	return yaml.Marshal(icRaw)
+}
+
+// pasteInTopology sets the replicas of the control plane and compute pools of the InstallConfig when the
+// ClusterDeployment specifies a Compact or SingleNode topology. With no compute replicas, the installer makes the
+// control plane nodes schedulable.
+func pasteInTopology(icData []byte, cd *hivev1.ClusterDeployment) ([]byte, error) {
+	if cd.Spec.Provisioning == nil {
+		return icData, nil
+	}
+	var controlPlaneReplicas int
+	switch cd.Spec.Provisioning.Topology {
+	case hivev1.CompactClusterTopology:
+		controlPlaneReplicas = 3
+	case hivev1.SingleNodeClusterTopology:
+		controlPlaneReplicas = 1
+	default:
+		return icData, nil
+	}
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	controlPlane, ok := icRaw["controlPlane"].(map[string]interface{})
+	if !ok {
+		controlPlane = map[string]interface{}{"name": "master"}
+	}
+	controlPlane["replicas"] = controlPlaneReplicas
+	icRaw["controlPlane"] = controlPlane
+	compute, _ := icRaw["compute"].([]interface{})
+	if len(compute) == 0 {
+		compute = []interface{}{map[string]interface{}{"name": "worker"}}
+	}
+	for _, pool := range compute {
+		if pool, ok := pool.(map[string]interface{}); ok {
+			pool["replicas"] = 0
+		}
+	}
+	icRaw["compute"] = compute
+	return yaml.Marshal(icRaw)
+}
+
+// pasteInProxy sets the proxy and the additional trust bundle of the InstallConfig when the ClusterDeployment specifies
+// a proxy. The settings of the ClusterDeployment replace those of the InstallConfig.
+func pasteInProxy(icData []byte, cd *hivev1.ClusterDeployment) ([]byte, error) {
+	proxy := cd.Spec.Proxy
+	if proxy == nil {
+		return icData, nil
+	}
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	icProxy := map[string]interface{}{}
+	if proxy.HTTPProxy != "" {
+		icProxy["httpProxy"] = proxy.HTTPProxy
+	}
+	if proxy.HTTPSProxy != "" {
+		icProxy["httpsProxy"] = proxy.HTTPSProxy
+	}
+	if proxy.NoProxy != "" {
+		icProxy["noProxy"] = proxy.NoProxy
+	}
+	icRaw["proxy"] = icProxy
+	if proxy.AdditionalTrustBundle != "" {
+		icRaw["additionalTrustBundle"] = proxy.AdditionalTrustBundle
+	}
+	return yaml.Marshal(icRaw)
+}
+
+// pasteInEgress sets the outbound type of the InstallConfig when the ClusterDeployment routes the egress traffic of an
+// Azure cluster through an existing NAT gateway, so that the installer does not set up outbound rules on the public
+// load balancer. Existing NAT gateways of other platforms are found by the installer through the existing network.
+func pasteInEgress(icData []byte, cd *hivev1.ClusterDeployment) ([]byte, error) {
+	if cd.Spec.Platform.Azure == nil || cd.Spec.Platform.Azure.Egress == nil {
+		return icData, nil
+	}
+	icRaw := map[string]interface{}{}
+	if err := yaml.Unmarshal(icData, &icRaw); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal InstallConfig")
+	}
+	platform, _ := icRaw["platform"].(map[string]interface{})
+	if platform == nil {
+		platform = map[string]interface{}{}
+	}
+	azure, _ := platform["azure"].(map[string]interface{})
+	if azure == nil {
+		azure = map[string]interface{}{}
+	}
+	azure["outboundType"] = string(installertypesazure.UserDefinedRoutingOutboundType)
+	platform["azure"] = azure
+	icRaw["platform"] = platform
+	return yaml.Marshal(icRaw)
+}
+
+// writeEtcdEncryptionManifest adds a manifest for the cluster APIServer configuration with the etcd encryption
+// specified by the ClusterDeployment, if any.
+func (m *InstallManager) writeEtcdEncryptionManifest(cd *hivev1.ClusterDeployment, manifestsDir string) error {
+	if cd.Spec.Provisioning == nil || cd.Spec.Provisioning.EtcdEncryption == nil {
+		return nil
+	}
+	apiServer := &configv1.APIServer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: configv1.SchemeGroupVersion.String(),
+			Kind:       "APIServer",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+		},
+		Spec: configv1.APIServerSpec{
+			Encryption: *cd.Spec.Provisioning.EtcdEncryption,
+		},
+	}
+	data, err := yaml.Marshal(apiServer)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal APIServer manifest")
+	}
+	dest := filepath.Join(manifestsDir, etcdEncryptionManifestFilename)
+	m.log.WithField("dest", dest).WithField("type", apiServer.Spec.Encryption.Type).Info("writing etcd encryption manifest")
+	return ioutil.WriteFile(dest, data, 0644)
+}
+
 func getHomeDir() string {
 	home := os.Getenv("HOME")
 	if home != "" {
@@ -21,12 +21,16 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
 
+	configv1 "github.com/openshift/api/config/v1"
 	installertypes "github.com/openshift/installer/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 )
@@ -81,26 +85,31 @@ func init() {
 func TestInstallManager(t *testing.T) {
 	apis.AddToScheme(scheme.Scheme)
 	tests := []struct {
-		name                          string
-		existing                      []runtime.Object
-		failedMetadataRead            bool
-		failedKubeconfigSave          bool
-		failedAdminPasswordSave       bool
-		failedInstallerLogRead        bool
-		failedProvisionUpdate         *int32
-		expectKubeconfigSecret        bool
-		expectPasswordSecret          bool
-		expectProvisionMetadataUpdate bool
-		expectProvisionLogUpdate      bool
-		expectError                   bool
+		name                                string
+		existing                            []runtime.Object
+		failedMetadataRead                  bool
+		failedKubeconfigSave                bool
+		failedAdminPasswordSave             bool
+		failedInstallerLogRead              bool
+		failedPlatformStatusGather          bool
+		failedNetworkValidation             bool
+		userProvidedNetwork                 bool
+		failedProvisionUpdate               *int32
+		expectKubeconfigSecret              bool
+		expectPasswordSecret                bool
+		expectProvisionMetadataUpdate       bool
+		expectProvisionLogUpdate            bool
+		expectProvisionPlatformStatusUpdate bool
+		expectError                         bool
 	}{
 		{
-			name:                          "successful install",
-			existing:                      []runtime.Object{testClusterDeployment(), testClusterProvision()},
-			expectKubeconfigSecret:        true,
-			expectPasswordSecret:          true,
-			expectProvisionMetadataUpdate: true,
-			expectProvisionLogUpdate:      true,
+			name:                                "successful install",
+			existing:                            []runtime.Object{testClusterDeployment(), testClusterProvision()},
+			expectKubeconfigSecret:              true,
+			expectPasswordSecret:                true,
+			expectProvisionMetadataUpdate:       true,
+			expectProvisionLogUpdate:            true,
+			expectProvisionPlatformStatusUpdate: true,
 		},
 		{
 			name:               "failed metadata read",
@@ -117,12 +126,13 @@ func TestInstallManager(t *testing.T) {
 			expectError:            true,
 		},
 		{
-			name:                          "failed cluster provision log update", // a non-fatal error
-			existing:                      []runtime.Object{testClusterDeployment(), testClusterProvision()},
-			failedProvisionUpdate:         pointer.Int32Ptr(1),
-			expectKubeconfigSecret:        true,
-			expectPasswordSecret:          true,
-			expectProvisionMetadataUpdate: true,
+			name:                                "failed cluster provision log update", // a non-fatal error
+			existing:                            []runtime.Object{testClusterDeployment(), testClusterProvision()},
+			failedProvisionUpdate:               pointer.Int32Ptr(1),
+			expectKubeconfigSecret:              true,
+			expectPasswordSecret:                true,
+			expectProvisionMetadataUpdate:       true,
+			expectProvisionPlatformStatusUpdate: true,
 		},
 		{
 			name:                 "failed admin kubeconfig save", // fatal error
@@ -138,12 +148,38 @@ func TestInstallManager(t *testing.T) {
 			expectError:             true,
 		},
 		{
-			name:                          "failed saving of installer log", // non-fatal
+			name:                                "failed saving of installer log", // non-fatal
+			existing:                            []runtime.Object{testClusterDeployment(), testClusterProvision()},
+			failedInstallerLogRead:              true,
+			expectKubeconfigSecret:              true,
+			expectPasswordSecret:                true,
+			expectProvisionMetadataUpdate:       true,
+			expectProvisionPlatformStatusUpdate: true,
+		},
+		{
+			name:                          "failed gathering of platform status", // non-fatal
 			existing:                      []runtime.Object{testClusterDeployment(), testClusterProvision()},
-			failedInstallerLogRead:        true,
+			failedPlatformStatusGather:    true,
 			expectKubeconfigSecret:        true,
 			expectPasswordSecret:          true,
 			expectProvisionMetadataUpdate: true,
+			expectProvisionLogUpdate:      true,
+		},
+		{
+			name:                                "user-provided network",
+			existing:                            []runtime.Object{testClusterDeployment(), testClusterProvision()},
+			userProvidedNetwork:                 true,
+			expectKubeconfigSecret:              true,
+			expectPasswordSecret:                true,
+			expectProvisionMetadataUpdate:       true,
+			expectProvisionLogUpdate:            true,
+			expectProvisionPlatformStatusUpdate: true,
+		},
+		{
+			name:                    "failed user-provided network validation", // fatal error
+			existing:                []runtime.Object{testClusterDeployment(), testClusterProvision()},
+			failedNetworkValidation: true,
+			expectError:             true,
 		},
 		{
 			name:        "infraID already set on cluster provision", // fatal error
@@ -195,6 +231,21 @@ func TestInstallManager(t *testing.T) {
 			im.Complete([]string{})
 
 			im.waitForProvisioningStage = func(*hivev1.ClusterProvision, *InstallManager) error { return nil }
+			im.gatherPlatformStatus = func(*hivev1.ClusterDeployment, *installertypes.ClusterMetadata, log.FieldLogger) (*hivev1.PlatformStatus, error) {
+				if test.failedPlatformStatusGather {
+					return nil, fmt.Errorf("failed to gather platform status")
+				}
+				return &hivev1.PlatformStatus{AWS: &hivev1aws.PlatformStatus{VPCID: "vpc-fake"}}, nil
+			}
+			im.validateUserProvidedNetwork = func(*hivev1.ClusterDeployment, *installertypes.InstallConfig, log.FieldLogger) (*hivev1.PlatformStatus, error) {
+				switch {
+				case test.failedNetworkValidation:
+					return nil, fmt.Errorf("user-provided network validation failed: no private subnets provided")
+				case test.userProvidedNetwork:
+					return &hivev1.PlatformStatus{AWS: &hivev1aws.PlatformStatus{UserProvidedNetwork: testUserProvidedNetwork()}}, nil
+				}
+				return nil, nil
+			}
 
 			if !assert.NoError(t, writeFakeBinary(filepath.Join(tempDir, installerBinary),
 				fmt.Sprintf(fakeInstallerBinary, tempDir))) {
@@ -333,13 +384,35 @@ func TestInstallManager(t *testing.T) {
 				if assert.NotNil(t, provision.Spec.InstallLog, "expected install log to be set") {
 					assert.Equal(t, "some fake installer log output\n", *provision.Spec.InstallLog, "did not find expected contents in saved installer log")
 				}
+			} else if test.failedNetworkValidation {
+				if assert.NotNil(t, provision.Spec.InstallLog, "expected install log to be set") {
+					assert.Contains(t, *provision.Spec.InstallLog, "user-provided network validation failed", "expected validation failure in install log")
+				}
 			} else {
 				assert.Nil(t, provision.Spec.InstallLog, "expected install log to be empty")
 			}
+
+			if test.expectProvisionPlatformStatusUpdate {
+				expectedPlatformStatus := &hivev1.PlatformStatus{AWS: &hivev1aws.PlatformStatus{VPCID: "vpc-fake"}}
+				if test.userProvidedNetwork {
+					expectedPlatformStatus.AWS.UserProvidedNetwork = testUserProvidedNetwork()
+				}
+				assert.Equal(t, expectedPlatformStatus, provision.Spec.PlatformStatus, "unexpected platform status")
+			} else {
+				assert.Nil(t, provision.Spec.PlatformStatus, "expected platform status to be empty")
+			}
 		})
 	}
 }
 
+func testUserProvidedNetwork() *hivev1aws.UserProvidedNetworkStatus {
+	return &hivev1aws.UserProvidedNetworkStatus{
+		VPCID:          "vpc-fake",
+		PrivateSubnets: []hivev1aws.SubnetStatus{{ID: "subnet-private", AvailabilityZone: "us-east-1a"}},
+		PublicSubnets:  []hivev1aws.SubnetStatus{{ID: "subnet-public", AvailabilityZone: "us-east-1a"}},
+	}
+}
+
 func writeFakeBinary(fileName string, contents string) error {
 	data := []byte(contents)
 	err := ioutil.WriteFile(fileName, data, 0755)
@@ -717,3 +790,217 @@ func Test_pasteInPullSecret(t *testing.T) {
 		})
 	}
 }
+
+func Test_pasteInFIPS(t *testing.T) {
+	cases := []struct {
+		name         string
+		fips         *bool
+		expectedFIPS interface{}
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:         "enabled",
+			fips:         pointer.BoolPtr(true),
+			expectedFIPS: true,
+		},
+		{
+			name:         "disabled",
+			fips:         pointer.BoolPtr(false),
+			expectedFIPS: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			icData, err := ioutil.ReadFile(filepath.Join("testdata", "install-config.yaml"))
+			require.NoError(t, err, "unexpected error reading install-config.yaml")
+			cd := &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Provisioning: &hivev1.Provisioning{FIPS: tc.fips},
+				},
+			}
+			actual, err := pasteInFIPS(icData, cd)
+			require.NoError(t, err, "unexpected error pasting in fips")
+			icRaw := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal(actual, &icRaw), "unexpected error unmarshalling InstallConfig")
+			assert.Equal(t, tc.expectedFIPS, icRaw["fips"], "unexpected fips setting")
+		})
+	}
+}
+
+func Test_pasteInTopology(t *testing.T) {
+	cases := []struct {
+		name                         string
+		topology                     hivev1.ClusterTopology
+		expectedControlPlaneReplicas float64
+		expectedComputeReplicas      float64
+	}{
+		{
+			name:                         "not set",
+			expectedControlPlaneReplicas: 3,
+			expectedComputeReplicas:      3,
+		},
+		{
+			name:                         "highly available",
+			topology:                     hivev1.HighlyAvailableClusterTopology,
+			expectedControlPlaneReplicas: 3,
+			expectedComputeReplicas:      3,
+		},
+		{
+			name:                         "compact",
+			topology:                     hivev1.CompactClusterTopology,
+			expectedControlPlaneReplicas: 3,
+			expectedComputeReplicas:      0,
+		},
+		{
+			name:                         "single node",
+			topology:                     hivev1.SingleNodeClusterTopology,
+			expectedControlPlaneReplicas: 1,
+			expectedComputeReplicas:      0,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			icData, err := ioutil.ReadFile(filepath.Join("testdata", "install-config.yaml"))
+			require.NoError(t, err, "unexpected error reading install-config.yaml")
+			cd := &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Provisioning: &hivev1.Provisioning{Topology: tc.topology},
+				},
+			}
+			actual, err := pasteInTopology(icData, cd)
+			require.NoError(t, err, "unexpected error pasting in topology")
+			icRaw := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal(actual, &icRaw), "unexpected error unmarshalling InstallConfig")
+			controlPlane := icRaw["controlPlane"].(map[string]interface{})
+			assert.Equal(t, tc.expectedControlPlaneReplicas, controlPlane["replicas"], "unexpected control plane replicas")
+			compute := icRaw["compute"].([]interface{})
+			require.Len(t, compute, 1, "unexpected compute pools")
+			assert.Equal(t, tc.expectedComputeReplicas, compute[0].(map[string]interface{})["replicas"], "unexpected compute replicas")
+		})
+	}
+}
+
+func Test_pasteInProxy(t *testing.T) {
+	cases := []struct {
+		name                string
+		proxy               *hivev1.ClusterProxy
+		expectedProxy       interface{}
+		expectedTrustBundle interface{}
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name: "proxy",
+			proxy: &hivev1.ClusterProxy{
+				HTTPProxy:  "http://proxy.example.com:3128",
+				HTTPSProxy: "https://proxy.example.com:3129",
+				NoProxy:    ".example.com,10.0.0.0/16",
+			},
+			expectedProxy: map[string]interface{}{
+				"httpProxy":  "http://proxy.example.com:3128",
+				"httpsProxy": "https://proxy.example.com:3129",
+				"noProxy":    ".example.com,10.0.0.0/16",
+			},
+		},
+		{
+			name: "proxy with trust bundle",
+			proxy: &hivev1.ClusterProxy{
+				HTTPSProxy:            "http://proxy.example.com:3128",
+				AdditionalTrustBundle: "fake-bundle",
+			},
+			expectedProxy: map[string]interface{}{
+				"httpsProxy": "http://proxy.example.com:3128",
+			},
+			expectedTrustBundle: "fake-bundle",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			icData, err := ioutil.ReadFile(filepath.Join("testdata", "install-config.yaml"))
+			require.NoError(t, err, "unexpected error reading install-config.yaml")
+			cd := &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Proxy: tc.proxy,
+				},
+			}
+			actual, err := pasteInProxy(icData, cd)
+			require.NoError(t, err, "unexpected error pasting in proxy")
+			icRaw := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal(actual, &icRaw), "unexpected error unmarshalling InstallConfig")
+			assert.Equal(t, tc.expectedProxy, icRaw["proxy"], "unexpected proxy")
+			assert.Equal(t, tc.expectedTrustBundle, icRaw["additionalTrustBundle"], "unexpected additional trust bundle")
+		})
+	}
+}
+
+func Test_pasteInEgress(t *testing.T) {
+	cases := []struct {
+		name     string
+		platform hivev1.Platform
+		expected interface{}
+	}{
+		{
+			name:     "aws",
+			platform: hivev1.Platform{AWS: &hivev1aws.Platform{Egress: &hivev1aws.Egress{NATGatewayIDs: []string{"nat-1"}}}},
+			expected: map[string]interface{}{"region": "us-east-1"},
+		},
+		{
+			name:     "azure without egress",
+			platform: hivev1.Platform{Azure: &hivev1azure.Platform{}},
+			expected: map[string]interface{}{"region": "us-east-1"},
+		},
+		{
+			name: "azure with NAT gateway",
+			platform: hivev1.Platform{Azure: &hivev1azure.Platform{
+				Egress: &hivev1azure.Egress{NATGatewayID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/natGateways/nat"},
+			}},
+			expected: map[string]interface{}{"region": "us-east-1"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			icData, err := ioutil.ReadFile(filepath.Join("testdata", "install-config.yaml"))
+			require.NoError(t, err, "unexpected error reading install-config.yaml")
+			cd := &hivev1.ClusterDeployment{Spec: hivev1.ClusterDeploymentSpec{Platform: tc.platform}}
+			actual, err := pasteInEgress(icData, cd)
+			require.NoError(t, err, "unexpected error pasting in egress")
+			icRaw := map[string]interface{}{}
+			require.NoError(t, yaml.Unmarshal(actual, &icRaw), "unexpected error unmarshalling InstallConfig")
+			platform, _ := icRaw["platform"].(map[string]interface{})
+			assert.Equal(t, tc.expected, platform["aws"], "unexpected aws platform")
+			if tc.platform.Azure != nil && tc.platform.Azure.Egress != nil {
+				assert.Equal(t, map[string]interface{}{"outboundType": "UserDefinedRouting"}, platform["azure"], "unexpected azure platform")
+			} else {
+				assert.Nil(t, platform["azure"], "unexpected azure platform")
+			}
+		})
+	}
+}
+
+func Test_writeEtcdEncryptionManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "etcd-encryption")
+	require.NoError(t, err, "unexpected error creating temp dir")
+	defer os.RemoveAll(dir)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "manifests"), 0755), "unexpected error creating manifests dir")
+
+	im := &InstallManager{WorkDir: dir, log: log.WithField("test", "Test_writeEtcdEncryptionManifest")}
+	cd := &hivev1.ClusterDeployment{
+		Spec: hivev1.ClusterDeploymentSpec{
+			Provisioning: &hivev1.Provisioning{
+				EtcdEncryption: &configv1.APIServerEncryption{Type: configv1.EncryptionTypeAESCBC},
+			},
+		},
+	}
+	require.NoError(t, im.writeEtcdEncryptionManifest(cd, filepath.Join(dir, "manifests")), "unexpected error writing etcd encryption manifest")
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "manifests", etcdEncryptionManifestFilename))
+	require.NoError(t, err, "unexpected error reading etcd encryption manifest")
+	apiServer := &configv1.APIServer{}
+	require.NoError(t, yaml.Unmarshal(data, apiServer), "unexpected error unmarshalling etcd encryption manifest")
+	assert.Equal(t, "APIServer", apiServer.Kind, "unexpected kind")
+	assert.Equal(t, "cluster", apiServer.Name, "unexpected name")
+	assert.Equal(t, configv1.EncryptionTypeAESCBC, apiServer.Spec.Encryption.Type, "unexpected encryption type")
+}
@@ -0,0 +1,288 @@
+package installmanager
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	gcputils "github.com/openshift/hive/contrib/pkg/utils/gcp"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+// userProvidedNetworkValidationFailed prefixes validation failures, so that they are matched by the install log
+// regexes.
+const userProvidedNetworkValidationFailed = "user-provided network validation failed"
+
+// validateUserProvidedNetwork checks that the existing network into which the cluster is to be installed is usable,
+// before the installer creates any resources, and describes it for the status of the ClusterDeployment. A nil status
+// is returned when the installer creates the network.
+func validateUserProvidedNetwork(cd *hivev1.ClusterDeployment, installConfig *installertypes.InstallConfig, logger log.FieldLogger) (*hivev1.PlatformStatus, error) {
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		egress := cd.Spec.Platform.AWS.Egress
+		if installConfig.AWS == nil || len(installConfig.AWS.Subnets) == 0 {
+			if egress != nil {
+				return nil, errors.Errorf("%s: existing NAT gateways require the install config to specify existing subnets", userProvidedNetworkValidationFailed)
+			}
+			return nil, nil
+		}
+		awsClient, err := awsclient.NewClient(nil, "", "", cd.Spec.Platform.AWS.Region)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create AWS client")
+		}
+		network, err := validateAWSUserProvidedNetwork(awsClient, installConfig, egress, logger)
+		if err != nil {
+			return nil, err
+		}
+		return &hivev1.PlatformStatus{AWS: &hivev1aws.PlatformStatus{UserProvidedNetwork: network}}, nil
+	case cd.Spec.Platform.Azure != nil:
+		if cd.Spec.Platform.Azure.Egress != nil && (installConfig.Azure == nil || installConfig.Azure.VirtualNetwork == "") {
+			return nil, errors.Errorf("%s: an existing NAT gateway requires the install config to specify an existing virtual network", userProvidedNetworkValidationFailed)
+		}
+		return nil, nil
+	case cd.Spec.Platform.GCP != nil:
+		egress := cd.Spec.Platform.GCP.Egress
+		if egress == nil {
+			return nil, nil
+		}
+		if installConfig.GCP == nil || installConfig.GCP.Network == "" {
+			return nil, errors.Errorf("%s: an existing Cloud NAT requires the install config to specify an existing network", userProvidedNetworkValidationFailed)
+		}
+		creds, err := gcputils.GetCreds("")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get GCP creds")
+		}
+		gcpClient, err := gcpclient.NewClient(creds)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create GCP client")
+		}
+		return nil, validateGCPCloudNAT(gcpClient, installConfig, egress)
+	default:
+		logger.Debug("no user-provided network to validate for platform")
+		return nil, nil
+	}
+}
+
+// validateAWSUserProvidedNetwork checks that the subnets of the install config exist in a single VPC, are not owned by
+// another cluster, and that there is one private subnet, with a route for egress traffic, per availability zone. Unless
+// the cluster is internal, each of those availability zones also needs a public subnet. When existing NAT gateways are
+// configured for egress, they must be available and the private subnets must route egress traffic through them.
+func validateAWSUserProvidedNetwork(awsClient awsclient.Client, installConfig *installertypes.InstallConfig, egress *hivev1aws.Egress, logger log.FieldLogger) (*hivev1aws.UserProvidedNetworkStatus, error) {
+	subnetIDs := installConfig.AWS.Subnets
+	output, err := awsClient.DescribeSubnets(context.TODO(), &ec2.DescribeSubnetsInput{SubnetIds: subnetIDs})
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: could not describe subnets", userProvidedNetworkValidationFailed)
+	}
+	if len(output.Subnets) == 0 {
+		return nil, errors.Errorf("%s: subnets %s not found", userProvidedNetworkValidationFailed, strings.Join(subnetIDs, ", "))
+	}
+
+	var problems []string
+	vpcID := aws.ToString(output.Subnets[0].VpcId)
+	for _, subnet := range output.Subnets[1:] {
+		if aws.ToString(subnet.VpcId) != vpcID {
+			problems = append(problems, fmt.Sprintf("subnet %s is not in VPC %s of subnet %s",
+				aws.ToString(subnet.SubnetId), vpcID, aws.ToString(output.Subnets[0].SubnetId)))
+		}
+	}
+	if egress != nil {
+		natProblems, err := validateAWSNATGateways(awsClient, egress.NATGatewayIDs, vpcID)
+		if err != nil {
+			return nil, err
+		}
+		problems = append(problems, natProblems...)
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, errors.Errorf("%s: %s", userProvidedNetworkValidationFailed, strings.Join(problems, "; "))
+	}
+
+	routeTables, err := awsClient.DescribeRouteTables(context.TODO(), &ec2.DescribeRouteTablesInput{
+		Filters: []ec2types.Filter{{
+			Name:   aws.String("vpc-id"),
+			Values: []string{vpcID},
+		}},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: could not describe route tables", userProvidedNetworkValidationFailed)
+	}
+
+	network := &hivev1aws.UserProvidedNetworkStatus{VPCID: vpcID}
+	privateZones, publicZones := map[string]string{}, map[string]string{}
+	for i := range output.Subnets {
+		subnet := &output.Subnets[i]
+		subnetID := aws.ToString(subnet.SubnetId)
+		zone := aws.ToString(subnet.AvailabilityZone)
+		for _, tag := range subnet.Tags {
+			if strings.HasPrefix(aws.ToString(tag.Key), kubernetesKeyPrefix) && aws.ToString(tag.Value) == "owned" {
+				problems = append(problems, fmt.Sprintf("subnet %s is owned by cluster %s",
+					subnetID, strings.TrimPrefix(aws.ToString(tag.Key), kubernetesKeyPrefix)))
+			}
+		}
+		routeTable, err := awsclient.SubnetRouteTable(routeTables.RouteTables, subnet, logger)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		isPublic, err := awsclient.IsSubnetPublic(routeTables.RouteTables, subnet, logger)
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		status := hivev1aws.SubnetStatus{ID: subnetID, AvailabilityZone: zone}
+		if isPublic {
+			if other, ok := publicZones[zone]; ok {
+				problems = append(problems, fmt.Sprintf("public subnets %s and %s are both in zone %s", other, subnetID, zone))
+			}
+			publicZones[zone] = subnetID
+			network.PublicSubnets = append(network.PublicSubnets, status)
+			continue
+		}
+		if other, ok := privateZones[zone]; ok {
+			problems = append(problems, fmt.Sprintf("private subnets %s and %s are both in zone %s", other, subnetID, zone))
+		}
+		privateZones[zone] = subnetID
+		network.PrivateSubnets = append(network.PrivateSubnets, status)
+		// Clusters behind a proxy may not need a default route.
+		if egress != nil {
+			if natGatewayID := egressNATGateway(routeTable); !sets.NewString(egress.NATGatewayIDs...).Has(natGatewayID) {
+				problems = append(problems, fmt.Sprintf("private subnet %s has no default route through NAT gateways %s",
+					subnetID, strings.Join(egress.NATGatewayIDs, ", ")))
+			}
+		} else if installConfig.Proxy == nil && !hasEgressRoute(routeTable) {
+			problems = append(problems, fmt.Sprintf("private subnet %s has no default route through a NAT gateway, transit gateway or instance", subnetID))
+		}
+	}
+
+	if len(network.PrivateSubnets) == 0 {
+		problems = append(problems, "no private subnets provided")
+	}
+	if installConfig.Publish != installertypes.InternalPublishingStrategy {
+		for zone := range privateZones {
+			if _, ok := publicZones[zone]; !ok {
+				problems = append(problems, fmt.Sprintf("no public subnet provided for zone %s", zone))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, errors.Errorf("%s: %s", userProvidedNetworkValidationFailed, strings.Join(problems, "; "))
+	}
+
+	sortSubnets(network.PrivateSubnets)
+	sortSubnets(network.PublicSubnets)
+	return network, nil
+}
+
+// hasEgressRoute returns true if the route table has a default route through a NAT gateway or another target which
+// can route traffic out of the VPC on behalf of a private subnet.
+func hasEgressRoute(routeTable *ec2types.RouteTable) bool {
+	for _, route := range routeTable.Routes {
+		if aws.ToString(route.DestinationCidrBlock) != "0.0.0.0/0" {
+			continue
+		}
+		if route.NatGatewayId != nil || route.TransitGatewayId != nil || route.InstanceId != nil ||
+			route.NetworkInterfaceId != nil || route.VpcPeeringConnectionId != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// egressNATGateway returns the ID of the NAT gateway of the default route of the route table, if any.
+func egressNATGateway(routeTable *ec2types.RouteTable) string {
+	for _, route := range routeTable.Routes {
+		if aws.ToString(route.DestinationCidrBlock) == "0.0.0.0/0" && route.NatGatewayId != nil {
+			return aws.ToString(route.NatGatewayId)
+		}
+	}
+	return ""
+}
+
+// validateAWSNATGateways returns the problems of the NAT gateways configured for egress: they must exist in the VPC of
+// the subnets and be available.
+func validateAWSNATGateways(awsClient awsclient.Client, natGatewayIDs []string, vpcID string) ([]string, error) {
+	output, err := awsClient.DescribeNatGateways(context.TODO(), &ec2.DescribeNatGatewaysInput{NatGatewayIds: natGatewayIDs})
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: could not describe NAT gateways", userProvidedNetworkValidationFailed)
+	}
+	var problems []string
+	found := sets.NewString()
+	for _, natGateway := range output.NatGateways {
+		id := aws.ToString(natGateway.NatGatewayId)
+		found.Insert(id)
+		if other := aws.ToString(natGateway.VpcId); other != vpcID {
+			problems = append(problems, fmt.Sprintf("NAT gateway %s is in VPC %s rather than VPC %s", id, other, vpcID))
+		}
+		if state := natGateway.State; state != ec2types.NatGatewayStateAvailable {
+			problems = append(problems, fmt.Sprintf("NAT gateway %s is %s", id, state))
+		}
+	}
+	if missing := sets.NewString(natGatewayIDs...).Difference(found); missing.Len() > 0 {
+		problems = append(problems, fmt.Sprintf("NAT gateways %s not found", strings.Join(missing.List(), ", ")))
+	}
+	return problems, nil
+}
+
+// validateGCPCloudNAT checks that the Cloud Router configured for egress is in the existing network of the install
+// config, and that its Cloud NAT gateways translate the traffic of the subnetworks of the cluster.
+func validateGCPCloudNAT(gcpClient gcpclient.Client, installConfig *installertypes.InstallConfig, egress *hivev1gcp.Egress) error {
+	router, err := gcpClient.GetComputeRouter(installConfig.GCP.Region, egress.CloudNATRouter)
+	if err != nil {
+		return errors.Wrapf(err, "%s: could not get Cloud Router %s", userProvidedNetworkValidationFailed, egress.CloudNATRouter)
+	}
+	// Networks and subnetworks are referenced by URLs ending with their names.
+	if network := path.Base(router.Network); network != installConfig.GCP.Network {
+		return errors.Errorf("%s: Cloud Router %s is in network %s rather than network %s",
+			userProvidedNetworkValidationFailed, egress.CloudNATRouter, network, installConfig.GCP.Network)
+	}
+	if len(router.Nats) == 0 {
+		return errors.Errorf("%s: Cloud Router %s has no Cloud NAT", userProvidedNetworkValidationFailed, egress.CloudNATRouter)
+	}
+	translated := sets.NewString()
+	for _, nat := range router.Nats {
+		switch nat.SourceSubnetworkIpRangesToNat {
+		case "ALL_SUBNETWORKS_ALL_IP_RANGES", "ALL_SUBNETWORKS_ALL_PRIMARY_IP_RANGES":
+			return nil
+		}
+		for _, subnetwork := range nat.Subnetworks {
+			translated.Insert(path.Base(subnetwork.Name))
+		}
+	}
+	var untranslated []string
+	for _, subnetwork := range sets.NewString(installConfig.GCP.ControlPlaneSubnet, installConfig.GCP.ComputeSubnet).List() {
+		if subnetwork != "" && !translated.Has(subnetwork) {
+			untranslated = append(untranslated, subnetwork)
+		}
+	}
+	if len(untranslated) > 0 {
+		return errors.Errorf("%s: Cloud Router %s has no Cloud NAT for subnetworks %s",
+			userProvidedNetworkValidationFailed, egress.CloudNATRouter, strings.Join(untranslated, ", "))
+	}
+	return nil
+}
+
+func sortSubnets(subnets []hivev1aws.SubnetStatus) {
+	sort.Slice(subnets, func(i, j int) bool {
+		if subnets[i].AvailabilityZone != subnets[j].AvailabilityZone {
+			return subnets[i].AvailabilityZone < subnets[j].AvailabilityZone
+		}
+		return subnets[i].ID < subnets[j].ID
+	})
+}
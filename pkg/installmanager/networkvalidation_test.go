@@ -0,0 +1,270 @@
+package installmanager
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	compute "google.golang.org/api/compute/v1"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+	installeraws "github.com/openshift/installer/pkg/types/aws"
+	installergcp "github.com/openshift/installer/pkg/types/gcp"
+
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+	mockgcp "github.com/openshift/hive/pkg/gcpclient/mock"
+)
+
+func TestValidateAWSUserProvidedNetwork(t *testing.T) {
+	subnet := func(id, zone string, tags ...ec2types.Tag) ec2types.Subnet {
+		return ec2types.Subnet{
+			SubnetId:         aws.String(id),
+			VpcId:            aws.String("vpc-1"),
+			AvailabilityZone: aws.String(zone),
+			Tags:             tags,
+		}
+	}
+	routeTable := func(subnetID string, route ec2types.Route) ec2types.RouteTable {
+		return ec2types.RouteTable{
+			RouteTableId: aws.String("rtb-" + subnetID),
+			Associations: []ec2types.RouteTableAssociation{{SubnetId: aws.String(subnetID)}},
+			Routes:       []ec2types.Route{route},
+		}
+	}
+	natRoute := ec2types.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1")}
+	igwRoute := ec2types.Route{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")}
+	localRoute := ec2types.Route{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local")}
+
+	natGateway := func(id string, state ec2types.NatGatewayState) ec2types.NatGateway {
+		return ec2types.NatGateway{NatGatewayId: aws.String(id), VpcId: aws.String("vpc-1"), State: state}
+	}
+
+	cases := []struct {
+		name            string
+		publish         installertypes.PublishingStrategy
+		subnets         []ec2types.Subnet
+		routeTables     []ec2types.RouteTable
+		egress          *hivev1aws.Egress
+		natGateways     []ec2types.NatGateway
+		expected        *hivev1aws.UserProvidedNetworkStatus
+		expectedProblem string
+	}{
+		{
+			name:    "valid",
+			subnets: []ec2types.Subnet{subnet("subnet-private-b", "us-east-1b"), subnet("subnet-private-a", "us-east-1a"), subnet("subnet-public-a", "us-east-1a"), subnet("subnet-public-b", "us-east-1b")},
+			routeTables: []ec2types.RouteTable{
+				routeTable("subnet-private-a", natRoute),
+				routeTable("subnet-private-b", natRoute),
+				routeTable("subnet-public-a", igwRoute),
+				routeTable("subnet-public-b", igwRoute),
+			},
+			expected: &hivev1aws.UserProvidedNetworkStatus{
+				VPCID: "vpc-1",
+				PrivateSubnets: []hivev1aws.SubnetStatus{
+					{ID: "subnet-private-a", AvailabilityZone: "us-east-1a"},
+					{ID: "subnet-private-b", AvailabilityZone: "us-east-1b"},
+				},
+				PublicSubnets: []hivev1aws.SubnetStatus{
+					{ID: "subnet-public-a", AvailabilityZone: "us-east-1a"},
+					{ID: "subnet-public-b", AvailabilityZone: "us-east-1b"},
+				},
+			},
+		},
+		{
+			name:        "internal without public subnets",
+			publish:     installertypes.InternalPublishingStrategy,
+			subnets:     []ec2types.Subnet{subnet("subnet-private-a", "us-east-1a")},
+			routeTables: []ec2types.RouteTable{routeTable("subnet-private-a", natRoute)},
+			expected: &hivev1aws.UserProvidedNetworkStatus{
+				VPCID:          "vpc-1",
+				PrivateSubnets: []hivev1aws.SubnetStatus{{ID: "subnet-private-a", AvailabilityZone: "us-east-1a"}},
+			},
+		},
+		{
+			name:            "missing public subnet",
+			subnets:         []ec2types.Subnet{subnet("subnet-private-a", "us-east-1a")},
+			routeTables:     []ec2types.RouteTable{routeTable("subnet-private-a", natRoute)},
+			expectedProblem: "no public subnet provided for zone us-east-1a",
+		},
+		{
+			name:            "missing NAT gateway",
+			publish:         installertypes.InternalPublishingStrategy,
+			subnets:         []ec2types.Subnet{subnet("subnet-private-a", "us-east-1a")},
+			routeTables:     []ec2types.RouteTable{routeTable("subnet-private-a", localRoute)},
+			expectedProblem: "private subnet subnet-private-a has no default route",
+		},
+		{
+			name:            "multiple private subnets in zone",
+			publish:         installertypes.InternalPublishingStrategy,
+			subnets:         []ec2types.Subnet{subnet("subnet-private-a", "us-east-1a"), subnet("subnet-private-b", "us-east-1a")},
+			routeTables:     []ec2types.RouteTable{routeTable("subnet-private-a", natRoute), routeTable("subnet-private-b", natRoute)},
+			expectedProblem: "private subnets subnet-private-a and subnet-private-b are both in zone us-east-1a",
+		},
+		{
+			name:    "owned by another cluster",
+			publish: installertypes.InternalPublishingStrategy,
+			subnets: []ec2types.Subnet{subnet("subnet-private-a", "us-east-1a",
+				ec2types.Tag{Key: aws.String("kubernetes.io/cluster/other-infra-id"), Value: aws.String("owned")})},
+			routeTables:     []ec2types.RouteTable{routeTable("subnet-private-a", natRoute)},
+			expectedProblem: "subnet subnet-private-a is owned by cluster other-infra-id",
+		},
+		{
+			name:        "existing NAT gateway",
+			publish:     installertypes.InternalPublishingStrategy,
+			subnets:     []ec2types.Subnet{subnet("subnet-private-a", "us-east-1a")},
+			routeTables: []ec2types.RouteTable{routeTable("subnet-private-a", natRoute)},
+			egress:      &hivev1aws.Egress{NATGatewayIDs: []string{"nat-1"}},
+			natGateways: []ec2types.NatGateway{natGateway("nat-1", ec2types.NatGatewayStateAvailable)},
+			expected: &hivev1aws.UserProvidedNetworkStatus{
+				VPCID:          "vpc-1",
+				PrivateSubnets: []hivev1aws.SubnetStatus{{ID: "subnet-private-a", AvailabilityZone: "us-east-1a"}},
+			},
+		},
+		{
+			name:            "private subnet not routed through existing NAT gateway",
+			publish:         installertypes.InternalPublishingStrategy,
+			subnets:         []ec2types.Subnet{subnet("subnet-private-a", "us-east-1a")},
+			routeTables:     []ec2types.RouteTable{routeTable("subnet-private-a", natRoute)},
+			egress:          &hivev1aws.Egress{NATGatewayIDs: []string{"nat-2"}},
+			natGateways:     []ec2types.NatGateway{natGateway("nat-2", ec2types.NatGatewayStateAvailable)},
+			expectedProblem: "private subnet subnet-private-a has no default route through NAT gateways nat-2",
+		},
+		{
+			name:            "unavailable NAT gateway",
+			publish:         installertypes.InternalPublishingStrategy,
+			subnets:         []ec2types.Subnet{subnet("subnet-private-a", "us-east-1a")},
+			routeTables:     []ec2types.RouteTable{routeTable("subnet-private-a", natRoute)},
+			egress:          &hivev1aws.Egress{NATGatewayIDs: []string{"nat-1"}},
+			natGateways:     []ec2types.NatGateway{natGateway("nat-1", ec2types.NatGatewayStateDeleted)},
+			expectedProblem: "NAT gateway nat-1 is deleted",
+		},
+		{
+			name:            "missing NAT gateway",
+			publish:         installertypes.InternalPublishingStrategy,
+			subnets:         []ec2types.Subnet{subnet("subnet-private-a", "us-east-1a")},
+			routeTables:     []ec2types.RouteTable{routeTable("subnet-private-a", natRoute)},
+			egress:          &hivev1aws.Egress{NATGatewayIDs: []string{"nat-1", "nat-3"}},
+			natGateways:     []ec2types.NatGateway{natGateway("nat-1", ec2types.NatGatewayStateAvailable)},
+			expectedProblem: "NAT gateways nat-3 not found",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			awsClient := mockaws.NewMockClient(mockCtrl)
+
+			subnetIDs := make([]string, len(tc.subnets))
+			for i, s := range tc.subnets {
+				subnetIDs[i] = aws.ToString(s.SubnetId)
+			}
+			awsClient.EXPECT().DescribeSubnets(gomock.Any(), &ec2.DescribeSubnetsInput{SubnetIds: subnetIDs}).
+				Return(&ec2.DescribeSubnetsOutput{Subnets: tc.subnets}, nil)
+			routeTables := awsClient.EXPECT().DescribeRouteTables(gomock.Any(), gomock.Any()).
+				Return(&ec2.DescribeRouteTablesOutput{RouteTables: tc.routeTables}, nil)
+			if tc.egress != nil {
+				awsClient.EXPECT().DescribeNatGateways(gomock.Any(), &ec2.DescribeNatGatewaysInput{NatGatewayIds: tc.egress.NATGatewayIDs}).
+					Return(&ec2.DescribeNatGatewaysOutput{NatGateways: tc.natGateways}, nil)
+				// Problems with the NAT gateways are reported before the routes are checked.
+				routeTables.MaxTimes(1)
+			}
+
+			installConfig := &installertypes.InstallConfig{
+				Platform: installertypes.Platform{AWS: &installeraws.Platform{Subnets: subnetIDs}},
+				Publish:  tc.publish,
+			}
+			network, err := validateAWSUserProvidedNetwork(awsClient, installConfig, tc.egress, log.WithField("test", tc.name))
+			if tc.expectedProblem != "" {
+				if assert.Error(t, err, "expected validation to fail") {
+					assert.Contains(t, err.Error(), userProvidedNetworkValidationFailed, "expected validation failure prefix")
+					assert.Contains(t, err.Error(), tc.expectedProblem, "unexpected validation failure")
+				}
+				return
+			}
+			if assert.NoError(t, err, "unexpected validation failure") {
+				assert.Equal(t, tc.expected, network, "unexpected network")
+			}
+		})
+	}
+}
+
+func TestValidateGCPCloudNAT(t *testing.T) {
+	const (
+		networkURL    = "https://www.googleapis.com/compute/v1/projects/test-project/global/networks/"
+		subnetworkURL = "https://www.googleapis.com/compute/v1/projects/test-project/regions/us-east1/subnetworks/"
+	)
+	nat := func(ranges string, subnetworks ...string) *compute.RouterNat {
+		n := &compute.RouterNat{Name: "nat", SourceSubnetworkIpRangesToNat: ranges}
+		for _, s := range subnetworks {
+			n.Subnetworks = append(n.Subnetworks, &compute.RouterNatSubnetworkToNat{Name: subnetworkURL + s})
+		}
+		return n
+	}
+
+	cases := []struct {
+		name            string
+		router          *compute.Router
+		expectedProblem string
+	}{
+		{
+			name:   "all subnetworks",
+			router: &compute.Router{Name: "router", Network: networkURL + "network", Nats: []*compute.RouterNat{nat("ALL_SUBNETWORKS_ALL_IP_RANGES")}},
+		},
+		{
+			name: "listed subnetworks",
+			router: &compute.Router{Name: "router", Network: networkURL + "network", Nats: []*compute.RouterNat{
+				nat("LIST_OF_SUBNETWORKS", "master-subnet"),
+				nat("LIST_OF_SUBNETWORKS", "worker-subnet"),
+			}},
+		},
+		{
+			name:            "other network",
+			router:          &compute.Router{Name: "router", Network: networkURL + "other", Nats: []*compute.RouterNat{nat("ALL_SUBNETWORKS_ALL_IP_RANGES")}},
+			expectedProblem: "Cloud Router router is in network other rather than network network",
+		},
+		{
+			name:            "no Cloud NAT",
+			router:          &compute.Router{Name: "router", Network: networkURL + "network"},
+			expectedProblem: "Cloud Router router has no Cloud NAT",
+		},
+		{
+			name: "subnetwork not translated",
+			router: &compute.Router{Name: "router", Network: networkURL + "network", Nats: []*compute.RouterNat{
+				nat("LIST_OF_SUBNETWORKS", "master-subnet"),
+			}},
+			expectedProblem: "Cloud Router router has no Cloud NAT for subnetworks worker-subnet",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			gcpClient := mockgcp.NewMockClient(mockCtrl)
+			gcpClient.EXPECT().GetComputeRouter("us-east1", "router").Return(tc.router, nil)
+
+			installConfig := &installertypes.InstallConfig{
+				Platform: installertypes.Platform{GCP: &installergcp.Platform{
+					Region:             "us-east1",
+					Network:            "network",
+					ControlPlaneSubnet: "master-subnet",
+					ComputeSubnet:      "worker-subnet",
+				}},
+			}
+			err := validateGCPCloudNAT(gcpClient, installConfig, &hivev1gcp.Egress{CloudNATRouter: "router"})
+			if tc.expectedProblem != "" {
+				if assert.Error(t, err, "expected validation to fail") {
+					assert.Contains(t, err.Error(), userProvidedNetworkValidationFailed, "expected validation failure prefix")
+					assert.Contains(t, err.Error(), tc.expectedProblem, "unexpected validation failure")
+				}
+				return
+			}
+			assert.NoError(t, err, "unexpected validation failure")
+		})
+	}
+}
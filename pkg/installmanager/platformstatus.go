@@ -0,0 +1,271 @@
+package installmanager
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	installertypes "github.com/openshift/installer/pkg/types"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	gcputils "github.com/openshift/hive/contrib/pkg/utils/gcp"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+const gcpInstanceFields = "items/*/instances(name,networkInterfaces(network,subnetwork),serviceAccounts(email)),nextPageToken"
+
+// gatherPlatformStatus looks up the platform resources used by a successfully installed cluster, so that they can be
+// reported in the status of the ClusterDeployment. A nil status is returned for platforms with nothing to report.
+func gatherPlatformStatus(cd *hivev1.ClusterDeployment, metadata *installertypes.ClusterMetadata, logger log.FieldLogger) (*hivev1.PlatformStatus, error) {
+	switch {
+	case cd.Spec.Platform.AWS != nil:
+		awsClient, err := awsclient.NewClient(nil, "", "", cd.Spec.Platform.AWS.Region)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create AWS client")
+		}
+		clusterDomain := fmt.Sprintf("%s.%s", cd.Spec.ClusterName, cd.Spec.BaseDomain)
+		if metadata.AWS != nil && metadata.AWS.ClusterDomain != "" {
+			clusterDomain = metadata.AWS.ClusterDomain
+		}
+		status, err := gatherAWSPlatformStatus(awsClient, metadata.InfraID, clusterDomain, logger)
+		if err != nil {
+			return nil, err
+		}
+		return &hivev1.PlatformStatus{AWS: status}, nil
+	case cd.Spec.Platform.Azure != nil:
+		return &hivev1.PlatformStatus{Azure: azurePlatformStatus(cd, metadata)}, nil
+	case cd.Spec.Platform.GCP != nil:
+		creds, err := gcputils.GetCreds("")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get GCP creds")
+		}
+		gcpClient, err := gcpclient.NewClient(creds)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create GCP client")
+		}
+		status, err := gatherGCPPlatformStatus(gcpClient, metadata.InfraID)
+		if err != nil {
+			return nil, err
+		}
+		// The installer creates a Cloud Router for the network it creates.
+		router := metadata.InfraID + "-router"
+		if egress := cd.Spec.Platform.GCP.Egress; egress != nil {
+			router = egress.CloudNATRouter
+		}
+		if err := gatherGCPCloudNATStatus(gcpClient, cd.Spec.Platform.GCP.Region, router, status); err != nil {
+			return nil, err
+		}
+		return &hivev1.PlatformStatus{GCP: status}, nil
+	default:
+		logger.Debug("no platform status to gather for platform")
+		return nil, nil
+	}
+}
+
+// gatherAWSPlatformStatus finds the subnets tagged for the cluster, whether created by the installer or brought by the
+// user, along with their VPC, the NAT gateways they route egress traffic through, and the private hosted zone of the
+// cluster domain.
+func gatherAWSPlatformStatus(awsClient awsclient.Client, infraID, clusterDomain string, logger log.FieldLogger) (*hivev1aws.PlatformStatus, error) {
+	status := &hivev1aws.PlatformStatus{}
+	var subnets []ec2types.Subnet
+
+	input := &ec2.DescribeSubnetsInput{
+		Filters: []ec2types.Filter{{
+			Name:   aws.String("tag-key"),
+			Values: []string{kubernetesKeyPrefix + infraID},
+		}},
+	}
+	for {
+		output, err := awsClient.DescribeSubnets(context.TODO(), input)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to describe subnets")
+		}
+		for _, subnet := range output.Subnets {
+			status.SubnetIDs = append(status.SubnetIDs, aws.ToString(subnet.SubnetId))
+			status.VPCID = aws.ToString(subnet.VpcId)
+		}
+		subnets = append(subnets, output.Subnets...)
+		if aws.ToString(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+	sort.Strings(status.SubnetIDs)
+
+	if status.VPCID != "" {
+		natGateways, err := gatherAWSNATGateways(awsClient, status.VPCID, subnets, logger)
+		if err != nil {
+			return nil, err
+		}
+		status.NATGateways = natGateways
+	}
+
+	zoneName := strings.TrimSuffix(clusterDomain, ".") + "."
+	zones, err := awsClient.ListHostedZonesByName(context.TODO(), &route53.ListHostedZonesByNameInput{
+		DNSName: aws.String(zoneName),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list hosted zones")
+	}
+	for _, zone := range zones.HostedZones {
+		// Zones are listed in order starting from the requested name.
+		if aws.ToString(zone.Name) != zoneName {
+			break
+		}
+		if zone.Config != nil && zone.Config.PrivateZone {
+			status.PrivateHostedZoneID = strings.TrimPrefix(aws.ToString(zone.Id), "/hostedzone/")
+			break
+		}
+	}
+
+	return status, nil
+}
+
+// gatherAWSNATGateways finds the NAT gateways of the default routes of the subnets, with their public IPs.
+func gatherAWSNATGateways(awsClient awsclient.Client, vpcID string, subnets []ec2types.Subnet, logger log.FieldLogger) ([]hivev1aws.NATGatewayStatus, error) {
+	routeTables, err := awsClient.DescribeRouteTables(context.TODO(), &ec2.DescribeRouteTablesInput{
+		Filters: []ec2types.Filter{{
+			Name:   aws.String("vpc-id"),
+			Values: []string{vpcID},
+		}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe route tables")
+	}
+	natGatewayIDs := sets.NewString()
+	for i := range subnets {
+		routeTable, err := awsclient.SubnetRouteTable(routeTables.RouteTables, &subnets[i], logger)
+		if err != nil {
+			logger.WithError(err).Debug("could not find route table of subnet")
+			continue
+		}
+		if id := egressNATGateway(routeTable); id != "" {
+			natGatewayIDs.Insert(id)
+		}
+	}
+	if natGatewayIDs.Len() == 0 {
+		return nil, nil
+	}
+
+	output, err := awsClient.DescribeNatGateways(context.TODO(), &ec2.DescribeNatGatewaysInput{NatGatewayIds: natGatewayIDs.List()})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to describe NAT gateways")
+	}
+	var natGateways []hivev1aws.NATGatewayStatus
+	for _, natGateway := range output.NatGateways {
+		status := hivev1aws.NATGatewayStatus{ID: aws.ToString(natGateway.NatGatewayId)}
+		for _, address := range natGateway.NatGatewayAddresses {
+			if ip := aws.ToString(address.PublicIp); ip != "" {
+				status.PublicIPs = append(status.PublicIPs, ip)
+			}
+		}
+		sort.Strings(status.PublicIPs)
+		natGateways = append(natGateways, status)
+	}
+	sort.Slice(natGateways, func(i, j int) bool { return natGateways[i].ID < natGateways[j].ID })
+	return natGateways, nil
+}
+
+// azurePlatformStatus reports the resource groups of the cluster from the installer metadata.
+func azurePlatformStatus(cd *hivev1.ClusterDeployment, metadata *installertypes.ClusterMetadata) *hivev1azure.PlatformStatus {
+	status := &hivev1azure.PlatformStatus{
+		// Default used by installers which do not report the resource group in the metadata.
+		ResourceGroupName:           metadata.InfraID + "-rg",
+		BaseDomainResourceGroupName: cd.Spec.Platform.Azure.BaseDomainResourceGroupName,
+	}
+	// The vendored Azure SDK does not know NAT gateways, so the configured NAT gateway is reported as is.
+	if egress := cd.Spec.Platform.Azure.Egress; egress != nil {
+		status.NATGatewayID = egress.NATGatewayID
+	}
+	if metadata.Azure != nil {
+		if metadata.Azure.ResourceGroupName != "" {
+			status.ResourceGroupName = metadata.Azure.ResourceGroupName
+		}
+		if metadata.Azure.BaseDomainResourceGroupName != "" {
+			status.BaseDomainResourceGroupName = metadata.Azure.BaseDomainResourceGroupName
+		}
+	}
+	return status
+}
+
+// gatherGCPPlatformStatus finds the networks and service accounts used by the instances of the cluster.
+func gatherGCPPlatformStatus(gcpClient gcpclient.Client, infraID string) (*hivev1gcp.PlatformStatus, error) {
+	networks := sets.NewString()
+	subnetworks := sets.NewString()
+	serviceAccounts := sets.NewString()
+	err := gcpClient.ListComputeInstances(gcpclient.ListComputeInstancesOptions{
+		Filter: fmt.Sprintf("name eq \"%s-.*\"", infraID),
+		Fields: gcpInstanceFields,
+	}, func(list *compute.InstanceAggregatedList) error {
+		for _, scopedList := range list.Items {
+			for _, instance := range scopedList.Instances {
+				for _, networkInterface := range instance.NetworkInterfaces {
+					// Networks are reported as URLs ending with the name of the network.
+					if networkInterface.Network != "" {
+						networks.Insert(path.Base(networkInterface.Network))
+					}
+					if networkInterface.Subnetwork != "" {
+						subnetworks.Insert(path.Base(networkInterface.Subnetwork))
+					}
+				}
+				for _, serviceAccount := range instance.ServiceAccounts {
+					serviceAccounts.Insert(serviceAccount.Email)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	status := &hivev1gcp.PlatformStatus{
+		Subnetworks:     subnetworks.List(),
+		ServiceAccounts: serviceAccounts.List(),
+	}
+	if networks.Len() > 0 {
+		status.Network = networks.List()[0]
+	}
+	return status, nil
+}
+
+// gatherGCPCloudNATStatus finds the Cloud NAT gateways of the Cloud Router of the cluster, along with their static
+// external IP addresses. Nothing is reported when the router does not exist.
+func gatherGCPCloudNATStatus(gcpClient gcpclient.Client, region, routerName string, status *hivev1gcp.PlatformStatus) error {
+	router, err := gcpClient.GetComputeRouter(region, routerName)
+	if gcpErr, ok := err.(*googleapi.Error); ok && gcpErr.Code == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get Cloud Router %s", routerName)
+	}
+	status.CloudNATRouter = router.Name
+	natIPs := sets.NewString()
+	for _, nat := range router.Nats {
+		status.CloudNATs = append(status.CloudNATs, nat.Name)
+		// Addresses are referenced by URLs ending with their names.
+		for _, natIP := range nat.NatIps {
+			natIPs.Insert(path.Base(natIP))
+		}
+	}
+	sort.Strings(status.CloudNATs)
+	status.NATIPs = natIPs.List()
+	return nil
+}
@@ -0,0 +1,183 @@
+package installmanager
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
+	mockaws "github.com/openshift/hive/pkg/awsclient/mock"
+	"github.com/openshift/hive/pkg/gcpclient"
+	mockgcp "github.com/openshift/hive/pkg/gcpclient/mock"
+)
+
+func TestGatherAWSPlatformStatus(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	awsClient := mockaws.NewMockClient(mockCtrl)
+
+	awsClient.EXPECT().DescribeSubnets(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+		if assert.Len(t, input.Filters, 1, "unexpected filters") {
+			assert.Equal(t, []string{"kubernetes.io/cluster/test-infra-id"}, input.Filters[0].Values, "unexpected filter values")
+		}
+		if input.NextToken == nil {
+			return &ec2.DescribeSubnetsOutput{
+				Subnets:   []ec2types.Subnet{{SubnetId: aws.String("subnet-b"), VpcId: aws.String("vpc-1")}},
+				NextToken: aws.String("page-2"),
+			}, nil
+		}
+		return &ec2.DescribeSubnetsOutput{
+			Subnets: []ec2types.Subnet{{SubnetId: aws.String("subnet-a"), VpcId: aws.String("vpc-1")}},
+		}, nil
+	}).Times(2)
+	awsClient.EXPECT().DescribeRouteTables(gomock.Any(), gomock.Any()).Return(&ec2.DescribeRouteTablesOutput{
+		RouteTables: []ec2types.RouteTable{
+			{
+				Associations: []ec2types.RouteTableAssociation{{SubnetId: aws.String("subnet-a")}},
+				Routes:       []ec2types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-1")}},
+			},
+			{
+				Associations: []ec2types.RouteTableAssociation{{SubnetId: aws.String("subnet-b")}},
+				Routes:       []ec2types.Route{{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1")}},
+			},
+		},
+	}, nil)
+	awsClient.EXPECT().DescribeNatGateways(gomock.Any(), &ec2.DescribeNatGatewaysInput{NatGatewayIds: []string{"nat-1"}}).
+		Return(&ec2.DescribeNatGatewaysOutput{
+			NatGateways: []ec2types.NatGateway{{
+				NatGatewayId:        aws.String("nat-1"),
+				NatGatewayAddresses: []ec2types.NatGatewayAddress{{PublicIp: aws.String("203.0.113.2")}, {PublicIp: aws.String("203.0.113.1")}},
+			}},
+		}, nil)
+	awsClient.EXPECT().ListHostedZonesByName(gomock.Any(), &route53.ListHostedZonesByNameInput{DNSName: aws.String("test-cluster.example.com.")}).
+		Return(&route53.ListHostedZonesByNameOutput{
+			HostedZones: []route53types.HostedZone{
+				{
+					Id:     aws.String("/hostedzone/public-zone"),
+					Name:   aws.String("test-cluster.example.com."),
+					Config: &route53types.HostedZoneConfig{PrivateZone: false},
+				},
+				{
+					Id:     aws.String("/hostedzone/private-zone"),
+					Name:   aws.String("test-cluster.example.com."),
+					Config: &route53types.HostedZoneConfig{PrivateZone: true},
+				},
+			},
+		}, nil)
+
+	status, err := gatherAWSPlatformStatus(awsClient, "test-infra-id", "test-cluster.example.com", log.WithField("test", "TestGatherAWSPlatformStatus"))
+	require.NoError(t, err, "unexpected error gathering platform status")
+	assert.Equal(t, &hivev1aws.PlatformStatus{
+		VPCID:               "vpc-1",
+		SubnetIDs:           []string{"subnet-a", "subnet-b"},
+		PrivateHostedZoneID: "private-zone",
+		NATGateways:         []hivev1aws.NATGatewayStatus{{ID: "nat-1", PublicIPs: []string{"203.0.113.1", "203.0.113.2"}}},
+	}, status, "unexpected platform status")
+}
+
+func TestGatherGCPPlatformStatus(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	gcpClient := mockgcp.NewMockClient(mockCtrl)
+
+	networkInterface := func(subnetwork string) *compute.NetworkInterface {
+		return &compute.NetworkInterface{
+			Network:    "https://www.googleapis.com/compute/v1/projects/test-project/global/networks/test-infra-id-network",
+			Subnetwork: "https://www.googleapis.com/compute/v1/projects/test-project/regions/us-east1/subnetworks/" + subnetwork,
+		}
+	}
+	gcpClient.EXPECT().ListComputeInstances(gomock.Any(), gomock.Any()).Do(
+		func(opts gcpclient.ListComputeInstancesOptions, f func(*compute.InstanceAggregatedList) error) {
+			assert.Equal(t, `name eq "test-infra-id-.*"`, opts.Filter, "unexpected filter")
+			f(&compute.InstanceAggregatedList{
+				Items: map[string]compute.InstancesScopedList{
+					"result": {
+						Instances: []*compute.Instance{
+							{
+								Name:              "test-infra-id-master-0",
+								NetworkInterfaces: []*compute.NetworkInterface{networkInterface("test-infra-id-master-subnet")},
+								ServiceAccounts:   []*compute.ServiceAccount{{Email: "test-infra-id-m@test-project.iam.gserviceaccount.com"}},
+							},
+							{
+								Name:              "test-infra-id-worker-a-abcde",
+								NetworkInterfaces: []*compute.NetworkInterface{networkInterface("test-infra-id-worker-subnet")},
+								ServiceAccounts:   []*compute.ServiceAccount{{Email: "test-infra-id-w@test-project.iam.gserviceaccount.com"}},
+							},
+							{
+								Name:              "test-infra-id-worker-b-fghij",
+								NetworkInterfaces: []*compute.NetworkInterface{networkInterface("test-infra-id-worker-subnet")},
+								ServiceAccounts:   []*compute.ServiceAccount{{Email: "test-infra-id-w@test-project.iam.gserviceaccount.com"}},
+							},
+						},
+					},
+				},
+			})
+		},
+	).Return(nil)
+
+	status, err := gatherGCPPlatformStatus(gcpClient, "test-infra-id")
+	require.NoError(t, err, "unexpected error gathering platform status")
+	assert.Equal(t, &hivev1gcp.PlatformStatus{
+		Network:     "test-infra-id-network",
+		Subnetworks: []string{"test-infra-id-master-subnet", "test-infra-id-worker-subnet"},
+		ServiceAccounts: []string{
+			"test-infra-id-m@test-project.iam.gserviceaccount.com",
+			"test-infra-id-w@test-project.iam.gserviceaccount.com",
+		},
+	}, status, "unexpected platform status")
+}
+
+func TestGatherGCPCloudNATStatus(t *testing.T) {
+	cases := []struct {
+		name     string
+		router   *compute.Router
+		err      error
+		expected *hivev1gcp.PlatformStatus
+	}{
+		{
+			name: "router",
+			router: &compute.Router{
+				Name: "test-infra-id-router",
+				Nats: []*compute.RouterNat{
+					{Name: "test-infra-id-nat-worker", NatIps: []string{"https://www.googleapis.com/compute/v1/projects/test-project/regions/us-east1/addresses/worker-ip"}},
+					{Name: "test-infra-id-nat-master", NatIpAllocateOption: "AUTO_ONLY"},
+				},
+			},
+			expected: &hivev1gcp.PlatformStatus{
+				CloudNATRouter: "test-infra-id-router",
+				CloudNATs:      []string{"test-infra-id-nat-master", "test-infra-id-nat-worker"},
+				NATIPs:         []string{"worker-ip"},
+			},
+		},
+		{
+			name:     "no router",
+			err:      &googleapi.Error{Code: http.StatusNotFound},
+			expected: &hivev1gcp.PlatformStatus{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			gcpClient := mockgcp.NewMockClient(mockCtrl)
+			gcpClient.EXPECT().GetComputeRouter("us-east1", "test-infra-id-router").Return(tc.router, tc.err)
+
+			status := &hivev1gcp.PlatformStatus{}
+			require.NoError(t, gatherGCPCloudNATStatus(gcpClient, "us-east1", "test-infra-id-router", status), "unexpected error gathering Cloud NAT status")
+			assert.Equal(t, tc.expected, status, "unexpected platform status")
+		})
+	}
+}
@@ -1,11 +1,12 @@
 package installmanager
 
 import (
+	"context"
 	"fmt"
 	"os"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	log "github.com/sirupsen/logrus"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -81,7 +82,7 @@ func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision
 
 		logkey := fmt.Sprintf("%v/%v-%v", folder, clusterprovision.Name, stat.Name())
 
-		_, err = awsc.Upload(&s3manager.UploadInput{
+		_, err = awsc.Upload(context.TODO(), &s3.PutObjectInput{
 			Bucket: aws.String(bucket),
 			Key:    aws.String(logkey),
 			Body:   file,
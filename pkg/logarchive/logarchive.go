@@ -0,0 +1,179 @@
+// Package logarchive archives the logs of install and uninstall pods, so that they can be retrieved after the pods
+// are gone.
+package logarchive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// defaultRetentionDays is the number of days after which archived logs expire when HiveConfig does not say.
+	defaultRetentionDays = 30
+
+	// retentionRuleID is the ID of the lifecycle rule expiring the archived logs in the bucket.
+	retentionRuleID = "hive-log-archive-retention"
+
+	noSuchLifecycleConfigurationErrorCode = "NoSuchLifecycleConfiguration"
+)
+
+// Archive stores logs and signs URLs to fetch them.
+type Archive interface {
+	// Put stores the log read from body under the key.
+	Put(key string, body io.Reader) error
+
+	// EnsureRetention ensures that the archived logs expire after the configured retention.
+	EnsureRetention() error
+
+	// SignURL returns a URL to fetch the log stored under the key, which is valid for the given duration.
+	SignURL(key string, expiry time.Duration) (string, error)
+}
+
+// ReadConfig reads the log archive configuration from the file pointed to by the LogArchiveConfigFileEnvVar
+// environment variable. A nil configuration is returned when logs are not archived.
+func ReadConfig() (*hivev1.LogArchiveConfig, error) {
+	path := os.Getenv(constants.LogArchiveConfigFileEnvVar)
+	if len(path) == 0 {
+		return nil, nil
+	}
+	fileBytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the log archive config file")
+	}
+	if len(fileBytes) == 0 || string(fileBytes) == "null" {
+		return nil, nil
+	}
+	config := &hivev1.LogArchiveConfig{}
+	if err := json.Unmarshal(fileBytes, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the log archive config")
+	}
+	return config, nil
+}
+
+// New returns the archive configured in HiveConfig, using the credentials secret in the given namespace.
+func New(c client.Client, namespace string, config *hivev1.LogArchiveConfig) (Archive, error) {
+	if config == nil || config.AWS == nil {
+		return nil, errors.New("no log archive is configured")
+	}
+	awsClient, err := awsclient.NewClient(c, config.AWS.CredentialsSecretRef.Name, namespace, config.AWS.Region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the AWS client for the log archive")
+	}
+	return &s3Archive{
+		client:        awsClient,
+		bucket:        config.AWS.Bucket,
+		retentionDays: retentionDays(config),
+	}, nil
+}
+
+// Key returns the key of the log of a container of a pod run for a ClusterProvision or ClusterDeprovision.
+func Key(namespace, clusterDeploymentName, ownerName, podName, containerName string) string {
+	return fmt.Sprintf("%s/%s/%s/%s-%s.log", namespace, clusterDeploymentName, ownerName, podName, containerName)
+}
+
+func retentionDays(config *hivev1.LogArchiveConfig) int32 {
+	if config.RetentionDays == nil {
+		return defaultRetentionDays
+	}
+	return int32(*config.RetentionDays)
+}
+
+// s3Archive archives logs in an AWS S3 bucket.
+type s3Archive struct {
+	client        awsclient.Client
+	bucket        string
+	retentionDays int32
+}
+
+var _ Archive = &s3Archive{}
+
+func (a *s3Archive) Put(key string, body io.Reader) error {
+	_, err := a.client.Upload(context.TODO(), &s3.PutObjectInput{
+		Bucket: aws.String(a.bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	})
+	return errors.Wrapf(err, "failed to upload log %s", key)
+}
+
+// EnsureRetention adds, or updates, a lifecycle rule expiring the objects of the bucket, while keeping any other rules
+// of the bucket.
+func (a *s3Archive) EnsureRetention() error {
+	var rules []s3types.LifecycleRule
+	output, err := a.client.GetBucketLifecycleConfiguration(context.TODO(), &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(a.bucket),
+	})
+	switch {
+	case err == nil:
+		rules = output.Rules
+	case isNoSuchLifecycleConfiguration(err):
+	default:
+		return errors.Wrap(err, "failed to get the lifecycle configuration of the bucket")
+	}
+	rules, changed := withRetentionRule(rules, a.retentionDays)
+	if !changed {
+		return nil
+	}
+	_, err = a.client.PutBucketLifecycleConfiguration(context.TODO(), &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 aws.String(a.bucket),
+		LifecycleConfiguration: &s3types.BucketLifecycleConfiguration{Rules: rules},
+	})
+	return errors.Wrap(err, "failed to put the lifecycle configuration of the bucket")
+}
+
+func (a *s3Archive) SignURL(key string, expiry time.Duration) (string, error) {
+	req, err := a.client.PresignGetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: aws.String(a.bucket),
+		Key:    aws.String(key),
+	}, expiry)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to sign the URL of log %s", key)
+	}
+	return req.URL, nil
+}
+
+// withRetentionRule returns the lifecycle rules with the retention rule set to expire objects after the given number
+// of days, and whether the rules changed.
+func withRetentionRule(rules []s3types.LifecycleRule, days int32) ([]s3types.LifecycleRule, bool) {
+	rule := s3types.LifecycleRule{
+		ID:         aws.String(retentionRuleID),
+		Status:     s3types.ExpirationStatusEnabled,
+		Filter:     &s3types.LifecycleRuleFilterMemberPrefix{Value: ""},
+		Expiration: &s3types.LifecycleExpiration{Days: days},
+	}
+	for i, r := range rules {
+		if aws.ToString(r.ID) != retentionRuleID {
+			continue
+		}
+		if r.Status == s3types.ExpirationStatusEnabled && r.Expiration != nil && r.Expiration.Days == days {
+			return rules, false
+		}
+		rules[i] = rule
+		return rules, true
+	}
+	return append(rules, rule), true
+}
+
+func isNoSuchLifecycleConfiguration(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == noSuchLifecycleConfigurationErrorCode
+}
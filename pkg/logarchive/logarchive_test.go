@@ -0,0 +1,65 @@
+package logarchive
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRetentionRule(t *testing.T) {
+	otherRule := s3types.LifecycleRule{
+		ID:     aws.String("other"),
+		Status: s3types.ExpirationStatusEnabled,
+	}
+	retentionRule := func(days int32) s3types.LifecycleRule {
+		return s3types.LifecycleRule{
+			ID:         aws.String(retentionRuleID),
+			Status:     s3types.ExpirationStatusEnabled,
+			Filter:     &s3types.LifecycleRuleFilterMemberPrefix{Value: ""},
+			Expiration: &s3types.LifecycleExpiration{Days: days},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		rules           []s3types.LifecycleRule
+		expectedRules   []s3types.LifecycleRule
+		expectedChanged bool
+	}{
+		{
+			name:            "no rules",
+			expectedRules:   []s3types.LifecycleRule{retentionRule(30)},
+			expectedChanged: true,
+		},
+		{
+			name:            "other rules",
+			rules:           []s3types.LifecycleRule{otherRule},
+			expectedRules:   []s3types.LifecycleRule{otherRule, retentionRule(30)},
+			expectedChanged: true,
+		},
+		{
+			name:            "different retention",
+			rules:           []s3types.LifecycleRule{retentionRule(7), otherRule},
+			expectedRules:   []s3types.LifecycleRule{retentionRule(30), otherRule},
+			expectedChanged: true,
+		},
+		{
+			name:          "same retention",
+			rules:         []s3types.LifecycleRule{otherRule, retentionRule(30)},
+			expectedRules: []s3types.LifecycleRule{otherRule, retentionRule(30)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules, changed := withRetentionRule(test.rules, 30)
+			assert.Equal(t, test.expectedChanged, changed, "unexpected changed")
+			assert.Equal(t, test.expectedRules, rules, "unexpected rules")
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "ns/cd/cd-0-abcde/cd-0-abcde-provision-xyz-hive.log", Key("ns", "cd", "cd-0-abcde", "cd-0-abcde-provision-xyz", "hive"))
+}
@@ -0,0 +1,344 @@
+// Package machinesetgen generates the MachineSets Hive syncs to a cluster for a MachinePool. It is used by the
+// machinepool controller, and can be used by other tools to predict exactly which MachineSets Hive will create.
+package machinesetgen
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// MachinePoolNameLabel is the label on generated MachineSets holding the name of their MachinePool.
+	MachinePoolNameLabel = "hive.openshift.io/machine-pool"
+
+	workerRole       = "worker"
+	nodeRoleLabelFmt = "node-role.kubernetes.io/%s"
+)
+
+// Generator generates the platform specific MachineSets for a MachinePool. The actuators of the machinepool
+// controller implement Generator.
+type Generator interface {
+
+	// GenerateMachineSets returns the platform specific MachineSets for a given MachinePool. Returns the list of
+	// generated machine sets, a boolean indicating if the caller should proceed or not, and an error. The boolean may
+	// be set in situations where we have not encountered an error, but still need to wait before we can proceed.
+	GenerateMachineSets(*hivev1.ClusterDeployment, *hivev1.MachinePool, log.FieldLogger) (msets []*machineapi.MachineSet, proceed bool, genError error)
+}
+
+// Generate returns the MachineSets Hive syncs to the cluster for the MachinePool. The platform specific MachineSets
+// from the generator are sorted by name and then decorated with the settings of the MachinePool that are common to
+// all platforms, and with the defaults of HiveConfig, which may be nil, so the result is the same for the same inputs
+// regardless of the order the generator returned them in.
+func Generate(generator Generator, cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, defaults *hivev1.MachinePoolDefaults, logger log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
+	machineSets, proceed, err := generator.GenerateMachineSets(cd, pool, logger)
+	if err != nil || !proceed {
+		return nil, proceed, err
+	}
+	sort.SliceStable(machineSets, func(i, j int) bool {
+		return machineSets[i].Name < machineSets[j].Name
+	})
+	Decorate(cd, pool, defaults, machineSets)
+	return machineSets, true, nil
+}
+
+// Decorate applies the settings of the MachinePool that are common to all platforms, the labels the
+// ClusterDeployment propagates to its nodes, and the default labels and taints of HiveConfig, which may be nil, to the
+// generated MachineSets, which must be sorted in the order they are synced in.
+func Decorate(cd *hivev1.ClusterDeployment, pool *hivev1.MachinePool, defaults *hivev1.MachinePoolDefaults, machineSets []*machineapi.MachineSet) {
+	nodeLabels := PropagatedNodeLabels(cd)
+	var defaultLabels map[string]string
+	if defaults != nil {
+		defaultLabels = defaults.Labels
+	}
+	for i, ms := range machineSets {
+		if pool.Spec.Autoscaling != nil {
+			min, _ := MinMaxReplicas(pool, machineSets, i)
+			ms.Spec.Replicas = &min
+		} else if len(pool.Spec.ZoneDistribution) > 0 {
+			replicas := Replicas(pool, machineSets, i)
+			ms.Spec.Replicas = &replicas
+		}
+
+		if ms.Labels == nil {
+			ms.Labels = make(map[string]string, 2)
+		}
+		ms.Labels[MachinePoolNameLabel] = pool.Spec.Name
+		// Add the managed-by-Hive label:
+		ms.Labels[constants.HiveManagedLabel] = "true"
+		if ms.Annotations == nil {
+			ms.Annotations = make(map[string]string, 1)
+		}
+		ms.Annotations[constants.MachinePoolGenerationAnnotation] = strconv.FormatInt(pool.Generation, 10)
+
+		// Apply the default labels of HiveConfig, then the labels propagated from the ClusterDeployment, then hive
+		// MachinePool labels, to MachineSet MachineSpec.
+		ms.Spec.Template.Spec.ObjectMeta.Labels = make(map[string]string, len(defaultLabels)+len(nodeLabels)+len(pool.Spec.Labels))
+		for key, value := range defaultLabels {
+			ms.Spec.Template.Spec.ObjectMeta.Labels[key] = value
+		}
+		for key, value := range nodeLabels {
+			ms.Spec.Template.Spec.ObjectMeta.Labels[key] = value
+		}
+		for key, value := range pool.Spec.Labels {
+			ms.Spec.Template.Spec.ObjectMeta.Labels[key] = value
+		}
+		// Nodes of pools with their own machine config pool carry the node role selected by that pool.
+		if UsesCustomMachineConfigPool(pool) {
+			ms.Spec.Template.Spec.ObjectMeta.Labels[NodeRoleLabel(pool.Spec.Name)] = ""
+		}
+
+		// Apply hive MachinePool taints, then the default taints of HiveConfig, to MachineSet MachineSpec.
+		ms.Spec.Template.Spec.Taints = Taints(pool, defaults)
+	}
+}
+
+// Taints returns the taints of the machines of the MachinePool: the taints of the MachinePool, followed by the
+// default taints which the MachinePool has no taint with the same key and effect for.
+func Taints(pool *hivev1.MachinePool, defaults *hivev1.MachinePoolDefaults) []corev1.Taint {
+	if defaults == nil || len(defaults.Taints) == 0 {
+		return pool.Spec.Taints
+	}
+	taints := make([]corev1.Taint, len(pool.Spec.Taints), len(pool.Spec.Taints)+len(defaults.Taints))
+	copy(taints, pool.Spec.Taints)
+	for _, defaultTaint := range defaults.Taints {
+		found := false
+		for _, taint := range pool.Spec.Taints {
+			if taint.Key == defaultTaint.Key && taint.Effect == defaultTaint.Effect {
+				found = true
+				break
+			}
+		}
+		if !found {
+			taints = append(taints, defaultTaint)
+		}
+	}
+	return taints
+}
+
+// PropagatedNodeLabels returns the labels of the ClusterDeployment which are propagated to the nodes of the cluster.
+// Keys which the ClusterDeployment is not labeled with are skipped.
+func PropagatedNodeLabels(cd *hivev1.ClusterDeployment) map[string]string {
+	labels := make(map[string]string, len(cd.Spec.PropagatedNodeLabels))
+	for _, key := range cd.Spec.PropagatedNodeLabels {
+		if value, ok := cd.Labels[key]; ok {
+			labels[key] = value
+		}
+	}
+	return labels
+}
+
+// MinMaxReplicas returns the minimum and maximum replicas of the MachineSet at the given index when the replicas of
+// the auto-scaling MachinePool are spread across the MachineSets. MachineSets of zones pinned by the zone distribution
+// of the MachinePool get their pinned replicas, and any remainder goes to the first of the other MachineSets.
+func MinMaxReplicas(pool *hivev1.MachinePool, machineSets []*machineapi.MachineSet, machineSetIndex int) (min, max int32) {
+	min = spread(pool.Spec.Autoscaling.MinReplicas, pinnedReplicas(pool, machineSets, func(z hivev1.MachinePoolZoneReplicas) *int32 {
+		return z.MinReplicas
+	}), len(machineSets), machineSetIndex)
+	max = spread(pool.Spec.Autoscaling.MaxReplicas, pinnedReplicas(pool, machineSets, func(z hivev1.MachinePoolZoneReplicas) *int32 {
+		return z.MaxReplicas
+	}), len(machineSets), machineSetIndex)
+	if max < min {
+		max = min
+	}
+	return
+}
+
+// Replicas returns the replicas of the MachineSet at the given index when the replicas of the MachinePool, which
+// does not use autoscaling, are spread across the MachineSets. MachineSets of zones pinned by the zone distribution of
+// the MachinePool get their pinned replicas, and any remainder goes to the first of the other MachineSets.
+func Replicas(pool *hivev1.MachinePool, machineSets []*machineapi.MachineSet, machineSetIndex int) int32 {
+	return spread(totalReplicas(pool), pinnedReplicas(pool, machineSets, func(z hivev1.MachinePoolZoneReplicas) *int32 {
+		return z.Replicas
+	}), len(machineSets), machineSetIndex)
+}
+
+// ValidateZoneDistribution returns an error when the zone distribution of the MachinePool names a zone which none of
+// the MachineSets are in, or its pinned replicas do not add up to the replicas of the MachinePool.
+func ValidateZoneDistribution(pool *hivev1.MachinePool, machineSets []*machineapi.MachineSet) error {
+	if len(pool.Spec.ZoneDistribution) == 0 {
+		return nil
+	}
+	zones := sets.NewString()
+	for _, ms := range machineSets {
+		zones.Insert(Zone(ms))
+	}
+	distributed := make([]string, 0, len(pool.Spec.ZoneDistribution))
+	for zone := range pool.Spec.ZoneDistribution {
+		distributed = append(distributed, zone)
+	}
+	sort.Strings(distributed)
+	for _, zone := range distributed {
+		if !zones.Has(zone) {
+			return fmt.Errorf("zone %s of the zone distribution is not a zone of the machine pool, which are: %s", zone, strings.Join(zones.List(), ", "))
+		}
+	}
+
+	check := func(what string, total int32, pinned []*int32) error {
+		var sum int32
+		all := true
+		for _, p := range pinned {
+			if p == nil {
+				all = false
+				continue
+			}
+			sum += *p
+		}
+		switch {
+		case sum > total:
+			return fmt.Errorf("the pinned %s of the zone distribution add up to %d, more than the %d of the machine pool", what, sum, total)
+		case all && sum != total:
+			return fmt.Errorf("every zone is pinned, but the pinned %s of the zone distribution add up to %d rather than the %d of the machine pool", what, sum, total)
+		}
+		return nil
+	}
+	if pool.Spec.Autoscaling == nil {
+		return check("replicas", totalReplicas(pool), pinnedReplicas(pool, machineSets, func(z hivev1.MachinePoolZoneReplicas) *int32 {
+			return z.Replicas
+		}))
+	}
+	if err := check("minimum replicas", pool.Spec.Autoscaling.MinReplicas, pinnedReplicas(pool, machineSets, func(z hivev1.MachinePoolZoneReplicas) *int32 {
+		return z.MinReplicas
+	})); err != nil {
+		return err
+	}
+	return check("maximum replicas", pool.Spec.Autoscaling.MaxReplicas, pinnedReplicas(pool, machineSets, func(z hivev1.MachinePoolZoneReplicas) *int32 {
+		return z.MaxReplicas
+	}))
+}
+
+// Zone returns the availability zone of the machines of the MachineSet, read from its provider spec. The zone is empty
+// for platforms without zones.
+func Zone(ms *machineapi.MachineSet) string {
+	if ms.Spec.Template.Spec.ProviderSpec.Value == nil {
+		return ""
+	}
+	raw, err := json.Marshal(ms.Spec.Template.Spec.ProviderSpec.Value)
+	if err != nil {
+		return ""
+	}
+	spec := struct {
+		// Placement holds the zone of AWS machines.
+		Placement struct {
+			AvailabilityZone string `json:"availabilityZone"`
+		} `json:"placement"`
+		// Zone holds the zone of GCP and Azure machines.
+		Zone string `json:"zone"`
+		// AvailabilityZone holds the zone of OpenStack machines.
+		AvailabilityZone string `json:"availabilityZone"`
+	}{}
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return ""
+	}
+	switch {
+	case spec.Placement.AvailabilityZone != "":
+		return spec.Placement.AvailabilityZone
+	case spec.Zone != "":
+		return spec.Zone
+	}
+	return spec.AvailabilityZone
+}
+
+func totalReplicas(pool *hivev1.MachinePool) int32 {
+	if pool.Spec.Replicas == nil {
+		return 1
+	}
+	return int32(*pool.Spec.Replicas)
+}
+
+// pinnedReplicas returns the replicas the zone distribution of the MachinePool pins for each of the MachineSets, nil
+// for the MachineSets of zones which are not pinned. Nil is returned when the MachinePool has no zone distribution.
+func pinnedReplicas(pool *hivev1.MachinePool, machineSets []*machineapi.MachineSet, replicas func(hivev1.MachinePoolZoneReplicas) *int32) []*int32 {
+	if len(pool.Spec.ZoneDistribution) == 0 {
+		return nil
+	}
+	pinned := make([]*int32, len(machineSets))
+	for i, ms := range machineSets {
+		if zoneReplicas, ok := pool.Spec.ZoneDistribution[Zone(ms)]; ok {
+			pinned[i] = replicas(zoneReplicas)
+		}
+	}
+	return pinned
+}
+
+// spread returns the replicas of the MachineSet at the given index when the total replicas are spread across the
+// given number of MachineSets. MachineSets with pinned replicas get them, and the rest of the replicas are spread
+// evenly across the other MachineSets, with any remainder going to the first of them. Pinned may be nil when no
+// MachineSet is pinned.
+func spread(total int32, pinned []*int32, noOfMachineSets, machineSetIndex int) int32 {
+	if pinned != nil && pinned[machineSetIndex] != nil {
+		return *pinned[machineSetIndex]
+	}
+	remaining := total
+	var unpinned, position int32
+	for i := 0; i < noOfMachineSets; i++ {
+		if pinned != nil && pinned[i] != nil {
+			remaining -= *pinned[i]
+			continue
+		}
+		if i < machineSetIndex {
+			position++
+		}
+		unpinned++
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+	replicas := remaining / unpinned
+	if position < remaining%unpinned {
+		replicas++
+	}
+	return replicas
+}
+
+// Hash returns a stable hash of the name, labels, annotations and spec of the MachineSet. Two MachineSets with the
+// same hash would be synced to the cluster identically.
+func Hash(ms *machineapi.MachineSet) (string, error) {
+	// Maps are marshalled with sorted keys, so the encoding is stable.
+	b, err := json.Marshal(struct {
+		Namespace   string                    `json:"namespace"`
+		Name        string                    `json:"name"`
+		Labels      map[string]string         `json:"labels"`
+		Annotations map[string]string         `json:"annotations"`
+		Spec        machineapi.MachineSetSpec `json:"spec"`
+	}{
+		Namespace:   ms.Namespace,
+		Name:        ms.Name,
+		Labels:      ms.Labels,
+		Annotations: ms.Annotations,
+		Spec:        ms.Spec,
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
+
+// HasNodeConfig returns true if the MachinePool configures the machine config of its nodes.
+func HasNodeConfig(pool *hivev1.MachinePool) bool {
+	return pool.Spec.KubeletConfig != nil || len(pool.Spec.MachineConfigs) > 0
+}
+
+// UsesCustomMachineConfigPool returns true if the nodes of the MachinePool need a machine config pool of their own.
+// The worker MachinePool uses the worker machine config pool that exists in every cluster.
+func UsesCustomMachineConfigPool(pool *hivev1.MachinePool) bool {
+	return HasNodeConfig(pool) && pool.Spec.Name != workerRole
+}
+
+// NodeRoleLabel returns the label selecting the nodes of a machine config pool for the given role.
+func NodeRoleLabel(role string) string {
+	return fmt.Sprintf(nodeRoleLabelFmt, role)
+}
@@ -0,0 +1,366 @@
+package machinesetgen
+
+import (
+	"errors"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	machineapi "github.com/openshift/api/machine/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+type fakeGenerator struct {
+	names   []string
+	proceed bool
+	err     error
+}
+
+func (g *fakeGenerator) GenerateMachineSets(*hivev1.ClusterDeployment, *hivev1.MachinePool, log.FieldLogger) ([]*machineapi.MachineSet, bool, error) {
+	if g.err != nil || !g.proceed {
+		return nil, g.proceed, g.err
+	}
+	machineSets := make([]*machineapi.MachineSet, len(g.names))
+	for i, name := range g.names {
+		machineSets[i] = &machineapi.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "openshift-machine-api",
+			},
+			Spec: machineapi.MachineSetSpec{
+				Replicas: pointer.Int32Ptr(1),
+			},
+		}
+	}
+	return machineSets, true, nil
+}
+
+func TestGenerate(t *testing.T) {
+	cases := []struct {
+		name                   string
+		cd                     *hivev1.ClusterDeployment
+		pool                   *hivev1.MachinePool
+		defaults               *hivev1.MachinePoolDefaults
+		generator              *fakeGenerator
+		expectErr              bool
+		expectProceed          bool
+		expectedNames          []string
+		expectedReplicas       []int32
+		expectedRole           bool
+		expectedTemplateLabels map[string]string
+		expectedTaints         []corev1.Taint
+	}{
+		{
+			name:             "sorted by name",
+			pool:             testPool(),
+			generator:        &fakeGenerator{names: []string{"foo-worker-c", "foo-worker-a", "foo-worker-b"}, proceed: true},
+			expectProceed:    true,
+			expectedNames:    []string{"foo-worker-a", "foo-worker-b", "foo-worker-c"},
+			expectedReplicas: []int32{1, 1, 1},
+		},
+		{
+			name: "autoscaling replicas spread in name order",
+			pool: func() *hivev1.MachinePool {
+				p := testPool()
+				p.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 5, MaxReplicas: 10}
+				return p
+			}(),
+			generator:        &fakeGenerator{names: []string{"foo-worker-c", "foo-worker-a", "foo-worker-b"}, proceed: true},
+			expectProceed:    true,
+			expectedNames:    []string{"foo-worker-a", "foo-worker-b", "foo-worker-c"},
+			expectedReplicas: []int32{2, 2, 1},
+		},
+		{
+			name: "custom machine config pool",
+			pool: func() *hivev1.MachinePool {
+				p := testPool()
+				p.Spec.Name = "infra"
+				p.Spec.KubeletConfig = &runtime.RawExtension{Raw: []byte(`{"maxPods":500}`)}
+				return p
+			}(),
+			generator:        &fakeGenerator{names: []string{"foo-infra-a"}, proceed: true},
+			expectProceed:    true,
+			expectedNames:    []string{"foo-infra-a"},
+			expectedReplicas: []int32{1},
+			expectedRole:     true,
+		},
+		{
+			name: "propagated node labels",
+			cd: &hivev1.ClusterDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"environment": "prod", "tenant": "a", "foo": "cluster"},
+				},
+				Spec: hivev1.ClusterDeploymentSpec{
+					PropagatedNodeLabels: []string{"environment", "foo", "missing"},
+				},
+			},
+			pool:                   testPool(),
+			generator:              &fakeGenerator{names: []string{"foo-worker-a"}, proceed: true},
+			expectProceed:          true,
+			expectedNames:          []string{"foo-worker-a"},
+			expectedReplicas:       []int32{1},
+			expectedTemplateLabels: map[string]string{"environment": "prod", "foo": "bar"},
+		},
+		{
+			name: "defaults",
+			pool: testPool(),
+			defaults: &hivev1.MachinePoolDefaults{
+				Labels: map[string]string{"monitoring": "exempt", "foo": "default"},
+				Taints: []corev1.Taint{
+					{Key: "foo", Value: "default", Effect: corev1.TaintEffectNoSchedule},
+					{Key: "foo", Value: "default", Effect: corev1.TaintEffectNoExecute},
+					{Key: "dedicated", Value: "org", Effect: corev1.TaintEffectNoSchedule},
+				},
+			},
+			generator:              &fakeGenerator{names: []string{"foo-worker-a"}, proceed: true},
+			expectProceed:          true,
+			expectedNames:          []string{"foo-worker-a"},
+			expectedReplicas:       []int32{1},
+			expectedTemplateLabels: map[string]string{"monitoring": "exempt", "foo": "bar"},
+			expectedTaints: []corev1.Taint{
+				{Key: "foo", Value: "bar", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "foo", Value: "default", Effect: corev1.TaintEffectNoExecute},
+				{Key: "dedicated", Value: "org", Effect: corev1.TaintEffectNoSchedule},
+			},
+		},
+		{
+			name:      "not proceeding",
+			pool:      testPool(),
+			generator: &fakeGenerator{proceed: false},
+		},
+		{
+			name:      "generator error",
+			pool:      testPool(),
+			generator: &fakeGenerator{err: errors.New("boom")},
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := tc.cd
+			if cd == nil {
+				cd = &hivev1.ClusterDeployment{}
+			}
+			machineSets, proceed, err := Generate(tc.generator, cd, tc.pool, tc.defaults, log.StandardLogger())
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectProceed, proceed, "unexpected proceed")
+			require.Len(t, machineSets, len(tc.expectedNames), "unexpected number of machine sets")
+			for i, ms := range machineSets {
+				assert.Equal(t, tc.expectedNames[i], ms.Name, "unexpected machine set name")
+				assert.Equal(t, tc.expectedReplicas[i], *ms.Spec.Replicas, "unexpected replicas")
+				assert.Equal(t, tc.pool.Spec.Name, ms.Labels[MachinePoolNameLabel], "unexpected machine pool label")
+				assert.Equal(t, "true", ms.Labels[constants.HiveManagedLabel], "unexpected managed label")
+				assert.Equal(t, "3", ms.Annotations[constants.MachinePoolGenerationAnnotation], "unexpected generation annotation")
+				assert.Equal(t, "bar", ms.Spec.Template.Spec.ObjectMeta.Labels["foo"], "unexpected template label")
+				_, hasRole := ms.Spec.Template.Spec.ObjectMeta.Labels[NodeRoleLabel(tc.pool.Spec.Name)]
+				assert.Equal(t, tc.expectedRole, hasRole, "unexpected node role label")
+				if tc.expectedTemplateLabels != nil {
+					assert.Equal(t, tc.expectedTemplateLabels, ms.Spec.Template.Spec.ObjectMeta.Labels, "unexpected template labels")
+				}
+				expectedTaints := tc.pool.Spec.Taints
+				if tc.expectedTaints != nil {
+					expectedTaints = tc.expectedTaints
+				}
+				assert.Equal(t, expectedTaints, ms.Spec.Template.Spec.Taints, "unexpected taints")
+			}
+		})
+	}
+}
+
+func TestGenerateIdempotent(t *testing.T) {
+	pool := testPool()
+	pool.Spec.Labels["a"] = "b"
+	pool.Spec.Labels["c"] = "d"
+	first, _, err := Generate(&fakeGenerator{names: []string{"foo-worker-b", "foo-worker-a"}, proceed: true}, &hivev1.ClusterDeployment{}, pool, nil, log.StandardLogger())
+	require.NoError(t, err, "unexpected error")
+	second, _, err := Generate(&fakeGenerator{names: []string{"foo-worker-a", "foo-worker-b"}, proceed: true}, &hivev1.ClusterDeployment{}, pool, nil, log.StandardLogger())
+	require.NoError(t, err, "unexpected error")
+	require.Len(t, second, len(first), "unexpected number of machine sets")
+	for i := range first {
+		firstHash, err := Hash(first[i])
+		require.NoError(t, err, "unexpected error hashing")
+		secondHash, err := Hash(second[i])
+		require.NoError(t, err, "unexpected error hashing")
+		assert.Equal(t, firstHash, secondHash, "expected the same hash for %s", first[i].Name)
+	}
+	firstHash, _ := Hash(first[0])
+	secondHash, _ := Hash(first[1])
+	assert.NotEqual(t, firstHash, secondHash, "expected different machine sets to have different hashes")
+}
+
+func testPool() *hivev1.MachinePool {
+	return &hivev1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Generation: 3,
+		},
+		Spec: hivev1.MachinePoolSpec{
+			Name:   "worker",
+			Labels: map[string]string{"foo": "bar"},
+			Taints: []corev1.Taint{{Key: "foo", Value: "bar", Effect: corev1.TaintEffectNoSchedule}},
+		},
+	}
+}
+
+func TestZone(t *testing.T) {
+	cases := []struct {
+		name         string
+		providerSpec string
+		expectedZone string
+	}{
+		{name: "aws", providerSpec: `{"placement":{"availabilityZone":"us-east-1a","region":"us-east-1"}}`, expectedZone: "us-east-1a"},
+		{name: "gcp", providerSpec: `{"zone":"us-east1-b","region":"us-east1"}`, expectedZone: "us-east1-b"},
+		{name: "openstack", providerSpec: `{"availabilityZone":"nova"}`, expectedZone: "nova"},
+		{name: "no zone", providerSpec: `{"template":"rhcos"}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ms := zonedMachineSet("foo-worker", "")
+			ms.Spec.Template.Spec.ProviderSpec.Value = &runtime.RawExtension{Raw: []byte(tc.providerSpec)}
+			assert.Equal(t, tc.expectedZone, Zone(ms), "unexpected zone")
+		})
+	}
+	assert.Empty(t, Zone(&machineapi.MachineSet{}), "expected no zone without provider spec")
+}
+
+func TestZoneDistribution(t *testing.T) {
+	machineSets := []*machineapi.MachineSet{
+		zonedMachineSet("foo-worker-a", "a"),
+		zonedMachineSet("foo-worker-b", "b"),
+		zonedMachineSet("foo-worker-c", "c"),
+	}
+	cases := []struct {
+		name             string
+		replicas         *int64
+		autoscaling      *hivev1.MachinePoolAutoscaling
+		distribution     map[string]hivev1.MachinePoolZoneReplicas
+		expectedReplicas []int32
+		expectedMin      []int32
+		expectedMax      []int32
+		expectErr        bool
+	}{
+		{
+			name:             "no distribution",
+			replicas:         pointer.Int64Ptr(7),
+			expectedReplicas: []int32{3, 2, 2},
+		},
+		{
+			name:             "pinned zone",
+			replicas:         pointer.Int64Ptr(7),
+			distribution:     map[string]hivev1.MachinePoolZoneReplicas{"a": {Replicas: pointer.Int32Ptr(1)}},
+			expectedReplicas: []int32{1, 3, 3},
+		},
+		{
+			name:     "every zone pinned",
+			replicas: pointer.Int64Ptr(4),
+			distribution: map[string]hivev1.MachinePoolZoneReplicas{
+				"a": {Replicas: pointer.Int32Ptr(0)},
+				"b": {Replicas: pointer.Int32Ptr(3)},
+				"c": {Replicas: pointer.Int32Ptr(1)},
+			},
+			expectedReplicas: []int32{0, 3, 1},
+		},
+		{
+			name:         "autoscaling",
+			autoscaling:  &hivev1.MachinePoolAutoscaling{MinReplicas: 4, MaxReplicas: 10},
+			distribution: map[string]hivev1.MachinePoolZoneReplicas{"b": {MinReplicas: pointer.Int32Ptr(0), MaxReplicas: pointer.Int32Ptr(2)}},
+			expectedMin:  []int32{2, 0, 2},
+			expectedMax:  []int32{4, 2, 4},
+		},
+		{
+			name:         "unknown zone",
+			replicas:     pointer.Int64Ptr(3),
+			distribution: map[string]hivev1.MachinePoolZoneReplicas{"d": {Replicas: pointer.Int32Ptr(1)}},
+			expectErr:    true,
+		},
+		{
+			name:         "too many pinned replicas",
+			replicas:     pointer.Int64Ptr(3),
+			distribution: map[string]hivev1.MachinePoolZoneReplicas{"a": {Replicas: pointer.Int32Ptr(4)}},
+			expectErr:    true,
+		},
+		{
+			name:         "too many pinned maximum replicas",
+			autoscaling:  &hivev1.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 6},
+			distribution: map[string]hivev1.MachinePoolZoneReplicas{"a": {MinReplicas: pointer.Int32Ptr(1), MaxReplicas: pointer.Int32Ptr(7)}},
+			expectErr:    true,
+		},
+		{
+			name:     "every zone pinned with too few replicas",
+			replicas: pointer.Int64Ptr(5),
+			distribution: map[string]hivev1.MachinePoolZoneReplicas{
+				"a": {Replicas: pointer.Int32Ptr(1)},
+				"b": {Replicas: pointer.Int32Ptr(1)},
+				"c": {Replicas: pointer.Int32Ptr(1)},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := testPool()
+			pool.Spec.Replicas = tc.replicas
+			pool.Spec.Autoscaling = tc.autoscaling
+			pool.Spec.ZoneDistribution = tc.distribution
+
+			err := ValidateZoneDistribution(pool, machineSets)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			for i := range machineSets {
+				if tc.autoscaling == nil {
+					assert.Equal(t, tc.expectedReplicas[i], Replicas(pool, machineSets, i), "unexpected replicas of %s", machineSets[i].Name)
+					continue
+				}
+				min, max := MinMaxReplicas(pool, machineSets, i)
+				assert.Equal(t, tc.expectedMin[i], min, "unexpected min replicas of %s", machineSets[i].Name)
+				assert.Equal(t, tc.expectedMax[i], max, "unexpected max replicas of %s", machineSets[i].Name)
+			}
+		})
+	}
+}
+
+func TestDecorateZoneDistribution(t *testing.T) {
+	pool := testPool()
+	pool.Spec.Replicas = pointer.Int64Ptr(5)
+	pool.Spec.ZoneDistribution = map[string]hivev1.MachinePoolZoneReplicas{"b": {Replicas: pointer.Int32Ptr(3)}}
+	machineSets := []*machineapi.MachineSet{
+		zonedMachineSet("foo-worker-a", "a"),
+		zonedMachineSet("foo-worker-b", "b"),
+	}
+	Decorate(&hivev1.ClusterDeployment{}, pool, nil, machineSets)
+	assert.Equal(t, int32(2), *machineSets[0].Spec.Replicas, "unexpected replicas of unpinned zone")
+	assert.Equal(t, int32(3), *machineSets[1].Spec.Replicas, "unexpected replicas of pinned zone")
+}
+
+func zonedMachineSet(name, zone string) *machineapi.MachineSet {
+	return &machineapi.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "openshift-machine-api",
+		},
+		Spec: machineapi.MachineSetSpec{
+			Replicas: pointer.Int32Ptr(1),
+			Template: machineapi.MachineTemplateSpec{
+				Spec: machineapi.MachineSpec{
+					ProviderSpec: machineapi.ProviderSpec{
+						Value: &runtime.RawExtension{Raw: []byte(`{"placement":{"availabilityZone":"` + zone + `"}}`)},
+					},
+				},
+			},
+		},
+	}
+}
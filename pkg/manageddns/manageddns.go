@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
@@ -29,3 +30,27 @@ func ReadManagedDomainsFile() ([]hivev1.ManageDNSConfig, error) {
 
 	return domains, nil
 }
+
+// FindManagedDomain returns the managed domain of which the given base domain is a direct child, or nil if there is
+// none.
+func FindManagedDomain(domains []hivev1.ManageDNSConfig, baseDomain string) *hivev1.ManageDNSConfig {
+	for i, md := range domains {
+		for _, domain := range md.Domains {
+			childPart := strings.TrimSuffix(baseDomain, "."+domain)
+			if childPart != baseDomain && childPart != "" && !strings.ContainsRune(childPart, '.') {
+				return &domains[i]
+			}
+		}
+	}
+	return nil
+}
+
+// FindChildZonesManagedDomain returns the managed domain of which the given base domain is a direct child, when the
+// managed domain configures the DNS provider of its child zones, or nil otherwise.
+func FindChildZonesManagedDomain(domains []hivev1.ManageDNSConfig, baseDomain string) *hivev1.ManageDNSConfig {
+	managedDomain := FindManagedDomain(domains, baseDomain)
+	if managedDomain == nil || managedDomain.ChildZones == nil {
+		return nil
+	}
+	return managedDomain
+}
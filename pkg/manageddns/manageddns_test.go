@@ -0,0 +1,58 @@
+package manageddns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestFindManagedDomain(t *testing.T) {
+	domains := []hivev1.ManageDNSConfig{
+		{Domains: []string{"aws.example.com", "aws.example.org"}, AWS: &hivev1.ManageDNSAWSConfig{}},
+		{Domains: []string{"gcp.example.com"}, GCP: &hivev1.ManageDNSGCPConfig{}},
+	}
+	cases := []struct {
+		name       string
+		baseDomain string
+		expected   *hivev1.ManageDNSConfig
+	}{
+		{
+			name:       "child of first domain",
+			baseDomain: "mycluster.aws.example.com",
+			expected:   &domains[0],
+		},
+		{
+			name:       "child of other domain of the same config",
+			baseDomain: "mycluster.aws.example.org",
+			expected:   &domains[0],
+		},
+		{
+			name:       "child of second config",
+			baseDomain: "mycluster.gcp.example.com",
+			expected:   &domains[1],
+		},
+		{
+			name:       "managed domain itself",
+			baseDomain: "aws.example.com",
+		},
+		{
+			name:       "grandchild",
+			baseDomain: "a.mycluster.aws.example.com",
+		},
+		{
+			name:       "suffix without dot",
+			baseDomain: "myclusteraws.example.com",
+		},
+		{
+			name:       "unmanaged",
+			baseDomain: "mycluster.example.net",
+		},
+	}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Same(t, test.expected, FindManagedDomain(domains, test.baseDomain))
+		})
+	}
+}
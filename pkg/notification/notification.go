@@ -0,0 +1,193 @@
+// Package notification sends ClusterDeployment lifecycle events to the notification sinks configured in
+// HiveConfig.Spec.Notifications.
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const sendTimeout = 30 * time.Second
+
+var (
+	metricNotificationsSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_notifications_sent_total",
+		Help: "Counter incremented every time a notification of a cluster deployment lifecycle event is sent to a sink.",
+	}, []string{"sink", "event", "result"})
+
+	loadOnce        sync.Once
+	defaultNotifier *notifier
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricNotificationsSent)
+}
+
+// Event is a lifecycle event of a ClusterDeployment. It is the payload POSTed to Generic sinks.
+type Event struct {
+	Type              hivev1.NotificationEventType `json:"type"`
+	ClusterDeployment string                       `json:"clusterDeployment"`
+	Namespace         string                       `json:"namespace"`
+	InfraID           string                       `json:"infraID,omitempty"`
+	ClusterPool       string                       `json:"clusterPool,omitempty"`
+	ClusterClaim      string                       `json:"clusterClaim,omitempty"`
+	Message           string                       `json:"message,omitempty"`
+	Time              metav1.Time                  `json:"time"`
+}
+
+// NewEvent returns an event of the given type for the ClusterDeployment.
+func NewEvent(eventType hivev1.NotificationEventType, cd *hivev1.ClusterDeployment, message string) Event {
+	event := Event{
+		Type:              eventType,
+		ClusterDeployment: cd.Name,
+		Namespace:         cd.Namespace,
+		Message:           message,
+		Time:              metav1.Now(),
+	}
+	if cd.Spec.ClusterMetadata != nil {
+		event.InfraID = cd.Spec.ClusterMetadata.InfraID
+	}
+	if poolRef := cd.Spec.ClusterPoolRef; poolRef != nil {
+		event.ClusterPool = poolRef.PoolName
+		event.ClusterClaim = poolRef.ClaimName
+	}
+	return event
+}
+
+// slackMessage is the payload POSTed to Slack sinks.
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// Notify sends the event to the configured sinks which subscribe to its type. Notifications are sent in the
+// background, so that controllers are not held up by slow sinks; failures are logged and counted in metrics.
+func Notify(event Event, logger log.FieldLogger) {
+	n := getNotifier(logger)
+	for _, sink := range n.sinksFor(event.Type) {
+		go n.send(sink, event, logger)
+	}
+}
+
+type notifier struct {
+	sinks      []hivev1.NotificationSink
+	httpClient *http.Client
+}
+
+func getNotifier(logger log.FieldLogger) *notifier {
+	loadOnce.Do(func() {
+		defaultNotifier = &notifier{httpClient: &http.Client{Timeout: sendTimeout}}
+		config, err := readNotificationsConfig()
+		if err != nil {
+			logger.WithError(err).Error("could not read notifications config, notifications are disabled")
+			return
+		}
+		if config != nil {
+			defaultNotifier.sinks = config.Sinks
+		}
+	})
+	return defaultNotifier
+}
+
+// sinksFor returns the sinks which subscribe to the event type.
+func (n *notifier) sinksFor(eventType hivev1.NotificationEventType) []hivev1.NotificationSink {
+	var sinks []hivev1.NotificationSink
+	for _, sink := range n.sinks {
+		if len(sink.Events) == 0 {
+			sinks = append(sinks, sink)
+			continue
+		}
+		for _, t := range sink.Events {
+			if t == eventType {
+				sinks = append(sinks, sink)
+				break
+			}
+		}
+	}
+	return sinks
+}
+
+func (n *notifier) send(sink hivev1.NotificationSink, event Event, logger log.FieldLogger) error {
+	sinkLog := logger.WithField("sink", sink.Name).WithField("event", event.Type)
+	err := n.post(sink, event)
+	result := "succeeded"
+	if err != nil {
+		result = "failed"
+		sinkLog.WithError(err).Error("failed to send notification")
+	} else {
+		sinkLog.Debug("sent notification")
+	}
+	metricNotificationsSent.WithLabelValues(sink.Name, string(event.Type), result).Inc()
+	return err
+}
+
+func (n *notifier) post(sink hivev1.NotificationSink, event Event) error {
+	var payload interface{} = event
+	if sink.Format == hivev1.SlackNotificationSinkFormat {
+		payload = slackMessage{Text: slackText(event)}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal notification")
+	}
+	resp, err := n.httpClient.Post(sink.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to post notification")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sink responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func slackText(event Event) string {
+	text := fmt.Sprintf("ClusterDeployment %s/%s: %s", event.Namespace, event.ClusterDeployment, event.Type)
+	if event.ClusterClaim != "" {
+		text += fmt.Sprintf(" (ClusterPool %s, ClusterClaim %s)", event.ClusterPool, event.ClusterClaim)
+	}
+	if event.Message != "" {
+		text += ": " + event.Message
+	}
+	return text
+}
+
+// readNotificationsConfig reads the notifications configuration from the file pointed to by the
+// NotificationsConfigFileEnvVar environment variable. A nil configuration is returned when notifications
+// are not configured.
+func readNotificationsConfig() (*hivev1.NotificationsConfig, error) {
+	path := os.Getenv(constants.NotificationsConfigFileEnvVar)
+	if len(path) == 0 {
+		return nil, nil
+	}
+	fileBytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the notifications config file")
+	}
+	if len(fileBytes) == 0 {
+		return nil, nil
+	}
+	config := &hivev1.NotificationsConfig{}
+	if err := json.Unmarshal(fileBytes, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the notifications config")
+	}
+	return config, nil
+}
@@ -0,0 +1,153 @@
+package notification
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+const (
+	testNamespace = "test-namespace"
+	testName      = "test-cluster"
+)
+
+func TestSinksFor(t *testing.T) {
+	n := &notifier{sinks: []hivev1.NotificationSink{
+		{Name: "all"},
+		{Name: "provisions", Events: []hivev1.NotificationEventType{
+			hivev1.ProvisionSucceededNotificationEvent,
+			hivev1.ProvisionFailedNotificationEvent,
+		}},
+		{Name: "claims", Events: []hivev1.NotificationEventType{hivev1.ClaimAssignedNotificationEvent}},
+	}}
+	cases := []struct {
+		eventType     hivev1.NotificationEventType
+		expectedSinks []string
+	}{
+		{
+			eventType:     hivev1.ProvisionFailedNotificationEvent,
+			expectedSinks: []string{"all", "provisions"},
+		},
+		{
+			eventType:     hivev1.ClaimAssignedNotificationEvent,
+			expectedSinks: []string{"all", "claims"},
+		},
+		{
+			eventType:     hivev1.HibernatedNotificationEvent,
+			expectedSinks: []string{"all"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.eventType), func(t *testing.T) {
+			var actual []string
+			for _, sink := range n.sinksFor(tc.eventType) {
+				actual = append(actual, sink.Name)
+			}
+			assert.Equal(t, tc.expectedSinks, actual, "unexpected sinks")
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	cd := testcd.BasicBuilder().Options(
+		testcd.WithName(testName),
+		testcd.WithNamespace(testNamespace),
+		testcd.WithClusterPoolReference(testNamespace, "test-pool", "test-claim"),
+	).Build()
+	event := NewEvent(hivev1.ClaimAssignedNotificationEvent, cd, "Assigned to ClusterClaim test-claim")
+
+	cases := []struct {
+		name            string
+		format          hivev1.NotificationSinkFormat
+		status          int
+		expectErr       bool
+		validatePayload func(t *testing.T, body []byte)
+	}{
+		{
+			name:   "generic",
+			status: http.StatusOK,
+			validatePayload: func(t *testing.T, body []byte) {
+				actual := Event{}
+				require.NoError(t, json.Unmarshal(body, &actual), "unexpected error unmarshalling event")
+				assert.Equal(t, hivev1.ClaimAssignedNotificationEvent, actual.Type, "unexpected event type")
+				assert.Equal(t, testName, actual.ClusterDeployment, "unexpected cluster deployment")
+				assert.Equal(t, testNamespace, actual.Namespace, "unexpected namespace")
+				assert.Equal(t, "test-pool", actual.ClusterPool, "unexpected cluster pool")
+				assert.Equal(t, "test-claim", actual.ClusterClaim, "unexpected cluster claim")
+			},
+		},
+		{
+			name:   "slack",
+			format: hivev1.SlackNotificationSinkFormat,
+			status: http.StatusOK,
+			validatePayload: func(t *testing.T, body []byte) {
+				actual := slackMessage{}
+				require.NoError(t, json.Unmarshal(body, &actual), "unexpected error unmarshalling slack message")
+				assert.Equal(t,
+					"ClusterDeployment test-namespace/test-cluster: ClaimAssigned (ClusterPool test-pool, ClusterClaim test-claim): Assigned to ClusterClaim test-claim",
+					actual.Text, "unexpected slack message")
+			},
+		},
+		{
+			name:      "sink error",
+			status:    http.StatusServiceUnavailable,
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, http.MethodPost, req.Method, "unexpected method")
+				body, _ = ioutil.ReadAll(req.Body)
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+			n := &notifier{httpClient: server.Client()}
+
+			err := n.send(hivev1.NotificationSink{Name: "test", URL: server.URL, Format: tc.format}, event, log.StandardLogger())
+			if tc.expectErr {
+				assert.Error(t, err, "expected error sending notification")
+				return
+			}
+			require.NoError(t, err, "unexpected error sending notification")
+			if tc.validatePayload != nil {
+				tc.validatePayload(t, body)
+			}
+		})
+	}
+}
+
+func TestReadNotificationsConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "notifications")
+	require.NoError(t, err, "unexpected error creating temp dir")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "config")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"sinks":[{"name":"ops","url":"https://example.com","format":"Slack"}]}`), 0600),
+		"unexpected error writing config")
+
+	os.Setenv(constants.NotificationsConfigFileEnvVar, path)
+	defer os.Unsetenv(constants.NotificationsConfigFileEnvVar)
+	config, err := readNotificationsConfig()
+	require.NoError(t, err, "unexpected error reading config")
+	require.NotNil(t, config, "expected config")
+	assert.Equal(t, []hivev1.NotificationSink{{Name: "ops", URL: "https://example.com", Format: hivev1.SlackNotificationSinkFormat}}, config.Sinks,
+		"unexpected sinks")
+
+	os.Setenv(constants.NotificationsConfigFileEnvVar, filepath.Join(dir, "missing"))
+	config, err = readNotificationsConfig()
+	require.NoError(t, err, "unexpected error reading missing config")
+	assert.Nil(t, config, "expected no config")
+}
@@ -3,6 +3,7 @@
 // config/clustersync/service.yaml
 // config/clustersync/statefulset.yaml
 // config/hiveadmission/apiservice.yaml
+// config/hiveadmission/clusteraccesstoken-webhook.yaml
 // config/hiveadmission/clusterdeployment-webhook.yaml
 // config/hiveadmission/clusterimageset-webhook.yaml
 // config/hiveadmission/clusterprovision-webhook.yaml
@@ -10,6 +11,7 @@
 // config/hiveadmission/dnszones-webhook.yaml
 // config/hiveadmission/hiveadmission_rbac_role.yaml
 // config/hiveadmission/hiveadmission_rbac_role_binding.yaml
+// config/hiveadmission/hostinventory-webhook.yaml
 // config/hiveadmission/machinepool-webhook.yaml
 // config/hiveadmission/selectorsyncset-webhook.yaml
 // config/hiveadmission/service-account.yaml
@@ -256,6 +258,50 @@ func configHiveadmissionApiserviceYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionClusteraccesstokenWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: clusteraccesstokenvalidators.admission.hive.openshift.io
+webhooks:
+- name: clusteraccesstokenvalidators.admission.hive.openshift.io
+  admissionReviewVersions:
+  - v1beta1
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusteraccesstokenvalidators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusteraccesstokens
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionClusteraccesstokenWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusteraccesstokenWebhookYaml, nil
+}
+
+func configHiveadmissionClusteraccesstokenWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusteraccesstokenWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusteraccesstoken-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionClusterdeploymentWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1
 kind: ValidatingWebhookConfiguration
@@ -431,6 +477,8 @@ spec:
         ports:
         - containerPort: 9443
           protocol: TCP
+        - containerPort: 9444
+          protocol: TCP
         envFrom:
         - configMapRef:
             name: hive-feature-gates
@@ -523,6 +571,16 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - hive.openshift.io
+  resources:
+  - clusterdeployments
+  - machinepools
+  - tenantquotas
+  verbs:
+  - get
+  - list
+  - watch
 - apiGroups:
   - ""
   resources:
@@ -601,6 +659,50 @@ func configHiveadmissionHiveadmission_rbac_role_bindingYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionHostinventoryWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: hostinventoryvalidators.admission.hive.openshift.io
+webhooks:
+- name: hostinventoryvalidators.admission.hive.openshift.io
+  admissionReviewVersions:
+  - v1beta1
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/hostinventoryvalidators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - hostinventories
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionHostinventoryWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionHostinventoryWebhookYaml, nil
+}
+
+func configHiveadmissionHostinventoryWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionHostinventoryWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/hostinventory-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionMachinepoolWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1
 kind: ValidatingWebhookConfiguration
@@ -724,9 +826,14 @@ spec:
   selector:
     app: hiveadmission
   ports:
-  - port: 443
+  - name: https
+    port: 443
     targetPort: 9443
     protocol: TCP
+  - name: conversion
+    port: 9444
+    targetPort: 9444
+    protocol: TCP
 `)
 
 func configHiveadmissionServiceYamlBytes() ([]byte, error) {
@@ -889,6 +996,31 @@ rules:
   - "*"
   verbs:
   - "*"
+- apiGroups:
+  - agent-install.openshift.io
+  resources:
+  - nmstateconfigs
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - patch
+  - delete
+- apiGroups:
+  - hypershift.openshift.io
+  resources:
+  - hostedclusters
+  - nodepools
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - patch
+  - delete
 - apiGroups:
   - batch
   resources:
@@ -935,6 +1067,12 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - ""
+  resources:
+  - pods
+  verbs:
+  - delete
 - apiGroups:
   - rbac.authorization.k8s.io
   resources:
@@ -948,6 +1086,18 @@ rules:
   - update
   - patch
   - delete
+- apiGroups:
+  - cert-manager.io
+  resources:
+  - certificates
+  verbs:
+  - get
+  - list
+  - watch
+  - create
+  - update
+  - patch
+  - delete
 - apiGroups:
   - velero.io
   resources:
@@ -1101,6 +1251,9 @@ rules:
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
+  - clusteraccesstokens
+  - hostedclusterinstalls
+  - hostinventories
   verbs:
   - get
   - list
@@ -1112,6 +1265,7 @@ rules:
   - hiveconfigs
   - selectorsyncsets
   - selectorsyncidentityproviders
+  - tenantquotas
   verbs:
   - get
   - list
@@ -1252,6 +1406,7 @@ rules:
   - clusterdeployments
   - clusterprovisions
   - clusterdeprovisions
+  - hostedclusterinstalls
   verbs:
   - get
   - list
@@ -1478,10 +1633,14 @@ rules:
   - syncidentityproviders
   - syncsets
   - syncsetinstances
+  - tenantquotas
+  - clusteraccesstokens
   - clusterdeprovisions
   # TODO: remove once v1alpha1 compat removed
   - clusterdeprovisionrequests
   - clusterstates
+  - hostedclusterinstalls
+  - hostinventories
   verbs:
   - get
   - list
@@ -1599,6 +1758,11 @@ data:
       - "EIP: AddressLimitExceeded"
       installFailingReason: EIPAddressLimitExceeded
       installFailingMessage: EIP Address limit exceeded
+    - name: UserProvidedNetworkInvalid
+      searchRegexStrings:
+      - "user-provided network validation failed"
+      installFailingReason: UserProvidedNetworkInvalid
+      installFailingMessage: The existing VPC or subnets provided for the install are not usable
     - name: MissingPublicSubnetForZone
       searchRegexStrings:
       - "No public subnet provided for zone"
@@ -2046,6 +2210,7 @@ var _bindata = map[string]func() (*asset, error){
 	"config/clustersync/service.yaml":                           configClustersyncServiceYaml,
 	"config/clustersync/statefulset.yaml":                       configClustersyncStatefulsetYaml,
 	"config/hiveadmission/apiservice.yaml":                      configHiveadmissionApiserviceYaml,
+	"config/hiveadmission/clusteraccesstoken-webhook.yaml":      configHiveadmissionClusteraccesstokenWebhookYaml,
 	"config/hiveadmission/clusterdeployment-webhook.yaml":       configHiveadmissionClusterdeploymentWebhookYaml,
 	"config/hiveadmission/clusterimageset-webhook.yaml":         configHiveadmissionClusterimagesetWebhookYaml,
 	"config/hiveadmission/clusterprovision-webhook.yaml":        configHiveadmissionClusterprovisionWebhookYaml,
@@ -2053,6 +2218,7 @@ var _bindata = map[string]func() (*asset, error){
 	"config/hiveadmission/dnszones-webhook.yaml":                configHiveadmissionDnszonesWebhookYaml,
 	"config/hiveadmission/hiveadmission_rbac_role.yaml":         configHiveadmissionHiveadmission_rbac_roleYaml,
 	"config/hiveadmission/hiveadmission_rbac_role_binding.yaml": configHiveadmissionHiveadmission_rbac_role_bindingYaml,
+	"config/hiveadmission/hostinventory-webhook.yaml":           configHiveadmissionHostinventoryWebhookYaml,
 	"config/hiveadmission/machinepool-webhook.yaml":             configHiveadmissionMachinepoolWebhookYaml,
 	"config/hiveadmission/selectorsyncset-webhook.yaml":         configHiveadmissionSelectorsyncsetWebhookYaml,
 	"config/hiveadmission/service-account.yaml":                 configHiveadmissionServiceAccountYaml,
@@ -2136,6 +2302,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		}},
 		"hiveadmission": {nil, map[string]*bintree{
 			"apiservice.yaml":                      {configHiveadmissionApiserviceYaml, map[string]*bintree{}},
+			"clusteraccesstoken-webhook.yaml":      {configHiveadmissionClusteraccesstokenWebhookYaml, map[string]*bintree{}},
 			"clusterdeployment-webhook.yaml":       {configHiveadmissionClusterdeploymentWebhookYaml, map[string]*bintree{}},
 			"clusterimageset-webhook.yaml":         {configHiveadmissionClusterimagesetWebhookYaml, map[string]*bintree{}},
 			"clusterprovision-webhook.yaml":        {configHiveadmissionClusterprovisionWebhookYaml, map[string]*bintree{}},
@@ -2143,6 +2310,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 			"dnszones-webhook.yaml":                {configHiveadmissionDnszonesWebhookYaml, map[string]*bintree{}},
 			"hiveadmission_rbac_role.yaml":         {configHiveadmissionHiveadmission_rbac_roleYaml, map[string]*bintree{}},
 			"hiveadmission_rbac_role_binding.yaml": {configHiveadmissionHiveadmission_rbac_role_bindingYaml, map[string]*bintree{}},
+			"hostinventory-webhook.yaml":           {configHiveadmissionHostinventoryWebhookYaml, map[string]*bintree{}},
 			"machinepool-webhook.yaml":             {configHiveadmissionMachinepoolWebhookYaml, map[string]*bintree{}},
 			"selectorsyncset-webhook.yaml":         {configHiveadmissionSelectorsyncsetWebhookYaml, map[string]*bintree{}},
 			"service-account.yaml":                 {configHiveadmissionServiceAccountYaml, map[string]*bintree{}},
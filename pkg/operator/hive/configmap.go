@@ -104,6 +104,116 @@ var failedProvisionConfigMapInfo = configMapInfo{
 	},
 }
 
+var costEstimationConfigMapInfo = configMapInfo{
+	name:                 "hive-cost-estimation-config",
+	nameKey:              "hive-cost-estimation-config",
+	mountPath:            "/data/cost-estimation-config",
+	envVar:               constants.CostEstimationConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return instance.Spec.CostEstimation, nil
+	},
+}
+
+var notificationsConfigMapInfo = configMapInfo{
+	name:                 "hive-notifications-config",
+	nameKey:              "hive-notifications-config",
+	mountPath:            "/data/notifications-config",
+	envVar:               constants.NotificationsConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return instance.Spec.Notifications, nil
+	},
+}
+
+var externalSecretsConfigMapInfo = configMapInfo{
+	name:                 "hive-external-secrets-config",
+	nameKey:              "hive-external-secrets-config",
+	mountPath:            "/data/external-secrets-config",
+	envVar:               constants.ExternalSecretsConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return instance.Spec.ExternalSecrets, nil
+	},
+}
+
+var credentialsBrokerConfigMapInfo = configMapInfo{
+	name:                 "hive-credentials-broker-config",
+	nameKey:              "hive-credentials-broker-config",
+	mountPath:            "/data/credentials-broker-config",
+	envVar:               constants.CredentialsBrokerConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return instance.Spec.CredentialsBroker, nil
+	},
+}
+
+var installManagerImageOverridesConfigMapInfo = configMapInfo{
+	name:                 "hive-install-manager-image-overrides",
+	nameKey:              "hive-install-manager-image-overrides",
+	mountPath:            "/data/install-manager-image-overrides",
+	envVar:               constants.InstallManagerImageOverridesFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return &instance.Spec.InstallManagerImageOverrides, nil
+	},
+}
+
+var executionClusterConfigMapInfo = configMapInfo{
+	name:                 "hive-execution-cluster-config",
+	nameKey:              "hive-execution-cluster-config",
+	mountPath:            "/data/execution-cluster-config",
+	envVar:               constants.ExecutionClusterConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return instance.Spec.ExecutionCluster, nil
+	},
+}
+
+var logArchiveConfigMapInfo = configMapInfo{
+	name:                 "hive-log-archive-config",
+	nameKey:              "hive-log-archive-config",
+	mountPath:            "/data/log-archive-config",
+	envVar:               constants.LogArchiveConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return instance.Spec.LogArchive, nil
+	},
+}
+
+var sensitiveArtifactsConfigMapInfo = configMapInfo{
+	name:                 "hive-sensitive-artifacts-config",
+	nameKey:              "hive-sensitive-artifacts-config",
+	mountPath:            "/data/sensitive-artifacts-config",
+	envVar:               constants.SensitiveArtifactsConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return instance.Spec.SensitiveArtifacts, nil
+	},
+}
+
+var adminSecretEncryptionConfigMapInfo = configMapInfo{
+	name:                 "hive-admin-secret-encryption-config",
+	nameKey:              "hive-admin-secret-encryption-config",
+	mountPath:            "/data/admin-secret-encryption-config",
+	envVar:               constants.AdminSecretEncryptionConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return instance.Spec.AdminSecretEncryption, nil
+	},
+}
+
+var machinePoolDefaultsConfigMapInfo = configMapInfo{
+	name:                 "hive-machine-pool-defaults-config",
+	nameKey:              "hive-machine-pool-defaults-config",
+	mountPath:            "/data/machine-pool-defaults-config",
+	envVar:               constants.MachinePoolDefaultsConfigFileEnvVar,
+	volumeSourceOptional: true,
+	getData: func(instance *hivev1.HiveConfig) (interface{}, error) {
+		return instance.Spec.MachinePoolDefaults, nil
+	},
+}
+
 func (r *ReconcileHiveConfig) supportedContractsConfigMapInfo() configMapInfo {
 	f := func(instance *hivev1.HiveConfig) (interface{}, error) {
 		supported := map[string][]contracts.ContractImplementation{}
@@ -214,6 +324,12 @@ func setHiveControllersConfig(config *hivev1.ControllerConfig, cmData *map[strin
 	if config.QueueBurst != nil {
 		(*cmData)[fmt.Sprintf(utils.QueueBurstEnvVariableFormat, controllerName)] = strconv.Itoa(int(*config.QueueBurst))
 	}
+	if config.StartupRampDuration != nil {
+		(*cmData)[fmt.Sprintf(utils.StartupRampDurationEnvVariableFormat, controllerName)] = config.StartupRampDuration.Duration.String()
+	}
+	if config.UnreachableConcurrentReconciles != nil {
+		(*cmData)[fmt.Sprintf(utils.UnreachableConcurrentReconcilesEnvVariableFormat, controllerName)] = strconv.Itoa(int(*config.UnreachableConcurrentReconciles))
+	}
 }
 
 var featureGatesConfigMapInfo = configMapInfo{
@@ -237,10 +353,13 @@ var featureGatesConfigMapInfo = configMapInfo{
 
 // deployConfigMap deploys a configmap into hive's target namespace for use by one or more controllers.
 // cmInfo is a configMapInfo containing the string constants to be used for the configmap's name
-//   and the key therein that will correspond to the base filename when the configmap is mounted
-//   into the controller's container; and the data to be stored in the configmap.
+//
+//	and the key therein that will correspond to the base filename when the configmap is mounted
+//	into the controller's container; and the data to be stored in the configmap.
+//
 // namespacesToClean is a list of strings indicating former target namespaces from which this configmap
-//   is to be deleted.
+//
+//	is to be deleted.
 func (r *ReconcileHiveConfig) deployConfigMap(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, cmInfo configMapInfo, namespacesToClean []string) (string, error) {
 	cmLog := hLog.WithField("configMap.name", cmInfo.name)
 
@@ -70,3 +70,15 @@ func (r *ReconcileHiveConfig) Get(ctx context.Context, nsName types.NamespacedNa
 	}
 	return nil
 }
+
+func (r *ReconcileHiveConfig) Patch(ctx context.Context, nsName types.NamespacedName, obj runtime.Object, patchType types.PatchType, data []byte) error {
+	client := r.clientFor(obj, nsName.Namespace)
+	usObj, err := client.Patch(ctx, nsName.Name, patchType, data, metav1.PatchOptions{})
+	if err != nil {
+		return err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(usObj.UnstructuredContent(), obj); err != nil {
+		return errors.Wrapf(err, "error converting unstructured to %T for %v", obj, nsName)
+	}
+	return nil
+}
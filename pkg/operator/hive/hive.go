@@ -144,6 +144,16 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 	addConfigVolume(&hiveDeployment.Spec.Template.Spec, managedDomainsConfigMapInfo, hiveContainer)
 	addConfigVolume(&hiveDeployment.Spec.Template.Spec, awsPrivateLinkConfigMapInfo, hiveContainer)
 	addConfigVolume(&hiveDeployment.Spec.Template.Spec, failedProvisionConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, costEstimationConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, notificationsConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, externalSecretsConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, credentialsBrokerConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, installManagerImageOverridesConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, executionClusterConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, logArchiveConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, sensitiveArtifactsConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, adminSecretEncryptionConfigMapInfo, hiveContainer)
+	addConfigVolume(&hiveDeployment.Spec.Template.Spec, machinePoolDefaultsConfigMapInfo, hiveContainer)
 
 	// This triggers the clusterdeployment controller to copy the secret into the CD's namespace.
 	// It would be neat if it did that purely based on the FailedProvisionConfig ConfigMap, to
@@ -191,6 +201,72 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		}
 	}
 
+	if instance.Spec.Backup.RepairAfterRestore {
+		hLog.Info("repair after restore enabled")
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.RepairAfterRestoreEnvVar,
+			Value: "true",
+		})
+	}
+
+	if gc := instance.Spec.GarbageCollection; gc != nil {
+		hLog.Info("garbage collection enabled")
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.GarbageCollectionEnvVar,
+			Value: "true",
+		})
+		if gc.DryRun {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  hiveconstants.GarbageCollectionDryRunEnvVar,
+				Value: "true",
+			})
+		}
+		if gc.Interval != nil {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  hiveconstants.GarbageCollectionIntervalEnvVar,
+				Value: gc.Interval.Duration.String(),
+			})
+		}
+	}
+
+	if ss := instance.Spec.SpokeStatus; ss != nil {
+		hLog.Info("spoke status enabled")
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.SpokeStatusHubNameEnvVar,
+			Value: ss.HubName,
+		})
+	}
+
+	addClusterAccessTokenAllowedClusterRolesEnv(hiveContainer, instance)
+
+	if timeout := instance.Spec.DeletionBlockedTimeout; timeout != nil {
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  hiveconstants.DeletionBlockedTimeoutEnvVar,
+			Value: timeout.Duration.String(),
+		})
+	}
+
+	if awsClient := instance.Spec.AWSClient; awsClient != nil {
+		if awsClient.RetryMode != "" {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  hiveconstants.AWSRetryModeEnvVar,
+				Value: string(awsClient.RetryMode),
+			})
+		}
+		if awsClient.MaxAttempts != nil {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  hiveconstants.AWSMaxAttemptsEnvVar,
+				Value: strconv.Itoa(*awsClient.MaxAttempts),
+			})
+		}
+		if awsClient.CallTimeout != nil {
+			hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+				Name:  hiveconstants.AWSCallTimeoutEnvVar,
+				Value: awsClient.CallTimeout.Duration.String(),
+			})
+		}
+	}
+
 	if instance.Spec.ArgoCD.Enabled {
 		hLog.Infof("ArgoCD integration enabled")
 		tmpEnvVar := corev1.EnvVar{
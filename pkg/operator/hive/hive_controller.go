@@ -488,6 +488,86 @@ func (r *ReconcileHiveConfig) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
+	ceConfigHash, err := r.deployConfigMap(hLog, h, instance, costEstimationConfigMapInfo, namespacesToClean)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying cost estimation configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingCostEstimationConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	notificationsConfigHash, err := r.deployConfigMap(hLog, h, instance, notificationsConfigMapInfo, namespacesToClean)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying notifications configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingNotificationsConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	externalSecretsConfigHash, err := r.deployConfigMap(hLog, h, instance, externalSecretsConfigMapInfo, namespacesToClean)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying external secrets configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingExternalSecretsConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	credentialsBrokerConfigHash, err := r.deployConfigMap(hLog, h, instance, credentialsBrokerConfigMapInfo, namespacesToClean)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying credentials broker configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingCredentialsBrokerConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	imageOverridesConfigHash, err := r.deployConfigMap(hLog, h, instance, installManagerImageOverridesConfigMapInfo, namespacesToClean)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying install manager image overrides configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingInstallManagerImageOverridesConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	executionClusterConfigHash, err := r.deployConfigMap(hLog, h, instance, executionClusterConfigMapInfo, namespacesToClean)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying execution cluster configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingExecutionClusterConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	logArchiveConfigHash, err := r.deployConfigMap(hLog, h, instance, logArchiveConfigMapInfo, namespacesToClean)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying log archive configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingLogArchiveConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	sensitiveArtifactsConfigHash, err := r.deployConfigMap(hLog, h, instance, sensitiveArtifactsConfigMapInfo, namespacesToClean)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying sensitive artifacts configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingSensitiveArtifactsConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	adminSecretEncryptionConfigHash, err := r.deployConfigMap(hLog, h, instance, adminSecretEncryptionConfigMapInfo, namespacesToClean)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying admin secret encryption configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingAdminSecretEncryptionConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
+	machinePoolDefaultsConfigHash, err := r.deployConfigMap(hLog, h, instance, machinePoolDefaultsConfigMapInfo, namespacesToClean)
+	if err != nil {
+		hLog.WithError(err).Error("error deploying machine pool defaults configmap")
+		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingMachinePoolDefaultsConfigmap", err.Error())
+		r.updateHiveConfigStatus(origHiveConfig, instance, hLog, false)
+		return reconcile.Result{}, err
+	}
+
 	scConfigHash, err := r.deployConfigMap(hLog, h, instance, r.supportedContractsConfigMapInfo(), namespacesToClean)
 	if err != nil {
 		hLog.WithError(err).Error("error deploying supported contracts configmap")
@@ -513,7 +593,7 @@ func (r *ReconcileHiveConfig) Reconcile(ctx context.Context, request reconcile.R
 		return reconcile.Result{}, err
 	}
 
-	err = r.deployHive(hLog, h, instance, namespacesToClean, confighash, managedDomainsConfigHash, fpConfigHash)
+	err = r.deployHive(hLog, h, instance, namespacesToClean, confighash, managedDomainsConfigHash, fpConfigHash, ceConfigHash, notificationsConfigHash, externalSecretsConfigHash, credentialsBrokerConfigHash, imageOverridesConfigHash, executionClusterConfigHash, logArchiveConfigHash, sensitiveArtifactsConfigHash, adminSecretEncryptionConfigHash, machinePoolDefaultsConfigHash)
 	if err != nil {
 		hLog.WithError(err).Error("error deploying Hive")
 		instance.Status.Conditions = util.SetHiveConfigCondition(instance.Status.Conditions, hivev1.HiveReadyCondition, corev1.ConditionFalse, "ErrorDeployingHive", err.Error())
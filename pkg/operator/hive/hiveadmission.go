@@ -2,8 +2,11 @@ package hive
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
+	"strings"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -12,6 +15,7 @@ import (
 	hivecontractsv1alpha1 "github.com/openshift/hive/apis/hivecontracts/v1alpha1"
 	hiveconstants "github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	hiveconversionwebhooks "github.com/openshift/hive/pkg/conversion-webhooks/hive"
 	"github.com/openshift/hive/pkg/operator/assets"
 	"github.com/openshift/hive/pkg/operator/util"
 	"github.com/openshift/hive/pkg/resource"
@@ -35,6 +39,20 @@ const (
 	hiveAdmissionServingCertSecretName = "hiveadmission-serving-cert"
 )
 
+const (
+	hiveAdmissionServiceName       = "hiveadmission"
+	conversionWebhookPort          = 9444
+	injectCABundleAnnotation       = "service.beta.openshift.io/inject-cabundle"
+	conversionWebhookReviewVersion = "v1"
+)
+
+// conversionWebhookCRDs are the CRDs with more than one version, which are converted by the conversion webhook of
+// hiveadmission.
+var conversionWebhookCRDs = []string{
+	"clusterdeployments.hive.openshift.io",
+	"machinepools.hive.openshift.io",
+}
+
 const (
 	aggregatorClientCAHashAnnotation = "hive.openshift.io/ca-hash"
 	servingCertSecretHashAnnotation  = "hive.openshift.io/serving-cert-secret-hash"
@@ -51,10 +69,12 @@ const (
 )
 
 var webhookAssets = []string{
+	"config/hiveadmission/clusteraccesstoken-webhook.yaml",
 	"config/hiveadmission/clusterdeployment-webhook.yaml",
 	"config/hiveadmission/clusterimageset-webhook.yaml",
 	"config/hiveadmission/clusterprovision-webhook.yaml",
 	"config/hiveadmission/dnszones-webhook.yaml",
+	"config/hiveadmission/hostinventory-webhook.yaml",
 	"config/hiveadmission/machinepool-webhook.yaml",
 	"config/hiveadmission/syncset-webhook.yaml",
 	"config/hiveadmission/selectorsyncset-webhook.yaml",
@@ -143,6 +163,7 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 	addConfigVolume(&hiveAdmDeployment.Spec.Template.Spec, awsPrivateLinkConfigMapInfo, hiveAdmContainer)
 	addConfigVolume(&hiveAdmDeployment.Spec.Template.Spec, r.supportedContractsConfigMapInfo(), hiveAdmContainer)
 	addReleaseImageVerificationConfigMapEnv(hiveAdmContainer, instance)
+	addClusterAccessTokenAllowedClusterRolesEnv(hiveAdmContainer, instance)
 
 	validatingWebhooks := make([]*admregv1.ValidatingWebhookConfiguration, len(webhookAssets))
 	for i, yaml := range webhookAssets {
@@ -221,10 +242,88 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 		hLog.WithField("webhook", webhook.Name).Infof("validating webhook: %s", result)
 	}
 
+	var caBundle []byte
+	if !isOpenShift || is311 {
+		_, caBundle, err = r.getCACerts(hLog, hiveNSName)
+		if err != nil {
+			hLog.WithError(err).Error("error getting CA certs for conversion webhooks")
+			return err
+		}
+	}
+	for _, crdName := range conversionWebhookCRDs {
+		if err := r.configureConversionWebhook(hLog, crdName, hiveNSName, caBundle); err != nil {
+			return err
+		}
+	}
+
 	hLog.Info("hiveadmission components reconciled successfully")
 	return nil
 }
 
+// configureConversionWebhook points the conversion of a CRD at the conversion webhook of hiveadmission. The CA bundle
+// is injected by the service CA operator if caBundle is nil. The CRD is patched rather than updated, as updating it
+// through the CRD types of this client would drop the parts of its schema they do not know, like CEL rules.
+func (r *ReconcileHiveConfig) configureConversionWebhook(hLog log.FieldLogger, crdName, hiveNSName string, caBundle []byte) error {
+	cLog := hLog.WithField("crd", crdName)
+	crd := &apiextv1.CustomResourceDefinition{}
+	if err := r.Get(context.Background(), types.NamespacedName{Name: crdName}, crd); err != nil {
+		cLog.WithError(err).Error("error fetching CRD for conversion webhook")
+		return err
+	}
+	origCRD := crd.DeepCopy()
+
+	if caBundle == nil {
+		if crd.Annotations == nil {
+			crd.Annotations = map[string]string{}
+		}
+		crd.Annotations[injectCABundleAnnotation] = "true"
+		// Keep the CA bundle already injected by the service CA operator.
+		if crd.Spec.Conversion != nil && crd.Spec.Conversion.Webhook != nil && crd.Spec.Conversion.Webhook.ClientConfig != nil {
+			caBundle = crd.Spec.Conversion.Webhook.ClientConfig.CABundle
+		}
+	}
+	port := int32(conversionWebhookPort)
+	path := hiveconversionwebhooks.ConversionPath
+	crd.Spec.Conversion = &apiextv1.CustomResourceConversion{
+		Strategy: apiextv1.WebhookConverter,
+		Webhook: &apiextv1.WebhookConversion{
+			ClientConfig: &apiextv1.WebhookClientConfig{
+				Service: &apiextv1.ServiceReference{
+					Namespace: hiveNSName,
+					Name:      hiveAdmissionServiceName,
+					Path:      &path,
+					Port:      &port,
+				},
+				CABundle: caBundle,
+			},
+			ConversionReviewVersions: []string{conversionWebhookReviewVersion},
+		},
+	}
+
+	if reflect.DeepEqual(origCRD, crd) {
+		cLog.Debug("conversion webhook already configured")
+		return nil
+	}
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{"conversion": crd.Spec.Conversion},
+	}
+	if crd.Annotations[injectCABundleAnnotation] != "" {
+		patch["metadata"] = map[string]interface{}{
+			"annotations": map[string]string{injectCABundleAnnotation: crd.Annotations[injectCABundleAnnotation]},
+		}
+	}
+	patchData, err := json.Marshal(patch)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal conversion webhook patch")
+	}
+	if err := r.Patch(context.Background(), types.NamespacedName{Name: crdName}, crd, types.MergePatchType, patchData); err != nil {
+		cLog.WithError(err).Error("error configuring conversion webhook")
+		return err
+	}
+	cLog.Info("conversion webhook configured")
+	return nil
+}
+
 func (r *ReconcileHiveConfig) getCACerts(hLog log.FieldLogger, hiveNSName string) ([]byte, []byte, error) {
 	// Locate the kube CA by looking up secrets in hive namespace, finding one of
 	// type 'kubernetes.io/service-account-token', and reading the CA off it.
@@ -332,3 +431,13 @@ func addReleaseImageVerificationConfigMapEnv(container *corev1.Container, instan
 		Value: instance.Spec.ReleaseImageVerificationConfigMapRef.Name,
 	})
 }
+
+func addClusterAccessTokenAllowedClusterRolesEnv(container *corev1.Container, instance *hivev1.HiveConfig) {
+	if instance.Spec.ClusterAccessTokens == nil || len(instance.Spec.ClusterAccessTokens.AllowedClusterRoles) == 0 {
+		return
+	}
+	container.Env = append(container.Env, corev1.EnvVar{
+		Name:  hiveconstants.ClusterAccessTokenAllowedClusterRolesEnvVar,
+		Value: strings.Join(instance.Spec.ClusterAccessTokens.AllowedClusterRoles, ","),
+	})
+}
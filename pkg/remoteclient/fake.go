@@ -2,31 +2,78 @@ package remoteclient
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/pkg/errors"
 
 	configv1 "github.com/openshift/api/config/v1"
 	openshiftapiv1 "github.com/openshift/api/config/v1"
+	machineapi "github.com/openshift/api/machine/v1beta1"
 	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	kubeclient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	machineAPINamespace = "openshift-machine-api"
 )
 
+// simulatedClusters holds the clients for simulated clusters, keyed by the namespace and name of their
+// ClusterDeployment. A simulated cluster lives for as long as the process, or until it is forgotten, so that
+// objects written to it by one reconcile can be read back by the next.
+var simulatedClusters = struct {
+	sync.Mutex
+	clients map[types.NamespacedName]client.Client
+}{
+	clients: map[types.NamespacedName]client.Client{},
+}
+
 // fakeBuilder builds fake clients for fake clusters. Used to simulate communication with a cluster
 // that doesn't actually exist in scale testing.
 type fakeBuilder struct {
 	urlToUse int
+	// simulatedCluster is the key of the simulated cluster to use. If nil, each client built is populated afresh
+	// and does not retain any changes.
+	simulatedCluster *types.NamespacedName
 }
 
 // Build returns a fake controller-runtime test client populated with the resources we expect to query for a
-// fake cluster.
+// fake cluster. For a simulated cluster, the same client is returned on every call.
 func (b *fakeBuilder) Build() (client.Client, error) {
+	if b.simulatedCluster == nil {
+		return buildFakeClient(b.simulatedCluster)
+	}
 
+	simulatedClusters.Lock()
+	defer simulatedClusters.Unlock()
+	if c, ok := simulatedClusters.clients[*b.simulatedCluster]; ok {
+		return c, nil
+	}
+	c, err := buildFakeClient(b.simulatedCluster)
+	if err != nil {
+		return nil, err
+	}
+	simulatedClusters.clients[*b.simulatedCluster] = c
+	return c, nil
+}
+
+// ForgetSimulatedCluster discards the simulated cluster for the ClusterDeployment, if there is one.
+func ForgetSimulatedCluster(cd *hivev1.ClusterDeployment) {
+	simulatedClusters.Lock()
+	defer simulatedClusters.Unlock()
+	delete(simulatedClusters.clients, types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name})
+}
+
+func buildFakeClient(simulatedCluster *types.NamespacedName) (client.Client, error) {
 	scheme, err := buildScheme()
 	if err != nil {
 		return nil, err
@@ -97,6 +144,28 @@ func (b *fakeBuilder) Build() (client.Client, error) {
 		})
 	}
 
+	// A simulated cluster also needs the machine API objects that the machinepool controller expects to find in a
+	// freshly installed cluster.
+	if simulatedCluster != nil {
+		fakeObjects = append(fakeObjects,
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: machineAPINamespace,
+				},
+			},
+			&machineapi.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("%s-master-0", simulatedCluster.Name),
+					Namespace: machineAPINamespace,
+					Labels: map[string]string{
+						"machine.openshift.io/cluster-api-machine-type": "master",
+						"machine.openshift.io/cluster-api-machine-role": "master",
+					},
+				},
+			},
+		)
+	}
+
 	return fake.NewFakeClientWithScheme(scheme, fakeObjects...), nil
 }
 
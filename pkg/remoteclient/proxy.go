@@ -0,0 +1,88 @@
+package remoteclient
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/client-go/rest"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// configureProxy sends the requests of the REST config through the cluster-wide proxy of the ClusterDeployment, if
+// any, and trusts the additional trust bundle of the proxy.
+func configureProxy(cfg *rest.Config, proxy *hivev1.ClusterProxy) error {
+	if proxy == nil {
+		return nil
+	}
+	proxyFunc, err := proxyFunc(proxy)
+	if err != nil {
+		return err
+	}
+	cfg.Proxy = proxyFunc
+	if proxy.AdditionalTrustBundle != "" && len(cfg.TLSClientConfig.CAData) > 0 {
+		caData := append([]byte{}, cfg.TLSClientConfig.CAData...)
+		if !strings.HasSuffix(string(caData), "\n") {
+			caData = append(caData, '\n')
+		}
+		cfg.TLSClientConfig.CAData = append(caData, proxy.AdditionalTrustBundle...)
+	}
+	return nil
+}
+
+// proxyFunc returns a proxy func for the transport of a REST config that picks the proxy by the scheme of the request,
+// and bypasses the proxy for the hosts matching NoProxy.
+func proxyFunc(proxy *hivev1.ClusterProxy) (func(*http.Request) (*url.URL, error), error) {
+	var httpProxy, httpsProxy *url.URL
+	var err error
+	if proxy.HTTPProxy != "" {
+		if httpProxy, err = url.Parse(proxy.HTTPProxy); err != nil {
+			return nil, errors.Wrap(err, "could not parse httpProxy")
+		}
+	}
+	if proxy.HTTPSProxy != "" {
+		if httpsProxy, err = url.Parse(proxy.HTTPSProxy); err != nil {
+			return nil, errors.Wrap(err, "could not parse httpsProxy")
+		}
+	}
+	noProxy := strings.Split(proxy.NoProxy, ",")
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL.Hostname(), noProxy) {
+			return nil, nil
+		}
+		if req.URL.Scheme == "https" {
+			return httpsProxy, nil
+		}
+		return httpProxy, nil
+	}, nil
+}
+
+// bypassProxy returns true when the host matches an entry of noProxy: "*", a CIDR containing the host, the host
+// itself, or a domain of which the host is a subdomain. Entries with a leading "." only match subdomains.
+func bypassProxy(host string, noProxy []string) bool {
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+		case entry == "*":
+			return true
+		case strings.Contains(entry, "/"):
+			if _, cidr, err := net.ParseCIDR(entry); err == nil && ip != nil && cidr.Contains(ip) {
+				return true
+			}
+		case strings.HasPrefix(entry, "."):
+			if strings.HasSuffix(host, entry) {
+				return true
+			}
+		case host == entry || strings.HasSuffix(host, "."+entry):
+			return true
+		}
+	}
+	return false
+}
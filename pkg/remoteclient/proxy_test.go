@@ -0,0 +1,99 @@
+package remoteclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func Test_proxyFunc(t *testing.T) {
+	proxy := &hivev1.ClusterProxy{
+		HTTPProxy:  "http://http-proxy.example.com:3128",
+		HTTPSProxy: "http://https-proxy.example.com:3128",
+		NoProxy:    "internal.example.com, .svc,10.0.0.0/16",
+	}
+	cases := []struct {
+		name          string
+		url           string
+		expectedProxy string
+	}{
+		{
+			name:          "https",
+			url:           "https://api.test-cluster.example.com:6443/api",
+			expectedProxy: "http://https-proxy.example.com:3128",
+		},
+		{
+			name:          "http",
+			url:           "http://api.test-cluster.example.com/api",
+			expectedProxy: "http://http-proxy.example.com:3128",
+		},
+		{
+			name: "no proxy domain",
+			url:  "https://internal.example.com:6443/api",
+		},
+		{
+			name: "no proxy subdomain",
+			url:  "https://api.internal.example.com:6443/api",
+		},
+		{
+			name: "no proxy leading dot",
+			url:  "https://kubernetes.default.svc/api",
+		},
+		{
+			name:          "leading dot does not match domain",
+			url:           "https://svc/api",
+			expectedProxy: "http://https-proxy.example.com:3128",
+		},
+		{
+			name: "no proxy cidr",
+			url:  "https://10.0.3.4:6443/api",
+		},
+		{
+			name:          "outside no proxy cidr",
+			url:           "https://10.1.3.4:6443/api",
+			expectedProxy: "http://https-proxy.example.com:3128",
+		},
+	}
+	proxyFunc, err := proxyFunc(proxy)
+	require.NoError(t, err, "unexpected error building proxy func")
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err, "unexpected error building request")
+			actual, err := proxyFunc(req)
+			require.NoError(t, err, "unexpected error getting proxy")
+			if tc.expectedProxy == "" {
+				assert.Nil(t, actual, "expected proxy to be bypassed")
+				return
+			}
+			if assert.NotNil(t, actual, "expected proxy") {
+				assert.Equal(t, tc.expectedProxy, actual.String(), "unexpected proxy")
+			}
+		})
+	}
+}
+
+func Test_builder_RESTConfigProxy(t *testing.T) {
+	cd := testClusterDeployment()
+	cd.Spec.Proxy = &hivev1.ClusterProxy{
+		HTTPSProxy:            "http://proxy.example.com:3128",
+		AdditionalTrustBundle: "fake-bundle",
+	}
+	c := fakeClient(cd, testKubeconfigSecret(t))
+	cfg, err := NewBuilder(c, cd, "test-controller-name").RESTConfig()
+	require.NoError(t, err, "unexpected error getting REST config")
+	if assert.NotNil(t, cfg.Proxy, "expected proxy func") {
+		req, err := http.NewRequest(http.MethodGet, cfg.Host, nil)
+		require.NoError(t, err, "unexpected error building request")
+		proxyURL, err := cfg.Proxy(req)
+		require.NoError(t, err, "unexpected error getting proxy")
+		if assert.NotNil(t, proxyURL, "expected proxy") {
+			assert.Equal(t, "http://proxy.example.com:3128", proxyURL.String(), "unexpected proxy")
+		}
+	}
+	assert.Contains(t, string(cfg.TLSClientConfig.CAData), "fake-bundle", "expected trust bundle in CA data")
+}
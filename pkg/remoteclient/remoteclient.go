@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/dynamic"
@@ -26,6 +27,7 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/secretencryption"
 )
 
 // Builder is used to build API clients to the remote cluster
@@ -55,12 +57,17 @@ type Builder interface {
 // ClusterDeployment.
 // The controllerName is needed for metrics.
 // If the ClusterDeployment carries the fake cluster annotation, a fake client will be returned populated with
-// runtime.Objects we need to query for in all our controllers.
+// runtime.Objects we need to query for in all our controllers. If it also carries the simulated cluster annotation,
+// the fake client is shared by all builders for the ClusterDeployment and retains the changes made through it.
 func NewBuilder(c client.Client, cd *hivev1.ClusterDeployment, controllerName hivev1.ControllerName) Builder {
 	if utils.IsFakeCluster(cd) {
-		return &fakeBuilder{
+		b := &fakeBuilder{
 			urlToUse: activeURL,
 		}
+		if utils.IsSimulatedCluster(cd) {
+			b.simulatedCluster = &types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}
+		}
+		return b
 	}
 	return &builder{
 		c:              c,
@@ -71,7 +78,7 @@ func NewBuilder(c client.Client, cd *hivev1.ClusterDeployment, controllerName hi
 }
 
 // ConnectToRemoteCluster connects to a remote cluster using the specified builder.
-// If the ClusterDeployment is marked as unreachable, then no connection will be made.
+// If the ClusterDeployment is marked as unreachable, or the cluster is sleeping, then no connection will be made.
 // If there are problems connecting, then the specified clusterdeployment will be marked as unreachable.
 func ConnectToRemoteCluster(
 	cd *hivev1.ClusterDeployment,
@@ -101,6 +108,11 @@ func connectToRemoteCluster(
 	logger log.FieldLogger,
 	buildFunc func(builder Builder) (interface{}, error),
 ) (remoteClient interface{}, unreachable, requeue bool) {
+	if utils.IsClusterSleeping(cd) {
+		logger.WithField("powerState", cd.Status.PowerState).Debug("skipping sleeping cluster")
+		unreachable = true
+		return
+	}
 	if u, _ := Unreachable(cd); u {
 		logger.Debug("skipping cluster with unreachable condition")
 		unreachable = true
@@ -282,6 +294,10 @@ func (b *builder) RESTConfig() (*rest.Config, error) {
 
 	utils.AddControllerMetricsTransportWrapper(cfg, b.controllerName, true)
 
+	if err := configureProxy(cfg, b.cd.Spec.Proxy); err != nil {
+		return nil, err
+	}
+
 	if override := b.cd.Spec.ControlPlaneConfig.APIURLOverride; override != "" {
 		if b.urlToUse == primaryURL ||
 			(b.urlToUse == activeURL && IsPrimaryURLActive(b.cd)) {
@@ -301,6 +317,9 @@ func unadulteratedRESTConfig(c client.Client, cd *hivev1.ClusterDeployment) (*re
 	); err != nil {
 		return nil, errors.Wrap(err, "could not get admin kubeconfig secret")
 	}
+	if _, err := secretencryption.DecryptSecret(context.Background(), c, kubeconfigSecret); err != nil {
+		return nil, errors.Wrap(err, "could not decrypt admin kubeconfig secret")
+	}
 	return restConfigFromSecret(kubeconfigSecret)
 }
 
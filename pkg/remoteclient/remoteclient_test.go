@@ -8,8 +8,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	machineapi "github.com/openshift/api/machine/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -226,6 +229,66 @@ func Test_builder_Build(t *testing.T) {
 	}
 }
 
+func Test_fakeBuilder_Build(t *testing.T) {
+	cases := []struct {
+		name              string
+		simulated         bool
+		expectRetained    bool
+		expectedMachines  int
+		expectedNamespace bool
+	}{
+		{
+			name: "fake cluster",
+		},
+		{
+			name:              "simulated cluster",
+			simulated:         true,
+			expectRetained:    true,
+			expectedMachines:  1,
+			expectedNamespace: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cd := testClusterDeployment()
+			cd.Annotations = map[string]string{constants.HiveFakeClusterAnnotation: "true"}
+			if tc.simulated {
+				cd.Annotations[constants.HiveSimulatedClusterAnnotation] = "true"
+			}
+			defer ForgetSimulatedCluster(cd)
+			c := fakeClient(cd)
+
+			rc, err := NewBuilder(c, cd, testControllerName).Build()
+			require.NoError(t, err, "unexpected error building client")
+
+			machines := &machineapi.MachineList{}
+			require.NoError(t, rc.List(context.Background(), machines), "unexpected error listing machines")
+			assert.Len(t, machines.Items, tc.expectedMachines, "unexpected number of machines")
+			err = rc.Get(context.Background(), client.ObjectKey{Name: machineAPINamespace}, &corev1.Namespace{})
+			assert.Equal(t, tc.expectedNamespace, err == nil, "unexpected presence of machine API namespace")
+
+			ms := &machineapi.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: machineAPINamespace,
+					Name:      "worker",
+				},
+			}
+			require.NoError(t, rc.Create(context.Background(), ms), "unexpected error creating machine set")
+
+			rc, err = NewBuilder(c, cd, testControllerName).Build()
+			require.NoError(t, err, "unexpected error rebuilding client")
+			err = rc.Get(context.Background(), client.ObjectKey{Namespace: machineAPINamespace, Name: "worker"}, &machineapi.MachineSet{})
+			assert.Equal(t, tc.expectRetained, err == nil, "unexpected retention of machine set")
+
+			ForgetSimulatedCluster(cd)
+			rc, err = NewBuilder(c, cd, testControllerName).Build()
+			require.NoError(t, err, "unexpected error rebuilding client")
+			err = rc.Get(context.Background(), client.ObjectKey{Namespace: machineAPINamespace, Name: "worker"}, &machineapi.MachineSet{})
+			assert.True(t, apierrors.IsNotFound(err), "expected machine set to be forgotten")
+		})
+	}
+}
+
 func fakeClient(objects ...runtime.Object) client.Client {
 	scheme := runtime.NewScheme()
 	hivev1.AddToScheme(scheme)
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -78,3 +79,18 @@ func (fakeHelper) Patch(name types.NamespacedName, kind, apiVersion string, patc
 func (fakeHelper) Delete(apiVersion, kind, namespace, name string) error {
 	return nil
 }
+
+// Get returns a resource with the given type, namespace and name which reports itself as ready in the conditions
+// checked by callers, such as Established for CustomResourceDefinitions and Available for APIServices.
+func (fakeHelper) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(apiVersion)
+	u.SetKind(kind)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	unstructured.SetNestedSlice(u.Object, []interface{}{
+		map[string]interface{}{"type": "Established", "status": "True"},
+		map[string]interface{}{"type": "Available", "status": "True"},
+	}, "status", "conditions")
+	return u, nil
+}
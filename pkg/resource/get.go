@@ -0,0 +1,33 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func (r *helper) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	f, err := r.getFactory(namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get factory")
+	}
+	mapper, err := f.ToRESTMapper()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get mapper")
+	}
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get mapping")
+	}
+	dynamicClient, err := f.DynamicClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create dynamic client")
+	}
+	// Delegate the not-found handling to the caller, which knows whether a missing resource is an error.
+	return dynamicClient.Resource(mapping.Resource).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+}
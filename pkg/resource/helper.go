@@ -7,6 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -37,6 +38,8 @@ type Helper interface {
 	// Patch invokes the kubectl patch command with the given resource, patch and patch type
 	Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error
 	Delete(apiVersion, kind, namespace, name string) error
+	// Get returns the resource with the given type, namespace and name from the target cluster.
+	Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error)
 }
 
 // helper contains configuration for apply and patch operations
@@ -2,13 +2,31 @@ package resource
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	kcmdpatch "k8s.io/kubectl/pkg/cmd/patch"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ApplyPatchType is the patch type for server-side apply patches. kubectl patch does not support
+	// server-side apply, so these patches are sent through the dynamic client instead.
+	ApplyPatchType = "apply"
+
+	// applyFieldManager is the field manager recorded for fields set by server-side apply patches.
+	applyFieldManager = "hive"
 )
 
 var (
@@ -16,11 +34,15 @@ var (
 		"json":      types.JSONPatchType,
 		"merge":     types.MergePatchType,
 		"strategic": types.StrategicMergePatchType,
+		"apply":     types.ApplyPatchType,
 	}
 )
 
 // Patch invokes the kubectl patch command with the given resource, patch and patch type
 func (r *helper) Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error {
+	if patchType == ApplyPatchType {
+		return r.serverSideApply(name, kind, apiVersion, patch)
+	}
 
 	ioStreams := genericclioptions.IOStreams{
 		In:     &bytes.Buffer{},
@@ -68,10 +90,69 @@ func (r *helper) setupPatchCommand(name, kind, apiVersion, patchType string, f c
 	}
 	_, ok := patchTypes[patchType]
 	if !ok {
-		return nil, fmt.Errorf("Invalid patch type: %s. Valid patch types are 'strategic', 'merge' or 'json'", patchType)
+		return nil, fmt.Errorf("Invalid patch type: %s. Valid patch types are 'strategic', 'merge', 'json' or 'apply'", patchType)
 	}
 	o.PatchType = patchType
 	o.Patch = patch
 
 	return o, nil
 }
+
+// serverSideApply applies the given partial object with server-side apply, forcing ownership of the fields it sets.
+// Unlike strategic merge patches, this works for custom resources, merging lists by the keys declared in their schema.
+func (r *helper) serverSideApply(name types.NamespacedName, kind, apiVersion string, patch []byte) error {
+	f, err := r.getFactory(name.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "could not get factory")
+	}
+	mapper, err := f.ToRESTMapper()
+	if err != nil {
+		return errors.Wrap(err, "could not get mapper")
+	}
+	gvk := schema.FromAPIVersionAndKind(apiVersion, kind)
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return errors.Wrap(err, "could not get mapping")
+	}
+	namespace := name.Namespace
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		namespace = ""
+	}
+	data, err := applyPatchObject(patch, apiVersion, kind, namespace, name.Name)
+	if err != nil {
+		return err
+	}
+	dynamicClient, err := f.DynamicClient()
+	if err != nil {
+		return errors.Wrap(err, "could not create dynamic client")
+	}
+	if _, err := dynamicClient.Resource(mapping.Resource).Namespace(namespace).Patch(
+		context.Background(),
+		name.Name,
+		types.ApplyPatchType,
+		data,
+		metav1.PatchOptions{FieldManager: applyFieldManager, Force: pointer.BoolPtr(true)},
+	); err != nil {
+		r.logger.WithError(err).Warn("server-side apply failed")
+		return err
+	}
+	r.logger.Info("server-side apply successful")
+	return nil
+}
+
+// applyPatchObject parses a YAML or JSON apply patch and fills in the identifying fields of the target object,
+// so that the patch only needs to contain the fields being set.
+func applyPatchObject(patch []byte, apiVersion, kind, namespace, name string) ([]byte, error) {
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(patch, &obj.Object); err != nil {
+		return nil, errors.Wrap(err, "could not parse apply patch")
+	}
+	if obj.Object == nil {
+		obj.Object = map[string]interface{}{}
+	}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	return json.Marshal(obj.Object)
+}
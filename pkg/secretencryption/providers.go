@@ -0,0 +1,267 @@
+package secretencryption
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.1/keyvault"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/cloudkms/v1"
+	"google.golang.org/api/option"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/awsclient"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	// defaultAWSRegion is the region of the AWS KMS key when HiveConfig does not say.
+	defaultAWSRegion = "us-east-1"
+
+	// localKeySecretKey is the key of the secret holding the AES key of the local provider.
+	localKeySecretKey = "key"
+)
+
+func getSecret(c client.Client, namespace string, ref corev1.LocalObjectReference) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		return nil, errors.Wrapf(err, "could not get secret %s", ref.Name)
+	}
+	return secret, nil
+}
+
+// awsProvider encrypts data keys with an AWS KMS key.
+type awsProvider struct {
+	client *kms.Client
+	keyID  string
+}
+
+var _ Provider = &awsProvider{}
+
+func newAWSProvider(c client.Client, namespace string, config *hivev1.AWSKMSConfig) (*awsProvider, error) {
+	secret, err := getSecret(c, namespace, config.CredentialsSecretRef)
+	if err != nil {
+		return nil, err
+	}
+	region := config.Region
+	if region == "" {
+		region = defaultAWSRegion
+	}
+	cfg, err := awsclient.NewConfigFromSecret(secret, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create the AWS config for KMS")
+	}
+	return &awsProvider{client: kms.NewFromConfig(cfg), keyID: config.KeyID}, nil
+}
+
+func (p *awsProvider) Name() string {
+	return "AWS"
+}
+
+func (p *awsProvider) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	output, err := p.client.Encrypt(ctx, &kms.EncryptInput{
+		KeyId:     aws.String(p.keyID),
+		Plaintext: key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.CiphertextBlob, nil
+}
+
+func (p *awsProvider) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	output, err := p.client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(p.keyID),
+		CiphertextBlob: wrapped,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Plaintext, nil
+}
+
+// gcpProvider encrypts data keys with a Google Cloud KMS key.
+type gcpProvider struct {
+	keys    *cloudkms.ProjectsLocationsKeyRingsCryptoKeysService
+	keyName string
+}
+
+var _ Provider = &gcpProvider{}
+
+func newGCPProvider(c client.Client, namespace string, config *hivev1.GCPKMSConfig) (*gcpProvider, error) {
+	secret, err := getSecret(c, namespace, config.CredentialsSecretRef)
+	if err != nil {
+		return nil, err
+	}
+	authJSON, ok := secret.Data[constants.GCPCredentialsName]
+	if !ok {
+		return nil, fmt.Errorf("creds secret does not contain %q data", constants.GCPCredentialsName)
+	}
+	ctx := context.TODO()
+	creds, err := google.CredentialsFromJSON(ctx, authJSON, cloudkms.CloudkmsScope)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load the GCP credentials")
+	}
+	service, err := cloudkms.NewService(ctx,
+		option.WithCredentials(creds),
+		option.WithUserAgent("openshift.io hive/v1"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create the GCP KMS client")
+	}
+	return &gcpProvider{keys: service.Projects.Locations.KeyRings.CryptoKeys, keyName: config.KeyName}, nil
+}
+
+func (p *gcpProvider) Name() string {
+	return "GCP"
+}
+
+func (p *gcpProvider) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	resp, err := p.keys.Encrypt(p.keyName, &cloudkms.EncryptRequest{
+		Plaintext: base64.StdEncoding.EncodeToString(key),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Ciphertext)
+}
+
+func (p *gcpProvider) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	resp, err := p.keys.Decrypt(p.keyName, &cloudkms.DecryptRequest{
+		Ciphertext: base64.StdEncoding.EncodeToString(wrapped),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Plaintext)
+}
+
+// azureProvider wraps data keys with an RSA key in Azure Key Vault. As unwrapping needs the version of the key which
+// wrapped the data key, the wrapped data keys are stored as "<key version>:<wrapped key>".
+type azureProvider struct {
+	client     keyvault.BaseClient
+	vaultURL   string
+	keyName    string
+	keyVersion string
+}
+
+var _ Provider = &azureProvider{}
+
+func newAzureProvider(c client.Client, namespace string, config *hivev1.AzureKeyVaultConfig) (*azureProvider, error) {
+	secret, err := getSecret(c, namespace, config.CredentialsSecretRef)
+	if err != nil {
+		return nil, err
+	}
+	authJSON, ok := secret.Data[constants.AzureCredentialsName]
+	if !ok {
+		return nil, fmt.Errorf("creds secret does not contain %q data", constants.AzureCredentialsName)
+	}
+	var authMap map[string]string
+	if err := json.Unmarshal(authJSON, &authMap); err != nil {
+		return nil, errors.Wrap(err, "could not parse the Azure credentials")
+	}
+	for _, field := range []string{"clientId", "clientSecret", "tenantId"} {
+		if _, ok := authMap[field]; !ok {
+			return nil, fmt.Errorf("missing %s in auth", field)
+		}
+	}
+	env, err := azure.EnvironmentFromName(config.CloudName.Name())
+	if err != nil {
+		return nil, err
+	}
+	authConfig := auth.NewClientCredentialsConfig(authMap["clientId"], authMap["clientSecret"], authMap["tenantId"])
+	authConfig.Resource = strings.TrimSuffix(env.ResourceIdentifiers.KeyVault, "/")
+	authConfig.AADEndpoint = env.ActiveDirectoryEndpoint
+	authorizer, err := authConfig.Authorizer()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create the Azure authorizer")
+	}
+	kv := keyvault.New()
+	kv.Authorizer = authorizer
+	return &azureProvider{
+		client:     kv,
+		vaultURL:   config.VaultURL,
+		keyName:    config.KeyName,
+		keyVersion: config.KeyVersion,
+	}, nil
+}
+
+func (p *azureProvider) Name() string {
+	return "Azure"
+}
+
+func (p *azureProvider) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	result, err := p.client.WrapKey(ctx, p.vaultURL, p.keyName, p.keyVersion, keyvault.KeyOperationsParameters{
+		Algorithm: keyvault.RSAOAEP256,
+		Value:     to.StringPtr(base64.RawURLEncoding.EncodeToString(key)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Kid == nil || result.Result == nil {
+		return nil, errors.New("key vault did not return the wrapped key")
+	}
+	version := (*result.Kid)[strings.LastIndex(*result.Kid, "/")+1:]
+	return []byte(version + ":" + *result.Result), nil
+}
+
+func (p *azureProvider) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	parts := strings.SplitN(string(wrapped), ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("wrapped key does not contain the key version")
+	}
+	result, err := p.client.UnwrapKey(ctx, p.vaultURL, p.keyName, parts[0], keyvault.KeyOperationsParameters{
+		Algorithm: keyvault.RSAOAEP256,
+		Value:     to.StringPtr(parts[1]),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.Result == nil {
+		return nil, errors.New("key vault did not return the unwrapped key")
+	}
+	return base64.RawURLEncoding.DecodeString(*result.Result)
+}
+
+// localProvider encrypts data keys with an AES key held in a secret on the hub.
+type localProvider struct {
+	key []byte
+}
+
+var _ Provider = &localProvider{}
+
+func newLocalProvider(c client.Client, namespace string, config *hivev1.LocalKeyConfig) (*localProvider, error) {
+	secret, err := getSecret(c, namespace, config.SecretRef)
+	if err != nil {
+		return nil, err
+	}
+	key := secret.Data[localKeySecretKey]
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("secret %s must contain a %d-byte %q key", config.SecretRef.Name, dataKeySize, localKeySecretKey)
+	}
+	return &localProvider{key: key}, nil
+}
+
+func (p *localProvider) Name() string {
+	return "Local"
+}
+
+func (p *localProvider) WrapKey(ctx context.Context, key []byte) ([]byte, error) {
+	return seal(p.key, key)
+}
+
+func (p *localProvider) UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	return open(p.key, wrapped)
+}
@@ -0,0 +1,254 @@
+// Package secretencryption envelope-encrypts the data of secrets stored on the hub. Each secret is encrypted with its
+// own random data key, and the data key is encrypted by a key management service and stored in an annotation of the
+// secret.
+package secretencryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// dataKeySize is the size of the AES-256 data keys.
+	dataKeySize = 32
+
+	// dataKeyCacheSize and dataKeyCacheTTL bound how many decrypted data keys are held in memory and for how long, so
+	// that keys of secrets which are no longer used are dropped.
+	dataKeyCacheSize = 1000
+	dataKeyCacheTTL  = time.Hour
+)
+
+// Provider encrypts and decrypts data keys with a key held by a key management service.
+type Provider interface {
+	// Name is the name of the key management service, recorded in the EncryptionProviderAnnotation of the secrets.
+	Name() string
+	// WrapKey encrypts the data key.
+	WrapKey(ctx context.Context, key []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key encrypted by WrapKey.
+	UnwrapKey(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// NewProvider returns the provider of the key management service configured in the KMS config. The credentials of the
+// service are read from secrets in the given namespace.
+func NewProvider(c client.Client, namespace string, config *hivev1.KMSConfig) (Provider, error) {
+	if config == nil {
+		return nil, errors.New("no key management service is configured")
+	}
+	configured := 0
+	for _, set := range []bool{config.AWS != nil, config.GCP != nil, config.Azure != nil, config.Local != nil} {
+		if set {
+			configured++
+		}
+	}
+	if configured != 1 {
+		return nil, fmt.Errorf("exactly one key management service must be configured, found %d", configured)
+	}
+	switch {
+	case config.AWS != nil:
+		return newAWSProvider(c, namespace, config.AWS)
+	case config.GCP != nil:
+		return newGCPProvider(c, namespace, config.GCP)
+	case config.Azure != nil:
+		return newAzureProvider(c, namespace, config.Azure)
+	default:
+		return newLocalProvider(c, namespace, config.Local)
+	}
+}
+
+// IsEncrypted returns true if the data of the secret is envelope-encrypted.
+func IsEncrypted(secret *corev1.Secret) bool {
+	_, ok := secret.Annotations[constants.EncryptedDataKeyAnnotation]
+	return ok
+}
+
+// Encrypt replaces each value of the data of the secret with the value encrypted by a new data key. The data key,
+// encrypted by the provider, is stored in the annotations of the secret. The secret is not updated on the server.
+func Encrypt(ctx context.Context, p Provider, secret *corev1.Secret) error {
+	if IsEncrypted(secret) {
+		return errors.New("secret is already encrypted")
+	}
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return errors.Wrap(err, "could not generate a data key")
+	}
+	wrapped, err := p.WrapKey(ctx, dataKey)
+	if err != nil {
+		return errors.Wrap(err, "could not encrypt the data key")
+	}
+	for key, value := range secret.Data {
+		sealed, err := seal(dataKey, value)
+		if err != nil {
+			return errors.Wrapf(err, "could not encrypt key %s", key)
+		}
+		secret.Data[key] = sealed
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[constants.EncryptedDataKeyAnnotation] = base64.StdEncoding.EncodeToString(wrapped)
+	secret.Annotations[constants.EncryptionProviderAnnotation] = p.Name()
+	return nil
+}
+
+// Decrypt replaces each value of the data of an encrypted secret with the decrypted value, and removes the encryption
+// annotations. Secrets which are not encrypted are left alone. The secret is not updated on the server.
+func Decrypt(ctx context.Context, p Provider, secret *corev1.Secret) error {
+	if !IsEncrypted(secret) {
+		return nil
+	}
+	if name := secret.Annotations[constants.EncryptionProviderAnnotation]; name != "" && name != p.Name() {
+		return fmt.Errorf("secret was encrypted by %s, not by the configured %s", name, p.Name())
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(secret.Annotations[constants.EncryptedDataKeyAnnotation])
+	if err != nil {
+		return errors.Wrap(err, "could not decode the encrypted data key")
+	}
+	dataKey, err := dataKeys.get(wrapped, func() ([]byte, error) { return p.UnwrapKey(ctx, wrapped) })
+	if err != nil {
+		return errors.Wrap(err, "could not decrypt the data key")
+	}
+	for key, value := range secret.Data {
+		opened, err := open(dataKey, value)
+		if err != nil {
+			return errors.Wrapf(err, "could not decrypt key %s", key)
+		}
+		secret.Data[key] = opened
+	}
+	delete(secret.Annotations, constants.EncryptedDataKeyAnnotation)
+	delete(secret.Annotations, constants.EncryptionProviderAnnotation)
+	return nil
+}
+
+// DecryptSecret decrypts the admin secret of a cluster in place with the provider configured in
+// HiveConfig.Spec.AdminSecretEncryption, so that callers can use the secret as if it were never encrypted. It returns
+// true if the secret was encrypted, in which case callers updating the secret must encrypt it again with
+// EncryptSecret. The credentials of the key management service are read from the namespace of Hive.
+func DecryptSecret(ctx context.Context, c client.Client, secret *corev1.Secret) (bool, error) {
+	if !IsEncrypted(secret) {
+		return false, nil
+	}
+	p, err := configuredProvider(c)
+	if err != nil {
+		return true, err
+	}
+	return true, Decrypt(ctx, p, secret)
+}
+
+// EncryptSecret encrypts the admin secret of a cluster in place with the provider configured in
+// HiveConfig.Spec.AdminSecretEncryption.
+func EncryptSecret(ctx context.Context, c client.Client, secret *corev1.Secret) error {
+	p, err := configuredProvider(c)
+	if err != nil {
+		return err
+	}
+	return Encrypt(ctx, p, secret)
+}
+
+func configuredProvider(c client.Client) (Provider, error) {
+	config, err := ReadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, errors.New("secret is encrypted but no admin secret encryption is configured")
+	}
+	return NewProvider(c, controllerutils.GetHiveNamespace(), &config.KMS)
+}
+
+// ReadConfig reads the admin secret encryption configuration from the file pointed to by the
+// AdminSecretEncryptionConfigFileEnvVar environment variable. A nil configuration is returned when the encryption is
+// not configured.
+func ReadConfig() (*hivev1.AdminSecretEncryptionConfig, error) {
+	path := os.Getenv(constants.AdminSecretEncryptionConfigFileEnvVar)
+	if len(path) == 0 {
+		return nil, nil
+	}
+	fileBytes, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the admin secret encryption config file")
+	}
+	if len(fileBytes) == 0 || string(fileBytes) == "null" {
+		return nil, nil
+	}
+	config := &hivev1.AdminSecretEncryptionConfig{}
+	if err := json.Unmarshal(fileBytes, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the admin secret encryption config")
+	}
+	return config, nil
+}
+
+// dataKeyCache caches the decrypted data keys by their encrypted value, so that the key management service is not
+// called each time a controller connects to a cluster. The least recently used keys are evicted once the cache is
+// full, and keys expire after dataKeyCacheTTL.
+type dataKeyCache struct {
+	keys *cache.LRUExpireCache
+}
+
+var dataKeys = &dataKeyCache{keys: cache.NewLRUExpireCache(dataKeyCacheSize)}
+
+func (c *dataKeyCache) get(wrapped []byte, unwrap func() ([]byte, error)) ([]byte, error) {
+	if key, ok := c.keys.Get(string(wrapped)); ok {
+		return key.([]byte), nil
+	}
+	key, err := unwrap()
+	if err != nil {
+		return nil, err
+	}
+	c.keys.Add(string(wrapped), key, dataKeyCacheTTL)
+	return key, nil
+}
+
+// seal encrypts the value with AES-GCM under the key. The random nonce is prepended to the ciphertext.
+func seal(key, value []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, value, nil), nil
+}
+
+// open decrypts a value encrypted by seal.
+func open(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("encrypted value is too short")
+	}
+	return gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
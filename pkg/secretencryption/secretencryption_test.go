@@ -0,0 +1,170 @@
+package secretencryption
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+const (
+	hiveNamespace = "hive"
+	keySecretName = "local-key"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	keySecret := func(key []byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: hiveNamespace, Name: keySecretName},
+			Data:       map[string][]byte{localKeySecretKey: key},
+		}
+	}
+	localConfig := &hivev1.KMSConfig{
+		Local: &hivev1.LocalKeyConfig{SecretRef: corev1.LocalObjectReference{Name: keySecretName}},
+	}
+
+	tests := []struct {
+		name        string
+		existing    []runtime.Object
+		config      *hivev1.KMSConfig
+		expectError bool
+	}{
+		{
+			name:     "local key",
+			existing: []runtime.Object{keySecret(bytes.Repeat([]byte{7}, 32))},
+			config:   localConfig,
+		},
+		{
+			name:        "local key too short",
+			existing:    []runtime.Object{keySecret([]byte("short"))},
+			config:      localConfig,
+			expectError: true,
+		},
+		{
+			name:        "missing key secret",
+			config:      localConfig,
+			expectError: true,
+		},
+		{
+			name: "several providers",
+			config: &hivev1.KMSConfig{
+				AWS:   &hivev1.AWSKMSConfig{KeyID: "key"},
+				Local: localConfig.Local,
+			},
+			expectError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(test.existing...).Build()
+			p, err := NewProvider(c, hiveNamespace, test.config)
+			if test.expectError {
+				assert.Error(t, err, "expected error creating provider")
+				return
+			}
+			require.NoError(t, err, "unexpected error creating provider")
+
+			secret := &corev1.Secret{
+				Data: map[string][]byte{
+					constants.KubeconfigSecretKey: []byte("kubeconfig"),
+					"other":                       []byte("other"),
+				},
+			}
+			require.NoError(t, Encrypt(context.TODO(), p, secret), "unexpected error encrypting")
+			assert.True(t, IsEncrypted(secret), "expected secret to be encrypted")
+			assert.Equal(t, "Local", secret.Annotations[constants.EncryptionProviderAnnotation], "unexpected provider")
+			assert.NotEqual(t, []byte("kubeconfig"), secret.Data[constants.KubeconfigSecretKey], "expected data to be encrypted")
+			assert.Error(t, Encrypt(context.TODO(), p, secret), "expected error encrypting twice")
+
+			require.NoError(t, Decrypt(context.TODO(), p, secret), "unexpected error decrypting")
+			assert.False(t, IsEncrypted(secret), "expected secret to be decrypted")
+			assert.Equal(t, map[string][]byte{
+				constants.KubeconfigSecretKey: []byte("kubeconfig"),
+				"other":                       []byte("other"),
+			}, secret.Data, "unexpected decrypted data")
+		})
+	}
+}
+
+func TestDecryptSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: hiveNamespace, Name: keySecretName},
+		Data:       map[string][]byte{localKeySecretKey: bytes.Repeat([]byte{3}, 32)},
+	}).Build()
+
+	dir, err := ioutil.TempDir("", "secretencryption")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "config")
+	require.NoError(t, ioutil.WriteFile(configFile, []byte(`{"kms":{"local":{"secretRef":{"name":"local-key"}}}}`), 0600))
+	defer os.Unsetenv(constants.AdminSecretEncryptionConfigFileEnvVar)
+	defer os.Unsetenv(constants.HiveNamespaceEnvVar)
+	os.Setenv(constants.HiveNamespaceEnvVar, hiveNamespace)
+
+	plain := &corev1.Secret{Data: map[string][]byte{"password": []byte("secret")}}
+	encrypted, err := DecryptSecret(context.TODO(), c, plain)
+	require.NoError(t, err, "unexpected error decrypting plain secret without config")
+	assert.False(t, encrypted, "expected plain secret to be reported as not encrypted")
+
+	os.Setenv(constants.AdminSecretEncryptionConfigFileEnvVar, configFile)
+	secret := plain.DeepCopy()
+	require.NoError(t, EncryptSecret(context.TODO(), c, secret), "unexpected error encrypting")
+	encrypted, err = DecryptSecret(context.TODO(), c, secret)
+	require.NoError(t, err, "unexpected error decrypting")
+	assert.True(t, encrypted, "expected secret to be reported as encrypted")
+	assert.Equal(t, plain.Data, secret.Data, "unexpected decrypted data")
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestDataKeyCache(t *testing.T) {
+	clock := &fakeClock{now: time.Now()}
+	keys := &dataKeyCache{keys: cache.NewLRUExpireCacheWithClock(2, clock)}
+	unwraps := 0
+	get := func(wrapped string) {
+		key, err := keys.get([]byte(wrapped), func() ([]byte, error) {
+			unwraps++
+			return []byte("key-" + wrapped), nil
+		})
+		require.NoError(t, err, "unexpected error getting data key")
+		assert.Equal(t, "key-"+wrapped, string(key), "unexpected data key")
+	}
+
+	get("a")
+	get("a")
+	assert.Equal(t, 1, unwraps, "expected cached data key to be used")
+
+	get("b")
+	get("c")
+	get("a")
+	assert.Equal(t, 4, unwraps, "expected least recently used data key to be evicted")
+
+	clock.now = clock.now.Add(dataKeyCacheTTL + time.Second)
+	get("a")
+	assert.Equal(t, 5, unwraps, "expected data key to expire")
+}
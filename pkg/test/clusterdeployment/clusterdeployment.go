@@ -188,6 +188,13 @@ func WithHibernateAfter(dur time.Duration) Option {
 	}
 }
 
+// WithMaintenanceWindows sets the maintenance windows of the ClusterDeployment.
+func WithMaintenanceWindows(windows ...hivev1.MaintenanceWindow) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.MaintenanceWindows = windows
+	}
+}
+
 // WithAWSPlatform sets the specified aws platform on the supplied object.
 func WithAWSPlatform(platform *hivev1aws.Platform) Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
@@ -104,6 +104,18 @@ func WithClusterDeploymentLabels(labels map[string]string) Option {
 	}
 }
 
+func WithNamespaceTemplate(template string) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.NamespaceTemplate = template
+	}
+}
+
+func WithBrokenClusterTimeout(timeout time.Duration) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.BrokenClusterTimeout = &metav1.Duration{Duration: timeout}
+	}
+}
+
 func WithPlatform(platform hivev1.Platform) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
 		clusterPool.Spec.Platform = platform
@@ -130,6 +142,12 @@ func WithInstallConfigSecretTemplateRef(name string) Option {
 	}
 }
 
+func WithHostedCluster(hostedCluster *hivev1.HostedClusterConfig) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.HostedCluster = hostedCluster
+	}
+}
+
 func WithInstallAttemptsLimit(ial int32) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
 		clusterPool.Spec.InstallAttemptsLimit = &ial
@@ -166,6 +184,18 @@ func WithMaximumClaimLifetime(d time.Duration) Option {
 	}
 }
 
+func WithClaimReleaseHooks(hooks ...hivev1.TeardownHook) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.ClaimReleaseHooks = hooks
+	}
+}
+
+func WithReleasePolicy(policy hivev1.ClusterPoolReleasePolicy) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.ReleasePolicy = policy
+	}
+}
+
 // WithCondition adds the specified condition to the ClusterPool
 func WithCondition(cond hivev1.ClusterPoolCondition) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
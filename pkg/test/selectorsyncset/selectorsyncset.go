@@ -125,3 +125,9 @@ func WithPatches(patches ...hivev1.SyncObjectPatch) Option {
 		selectorSyncSet.Spec.Patches = patches
 	}
 }
+
+func WithResourcesToDelete(refs ...hivev1.SyncObjectReference) Option {
+	return func(selectorSyncSet *hivev1.SelectorSyncSet) {
+		selectorSyncSet.Spec.ResourcesToDelete = refs
+	}
+}
@@ -127,3 +127,9 @@ func WithPatches(patches ...hivev1.SyncObjectPatch) Option {
 		syncSet.Spec.Patches = patches
 	}
 }
+
+func WithResourcesToDelete(refs ...hivev1.SyncObjectReference) Option {
+	return func(syncSet *hivev1.SyncSet) {
+		syncSet.Spec.ResourcesToDelete = refs
+	}
+}
@@ -0,0 +1,185 @@
+package v1
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func clusterAccessTokenSpec(clusterRole string) hivev1.ClusterAccessTokenSpec {
+	return hivev1.ClusterAccessTokenSpec{
+		ClusterDeploymentRef: corev1.LocalObjectReference{Name: "test-cluster"},
+		ClusterRoleName:      clusterRole,
+	}
+}
+
+func TestClusterAccessTokenValidatingResource(t *testing.T) {
+	// Arrange
+	data := NewClusterAccessTokenValidatingAdmissionHook(createDecoder(t))
+	expectedPlural := schema.GroupVersionResource{
+		Group:    "admission.hive.openshift.io",
+		Version:  "v1",
+		Resource: "clusteraccesstokenvalidators",
+	}
+	expectedSingular := "clusteraccesstokenvalidator"
+
+	// Act
+	plural, singular := data.ValidatingResource()
+
+	// Assert
+	assert.Equal(t, expectedPlural, plural)
+	assert.Equal(t, expectedSingular, singular)
+}
+
+func TestClusterAccessTokenInitialize(t *testing.T) {
+	// Arrange
+	data := NewClusterAccessTokenValidatingAdmissionHook(createDecoder(t))
+
+	// Act
+	err := data.Initialize(nil, nil)
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func TestClusterAccessTokenValidate(t *testing.T) {
+	cases := []struct {
+		name            string
+		allowedRoles    string
+		newSpec         hivev1.ClusterAccessTokenSpec
+		oldSpec         hivev1.ClusterAccessTokenSpec
+		newObjectRaw    []byte
+		oldObjectRaw    []byte
+		operation       admissionv1beta1.Operation
+		expectedAllowed bool
+		gvr             *metav1.GroupVersionResource
+	}{
+		{
+			name:            "Test create with default allowed role",
+			newSpec:         clusterAccessTokenSpec("view"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create with role not allowed by default",
+			newSpec:         clusterAccessTokenSpec("cluster-admin"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with configured allowed role",
+			allowedRoles:    "view,cluster-reader",
+			newSpec:         clusterAccessTokenSpec("cluster-reader"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test create with role not configured",
+			allowedRoles:    "cluster-reader",
+			newSpec:         clusterAccessTokenSpec("view"),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test update to role not allowed",
+			newSpec:         clusterAccessTokenSpec("cluster-admin"),
+			oldSpec:         clusterAccessTokenSpec("view"),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test update of token with role no longer allowed",
+			allowedRoles:    "cluster-reader",
+			newSpec:         clusterAccessTokenSpec("view"),
+			oldSpec:         clusterAccessTokenSpec("view"),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test unable to marshal new object during create",
+			newObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test unable to marshal old object during update",
+			newSpec:         clusterAccessTokenSpec("view"),
+			oldObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test that we don't validate deletes",
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test doesn't validate with right group and version, wrong resource",
+			gvr: &metav1.GroupVersionResource{
+				Group:    "hive.openshift.io",
+				Version:  "v1",
+				Resource: "not the right resource",
+			},
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			os.Setenv(constants.ClusterAccessTokenAllowedClusterRolesEnvVar, tc.allowedRoles)
+			defer os.Unsetenv(constants.ClusterAccessTokenAllowedClusterRolesEnvVar)
+			data := NewClusterAccessTokenValidatingAdmissionHook(createDecoder(t))
+			newObject := &hivev1.ClusterAccessToken{
+				Spec: tc.newSpec,
+			}
+			oldObject := &hivev1.ClusterAccessToken{
+				Spec: tc.oldSpec,
+			}
+
+			if tc.newObjectRaw == nil {
+				tc.newObjectRaw, _ = json.Marshal(newObject)
+			}
+
+			if tc.oldObjectRaw == nil {
+				tc.oldObjectRaw, _ = json.Marshal(oldObject)
+			}
+
+			if tc.gvr == nil {
+				tc.gvr = &metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "clusteraccesstokens",
+				}
+			}
+
+			request := &admissionv1beta1.AdmissionRequest{
+				Operation: tc.operation,
+				Resource:  *tc.gvr,
+				Object: runtime.RawExtension{
+					Raw: tc.newObjectRaw,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: tc.oldObjectRaw,
+				},
+			}
+
+			// Act
+			response := data.Validate(request)
+
+			// Assert
+			assert.Equal(t, tc.expectedAllowed, response.Allowed)
+		})
+	}
+}
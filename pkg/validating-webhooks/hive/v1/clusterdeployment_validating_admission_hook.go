@@ -2,10 +2,12 @@ package v1
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -24,12 +26,16 @@ import (
 
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/openshift/installer/pkg/validate"
+
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	hivecontractsv1alpha1 "github.com/openshift/hive/apis/hivecontracts/v1alpha1"
 
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/manageddns"
 	"github.com/openshift/hive/pkg/util/contracts"
 )
@@ -44,7 +50,13 @@ const (
 )
 
 var (
-	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "InstallAttemptsLimit", "Platform.AgentBareMetal.AgentSelector"}
+	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "DeletionProtection", "ClusterPoolRef", "PowerState", "HibernateAfter", "InstallAttemptsLimit", "Platform.AgentBareMetal.AgentSelector", "Provisioning.FIPS", "Provisioning.EtcdEncryption", "Provisioning.Topology", "RemediationPolicy", "MaintenanceWindows", "Proxy", "PropagatedNodeLabels", "TeardownHooks", "ControlPlaneMachines", "ClusterAutoscaler"}
+
+	validMaintenanceWindowDays = sets.NewString("Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday")
+
+	// breakGlassFields are the immutable fields which can be allowed to change with the AllowImmutableChangesAnnotation.
+	// Changes to them are reported as errors on the field, rather than in the diff of the other immutable fields.
+	breakGlassFields = sets.NewString("platform", "baseDomain", "clusterName", "installed")
 )
 
 // ClusterDeploymentValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
@@ -55,6 +67,7 @@ type ClusterDeploymentValidatingAdmissionHook struct {
 	fs                   *featureSet
 	awsPrivateLinkConfig *hivev1.AWSPrivateLinkConfig
 	supportedContracts   contracts.SupportedContractImplementationsList
+	quotaChecker         *tenantQuotaChecker
 }
 
 // NewClusterDeploymentValidatingAdmissionHook constructs a new ClusterDeploymentValidatingAdmissionHook
@@ -117,7 +130,13 @@ func (a *ClusterDeploymentValidatingAdmissionHook) Initialize(kubeClientConfig *
 		"version":  clusterDeploymentAdmissionVersion,
 		"resource": "clusterdeploymentvalidator",
 	}).Info("Initializing validation REST resource")
-	return nil // No initialization needed right now.
+
+	quotaChecker, err := newTenantQuotaChecker(kubeClientConfig, stopCh)
+	if err != nil {
+		return err
+	}
+	a.quotaChecker = quotaChecker
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -298,8 +317,31 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 		if cd.Spec.Provisioning.SSHPrivateKeySecretRef != nil && cd.Spec.Provisioning.SSHPrivateKeySecretRef.Name == "" {
 			allErrs = append(allErrs, field.Required(specPath.Child("provisioning", "sshPrivateKeySecretRef", "name"), "must specify a name for the ssh private key secret if the ssh private key secret is specified"))
 		}
+		switch topology := cd.Spec.Provisioning.Topology; topology {
+		case "", hivev1.HighlyAvailableClusterTopology, hivev1.CompactClusterTopology, hivev1.SingleNodeClusterTopology:
+		default:
+			allErrs = append(allErrs, field.NotSupported(specPath.Child("provisioning", "topology"), topology, []string{
+				string(hivev1.HighlyAvailableClusterTopology),
+				string(hivev1.CompactClusterTopology),
+				string(hivev1.SingleNodeClusterTopology),
+			}))
+		}
+		if ibi := cd.Spec.Provisioning.ImageBasedInstall; ibi != nil {
+			allErrs = append(allErrs, validateImageBasedInstall(specPath, &cd.Spec, ibi)...)
+		}
 	}
 
+	allErrs = append(allErrs, validateRemediationPolicy(specPath.Child("remediationPolicy"), cd.Spec.RemediationPolicy)...)
+	allErrs = append(allErrs, validateMaintenanceWindows(specPath.Child("maintenanceWindows"), cd.Spec.MaintenanceWindows)...)
+	allErrs = append(allErrs, validateProxy(specPath.Child("proxy"), cd.Spec.Proxy)...)
+	allErrs = append(allErrs, validatePropagatedNodeLabels(specPath.Child("propagatedNodeLabels"), cd.Spec.PropagatedNodeLabels)...)
+	allErrs = append(allErrs, validateTeardownHooks(specPath.Child("teardownHooks"), cd.Spec.TeardownHooks)...)
+	allErrs = append(allErrs, validateControlPlaneMachines(specPath.Child("controlPlaneMachines"), cd.Spec.Platform, cd.Spec.ControlPlaneMachines)...)
+	allErrs = append(allErrs, validateClusterAutoscaler(specPath.Child("clusterAutoscaler"), cd.Spec.ClusterAutoscaler)...)
+	allErrs = append(allErrs, controllerutils.ValidateClusterOverrideAnnotations(cd.Annotations, field.NewPath("metadata", "annotations"))...)
+	_, annotationErrs := allowedImmutableChanges(field.NewPath("metadata", "annotations"), cd.Annotations)
+	allErrs = append(allErrs, annotationErrs...)
+
 	if cd.Spec.ClusterInstallRef != nil {
 		supported := a.supportedContracts.SupportedImplementations(hivecontractsv1alpha1.ClusterInstallContractName)
 		if len(supported) == 0 {
@@ -319,6 +361,10 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 		}
 	}
 
+	if len(allErrs) == 0 {
+		allErrs = append(allErrs, a.quotaChecker.validateClusterDeploymentCreate(cd, contextLogger)...)
+	}
+
 	if len(allErrs) > 0 {
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
 		return &admissionv1beta1.AdmissionResponse{
@@ -359,6 +405,280 @@ func validateAWSPrivateLink(path *field.Path, platform *hivev1aws.Platform, conf
 	return allErrs
 }
 
+func validateMaintenanceWindows(path *field.Path, windows []hivev1.MaintenanceWindow) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, window := range windows {
+		windowPath := path.Index(i)
+		if _, err := time.Parse(controllerutils.MaintenanceWindowStartTimeLayout, window.StartTime); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("startTime"), window.StartTime, "must be formatted as HH:MM"))
+		}
+		if d := window.Duration.Duration; d <= 0 || d > 7*24*time.Hour {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("duration"), d.String(), "must be greater than zero and at most a week"))
+		}
+		for j, day := range window.Days {
+			if !validMaintenanceWindowDays.Has(string(day)) {
+				allErrs = append(allErrs, field.NotSupported(windowPath.Child("days").Index(j), day, validMaintenanceWindowDays.List()))
+			}
+		}
+	}
+	return allErrs
+}
+
+// allowedImmutableChanges returns the immutable fields which the AllowImmutableChangesAnnotation of a
+// ClusterDeployment allows to change.
+func allowedImmutableChanges(path *field.Path, annotations map[string]string) (sets.String, field.ErrorList) {
+	allowed := sets.NewString()
+	allErrs := field.ErrorList{}
+	value, ok := annotations[constants.AllowImmutableChangesAnnotation]
+	if !ok {
+		return allowed, allErrs
+	}
+	for _, f := range strings.Split(value, ",") {
+		f = strings.TrimSpace(f)
+		if !breakGlassFields.Has(f) {
+			allErrs = append(allErrs, field.NotSupported(path.Key(constants.AllowImmutableChangesAnnotation), f, breakGlassFields.List()))
+			continue
+		}
+		allowed.Insert(f)
+	}
+	return allowed, allErrs
+}
+
+// immutablePlatform returns the platform without its mutable fields.
+// validateAWSEgress checks that the existing NAT gateways for the egress traffic of the cluster are listed once each.
+func validateAWSEgress(path *field.Path, egress *hivev1aws.Egress) field.ErrorList {
+	allErrs := field.ErrorList{}
+	natGatewaysPath := path.Child("natGatewayIDs")
+	if len(egress.NATGatewayIDs) == 0 {
+		allErrs = append(allErrs, field.Required(natGatewaysPath, "must specify at least one NAT gateway"))
+	}
+	seen := sets.NewString()
+	for i, id := range egress.NATGatewayIDs {
+		if !strings.HasPrefix(id, "nat-") {
+			allErrs = append(allErrs, field.Invalid(natGatewaysPath.Index(i), id, "must be the ID of a NAT gateway"))
+		}
+		if seen.Has(id) {
+			allErrs = append(allErrs, field.Duplicate(natGatewaysPath.Index(i), id))
+		}
+		seen.Insert(id)
+	}
+	return allErrs
+}
+
+func immutablePlatform(platform hivev1.Platform) *hivev1.Platform {
+	p := platform.DeepCopy()
+	if p.AgentBareMetal != nil {
+		p.AgentBareMetal.AgentSelector = metav1.LabelSelector{}
+	}
+	return p
+}
+
+func validatePropagatedNodeLabels(path *field.Path, keys []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := sets.NewString()
+	for i, key := range keys {
+		for _, msg := range validation.IsQualifiedName(key) {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), key, msg))
+		}
+		if seen.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(path.Index(i), key))
+		}
+		seen.Insert(key)
+	}
+	return allErrs
+}
+
+func validateControlPlaneMachines(path *field.Path, platform hivev1.Platform, machines *hivev1.ControlPlaneMachineSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if machines == nil {
+		return allErrs
+	}
+	if platform.AWS == nil && platform.GCP == nil && platform.Azure == nil {
+		allErrs = append(allErrs, field.Forbidden(path, "the control plane can only be resized on AWS, GCP and Azure"))
+	}
+	if machines.Type == "" {
+		allErrs = append(allErrs, field.Required(path.Child("type"), "must specify the instance type of the control plane machines"))
+	}
+	return allErrs
+}
+
+func validateClusterAutoscaler(path *field.Path, settings *hivev1.ClusterAutoscalerSettings) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if settings == nil {
+		return allErrs
+	}
+	if scaleDown := settings.ScaleDown; scaleDown != nil {
+		scaleDownPath := path.Child("scaleDown")
+		for _, d := range []struct {
+			name     string
+			duration *metav1.Duration
+		}{
+			{"delayAfterAdd", scaleDown.DelayAfterAdd},
+			{"delayAfterDelete", scaleDown.DelayAfterDelete},
+			{"delayAfterFailure", scaleDown.DelayAfterFailure},
+			{"unneededTime", scaleDown.UnneededTime},
+		} {
+			if d.duration != nil && d.duration.Duration < 0 {
+				allErrs = append(allErrs, field.Invalid(scaleDownPath.Child(d.name), d.duration.Duration.String(), "must not be negative"))
+			}
+		}
+		if t := scaleDown.UtilizationThreshold; t != nil {
+			if v, err := strconv.ParseFloat(*t, 64); err != nil || v < 0 || v > 1 {
+				allErrs = append(allErrs, field.Invalid(scaleDownPath.Child("utilizationThreshold"), *t, "must be a decimal between 0 and 1"))
+			}
+		}
+	}
+	if limits := settings.ResourceLimits; limits != nil {
+		limitsPath := path.Child("resourceLimits")
+		if limits.MaxNodesTotal != nil && *limits.MaxNodesTotal < 0 {
+			allErrs = append(allErrs, field.Invalid(limitsPath.Child("maxNodesTotal"), *limits.MaxNodesTotal, "must not be negative"))
+		}
+		for _, r := range []struct {
+			name  string
+			limit *hivev1.ClusterAutoscalerResourceRange
+		}{
+			{"cores", limits.Cores},
+			{"memory", limits.Memory},
+		} {
+			if r.limit == nil {
+				continue
+			}
+			if r.limit.Min < 0 {
+				allErrs = append(allErrs, field.Invalid(limitsPath.Child(r.name, "min"), r.limit.Min, "must not be negative"))
+			}
+			if r.limit.Max < r.limit.Min {
+				allErrs = append(allErrs, field.Invalid(limitsPath.Child(r.name, "max"), r.limit.Max, "must not be less than min"))
+			}
+		}
+		seen := sets.NewString()
+		for i, gpu := range limits.GPUs {
+			gpuPath := limitsPath.Child("gpus").Index(i)
+			switch {
+			case gpu.Type == "":
+				allErrs = append(allErrs, field.Required(gpuPath.Child("type"), "must specify the type of the GPUs"))
+			case seen.Has(gpu.Type):
+				allErrs = append(allErrs, field.Duplicate(gpuPath.Child("type"), gpu.Type))
+			}
+			seen.Insert(gpu.Type)
+			if gpu.Min < 0 {
+				allErrs = append(allErrs, field.Invalid(gpuPath.Child("min"), gpu.Min, "must not be negative"))
+			}
+			if gpu.Max < 1 || gpu.Max < gpu.Min {
+				allErrs = append(allErrs, field.Invalid(gpuPath.Child("max"), gpu.Max, "must be at least 1 and not less than min"))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateTeardownHooks(path *field.Path, hooks []hivev1.TeardownHook) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := sets.NewString()
+	for i, hook := range hooks {
+		hookPath := path.Index(i)
+		switch {
+		case hook.Name == "":
+			allErrs = append(allErrs, field.Required(hookPath.Child("name"), "name is required"))
+		case seen.Has(hook.Name):
+			allErrs = append(allErrs, field.Duplicate(hookPath.Child("name"), hook.Name))
+		}
+		seen.Insert(hook.Name)
+		if len(hook.Resources) == 0 {
+			allErrs = append(allErrs, field.Required(hookPath.Child("resources"), "at least one resource is required"))
+		}
+		allErrs = append(allErrs, validateResources(hook.Resources, hookPath.Child("resources"))...)
+		for j, resource := range hook.Resources {
+			u := &unstructured.Unstructured{}
+			if err := u.UnmarshalJSON(resource.Raw); err != nil {
+				// Reported by validateResources
+				continue
+			}
+			if u.GetName() == "" {
+				allErrs = append(allErrs, field.Required(hookPath.Child("resources").Index(j).Child("metadata", "name"), "name is required"))
+			}
+		}
+		if hook.Timeout != nil && hook.Timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(hookPath.Child("timeout"), hook.Timeout.Duration.String(), "must be positive"))
+		}
+	}
+	return allErrs
+}
+
+func validateProxy(path *field.Path, proxy *hivev1.ClusterProxy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if proxy == nil {
+		return allErrs
+	}
+	if proxy.HTTPProxy == "" && proxy.HTTPSProxy == "" {
+		allErrs = append(allErrs, field.Required(path, "must specify at least one of httpProxy or httpsProxy"))
+	}
+	if proxy.HTTPProxy != "" {
+		if err := validate.URIWithProtocol(proxy.HTTPProxy, "http"); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("httpProxy"), proxy.HTTPProxy, err.Error()))
+		}
+	}
+	if proxy.HTTPSProxy != "" {
+		if err := validate.URIWithProtocol(proxy.HTTPSProxy, "http"); err != nil {
+			if err := validate.URIWithProtocol(proxy.HTTPSProxy, "https"); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("httpsProxy"), proxy.HTTPSProxy, "must use http or https protocol"))
+			}
+		}
+	}
+	if proxy.NoProxy != "" {
+		for _, entry := range strings.Split(proxy.NoProxy, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "*" || validate.IP(entry) == nil {
+				continue
+			}
+			if _, _, err := net.ParseCIDR(entry); err == nil {
+				continue
+			}
+			if err := validate.NoProxyDomainName(entry); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("noProxy"), entry, "each entry must be a domain, an IP address, a CIDR or \"*\""))
+			}
+		}
+	}
+	if proxy.AdditionalTrustBundle != "" {
+		if err := validate.CABundle(proxy.AdditionalTrustBundle); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("additionalTrustBundle"), "", err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func validateRemediationPolicy(path *field.Path, policy *hivev1.RemediationPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if policy == nil {
+		return allErrs
+	}
+	if policy.UnhealthyDuration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("unhealthyDuration"), policy.UnhealthyDuration.Duration.String(), "must be greater than zero"))
+	}
+	if len(policy.Actions) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("actions"), "must specify at least one remediation action"))
+	}
+	for i, action := range policy.Actions {
+		actionPath := path.Child("actions").Index(i)
+		switch action.Type {
+		case hivev1.WebhookRemediationAction:
+			if action.Webhook == nil || action.Webhook.URL == "" {
+				allErrs = append(allErrs, field.Required(actionPath.Child("webhook", "url"), "must specify a webhook URL for webhook actions"))
+			}
+		case hivev1.RestartMachinesRemediationAction, hivev1.ApproveCSRsRemediationAction:
+			if action.Webhook != nil {
+				allErrs = append(allErrs, field.Forbidden(actionPath.Child("webhook"), "webhook can only be set for webhook actions"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(actionPath.Child("type"), action.Type, []string{
+				string(hivev1.RestartMachinesRemediationAction),
+				string(hivev1.ApproveCSRsRemediationAction),
+				string(hivev1.WebhookRemediationAction),
+			}))
+		}
+	}
+	return allErrs
+}
+
 /* TODO: move to explicit validation for AgentClusterInstall */
 /*
 func validateAgentInstallStrategy(specPath *field.Path, cd *hivev1.ClusterDeployment) field.ErrorList {
@@ -430,6 +750,26 @@ func validatefeatureGates(decoder *admission.Decoder, admissionSpec *admissionv1
 	return nil
 }
 
+// validateImageBasedInstall validates the image-based install of a ClusterDeployment, which configures a single host
+// pre-installed from a seed image over SSH.
+func validateImageBasedInstall(specPath *field.Path, spec *hivev1.ClusterDeploymentSpec, ibi *hivev1.ImageBasedInstall) field.ErrorList {
+	allErrs := field.ErrorList{}
+	provisioningPath := specPath.Child("provisioning")
+	if ibi.HostAddress == "" {
+		allErrs = append(allErrs, field.Required(provisioningPath.Child("imageBasedInstall", "hostAddress"), "must specify the address of the host"))
+	}
+	if spec.Provisioning.Topology != hivev1.SingleNodeClusterTopology {
+		allErrs = append(allErrs, field.Invalid(provisioningPath.Child("topology"), spec.Provisioning.Topology, "image-based installs require the SingleNode topology"))
+	}
+	if spec.Provisioning.SSHPrivateKeySecretRef == nil {
+		allErrs = append(allErrs, field.Required(provisioningPath.Child("sshPrivateKeySecretRef"), "image-based installs reach the host over SSH"))
+	}
+	if spec.Platform.BareMetal == nil {
+		allErrs = append(allErrs, field.Forbidden(provisioningPath.Child("imageBasedInstall"), "image-based installs require the bare metal platform"))
+	}
+	return allErrs
+}
+
 func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.ErrorList {
 	allErrs := field.ErrorList{}
 	numberOfPlatforms := 0
@@ -445,6 +785,9 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if aws.Region == "" {
 			allErrs = append(allErrs, field.Required(awsPath.Child("region"), "must specify AWS region"))
 		}
+		if aws.Egress != nil {
+			allErrs = append(allErrs, validateAWSEgress(awsPath.Child("egress"), aws.Egress)...)
+		}
 	}
 	if azure := platform.Azure; azure != nil {
 		numberOfPlatforms++
@@ -458,6 +801,15 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if azure.BaseDomainResourceGroupName == "" {
 			allErrs = append(allErrs, field.Required(azurePath.Child("baseDomainResourceGroupName"), "must specify the Azure resource group for the base domain"))
 		}
+		if egress := azure.Egress; egress != nil {
+			natGatewayPath := azurePath.Child("egress", "natGatewayID")
+			switch {
+			case egress.NATGatewayID == "":
+				allErrs = append(allErrs, field.Required(natGatewayPath, "must specify the resource ID of the NAT gateway"))
+			case !strings.Contains(strings.ToLower(egress.NATGatewayID), "/providers/microsoft.network/natgateways/"):
+				allErrs = append(allErrs, field.Invalid(natGatewayPath, egress.NATGatewayID, "must be the resource ID of a NAT gateway"))
+			}
+		}
 	}
 	if gcp := platform.GCP; gcp != nil {
 		numberOfPlatforms++
@@ -468,6 +820,9 @@ func validateClusterPlatform(path *field.Path, platform hivev1.Platform) field.E
 		if gcp.Region == "" {
 			allErrs = append(allErrs, field.Required(gcpPath.Child("region"), "must specify GCP region"))
 		}
+		if gcp.Egress != nil && gcp.Egress.CloudNATRouter == "" {
+			allErrs = append(allErrs, field.Required(gcpPath.Child("egress", "cloudNATRouter"), "must specify the Cloud Router of the Cloud NAT"))
+		}
 	}
 	if openstack := platform.OpenStack; openstack != nil {
 		numberOfPlatforms++
@@ -632,6 +987,21 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 	allErrs := field.ErrorList{}
 	specPath := field.NewPath("spec")
 
+	allowedChanges, annotationErrs := allowedImmutableChanges(field.NewPath("metadata", "annotations"), cd.Annotations)
+	allErrs = append(allErrs, annotationErrs...)
+	if allowedChanges.Len() > 0 {
+		contextLogger.WithField("fields", allowedChanges.List()).Warn("allowing changes to immutable fields")
+	}
+	if !allowedChanges.Has("platform") {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(immutablePlatform(cd.Spec.Platform), immutablePlatform(oldObject.Spec.Platform), specPath.Child("platform"))...)
+	}
+	if !allowedChanges.Has("baseDomain") {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(cd.Spec.BaseDomain, oldObject.Spec.BaseDomain, specPath.Child("baseDomain"))...)
+	}
+	if !allowedChanges.Has("clusterName") {
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(cd.Spec.ClusterName, oldObject.Spec.ClusterName, specPath.Child("clusterName"))...)
+	}
+
 	if cd.Spec.Installed {
 		if cd.Spec.ClusterMetadata != nil {
 			if oldObject.Spec.Installed {
@@ -641,11 +1011,37 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 			allErrs = append(allErrs, field.Required(specPath.Child("clusterMetadata"), "installed cluster must have cluster metadata"))
 		}
 	} else {
-		if oldObject.Spec.Installed {
+		if oldObject.Spec.Installed && !allowedChanges.Has("installed") {
 			allErrs = append(allErrs, field.Invalid(specPath.Child("installed"), cd.Spec.Installed, "cannot make uninstalled once installed"))
 		}
 	}
 
+	// FIPS, etcd encryption and topology are applied at install time, and so can only be changed before the cluster is
+	// installed.
+	if oldObject.Spec.Installed {
+		var oldFIPS, newFIPS *bool
+		var oldEncryption, newEncryption *configv1.APIServerEncryption
+		var oldTopology, newTopology hivev1.ClusterTopology
+		if p := oldObject.Spec.Provisioning; p != nil {
+			oldFIPS, oldEncryption, oldTopology = p.FIPS, p.EtcdEncryption, p.Topology
+		}
+		if p := cd.Spec.Provisioning; p != nil {
+			newFIPS, newEncryption, newTopology = p.FIPS, p.EtcdEncryption, p.Topology
+		}
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newFIPS, oldFIPS, specPath.Child("provisioning", "fips"))...)
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newEncryption, oldEncryption, specPath.Child("provisioning", "etcdEncryption"))...)
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newTopology, oldTopology, specPath.Child("provisioning", "topology"))...)
+	}
+
+	allErrs = append(allErrs, validateRemediationPolicy(specPath.Child("remediationPolicy"), cd.Spec.RemediationPolicy)...)
+	allErrs = append(allErrs, validateMaintenanceWindows(specPath.Child("maintenanceWindows"), cd.Spec.MaintenanceWindows)...)
+	allErrs = append(allErrs, validateProxy(specPath.Child("proxy"), cd.Spec.Proxy)...)
+	allErrs = append(allErrs, validatePropagatedNodeLabels(specPath.Child("propagatedNodeLabels"), cd.Spec.PropagatedNodeLabels)...)
+	allErrs = append(allErrs, validateTeardownHooks(specPath.Child("teardownHooks"), cd.Spec.TeardownHooks)...)
+	allErrs = append(allErrs, validateControlPlaneMachines(specPath.Child("controlPlaneMachines"), cd.Spec.Platform, cd.Spec.ControlPlaneMachines)...)
+	allErrs = append(allErrs, validateClusterAutoscaler(specPath.Child("clusterAutoscaler"), cd.Spec.ClusterAutoscaler)...)
+	allErrs = append(allErrs, controllerutils.ValidateClusterOverrideAnnotations(cd.Annotations, field.NewPath("metadata", "annotations"))...)
+
 	// Validate the ClusterPoolRef:
 	switch oldPoolRef, newPoolRef := oldObject.Spec.ClusterPoolRef, cd.Spec.ClusterPoolRef; {
 	case oldPoolRef != nil && newPoolRef != nil:
@@ -722,6 +1118,14 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateDelete(request *admis
 		}
 	}
 
+	if controllerutils.HasDeletionProtection(oldObject) && !controllerutils.IsDeleteConfirmed(oldObject) {
+		allErrs = append(allErrs, field.Forbidden(
+			field.NewPath("spec", "deletionProtection", "enabled"),
+			fmt.Sprintf("deletion protection is enabled, set the %s annotation to %q to confirm deletion",
+				constants.ConfirmDeleteAnnotation, oldObject.Name),
+		))
+	}
+
 	if len(allErrs) > 0 {
 		logger.WithError(allErrs.ToAggregate()).Info("failed validation")
 		status := errors.NewInvalid(schemaGVK(request.Kind).GroupKind(), request.Name, allErrs).Status()
@@ -744,6 +1148,8 @@ func hasChangedImmutableField(oldObject, cd *hivev1.ClusterDeploymentSpec) (bool
 	opts := cmp.Options{
 		cmpopts.EquateEmpty(),
 		cmpopts.IgnoreFields(hivev1.ClusterDeploymentSpec{}, mutableFields...),
+		// The break-glass fields are validated separately.
+		cmpopts.IgnoreFields(hivev1.ClusterDeploymentSpec{}, "Platform", "BaseDomain", "ClusterName"),
 		cmp.Reporter(r),
 	}
 	return !cmp.Equal(oldObject, cd, opts), r.String()
@@ -826,6 +1232,17 @@ func validateIngressServingCertificateExists(cd *hivev1.ClusterDeploymentSpec) b
 	return true
 }
 
+// a load balancer scope only applies to the LoadBalancerService publishing strategy
+func validateIngressLoadBalancerScope(cd *hivev1.ClusterDeploymentSpec) bool {
+	for _, ingress := range cd.Ingress {
+		strategy := ingress.EndpointPublishingStrategy
+		if strategy != nil && strategy.LoadBalancerScope != "" && strategy.Type != hivev1.IngressLoadBalancerServiceStrategyType {
+			return false
+		}
+	}
+	return true
+}
+
 // empty ingress is allowed (for create), but if it's non-zero
 // it must include an entry for 'default'
 func validateIngressList(cd *hivev1.ClusterDeploymentSpec) bool {
@@ -915,6 +1332,18 @@ func validateIngress(cd *hivev1.ClusterDeployment, contextLogger *log.Entry) *ad
 		}
 	}
 
+	if !validateIngressLoadBalancerScope(&cd.Spec) {
+		message := "Ingress load balancer scope may only be set with the LoadBalancerService endpoint publishing strategy"
+		contextLogger.Infof("Failed validation: %v", message)
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: message,
+			},
+		}
+	}
+
 	// everything passed
 	return nil
 }
@@ -943,6 +1372,17 @@ func validateCertificateBundles(cd *hivev1.ClusterDeployment, contextLogger *log
 				},
 			}
 		}
+		if cm := certBundle.CertManager; cm != nil && (cm.IssuerRef.Name == "" || len(cm.DNSNames) == 0) {
+			message := "Certificate bundle issued by cert-manager must specify an issuer and at least one DNS name"
+			contextLogger.Infof("Failed validation: %v", message)
+			return &admissionv1beta1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+					Message: message,
+				},
+			}
+		}
 	}
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -13,9 +14,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+
+	configv1 "github.com/openshift/api/config/v1"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1agent "github.com/openshift/hive/apis/hive/v1/agent"
+	hivev1baremetal "github.com/openshift/hive/apis/hive/v1/baremetal"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
@@ -156,6 +161,15 @@ func validAgentBareMetalClusterDeployment() *hivev1.ClusterDeployment {
 	return cd
 }
 
+func validImageBasedClusterDeployment() *hivev1.ClusterDeployment {
+	cd := clusterDeploymentTemplate()
+	cd.Spec.Platform.BareMetal = &hivev1baremetal.Platform{}
+	cd.Spec.Provisioning.Topology = hivev1.SingleNodeClusterTopology
+	cd.Spec.Provisioning.SSHPrivateKeySecretRef = &corev1.LocalObjectReference{Name: "ssh-key"}
+	cd.Spec.Provisioning.ImageBasedInstall = &hivev1.ImageBasedInstall{HostAddress: "192.168.111.20"}
+	return cd
+}
+
 // Meant to be used to compare new and old as the same values.
 func validClusterDeploymentSameValues() *hivev1.ClusterDeployment {
 	return validAWSClusterDeployment()
@@ -218,63 +232,748 @@ func TestClusterDeploymentValidate(t *testing.T) {
 		supportedContracts  contracts.SupportedContractImplementationsList
 	}{
 		{
-			name:            "Test valid create",
-			newObject:       validAWSClusterDeployment(),
+			name:            "Test valid create",
+			newObject:       validAWSClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test Delete Operation is allowed even with mismatch objects",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       validClusterDeploymentDifferentImmutableValue(),
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test Update Operation is allowed with same data",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       validClusterDeploymentSameValues(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "Test Update Operation is allowed with different mutable data",
+			oldObject:       validAWSClusterDeployment(),
+			newObject:       validClusterDeploymentDifferentMutableValue(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test reject missing InstallConfigSecretRef",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.InstallConfigSecretRef = nil
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test setting installed flag",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test clearing installed flag",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				return cd
+			}(),
+			newObject:       validAWSClusterDeployment(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test clearing installed flag with allow immutable changes annotation",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Annotations = map[string]string{constants.AllowImmutableChangesAnnotation: "installed"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test changing cluster name",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ClusterName = "other-cluster"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test changing platform and base domain with allow immutable changes annotation",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Annotations = map[string]string{constants.AllowImmutableChangesAnnotation: "platform, baseDomain"}
+				cd.Spec.Platform.AWS.Region = "other-region"
+				cd.Spec.BaseDomain = "other.example.com"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test changing platform with allow immutable changes annotation for other fields",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Annotations = map[string]string{constants.AllowImmutableChangesAnnotation: "baseDomain"}
+				cd.Spec.Platform.AWS.Region = "other-region"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test allow immutable changes annotation with unsupported field",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Annotations = map[string]string{constants.AllowImmutableChangesAnnotation: "pullSecretRef"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test setting fips and etcd encryption before installed",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.FIPS = pointer.BoolPtr(true)
+				cd.Spec.Provisioning.EtcdEncryption = &configv1.APIServerEncryption{Type: configv1.EncryptionTypeAESCBC}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test changing fips after installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Provisioning.FIPS = pointer.BoolPtr(true)
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Provisioning.FIPS = pointer.BoolPtr(false)
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test changing etcd encryption after installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Provisioning.EtcdEncryption = &configv1.APIServerEncryption{Type: configv1.EncryptionTypeAESCBC}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with single node topology",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Topology = hivev1.SingleNodeClusterTopology
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with invalid topology",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Topology = "TwoNode"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test create with image-based install",
+			newObject:       validImageBasedClusterDeployment(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with image-based install without host address",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validImageBasedClusterDeployment()
+				cd.Spec.Provisioning.ImageBasedInstall.HostAddress = ""
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with image-based install of compact cluster",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validImageBasedClusterDeployment()
+				cd.Spec.Provisioning.Topology = hivev1.CompactClusterTopology
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with image-based install without ssh key",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validImageBasedClusterDeployment()
+				cd.Spec.Provisioning.SSHPrivateKeySecretRef = nil
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with image-based install on AWS",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Topology = hivev1.SingleNodeClusterTopology
+				cd.Spec.Provisioning.SSHPrivateKeySecretRef = &corev1.LocalObjectReference{Name: "ssh-key"}
+				cd.Spec.Provisioning.ImageBasedInstall = &hivev1.ImageBasedInstall{HostAddress: "192.168.111.20"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test setting topology before installed",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Provisioning.Topology = hivev1.CompactClusterTopology
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test changing topology after installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Provisioning.Topology = hivev1.CompactClusterTopology
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Provisioning.Topology = hivev1.HighlyAvailableClusterTopology
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with remediation policy",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.RemediationPolicy = &hivev1.RemediationPolicy{
+					UnhealthyDuration: metav1.Duration{Duration: 2 * time.Hour},
+					Actions: []hivev1.RemediationAction{
+						{Type: hivev1.ApproveCSRsRemediationAction},
+						{Type: hivev1.RestartMachinesRemediationAction},
+						{Type: hivev1.WebhookRemediationAction, Webhook: &hivev1.RemediationWebhook{URL: "https://incidents.example.com"}},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with remediation policy without unhealthy duration",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.RemediationPolicy = &hivev1.RemediationPolicy{
+					Actions: []hivev1.RemediationAction{{Type: hivev1.ApproveCSRsRemediationAction}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with remediation webhook action without url",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.RemediationPolicy = &hivev1.RemediationPolicy{
+					UnhealthyDuration: metav1.Duration{Duration: 2 * time.Hour},
+					Actions:           []hivev1.RemediationAction{{Type: hivev1.WebhookRemediationAction}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test adding remediation policy after installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.RemediationPolicy = &hivev1.RemediationPolicy{
+					UnhealthyDuration: metav1.Duration{Duration: 2 * time.Hour},
+					Actions:           []hivev1.RemediationAction{{Type: hivev1.RestartMachinesRemediationAction}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with maintenance windows",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.MaintenanceWindows = []hivev1.MaintenanceWindow{
+					{Days: []hivev1.MaintenanceWindowDay{"Saturday", "Sunday"}, StartTime: "02:00", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+					{StartTime: "23:30", Duration: metav1.Duration{Duration: time.Hour}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with invalid maintenance window start time",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.MaintenanceWindows = []hivev1.MaintenanceWindow{
+					{StartTime: "2am", Duration: metav1.Duration{Duration: time.Hour}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with maintenance window longer than a week",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.MaintenanceWindows = []hivev1.MaintenanceWindow{
+					{StartTime: "02:00", Duration: metav1.Duration{Duration: 8 * 24 * time.Hour}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test adding maintenance windows after installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.MaintenanceWindows = []hivev1.MaintenanceWindow{
+					{Days: []hivev1.MaintenanceWindowDay{"Monday"}, StartTime: "02:00", Duration: metav1.Duration{Duration: time.Hour}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with invalid maintenance window day",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.MaintenanceWindows = []hivev1.MaintenanceWindow{
+					{Days: []hivev1.MaintenanceWindowDay{"Funday"}, StartTime: "02:00", Duration: metav1.Duration{Duration: time.Hour}},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with proxy",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Proxy = &hivev1.ClusterProxy{
+					HTTPProxy:  "http://proxy.example.com:3128",
+					HTTPSProxy: "https://proxy.example.com:3129",
+					NoProxy:    ".example.com,internal.example.com,10.0.0.0/16,192.168.1.1",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test changing proxy after installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Proxy = &hivev1.ClusterProxy{HTTPSProxy: "http://proxy.example.com:3128"}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.Proxy = &hivev1.ClusterProxy{HTTPSProxy: "http://other-proxy.example.com:3128"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with proxy without proxy URLs",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Proxy = &hivev1.ClusterProxy{
+					NoProxy: ".example.com",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with https httpProxy",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Proxy = &hivev1.ClusterProxy{
+					HTTPProxy: "https://proxy.example.com:3128",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with invalid noProxy",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Proxy = &hivev1.ClusterProxy{
+					HTTPSProxy: "http://proxy.example.com:3128",
+					NoProxy:    "*.example.com",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with invalid proxy trust bundle",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Proxy = &hivev1.ClusterProxy{
+					HTTPSProxy:            "http://proxy.example.com:3128",
+					AdditionalTrustBundle: "not a certificate",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test changing propagated node labels after installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.PropagatedNodeLabels = []string{"environment", "example.com/tenant"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with invalid propagated node label",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.PropagatedNodeLabels = []string{"not a label"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with duplicate propagated node label",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.PropagatedNodeLabels = []string{"environment", "environment"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test resizing control plane after installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.ControlPlaneMachines = &hivev1.ControlPlaneMachineSpec{Type: "m6i.2xlarge"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with control plane machines without type",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.ControlPlaneMachines = &hivev1.ControlPlaneMachineSpec{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with control plane machines on unsupported platform",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validVSphereClusterDeployment()
+				cd.Spec.ControlPlaneMachines = &hivev1.ControlPlaneMachineSpec{Type: "large"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test update cluster autoscaler after installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				threshold := "0.4"
+				balance := true
+				cd.Spec.ClusterAutoscaler = &hivev1.ClusterAutoscalerSettings{
+					ScaleDown: &hivev1.ClusterAutoscalerScaleDown{
+						DelayAfterAdd:        &metav1.Duration{Duration: 5 * time.Minute},
+						UtilizationThreshold: &threshold,
+					},
+					BalanceSimilarNodeGroups: &balance,
+					ResourceLimits: &hivev1.ClusterAutoscalerResourceLimits{
+						Cores: &hivev1.ClusterAutoscalerResourceRange{Min: 8, Max: 128},
+						GPUs: []hivev1.ClusterAutoscalerGPULimit{
+							{Type: "nvidia.com/gpu", Min: 0, Max: 4},
+						},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with cluster autoscaler utilization threshold above 1",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				threshold := "1.5"
+				cd.Spec.ClusterAutoscaler = &hivev1.ClusterAutoscalerSettings{
+					ScaleDown: &hivev1.ClusterAutoscalerScaleDown{UtilizationThreshold: &threshold},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with cluster autoscaler negative scale down delay",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ClusterAutoscaler = &hivev1.ClusterAutoscalerSettings{
+					ScaleDown: &hivev1.ClusterAutoscalerScaleDown{UnneededTime: &metav1.Duration{Duration: -time.Minute}},
+				}
+				return cd
+			}(),
 			operation:       admissionv1beta1.Create,
-			expectedAllowed: true,
+			expectedAllowed: false,
 		},
 		{
-			name:            "Test Delete Operation is allowed even with mismatch objects",
-			oldObject:       validAWSClusterDeployment(),
-			newObject:       validClusterDeploymentDifferentImmutableValue(),
-			operation:       admissionv1beta1.Delete,
-			expectedAllowed: true,
+			name: "Test create with cluster autoscaler memory max below min",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ClusterAutoscaler = &hivev1.ClusterAutoscalerSettings{
+					ResourceLimits: &hivev1.ClusterAutoscalerResourceLimits{
+						Memory: &hivev1.ClusterAutoscalerResourceRange{Min: 64, Max: 32},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
 		},
 		{
-			name:            "Test Update Operation is allowed with same data",
-			oldObject:       validAWSClusterDeployment(),
-			newObject:       validClusterDeploymentSameValues(),
-			operation:       admissionv1beta1.Update,
-			expectedAllowed: true,
+			name: "Test create with cluster autoscaler duplicate GPU type",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.ClusterAutoscaler = &hivev1.ClusterAutoscalerSettings{
+					ResourceLimits: &hivev1.ClusterAutoscalerResourceLimits{
+						GPUs: []hivev1.ClusterAutoscalerGPULimit{
+							{Type: "nvidia.com/gpu", Max: 4},
+							{Type: "nvidia.com/gpu", Max: 8},
+						},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
 		},
 		{
-			name:            "Test Update Operation is allowed with different mutable data",
-			oldObject:       validAWSClusterDeployment(),
-			newObject:       validClusterDeploymentDifferentMutableValue(),
+			name: "Test adding teardown hooks after installed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Installed = true
+				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.TeardownHooks = []hivev1.TeardownHook{validTeardownHook("deregister")}
+				return cd
+			}(),
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: true,
 		},
 		{
-			name:      "Test reject missing InstallConfigSecretRef",
-			oldObject: validAWSClusterDeployment(),
+			name: "Test create with duplicate teardown hook names",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.Provisioning.InstallConfigSecretRef = nil
+				cd.Spec.TeardownHooks = []hivev1.TeardownHook{validTeardownHook("deregister"), validTeardownHook("deregister")}
 				return cd
 			}(),
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
 		{
-			name:      "Test setting installed flag",
-			oldObject: validAWSClusterDeployment(),
+			name: "Test create with teardown hook without resources",
 			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.Installed = true
-				cd.Spec.ClusterMetadata = &hivev1.ClusterMetadata{}
+				cd.Spec.TeardownHooks = []hivev1.TeardownHook{{Name: "deregister"}}
 				return cd
 			}(),
-			operation:       admissionv1beta1.Update,
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with teardown hook resource without name",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.TeardownHooks = []hivev1.TeardownHook{{
+					Name:      "deregister",
+					Resources: []runtime.RawExtension{{Raw: []byte(`{"apiVersion":"batch/v1","kind":"Job","metadata":{"namespace":"hooks"}}`)}},
+				}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with negative teardown hook timeout",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				hook := validTeardownHook("deregister")
+				hook.Timeout = &metav1.Duration{Duration: -time.Minute}
+				cd.Spec.TeardownHooks = []hivev1.TeardownHook{hook}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with override annotations",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Annotations = map[string]string{
+					constants.SyncSetReapplyIntervalAnnotation:    "30m",
+					constants.MachinePoolResyncIntervalAnnotation: "1m",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
 		{
-			name: "Test clearing installed flag",
-			oldObject: func() *hivev1.ClusterDeployment {
+			name: "Test create with invalid override annotation",
+			newObject: func() *hivev1.ClusterDeployment {
 				cd := validAWSClusterDeployment()
-				cd.Spec.Installed = true
+				cd.Annotations = map[string]string{constants.SyncSetReapplyIntervalAnnotation: "often"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test update with invalid override annotation",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Annotations = map[string]string{constants.UnreachableRecheckIntervalAnnotation: "-5m"}
 				return cd
 			}(),
-			newObject:       validAWSClusterDeployment(),
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: false,
 		},
@@ -622,6 +1321,93 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name: "Azure create with NAT gateway",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.Egress = &hivev1azure.Egress{
+					NATGatewayID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/natGateways/nat",
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Azure create with invalid NAT gateway",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAzureClusterDeployment()
+				cd.Spec.Platform.Azure.Egress = &hivev1azure.Egress{NATGatewayID: "nat"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS create with NAT gateways",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.Egress = &hivev1aws.Egress{NATGatewayIDs: []string{"nat-1", "nat-2"}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "AWS create with invalid NAT gateway",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.Egress = &hivev1aws.Egress{NATGatewayIDs: []string{"igw-1"}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS create with duplicate NAT gateways",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.Egress = &hivev1aws.Egress{NATGatewayIDs: []string{"nat-1", "nat-1"}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "AWS update NAT gateways",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.Egress = &hivev1aws.Egress{NATGatewayIDs: []string{"nat-1"}}
+				return cd
+			}(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Platform.AWS.Egress = &hivev1aws.Egress{NATGatewayIDs: []string{"nat-2"}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "GCP create with Cloud NAT",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.Egress = &hivev1gcp.Egress{CloudNATRouter: "router"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "GCP create with Cloud NAT missing router",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validGCPClusterDeployment()
+				cd.Spec.Platform.GCP.Egress = &hivev1gcp.Egress{}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name: "Azure create missing region",
 			newObject: func() *hivev1.ClusterDeployment {
@@ -721,6 +1507,32 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name: "ingress with internal load balancer",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentWithIngress()
+				cd.Spec.Ingress[0].EndpointPublishingStrategy = &hivev1.IngressEndpointPublishingStrategy{
+					Type:              hivev1.IngressLoadBalancerServiceStrategyType,
+					LoadBalancerScope: hivev1.IngressInternalLoadBalancerScope,
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "ingress with load balancer scope on host network",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validClusterDeploymentWithIngress()
+				cd.Spec.Ingress[0].EndpointPublishingStrategy = &hivev1.IngressEndpointPublishingStrategy{
+					Type:              hivev1.IngressHostNetworkStrategyType,
+					LoadBalancerScope: hivev1.IngressInternalLoadBalancerScope,
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name: "ingress with missing serving certificate",
 			newObject: func() *hivev1.ClusterDeployment {
@@ -780,6 +1592,43 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: false,
 		},
+		{
+			name: "serving certificate issued by cert-manager",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name:                 "test-serving-cert",
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+						CertManager: &hivev1.CertManagerCertificate{
+							IssuerRef: hivev1.CertManagerIssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"},
+							DNSNames:  []string{"api.test.example.com"},
+						},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "serving certificate issued by cert-manager without DNS names",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.CertificateBundles = []hivev1.CertificateBundleSpec{
+					{
+						Name:                 "test-serving-cert",
+						CertificateSecretRef: corev1.LocalObjectReference{Name: "test-serving-cert-secret"},
+						CertManager: &hivev1.CertManagerCertificate{
+							IssuerRef: hivev1.CertManagerIssuerReference{Name: "letsencrypt"},
+						},
+					},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name: "InstallConfig is missing",
 			newObject: func() *hivev1.ClusterDeployment {
@@ -953,6 +1802,44 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Delete,
 			expectedAllowed: true,
 		},
+		{
+			name: "Test delete with deletion protection unconfirmed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.DeletionProtection = &hivev1.DeletionProtection{Enabled: true}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test delete with deletion protection confirmed",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.DeletionProtection = &hivev1.DeletionProtection{Enabled: true}
+				if cd.Annotations == nil {
+					cd.Annotations = make(map[string]string, 1)
+				}
+				cd.Annotations[constants.ConfirmDeleteAnnotation] = cd.Name
+				return cd
+			}(),
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test delete with deletion protection confirmed with wrong name",
+			oldObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.DeletionProtection = &hivev1.DeletionProtection{Enabled: true}
+				if cd.Annotations == nil {
+					cd.Annotations = make(map[string]string, 1)
+				}
+				cd.Annotations[constants.ConfirmDeleteAnnotation] = "true"
+				return cd
+			}(),
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test delete on OpenShift 3.11",
 			oldObject:       nil,
@@ -1160,3 +2047,12 @@ func TestNewClusterDeploymentValidatingAdmissionHook(t *testing.T) {
 	webhook := NewClusterDeploymentValidatingAdmissionHook(createDecoder(t))
 	assert.Equal(t, webhook.validManagedDomains, expectedDomains, "valid domains must match expected")
 }
+
+func validTeardownHook(name string) hivev1.TeardownHook {
+	return hivev1.TeardownHook{
+		Name: name,
+		Resources: []runtime.RawExtension{
+			{Raw: []byte(`{"apiVersion":"batch/v1","kind":"Job","metadata":{"namespace":"hooks","name":"deregister"}}`)},
+		},
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
@@ -178,6 +179,17 @@ func (a *ClusterPoolValidatingAdmissionHook) validateCreate(admissionSpec *admis
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs = append(allErrs, validateNamespaceTemplate(specPath.Child("namespaceTemplate"), newObject)...)
+	if newObject.Spec.HostedCluster != nil && newObject.Spec.InstallConfigSecretTemplateRef != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("installConfigSecretTemplateRef"), "cannot be set for a pool of hosted clusters"))
+	}
+	if timeout := newObject.Spec.BrokenClusterTimeout; timeout != nil && timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("brokenClusterTimeout"), timeout.Duration.String(), "must be positive"))
+	}
+	allErrs = append(allErrs, validateTeardownHooks(specPath.Child("claimReleaseHooks"), newObject.Spec.ClaimReleaseHooks)...)
+	if newObject.Spec.ReleasePolicy == hivev1.ReuseClusterPoolReleasePolicy && len(newObject.Spec.ClaimReleaseHooks) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("claimReleaseHooks"), "must be set to sanitize released clusters for reuse"))
+	}
 
 	if len(allErrs) > 0 {
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
@@ -238,6 +250,17 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs = append(allErrs, validateNamespaceTemplate(specPath.Child("namespaceTemplate"), newObject)...)
+	if newObject.Spec.HostedCluster != nil && newObject.Spec.InstallConfigSecretTemplateRef != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("installConfigSecretTemplateRef"), "cannot be set for a pool of hosted clusters"))
+	}
+	if timeout := newObject.Spec.BrokenClusterTimeout; timeout != nil && timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("brokenClusterTimeout"), timeout.Duration.String(), "must be positive"))
+	}
+	allErrs = append(allErrs, validateTeardownHooks(specPath.Child("claimReleaseHooks"), newObject.Spec.ClaimReleaseHooks)...)
+	if newObject.Spec.ReleasePolicy == hivev1.ReuseClusterPoolReleasePolicy && len(newObject.Spec.ClaimReleaseHooks) == 0 {
+		allErrs = append(allErrs, field.Required(specPath.Child("claimReleaseHooks"), "must be set to sanitize released clusters for reuse"))
+	}
 
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
@@ -254,3 +277,13 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 		Allowed: true,
 	}
 }
+
+func validateNamespaceTemplate(path *field.Path, clp *hivev1.ClusterPool) field.ErrorList {
+	if clp.Spec.NamespaceTemplate == "" {
+		return nil
+	}
+	if _, err := controllerutils.ClusterPoolNamespacePrefix(clp); err != nil {
+		return field.ErrorList{field.Invalid(path, clp.Spec.NamespaceTemplate, err.Error())}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -216,6 +217,128 @@ func TestClusterPoolValidate(t *testing.T) {
 			operation:       admissionv1beta1.Create,
 			expectedAllowed: true,
 		},
+		{
+			name: "create with namespace template",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Name = "test-pool"
+				cp.Spec.NamespaceTemplate = "ci-{{.PoolName}}"
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with unparseable namespace template",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Name = "test-pool"
+				cp.Spec.NamespaceTemplate = "ci-{{.PoolName"
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "update with namespace template rendering an invalid name",
+			oldObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Name = "test-pool"
+				return cp
+			}(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Name = "test-pool"
+				cp.Spec.NamespaceTemplate = "{{.ClaimName}}"
+				return cp
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with broken cluster timeout",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.BrokenClusterTimeout = &metav1.Duration{Duration: 30 * time.Minute}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create with zero broken cluster timeout",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.BrokenClusterTimeout = &metav1.Duration{}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "create with claim release hooks",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.ClaimReleaseHooks = []hivev1.TeardownHook{validTeardownHook("cleanup")}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "update with duplicate claim release hooks",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.ClaimReleaseHooks = []hivev1.TeardownHook{validTeardownHook("cleanup"), validTeardownHook("cleanup")}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create reusing pool",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.ReleasePolicy = hivev1.ReuseClusterPoolReleasePolicy
+				cp.Spec.ClaimReleaseHooks = []hivev1.TeardownHook{validTeardownHook("cleanup")}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "update to reusing pool without claim release hooks",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.ReleasePolicy = hivev1.ReuseClusterPoolReleasePolicy
+				return cp
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "create hosted cluster pool",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.HostedCluster = &hivev1.HostedClusterConfig{}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "create hosted cluster pool with install config template",
+			newObject: func() *hivev1.ClusterPool {
+				cp := validAWSClusterPool()
+				cp.Spec.HostedCluster = &hivev1.HostedClusterConfig{}
+				cp.Spec.InstallConfigSecretTemplateRef = &corev1.LocalObjectReference{Name: "install-config"}
+				return cp
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test valid delete",
 			oldObject:       validAWSClusterPool(),
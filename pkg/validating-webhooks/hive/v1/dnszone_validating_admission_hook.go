@@ -228,9 +228,29 @@ func (a *DNSZoneValidatingAdmissionHook) validateUpdate(admissionSpec *admission
 		}
 	}
 
+	if awsZoneID(oldObject) != awsZoneID(newObject) {
+		message := "DNSZone.Spec.AWS.ZoneID is immutable"
+		contextLogger.Infof("Failed validation: %v", message)
+
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: message,
+			},
+		}
+	}
+
 	// If we get here, then all checks passed, so the object is valid.
 	contextLogger.Info("Successful validation")
 	return &admissionv1beta1.AdmissionResponse{
 		Allowed: true,
 	}
 }
+
+func awsZoneID(dnsZone *hivev1.DNSZone) string {
+	if dnsZone.Spec.AWS == nil {
+		return ""
+	}
+	return dnsZone.Spec.AWS.ZoneID
+}
@@ -46,6 +46,8 @@ func TestDNSZoneValidate(t *testing.T) {
 		name            string
 		newZoneStr      string
 		oldZoneStr      string
+		newZoneID       string
+		oldZoneID       string
 		newObjectRaw    []byte
 		oldObjectRaw    []byte
 		operation       admissionv1beta1.Operation
@@ -104,6 +106,35 @@ func TestDNSZoneValidate(t *testing.T) {
 
 			expectedAllowed: true,
 		},
+		{
+			name:       "Test DNSZone.Spec.AWS.ZoneID is immutable",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newZoneID:  "Z2",
+			oldZoneID:  "Z1",
+			operation:  admissionv1beta1.Update,
+
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test DNSZone.Spec.AWS.ZoneID cannot be added",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newZoneID:  "Z1",
+			operation:  admissionv1beta1.Update,
+
+			expectedAllowed: false,
+		},
+		{
+			name:       "Test updates with unchanged DNSZone.Spec.AWS.ZoneID allowed",
+			newZoneStr: "this.is.a.valid.zone",
+			oldZoneStr: "this.is.a.valid.zone",
+			newZoneID:  "Z1",
+			oldZoneID:  "Z1",
+			operation:  admissionv1beta1.Update,
+
+			expectedAllowed: true,
+		},
 		{
 			name:            "Test that we don't validate deletes",
 			operation:       admissionv1beta1.Delete,
@@ -152,6 +183,12 @@ func TestDNSZoneValidate(t *testing.T) {
 					Zone: tc.oldZoneStr,
 				},
 			}
+			if tc.newZoneID != "" {
+				newObject.Spec.AWS = &hivev1.AWSDNSZoneSpec{ZoneID: tc.newZoneID}
+			}
+			if tc.oldZoneID != "" {
+				oldObject.Spec.AWS = &hivev1.AWSDNSZoneSpec{ZoneID: tc.oldZoneID}
+			}
 
 			if tc.newObjectRaw == nil {
 				tc.newObjectRaw, _ = json.Marshal(newObject)
@@ -0,0 +1,199 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const testNetworkConfigTemplate = `interfaces:
+- name: {{ (index .Interfaces 0).Name }}
+  type: ethernet
+  state: up
+  ipv4:
+    enabled: true
+    address:
+    - ip: {{ .Vars.ip }}
+      prefix-length: 24
+`
+
+func validHostInventorySpec() hivev1.HostInventorySpec {
+	return hivev1.HostInventorySpec{
+		ClusterDeploymentRef:  corev1.LocalObjectReference{Name: "test-cluster"},
+		NetworkConfigTemplate: testNetworkConfigTemplate,
+		Hosts: []hivev1.InventoryHost{{
+			Name:       "master-0",
+			Interfaces: []hivev1.InventoryHostInterface{{Name: "eth0", MACAddress: "52:54:00:00:00:01"}},
+			Variables:  map[string]string{"ip": "192.168.111.10"},
+		}},
+	}
+}
+
+func TestHostInventoryValidatingResource(t *testing.T) {
+	// Arrange
+	data := NewHostInventoryValidatingAdmissionHook(createDecoder(t))
+	expectedPlural := schema.GroupVersionResource{
+		Group:    "admission.hive.openshift.io",
+		Version:  "v1",
+		Resource: "hostinventoryvalidators",
+	}
+	expectedSingular := "hostinventoryvalidator"
+
+	// Act
+	plural, singular := data.ValidatingResource()
+
+	// Assert
+	assert.Equal(t, expectedPlural, plural)
+	assert.Equal(t, expectedSingular, singular)
+}
+
+func TestHostInventoryInitialize(t *testing.T) {
+	// Arrange
+	data := NewHostInventoryValidatingAdmissionHook(createDecoder(t))
+
+	// Act
+	err := data.Initialize(nil, nil)
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func TestHostInventoryValidate(t *testing.T) {
+	cases := []struct {
+		name            string
+		newSpec         hivev1.HostInventorySpec
+		oldSpec         hivev1.HostInventorySpec
+		newObjectRaw    []byte
+		oldObjectRaw    []byte
+		operation       admissionv1beta1.Operation
+		expectedAllowed bool
+		gvr             *metav1.GroupVersionResource
+	}{
+		{
+			name:            "Test valid create",
+			newSpec:         validHostInventorySpec(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with missing variable",
+			newSpec: func() hivev1.HostInventorySpec {
+				spec := validHostInventorySpec()
+				spec.Hosts[0].Variables = nil
+				return spec
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create without cluster deployment",
+			newSpec: func() hivev1.HostInventorySpec {
+				spec := validHostInventorySpec()
+				spec.ClusterDeploymentRef.Name = ""
+				return spec
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test valid update",
+			newSpec:         validHostInventorySpec(),
+			oldSpec:         validHostInventorySpec(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test update with invalid MAC address",
+			newSpec: func() hivev1.HostInventorySpec {
+				spec := validHostInventorySpec()
+				spec.Hosts[0].Interfaces[0].MACAddress = "not-a-mac"
+				return spec
+			}(),
+			oldSpec:         validHostInventorySpec(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test unable to marshal new object during create",
+			newObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test unable to marshal old object during update",
+			newSpec:         validHostInventorySpec(),
+			oldObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "Test that we don't validate deletes",
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test doesn't validate with right group and version, wrong resource",
+			gvr: &metav1.GroupVersionResource{
+				Group:    "hive.openshift.io",
+				Version:  "v1",
+				Resource: "not the right resource",
+			},
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			data := NewHostInventoryValidatingAdmissionHook(createDecoder(t))
+			newObject := &hivev1.HostInventory{
+				Spec: tc.newSpec,
+			}
+			oldObject := &hivev1.HostInventory{
+				Spec: tc.oldSpec,
+			}
+
+			if tc.newObjectRaw == nil {
+				tc.newObjectRaw, _ = json.Marshal(newObject)
+			}
+
+			if tc.oldObjectRaw == nil {
+				tc.oldObjectRaw, _ = json.Marshal(oldObject)
+			}
+
+			if tc.gvr == nil {
+				tc.gvr = &metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "hostinventories",
+				}
+			}
+
+			request := &admissionv1beta1.AdmissionRequest{
+				Operation: tc.operation,
+				Resource:  *tc.gvr,
+				Object: runtime.RawExtension{
+					Raw: tc.newObjectRaw,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: tc.oldObjectRaw,
+				},
+			}
+
+			// Act
+			response := data.Validate(request)
+
+			// Assert
+			assert.Equal(t, tc.expectedAllowed, response.Allowed)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package v1
 import (
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 
 	log "github.com/sirupsen/logrus"
 
@@ -13,6 +15,9 @@ import (
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -38,7 +43,8 @@ const (
 
 // MachinePoolValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
 type MachinePoolValidatingAdmissionHook struct {
-	decoder *admission.Decoder
+	decoder      *admission.Decoder
+	quotaChecker *tenantQuotaChecker
 }
 
 // NewMachinePoolValidatingAdmissionHook constructs a new MachinePoolValidatingAdmissionHook
@@ -73,7 +79,12 @@ func (a *MachinePoolValidatingAdmissionHook) Initialize(kubeClientConfig *rest.C
 		"resource": "machinepoolvalidator",
 	}).Info("Initializing validation REST resource")
 
-	return nil // No initialization needed right now.
+	quotaChecker, err := newTenantQuotaChecker(kubeClientConfig, stopCh)
+	if err != nil {
+		return err
+	}
+	a.quotaChecker = quotaChecker
+	return nil
 }
 
 // Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
@@ -149,7 +160,11 @@ func (a *MachinePoolValidatingAdmissionHook) validateCreateRequest(request *admi
 		WithField("object.Name", newObject.Name).
 		WithField("object.Namespace", newObject.Namespace)
 
-	if allErrs := validateMachinePoolCreate(newObject); len(allErrs) > 0 {
+	allErrs := validateMachinePoolCreate(newObject)
+	if len(allErrs) == 0 {
+		allErrs = a.quotaChecker.validateMachinePool(nil, newObject, logger)
+	}
+	if len(allErrs) > 0 {
 		logger.WithError(allErrs.ToAggregate()).Info("failed validation")
 		status := errors.NewInvalid(schemaGVK(request.Kind).GroupKind(), request.Name, allErrs).Status()
 		return &admissionv1beta1.AdmissionResponse{
@@ -183,7 +198,11 @@ func (a *MachinePoolValidatingAdmissionHook) validateUpdateRequest(request *admi
 		return resp
 	}
 
-	if allErrs := validateMachinePoolUpdate(oldObject, newObject); len(allErrs) > 0 {
+	allErrs := validateMachinePoolUpdate(oldObject, newObject)
+	if len(allErrs) == 0 {
+		allErrs = a.quotaChecker.validateMachinePool(oldObject, newObject, logger)
+	}
+	if len(allErrs) > 0 {
 		logger.WithError(allErrs.ToAggregate()).Info("failed validation")
 		status := errors.NewInvalid(schemaGVK(request.Kind).GroupKind(), request.Name, allErrs).Status()
 		return &admissionv1beta1.AdmissionResponse{
@@ -224,10 +243,38 @@ func validateMachinePoolUpdate(old, new *hivev1.MachinePool) field.ErrorList {
 	specPath := field.NewPath("spec")
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.ClusterDeploymentRef, old.Spec.ClusterDeploymentRef, specPath.Child("clusterDeploymentRef"))...)
 	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Name, old.Spec.Name, specPath.Child("name"))...)
-	allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Platform, old.Spec.Platform, specPath.Child("platform"))...)
+	if (new.Spec.MachineSetSelector == nil) != (old.Spec.MachineSetSelector == nil) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("machineSetSelector"), new.Spec.MachineSetSelector, "machine pool cannot switch between managed and externally managed"))
+	}
+	if new.Spec.RolloutStrategy == nil {
+		allErrs = append(allErrs, validation.ValidateImmutableField(new.Spec.Platform, old.Spec.Platform, specPath.Child("platform"))...)
+	} else if platformName(&new.Spec.Platform) != platformName(&old.Spec.Platform) {
+		// Machines can be rolled out with a new configuration, but not onto another cloud.
+		allErrs = append(allErrs, field.Invalid(specPath.Child("platform"), new.Spec.Platform, "platform type is immutable"))
+	}
 	return allErrs
 }
 
+// platformName returns the name of the platform set in a machine pool platform.
+func platformName(platform *hivev1.MachinePoolPlatform) string {
+	switch {
+	case platform.AWS != nil:
+		return "aws"
+	case platform.Azure != nil:
+		return "azure"
+	case platform.GCP != nil:
+		return "gcp"
+	case platform.OpenStack != nil:
+		return "openstack"
+	case platform.VSphere != nil:
+		return "vsphere"
+	case platform.Ovirt != nil:
+		return "ovirt"
+	default:
+		return ""
+	}
+}
+
 func validateMachinePoolName(pool *hivev1.MachinePool) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if pool.Name != fmt.Sprintf("%s-%s", pool.Spec.ClusterDeploymentRef.Name, pool.Spec.Name) {
@@ -303,10 +350,14 @@ func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *fi
 		allErrs = append(allErrs, validateOvirtMachinePoolPlatformInvariants(p, platformPath.Child("ovirt"))...)
 	}
 
-	switch len(platforms) {
-	case 0:
+	switch {
+	case spec.MachineSetSelector != nil:
+		if len(platforms) > 0 {
+			allErrs = append(allErrs, field.Invalid(platformPath, spec.Platform, "platform must not be specified when machineSetSelector is specified"))
+		}
+	case len(platforms) == 0:
 		allErrs = append(allErrs, field.Required(platformPath, "must specify a platform"))
-	case 1:
+	case len(platforms) == 1:
 		// valid
 	default:
 		allErrs = append(allErrs, field.Invalid(platformPath, spec.Platform, fmt.Sprintf("multiple platforms specified: %s", platforms)))
@@ -327,6 +378,198 @@ func validateMachinePoolSpecInvariants(spec *hivev1.MachinePoolSpec, fldPath *fi
 		}
 	}
 	allErrs = append(allErrs, metavalidation.ValidateLabels(spec.Labels, fldPath.Child("labels"))...)
+	allErrs = append(allErrs, validateMachinePoolMachineConfigs(spec, fldPath.Child("machineConfigs"))...)
+	allErrs = append(allErrs, validateMachinePoolZoneDistribution(spec, fldPath.Child("zoneDistribution"))...)
+	if spec.MachineSetSelector != nil {
+		allErrs = append(allErrs, validateExternallyManagedMachinePool(spec, fldPath)...)
+	}
+	if spec.MachineHealthCheck != nil {
+		allErrs = append(allErrs, validateMachinePoolMachineHealthCheck(spec.MachineHealthCheck, fldPath.Child("machineHealthCheck"))...)
+	}
+	if spec.RolloutStrategy != nil {
+		rolloutStrategyPath := fldPath.Child("rolloutStrategy")
+		allErrs = append(allErrs, validateMachinePoolRolloutStrategy(spec.RolloutStrategy, rolloutStrategyPath)...)
+		switch spec.RolloutStrategy.Type {
+		case hivev1.BlueGreenMachinePoolRolloutStrategyType, hivev1.RollingReplacementMachinePoolRolloutStrategyType:
+			if spec.Autoscaling != nil {
+				allErrs = append(allErrs, field.Invalid(rolloutStrategyPath.Child("type"), spec.RolloutStrategy.Type, "MachineSet replacement rollouts cannot be used with autoscaling"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateExternallyManagedMachinePool checks that an externally managed machine pool selects MachineSets, and does
+// not configure anything which only applies to the MachineSets Hive manages.
+func validateExternallyManagedMachinePool(spec *hivev1.MachinePoolSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	selectorPath := fldPath.Child("machineSetSelector")
+	allErrs = append(allErrs, metavalidation.ValidateLabelSelector(spec.MachineSetSelector, selectorPath)...)
+	if len(spec.MachineSetSelector.MatchLabels) == 0 && len(spec.MachineSetSelector.MatchExpressions) == 0 {
+		// An empty selector would select the MachineSets of every other machine pool as well.
+		allErrs = append(allErrs, field.Required(selectorPath, "machineSetSelector must not be empty"))
+	}
+	unsupported := func(set bool, name string) {
+		if set {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(name), fmt.Sprintf("%s cannot be used with machineSetSelector", name)))
+		}
+	}
+	unsupported(spec.Replicas != nil, "replicas")
+	unsupported(len(spec.Labels) > 0, "labels")
+	unsupported(len(spec.Taints) > 0, "taints")
+	unsupported(spec.KubeletConfig != nil, "kubeletConfig")
+	unsupported(len(spec.MachineConfigs) > 0, "machineConfigs")
+	unsupported(spec.TagReconciliation != "", "tagReconciliation")
+	unsupported(spec.RolloutStrategy != nil, "rolloutStrategy")
+	unsupported(len(spec.ZoneDistribution) > 0, "zoneDistribution")
+	unsupported(spec.AdoptExisting, "adoptExisting")
+	return allErrs
+}
+
+// validateMachinePoolZoneDistribution checks that every zone of the zone distribution pins the replicas which apply
+// to the machine pool, and that the pinned replicas do not add up to more than the replicas of the machine pool.
+// Whether the zones are zones of the machine pool is only known once its MachineSets are generated, so it is left to
+// the machinepool controller.
+func validateMachinePoolZoneDistribution(spec *hivev1.MachinePoolSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(spec.ZoneDistribution) == 0 {
+		return allErrs
+	}
+	zones := make([]string, 0, len(spec.ZoneDistribution))
+	for zone := range spec.ZoneDistribution {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	var replicas, minReplicas, maxReplicas int64
+	for _, zone := range zones {
+		zoneReplicas := spec.ZoneDistribution[zone]
+		zonePath := fldPath.Key(zone)
+		if zone == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath, zone, "zone must not be empty"))
+		}
+		validate := func(value *int32, name string, required bool) {
+			switch {
+			case value == nil && required:
+				allErrs = append(allErrs, field.Required(zonePath.Child(name), fmt.Sprintf("%s must be specified", name)))
+			case value != nil && !required:
+				allErrs = append(allErrs, field.Forbidden(zonePath.Child(name), fmt.Sprintf("%s must not be specified", name)))
+			case value != nil && *value < 0:
+				allErrs = append(allErrs, field.Invalid(zonePath.Child(name), *value, fmt.Sprintf("%s must not be negative", name)))
+			}
+		}
+		autoscaling := spec.Autoscaling != nil
+		validate(zoneReplicas.Replicas, "replicas", !autoscaling)
+		validate(zoneReplicas.MinReplicas, "minReplicas", autoscaling)
+		validate(zoneReplicas.MaxReplicas, "maxReplicas", autoscaling)
+		if zoneReplicas.MinReplicas != nil && zoneReplicas.MaxReplicas != nil && *zoneReplicas.MinReplicas > *zoneReplicas.MaxReplicas {
+			allErrs = append(allErrs, field.Invalid(zonePath.Child("minReplicas"), *zoneReplicas.MinReplicas, "minimum replicas must not be greater than maximum replicas"))
+		}
+		if zoneReplicas.Replicas != nil {
+			replicas += int64(*zoneReplicas.Replicas)
+		}
+		if zoneReplicas.MinReplicas != nil {
+			minReplicas += int64(*zoneReplicas.MinReplicas)
+		}
+		if zoneReplicas.MaxReplicas != nil {
+			maxReplicas += int64(*zoneReplicas.MaxReplicas)
+		}
+	}
+
+	if spec.Autoscaling != nil {
+		if minReplicas > int64(spec.Autoscaling.MinReplicas) {
+			allErrs = append(allErrs, field.Invalid(fldPath, minReplicas, "pinned minimum replicas must not add up to more than the minimum replicas of the machine pool"))
+		}
+		if maxReplicas > int64(spec.Autoscaling.MaxReplicas) {
+			allErrs = append(allErrs, field.Invalid(fldPath, maxReplicas, "pinned maximum replicas must not add up to more than the maximum replicas of the machine pool"))
+		}
+		return allErrs
+	}
+	total := int64(1)
+	if spec.Replicas != nil {
+		total = *spec.Replicas
+	}
+	if replicas > total {
+		allErrs = append(allErrs, field.Invalid(fldPath, replicas, "pinned replicas must not add up to more than the replicas of the machine pool"))
+	}
+	return allErrs
+}
+
+func validateMachinePoolRolloutStrategy(strategy *hivev1.MachinePoolRolloutStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	validate := func(value *intstr.IntOrString, path *field.Path) {
+		if value == nil {
+			return
+		}
+		scaled, err := intstr.GetScaledValueFromIntOrPercent(value, 100, false)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(path, value.String(), "must be an integer or a percentage"))
+		case scaled < 0:
+			allErrs = append(allErrs, field.Invalid(path, value.String(), "must not be negative"))
+		case value.Type == intstr.String && scaled > 100:
+			allErrs = append(allErrs, field.Invalid(path, value.String(), "must not be greater than 100%"))
+		}
+	}
+	validate(strategy.MaxSurge, fldPath.Child("maxSurge"))
+	validate(strategy.MaxUnavailable, fldPath.Child("maxUnavailable"))
+	return allErrs
+}
+
+func validateMachinePoolMachineHealthCheck(healthCheck *hivev1.MachinePoolMachineHealthCheck, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	conditionsPath := fldPath.Child("unhealthyConditions")
+	if len(healthCheck.UnhealthyConditions) == 0 {
+		allErrs = append(allErrs, field.Required(conditionsPath, "at least one unhealthy condition is required"))
+	}
+	for i, c := range healthCheck.UnhealthyConditions {
+		if c.Type == "" {
+			allErrs = append(allErrs, field.Required(conditionsPath.Index(i).Child("type"), "condition type is required"))
+		}
+		if c.Status == "" {
+			allErrs = append(allErrs, field.Required(conditionsPath.Index(i).Child("status"), "condition status is required"))
+		}
+		if c.Timeout.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(conditionsPath.Index(i).Child("timeout"), c.Timeout.Duration.String(), "must not be negative"))
+		}
+	}
+	if value := healthCheck.MaxUnhealthy; value != nil {
+		maxUnhealthyPath := fldPath.Child("maxUnhealthy")
+		scaled, err := intstr.GetScaledValueFromIntOrPercent(value, 100, false)
+		switch {
+		case err != nil:
+			allErrs = append(allErrs, field.Invalid(maxUnhealthyPath, value.String(), "must be an integer or a percentage"))
+		case scaled < 0:
+			allErrs = append(allErrs, field.Invalid(maxUnhealthyPath, value.String(), "must not be negative"))
+		case value.Type == intstr.String && scaled > 100:
+			allErrs = append(allErrs, field.Invalid(maxUnhealthyPath, value.String(), "must not be greater than 100%"))
+		}
+	}
+	if timeout := healthCheck.NodeStartupTimeout; timeout != nil && timeout.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("nodeStartupTimeout"), timeout.Duration.String(), "must not be negative"))
+	}
+	return allErrs
+}
+
+func validateMachinePoolMachineConfigs(spec *hivev1.MachinePoolSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.NewString()
+	for i, config := range spec.MachineConfigs {
+		namePath := fldPath.Index(i).Child("name")
+		switch {
+		case config.Name == "":
+			allErrs = append(allErrs, field.Required(namePath, "machine config name is required"))
+		case names.Has(config.Name):
+			allErrs = append(allErrs, field.Duplicate(namePath, config.Name))
+		default:
+			// The name of the MachineConfig in the cluster is derived from the pool name and the config name.
+			mcName := fmt.Sprintf("99-%s-hive-%s", spec.Name, config.Name)
+			for _, msg := range utilvalidation.IsDNS1123Subdomain(mcName) {
+				allErrs = append(allErrs, field.Invalid(namePath, config.Name, msg))
+			}
+		}
+		names.Insert(config.Name)
+	}
 	return allErrs
 }
 
@@ -340,6 +583,23 @@ func validateAWSMachinePoolPlatformInvariants(platform *hivev1aws.MachinePoolPla
 	if platform.InstanceType == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("instanceType"), "instance type is required"))
 	}
+	securityGroupIDs := sets.NewString()
+	for i, id := range platform.AdditionalSecurityGroupIDs {
+		idPath := fldPath.Child("additionalSecurityGroupIDs").Index(i)
+		switch {
+		case id == "":
+			allErrs = append(allErrs, field.Invalid(idPath, id, "security group ID cannot be an empty string"))
+		case securityGroupIDs.Has(id):
+			allErrs = append(allErrs, field.Duplicate(idPath, id))
+		}
+		securityGroupIDs.Insert(id)
+	}
+	if spot := platform.SpotMarketOptions; spot != nil && spot.MaxPrice != nil {
+		maxPricePath := fldPath.Child("spotMarketOptions", "maxPrice")
+		if price, err := strconv.ParseFloat(*spot.MaxPrice, 64); err != nil || price <= 0 {
+			allErrs = append(allErrs, field.Invalid(maxPricePath, *spot.MaxPrice, "max price must be a positive decimal number of dollars per hour"))
+		}
+	}
 	rootVolume := &platform.EC2RootVolume
 	rootVolumePath := fldPath.Child("ec2RootVolume")
 	if rootVolume.IOPS < 0 {
@@ -364,6 +624,18 @@ func validateGCPMachinePoolPlatformInvariants(platform *hivev1gcp.MachinePool, f
 	if platform.InstanceType == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("instanceType"), "instance type is required"))
 	}
+	if osImage := platform.OSImage; osImage != nil {
+		osImagePath := fldPath.Child("osImage")
+		switch {
+		case osImage.Name == "" && osImage.Family == "":
+			allErrs = append(allErrs, field.Required(osImagePath, "one of name or family is required"))
+		case osImage.Name != "" && osImage.Family != "":
+			allErrs = append(allErrs, field.Invalid(osImagePath.Child("family"), osImage.Family, "only one of name or family may be set"))
+		}
+	}
+	if platform.Preemptible && platform.ProvisioningModel != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("provisioningModel"), *platform.ProvisioningModel, "cannot be set together with preemptible"))
+	}
 	return allErrs
 }
 
@@ -382,6 +654,84 @@ func validateAzureMachinePoolPlatformInvariants(platform *hivev1azure.MachinePoo
 	if osDisk.DiskSizeGB <= 0 {
 		allErrs = append(allErrs, field.Invalid(osDiskPath.Child("iops"), osDisk.DiskSizeGB, "disk size must be positive"))
 	}
+	if spot := platform.SpotVMOptions; spot != nil && spot.MaxPrice != nil {
+		maxPricePath := fldPath.Child("spotVMOptions", "maxPrice")
+		if price, err := strconv.ParseFloat(*spot.MaxPrice, 64); err != nil || (price <= 0 && price != -1) {
+			allErrs = append(allErrs, field.Invalid(maxPricePath, *spot.MaxPrice, "max price must be -1 or a positive decimal number of dollars per hour"))
+		}
+	}
+	allErrs = append(allErrs, validateAzureMachinePoolNetwork(platform, fldPath)...)
+	allErrs = append(allErrs, validateAzureMachinePoolOSImage(platform.OSImage, fldPath.Child("osImage"))...)
+	return allErrs
+}
+
+func validateAzureMachinePoolOSImage(osImage *hivev1azure.OSImage, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if osImage == nil {
+		return allErrs
+	}
+	marketplace := map[string]string{
+		"publisher": osImage.Publisher,
+		"offer":     osImage.Offer,
+		"sku":       osImage.SKU,
+		"version":   osImage.Version,
+	}
+	isMarketplace := false
+	for _, value := range marketplace {
+		if value != "" {
+			isMarketplace = true
+		}
+	}
+	switch {
+	case osImage.ResourceID != "" && isMarketplace:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("resourceID"), osImage.ResourceID,
+			"resource ID cannot be set with a marketplace image"))
+	case osImage.ResourceID == "" && !isMarketplace:
+		allErrs = append(allErrs, field.Required(fldPath, "either resource ID or a marketplace image is required"))
+	case isMarketplace:
+		for _, name := range []string{"publisher", "offer", "sku", "version"} {
+			if marketplace[name] == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child(name), "all of publisher, offer, sku and version are required for a marketplace image"))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateAzureMachinePoolNetwork(platform *hivev1azure.MachinePool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	zones := sets.NewString(platform.Zones...)
+	for zone, subnet := range platform.ZoneSubnets {
+		zonePath := fldPath.Child("zoneSubnets").Key(zone)
+		if len(zones) > 0 && !zones.Has(zone) {
+			allErrs = append(allErrs, field.Invalid(zonePath, zone, "zone is not one of the zones of the machine pool"))
+		}
+		if subnet == "" {
+			allErrs = append(allErrs, field.Invalid(zonePath, subnet, "subnet cannot be an empty string"))
+		}
+	}
+	if platform.VirtualNetwork == "" {
+		if platform.NetworkResourceGroupName != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("networkResourceGroupName"), platform.NetworkResourceGroupName,
+				"network resource group requires a virtual network"))
+		}
+		return allErrs
+	}
+	if platform.NetworkResourceGroupName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("networkResourceGroupName"), "network resource group is required with a virtual network"))
+	}
+	if platform.ComputeSubnet == "" {
+		allZonesHaveSubnets := len(zones) > 0
+		for _, zone := range platform.Zones {
+			if platform.ZoneSubnets[zone] == "" {
+				allZonesHaveSubnets = false
+			}
+		}
+		if !allZonesHaveSubnets {
+			allErrs = append(allErrs, field.Required(fldPath.Child("computeSubnet"),
+				"compute subnet is required with a virtual network, unless every zone has a subnet in zoneSubnets"))
+		}
+	}
 	return allErrs
 }
 
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -11,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -356,6 +358,57 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "AWS network interface options",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.AdditionalSecurityGroupIDs = []string{"sg-1", "sg-2"}
+				pool.Spec.Platform.AWS.AssociatePublicIP = pointer.BoolPtr(true)
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "empty AWS security group ID",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.AdditionalSecurityGroupIDs = []string{""}
+				return pool
+			}(),
+		},
+		{
+			name: "duplicate AWS security group ID",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.AdditionalSecurityGroupIDs = []string{"sg-1", "sg-1"}
+				return pool
+			}(),
+		},
+		{
+			name: "AWS spot instances with max price",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.SpotMarketOptions = &hivev1aws.SpotMarketOptions{MaxPrice: pointer.StringPtr("0.25")}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "invalid AWS spot max price",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.SpotMarketOptions = &hivev1aws.SpotMarketOptions{MaxPrice: pointer.StringPtr("$1")}
+				return pool
+			}(),
+		},
+		{
+			name: "zero AWS spot max price",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Platform.AWS.SpotMarketOptions = &hivev1aws.SpotMarketOptions{MaxPrice: pointer.StringPtr("0")}
+				return pool
+			}(),
+		},
 		{
 			name: "invalid AWS volume IOPS",
 			provision: func() *hivev1.MachinePool {
@@ -414,6 +467,146 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "GCP os image by name",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.OSImage = &hivev1gcp.OSImage{Project: "images-project", Name: "golden"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "GCP os image by family",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.OSImage = &hivev1gcp.OSImage{Family: "golden"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "GCP os image without name or family",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.OSImage = &hivev1gcp.OSImage{Project: "images-project"}
+				return pool
+			}(),
+		},
+		{
+			name: "GCP os image with name and family",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.OSImage = &hivev1gcp.OSImage{Name: "golden", Family: "golden"}
+				return pool
+			}(),
+		},
+		{
+			name: "GCP preemptible VMs",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.Preemptible = true
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "GCP spot VMs",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				spot := hivev1gcp.SpotProvisioningModel
+				pool.Spec.Platform.GCP.ProvisioningModel = &spot
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "GCP preemptible and spot VMs",
+			provision: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.Platform.GCP.Preemptible = true
+				spot := hivev1gcp.SpotProvisioningModel
+				pool.Spec.Platform.GCP.ProvisioningModel = &spot
+				return pool
+			}(),
+		},
+		{
+			name: "Azure spot VMs",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure spot VMs with max price",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{MaxPrice: pointer.StringPtr("0.05")}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure spot VMs with pay-as-you-go max price",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{MaxPrice: pointer.StringPtr("-1")}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure spot VMs with invalid max price",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.SpotVMOptions = &hivev1azure.SpotVMOptions{MaxPrice: pointer.StringPtr("0")}
+				return pool
+			}(),
+		},
+		{
+			name: "Azure gallery image",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.OSImage = &hivev1azure.OSImage{ResourceID: "/subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/galleries/g/images/i/versions/1.0.0"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure marketplace image",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.OSImage = &hivev1azure.OSImage{Publisher: "redhat", Offer: "rh-ocp-worker", SKU: "rh-ocp-worker", Version: "4.8.2021122100"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure incomplete marketplace image",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.OSImage = &hivev1azure.OSImage{Publisher: "redhat", Offer: "rh-ocp-worker"}
+				return pool
+			}(),
+		},
+		{
+			name: "Azure gallery and marketplace image",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.OSImage = &hivev1azure.OSImage{ResourceID: "/subscriptions/s/images/i", Publisher: "redhat", Offer: "rh-ocp-worker", SKU: "rh-ocp-worker", Version: "4.8.2021122100"}
+				return pool
+			}(),
+		},
+		{
+			name: "Azure empty image",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.OSImage = &hivev1azure.OSImage{}
+				return pool
+			}(),
+		},
 		{
 			name: "explicit Azure zones",
 			provision: func() *hivev1.MachinePool {
@@ -447,6 +640,75 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "Azure virtual network",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.NetworkResourceGroupName = "test-network-rg"
+				pool.Spec.Platform.Azure.VirtualNetwork = "test-vnet"
+				pool.Spec.Platform.Azure.ComputeSubnet = "test-subnet"
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure virtual network without network resource group",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.VirtualNetwork = "test-vnet"
+				pool.Spec.Platform.Azure.ComputeSubnet = "test-subnet"
+				return pool
+			}(),
+		},
+		{
+			name: "Azure virtual network without compute subnet",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.NetworkResourceGroupName = "test-network-rg"
+				pool.Spec.Platform.Azure.VirtualNetwork = "test-vnet"
+				return pool
+			}(),
+		},
+		{
+			name: "Azure virtual network with subnet for every zone",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.Zones = []string{"1", "2"}
+				pool.Spec.Platform.Azure.NetworkResourceGroupName = "test-network-rg"
+				pool.Spec.Platform.Azure.VirtualNetwork = "test-vnet"
+				pool.Spec.Platform.Azure.ZoneSubnets = map[string]string{"1": "test-subnet-1", "2": "test-subnet-2"}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "Azure virtual network missing subnet for zone",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.Zones = []string{"1", "2"}
+				pool.Spec.Platform.Azure.NetworkResourceGroupName = "test-network-rg"
+				pool.Spec.Platform.Azure.VirtualNetwork = "test-vnet"
+				pool.Spec.Platform.Azure.ZoneSubnets = map[string]string{"1": "test-subnet-1"}
+				return pool
+			}(),
+		},
+		{
+			name: "Azure zone subnet for unknown zone",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.Zones = []string{"1"}
+				pool.Spec.Platform.Azure.ZoneSubnets = map[string]string{"2": "test-subnet-2"}
+				return pool
+			}(),
+		},
+		{
+			name: "Azure network resource group without virtual network",
+			provision: func() *hivev1.MachinePool {
+				pool := testAzureMachinePool()
+				pool.Spec.Platform.Azure.NetworkResourceGroupName = "test-network-rg"
+				return pool
+			}(),
+		},
 		{
 			name: "valid labels",
 			provision: func() *hivev1.MachinePool {
@@ -489,6 +751,322 @@ func Test_MachinePoolAdmission_Validate_Create(t *testing.T) {
 			}(),
 			expectAllowed: true,
 		},
+		{
+			name: "machine configs",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.KubeletConfig = &runtime.RawExtension{Raw: []byte(`{"maxPods": 500}`)}
+				pool.Spec.MachineConfigs = []hivev1.MachinePoolMachineConfig{
+					{Name: "hugepages", Spec: runtime.RawExtension{Raw: []byte(`{"kernelArguments": ["hugepages=8"]}`)}},
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "duplicate machine config names",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.MachineConfigs = []hivev1.MachinePoolMachineConfig{{Name: "hugepages"}, {Name: "hugepages"}}
+				return pool
+			}(),
+		},
+		{
+			name: "invalid machine config name",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.MachineConfigs = []hivev1.MachinePoolMachineConfig{{Name: "Huge_Pages"}}
+				return pool
+			}(),
+		},
+		{
+			name: "rollout strategy",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxSurge, maxUnavailable := intstr.FromString("25%"), intstr.FromInt(1)
+				pool.Spec.RolloutStrategy = &hivev1.MachinePoolRolloutStrategy{MaxSurge: &maxSurge, MaxUnavailable: &maxUnavailable}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "blue/green rollout strategy with autoscaling",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 6}
+				pool.Spec.RolloutStrategy = &hivev1.MachinePoolRolloutStrategy{Type: hivev1.BlueGreenMachinePoolRolloutStrategyType}
+				return pool
+			}(),
+		},
+		{
+			name: "rolling replacement rollout strategy",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxSurge := intstr.FromString("50%")
+				pool.Spec.RolloutStrategy = &hivev1.MachinePoolRolloutStrategy{
+					Type:     hivev1.RollingReplacementMachinePoolRolloutStrategyType,
+					MaxSurge: &maxSurge,
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "rolling replacement rollout strategy with autoscaling",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 6}
+				pool.Spec.RolloutStrategy = &hivev1.MachinePoolRolloutStrategy{Type: hivev1.RollingReplacementMachinePoolRolloutStrategyType}
+				return pool
+			}(),
+		},
+		{
+			name: "negative max surge",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxSurge := intstr.FromInt(-1)
+				pool.Spec.RolloutStrategy = &hivev1.MachinePoolRolloutStrategy{MaxSurge: &maxSurge}
+				return pool
+			}(),
+		},
+		{
+			name: "max unavailable above 100%",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxUnavailable := intstr.FromString("150%")
+				pool.Spec.RolloutStrategy = &hivev1.MachinePoolRolloutStrategy{MaxUnavailable: &maxUnavailable}
+				return pool
+			}(),
+		},
+		{
+			name: "max unavailable not a percentage",
+			provision: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				maxUnavailable := intstr.FromString("one")
+				pool.Spec.RolloutStrategy = &hivev1.MachinePoolRolloutStrategy{MaxUnavailable: &maxUnavailable}
+				return pool
+			}(),
+		},
+		{
+			name:          "externally managed",
+			provision:     testExternallyManagedMachinePool(),
+			expectAllowed: true,
+		},
+		{
+			name: "externally managed with autoscaling",
+			provision: func() *hivev1.MachinePool {
+				pool := testExternallyManagedMachinePool()
+				pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 1, MaxReplicas: 5}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "externally managed with platform",
+			provision: func() *hivev1.MachinePool {
+				pool := testExternallyManagedMachinePool()
+				pool.Spec.Platform.AWS = validAWSMachinePoolPlatform()
+				return pool
+			}(),
+		},
+		{
+			name: "externally managed with empty selector",
+			provision: func() *hivev1.MachinePool {
+				pool := testExternallyManagedMachinePool()
+				pool.Spec.MachineSetSelector = &metav1.LabelSelector{}
+				return pool
+			}(),
+		},
+		{
+			name: "externally managed with invalid selector",
+			provision: func() *hivev1.MachinePool {
+				pool := testExternallyManagedMachinePool()
+				pool.Spec.MachineSetSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "owner",
+					Operator: "Matches",
+				}}}
+				return pool
+			}(),
+		},
+		{
+			name: "externally managed with replicas",
+			provision: func() *hivev1.MachinePool {
+				pool := testExternallyManagedMachinePool()
+				pool.Spec.Replicas = pointer.Int64Ptr(3)
+				return pool
+			}(),
+		},
+		{
+			name: "externally managed with labels",
+			provision: func() *hivev1.MachinePool {
+				pool := testExternallyManagedMachinePool()
+				pool.Spec.Labels = map[string]string{"foo": "bar"}
+				return pool
+			}(),
+		},
+		{
+			name: "externally managed with rollout strategy",
+			provision: func() *hivev1.MachinePool {
+				pool := testExternallyManagedMachinePool()
+				pool.Spec.RolloutStrategy = &hivev1.MachinePoolRolloutStrategy{}
+				return pool
+			}(),
+		},
+		{
+			name: "externally managed with zone distribution",
+			provision: func() *hivev1.MachinePool {
+				pool := testExternallyManagedMachinePool()
+				pool.Spec.ZoneDistribution = map[string]hivev1.MachinePoolZoneReplicas{"us-east-1a": {Replicas: pointer.Int32Ptr(1)}}
+				return pool
+			}(),
+		},
+		{
+			name: "externally managed with adopt existing",
+			provision: func() *hivev1.MachinePool {
+				pool := testExternallyManagedMachinePool()
+				pool.Spec.AdoptExisting = true
+				return pool
+			}(),
+		},
+		{
+			name: "adopt existing",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.AdoptExisting = true
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "machine health check",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				maxUnhealthy := intstr.FromString("40%")
+				pool.Spec.MachineHealthCheck = &hivev1.MachinePoolMachineHealthCheck{
+					UnhealthyConditions: []hivev1.MachinePoolUnhealthyCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+						{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+					},
+					MaxUnhealthy:       &maxUnhealthy,
+					NodeStartupTimeout: &metav1.Duration{Duration: 20 * time.Minute},
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "machine health check without unhealthy conditions",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.MachineHealthCheck = &hivev1.MachinePoolMachineHealthCheck{}
+				return pool
+			}(),
+		},
+		{
+			name: "machine health check with incomplete unhealthy condition",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.MachineHealthCheck = &hivev1.MachinePoolMachineHealthCheck{
+					UnhealthyConditions: []hivev1.MachinePoolUnhealthyCondition{
+						{Type: corev1.NodeReady, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+					},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "machine health check with invalid max unhealthy",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				maxUnhealthy := intstr.FromString("150%")
+				pool.Spec.MachineHealthCheck = &hivev1.MachinePoolMachineHealthCheck{
+					UnhealthyConditions: []hivev1.MachinePoolUnhealthyCondition{
+						{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+					},
+					MaxUnhealthy: &maxUnhealthy,
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "zone distribution",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Replicas = pointer.Int64Ptr(5)
+				pool.Spec.ZoneDistribution = map[string]hivev1.MachinePoolZoneReplicas{
+					"us-east-1a": {Replicas: pointer.Int32Ptr(3)},
+					"us-east-1b": {Replicas: pointer.Int32Ptr(0)},
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "zone distribution with autoscaling",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 9}
+				pool.Spec.ZoneDistribution = map[string]hivev1.MachinePoolZoneReplicas{
+					"us-east-1a": {MinReplicas: pointer.Int32Ptr(1), MaxReplicas: pointer.Int32Ptr(6)},
+				}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "zone distribution without replicas",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Replicas = pointer.Int64Ptr(5)
+				pool.Spec.ZoneDistribution = map[string]hivev1.MachinePoolZoneReplicas{"us-east-1a": {MinReplicas: pointer.Int32Ptr(1)}}
+				return pool
+			}(),
+		},
+		{
+			name: "zone distribution with autoscaling and replicas",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 9}
+				pool.Spec.ZoneDistribution = map[string]hivev1.MachinePoolZoneReplicas{
+					"us-east-1a": {Replicas: pointer.Int32Ptr(1), MinReplicas: pointer.Int32Ptr(1), MaxReplicas: pointer.Int32Ptr(3)},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "zone distribution with min greater than max",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 9}
+				pool.Spec.ZoneDistribution = map[string]hivev1.MachinePoolZoneReplicas{
+					"us-east-1a": {MinReplicas: pointer.Int32Ptr(3), MaxReplicas: pointer.Int32Ptr(2)},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "zone distribution exceeding replicas",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Replicas = pointer.Int64Ptr(3)
+				pool.Spec.ZoneDistribution = map[string]hivev1.MachinePoolZoneReplicas{
+					"us-east-1a": {Replicas: pointer.Int32Ptr(2)},
+					"us-east-1b": {Replicas: pointer.Int32Ptr(2)},
+				}
+				return pool
+			}(),
+		},
+		{
+			name: "zone distribution exceeding maximum replicas",
+			provision: func() *hivev1.MachinePool {
+				pool := testAWSMachinePool()
+				pool.Spec.Autoscaling = &hivev1.MachinePoolAutoscaling{MinReplicas: 3, MaxReplicas: 6}
+				pool.Spec.ZoneDistribution = map[string]hivev1.MachinePoolZoneReplicas{
+					"us-east-1a": {MinReplicas: pointer.Int32Ptr(1), MaxReplicas: pointer.Int32Ptr(7)},
+				}
+				return pool
+			}(),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -592,6 +1170,46 @@ func Test_MachinePoolAdmission_Validate_Update(t *testing.T) {
 				return pool
 			}(),
 		},
+		{
+			name: "instance type changed with rollout strategy",
+			old:  testMachinePool(),
+			new: func() *hivev1.MachinePool {
+				pool := testMachinePool()
+				pool.Spec.Platform.AWS.InstanceType = "other-instance-type"
+				pool.Spec.RolloutStrategy = &hivev1.MachinePoolRolloutStrategy{}
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "platform changed with rollout strategy",
+			old:  testMachinePool(),
+			new: func() *hivev1.MachinePool {
+				pool := testGCPMachinePool()
+				pool.Spec.RolloutStrategy = &hivev1.MachinePoolRolloutStrategy{}
+				return pool
+			}(),
+		},
+		{
+			name: "externally managed selector changed",
+			old:  testExternallyManagedMachinePool(),
+			new: func() *hivev1.MachinePool {
+				pool := testExternallyManagedMachinePool()
+				pool.Spec.MachineSetSelector.MatchLabels["owner"] = "other-operator"
+				return pool
+			}(),
+			expectAllowed: true,
+		},
+		{
+			name: "managed pool becomes externally managed",
+			old:  testMachinePool(),
+			new:  testExternallyManagedMachinePool(),
+		},
+		{
+			name: "externally managed pool becomes managed",
+			old:  testExternallyManagedMachinePool(),
+			new:  testMachinePool(),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -639,6 +1257,15 @@ func testMachinePool() *hivev1.MachinePool {
 	}
 }
 
+func testExternallyManagedMachinePool() *hivev1.MachinePool {
+	pool := testMachinePool()
+	pool.Spec.Platform = hivev1.MachinePoolPlatform{}
+	pool.Spec.MachineSetSelector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"owner": "external-operator"},
+	}
+	return pool
+}
+
 func testAWSMachinePool() *hivev1.MachinePool {
 	pool := testMachinePool()
 	pool.Spec.Platform = hivev1.MachinePoolPlatform{
@@ -108,6 +108,26 @@ func TestSelectorSyncSetValidate(t *testing.T) {
 			selectorSyncSet: testSecretReferenceSelectorSyncSet(),
 			expectedAllowed: true,
 		},
+		{
+			name:      "Test valid resourcesToDelete create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ResourcesToDelete = []hivev1.SyncObjectReference{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "old"}}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test resourcesToDelete missing kind update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.ResourcesToDelete = []hivev1.SyncObjectReference{{APIVersion: "v1", Namespace: "default", Name: "old"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:      "Test invalid SecretReference no source name create",
 			operation: admissionv1beta1.Create,
@@ -401,7 +421,7 @@ func testPatchSelectorSyncSet(patchType string) *hivev1.SelectorSyncSet {
 		SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
 			Patches: []hivev1.SyncObjectPatch{
 				{
-					Patch:     "blah",
+					Patch:     `[{"op": "test", "path": "/spec/replicas", "value": 2}, {"op": "replace", "path": "/spec/replicas", "value": 3}]`,
 					PatchType: "json",
 				},
 				{
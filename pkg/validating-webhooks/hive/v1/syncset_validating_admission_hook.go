@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
@@ -15,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/yaml"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 )
@@ -39,9 +41,21 @@ var validPatchTypes = map[string]bool{
 	"json":      true,
 	"merge":     true,
 	"strategic": true,
+	"apply":     true,
 }
 
-var validPatchTypeSlice = []string{"json", "merge", "strategic"}
+var validPatchTypeSlice = []string{"json", "merge", "strategic", "apply"}
+
+var validJSONPatchOps = map[string]bool{
+	"add":     true,
+	"remove":  true,
+	"replace": true,
+	"move":    true,
+	"copy":    true,
+	"test":    true,
+}
+
+var validJSONPatchOpSlice = []string{"add", "remove", "replace", "move", "copy", "test"}
 
 var (
 	validResourceApplyModes = map[hivev1.SyncSetResourceApplyMode]bool{
@@ -193,6 +207,7 @@ func (a *SyncSetValidatingAdmissionHook) validateCreate(admissionSpec *admission
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateResources(newObject.Spec.Resources, field.NewPath("spec").Child("resources"))...)
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec").Child("patches"))...)
+	allErrs = append(allErrs, validateResourcesToDelete(newObject.Spec.ResourcesToDelete, newObject.Spec.Resources, field.NewPath("spec", "resourcesToDelete"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec").Child("secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
@@ -241,6 +256,7 @@ func (a *SyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *admission
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validateResources(newObject.Spec.Resources, field.NewPath("spec", "resources"))...)
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec", "patches"))...)
+	allErrs = append(allErrs, validateResourcesToDelete(newObject.Spec.ResourcesToDelete, newObject.Spec.Resources, field.NewPath("spec", "resourcesToDelete"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
@@ -268,6 +284,68 @@ func validatePatches(patches []hivev1.SyncObjectPatch, fldPath *field.Path) fiel
 		if !validPatchTypes[patch.PatchType] {
 			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i).Child("PatchType"), patch.PatchType, validPatchTypeSlice))
 		}
+		switch patch.PatchType {
+		case "json":
+			allErrs = append(allErrs, validateJSONPatch(patch.Patch, fldPath.Index(i).Child("Patch"))...)
+		case "apply":
+			obj := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte(patch.Patch), &obj); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("Patch"), patch.Patch, "apply patch must be a YAML or JSON object: "+err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateJSONPatch ensures a JSON (RFC 6902) patch decodes and only uses supported operations, each with a path.
+// A failing test operation aborts the whole patch, so tests can guard the other operations against unexpected state.
+func validateJSONPatch(patch string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	ops, err := jsonpatch.DecodePatch([]byte(patch))
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, patch, "json patch must be a list of operations: "+err.Error()))
+	}
+	for i, op := range ops {
+		if !validJSONPatchOps[op.Kind()] {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i).Child("op"), op.Kind(), validJSONPatchOpSlice))
+		}
+		if _, err := op.Path(); err != nil {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("path"), "path is required"))
+		}
+	}
+	return allErrs
+}
+
+func validateResourcesToDelete(resourcesToDelete []hivev1.SyncObjectReference, resources []runtime.RawExtension, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	applied := map[hivev1.SyncObjectReference]bool{}
+	for _, resource := range resources {
+		u := &unstructured.Unstructured{}
+		if err := json.Unmarshal(resource.Raw, u); err != nil {
+			// Reported by validateResources
+			continue
+		}
+		applied[hivev1.SyncObjectReference{
+			APIVersion: u.GetAPIVersion(),
+			Kind:       u.GetKind(),
+			Name:       u.GetName(),
+			Namespace:  u.GetNamespace(),
+		}] = true
+	}
+	for i, ref := range resourcesToDelete {
+		path := fldPath.Index(i)
+		if ref.APIVersion == "" {
+			allErrs = append(allErrs, field.Required(path.Child("apiVersion"), "apiVersion is required"))
+		}
+		if ref.Kind == "" {
+			allErrs = append(allErrs, field.Required(path.Child("kind"), "kind is required"))
+		}
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("name"), "name is required"))
+		}
+		if applied[ref] {
+			allErrs = append(allErrs, field.Invalid(path, ref, "resource to delete is also in resources"))
+		}
 	}
 	return allErrs
 }
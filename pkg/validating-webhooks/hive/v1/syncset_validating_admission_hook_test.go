@@ -78,6 +78,56 @@ func TestSyncSetValidate(t *testing.T) {
 			syncSet:         testInvalidPatchSyncSet(),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid apply patch create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.Patches = []hivev1.SyncObjectPatch{{Patch: `{"spec": {"replicas": 3}}`, PatchType: "apply"}}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid apply patch create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.Patches = []hivev1.SyncObjectPatch{{Patch: `[not an object`, PatchType: "apply"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test unsupported json patch op create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.Patches = []hivev1.SyncObjectPatch{{Patch: `[{"op": "frobnicate", "path": "/spec"}]`, PatchType: "json"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test json patch op missing path create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.Patches = []hivev1.SyncObjectPatch{{Patch: `[{"op": "remove"}]`, PatchType: "json"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid json patch create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.Patches = []hivev1.SyncObjectPatch{{Patch: `{"spec": {"replicas": 3}}`, PatchType: "json"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test create with no patches",
 			operation:       admissionv1beta1.Create,
@@ -242,6 +292,36 @@ func TestSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid resourcesToDelete create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ResourcesToDelete = []hivev1.SyncObjectReference{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "old"}}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test resourcesToDelete missing name update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ResourcesToDelete = []hivev1.SyncObjectReference{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test resourcesToDelete also in resources create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSetWithResources(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"namespace": "default", "name": "old"}}`)
+				ss.Spec.ResourcesToDelete = []hivev1.SyncObjectReference{{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "old"}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test invalid unmarshalable Resource create",
 			operation:       admissionv1beta1.Create,
@@ -421,7 +501,7 @@ func testPatchSyncSet(patchType string) *hivev1.SyncSet {
 		SyncSetCommonSpec: hivev1.SyncSetCommonSpec{
 			Patches: []hivev1.SyncObjectPatch{
 				{
-					Patch:     "blah",
+					Patch:     `[{"op": "test", "path": "/spec/replicas", "value": 2}, {"op": "replace", "path": "/spec/replicas", "value": 3}]`,
 					PatchType: "json",
 				},
 				{
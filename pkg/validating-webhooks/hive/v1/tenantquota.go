@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// tenantQuotaChecker validates the creation of ClusterDeployments and MachinePools against the TenantQuotas in
+// their namespace.
+type tenantQuotaChecker struct {
+	client client.Reader
+}
+
+var (
+	sharedTenantQuotaCheckerOnce sync.Once
+	sharedTenantQuotaChecker     *tenantQuotaChecker
+	sharedTenantQuotaCheckerErr  error
+)
+
+// newTenantQuotaChecker returns a checker reading from an informer cache of TenantQuotas, ClusterDeployments and
+// MachinePools, so that admission does not list them from the API server on every request. The cache is shared by
+// the admission hooks and stopped with stopCh. Quotas are not enforced when no config is provided, in which case a
+// nil checker is returned.
+func newTenantQuotaChecker(kubeClientConfig *rest.Config, stopCh <-chan struct{}) (*tenantQuotaChecker, error) {
+	if kubeClientConfig == nil {
+		return nil, nil
+	}
+	sharedTenantQuotaCheckerOnce.Do(func() {
+		sharedTenantQuotaChecker, sharedTenantQuotaCheckerErr = startTenantQuotaChecker(kubeClientConfig, stopCh)
+	})
+	return sharedTenantQuotaChecker, sharedTenantQuotaCheckerErr
+}
+
+func startTenantQuotaChecker(kubeClientConfig *rest.Config, stopCh <-chan struct{}) (*tenantQuotaChecker, error) {
+	scheme := runtime.NewScheme()
+	if err := hivev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	informerCache, err := cache.New(kubeClientConfig, cache.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+	for _, obj := range []client.Object{&hivev1.TenantQuota{}, &hivev1.ClusterDeployment{}, &hivev1.MachinePool{}} {
+		if _, err := informerCache.GetInformer(ctx, obj); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	go func() {
+		if err := informerCache.Start(ctx); err != nil {
+			log.WithError(err).Error("tenant quota cache stopped")
+		}
+	}()
+	if !informerCache.WaitForCacheSync(ctx) {
+		cancel()
+		return nil, errors.New("tenant quota cache did not sync")
+	}
+	return &tenantQuotaChecker{client: informerCache}, nil
+}
+
+// validateClusterDeploymentCreate checks that creating the ClusterDeployment does not exceed any quota in its namespace.
+func (c *tenantQuotaChecker) validateClusterDeploymentCreate(cd *hivev1.ClusterDeployment, logger log.FieldLogger) field.ErrorList {
+	if c == nil {
+		return nil
+	}
+	path := field.NewPath("metadata", "namespace")
+	quotas := &hivev1.TenantQuotaList{}
+	if err := c.client.List(context.TODO(), quotas, client.InNamespace(cd.Namespace)); err != nil {
+		logger.WithError(err).Error("could not list TenantQuotas")
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	if len(quotas.Items) == 0 {
+		return nil
+	}
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := c.client.List(context.TODO(), cds, client.InNamespace(cd.Namespace)); err != nil {
+		logger.WithError(err).Error("could not list ClusterDeployments")
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	pools := &hivev1.MachinePoolList{}
+	if err := c.client.List(context.TODO(), pools, client.InNamespace(cd.Namespace)); err != nil {
+		logger.WithError(err).Error("could not list MachinePools")
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	newCDs := append(cds.Items[:len(cds.Items):len(cds.Items)], *cd)
+	return checkTenantQuotas(path, quotas.Items, cds.Items, newCDs, pools.Items, pools.Items)
+}
+
+// validateMachinePool checks that creating or updating the MachinePool does not exceed any quota in its namespace.
+// The oldPool is nil when the MachinePool is being created.
+func (c *tenantQuotaChecker) validateMachinePool(oldPool, newPool *hivev1.MachinePool, logger log.FieldLogger) field.ErrorList {
+	if c == nil {
+		return nil
+	}
+	path := field.NewPath("spec")
+	quotas := &hivev1.TenantQuotaList{}
+	if err := c.client.List(context.TODO(), quotas, client.InNamespace(newPool.Namespace)); err != nil {
+		logger.WithError(err).Error("could not list TenantQuotas")
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	if len(quotas.Items) == 0 {
+		return nil
+	}
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := c.client.List(context.TODO(), cds, client.InNamespace(newPool.Namespace)); err != nil {
+		logger.WithError(err).Error("could not list ClusterDeployments")
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	pools := &hivev1.MachinePoolList{}
+	if err := c.client.List(context.TODO(), pools, client.InNamespace(newPool.Namespace)); err != nil {
+		logger.WithError(err).Error("could not list MachinePools")
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+	oldPools := make([]hivev1.MachinePool, 0, len(pools.Items))
+	newPools := make([]hivev1.MachinePool, 0, len(pools.Items)+1)
+	for _, pool := range pools.Items {
+		if pool.Name == newPool.Name {
+			continue
+		}
+		oldPools = append(oldPools, pool)
+		newPools = append(newPools, pool)
+	}
+	if oldPool != nil {
+		oldPools = append(oldPools, *oldPool)
+	}
+	newPools = append(newPools, *newPool)
+	return checkTenantQuotas(path, quotas.Items, cds.Items, cds.Items, oldPools, newPools)
+}
+
+func checkTenantQuotas(path *field.Path, quotas []hivev1.TenantQuota, oldCDs, newCDs []hivev1.ClusterDeployment, oldPools, newPools []hivev1.MachinePool) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i := range quotas {
+		quota := &quotas[i]
+		oldUsage := controllerutils.ComputeTenantQuotaUsage(quota, oldCDs, oldPools)
+		newUsage := controllerutils.ComputeTenantQuotaUsage(quota, newCDs, newPools)
+		for _, exceeded := range controllerutils.TenantQuotaExceeded(quota, oldUsage, newUsage) {
+			allErrs = append(allErrs, field.Forbidden(path, exceeded))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+)
+
+const testQuotaNamespace = "quota-namespace"
+
+func testTenantQuota(spec hivev1.TenantQuotaSpec) *hivev1.TenantQuota {
+	return &hivev1.TenantQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testQuotaNamespace, Name: "quota"},
+		Spec:       spec,
+	}
+}
+
+func testQuotaClusterDeployment(name string) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testQuotaNamespace, Name: name},
+	}
+}
+
+func testQuotaMachinePool(name, instanceType string, replicas int64) *hivev1.MachinePool {
+	return &hivev1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: testQuotaNamespace, Name: name},
+		Spec: hivev1.MachinePoolSpec{
+			Name:     name,
+			Replicas: pointer.Int64Ptr(replicas),
+			Platform: hivev1.MachinePoolPlatform{
+				AWS: &hivev1aws.MachinePoolPlatform{InstanceType: instanceType},
+			},
+		},
+	}
+}
+
+func newTestTenantQuotaChecker(existing ...client.Object) *tenantQuotaChecker {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	return &tenantQuotaChecker{client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing...).Build()}
+}
+
+func TestTenantQuotaChecker_ClusterDeploymentCreate(t *testing.T) {
+	cases := []struct {
+		name        string
+		existing    []client.Object
+		expectError bool
+	}{
+		{
+			name:     "no quota",
+			existing: []client.Object{testQuotaClusterDeployment("cd1")},
+		},
+		{
+			name: "within quota",
+			existing: []client.Object{
+				testTenantQuota(hivev1.TenantQuotaSpec{MaxClusterDeployments: pointer.Int32Ptr(2)}),
+				testQuotaClusterDeployment("cd1"),
+			},
+		},
+		{
+			name: "quota exceeded",
+			existing: []client.Object{
+				testTenantQuota(hivev1.TenantQuotaSpec{MaxClusterDeployments: pointer.Int32Ptr(1)}),
+				testQuotaClusterDeployment("cd1"),
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := newTestTenantQuotaChecker(tc.existing...)
+			errs := checker.validateClusterDeploymentCreate(testQuotaClusterDeployment("new-cd"), log.StandardLogger())
+			if tc.expectError {
+				assert.NotEmpty(t, errs, "expected quota to be exceeded")
+			} else {
+				assert.Empty(t, errs, "unexpected quota errors")
+			}
+		})
+	}
+}
+
+func TestTenantQuotaChecker_MachinePool(t *testing.T) {
+	cases := []struct {
+		name        string
+		existing    []client.Object
+		oldPool     *hivev1.MachinePool
+		newPool     *hivev1.MachinePool
+		expectError bool
+	}{
+		{
+			name: "create within quota",
+			existing: []client.Object{
+				testTenantQuota(hivev1.TenantQuotaSpec{MaxMachineReplicas: pointer.Int64Ptr(6)}),
+				testQuotaMachinePool("worker", "m5.large", 3),
+			},
+			newPool: testQuotaMachinePool("infra", "m5.large", 3),
+		},
+		{
+			name: "create exceeds machine replicas",
+			existing: []client.Object{
+				testTenantQuota(hivev1.TenantQuotaSpec{MaxMachineReplicas: pointer.Int64Ptr(5)}),
+				testQuotaMachinePool("worker", "m5.large", 3),
+			},
+			newPool:     testQuotaMachinePool("infra", "m5.large", 3),
+			expectError: true,
+		},
+		{
+			name: "create uses restricted instance family",
+			existing: []client.Object{
+				testTenantQuota(hivev1.TenantQuotaSpec{
+					InstanceFamilies: []hivev1.InstanceFamilyQuota{{Family: "p3", MaxReplicas: 0}},
+				}),
+			},
+			newPool:     testQuotaMachinePool("gpu", "p3.2xlarge", 1),
+			expectError: true,
+		},
+		{
+			name: "update scales up beyond quota",
+			existing: []client.Object{
+				testTenantQuota(hivev1.TenantQuotaSpec{MaxMachineReplicas: pointer.Int64Ptr(5)}),
+				testQuotaMachinePool("worker", "m5.large", 3),
+			},
+			oldPool:     testQuotaMachinePool("worker", "m5.large", 3),
+			newPool:     testQuotaMachinePool("worker", "m5.large", 6),
+			expectError: true,
+		},
+		{
+			name: "update scales down while over quota",
+			existing: []client.Object{
+				testTenantQuota(hivev1.TenantQuotaSpec{MaxMachineReplicas: pointer.Int64Ptr(5)}),
+				testQuotaMachinePool("worker", "m5.large", 10),
+			},
+			oldPool: testQuotaMachinePool("worker", "m5.large", 10),
+			newPool: testQuotaMachinePool("worker", "m5.large", 8),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := newTestTenantQuotaChecker(tc.existing...)
+			errs := checker.validateMachinePool(tc.oldPool, tc.newPool, log.StandardLogger())
+			if tc.expectError {
+				assert.NotEmpty(t, errs, "expected quota to be exceeded")
+			} else {
+				assert.Empty(t, errs, "unexpected quota errors")
+			}
+		})
+	}
+}